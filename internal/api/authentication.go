@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
@@ -26,7 +27,11 @@ const (
 
 func allowUnauthenticated(r *http.Request) bool {
 	// #2715 - allow access to UI files
-	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets")
+	// share links are authenticated by their token, and signed urls by their
+	// signature
+	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets") ||
+		strings.HasPrefix(r.URL.Path, shareEndpoint+"/") ||
+		validSignedURL(config.GetInstance().GetJWTSignKey(), r.URL, time.Now())
 }
 
 func authenticateHandler() func(http.Handler) http.Handler {
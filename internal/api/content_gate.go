@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// ContentGateTagFinder finds the descendants of the gated tags.
+type ContentGateTagFinder interface {
+	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*models.TagPath, error)
+}
+
+// contentGate hides objects carrying the gated tags, or their descendants,
+// while the content gate is locked.
+type contentGate struct {
+	tagFinder ContentGateTagFinder
+}
+
+// gatedTagIDs returns the ids of the gated tags and their descendants.
+// Returns nil if the content gate is disabled or unlocked.
+func (g contentGate) gatedTagIDs(ctx context.Context) ([]int, error) {
+	tags, err := stringslice.StringSliceToIntSlice(manager.GetInstance().GatedTags())
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var ret []int
+	for _, id := range tags {
+		descendants, err := g.tagFinder.FindAllDescendants(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// descendants includes the tag itself
+		for _, t := range descendants {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				ret = append(ret, t.ID)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// isGated returns true if the object with the id carries a gated tag.
+func (g contentGate) isGated(ctx context.Context, l models.TagIDLoader, id int) (bool, error) {
+	gated, err := g.gatedTagIDs(ctx)
+	if err != nil || len(gated) == 0 {
+		return false, err
+	}
+
+	return carriesGatedTag(ctx, l, id, gated)
+}
+
+func carriesGatedTag(ctx context.Context, l models.TagIDLoader, id int, gated []int) (bool, error) {
+	tagIDs, err := l.GetTagIDs(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tagID := range tagIDs {
+		for _, gatedID := range gated {
+			if tagID == gatedID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// filterGated returns the objects that do not carry a gated tag.
+func filterGated[T any](ctx context.Context, g contentGate, l models.TagIDLoader, objs []T, getID func(T) int) ([]T, error) {
+	gated, err := g.gatedTagIDs(ctx)
+	if err != nil || len(gated) == 0 {
+		return objs, err
+	}
+
+	ret := make([]T, 0, len(objs))
+	for _, o := range objs {
+		isGated, err := carriesGatedTag(ctx, l, getID(o), gated)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isGated {
+			ret = append(ret, o)
+		}
+	}
+
+	return ret, nil
+}
+
+// gatedTagsCriterion returns a criterion excluding objects with the gated
+// tags or their descendants. Returns nil if content is not gated.
+func gatedTagsCriterion() *models.HierarchicalMultiCriterionInput {
+	return manager.GetInstance().GatedTagsCriterion()
+}
+
+func gateSceneFilter(f *models.SceneFilterType) *models.SceneFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.SceneFilterType{
+		OperatorFilter: models.OperatorFilter[models.SceneFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+func gateImageFilter(f *models.ImageFilterType) *models.ImageFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.ImageFilterType{
+		OperatorFilter: models.OperatorFilter[models.ImageFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+func gateGalleryFilter(f *models.GalleryFilterType) *models.GalleryFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.GalleryFilterType{
+		OperatorFilter: models.OperatorFilter[models.GalleryFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+func gatePerformerFilter(f *models.PerformerFilterType) *models.PerformerFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.PerformerFilterType{
+		OperatorFilter: models.OperatorFilter[models.PerformerFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+func gateStudioFilter(f *models.StudioFilterType) *models.StudioFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.StudioFilterType{
+		OperatorFilter: models.OperatorFilter[models.StudioFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+func gateGroupFilter(f *models.GroupFilterType) *models.GroupFilterType {
+	c := gatedTagsCriterion()
+	if c == nil {
+		return f
+	}
+
+	return &models.GroupFilterType{
+		OperatorFilter: models.OperatorFilter[models.GroupFilterType]{And: f},
+		Tags:           c,
+	}
+}
+
+// gateSceneMarkerFilter excludes markers with gated tags, and markers of
+// scenes with gated tags. Scene marker filters have no AND operator, so the
+// gated tags are added to the excluded tags of the filter's own criteria.
+func (g contentGate) gateSceneMarkerFilter(ctx context.Context, f *models.SceneMarkerFilterType) (*models.SceneMarkerFilterType, error) {
+	gated, err := g.gatedTagIDs(ctx)
+	if err != nil || len(gated) == 0 {
+		return f, err
+	}
+
+	gatedStrs := intslice.IntSliceToStringSlice(gated)
+	exclude := func(c *models.HierarchicalMultiCriterionInput) *models.HierarchicalMultiCriterionInput {
+		if c == nil {
+			return &models.HierarchicalMultiCriterionInput{
+				Value:    gatedStrs,
+				Modifier: models.CriterionModifierExcludes,
+			}
+		}
+
+		ret := *c
+		ret.Excludes = append(append([]string{}, c.Excludes...), gatedStrs...)
+		return &ret
+	}
+
+	var ret models.SceneMarkerFilterType
+	if f != nil {
+		ret = *f
+	}
+	ret.Tags = exclude(ret.Tags)
+	ret.SceneTags = exclude(ret.SceneTags)
+
+	return &ret, nil
+}
+
+// backgroundRequestHeader marks requests that the UI makes without user
+// input, such as polling and refetching after events.
+const backgroundRequestHeader = "X-Stash-Background"
+
+// isUserRequest returns true if the request counts as user activity.
+// Websocket connections are not counted, so that open subscriptions do not
+// keep the gate unlocked, and neither are background requests.
+func isUserRequest(r *http.Request) bool {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+
+	return r.Header.Get(backgroundRequestHeader) == ""
+}
+
+// touchContentGateHandler records user requests as activity, delaying the
+// automatic lock of the content gate.
+func touchContentGateHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isUserRequest(r) {
+			manager.GetInstance().TouchContentGate()
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -16,4 +16,5 @@ const (
 	imageKey
 	pluginKey
 	profileImageKey
+	shareLinkKey
 )
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
+)
+
+var errDeleteDryRun = errors.New("delete dry run")
+
+// deleteConfirmationError is returned when deleting files requires
+// confirmation. The token and a summary of the files to be deleted are
+// included in the error extensions.
+type deleteConfirmationError struct {
+	*file.DeleteConfirmation
+}
+
+func (e *deleteConfirmationError) Error() string {
+	return fmt.Sprintf("deleting %d files (%d bytes) requires confirmation", e.FileCount, e.TotalSize)
+}
+
+func (e *deleteConfirmationError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":               "DELETE_CONFIRMATION_REQUIRED",
+		"confirmation_token": e.Token,
+		"file_count":         e.FileCount,
+		"total_size":         e.TotalSize,
+		"expires_at":         e.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// withDeleteConfirmation runs fn in a transaction, requiring confirmation if
+// library files are deleted and delete confirmation is enabled.
+//
+// If token is nil, fn is run with the deleter in dry run mode, the
+// transaction is rolled back, and a deleteConfirmationError is returned for
+// the files that would have been deleted. Otherwise the deletion is only
+// committed if the token was issued for the same files.
+func (r *mutationResolver) withDeleteConfirmation(ctx context.Context, fileDeleter *file.Deleter, deleteFile bool, token *string, fn func(ctx context.Context) error) error {
+	mgr := manager.GetInstance()
+	if !deleteFile || !mgr.Config.IsDeleteConfirmation() {
+		return r.withTxn(ctx, fn)
+	}
+
+	if token != nil {
+		return r.withTxn(ctx, func(ctx context.Context) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+
+			paths, _ := fileDeleter.Destroyed()
+			return mgr.DeleteConfirmer.Confirm(*token, paths)
+		})
+	}
+
+	fileDeleter.DryRun = true
+	err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		return errDeleteDryRun
+	})
+	fileDeleter.DryRun = false
+
+	if !errors.Is(err, errDeleteDryRun) {
+		return err
+	}
+
+	paths, size := fileDeleter.Destroyed()
+	// clear the dry run state
+	fileDeleter.Rollback()
+
+	// nothing to confirm if no library files are deleted
+	if len(paths) == 0 {
+		return r.withTxn(ctx, fn)
+	}
+
+	dc, err := mgr.DeleteConfirmer.Issue(paths, size)
+	if err != nil {
+		return fmt.Errorf("issuing delete confirmation: %w", err)
+	}
+
+	return &deleteConfirmationError{dc}
+}
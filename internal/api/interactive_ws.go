@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/buttplug"
+	"github.com/stashapp/stash/pkg/interactive"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// interactiveEndpoint is the websocket channel for interactive playback.
+// It is separate from graphql subscriptions, so that heartbeats are handled
+// as soon as they are received.
+const interactiveEndpoint = "/interactive/ws"
+
+// interactiveReadTimeout is how long a connection is kept open without
+// receiving a message. Clients send heartbeats far more often.
+const interactiveReadTimeout = 30 * time.Second
+
+var interactiveUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// interactiveSession is the state of a connection to the interactive
+// channel. Messages are handled in the order they are received, by a single
+// goroutine.
+type interactiveSession struct {
+	conn    *websocket.Conn
+	latency interactive.LatencyEstimator
+	sync    interactive.PlaybackSync
+
+	// scene is the last scene played, which is reused while the client
+	// plays the same scene
+	scene *models.Scene
+}
+
+func handleInteractiveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := interactiveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already written the error response
+		logger.Debugf("[interactive] upgrading connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	s := &interactiveSession{
+		conn: conn,
+	}
+	s.run(r.Context())
+}
+
+func (s *interactiveSession) run(ctx context.Context) {
+	// stop the devices if the client goes away while playing
+	defer func() {
+		if s.sync.Stop() {
+			if err := manager.GetInstance().ButtplugStop(context.Background()); err != nil && !errors.Is(err, buttplug.ErrNotConnected) {
+				logger.Warnf("[interactive] stopping devices: %v", err)
+			}
+		}
+	}()
+
+	for {
+		if err := s.conn.SetReadDeadline(time.Now().Add(interactiveReadTimeout)); err != nil {
+			return
+		}
+
+		var msg interactive.Message
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			// the message has been read, so the connection is still usable
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				s.sendError(fmt.Errorf("invalid message: %w", err))
+				continue
+			}
+
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Debugf("[interactive] closing connection: %v", err)
+			}
+			return
+		}
+
+		if err := s.handle(ctx, msg); err != nil {
+			s.sendError(err)
+		}
+	}
+}
+
+func (s *interactiveSession) handle(ctx context.Context, msg interactive.Message) error {
+	received := time.Now()
+
+	switch msg.Type {
+	case interactive.MessageTypePing:
+		return s.send(interactive.Message{
+			Type:       interactive.MessageTypePong,
+			ID:         msg.ID,
+			ClientTime: msg.ClientTime,
+			ServerTime: received.UnixMilli(),
+		})
+	case interactive.MessageTypeHeartbeat:
+		return s.heartbeat(ctx, msg, received)
+	}
+
+	return fmt.Errorf("unsupported message type %q", msg.Type)
+}
+
+// heartbeat starts, resyncs or stops the devices to follow the playback
+// position of the client.
+func (s *interactiveSession) heartbeat(ctx context.Context, msg interactive.Message, received time.Time) error {
+	if msg.RTT > 0 {
+		s.latency.Add(time.Duration(msg.RTT * float64(time.Millisecond)))
+	}
+
+	// the position has moved on while the heartbeat was in transit
+	position := time.Duration(msg.Position * float64(time.Second))
+	if msg.Playing {
+		position += s.latency.OneWay()
+	}
+
+	action, drift := s.sync.Update(msg.SceneID, msg.Playing, position, received)
+
+	mgr := manager.GetInstance()
+	switch action {
+	case interactive.SyncNone:
+		return nil
+	case interactive.SyncStop:
+		if err := mgr.ButtplugStop(ctx); err != nil {
+			return err
+		}
+	case interactive.SyncPlay:
+		scene, err := s.findScene(ctx, msg.SceneID)
+		if err != nil {
+			return err
+		}
+
+		if err := mgr.ButtplugPlay(ctx, scene, position.Seconds()); err != nil {
+			return err
+		}
+	}
+
+	return s.send(interactive.Message{
+		Type:       interactive.MessageTypeStatus,
+		ServerTime: received.UnixMilli(),
+		SceneID:    msg.SceneID,
+		Position:   position.Seconds(),
+		Playing:    s.sync.Playing(),
+		Latency:    float64(s.latency.OneWay()) / float64(time.Millisecond),
+		Drift:      float64(drift) / float64(time.Millisecond),
+	})
+}
+
+func (s *interactiveSession) findScene(ctx context.Context, sceneID string) (*models.Scene, error) {
+	id, err := strconv.Atoi(sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	if s.scene != nil && s.scene.ID == id {
+		return s.scene, nil
+	}
+
+	r := manager.GetInstance().Repository
+	var ret *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.Scene.Find(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("scene with id %d not found", id)
+	}
+
+	s.scene = ret
+	return ret, nil
+}
+
+func (s *interactiveSession) send(msg interactive.Message) error {
+	if err := s.conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("sending %s: %w", msg.Type, err)
+	}
+	return nil
+}
+
+func (s *interactiveSession) sendError(err error) {
+	if err := s.conn.WriteJSON(interactive.Message{
+		Type:  interactive.MessageTypeError,
+		Error: err.Error(),
+	}); err != nil {
+		logger.Debugf("[interactive] sending error: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// withOrderingProfile returns the find filter with the configured default
+// ordering of the entity type applied.
+func withOrderingProfile(mode models.FilterMode, filter *models.FindFilterType) *models.FindFilterType {
+	p := config.GetInstance().GetOrderingProfile(mode)
+	if p == nil {
+		return filter
+	}
+
+	return p.Apply(filter)
+}
+
+// validateOrderingProfile runs a query of the entity type with the ordering
+// of the profile, so that a profile with a sort that is not supported by the
+// entity type is rejected, rather than causing every query to fail.
+func (r *mutationResolver) validateOrderingProfile(ctx context.Context, p models.OrderingProfile) error {
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("ordering profile for %s: %w", p.Mode, err)
+	}
+
+	perPage := 0
+	filter := p.Apply(&models.FindFilterType{PerPage: &perPage})
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		switch p.Mode {
+		case models.FilterModeScenes:
+			_, err = r.repository.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{FindFilter: filter},
+			})
+		case models.FilterModeImages:
+			_, err = r.repository.Image.Query(ctx, models.ImageQueryOptions{
+				QueryOptions: models.QueryOptions{FindFilter: filter},
+			})
+		case models.FilterModePerformers:
+			_, _, err = r.repository.Performer.Query(ctx, nil, filter)
+		case models.FilterModeStudios:
+			_, _, err = r.repository.Studio.Query(ctx, nil, filter)
+		case models.FilterModeGalleries:
+			_, _, err = r.repository.Gallery.Query(ctx, nil, filter)
+		case models.FilterModeTags:
+			_, _, err = r.repository.Tag.Query(ctx, nil, filter)
+		case models.FilterModeGroups, models.FilterModeMovies:
+			_, _, err = r.repository.Group.Query(ctx, nil, filter)
+		case models.FilterModeGames, models.FilterModeSceneMarkers:
+			if len(p.ThenBy) > 0 {
+				return fmt.Errorf("then by sorts are not supported for %s", p.Mode)
+			}
+
+			if p.Mode == models.FilterModeGames {
+				_, _, err = r.repository.Game.Query(ctx, nil, filter)
+			} else {
+				_, _, err = r.repository.SceneMarker.Query(ctx, nil, filter)
+			}
+		}
+		return err
+	}); err != nil {
+		return fmt.Errorf("ordering profile for %s: %w", p.Mode, err)
+	}
+
+	return nil
+}
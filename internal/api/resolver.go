@@ -46,6 +46,10 @@ func (r *Resolver) scraperCache() *scraper.Cache {
 	return manager.GetInstance().ScraperCache
 }
 
+func (r *Resolver) contentGate() contentGate {
+	return contentGate{tagFinder: r.repository.Tag}
+}
+
 func (r *Resolver) Gallery() GalleryResolver {
 	return &galleryResolver{r}
 }
@@ -126,6 +130,54 @@ func (r *Resolver) SceneUpdateInput() SceneUpdateInputResolver {
 func (r *Resolver) SceneCreateInput() SceneCreateInputResolver {
 	return &sceneCreateInputResolver{r}
 }
+func (r *Resolver) SceneParserBatch() SceneParserBatchResolver {
+	return &sceneParserBatchResolver{r}
+}
+func (r *Resolver) SceneParserChange() SceneParserChangeResolver {
+	return &sceneParserChangeResolver{r}
+}
+func (r *Resolver) Note() NoteResolver {
+	return &noteResolver{r}
+}
+func (r *Resolver) PerformerConflict() PerformerConflictResolver {
+	return &performerConflictResolver{r}
+}
+func (r *Resolver) ShareLink() ShareLinkResolver {
+	return &shareLinkResolver{r}
+}
+func (r *Resolver) SmartPlaylist() SmartPlaylistResolver {
+	return &smartPlaylistResolver{r}
+}
+func (r *Resolver) RetentionReportItem() RetentionReportItemResolver {
+	return &retentionReportItemResolver{r}
+}
+func (r *Resolver) GroupProposalPart() GroupProposalPartResolver {
+	return &groupProposalPartResolver{r}
+}
+func (r *Resolver) StudioProposal() StudioProposalResolver {
+	return &studioProposalResolver{r}
+}
+func (r *Resolver) SceneDateProposal() SceneDateProposalResolver {
+	return &sceneDateProposalResolver{r}
+}
+func (r *Resolver) TempFile() TempFileResolver {
+	return &tempFileResolver{r}
+}
+func (r *Resolver) PerformerStats() PerformerStatsResolver {
+	return &performerStatsResolver{r}
+}
+func (r *Resolver) SyncPlaySession() SyncPlaySessionResolver {
+	return &syncPlaySessionResolver{r}
+}
+func (r *Resolver) ReencodeEstimate() ReencodeEstimateResolver {
+	return &reencodeEstimateResolver{r}
+}
+func (r *Resolver) ReencodePlan() ReencodePlanResolver {
+	return &reencodePlanResolver{r}
+}
+func (r *Resolver) FindScenesResultType() FindScenesResultTypeResolver {
+	return &findScenesResultTypeResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -154,6 +206,22 @@ type sceneUpdateInputResolver struct{ *Resolver }
 type sceneCreateInputResolver struct{ *Resolver }
 type folderResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
+type sceneParserBatchResolver struct{ *Resolver }
+type sceneParserChangeResolver struct{ *Resolver }
+type noteResolver struct{ *Resolver }
+type performerConflictResolver struct{ *Resolver }
+type shareLinkResolver struct{ *Resolver }
+type smartPlaylistResolver struct{ *Resolver }
+type retentionReportItemResolver struct{ *Resolver }
+type groupProposalPartResolver struct{ *Resolver }
+type studioProposalResolver struct{ *Resolver }
+type sceneDateProposalResolver struct{ *Resolver }
+type tempFileResolver struct{ *Resolver }
+type performerStatsResolver struct{ *Resolver }
+type syncPlaySessionResolver struct{ *Resolver }
+type reencodeEstimateResolver struct{ *Resolver }
+type reencodePlanResolver struct{ *Resolver }
+type findScenesResultTypeResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
 
@@ -161,6 +229,13 @@ func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) err
 	return r.repository.WithTxn(ctx, fn)
 }
 
+// withRetryTxn is withTxn, retrying if the database is locked. fn may be
+// called more than once, so it must reset any state it sets outside of the
+// transaction.
+func (r *Resolver) withRetryTxn(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.repository.WithRetryTxn(ctx, fn)
+}
+
 func (r *Resolver) withReadTxn(ctx context.Context, fn func(ctx context.Context) error) error {
 	return r.repository.WithReadTxn(ctx, fn)
 }
@@ -198,137 +273,30 @@ func (r *queryResolver) MarkerStrings(ctx context.Context, q *string, sort *stri
 }
 
 func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
-	var ret StatsResultType
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		repo := r.repository
-		sceneQB := repo.Scene
-		imageQB := repo.Image
-		galleryQB := repo.Gallery
-		studioQB := repo.Studio
-		performerQB := repo.Performer
-		movieQB := repo.Group
-		tagQB := repo.Tag
-
-		// embrace the error
-
-		scenesCount, err := sceneQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		scenesSize, err := sceneQB.Size(ctx)
-		if err != nil {
-			return err
-		}
-
-		scenesDuration, err := sceneQB.Duration(ctx)
-		if err != nil {
-			return err
-		}
-
-		imageCount, err := imageQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		imageSize, err := imageQB.Size(ctx)
-		if err != nil {
-			return err
-		}
-
-		galleryCount, err := galleryQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		performersCount, err := performerQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		studiosCount, err := studioQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		groupsCount, err := movieQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		tagsCount, err := tagQB.Count(ctx)
-		if err != nil {
-			return err
-		}
-
-		scenesTotalOCount, err := sceneQB.GetAllOCount(ctx)
-		if err != nil {
-			return err
-		}
-		imagesTotalOCount, err := imageQB.OCount(ctx)
-		if err != nil {
-			return err
-		}
-		galleriesTotalOCount, err := galleryQB.OCount(ctx)
-		if err != nil {
-			return err
-		}
-		totalOCount := scenesTotalOCount + imagesTotalOCount + galleriesTotalOCount
-
-		scenesTotalOMGCount, err := sceneQB.GetAllOMGCount(ctx)
-		if err != nil {
-			return err
-		}
-		imagesTotalOMGCount, err := imageQB.GetAllOMGCount(ctx)
-		if err != nil {
-			return err
-		}
-		galleriesTotalOMGCount, err := galleryQB.GetAllOMGCount(ctx)
-		if err != nil {
-			return err
-		}
-		totalOMGCount := scenesTotalOMGCount + imagesTotalOMGCount + galleriesTotalOMGCount
-
-		totalPlayDuration, err := sceneQB.PlayDuration(ctx)
-		if err != nil {
-			return err
-		}
-
-		totalPlayCount, err := sceneQB.CountAllViews(ctx)
-		if err != nil {
-			return err
-		}
-
-		uniqueScenePlayCount, err := sceneQB.CountUniqueViews(ctx)
-		if err != nil {
-			return err
-		}
-
-		ret = StatsResultType{
-			SceneCount:        scenesCount,
-			ScenesSize:        scenesSize,
-			ScenesDuration:    scenesDuration,
-			ImageCount:        imageCount,
-			ImagesSize:        imageSize,
-			GalleryCount:      galleryCount,
-			PerformerCount:    performersCount,
-			StudioCount:       studiosCount,
-			GroupCount:        groupsCount,
-			MovieCount:        groupsCount,
-			TagCount:          tagsCount,
-			TotalOCount:       totalOCount,
-			TotalOmgCount:     totalOMGCount,
-			TotalPlayDuration: totalPlayDuration,
-			TotalPlayCount:    totalPlayCount,
-			ScenesPlayed:      uniqueScenePlayCount,
-		}
-
-		return nil
-	}); err != nil {
+	stats, err := manager.GetInstance().LibraryStats(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return &ret, nil
+	return &StatsResultType{
+		SceneCount:        stats.SceneCount,
+		ScenesSize:        stats.ScenesSize,
+		ScenesDuration:    stats.ScenesDuration,
+		ImageCount:        stats.ImageCount,
+		ImagesSize:        stats.ImagesSize,
+		GalleryCount:      stats.GalleryCount,
+		PerformerCount:    stats.PerformerCount,
+		StudioCount:       stats.StudioCount,
+		GroupCount:        stats.GroupCount,
+		MovieCount:        stats.GroupCount,
+		TagCount:          stats.TagCount,
+		TotalOCount:       stats.TotalOCount,
+		TotalOmgCount:     stats.TotalOMGCount,
+		TotalPlayDuration: stats.TotalPlayDuration,
+		TotalPlayCount:    stats.TotalPlayCount,
+		ScenesPlayed:      stats.ScenesPlayed,
+		LastComputed:      stats.ComputedAt,
+	}, nil
 }
 
 func (r *queryResolver) OCountStats(ctx context.Context) (*OCountStatsResultType, error) {
@@ -387,36 +355,48 @@ func (r *queryResolver) OCountStats(ctx context.Context) (*OCountStatsResultType
 		allODates = append(allODates, imageOMGDates...)
 		allODates = append(allODates, galleryOMGDates...)
 
-		// Group by date
-		dailyCounts := make(map[string]int)
-		for _, oDate := range allODates {
-			dateStr := oDate.Format("2006-01-02")
-			dailyCounts[dateStr]++
+		ret = OCountStatsResultType{
+			DailyStats: dailyCountStats(allODates),
 		}
 
-		// Convert to slice and sort by date
-		var dailyStats []*OCountDailyStatsType
-		for date, count := range dailyCounts {
-			// Parse date and format for display
-			parsedDate, err := time.Parse("2006-01-02", date)
-			if err != nil {
-				continue
-			}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *queryResolver) OmgCountStats(ctx context.Context) (*OCountStatsResultType, error) {
+	var ret OCountStatsResultType
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		repo := r.repository
 
-			dailyStats = append(dailyStats, &OCountDailyStatsType{
-				Date:        date,
-				DateDisplay: parsedDate.Format("2 Jan"),
-				Count:       count,
-			})
+		// Get all omg-count dates from the last year
+		now := time.Now()
+		oneYearAgo := now.AddDate(-1, 0, 0)
+
+		sceneOMGDates, err := repo.Scene.GetOMGDatesInRange(ctx, oneYearAgo, now)
+		if err != nil {
+			return err
 		}
 
-		// Sort by date
-		sort.Slice(dailyStats, func(i, j int) bool {
-			return dailyStats[i].Date < dailyStats[j].Date
-		})
+		imageOMGDates, err := repo.Image.GetOMGDatesInRange(ctx, oneYearAgo, now)
+		if err != nil {
+			return err
+		}
+
+		galleryOMGDates, err := repo.Gallery.GetOMGDatesInRange(ctx, oneYearAgo, now)
+		if err != nil {
+			return err
+		}
+
+		allOMGDates := sceneOMGDates
+		allOMGDates = append(allOMGDates, imageOMGDates...)
+		allOMGDates = append(allOMGDates, galleryOMGDates...)
 
 		ret = OCountStatsResultType{
-			DailyStats: dailyStats,
+			DailyStats: dailyCountStats(allOMGDates),
 		}
 
 		return nil
@@ -427,6 +407,39 @@ func (r *queryResolver) OCountStats(ctx context.Context) (*OCountStatsResultType
 	return &ret, nil
 }
 
+// dailyCountStats groups the dates by day, sorted by date.
+func dailyCountStats(dates []time.Time) []*OCountDailyStatsType {
+	// Group by date
+	dailyCounts := make(map[string]int)
+	for _, d := range dates {
+		dateStr := d.Format("2006-01-02")
+		dailyCounts[dateStr]++
+	}
+
+	// Convert to slice and sort by date
+	var dailyStats []*OCountDailyStatsType
+	for date, count := range dailyCounts {
+		// Parse date and format for display
+		parsedDate, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+
+		dailyStats = append(dailyStats, &OCountDailyStatsType{
+			Date:        date,
+			DateDisplay: parsedDate.Format("2 Jan"),
+			Count:       count,
+		})
+	}
+
+	// Sort by date
+	sort.Slice(dailyStats, func(i, j int) bool {
+		return dailyStats[i].Date < dailyStats[j].Date
+	})
+
+	return dailyStats
+}
+
 func (r *queryResolver) Version(ctx context.Context) (*Version, error) {
 	version, hash, buildtime := build.Version()
 
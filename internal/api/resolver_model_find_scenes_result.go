@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FindScenesResultType is the result of a scene query. The total duration
+// and size of the scenes are resolved separately, so that totals requested
+// in deferred fragments do not delay the scenes.
+type FindScenesResultType struct {
+	Count  int             `json:"count"`
+	Scenes []*models.Scene `json:"scenes"`
+
+	// totals returns the total duration and size of the scenes
+	totals func(ctx context.Context) (*models.SceneQueryResult, error)
+}
+
+// loadedSceneTotals returns totals which were loaded with the scenes.
+func loadedSceneTotals(result *models.SceneQueryResult) func(ctx context.Context) (*models.SceneQueryResult, error) {
+	return func(ctx context.Context) (*models.SceneQueryResult, error) {
+		return result, nil
+	}
+}
+
+// deferredSceneTotals returns totals which are loaded when first resolved.
+func (r *Resolver) deferredSceneTotals(sceneFilter *models.SceneFilterType, filter *models.FindFilterType) func(ctx context.Context) (*models.SceneQueryResult, error) {
+	// only the totals are needed, not the scenes
+	totalsFilter := models.FindFilterType{}
+	if filter != nil {
+		totalsFilter = *filter
+	}
+	perPage := 0
+	totalsFilter.PerPage = &perPage
+
+	var (
+		once   sync.Once
+		result *models.SceneQueryResult
+		err    error
+	)
+
+	return func(ctx context.Context) (*models.SceneQueryResult, error) {
+		once.Do(func() {
+			err = r.withReadTxn(ctx, func(ctx context.Context) error {
+				result, err = r.repository.Scene.Query(ctx, models.SceneQueryOptions{
+					QueryOptions: models.QueryOptions{
+						FindFilter: &totalsFilter,
+					},
+					SceneFilter:   sceneFilter,
+					TotalDuration: true,
+					TotalSize:     true,
+				})
+				return err
+			})
+		})
+
+		return result, err
+	}
+}
+
+// deferredFields returns the names of the fields selected for the current
+// resolver context which are only requested in deferred fragments.
+func deferredFields(ctx context.Context) []string {
+	var ret []string
+	for _, f := range graphql.CollectFieldsCtx(ctx, nil) {
+		if f.Deferrable != nil {
+			ret = append(ret, f.Name)
+		}
+	}
+
+	return ret
+}
+
+func (r *findScenesResultTypeResolver) Duration(ctx context.Context, obj *FindScenesResultType) (float64, error) {
+	totals, err := obj.totals(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return totals.TotalDuration, nil
+}
+
+func (r *findScenesResultTypeResolver) Filesize(ctx context.Context, obj *FindScenesResultType) (float64, error) {
+	totals, err := obj.totals(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return totals.TotalSize, nil
+}
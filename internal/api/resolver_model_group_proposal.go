@@ -0,0 +1,13 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *groupProposalPartResolver) Scene(ctx context.Context, obj *group.Part) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
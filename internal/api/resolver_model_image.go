@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
@@ -93,6 +95,22 @@ func (r *imageResolver) Galleries(ctx context.Context, obj *models.Image) (ret [
 	return ret, firstError(errs)
 }
 
+func (r *imageResolver) GalleryOrderIndex(ctx context.Context, obj *models.Image, galleryID string) (ret *int, err error) {
+	galleryIDInt, err := strconv.Atoi(galleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.GetImageOrderIndex(ctx, galleryIDInt, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *imageResolver) Rating100(ctx context.Context, obj *models.Image) (*int, error) {
 	return obj.Rating, nil
 }
@@ -118,6 +118,24 @@ func (r *performerResolver) Birthdate(ctx context.Context, obj *models.Performer
 	return nil, nil
 }
 
+func (r *performerResolver) Age(ctx context.Context, obj *models.Performer) (*int, error) {
+	return performer.Age(obj), nil
+}
+
+func (r *performerResolver) Bmi(ctx context.Context, obj *models.Performer) (*float64, error) {
+	return performer.BMI(obj), nil
+}
+
+func (r *performerResolver) CareerStartYear(ctx context.Context, obj *models.Performer) (*int, error) {
+	start, _ := performer.CareerSpan(obj.CareerLength)
+	return start, nil
+}
+
+func (r *performerResolver) CareerEndYear(ctx context.Context, obj *models.Performer) (*int, error) {
+	_, end := performer.CareerSpan(obj.CareerLength)
+	return end, nil
+}
+
 func (r *performerResolver) ImagePath(ctx context.Context, obj *models.Performer) (*string, error) {
 	var hasImage bool
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *performerConflictResolver) Performer(ctx context.Context, obj *models.PerformerConflict) (*models.Performer, error) {
+	return loaders.From(ctx).PerformerByID.Load(obj.PerformerID)
+}
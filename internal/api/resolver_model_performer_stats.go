@@ -0,0 +1,13 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/activity"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *performerStatsResolver) Performer(ctx context.Context, obj *activity.PerformerStats) (*models.Performer, error) {
+	return loaders.From(ctx).PerformerByID.Load(obj.PerformerID)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *reencodeEstimateResolver) Scene(ctx context.Context, obj *scene.ReencodeEstimate) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
+
+func (r *reencodeEstimateResolver) EstimatedTime(ctx context.Context, obj *scene.ReencodeEstimate) (float64, error) {
+	return obj.EstimatedTime.Seconds(), nil
+}
+
+func (r *reencodePlanResolver) EstimatedTime(ctx context.Context, obj *scene.ReencodePlan) (float64, error) {
+	return obj.EstimatedTime.Seconds(), nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/pose"
 )
 
 func convertVideoFile(f models.File) (*models.VideoFile, error) {
@@ -101,6 +103,47 @@ func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) ([]*VideoF
 	return ret, nil
 }
 
+func (r *sceneResolver) Extras(ctx context.Context, obj *models.Scene) ([]*SceneExtra, error) {
+	var extras []*models.SceneExtra
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		extras, err = r.repository.SceneExtra.FindBySceneID(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	fileIDs := make([]models.FileID, len(extras))
+	for i, e := range extras {
+		fileIDs[i] = e.FileID
+	}
+
+	files, errs := loaders.From(ctx).FileByID.LoadAll(fileIDs)
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
+	apiKey := manager.GetInstance().Config.GetAPIKey()
+
+	ret := make([]*SceneExtra, len(extras))
+	for i, e := range extras {
+		f, err := convertVideoFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+
+		ret[i] = &SceneExtra{
+			File:   &VideoFile{VideoFile: f},
+			Role:   e.Role,
+			Stream: builder.GetExtraStreamURL(e.Role, e.FileID, apiKey).String(),
+		}
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) Rating(ctx context.Context, obj *models.Scene) (*int, error) {
 	if obj.Rating != nil {
 		rating := models.Rating100To5(*obj.Rating)
@@ -140,11 +183,14 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
 	screenshotPath := builder.GetScreenshotURL()
 	previewPath := builder.GetStreamPreviewURL()
+	wallPreviewPath := builder.GetWallPreviewURL()
 	streamPath := builder.GetStreamURL(config.GetAPIKey()).String()
 	webpPath := builder.GetStreamPreviewImageURL()
 	objHash := obj.GetHash(config.GetVideoFileNamingAlgorithm())
 	vttPath := builder.GetSpriteVTTURL(objHash)
-	spritePath := builder.GetSpriteURL(objHash)
+	_, spriteFormat := manager.FindSpriteImageFilePath(manager.GetInstance().Paths.Scene, objHash, config.GetSpriteFormat())
+	spritePath := builder.GetSpriteURL(objHash, spriteFormat)
+	spriteMetadataPath := builder.GetSpriteMetadataURL()
 	funscriptPath := builder.GetFunscriptURL()
 	captionBasePath := builder.GetCaptionURL()
 	interactiveHeatmap := builder.GetInteractiveHeatmapURL()
@@ -152,10 +198,12 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	return &ScenePathsType{
 		Screenshot:         &screenshotPath,
 		Preview:            &previewPath,
+		WallPreview:        &wallPreviewPath,
 		Stream:             &streamPath,
 		Webp:               &webpPath,
 		Vtt:                &vttPath,
 		Sprite:             &spritePath,
+		SpriteMetadata:     &spriteMetadataPath,
 		Funscript:          &funscriptPath,
 		InteractiveHeatmap: &interactiveHeatmap,
 		Caption:            &captionBasePath,
@@ -300,17 +348,22 @@ func (r *sceneResolver) ScenePerformers(ctx context.Context, obj *models.Scene)
 	loader := loaders.From(ctx).PerformerByID
 
 	for _, sp := range obj.ScenePerformers.List() {
-		performer, err := loader.Load(sp.PerformerID)
+		p, err := loader.Load(sp.PerformerID)
 		if err != nil {
 			return nil, err
 		}
 
 		scenePerformer := &ScenePerformer{
-			Performer:       performer,
+			Performer:       p,
 			SmallRole:       sp.SmallRole,
 			RoleDescription: sp.RoleDescription,
 		}
 
+		if obj.Date != nil && p != nil && p.Birthdate != nil {
+			age := performer.AgeAt(*p.Birthdate, *obj.Date)
+			scenePerformer.AgeAtScene = &age
+		}
+
 		ret = append(ret, scenePerformer)
 	}
 
@@ -670,3 +723,56 @@ func (r *sceneResolver) VideoFilters(ctx context.Context, obj *models.Scene) (*m
 func (r *sceneResolver) VideoTransforms(ctx context.Context, obj *models.Scene) (*models.VideoTransforms, error) {
 	return obj.VideoTransforms, nil
 }
+
+func (r *sceneResolver) TranscodeArgs(ctx context.Context, obj *models.Scene) (*models.TranscodeArgs, error) {
+	if obj.TranscodeArgs.IsEmpty() {
+		return nil, nil
+	}
+
+	return obj.TranscodeArgs, nil
+}
+
+func (r *sceneResolver) poseAnalyzer() *pose.Analyzer {
+	return &pose.Analyzer{
+		Markers:    r.repository.SceneMarker,
+		Tags:       r.repository.Tag,
+		Similarity: r.repository.SceneSimilarity,
+	}
+}
+
+func (r *sceneResolver) PoseCoverage(ctx context.Context, obj *models.Scene) (ret *models.PoseCoverage, err error) {
+	primaryFile, err := r.getPrimaryFile(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var duration float64
+	if primaryFile != nil {
+		duration = primaryFile.Duration
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.poseAnalyzer().Coverage(ctx, obj.ID, duration)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) PoseTagSuggestions(ctx context.Context, obj *models.Scene, limit *int) (ret []*models.PoseTagSuggestion, err error) {
+	l := 10
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.poseAnalyzer().Suggest(ctx, obj.ID, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
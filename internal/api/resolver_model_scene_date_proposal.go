@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *sceneDateProposalResolver) Scene(ctx context.Context, obj *scene.DateProposal) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
+
+func (r *sceneDateProposalResolver) Date(ctx context.Context, obj *scene.DateProposal) (string, error) {
+	return obj.Date.String(), nil
+}
@@ -54,3 +54,8 @@ func (r *sceneMarkerResolver) Screenshot(ctx context.Context, obj *models.SceneM
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	return urlbuilders.NewSceneMarkerURLBuilder(baseURL, obj).GetScreenshotURL(), nil
 }
+
+func (r *sceneMarkerResolver) Thumbnail(ctx context.Context, obj *models.SceneMarker) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	return urlbuilders.NewSceneMarkerURLBuilder(baseURL, obj).GetThumbnailURL(), nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *sceneParserBatchResolver) Changes(ctx context.Context, obj *models.SceneParserBatch, status *models.SceneParserChangeStatus) (ret []*models.SceneParserChange, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SceneParserBatch.FindChanges(ctx, obj.ID, status)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneParserChangeResolver) Scene(ctx context.Context, obj *models.SceneParserChange) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
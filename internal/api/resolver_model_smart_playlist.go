@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *smartPlaylistResolver) SceneFilter(ctx context.Context, obj *models.SmartPlaylist) (map[string]interface{}, error) {
+	if obj.SceneFilter == nil {
+		return nil, nil
+	}
+
+	// return the filter in the same form as it is input
+	encoded, err := json.Marshal(obj.SceneFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	if err := json.Unmarshal(encoded, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+func (r *studioProposalResolver) Scene(ctx context.Context, obj *studio.Proposal) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
+
+func (r *studioProposalResolver) Studio(ctx context.Context, obj *studio.Proposal) (*models.Studio, error) {
+	return loaders.From(ctx).StudioByID.Load(obj.StudioID)
+}
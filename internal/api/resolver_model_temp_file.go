@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+func (r *tempFileResolver) Age(ctx context.Context, obj *fsutil.TempEntry) (int, error) {
+	return int(time.Since(obj.ModTime).Seconds()), nil
+}
+
+func (r *tempFileResolver) InUse(ctx context.Context, obj *fsutil.TempEntry) (bool, error) {
+	return manager.GetInstance().IsTempFileInUse(obj.Path), nil
+}
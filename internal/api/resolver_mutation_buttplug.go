@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *mutationResolver) ButtplugConnect(ctx context.Context) (*ButtplugStatus, error) {
+	if err := manager.GetInstance().ButtplugConnect(ctx); err != nil {
+		return nil, err
+	}
+
+	return buttplugStatus(), nil
+}
+
+func (r *mutationResolver) ButtplugDisconnect(ctx context.Context) (bool, error) {
+	manager.GetInstance().ButtplugDisconnect()
+	return true, nil
+}
+
+func (r *mutationResolver) ButtplugScan(ctx context.Context) (bool, error) {
+	if err := manager.GetInstance().ButtplugScan(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ButtplugSetDeviceLatency(ctx context.Context, input ButtplugSetDeviceLatencyInput) (bool, error) {
+	c := manager.GetInstance().Config
+	c.SetButtplugDeviceLatency(input.Name, input.Latency)
+
+	if err := c.Write(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ButtplugPlay(ctx context.Context, input ButtplugPlayInput) (bool, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	var s *models.Scene
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	position := 0.
+	if input.Position != nil {
+		position = *input.Position
+	}
+
+	if err := manager.GetInstance().ButtplugPlay(ctx, s, position); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ButtplugStop(ctx context.Context) (bool, error) {
+	if err := manager.GetInstance().ButtplugStop(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -2,9 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 func (r *mutationResolver) ColorPresetCreate(ctx context.Context, input ColorPresetCreateInput) (*models.ColorPreset, error) {
@@ -25,6 +30,17 @@ func (r *mutationResolver) ColorPresetCreate(ctx context.Context, input ColorPre
 	// Start the transaction and save the color preset
 	var colorPreset *models.ColorPreset
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		// add new presets to the end of the sort order
+		if input.Sort == nil {
+			presets, err := r.repository.ColorPreset.FindAll(ctx)
+			if err != nil {
+				return err
+			}
+			for _, p := range presets {
+				newColorPreset.Sort = max(newColorPreset.Sort, p.Sort+1)
+			}
+		}
+
 		var err error
 		colorPreset, err = r.repository.ColorPreset.Create(ctx, newColorPreset)
 		return err
@@ -95,3 +111,178 @@ func (r *mutationResolver) ColorPresetDestroy(ctx context.Context, input ColorPr
 
 	return true, nil
 }
+
+func (r *mutationResolver) ColorPresetsReorder(ctx context.Context, ids []string) ([]*models.ColorPreset, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.ColorPreset
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ColorPreset
+		presets, err := qb.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int]*models.ColorPreset, len(presets))
+		for _, p := range presets {
+			byID[p.ID] = p
+		}
+
+		// the given presets come first, followed by the others in their
+		// current order
+		var ordered []*models.ColorPreset
+		for _, id := range idInts {
+			p := byID[id]
+			if p == nil {
+				return fmt.Errorf("color preset with id %d not found", id)
+			}
+			ordered = append(ordered, p)
+			delete(byID, id)
+		}
+		for _, p := range presets {
+			if byID[p.ID] != nil {
+				ordered = append(ordered, p)
+			}
+		}
+
+		for i, p := range ordered {
+			sort := i + 1
+			if p.Sort == sort {
+				ret = append(ret, p)
+				continue
+			}
+
+			partial := models.NewColorPresetPartial()
+			partial.Sort = models.NewOptionalInt(sort)
+			updated, err := qb.Update(ctx, p.ID, partial)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, updated)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// colorPresetRules converts rule inputs to rules, resolving the presets and
+// the descendants of the parent tags.
+func (r *mutationResolver) colorPresetRules(ctx context.Context, input []*ColorPresetRuleInput) ([]tag.ColorPresetRule, error) {
+	ret := make([]tag.ColorPresetRule, len(input))
+	for i, in := range input {
+		if len(in.NamePatterns) == 0 && len(in.ParentTagIds) == 0 {
+			return nil, errors.New("color preset rules must have name patterns or parent tags")
+		}
+
+		presetID, err := strconv.Atoi(in.PresetID)
+		if err != nil {
+			return nil, err
+		}
+
+		preset, err := r.repository.ColorPreset.Find(ctx, presetID)
+		if err != nil {
+			return nil, err
+		}
+		if preset == nil {
+			return nil, fmt.Errorf("color preset with id %d not found", presetID)
+		}
+
+		patterns, err := tag.CompileNamePatterns(in.NamePatterns)
+		if err != nil {
+			return nil, err
+		}
+
+		rule := tag.ColorPresetRule{
+			Preset:       preset,
+			NamePatterns: patterns,
+		}
+
+		if len(in.ParentTagIds) > 0 {
+			parentIDs, err := stringslice.StringSliceToIntSlice(in.ParentTagIds)
+			if err != nil {
+				return nil, err
+			}
+
+			rule.DescendantIDs = make(map[int]bool)
+			for _, parentID := range parentIDs {
+				descendants, err := r.repository.Tag.FindAllDescendants(ctx, parentID, nil)
+				if err != nil {
+					return nil, err
+				}
+				for _, d := range descendants {
+					// the results include the parent itself
+					if d.ID != parentID {
+						rule.DescendantIDs[d.ID] = true
+					}
+				}
+			}
+		}
+
+		ret[i] = rule
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ApplyColorPreset(ctx context.Context, input ApplyColorPresetInput) ([]*models.Tag, error) {
+	overwrite := input.Overwrite != nil && *input.Overwrite
+	dryRun := input.DryRun != nil && *input.DryRun
+
+	var ret []*models.Tag
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		rules, err := r.colorPresetRules(ctx, input.Rules)
+		if err != nil {
+			return err
+		}
+
+		qb := r.repository.Tag
+		perPage := -1
+		tags, _, err := qb.Query(ctx, nil, &models.FindFilterType{
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return fmt.Errorf("finding tags: %w", err)
+		}
+
+		changes := tag.ColorPresetChanges(tags, rules, overwrite)
+		for _, t := range tags {
+			color, found := changes[t.ID]
+			if !found {
+				continue
+			}
+
+			if dryRun {
+				t.Color = color
+				ret = append(ret, t)
+				continue
+			}
+
+			partial := models.NewTagPartial()
+			partial.Color = models.NewOptionalString(color)
+			updated, err := qb.UpdatePartial(ctx, t.ID, partial)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, updated)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		for _, t := range ret {
+			r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagUpdatePost, input, []string{"color"})
+		}
+	}
+
+	return ret, nil
+}
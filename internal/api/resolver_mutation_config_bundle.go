@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *mutationResolver) ExportConfiguration(ctx context.Context, input *ExportConfigurationInput) (string, error) {
+	if input == nil {
+		input = &ExportConfigurationInput{}
+	}
+
+	includePaths := input.IncludePaths != nil && *input.IncludePaths
+	bundle, err := config.GetInstance().ExportBundle(includePaths)
+	if err != nil {
+		return "", err
+	}
+
+	var data []byte
+	if input.Format != nil && *input.Format == ConfigBundleFormatJSON {
+		data, err = json.MarshalIndent(bundle, "", "  ")
+	} else {
+		data, err = yaml.Marshal(bundle)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (r *mutationResolver) ImportConfiguration(ctx context.Context, input ImportConfigurationInput) (*ImportConfigurationResult, error) {
+	data := []byte(input.Bundle)
+
+	if input.DryRun != nil && *input.DryRun {
+		changes, err := config.GetInstance().DiffBundle(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ImportConfigurationResult{
+			Changes: changes,
+		}, nil
+	}
+
+	changes, err := manager.GetInstance().ImportConfiguration(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportConfigurationResult{
+		Changes: changes,
+		Applied: true,
+	}, nil
+}
@@ -9,15 +9,26 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/conversion"
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/mediaserver"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/ocr"
+	"github.com/stashapp/stash/pkg/retention"
+	"github.com/stashapp/stash/pkg/reverseimage"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/utils"
+	"golang.org/x/text/language"
 )
 
 var ErrOverriddenConfig = errors.New("cannot set overridden value")
@@ -38,8 +49,33 @@ func (r *mutationResolver) DownloadFFMpeg(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("ffmpeg and ffprobe already installed at %s and %s", ffmpegPath, ffprobePath)
 	}
 
+	jobID := r.startDownloadFFMpeg(ctx)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) UpdateFFMpeg(ctx context.Context, input *UpdateFFMpegInput) (*string, error) {
+	mgr := manager.GetInstance()
+
+	force := input != nil && input.Force != nil && *input.Force
+	if !force && mgr.FFMpeg != nil && mgr.FFProbe != nil {
+		status, err := ffmpeg.GetStatus(ctx, mgr.FFMpeg.Path())
+		if err == nil && status.Supported() {
+			logger.Infof("ffmpeg %s at %s is supported, not updating", status.Version, status.Path)
+			return nil, nil
+		}
+	}
+
+	jobID := strconv.Itoa(r.startDownloadFFMpeg(ctx))
+
+	return &jobID, nil
+}
+
+func (r *mutationResolver) startDownloadFFMpeg(ctx context.Context) int {
+	mgr := manager.GetInstance()
+
 	t := &task.DownloadFFmpegJob{
-		ConfigDirectory: configDir,
+		ConfigDirectory: mgr.Config.GetConfigPathAbs(),
 		OnComplete: func(ctx context.Context) {
 			// clear the ffmpeg and ffprobe paths
 			logger.Infof("Clearing ffmpeg and ffprobe config paths so they are resolved from the config directory")
@@ -50,9 +86,7 @@ func (r *mutationResolver) DownloadFFMpeg(ctx context.Context) (string, error) {
 		},
 	}
 
-	jobID := mgr.JobManager.Add(ctx, "Downloading ffmpeg...", t)
-
-	return strconv.Itoa(jobID), nil
+	return mgr.JobManager.Add(ctx, "Downloading ffmpeg...", t)
 }
 
 func (r *mutationResolver) setConfigString(key string, value *string) {
@@ -83,12 +117,27 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 	}
 }
 
+// validateBindAddresses returns an error if any of the addresses is not an
+// IP address or the name of a network interface with usable addresses.
+func validateBindAddresses(addresses []string) error {
+	_, err := utils.ResolveBindAddresses(addresses, 0)
+	return err
+}
+
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
+			if s.Rclone {
+				// remotes are checked when scanned
+				if !strings.Contains(s.Path, ":") {
+					return makeConfigGeneralResult(), fmt.Errorf("rclone path %q must be in the form remote:path", s.Path)
+				}
+				continue
+			}
+
 			// Only validate existence of new paths
 			isNew := true
 			for _, path := range existingPaths {
@@ -142,6 +191,11 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.Database, *input.DatabasePath)
 	}
 
+	if input.DatabaseBusyTimeout != nil && *input.DatabaseBusyTimeout < 0 {
+		return makeConfigGeneralResult(), errors.New("databaseBusyTimeout must not be negative")
+	}
+	r.setConfigInt(config.DatabaseBusyTimeout, input.DatabaseBusyTimeout)
+
 	existingBackupDirectoryPath := c.GetBackupDirectoryPath()
 	if input.BackupDirectoryPath != nil && existingBackupDirectoryPath != *input.BackupDirectoryPath {
 		if err := validateDir(config.BackupDirectoryPath, *input.BackupDirectoryPath, true); err != nil {
@@ -271,6 +325,11 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
+	r.setConfigInt(config.ParallelIOTasks, input.ParallelIOTasks)
+	if input.PerformanceProfile != nil {
+		c.SetString(config.PerformanceProfileKey, input.PerformanceProfile.String())
+	}
+	r.setConfigBool(config.ResumeInterruptedJobs, input.ResumeInterruptedJobs)
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
 	r.setConfigFloat(config.PreviewSegmentDuration, input.PreviewSegmentDuration)
@@ -280,7 +339,62 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.PreviewPreset, input.PreviewPreset.String())
 	}
 
+	if input.WallPreviewHeight != nil && *input.WallPreviewHeight <= 0 {
+		return makeConfigGeneralResult(), errors.New("wallPreviewHeight must be greater than 0")
+	}
+	r.setConfigInt(config.WallPreviewHeight, input.WallPreviewHeight)
+	if input.WallPreviewCrf != nil && (*input.WallPreviewCrf <= 0 || *input.WallPreviewCrf > 51) {
+		return makeConfigGeneralResult(), errors.New("wallPreviewCRF must be between 1 and 51")
+	}
+	r.setConfigInt(config.WallPreviewCRF, input.WallPreviewCrf)
+	if input.WallPreviewPreset != nil {
+		c.SetString(config.WallPreviewPreset, input.WallPreviewPreset.String())
+	}
+
+	if input.SpriteRows != nil && *input.SpriteRows <= 0 {
+		return makeConfigGeneralResult(), errors.New("spriteRows must be greater than 0")
+	}
+	r.setConfigInt(config.SpriteRows, input.SpriteRows)
+	if input.SpriteColumns != nil && *input.SpriteColumns <= 0 {
+		return makeConfigGeneralResult(), errors.New("spriteColumns must be greater than 0")
+	}
+	r.setConfigInt(config.SpriteColumns, input.SpriteColumns)
+	if input.SpriteInterval != nil && *input.SpriteInterval < 0 {
+		return makeConfigGeneralResult(), errors.New("spriteInterval must not be negative")
+	}
+	r.setConfigFloat(config.SpriteInterval, input.SpriteInterval)
+	if input.SpriteFormat != nil {
+		c.SetString(config.SpriteFormat, input.SpriteFormat.String())
+	}
+	r.setConfigBool(config.SpriteOnDemand, input.SpriteOnDemand)
+
+	r.setConfigBool(config.SmartCovers, input.SmartCovers)
+	if input.SmartCoverCandidates != nil && *input.SmartCoverCandidates <= 0 {
+		return makeConfigGeneralResult(), errors.New("smartCoverCandidates must be greater than 0")
+	}
+	r.setConfigInt(config.SmartCoverCandidates, input.SmartCoverCandidates)
+	r.setConfigBool(config.SmartCoverPreferPeople, input.SmartCoverPreferPeople)
+
+	r.setConfigString(config.OCRCommand, input.OcrCommand)
+	if input.OcrArgs != nil {
+		c.SetInterface(config.OCRArgs, input.OcrArgs)
+	}
+	if input.OcrEndpoint != nil && *input.OcrEndpoint != "" {
+		if err := ocr.ValidateEndpoint(*input.OcrEndpoint); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("ocrEndpoint: %w", err)
+		}
+	}
+	r.setConfigString(config.OCREndpoint, input.OcrEndpoint)
+	r.setConfigString(config.OCRAPIKey, input.OcrAPIKey)
+	if input.OcrFrames != nil && *input.OcrFrames <= 0 {
+		return makeConfigGeneralResult(), errors.New("ocrFrames must be greater than 0")
+	}
+	r.setConfigInt(config.OCRFrames, input.OcrFrames)
+
 	r.setConfigBool(config.TranscodeHardwareAcceleration, input.TranscodeHardwareAcceleration)
+	if input.DeinterlaceFilter != nil {
+		c.SetString(config.DeinterlaceFilter, input.DeinterlaceFilter.String())
+	}
 	if input.MaxTranscodeSize != nil {
 		c.SetString(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
@@ -289,7 +403,23 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
 	r.setConfigBool(config.WriteImageThumbnails, input.WriteImageThumbnails)
+
+	regenerateThumbnails := false
+	if input.ImageThumbnailProfiles != nil {
+		profiles := make([]image.ThumbnailProfile, len(input.ImageThumbnailProfiles))
+		for i, p := range input.ImageThumbnailProfiles {
+			profiles[i] = *p
+		}
+
+		changed, err := c.SetImageThumbnailProfiles(profiles)
+		if err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		regenerateThumbnails = changed && len(profiles) > 0
+	}
+
 	r.setConfigBool(config.CreateImageClipsFromVideos, input.CreateImageClipsFromVideos)
+	r.setConfigBool(config.DeleteConfirmation, input.DeleteConfirmation)
 
 	if input.GalleryCoverRegex != nil {
 		_, err := regexp.Compile(*input.GalleryCoverRegex)
@@ -385,6 +515,168 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		r.setConfigString(config.PythonPath, input.PythonPath)
 	}
 
+	if input.RclonePath != nil {
+		r.setConfigString(config.RclonePath, input.RclonePath)
+	}
+
+	if input.BindAddresses != nil {
+		if err := validateBindAddresses(input.BindAddresses); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		c.SetInterface(config.BindAddresses, input.BindAddresses)
+	}
+
+	if input.RetentionRules != nil {
+		rules := make([]retention.Rule, len(input.RetentionRules))
+		for i, rule := range input.RetentionRules {
+			rules[i] = *rule
+		}
+
+		if err := c.SetRetentionRules(rules); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
+	if input.RetentionArchivePath != nil && c.GetRetentionArchivePath() != *input.RetentionArchivePath {
+		if err := validateDir(config.RetentionArchivePath, *input.RetentionArchivePath, true); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetString(config.RetentionArchivePath, *input.RetentionArchivePath)
+	}
+
+	if input.RetentionReportInterval != nil && *input.RetentionReportInterval < 0 {
+		return makeConfigGeneralResult(), errors.New("retentionReportInterval must not be negative")
+	}
+	r.setConfigInt(config.RetentionReportInterval, input.RetentionReportInterval)
+
+	if input.AutoConversionRules != nil {
+		rules := make([]conversion.Rule, len(input.AutoConversionRules))
+		for i, rule := range input.AutoConversionRules {
+			rules[i] = *rule
+		}
+
+		if err := c.SetAutoConversionRules(rules); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
+	if input.AutoConversionMaxConcurrent != nil && *input.AutoConversionMaxConcurrent < 1 {
+		return makeConfigGeneralResult(), errors.New("autoConversionMaxConcurrent must be at least 1")
+	}
+	r.setConfigInt(config.AutoConversionMaxConcurrent, input.AutoConversionMaxConcurrent)
+
+	if input.AutoConversionDailyBudget != nil && *input.AutoConversionDailyBudget < 0 {
+		return makeConfigGeneralResult(), errors.New("autoConversionDailyBudget must not be negative")
+	}
+	r.setConfigInt(config.AutoConversionDailyBudget, input.AutoConversionDailyBudget)
+
+	if input.MediaServers != nil {
+		servers := make([]mediaserver.Server, len(input.MediaServers))
+		for i, server := range input.MediaServers {
+			servers[i] = *server
+		}
+
+		if err := c.SetMediaServers(servers); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
+	if input.MediaServerSyncInterval != nil && *input.MediaServerSyncInterval < 0 {
+		return makeConfigGeneralResult(), errors.New("mediaServerSyncInterval must not be negative")
+	}
+	r.setConfigInt(config.MediaServerSyncInterval, input.MediaServerSyncInterval)
+
+	if input.TempFilesMaxAge != nil && *input.TempFilesMaxAge < 0 {
+		return makeConfigGeneralResult(), errors.New("tempFilesMaxAge must not be negative")
+	}
+	r.setConfigInt(config.TempFilesMaxAge, input.TempFilesMaxAge)
+
+	if input.TempFilesMaxSize != nil && *input.TempFilesMaxSize < 0 {
+		return makeConfigGeneralResult(), errors.New("tempFilesMaxSize must not be negative")
+	}
+	r.setConfigInt(config.TempFilesMaxSize, input.TempFilesMaxSize)
+
+	refreshSortOptions := false
+	if input.SortCollation != nil {
+		c.SetString(config.SortCollation, input.SortCollation.String())
+		refreshSortOptions = true
+	}
+	if input.SortLocale != nil {
+		if *input.SortLocale != "" {
+			if _, err := language.Parse(*input.SortLocale); err != nil {
+				return makeConfigGeneralResult(), fmt.Errorf("invalid sortLocale: %w", err)
+			}
+		}
+		c.SetString(config.SortLocale, *input.SortLocale)
+		refreshSortOptions = true
+	}
+
+	refreshTimezone := false
+	if input.Timezone != nil {
+		if _, err := time.LoadLocation(*input.Timezone); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid timezone: %w", err)
+		}
+		c.SetString(config.Timezone, *input.Timezone)
+		refreshTimezone = true
+	}
+
+	if input.OrderingProfiles != nil {
+		profiles := make([]models.OrderingProfile, len(input.OrderingProfiles))
+		for i, p := range input.OrderingProfiles {
+			if err := r.validateOrderingProfile(ctx, *p); err != nil {
+				return makeConfigGeneralResult(), err
+			}
+			profiles[i] = *p
+		}
+
+		if err := c.SetOrderingProfiles(profiles); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
+	if input.ContentGateTags != nil || input.ContentGatePin != nil || input.ContentGateTimeout != nil {
+		// the gate settings could otherwise be used to show gated content
+		if len(manager.GetInstance().GatedTags()) > 0 {
+			return makeConfigGeneralResult(), errors.New("content gate settings cannot be changed while the content gate is locked")
+		}
+	}
+	if input.ContentGateTags != nil {
+		if _, err := stringslice.StringSliceToIntSlice(input.ContentGateTags); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid contentGateTags: %w", err)
+		}
+		c.SetInterface(config.ContentGateTags, input.ContentGateTags)
+	}
+	if input.ContentGateTimeout != nil {
+		if *input.ContentGateTimeout < 0 {
+			return makeConfigGeneralResult(), errors.New("contentGateTimeout must not be negative")
+		}
+		r.setConfigInt(config.ContentGateTimeout, input.ContentGateTimeout)
+	}
+	if input.ContentGatePin != nil {
+		c.SetContentGatePIN(*input.ContentGatePin)
+	}
+
+	if input.AutoTagPerformerMatchModes != nil {
+		c.SetAutoTagMatchModes(config.AutoTagPerformerMatchModes, input.AutoTagPerformerMatchModes)
+	}
+	if input.AutoTagStudioMatchModes != nil {
+		c.SetAutoTagMatchModes(config.AutoTagStudioMatchModes, input.AutoTagStudioMatchModes)
+	}
+	if input.AutoTagTagMatchModes != nil {
+		c.SetAutoTagMatchModes(config.AutoTagTagMatchModes, input.AutoTagTagMatchModes)
+	}
+	if input.StudioDomainMappings != nil {
+		mappings := make([]studio.DomainMapping, len(input.StudioDomainMappings))
+		for i, m := range input.StudioDomainMappings {
+			mappings[i] = *m
+		}
+
+		if err := c.SetStudioDomainMappings(mappings); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
 	if input.TranscodeInputArgs != nil {
 		c.SetInterface(config.TranscodeInputArgs, input.TranscodeInputArgs)
 	}
@@ -399,6 +691,16 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.DrawFunscriptHeatmapRange, input.DrawFunscriptHeatmapRange)
+	if input.FunscriptHeatmapPalette != nil {
+		c.SetString(config.FunscriptHeatmapPalette, input.FunscriptHeatmapPalette.String())
+	}
+	if input.FunscriptHeatmapHeight != nil {
+		if *input.FunscriptHeatmapHeight <= 0 {
+			return makeConfigGeneralResult(), errors.New("funscript heatmap height must be greater than zero")
+		}
+		c.SetInt(config.FunscriptHeatmapHeight, *input.FunscriptHeatmapHeight)
+	}
+	r.setConfigInt(config.FunscriptHeatmapSmoothing, input.FunscriptHeatmapSmoothing)
 
 	if input.ScraperPackageSources != nil {
 		c.SetInterface(config.ScraperPackageSources, input.ScraperPackageSources)
@@ -439,6 +741,19 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshSortOptions {
+		manager.GetInstance().SetSortOptions()
+	}
+	if refreshTimezone {
+		manager.GetInstance().SetTimezone()
+	}
+	if regenerateThumbnails {
+		// generate the thumbnails of the new profiles, rather than
+		// generating them on the fly as images are viewed
+		if _, err := manager.GetInstance().Generate(ctx, manager.GenerateMetadataInput{ImageThumbnails: true}); err != nil {
+			logger.Warnf("could not start image thumbnail generation: %v", err)
+		}
+	}
 
 	return makeConfigGeneralResult(), nil
 }
@@ -467,6 +782,10 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 	r.setConfigBool(config.ContinuePlaylistDefault, input.ContinuePlaylistDefault)
 
 	r.setConfigString(config.Language, input.Language)
+	if input.Language != nil {
+		// the sort locale defaults to the language
+		manager.GetInstance().SetSortOptions()
+	}
 
 	if input.ImageLightbox != nil {
 		options := input.ImageLightbox
@@ -511,10 +830,15 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 
 	r.setConfigString(config.HandyKey, input.HandyKey)
 	r.setConfigInt(config.FunscriptOffset, input.FunscriptOffset)
+	r.setConfigString(config.ButtplugServerURL, input.ButtplugServerURL)
 	r.setConfigBool(config.UseStashHostedFunscript, input.UseStashHostedFunscript)
 
 	r.setConfigInt(config.RandomRatingThreshold, input.RandomRatingThreshold)
 	r.setConfigInt(config.RandomBestRatingThreshold, input.RandomBestRatingThreshold)
+	if input.PlayRandomExclusionWindow != nil && *input.PlayRandomExclusionWindow < 0 {
+		return makeConfigInterfaceResult(), errors.New("playRandomExclusionWindow must not be negative")
+	}
+	r.setConfigInt(config.PlayRandomExclusionWindow, input.PlayRandomExclusionWindow)
 	r.setConfigBool(config.ShowSimilarityPercent, input.ShowSimilarityPercent)
 
 	r.setConfigString(config.ExternalVideoPlayer, input.ExternalVideoPlayer)
@@ -539,6 +863,14 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 	r.setConfigString(config.DLNAVideoSortOrder, input.VideoSortOrder)
 	r.setConfigInt(config.DLNAPort, input.Port)
 
+	if input.ExcludeTags != nil {
+		c.SetInterface(config.DLNAExcludeTags, input.ExcludeTags)
+	}
+
+	r.setConfigBool(config.DLNAExcludeUnorganized, input.ExcludeUnorganized)
+	r.setConfigBool(config.DLNAExcludeBroken, input.ExcludeBroken)
+	r.setConfigBool(config.DLNAExcludeQuarantined, input.ExcludeQuarantined)
+
 	refresh := false
 	if input.Enabled != nil {
 		c.SetBool(config.DLNADefaultEnabled, *input.Enabled)
@@ -549,6 +881,13 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 		c.SetInterface(config.DLNAInterfaces, input.Interfaces)
 	}
 
+	if input.BindAddresses != nil {
+		if err := validateBindAddresses(input.BindAddresses); err != nil {
+			return makeConfigDLNAResult(), err
+		}
+		c.SetInterface(config.DLNABindAddresses, input.BindAddresses)
+	}
+
 	if err := c.Write(); err != nil {
 		return makeConfigDLNAResult(), err
 	}
@@ -574,6 +913,19 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 		refreshScraperCache = true
 	}
 
+	if input.ScraperCDPMaxSessions != nil && *input.ScraperCDPMaxSessions < 1 {
+		return makeConfigScrapingResult(), errors.New("scraperCDPMaxSessions must be at least 1")
+	}
+	r.setConfigInt(config.ScraperCDPMaxSessions, input.ScraperCDPMaxSessions)
+	if input.ScraperCDPTimeout != nil && *input.ScraperCDPTimeout < 1 {
+		return makeConfigScrapingResult(), errors.New("scraperCDPTimeout must be at least 1")
+	}
+	r.setConfigInt(config.ScraperCDPTimeout, input.ScraperCDPTimeout)
+	if input.ScraperCDPMaxMemory != nil && *input.ScraperCDPMaxMemory < 0 {
+		return makeConfigScrapingResult(), errors.New("scraperCDPMaxMemory must not be negative")
+	}
+	r.setConfigInt(config.ScraperCDPMaxMemory, input.ScraperCDPMaxMemory)
+
 	if input.ExcludeTagPatterns != nil {
 		for _, r := range input.ExcludeTagPatterns {
 			_, err := regexp.Compile(r)
@@ -586,6 +938,17 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 
 	r.setConfigBool(config.ScraperCertCheck, input.ScraperCertCheck)
 
+	if input.ReverseImageSearchProviders != nil {
+		providers := make([]reverseimage.Provider, len(input.ReverseImageSearchProviders))
+		for i, p := range input.ReverseImageSearchProviders {
+			providers[i] = *p
+		}
+
+		if err := c.SetReverseImageSearchProviders(providers); err != nil {
+			return makeConfigScrapingResult(), err
+		}
+	}
+
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
@@ -617,6 +980,15 @@ func (r *mutationResolver) ConfigureDefaults(ctx context.Context, input ConfigDe
 		c.SetInterface(config.DefaultGenerateSettings, input.Generate)
 	}
 
+	if input.MarkerEnd != nil {
+		for _, d := range input.MarkerEnd.TagDurations {
+			if d.Duration <= 0 {
+				return makeConfigDefaultsResult(), fmt.Errorf("duration of markers with tag %s must be greater than zero", d.TagID)
+			}
+		}
+		c.SetInterface(config.DefaultMarkerEndSettings, input.MarkerEnd)
+	}
+
 	r.setConfigBool(config.DeleteFileDefault, input.DeleteFile)
 	r.setConfigBool(config.DeleteGeneratedDefault, input.DeleteGenerated)
 
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+func (r *mutationResolver) UnlockContentGate(ctx context.Context, pin string) (*ContentGateStatus, error) {
+	if err := manager.GetInstance().UnlockContentGate(pin); err != nil {
+		if errors.Is(err, manager.ErrInvalidContentGatePIN) {
+			logger.Warn("Invalid content gate PIN entered")
+		}
+		return nil, err
+	}
+
+	return makeContentGateStatus(), nil
+}
+
+func (r *mutationResolver) LockContentGate(ctx context.Context) (*ContentGateStatus, error) {
+	manager.GetInstance().ContentGate.Lock()
+	return makeContentGateStatus(), nil
+}
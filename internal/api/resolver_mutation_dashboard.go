@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/dashboard"
+)
+
+// dashboardMutex serialises changes to the dashboard, so that concurrent
+// pins are not lost.
+var dashboardMutex sync.Mutex
+
+func (r *mutationResolver) ConfigureDashboard(ctx context.Context, input DashboardInput) (*Dashboard, error) {
+	d := dashboard.Dashboard{
+		SavedFilterIDs: input.SavedFilterIds,
+		Pins:           make([]dashboard.Pin, len(input.Pins)),
+		Widgets:        make([]dashboard.Widget, len(input.Widgets)),
+	}
+	for i, p := range input.Pins {
+		d.Pins[i] = *p
+	}
+	for i, w := range input.Widgets {
+		d.Widgets[i] = *w
+	}
+
+	dashboardMutex.Lock()
+	defer dashboardMutex.Unlock()
+
+	if err := r.setDashboard(d); err != nil {
+		return nil, err
+	}
+
+	return r.makeDashboard(ctx, d)
+}
+
+func (r *mutationResolver) DashboardPin(ctx context.Context, input dashboard.Pin, pinned bool) (*Dashboard, error) {
+	dashboardMutex.Lock()
+	defer dashboardMutex.Unlock()
+
+	d := config.GetInstance().GetDashboard()
+
+	if pinned {
+		if err := d.Pin(input); err != nil && !errors.Is(err, dashboard.ErrAlreadyPinned) {
+			return nil, err
+		}
+	} else {
+		d.Unpin(input)
+	}
+
+	if err := r.setDashboard(d); err != nil {
+		return nil, err
+	}
+
+	return r.makeDashboard(ctx, d)
+}
+
+func (r *mutationResolver) setDashboard(d dashboard.Dashboard) error {
+	c := config.GetInstance()
+	if err := c.SetDashboard(d); err != nil {
+		return err
+	}
+
+	return c.Write()
+}
@@ -16,7 +16,7 @@ func (r *mutationResolver) MoveFiles(ctx context.Context, input MoveFilesInput)
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		fileStore := r.repository.File
 		folderStore := r.repository.Folder
-		mover := file.NewMover(fileStore, folderStore)
+		mover := manager.GetInstance().NewFileMover(fileStore, folderStore)
 		mover.RegisterHooks(ctx)
 
 		var (
@@ -143,19 +143,19 @@ func (r *mutationResolver) validateFileExtensionList(exts []string, oldBasename,
 	return nil
 }
 
-func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string) (ret bool, err error) {
+func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string, confirmationToken *string) (ret bool, err error) {
 	fileIDs, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
-	fileDeleter := file.NewDeleter()
+	fileDeleter := manager.GetInstance().NewFileDeleter()
 	destroyer := &file.ZipDestroyer{
 		FileDestroyer:   r.repository.File,
 		FolderDestroyer: r.repository.Folder,
 	}
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter, true, confirmationToken, func(ctx context.Context) error {
 		qb := r.repository.File
 
 		for _, fileIDInt := range fileIDs {
@@ -208,6 +208,11 @@ func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string) (ret b
 	return true, nil
 }
 
+func (r *mutationResolver) DeleteExactDuplicateFiles(ctx context.Context, input manager.DeleteExactDuplicateFilesInput) (string, error) {
+	jobID := manager.GetInstance().DeleteExactDuplicateFiles(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) FileSetFingerprints(ctx context.Context, input FileSetFingerprintsInput) (bool, error) {
 	fileIDInt, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -281,6 +286,10 @@ func (r *mutationResolver) ScanVideoFileThreats(ctx context.Context, fileID stri
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) ScanFileForThreats(ctx context.Context, fileID string) (*manager.FileThreatScan, error) {
+	return manager.GetInstance().ScanFileForThreats(ctx, fileID)
+}
+
 func (r *mutationResolver) ScanAllScenesForThreats(ctx context.Context) (string, error) {
 	jobID, err := manager.GetInstance().ScanAllScenesForThreats(ctx)
 	if err != nil {
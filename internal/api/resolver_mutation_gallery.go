@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/hook"
@@ -301,6 +301,13 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Gallery
 
+		if !utils.IsTrue(input.IncludeLocked) {
+			galleryIDs, err = r.excludeLockedGalleries(ctx, galleryIDs)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, galleryID := range galleryIDs {
 			gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
 			if err != nil {
@@ -340,15 +347,17 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 	var galleries []*models.Gallery
 	var imgsDestroyed []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 
 	deleteGenerated := utils.IsTrue(input.DeleteGenerated)
 	deleteFile := utils.IsTrue(input.DeleteFile)
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter.Deleter, deleteFile, input.ConfirmationToken, func(ctx context.Context) error {
 		qb := r.repository.Gallery
+		// reset in case the transaction is run again after a dry run
+		galleries = nil
 
 		for _, id := range galleryIDs {
 			gallery, err := qb.Find(ctx, id)
@@ -360,6 +369,13 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 				return fmt.Errorf("gallery with id %d not found", id)
 			}
 
+			// locked galleries are only skipped when destroying several
+			// galleries at once
+			if gallery.Locked && len(galleryIDs) > 1 && !utils.IsTrue(input.IncludeLocked) {
+				logger.Infof("Skipping locked gallery %d", gallery.ID)
+				continue
+			}
+
 			if err := gallery.LoadFiles(ctx, qb); err != nil {
 				return fmt.Errorf("loading files for gallery %d", id)
 			}
@@ -512,6 +528,43 @@ func (r *mutationResolver) SetGalleryCover(ctx context.Context, input GallerySet
 	return true, nil
 }
 
+func (r *mutationResolver) ReorderGalleryImages(ctx context.Context, input ReorderGalleryImagesInput) (bool, error) {
+	galleryID, err := strconv.Atoi(input.GalleryID)
+	if err != nil {
+		return false, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	imageIDs, err := stringslice.StringSliceToIntSlice(input.ImageIds)
+	if err != nil {
+		return false, fmt.Errorf("converting image ids: %w", err)
+	}
+
+	insertPointID, err := strconv.Atoi(input.InsertAtID)
+	if err != nil {
+		return false, fmt.Errorf("converting insert at id: %w", err)
+	}
+
+	insertAfter := utils.IsTrue(input.InsertAfter)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+		gallery, err := qb.Find(ctx, galleryID)
+		if err != nil {
+			return err
+		}
+
+		if gallery == nil {
+			return fmt.Errorf("gallery with id %d not found", galleryID)
+		}
+
+		return r.galleryService.ReorderImages(ctx, gallery, imageIDs, insertPointID, insertAfter)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) ResetGalleryCover(ctx context.Context, input GalleryResetCoverInput) (bool, error) {
 	galleryID, err := strconv.Atoi(input.GalleryID)
 	if err != nil {
@@ -560,6 +613,7 @@ func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input Galle
 	newChapter.Title = input.Title
 	newChapter.ImageIndex = input.ImageIndex
 	newChapter.GalleryID = galleryID
+	newChapter.DisplayMode = input.DisplayMode
 
 	// Start the transaction and save the gallery chapter
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -597,6 +651,7 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 
 	updatedChapter.Title = translator.optionalString(input.Title, "title")
 	updatedChapter.ImageIndex = translator.optionalInt(input.ImageIndex, "image_index")
+	updatedChapter.DisplayMode = translator.optionalInt(input.DisplayMode, "display_mode")
 	updatedChapter.GalleryID, err = translator.optionalIntFromString(input.GalleryID, "gallery_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting gallery id: %w", err)
@@ -885,6 +940,10 @@ func (r *mutationResolver) GalleryIncrementPlay(ctx context.Context, id string)
 			return err
 		}
 
+		if manager.GetInstance().Config.IsViewHistoryPaused() {
+			return nil
+		}
+
 		_, err = qb.AddViews(ctx, galleryID, []time.Time{time.Now()})
 		if err != nil {
 			return err
@@ -921,7 +980,12 @@ func (r *mutationResolver) GalleryAddPlay(ctx context.Context, id string, times
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Gallery
 
-		updatedTimes, err = qb.AddViews(ctx, galleryID, timeTimes)
+		// only plays recorded with explicit times are kept while paused
+		if len(timeTimes) == 0 && manager.GetInstance().Config.IsViewHistoryPaused() {
+			updatedTimes, err = qb.GetViewDates(ctx, galleryID)
+		} else {
+			updatedTimes, err = qb.AddViews(ctx, galleryID, timeTimes)
+		}
 		if err != nil {
 			return err
 		}
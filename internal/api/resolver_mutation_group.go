@@ -2,14 +2,19 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/internal/static"
 	"github.com/stashapp/stash/pkg/group"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -411,3 +416,332 @@ func (r *mutationResolver) ReorderSubGroups(ctx context.Context, input ReorderSu
 
 	return true, nil
 }
+
+// groupSceneIndexes returns the scenes in the group and their scene indexes.
+func (r *mutationResolver) groupSceneIndexes(ctx context.Context, groupID int) ([]group.SceneIndex, error) {
+	scenes, err := scene.Query(ctx, r.repository.Scene, &models.SceneFilterType{
+		Groups: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(groupID)},
+			Modifier: models.CriterionModifierIncludes,
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("finding scenes of group %d: %w", groupID, err)
+	}
+
+	var ret []group.SceneIndex
+	for _, s := range scenes {
+		if err := s.LoadGroups(ctx, r.repository.Scene); err != nil {
+			return nil, err
+		}
+
+		for _, g := range s.Groups.List() {
+			if g.GroupID == groupID {
+				ret = append(ret, group.SceneIndex{
+					SceneID: s.ID,
+					Index:   g.SceneIndex,
+				})
+				break
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// addScenesToGroup adds the scenes to the group at the given scene indexes.
+func (r *mutationResolver) addScenesToGroup(ctx context.Context, groupID int, scenes []group.SceneIndex) error {
+	for _, s := range scenes {
+		partial := models.NewScenePartial()
+		partial.GroupIDs = &models.UpdateGroupIDs{
+			Groups: []models.GroupsScenes{{GroupID: groupID, SceneIndex: s.Index}},
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+
+		if _, err := r.repository.Scene.UpdatePartial(ctx, s.SceneID, partial); err != nil {
+			return fmt.Errorf("adding scene %d to group %d: %w", s.SceneID, groupID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) GroupsMerge(ctx context.Context, input GroupsMergeInput) (*models.Group, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Group
+
+		dest, err := qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+		if dest == nil {
+			return fmt.Errorf("group with id %d not found", destination)
+		}
+
+		destScenes, err := r.groupSceneIndexes(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		var (
+			sourceScenes [][]group.SceneIndex
+			tagIDs       []int
+			urls         []string
+			subGroups    []models.GroupIDDescription
+		)
+
+		for _, id := range source {
+			if id == destination {
+				return errors.New("cannot merge where source == destination")
+			}
+
+			src, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if src == nil {
+				return fmt.Errorf("group with id %d not found", id)
+			}
+
+			scenes, err := r.groupSceneIndexes(ctx, id)
+			if err != nil {
+				return err
+			}
+			sourceScenes = append(sourceScenes, scenes)
+
+			if err := src.LoadTagIDs(ctx, qb); err != nil {
+				return err
+			}
+			tagIDs = append(tagIDs, src.TagIDs.List()...)
+
+			if err := src.LoadURLs(ctx, qb); err != nil {
+				return err
+			}
+			urls = append(urls, src.URLs.List()...)
+
+			if err := src.LoadSubGroupIDs(ctx, qb); err != nil {
+				return err
+			}
+			for _, sg := range src.SubGroups.List() {
+				// the destination and the other sources cannot be sub-groups
+				if sg.GroupID != destination && !slices.Contains(source, sg.GroupID) {
+					subGroups = append(subGroups, sg)
+				}
+			}
+		}
+
+		if err := r.addScenesToGroup(ctx, destination, group.MergeSceneIndexes(destScenes, sourceScenes)); err != nil {
+			return err
+		}
+
+		partial := models.NewGroupPartial()
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		partial.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+		if len(subGroups) > 0 {
+			partial.SubGroups = &models.UpdateGroupDescriptions{
+				Groups: subGroups,
+				Mode:   models.RelationshipUpdateModeAdd,
+			}
+		}
+
+		// destroy the sources first so that their sub-groups can be moved
+		for _, id := range source {
+			if err := qb.Destroy(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		_, err = r.groupService.UpdatePartial(ctx, destination, partial, group.ImageInput{}, group.ImageInput{})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// for backwards compatibility - run both movie and group hooks
+	for _, id := range source {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.GroupDestroyPost, input, nil)
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.MovieDestroyPost, input, nil)
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, destination, hook.GroupUpdatePost, input, nil)
+	r.hookExecutor.ExecutePostHooks(ctx, destination, hook.MovieUpdatePost, input, nil)
+
+	return r.getGroup(ctx, destination)
+}
+
+func (r *mutationResolver) TagToGroup(ctx context.Context, input TagToGroupInput) (*models.Group, error) {
+	tagID, err := strconv.Atoi(input.TagID)
+	if err != nil {
+		return nil, fmt.Errorf("converting tag id: %w", err)
+	}
+
+	destroySource := utils.IsTrue(input.DestroySource)
+
+	var newGroup models.Group
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		tqb := r.repository.Tag
+
+		t, err := tqb.Find(ctx, tagID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("tag with id %d not found", tagID)
+		}
+
+		if err := t.LoadAliases(ctx, tqb); err != nil {
+			return err
+		}
+
+		image, err := tqb.GetImage(ctx, tagID)
+		if err != nil {
+			return err
+		}
+
+		newGroup = models.NewGroup()
+		newGroup.Name = t.Name
+		newGroup.Aliases = strings.Join(t.Aliases.List(), ", ")
+		newGroup.Synopsis = t.Description
+
+		if err := r.groupService.Create(ctx, &newGroup, image, nil); err != nil {
+			return err
+		}
+
+		scenes, err := scene.Query(ctx, r.repository.Scene, &models.SceneFilterType{
+			Tags: &models.HierarchicalMultiCriterionInput{
+				Value:    []string{input.TagID},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("finding scenes with tag %d: %w", tagID, err)
+		}
+
+		toAdd := make([]group.SceneIndex, len(scenes))
+		for i, s := range scenes {
+			toAdd[i] = group.SceneIndex{SceneID: s.ID}
+		}
+		if err := r.addScenesToGroup(ctx, newGroup.ID, toAdd); err != nil {
+			return err
+		}
+
+		if destroySource {
+			return tqb.Destroy(ctx, tagID)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// for backwards compatibility - run both movie and group hooks
+	r.hookExecutor.ExecutePostHooks(ctx, newGroup.ID, hook.GroupCreatePost, input, nil)
+	r.hookExecutor.ExecutePostHooks(ctx, newGroup.ID, hook.MovieCreatePost, input, nil)
+	if destroySource {
+		r.hookExecutor.ExecutePostHooks(ctx, tagID, hook.TagDestroyPost, input, nil)
+	}
+
+	return r.getGroup(ctx, newGroup.ID)
+}
+
+func (r *mutationResolver) GroupToTag(ctx context.Context, input GroupToTagInput) (*models.Tag, error) {
+	groupID, err := strconv.Atoi(input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("converting group id: %w", err)
+	}
+
+	destroySource := utils.IsTrue(input.DestroySource)
+
+	var (
+		t       *models.Tag
+		created bool
+	)
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		tqb := r.repository.Tag
+
+		g, err := r.repository.Group.Find(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if g == nil {
+			return fmt.Errorf("group with id %d not found", groupID)
+		}
+
+		// use the existing tag with the name of the group, if any
+		t, err = tqb.FindByName(ctx, g.Name, true)
+		if err != nil {
+			return err
+		}
+
+		if t == nil {
+			newTag := models.NewTag()
+			newTag.Name = g.Name
+			newTag.Description = g.Synopsis
+
+			if err := tag.ValidateCreate(ctx, newTag, tqb); err != nil {
+				return err
+			}
+			if err := tqb.Create(ctx, &newTag); err != nil {
+				return err
+			}
+
+			t = &newTag
+			created = true
+		}
+
+		scenes, err := r.groupSceneIndexes(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			partial := models.NewScenePartial()
+			partial.TagIDs = &models.UpdateIDs{
+				IDs:  []int{t.ID},
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+
+			if _, err := r.repository.Scene.UpdatePartial(ctx, s.SceneID, partial); err != nil {
+				return fmt.Errorf("adding tag %d to scene %d: %w", t.ID, s.SceneID, err)
+			}
+		}
+
+		if destroySource {
+			return r.repository.Group.Destroy(ctx, groupID)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if created {
+		r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagCreatePost, input, nil)
+	}
+	if destroySource {
+		// for backwards compatibility - run both movie and group hooks
+		r.hookExecutor.ExecutePostHooks(ctx, groupID, hook.GroupDestroyPost, input, nil)
+		r.hookExecutor.ExecutePostHooks(ctx, groupID, hook.MovieDestroyPost, input, nil)
+	}
+
+	return t, nil
+}
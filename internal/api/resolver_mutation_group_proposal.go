@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) DetectGroups(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().DetectGroups(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ApplyGroupProposals(ctx context.Context, input ApplyGroupProposalsInput) (string, error) {
+	var ids []int
+	if input.Ids != nil {
+		var err error
+		ids, err = stringslice.StringSliceToIntSlice(input.Ids)
+		if err != nil {
+			return "", fmt.Errorf("converting proposal ids: %w", err)
+		}
+	}
+
+	jobID, err := manager.GetInstance().ApplyGroupProposals(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
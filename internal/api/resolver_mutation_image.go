@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -310,10 +309,10 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 
 	var i *models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter.Deleter, utils.IsTrue(input.DeleteFile), input.ConfirmationToken, func(ctx context.Context) error {
 		i, err = r.repository.Image.Find(ctx, imageID)
 		if err != nil {
 			return err
@@ -350,11 +349,13 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 
 	var images []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter.Deleter, utils.IsTrue(input.DeleteFile), input.ConfirmationToken, func(ctx context.Context) error {
 		qb := r.repository.Image
+		// reset in case the transaction is run again after a dry run
+		images = nil
 
 		for _, imageID := range imageIDs {
 			i, err := qb.Find(ctx, imageID)
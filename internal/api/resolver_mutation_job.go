@@ -22,3 +22,17 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) RetryFailedJobItems(ctx context.Context, jobID string) (string, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	newID, err := manager.GetInstance().JobManager.RetryFailed(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(newID), nil
+}
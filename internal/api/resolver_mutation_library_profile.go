@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *mutationResolver) SaveLibraryProfile(ctx context.Context, input config.LibraryProfileInput) (*config.LibraryProfile, error) {
+	return manager.GetInstance().SaveLibraryProfile(input)
+}
+
+func (r *mutationResolver) DeleteLibraryProfile(ctx context.Context, name string) (bool, error) {
+	if err := manager.GetInstance().DeleteLibraryProfile(name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SwitchLibraryProfile(ctx context.Context, name string) (bool, error) {
+	if err := manager.GetInstance().SwitchLibraryProfile(ctx, name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) LockItems(ctx context.Context, input LockItemsInput) (bool, error) {
+	return r.setLocked(ctx, input, true)
+}
+
+func (r *mutationResolver) UnlockItems(ctx context.Context, input LockItemsInput) (bool, error) {
+	return r.setLocked(ctx, input, false)
+}
+
+func (r *mutationResolver) setLocked(ctx context.Context, input LockItemsInput, locked bool) (bool, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIds)
+	if err != nil {
+		return false, fmt.Errorf("converting scene ids: %w", err)
+	}
+	performerIDs, err := stringslice.StringSliceToIntSlice(input.PerformerIds)
+	if err != nil {
+		return false, fmt.Errorf("converting performer ids: %w", err)
+	}
+	galleryIDs, err := stringslice.StringSliceToIntSlice(input.GalleryIds)
+	if err != nil {
+		return false, fmt.Errorf("converting gallery ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		scenePartial := models.NewScenePartial()
+		scenePartial.Locked = models.NewOptionalBool(locked)
+		for _, id := range sceneIDs {
+			if _, err := r.repository.Scene.UpdatePartial(ctx, id, scenePartial); err != nil {
+				return fmt.Errorf("updating scene %d: %w", id, err)
+			}
+		}
+
+		performerPartial := models.NewPerformerPartial()
+		performerPartial.Locked = models.NewOptionalBool(locked)
+		for _, id := range performerIDs {
+			if _, err := r.repository.Performer.UpdatePartial(ctx, id, performerPartial); err != nil {
+				return fmt.Errorf("updating performer %d: %w", id, err)
+			}
+		}
+
+		galleryPartial := models.NewGalleryPartial()
+		galleryPartial.Locked = models.NewOptionalBool(locked)
+		for _, id := range galleryIDs {
+			if _, err := r.repository.Gallery.UpdatePartial(ctx, id, galleryPartial); err != nil {
+				return fmt.Errorf("updating gallery %d: %w", id, err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// excludeLockedScenes returns the ids of the scenes that are not locked, in
+// the same order. Must be called within a transaction.
+func (r *mutationResolver) excludeLockedScenes(ctx context.Context, ids []int) ([]int, error) {
+	scenes, err := r.repository.Scene.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[int]bool)
+	for _, s := range scenes {
+		if s.Locked {
+			logger.Infof("Skipping locked scene %d", s.ID)
+			locked[s.ID] = true
+		}
+	}
+
+	var ret []int
+	for _, id := range ids {
+		if !locked[id] {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret, nil
+}
+
+// excludeLockedPerformers returns the ids of the performers that are not
+// locked, in the same order. Must be called within a transaction.
+func (r *mutationResolver) excludeLockedPerformers(ctx context.Context, ids []int) ([]int, error) {
+	performers, err := r.repository.Performer.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, p := range performers {
+		if p.Locked {
+			logger.Infof("Skipping locked performer %d", p.ID)
+			continue
+		}
+		ret = append(ret, p.ID)
+	}
+
+	return ret, nil
+}
+
+// excludeLockedGalleries returns the ids of the galleries that are not
+// locked, in the same order. Must be called within a transaction.
+func (r *mutationResolver) excludeLockedGalleries(ctx context.Context, ids []int) ([]int, error) {
+	galleries, err := r.repository.Gallery.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, g := range galleries {
+		if g.Locked {
+			logger.Infof("Skipping locked gallery %d", g.ID)
+			continue
+		}
+		ret = append(ret, g.ID)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) MetadataSyncMediaServers(ctx context.Context) (string, error) {
+	mgr := manager.GetInstance()
+	if len(mgr.Config.GetMediaServers()) == 0 {
+		return "", errors.New("no media servers are configured")
+	}
+
+	jobID, ok := mgr.SyncMediaServers(ctx)
+	if !ok {
+		return "", errors.New("a sync with the media servers is already running")
+	}
+
+	return strconv.Itoa(jobID), nil
+}
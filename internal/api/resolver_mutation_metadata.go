@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func (r *mutationResolver) MetadataScan(ctx context.Context, input manager.ScanMetadataInput) (string, error) {
@@ -94,11 +95,30 @@ func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataOCR(ctx context.Context, input manager.OCRMetadataInput) (string, error) {
+	jobID, err := manager.GetInstance().OCR(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.CleanMetadataInput) (string, error) {
 	jobID := manager.GetInstance().Clean(ctx, input)
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataRelinkFiles(ctx context.Context, input manager.RelinkFilesInput) (string, error) {
+	jobID := manager.GetInstance().RelinkFiles(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataNormalizeFilenames(ctx context.Context, input manager.NormalizeFilenamesInput) (string, error) {
+	jobID := manager.GetInstance().NormalizeFilenames(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) (string, error) {
 	mgr := manager.GetInstance()
 	t := &task.CleanGeneratedJob{
@@ -114,6 +134,19 @@ func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input tas
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataCleanBlobs(ctx context.Context, input task.CleanBlobsOptions) (string, error) {
+	mgr := manager.GetInstance()
+	t := &task.CleanBlobsJob{
+		TxnManager: mgr.Database,
+		BlobStore:  mgr.Database.Blobs,
+		Vacuumer:   mgr.Database,
+		Options:    input,
+	}
+	jobID := mgr.JobManager.Add(ctx, "Cleaning unused blobs...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MigrateHashNaming(ctx context.Context) (string, error) {
 	jobID := manager.GetInstance().MigrateHash(ctx)
 	return strconv.Itoa(jobID), nil
@@ -181,3 +214,18 @@ func (r *mutationResolver) OptimiseDatabase(ctx context.Context) (string, error)
 	jobID := manager.GetInstance().OptimiseDatabase(ctx)
 	return strconv.Itoa(jobID), nil
 }
+
+func (r *mutationResolver) RebuildPhashIndex(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().RebuildPhashIndex(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataCheckConsistency(ctx context.Context, input *ConsistencyCheckInput) (string, error) {
+	var fix []models.ConsistencyCategory
+	if input != nil {
+		fix = input.Fix
+	}
+
+	jobID := manager.GetInstance().CheckConsistency(ctx, fix)
+	return strconv.Itoa(jobID), nil
+}
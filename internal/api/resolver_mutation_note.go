@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func (r *mutationResolver) NoteCreate(ctx context.Context, input NoteCreateInput) (*models.Note, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	var err error
+	newNote := models.NewNote()
+	newNote.Content = input.Content
+	newNote.Author = session.GetCurrentUserID(ctx)
+
+	newNote.SceneID, err = translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	newNote.PerformerID, err = translator.intPtrFromString(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+	newNote.StudioID, err = translator.intPtrFromString(input.StudioID)
+	if err != nil {
+		return nil, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	if err := newNote.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := r.noteObjectExists(ctx, newNote); err != nil {
+			return err
+		}
+
+		return r.repository.Note.Create(ctx, &newNote)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newNote, nil
+}
+
+// noteObjectExists returns an error if the object of the note does not exist.
+func (r *mutationResolver) noteObjectExists(ctx context.Context, n models.Note) error {
+	switch {
+	case n.SceneID != nil:
+		s, err := r.repository.Scene.Find(ctx, *n.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", *n.SceneID)
+		}
+	case n.PerformerID != nil:
+		p, err := r.repository.Performer.Find(ctx, *n.PerformerID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", *n.PerformerID)
+		}
+	case n.StudioID != nil:
+		s, err := r.repository.Studio.Find(ctx, *n.StudioID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("studio with id %d not found", *n.StudioID)
+		}
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) NoteUpdate(ctx context.Context, input NoteUpdateInput) (ret *models.Note, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Note
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ret == nil {
+			return fmt.Errorf("note with id %d not found", id)
+		}
+
+		ret.Content = input.Content
+		ret.UpdatedAt = time.Now()
+
+		if err := ret.Validate(); err != nil {
+			return err
+		}
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) NoteDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.Note.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
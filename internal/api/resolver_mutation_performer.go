@@ -2,8 +2,10 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
@@ -438,6 +440,13 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 
+		if !utils.IsTrue(input.IncludeLocked) {
+			performerIDs, err = r.excludeLockedPerformers(ctx, performerIDs)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, performerID := range performerIDs {
 			if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
 				if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
@@ -495,7 +504,7 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input Performer
 	return true, nil
 }
 
-func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs []string) (bool, error) {
+func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs []string, includeLocked *bool) (bool, error) {
 	ids, err := stringslice.StringSliceToIntSlice(performerIDs)
 	if err != nil {
 		return false, fmt.Errorf("converting ids: %w", err)
@@ -503,6 +512,14 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
+
+		if !utils.IsTrue(includeLocked) {
+			ids, err = r.excludeLockedPerformers(ctx, ids)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, id := range ids {
 			if err := qb.Destroy(ctx, id); err != nil {
 				return err
@@ -520,3 +537,77 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) PerformerLinkAlias(ctx context.Context, input PerformerLinkAliasInput) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	alias := strings.TrimSpace(input.Alias)
+	if alias == "" {
+		return nil, errors.New("alias must not be empty")
+	}
+
+	updatedPerformer := models.NewPerformerPartial()
+	updatedPerformer.Aliases = &models.UpdateStrings{
+		Values: []string{alias},
+		Mode:   models.RelationshipUpdateModeAdd,
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
+			return err
+		}
+
+		_, err := qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, performerID, hook.PerformerUpdatePost, input, []string{"aliases"})
+	return r.getPerformer(ctx, performerID)
+}
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, input PerformersMergeInput) (*models.Performer, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		p, err := qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", destination)
+		}
+
+		return qb.Merge(ctx, source, destination)
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range source {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.PerformerDestroyPost, input, nil)
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, destination, hook.PerformerUpdatePost, input, nil)
+
+	return r.getPerformer(ctx, destination)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) PlanReencode(ctx context.Context, input PlanReencodeInput) (string, error) {
+	target := ffmpeg.ReencodeTarget{
+		Codec: input.Target.Codec,
+		CRF:   input.Target.Codec.DefaultCRF(),
+	}
+	if input.Target.Crf != nil {
+		target.CRF = *input.Target.Crf
+	}
+	if input.Target.MaxHeight != nil {
+		target.MaxHeight = *input.Target.MaxHeight
+	}
+
+	samples := 0
+	if input.Samples != nil {
+		samples = *input.Samples
+	}
+
+	jobID, err := manager.GetInstance().PlanReencode(ctx, target, input.SceneFilter, samples)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ApplyReencodePlan(ctx context.Context, input ApplyReencodePlanInput) (string, error) {
+	var ids []int
+	if input.Ids != nil {
+		var err error
+		ids, err = stringslice.StringSliceToIntSlice(input.Ids)
+		if err != nil {
+			return "", fmt.Errorf("converting estimate ids: %w", err)
+		}
+	}
+
+	jobID, err := manager.GetInstance().ApplyReencodePlan(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/retention"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) RunRetentionReport(ctx context.Context, includeLocked *bool) (*retention.Report, error) {
+	return manager.GetInstance().RunRetentionReport(ctx, includeLocked != nil && *includeLocked)
+}
+
+func (r *mutationResolver) ApplyRetention(ctx context.Context, input ApplyRetentionInput) (string, error) {
+	if !input.Confirm {
+		return "", errors.New("applying retention deletes or moves files and must be confirmed")
+	}
+
+	var sceneIDs []int
+	if input.SceneIds != nil {
+		var err error
+		sceneIDs, err = stringslice.StringSliceToIntSlice(input.SceneIds)
+		if err != nil {
+			return "", fmt.Errorf("converting scene ids: %w", err)
+		}
+	}
+
+	jobID, err := manager.GetInstance().ApplyRetention(ctx, sceneIDs)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"strconv"
@@ -12,7 +13,6 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/ffmpeg"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -163,7 +163,7 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 	}
 
 	// Start the transaction and save the scene
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withRetryTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.sceneUpdate(ctx, input, translator)
 		return err
 	}); err != nil {
@@ -178,7 +178,10 @@ func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.Sce
 	inputMaps := getUpdateInputMaps(ctx)
 
 	// Start the transaction and save the scenes
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withRetryTxn(ctx, func(ctx context.Context) error {
+		// discard the scenes of a previous attempt
+		ret = nil
+
 		for i, scene := range input {
 			translator := changesetTranslator{
 				inputMap: inputMaps[i],
@@ -243,6 +246,8 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	// Video filters and transforms
 	updatedScene.VideoFilters = input.VideoFilters
 	updatedScene.VideoTransforms = input.VideoTransforms
+	updatedScene.TranscodeArgs = input.TranscodeArgs
+	updatedScene.Deinterlace = translator.optionalBool(input.Deinterlace, "deinterlace")
 	updatedScene.IsBroken = translator.optionalBool(input.IsBroken, "is_broken")
 	updatedScene.IsNotBroken = translator.optionalBool(input.IsNotBroken, "is_not_broken")
 	updatedScene.AudioOffsetMs = translator.optionalInt(input.AudioOffsetMs, "audio_offset_ms")
@@ -513,6 +518,13 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Scene
 
+		if !utils.IsTrue(input.IncludeLocked) {
+			sceneIDs, err = r.excludeLockedScenes(ctx, sceneIDs)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, sceneID := range sceneIDs {
 			scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
 			if err != nil {
@@ -553,7 +565,7 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 
 	var s *models.Scene
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -561,7 +573,7 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 	deleteGenerated := utils.IsTrue(input.DeleteGenerated)
 	deleteFile := utils.IsTrue(input.DeleteFile)
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter.Deleter, deleteFile, input.ConfirmationToken, func(ctx context.Context) error {
 		qb := r.repository.Scene
 		var err error
 		s, err = qb.Find(ctx, sceneID)
@@ -573,8 +585,11 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 			return fmt.Errorf("scene with id %d not found", sceneID)
 		}
 
-		// kill any running encoders
-		manager.KillRunningStreams(s, fileNamingAlgo)
+		// kill any running encoders, unless only checking which files
+		// would be deleted
+		if !fileDeleter.DryRun {
+			manager.KillRunningStreams(s, fileNamingAlgo)
+		}
 
 		return r.sceneService.Destroy(ctx, s, fileDeleter, deleteGenerated, deleteFile)
 	}); err != nil {
@@ -606,7 +621,7 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -614,8 +629,10 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 	deleteGenerated := utils.IsTrue(input.DeleteGenerated)
 	deleteFile := utils.IsTrue(input.DeleteFile)
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withDeleteConfirmation(ctx, fileDeleter.Deleter, deleteFile, input.ConfirmationToken, func(ctx context.Context) error {
 		qb := r.repository.Scene
+		// reset in case the transaction is run again after a dry run
+		scenes = nil
 
 		for _, id := range sceneIDs {
 			scene, err := qb.Find(ctx, id)
@@ -626,10 +643,18 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 				return fmt.Errorf("scene with id %d not found", id)
 			}
 
+			if scene.Locked && !utils.IsTrue(input.IncludeLocked) {
+				logger.Infof("Skipping locked scene %d", scene.ID)
+				continue
+			}
+
 			scenes = append(scenes, scene)
 
-			// kill any running encoders
-			manager.KillRunningStreams(scene, fileNamingAlgo)
+			// kill any running encoders, unless only checking which files
+			// would be deleted
+			if !fileDeleter.DryRun {
+				manager.KillRunningStreams(scene, fileNamingAlgo)
+			}
 
 			if err := r.sceneService.Destroy(ctx, scene, fileDeleter, deleteGenerated, deleteFile); err != nil {
 				return err
@@ -678,6 +703,70 @@ func (r *mutationResolver) SceneAssignFile(ctx context.Context, input AssignScen
 	return true, nil
 }
 
+func (r *mutationResolver) SceneSetExtra(ctx context.Context, input SceneSetExtraInput) (bool, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	fileID, err := strconv.Atoi(input.FileID)
+	if err != nil {
+		return false, fmt.Errorf("converting file id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.Resolver.sceneService.SetExtra(ctx, sceneID, models.FileID(fileID), input.Role)
+	}); err != nil {
+		return false, fmt.Errorf("setting scene extra: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SceneRemoveExtra(ctx context.Context, fileID string) (bool, error) {
+	fileIDInt, err := strconv.Atoi(fileID)
+	if err != nil {
+		return false, fmt.Errorf("converting file id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.Resolver.sceneService.RemoveExtra(ctx, models.FileID(fileIDInt))
+	}); err != nil {
+		return false, fmt.Errorf("removing scene extra: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SceneRelinkFile(ctx context.Context, input AssignSceneFileInput) (bool, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	fileID, err := strconv.Atoi(input.FileID)
+	if err != nil {
+		return false, fmt.Errorf("converting file id: %w", err)
+	}
+
+	mgr := manager.GetInstance()
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        manager.GetInstance().NewFileDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		// delete the generated files of a removed scene on commit
+		fileDeleter.RegisterHooks(ctx)
+		return r.Resolver.sceneService.Relink(ctx, sceneID, models.FileID(fileID), fileDeleter)
+	}); err != nil {
+		return false, fmt.Errorf("relinking file to scene: %w", err)
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput) (*models.Scene, error) {
 	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
 	if err != nil {
@@ -716,7 +805,7 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 
 	mgr := manager.GetInstance()
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
 		Paths:          mgr.Paths,
 	}
@@ -727,6 +816,7 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 			ScenePartial:       *values,
 			IncludePlayHistory: utils.IsTrue(input.PlayHistory),
 			IncludeOHistory:    utils.IsTrue(input.OHistory),
+			IncludeOMGHistory:  utils.IsTrue(input.OmgHistory),
 		}); err != nil {
 			return err
 		}
@@ -782,6 +872,13 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 			return nil, err
 		}
 		newMarker.EndSeconds = input.EndSeconds
+	} else {
+		// finding scene changes runs ffmpeg, so this is done outside of the
+		// write transaction
+		newMarker.EndSeconds, err = r.sceneMarkerDefaultEnd(ctx, &newMarker)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tagIDs, err := stringslice.StringSliceToIntSlice(input.TagIds)
@@ -809,6 +906,55 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 	return r.getSceneMarker(ctx, newMarker.ID)
 }
 
+// sceneMarkerDefaultEnd returns the end of the new marker m, which was
+// created without an end, using the default marker end settings. Returns nil
+// if the marker should be left without an end.
+func (r *mutationResolver) sceneMarkerDefaultEnd(ctx context.Context, m *models.SceneMarker) (*float64, error) {
+	mgr := manager.GetInstance()
+	settings := mgr.Config.GetDefaultMarkerEndSettings()
+	if settings == nil {
+		return nil, nil
+	}
+
+	var (
+		others []*models.SceneMarker
+		f      *models.VideoFile
+	)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.repository.Scene.Find(ctx, m.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", m.SceneID)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+			return err
+		}
+		f = s.Files.Primary()
+
+		others, err = r.repository.SceneMarker.FindBySceneID(ctx, m.SceneID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var finder scene.SceneChangeFinder
+	if mgr.FFMpeg != nil {
+		finder = mgr.FFMpeg
+	}
+
+	end, err := scene.MarkerEnd(ctx, *settings, m, others, f, finder)
+	if err != nil {
+		// the marker is still usable without an end
+		logger.Warnf("setting end of marker at %f in scene %d: %v", m.Seconds, m.SceneID, err)
+		return nil, nil
+	}
+
+	return end, nil
+}
+
 func validateSceneMarkerEndSeconds(seconds, endSeconds float64) error {
 	if endSeconds < seconds {
 		return fmt.Errorf("end_seconds (%f) must be greater than or equal to seconds (%f)", endSeconds, seconds)
@@ -860,7 +1006,7 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	mgr := manager.GetInstance()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
 		Paths:          mgr.Paths,
 	}
@@ -956,7 +1102,7 @@ func (r *mutationResolver) SceneMarkersDestroy(ctx context.Context, markerIDs []
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -1014,7 +1160,7 @@ func (r *mutationResolver) SceneSaveActivity(ctx context.Context, id string, res
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withRetryTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Scene
 
 		ret, err = qb.SaveActivity(ctx, sceneID, resumeTime, playDuration)
@@ -1032,7 +1178,7 @@ func (r *mutationResolver) SceneResetActivity(ctx context.Context, id string, re
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
+	if err := r.withRetryTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Scene
 
 		ret, err = qb.ResetActivity(ctx, sceneID, utils.IsTrue(resetResume), utils.IsTrue(resetDuration))
@@ -1056,6 +1202,11 @@ func (r *mutationResolver) SceneIncrementPlayCount(ctx context.Context, id strin
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Scene
 
+		if manager.GetInstance().Config.IsViewHistoryPaused() {
+			updatedTimes, err = qb.GetViewDates(ctx, sceneID)
+			return err
+		}
+
 		updatedTimes, err = qb.AddViews(ctx, sceneID, nil)
 		return err
 	}); err != nil {
@@ -1083,6 +1234,12 @@ func (r *mutationResolver) SceneAddPlay(ctx context.Context, id string, t []*tim
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Scene
 
+		// only plays recorded with explicit times are kept while paused
+		if len(times) == 0 && manager.GetInstance().Config.IsViewHistoryPaused() {
+			updatedTimes, err = qb.GetViewDates(ctx, sceneID)
+			return err
+		}
+
 		updatedTimes, err = qb.AddViews(ctx, sceneID, times)
 		return err
 	}); err != nil {
@@ -1384,6 +1541,11 @@ func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id strin
 	return "todo", nil
 }
 
+func (r *mutationResolver) SceneRerollCover(ctx context.Context, id string) (string, error) {
+	jobID := manager.GetInstance().RerollCover(ctx, id)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SceneSaveFilteredScreenshot(ctx context.Context, input SceneSaveFilteredScreenshotInput) (bool, error) {
 	if strings.TrimSpace(input.Image) == "" {
 		return false, errors.New("image payload is required")
@@ -1396,6 +1558,24 @@ func (r *mutationResolver) SceneSaveFilteredScreenshot(ctx context.Context, inpu
 	return true, nil
 }
 
+func (r *mutationResolver) CaptureGalleryFromScene(ctx context.Context, input CaptureGalleryFromSceneInput) (string, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return "", fmt.Errorf("converting scene id: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().CaptureGalleryFromScene(ctx, sceneID, manager.CaptureGalleryOptions{
+		Timestamps: input.Timestamps,
+		Interval:   input.Interval,
+		Title:      input.Title,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) RecalculateSceneSimilarities(ctx context.Context, sceneID *string) (string, error) {
 	var sceneIDInt *int
 	if sceneID != nil {
@@ -1440,6 +1620,10 @@ func (r *mutationResolver) SceneConvertToMp4(ctx context.Context, id string) (st
 		return "", fmt.Errorf("loading scene and files: %w", err)
 	}
 
+	if err := validateSceneFilesWritable(scene); err != nil {
+		return "", err
+	}
+
 	// Создаем задачу конвертации
 	fileNamingAlgorithm := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 	g := &generate.Generator{
@@ -1500,6 +1684,10 @@ func (r *mutationResolver) SceneConvertHLSToMp4(ctx context.Context, id string)
 		return "", fmt.Errorf("loading scene and files: %w", err)
 	}
 
+	if err := validateSceneFilesWritable(scene); err != nil {
+		return "", err
+	}
+
 	// Check if it's actually an HLS video
 	pf := scene.Files.Primary()
 	if pf == nil {
@@ -1605,6 +1793,10 @@ func (r *mutationResolver) SceneReduceResolution(ctx context.Context, input mode
 		return "", fmt.Errorf("file with id %d not found in scene %d", fileID, sceneID)
 	}
 
+	if err := manager.GetInstance().ValidateWritable(targetFile.Path); err != nil {
+		return "", err
+	}
+
 	// Verify that target resolution is smaller than current
 	if targetFile.Width <= input.TargetWidth && targetFile.Height <= input.TargetHeight {
 		return "", fmt.Errorf("target resolution %dx%d is not smaller than current resolution %dx%d",
@@ -1650,14 +1842,202 @@ func (r *mutationResolver) SceneReduceResolution(ctx context.Context, input mode
 }
 
 func (r *mutationResolver) SceneTrimVideo(ctx context.Context, input models.TrimVideoInput) (string, error) {
+	scene, targetFile, err := r.findSceneVideoFile(ctx, input.SceneID, input.FileID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := manager.GetInstance().ValidateWritable(targetFile.Path); err != nil {
+		return "", err
+	}
+
+	if err := validateTrimTimes(input, targetFile.Duration); err != nil {
+		return "", err
+	}
+
+	// Create video trimming task
+	task := r.newTrimVideoTask(scene, targetFile)
+	task.StartTime, task.EndTime = trimTimes(input)
+
+	// Start the task in separate thread via JobManager
+	jobExec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return task.Execute(ctx, progress)
+	})
+	jobID := manager.GetInstance().JobManager.Start(ctx, task.GetDescription(), jobExec)
+
+	return strconv.Itoa(jobID), nil
+}
+
+// validateTrimTimes returns an error if the trim times of the input are not
+// within a video of the duration.
+func validateTrimTimes(input models.TrimVideoInput, duration float64) error {
+	// At least one time must be set (greater than 0)
+	if input.StartTime <= 0 && input.EndTime <= 0 {
+		return fmt.Errorf("at least one trim time must be set")
+	}
+
+	// Validate start time if set
+	if input.StartTime > 0 {
+		if input.StartTime >= duration {
+			return fmt.Errorf("start time %.2f cannot be greater than or equal to video duration %.2f", input.StartTime, duration)
+		}
+	}
+
+	// Validate end time if set
+	if input.EndTime > 0 {
+		if input.EndTime > duration {
+			return fmt.Errorf("end time %.2f cannot be greater than video duration %.2f", input.EndTime, duration)
+		}
+	}
+
+	// If both are set, validate relationship
+	if input.StartTime > 0 && input.EndTime > 0 {
+		if input.EndTime <= input.StartTime {
+			return fmt.Errorf("end time %.2f must be greater than start time %.2f", input.EndTime, input.StartTime)
+		}
+	}
+
+	return nil
+}
+
+// trimTimes returns the trim times of the input, converting 0 values to nil
+// for proper handling.
+func trimTimes(input models.TrimVideoInput) (start, end *float64) {
+	if input.StartTime > 0 {
+		start = &input.StartTime
+	}
+	if input.EndTime > 0 {
+		end = &input.EndTime
+	}
+	return start, end
+}
+
+func (r *mutationResolver) SceneTransformVideo(ctx context.Context, input models.TransformVideoInput) (string, error) {
+	scene, targetFile, err := r.findSceneVideoFile(ctx, input.SceneID, input.FileID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := manager.GetInstance().ValidateWritable(targetFile.Path); err != nil {
+		return "", err
+	}
+
+	transform := ffmpeg.VideoTransform{
+		FlipHorizontal: input.FlipHorizontal != nil && *input.FlipHorizontal,
+		FlipVertical:   input.FlipVertical != nil && *input.FlipVertical,
+	}
+	if input.Rotate != nil {
+		transform.Rotate = *input.Rotate
+	}
+	if input.Crop != nil {
+		transform.Crop = &ffmpeg.VideoCrop{
+			X:      input.Crop.X,
+			Y:      input.Crop.Y,
+			Width:  input.Crop.Width,
+			Height: input.Crop.Height,
+		}
+	}
+
+	if err := transform.Validate(targetFile.Width, targetFile.Height); err != nil {
+		return "", err
+	}
+
+	task := &manager.TransformVideoTask{
+		TrimVideoTask: *r.newTrimVideoTask(scene, targetFile),
+		Transform:     transform,
+	}
+
+	jobExec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return task.Execute(ctx, progress)
+	})
+	jobID := manager.GetInstance().JobManager.Start(ctx, task.GetDescription(), jobExec)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) SceneRemediateVFR(ctx context.Context, sceneID string, fileID string) (string, error) {
+	scene, targetFile, err := r.findSceneVideoFile(ctx, sceneID, fileID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := manager.GetInstance().ValidateWritable(targetFile.Path); err != nil {
+		return "", err
+	}
+
+	task := &manager.RemediateVFRTask{
+		TrimVideoTask: *r.newTrimVideoTask(scene, targetFile),
+	}
+
+	issues, err := task.Detect()
+	if err != nil {
+		return "", err
+	}
+	if !issues.Any() {
+		return "", manager.ErrNoTimingIssues
+	}
+
+	jobExec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return task.Execute(ctx, progress)
+	})
+	jobID := manager.GetInstance().JobManager.Start(ctx, task.GetDescription(), jobExec)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) SceneDownloadWithSubtitles(ctx context.Context, input SceneDownloadWithSubtitlesInput) (*SubtitledDownload, error) {
 	sceneID, err := strconv.Atoi(input.SceneID)
 	if err != nil {
-		return "", fmt.Errorf("converting scene id: %w", err)
+		return nil, fmt.Errorf("converting scene id: %w", err)
 	}
 
-	fileID, err := strconv.Atoi(input.FileID)
+	captions := make([]manager.CaptionSelection, len(input.Captions))
+	for i, c := range input.Captions {
+		captions[i] = *c
+	}
+
+	mode := ffmpeg.SubtitleModeSoft
+	if input.Mode != nil {
+		mode = *input.Mode
+	}
+
+	d, err := manager.GetInstance().DownloadWithSubtitles(ctx, sceneID, captions, mode)
 	if err != nil {
-		return "", fmt.Errorf("converting file id: %w", err)
+		return nil, err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+
+	return &SubtitledDownload{
+		JobID: strconv.Itoa(d.JobID),
+		URL:   baseURL + "/downloads/" + d.DownloadHash + "/" + url.PathEscape(d.Filename),
+	}, nil
+}
+
+// validateSceneFilesWritable returns an error if any file of the scene is in a
+// read-only stash path.
+func validateSceneFilesWritable(scene *models.Scene) error {
+	mgr := manager.GetInstance()
+	for _, f := range scene.Files.List() {
+		if err := mgr.ValidateWritable(f.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findSceneVideoFile returns the scene with its files loaded, and the video
+// file of the scene with the given id.
+func (r *Resolver) findSceneVideoFile(ctx context.Context, sceneIDStr string, fileIDStr string) (*models.Scene, *models.VideoFile, error) {
+	sceneID, err := strconv.Atoi(sceneIDStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	fileID, err := strconv.Atoi(fileIDStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("converting file id: %w", err)
 	}
 
 	// Get scene and load files in one transaction
@@ -1676,97 +2056,90 @@ func (r *mutationResolver) SceneTrimVideo(ctx context.Context, input models.Trim
 		// Load scene files within transaction
 		return scene.LoadFiles(ctx, r.repository.Scene)
 	}); err != nil {
-		return "", fmt.Errorf("loading scene and files: %w", err)
+		return nil, nil, fmt.Errorf("loading scene and files: %w", err)
 	}
 
 	// Verify that file belongs to scene
-	var targetFile *models.VideoFile
 	for _, sceneFile := range scene.Files.List() {
 		vf, err := convertVideoFile(sceneFile)
 		if err == nil && int(vf.ID) == fileID {
-			targetFile = vf
-			break
+			return scene, vf, nil
 		}
 	}
 
-	if targetFile == nil {
-		return "", fmt.Errorf("file with id %d not found in scene %d", fileID, sceneID)
+	return nil, nil, fmt.Errorf("file with id %d not found in scene %d", fileID, sceneID)
+}
+
+func (r *mutationResolver) SceneManageAudioTracks(ctx context.Context, input models.ManageAudioTracksInput) (string, error) {
+	scene, targetFile, err := r.findSceneVideoFile(ctx, input.SceneID, input.FileID)
+	if err != nil {
+		return "", err
 	}
 
-	// Validate trim times
-	// At least one time must be set (greater than 0)
-	if input.StartTime <= 0 && input.EndTime <= 0 {
-		return "", fmt.Errorf("at least one trim time must be set")
+	if err := manager.GetInstance().ValidateWritable(targetFile.Path); err != nil {
+		return "", err
 	}
 
-	// Validate start time if set
-	if input.StartTime > 0 {
-		if input.StartTime >= targetFile.Duration {
-			return "", fmt.Errorf("start time %.2f cannot be greater than or equal to video duration %.2f", input.StartTime, targetFile.Duration)
-		}
+	if input.Tracks == nil && len(input.Extract) == 0 {
+		return "", errors.New("no audio track changes requested")
 	}
 
-	// Validate end time if set
-	if input.EndTime > 0 {
-		if input.EndTime > targetFile.Duration {
-			return "", fmt.Errorf("end time %.2f cannot be greater than video duration %.2f", input.EndTime, targetFile.Duration)
-		}
+	if input.DefaultTrack != nil && input.Tracks == nil {
+		return "", errors.New("tracks must be set to change the default track")
 	}
 
-	// If both are set, validate relationship
-	if input.StartTime > 0 && input.EndTime > 0 {
-		if input.EndTime <= input.StartTime {
-			return "", fmt.Errorf("end time %.2f must be greater than start time %.2f", input.EndTime, input.StartTime)
-		}
+	mgr := manager.GetInstance()
+	task := &manager.ManageAudioTracksTask{
+		Repository:            r.repository,
+		FFMpeg:                mgr.FFMpeg,
+		FFProbe:               mgr.FFProbe,
+		Paths:                 mgr.Paths,
+		FileNamingAlgorithm:   mgr.Config.GetVideoFileNamingAlgorithm(),
+		FingerprintCalculator: &manager.FingerprintCalculator{Config: mgr.Config},
+		Scene:                 *scene,
+		File:                  targetFile,
+		Extract:               input.Extract,
 	}
 
-	// Create video trimming task
-	fileNamingAlgorithm := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
-	g := &generate.Generator{
-		Encoder:      manager.GetInstance().FFMpeg,
-		FFMpegConfig: manager.GetInstance().Config,
-		LockManager:  manager.GetInstance().ReadLockManager,
-		MarkerPaths:  manager.GetInstance().Paths.SceneMarkers,
-		ScenePaths:   manager.GetInstance().Paths.Scene,
-		Overwrite:    true,
+	if input.Tracks != nil {
+		task.Tracks = &ffmpeg.AudioTrackOptions{
+			Keep:    input.Tracks,
+			Default: input.DefaultTrack,
+		}
 	}
 
-	// Create fingerprint calculator
-	fingerprintCalc := &manager.FingerprintCalculator{Config: manager.GetInstance().Config}
+	jobExec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return task.Execute(ctx, progress)
+	})
+	jobID := mgr.JobManager.Start(ctx, task.GetDescription(), jobExec)
 
-	// Convert 0 values to nil for proper handling
-	var startTime *float64
-	if input.StartTime > 0 {
-		startTime = &input.StartTime
-	}
+	return strconv.Itoa(jobID), nil
+}
 
-	var endTime *float64
-	if input.EndTime > 0 {
-		endTime = &input.EndTime
+// newTrimVideoTask returns a task that replaces the video file of the scene.
+func (r *mutationResolver) newTrimVideoTask(scene *models.Scene, targetFile *models.VideoFile) *manager.TrimVideoTask {
+	mgr := manager.GetInstance()
+	g := &generate.Generator{
+		Encoder:      mgr.FFMpeg,
+		FFMpegConfig: mgr.Config,
+		LockManager:  mgr.ReadLockManager,
+		MarkerPaths:  mgr.Paths.SceneMarkers,
+		ScenePaths:   mgr.Paths.Scene,
+		Overwrite:    true,
 	}
 
-	task := &manager.TrimVideoTask{
+	return &manager.TrimVideoTask{
 		Scene:                 *scene,
 		FileID:                targetFile.ID,
-		StartTime:             startTime,
-		EndTime:               endTime,
-		FileNamingAlgorithm:   fileNamingAlgorithm,
+		FileNamingAlgorithm:   mgr.Config.GetVideoFileNamingAlgorithm(),
 		G:                     g,
-		FFMpeg:                manager.GetInstance().FFMpeg,
-		FFProbe:               manager.GetInstance().FFProbe,
-		Config:                manager.GetInstance().Config,
-		Paths:                 manager.GetInstance().Paths,
+		FFMpeg:                mgr.FFMpeg,
+		FFProbe:               mgr.FFProbe,
+		Config:                mgr.Config,
+		Paths:                 mgr.Paths,
 		Repository:            r.repository,
-		FingerprintCalculator: fingerprintCalc,
+		FingerprintCalculator: &manager.FingerprintCalculator{Config: mgr.Config},
 	}
-
-	// Start the task in separate thread via JobManager
-	jobExec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
-		return task.Execute(ctx, progress)
-	})
-	jobID := manager.GetInstance().JobManager.Start(ctx, task.GetDescription(), jobExec)
-
-	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) SceneRegenerateSprites(ctx context.Context, id string) (string, error) {
@@ -1813,6 +2186,55 @@ func (r *mutationResolver) SceneRegenerateSprites(ctx context.Context, id string
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) GenerateHeatmap(ctx context.Context, input GenerateHeatmapInput) (string, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return "", fmt.Errorf("converting scene id: %w", err)
+	}
+
+	mgr := manager.GetInstance()
+	options := manager.HeatmapOptionsFromConfig(mgr.Config)
+	if input.Palette != nil {
+		options.Palette = *input.Palette
+	}
+	if input.Height != nil {
+		if *input.Height <= 0 {
+			return "", errors.New("height must be greater than zero")
+		}
+		options.Height = *input.Height
+	}
+	if input.SmoothingWindow != nil {
+		options.SmoothingWindow = *input.SmoothingWindow
+	}
+	if input.DrawRange != nil {
+		options.DrawRange = *input.DrawRange
+	}
+
+	var s *models.Scene
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		return s.LoadFiles(ctx, r.repository.Scene)
+	}); err != nil {
+		return "", err
+	}
+
+	jobID, err := mgr.GenerateHeatmap(ctx, s, options)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) OpenInExternalPlayer(ctx context.Context, id string) (bool, error) {
 	sceneID, err := strconv.Atoi(id)
 	if err != nil {
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) DetectSceneDates(ctx context.Context, input *DetectSceneDatesInput) (string, error) {
+	useMetadata := input == nil || input.UseMetadata == nil || *input.UseMetadata
+
+	jobID := manager.GetInstance().DetectDates(ctx, useMetadata)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ApplySceneDateProposals(ctx context.Context, input ApplySceneDateProposalsInput) (string, error) {
+	var ids []int
+	if input.Ids != nil {
+		var err error
+		ids, err = stringslice.StringSliceToIntSlice(input.Ids)
+		if err != nil {
+			return "", fmt.Errorf("converting proposal ids: %w", err)
+		}
+	}
+
+	minConfidence := 0.0
+	if input.MinConfidence != nil {
+		minConfidence = *input.MinConfidence
+	}
+	if minConfidence < 0 || minConfidence > 1 {
+		return "", errors.New("min_confidence must be between 0 and 1")
+	}
+
+	jobID, err := manager.GetInstance().ApplyDateProposals(ctx, ids, minConfidence)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -0,0 +1,331 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) newMarkerFileDeleter() *scene.FileDeleter {
+	mgr := manager.GetInstance()
+	return &scene.FileDeleter{
+		Deleter:        mgr.NewFileDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+}
+
+func (r *mutationResolver) BulkSceneMarkerUpdate(ctx context.Context, input BulkSceneMarkerUpdateInput) ([]*models.SceneMarker, error) {
+	markerIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return nil, fmt.Errorf("converting ids: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	primaryTagID, err := translator.optionalIntFromString(input.PrimaryTagID, "primary_tag_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting primary tag id: %w", err)
+	}
+	tagIDs, err := translator.updateIdsBulk(input.TagIds, "tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	offset := 0.
+	if input.OffsetSeconds != nil {
+		offset = *input.OffsetSeconds
+	}
+
+	fileDeleter := r.newMarkerFileDeleter()
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SceneMarker
+		sqb := r.repository.Scene
+
+		scenes := make(map[int]*models.Scene)
+		for _, markerID := range markerIDs {
+			marker, err := qb.Find(ctx, markerID)
+			if err != nil {
+				return err
+			}
+			if marker == nil {
+				return fmt.Errorf("scene marker with id %d not found", markerID)
+			}
+
+			s := scenes[marker.SceneID]
+			if s == nil {
+				s, err = sqb.Find(ctx, marker.SceneID)
+				if err != nil {
+					return err
+				}
+				if s == nil {
+					return fmt.Errorf("scene with id %d not found", marker.SceneID)
+				}
+				if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+					return err
+				}
+				scenes[s.ID] = s
+			}
+
+			updatedMarker := models.NewSceneMarkerPartial()
+			updatedMarker.PrimaryTagID = primaryTagID
+
+			if offset != 0 {
+				duration := 0.
+				if f := s.Files.Primary(); f != nil {
+					duration = f.Duration
+				}
+
+				seconds, endSeconds := scene.ShiftMarker(marker, offset, duration)
+				updatedMarker.Seconds = models.NewOptionalFloat64(seconds)
+				if endSeconds != nil {
+					updatedMarker.EndSeconds = models.NewOptionalFloat64(*endSeconds)
+				}
+
+				// remove the marker preview since the timestamp changed
+				if err := fileDeleter.MarkMarkerFiles(s, int(marker.Seconds)); err != nil {
+					return err
+				}
+			}
+
+			newMarker, err := qb.UpdatePartial(ctx, markerID, updatedMarker)
+			if err != nil {
+				return err
+			}
+
+			if tagIDs != nil || primaryTagID.Set {
+				existing, err := qb.GetTagIDs(ctx, markerID)
+				if err != nil {
+					return err
+				}
+
+				// If this tag is the primary tag, then let's not add it.
+				newTagIDs := sliceutil.Exclude(tagIDs.Apply(existing), []int{newMarker.PrimaryTagID})
+				if err := qb.UpdateTags(ctx, markerID, newTagIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		fileDeleter.Rollback()
+		return nil, err
+	}
+
+	fileDeleter.Commit()
+
+	var ret []*models.SceneMarker
+	for _, markerID := range markerIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, markerID, hook.SceneMarkerUpdatePost, input, translator.getFields())
+
+		marker, err := r.getSceneMarker(ctx, markerID)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, marker)
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneMarkersDestroyByTag(ctx context.Context, input SceneMarkersDestroyByTagInput) (int, error) {
+	tagID, err := strconv.Atoi(input.TagID)
+	if err != nil {
+		return 0, fmt.Errorf("converting tag id: %w", err)
+	}
+
+	filter := &models.SceneMarkerFilterType{
+		Tags: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{input.TagID},
+			Modifier: models.CriterionModifierIncludes,
+		},
+	}
+	if len(input.SceneIds) > 0 {
+		filter.Scenes = &models.MultiCriterionInput{
+			Value:    input.SceneIds,
+			Modifier: models.CriterionModifierIncludes,
+		}
+	}
+	primaryOnly := input.PrimaryOnly != nil && *input.PrimaryOnly
+
+	var markers []*models.SceneMarker
+	fileDeleter := r.newMarkerFileDeleter()
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SceneMarker
+		sqb := r.repository.Scene
+
+		perPage := -1
+		found, _, err := qb.Query(ctx, filter, &models.FindFilterType{
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return fmt.Errorf("finding scene markers: %w", err)
+		}
+
+		for _, marker := range found {
+			if primaryOnly && marker.PrimaryTagID != tagID {
+				continue
+			}
+
+			s, err := sqb.Find(ctx, marker.SceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", marker.SceneID)
+			}
+
+			if err := scene.DestroyMarker(ctx, s, marker, qb, fileDeleter); err != nil {
+				return err
+			}
+
+			markers = append(markers, marker)
+		}
+
+		return nil
+	}); err != nil {
+		fileDeleter.Rollback()
+		return 0, err
+	}
+
+	fileDeleter.Commit()
+
+	for _, marker := range markers {
+		r.hookExecutor.ExecutePostHooks(ctx, marker.ID, hook.SceneMarkerDestroyPost, input, nil)
+	}
+
+	return len(markers), nil
+}
+
+// sceneDuplicateIDs returns the IDs of the other scenes with a file within
+// distance of a phash of the files of the scene.
+func (r *mutationResolver) sceneDuplicateIDs(ctx context.Context, s *models.Scene, distance int) ([]int, error) {
+	if err := s.LoadFiles(ctx, r.repository.Scene); err != nil {
+		return nil, err
+	}
+
+	var hashes []int64
+	for _, f := range s.Files.List() {
+		switch v := f.Fingerprints.Get(models.FingerprintTypePhash).(type) {
+		case int64:
+			hashes = append(hashes, v)
+		case int:
+			hashes = append(hashes, int64(v))
+		}
+	}
+
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("scene %d has no phash", s.ID)
+	}
+
+	var ret []int
+	add := func(sceneID int) {
+		if sceneID != s.ID {
+			ret = sliceutil.AppendUnique(ret, sceneID)
+		}
+	}
+
+	index := manager.GetInstance().PhashIndex
+	if index.Ready() {
+		for _, hash := range hashes {
+			for _, e := range index.Search(hash, distance) {
+				add(e.SceneID)
+			}
+		}
+
+		return ret, nil
+	}
+
+	all, err := r.repository.Scene.FindAllPhashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range hashes {
+		for _, h := range all {
+			if bits.OnesCount64(uint64(h.Hash^hash)) <= distance {
+				add(h.SceneID)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneMarkersCopy(ctx context.Context, input SceneMarkersCopyInput) ([]*models.SceneMarker, error) {
+	sourceID, err := strconv.Atoi(input.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("converting source id: %w", err)
+	}
+
+	destIDs, err := stringslice.StringSliceToIntSlice(input.DestinationIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination ids: %w", err)
+	}
+
+	distance := 0
+	if input.Distance != nil {
+		distance = *input.Distance
+	}
+
+	var ret []*models.SceneMarker
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		source, err := r.repository.Scene.Find(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return fmt.Errorf("scene with id %d not found", sourceID)
+		}
+
+		if input.DestinationIds == nil {
+			destIDs, err = r.sceneDuplicateIDs(ctx, source, distance)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, destID := range destIDs {
+			if destID == sourceID {
+				continue
+			}
+
+			dest, err := r.repository.Scene.Find(ctx, destID)
+			if err != nil {
+				return err
+			}
+			if dest == nil {
+				return fmt.Errorf("scene with id %d not found", destID)
+			}
+
+			created, err := scene.CopyMarkers(ctx, r.repository.SceneMarker, sourceID, destID)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, created...)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, marker := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, marker.ID, hook.SceneMarkerCreatePost, input, nil)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) SceneParserBatchCreate(ctx context.Context, input SceneParserBatchCreateInput) (ret *models.SceneParserBatch, err error) {
+	if input.Filter.Q == nil || *input.Filter.Q == "" {
+		return nil, errors.New("pattern must be set")
+	}
+
+	pattern := *input.Filter.Q
+
+	// parse all matching scenes, not just the current page
+	filter := *input.Filter
+	page := 1
+	perPage := -1
+	filter.Page = &page
+	filter.PerPage = &perPage
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		repo := scene.NewFilenameParserRepository(r.repository)
+		parser := scene.NewFilenameParser(&filter, input.Config, repo)
+
+		results, _, err := parser.Parse(ctx)
+		if err != nil {
+			return err
+		}
+
+		var changes []*models.SceneParserChange
+		for _, result := range results {
+			c, err := result.Changes()
+			if err != nil {
+				return fmt.Errorf("scene %d: %w", result.Scene.ID, err)
+			}
+			changes = append(changes, c...)
+		}
+
+		now := time.Now()
+		ret = &models.SceneParserBatch{
+			Pattern:   pattern,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		return r.repository.SceneParserBatch.Create(ctx, ret, changes)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneParserChangesUpdate(ctx context.Context, input SceneParserChangesUpdateInput) (bool, error) {
+	if input.Status == models.SceneParserChangeStatusApplied {
+		return false, errors.New("changes can only be applied by applying the batch")
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SceneParserBatch.UpdateChangeStatus(ctx, ids, input.Status)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SceneParserBatchApply(ctx context.Context, id string) (string, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		b, err := r.repository.SceneParserBatch.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("scene parser batch with id %d not found", idInt)
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().ApplySceneParserBatch(ctx, idInt)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) SceneParserBatchDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SceneParserBatch.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
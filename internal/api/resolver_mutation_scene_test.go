@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var errDatabaseLocked = errors.New("database is locked")
+
+// lockingDatabase is a mock database where errDatabaseLocked is a locked
+// database error, so that transactions failing with it are retried.
+type lockingDatabase struct {
+	*mocks.Database
+}
+
+func (lockingDatabase) IsLocked(err error) bool {
+	return errors.Is(err, errDatabaseLocked)
+}
+
+// withUpdateInput returns a context with the variables of a mutation whose
+// input argument is input, as used to find the fields set in the input.
+func withUpdateInput(ctx context.Context, input interface{}) context.Context {
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{
+		Variables: map[string]interface{}{updateInputField: input},
+	})
+
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Field: graphql.CollectedField{
+			Field: &ast.Field{
+				Definition: &ast.FieldDefinition{
+					Arguments: ast.ArgumentDefinitionList{{Name: updateInputField}},
+				},
+				Arguments: ast.ArgumentList{{
+					Name:  updateInputField,
+					Value: &ast.Value{Kind: ast.Variable, Raw: updateInputField},
+				}},
+			},
+		},
+	})
+}
+
+// loadedScene returns a scene with its relationships loaded.
+func loadedScene(id int, title string) *models.Scene {
+	return &models.Scene{
+		ID:              id,
+		Title:           title,
+		URLs:            models.NewRelatedStrings([]string{}),
+		GalleryIDs:      models.NewRelatedIDs([]int{}),
+		TagIDs:          models.NewRelatedIDs([]int{}),
+		PerformerIDs:    models.NewRelatedIDs([]int{}),
+		ScenePerformers: models.NewRelatedScenePerformers([]models.PerformerScenes{}),
+		PerformerTagIDs: models.NewRelatedPerformerTags([]models.ScenesTagsPerformer{}),
+		Groups:          models.NewRelatedGroups([]models.GroupsScenes{}),
+		StashIDs:        models.NewRelatedStashIDs([]models.StashID{}),
+		Files:           models.NewRelatedVideoFiles([]*models.VideoFile{}),
+	}
+}
+
+func TestScenesUpdate_retry(t *testing.T) {
+	db := mocks.NewDatabase()
+	r := newResolver(db)
+	r.repository.TxnManager = lockingDatabase{db}
+
+	scene1 := loadedScene(1, "one")
+	scene2 := loadedScene(2, "two")
+
+	db.Scene.On("Find", mock.Anything, 1).Return(scene1, nil)
+	db.Scene.On("Find", mock.Anything, 2).Return(scene2, nil)
+	db.Scene.On("UpdatePartial", mock.Anything, 1, mock.Anything).Return(scene1, nil).Twice()
+	// the first attempt fails after updating the first scene
+	db.Scene.On("UpdatePartial", mock.Anything, 2, mock.Anything).Return(nil, errDatabaseLocked).Once()
+	db.Scene.On("UpdatePartial", mock.Anything, 2, mock.Anything).Return(scene2, nil).Once()
+
+	ctx := withUpdateInput(testCtx, []interface{}{
+		map[string]interface{}{"id": "1", "title": "one"},
+		map[string]interface{}{"id": "2", "title": "two"},
+	})
+
+	one, two := "one", "two"
+	ret, err := r.Mutation().ScenesUpdate(ctx, []*models.SceneUpdateInput{
+		{ID: "1", Title: &one},
+		{ID: "2", Title: &two},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Scene{scene1, scene2}, ret)
+	db.AssertExpectations(t)
+}
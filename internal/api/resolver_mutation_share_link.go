@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/hash"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// shareLinkTokenLength is the number of random bytes in a share link token.
+const shareLinkTokenLength = 20
+
+func (r *mutationResolver) CreateShareLink(ctx context.Context, input ShareLinkCreateInput) (*models.ShareLink, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	var err error
+	newLink := models.NewShareLink()
+
+	newLink.SceneID, err = translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	newLink.GalleryID, err = translator.intPtrFromString(input.GalleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	if (newLink.SceneID == nil) == (newLink.GalleryID == nil) {
+		return nil, errors.New("exactly one of scene_id and gallery_id must be set")
+	}
+
+	if input.MaxViews != nil && *input.MaxViews <= 0 {
+		return nil, errors.New("max_views must be greater than zero")
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+
+	newLink.AllowDownload = input.AllowDownload != nil && *input.AllowDownload
+	newLink.MaxViews = input.MaxViews
+	newLink.ExpiresAt = input.ExpiresAt
+
+	newLink.Token, err = hash.GenerateRandomKey(shareLinkTokenLength)
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if newLink.SceneID != nil {
+			s, err := r.repository.Scene.Find(ctx, *newLink.SceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", *newLink.SceneID)
+			}
+		} else {
+			g, err := r.repository.Gallery.Find(ctx, *newLink.GalleryID)
+			if err != nil {
+				return err
+			}
+			if g == nil {
+				return fmt.Errorf("gallery with id %d not found", *newLink.GalleryID)
+			}
+		}
+
+		return r.repository.ShareLink.Create(ctx, &newLink)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newLink, nil
+}
+
+func (r *mutationResolver) RevokeShareLink(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.ShareLink.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// randomSortSeedMax is the upper bound of the seeds of random playlist sorts.
+const randomSortSeedMax = 1e8
+
+func (r *mutationResolver) SaveSmartPlaylist(ctx context.Context, input SaveSmartPlaylistInput) (*models.SmartPlaylist, error) {
+	translator := changesetTranslator{}
+
+	id, err := translator.intPtrFromString(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	p := models.NewSmartPlaylist()
+	p.Name = strings.TrimSpace(input.Name)
+	p.SceneFilter = input.SceneFilter
+	p.Sort = translator.string(input.Sort)
+	if input.Direction != nil {
+		p.Direction = *input.Direction
+	}
+	p.MaxItemDuration = input.MaxItemDuration
+	p.UseMarkers = translator.bool(input.UseMarkers)
+	p.SkipWatched = translator.bool(input.SkipWatched)
+
+	// store the seed of random sorts, so that the order is the same for
+	// each request
+	if p.Sort == "random" {
+		p.Sort = "random_" + strconv.Itoa(rand.Intn(randomSortSeedMax))
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SmartPlaylist
+
+		if id == nil {
+			return qb.Create(ctx, &p)
+		}
+
+		existing, err := qb.Find(ctx, *id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("smart playlist with id %d not found", *id)
+		}
+
+		p.ID = existing.ID
+		p.CreatedAt = existing.CreatedAt
+
+		return qb.Update(ctx, &p)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *mutationResolver) DestroySmartPlaylist(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SmartPlaylist.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -2,15 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
-	"github.com/stashapp/stash/pkg/stashbox"
 )
 
 func (r *mutationResolver) SubmitStashBoxFingerprints(ctx context.Context, input StashBoxFingerprintSubmissionInput) (bool, error) {
@@ -73,22 +72,7 @@ func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input S
 
 	var res *string
 	err = r.withReadTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
-		scene, err := qb.Find(ctx, id)
-		if err != nil {
-			return err
-		}
-
-		if scene == nil {
-			return fmt.Errorf("scene with id %d not found", id)
-		}
-
-		cover, err := qb.GetCover(ctx, id)
-		if err != nil {
-			logger.Errorf("Error getting scene cover: %v", err)
-		}
-
-		draft, err := r.makeSceneDraft(ctx, scene, cover)
+		draft, err := manager.SceneDraft(ctx, r.repository, id)
 		if err != nil {
 			return err
 		}
@@ -100,64 +84,6 @@ func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input S
 	return res, err
 }
 
-func (r *mutationResolver) makeSceneDraft(ctx context.Context, s *models.Scene, cover []byte) (*stashbox.SceneDraft, error) {
-	if err := s.LoadURLs(ctx, r.repository.Scene); err != nil {
-		return nil, fmt.Errorf("loading scene URLs: %w", err)
-	}
-
-	if err := s.LoadStashIDs(ctx, r.repository.Scene); err != nil {
-		return nil, err
-	}
-
-	draft := &stashbox.SceneDraft{
-		Scene: s,
-	}
-
-	pqb := r.repository.Performer
-	sqb := r.repository.Studio
-
-	if s.StudioID != nil {
-		var err error
-		draft.Studio, err = sqb.Find(ctx, *s.StudioID)
-		if err != nil {
-			return nil, err
-		}
-		if draft.Studio == nil {
-			return nil, fmt.Errorf("studio with id %d not found", *s.StudioID)
-		}
-
-		if err := draft.Studio.LoadStashIDs(ctx, r.repository.Studio); err != nil {
-			return nil, err
-		}
-	}
-
-	// submit all file fingerprints
-	if err := s.LoadFiles(ctx, r.repository.Scene); err != nil {
-		return nil, err
-	}
-
-	scenePerformers, err := pqb.FindBySceneID(ctx, s.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, p := range scenePerformers {
-		if err := p.LoadStashIDs(ctx, pqb); err != nil {
-			return nil, err
-		}
-	}
-	draft.Performers = scenePerformers
-
-	draft.Tags, err = r.repository.Tag.FindBySceneID(ctx, s.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	draft.Cover = cover
-
-	return draft, nil
-}
-
 func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
 	b, err := resolveStashBox(input.StashBoxIndex, input.StashBoxEndpoint)
 	if err != nil {
@@ -173,34 +99,41 @@ func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, inp
 
 	var res *string
 	err = r.withReadTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Performer
-		performer, err := qb.Find(ctx, id)
+		performer, img, err := manager.PerformerDraft(ctx, r.repository, id)
 		if err != nil {
 			return err
 		}
 
-		if performer == nil {
-			return fmt.Errorf("performer with id %d not found", id)
-		}
+		res, err = client.SubmitPerformerDraft(ctx, performer, img)
+		return err
+	})
 
-		pqb := r.repository.Performer
-		if err := performer.LoadAliases(ctx, pqb); err != nil {
-			return err
-		}
+	return res, err
+}
 
-		if err := performer.LoadURLs(ctx, pqb); err != nil {
-			return err
-		}
+func (r *mutationResolver) SubmitStashBoxDrafts(ctx context.Context, input StashBoxBatchDraftSubmissionInput) (string, error) {
+	b, err := resolveStashBox(nil, &input.StashBoxEndpoint)
+	if err != nil {
+		return "", err
+	}
 
-		if err := performer.LoadStashIDs(ctx, pqb); err != nil {
-			return err
-		}
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIds)
+	if err != nil {
+		return "", fmt.Errorf("converting scene ids: %w", err)
+	}
+	performerIDs, err := stringslice.StringSliceToIntSlice(input.PerformerIds)
+	if err != nil {
+		return "", fmt.Errorf("converting performer ids: %w", err)
+	}
 
-		img, _ := pqb.GetImage(ctx, performer.ID)
+	if len(sceneIDs) == 0 && len(performerIDs) == 0 {
+		return "", errors.New("no scenes or performers to submit")
+	}
 
-		res, err = client.SubmitPerformerDraft(ctx, performer, img)
-		return err
-	})
+	jobID, err := manager.GetInstance().SubmitStashBoxDrafts(ctx, b, sceneIDs, performerIDs)
+	if err != nil {
+		return "", err
+	}
 
-	return res, err
+	return strconv.Itoa(jobID), nil
 }
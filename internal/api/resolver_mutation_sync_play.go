@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/syncplay"
+)
+
+func (r *mutationResolver) SyncPlayCreate(ctx context.Context, input SyncPlayCreateInput) (*syncplay.Session, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		gated := false
+		if s != nil {
+			gated, err = r.contentGate().isGated(ctx, r.repository.Scene, s.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if s == nil || gated {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	hostOnly := input.HostOnly != nil && *input.HostOnly
+	ret := manager.GetInstance().SyncPlay.Create(sceneID, hostOnly, time.Now())
+	return &ret, nil
+}
+
+func (r *mutationResolver) SyncPlayCommand(ctx context.Context, input SyncPlayCommandInput) (*syncplay.Session, error) {
+	var latency time.Duration
+	if input.Latency != nil {
+		latency = time.Duration(*input.Latency * float64(time.Second))
+	}
+
+	ret, err := manager.GetInstance().SyncPlay.Command(input.SessionID, input.MemberID, input.Action, input.Position, latency, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *mutationResolver) SyncPlayUpdate(ctx context.Context, input SyncPlayUpdateInput) (*syncplay.Session, error) {
+	ret, err := manager.GetInstance().SyncPlay.Update(input.SessionID, input.MemberID, input.HostID, input.HostOnly, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *mutationResolver) SyncPlayRemoveMember(ctx context.Context, sessionID string, memberID string, targetID string) (bool, error) {
+	if err := manager.GetInstance().SyncPlay.Remove(sessionID, memberID, targetID, time.Now()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SyncPlayEnd(ctx context.Context, sessionID string, memberID string) (bool, error) {
+	if err := manager.GetInstance().SyncPlay.End(sessionID, memberID, time.Now()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+func (r *mutationResolver) PurgeTempFiles(ctx context.Context, input PurgeTempFilesInput) ([]*fsutil.TempEntry, error) {
+	var olderThan time.Duration
+	if input.OlderThanHours != nil {
+		if *input.OlderThanHours < 0 {
+			return nil, errors.New("older_than_hours must not be negative")
+		}
+		olderThan = time.Duration(*input.OlderThanHours) * time.Hour
+	}
+
+	purged, err := manager.GetInstance().PurgeTempFiles(input.Names, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	return tempFilePointers(purged), nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *mutationResolver) ViewHistoryDeleteEntry(ctx context.Context, input ViewHistoryDeleteEntryInput) (ret int, err error) {
+	if (input.SceneID == nil) == (input.GalleryID == nil) {
+		return 0, errors.New("exactly one of scene_id or gallery_id must be provided")
+	}
+
+	if input.To.Before(input.From) {
+		return 0, errors.New("to must not be before from")
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if input.SceneID != nil {
+			id, err := strconv.Atoi(*input.SceneID)
+			if err != nil {
+				return fmt.Errorf("converting scene id: %w", err)
+			}
+
+			ret, err = r.repository.Scene.DeleteViewsInRange(ctx, id, input.From, input.To)
+			return err
+		}
+
+		id, err := strconv.Atoi(*input.GalleryID)
+		if err != nil {
+			return fmt.Errorf("converting gallery id: %w", err)
+		}
+
+		ret, err = r.repository.Gallery.DeleteViewsInRange(ctx, id, input.From, input.To)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ViewHistoryClearBefore(ctx context.Context, before time.Time) (ret int, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		scenes, err := r.repository.Scene.DeleteViewsBefore(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		galleries, err := r.repository.Gallery.DeleteViewsBefore(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		ret = scenes + galleries
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ViewHistoryPause(ctx context.Context, until *time.Time) (bool, error) {
+	if until != nil && !until.After(time.Now()) {
+		return false, errors.New("until must be in the future")
+	}
+
+	c := config.GetInstance()
+	c.SetViewHistoryPaused(until)
+	if err := c.Write(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ViewHistoryResume(ctx context.Context) (bool, error) {
+	c := config.GetInstance()
+	c.ResumeViewHistory()
+	if err := c.Write(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/activity"
+)
+
+const (
+	defaultActivityDays = 365
+	maxActivityDays     = 3660
+)
+
+func (r *queryResolver) ActivityCalendar(ctx context.Context, input *ActivityCalendarInput) (*activity.Calendar, error) {
+	days := defaultActivityDays
+	var timeZone *string
+	if input != nil {
+		if input.Days != nil {
+			days = *input.Days
+		}
+		timeZone = input.TimeZone
+	}
+
+	loc, err := activityLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().In(loc)
+	start, err := activityStart(end, days)
+	if err != nil {
+		return nil, err
+	}
+
+	var views, oDates []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		views, err = r.repository.Scene.GetViewDatesInRange(ctx, start, end)
+		if err != nil {
+			return err
+		}
+
+		oDates, err = r.repository.Scene.GetODatesInRange(ctx, start, end)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := activity.NewCalendar(views, oDates, start, end, loc)
+	return &ret, nil
+}
+
+// activityLocation returns the time zone in which days are counted, which
+// is the configured time zone if tz is not set.
+func activityLocation(tz *string) (*time.Location, error) {
+	if tz == nil || *tz == "" {
+		return manager.GetInstance().Config.GetTimezone(), nil
+	}
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+	return loc, nil
+}
+
+// activityStart returns the start of the range of days up to and including
+// the day of end.
+func activityStart(end time.Time, days int) (time.Time, error) {
+	if days < 1 || days > maxActivityDays {
+		return time.Time{}, fmt.Errorf("days must be between 1 and %d", maxActivityDays)
+	}
+
+	y, m, d := end.Date()
+	return time.Date(y, m, d-(days-1), 0, 0, 0, 0, end.Location()), nil
+}
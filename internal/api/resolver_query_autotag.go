@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/match"
+)
+
+func (r *queryResolver) AutoTagPreview(ctx context.Context, path string) (*AutoTagPreview, error) {
+	cache := manager.GetInstance().Config.GetAutoTagMatchCache()
+
+	// auto-tag matches against the full path of files
+	const trimExt = false
+
+	ret := &AutoTagPreview{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret.Performers, err = match.PathToPerformers(ctx, path, r.repository.Performer, cache, trimExt)
+		if err != nil {
+			return err
+		}
+
+		ret.Studio, err = match.PathToStudio(ctx, path, r.repository.Studio, cache, trimExt)
+		if err != nil {
+			return err
+		}
+
+		ret.Tags, err = match.PathToTags(ctx, path, r.repository.Tag, cache, trimExt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
+)
+
+func (r *queryResolver) BrokenFiles(ctx context.Context) ([]*file.BrokenFile, error) {
+	files := manager.GetInstance().BrokenFiles()
+
+	ret := make([]*file.BrokenFile, len(files))
+	for i := range files {
+		ret[i] = &files[i]
+	}
+	return ret, nil
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func buttplugStatus() *ButtplugStatus {
+	mgr := manager.GetInstance()
+
+	ret := &ButtplugStatus{
+		ServerURL: mgr.Config.GetButtplugServerURL(),
+		Devices:   []*ButtplugDevice{},
+	}
+
+	client := mgr.ButtplugClient()
+	if client == nil {
+		return ret
+	}
+
+	serverName := client.ServerName()
+	ret.Connected = true
+	ret.ServerName = &serverName
+
+	for _, d := range client.Devices() {
+		ret.Devices = append(ret.Devices, &ButtplugDevice{
+			Index:   d.Index,
+			Name:    d.Name,
+			Latency: int(mgr.ButtplugDeviceLatency(d).Milliseconds()),
+			Linear:  d.LinearActuators > 0,
+			Vibrate: d.VibrateActuators > 0,
+		})
+	}
+
+	return ret
+}
+
+func (r *queryResolver) ButtplugStatus(ctx context.Context) (*ButtplugStatus, error) {
+	return buttplugStatus(), nil
+}
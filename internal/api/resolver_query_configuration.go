@@ -7,9 +7,17 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/conversion"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/mediaserver"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/retention"
+	"github.com/stashapp/stash/pkg/reverseimage"
+	"github.com/stashapp/stash/pkg/studio"
 	"golang.org/x/text/collate"
 )
 
@@ -17,6 +25,25 @@ func (r *queryResolver) Configuration(ctx context.Context) (*ConfigResult, error
 	return makeConfigResult(), nil
 }
 
+func (r *queryResolver) LibraryProfiles(ctx context.Context) ([]*config.LibraryProfile, error) {
+	return config.GetInstance().GetLibraryProfiles(), nil
+}
+
+func (r *queryResolver) ActiveLibraryProfile(ctx context.Context) (string, error) {
+	return config.GetInstance().GetActiveLibraryProfile(), nil
+}
+
+func (r *queryResolver) StashStatus(ctx context.Context) ([]*file.PathHealth, error) {
+	status := manager.GetInstance().StashStatus()
+
+	ret := make([]*file.PathHealth, len(status))
+	for i := range status {
+		ret[i] = &status[i]
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) Directory(ctx context.Context, path, locale *string) (*Directory, error) {
 
 	directory := &Directory{}
@@ -84,10 +111,42 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 	maxStreamingTranscodeSize := config.GetMaxStreamingTranscodeSize()
 
 	customPerformerImageLocation := config.GetCustomPerformerImageLocation()
+	retentionArchivePath := config.GetRetentionArchivePath()
+
+	retentionRules := []*retention.Rule{}
+	for _, rule := range config.GetRetentionRules() {
+		retentionRules = append(retentionRules, &rule)
+	}
+
+	autoConversionRules := []*conversion.Rule{}
+	for _, rule := range config.GetAutoConversionRules() {
+		autoConversionRules = append(autoConversionRules, &rule)
+	}
+
+	mediaServers := []*mediaserver.Server{}
+	for _, server := range config.GetMediaServers() {
+		mediaServers = append(mediaServers, &server)
+	}
+
+	studioDomainMappings := []*studio.DomainMapping{}
+	for _, m := range config.GetStudioDomainMappings() {
+		studioDomainMappings = append(studioDomainMappings, &m)
+	}
+
+	imageThumbnailProfiles := []*image.ThumbnailProfile{}
+	for _, p := range config.GetImageThumbnailProfiles() {
+		imageThumbnailProfiles = append(imageThumbnailProfiles, &p)
+	}
+
+	orderingProfiles := []*models.OrderingProfile{}
+	for _, p := range config.GetOrderingProfiles() {
+		orderingProfiles = append(orderingProfiles, &p)
+	}
 
 	return &ConfigGeneralResult{
 		Stashes:                       config.GetStashPaths(),
 		DatabasePath:                  config.GetDatabasePath(),
+		DatabaseBusyTimeout:           int(config.GetDatabaseBusyTimeout().Milliseconds()),
 		BackupDirectoryPath:           config.GetBackupDirectoryPath(),
 		GeneratedPath:                 config.GetGeneratedPath(),
 		MetadataPath:                  config.GetMetadataPath(),
@@ -102,17 +161,39 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CalculateMd5:                  config.IsCalculateMD5(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		ParallelIOTasks:               config.GetParallelIOTasks(),
+		PerformanceProfile:            config.GetPerformanceProfile(),
+		ResumeInterruptedJobs:         config.GetResumeInterruptedJobs(),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
 		PreviewExcludeStart:           config.GetPreviewExcludeStart(),
 		PreviewExcludeEnd:             config.GetPreviewExcludeEnd(),
 		PreviewPreset:                 config.GetPreviewPreset(),
+		WallPreviewHeight:             config.GetWallPreviewHeight(),
+		WallPreviewCrf:                config.GetWallPreviewCRF(),
+		WallPreviewPreset:             config.GetWallPreviewPreset(),
+		SpriteRows:                    config.GetSpriteRows(),
+		SpriteColumns:                 config.GetSpriteColumns(),
+		SpriteInterval:                config.GetSpriteInterval(),
+		SpriteFormat:                  config.GetSpriteFormat(),
+		SpriteOnDemand:                config.GetSpriteOnDemand(),
+		SmartCovers:                   config.GetSmartCovers(),
+		SmartCoverCandidates:          config.GetSmartCoverCandidates(),
+		SmartCoverPreferPeople:        config.GetSmartCoverPreferPeople(),
+		OcrCommand:                    config.GetOCRCommand(),
+		OcrArgs:                       config.GetOCRArgs(),
+		OcrEndpoint:                   config.GetOCREndpoint(),
+		OcrAPIKey:                     config.GetOCRAPIKey(),
+		OcrFrames:                     config.GetOCRFrames(),
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
+		DeinterlaceFilter:             config.GetDeinterlaceFilter(),
 		MaxTranscodeSize:              &maxTranscodeSize,
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
+		ImageThumbnailProfiles:        imageThumbnailProfiles,
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
+		DeleteConfirmation:            config.IsDeleteConfirmation(),
 		GalleryCoverRegex:             config.GetGalleryCoverRegex(),
 		APIKey:                        config.GetAPIKey(),
 		Username:                      config.GetUsername(),
@@ -131,11 +212,36 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
 		StashBoxes:                    config.GetStashBoxes(),
 		PythonPath:                    config.GetPythonPath(),
+		RclonePath:                    config.GetRclonePath(),
+		BindAddresses:                 config.GetBindAddresses(),
+		RetentionRules:                retentionRules,
+		RetentionArchivePath:          &retentionArchivePath,
+		RetentionReportInterval:       int(config.GetRetentionReportInterval().Hours()),
+		AutoConversionRules:           autoConversionRules,
+		AutoConversionMaxConcurrent:   config.GetAutoConversionMaxConcurrent(),
+		AutoConversionDailyBudget:     config.GetAutoConversionDailyBudget(),
+		MediaServers:                  mediaServers,
+		MediaServerSyncInterval:       int(config.GetMediaServerSyncInterval().Hours()),
+		TempFilesMaxAge:               int(config.GetTempFilesMaxAge().Hours()),
+		TempFilesMaxSize:              int(config.GetTempFilesMaxSize() >> 30),
+		SortCollation:                 config.GetSortCollation(),
+		SortLocale:                    config.GetSortLocale(),
+		Timezone:                      config.GetTimezone().String(),
+		OrderingProfiles:              orderingProfiles,
+		ContentGateTags:               config.GetContentGateTags(),
+		ContentGateTimeout:            int(config.GetContentGateTimeout().Minutes()),
+		AutoTagPerformerMatchModes:    config.GetAutoTagPerformerMatchModes(),
+		AutoTagStudioMatchModes:       config.GetAutoTagStudioMatchModes(),
+		AutoTagTagMatchModes:          config.GetAutoTagTagMatchModes(),
+		StudioDomainMappings:          studioDomainMappings,
 		TranscodeInputArgs:            config.GetTranscodeInputArgs(),
 		TranscodeOutputArgs:           config.GetTranscodeOutputArgs(),
 		LiveTranscodeInputArgs:        config.GetLiveTranscodeInputArgs(),
 		LiveTranscodeOutputArgs:       config.GetLiveTranscodeOutputArgs(),
 		DrawFunscriptHeatmapRange:     config.GetDrawFunscriptHeatmapRange(),
+		FunscriptHeatmapPalette:       config.GetFunscriptHeatmapPalette(),
+		FunscriptHeatmapHeight:        config.GetFunscriptHeatmapHeight(),
+		FunscriptHeatmapSmoothing:     config.GetFunscriptHeatmapSmoothing(),
 		ScraperPackageSources:         config.GetScraperPackageSources(),
 		PluginPackageSources:          config.GetPluginPackageSources(),
 	}
@@ -164,9 +270,11 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 	language := config.GetLanguage()
 	handyKey := config.GetHandyKey()
 	scriptOffset := config.GetFunscriptOffset()
+	buttplugServerURL := config.GetButtplugServerURL()
 	useStashHostedFunscript := config.GetUseStashHostedFunscript()
 	randomRatingThreshold := config.GetRandomRatingThreshold()
 	randomBestRatingThreshold := config.GetRandomBestRatingThreshold()
+	playRandomExclusionWindow := config.GetPlayRandomExclusionWindow()
 	showSimilarityPercent := config.GetShowSimilarityPercent()
 	externalVideoPlayer := config.GetExternalVideoPlayer()
 	redirectHomeToScenes := config.GetRedirectHomeToScenes()
@@ -203,10 +311,12 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 
 		HandyKey:                &handyKey,
 		FunscriptOffset:         &scriptOffset,
+		ButtplugServerURL:       &buttplugServerURL,
 		UseStashHostedFunscript: &useStashHostedFunscript,
 
 		RandomRatingThreshold:     &randomRatingThreshold,
 		RandomBestRatingThreshold: &randomBestRatingThreshold,
+		PlayRandomExclusionWindow: &playRandomExclusionWindow,
 		ShowSimilarityPercent:     &showSimilarityPercent,
 		ExternalVideoPlayer:       &externalVideoPlayer,
 		RedirectHomeToScenes:      &redirectHomeToScenes,
@@ -217,12 +327,17 @@ func makeConfigDLNAResult() *ConfigDLNAResult {
 	config := config.GetInstance()
 
 	return &ConfigDLNAResult{
-		ServerName:     config.GetDLNAServerName(),
-		Enabled:        config.GetDLNADefaultEnabled(),
-		Port:           config.GetDLNAPort(),
-		WhitelistedIPs: config.GetDLNADefaultIPWhitelist(),
-		Interfaces:     config.GetDLNAInterfaces(),
-		VideoSortOrder: config.GetVideoSortOrder(),
+		ServerName:         config.GetDLNAServerName(),
+		Enabled:            config.GetDLNADefaultEnabled(),
+		Port:               config.GetDLNAPort(),
+		WhitelistedIPs:     config.GetDLNADefaultIPWhitelist(),
+		Interfaces:         config.GetDLNAInterfaces(),
+		BindAddresses:      config.GetDLNABindAddresses(),
+		VideoSortOrder:     config.GetVideoSortOrder(),
+		ExcludeTags:        config.GetDLNAExcludeTags(),
+		ExcludeUnorganized: config.GetDLNAExcludeUnorganized(),
+		ExcludeBroken:      config.GetDLNAExcludeBroken(),
+		ExcludeQuarantined: config.GetDLNAExcludeQuarantined(),
 	}
 }
 
@@ -232,11 +347,20 @@ func makeConfigScrapingResult() *ConfigScrapingResult {
 	scraperUserAgent := config.GetScraperUserAgent()
 	scraperCDPPath := config.GetScraperCDPPath()
 
+	reverseImageSearchProviders := []*reverseimage.Provider{}
+	for _, p := range config.GetReverseImageSearchProviders() {
+		reverseImageSearchProviders = append(reverseImageSearchProviders, &p)
+	}
+
 	return &ConfigScrapingResult{
-		ScraperUserAgent:   &scraperUserAgent,
-		ScraperCertCheck:   config.GetScraperCertCheck(),
-		ScraperCDPPath:     &scraperCDPPath,
-		ExcludeTagPatterns: config.GetScraperExcludeTagPatterns(),
+		ScraperUserAgent:            &scraperUserAgent,
+		ScraperCertCheck:            config.GetScraperCertCheck(),
+		ScraperCDPPath:              &scraperCDPPath,
+		ScraperCDPMaxSessions:       config.GetScraperCDPMaxSessions(),
+		ScraperCDPTimeout:           config.GetScraperCDPTimeout(),
+		ScraperCDPMaxMemory:         config.GetScraperCDPMaxMemory(),
+		ExcludeTagPatterns:          config.GetScraperExcludeTagPatterns(),
+		ReverseImageSearchProviders: reverseImageSearchProviders,
 	}
 }
 
@@ -250,6 +374,7 @@ func makeConfigDefaultsResult() *ConfigDefaultSettingsResult {
 		Scan:            config.GetDefaultScanSettings(),
 		AutoTag:         config.GetDefaultAutoTagSettings(),
 		Generate:        config.GetDefaultGenerateSettings(),
+		MarkerEnd:       config.GetDefaultMarkerEndSettings(),
 		DeleteFile:      &deleteFileDefault,
 		DeleteGenerated: &deleteGeneratedDefault,
 	}
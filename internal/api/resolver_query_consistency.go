@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) ConsistencyReport(ctx context.Context) (*models.ConsistencyReport, error) {
+	return manager.GetInstance().ConsistencyReport(), nil
+}
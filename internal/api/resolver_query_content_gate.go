@@ -0,0 +1,20 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) ContentGateStatus(ctx context.Context) (*ContentGateStatus, error) {
+	return makeContentGateStatus(), nil
+}
+
+func makeContentGateStatus() *ContentGateStatus {
+	mgr := manager.GetInstance()
+	return &ContentGateStatus{
+		Enabled:   mgr.Config.IsContentGateEnabled(),
+		Locked:    len(mgr.GatedTags()) > 0,
+		ExpiresAt: mgr.ContentGateExpiresAt(),
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/dashboard"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *queryResolver) Dashboard(ctx context.Context) (*Dashboard, error) {
+	return r.makeDashboard(ctx, config.GetInstance().GetDashboard())
+}
+
+// makeDashboard resolves the pinned objects and widget contents of the
+// dashboard. Pinned objects that no longer exist or are hidden by the
+// content gate are omitted.
+func (r *Resolver) makeDashboard(ctx context.Context, d dashboard.Dashboard) (*Dashboard, error) {
+	ret := &Dashboard{
+		SavedFilters:     []*models.SavedFilter{},
+		Pins:             make([]*dashboard.Pin, len(d.Pins)),
+		PinnedScenes:     []*models.Scene{},
+		PinnedGroups:     []*models.Group{},
+		PinnedPerformers: []*models.Performer{},
+		Widgets:          make([]*DashboardWidget, len(d.Widgets)),
+	}
+
+	for i := range d.Pins {
+		ret.Pins[i] = &d.Pins[i]
+	}
+
+	savedFilterIDs, err := stringslice.StringSliceToIntSlice(d.SavedFilterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		gate := r.contentGate()
+		repo := r.repository
+
+		if len(savedFilterIDs) > 0 {
+			const ignoreNotFound = true
+			savedFilters, err := repo.SavedFilter.FindMany(ctx, savedFilterIDs, ignoreNotFound)
+			if err != nil {
+				return err
+			}
+
+			for _, f := range savedFilters {
+				if f != nil {
+					ret.SavedFilters = append(ret.SavedFilters, f)
+				}
+			}
+		}
+
+		for _, id := range d.PinnedIDs(dashboard.ObjectTypeScene) {
+			s, err := repo.Scene.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if s != nil {
+				ret.PinnedScenes = append(ret.PinnedScenes, s)
+			}
+		}
+		if ret.PinnedScenes, err = filterGated(ctx, gate, repo.Scene, ret.PinnedScenes, func(s *models.Scene) int { return s.ID }); err != nil {
+			return err
+		}
+
+		for _, id := range d.PinnedIDs(dashboard.ObjectTypeGroup) {
+			g, err := repo.Group.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if g != nil {
+				ret.PinnedGroups = append(ret.PinnedGroups, g)
+			}
+		}
+		if ret.PinnedGroups, err = filterGated(ctx, gate, repo.Group, ret.PinnedGroups, func(g *models.Group) int { return g.ID }); err != nil {
+			return err
+		}
+
+		for _, id := range d.PinnedIDs(dashboard.ObjectTypePerformer) {
+			p, err := repo.Performer.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if p != nil {
+				ret.PinnedPerformers = append(ret.PinnedPerformers, p)
+			}
+		}
+		if ret.PinnedPerformers, err = filterGated(ctx, gate, repo.Performer, ret.PinnedPerformers, func(p *models.Performer) int { return p.ID }); err != nil {
+			return err
+		}
+
+		for i, w := range d.Widgets {
+			ret.Widgets[i], err = r.makeDashboardWidget(ctx, w)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// makeDashboardWidget resolves the contents of the widget. Assumes a read
+// transaction is held.
+func (r *Resolver) makeDashboardWidget(ctx context.Context, w dashboard.Widget) (*DashboardWidget, error) {
+	ret := &DashboardWidget{
+		Type:       w.Type,
+		ObjectType: w.ObjectType,
+		Count:      w.Count,
+	}
+
+	// only recently added widgets are supported
+	sort := "created_at"
+	direction := models.SortDirectionEnumDesc
+	findFilter := &models.FindFilterType{
+		Sort:      &sort,
+		Direction: &direction,
+		PerPage:   &w.Count,
+	}
+
+	repo := r.repository
+	var err error
+
+	switch w.ObjectType {
+	case dashboard.ObjectTypeScene:
+		var result *models.SceneQueryResult
+		result, err = repo.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: findFilter,
+			},
+			SceneFilter: gateSceneFilter(nil),
+		})
+		if err == nil {
+			ret.Scenes, err = result.Resolve(ctx)
+		}
+	case dashboard.ObjectTypeGroup:
+		ret.Groups, _, err = repo.Group.Query(ctx, gateGroupFilter(nil), findFilter)
+	case dashboard.ObjectTypePerformer:
+		ret.Performers, _, err = repo.Performer.Query(ctx, gatePerformerFilter(nil), findFilter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 func (r *queryResolver) FindColorPreset(ctx context.Context, id string) (ret *models.ColorPreset, err error) {
@@ -69,3 +70,34 @@ func (r *queryResolver) FindColorPresets(ctx context.Context, filter *models.Fin
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindTagsByColorPreset(ctx context.Context) ([]*ColorPresetTagGroup, error) {
+	var ret []*ColorPresetTagGroup
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		presets, err := r.repository.ColorPreset.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		perPage := -1
+		tags, _, err := r.repository.Tag.Query(ctx, nil, &models.FindFilterType{
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, g := range tag.GroupByColorPreset(tags, presets) {
+			ret = append(ret, &ColorPresetTagGroup{
+				ColorPreset: g.Preset,
+				Tags:        g.Tags,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
@@ -118,3 +120,60 @@ func (r *queryResolver) FindFiles(
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindExactDuplicateFiles(ctx context.Context, fingerprintType *string) (ret *FindExactDuplicateFilesResultType, err error) {
+	fpType := models.FingerprintTypeOshash
+	if fingerprintType != nil && *fingerprintType != "" {
+		fpType = *fingerprintType
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		groups, err := file.FindExactDuplicates(ctx, r.repository.File, fpType)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindExactDuplicateFilesResultType{
+			Count:  len(groups),
+			Groups: make([]*ExactDuplicateFileGroup, len(groups)),
+		}
+
+		for i, g := range groups {
+			fp := g.Fingerprint
+			reclaimable := g.ReclaimableSize()
+			ret.Groups[i] = &ExactDuplicateFileGroup{
+				Fingerprint:     &fp,
+				Files:           convertBaseFiles(g.Files),
+				ReclaimableSize: reclaimable,
+			}
+			ret.ReclaimableSize += reclaimable
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindFilenameNormalizations(ctx context.Context, input manager.NormalizeFilenamesInput) ([]*FilenameNormalization, error) {
+	ns, err := manager.GetInstance().FindFilenameNormalizations(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*FilenameNormalization, len(ns))
+	for i, n := range ns {
+		base := n.File.Base()
+		ret[i] = &FilenameNormalization{
+			FileID:      base.ID.String(),
+			Path:        base.Path,
+			NewBasename: n.NewBasename,
+			NewPath:     n.NewPath(),
+			Conflict:    n.Conflict,
+		}
+	}
+
+	return ret, nil
+}
@@ -16,6 +16,14 @@ func (r *queryResolver) FindGallery(ctx context.Context, id string) (ret *models
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Gallery.Find(ctx, idInt)
+		if err != nil || ret == nil {
+			return err
+		}
+
+		gated, err := r.contentGate().isGated(ctx, r.repository.Gallery, ret.ID)
+		if gated {
+			ret = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -25,6 +33,8 @@ func (r *queryResolver) FindGallery(ctx context.Context, id string) (ret *models
 }
 
 func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models.GalleryFilterType, filter *models.FindFilterType, ids []string) (ret *FindGalleriesResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeGalleries, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
@@ -37,9 +47,12 @@ func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models
 
 		if len(idInts) > 0 {
 			galleries, err = r.repository.Gallery.FindMany(ctx, idInts)
+			if err == nil {
+				galleries, err = filterGated(ctx, r.contentGate(), r.repository.Gallery, galleries, func(o *models.Gallery) int { return o.ID })
+			}
 			total = len(galleries)
 		} else {
-			galleries, total, err = r.repository.Gallery.Query(ctx, galleryFilter, filter)
+			galleries, total, err = r.repository.Gallery.Query(ctx, gateGalleryFilter(galleryFilter), filter)
 		}
 
 		if err != nil {
@@ -25,6 +25,8 @@ func (r *queryResolver) FindGame(ctx context.Context, id string) (ret *models.Ga
 }
 
 func (r *queryResolver) FindGames(ctx context.Context, gameFilter *models.GameFilterType, filter *models.FindFilterType, ids []string) (ret *FindGamesResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeGames, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
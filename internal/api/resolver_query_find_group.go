@@ -16,6 +16,14 @@ func (r *queryResolver) FindGroup(ctx context.Context, id string) (ret *models.G
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Group.Find(ctx, idInt)
+		if err != nil || ret == nil {
+			return err
+		}
+
+		gated, err := r.contentGate().isGated(ctx, r.repository.Group, ret.ID)
+		if gated {
+			ret = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -25,6 +33,8 @@ func (r *queryResolver) FindGroup(ctx context.Context, id string) (ret *models.G
 }
 
 func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.GroupFilterType, filter *models.FindFilterType, ids []string) (ret *FindGroupsResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeGroups, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
@@ -37,9 +47,12 @@ func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.Grou
 
 		if len(idInts) > 0 {
 			groups, err = r.repository.Group.FindMany(ctx, idInts)
+			if err == nil {
+				groups, err = filterGated(ctx, r.contentGate(), r.repository.Group, groups, func(o *models.Group) int { return o.ID })
+			}
 			total = len(groups)
 		} else {
-			groups, total, err = r.repository.Group.Query(ctx, groupFilter, filter)
+			groups, total, err = r.repository.Group.Query(ctx, gateGroupFilter(groupFilter), filter)
 		}
 
 		if err != nil {
@@ -38,7 +38,14 @@ func (r *queryResolver) FindImage(ctx context.Context, id *string, checksum *str
 				image = images[0]
 			}
 		}
+		if err != nil || image == nil {
+			return err
+		}
 
+		gated, err := r.contentGate().isGated(ctx, qb, image.ID)
+		if gated {
+			image = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -54,6 +61,8 @@ func (r *queryResolver) FindImages(
 	ids []string,
 	filter *models.FindFilterType,
 ) (ret *FindImagesResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeImages, filter)
+
 	if len(ids) > 0 {
 		imageIds, err = stringslice.StringSliceToIntSlice(ids)
 		if err != nil {
@@ -70,6 +79,9 @@ func (r *queryResolver) FindImages(
 
 		if len(imageIds) > 0 {
 			images, err = r.repository.Image.FindMany(ctx, imageIds)
+			if err == nil {
+				images, err = filterGated(ctx, r.contentGate(), qb, images, func(i *models.Image) int { return i.ID })
+			}
 			if err == nil {
 				result.Count = len(images)
 				for _, s := range images {
@@ -97,7 +109,7 @@ func (r *queryResolver) FindImages(
 					FindFilter: filter,
 					Count:      slices.Contains(fields, "count"),
 				},
-				ImageFilter: imageFilter,
+				ImageFilter: gateImageFilter(imageFilter),
 				Megapixels:  slices.Contains(fields, "megapixels"),
 				TotalSize:   slices.Contains(fields, "filesize"),
 			})
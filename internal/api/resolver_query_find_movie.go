@@ -16,6 +16,14 @@ func (r *queryResolver) FindMovie(ctx context.Context, id string) (ret *models.G
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Group.Find(ctx, idInt)
+		if err != nil || ret == nil {
+			return err
+		}
+
+		gated, err := r.contentGate().isGated(ctx, r.repository.Group, ret.ID)
+		if gated {
+			ret = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -25,6 +33,8 @@ func (r *queryResolver) FindMovie(ctx context.Context, id string) (ret *models.G
 }
 
 func (r *queryResolver) FindMovies(ctx context.Context, movieFilter *models.GroupFilterType, filter *models.FindFilterType, ids []string) (ret *FindMoviesResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeMovies, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
@@ -37,9 +47,12 @@ func (r *queryResolver) FindMovies(ctx context.Context, movieFilter *models.Grou
 
 		if len(idInts) > 0 {
 			groups, err = r.repository.Group.FindMany(ctx, idInts)
+			if err == nil {
+				groups, err = filterGated(ctx, r.contentGate(), r.repository.Group, groups, func(o *models.Group) int { return o.ID })
+			}
 			total = len(groups)
 		} else {
-			groups, total, err = r.repository.Group.Query(ctx, movieFilter, filter)
+			groups, total, err = r.repository.Group.Query(ctx, gateGroupFilter(movieFilter), filter)
 		}
 
 		if err != nil {
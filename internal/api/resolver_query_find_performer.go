@@ -2,9 +2,13 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -16,6 +20,14 @@ func (r *queryResolver) FindPerformer(ctx context.Context, id string) (ret *mode
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Performer.Find(ctx, idInt)
+		if err != nil || ret == nil {
+			return err
+		}
+
+		gated, err := r.contentGate().isGated(ctx, r.repository.Performer, ret.ID)
+		if gated {
+			ret = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -25,6 +37,8 @@ func (r *queryResolver) FindPerformer(ctx context.Context, id string) (ret *mode
 }
 
 func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *models.PerformerFilterType, filter *models.FindFilterType, performerIDs []int, ids []string) (ret *FindPerformersResultType, err error) {
+	filter = withOrderingProfile(models.FilterModePerformers, filter)
+
 	if len(ids) > 0 {
 		performerIDs, err = stringslice.StringSliceToIntSlice(ids)
 		if err != nil {
@@ -44,9 +58,12 @@ func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *mod
 
 		if len(performerIDs) > 0 {
 			performers, err = r.repository.Performer.FindMany(ctx, performerIDs)
+			if err == nil {
+				performers, err = filterGated(ctx, r.contentGate(), r.repository.Performer, performers, func(o *models.Performer) int { return o.ID })
+			}
 			total = len(performers)
 		} else {
-			performers, total, err = r.repository.Performer.Query(ctx, performerFilter, filter)
+			performers, total, err = r.repository.Performer.Query(ctx, gatePerformerFilter(performerFilter), filter)
 		}
 
 		if err != nil {
@@ -76,3 +93,93 @@ func (r *queryResolver) AllPerformers(ctx context.Context) (ret []*models.Perfor
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindDuplicatePerformers(ctx context.Context) (ret [][]*models.Performer, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		names, err := qb.AllNames(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, ids := range match.DuplicatePerformers(names) {
+			performers, err := qb.FindMany(ctx, ids)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, performers)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = [][]*models.Performer{}
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindPerformerInconsistencies(ctx context.Context, ids []string) ([]*PerformerInconsistency, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*PerformerInconsistency
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var performers []*models.Performer
+		if ids == nil {
+			performers, err = r.repository.Performer.All(ctx)
+		} else {
+			performers, err = r.repository.Performer.FindMany(ctx, idInts)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, p := range performers {
+			for _, d := range performer.Inconsistencies(p) {
+				ret = append(ret, &PerformerInconsistency{
+					Performer:   p,
+					Description: d,
+				})
+			}
+
+			if p.Birthdate == nil {
+				continue
+			}
+
+			scenes, err := scene.Query(ctx, r.repository.Scene, &models.SceneFilterType{
+				Performers: &models.MultiCriterionInput{
+					Value:    []string{strconv.Itoa(p.ID)},
+					Modifier: models.CriterionModifierIncludes,
+				},
+				Date: &models.DateCriterionInput{
+					Value:    p.Birthdate.String(),
+					Modifier: models.CriterionModifierLessThan,
+				},
+			}, nil)
+			if err != nil {
+				return fmt.Errorf("finding scenes of performer %d: %w", p.ID, err)
+			}
+
+			for _, s := range scenes {
+				ret = append(ret, &PerformerInconsistency{
+					Performer:   p,
+					Scene:       s,
+					Description: fmt.Sprintf("scene date %s is before birthdate %s", s.Date, p.Birthdate),
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -2,14 +2,20 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"math/bits"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *queryResolver) FindScene(ctx context.Context, id *string, checksum *string) (*models.Scene, error) {
@@ -43,7 +49,14 @@ func (r *queryResolver) FindScene(ctx context.Context, id *string, checksum *str
 				}
 			}
 		}
+		if err != nil || scene == nil {
+			return err
+		}
 
+		gated, err := r.contentGate().isGated(ctx, qb, scene.ID)
+		if gated {
+			scene = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -77,7 +90,15 @@ func (r *queryResolver) FindSceneByHash(ctx context.Context, input SceneHashInpu
 			}
 		}
 
-		return nil
+		if scene == nil {
+			return nil
+		}
+
+		gated, err := r.contentGate().isGated(ctx, qb, scene.ID)
+		if gated {
+			scene = nil
+		}
+		return err
 	}); err != nil {
 		return nil, err
 	}
@@ -92,6 +113,8 @@ func (r *queryResolver) FindScenes(
 	ids []string,
 	filter *models.FindFilterType,
 ) (ret *FindScenesResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeScenes, filter)
+
 	if len(ids) > 0 {
 		sceneIDs, err = stringslice.StringSliceToIntSlice(ids)
 		if err != nil {
@@ -106,8 +129,16 @@ func (r *queryResolver) FindScenes(
 		fields := graphql.CollectAllFields(ctx)
 		result := &models.SceneQueryResult{}
 
+		// totals in deferred fragments are loaded when they are resolved, so
+		// that they do not delay the scenes
+		deferred := deferredFields(ctx)
+		deferTotals := len(sceneIDs) == 0 && (slices.Contains(deferred, "duration") || slices.Contains(deferred, "filesize"))
+
 		if len(sceneIDs) > 0 {
 			scenes, err = r.repository.Scene.FindMany(ctx, sceneIDs)
+			if err == nil {
+				scenes, err = filterGated(ctx, r.contentGate(), r.repository.Scene, scenes, func(s *models.Scene) int { return s.ID })
+			}
 			if err == nil {
 				result.Count = len(scenes)
 				for _, s := range scenes {
@@ -131,9 +162,9 @@ func (r *queryResolver) FindScenes(
 					FindFilter: filter,
 					Count:      slices.Contains(fields, "count"),
 				},
-				SceneFilter:   sceneFilter,
-				TotalDuration: slices.Contains(fields, "duration"),
-				TotalSize:     slices.Contains(fields, "filesize"),
+				SceneFilter:   gateSceneFilter(sceneFilter),
+				TotalDuration: !deferTotals && slices.Contains(fields, "duration"),
+				TotalSize:     !deferTotals && slices.Contains(fields, "filesize"),
 			})
 			if err == nil {
 				scenes, err = result.Resolve(ctx)
@@ -145,10 +176,13 @@ func (r *queryResolver) FindScenes(
 		}
 
 		ret = &FindScenesResultType{
-			Count:    result.Count,
-			Scenes:   scenes,
-			Duration: result.TotalDuration,
-			Filesize: result.TotalSize,
+			Count:  result.Count,
+			Scenes: scenes,
+			totals: loadedSceneTotals(result),
+		}
+
+		if deferTotals {
+			ret.totals = r.deferredSceneTotals(gateSceneFilter(sceneFilter), filter)
 		}
 
 		return nil
@@ -159,6 +193,22 @@ func (r *queryResolver) FindScenes(
 	return ret, nil
 }
 
+func (r *queryResolver) FindScenesMissingPoseCoverage(ctx context.Context, sceneFilter *models.SceneFilterType, filter *models.FindFilterType) (ret *FindScenesResultType, err error) {
+	f := &models.SceneFilterType{}
+	if sceneFilter != nil {
+		*f = *sceneFilter
+	}
+
+	hasPoseCoverage := false
+	f.HasPoseCoverage = &hasPoseCoverage
+
+	return r.FindScenes(ctx, f, nil, nil, filter)
+}
+
+func (r *queryResolver) FindRandomScene(ctx context.Context, input manager.RandomSceneInput) (*models.Scene, error) {
+	return manager.GetInstance().RandomScene(ctx, input)
+}
+
 func (r *queryResolver) FindScenesByPathRegex(ctx context.Context, filter *models.FindFilterType) (ret *FindScenesResultType, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 
@@ -186,7 +236,7 @@ func (r *queryResolver) FindScenesByPathRegex(ctx context.Context, filter *model
 				FindFilter: queryFilter,
 				Count:      slices.Contains(fields, "count"),
 			},
-			SceneFilter:   sceneFilter,
+			SceneFilter:   gateSceneFilter(sceneFilter),
 			TotalDuration: slices.Contains(fields, "duration"),
 			TotalSize:     slices.Contains(fields, "filesize"),
 		})
@@ -200,10 +250,9 @@ func (r *queryResolver) FindScenesByPathRegex(ctx context.Context, filter *model
 		}
 
 		ret = &FindScenesResultType{
-			Count:    result.Count,
-			Scenes:   scenes,
-			Duration: result.TotalDuration,
-			Filesize: result.TotalSize,
+			Count:  result.Count,
+			Scenes: scenes,
+			totals: loadedSceneTotals(result),
 		}
 
 		return nil
@@ -247,6 +296,32 @@ func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int,
 	if durationDiff != nil {
 		durDiff = *durationDiff
 	}
+
+	// use the phash index if it has been built, rather than comparing every
+	// pair of phashes
+	index := manager.GetInstance().PhashIndex
+	if dist > 0 && index.Ready() {
+		groups := index.Duplicates(dist, durDiff)
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			for _, ids := range groups {
+				// the index may contain scenes that have since been deleted
+				scenes, err := r.repository.Scene.FindByIDs(ctx, ids)
+				if err != nil {
+					return err
+				}
+				if len(scenes) > 1 {
+					ret = append(ret, scenes)
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		sortDuplicatesByPath(ret)
+		return ret, nil
+	}
+
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Scene.FindDuplicates(ctx, dist, durDiff)
 		return err
@@ -257,6 +332,88 @@ func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int,
 	return ret, nil
 }
 
+// sortDuplicatesByPath sorts scenes within each group, and the groups, by
+// path, matching the order returned by SceneFinder.FindDuplicates.
+func sortDuplicatesByPath(groups [][]*models.Scene) {
+	for _, g := range groups {
+		slices.SortFunc(g, func(a, b *models.Scene) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+	slices.SortStableFunc(groups, func(a, b []*models.Scene) int {
+		return strings.Compare(a[0].Path, b[0].Path)
+	})
+}
+
+func (r *queryResolver) FindScenesByPhash(ctx context.Context, phash string, distance *int) ([]*models.Scene, error) {
+	hash, err := utils.StringToPhash(phash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid phash %q: %w", phash, err)
+	}
+
+	dist := 0
+	if distance != nil {
+		dist = *distance
+	}
+
+	var ret []*models.Scene
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var ids []int
+
+		index := manager.GetInstance().PhashIndex
+		if index.Ready() {
+			for _, e := range index.Search(hash, dist) {
+				ids = sliceutil.AppendUnique(ids, e.SceneID)
+			}
+		} else {
+			hashes, err := r.repository.Scene.FindAllPhashes(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, h := range hashes {
+				if bits.OnesCount64(uint64(h.Hash^hash)) <= dist {
+					ids = sliceutil.AppendUnique(ids, h.SceneID)
+				}
+			}
+		}
+
+		ret, err = r.repository.Scene.FindByIDs(ctx, ids)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindSceneRelinkMatches(ctx context.Context, input manager.RelinkFilesInput) ([]*SceneRelinkMatch, error) {
+	matches, err := manager.GetInstance().FindRelinkMatches(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*SceneRelinkMatch, len(matches))
+	for i, m := range matches {
+		candidates := make([]*SceneRelinkCandidate, len(m.Candidates))
+		for j, c := range m.Candidates {
+			candidates[j] = &SceneRelinkCandidate{
+				File:      c.File,
+				MatchType: RelinkMatchType(c.MatchType),
+			}
+		}
+
+		ret[i] = &SceneRelinkMatch{
+			Scene:        m.Scene,
+			MissingFiles: m.MissingFiles,
+			Candidates:   candidates,
+			Ambiguous:    m.Ambiguous(),
+		}
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) AllScenes(ctx context.Context) (ret []*models.Scene, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Scene.All(ctx)
@@ -8,6 +8,8 @@ import (
 )
 
 func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter *models.SceneMarkerFilterType, filter *models.FindFilterType, ids []string) (ret *FindSceneMarkersResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeSceneMarkers, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
@@ -20,8 +22,15 @@ func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter
 
 		if len(idInts) > 0 {
 			sceneMarkers, err = r.repository.SceneMarker.FindMany(ctx, idInts)
+			if err == nil {
+				sceneMarkers, err = filterGated(ctx, r.contentGate(), r.repository.SceneMarker, sceneMarkers, func(o *models.SceneMarker) int { return o.ID })
+			}
 			total = len(sceneMarkers)
 		} else {
+			sceneMarkerFilter, err = r.contentGate().gateSceneMarkerFilter(ctx, sceneMarkerFilter)
+			if err != nil {
+				return err
+			}
 			sceneMarkers, total, err = r.repository.SceneMarker.Query(ctx, sceneMarkerFilter, filter)
 		}
 
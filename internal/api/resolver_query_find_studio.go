@@ -17,6 +17,14 @@ func (r *queryResolver) FindStudio(ctx context.Context, id string) (ret *models.
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		var err error
 		ret, err = r.repository.Studio.Find(ctx, idInt)
+		if err != nil || ret == nil {
+			return err
+		}
+
+		gated, err := r.contentGate().isGated(ctx, r.repository.Studio, ret.ID)
+		if gated {
+			ret = nil
+		}
 		return err
 	}); err != nil {
 		return nil, err
@@ -26,6 +34,8 @@ func (r *queryResolver) FindStudio(ctx context.Context, id string) (ret *models.
 }
 
 func (r *queryResolver) FindStudios(ctx context.Context, studioFilter *models.StudioFilterType, filter *models.FindFilterType, ids []string) (ret *FindStudiosResultType, err error) {
+	filter = withOrderingProfile(models.FilterModeStudios, filter)
+
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
@@ -38,9 +48,12 @@ func (r *queryResolver) FindStudios(ctx context.Context, studioFilter *models.St
 
 		if len(idInts) > 0 {
 			studios, err = r.repository.Studio.FindMany(ctx, idInts)
+			if err == nil {
+				studios, err = filterGated(ctx, r.contentGate(), r.repository.Studio, studios, func(o *models.Studio) int { return o.ID })
+			}
 			total = len(studios)
 		} else {
-			studios, total, err = r.repository.Studio.Query(ctx, studioFilter, filter)
+			studios, total, err = r.repository.Studio.Query(ctx, gateStudioFilter(studioFilter), filter)
 		}
 		if err != nil {
 			return err
@@ -2,11 +2,26 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/models"
 )
 
-func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *ViewHistoryFilter, filter *models.FindFilterType) (ret *ViewHistoryResult, err error) {
+func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *ViewHistoryFilter, filter *models.FindFilterType, grouping *models.ViewHistoryGrouping, after *string) (ret *ViewHistoryResult, err error) {
+	options := models.CombinedViewHistoryOptions{
+		Grouping: models.ViewHistoryGroupingDay,
+	}
+	if grouping != nil {
+		options.Grouping = *grouping
+	}
+	if after != nil && *after != "" {
+		options.After, err = models.DecodeViewHistoryCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		page := 1
 		perPage := 25
@@ -19,12 +34,27 @@ func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *View
 			}
 		}
 
+		options.Page = page
+		options.PerPage = perPage
+
 		// Get combined aggregated view history for scenes and galleries
-		combinedViews, err := r.repository.Scene.GetCombinedAggregatedViewHistory(ctx, page, perPage)
+		combinedViews, err := r.repository.Scene.GetCombinedAggregatedViewHistory(ctx, options)
 		if err != nil {
 			return err
 		}
 
+		// a full page may have more entries after it
+		var nextCursor *string
+		if perPage > 0 && len(combinedViews) == perPage {
+			last := combinedViews[len(combinedViews)-1]
+			c := models.ViewHistoryCursor{
+				ViewDate:    last.ViewDate,
+				ContentType: last.ContentType,
+				ContentID:   last.ContentID,
+			}.Encode()
+			nextCursor = &c
+		}
+
 		// Convert to ViewHistoryEntry format
 		var entries []*ViewHistoryEntry
 
@@ -40,11 +70,12 @@ func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *View
 				}
 
 				entry := &ViewHistoryEntry{
-					Scene:     scene,
-					ViewDate:  cv.ViewDate,
-					ODate:     cv.ODate,
-					OmgDate:   cv.OmgDate,
-					ViewCount: &cv.ViewCount,
+					Scene:            scene,
+					ViewDate:         cv.ViewDate,
+					EarliestViewDate: cv.EarliestViewDate,
+					ODate:            cv.ODate,
+					OmgDate:          cv.OmgDate,
+					ViewCount:        &cv.ViewCount,
 				}
 				entries = append(entries, entry)
 			} else if cv.ContentType == "gallery" {
@@ -58,11 +89,12 @@ func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *View
 				}
 
 				entry := &ViewHistoryEntry{
-					Gallery:   gallery,
-					ViewDate:  cv.ViewDate,
-					ODate:     cv.ODate,
-					OmgDate:   cv.OmgDate,
-					ViewCount: &cv.ViewCount,
+					Gallery:          gallery,
+					ViewDate:         cv.ViewDate,
+					EarliestViewDate: cv.EarliestViewDate,
+					ODate:            cv.ODate,
+					OmgDate:          cv.OmgDate,
+					ViewCount:        &cv.ViewCount,
 				}
 				entries = append(entries, entry)
 			}
@@ -96,11 +128,21 @@ func (r *queryResolver) FindViewHistory(ctx context.Context, historyFilter *View
 		}
 		totalOMGCount := scenesTotalOMGCount + galleriesTotalOMGCount
 
+		c := config.GetInstance()
+		paused := c.IsViewHistoryPaused()
+		var pausedUntil *time.Time
+		if paused {
+			pausedUntil = c.GetViewHistoryPausedUntil()
+		}
+
 		ret = &ViewHistoryResult{
-			Count:        totalCount,
-			Items:        entries,
-			TotalOCount:  totalOCount,
+			Count:         totalCount,
+			Items:         entries,
+			TotalOCount:   totalOCount,
 			TotalOMGCount: totalOMGCount,
+			NextCursor:    nextCursor,
+			Paused:        paused,
+			PausedUntil:   pausedUntil,
 		}
 
 		return nil
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	defaultSlideshowCount = 5
+	maxSlideshowCount     = 50
+
+	// slideshowURLExpiry is how long the urls of slideshow images are valid
+	slideshowURLExpiry = time.Hour
+)
+
+func (r *queryResolver) GallerySlideshow(ctx context.Context, input GallerySlideshowInput) ([]*GallerySlideshowImage, error) {
+	galleryID, err := strconv.Atoi(input.GalleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	if input.Position < 0 {
+		return nil, errors.New("position must not be negative")
+	}
+
+	count := defaultSlideshowCount
+	if input.Count != nil {
+		count = *input.Count
+	}
+	if count < 1 || count > maxSlideshowCount {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxSlideshowCount)
+	}
+
+	mgr := manager.GetInstance()
+
+	var profile *image.ThumbnailProfile
+	if input.Profile != nil {
+		profile = image.FindThumbnailProfile(mgr.Config.GetImageThumbnailProfiles(), input.Profile.Key())
+		if profile == nil {
+			return nil, fmt.Errorf("thumbnail profile %s is not configured", input.Profile.Key())
+		}
+	}
+
+	loop := input.Loop != nil && *input.Loop
+
+	var images []*models.Image
+	var positions []int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		gated, err := r.contentGate().isGated(ctx, r.repository.Gallery, galleryID)
+		if err != nil || gated {
+			return err
+		}
+
+		total, err := r.repository.Image.CountByGalleryID(ctx, galleryID)
+		if err != nil {
+			return err
+		}
+
+		gate := r.contentGate()
+		for i := 1; i <= count; i++ {
+			position := input.Position + i
+			if loop && total > 0 {
+				position %= total
+			}
+			// stop after the last image, or at the current one when looping
+			if position >= total || (loop && position == input.Position) {
+				break
+			}
+
+			img, err := r.repository.Image.FindByGalleryIDIndex(ctx, galleryID, uint(position))
+			if err != nil {
+				return err
+			}
+			if img == nil {
+				continue
+			}
+
+			if gated, err := gate.isGated(ctx, r.repository.Image, img.ID); err != nil {
+				return err
+			} else if gated {
+				continue
+			}
+
+			if err := img.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+				return err
+			}
+
+			images = append(images, img)
+			positions = append(positions, position)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if profile != nil {
+		mgr.PrefetchImageThumbnails(images, *profile)
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	key := mgr.Config.GetJWTSignKey()
+	expires := time.Now().Add(slideshowURLExpiry)
+
+	ret := make([]*GallerySlideshowImage, len(images))
+	for i, img := range images {
+		// the urls are signed relative to the server root
+		builder := urlbuilders.NewImageURLBuilder("", img)
+		imageURL := builder.GetImageURL()
+		if profile != nil {
+			imageURL = builder.GetThumbnailProfileURL(profile.Key())
+		}
+
+		u, err := url.Parse(imageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		ret[i] = &GallerySlideshowImage{
+			ImageID:   strconv.Itoa(img.ID),
+			Position:  positions[i],
+			URL:       baseURL + signURL(key, *u, expires).String(),
+			ExpiresAt: expires,
+		}
+	}
+
+	return ret, nil
+}
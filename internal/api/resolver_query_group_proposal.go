@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/group"
+)
+
+func (r *queryResolver) GroupProposals(ctx context.Context) (*group.ProposalReport, error) {
+	return manager.GetInstance().GroupProposals(), nil
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) IdentifyScenePreview(ctx context.Context, sceneID string, input identify.Options) (*identify.Preview, error) {
+	idInt, err := strconv.Atoi(sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.PreviewIdentify(ctx, idInt, input)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
@@ -32,21 +33,83 @@ func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job,
 	return jobToJobModel(*j), nil
 }
 
+func (r *queryResolver) FindJobResults(ctx context.Context, input FindJobResultsInput) ([]*JobItemResult, error) {
+	jobID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	j := manager.GetInstance().JobManager.GetJob(jobID)
+	if j == nil {
+		return nil, fmt.Errorf("job with id %d not found", jobID)
+	}
+
+	ret := []*JobItemResult{}
+	for _, res := range j.Results {
+		if input.Status != nil && job.ItemStatus(*input.Status) != res.Status {
+			continue
+		}
+
+		ret = append(ret, &JobItemResult{
+			ID:     res.ID,
+			Status: JobItemStatus(res.Status),
+			Error:  res.Error,
+		})
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) JobStats(ctx context.Context) ([]*JobTypeStats, error) {
+	stats := manager.GetInstance().JobManager.GetStats()
+
+	ret := make([]*JobTypeStats, len(stats))
+	for i, s := range stats {
+		ret[i] = &JobTypeStats{
+			Type:           s.Type,
+			Runs:           s.Runs,
+			WallTime:       s.WallTime.Seconds(),
+			CPUTime:        s.CPUTime.Seconds(),
+			BytesProcessed: float64(s.BytesProcessed),
+			LastRun:        s.LastRun,
+		}
+	}
+
+	return ret, nil
+}
+
 func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
-		ID:          strconv.Itoa(j.ID),
-		Status:      JobStatus(j.Status),
-		Description: j.Description,
-		SubTasks:    j.Details,
-		StartTime:   j.StartTime,
-		EndTime:     j.EndTime,
-		AddTime:     j.AddTime,
-		Error:       j.Error,
+		ID:               strconv.Itoa(j.ID),
+		Status:           JobStatus(j.Status),
+		Description:      j.Description,
+		SubTasks:         j.Details,
+		StartTime:        j.StartTime,
+		EndTime:          j.EndTime,
+		AddTime:          j.AddTime,
+		Error:            j.Error,
+		Type:             j.Type,
+		BytesProcessed:   float64(j.BytesProcessed),
+		EstimatedEndTime: j.EstimatedEndTime,
+	}
+
+	for _, res := range j.Results {
+		switch res.Status {
+		case job.ItemStatusSucceeded:
+			ret.ItemsSucceeded++
+		case job.ItemStatusFailed:
+			ret.ItemsFailed++
+		}
 	}
 
 	if j.Progress != -1 {
 		ret.Progress = &j.Progress
 	}
 
+	if j.EndTime != nil {
+		cpuTime := j.CPUTime.Seconds()
+		ret.CPUTime = &cpuTime
+	}
+
 	return ret
 }
@@ -8,16 +8,5 @@ import (
 
 func (r *queryResolver) Logs(ctx context.Context) ([]*LogEntry, error) {
 	logger := manager.GetInstance().Logger
-	logCache := logger.GetLogCache()
-	ret := make([]*LogEntry, len(logCache))
-
-	for i, entry := range logCache {
-		ret[i] = &LogEntry{
-			Time:    entry.Time,
-			Level:   getLogLevel(entry.Type),
-			Message: entry.Message,
-		}
-	}
-
-	return ret, nil
+	return logEntriesFromLogItems(logger.GetLogCache()), nil
 }
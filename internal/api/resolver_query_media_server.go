@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/mediaserver"
+)
+
+func (r *queryResolver) MediaServerSyncReport(ctx context.Context) (*mediaserver.Report, error) {
+	return manager.GetInstance().MediaServerSyncReport(), nil
+}
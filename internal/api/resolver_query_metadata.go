@@ -4,8 +4,18 @@ import (
 	"context"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) Diagnostics(ctx context.Context) (*manager.DiagnosticsReport, error) {
+	return manager.GetInstance().RunDiagnostics(ctx), nil
+}
+
+func (r *queryResolver) PhashIndexStats(ctx context.Context) (*utils.PhashIndexStats, error) {
+	ret := manager.GetInstance().PhashIndex.Stats()
+	return &ret, nil
+}
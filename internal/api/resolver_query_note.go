@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindNotes(ctx context.Context, sceneID *string, performerID *string, studioID *string) (ret []*models.Note, err error) {
+	translator := changesetTranslator{}
+
+	var object models.NoteObject
+	object.SceneID, err = translator.intPtrFromString(sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	object.PerformerID, err = translator.intPtrFromString(performerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+	object.StudioID, err = translator.intPtrFromString(studioID)
+	if err != nil {
+		return nil, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Note.FindByObject(ctx, object)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
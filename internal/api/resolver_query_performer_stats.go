@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/activity"
+)
+
+func (r *queryResolver) PerformerStats(ctx context.Context, input *PerformerStatsInput) (*PerformerStatsResult, error) {
+	if input == nil {
+		input = &PerformerStatsInput{}
+	}
+
+	by := activity.PerformerStatsSortWatchTime
+	if input.Sort != nil {
+		by = *input.Sort
+	}
+
+	performerID := 0
+	if input.PerformerID != nil {
+		var err error
+		performerID, err = strconv.Atoi(*input.PerformerID)
+		if err != nil {
+			return nil, fmt.Errorf("converting performer id: %w", err)
+		}
+	}
+
+	if input.Limit != nil && *input.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+
+	loc, err := activityLocation(input.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().In(loc)
+
+	// the zero time includes all history
+	var start time.Time
+	if input.Days != nil {
+		start, err = activityStart(end, *input.Days)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var scenes []activity.SceneActivity
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		scenes, err = r.sceneActivity(ctx, start, end)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	stats := activity.NewPerformerStats(scenes, by)
+
+	ret := &PerformerStatsResult{
+		End:        end,
+		Count:      len(stats),
+		Performers: []*activity.PerformerStats{},
+	}
+	if input.Days != nil {
+		ret.Start = &start
+	}
+
+	for i := range stats {
+		if performerID != 0 && stats[i].PerformerID != performerID {
+			continue
+		}
+		if input.Limit != nil && len(ret.Performers) >= *input.Limit {
+			break
+		}
+
+		ret.Performers = append(ret.Performers, &stats[i])
+	}
+
+	return ret, nil
+}
+
+// sceneActivity returns the activity of the scenes viewed or with an
+// o-count in the range, with the performers of the scenes.
+func (r *queryResolver) sceneActivity(ctx context.Context, start, end time.Time) ([]activity.SceneActivity, error) {
+	qb := r.repository.Scene
+
+	views, err := qb.GetViewEventsInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	oEvents, err := qb.GetOEventsInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	bySceneID := make(map[int]*activity.SceneActivity)
+	get := func(id int) *activity.SceneActivity {
+		a, found := bySceneID[id]
+		if !found {
+			a = &activity.SceneActivity{SceneID: id}
+			bySceneID[id] = a
+		}
+		return a
+	}
+
+	for _, v := range views {
+		get(v.ID).Views++
+	}
+	for _, o := range oEvents {
+		get(o.ID).OCount++
+	}
+
+	ids := make([]int, 0, len(bySceneID))
+	for id := range bySceneID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	scenes, err := qb.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	totalViews, err := qb.GetManyViewCount(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]activity.SceneActivity, 0, len(scenes))
+	for i, s := range scenes {
+		if err := s.LoadPerformerIDs(ctx, qb); err != nil {
+			return nil, err
+		}
+
+		a := bySceneID[s.ID]
+		a.PerformerIDs = s.PerformerIDs.List()
+		a.WatchTime = activity.WatchTimeInRange(s.PlayDuration, a.Views, totalViews[i])
+		ret = append(ret, *a)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *queryResolver) ReencodePlan(ctx context.Context) (*scene.ReencodePlan, error) {
+	return manager.GetInstance().ReencodePlan(), nil
+}
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/retention"
+)
+
+func (r *queryResolver) RetentionReport(ctx context.Context) (*retention.Report, error) {
+	return manager.GetInstance().RetentionReport(), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -43,3 +44,23 @@ func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manage
 
 	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
 }
+
+func (r *queryResolver) SceneAudioTracks(ctx context.Context, sceneID string, fileID string) ([]*ffmpeg.AudioTrack, error) {
+	_, f, err := r.findSceneVideoFile(ctx, sceneID, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := manager.GetInstance().FFProbe.NewVideoFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading video file: %w", err)
+	}
+
+	tracks := probe.AudioTracks()
+	ret := make([]*ffmpeg.AudioTrack, len(tracks))
+	for i := range tracks {
+		ret[i] = &tracks[i]
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *queryResolver) CompareScenes(ctx context.Context, sceneA string, sceneB string) (*scene.Comparison, error) {
+	idA, err := strconv.Atoi(sceneA)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene a id: %w", err)
+	}
+
+	idB, err := strconv.Atoi(sceneB)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene b id: %w", err)
+	}
+
+	var ret scene.Comparison
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		a, markersA, err := r.findComparedScene(ctx, idA)
+		if err != nil {
+			return err
+		}
+
+		b, markersB, err := r.findComparedScene(ctx, idB)
+		if err != nil {
+			return err
+		}
+
+		ret = scene.Compare(a, b, markersA, markersB)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// findComparedScene returns the scene with its relationships loaded, and its
+// markers.
+func (r *queryResolver) findComparedScene(ctx context.Context, id int) (*models.Scene, []*models.SceneMarker, error) {
+	s, err := r.repository.Scene.Find(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == nil {
+		return nil, nil, fmt.Errorf("scene with id %d not found", id)
+	}
+
+	if err := s.LoadRelationships(ctx, r.repository.Scene); err != nil {
+		return nil, nil, fmt.Errorf("loading scene %d relationships: %w", id, err)
+	}
+
+	markers, err := r.repository.SceneMarker.FindBySceneID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding scene %d markers: %w", id, err)
+	}
+
+	return s, markers, nil
+}
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *queryResolver) SceneDateProposals(ctx context.Context) (*scene.DateProposalReport, error) {
+	return manager.GetInstance().DateProposals(), nil
+}
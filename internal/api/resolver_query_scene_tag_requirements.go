@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+func (r *queryResolver) SceneTagRequirements(ctx context.Context, id string) (ret *models.TagRequirements, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		s, err := qb.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", idInt)
+		}
+
+		tagIDs, err := qb.GetTagIDs(ctx, idInt)
+		if err != nil {
+			return err
+		}
+
+		performerTags, err := qb.GetPerformerTagIDs(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		for _, pt := range performerTags {
+			tagIDs = sliceutil.AppendUnique(tagIDs, pt.TagID)
+		}
+
+		tags, err := r.repository.Tag.FindMany(ctx, tagIDs)
+		if err != nil {
+			return err
+		}
+
+		colors := sliceutil.Map(tags, func(t *models.Tag) string {
+			return t.Color
+		})
+
+		presets, err := r.repository.ColorPreset.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		ret = models.EvaluateTagRequirements(presets, colors)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
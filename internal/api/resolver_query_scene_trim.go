@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *queryResolver) SceneTrimPreview(ctx context.Context, input models.TrimVideoInput) (*scene.TrimImpact, error) {
+	s, targetFile, err := r.findSceneVideoFile(ctx, input.SceneID, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTrimTimes(input, targetFile.Duration); err != nil {
+		return nil, err
+	}
+
+	start, end := trimTimes(input)
+	return manager.GetInstance().TrimImpact(ctx, s, targetFile, start, end)
+}
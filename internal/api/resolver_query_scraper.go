@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper"
@@ -18,6 +19,19 @@ func (r *queryResolver) ScrapeURL(ctx context.Context, url string, ty scraper.Sc
 	return r.scraperCache().ScrapeURL(ctx, url, ty)
 }
 
+func (r *queryResolver) TraceScraper(ctx context.Context, input scraper.ScraperTraceInput) (*scraper.ScraperTrace, error) {
+	return r.scraperCache().Trace(ctx, input)
+}
+
+func (r *queryResolver) ScraperCDPStatus(ctx context.Context) (*scraper.CDPStatus, error) {
+	status := r.scraperCache().CDPStatus()
+	return &status, nil
+}
+
+func (r *queryResolver) ReverseImageSearch(ctx context.Context, input manager.ReverseImageSearchInput) ([]*manager.ReverseImageMatch, error) {
+	return manager.GetInstance().ReverseImageSearch(ctx, input)
+}
+
 func (r *queryResolver) ListScrapers(ctx context.Context, types []scraper.ScrapeContentType) ([]*scraper.Scraper, error) {
 	return r.scraperCache().ListScrapers(types), nil
 }
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindShareLinks(ctx context.Context, sceneID *string, galleryID *string) (ret []*models.ShareLink, err error) {
+	translator := changesetTranslator{}
+
+	sceneIDInt, err := translator.intPtrFromString(sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	galleryIDInt, err := translator.intPtrFromString(galleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.ShareLink.FindByObject(ctx, sceneIDInt, galleryIDInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
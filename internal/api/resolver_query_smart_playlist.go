@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// playlistViewBatchSize is the number of scenes checked for views at once
+// when skipping watched scenes.
+const playlistViewBatchSize = 100
+
+func (r *queryResolver) FindSmartPlaylists(ctx context.Context) (ret []*models.SmartPlaylist, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SmartPlaylist.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindSmartPlaylist(ctx context.Context, id string) (ret *models.SmartPlaylist, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SmartPlaylist.Find(ctx, idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) NextInPlaylist(ctx context.Context, playlistID string, sceneID *string) (*SmartPlaylistItem, error) {
+	translator := changesetTranslator{}
+
+	playlistIDInt, err := strconv.Atoi(playlistID)
+	if err != nil {
+		return nil, fmt.Errorf("converting playlist id: %w", err)
+	}
+	current, err := translator.intPtrFromString(sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	var ret *SmartPlaylistItem
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		p, err := r.repository.SmartPlaylist.Find(ctx, playlistIDInt)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("smart playlist with id %d not found", playlistIDInt)
+		}
+
+		result, err := r.repository.Scene.Query(ctx, scene.QueryOptions(p.SceneFilter, p.FindFilter(), false))
+		if err != nil {
+			return err
+		}
+		ids := result.IDs
+
+		next := models.NextPlaylistIndex(ids, current)
+		if next != -1 && p.SkipWatched {
+			next, err = r.nextUnwatched(ctx, ids, next)
+			if err != nil {
+				return err
+			}
+		}
+		if next == -1 {
+			return nil
+		}
+
+		s, err := r.repository.Scene.Find(ctx, ids[next])
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", ids[next])
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+			return err
+		}
+
+		var duration float64
+		if f := s.Files.Primary(); f != nil {
+			duration = f.Duration
+		}
+
+		var markers []*models.SceneMarker
+		if p.UseMarkers {
+			markers, err = r.repository.SceneMarker.FindBySceneID(ctx, s.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		segment := p.Segment(duration, markers)
+		ret = &SmartPlaylistItem{
+			Scene:        s,
+			Index:        next,
+			Count:        len(ids),
+			StartSeconds: segment.Start,
+			EndSeconds:   segment.End,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// nextUnwatched returns the index of the first scene in ids from start which
+// has not been played, or -1 if all of them have been played.
+func (r *queryResolver) nextUnwatched(ctx context.Context, ids []int, start int) (int, error) {
+	for i := start; i < len(ids); i += playlistViewBatchSize {
+		batch := ids[i:min(i+playlistViewBatchSize, len(ids))]
+
+		counts, err := r.repository.Scene.GetManyViewCount(ctx, batch)
+		if err != nil {
+			return -1, err
+		}
+
+		for j, count := range counts {
+			if count == 0 {
+				return i + j, nil
+			}
+		}
+	}
+
+	return -1, nil
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindStashBoxSubmissions(ctx context.Context, stashBoxEndpoint string) (ret []*models.StashBoxSubmission, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.StashBoxSubmission.FindByEndpoint(ctx, stashBoxEndpoint)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
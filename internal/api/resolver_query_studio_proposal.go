@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+func (r *queryResolver) StudioProposals(ctx context.Context) (*studio.ProposalReport, error) {
+	return manager.GetInstance().StudioProposals(), nil
+}
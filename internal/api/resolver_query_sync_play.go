@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/syncplay"
+)
+
+func (r *queryResolver) SyncPlaySessions(ctx context.Context) ([]*syncplay.Session, error) {
+	sessions := manager.GetInstance().SyncPlay.Sessions(time.Now())
+
+	ret := make([]*syncplay.Session, len(sessions))
+	for i := range sessions {
+		ret[i] = &sessions[i]
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindSyncPlaySession(ctx context.Context, id string) (*syncplay.Session, error) {
+	s, err := manager.GetInstance().SyncPlay.Get(id)
+	if errors.Is(err, syncplay.ErrSessionNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+func (r *queryResolver) TempFiles(ctx context.Context) ([]*fsutil.TempEntry, error) {
+	entries, err := manager.GetInstance().TempFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return tempFilePointers(entries), nil
+}
+
+func tempFilePointers(entries []fsutil.TempEntry) []*fsutil.TempEntry {
+	ret := make([]*fsutil.TempEntry, len(entries))
+	for i := range entries {
+		ret[i] = &entries[i]
+	}
+	return ret
+}
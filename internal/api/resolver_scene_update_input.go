@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -45,6 +46,27 @@ func (r *sceneUpdateInputResolver) VideoTransforms(ctx context.Context, obj *mod
 	return nil
 }
 
+func (r *sceneUpdateInputResolver) TranscodeArgs(ctx context.Context, obj *models.SceneUpdateInput, data *TranscodeArgsInput) error {
+	if data == nil {
+		obj.TranscodeArgs = nil
+		return nil
+	}
+
+	if err := ffmpeg.ValidateExtraArgs(data.InputArgs); err != nil {
+		return fmt.Errorf("invalid transcode input args: %w", err)
+	}
+	if err := ffmpeg.ValidateExtraArgs(data.OutputArgs); err != nil {
+		return fmt.Errorf("invalid transcode output args: %w", err)
+	}
+
+	obj.TranscodeArgs = &models.TranscodeArgs{
+		InputArgs:  data.InputArgs,
+		OutputArgs: data.OutputArgs,
+	}
+
+	return nil
+}
+
 func (r *sceneUpdateInputResolver) AudioOffsetMs(ctx context.Context, obj *models.SceneUpdateInput, data *int) error {
 	obj.AudioOffsetMs = data
 	return nil
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/internal/log"
 	"github.com/stashapp/stash/internal/manager"
@@ -26,6 +27,36 @@ func getLogLevel(logType string) LogLevel {
 	}
 }
 
+// logFilterLevel returns the log level name used by the log package.
+func logFilterLevel(level LogLevel) string {
+	switch level {
+	case LogLevelTrace:
+		return "trace"
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelWarning:
+		return "warning"
+	case LogLevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+func logFilterFromInput(input *LogFilterInput) log.LogFilter {
+	var ret log.LogFilter
+	if input == nil {
+		return ret
+	}
+
+	if input.Level != nil {
+		ret.MinLevel = logFilterLevel(*input.Level)
+	}
+	ret.Subsystems = input.Subsystems
+
+	return ret
+}
+
 func logEntriesFromLogItems(logItems []log.LogItem) []*LogEntry {
 	ret := make([]*LogEntry, len(logItems))
 
@@ -35,6 +66,10 @@ func logEntriesFromLogItems(logItems []log.LogItem) []*LogEntry {
 			Level:   getLogLevel(entry.Type),
 			Message: entry.Message,
 		}
+
+		if subsystem := entry.Subsystem(); subsystem != "" {
+			ret[i].Subsystem = &subsystem
+		}
 	}
 
 	return ret
@@ -61,3 +96,45 @@ func (r *subscriptionResolver) LoggingSubscribe(ctx context.Context) (<-chan []*
 
 	return ret, nil
 }
+
+func (r *subscriptionResolver) Logs(ctx context.Context, filter *LogFilterInput) (<-chan []*LogEntry, error) {
+	ret := make(chan []*LogEntry, 100)
+	stop := make(chan int, 1)
+	logger := manager.GetInstance().Logger
+	logFilter := logFilterFromInput(filter)
+	logSub := logger.SubscribeToLog(stop)
+
+	// entries sent in the backfill may still be broadcast after subscribing
+	var backfillEnd time.Time
+	if filter != nil && filter.Backfill != nil && *filter.Backfill > 0 {
+		backfill := logger.GetRecentLogItems(logFilter, *filter.Backfill)
+		if len(backfill) > 0 {
+			backfillEnd = backfill[len(backfill)-1].Time
+			ret <- logEntriesFromLogItems(backfill)
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case logItems := <-logSub:
+				var matched []log.LogItem
+				for _, l := range logFilter.Filter(logItems) {
+					if l.Time.After(backfillEnd) {
+						matched = append(matched, l)
+					}
+				}
+
+				if len(matched) > 0 {
+					ret <- logEntriesFromLogItems(matched)
+				}
+			case <-ctx.Done():
+				stop <- 0
+				close(ret)
+				return
+			}
+		}
+	}()
+
+	return ret, nil
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/syncplay"
+)
+
+func (r *subscriptionResolver) SyncPlayJoin(ctx context.Context, sessionID string, name string) (<-chan *syncplay.Event, error) {
+	user := ""
+	if u := session.GetCurrentUserID(ctx); u != nil {
+		user = *u
+	}
+
+	events, err := manager.GetInstance().SyncPlay.Join(ctx, sessionID, name, user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// the events channel is closed when the member leaves the session
+	ret := make(chan *syncplay.Event, 100)
+	go func() {
+		defer close(ret)
+		for e := range events {
+			e := e
+			select {
+			case ret <- &e:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return ret, nil
+}
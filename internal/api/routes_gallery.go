@@ -18,6 +18,7 @@ import (
 
 type GalleryFinder interface {
 	models.GalleryGetter
+	models.TagIDLoader
 	FindByChecksum(ctx context.Context, checksum string) ([]*models.Gallery, error)
 }
 
@@ -33,6 +34,7 @@ type galleryRoutes struct {
 	galleryFinder GalleryFinder
 	imageFinder   GalleryImageFinder
 	fileGetter    models.FileGetter
+	gate          contentGate
 }
 
 func (rs galleryRoutes) Routes() chi.Router {
@@ -146,6 +148,12 @@ func (rs galleryRoutes) GalleryCtx(next http.Handler) http.Handler {
 				}
 			}
 
+			if gallery != nil {
+				if gated, err := rs.gate.isGated(ctx, qb, gallery.ID); err != nil || gated {
+					gallery = nil
+				}
+			}
+
 			return nil
 		})
 		if gallery == nil {
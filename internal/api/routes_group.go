@@ -16,6 +16,7 @@ import (
 
 type GroupFinder interface {
 	models.GroupGetter
+	models.TagIDLoader
 	GetFrontImage(ctx context.Context, groupID int) ([]byte, error)
 	GetBackImage(ctx context.Context, groupID int) ([]byte, error)
 }
@@ -23,6 +24,7 @@ type GroupFinder interface {
 type groupRoutes struct {
 	routes
 	groupFinder GroupFinder
+	gate        contentGate
 }
 
 func (rs groupRoutes) Routes() chi.Router {
@@ -100,6 +102,11 @@ func (rs groupRoutes) GroupCtx(next http.Handler) http.Handler {
 		var group *models.Group
 		_ = rs.withReadTxn(r, func(ctx context.Context) error {
 			group, _ = rs.groupFinder.Find(ctx, groupID)
+			if group != nil {
+				if gated, err := rs.gate.isGated(ctx, rs.groupFinder, group.ID); err != nil || gated {
+					group = nil
+				}
+			}
 			return nil
 		})
 		if group == nil {
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"net/http"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -13,7 +14,6 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/static"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
@@ -23,13 +23,19 @@ import (
 
 type ImageFinder interface {
 	models.ImageGetter
+	models.TagIDLoader
 	FindByChecksum(ctx context.Context, checksum string) ([]*models.Image, error)
 }
 
+// imageResizeCacheDir is the directory in the cache directory that resized
+// images are written to.
+const imageResizeCacheDir = "image_resize"
+
 type imageRoutes struct {
 	routes
 	imageFinder ImageFinder
 	fileGetter  models.FileGetter
+	gate        contentGate
 }
 
 func (rs imageRoutes) Routes() chi.Router {
@@ -38,6 +44,7 @@ func (rs imageRoutes) Routes() chi.Router {
 	r.Route("/{imageId}", func(r chi.Router) {
 		r.Use(rs.ImageCtx)
 
+		r.Get("/", rs.Resize)
 		r.Get("/image", rs.Image)
 		r.Get("/thumbnail", rs.Thumbnail)
 		r.Get("/preview", rs.Preview)
@@ -53,6 +60,29 @@ func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 
 func (rs imageRoutes) serveThumbnail(w http.ResponseWriter, r *http.Request, img *models.Image, modTime *time.Time) {
 	mgr := manager.GetInstance()
+
+	// serve the requested thumbnail profile, or the one best matching the
+	// requested size and the formats accepted by the client, if any are
+	// configured
+	profiles := mgr.Config.GetImageThumbnailProfiles()
+	if p := image.FindThumbnailProfile(profiles, r.URL.Query().Get("profile")); p != nil {
+		if rs.serveProfileThumbnail(w, r, img, *p, modTime) {
+			return
+		}
+	} else if len(profiles) > 0 {
+		w.Header().Add("Vary", "Accept")
+
+		size := models.DefaultGthumbWidth
+		if s, err := strconv.Atoi(r.URL.Query().Get("size")); err == nil && s > 0 {
+			size = s
+		}
+
+		p := image.SelectThumbnailProfile(profiles, size, r.Header.Get("Accept"))
+		if p != nil && rs.serveProfileThumbnail(w, r, img, *p, modTime) {
+			return
+		}
+	}
+
 	filepath := mgr.Paths.Generated.GetThumbnailPath(img.Checksum, models.DefaultGthumbWidth)
 
 	// if the thumbnail doesn't exist, encode on the fly
@@ -115,6 +145,56 @@ func (rs imageRoutes) serveThumbnail(w http.ResponseWriter, r *http.Request, img
 	}
 }
 
+// serveProfileThumbnail serves the thumbnail of the image for the profile,
+// generating it if it does not exist. Returns false if the thumbnail could
+// not be generated, in which case the default thumbnail should be served.
+func (rs imageRoutes) serveProfileThumbnail(w http.ResponseWriter, r *http.Request, img *models.Image, profile image.ThumbnailProfile, modTime *time.Time) bool {
+	mgr := manager.GetInstance()
+	filepath := mgr.Paths.Generated.GetThumbnailProfilePath(img.Checksum, profile.Key(), profile.Format.Extension())
+
+	exists, _ := fsutil.FileExists(filepath)
+	if exists {
+		if modTime == nil {
+			utils.ServeStaticFile(w, r, filepath)
+		} else {
+			utils.ServeStaticFileModTime(w, r, filepath, *modTime)
+		}
+		return true
+	}
+
+	f := img.Files.Primary()
+	if f == nil {
+		return false
+	}
+
+	// use the image thumbnail generate wait group to limit the number of concurrent thumbnail generation tasks
+	wg := &mgr.ImageThumbnailGenerateWaitGroup
+	wg.Add()
+	defer wg.Done()
+
+	encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, image.ClipPreviewOptions{})
+	data, err := encoder.GetProfileThumbnail(f, profile)
+	if err != nil {
+		if !errors.Is(err, image.ErrNotSupportedForThumbnail) && !errors.Is(err, fs.ErrNotExist) {
+			logger.Errorf("error generating %s thumbnail for %s: %v", profile.Key(), f.Base().Path, err)
+		}
+		return false
+	}
+
+	if mgr.Config.IsWriteImageThumbnails() {
+		if err := fsutil.WriteFile(filepath, data); err == nil {
+			utils.ServeStaticFile(w, r, filepath)
+			return true
+		}
+		logger.Errorf("error writing %s thumbnail for image %s: %v", profile.Key(), img.Path, err)
+	}
+
+	// the content type of avif is not detected from the data
+	w.Header().Set("Content-Type", profile.Format.MimeType())
+	utils.ServeStaticContent(w, r, data)
+	return true
+}
+
 func (rs imageRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	img := r.Context().Value(imageKey).(*models.Image)
 	filepath := manager.GetInstance().Paths.Generated.GetClipPreviewPath(img.Checksum, models.DefaultGthumbWidth)
@@ -130,9 +210,80 @@ func (rs imageRoutes) Image(w http.ResponseWriter, r *http.Request) {
 	rs.serveImage(w, r, i, useDefault)
 }
 
+// Resize serves the image resized and converted to the dimensions and format
+// of the w, h and fmt query parameters, or the original image if none are
+// set. Resized images are cached in the cache directory.
+func (rs imageRoutes) Resize(w http.ResponseWriter, r *http.Request) {
+	i := r.Context().Value(imageKey).(*models.Image)
+
+	options, err := image.ParseResizeOptions(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if options == nil {
+		const useDefault = false
+		rs.serveImage(w, r, i, useDefault)
+		return
+	}
+
+	f := i.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	// the checksum changes when the image file does, so the etag only needs
+	// to identify the image and options
+	key := options.Key()
+	w.Header().Set("ETag", `"`+i.Checksum+"_"+key+`"`)
+	w.Header().Set("Content-Type", options.Format.MimeType())
+
+	mgr := manager.GetInstance()
+
+	var cachePath string
+	if cacheDir := mgr.Config.GetCachePath(); cacheDir != "" {
+		cachePath = filepath.Join(cacheDir, imageResizeCacheDir, i.Checksum+"_"+key+"."+options.Format.Extension())
+
+		if exists, _ := fsutil.FileExists(cachePath); exists {
+			utils.ServeStaticFile(w, r, cachePath)
+			return
+		}
+	}
+
+	// use the image thumbnail generate wait group to limit the number of concurrent thumbnail generation tasks
+	wg := &mgr.ImageThumbnailGenerateWaitGroup
+	wg.Add()
+	defer wg.Done()
+
+	encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, image.ClipPreviewOptions{})
+	data, err := encoder.GetResized(f, *options)
+	if err != nil {
+		switch {
+		case errors.Is(err, image.ErrNotSupportedForThumbnail):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, fs.ErrNotExist):
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		default:
+			logger.Errorf("error resizing %s to %s: %v", f.Base().Path, key, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if cachePath != "" {
+		if err := fsutil.WriteFile(cachePath, data); err != nil {
+			logger.Errorf("error caching resized image %s: %v", cachePath, err)
+		}
+	}
+
+	utils.ServeStaticContent(w, r, data)
+}
+
 func (rs imageRoutes) serveImage(w http.ResponseWriter, r *http.Request, i *models.Image, useDefault bool) {
 	if i.Files.Primary() != nil {
-		err := i.Files.Primary().Base().Serve(&file.OsFS{}, w, r)
+		err := i.Files.Primary().Base().Serve(manager.GetInstance().StreamFS(), w, r)
 		if err == nil {
 			return
 		}
@@ -183,6 +334,12 @@ func (rs imageRoutes) ImageCtx(next http.Handler) http.Handler {
 				}
 			}
 
+			if image != nil {
+				if gated, err := rs.gate.isGated(ctx, qb, image.ID); err != nil || gated {
+					image = nil
+				}
+			}
+
 			return nil
 		})
 		if image == nil {
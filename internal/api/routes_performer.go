@@ -15,6 +15,7 @@ import (
 
 type PerformerFinder interface {
 	models.PerformerGetter
+	models.TagIDLoader
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
 	GetProfileImage(ctx context.Context, performerID int, imageID int) ([]byte, error)
 	FindProfileImage(ctx context.Context, performerID int, imageID int) (*models.PerformerProfileImage, error)
@@ -23,6 +24,7 @@ type PerformerFinder interface {
 type performerRoutes struct {
 	routes
 	performerFinder PerformerFinder
+	gate            contentGate
 }
 
 func (rs performerRoutes) Routes() chi.Router {
@@ -171,6 +173,14 @@ func (rs performerRoutes) PerformerCtx(next http.Handler) http.Handler {
 		_ = rs.withReadTxn(r, func(ctx context.Context) error {
 			var err error
 			performer, err = rs.performerFinder.Find(ctx, performerID)
+			if err != nil || performer == nil {
+				return err
+			}
+
+			gated, err := rs.gate.isGated(ctx, rs.performerFinder, performer.ID)
+			if gated {
+				performer = nil
+			}
 			return err
 		})
 		if performer == nil {
@@ -3,13 +3,17 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io/fs"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
@@ -22,6 +26,7 @@ import (
 
 type SceneFinder interface {
 	models.SceneGetter
+	models.TagIDLoader
 
 	FindByChecksum(ctx context.Context, checksum string) ([]*models.Scene, error)
 	FindByOSHash(ctx context.Context, oshash string) ([]*models.Scene, error)
@@ -38,6 +43,10 @@ type SceneMarkerTagFinder interface {
 	FindBySceneMarkerID(ctx context.Context, sceneMarkerID int) ([]*models.Tag, error)
 }
 
+type SceneExtraFinder interface {
+	FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneExtra, error)
+}
+
 type CaptionFinder interface {
 	GetCaptions(ctx context.Context, fileID models.FileID) ([]*models.VideoCaption, error)
 }
@@ -48,7 +57,9 @@ type sceneRoutes struct {
 	fileGetter        models.FileGetter
 	captionFinder     CaptionFinder
 	sceneMarkerFinder SceneMarkerFinder
+	extraFinder       SceneExtraFinder
 	tagFinder         SceneMarkerTagFinder
+	gate              contentGate
 }
 
 func (rs sceneRoutes) Routes() chi.Router {
@@ -70,21 +81,27 @@ func (rs sceneRoutes) Routes() chi.Router {
 
 		r.Get("/screenshot", rs.Screenshot)
 		r.Get("/preview", rs.Preview)
+		r.Get("/wall_preview", rs.WallPreview)
 		r.Get("/webp", rs.Webp)
 		r.Get("/vtt/chapter", rs.VttChapter)
 		r.Get("/vtt/thumbs", rs.VttThumbs)
 		r.Get("/vtt/sprite", rs.VttSprite)
+		r.Get("/vtt/sprite.json", rs.VttSpriteJSON)
 		r.Get("/funscript", rs.Funscript)
 		r.Get("/interactive_csv", rs.InteractiveCSV)
 		r.Get("/interactive_heatmap", rs.InteractiveHeatmap)
 		r.Get("/caption", rs.CaptionLang)
 
+		r.Get("/extra/{role}/stream", rs.StreamExtra)
+
 		r.Get("/scene_marker/{sceneMarkerId}/stream", rs.SceneMarkerStream)
 		r.Get("/scene_marker/{sceneMarkerId}/preview", rs.SceneMarkerPreview)
 		r.Get("/scene_marker/{sceneMarkerId}/screenshot", rs.SceneMarkerScreenshot)
+		r.Get("/scene_marker/{sceneMarkerId}/thumbnail", rs.SceneMarkerThumbnail)
 	})
 	r.Get("/{sceneHash}_thumbs.vtt", rs.VttThumbs)
 	r.Get("/{sceneHash}_sprite.jpg", rs.VttSprite)
+	r.Get("/{sceneHash}_sprite.webp", rs.VttSpriteWebp)
 
 	return r
 }
@@ -154,10 +171,19 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	resolution := r.Form.Get("resolution")
 
 	options := ffmpeg.TranscodeOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		StartTime:  ss,
+		StreamType:    streamType,
+		VideoFile:     f,
+		Resolution:    resolution,
+		StartTime:     ss,
+		TranscodeArgs: scene.TranscodeArgs,
+		Deinterlace:   sceneDeinterlaceFilter(scene, f),
+	}
+
+	// apply the saved video filters when requested, for players that cannot
+	// apply them on the client
+	if applyFilters, _ := strconv.ParseBool(r.Form.Get("filters")); applyFilters {
+		options.VideoFilters = scene.VideoFilters
+		options.VideoTransforms = scene.VideoTransforms
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
@@ -232,16 +258,24 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	resolution := r.Form.Get("resolution")
 
 	options := ffmpeg.StreamOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		Hash:       sceneHash,
-		Segment:    segment,
+		StreamType:    streamType,
+		VideoFile:     f,
+		Resolution:    resolution,
+		Hash:          sceneHash,
+		Segment:       segment,
+		TranscodeArgs: scene.TranscodeArgs,
+		Deinterlace:   sceneDeinterlaceFilter(scene, f),
 	}
 
 	streamManager.ServeSegment(w, r, options)
 }
 
+// sceneDeinterlaceFilter returns the filter used to deinterlace the scene
+// file when transcoding, or an empty filter if it is not deinterlaced.
+func sceneDeinterlaceFilter(scene *models.Scene, f *models.VideoFile) models.DeinterlaceFilter {
+	return ffmpeg.DeinterlaceFilterFor(f.Interlaced, scene.Deinterlace, config.GetInstance().GetDeinterlaceFilter())
+}
+
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -260,6 +294,20 @@ func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+// WallPreview serves the lightweight wall preview of the scene, falling back
+// to the preview video if it has not been generated.
+func (rs sceneRoutes) WallPreview(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	filepath := manager.GetInstance().Paths.Scene.GetWallPreviewPath(sceneHash)
+
+	if exists, _ := fsutil.FileExists(filepath); !exists {
+		filepath = manager.GetInstance().Paths.Scene.GetVideoPreviewPath(sceneHash)
+	}
+
+	utils.ServeStaticFile(w, r, filepath)
+}
+
 func (rs sceneRoutes) Webp(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
@@ -352,6 +400,17 @@ func (rs sceneRoutes) VttThumbs(w http.ResponseWriter, r *http.Request) {
 	}
 	filepath := manager.GetInstance().Paths.Scene.GetSpriteVttFilePath(sceneHash)
 
+	// the scrubber requests the vtt file first, so a missing sprite is
+	// generated here
+	if exists, _ := fsutil.FileExists(filepath); !exists && config.GetInstance().GetSpriteOnDemand() {
+		if scene == nil {
+			scene = rs.findSceneByHash(r, sceneHash)
+		}
+		if scene != nil && !rs.generateSpriteOnDemand(w, r, scene) {
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "text/vtt")
 	utils.ServeStaticFile(w, r, filepath)
 }
@@ -364,11 +423,131 @@ func (rs sceneRoutes) VttSprite(w http.ResponseWriter, r *http.Request) {
 	} else {
 		sceneHash = chi.URLParam(r, "sceneHash")
 	}
-	filepath := manager.GetInstance().Paths.Scene.GetSpriteImageFilePath(sceneHash)
+	var filepath string
+	if ok && scene != nil {
+		filepath, _ = manager.FindSpriteImageFilePath(manager.GetInstance().Paths.Scene, sceneHash, config.GetInstance().GetSpriteFormat())
+	} else {
+		filepath = manager.GetInstance().Paths.Scene.GetSpriteImageFilePath(sceneHash)
+	}
+
+	utils.ServeStaticFile(w, r, filepath)
+}
+
+func (rs sceneRoutes) VttSpriteWebp(w http.ResponseWriter, r *http.Request) {
+	sceneHash := chi.URLParam(r, "sceneHash")
+	filepath := manager.GetInstance().Paths.Scene.GetSpriteWebpImageFilePath(sceneHash)
 
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+// findSceneByHash returns the scene with the hash of the configured file
+// naming algorithm, with its primary file loaded. Returns nil if there is no
+// such scene or it is hidden by the content gate.
+func (rs sceneRoutes) findSceneByHash(r *http.Request, sceneHash string) *models.Scene {
+	var ret *models.Scene
+	_ = rs.withReadTxn(r, func(ctx context.Context) error {
+		var scenes []*models.Scene
+		var err error
+		if config.GetInstance().GetVideoFileNamingAlgorithm() == models.HashAlgorithmMd5 {
+			scenes, err = rs.sceneFinder.FindByChecksum(ctx, sceneHash)
+		} else {
+			scenes, err = rs.sceneFinder.FindByOSHash(ctx, sceneHash)
+		}
+		if err != nil || len(scenes) == 0 {
+			return err
+		}
+
+		scene := scenes[0]
+		if err := scene.LoadPrimaryFile(ctx, rs.fileGetter); err != nil {
+			return err
+		}
+
+		if gated, err := rs.gate.isGated(ctx, rs.sceneFinder, scene.ID); err != nil || gated {
+			return err
+		}
+
+		ret = scene
+		return nil
+	})
+
+	return ret
+}
+
+// generateSpriteOnDemand generates a reduced sprite for the scene if it does
+// not have one. Returns false if an error response was written because the
+// sprite cannot be generated now.
+func (rs sceneRoutes) generateSpriteOnDemand(w http.ResponseWriter, r *http.Request, scene *models.Scene) bool {
+	err := manager.GetInstance().GenerateSpriteOnDemand(r.Context(), scene)
+	switch {
+	case err == nil, errors.Is(err, manager.ErrSpriteGenerationFailed), errors.Is(err, context.Canceled):
+	case errors.Is(err, manager.ErrSpriteGenerationBusy):
+		w.Header().Set("Retry-After", "10")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return false
+	default:
+		logger.Warnf("error generating sprite for scene %d: %v", scene.ID, err)
+	}
+
+	return true
+}
+
+// spriteMetadata describes the thumbnails of a scene sprite image.
+type spriteMetadata struct {
+	Image      string               `json:"image"`
+	Thumbnails []utils.VTTThumbnail `json:"thumbnails"`
+}
+
+// VttSpriteJSON returns the sprite image URL and the timestamps and
+// coordinates of each thumbnail in the sprite image as JSON.
+func (rs sceneRoutes) VttSpriteJSON(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	c := config.GetInstance()
+	scenePaths := manager.GetInstance().Paths.Scene
+	sceneHash := scene.GetHash(c.GetVideoFileNamingAlgorithm())
+
+	if !rs.generateSpriteOnDemand(w, r, scene) {
+		return
+	}
+
+	f, err := os.Open(scenePaths.GetSpriteVttFilePath(sceneHash))
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	thumbnails, err := utils.ParseVTTThumbnails(f)
+	if err != nil {
+		logger.Warnf("error parsing sprite vtt for scene %d: %v", scene.ID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// thumbnail images are relative to the vtt file, so replace them with the image url
+	_, format := manager.FindSpriteImageFilePath(scenePaths, sceneHash, c.GetSpriteFormat())
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+	image := urlbuilders.NewSceneURLBuilder(baseURL, scene).GetSpriteURL(sceneHash, format)
+	for i := range thumbnails {
+		thumbnails[i].Image = image
+	}
+
+	data, err := json.Marshal(spriteMetadata{
+		Image:      image,
+		Thumbnails: thumbnails,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	utils.ServeStaticContent(w, r, data)
+}
+
 func (rs sceneRoutes) Funscript(w http.ResponseWriter, r *http.Request) {
 	s := r.Context().Value(sceneKey).(*models.Scene)
 	filepath := video.GetFunscriptPath(s.Path)
@@ -459,6 +638,58 @@ func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 	rs.Caption(w, r, l, ext)
 }
 
+// StreamExtra streams the extra file of the role in the url. The file_id
+// parameter selects between extras of the same role, defaulting to the first.
+func (rs sceneRoutes) StreamExtra(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	role := models.SceneExtraRole(strings.ToUpper(chi.URLParam(r, "role")))
+	fileID, _ := strconv.Atoi(r.URL.Query().Get("file_id"))
+
+	var f *models.VideoFile
+	readTxnErr := rs.withReadTxn(r, func(ctx context.Context) error {
+		extras, err := rs.extraFinder.FindBySceneID(ctx, scene.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range extras {
+			if e.Role != role || (fileID != 0 && e.FileID != models.FileID(fileID)) {
+				continue
+			}
+
+			files, err := rs.fileGetter.Find(ctx, e.FileID)
+			if err != nil {
+				return err
+			}
+			if len(files) > 0 {
+				f, _ = files[0].(*models.VideoFile)
+			}
+			return nil
+		}
+
+		return nil
+	})
+	if errors.Is(readTxnErr, context.Canceled) {
+		return
+	}
+	if readTxnErr != nil {
+		logger.Warnf("read transaction error on fetch scene extra: %v", readTxnErr)
+		http.Error(w, readTxnErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if f == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	ss := manager.SceneServer{
+		TxnManager:       rs.txnManager,
+		SceneCoverGetter: rs.sceneFinder,
+	}
+	ss.StreamExtraDirect(f, w, r)
+}
+
 func (rs sceneRoutes) SceneMarkerStream(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
@@ -559,6 +790,49 @@ func (rs sceneRoutes) SceneMarkerScreenshot(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// SceneMarkerThumbnail serves the thumbnail of the marker, falling back to
+// its screenshot if no thumbnail has been generated.
+func (rs sceneRoutes) SceneMarkerThumbnail(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	sceneMarkerID, _ := strconv.Atoi(chi.URLParam(r, "sceneMarkerId"))
+	var sceneMarker *models.SceneMarker
+	readTxnErr := rs.withReadTxn(r, func(ctx context.Context) error {
+		var err error
+		sceneMarker, err = rs.sceneMarkerFinder.Find(ctx, sceneMarkerID)
+		return err
+	})
+	if errors.Is(readTxnErr, context.Canceled) {
+		return
+	}
+	if readTxnErr != nil {
+		logger.Warnf("read transaction error on fetch scene marker thumbnail: %v", readTxnErr)
+		http.Error(w, readTxnErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if sceneMarker == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	markerPaths := manager.GetInstance().Paths.SceneMarkers
+	seconds := int(sceneMarker.Seconds)
+	for _, filepath := range []string{
+		markerPaths.GetThumbnailPath(sceneHash, seconds),
+		markerPaths.GetScreenshotPath(sceneHash, seconds),
+	} {
+		if exists, _ := fsutil.FileExists(filepath); exists {
+			utils.ServeStaticFile(w, r, filepath)
+			return
+		}
+	}
+
+	// If the image doesn't exist, send the placeholder
+	w.Header().Set("Content-Type", "image/png")
+	utils.ServeStaticContent(w, r, utils.PendingGenerateResource)
+}
+
 func (rs sceneRoutes) SceneCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sceneID, err := strconv.Atoi(chi.URLParam(r, "sceneId"))
@@ -582,6 +856,12 @@ func (rs sceneRoutes) SceneCtx(next http.Handler) http.Handler {
 				}
 			}
 
+			if scene != nil {
+				if gated, err := rs.gate.isGated(ctx, qb, scene.ID); err != nil || gated {
+					scene = nil
+				}
+			}
+
 			return nil
 		})
 		if scene == nil {
@@ -0,0 +1,400 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const shareEndpoint = "/share"
+
+// shareViewDuration is how long the files of a link with a view limit can be
+// loaded after the page is viewed.
+const shareViewDuration = 3 * time.Hour
+
+type ShareLinkFinder interface {
+	FindByToken(ctx context.Context, token string) (*models.ShareLink, error)
+	IncrementViewCount(ctx context.Context, id int) (bool, error)
+}
+
+type ShareSceneFinder interface {
+	models.SceneGetter
+	models.TagIDLoader
+}
+
+type ShareGalleryFinder interface {
+	models.GalleryGetter
+	models.TagIDLoader
+}
+
+type ShareImageFinder interface {
+	models.ImageGetter
+	models.GalleryIDLoader
+	models.TagIDLoader
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.Image, error)
+}
+
+// shareRoutes serves the shared scenes and galleries. These routes do not
+// require authentication, so only the shared object and its files are
+// accessible through them.
+type shareRoutes struct {
+	routes
+	imageRoutes     imageRoutes
+	shareLinkFinder ShareLinkFinder
+	sceneFinder     ShareSceneFinder
+	galleryFinder   ShareGalleryFinder
+	imageFinder     ShareImageFinder
+	fileGetter      models.FileGetter
+	gate            contentGate
+	// signKey returns the key signing the file urls of links with a view
+	// limit.
+	signKey func() []byte
+}
+
+func (rs shareRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{token}", func(r chi.Router) {
+		r.Use(rs.ShareLinkCtx)
+
+		r.Get("/", rs.Page)
+
+		r.Group(func(r chi.Router) {
+			r.Use(rs.ShareViewCtx)
+
+			r.Get("/stream", rs.Stream)
+			r.Get("/download", rs.Download)
+			r.Get("/image/{imageId}", rs.Image)
+			r.Get("/thumbnail/{imageId}", rs.Thumbnail)
+		})
+	})
+
+	return r
+}
+
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<style>
+body { background: #202b33; color: #f5f8fa; font-family: sans-serif; margin: 1rem; }
+a { color: #48aff0; }
+video { max-width: 100%; max-height: 80vh; }
+.images { display: flex; flex-wrap: wrap; gap: 0.5rem; }
+.images img { height: 200px; }
+</style>
+</head>
+<body>
+<h2>{{.Title}}</h2>
+{{if .StreamURL}}
+<video src="{{.StreamURL}}" controls></video>
+{{end}}
+{{if .DownloadURL}}
+<p><a href="{{.DownloadURL}}">Download</a></p>
+{{end}}
+<div class="images">
+{{range .Images}}
+<a href="{{.URL}}"{{if $.AllowDownload}} download{{end}}><img src="{{.ThumbnailURL}}" loading="lazy"></a>
+{{end}}
+</div>
+</body>
+</html>
+`))
+
+type sharePageImage struct {
+	URL          string
+	ThumbnailURL string
+}
+
+type sharePageData struct {
+	Title         string
+	StreamURL     string
+	DownloadURL   string
+	AllowDownload bool
+	Images        []sharePageImage
+}
+
+// Page renders the shared object. Opening the page counts as a view. The
+// media urls of links with a view limit are signed, so that they are only
+// served to the views of the page.
+func (rs shareRoutes) Page(w http.ResponseWriter, r *http.Request) {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+
+	prefix := getProxyPrefix(r)
+	base := shareEndpoint + "/" + link.Token
+	expires := time.Now().Add(shareViewDuration)
+	mediaURL := func(path string) string {
+		u := url.URL{Path: base + path}
+		if link.MaxViews != nil {
+			return prefix + signURL(rs.signKey(), u, expires).String()
+		}
+		return prefix + u.String()
+	}
+
+	data := sharePageData{
+		AllowDownload: link.AllowDownload,
+	}
+
+	if err := txn.WithTxn(r.Context(), rs.txnManager, func(ctx context.Context) error {
+		viewed, err := rs.shareLinkFinder.IncrementViewCount(ctx, link.ID)
+		if err != nil {
+			return err
+		}
+		if !viewed {
+			return models.ErrShareLinkViewLimit
+		}
+
+		return rs.loadPageData(ctx, link, mediaURL, &data)
+	}); err != nil {
+		if errors.Is(err, models.ErrShareLinkViewLimit) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+
+		logger.Errorf("error rendering share link %d: %v", link.ID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := sharePageTemplate.Execute(w, data); err != nil {
+		logger.Errorf("error rendering share link %d: %v", link.ID, err)
+	}
+}
+
+// loadPageData loads the shared object into data. mediaURL returns the url
+// of the path relative to the link.
+func (rs shareRoutes) loadPageData(ctx context.Context, link *models.ShareLink, mediaURL func(path string) string, data *sharePageData) error {
+	if link.SceneID != nil {
+		s, err := rs.sceneFinder.Find(ctx, *link.SceneID)
+		if err != nil || s == nil {
+			return err
+		}
+
+		data.Title = s.GetTitle()
+		data.StreamURL = mediaURL("/stream")
+		if link.AllowDownload {
+			data.DownloadURL = mediaURL("/download")
+		}
+		return nil
+	}
+
+	g, err := rs.galleryFinder.Find(ctx, *link.GalleryID)
+	if err != nil || g == nil {
+		return err
+	}
+
+	images, err := rs.imageFinder.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return err
+	}
+
+	images, err = filterGated(ctx, rs.gate, rs.imageFinder, images, func(i *models.Image) int { return i.ID })
+	if err != nil {
+		return err
+	}
+
+	data.Title = g.GetTitle()
+	for _, i := range images {
+		id := strconv.Itoa(i.ID)
+		data.Images = append(data.Images, sharePageImage{
+			URL:          mediaURL("/image/" + id),
+			ThumbnailURL: mediaURL("/thumbnail/" + id),
+		})
+	}
+
+	return nil
+}
+
+func (rs shareRoutes) Stream(w http.ResponseWriter, r *http.Request) {
+	rs.serveScene(w, r, false)
+}
+
+func (rs shareRoutes) Download(w http.ResponseWriter, r *http.Request) {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+	if !link.AllowDownload {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	rs.serveScene(w, r, true)
+}
+
+func (rs shareRoutes) serveScene(w http.ResponseWriter, r *http.Request, attachment bool) {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+	if link.SceneID == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	var f *models.VideoFile
+	_ = rs.withReadTxn(r, func(ctx context.Context) error {
+		s, err := rs.sceneFinder.Find(ctx, *link.SceneID)
+		if err != nil || s == nil {
+			return err
+		}
+
+		if err := s.LoadPrimaryFile(ctx, rs.fileGetter); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Errorf("error loading primary file for scene %d: %v", s.ID, err)
+			}
+			return nil
+		}
+
+		f = s.Files.Primary()
+		return nil
+	})
+
+	if f == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	if attachment {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, f.Basename))
+	}
+
+	if err := f.Serve(manager.GetInstance().StreamFS(), w, r); err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	}
+}
+
+func (rs shareRoutes) Image(w http.ResponseWriter, r *http.Request) {
+	img := rs.galleryImage(r)
+	if img == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	const useDefault = false
+	rs.imageRoutes.serveImage(w, r, img, useDefault)
+}
+
+func (rs shareRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
+	img := rs.galleryImage(r)
+	if img == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	rs.imageRoutes.serveThumbnail(w, r, img, nil)
+}
+
+// galleryImage returns the image in the url if it belongs to the shared
+// gallery, with its primary file loaded. Returns nil otherwise.
+func (rs shareRoutes) galleryImage(r *http.Request) *models.Image {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+	if link.GalleryID == nil {
+		return nil
+	}
+
+	imageID, err := strconv.Atoi(chi.URLParam(r, "imageId"))
+	if err != nil {
+		return nil
+	}
+
+	var ret *models.Image
+	_ = rs.withReadTxn(r, func(ctx context.Context) error {
+		img, err := rs.imageFinder.Find(ctx, imageID)
+		if err != nil || img == nil {
+			return err
+		}
+
+		if err := img.LoadGalleryIDs(ctx, rs.imageFinder); err != nil {
+			return err
+		}
+		if !slices.Contains(img.GalleryIDs.List(), *link.GalleryID) {
+			return nil
+		}
+
+		if gated, err := rs.gate.isGated(ctx, rs.imageFinder, img.ID); err != nil || gated {
+			return err
+		}
+
+		if err := img.LoadPrimaryFile(ctx, rs.fileGetter); err != nil {
+			return err
+		}
+		if img.Files.Primary() == nil {
+			return nil
+		}
+
+		ret = img
+		return nil
+	})
+
+	return ret
+}
+
+// ShareLinkCtx loads the link of the token. Expired links, and links to
+// objects hidden by the content gate, are treated as not found. The view limit is checked when opening the page, and by
+// ShareViewCtx for its files.
+func (rs shareRoutes) ShareLinkCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := chi.URLParam(r, "token")
+
+		var link *models.ShareLink
+		_ = rs.withReadTxn(r, func(ctx context.Context) error {
+			found, err := rs.shareLinkFinder.FindByToken(ctx, token)
+			if err != nil || found == nil {
+				return err
+			}
+
+			if gated, err := rs.isGated(ctx, found); err != nil || gated {
+				return err
+			}
+
+			link = found
+			return nil
+		})
+		if link == nil || link.Expired(time.Now()) {
+			http.Error(w, http.StatusText(404), 404)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), shareLinkKey, link)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ShareViewCtx serves the files of links with a view limit only if the url
+// was signed by Page, so that the files can be loaded for a while after
+// each view, but not without viewing the page.
+func (rs shareRoutes) ShareViewCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+
+		if link.MaxViews != nil && !validSignedURL(rs.signKey(), r.URL, time.Now()) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isGated returns true if the shared scene or gallery is hidden by the
+// content gate.
+func (rs shareRoutes) isGated(ctx context.Context, link *models.ShareLink) (bool, error) {
+	if link.SceneID != nil {
+		return rs.gate.isGated(ctx, rs.sceneFinder, *link.SceneID)
+	}
+
+	return rs.gate.isGated(ctx, rs.galleryFinder, *link.GalleryID)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareRoutes_ShareViewCtx(t *testing.T) {
+	key := []byte("key")
+	rs := shareRoutes{signKey: func() []byte { return key }}
+
+	handler := rs.ShareViewCtx(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	maxViews := 1
+	stream := url.URL{Path: shareEndpoint + "/token/stream"}
+	signed := signURL(key, stream, time.Now().Add(time.Hour))
+	expired := signURL(key, stream, time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name string
+		link *models.ShareLink
+		url  *url.URL
+		want int
+	}{
+		{"no view limit", &models.ShareLink{}, &stream, http.StatusOK},
+		{"view limit unsigned", &models.ShareLink{MaxViews: &maxViews}, &stream, http.StatusForbidden},
+		{"view limit signed", &models.ShareLink{MaxViews: &maxViews}, signed, http.StatusOK},
+		{"view limit expired", &models.ShareLink{MaxViews: &maxViews}, expired, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url.String(), nil)
+			r = r.WithContext(context.WithValue(r.Context(), shareLinkKey, tt.link))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"path"
@@ -51,6 +52,7 @@ const (
 
 type Server struct {
 	http.Server
+	addresses      []string
 	displayAddress string
 
 	manager *manager.Manager
@@ -86,13 +88,17 @@ func Initialize() (*Server, error) {
 
 	initCustomPerformerImages(cfg.GetCustomPerformerImageLocation())
 
-	displayHost := cfg.GetHost()
-	if displayHost == "0.0.0.0" {
+	addresses, err := getListenAddresses(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving bind addresses: %v", err)
+	}
+
+	displayHost, _, _ := net.SplitHostPort(addresses[0])
+	if ip := net.ParseIP(displayHost); displayHost == "" || (ip != nil && ip.IsUnspecified()) {
 		displayHost = "localhost"
 	}
-	displayAddress := displayHost + ":" + strconv.Itoa(cfg.GetPort())
+	displayAddress := net.JoinHostPort(displayHost, strconv.Itoa(cfg.GetPort()))
 
-	address := cfg.GetHost() + ":" + strconv.Itoa(cfg.GetPort())
 	tlsConfig, err := makeTLSConfig(cfg)
 	if err != nil {
 		// assume we don't want to start with a broken TLS configuration
@@ -109,7 +115,7 @@ func Initialize() (*Server, error) {
 
 	server := &Server{
 		Server: http.Server{
-			Addr:      address,
+			Addr:      addresses[0],
 			Handler:   r,
 			TLSConfig: tlsConfig,
 			// disable http/2 support by default
@@ -118,6 +124,7 @@ func Initialize() (*Server, error) {
 			// streams when deleting a scene file.
 			TLSNextProto: make(map[string]func(*http.Server, *tls.Conn, http.Handler)),
 		},
+		addresses:      addresses,
 		displayAddress: displayAddress,
 		manager:        mgr,
 	}
@@ -127,6 +134,7 @@ func Initialize() (*Server, error) {
 	r.Use(authenticateHandler())
 	visitedPluginHandler := mgr.SessionStore.VisitedPluginHandler()
 	r.Use(visitedPluginHandler)
+	r.Use(touchContentGateHandler)
 
 	r.Use(middleware.Recoverer)
 
@@ -183,12 +191,18 @@ func Initialize() (*Server, error) {
 	})
 	gqlSrv.AddTransport(gqlTransport.Options{})
 	gqlSrv.AddTransport(gqlTransport.GET{})
+	// must be added before POST, which accepts the same requests
+	gqlSrv.AddTransport(deferTransport{
+		MultipartMixed: gqlTransport.MultipartMixed{
+			DeliveryTimeout: deferDeliveryInterval,
+		},
+	})
 	gqlSrv.AddTransport(gqlTransport.POST{})
 	gqlSrv.AddTransport(gqlTransport.MultipartForm{
 		MaxUploadSize: cfg.GetMaxUploadSize(),
 	})
 
-	gqlSrv.SetQueryCache(gqlLru.New[*ast.QueryDocument](1000))
+	gqlSrv.SetQueryCache(gqlLru.New[*ast.QueryDocument](cfg.GetGraphQLQueryCacheSize()))
 	gqlSrv.Use(gqlExtension.Introspection{})
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
@@ -205,6 +219,7 @@ func Initialize() (*Server, error) {
 	pluginCache.RegisterGQLHandler(gqlHandler)
 
 	r.HandleFunc(gqlEndpoint, gqlHandlerFunc)
+	r.HandleFunc(interactiveEndpoint, handleInteractiveWebsocket)
 	r.HandleFunc(playgroundEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		setPageSecurityHeaders(w, r, pluginCache.ListPlugins())
 		endpoint := getProxyPrefix(r) + gqlEndpoint
@@ -221,6 +236,7 @@ func Initialize() (*Server, error) {
 	r.Mount("/game", server.getGameRoutes())
 	r.Mount("/downloads", server.getDownloadsRoutes())
 	r.Mount("/plugin", server.getPluginRoutes())
+	r.Mount(shareEndpoint, server.getShareRoutes())
 
 	r.HandleFunc("/css", cssHandler(cfg))
 	r.HandleFunc("/javascript", javascriptHandler(cfg))
@@ -297,17 +313,33 @@ func Initialize() (*Server, error) {
 	return server, nil
 }
 
-// Start starts the server. It listens on the configured address and port.
-// It calls ListenAndServeTLS if TLS is configured, otherwise it calls ListenAndServe.
+// getListenAddresses returns the addresses to listen on. The bind addresses
+// are used if set, otherwise the host is used.
+func getListenAddresses(cfg *config.Config) ([]string, error) {
+	bindAddresses := cfg.GetBindAddresses()
+	if len(bindAddresses) == 0 {
+		return []string{net.JoinHostPort(cfg.GetHost(), strconv.Itoa(cfg.GetPort()))}, nil
+	}
+
+	return utils.ResolveBindAddresses(bindAddresses, cfg.GetPort())
+}
+
+// Start starts the server. It listens on the configured addresses and port.
+// It calls ServeTLS if TLS is configured, otherwise it calls Serve.
 // Calls to Start are blocked until the server is shutdown.
 func (s *Server) Start() error {
-	logger.Infof("stash is listening on " + s.Addr)
+	l, err := utils.ListenAll("tcp", s.addresses)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("stash is listening on " + strings.Join(s.addresses, ", "))
 	logger.Infof("stash is running at " + s.displayAddress)
 
 	if s.TLSConfig != nil {
-		return s.ListenAndServeTLS("", "")
+		return s.ServeTLS(l, "", "")
 	} else {
-		return s.ListenAndServe()
+		return s.Serve(l)
 	}
 }
 
@@ -332,6 +364,10 @@ func (w *performerFinderWrapper) FindMany(ctx context.Context, ids []int) ([]*mo
 	return w.performer.FindMany(ctx, ids)
 }
 
+func (w *performerFinderWrapper) GetTagIDs(ctx context.Context, id int) ([]int, error) {
+	return w.performer.GetTagIDs(ctx, id)
+}
+
 func (w *performerFinderWrapper) GetImage(ctx context.Context, performerID int) ([]byte, error) {
 	return w.performer.GetImage(ctx, performerID)
 }
@@ -362,6 +398,7 @@ func (s *Server) getPerformerRoutes() chi.Router {
 			performer:             repo.Performer,
 			performerProfileImage: repo.PerformerProfileImage,
 		},
+		gate: contentGate{tagFinder: repo.Tag},
 	}.Routes()
 }
 
@@ -373,7 +410,9 @@ func (s *Server) getSceneRoutes() chi.Router {
 		fileGetter:        repo.File,
 		captionFinder:     repo.File,
 		sceneMarkerFinder: repo.SceneMarker,
+		extraFinder:       repo.SceneExtra,
 		tagFinder:         repo.Tag,
+		gate:              contentGate{tagFinder: repo.Tag},
 	}.Routes()
 }
 
@@ -384,6 +423,7 @@ func (s *Server) getGalleryRoutes() chi.Router {
 		imageFinder:   repo.Image,
 		galleryFinder: repo.Gallery,
 		fileGetter:    repo.File,
+		gate:          contentGate{tagFinder: repo.Tag},
 	}.Routes()
 }
 
@@ -393,6 +433,27 @@ func (s *Server) getImageRoutes() chi.Router {
 		routes:      routes{txnManager: repo.TxnManager},
 		imageFinder: repo.Image,
 		fileGetter:  repo.File,
+		gate:        contentGate{tagFinder: repo.Tag},
+	}.Routes()
+}
+
+func (s *Server) getShareRoutes() chi.Router {
+	repo := s.manager.Repository
+	return shareRoutes{
+		routes: routes{txnManager: repo.TxnManager},
+		imageRoutes: imageRoutes{
+			routes:      routes{txnManager: repo.TxnManager},
+			imageFinder: repo.Image,
+			fileGetter:  repo.File,
+			gate:        contentGate{tagFinder: repo.Tag},
+		},
+		shareLinkFinder: repo.ShareLink,
+		sceneFinder:     repo.Scene,
+		galleryFinder:   repo.Gallery,
+		imageFinder:     repo.Image,
+		fileGetter:      repo.File,
+		gate:            contentGate{tagFinder: repo.Tag},
+		signKey:         s.manager.Config.GetJWTSignKey,
 	}.Routes()
 }
 
@@ -409,6 +470,7 @@ func (s *Server) getGroupRoutes() chi.Router {
 	return groupRoutes{
 		routes:      routes{txnManager: repo.TxnManager},
 		groupFinder: repo.Group,
+		gate:        contentGate{tagFinder: repo.Tag},
 	}.Routes()
 }
 
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Signed urls give access to a single path and query until they expire,
+// without a session or api key, so that they can be fetched by clients
+// which do not send credentials, such as prefetch requests.
+const (
+	signedURLExpiresParam   = "expires"
+	signedURLSignatureParam = "signature"
+)
+
+// urlSignature returns the signature of the path and the query, which must
+// include the expiry time.
+func urlSignature(key []byte, path string, query url.Values) string {
+	mac := hmac.New(sha256.New, key)
+	// Encode sorts the query by key
+	mac.Write([]byte(path + "?" + query.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signURL returns a copy of the url, relative to the server root, signed
+// until the expiry time.
+func signURL(key []byte, u url.URL, expires time.Time) *url.URL {
+	query := u.Query()
+	query.Del(signedURLSignatureParam)
+	query.Set(signedURLExpiresParam, strconv.FormatInt(expires.Unix(), 10))
+	query.Set(signedURLSignatureParam, urlSignature(key, u.Path, query))
+
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// validSignedURL returns true if the url carries a valid signature which has
+// not expired.
+func validSignedURL(key []byte, u *url.URL, now time.Time) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	query := u.Query()
+	signature := query.Get(signedURLSignatureParam)
+	if signature == "" {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(query.Get(signedURLExpiresParam), 10, 64)
+	if err != nil || now.Unix() > expires {
+		return false
+	}
+
+	query.Del(signedURLSignatureParam)
+	return hmac.Equal([]byte(signature), []byte(urlSignature(key, u.Path, query)))
+}
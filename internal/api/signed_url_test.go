@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedURL(t *testing.T) {
+	key := []byte("key")
+	now := time.Unix(1700000000, 0)
+
+	u, _ := url.Parse("/image/1/thumbnail?t=10&profile=1280_webp_q80")
+	signed := signURL(key, *u, now.Add(time.Hour))
+
+	assert.Empty(t, u.Query().Get(signedURLSignatureParam), "url is not modified")
+	assert.True(t, validSignedURL(key, signed, now))
+	assert.False(t, validSignedURL(key, signed, now.Add(2*time.Hour)), "expired")
+	assert.False(t, validSignedURL([]byte("other"), signed, now), "other key")
+	assert.False(t, validSignedURL(nil, signed, now), "no key")
+	assert.False(t, validSignedURL(key, u, now), "not signed")
+
+	tampered := *signed
+	tampered.Path = "/image/2/thumbnail"
+	assert.False(t, validSignedURL(key, &tampered, now), "other path")
+
+	query := signed.Query()
+	query.Set(signedURLExpiresParam, "1800000000")
+	tampered = *signed
+	tampered.RawQuery = query.Encode()
+	assert.False(t, validSignedURL(key, &tampered, now), "extended expiry")
+}
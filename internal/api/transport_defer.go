@@ -0,0 +1,42 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	gqlTransport "github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// deferDeliveryInterval is how often the results of @defer fragments
+// resolved since the last response are sent to the client.
+const deferDeliveryInterval = 20 * time.Millisecond
+
+// deferTransport serves operations containing @defer incrementally as a
+// multipart/mixed response. Apollo Client accepts multipart/mixed responses
+// for every operation, so operations without @defer are left to the POST
+// transport.
+type deferTransport struct {
+	gqlTransport.MultipartMixed
+}
+
+var _ graphql.Transport = deferTransport{}
+
+func (t deferTransport) Supports(r *http.Request) bool {
+	if !t.MultipartMixed.Supports(r) {
+		return false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+
+	// restore the body for the transport handling the request
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(body, []byte("@defer"))
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	gqlTransport "github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferTransport(t *testing.T) {
+	h := testserver.New()
+	h.AddTransport(deferTransport{})
+	h.AddTransport(gqlTransport.POST{})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	post := func(t *testing.T, query string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(query))
+		require.NoError(t, err)
+
+		// sent by Apollo Client for every operation
+		req.Header.Set("Accept", "multipart/mixed;deferSpec=20220824,application/json")
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("without defer", func(t *testing.T) {
+		res := post(t, `{"query":"query { name }"}`)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		br := bufio.NewReader(res.Body)
+		line, _ := br.ReadString('\n')
+		assert.JSONEq(t, `{"data":{"name":"test"}}`, line)
+	})
+
+	t.Run("with defer", func(t *testing.T) {
+		go h.SendNextSubscriptionMessage()
+
+		res := post(t, `{"query":"query { ... @defer { name } }"}`)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Content-Type"), "multipart/mixed")
+
+		br := bufio.NewReader(res.Body)
+		var initial string
+		for !strings.HasPrefix(initial, "{") {
+			var err error
+			initial, err = br.ReadString('\n')
+			require.NoError(t, err)
+		}
+		assert.JSONEq(t, `{"data":{"name":null},"hasNext":true}`, initial)
+
+		h.SendNextSubscriptionMessage()
+		h.SendCompleteSubscriptionMessage()
+	})
+}
@@ -32,6 +32,12 @@ func (b ImageURLBuilder) GetThumbnailURL() string {
 	return b.BaseURL + "/image/" + b.ImageID + "/thumbnail?t=" + b.UpdatedAt
 }
 
+// GetThumbnailProfileURL returns the url of the thumbnail of the profile with
+// the key.
+func (b ImageURLBuilder) GetThumbnailProfileURL(profileKey string) string {
+	return b.BaseURL + "/image/" + b.ImageID + "/thumbnail?t=" + b.UpdatedAt + "&profile=" + profileKey
+}
+
 func (b ImageURLBuilder) GetPreviewURL() string {
 	if exists, err := fsutil.FileExists(manager.GetInstance().Paths.Generated.GetClipPreviewPath(b.Checksum, models.DefaultGthumbWidth)); exists && err == nil {
 		return b.BaseURL + "/image/" + b.ImageID + "/preview?" + b.UpdatedAt
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -37,10 +38,32 @@ func (b SceneURLBuilder) GetStreamURL(apiKey string) *url.URL {
 	return u
 }
 
+// GetExtraStreamURL returns the url streaming the extra file of the role.
+func (b SceneURLBuilder) GetExtraStreamURL(role models.SceneExtraRole, fileID models.FileID, apiKey string) *url.URL {
+	u, err := url.Parse(fmt.Sprintf("%s/scene/%s/extra/%s/stream", b.BaseURL, b.SceneID, strings.ToLower(role.String())))
+	if err != nil {
+		// shouldn't happen
+		panic(err)
+	}
+
+	v := u.Query()
+	v.Set("file_id", fileID.String())
+	if apiKey != "" {
+		v.Set("apikey", apiKey)
+	}
+	u.RawQuery = v.Encode()
+
+	return u
+}
+
 func (b SceneURLBuilder) GetStreamPreviewURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/preview"
 }
 
+func (b SceneURLBuilder) GetWallPreviewURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/wall_preview"
+}
+
 func (b SceneURLBuilder) GetStreamPreviewImageURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/webp"
 }
@@ -49,10 +72,17 @@ func (b SceneURLBuilder) GetSpriteVTTURL(checksum string) string {
 	return b.BaseURL + "/scene/" + checksum + "_thumbs.vtt"
 }
 
-func (b SceneURLBuilder) GetSpriteURL(checksum string) string {
+func (b SceneURLBuilder) GetSpriteURL(checksum string, format models.SpriteFormat) string {
+	if format == models.SpriteFormatWebp {
+		return b.BaseURL + "/scene/" + checksum + "_sprite.webp"
+	}
 	return b.BaseURL + "/scene/" + checksum + "_sprite.jpg"
 }
 
+func (b SceneURLBuilder) GetSpriteMetadataURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/vtt/sprite.json"
+}
+
 func (b SceneURLBuilder) GetScreenshotURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/screenshot?t=" + b.UpdatedAt
 }
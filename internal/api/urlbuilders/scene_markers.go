@@ -31,3 +31,7 @@ func (b SceneMarkerURLBuilder) GetPreviewURL() string {
 func (b SceneMarkerURLBuilder) GetScreenshotURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/scene_marker/" + b.MarkerID + "/screenshot"
 }
+
+func (b SceneMarkerURLBuilder) GetThumbnailURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/scene_marker/" + b.MarkerID + "/thumbnail"
+}
@@ -31,22 +31,28 @@ type GalleryQueryPerformerUpdater interface {
 }
 
 func getPerformerTaggers(p *models.Performer, cache *match.Cache) []tagger {
+	options := cache.PerformerOptions()
+
 	ret := []tagger{{
-		ID:    p.ID,
-		Type:  "performer",
-		Name:  p.Name,
-		cache: cache,
+		ID:      p.ID,
+		Type:    "performer",
+		Name:    p.Name,
+		cache:   cache,
+		options: options,
 	}}
 
-	// TODO - disabled until we can have finer control over alias matching
-	// for _, a := range p.Aliases.List() {
-	// 	ret = append(ret, tagger{
-	// 		ID:    p.ID,
-	// 		Type:  "performer",
-	// 		Name:  a,
-	// 		cache: cache,
-	// 	})
-	// }
+	// aliases are only matched with the aliases matching mode
+	if options.Aliases {
+		for _, a := range p.Aliases.List() {
+			ret = append(ret, tagger{
+				ID:      p.ID,
+				Type:    "performer",
+				Name:    a,
+				cache:   cache,
+				options: options,
+			})
+		}
+	}
 
 	return ret
 }
@@ -57,7 +63,7 @@ func (tagger *Tagger) PerformerScenes(ctx context.Context, p *models.Performer,
 	t := getPerformerTaggers(p, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagScenes(ctx, paths, rw, func(o *models.Scene) (bool, error) {
+		if err := tt.tagScenes(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Scene) (bool, error) {
 			if err := o.LoadPerformerIDs(ctx, rw); err != nil {
 				return false, err
 			}
@@ -115,7 +121,7 @@ func (tagger *Tagger) PerformerGalleries(ctx context.Context, p *models.Performe
 	t := getPerformerTaggers(p, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagGalleries(ctx, paths, rw, func(o *models.Gallery) (bool, error) {
+		if err := tt.tagGalleries(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Gallery) (bool, error) {
 			if err := o.LoadPerformerIDs(ctx, rw); err != nil {
 				return false, err
 			}
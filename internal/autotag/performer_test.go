@@ -115,6 +115,63 @@ func testPerformerScenes(t *testing.T, performerName, expectedRegex string) {
 	db.AssertExpectations(t)
 }
 
+func TestPerformerScenesLocked(t *testing.T) {
+	t.Parallel()
+
+	const (
+		performerID   = 2
+		performerName = "performer name"
+		unlockedID    = 1
+		lockedID      = 2
+	)
+
+	performer := models.Performer{
+		ID:      performerID,
+		Name:    performerName,
+		Aliases: models.NewRelatedStrings([]string{}),
+	}
+
+	for _, includeLocked := range []bool{false, true} {
+		db := mocks.NewDatabase()
+
+		scenes := []*models.Scene{
+			{
+				ID:           unlockedID,
+				Path:         "performer name.mp4",
+				PerformerIDs: models.NewRelatedIDs([]int{}),
+			},
+			{
+				ID:           lockedID,
+				Path:         "performer name 2.mp4",
+				PerformerIDs: models.NewRelatedIDs([]int{}),
+				Locked:       true,
+			},
+		}
+
+		db.Scene.On("Query", mock.Anything, mock.Anything).
+			Return(mocks.SceneQueryResult(scenes, len(scenes)), nil).Once()
+
+		expectedIDs := []int{unlockedID}
+		if includeLocked {
+			expectedIDs = append(expectedIDs, lockedID)
+		}
+
+		for _, sceneID := range expectedIDs {
+			db.Scene.On("UpdatePartial", mock.Anything, sceneID, mock.Anything).Return(nil, nil).Once()
+		}
+
+		tagger := Tagger{
+			TxnManager:    db,
+			IncludeLocked: includeLocked,
+		}
+
+		err := tagger.PerformerScenes(testCtx, &performer, nil, db.Scene)
+
+		assert.Nil(t, err)
+		db.AssertExpectations(t)
+	}
+}
+
 func TestPerformerImages(t *testing.T) {
 	t.Parallel()
 
@@ -57,6 +57,7 @@ func ScenePerformers(ctx context.Context, s *models.Scene, rw ScenePerformerUpda
 }
 
 // SceneStudios tags the provided scene with the first studio whose name matches the scene's path.
+// If no studio matches the path, the on-screen text recognized in the scene is matched instead.
 //
 // Scenes will not be tagged if studio is already set.
 func SceneStudios(ctx context.Context, s *models.Scene, rw SceneFinderUpdater, studioReader models.StudioAutoTagQueryer, cache *match.Cache) error {
@@ -67,6 +68,17 @@ func SceneStudios(ctx context.Context, s *models.Scene, rw SceneFinderUpdater, s
 
 	t := getSceneFileTagger(s, cache)
 
+	added := false
+	if err := t.tagStudios(ctx, studioReader, func(subjectID, otherID int) (bool, error) {
+		var err error
+		added, err = addSceneStudio(ctx, rw, s, otherID)
+		return added, err
+	}); err != nil || added || s.OCRText == "" {
+		return err
+	}
+
+	// watermarks and site names are often shown in the scene
+	t.Path = s.OCRText
 	return t.tagStudios(ctx, studioReader, func(subjectID, otherID int) (bool, error) {
 		return addSceneStudio(ctx, rw, s, otherID)
 	})
@@ -288,6 +288,48 @@ func TestSceneStudios(t *testing.T) {
 	}
 }
 
+func TestSceneStudiosOCRText(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sceneID    = 1
+		studioName = "studio name"
+		studioID   = 2
+	)
+	studio := models.Studio{
+		ID:   studioID,
+		Name: studioName,
+	}
+
+	db := mocks.NewDatabase()
+
+	db.Studio.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	// the path does not match the studio, but the on-screen text does
+	db.Studio.On("QueryForAutoTag", testCtx, mock.Anything).Return(nil, nil).Once()
+	db.Studio.On("QueryForAutoTag", testCtx, mock.Anything).Return([]*models.Studio{&studio}, nil).Once()
+	db.Studio.On("GetAliases", testCtx, studioID).Return([]string{}, nil).Once()
+
+	matchPartial := mock.MatchedBy(func(got models.ScenePartial) bool {
+		expected := models.ScenePartial{
+			StudioID: models.NewOptionalInt(studioID),
+		}
+
+		return scenePartialsEqual(got, expected)
+	})
+	db.Scene.On("UpdatePartial", testCtx, sceneID, matchPartial).Return(nil, nil).Once()
+
+	scene := models.Scene{
+		ID:      sceneID,
+		Path:    "/path/to/scene.mp4",
+		OCRText: "Presented by\nStudio Name",
+	}
+	err := SceneStudios(testCtx, &scene, db.Scene, db.Studio, nil)
+
+	assert.Nil(t, err)
+	db.AssertExpectations(t)
+}
+
 func TestSceneTags(t *testing.T) {
 	t.Parallel()
 
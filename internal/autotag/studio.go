@@ -60,18 +60,22 @@ func addGalleryStudio(ctx context.Context, galleryWriter GalleryFinderUpdater, o
 }
 
 func getStudioTagger(p *models.Studio, aliases []string, cache *match.Cache) []tagger {
+	options := cache.StudioOptions()
+
 	ret := []tagger{{
-		ID:    p.ID,
-		Type:  "studio",
-		Name:  p.Name,
-		cache: cache,
+		ID:      p.ID,
+		Type:    "studio",
+		Name:    p.Name,
+		cache:   cache,
+		options: options,
 	}}
 
 	for _, a := range aliases {
 		ret = append(ret, tagger{
-			ID:   p.ID,
-			Type: "studio",
-			Name: a,
+			ID:      p.ID,
+			Type:    "studio",
+			Name:    a,
+			options: options,
 		})
 	}
 
@@ -83,7 +87,7 @@ func (tagger *Tagger) StudioScenes(ctx context.Context, p *models.Studio, paths
 	t := getStudioTagger(p, aliases, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagScenes(ctx, paths, rw, func(o *models.Scene) (bool, error) {
+		if err := tt.tagScenes(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Scene) (bool, error) {
 			// don't set if already set
 			if o.StudioID != nil {
 				return false, nil
@@ -143,7 +147,7 @@ func (tagger *Tagger) StudioGalleries(ctx context.Context, p *models.Studio, pat
 	t := getStudioTagger(p, aliases, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagGalleries(ctx, paths, rw, func(o *models.Gallery) (bool, error) {
+		if err := tt.tagGalleries(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Gallery) (bool, error) {
 			// don't set if already set
 			if o.StudioID != nil {
 				return false, nil
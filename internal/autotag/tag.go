@@ -31,19 +31,23 @@ type GalleryQueryTagUpdater interface {
 }
 
 func getTagTaggers(p *models.Tag, aliases []string, cache *match.Cache) []tagger {
+	options := cache.TagOptions()
+
 	ret := []tagger{{
-		ID:    p.ID,
-		Type:  "tag",
-		Name:  p.Name,
-		cache: cache,
+		ID:      p.ID,
+		Type:    "tag",
+		Name:    p.Name,
+		cache:   cache,
+		options: options,
 	}}
 
 	for _, a := range aliases {
 		ret = append(ret, tagger{
-			ID:    p.ID,
-			Type:  "tag",
-			Name:  a,
-			cache: cache,
+			ID:      p.ID,
+			Type:    "tag",
+			Name:    a,
+			cache:   cache,
+			options: options,
 		})
 	}
 
@@ -55,7 +59,7 @@ func (tagger *Tagger) TagScenes(ctx context.Context, p *models.Tag, paths []stri
 	t := getTagTaggers(p, aliases, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagScenes(ctx, paths, rw, func(o *models.Scene) (bool, error) {
+		if err := tt.tagScenes(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Scene) (bool, error) {
 			if err := o.LoadTagIDs(ctx, rw); err != nil {
 				return false, err
 			}
@@ -113,7 +117,7 @@ func (tagger *Tagger) TagGalleries(ctx context.Context, p *models.Tag, paths []s
 	t := getTagTaggers(p, aliases, tagger.Cache)
 
 	for _, tt := range t {
-		if err := tt.tagGalleries(ctx, paths, rw, func(o *models.Gallery) (bool, error) {
+		if err := tt.tagGalleries(ctx, paths, rw, tagger.IncludeLocked, func(o *models.Gallery) (bool, error) {
 			if err := o.LoadTagIDs(ctx, rw); err != nil {
 				return false, err
 			}
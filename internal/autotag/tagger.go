@@ -11,6 +11,10 @@
 // "foo-bar.mp4", "aaa.foo bar.bbb.mp4".
 // The following would not be considered a match:
 // "aafoo bar.mp4", "foo barbb.mp4", "foo/bar.mp4"
+//
+// The matching can be changed for each type of entity with the modes of
+// match.Options, such as requiring the words of a name to be separated or
+// transliterating names and paths.
 package autotag
 
 import (
@@ -26,6 +30,9 @@ import (
 type Tagger struct {
 	TxnManager txn.Manager
 	Cache      *match.Cache
+	// IncludeLocked tags locked scenes and galleries, which are skipped
+	// otherwise.
+	IncludeLocked bool
 }
 
 type tagger struct {
@@ -36,6 +43,8 @@ type tagger struct {
 	trimExt bool
 
 	cache *match.Cache
+	// options are the options used to match Name against paths
+	options match.Options
 }
 
 type addLinkFunc func(subjectID, otherID int) (bool, error)
@@ -114,8 +123,12 @@ func (t *tagger) tagTags(ctx context.Context, tagReader models.TagAutoTagQueryer
 	return nil
 }
 
-func (t *tagger) tagScenes(ctx context.Context, paths []string, sceneReader models.SceneQueryer, addFunc addSceneLinkFunc) error {
-	return match.PathToScenesFn(ctx, t.Name, paths, sceneReader, func(ctx context.Context, p *models.Scene) error {
+func (t *tagger) tagScenes(ctx context.Context, paths []string, sceneReader models.SceneQueryer, includeLocked bool, addFunc addSceneLinkFunc) error {
+	return match.PathToScenesFn(ctx, t.Name, paths, t.options, sceneReader, func(ctx context.Context, p *models.Scene) error {
+		if p.Locked && !includeLocked {
+			return nil
+		}
+
 		added, err := addFunc(p)
 
 		if err != nil {
@@ -131,7 +144,7 @@ func (t *tagger) tagScenes(ctx context.Context, paths []string, sceneReader mode
 }
 
 func (t *tagger) tagImages(ctx context.Context, paths []string, imageReader models.ImageQueryer, addFunc addImageLinkFunc) error {
-	return match.PathToImagesFn(ctx, t.Name, paths, imageReader, func(ctx context.Context, p *models.Image) error {
+	return match.PathToImagesFn(ctx, t.Name, paths, t.options, imageReader, func(ctx context.Context, p *models.Image) error {
 		added, err := addFunc(p)
 
 		if err != nil {
@@ -146,8 +159,12 @@ func (t *tagger) tagImages(ctx context.Context, paths []string, imageReader mode
 	})
 }
 
-func (t *tagger) tagGalleries(ctx context.Context, paths []string, galleryReader models.GalleryQueryer, addFunc addGalleryLinkFunc) error {
-	return match.PathToGalleriesFn(ctx, t.Name, paths, galleryReader, func(ctx context.Context, p *models.Gallery) error {
+func (t *tagger) tagGalleries(ctx context.Context, paths []string, galleryReader models.GalleryQueryer, includeLocked bool, addFunc addGalleryLinkFunc) error {
+	return match.PathToGalleriesFn(ctx, t.Name, paths, t.options, galleryReader, func(ctx context.Context, p *models.Gallery) error {
+		if p.Locked && !includeLocked {
+			return nil
+		}
+
 		added, err := addFunc(p)
 
 		if err != nil {
@@ -76,6 +76,12 @@ func (me *contentDirectoryService) updateIDString() string {
 	return fmt.Sprintf("%d", uint32(os.Getpid()))
 }
 
+// videoItem extends upnpav.Item with properties that it does not support.
+type videoItem struct {
+	upnpav.Item
+	Languages []string `xml:"dc:language,omitempty"`
+}
+
 func sceneToContainer(scene *models.Scene, parent string, host string) interface{} {
 	// make stash server URL
 	// TODO - fix this
@@ -107,9 +113,10 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 
 	mimeType := "video/mp4"
 	var (
-		size     int
-		bitrate  uint
-		duration int64
+		size      int
+		bitrate   uint
+		duration  int64
+		languages []string
 	)
 
 	f := scene.Files.Primary()
@@ -117,6 +124,7 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		size = int(f.Size)
 		bitrate = uint(f.BitRate)
 		duration = int64(f.Duration)
+		languages = f.AudioLanguages
 	}
 
 	item.Res = append(item.Res, upnpav.Resource{
@@ -142,7 +150,10 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_MED",
 	})
 
-	return item
+	return videoItem{
+		Item:      item,
+		Languages: languages,
+	}
 }
 
 // ContentDirectory object from ObjectID.
@@ -360,7 +371,7 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 
 		r := me.repository
 		if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
-			scene, err = r.SceneFinder.Find(ctx, sceneID)
+			scene, err = me.findScene(ctx, r.SceneFinder, sceneID)
 			if scene != nil {
 				err = scene.LoadPrimaryFile(ctx, r.FileGetter)
 			}
@@ -461,14 +472,14 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 			Direction: &direction,
 		}
 
-		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, findFilter)
+		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, me.sceneFilter(sceneFilter), findFilter)
 		if err != nil {
 			return err
 		}
 
 		if total > pageSize {
 			pager := scenePager{
-				sceneFilter: sceneFilter,
+				sceneFilter: me.sceneFilter(sceneFilter),
 				parentID:    parentID,
 			}
 
@@ -500,7 +511,7 @@ func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilter
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
 		pager := scenePager{
-			sceneFilter: sceneFilter,
+			sceneFilter: me.sceneFilter(sceneFilter),
 			parentID:    parentID,
 		}
 
@@ -588,7 +599,16 @@ func (me *contentDirectoryService) getTags() []interface{} {
 			return err
 		}
 
+		excluded, err := me.excludedTagIDs(ctx, r.TagFinder)
+		if err != nil {
+			return err
+		}
+
 		for _, s := range tags {
+			if excluded[s.ID] {
+				continue
+			}
+
 			objs = append(objs, makeStorageFolder("tags/"+strconv.Itoa(s.ID), s.Name, "tags"))
 		}
 
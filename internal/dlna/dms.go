@@ -62,6 +62,7 @@ type StudioFinder interface {
 
 type TagFinder interface {
 	All(ctx context.Context) ([]*models.Tag, error)
+	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*models.TagPath, error)
 }
 
 type PerformerFinder interface {
@@ -278,6 +279,7 @@ type Server struct {
 	repository         Repository
 	sceneServer        sceneServer
 	ipWhitelistManager *ipWhitelistManager
+	exclusionConfig    ExclusionConfig
 	VideoSortOrder     string
 
 	subscribeLock sync.Mutex
@@ -450,8 +452,8 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			return nil
 		}
-		scene, _ = repo.SceneFinder.Find(ctx, idInt)
-		return nil
+		scene, err = me.findScene(ctx, repo.SceneFinder, idInt)
+		return err
 	})
 	if err != nil {
 		logger.Warnf("failed to execute read transaction while trying to serve an icon: %v", err)
@@ -602,8 +604,8 @@ func (me *Server) initMux(mux *http.ServeMux) {
 			if err != nil {
 				return nil
 			}
-			scene, _ = repo.SceneFinder.Find(ctx, sceneIdInt)
-			return nil
+			scene, err = me.findScene(ctx, repo.SceneFinder, sceneIdInt)
+			return err
 		})
 		if err != nil {
 			logger.Warnf("failed to execute read transaction for scene id (%v): %v", sceneId, err)
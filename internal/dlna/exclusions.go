@@ -0,0 +1,121 @@
+package dlna
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// ExclusionConfig provides the rules for the content hidden from DLNA
+// clients. The rules are read for each request, so that changes apply
+// without restarting the DLNA server.
+type ExclusionConfig interface {
+	GetDLNAExcludeTags() []string
+	GetDLNAExcludeUnorganized() bool
+	GetDLNAExcludeBroken() bool
+	GetDLNAExcludeQuarantined() bool
+}
+
+// exclusionFilter returns f combined with a filter excluding the scenes
+// hidden from DLNA clients. Returns f if no scenes are excluded.
+func exclusionFilter(c ExclusionConfig, f *models.SceneFilterType) *models.SceneFilterType {
+	ret := &models.SceneFilterType{}
+	excluded := false
+
+	if tags := c.GetDLNAExcludeTags(); len(tags) > 0 {
+		depth := -1
+		ret.Tags = &models.HierarchicalMultiCriterionInput{
+			Value:    tags,
+			Modifier: models.CriterionModifierExcludes,
+			Depth:    &depth,
+		}
+		excluded = true
+	}
+
+	if c.GetDLNAExcludeUnorganized() {
+		organized := true
+		ret.Organized = &organized
+		excluded = true
+	}
+
+	if c.GetDLNAExcludeBroken() {
+		broken := false
+		ret.IsBroken = &broken
+		excluded = true
+	}
+
+	if c.GetDLNAExcludeQuarantined() {
+		hasThreats := false
+		ret.HasThreats = &hasThreats
+		excluded = true
+	}
+
+	if !excluded {
+		return f
+	}
+
+	ret.And = f
+	return ret
+}
+
+func (me *Server) sceneFilter(f *models.SceneFilterType) *models.SceneFilterType {
+	return exclusionFilter(me.exclusionConfig, f)
+}
+
+// isSceneExcluded returns true if the scene with the id is hidden from DLNA
+// clients.
+func (me *Server) isSceneExcluded(ctx context.Context, r SceneFinder, id int) (bool, error) {
+	idFilter := &models.SceneFilterType{
+		ID: &models.IntCriterionInput{
+			Value:    id,
+			Modifier: models.CriterionModifierEquals,
+		},
+	}
+
+	f := me.sceneFilter(idFilter)
+	if f == idFilter {
+		return false, nil
+	}
+
+	count, err := r.QueryCount(ctx, f, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
+
+// findScene returns the scene with the id, or nil if it does not exist or
+// is hidden from DLNA clients.
+func (me *Server) findScene(ctx context.Context, r SceneFinder, id int) (*models.Scene, error) {
+	excluded, err := me.isSceneExcluded(ctx, r, id)
+	if err != nil || excluded {
+		return nil, err
+	}
+
+	return r.Find(ctx, id)
+}
+
+// excludedTagIDs returns the ids of the excluded tags and their descendants.
+func (me *Server) excludedTagIDs(ctx context.Context, r TagFinder) (map[int]bool, error) {
+	tags, err := stringslice.StringSliceToIntSlice(me.exclusionConfig.GetDLNAExcludeTags())
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+
+	ret := make(map[int]bool)
+	for _, id := range tags {
+		descendants, err := r.FindAllDescendants(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// descendants includes the tag itself
+		for _, t := range descendants {
+			ret[t.ID] = true
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,48 @@
+package dlna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type testExclusionConfig struct {
+	tags        []string
+	unorganized bool
+	broken      bool
+	quarantined bool
+}
+
+func (c testExclusionConfig) GetDLNAExcludeTags() []string    { return c.tags }
+func (c testExclusionConfig) GetDLNAExcludeUnorganized() bool { return c.unorganized }
+func (c testExclusionConfig) GetDLNAExcludeBroken() bool      { return c.broken }
+func (c testExclusionConfig) GetDLNAExcludeQuarantined() bool { return c.quarantined }
+
+func TestExclusionFilter(t *testing.T) {
+	f := &models.SceneFilterType{}
+
+	assert.Same(t, f, exclusionFilter(testExclusionConfig{}, f))
+
+	ret := exclusionFilter(testExclusionConfig{
+		tags:        []string{"1", "2"},
+		unorganized: true,
+		broken:      true,
+		quarantined: true,
+	}, f)
+
+	assert.Same(t, f, ret.And)
+	assert.Equal(t, []string{"1", "2"}, ret.Tags.Value)
+	assert.Equal(t, models.CriterionModifierExcludes, ret.Tags.Modifier)
+	assert.Equal(t, -1, *ret.Tags.Depth)
+	assert.True(t, *ret.Organized)
+	assert.False(t, *ret.IsBroken)
+	assert.False(t, *ret.HasThreats)
+
+	ret = exclusionFilter(testExclusionConfig{broken: true}, f)
+	assert.Nil(t, ret.Tags)
+	assert.Nil(t, ret.Organized)
+	assert.Nil(t, ret.HasThreats)
+	assert.False(t, *ret.IsBroken)
+}
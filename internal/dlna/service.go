@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type Repository struct {
@@ -77,6 +78,9 @@ type Config interface {
 	GetDLNADefaultIPWhitelist() []string
 	GetVideoSortOrder() string
 	GetDLNAPortAsString() string
+	GetDLNAPort() int
+	GetDLNABindAddresses() []string
+	ExclusionConfig
 }
 
 type Service struct {
@@ -130,6 +134,22 @@ func (s *Service) getInterfaces() ([]net.Interface, error) {
 	return ifs, nil
 }
 
+// listen listens on the configured bind addresses, or on all interfaces if
+// none are configured.
+func (s *Service) listen() (net.Listener, error) {
+	bindAddresses := s.config.GetDLNABindAddresses()
+	if len(bindAddresses) == 0 {
+		return net.Listen("tcp", s.config.GetDLNAPortAsString())
+	}
+
+	addresses, err := utils.ResolveBindAddresses(bindAddresses, s.config.GetDLNAPort())
+	if err != nil {
+		return nil, fmt.Errorf("error resolving DLNA bind addresses: %w", err)
+	}
+
+	return utils.ListenAll("tcp", addresses)
+}
+
 func (s *Service) init() error {
 	friendlyName := s.config.GetDLNAServerName()
 	if friendlyName == "" {
@@ -151,21 +171,21 @@ func (s *Service) init() error {
 		return err
 	}
 
+	httpConn, err := s.listen()
+	if err != nil {
+		return err
+	}
+
 	s.server = &Server{
 		repository:         s.repository,
 		sceneServer:        s.sceneServer,
 		ipWhitelistManager: s.ipWhitelistMgr,
+		exclusionConfig:    s.config,
 		Interfaces:         interfaces,
-		HTTPConn: func() net.Listener {
-			conn, err := net.Listen("tcp", dmsConfig.Http)
-			if err != nil {
-				logger.Error(err.Error())
-			}
-			return conn
-		}(),
-		FriendlyName:   dmsConfig.FriendlyName,
-		RootObjectPath: filepath.Clean(dmsConfig.Path),
-		LogHeaders:     dmsConfig.LogHeaders,
+		HTTPConn:           httpConn,
+		FriendlyName:       dmsConfig.FriendlyName,
+		RootObjectPath:     filepath.Clean(dmsConfig.Path),
+		LogHeaders:         dmsConfig.LogHeaders,
 		// Icons: []Icon{
 		// 	{
 		// 		Width:    48,
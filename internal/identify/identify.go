@@ -20,6 +20,7 @@ import (
 
 var (
 	ErrSkipSingleNamePerformer = errors.New("a performer was skipped because they only had a single name and no disambiguation")
+	ErrPerformerConflict       = errors.New("a performer was skipped because their name is the same as or similar to existing performers")
 )
 
 type MultipleMatchesFoundError struct {
@@ -51,6 +52,9 @@ type SceneIdentifier struct {
 	StudioReaderWriter models.StudioReaderWriter
 	PerformerCreator   PerformerCreator
 	TagFinderCreator   models.TagFinderCreator
+	// PerformerGetter is used to name performers in previews. Performer ids
+	// are used if nil.
+	PerformerGetter models.PerformerGetter
 
 	DefaultOptions              *MetadataOptions
 	Sources                     []ScraperSource
@@ -272,17 +276,7 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, result *scrapeResult) error {
 	var updater *scene.UpdateSet
 	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
-		// load scene relationships
-		if err := s.LoadURLs(ctx, t.SceneReaderUpdater); err != nil {
-			return err
-		}
-		if err := s.LoadPerformerIDs(ctx, t.SceneReaderUpdater); err != nil {
-			return err
-		}
-		if err := s.LoadTagIDs(ctx, t.SceneReaderUpdater); err != nil {
-			return err
-		}
-		if err := s.LoadStashIDs(ctx, t.SceneReaderUpdater); err != nil {
+		if err := t.loadSceneRelationships(ctx, s); err != nil {
 			return err
 		}
 
@@ -324,6 +318,19 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, resu
 	return nil
 }
 
+func (t *SceneIdentifier) loadSceneRelationships(ctx context.Context, s *models.Scene) error {
+	if err := s.LoadURLs(ctx, t.SceneReaderUpdater); err != nil {
+		return err
+	}
+	if err := s.LoadPerformerIDs(ctx, t.SceneReaderUpdater); err != nil {
+		return err
+	}
+	if err := s.LoadTagIDs(ctx, t.SceneReaderUpdater); err != nil {
+		return err
+	}
+	return s.LoadStashIDs(ctx, t.SceneReaderUpdater)
+}
+
 func (t *SceneIdentifier) addTagToScene(ctx context.Context, s *models.Scene, tagToAdd string) error {
 	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
 		tagID, err := strconv.Atoi(tagToAdd)
@@ -415,6 +422,14 @@ func getScenePartial(scene *models.Scene, scraped *models.ScrapedScene, fieldOpt
 					Mode:   models.RelationshipUpdateModeSet,
 				}
 			}
+		case FieldStrategyFillBlank:
+			// only set if there are no existing urls
+			if len(scene.URLs.List()) == 0 {
+				partial.URLs = &models.UpdateStrings{
+					Values: scraped.URLs,
+					Mode:   models.RelationshipUpdateModeSet,
+				}
+			}
 		}
 	}
 	if scraped.Director != nil && (scene.Director != *scraped.Director) {
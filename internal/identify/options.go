@@ -1,8 +1,10 @@
 package identify
 
 import (
+	"cmp"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/scraper"
@@ -12,10 +14,31 @@ type Source struct {
 	Source *scraper.Source `json:"source"`
 	// Options defined for a source override the defaults
 	Options *MetadataOptions `json:"options"`
+	// Sources with a lower priority are tried first. Sources without a
+	// priority are tried after those with one, in the order given.
+	Priority *int `json:"priority"`
+}
+
+// SortSources returns the sources in the order they should be tried.
+func SortSources(sources []*Source) []*Source {
+	ret := slices.Clone(sources)
+	slices.SortStableFunc(ret, func(a, b *Source) int {
+		switch {
+		case a.Priority == nil && b.Priority == nil:
+			return 0
+		case a.Priority == nil:
+			return 1
+		case b.Priority == nil:
+			return -1
+		}
+		return cmp.Compare(*a.Priority, *b.Priority)
+	})
+	return ret
 }
 
 type Options struct {
 	// An ordered list of sources to identify items with. Only the first source that finds a match is used.
+	// Source priorities take precedence over the list order.
 	Sources []*Source `json:"sources"`
 	// Options defined here override the configured defaults
 	Options *MetadataOptions `json:"options"`
@@ -23,6 +46,8 @@ type Options struct {
 	SceneIDs []string `json:"sceneIDs"`
 	// paths of scenes to identify - ignored if scene ids are set
 	Paths []string `json:"paths"`
+	// also identify locked scenes
+	IncludeLocked bool `json:"includeLocked"`
 }
 
 type MetadataOptions struct {
@@ -58,6 +83,10 @@ const (
 	// For multi-value fields, merge with existing.
 	// For single-value fields, ignore if already set
 	FieldStrategyMerge FieldStrategy = "MERGE"
+	// Only sets the field if it has no existing value.
+	//   For multi-value fields, the scraped values are only set if there are
+	//   no existing values.
+	FieldStrategyFillBlank FieldStrategy = "FILL_BLANK"
 	// Always replaces the value if a value is found.
 	//   For multi-value fields, any existing values are removed and replaced with the
 	//   scraped values.
@@ -67,12 +96,13 @@ const (
 var AllFieldStrategy = []FieldStrategy{
 	FieldStrategyIgnore,
 	FieldStrategyMerge,
+	FieldStrategyFillBlank,
 	FieldStrategyOverwrite,
 }
 
 func (e FieldStrategy) IsValid() bool {
 	switch e {
-	case FieldStrategyIgnore, FieldStrategyMerge, FieldStrategyOverwrite:
+	case FieldStrategyIgnore, FieldStrategyMerge, FieldStrategyFillBlank, FieldStrategyOverwrite:
 		return true
 	}
 	return false
@@ -0,0 +1,21 @@
+package identify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSources(t *testing.T) {
+	one := 1
+	two := 2
+
+	a := &Source{}
+	b := &Source{Priority: &two}
+	c := &Source{}
+	d := &Source{Priority: &one}
+
+	got := SortSources([]*Source{a, b, c, d})
+
+	assert.Equal(t, []*Source{d, b, a, c}, got)
+}
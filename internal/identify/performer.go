@@ -28,6 +28,11 @@ func getPerformerID(ctx context.Context, endpoint string, w PerformerCreator, p
 		if skipSingleNamePerformers && !strings.Contains(*p.Name, " ") && (p.Disambiguation == nil || len(*p.Disambiguation) == 0) {
 			return nil, ErrSkipSingleNamePerformer
 		}
+		// don't create duplicates of existing performers - the conflict
+		// must be resolved by the user
+		if len(p.Conflicts) > 0 {
+			return nil, ErrPerformerConflict
+		}
 		return createMissingPerformer(ctx, endpoint, w, p)
 	}
 
@@ -115,6 +115,22 @@ func Test_getPerformerID(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"conflicting name creating",
+			args{
+				emptyEndpoint,
+				&models.ScrapedPerformer{
+					Name: &name,
+					Conflicts: []*models.PerformerConflict{
+						{PerformerID: validStoredID, Reason: models.PerformerConflictReasonAlias, MatchedName: name},
+					},
+				},
+				true,
+				false,
+			},
+			nil,
+			true,
+		},
 		{
 			"valid name creating",
 			args{
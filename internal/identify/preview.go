@@ -0,0 +1,230 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// errPreviewRollback is returned from the preview transaction so that any
+// objects created while generating the preview are rolled back.
+var errPreviewRollback = errors.New("rolling back preview")
+
+// FieldChange is a change to a scene field that would be made by identify.
+// Relationship fields are described by the names of the related objects.
+type FieldChange struct {
+	Field    string  `json:"field"`
+	Current  *string `json:"current"`
+	Proposed *string `json:"proposed"`
+}
+
+type Preview struct {
+	// Name of the source that matched the scene. Empty if no source matched.
+	Source  string         `json:"source"`
+	Changes []*FieldChange `json:"changes"`
+}
+
+// Preview returns the changes that Identify would make to the scene, without
+// making them.
+func (t *SceneIdentifier) Preview(ctx context.Context, s *models.Scene) (*Preview, error) {
+	result, err := t.scrapeScene(ctx, s)
+	var multipleMatchErr *MultipleMatchesFoundError
+	if err != nil && !errors.As(err, &multipleMatchErr) {
+		return nil, err
+	}
+
+	if result == nil {
+		return &Preview{}, nil
+	}
+
+	ret := &Preview{
+		Source: result.source.Name,
+	}
+
+	// missing objects may be created when getting the updater, so the
+	// transaction is always rolled back
+	err = txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		if err := t.loadSceneRelationships(ctx, s); err != nil {
+			return err
+		}
+
+		updater, err := t.getSceneUpdater(ctx, s, result)
+		if err != nil {
+			return err
+		}
+
+		ret.Changes, err = t.sceneChanges(ctx, s, updater)
+		if err != nil {
+			return err
+		}
+
+		return errPreviewRollback
+	})
+	if err != nil && !errors.Is(err, errPreviewRollback) {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (t *SceneIdentifier) sceneChanges(ctx context.Context, s *models.Scene, updater *scene.UpdateSet) ([]*FieldChange, error) {
+	var ret []*FieldChange
+	add := func(field string, current string, proposed string) {
+		c := &FieldChange{Field: field}
+		if current != "" {
+			c.Current = &current
+		}
+		c.Proposed = &proposed
+		ret = append(ret, c)
+	}
+
+	p := updater.Partial
+
+	if p.Title.Set {
+		add("title", s.Title, p.Title.Value)
+	}
+	if p.Code.Set {
+		add("code", s.Code, p.Code.Value)
+	}
+	if p.Details.Set {
+		add("details", s.Details, p.Details.Value)
+	}
+	if p.Director.Set {
+		add("director", s.Director, p.Director.Value)
+	}
+	if p.Date.Set {
+		current := ""
+		if s.Date != nil {
+			current = s.Date.String()
+		}
+		add("date", current, p.Date.Value.String())
+	}
+	if p.URLs != nil {
+		add("urls", strings.Join(s.URLs.List(), ", "), strings.Join(p.URLs.Values, ", "))
+	}
+	if p.StudioID.Set {
+		var current []int
+		if s.StudioID != nil {
+			current = []int{*s.StudioID}
+		}
+
+		currentNames, err := t.studioNames(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		proposedNames, err := t.studioNames(ctx, []int{p.StudioID.Value})
+		if err != nil {
+			return nil, err
+		}
+		add("studio", currentNames, proposedNames)
+	}
+	if p.PerformerIDs != nil {
+		currentNames, err := t.performerNames(ctx, s.PerformerIDs.List())
+		if err != nil {
+			return nil, err
+		}
+		proposedNames, err := t.performerNames(ctx, p.PerformerIDs.IDs)
+		if err != nil {
+			return nil, err
+		}
+		add("performers", currentNames, proposedNames)
+	}
+	if p.TagIDs != nil {
+		currentNames, err := t.tagNames(ctx, s.TagIDs.List())
+		if err != nil {
+			return nil, err
+		}
+		proposedNames, err := t.tagNames(ctx, p.TagIDs.IDs)
+		if err != nil {
+			return nil, err
+		}
+		add("tags", currentNames, proposedNames)
+	}
+	if p.StashIDs != nil {
+		add("stash_ids", stashIDsString(s.StashIDs.List()), stashIDsString(p.StashIDs.StashIDs))
+	}
+	if p.Organized.Set {
+		add("organized", strconv.FormatBool(s.Organized), strconv.FormatBool(p.Organized.Value))
+	}
+	if len(updater.CoverImage) > 0 {
+		add("cover_image", "", "new image")
+	}
+
+	return ret, nil
+}
+
+func (t *SceneIdentifier) studioNames(ctx context.Context, ids []int) (string, error) {
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	studios, err := t.StudioReaderWriter.FindMany(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(studios))
+	for i, s := range studios {
+		names[i] = s.Name
+	}
+	return strings.Join(names, ", "), nil
+}
+
+func (t *SceneIdentifier) performerNames(ctx context.Context, ids []int) (string, error) {
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	if t.PerformerGetter == nil {
+		return idsString(ids), nil
+	}
+
+	performers, err := t.PerformerGetter.FindMany(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(performers))
+	for i, p := range performers {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", "), nil
+}
+
+func (t *SceneIdentifier) tagNames(ctx context.Context, ids []int) (string, error) {
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	tags, err := t.TagFinderCreator.FindMany(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", "), nil
+}
+
+func idsString(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ", ")
+}
+
+func stashIDsString(stashIDs []models.StashID) string {
+	s := make([]string, len(stashIDs))
+	for i, id := range stashIDs {
+		s[i] = id.Endpoint + ": " + id.StashID
+	}
+	return strings.Join(s, ", ")
+}
@@ -89,6 +89,10 @@ func (g sceneRelationships) performers(ctx context.Context, ignoreMale bool) ([]
 	var performerIDs []int
 	originalPerformerIDs := g.scene.PerformerIDs.List()
 
+	if strategy == FieldStrategyFillBlank && len(originalPerformerIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		performerIDs = originalPerformerIDs
@@ -107,6 +111,10 @@ func (g sceneRelationships) performers(ctx context.Context, ignoreMale bool) ([]
 				singleNamePerformerSkipped = true
 				continue
 			}
+			if errors.Is(err, ErrPerformerConflict) {
+				logger.Warnf("Not creating performer %q: the name conflicts with %d existing performers", *p.Name, len(p.Conflicts))
+				continue
+			}
 			return nil, err
 		}
 
@@ -148,6 +156,10 @@ func (g sceneRelationships) tags(ctx context.Context) ([]int, error) {
 	var tagIDs []int
 	originalTagIDs := target.TagIDs.List()
 
+	if strategy == FieldStrategyFillBlank && len(originalTagIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		tagIDs = originalTagIDs
@@ -209,6 +221,10 @@ func (g sceneRelationships) stashIDs(ctx context.Context, setUpdateTime bool) ([
 	var stashIDs models.StashIDs
 	originalStashIDs := target.StashIDs.List()
 
+	if strategy == FieldStrategyFillBlank && len(originalStashIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		// make a copy so we don't modify the original
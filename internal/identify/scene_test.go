@@ -274,6 +274,38 @@ func Test_sceneRelationships_performers(t *testing.T) {
 			[]int{validStoredIDInt},
 			false,
 		},
+		{
+			"fill blank existing",
+			sceneWithPerformer,
+			&FieldOptions{
+				Strategy: FieldStrategyFillBlank,
+			},
+			[]*models.ScrapedPerformer{
+				{
+					Name:     &validName,
+					StoredID: &validStoredID,
+				},
+			},
+			false,
+			nil,
+			false,
+		},
+		{
+			"fill blank empty",
+			emptyScene,
+			&FieldOptions{
+				Strategy: FieldStrategyFillBlank,
+			},
+			[]*models.ScrapedPerformer{
+				{
+					Name:     &validName,
+					StoredID: &validStoredID,
+				},
+			},
+			false,
+			[]int{validStoredIDInt},
+			false,
+		},
 		{
 			"ignore male (not male)",
 			sceneWithPerformer,
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+var subsystemRE = regexp.MustCompile(`^\[([^\]]+)\]`)
+
+// Subsystem returns the subsystem of the log item, taken from a bracketed
+// prefix of the message, such as [trim-video]. Returns an empty string if
+// the message has no prefix.
+func (l LogItem) Subsystem() string {
+	m := subsystemRE.FindStringSubmatch(l.Message)
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+// level returns the level of the log item. Progress items are treated as
+// info items.
+func (l LogItem) level() logrus.Level {
+	switch l.Type {
+	case "trace":
+		return logrus.TraceLevel
+	case "debug":
+		return logrus.DebugLevel
+	case "warn":
+		return logrus.WarnLevel
+	case "error":
+		return logrus.ErrorLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
+
+// LogFilter matches log items by level and subsystem.
+type LogFilter struct {
+	// MinLevel is the least severe level to match. If empty, all levels are
+	// matched.
+	MinLevel string
+	// Subsystems to match, case-insensitively. If empty, all subsystems are
+	// matched, including items without a subsystem.
+	Subsystems []string
+}
+
+func (f LogFilter) Matches(l LogItem) bool {
+	if f.MinLevel != "" && l.level() > logLevelFromString(f.MinLevel) {
+		return false
+	}
+
+	if len(f.Subsystems) == 0 {
+		return true
+	}
+
+	subsystem := l.Subsystem()
+	for _, s := range f.Subsystems {
+		if strings.EqualFold(s, subsystem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the items matching the filter.
+func (f LogFilter) Filter(items []LogItem) []LogItem {
+	var ret []LogItem
+	for _, l := range items {
+		if f.Matches(l) {
+			ret = append(ret, l)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLogItem_Subsystem(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"[trim-video] trimmed file", "trim-video"},
+		{"[audio-tracks]", "audio-tracks"},
+		{"no subsystem", ""},
+		{"trailing [subsystem]", ""},
+	}
+
+	for _, tt := range tests {
+		if got := (LogItem{Message: tt.message}).Subsystem(); got != tt.want {
+			t.Errorf("LogItem.Subsystem(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestLogFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter LogFilter
+		item   LogItem
+		want   bool
+	}{
+		{"empty filter", LogFilter{}, LogItem{Type: "trace", Message: "x"}, true},
+		{"level matches", LogFilter{MinLevel: "warning"}, LogItem{Type: "error", Message: "x"}, true},
+		{"level too low", LogFilter{MinLevel: "warning"}, LogItem{Type: "info", Message: "x"}, false},
+		{"progress is info", LogFilter{MinLevel: "info"}, LogItem{Type: "progress", Message: "x"}, true},
+		{"subsystem matches", LogFilter{Subsystems: []string{"Trim-Video"}}, LogItem{Type: "info", Message: "[trim-video] x"}, true},
+		{"subsystem differs", LogFilter{Subsystems: []string{"trim-video"}}, LogItem{Type: "info", Message: "[audio-tracks] x"}, false},
+		{"no subsystem", LogFilter{Subsystems: []string{"trim-video"}}, LogItem{Type: "info", Message: "x"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.item); got != tt.want {
+				t.Errorf("LogFilter.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_GetRecentLogItems(t *testing.T) {
+	l := NewLogger()
+	for i := 0; i < maxLogCacheSize+10; i++ {
+		subsystem := "scan"
+		if i%2 == 0 {
+			subsystem = "trim-video"
+		}
+		l.addToCache(&LogItem{Type: "info", Message: fmt.Sprintf("[%s] %d", subsystem, i)})
+	}
+
+	got := l.GetRecentLogItems(LogFilter{Subsystems: []string{"trim-video"}}, 2)
+	var messages []string
+	for _, item := range got {
+		messages = append(messages, item.Message)
+	}
+
+	want := []string{
+		fmt.Sprintf("[trim-video] %d", maxLogCacheSize+6),
+		fmt.Sprintf("[trim-video] %d", maxLogCacheSize+8),
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("Logger.GetRecentLogItems() = %v, want %v", messages, want)
+	}
+
+	cache := l.GetLogCache()
+	if len(cache) != logCacheSize || cache[0].Message != fmt.Sprintf("[scan] %d", maxLogCacheSize+9) {
+		t.Errorf("Logger.GetLogCache() returned %d items, first %q", len(cache), cache[0].Message)
+	}
+}
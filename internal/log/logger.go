@@ -17,11 +17,18 @@ type LogItem struct {
 	Message string    `json:"message"`
 }
 
+// logCacheSize is the number of items returned by GetLogCache.
+const logCacheSize = 30
+
+// maxLogCacheSize is the number of items kept for backfilling log
+// subscriptions.
+const maxLogCacheSize = 1000
+
 type Logger struct {
 	logger         *logrus.Logger
 	progressLogger *logrus.Logger
 	mutex          sync.Mutex
-	logCache       []LogItem
+	logCache       []LogItem // oldest first
 	logSubs        []chan []LogItem
 	waiting        bool
 	lastBroadcast  time.Time
@@ -115,9 +122,9 @@ func (log *Logger) addToCache(l *LogItem) {
 	// only add to cache if meets minimum log level
 	level := logLevelFromString(l.Type)
 	if level <= log.logger.Level {
-		log.logCache = append([]LogItem{*l}, log.logCache...)
-		if len(log.logCache) > 30 {
-			log.logCache = log.logCache[:len(log.logCache)-1]
+		log.logCache = append(log.logCache, *l)
+		if len(log.logCache) > maxLogCacheSize {
+			log.logCache = log.logCache[len(log.logCache)-maxLogCacheSize:]
 		}
 	}
 }
@@ -130,13 +137,41 @@ func (log *Logger) addLogItem(l *LogItem) {
 	go log.broadcastLogItem(l)
 }
 
+// GetLogCache returns the most recent log items, newest first.
 func (log *Logger) GetLogCache() []LogItem {
 	log.mutex.Lock()
+	defer log.mutex.Unlock()
 
-	ret := make([]LogItem, len(log.logCache))
-	copy(ret, log.logCache)
+	n := len(log.logCache)
+	if n > logCacheSize {
+		n = logCacheSize
+	}
 
-	log.mutex.Unlock()
+	ret := make([]LogItem, n)
+	for i := range ret {
+		ret[i] = log.logCache[len(log.logCache)-1-i]
+	}
+
+	return ret
+}
+
+// GetRecentLogItems returns up to n of the most recent log items matching
+// the filter, oldest first.
+func (log *Logger) GetRecentLogItems(filter LogFilter, n int) []LogItem {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	var ret []LogItem
+	for i := len(log.logCache) - 1; i >= 0 && len(ret) < n; i-- {
+		if filter.Matches(log.logCache[i]) {
+			ret = append(ret, log.logCache[i])
+		}
+	}
+
+	// reverse to oldest first
+	for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+		ret[i], ret[j] = ret[j], ret[i]
+	}
 
 	return ret
 }
@@ -0,0 +1,323 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/remeh/sizedwaitgroup"
+
+	"github.com/stashapp/stash/pkg/conversion"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// autoConversions tracks the files queued for automatic conversion and the
+// conversions used from the daily budget. The budget is reset on startup.
+type autoConversions struct {
+	mutex  sync.Mutex
+	budget conversion.Budget
+	// queued are the files queued or being converted, which are not queued
+	// again by later scans
+	queued map[models.FileID]bool
+}
+
+func newAutoConversions() *autoConversions {
+	return &autoConversions{
+		queued: make(map[models.FileID]bool),
+	}
+}
+
+// take returns the items which are not already queued, up to the budget
+// left for the day of now, and marks them as queued.
+func (a *autoConversions) take(items []conversion.Item, limit int, now time.Time) []conversion.Item {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.budget.Limit = limit
+
+	var ret []conversion.Item
+	for _, item := range items {
+		if a.queued[item.FileID] {
+			continue
+		}
+		if !a.budget.Take(now) {
+			break
+		}
+
+		a.queued[item.FileID] = true
+		ret = append(ret, item)
+	}
+
+	return ret
+}
+
+func (a *autoConversions) done(fileID models.FileID) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	delete(a.queued, fileID)
+}
+
+// queueAutoConversions evaluates the automatic conversion rules against the
+// primary files of the scenes in the scanned paths, and queues a job
+// converting the matched files in stash paths with automatic conversion
+// enabled. All files in the paths are evaluated, so that files left over
+// when the daily budget was used up are converted after later scans.
+func (s *Manager) queueAutoConversions(ctx context.Context, paths []string) {
+	rules := s.Config.GetAutoConversionRules()
+	if len(rules) == 0 {
+		return
+	}
+
+	stashes := s.Config.GetStashPaths()
+	enabled := false
+	for _, stash := range stashes {
+		if stash.AutoConvert {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return
+	}
+
+	if s.FFMpeg == nil {
+		logger.Warn("[auto-convert] ffmpeg is not available, files are not converted")
+		return
+	}
+
+	candidates, err := s.autoConversionCandidates(ctx, paths)
+	if err != nil {
+		logger.Errorf("[auto-convert] error finding scanned scenes: %v", err)
+		return
+	}
+
+	matched := conversion.Evaluate(rules, candidates)
+	if len(matched) == 0 {
+		return
+	}
+
+	items := s.autoConversions.take(matched, s.Config.GetAutoConversionDailyBudget(), time.Now().In(s.Config.GetTimezone()))
+	if len(items) < len(matched) {
+		logger.Infof("[auto-convert] %d matched files are queued or over the daily budget, and are left for later scans", len(matched)-len(items))
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	j := &AutoConvertJob{
+		Items:         items,
+		MaxConcurrent: s.Config.GetAutoConversionMaxConcurrent(),
+	}
+
+	s.JobManager.Add(ctx, fmt.Sprintf("Converting %d files automatically...", len(items)), j)
+}
+
+// autoConversionCandidates returns the primary files of the scenes in the
+// paths which are in stash paths with automatic conversion enabled.
+func (s *Manager) autoConversionCandidates(ctx context.Context, paths []string) ([]conversion.Candidate, error) {
+	const batchSize = 1000
+
+	r := s.Repository
+	stashes := s.Config.GetStashPaths()
+
+	var ret []conversion.Candidate
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			batch, err := scene.Query(ctx, r.Scene, nil, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, sc := range batch {
+				// path is the path of the primary file
+				if sc.Path == "" || !stashes.IsAutoConvertPath(sc.Path) {
+					continue
+				}
+				if len(paths) > 0 && !fsutil.IsPathInDirs(paths, sc.Path) {
+					continue
+				}
+				// the file is being replaced by a running task
+				if s.FileLocks.IsLocked(sc.Path) {
+					continue
+				}
+
+				if err := sc.LoadPrimaryFile(ctx, r.File); err != nil {
+					return err
+				}
+
+				f := sc.Files.Primary()
+				if f == nil {
+					continue
+				}
+
+				ret = append(ret, conversion.Candidate{
+					SceneID:    sc.ID,
+					FileID:     f.ID,
+					Path:       f.Path,
+					Format:     f.Format,
+					VideoCodec: f.VideoCodec,
+					Width:      f.Width,
+					Height:     f.Height,
+					Size:       f.Size,
+				})
+			}
+
+			more = len(batch) == batchSize
+			*findFilter.Page++
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// AutoConvertJob converts the files matched by the automatic conversion
+// rules, converting up to MaxConcurrent files at once.
+type AutoConvertJob struct {
+	Items         []conversion.Item
+	MaxConcurrent int
+}
+
+func (j *AutoConvertJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+
+	// files which are not converted can be queued again by later scans
+	defer func() {
+		for _, item := range j.Items {
+			mgr.autoConversions.done(item.FileID)
+		}
+	}()
+
+	progress.SetTotal(len(j.Items))
+
+	wg := sizedwaitgroup.New(max(j.MaxConcurrent, 1))
+	for _, item := range j.Items {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			break
+		}
+
+		wg.Add()
+		go func(item conversion.Item) {
+			defer wg.Done()
+
+			progress.ExecuteTask(fmt.Sprintf("Converting %s", item.Path), func() {
+				err := j.convert(ctx, item)
+				if err != nil {
+					logger.Errorf("[auto-convert] error converting %s: %v", item.Path, err)
+				}
+				progress.ItemDone(strconv.Itoa(int(item.FileID)), err)
+			})
+
+			progress.Increment()
+		}(item)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func (j *AutoConvertJob) convert(ctx context.Context, item conversion.Item) error {
+	mgr := instance
+	r := mgr.Repository
+
+	var s *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.Scene.Find(ctx, item.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return nil
+		}
+
+		return s.LoadFiles(ctx, r.Scene)
+	}); err != nil {
+		return err
+	}
+
+	// the scene or file may have changed since the scan
+	if s == nil {
+		return fmt.Errorf("scene %d not found", item.SceneID)
+	}
+
+	primary := s.Files.Primary()
+	if primary == nil || primary.ID != item.FileID {
+		logger.Infof("[auto-convert] %s is no longer the primary file of scene %d", item.Path, item.SceneID)
+		return nil
+	}
+
+	if err := mgr.ValidateWritable(primary.Path); err != nil {
+		return err
+	}
+
+	logger.Infof("[auto-convert] %s matches rule %q", item.Path, item.Rule)
+
+	switch item.Action {
+	case conversion.ActionConvertToMP4:
+		t := &ConvertToMP4Task{
+			Scene:                 *s,
+			FileNamingAlgorithm:   mgr.Config.GetVideoFileNamingAlgorithm(),
+			G:                     newReplaceGenerator(),
+			FFMpeg:                mgr.FFMpeg,
+			FFProbe:               mgr.FFProbe,
+			Config:                mgr.Config,
+			Paths:                 mgr.Paths,
+			Repository:            r,
+			FingerprintCalculator: &FingerprintCalculator{Config: mgr.Config},
+		}
+		return t.Execute(ctx, &job.Progress{})
+	case conversion.ActionReduceResolution:
+		t := &ReduceResolutionTask{
+			Scene:                 *s,
+			FileID:                primary.ID,
+			TargetWidth:           item.TargetWidth,
+			TargetHeight:          item.TargetHeight,
+			FileNamingAlgorithm:   mgr.Config.GetVideoFileNamingAlgorithm(),
+			G:                     newReplaceGenerator(),
+			FFMpeg:                mgr.FFMpeg,
+			FFProbe:               mgr.FFProbe,
+			Config:                mgr.Config,
+			Paths:                 mgr.Paths,
+			Repository:            r,
+			FingerprintCalculator: &FingerprintCalculator{Config: mgr.Config},
+		}
+		return t.Execute(ctx, &job.Progress{})
+	}
+
+	return fmt.Errorf("unsupported conversion action %q", item.Action)
+}
+
+// Retry returns a job that converts the files with the given ids again.
+func (j *AutoConvertJob) Retry(ids []string) job.JobExec {
+	retry := make(map[string]bool)
+	for _, id := range ids {
+		retry[id] = true
+	}
+
+	var items []conversion.Item
+	for _, item := range j.Items {
+		if retry[strconv.Itoa(int(item.FileID))] {
+			items = append(items, item)
+		}
+	}
+
+	return &AutoConvertJob{
+		Items:         items,
+		MaxConcurrent: j.MaxConcurrent,
+	}
+}
@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+)
+
+// brokenFiles holds the files rejected as broken by scans, keyed by path.
+// Files are removed when their path is scanned again.
+type brokenFiles struct {
+	mutex sync.Mutex
+	files map[string]file.BrokenFile
+}
+
+func newBrokenFiles() *brokenFiles {
+	return &brokenFiles{
+		files: make(map[string]file.BrokenFile),
+	}
+}
+
+func (b *brokenFiles) add(path string, err *file.BrokenFileError) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.files[path] = file.BrokenFile{
+		Path:       path,
+		Reason:     err.Reason,
+		Detail:     err.Detail,
+		DetectedAt: time.Now(),
+	}
+}
+
+// clear removes the files in the paths, before they are scanned again.
+func (b *brokenFiles) clear(paths []string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for p := range b.files {
+		for _, dir := range paths {
+			if p == dir || strings.HasPrefix(p, dir+string(filepath.Separator)) {
+				delete(b.files, p)
+				break
+			}
+		}
+	}
+}
+
+func (b *brokenFiles) list() []file.BrokenFile {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ret := make([]file.BrokenFile, 0, len(b.files))
+	for _, f := range b.files {
+		ret = append(ret, f)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Path < ret[j].Path
+	})
+
+	return ret
+}
+
+// BrokenFiles returns the files rejected as broken by scans since startup,
+// ordered by path.
+func (s *Manager) BrokenFiles() []file.BrokenFile {
+	return s.brokenFiles.list()
+}
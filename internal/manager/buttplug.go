@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/buttplug"
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const buttplugClientName = "Stash"
+
+// buttplugDevices holds the connection to the Buttplug server, such as
+// Intiface Central, and the player of funscripts on its devices.
+type buttplugDevices struct {
+	mutex  sync.Mutex
+	client *buttplug.Client
+	player *buttplug.Player
+}
+
+// connected returns the client if it is connected, or nil.
+func (b *buttplugDevices) connected() *buttplug.Client {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.client == nil || !b.client.Connected() {
+		return nil
+	}
+
+	return b.client
+}
+
+func (b *buttplugDevices) close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.player != nil {
+		b.player.Stop()
+		b.player = nil
+	}
+
+	if b.client != nil {
+		if err := b.client.Close(); err != nil {
+			logger.Warnf("error closing buttplug connection: %v", err)
+		}
+		b.client = nil
+	}
+}
+
+// ButtplugClient returns the client connected to the Buttplug server, or nil
+// if there is no connection.
+func (s *Manager) ButtplugClient() *buttplug.Client {
+	return s.buttplug.connected()
+}
+
+// ButtplugConnect connects to the configured Buttplug server, replacing any
+// existing connection.
+func (s *Manager) ButtplugConnect(ctx context.Context) error {
+	s.buttplug.close()
+
+	url := s.Config.GetButtplugServerURL()
+	client, err := buttplug.Connect(ctx, url, buttplugClientName)
+	if err != nil {
+		return err
+	}
+
+	s.buttplug.mutex.Lock()
+	defer s.buttplug.mutex.Unlock()
+
+	s.buttplug.client = client
+	s.buttplug.player = buttplug.NewPlayer(client)
+
+	logger.Infof("Connected to buttplug server %s at %s", client.ServerName(), url)
+	return nil
+}
+
+// ButtplugDisconnect stops playback and disconnects from the Buttplug server.
+func (s *Manager) ButtplugDisconnect() {
+	s.buttplug.close()
+}
+
+// ButtplugScan starts scanning for devices on the Buttplug server.
+func (s *Manager) ButtplugScan(ctx context.Context) error {
+	client := s.buttplug.connected()
+	if client == nil {
+		return buttplug.ErrNotConnected
+	}
+
+	return client.StartScanning(ctx)
+}
+
+// ButtplugDeviceLatency returns the configured latency of the device.
+func (s *Manager) ButtplugDeviceLatency(d buttplug.Device) time.Duration {
+	return time.Duration(s.Config.GetButtplugDeviceLatencies()[d.Name]) * time.Millisecond
+}
+
+// ButtplugPlay plays the funscript of the scene on the connected devices,
+// from position seconds into the scene. The configured funscript offset and
+// the latency of each device are applied.
+func (s *Manager) ButtplugPlay(ctx context.Context, scene *models.Scene, position float64) error {
+	client := s.buttplug.connected()
+	if client == nil {
+		return buttplug.ErrNotConnected
+	}
+
+	script, err := LoadFunscriptData(video.GetFunscriptPath(scene.Path))
+	if err != nil {
+		return fmt.Errorf("loading funscript of scene %d: %w", scene.ID, err)
+	}
+
+	actions := buttplugActions(script, s.Config.GetFunscriptOffset())
+	if len(actions) == 0 {
+		return errors.New("funscript has no actions")
+	}
+
+	s.buttplug.mutex.Lock()
+	player := s.buttplug.player
+	s.buttplug.mutex.Unlock()
+
+	player.Play(actions, time.Duration(position*float64(time.Second)), s.ButtplugDeviceLatency)
+	return nil
+}
+
+// ButtplugStop stops playback and stops all devices.
+func (s *Manager) ButtplugStop(ctx context.Context) error {
+	s.buttplug.mutex.Lock()
+	client := s.buttplug.client
+	player := s.buttplug.player
+	s.buttplug.mutex.Unlock()
+
+	if client == nil {
+		return buttplug.ErrNotConnected
+	}
+
+	player.Stop()
+	return client.StopAll(ctx)
+}
+
+// buttplugActions converts the actions of the funscript, offset by offset
+// milliseconds, into device positions between 0 and 1.
+func buttplugActions(script Script, offset int) []buttplug.Action {
+	ret := make([]buttplug.Action, 0, len(script.Actions))
+	for _, a := range script.Actions {
+		pos := a.Pos
+		if script.Inverted {
+			pos = 100 - pos
+		}
+
+		at := time.Duration((a.At + float64(offset)) * float64(time.Millisecond))
+		if at < 0 {
+			continue
+		}
+
+		ret = append(ret, buttplug.Action{
+			At:       at,
+			Position: float64(min(max(pos, 0), 100)) / 100,
+		})
+	}
+
+	return ret
+}
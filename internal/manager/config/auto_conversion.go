@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/conversion"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// GetAutoConversionRules returns the configured automatic conversion rules,
+// in the order they are evaluated.
+func (i *Config) GetAutoConversionRules() []conversion.Rule {
+	var ret []conversion.Rule
+	if err := i.unmarshalKey(AutoConversionRules, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetAutoConversionRules validates and sets the automatic conversion rules.
+// Rule names must be unique.
+func (i *Config) SetAutoConversionRules(rules []conversion.Rule) error {
+	names := make(map[string]bool)
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("conversion rule %q: %w", r.Name, err)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate conversion rule %q", r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	value, err := toConfigMaps(rules)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(AutoConversionRules, value)
+	return nil
+}
+
+// GetAutoConversionMaxConcurrent returns the number of files converted at
+// once by automatic conversion.
+func (i *Config) GetAutoConversionMaxConcurrent() int {
+	return max(i.getInt(AutoConversionMaxConcurrent), 1)
+}
+
+// GetAutoConversionDailyBudget returns the number of files converted
+// automatically per day. Zero is unlimited.
+func (i *Config) GetAutoConversionDailyBudget() int {
+	return i.getInt(AutoConversionDailyBudget)
+}
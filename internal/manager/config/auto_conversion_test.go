@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/conversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetAutoConversionRules(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	rules := []conversion.Rule{
+		{Name: "legacy", Formats: []string{"avi", "wmv"}, Action: conversion.ActionConvertToMP4},
+		{Name: "4k", HeightAbove: 1080, Action: conversion.ActionReduceResolution, TargetHeight: 1080},
+	}
+
+	assert.NoError(i.SetAutoConversionRules(rules))
+	assert.Equal(rules, i.GetAutoConversionRules())
+
+	assert.Error(i.SetAutoConversionRules([]conversion.Rule{rules[0], rules[0]}))
+	assert.Error(i.SetAutoConversionRules([]conversion.Rule{{Name: "all", Action: conversion.ActionConvertToMP4}}))
+}
+
+func TestStashConfigs_IsAutoConvertPath(t *testing.T) {
+	stashes := StashConfigs{
+		{Path: "/videos", AutoConvert: true},
+		{Path: "/archive", AutoConvert: true, ReadOnly: true},
+		{Path: "/other"},
+	}
+
+	assert.True(t, stashes.IsAutoConvertPath("/videos/a.avi"))
+	assert.False(t, stashes.IsAutoConvertPath("/archive/a.avi"))
+	assert.False(t, stashes.IsAutoConvertPath("/other/a.avi"))
+	assert.False(t, stashes.IsAutoConvertPath("/elsewhere/a.avi"))
+}
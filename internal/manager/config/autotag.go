@@ -0,0 +1,93 @@
+package config
+
+import (
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+// getAutoTagMatchModes returns the valid matching modes set for the given
+// key.
+func (i *Config) getAutoTagMatchModes(key string) []match.Mode {
+	ret := []match.Mode{}
+	for _, v := range i.getStringSlice(key) {
+		m := match.Mode(v)
+		if !m.IsValid() {
+			logger.Warnf("ignoring invalid auto-tag match mode %q in %s", v, key)
+			continue
+		}
+		ret = append(ret, m)
+	}
+
+	return ret
+}
+
+// GetAutoTagPerformerMatchModes returns the modes used by auto-tag to match
+// performer names.
+func (i *Config) GetAutoTagPerformerMatchModes() []match.Mode {
+	return i.getAutoTagMatchModes(AutoTagPerformerMatchModes)
+}
+
+// GetAutoTagStudioMatchModes returns the modes used by auto-tag to match
+// studio names.
+func (i *Config) GetAutoTagStudioMatchModes() []match.Mode {
+	return i.getAutoTagMatchModes(AutoTagStudioMatchModes)
+}
+
+// GetAutoTagTagMatchModes returns the modes used by auto-tag to match tag
+// names.
+func (i *Config) GetAutoTagTagMatchModes() []match.Mode {
+	return i.getAutoTagMatchModes(AutoTagTagMatchModes)
+}
+
+// SetAutoTagMatchModes sets the matching modes for the given key, which is
+// one of AutoTagPerformerMatchModes, AutoTagStudioMatchModes or
+// AutoTagTagMatchModes.
+func (i *Config) SetAutoTagMatchModes(key string, modes []match.Mode) {
+	value := make([]string, len(modes))
+	for j, m := range modes {
+		value[j] = m.String()
+	}
+
+	i.SetInterface(key, value)
+}
+
+// GetAutoTagMatchCache returns a new cache for an auto-tag process, with
+// the configured matching options.
+func (i *Config) GetAutoTagMatchCache() *match.Cache {
+	return &match.Cache{
+		Performers: match.NewOptions(i.GetAutoTagPerformerMatchModes()),
+		Studios:    match.NewOptions(i.GetAutoTagStudioMatchModes()),
+		Tags:       match.NewOptions(i.GetAutoTagTagMatchModes()),
+	}
+}
+
+// GetStudioDomainMappings returns the user mappings of the domains of scene
+// urls to studios, used when inferring the studios of scenes.
+func (i *Config) GetStudioDomainMappings() []studio.DomainMapping {
+	var ret []studio.DomainMapping
+	if err := i.unmarshalKey(StudioDomainMappings, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetStudioDomainMappings validates and sets the mappings of domains to
+// studios.
+func (i *Config) SetStudioDomainMappings(mappings []studio.DomainMapping) error {
+	if err := studio.ValidateDomainMappings(mappings); err != nil {
+		return err
+	}
+
+	value := make([]map[string]interface{}, len(mappings))
+	for j, m := range mappings {
+		value[j] = map[string]interface{}{
+			"domain":    studio.Domain(m.Domain),
+			"studio_id": m.StudioID,
+		}
+	}
+
+	i.SetInterface(StudioDomainMappings, value)
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetAutoTagMatchModes(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	modes := []match.Mode{match.ModeWordBoundary, match.ModeTransliterate}
+	i.SetAutoTagMatchModes(AutoTagPerformerMatchModes, modes)
+	i.SetInterface(AutoTagTagMatchModes, []string{"ALIASES", "INVALID"})
+
+	assert.Equal(modes, i.GetAutoTagPerformerMatchModes())
+	assert.Equal([]match.Mode{}, i.GetAutoTagStudioMatchModes())
+
+	cache := i.GetAutoTagMatchCache()
+	assert.Equal(match.Options{WordBoundary: true, Transliterate: true}, cache.PerformerOptions())
+	assert.Equal(match.Options{}, cache.StudioOptions())
+	assert.Equal(match.Options{Aliases: true}, cache.TagOptions())
+}
+
+func TestConfig_SetStudioDomainMappings(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	assert.Empty(i.GetStudioDomainMappings())
+
+	err := i.SetStudioDomainMappings([]studio.DomainMapping{
+		{Domain: "https://www.Example.com/", StudioID: 1},
+		{Domain: "other.com", StudioID: 2},
+	})
+	assert.NoError(err)
+	assert.Equal([]studio.DomainMapping{
+		{Domain: "example.com", StudioID: 1},
+		{Domain: "other.com", StudioID: 2},
+	}, i.GetStudioDomainMappings())
+
+	err = i.SetStudioDomainMappings([]studio.DomainMapping{{Domain: "example.com"}})
+	assert.Error(err)
+	assert.Len(i.GetStudioDomainMappings(), 2, "invalid mappings should not be set")
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+// bundleExcludedKeys are never exported to or imported from a configuration
+// bundle.
+var bundleExcludedKeys = []string{
+	ApiKey,
+	Username,
+	Password,
+	JWTSignKey,
+	SessionStoreKey,
+	OCRAPIKey,
+	// may contain credentials
+	Proxy,
+	// the database is changed by switching library profiles
+	Database,
+	LibraryProfiles,
+	ActiveLibraryProfile,
+	// the content gate may only be changed while it is unlocked
+	ContentGateTags,
+	ContentGatePIN,
+	ContentGateTimeout,
+}
+
+// bundlePathKeys are specific to an instance, and are only exported if
+// requested.
+var bundlePathKeys = []string{
+	Stash,
+	Generated,
+	Cache,
+	Metadata,
+	BlobsPath,
+	BackupDirectoryPath,
+	FFMpegPath,
+	FFProbePath,
+	ScrapersPath,
+	PluginsPath,
+	PythonPath,
+	RclonePath,
+	ScraperCDPPath,
+	LogFile,
+}
+
+// pluginSecretSettingRE matches plugin setting names that are likely to hold
+// secrets.
+var pluginSecretSettingRE = regexp.MustCompile(`(?i)key|token|password|secret`)
+
+// listSecret is a secret field of the items of a list setting, such as the
+// API keys of the stash-box configurations.
+type listSecret struct {
+	// idField identifies the item, so that the secret of an imported item can
+	// be restored from the existing item.
+	idField     string
+	secretField string
+}
+
+// bundleListSecrets are the secret fields of list settings. They are
+// stripped on export, and kept from the existing items on import.
+var bundleListSecrets = map[string]listSecret{
+	StashBoxes:                  {idField: "endpoint", secretField: "apikey"},
+	MediaServers:                {idField: "name", secretField: "token"},
+	ReverseImageSearchProviders: {idField: "name", secretField: "api_key"},
+}
+
+// ConfigChange is a setting that is changed by importing a configuration
+// bundle. OldValue is nil if the setting was not set.
+type ConfigChange struct {
+	Key      string      `json:"key"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+func keyInList(key string, list []string) bool {
+	for _, k := range list {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBundleExcludedKey(key string) bool {
+	if keyInList(key, bundleExcludedKeys) {
+		return true
+	}
+
+	if strings.HasPrefix(key, PluginsSettingPrefix) {
+		// key is plugins.settings.<plugin id>.<setting>
+		parts := strings.Split(strings.TrimPrefix(key, PluginsSettingPrefix), ".")
+		return len(parts) > 1 && pluginSecretSettingRE.MatchString(parts[len(parts)-1])
+	}
+
+	return false
+}
+
+// flatten returns the leaf values of the nested map m, keyed by their
+// dot-separated paths. Lists are treated as leaf values.
+func flatten(m map[string]interface{}, prefix string, out map[string]interface{}) map[string]interface{} {
+	if out == nil {
+		out = make(map[string]interface{})
+	}
+
+	for k, v := range m {
+		key := prefix + k
+		if sub, ok := v.(map[string]interface{}); ok && len(sub) > 0 {
+			flatten(sub, key+".", out)
+			continue
+		}
+
+		out[key] = v
+	}
+
+	return out
+}
+
+// unflatten is the inverse of flatten.
+func unflatten(m map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for k, v := range m {
+		parts := strings.Split(k, ".")
+		cur := ret
+		for _, p := range parts[:len(parts)-1] {
+			sub, ok := cur[p].(map[string]interface{})
+			if !ok {
+				sub = make(map[string]interface{})
+				cur[p] = sub
+			}
+			cur = sub
+		}
+		cur[parts[len(parts)-1]] = v
+	}
+
+	return ret
+}
+
+// strip returns a copy of the items of the list setting v without their
+// secrets.
+func (ls listSecret) strip(v interface{}) interface{} {
+	items, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	var ret []interface{}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			ret = append(ret, item)
+			continue
+		}
+
+		stripped := make(map[string]interface{})
+		for k, v := range m {
+			if k != ls.secretField {
+				stripped[k] = v
+			}
+		}
+		ret = append(ret, stripped)
+	}
+
+	return ret
+}
+
+// restore returns a copy of the imported items of the list setting, with the
+// secrets of the existing items with the same ids.
+func (ls listSecret) restore(imported interface{}, existing interface{}) interface{} {
+	items, ok := imported.([]interface{})
+	if !ok {
+		return imported
+	}
+
+	secrets := make(map[interface{}]interface{})
+	if existingItems, ok := existing.([]interface{}); ok {
+		for _, item := range existingItems {
+			if m, ok := item.(map[string]interface{}); ok {
+				secrets[m[ls.idField]] = m[ls.secretField]
+			}
+		}
+	}
+
+	var ret []interface{}
+	for _, item := range ls.strip(items).([]interface{}) {
+		if m, ok := item.(map[string]interface{}); ok {
+			if secret, found := secrets[m[ls.idField]]; found && secret != nil {
+				m[ls.secretField] = secret
+			}
+		}
+		ret = append(ret, item)
+	}
+
+	return ret
+}
+
+// flatConfig returns the settings in the configuration file, as they would be
+// read back from the file.
+func (i *Config) flatConfig() (map[string]interface{}, error) {
+	data, err := i.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := yaml.Parser().Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(m, "", nil), nil
+}
+
+// ExportBundle returns the configuration without secrets, such as passwords
+// and API keys, or database settings. Instance specific paths are only
+// included if includePaths is true.
+func (i *Config) ExportBundle(includePaths bool) (map[string]interface{}, error) {
+	i.RLock()
+	defer i.RUnlock()
+
+	flat, err := i.flatConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	for k, v := range flat {
+		if isBundleExcludedKey(k) || (!includePaths && keyInList(k, bundlePathKeys)) {
+			continue
+		}
+
+		if ls, found := bundleListSecrets[k]; found {
+			v = ls.strip(v)
+		}
+
+		ret[k] = v
+	}
+
+	return unflatten(ret), nil
+}
+
+// diffBundle returns the changes that importing the bundle would make.
+// Assumes lock held.
+func (i *Config) diffBundle(data []byte) ([]*ConfigChange, error) {
+	// JSON is a subset of YAML, so this parses both formats
+	bundle, err := yaml.Parser().Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing configuration bundle: %w", err)
+	}
+
+	current, err := i.flatConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*ConfigChange
+	for k, v := range flatten(bundle, "", nil) {
+		if isBundleExcludedKey(k) {
+			continue
+		}
+
+		if ls, found := bundleListSecrets[k]; found {
+			v = ls.restore(v, current[k])
+		}
+
+		old := current[k]
+		if reflect.DeepEqual(old, v) {
+			continue
+		}
+
+		ret = append(ret, &ConfigChange{
+			Key:      k,
+			OldValue: old,
+			NewValue: v,
+		})
+	}
+
+	sort.Slice(ret, func(a, b int) bool {
+		return ret[a].Key < ret[b].Key
+	})
+
+	return ret, nil
+}
+
+// DiffBundle returns the changes that importing the configuration bundle
+// would make, without applying them.
+func (i *Config) DiffBundle(data []byte) ([]*ConfigChange, error) {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.diffBundle(data)
+}
+
+// ImportBundle merges the configuration bundle into the configuration and
+// returns the changes made. Secrets and database settings in the bundle are
+// ignored. The configuration is not written.
+func (i *Config) ImportBundle(data []byte) ([]*ConfigChange, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	changes, err := i.diffBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range changes {
+		i.set(c.Key, c.NewValue)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ExportBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+	i.SetString(Password, "hash")
+	i.SetString(ApiKey, "apikey")
+	i.SetString(ContentGatePIN, "pinhash")
+	i.SetString(OCRAPIKey, "secret")
+	i.SetString(Generated, "/generated")
+	i.SetInt(ParallelTasks, 2)
+	i.SetInterface(StashBoxes, []map[string]interface{}{
+		{"endpoint": "https://stashdb.org/graphql", "apikey": "secret", "name": "stashdb"},
+	})
+	i.SetPluginConfiguration("plugin", map[string]interface{}{"apiToken": "secret", "enabled": true})
+	i.SetInterface(MediaServers, []map[string]interface{}{
+		{"name": "plex", "url": "http://plex", "token": "secret"},
+	})
+	i.SetInterface(ReverseImageSearchProviders, []map[string]interface{}{
+		{"name": "search", "endpoint": "http://search", "api_key": "secret"},
+	})
+
+	got, err := i.ExportBundle(false)
+	assert.NoError(err)
+
+	assert.NotContains(got, Password)
+	assert.NotContains(got, ApiKey)
+	assert.NotContains(got, ContentGatePIN)
+	assert.NotContains(got, "ocr")
+	assert.NotContains(got, Generated)
+	assert.Equal(2, got[ParallelTasks])
+	assert.Equal([]interface{}{
+		map[string]interface{}{"endpoint": "https://stashdb.org/graphql", "name": "stashdb"},
+	}, got[StashBoxes])
+	assert.Equal(map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"name": "plex", "url": "http://plex"},
+		},
+	}, got["media_servers"])
+	assert.Equal([]interface{}{
+		map[string]interface{}{"name": "search", "endpoint": "http://search"},
+	}, got[ReverseImageSearchProviders])
+	assert.Equal(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"plugin": map[string]interface{}{"enabled": true},
+		},
+	}, got["plugins"])
+
+	got, err = i.ExportBundle(true)
+	assert.NoError(err)
+	assert.Equal("/generated", got[Generated])
+	assert.NotContains(got, Password)
+}
+
+func TestConfig_ImportBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+	i.SetInt(ParallelTasks, 2)
+	i.SetString(Password, "hash")
+	i.SetString(ContentGatePIN, "pinhash")
+	i.SetInterface(ContentGateTags, []string{"1"})
+	i.SetInterface(StashBoxes, []map[string]interface{}{
+		{"endpoint": "https://stashdb.org/graphql", "apikey": "secret", "name": "stashdb"},
+	})
+	i.SetInterface(MediaServers, []map[string]interface{}{
+		{"name": "plex", "url": "http://plex", "token": "secret"},
+	})
+
+	bundle := []byte(`
+parallel_tasks: 4
+password: imported
+content_gate_pin: imported
+content_gate_tags: []
+stash_boxes:
+  - endpoint: https://stashdb.org/graphql
+    name: StashDB
+media_servers:
+  servers:
+    - name: plex
+      url: http://plex:32400
+`)
+
+	changes, err := i.DiffBundle(bundle)
+	assert.NoError(err)
+	if assert.Len(changes, 3) {
+		assert.Equal(MediaServers, changes[0].Key)
+		assert.Equal(ParallelTasks, changes[1].Key)
+		assert.Equal(2, changes[1].OldValue)
+		assert.Equal(4, changes[1].NewValue)
+		assert.Equal(StashBoxes, changes[2].Key)
+	}
+
+	// diff does not apply the changes
+	assert.Equal(2, i.GetParallelTasks())
+
+	_, err = i.ImportBundle(bundle)
+	assert.NoError(err)
+
+	assert.Equal(4, i.GetParallelTasks())
+	assert.Equal("hash", i.getString(Password))
+	assert.Equal("pinhash", i.getString(ContentGatePIN))
+	assert.Equal([]string{"1"}, i.getStringSlice(ContentGateTags))
+
+	boxes := i.GetStashBoxes()
+	if assert.Len(boxes, 1) {
+		assert.Equal("StashDB", boxes[0].Name)
+		assert.Equal("secret", boxes[0].APIKey)
+	}
+
+	servers := i.GetMediaServers()
+	if assert.Len(servers, 1) {
+		assert.Equal("http://plex:32400", servers[0].URL)
+		assert.Equal("secret", servers[0].Token)
+	}
+
+	// importing again makes no changes
+	changes, err = i.DiffBundle(bundle)
+	assert.NoError(err)
+	assert.Empty(changes)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"strings"
 
 	"sync"
+	"time"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
 
 	"golang.org/x/crypto/bcrypt"
@@ -52,6 +54,78 @@ const (
 
 	Database = "database"
 
+	// time in milliseconds that database connections wait for a lock held by
+	// another connection, before failing with a busy error
+	DatabaseBusyTimeout        = "database_busy_timeout"
+	databaseBusyTimeoutDefault = 50
+
+	// library profiles that can be switched between
+	LibraryProfiles      = "library_profiles"
+	ActiveLibraryProfile = "active_library_profile"
+
+	// Dashboard is the dashboard configuration. It is stored in the
+	// configuration until there are user accounts to store it with.
+	Dashboard = "dashboard"
+
+	// retention rules selecting scenes whose files can be removed
+	RetentionRules       = "retention.rules"
+	RetentionArchivePath = "retention.archive_path"
+	// RetentionReportInterval is the number of hours between scheduled
+	// retention reports. Zero disables scheduled reports.
+	RetentionReportInterval        = "retention.report_interval"
+	retentionReportIntervalDefault = 24
+
+	// rules selecting the video files converted automatically after scans
+	// of stash paths with automatic conversion enabled.
+	// AutoConversionMaxConcurrent is the number of files converted at once,
+	// and AutoConversionDailyBudget the number of files converted per day,
+	// where zero is unlimited.
+	AutoConversionRules                = "auto_conversion.rules"
+	AutoConversionMaxConcurrent        = "auto_conversion.max_concurrent"
+	autoConversionMaxConcurrentDefault = 1
+	AutoConversionDailyBudget          = "auto_conversion.daily_budget"
+
+	// Plex and Jellyfin servers whose watch state and ratings are synced
+	// with scenes
+	MediaServers = "media_servers.servers"
+	// MediaServerSyncInterval is the number of hours between scheduled
+	// syncs with the media servers. Zero disables scheduled syncs.
+	MediaServerSyncInterval        = "media_servers.sync_interval"
+	mediaServerSyncIntervalDefault = 6
+
+	// TempFilesMaxAge is the number of hours after which the files left in
+	// the temp directory, such as the backups of conversion tasks, are
+	// removed. TempFilesMaxSize is the maximum total size of the temp
+	// directory in gigabytes, above which the oldest files are removed.
+	// Zero disables either limit.
+	TempFilesMaxAge        = "temp_files.max_age"
+	tempFilesMaxAgeDefault = 7 * 24
+	TempFilesMaxSize       = "temp_files.max_size"
+
+	// SortCollation is the collation used to sort by text fields, where
+	// the find filter does not set one. SortLocale is the locale used by the
+	// locale collation, which defaults to the interface language.
+	SortCollation = "sort_collation"
+	SortLocale    = "sort_locale"
+
+	// Timezone is the IANA name of the time zone in which event times are
+	// displayed and grouped into days, such as Europe/Berlin. Defaults to
+	// the local time zone of the server.
+	Timezone = "timezone"
+
+	// OrderingProfiles are the default orderings of the results of each
+	// entity type, applied where the find filter does not set a sort
+	OrderingProfiles = "ordering_profiles"
+
+	// modes used by auto-tag to match the names of each type of entity
+	AutoTagPerformerMatchModes = "autotag.performer_match_modes"
+	AutoTagStudioMatchModes    = "autotag.studio_match_modes"
+	AutoTagTagMatchModes       = "autotag.tag_match_modes"
+
+	// StudioDomainMappings map the domains of scene urls to studios, in
+	// addition to the urls of the studios, when inferring studios
+	StudioDomainMappings = "autotag.studio_domain_mappings"
+
 	Exclude      = "exclude"
 	ImageExclude = "image_exclude"
 
@@ -80,12 +154,23 @@ const (
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// ParallelIOTasks is the number of files read in parallel during a scan.
+	// Zero uses the number of parallel tasks.
+	ParallelIOTasks = "parallel_io_tasks"
+
+	// PerformanceProfileKey limits resource usage beyond the other settings.
+	PerformanceProfileKey = "performance_profile"
+
 	PreviewPreset                 = "preview_preset"
 	TranscodeHardwareAcceleration = "ffmpeg.hardware_acceleration"
+	DeinterlaceFilter             = "ffmpeg.deinterlace_filter"
 
 	SequentialScanning        = "sequential_scanning"
 	SequentialScanningDefault = false
 
+	ResumeInterruptedJobs        = "resume_interrupted_jobs"
+	resumeInterruptedJobsDefault = true
+
 	PreviewAudio        = "preview_audio"
 	previewAudioDefault = true
 
@@ -101,18 +186,63 @@ const (
 	PreviewExcludeEnd        = "preview_exclude_end"
 	previewExcludeEndDefault = "0"
 
+	// WallPreviewHeight, WallPreviewCRF and WallPreviewPreset are the
+	// profile of the lightweight previews generated for wall playback.
+	WallPreviewHeight        = "wall_preview_height"
+	wallPreviewHeightDefault = 480
+	WallPreviewCRF           = "wall_preview_crf"
+	wallPreviewCRFDefault    = 28
+	WallPreviewPreset        = "wall_preview_preset"
+
+	SpriteRows        = "sprite_rows"
+	spriteRowsDefault = 9
+
+	SpriteColumns        = "sprite_columns"
+	spriteColumnsDefault = 9
+
+	SpriteInterval = "sprite_interval"
+	SpriteFormat   = "sprite_format"
+
+	// choose generated covers from a sample of scored candidate frames
+	SmartCovers                 = "smart_covers"
+	SmartCoverCandidates        = "smart_cover_candidates"
+	smartCoverCandidatesDefault = 12
+	SmartCoverPreferPeople      = "smart_cover_prefer_people"
+
+	// recognize on-screen text in scene frames with an OCR program or
+	// service
+	OCRCommand       = "ocr.command"
+	OCRArgs          = "ocr.args"
+	OCREndpoint      = "ocr.endpoint"
+	OCRAPIKey        = "ocr.api_key"
+	OCRFrames        = "ocr.frames"
+	ocrFramesDefault = 10
+
+	// generate a reduced sprite when a missing sprite is first requested
+	SpriteOnDemand        = "sprite_on_demand"
+	spriteOnDemandDefault = true
+
 	WriteImageThumbnails        = "write_image_thumbnails"
 	writeImageThumbnailsDefault = true
 
+	// ImageThumbnailProfiles are the sizes and formats in which image
+	// thumbnails are generated, in addition to the default thumbnail.
+	ImageThumbnailProfiles = "image_thumbnail_profiles"
+
 	CreateImageClipsFromVideos        = "create_image_clip_from_videos"
 	createImageClipsFromVideosDefault = false
 
+	DeleteConfirmation = "delete_confirmation"
+
 	Host        = "host"
 	hostDefault = "0.0.0.0"
 
 	Port        = "port"
 	portDefault = 9999
 
+	// IP addresses or interface names to listen on. Overrides host if set.
+	BindAddresses = "bind_addresses"
+
 	ExternalHost = "external_host"
 
 	// http proxy url if required
@@ -135,11 +265,30 @@ const (
 	ScraperCDPPath            = "scraper_cdp_path"
 	ScraperExcludeTagPatterns = "scraper_exclude_tag_patterns"
 
+	// maximum number of pages loaded at the same time using CDP
+	ScraperCDPMaxSessions        = "scraper_cdp_max_sessions"
+	scraperCDPMaxSessionsDefault = 2
+
+	// timeout in seconds for loading a page using CDP
+	ScraperCDPTimeout        = "scraper_cdp_timeout"
+	scraperCDPTimeoutDefault = 60
+
+	// resident memory in MB above which a local CDP browser is restarted.
+	// Zero disables restarts
+	ScraperCDPMaxMemory        = "scraper_cdp_max_memory"
+	scraperCDPMaxMemoryDefault = 1024
+
+	// reverse image search providers used to find pages showing performer
+	// images and gallery covers
+	ReverseImageSearchProviders = "reverse_image_search_providers"
+
 	// stash-box options
 	StashBoxes = "stash_boxes"
 
 	PythonPath = "python_path"
 
+	RclonePath = "rclone_path"
+
 	// plugin options
 	PluginsPath          = "plugins_path"
 	PluginsSetting       = "plugins.settings"
@@ -224,12 +373,29 @@ const (
 	UseStashHostedFunscript        = "use_stash_hosted_funscript"
 	useStashHostedFunscriptDefault = false
 
+	// Buttplug.io (Intiface) device control
+	ButtplugServerURL        = "buttplug_server_url"
+	buttplugServerURLDefault = "ws://127.0.0.1:12345"
+	ButtplugDeviceLatency    = "buttplug_device_latency"
+
 	// Random button rating thresholds
 	RandomRatingThreshold            = "random_rating_threshold"
 	randomRatingThresholdDefault     = 55
 	RandomBestRatingThreshold        = "random_best_rating_threshold"
 	randomBestRatingThresholdDefault = 90
 
+	// number of scenes recently served by play random to a session that
+	// are not served to it again
+	PlayRandomExclusionWindow        = "play_random_exclusion_window"
+	playRandomExclusionWindowDefault = 50
+
+	// Content gate: content with the gated tags is hidden until the PIN is
+	// entered, and again after the timeout in minutes without activity
+	ContentGateTags           = "content_gate_tags"
+	ContentGatePIN            = "content_gate_pin"
+	ContentGateTimeout        = "content_gate_timeout"
+	contentGateTimeoutDefault = 15
+
 	// Similar scenes settings
 	ShowSimilarityPercent        = "show_similarity_percent"
 	showSimilarityPercentDefault = true
@@ -240,9 +406,20 @@ const (
 	// Redirect home page to scenes
 	RedirectHomeToScenes = "redirect_home_to_scenes"
 
+	// View history recording pause. If paused is true and paused until is
+	// empty, recording is paused until resumed.
+	ViewHistoryPaused      = "view_history.paused"
+	ViewHistoryPausedUntil = "view_history.paused_until"
+
 	DrawFunscriptHeatmapRange        = "draw_funscript_heatmap_range"
 	drawFunscriptHeatmapRangeDefault = true
 
+	FunscriptHeatmapPalette          = "funscript_heatmap_palette"
+	FunscriptHeatmapHeight           = "funscript_heatmap_height"
+	funscriptHeatmapHeightDefault    = 60
+	FunscriptHeatmapSmoothing        = "funscript_heatmap_smoothing"
+	funscriptHeatmapSmoothingDefault = 1
+
 	ThemeColor        = "theme_color"
 	DefaultThemeColor = "#202b33"
 
@@ -260,6 +437,7 @@ const (
 	DLNADefaultEnabled     = "dlna.default_enabled"
 	DLNADefaultIPWhitelist = "dlna.default_whitelist"
 	DLNAInterfaces         = "dlna.interfaces"
+	DLNABindAddresses      = "dlna.bind_addresses"
 
 	DLNAVideoSortOrder        = "dlna.video_sort_order"
 	dlnaVideoSortOrderDefault = "title"
@@ -267,6 +445,11 @@ const (
 	DLNAPort        = "dlna.port"
 	DLNAPortDefault = 1338
 
+	DLNAExcludeTags        = "dlna.exclude_tags"
+	DLNAExcludeUnorganized = "dlna.exclude_unorganized"
+	DLNAExcludeBroken      = "dlna.exclude_broken"
+	DLNAExcludeQuarantined = "dlna.exclude_quarantined"
+
 	// Logging options
 	LogFile          = "logfile"
 	LogOut           = "logout"
@@ -277,10 +460,11 @@ const (
 	defaultLogAccess = true
 
 	// Default settings
-	DefaultScanSettings     = "defaults.scan_task"
-	DefaultIdentifySettings = "defaults.identify_task"
-	DefaultAutoTagSettings  = "defaults.auto_tag_task"
-	DefaultGenerateSettings = "defaults.generate_task"
+	DefaultScanSettings      = "defaults.scan_task"
+	DefaultIdentifySettings  = "defaults.identify_task"
+	DefaultAutoTagSettings   = "defaults.auto_tag_task"
+	DefaultGenerateSettings  = "defaults.generate_task"
+	DefaultMarkerEndSettings = "defaults.marker_end"
 
 	DeleteFileDefault             = "defaults.delete_file"
 	DeleteGeneratedDefault        = "defaults.delete_generated"
@@ -460,6 +644,37 @@ func (i *Config) set(key string, value interface{}) {
 	_ = i.main.Set(key, value)
 }
 
+// toConfigMaps converts v to maps keyed by the json names of its fields, so
+// that it is written to the configuration file with the same keys it is read
+// with.
+func toConfigMaps[T any](v []T) ([]map[string]interface{}, error) {
+	var ret []map[string]interface{}
+	if err := convertJSON(v, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// toConfigMap is toConfigMaps for a single value.
+func toConfigMap(v interface{}) (map[string]interface{}, error) {
+	var ret map[string]interface{}
+	if err := convertJSON(v, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func convertJSON(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
 func (i *Config) SetDefault(key string, value interface{}) {
 	i.Lock()
 	defer i.Unlock()
@@ -716,6 +931,18 @@ func (i *Config) GetTempPath() string {
 	return tempPath
 }
 
+// GetTempFilesMaxAge returns the age after which files in the temp
+// directory are removed. Zero disables the limit.
+func (i *Config) GetTempFilesMaxAge() time.Duration {
+	return time.Duration(i.getInt(TempFilesMaxAge)) * time.Hour
+}
+
+// GetTempFilesMaxSize returns the maximum total size of the temp directory
+// in bytes. Zero disables the limit.
+func (i *Config) GetTempFilesMaxSize() int64 {
+	return int64(i.getInt(TempFilesMaxSize)) << 30
+}
+
 func (i *Config) GetBlobsPath() string {
 	return i.getString(BlobsPath)
 }
@@ -746,6 +973,12 @@ func (i *Config) GetDatabasePath() string {
 	return i.getString(Database)
 }
 
+// GetDatabaseBusyTimeout returns the time that database connections wait for
+// a lock held by another connection. Changes take effect on restart.
+func (i *Config) GetDatabaseBusyTimeout() time.Duration {
+	return time.Duration(i.getIntDefault(DatabaseBusyTimeout, databaseBusyTimeoutDefault)) * time.Millisecond
+}
+
 func (i *Config) GetBackupDirectoryPath() string {
 	return i.getString(BackupDirectoryPath)
 }
@@ -760,6 +993,23 @@ func (i *Config) GetBackupDirectoryPathOrDefault() string {
 	return ret
 }
 
+// GetScanExcludedPaths returns the directories written by stash itself,
+// which are never scanned regardless of the exclude patterns.
+func (i *Config) GetScanExcludedPaths() []string {
+	var ret []string
+	for _, p := range []string{
+		i.GetGeneratedPath(),
+		i.GetCachePath(),
+		i.GetTempPath(),
+		i.GetBackupDirectoryPath(),
+	} {
+		if p != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
 // GetFFMpegPath returns the path to the FFMpeg executable.
 // If empty, stash will attempt to resolve it from the path.
 func (i *Config) GetFFMpegPath() string {
@@ -823,6 +1073,46 @@ func (i *Config) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// GetSortCollation returns the collation used to sort by text fields, where
+// the find filter does not set one.
+func (i *Config) GetSortCollation() models.SortCollation {
+	ret := models.SortCollation(i.getString(SortCollation))
+	if !ret.IsValid() {
+		return models.SortCollationNatural
+	}
+
+	return ret
+}
+
+// GetSortLocale returns the locale used to sort with the locale collation.
+// Defaults to the interface language.
+func (i *Config) GetSortLocale() string {
+	ret := i.getString(SortLocale)
+	if ret == "" {
+		return i.GetLanguage()
+	}
+
+	return ret
+}
+
+// GetTimezone returns the time zone in which event times are displayed and
+// grouped into days. Defaults to the local time zone of the server if not
+// set or invalid.
+func (i *Config) GetTimezone() *time.Location {
+	name := i.getString(Timezone)
+	if name == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		logger.Warnf("invalid timezone %q, using the local time zone: %v", name, err)
+		return time.Local
+	}
+
+	return loc
+}
+
 func (i *Config) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -857,6 +1147,12 @@ func (i *Config) GetSequentialScanning() bool {
 	return i.getBool(SequentialScanning)
 }
 
+// GetResumeInterruptedJobs returns true if scan and generate jobs that were
+// interrupted by a shutdown should be resumed on startup.
+func (i *Config) GetResumeInterruptedJobs() bool {
+	return i.getBool(ResumeInterruptedJobs)
+}
+
 func (i *Config) GetGalleryCoverRegex() string {
 	var regexString = i.getString(GalleryCoverRegex)
 
@@ -883,6 +1179,24 @@ func (i *Config) GetScraperCDPPath() string {
 	return i.getString(ScraperCDPPath)
 }
 
+// GetScraperCDPMaxSessions returns the maximum number of pages that are
+// loaded at the same time using CDP.
+func (i *Config) GetScraperCDPMaxSessions() int {
+	return i.getIntDefault(ScraperCDPMaxSessions, scraperCDPMaxSessionsDefault)
+}
+
+// GetScraperCDPTimeout returns the timeout in seconds for loading a page
+// using CDP.
+func (i *Config) GetScraperCDPTimeout() int {
+	return i.getIntDefault(ScraperCDPTimeout, scraperCDPTimeoutDefault)
+}
+
+// GetScraperCDPMaxMemory returns the memory in MB above which a local CDP
+// browser is restarted, or zero if it is never restarted.
+func (i *Config) GetScraperCDPMaxMemory() int {
+	return i.getIntDefault(ScraperCDPMaxMemory, scraperCDPMaxMemoryDefault)
+}
+
 // GetScraperCertCheck returns true if the scraper should check for insecure
 // certificates when fetching an image or a page.
 func (i *Config) GetScraperCertCheck() bool {
@@ -961,6 +1275,10 @@ func (i *Config) GetPythonPath() string {
 	return i.getString(PythonPath)
 }
 
+func (i *Config) GetRclonePath() string {
+	return i.getString(RclonePath)
+}
+
 func (i *Config) GetHost() string {
 	ret := i.getString(Host)
 	if ret == "" {
@@ -970,6 +1288,12 @@ func (i *Config) GetHost() string {
 	return ret
 }
 
+// GetBindAddresses returns the IP addresses or interface names that the
+// server listens on. If empty, the server listens on the host.
+func (i *Config) GetBindAddresses() []string {
+	return i.getStringSlice(BindAddresses)
+}
+
 func (i *Config) GetPort() int {
 	ret := i.getInt(Port)
 	if ret == 0 {
@@ -1004,7 +1328,21 @@ func (i *Config) GetParallelTasksWithAutoDetection() int {
 	if parallelTasks <= 0 {
 		parallelTasks = (runtime.NumCPU() / 4) + 1
 	}
-	return parallelTasks
+	return i.capParallelTasks(parallelTasks)
+}
+
+func (i *Config) GetParallelIOTasks() int {
+	return i.getInt(ParallelIOTasks)
+}
+
+// GetParallelIOTasksWithAutoDetection returns the number of files to read in
+// parallel during a scan. Defaults to the number of parallel tasks.
+func (i *Config) GetParallelIOTasksWithAutoDetection() int {
+	if ret := i.getInt(ParallelIOTasks); ret > 0 {
+		return i.capParallelTasks(ret)
+	}
+
+	return i.GetParallelTasksWithAutoDetection()
 }
 
 func (i *Config) GetPreviewAudio() bool {
@@ -1035,6 +1373,97 @@ func (i *Config) GetPreviewExcludeEnd() string {
 	return i.getString(PreviewExcludeEnd)
 }
 
+// GetSpriteRows returns the maximum number of rows in a scene sprite image.
+func (i *Config) GetSpriteRows() int {
+	ret := i.getInt(SpriteRows)
+	if ret <= 0 {
+		return spriteRowsDefault
+	}
+
+	return ret
+}
+
+// GetSpriteColumns returns the number of columns in a scene sprite image.
+func (i *Config) GetSpriteColumns() int {
+	ret := i.getInt(SpriteColumns)
+	if ret <= 0 {
+		return spriteColumnsDefault
+	}
+
+	return ret
+}
+
+// GetSpriteInterval returns the interval in seconds between sprite
+// thumbnails. If zero, rows * columns thumbnails are spread evenly across
+// the duration of the video.
+func (i *Config) GetSpriteInterval() float64 {
+	return i.getFloat64(SpriteInterval)
+}
+
+// GetSpriteFormat returns the image format of generated sprites. Defaults
+// to JPG.
+func (i *Config) GetSpriteFormat() models.SpriteFormat {
+	ret := models.SpriteFormat(i.getString(SpriteFormat))
+	if !ret.IsValid() {
+		return models.SpriteFormatJpg
+	}
+
+	return ret
+}
+
+// GetSmartCovers returns true if generated covers are chosen from a sample
+// of scored candidate frames, rather than taken at a fixed time.
+func (i *Config) GetSmartCovers() bool {
+	return i.getBool(SmartCovers)
+}
+
+// GetSmartCoverCandidates returns the number of candidate frames scored when
+// choosing a cover.
+func (i *Config) GetSmartCoverCandidates() int {
+	return i.getIntDefault(SmartCoverCandidates, smartCoverCandidatesDefault)
+}
+
+// GetSmartCoverPreferPeople returns true if candidate cover frames with
+// people in them are preferred.
+func (i *Config) GetSmartCoverPreferPeople() bool {
+	return i.getBool(SmartCoverPreferPeople)
+}
+
+// GetOCRCommand returns the path of the OCR program, such as tesseract, used
+// to recognize on-screen text. Not used if the OCR endpoint is set.
+func (i *Config) GetOCRCommand() string {
+	return i.getString(OCRCommand)
+}
+
+// GetOCRArgs returns the arguments of the OCR program. If empty, the
+// arguments of tesseract are used.
+func (i *Config) GetOCRArgs() []string {
+	return i.getStringSlice(OCRArgs)
+}
+
+// GetOCREndpoint returns the url of the OCR service used to recognize
+// on-screen text.
+func (i *Config) GetOCREndpoint() string {
+	return i.getString(OCREndpoint)
+}
+
+// GetOCRAPIKey returns the API key sent to the OCR service.
+func (i *Config) GetOCRAPIKey() string {
+	return i.getString(OCRAPIKey)
+}
+
+// GetOCRFrames returns the number of frames of each scene in which on-screen
+// text is recognized.
+func (i *Config) GetOCRFrames() int {
+	return i.getIntDefault(OCRFrames, ocrFramesDefault)
+}
+
+// GetSpriteOnDemand returns true if a reduced sprite is generated when the
+// sprite of a scene is requested and does not exist.
+func (i *Config) GetSpriteOnDemand() bool {
+	return i.getBoolDefault(SpriteOnDemand, spriteOnDemandDefault)
+}
+
 // GetPreviewPreset returns the preset when generating previews. Defaults to
 // Slow.
 func (i *Config) GetPreviewPreset() models.PreviewPreset {
@@ -1048,10 +1477,55 @@ func (i *Config) GetPreviewPreset() models.PreviewPreset {
 	return models.PreviewPreset(ret)
 }
 
+// GetWallPreviewHeight returns the height of generated wall previews.
+// Defaults to 480.
+func (i *Config) GetWallPreviewHeight() int {
+	ret := i.getInt(WallPreviewHeight)
+	if ret <= 0 {
+		return wallPreviewHeightDefault
+	}
+
+	return ret
+}
+
+// GetWallPreviewCRF returns the constant rate factor used when encoding wall
+// previews. Defaults to 28.
+func (i *Config) GetWallPreviewCRF() int {
+	ret := i.getInt(WallPreviewCRF)
+	if ret <= 0 {
+		return wallPreviewCRFDefault
+	}
+
+	return ret
+}
+
+// GetWallPreviewPreset returns the preset when generating wall previews.
+// Defaults to Veryfast.
+func (i *Config) GetWallPreviewPreset() models.PreviewPreset {
+	ret := models.PreviewPreset(i.getString(WallPreviewPreset))
+	if !ret.IsValid() {
+		return models.PreviewPresetVeryfast
+	}
+
+	return ret
+}
+
 func (i *Config) GetTranscodeHardwareAcceleration() bool {
 	return i.getBool(TranscodeHardwareAcceleration)
 }
 
+// GetDeinterlaceFilter returns the filter used to deinterlace interlaced
+// video. Defaults to bwdif.
+func (i *Config) GetDeinterlaceFilter() models.DeinterlaceFilter {
+	ret := models.DeinterlaceFilter(i.getString(DeinterlaceFilter))
+
+	if !ret.IsValid() {
+		return models.DeinterlaceFilterBwdif
+	}
+
+	return ret
+}
+
 func (i *Config) GetMaxTranscodeSize() models.StreamingResolutionEnum {
 	ret := i.getString(MaxTranscodeSize)
 
@@ -1079,7 +1553,7 @@ func (i *Config) GetTranscodeInputArgs() []string {
 }
 
 func (i *Config) GetTranscodeOutputArgs() []string {
-	return i.getStringSlice(TranscodeOutputArgs)
+	return i.capFFMpegThreads(i.getStringSlice(TranscodeOutputArgs))
 }
 
 func (i *Config) GetLiveTranscodeInputArgs() []string {
@@ -1087,13 +1561,37 @@ func (i *Config) GetLiveTranscodeInputArgs() []string {
 }
 
 func (i *Config) GetLiveTranscodeOutputArgs() []string {
-	return i.getStringSlice(LiveTranscodeOutputArgs)
+	return i.capFFMpegThreads(i.getStringSlice(LiveTranscodeOutputArgs))
 }
 
 func (i *Config) GetDrawFunscriptHeatmapRange() bool {
 	return i.getBoolDefault(DrawFunscriptHeatmapRange, drawFunscriptHeatmapRangeDefault)
 }
 
+// GetFunscriptHeatmapPalette returns the colour palette of generated funscript
+// heatmaps.
+func (i *Config) GetFunscriptHeatmapPalette() HeatmapPalette {
+	ret := HeatmapPalette(i.getString(FunscriptHeatmapPalette))
+	if !ret.IsValid() {
+		return HeatmapPaletteDefault
+	}
+
+	return ret
+}
+
+// GetFunscriptHeatmapHeight returns the height in pixels of generated
+// funscript heatmaps.
+func (i *Config) GetFunscriptHeatmapHeight() int {
+	return i.getIntDefault(FunscriptHeatmapHeight, funscriptHeatmapHeightDefault)
+}
+
+// GetFunscriptHeatmapSmoothing returns the number of neighbouring heatmap
+// segments that the intensity is averaged over. A value of 1 disables
+// smoothing.
+func (i *Config) GetFunscriptHeatmapSmoothing() int {
+	return i.getIntDefault(FunscriptHeatmapSmoothing, funscriptHeatmapSmoothingDefault)
+}
+
 // IsWriteImageThumbnails returns true if image thumbnails should be written
 // to disk after generating on the fly.
 func (i *Config) IsWriteImageThumbnails() bool {
@@ -1104,6 +1602,12 @@ func (i *Config) IsCreateImageClipsFromVideos() bool {
 	return i.getBool(CreateImageClipsFromVideos)
 }
 
+// IsDeleteConfirmation returns true if deleting files requires confirming
+// with a token returned by a previous request.
+func (i *Config) IsDeleteConfirmation() bool {
+	return i.getBool(DeleteConfirmation)
+}
+
 func (i *Config) GetAPIKey() string {
 	return i.getString(ApiKey)
 }
@@ -1499,6 +2003,36 @@ func (i *Config) GetFunscriptOffset() int {
 	return i.getInt(FunscriptOffset)
 }
 
+// GetButtplugServerURL returns the websocket URL of the Buttplug server,
+// such as Intiface Central, used to control devices.
+func (i *Config) GetButtplugServerURL() string {
+	if ret := i.getString(ButtplugServerURL); ret != "" {
+		return ret
+	}
+	return buttplugServerURLDefault
+}
+
+// GetButtplugDeviceLatencies returns the latency in milliseconds of each
+// Buttplug device, keyed by device name.
+func (i *Config) GetButtplugDeviceLatencies() map[string]int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.forKey(ButtplugDeviceLatency).IntMap(ButtplugDeviceLatency)
+}
+
+// SetButtplugDeviceLatency sets the latency in milliseconds of the named
+// Buttplug device.
+func (i *Config) SetButtplugDeviceLatency(name string, latency int) {
+	latencies := i.GetButtplugDeviceLatencies()
+	if latencies == nil {
+		latencies = make(map[string]int)
+	}
+	latencies[name] = latency
+
+	i.SetInterface(ButtplugDeviceLatency, latencies)
+}
+
 func (i *Config) GetUseStashHostedFunscript() bool {
 	return i.getBoolDefault(UseStashHostedFunscript, useStashHostedFunscriptDefault)
 }
@@ -1511,6 +2045,12 @@ func (i *Config) GetRandomBestRatingThreshold() int {
 	return i.getIntDefault(RandomBestRatingThreshold, randomBestRatingThresholdDefault)
 }
 
+// GetPlayRandomExclusionWindow returns the number of scenes recently served
+// by play random to a session that are not served to it again.
+func (i *Config) GetPlayRandomExclusionWindow() int {
+	return i.getIntDefault(PlayRandomExclusionWindow, playRandomExclusionWindowDefault)
+}
+
 func (i *Config) GetShowSimilarityPercent() bool {
 	return i.getBoolDefault(ShowSimilarityPercent, showSimilarityPercentDefault)
 }
@@ -1523,6 +2063,51 @@ func (i *Config) GetRedirectHomeToScenes() bool {
 	return i.getBool(RedirectHomeToScenes)
 }
 
+// GetViewHistoryPausedUntil returns the time until which view history
+// recording is paused. Returns nil if recording is not paused, or is paused
+// until resumed.
+func (i *Config) GetViewHistoryPausedUntil() *time.Time {
+	v := i.getString(ViewHistoryPausedUntil)
+	if v == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// IsViewHistoryPaused returns true if view history recording is currently
+// paused.
+func (i *Config) IsViewHistoryPaused() bool {
+	if !i.getBool(ViewHistoryPaused) {
+		return false
+	}
+
+	until := i.GetViewHistoryPausedUntil()
+	return until == nil || time.Now().Before(*until)
+}
+
+// SetViewHistoryPaused pauses view history recording until the given time, or
+// until resumed if until is nil.
+func (i *Config) SetViewHistoryPaused(until *time.Time) {
+	i.SetBool(ViewHistoryPaused, true)
+	if until != nil {
+		i.SetString(ViewHistoryPausedUntil, until.UTC().Format(time.RFC3339))
+	} else {
+		i.SetInterface(ViewHistoryPausedUntil, nil)
+	}
+}
+
+// ResumeViewHistory resumes view history recording.
+func (i *Config) ResumeViewHistory() {
+	i.SetBool(ViewHistoryPaused, false)
+	i.SetInterface(ViewHistoryPausedUntil, nil)
+}
+
 func (i *Config) GetDeleteFileDefault() bool {
 	return i.getBool(DeleteFileDefault)
 }
@@ -1555,6 +2140,9 @@ func (i *Config) GetDefaultIdentifySettings() *identify.Options {
 // Returns nil if the settings could not be unmarshalled, or if it
 // has not been set.
 func (i *Config) GetDefaultScanSettings() *ScanMetadataOptions {
+	// read before locking, since the lock is not reentrant
+	lowMemory := i.IsLowMemory()
+
 	i.RLock()
 	defer i.RUnlock()
 	v := i.forKey(DefaultScanSettings)
@@ -1564,6 +2152,9 @@ func (i *Config) GetDefaultScanSettings() *ScanMetadataOptions {
 		if err := v.Unmarshal(DefaultScanSettings, &ret); err != nil {
 			return nil
 		}
+		if lowMemory {
+			ret.ScanGeneratePhashes = false
+		}
 		return &ret
 	}
 
@@ -1593,6 +2184,9 @@ func (i *Config) GetDefaultAutoTagSettings() *AutoTagMetadataOptions {
 // Returns nil if the settings could not be unmarshalled, or if it
 // has not been set.
 func (i *Config) GetDefaultGenerateSettings() *models.GenerateMetadataOptions {
+	// read before locking, since the lock is not reentrant
+	lowMemory := i.IsLowMemory()
+
 	i.RLock()
 	defer i.RUnlock()
 	v := i.forKey(DefaultGenerateSettings)
@@ -1602,6 +2196,29 @@ func (i *Config) GetDefaultGenerateSettings() *models.GenerateMetadataOptions {
 		if err := v.Unmarshal(DefaultGenerateSettings, &ret); err != nil {
 			return nil
 		}
+		if lowMemory {
+			ret.Phashes = false
+			ret.InteractiveHeatmapsSpeeds = false
+		}
+		return &ret
+	}
+
+	return nil
+}
+
+// GetDefaultMarkerEndSettings returns the settings setting the end of
+// markers created without an end. Returns nil if the settings could not be
+// unmarshalled, or if they have not been set.
+func (i *Config) GetDefaultMarkerEndSettings() *models.MarkerEndSettings {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(DefaultMarkerEndSettings)
+
+	if v.Exists(DefaultMarkerEndSettings) {
+		var ret models.MarkerEndSettings
+		if err := v.Unmarshal(DefaultMarkerEndSettings, &ret); err != nil {
+			return nil
+		}
 		return &ret
 	}
 
@@ -1644,6 +2261,12 @@ func (i *Config) GetDLNAInterfaces() []string {
 	return i.getStringSlice(DLNAInterfaces)
 }
 
+// GetDLNABindAddresses returns the IP addresses or interface names that the
+// DLNA server listens on. If empty, listens on all interfaces.
+func (i *Config) GetDLNABindAddresses() []string {
+	return i.getStringSlice(DLNABindAddresses)
+}
+
 // GetDLNAPort returns the port to run the DLNA server on. If empty, 1338
 // will be used.
 func (i *Config) GetDLNAPort() int {
@@ -1670,6 +2293,30 @@ func (i *Config) GetVideoSortOrder() string {
 	return ret
 }
 
+// GetDLNAExcludeTags returns the ids of the tags whose scenes, including
+// scenes with descendants of the tags, are hidden from DLNA clients.
+func (i *Config) GetDLNAExcludeTags() []string {
+	return i.getStringSlice(DLNAExcludeTags)
+}
+
+// GetDLNAExcludeUnorganized returns true if scenes that are not organized are
+// hidden from DLNA clients.
+func (i *Config) GetDLNAExcludeUnorganized() bool {
+	return i.getBool(DLNAExcludeUnorganized)
+}
+
+// GetDLNAExcludeBroken returns true if scenes marked as broken are hidden
+// from DLNA clients.
+func (i *Config) GetDLNAExcludeBroken() bool {
+	return i.getBool(DLNAExcludeBroken)
+}
+
+// GetDLNAExcludeQuarantined returns true if scenes with a file that has
+// detected threats are hidden from DLNA clients.
+func (i *Config) GetDLNAExcludeQuarantined() bool {
+	return i.getBool(DLNAExcludeQuarantined)
+}
+
 // GetLogFile returns the filename of the file to output logs to.
 // An empty string means that file logging will be disabled.
 func (i *Config) GetLogFile() string {
@@ -1844,11 +2491,18 @@ func (i *Config) setDefaultValues() {
 
 	i.setDefault(ParallelTasks, parallelTasksDefault)
 	i.setDefault(SequentialScanning, SequentialScanningDefault)
+	i.setDefault(ResumeInterruptedJobs, resumeInterruptedJobsDefault)
 	i.setDefault(PreviewSegmentDuration, previewSegmentDurationDefault)
 	i.setDefault(PreviewSegments, previewSegmentsDefault)
 	i.setDefault(PreviewExcludeStart, previewExcludeStartDefault)
 	i.setDefault(PreviewExcludeEnd, previewExcludeEndDefault)
 	i.setDefault(PreviewAudio, previewAudioDefault)
+	i.setDefault(RetentionReportInterval, retentionReportIntervalDefault)
+	i.setDefault(MediaServerSyncInterval, mediaServerSyncIntervalDefault)
+	i.setDefault(AutoConversionMaxConcurrent, autoConversionMaxConcurrentDefault)
+	i.setDefault(TempFilesMaxAge, tempFilesMaxAgeDefault)
+	i.setDefault(SpriteRows, spriteRowsDefault)
+	i.setDefault(SpriteColumns, spriteColumnsDefault)
 	i.setDefault(SoundOnPreview, false)
 
 	i.setDefault(ThemeColor, DefaultThemeColor)
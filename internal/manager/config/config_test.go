@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +33,14 @@ func TestConfig_GetAllPluginConfiguration(t *testing.T) {
 		"plugin2": {"key3": "value3"},
 	}, i.GetAllPluginConfiguration())
 }
+
+func TestConfig_GetTimezone(t *testing.T) {
+	i := InitializeEmpty()
+	assert.Equal(t, time.Local, i.GetTimezone())
+
+	i.SetString(Timezone, "Europe/Berlin")
+	assert.Equal(t, "Europe/Berlin", i.GetTimezone().String())
+
+	i.SetString(Timezone, "Nowhere/Invalid")
+	assert.Equal(t, time.Local, i.GetTimezone())
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// GetContentGateTags returns the ids of the tags whose content is hidden while
+// the content gate is locked.
+func (i *Config) GetContentGateTags() []string {
+	return i.getStringSlice(ContentGateTags)
+}
+
+// GetContentGateTimeout returns the time without activity after which the
+// content gate locks. Zero disables the automatic lock.
+func (i *Config) GetContentGateTimeout() time.Duration {
+	return time.Duration(i.getIntDefault(ContentGateTimeout, contentGateTimeoutDefault)) * time.Minute
+}
+
+// HasContentGatePIN returns true if a content gate PIN is set.
+func (i *Config) HasContentGatePIN() bool {
+	return i.getString(ContentGatePIN) != ""
+}
+
+// SetContentGatePIN sets the hash of the content gate PIN. A blank PIN
+// removes it.
+func (i *Config) SetContentGatePIN(pin string) {
+	if pin == "" {
+		i.SetString(ContentGatePIN, "")
+	} else {
+		i.SetString(ContentGatePIN, hashPassword(pin))
+	}
+}
+
+// ValidateContentGatePIN returns true if the PIN matches the content gate PIN.
+func (i *Config) ValidateContentGatePIN(pin string) bool {
+	hash := i.getString(ContentGatePIN)
+	if hash == "" {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pin)) == nil
+}
+
+// IsContentGateEnabled returns true if both gated tags and a PIN are set.
+func (i *Config) IsContentGateEnabled() bool {
+	return len(i.GetContentGateTags()) > 0 && i.HasContentGatePIN()
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ContentGate(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	assert.False(i.IsContentGateEnabled())
+	assert.Equal(15*time.Minute, i.GetContentGateTimeout())
+
+	i.SetInterface(ContentGateTags, []string{"1", "2"})
+	assert.False(i.IsContentGateEnabled(), "gate should be disabled without a PIN")
+
+	i.SetContentGatePIN("1234")
+	assert.True(i.IsContentGateEnabled())
+	assert.NotEqual("1234", i.getString(ContentGatePIN), "PIN should be stored hashed")
+	assert.True(i.ValidateContentGatePIN("1234"))
+	assert.False(i.ValidateContentGatePIN("4321"))
+
+	i.SetContentGatePIN("")
+	assert.False(i.IsContentGateEnabled())
+	assert.False(i.ValidateContentGatePIN(""))
+}
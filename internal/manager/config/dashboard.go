@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/dashboard"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// GetDashboard returns the dashboard configuration, or the default dashboard
+// if none is configured.
+func (i *Config) GetDashboard() dashboard.Dashboard {
+	i.RLock()
+	set := i.with(Dashboard) != nil
+	i.RUnlock()
+
+	if !set {
+		return dashboard.Default()
+	}
+
+	var ret dashboard.Dashboard
+	if err := i.unmarshalKey(Dashboard, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetDashboard validates and sets the dashboard configuration.
+func (i *Config) SetDashboard(d dashboard.Dashboard) error {
+	if err := d.Validate(); err != nil {
+		return fmt.Errorf("invalid dashboard: %w", err)
+	}
+
+	// empty lists are stored rather than omitted, so that an empty dashboard
+	// is not replaced by the default dashboard
+	if d.SavedFilterIDs == nil {
+		d.SavedFilterIDs = []string{}
+	}
+	if d.Pins == nil {
+		d.Pins = []dashboard.Pin{}
+	}
+	if d.Widgets == nil {
+		d.Widgets = []dashboard.Widget{}
+	}
+
+	value, err := toConfigMap(d)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(Dashboard, value)
+	return nil
+}
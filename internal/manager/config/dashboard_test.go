@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/dashboard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetDashboard(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	assert.Equal(dashboard.Default(), i.GetDashboard())
+
+	d := dashboard.Dashboard{
+		SavedFilterIDs: []string{"3", "1"},
+		Pins: []dashboard.Pin{
+			{Type: dashboard.ObjectTypeScene, ID: "10"},
+			{Type: dashboard.ObjectTypePerformer, ID: "2"},
+		},
+		Widgets: []dashboard.Widget{
+			{Type: dashboard.WidgetTypeRecentlyAdded, ObjectType: dashboard.ObjectTypeGroup, Count: 5},
+		},
+	}
+
+	assert.NoError(i.SetDashboard(d))
+	assert.Equal(d, i.GetDashboard())
+
+	// an empty dashboard is not replaced by the default
+	assert.NoError(i.SetDashboard(dashboard.Dashboard{}))
+	assert.Empty(i.GetDashboard().Widgets)
+
+	d.Pins = append(d.Pins, d.Pins[0])
+	assert.Error(i.SetDashboard(d))
+}
@@ -48,3 +48,93 @@ func (e *BlobsStorageType) UnmarshalGQL(v interface{}) error {
 func (e BlobsStorageType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type HeatmapPalette string
+
+const (
+	// Blue through green, yellow and red to purple
+	HeatmapPaletteDefault HeatmapPalette = "DEFAULT"
+	// Dark to light grey
+	HeatmapPaletteGrayscale HeatmapPalette = "GRAYSCALE"
+	// Perceptually uniform purple through blue and green to yellow
+	HeatmapPaletteViridis HeatmapPalette = "VIRIDIS"
+)
+
+var AllHeatmapPalette = []HeatmapPalette{
+	HeatmapPaletteDefault,
+	HeatmapPaletteGrayscale,
+	HeatmapPaletteViridis,
+}
+
+func (e HeatmapPalette) IsValid() bool {
+	switch e {
+	case HeatmapPaletteDefault, HeatmapPaletteGrayscale, HeatmapPaletteViridis:
+		return true
+	}
+	return false
+}
+
+func (e HeatmapPalette) String() string {
+	return string(e)
+}
+
+func (e *HeatmapPalette) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HeatmapPalette(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HeatmapPalette", str)
+	}
+	return nil
+}
+
+func (e HeatmapPalette) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PerformanceProfile string
+
+const (
+	// No limits beyond the configured settings
+	PerformanceProfileDefault PerformanceProfile = "DEFAULT"
+	// Reduced concurrency, ffmpeg threads and in-memory caches for devices
+	// with 1-2GB of memory
+	PerformanceProfileLowMemory PerformanceProfile = "LOW_MEMORY"
+)
+
+var AllPerformanceProfile = []PerformanceProfile{
+	PerformanceProfileDefault,
+	PerformanceProfileLowMemory,
+}
+
+func (e PerformanceProfile) IsValid() bool {
+	switch e {
+	case PerformanceProfileDefault, PerformanceProfileLowMemory:
+		return true
+	}
+	return false
+}
+
+func (e PerformanceProfile) String() string {
+	return string(e)
+}
+
+func (e *PerformanceProfile) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformanceProfile(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformanceProfile", str)
+	}
+	return nil
+}
+
+func (e PerformanceProfile) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// DefaultLibraryProfile is the name of the profile holding the library
+// configured before any profile was created.
+const DefaultLibraryProfile = "default"
+
+// LibraryProfile is a separate library with its own database, generated
+// files, blobs and stash paths.
+type LibraryProfile struct {
+	Name      string       `json:"name"`
+	Database  string       `json:"database"`
+	Generated string       `json:"generated"`
+	Blobs     string       `json:"blobs"`
+	Stashes   StashConfigs `json:"stashes"`
+}
+
+type LibraryProfileInput struct {
+	Name      string              `json:"name"`
+	Database  string              `json:"database"`
+	Generated string              `json:"generated"`
+	Blobs     *string             `json:"blobs"`
+	Stashes   []*StashConfigInput `json:"stashes"`
+}
+
+// GetLibraryProfiles returns the configured library profiles. The values
+// of the active profile are taken from the current configuration.
+func (i *Config) GetLibraryProfiles() []*LibraryProfile {
+	var ret []*LibraryProfile
+	if err := i.unmarshalKey(LibraryProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	active := i.GetActiveLibraryProfile()
+	current := i.currentLibraryProfile()
+	for idx, p := range ret {
+		if p.Name == active {
+			ret[idx] = current
+			return ret
+		}
+	}
+
+	return append([]*LibraryProfile{current}, ret...)
+}
+
+// GetActiveLibraryProfile returns the name of the active library profile.
+func (i *Config) GetActiveLibraryProfile() string {
+	ret := i.getString(ActiveLibraryProfile)
+	if ret == "" {
+		return DefaultLibraryProfile
+	}
+	return ret
+}
+
+func (i *Config) currentLibraryProfile() *LibraryProfile {
+	return &LibraryProfile{
+		Name:      i.GetActiveLibraryProfile(),
+		Database:  i.GetDatabasePath(),
+		Generated: i.GetGeneratedPath(),
+		Blobs:     i.GetBlobsPath(),
+		Stashes:   i.GetStashPaths(),
+	}
+}
+
+// SaveLibraryProfile adds or replaces an inactive library profile.
+func (i *Config) SaveLibraryProfile(input LibraryProfileInput) (*LibraryProfile, error) {
+	if input.Name == "" {
+		return nil, errors.New("profile name must not be empty")
+	}
+	if input.Name == i.GetActiveLibraryProfile() {
+		return nil, errors.New("cannot modify the active profile")
+	}
+	if input.Database == "" || input.Generated == "" {
+		return nil, errors.New("database and generated paths are required")
+	}
+	if i.GetBlobsStorage() == BlobStorageTypeFilesystem && (input.Blobs == nil || *input.Blobs == "") {
+		return nil, errors.New("blobs path is required when blobs are stored in the filesystem")
+	}
+
+	profiles := i.GetLibraryProfiles()
+	for _, p := range profiles {
+		if p.Name != input.Name && (p.Database == input.Database || p.Generated == input.Generated) {
+			return nil, fmt.Errorf("database and generated paths are already used by profile %q", p.Name)
+		}
+	}
+
+	profile := &LibraryProfile{
+		Name:      input.Name,
+		Database:  input.Database,
+		Generated: input.Generated,
+	}
+	if input.Blobs != nil {
+		profile.Blobs = *input.Blobs
+	}
+	for _, s := range input.Stashes {
+		profile.Stashes = append(profile.Stashes, &StashConfig{
+			Path:         s.Path,
+			ExcludeVideo: s.ExcludeVideo,
+			ExcludeImage: s.ExcludeImage,
+			ReadOnly:     s.ReadOnly,
+			Rclone:       s.Rclone,
+			AutoConvert:  s.AutoConvert,
+		})
+	}
+
+	replaced := false
+	for idx, p := range profiles {
+		if p.Name == input.Name {
+			profiles[idx] = profile
+			replaced = true
+		}
+	}
+	if !replaced {
+		profiles = append(profiles, profile)
+	}
+
+	i.SetInterface(LibraryProfiles, profiles)
+	return profile, nil
+}
+
+// DeleteLibraryProfile removes an inactive library profile. The database and
+// generated files of the profile are not deleted.
+func (i *Config) DeleteLibraryProfile(name string) error {
+	if name == i.GetActiveLibraryProfile() {
+		return errors.New("cannot delete the active profile")
+	}
+
+	profiles := i.GetLibraryProfiles()
+	for idx, p := range profiles {
+		if p.Name == name {
+			i.SetInterface(LibraryProfiles, append(profiles[:idx], profiles[idx+1:]...))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("profile %q not found", name)
+}
+
+// ActivateLibraryProfile stores the current library in the active profile
+// and replaces the database, generated, blobs and stash configuration with
+// the values of the named profile. The configuration must be written and
+// the database reopened afterwards.
+func (i *Config) ActivateLibraryProfile(name string) (*LibraryProfile, error) {
+	for _, key := range []string{Database, Generated, BlobsPath, Stash} {
+		if i.HasOverride(key) {
+			return nil, fmt.Errorf("cannot switch profiles while %s is overridden", key)
+		}
+	}
+
+	var profile *LibraryProfile
+	profiles := i.GetLibraryProfiles()
+	for _, p := range profiles {
+		if p.Name == name {
+			profile = p
+		}
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.set(LibraryProfiles, profiles)
+	i.set(ActiveLibraryProfile, profile.Name)
+	i.set(Database, profile.Database)
+	i.set(Generated, profile.Generated)
+	i.set(BlobsPath, profile.Blobs)
+	i.set(Stash, profile.Stashes)
+
+	return profile, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/mediaserver"
+)
+
+const mediaServerSyncStateFilename = "media_server_sync.json"
+
+// GetMediaServers returns the media servers whose watch state and ratings
+// are synced with scenes.
+func (i *Config) GetMediaServers() []mediaserver.Server {
+	var ret []mediaserver.Server
+	if err := i.unmarshalKey(MediaServers, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetMediaServers validates and sets the media servers. Server names must
+// be unique.
+func (i *Config) SetMediaServers(servers []mediaserver.Server) error {
+	if err := mediaserver.ValidateServers(servers); err != nil {
+		return err
+	}
+
+	value, err := toConfigMaps(servers)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(MediaServers, value)
+	return nil
+}
+
+// GetMediaServerSyncInterval returns the time between scheduled syncs with
+// the media servers. Zero disables scheduled syncs.
+func (i *Config) GetMediaServerSyncInterval() time.Duration {
+	return time.Duration(i.getInt(MediaServerSyncInterval)) * time.Hour
+}
+
+// GetMediaServerSyncStatePath returns the path of the file storing the state
+// of items when they were last synced with the media servers.
+func (i *Config) GetMediaServerSyncStatePath() string {
+	return filepath.Join(i.GetConfigPath(), mediaServerSyncStateFilename)
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/mediaserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetMediaServers(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	servers := []mediaserver.Server{
+		{
+			Name:         "plex",
+			Type:         mediaserver.ServerTypePlex,
+			URL:          "http://localhost:32400",
+			Token:        "token",
+			PathMappings: []mediaserver.PathMapping{{From: "/data/", To: "/stash/"}},
+		},
+		{
+			Name:   "jellyfin",
+			Type:   mediaserver.ServerTypeJellyfin,
+			URL:    "http://localhost:8096",
+			Token:  "key",
+			UserID: "user",
+		},
+	}
+
+	assert.NoError(i.SetMediaServers(servers))
+	assert.Equal(servers, i.GetMediaServers())
+
+	assert.Error(i.SetMediaServers([]mediaserver.Server{servers[0], servers[0]}))
+}
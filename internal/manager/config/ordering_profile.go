@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GetOrderingProfiles returns the configured default orderings of the
+// results of each entity type.
+func (i *Config) GetOrderingProfiles() []models.OrderingProfile {
+	var ret []models.OrderingProfile
+	if err := i.unmarshalKey(OrderingProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// GetOrderingProfile returns the default ordering of the results of the
+// given entity type, or nil if there is none.
+func (i *Config) GetOrderingProfile(mode models.FilterMode) *models.OrderingProfile {
+	for _, p := range i.GetOrderingProfiles() {
+		if p.Mode == mode {
+			return &p
+		}
+	}
+
+	return nil
+}
+
+// SetOrderingProfiles validates and sets the default orderings. There may be
+// at most one profile for each entity type.
+func (i *Config) SetOrderingProfiles(profiles []models.OrderingProfile) error {
+	modes := make(map[models.FilterMode]bool)
+	for _, p := range profiles {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("ordering profile for %s: %w", p.Mode, err)
+		}
+		if modes[p.Mode] {
+			return fmt.Errorf("duplicate ordering profile for %s", p.Mode)
+		}
+		modes[p.Mode] = true
+	}
+
+	value, err := toConfigMaps(profiles)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(OrderingProfiles, value)
+	return nil
+}
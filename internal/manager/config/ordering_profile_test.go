@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetOrderingProfiles(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	desc := models.SortDirectionEnumDesc
+	perPage := 60
+	profiles := []models.OrderingProfile{
+		{
+			Mode:   models.FilterModeScenes,
+			Sort:   "studio",
+			ThenBy: []*models.SortKey{{Sort: "date", Direction: &desc}},
+		},
+		{Mode: models.FilterModePerformers, Sort: "name", PerPage: &perPage},
+	}
+
+	assert.NoError(i.SetOrderingProfiles(profiles))
+	assert.Equal(profiles, i.GetOrderingProfiles())
+	assert.Equal(&profiles[1], i.GetOrderingProfile(models.FilterModePerformers))
+	assert.Nil(i.GetOrderingProfile(models.FilterModeTags))
+
+	assert.Error(i.SetOrderingProfiles([]models.OrderingProfile{profiles[0], profiles[0]}))
+	assert.Error(i.SetOrderingProfiles([]models.OrderingProfile{{Mode: models.FilterModeScenes}}))
+}
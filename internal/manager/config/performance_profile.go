@@ -0,0 +1,84 @@
+package config
+
+import (
+	"slices"
+	"strconv"
+)
+
+const (
+	// lowMemoryParallelTasks is the maximum number of parallel tasks and
+	// files read in parallel during scans in the low memory profile.
+	lowMemoryParallelTasks = 1
+
+	// lowMemoryFFMpegThreads is the number of threads used by ffmpeg
+	// encoders in the low memory profile, unless set in the output args.
+	lowMemoryFFMpegThreads = 2
+
+	// lowMemoryDatabaseCacheSize is the size of the SQLite page cache of
+	// each database connection in the low memory profile, in KiB. The
+	// SQLite default is 2000KiB.
+	lowMemoryDatabaseCacheSize = 512
+
+	graphqlQueryCacheSize          = 1000
+	lowMemoryGraphqlQueryCacheSize = 100
+)
+
+// GetPerformanceProfile returns the profile limiting resource usage.
+func (i *Config) GetPerformanceProfile() PerformanceProfile {
+	ret := PerformanceProfile(i.getString(PerformanceProfileKey))
+	if !ret.IsValid() {
+		return PerformanceProfileDefault
+	}
+
+	return ret
+}
+
+// IsLowMemory returns true if the low memory profile is active. In the low
+// memory profile, scans and generation run one task at a time, ffmpeg
+// encoders use a limited number of threads, phashes and heatmaps are not
+// generated by default, and in-memory caches are reduced or disabled.
+func (i *Config) IsLowMemory() bool {
+	return i.GetPerformanceProfile() == PerformanceProfileLowMemory
+}
+
+func (i *Config) capParallelTasks(n int) int {
+	if i.IsLowMemory() {
+		return min(n, lowMemoryParallelTasks)
+	}
+	return n
+}
+
+// capFFMpegThreads adds the low memory thread count to ffmpeg output args
+// that do not set the number of threads.
+func (i *Config) capFFMpegThreads(args []string) []string {
+	if !i.IsLowMemory() || slices.Contains(args, "-threads") {
+		return args
+	}
+
+	return append(slices.Clip(args), "-threads", strconv.Itoa(lowMemoryFFMpegThreads))
+}
+
+// GetDatabaseCacheSize returns the size of the SQLite page cache of each
+// database connection in KiB, or zero to use the SQLite default.
+func (i *Config) GetDatabaseCacheSize() int {
+	if i.IsLowMemory() {
+		return lowMemoryDatabaseCacheSize
+	}
+	return 0
+}
+
+// GetGraphQLQueryCacheSize returns the number of parsed GraphQL queries to
+// cache.
+func (i *Config) GetGraphQLQueryCacheSize() int {
+	if i.IsLowMemory() {
+		return lowMemoryGraphqlQueryCacheSize
+	}
+	return graphqlQueryCacheSize
+}
+
+// IsPhashIndexEnabled returns true if scene phashes are held in memory to
+// speed up duplicate searches. The index is disabled in the low memory
+// profile, where duplicates are found by comparing every pair of phashes.
+func (i *Config) IsPhashIndexEnabled() bool {
+	return !i.IsLowMemory()
+}
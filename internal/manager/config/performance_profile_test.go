@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_LowMemoryProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+	i.SetInt(ParallelTasks, 4)
+	i.SetInterface(TranscodeOutputArgs, []string{"-crf", "23"})
+	i.SetInterface(DefaultGenerateSettings, &models.GenerateMetadataOptions{
+		Covers:                    true,
+		Phashes:                   true,
+		InteractiveHeatmapsSpeeds: true,
+	})
+
+	assert.Equal(PerformanceProfileDefault, i.GetPerformanceProfile())
+	assert.Equal(4, i.GetParallelTasksWithAutoDetection())
+	assert.Equal([]string{"-crf", "23"}, i.GetTranscodeOutputArgs())
+	assert.True(i.GetDefaultGenerateSettings().Phashes)
+	assert.True(i.IsPhashIndexEnabled())
+
+	i.SetString(PerformanceProfileKey, PerformanceProfileLowMemory.String())
+
+	assert.Equal(lowMemoryParallelTasks, i.GetParallelTasksWithAutoDetection())
+	assert.Equal(lowMemoryParallelTasks, i.GetParallelIOTasksWithAutoDetection())
+	assert.Equal([]string{"-crf", "23", "-threads", "2"}, i.GetTranscodeOutputArgs())
+	assert.False(i.IsPhashIndexEnabled())
+
+	generate := i.GetDefaultGenerateSettings()
+	assert.True(generate.Covers)
+	assert.False(generate.Phashes)
+	assert.False(generate.InteractiveHeatmapsSpeeds)
+
+	// threads set by the user are not replaced
+	i.SetInterface(TranscodeOutputArgs, []string{"-threads", "4"})
+	assert.Equal([]string{"-threads", "4"}, i.GetTranscodeOutputArgs())
+
+	i.SetString(PerformanceProfileKey, "invalid")
+	assert.Equal(PerformanceProfileDefault, i.GetPerformanceProfile())
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/retention"
+)
+
+const retentionAuditLogFilename = "retention_audit.log"
+
+// GetRetentionRules returns the configured retention rules, in the order
+// they are evaluated.
+func (i *Config) GetRetentionRules() []retention.Rule {
+	var ret []retention.Rule
+	if err := i.unmarshalKey(RetentionRules, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetRetentionRules validates and sets the retention rules. Rule names must
+// be unique.
+func (i *Config) SetRetentionRules(rules []retention.Rule) error {
+	names := make(map[string]bool)
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("retention rule %q: %w", r.Name, err)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate retention rule %q", r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	value, err := toConfigMaps(rules)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(RetentionRules, value)
+	return nil
+}
+
+// GetRetentionArchivePath returns the directory that files are moved to by
+// retention rules with the archive action.
+func (i *Config) GetRetentionArchivePath() string {
+	return i.getString(RetentionArchivePath)
+}
+
+// GetRetentionReportInterval returns the time between scheduled retention
+// reports. Zero disables scheduled reports.
+func (i *Config) GetRetentionReportInterval() time.Duration {
+	return time.Duration(i.getInt(RetentionReportInterval)) * time.Hour
+}
+
+// GetRetentionAuditLogPath returns the path of the log of the files deleted
+// or archived by retention rules.
+func (i *Config) GetRetentionAuditLogPath() string {
+	return filepath.Join(i.GetConfigPath(), retentionAuditLogFilename)
+}
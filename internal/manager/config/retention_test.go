@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/retention"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetRetentionRules(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	rules := []retention.Rule{
+		{Name: "stale", Unwatched: true, RatingBelow: 40, OlderThanDays: 730, Action: retention.ActionArchive},
+		{Name: "abandoned", NotPlayedForDays: 365, Action: retention.ActionDelete},
+	}
+
+	assert.NoError(i.SetRetentionRules(rules))
+	assert.Equal(rules, i.GetRetentionRules())
+
+	assert.Error(i.SetRetentionRules([]retention.Rule{rules[0], rules[0]}))
+	assert.Error(i.SetRetentionRules([]retention.Rule{{Name: "all", Action: retention.ActionDelete}}))
+}
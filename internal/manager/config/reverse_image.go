@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/reverseimage"
+)
+
+// GetReverseImageSearchProviders returns the configured reverse image search
+// providers.
+func (i *Config) GetReverseImageSearchProviders() []reverseimage.Provider {
+	var ret []reverseimage.Provider
+	if err := i.unmarshalKey(ReverseImageSearchProviders, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetReverseImageSearchProviders validates and sets the reverse image search
+// providers. Provider names must be unique.
+func (i *Config) SetReverseImageSearchProviders(providers []reverseimage.Provider) error {
+	names := make(map[string]bool)
+	for _, p := range providers {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("reverse image search provider %q: %w", p.Name, err)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate reverse image search provider %q", p.Name)
+		}
+		names[p.Name] = true
+	}
+
+	value, err := toConfigMaps(providers)
+	if err != nil {
+		return err
+	}
+
+	i.SetInterface(ReverseImageSearchProviders, value)
+	return nil
+}
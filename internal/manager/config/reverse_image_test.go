@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/reverseimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetReverseImageSearchProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	providers := []reverseimage.Provider{
+		{Name: "local", Endpoint: "http://localhost:8000/search", MinScore: 0.5},
+		{Name: "remote", Endpoint: "https://example.com/api/search", APIKey: "key"},
+	}
+
+	assert.NoError(i.SetReverseImageSearchProviders(providers))
+	assert.Equal(providers, i.GetReverseImageSearchProviders())
+
+	assert.Error(i.SetReverseImageSearchProviders([]reverseimage.Provider{providers[0], providers[0]}))
+	assert.Error(i.SetReverseImageSearchProviders([]reverseimage.Provider{{Name: "invalid", Endpoint: "localhost"}}))
+}
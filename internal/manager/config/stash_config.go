@@ -11,12 +11,23 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	ReadOnly     bool   `json:"readOnly"`
+	Rclone       bool   `json:"rclone"`
+	AutoConvert  bool   `json:"autoConvert"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// ReadOnly prevents files in the path from being deleted, moved or replaced
+	ReadOnly bool `json:"readOnly"`
+	// Rclone indicates that the path is an rclone remote, such as
+	// "gdrive:Videos". Rclone stashes are always read-only.
+	Rclone bool `json:"rclone"`
+	// AutoConvert enables the automatic conversion of the video files in
+	// the path after scans, according to the conversion rules
+	AutoConvert bool `json:"autoConvert"`
 }
 
 type StashConfigs []*StashConfig
@@ -30,6 +41,17 @@ func (s StashConfigs) GetStashFromPath(path string) *StashConfig {
 	return nil
 }
 
+// IsReadOnlyPath returns true if the file path is in a read-only or rclone
+// stash path.
+func (s StashConfigs) IsReadOnlyPath(path string) bool {
+	for _, f := range s {
+		if (f.ReadOnly || f.Rclone) && fsutil.IsPathInDir(f.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s StashConfigs) GetStashFromDirPath(dirPath string) *StashConfig {
 	for _, f := range s {
 		if fsutil.IsPathInDir(f.Path, dirPath) {
@@ -38,3 +60,61 @@ func (s StashConfigs) GetStashFromDirPath(dirPath string) *StashConfig {
 	}
 	return nil
 }
+
+// Paths returns the paths of the stashes.
+func (s StashConfigs) Paths() []string {
+	ret := make([]string, len(s))
+	for i, f := range s {
+		ret[i] = f.Path
+	}
+	return ret
+}
+
+// Overlapping returns the paths that are within one of the stashes.
+func (s StashConfigs) Overlapping(paths []string) []string {
+	var ret []string
+	for _, p := range paths {
+		if s.GetStashFromDirPath(p) != nil {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// Remotes returns the rclone stashes.
+func (s StashConfigs) Remotes() StashConfigs {
+	var ret StashConfigs
+	for _, f := range s {
+		if f.Rclone {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+// Local returns the stashes that are not rclone remotes.
+func (s StashConfigs) Local() StashConfigs {
+	var ret StashConfigs
+	for _, f := range s {
+		if !f.Rclone {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+// IsRemotePath returns true if the file path is in an rclone stash path.
+func (s StashConfigs) IsRemotePath(path string) bool {
+	return fsutil.IsPathInDirs(s.Remotes().Paths(), path)
+}
+
+// IsAutoConvertPath returns true if the file path is in a stash path with
+// automatic conversion enabled, which is not read-only.
+func (s StashConfigs) IsAutoConvertPath(path string) bool {
+	for _, f := range s {
+		if f.AutoConvert && fsutil.IsPathInDir(f.Path, path) {
+			return !s.IsReadOnlyPath(path)
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStashConfigsOverlapping(t *testing.T) {
+	library := filepath.Join("data", "library")
+	stashes := StashConfigs{
+		{Path: library},
+	}
+
+	generated := filepath.Join(library, ".stash", "generated")
+	cache := filepath.Join("data", "cache")
+
+	assert.Equal(t, []string{generated}, stashes.Overlapping([]string{generated, cache}))
+	assert.Nil(t, stashes.Overlapping([]string{cache}))
+}
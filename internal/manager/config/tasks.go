@@ -17,6 +17,8 @@ type ScanMetadataOptions struct {
 	ScanGenerateThumbnails bool `json:"scanGenerateThumbnails"`
 	// Generate image thumbnails during scan
 	ScanGenerateClipPreviews bool `json:"scanGenerateClipPreviews"`
+	// Create gallery chapters from subfolders during scan
+	ScanGenerateGalleryChapters bool `json:"scanGenerateGalleryChapters"`
 }
 
 type AutoTagMetadataOptions struct {
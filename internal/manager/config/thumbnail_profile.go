@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// GetImageThumbnailProfiles returns the configured sizes and formats in
+// which image thumbnails are generated.
+func (i *Config) GetImageThumbnailProfiles() []image.ThumbnailProfile {
+	var ret []image.ThumbnailProfile
+	if err := i.unmarshalKey(ImageThumbnailProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// SetImageThumbnailProfiles validates and sets the image thumbnail profiles.
+// Returns true if the profiles changed, in which case the thumbnails of the
+// new profiles need to be generated.
+func (i *Config) SetImageThumbnailProfiles(profiles []image.ThumbnailProfile) (bool, error) {
+	if err := image.ValidateThumbnailProfiles(profiles); err != nil {
+		return false, err
+	}
+
+	current := i.GetImageThumbnailProfiles()
+	changed := len(current) != len(profiles)
+	for j := 0; !changed && j < len(profiles); j++ {
+		changed = current[j] != profiles[j]
+	}
+
+	value, err := toConfigMaps(profiles)
+	if err != nil {
+		return false, err
+	}
+
+	i.SetInterface(ImageThumbnailProfiles, value)
+	return changed, nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SetImageThumbnailProfiles(t *testing.T) {
+	assert := assert.New(t)
+
+	i := InitializeEmpty()
+
+	profiles := []image.ThumbnailProfile{
+		{Size: 320, Format: image.ThumbnailFormatWebp, Quality: 75},
+		{Size: 1280, Format: image.ThumbnailFormatAvif, Quality: 60},
+	}
+
+	changed, err := i.SetImageThumbnailProfiles(profiles)
+	assert.NoError(err)
+	assert.True(changed)
+	assert.Equal(profiles, i.GetImageThumbnailProfiles())
+
+	changed, err = i.SetImageThumbnailProfiles(profiles)
+	assert.NoError(err)
+	assert.False(changed)
+
+	_, err = i.SetImageThumbnailProfiles([]image.ThumbnailProfile{profiles[0], profiles[0]})
+	assert.Error(err)
+	_, err = i.SetImageThumbnailProfiles([]image.ThumbnailProfile{{Size: 320, Format: image.ThumbnailFormatJpeg}})
+	assert.Error(err)
+	assert.Equal(profiles, i.GetImageThumbnailProfiles())
+}
@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+// ImportConfiguration merges the configuration bundle into the configuration,
+// writes it and refreshes the affected services. Returns the changes made.
+func (s *Manager) ImportConfiguration(ctx context.Context, data []byte) ([]*config.ConfigChange, error) {
+	changes, err := s.Config.ImportBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	if err := s.Config.Write(); err != nil {
+		return nil, fmt.Errorf("writing configuration: %w", err)
+	}
+
+	s.RefreshConfig()
+	s.RefreshScraperCache()
+	s.RefreshPluginCache()
+	s.RefreshFFMpeg(ctx)
+	s.RefreshStreamManager()
+	s.SetBlobStoreOptions()
+	s.SetSortOptions()
+	s.SetTimezone()
+	s.RefreshScraperSourceManager()
+	s.RefreshPluginSourceManager()
+	s.RefreshDLNA()
+
+	return changes, nil
+}
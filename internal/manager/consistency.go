@@ -0,0 +1,261 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// consistencyReports holds the report of the last consistency check.
+type consistencyReports struct {
+	mutex sync.Mutex
+	last  *models.ConsistencyReport
+}
+
+func (r *consistencyReports) get() *models.ConsistencyReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *consistencyReports) set(report *models.ConsistencyReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = report
+}
+
+// ConsistencyReport returns the report of the last consistency check, or
+// nil if the library has not been checked.
+func (s *Manager) ConsistencyReport() *models.ConsistencyReport {
+	return s.consistency.get()
+}
+
+// CheckConsistency starts a job that checks the references between the
+// objects of the library and their files, and fixes the issues of the
+// categories in fix. The result is stored as the last report.
+func (s *Manager) CheckConsistency(ctx context.Context, fix []models.ConsistencyCategory) int {
+	j := &ConsistencyCheckJob{
+		Fix: fix,
+	}
+
+	return s.JobManager.Add(ctx, "Checking library consistency...", j)
+}
+
+// ConsistencyCheckJob finds broken references between the objects of the
+// library and their files, by category, and optionally fixes them:
+//   - scenes without files are deleted, keeping their generated files
+//   - video files without scenes are removed from the database, so that
+//     the next scan creates scenes for them
+//   - objects without a primary file are given their first file
+//   - the missing folders of galleries are removed from them
+//   - the fingerprints of files without fingerprints are calculated
+type ConsistencyCheckJob struct {
+	Fix []models.ConsistencyCategory
+}
+
+func (j *ConsistencyCheckJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+	r := mgr.Repository
+
+	fix := make(map[models.ConsistencyCategory]bool)
+	for _, c := range j.Fix {
+		fix[c] = true
+	}
+
+	report := &models.ConsistencyReport{}
+	progress.SetTotal(len(models.AllConsistencyCategory))
+
+	for _, category := range models.AllConsistencyCategory {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		var err error
+		progress.ExecuteTask(fmt.Sprintf("Checking %s", category), func() {
+			c := models.ConsistencyCategoryReport{
+				Category: category,
+			}
+
+			err = r.WithReadTxn(ctx, func(ctx context.Context) error {
+				var err error
+				c.Issues, err = r.Consistency.FindIssues(ctx, category)
+				return err
+			})
+			if err != nil {
+				return
+			}
+
+			if len(c.Issues) > 0 {
+				logger.Infof("[consistency] found %d issues of %s", len(c.Issues), category)
+			}
+
+			if fix[category] {
+				for _, issue := range c.Issues {
+					if job.IsCancelled(ctx) {
+						break
+					}
+
+					if err := j.fix(ctx, category, issue); err != nil {
+						logger.Errorf("[consistency] error fixing %s %d: %v", issue.ObjectType, issue.ObjectID, err)
+						c.Failed++
+					} else {
+						c.Fixed++
+					}
+				}
+			}
+
+			report.Categories = append(report.Categories, c)
+		})
+		if err != nil {
+			return fmt.Errorf("checking %s: %w", category, err)
+		}
+
+		progress.Increment()
+	}
+
+	report.GeneratedAt = time.Now()
+	mgr.consistency.set(report)
+
+	logger.Infof("[consistency] found %d issues", report.Total())
+	return nil
+}
+
+func (j *ConsistencyCheckJob) fix(ctx context.Context, category models.ConsistencyCategory, issue models.ConsistencyIssue) error {
+	r := instance.Repository
+
+	switch category {
+	case models.ConsistencyCategoryScenesWithoutFiles:
+		return j.destroyScene(ctx, issue.ObjectID)
+	case models.ConsistencyCategoryFilesWithoutScenes:
+		return r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.File.Destroy(ctx, models.FileID(issue.ObjectID))
+		})
+	case models.ConsistencyCategoryMissingPrimaryFile:
+		return r.WithTxn(ctx, func(ctx context.Context) error {
+			return j.setPrimaryFile(ctx, issue)
+		})
+	case models.ConsistencyCategoryMissingGalleryFolder:
+		return r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.Consistency.ClearGalleryFolder(ctx, issue.ObjectID)
+		})
+	case models.ConsistencyCategoryMissingFingerprints:
+		return j.calculateFingerprints(ctx, models.FileID(issue.ObjectID))
+	}
+
+	return fmt.Errorf("invalid consistency category %q", category)
+}
+
+func (j *ConsistencyCheckJob) destroyScene(ctx context.Context, id int) error {
+	mgr := instance
+	r := mgr.Repository
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        mgr.NewFileDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, id)
+		if err != nil || s == nil {
+			return err
+		}
+
+		// without files, the generated files of the scene cannot be found
+		return mgr.SceneService.Destroy(ctx, s, fileDeleter, false, false)
+	})
+	if err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	fileDeleter.Commit()
+	return nil
+}
+
+func (j *ConsistencyCheckJob) setPrimaryFile(ctx context.Context, issue models.ConsistencyIssue) error {
+	r := instance.Repository
+
+	if issue.FileID == nil {
+		return fmt.Errorf("no file to make the primary file of %s %d", issue.ObjectType, issue.ObjectID)
+	}
+
+	var err error
+	switch issue.ObjectType {
+	case models.ConsistencyObjectTypeScene:
+		partial := models.NewScenePartial()
+		partial.PrimaryFileID = issue.FileID
+		_, err = r.Scene.UpdatePartial(ctx, issue.ObjectID, partial)
+	case models.ConsistencyObjectTypeImage:
+		partial := models.NewImagePartial()
+		partial.PrimaryFileID = issue.FileID
+		_, err = r.Image.UpdatePartial(ctx, issue.ObjectID, partial)
+	case models.ConsistencyObjectTypeGallery:
+		partial := models.NewGalleryPartial()
+		partial.PrimaryFileID = issue.FileID
+		_, err = r.Gallery.UpdatePartial(ctx, issue.ObjectID, partial)
+	default:
+		err = fmt.Errorf("%s objects have no primary file", issue.ObjectType)
+	}
+
+	return err
+}
+
+// consistencyFileOpener opens a file of the library, which may be in a zip
+// file.
+type consistencyFileOpener struct {
+	file *models.BaseFile
+}
+
+func (o *consistencyFileOpener) Open() (io.ReadCloser, error) {
+	return o.file.Open(&file.OsFS{})
+}
+
+// calculateFingerprints calculates the fingerprints of the file. No
+// transaction is held while the file is read.
+func (j *ConsistencyCheckJob) calculateFingerprints(ctx context.Context, id models.FileID) error {
+	mgr := instance
+	r := mgr.Repository
+
+	var f models.File
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		files, err := r.File.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("file %d not found", id)
+		}
+
+		f = files[0]
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	base := f.Base()
+	calculator := &FingerprintCalculator{Config: mgr.Config}
+	fingerprints, err := calculator.CalculateFingerprints(base, &consistencyFileOpener{file: base}, false)
+	if err != nil {
+		return fmt.Errorf("calculating fingerprints of %s: %w", base.Path, err)
+	}
+
+	for _, fp := range fingerprints {
+		base.Fingerprints = base.Fingerprints.AppendUnique(fp)
+	}
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.File.Update(ctx, f)
+	})
+}
@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrContentGateDisabled   = errors.New("content gate is not enabled")
+	ErrInvalidContentGatePIN = errors.New("invalid PIN")
+	ErrContentGateThrottled  = errors.New("too many incorrect PINs, try again later")
+)
+
+// GatedTags returns the ids of the tags whose content must be hidden. Returns
+// nil if the content gate is disabled or unlocked.
+func (s *Manager) GatedTags() []string {
+	if !s.Config.IsContentGateEnabled() {
+		return nil
+	}
+
+	if !s.ContentGate.Locked(time.Now(), s.Config.GetContentGateTimeout()) {
+		return nil
+	}
+
+	return s.Config.GetContentGateTags()
+}
+
+// GatedTagsCriterion returns a tags criterion excluding content with the
+// gated tags or their descendants. Returns nil if content is not gated.
+func (s *Manager) GatedTagsCriterion() *models.HierarchicalMultiCriterionInput {
+	tags := s.GatedTags()
+	if len(tags) == 0 {
+		return nil
+	}
+
+	depth := -1
+	return &models.HierarchicalMultiCriterionInput{
+		Value:    tags,
+		Modifier: models.CriterionModifierExcludes,
+		Depth:    &depth,
+	}
+}
+
+// ContentGateExpiresAt returns the time the content gate will lock without
+// further activity. Returns nil if the gate is disabled, locked or does not
+// lock automatically.
+func (s *Manager) ContentGateExpiresAt() *time.Time {
+	if !s.Config.IsContentGateEnabled() {
+		return nil
+	}
+
+	ret, ok := s.ContentGate.ExpiresAt(time.Now(), s.Config.GetContentGateTimeout())
+	if !ok {
+		return nil
+	}
+
+	return &ret
+}
+
+// UnlockContentGate unlocks the content gate if the PIN is correct.
+func (s *Manager) UnlockContentGate(pin string) error {
+	if !s.Config.IsContentGateEnabled() {
+		return ErrContentGateDisabled
+	}
+
+	now := time.Now()
+	if s.ContentGate.Throttled(now) {
+		return ErrContentGateThrottled
+	}
+
+	if !s.Config.ValidateContentGatePIN(pin) {
+		s.ContentGate.Fail(now)
+		return ErrInvalidContentGatePIN
+	}
+
+	s.ContentGate.Unlock(now)
+	return nil
+}
+
+// TouchContentGate records activity, delaying the automatic lock of an
+// unlocked content gate.
+func (s *Manager) TouchContentGate() {
+	s.ContentGate.Touch(time.Now(), s.Config.GetContentGateTimeout())
+}
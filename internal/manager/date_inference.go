@@ -0,0 +1,290 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+var ErrNoDateProposals = errors.New("no date proposals have been detected")
+
+// dateProposalReports holds the last report of dates inferred for scenes
+// without a date.
+type dateProposalReports struct {
+	mutex sync.Mutex
+	last  *scene.DateProposalReport
+}
+
+func (r *dateProposalReports) get() *scene.DateProposalReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *dateProposalReports) set(report *scene.DateProposalReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = report
+}
+
+// DateProposals returns the last report of proposed scene dates, or nil if
+// dates have not been detected.
+func (s *Manager) DateProposals() *scene.DateProposalReport {
+	return s.dateProposals.get()
+}
+
+// DetectDates starts a job that infers the dates of the scenes without a
+// date, and stores the proposed dates as the last report. If useMetadata is
+// true, the metadata of the files is read as well as their names. No dates
+// are set.
+func (s *Manager) DetectDates(ctx context.Context, useMetadata bool) int {
+	j := &DetectDatesJob{
+		UseMetadata: useMetadata,
+	}
+
+	return s.JobManager.Add(ctx, "Detecting scene dates...", j)
+}
+
+// ApplyDateProposals starts a job that sets the dates of the last report
+// with at least the minimum confidence. If ids is not nil, only those
+// proposals are applied.
+func (s *Manager) ApplyDateProposals(ctx context.Context, ids []int, minConfidence float64) (int, error) {
+	report := s.dateProposals.get()
+	if report == nil {
+		return 0, ErrNoDateProposals
+	}
+
+	var selected map[int]bool
+	if ids != nil {
+		selected = make(map[int]bool)
+		for _, id := range ids {
+			selected[id] = true
+		}
+	}
+
+	var proposals []scene.DateProposal
+	for _, p := range report.Proposals {
+		if (selected == nil || selected[p.ID]) && p.Confidence >= minConfidence {
+			proposals = append(proposals, p)
+		}
+	}
+
+	if len(proposals) == 0 {
+		return 0, errors.New("no date proposals to apply")
+	}
+
+	j := &ApplyDateProposalsJob{
+		Proposals: proposals,
+	}
+
+	return s.JobManager.Add(ctx, "Setting detected scene dates...", j), nil
+}
+
+// dateCandidateScene is a scene without a date and the path of its primary
+// file.
+type dateCandidateScene struct {
+	id   int
+	path string
+}
+
+// DetectDatesJob infers the dates of the scenes without a date from the
+// dates in their filenames and, optionally, the dates in the metadata of
+// their files.
+type DetectDatesJob struct {
+	UseMetadata bool
+}
+
+func (j *DetectDatesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+
+	scenes, err := j.findScenes(ctx)
+	if err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+
+	// files are probed outside of a transaction
+	stashPaths := mgr.Config.GetStashPaths()
+	now := time.Now()
+
+	useMetadata := j.UseMetadata
+	if useMetadata && mgr.FFProbe == nil {
+		logger.Warn("ffprobe is not available, only filenames are used to detect dates")
+		useMetadata = false
+	}
+
+	var candidates []scene.DateCandidate
+	progress.SetTotal(len(scenes))
+	for _, s := range scenes {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		evidence := scene.FilenameDates(s.path, now)
+
+		// files in rclone stashes are too slow to probe
+		if useMetadata && !stashPaths.IsRemotePath(s.path) {
+			progress.ExecuteTask(fmt.Sprintf("Reading metadata of %s", s.path), func() {
+				probe, err := mgr.FFProbe.NewVideoFile(s.path)
+				if err != nil {
+					logger.Warnf("Error reading metadata of %s: %v", s.path, err)
+					return
+				}
+
+				evidence = append(evidence, scene.MetadataDates(probe.CreationTime, probe.Date, probe.QuickTimeCreationDate, now)...)
+			})
+		}
+
+		if len(evidence) > 0 {
+			candidates = append(candidates, scene.DateCandidate{
+				SceneID:  s.id,
+				Evidence: evidence,
+			})
+		}
+
+		progress.Increment()
+	}
+
+	report := &scene.DateProposalReport{
+		GeneratedAt: time.Now(),
+		Proposals:   scene.ProposeDates(candidates),
+	}
+	mgr.dateProposals.set(report)
+
+	logger.Infof("Detected dates for %d scenes", len(report.Proposals))
+	return nil
+}
+
+// findScenes returns the unlocked scenes without a date which have a file.
+func (j *DetectDatesJob) findScenes(ctx context.Context) ([]dateCandidateScene, error) {
+	const batchSize = 1000
+
+	r := instance.Repository
+
+	isMissing := "date"
+	sceneFilter := &models.SceneFilterType{
+		IsMissing: &isMissing,
+	}
+
+	var ret []dateCandidateScene
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				// path is the path of the primary file
+				if s.Locked || s.Path == "" {
+					continue
+				}
+
+				ret = append(ret, dateCandidateScene{
+					id:   s.ID,
+					path: s.Path,
+				})
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// ApplyDateProposalsJob sets the date of the scene of each proposal.
+type ApplyDateProposalsJob struct {
+	Proposals []scene.DateProposal
+}
+
+func (j *ApplyDateProposalsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	progress.SetTotal(len(j.Proposals))
+
+	for _, p := range j.Proposals {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Setting date of scene %d", p.SceneID), func() {
+			err := j.apply(ctx, p)
+			if err != nil {
+				logger.Errorf("Error setting date of scene %d: %v", p.SceneID, err)
+			}
+			progress.ItemDone(strconv.Itoa(p.ID), err)
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (j *ApplyDateProposalsJob) apply(ctx context.Context, p scene.DateProposal) error {
+	r := instance.Repository
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, p.SceneID)
+		if err != nil {
+			return err
+		}
+
+		// the scene may have been deleted, locked or given a date since the
+		// proposal was made
+		if s == nil || s.Locked || s.Date != nil {
+			logger.Infof("Skipping scene %d", p.SceneID)
+			return nil
+		}
+
+		partial := models.NewScenePartial()
+		partial.Date = models.NewOptionalDate(p.Date)
+
+		if _, err := r.Scene.UpdatePartial(ctx, s.ID, partial); err != nil {
+			return err
+		}
+
+		logger.Infof("Set date of scene %d to %s", s.ID, p.Date)
+		return nil
+	})
+}
+
+// Retry returns a job that applies the proposals with the given ids again.
+func (j *ApplyDateProposalsJob) Retry(ids []string) job.JobExec {
+	retry := make(map[string]bool)
+	for _, id := range ids {
+		retry[id] = true
+	}
+
+	var proposals []scene.DateProposal
+	for _, p := range j.Proposals {
+		if retry[strconv.Itoa(p.ID)] {
+			proposals = append(proposals, p)
+		}
+	}
+
+	return &ApplyDateProposalsJob{
+		Proposals: proposals,
+	}
+}
@@ -0,0 +1,294 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	// diagnosticTimeout is the maximum time allowed for checks using the network
+	diagnosticTimeout = 10 * time.Second
+
+	diskSpaceWarn = 1 << 30   // 1GiB
+	diskSpaceFail = 100 << 20 // 100MiB
+
+	clockSkewWarn = time.Minute
+	clockSkewFail = 10 * time.Minute
+)
+
+type DiagnosticCheck struct {
+	Name    string           `json:"name"`
+	Status  DiagnosticStatus `json:"status"`
+	Message string           `json:"message"`
+}
+
+type DiagnosticsReport struct {
+	// Status is the most severe status of the checks
+	Status DiagnosticStatus   `json:"status"`
+	Checks []*DiagnosticCheck `json:"checks"`
+}
+
+func (r *DiagnosticsReport) add(name string, status DiagnosticStatus, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, &DiagnosticCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+
+	if diagnosticSeverity(status) > diagnosticSeverity(r.Status) {
+		r.Status = status
+	}
+}
+
+func diagnosticSeverity(s DiagnosticStatus) int {
+	switch s {
+	case DiagnosticStatusWarn:
+		return 1
+	case DiagnosticStatusFail:
+		return 2
+	default:
+		return 0
+	}
+}
+
+type diagnosticPath struct {
+	name     string
+	path     string
+	required bool
+}
+
+// RunDiagnostics checks the health of the system and returns a report of the
+// results.
+func (s *Manager) RunDiagnostics(ctx context.Context) *DiagnosticsReport {
+	ret := &DiagnosticsReport{
+		Status: DiagnosticStatusPass,
+	}
+
+	s.diagnosePaths(ret)
+	s.diagnoseFFMpeg(ret)
+	s.diagnoseDatabase(ctx, ret)
+	s.diagnoseClock(ctx, ret)
+	s.diagnoseCDP(ctx, ret)
+	s.diagnosePerformanceProfile(ret)
+
+	return ret
+}
+
+func (s *Manager) diagnosticPaths() []diagnosticPath {
+	cfg := s.Config
+
+	ret := []diagnosticPath{
+		{"config", cfg.GetConfigPathAbs(), true},
+		{"database", filepath.Dir(cfg.GetDatabasePath()), true},
+		{"generated", cfg.GetGeneratedPath(), true},
+		{"cache", cfg.GetCachePath(), false},
+		{"metadata", cfg.GetMetadataPath(), false},
+		{"backup", cfg.GetBackupDirectoryPath(), false},
+	}
+
+	if cfg.GetBlobsStorage() == config.BlobStorageTypeFilesystem {
+		ret = append(ret, diagnosticPath{"blobs", cfg.GetBlobsPath(), true})
+	}
+
+	return ret
+}
+
+func (s *Manager) diagnosePaths(r *DiagnosticsReport) {
+	for _, p := range s.diagnosticPaths() {
+		writableName := "writable_" + p.name
+		if p.path == "" {
+			if p.required {
+				r.add(writableName, DiagnosticStatusFail, "%s path is not set", p.name)
+			} else {
+				r.add(writableName, DiagnosticStatusSkip, "%s path is not set", p.name)
+			}
+			continue
+		}
+
+		if err := fsutil.IsDirWritable(p.path); err != nil {
+			status := DiagnosticStatusWarn
+			if p.required {
+				status = DiagnosticStatusFail
+			}
+			r.add(writableName, status, "%s is not writable: %v", p.path, err)
+			continue
+		}
+
+		r.add(writableName, DiagnosticStatusPass, "%s is writable", p.path)
+
+		diskName := "disk_space_" + p.name
+		free, err := fsutil.FreeSpace(p.path)
+		switch {
+		case err != nil:
+			r.add(diskName, DiagnosticStatusSkip, "could not get free space for %s: %v", p.path, err)
+		case free < diskSpaceFail:
+			r.add(diskName, DiagnosticStatusFail, "%s has %s free", p.path, utils.FormatBytes(int64(free)))
+		case free < diskSpaceWarn:
+			r.add(diskName, DiagnosticStatusWarn, "%s has %s free", p.path, utils.FormatBytes(int64(free)))
+		default:
+			r.add(diskName, DiagnosticStatusPass, "%s has %s free", p.path, utils.FormatBytes(int64(free)))
+		}
+	}
+}
+
+func (s *Manager) diagnoseFFMpeg(r *DiagnosticsReport) {
+	status := s.ffmpegStatus
+	switch {
+	case status == nil:
+		r.add("ffmpeg", DiagnosticStatusFail, "ffmpeg was not found")
+	case !status.VersionSupported():
+		r.add("ffmpeg", DiagnosticStatusWarn, "ffmpeg at %s is unknown or older than the minimum supported version %s", status.Path, ffmpeg.MinimumVersion)
+	case len(status.MissingFeatures) > 0:
+		r.add("ffmpeg", DiagnosticStatusWarn, "ffmpeg %s is missing codec support: %s", status.Version, strings.Join(status.MissingFeatures, ", "))
+	default:
+		r.add("ffmpeg", DiagnosticStatusPass, "ffmpeg %s at %s", status.Version, status.Path)
+	}
+
+	if status != nil {
+		if len(status.HardwareEncoders) > 0 {
+			r.add("ffmpeg_hardware_encoders", DiagnosticStatusPass, "ffmpeg supports hardware encoders: %s", strings.Join(status.HardwareEncoders, ", "))
+		} else {
+			r.add("ffmpeg_hardware_encoders", DiagnosticStatusSkip, "ffmpeg does not support any hardware encoders")
+		}
+	}
+
+	switch {
+	case s.FFProbe == nil:
+		r.add("ffprobe", DiagnosticStatusFail, "ffprobe was not found")
+	case s.ffprobeVersion == nil:
+		r.add("ffprobe", DiagnosticStatusWarn, "ffprobe version at %s could not be detected", s.FFProbe.Path())
+	default:
+		r.add("ffprobe", DiagnosticStatusPass, "ffprobe %s at %s", s.ffprobeVersion, s.FFProbe.Path())
+	}
+}
+
+func (s *Manager) diagnoseDatabase(ctx context.Context, r *DiagnosticsReport) {
+	if err := s.Database.Ready(); err != nil {
+		r.add("database", DiagnosticStatusFail, "database is not ready: %v", err)
+		return
+	}
+
+	problems, err := s.Database.QuickCheck(ctx)
+	switch {
+	case err != nil:
+		r.add("database", DiagnosticStatusFail, "database integrity check failed: %v", err)
+	case len(problems) > 0:
+		r.add("database", DiagnosticStatusFail, "database integrity check found problems: %s", strings.Join(problems, "; "))
+	default:
+		r.add("database", DiagnosticStatusPass, "database integrity check passed")
+	}
+}
+
+// diagnoseClock compares the local clock with the Date header returned by the
+// first configured stash-box endpoint.
+func (s *Manager) diagnoseClock(ctx context.Context, r *DiagnosticsReport) {
+	boxes := s.Config.GetStashBoxes()
+	if len(boxes) == 0 {
+		r.add("clock_skew", DiagnosticStatusSkip, "no stash-box endpoint to compare the clock with")
+		return
+	}
+
+	endpoint := boxes[0].Endpoint
+	skew, err := clockSkew(ctx, endpoint)
+	if err != nil {
+		r.add("clock_skew", DiagnosticStatusSkip, "could not get the time from %s: %v", endpoint, err)
+		return
+	}
+
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs >= clockSkewFail:
+		r.add("clock_skew", DiagnosticStatusFail, "local clock differs from %s by %s", endpoint, skew)
+	case abs >= clockSkewWarn:
+		r.add("clock_skew", DiagnosticStatusWarn, "local clock differs from %s by %s", endpoint, skew)
+	default:
+		r.add("clock_skew", DiagnosticStatusPass, "local clock differs from %s by %s", endpoint, skew)
+	}
+}
+
+// clockSkew returns the difference between the local time and the time in
+// the Date header returned by url, accounting for the round trip time.
+func clockSkew(ctx context.Context, url string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, diagnosticTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	end := time.Now()
+
+	remote, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid Date header: %w", err)
+	}
+
+	local := start.Add(end.Sub(start) / 2)
+	// the Date header has a precision of one second
+	return local.Sub(remote).Truncate(time.Second), nil
+}
+
+func (s *Manager) diagnoseCDP(ctx context.Context, r *DiagnosticsReport) {
+	cdpPath := s.Config.GetScraperCDPPath()
+	if cdpPath == "" {
+		r.add("scraper_cdp", DiagnosticStatusSkip, "scraper CDP path is not set")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticTimeout)
+	defer cancel()
+
+	if err := scraper.CheckCDP(ctx, s.Config); err != nil {
+		logger.Debugf("[diagnostics] CDP check failed: %v", err)
+		r.add("scraper_cdp", DiagnosticStatusFail, "could not connect to %s: %v", cdpPath, err)
+		return
+	}
+
+	r.add("scraper_cdp", DiagnosticStatusPass, "connected to %s", cdpPath)
+}
+
+func (s *Manager) diagnosePerformanceProfile(r *DiagnosticsReport) {
+	cfg := s.Config
+	profile := cfg.GetPerformanceProfile()
+	if profile != config.PerformanceProfileLowMemory {
+		r.add("performance_profile", DiagnosticStatusPass, "%s profile is active", profile)
+		return
+	}
+
+	phashIndex := "enabled"
+	if !cfg.IsPhashIndexEnabled() {
+		phashIndex = "disabled"
+	}
+
+	r.add("performance_profile", DiagnosticStatusPass,
+		"%s profile is active: %d parallel tasks, ffmpeg output args %q, database cache %dKiB, GraphQL query cache %d, phash index %s, phash and heatmap generation disabled by default",
+		profile,
+		cfg.GetParallelTasksWithAutoDetection(),
+		strings.Join(cfg.GetTranscodeOutputArgs(), " "),
+		cfg.GetDatabaseCacheSize(),
+		cfg.GetGraphQLQueryCacheSize(),
+		phashIndex,
+	)
+}
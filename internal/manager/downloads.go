@@ -1,6 +1,8 @@
 package manager
 
 import (
+	"errors"
+	"io/fs"
 	"net/http"
 	"os"
 	"sync"
@@ -112,3 +114,27 @@ func (s *DownloadStore) waitAndRemoveFile(hash string, w *http.ResponseWriter, r
 		}
 	})
 }
+
+// ExpireFile removes the file with the given hash after the duration, even
+// if it was registered to be kept.
+func (s *DownloadStore) ExpireFile(hash string, after time.Duration) {
+	time.AfterFunc(after, func() {
+		s.RemoveFile(hash)
+	})
+}
+
+// RemoveFile unregisters the file with the given hash and deletes it.
+func (s *DownloadStore) RemoveFile(hash string) {
+	s.mutex.Lock()
+	f, ok := s.m[hash]
+	delete(s.m, hash)
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Errorf("error removing download %s: %v", f.path, err)
+	}
+}
@@ -0,0 +1,318 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type DeleteExactDuplicateFilesInput struct {
+	// Fingerprint type used to group files. Defaults to oshash
+	FingerprintType *string `json:"fingerprint_type"`
+	// If set, only groups with these fingerprint values are processed
+	Fingerprints []string `json:"fingerprints"`
+	// Also process groups where duplicate files belong to scenes, images or
+	// galleries that do not contain the primary file. Those objects are
+	// destroyed if the duplicate is their only file.
+	IncludeUnrelated bool `json:"include_unrelated"`
+	// Also process groups where duplicate files belong to locked scenes or
+	// galleries
+	IncludeLocked bool `json:"include_locked"`
+	// Do a dry run. Don't delete any files
+	DryRun bool `json:"dry_run"`
+}
+
+func (i DeleteExactDuplicateFilesInput) fingerprintType() string {
+	if i.FingerprintType != nil && *i.FingerprintType != "" {
+		return *i.FingerprintType
+	}
+
+	return models.FingerprintTypeOshash
+}
+
+func (i DeleteExactDuplicateFilesInput) includes(g file.ExactDuplicateGroup) bool {
+	if len(i.Fingerprints) == 0 {
+		return true
+	}
+
+	v := g.Fingerprint.Value()
+	for _, fp := range i.Fingerprints {
+		if fp == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteExactDuplicateFiles starts a job that deletes all but the primary
+// file of each group of files sharing an identical fingerprint.
+// Returns the job ID.
+func (s *Manager) DeleteExactDuplicateFiles(ctx context.Context, input DeleteExactDuplicateFilesInput) int {
+	j := &deleteExactDuplicateFilesJob{
+		repository: s.Repository,
+		input:      input,
+		scanSubs:   s.scanSubs,
+		readOnly:   s,
+	}
+
+	return s.JobManager.Add(ctx, "Deleting duplicate files...", j)
+}
+
+// deleteExactDuplicateFilesJob deletes duplicate files. Objects related to a
+// deleted file are handled in the same way as the clean task: the primary
+// file is reassigned if the object has other files, otherwise the object is
+// destroyed. Unless IncludeUnrelated is set, groups where a duplicate belongs
+// to an object that does not contain the primary file are skipped, so that
+// no object is destroyed.
+type deleteExactDuplicateFilesJob struct {
+	repository models.Repository
+	input      DeleteExactDuplicateFilesInput
+	scanSubs   *subscriptionManager
+	readOnly   file.ReadOnlyChecker
+}
+
+func (j *deleteExactDuplicateFilesJob) logPrefix() string {
+	if j.input.DryRun {
+		return "[dry run] "
+	}
+
+	return ""
+}
+
+func (j *deleteExactDuplicateFilesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Infof("Starting deletion of duplicate files")
+	start := time.Now()
+	if j.input.DryRun {
+		logger.Infof("Running in Dry Mode")
+	}
+
+	var (
+		groups []file.ExactDuplicateGroup
+		err    error
+	)
+	progress.ExecuteTask("Finding duplicate files", func() {
+		groups, err = j.findGroups(ctx)
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error finding duplicate files: %w", err)
+	}
+
+	total := 0
+	for _, g := range groups {
+		total += len(g.Duplicates())
+	}
+	progress.SetTotal(total)
+
+	var (
+		deleted   int
+		reclaimed int64
+	)
+	handler := &cleanHandler{}
+
+	for _, g := range groups {
+		primary := g.Primary().Base()
+		for _, f := range g.Duplicates() {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			path := f.Base().Path
+			progress.ExecuteTask("Deleting "+path, func() {
+				defer progress.Increment()
+
+				logger.Infof("%sDeleting duplicate file %q (duplicate of %q)", j.logPrefix(), path, primary.Path)
+
+				if !j.input.DryRun {
+					if err := j.deleteFile(ctx, handler, f); err != nil {
+						logger.Errorf("Error deleting duplicate file %q: %v", path, err)
+						return
+					}
+				}
+
+				deleted++
+				reclaimed += f.Base().Size
+			})
+		}
+	}
+
+	if !j.input.DryRun && deleted > 0 {
+		j.scanSubs.notify()
+	}
+
+	elapsed := time.Since(start)
+	logger.Infof("%sDeleted %d duplicate files, reclaiming %s (%s)", j.logPrefix(), deleted, utils.FormatBytes(reclaimed), elapsed)
+	return nil
+}
+
+func (j *deleteExactDuplicateFilesJob) findGroups(ctx context.Context) ([]file.ExactDuplicateGroup, error) {
+	r := j.repository
+
+	var ret []file.ExactDuplicateGroup
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		groups, err := file.FindExactDuplicates(ctx, r.File, j.input.fingerprintType())
+		if err != nil {
+			return err
+		}
+
+		for _, g := range groups {
+			if !j.input.includes(g) {
+				continue
+			}
+
+			if !j.input.IncludeUnrelated {
+				related, err := j.isRelated(ctx, g)
+				if err != nil {
+					return err
+				}
+
+				if !related {
+					logger.Infof("Skipping duplicates of %q since they belong to other objects", g.Primary().Base().Path)
+					continue
+				}
+			}
+
+			if !j.input.IncludeLocked {
+				locked, err := j.isLocked(ctx, g)
+				if err != nil {
+					return err
+				}
+
+				if locked {
+					logger.Infof("Skipping duplicates of %q since they belong to locked objects", g.Primary().Base().Path)
+					continue
+				}
+			}
+
+			ret = append(ret, g)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// isRelated returns true if every object related to a duplicate file in the
+// group is also related to the primary file.
+func (j *deleteExactDuplicateFilesJob) isRelated(ctx context.Context, g file.ExactDuplicateGroup) (bool, error) {
+	primaryObjects, err := j.relatedObjects(ctx, g.Primary().Base().ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range g.Duplicates() {
+		objects, err := j.relatedObjects(ctx, f.Base().ID)
+		if err != nil {
+			return false, err
+		}
+
+		for o := range objects {
+			if !primaryObjects[o] {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// isLocked returns true if a duplicate file in the group belongs to a locked
+// scene or gallery.
+func (j *deleteExactDuplicateFilesJob) isLocked(ctx context.Context, g file.ExactDuplicateGroup) (bool, error) {
+	r := j.repository
+
+	for _, f := range g.Duplicates() {
+		scenes, err := r.Scene.FindByFileID(ctx, f.Base().ID)
+		if err != nil {
+			return false, fmt.Errorf("finding scenes for file: %w", err)
+		}
+		for _, s := range scenes {
+			if s.Locked {
+				return true, nil
+			}
+		}
+
+		galleries, err := r.Gallery.FindByFileID(ctx, f.Base().ID)
+		if err != nil {
+			return false, fmt.Errorf("finding galleries for file: %w", err)
+		}
+		for _, g := range galleries {
+			if g.Locked {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// relatedObjects returns a set of keys identifying the scenes, images and
+// galleries that contain the file.
+func (j *deleteExactDuplicateFilesJob) relatedObjects(ctx context.Context, fileID models.FileID) (map[string]bool, error) {
+	r := j.repository
+	ret := make(map[string]bool)
+
+	scenes, err := r.Scene.FindByFileID(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scenes for file: %w", err)
+	}
+	for _, s := range scenes {
+		ret["scene:"+strconv.Itoa(s.ID)] = true
+	}
+
+	images, err := r.Image.FindByFileID(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("finding images for file: %w", err)
+	}
+	for _, i := range images {
+		ret["image:"+strconv.Itoa(i.ID)] = true
+	}
+
+	galleries, err := r.Gallery.FindByFileID(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("finding galleries for file: %w", err)
+	}
+	for _, g := range galleries {
+		ret["gallery:"+strconv.Itoa(g.ID)] = true
+	}
+
+	return ret, nil
+}
+
+func (j *deleteExactDuplicateFilesJob) deleteFile(ctx context.Context, handler *cleanHandler, f models.File) error {
+	r := j.repository
+	fileDeleter := file.NewDeleter()
+	fileDeleter.ReadOnly = j.readOnly
+	destroyer := &file.ZipDestroyer{
+		FileDestroyer:   r.File,
+		FolderDestroyer: r.Folder,
+	}
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		fileDeleter.RegisterHooks(ctx)
+
+		if err := handler.HandleFile(ctx, fileDeleter, f.Base().ID); err != nil {
+			return fmt.Errorf("handling related objects: %w", err)
+		}
+
+		const deleteFile = true
+		return destroyer.DestroyZip(ctx, f, fileDeleter, deleteFile)
+	})
+}
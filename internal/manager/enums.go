@@ -48,3 +48,48 @@ func (e *SystemStatusEnum) UnmarshalGQL(v interface{}) error {
 func (e SystemStatusEnum) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type DiagnosticStatus string
+
+const (
+	DiagnosticStatusPass DiagnosticStatus = "PASS"
+	DiagnosticStatusWarn DiagnosticStatus = "WARN"
+	DiagnosticStatusFail DiagnosticStatus = "FAIL"
+	DiagnosticStatusSkip DiagnosticStatus = "SKIP"
+)
+
+var AllDiagnosticStatus = []DiagnosticStatus{
+	DiagnosticStatusPass,
+	DiagnosticStatusWarn,
+	DiagnosticStatusFail,
+	DiagnosticStatusSkip,
+}
+
+func (e DiagnosticStatus) IsValid() bool {
+	switch e {
+	case DiagnosticStatusPass, DiagnosticStatusWarn, DiagnosticStatusFail, DiagnosticStatusSkip:
+		return true
+	}
+	return false
+}
+
+func (e DiagnosticStatus) String() string {
+	return string(e)
+}
+
+func (e *DiagnosticStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiagnosticStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiagnosticStatus", str)
+	}
+	return nil
+}
+
+func (e DiagnosticStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
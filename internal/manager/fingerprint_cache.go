@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// fingerprintCacheFilename is the name of the file in the cache directory
+// that the fingerprint cache is saved to on shutdown.
+const fingerprintCacheFilename = "fingerprint_cache.json"
+
+func (s *Manager) fingerprintCachePath() string {
+	return filepath.Join(s.Config.GetCachePath(), fingerprintCacheFilename)
+}
+
+// loadFingerprintCache loads the fingerprint cache saved on shutdown.
+func (s *Manager) loadFingerprintCache() {
+	data, err := os.ReadFile(s.fingerprintCachePath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("Error reading fingerprint cache: %v", err)
+		}
+		return
+	}
+
+	var entries []file.FingerprintCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		logger.Warnf("Error reading fingerprint cache: %v", err)
+		return
+	}
+
+	s.FingerprintCache.Load(entries)
+	logger.Debugf("Loaded fingerprint cache with %d files", len(entries))
+}
+
+// saveFingerprintCache saves the fingerprint cache to the cache directory.
+func (s *Manager) saveFingerprintCache() {
+	entries := s.FingerprintCache.Entries()
+	if len(entries) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		logger.Errorf("Error saving fingerprint cache: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(s.fingerprintCachePath(), data, 0644); err != nil {
+		logger.Errorf("Error saving fingerprint cache: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type tagDescendantFinder interface {
+	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*models.TagPath, error)
+}
+
+// sceneExclusion is a generate exclusion with its tags resolved to include
+// their sub-tags.
+type sceneExclusion struct {
+	models.GenerateExclusion
+	tagIDs map[int]bool
+}
+
+// newSceneExclusion resolves the tags of the exclusion. Returns nil if e is
+// nil.
+func newSceneExclusion(ctx context.Context, tagFinder tagDescendantFinder, e *models.GenerateExclusion) (*sceneExclusion, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(e.TagIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	ret := &sceneExclusion{
+		GenerateExclusion: *e,
+		tagIDs:            make(map[int]bool),
+	}
+
+	for _, id := range ids {
+		descendants, err := tagFinder.FindAllDescendants(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// descendants includes the tag itself
+		for _, t := range descendants {
+			ret.tagIDs[t.ID] = true
+		}
+	}
+
+	return ret, nil
+}
+
+// excludes returns true if the scene matches any rule of the exclusion. The
+// scene must have its files loaded. A nil exclusion excludes nothing.
+func (e *sceneExclusion) excludes(ctx context.Context, l models.TagIDLoader, s *models.Scene) (bool, error) {
+	if e == nil {
+		return false, nil
+	}
+
+	if e.ExcludesFile(s.Files.Primary()) {
+		return true, nil
+	}
+
+	if len(e.tagIDs) == 0 {
+		return false, nil
+	}
+
+	if err := s.LoadTagIDs(ctx, l); err != nil {
+		return false, err
+	}
+
+	for _, id := range s.TagIDs.List() {
+		if e.tagIDs[id] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -12,6 +12,7 @@ import (
 	"sort"
 
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 )
@@ -20,10 +21,30 @@ type InteractiveHeatmapSpeedGenerator struct {
 	InteractiveSpeed int
 	Funscript        Script
 	Width            int
-	Height           int
 	NumSegments      int
 
+	HeatmapOptions
+}
+
+// HeatmapOptions control the appearance of generated heatmaps.
+type HeatmapOptions struct {
 	DrawRange bool
+	Palette   config.HeatmapPalette
+	Height    int
+	// SmoothingWindow is the number of neighbouring segments that the
+	// intensity is averaged over. Values below 2 disable smoothing.
+	SmoothingWindow int
+}
+
+// HeatmapOptionsFromConfig returns the heatmap options set in the
+// configuration.
+func HeatmapOptionsFromConfig(c *config.Config) HeatmapOptions {
+	return HeatmapOptions{
+		DrawRange:       c.GetDrawFunscriptHeatmapRange(),
+		Palette:         c.GetFunscriptHeatmapPalette(),
+		Height:          c.GetFunscriptHeatmapHeight(),
+		SmoothingWindow: c.GetFunscriptHeatmapSmoothing(),
+	}
 }
 
 type Script struct {
@@ -54,12 +75,15 @@ type GradientTable []struct {
 	YRange [2]float64
 }
 
-func NewInteractiveHeatmapSpeedGenerator(drawRange bool) *InteractiveHeatmapSpeedGenerator {
+func NewInteractiveHeatmapSpeedGenerator(options HeatmapOptions) *InteractiveHeatmapSpeedGenerator {
+	if options.Height <= 0 {
+		options.Height = 60
+	}
+
 	return &InteractiveHeatmapSpeedGenerator{
-		Width:       1280,
-		Height:      60,
-		NumSegments: 600,
-		DrawRange:   drawRange,
+		Width:          1280,
+		NumSegments:    600,
+		HeatmapOptions: options,
 	}
 }
 
@@ -154,7 +178,7 @@ func (funscript *Script) UpdateIntensityAndSpeed() {
 
 // funscript needs to have intensity updated first
 func (g *InteractiveHeatmapSpeedGenerator) RenderHeatmap(heatmapPath string, sceneDurationMilli int64) error {
-	gradient := g.Funscript.getGradientTable(g.NumSegments, sceneDurationMilli)
+	gradient := g.Funscript.getGradientTable(g.NumSegments, sceneDurationMilli, g.Palette, g.SmoothingWindow)
 
 	img := image.NewRGBA(image.Rect(0, 0, g.Width, g.Height))
 	for x := 0; x < g.Width; x++ {
@@ -240,7 +264,7 @@ func (gt GradientTable) GetYRange(t float64) [2]float64 {
 	return gt[len(gt)-1].YRange
 }
 
-func (funscript Script) getGradientTable(numSegments int, sceneDurationMilli int64) GradientTable {
+func (funscript Script) getGradientTable(numSegments int, sceneDurationMilli int64, palette config.HeatmapPalette, smoothingWindow int) GradientTable {
 	const windowSize = 15
 	const backfillThreshold = float64(500)
 
@@ -313,19 +337,71 @@ func (funscript Script) getGradientTable(numSegments int, sceneDurationMilli int
 		}
 	}
 
+	intensities := make([]float64, numSegments)
 	for i := 0; i < numSegments; i++ {
-		gradient[i].Pos = float64(i) / float64(numSegments-1)
-		gradient[i].YRange = segments[i].yRange
 		if segments[i].count > 0 {
-			gradient[i].Col = getSegmentColor(float64(segments[i].intensity) / float64(segments[i].count))
-		} else {
-			gradient[i].Col = getSegmentColor(0.0)
+			intensities[i] = float64(segments[i].intensity) / float64(segments[i].count)
 		}
 	}
+	intensities = smoothIntensities(intensities, smoothingWindow)
+
+	for i := 0; i < numSegments; i++ {
+		gradient[i].Pos = float64(i) / float64(numSegments-1)
+		gradient[i].YRange = segments[i].yRange
+		gradient[i].Col = getPaletteColor(palette, intensities[i])
+	}
 
 	return gradient
 }
 
+// smoothIntensities returns the moving average of the intensities over a
+// window centred on each segment.
+func smoothIntensities(intensities []float64, window int) []float64 {
+	if window < 2 {
+		return intensities
+	}
+
+	ret := make([]float64, len(intensities))
+	half := window / 2
+	for i := range intensities {
+		lo := max(i-half, 0)
+		hi := min(i+window-half, len(intensities))
+
+		var total float64
+		for _, v := range intensities[lo:hi] {
+			total += v
+		}
+		ret[i] = total / float64(hi-lo)
+	}
+
+	return ret
+}
+
+// maxIntensity is the intensity at which palettes reach their last colour.
+const maxIntensity = 625.0
+
+func getPaletteColor(palette config.HeatmapPalette, intensity float64) colorful.Color {
+	switch palette {
+	case config.HeatmapPaletteGrayscale:
+		return blendStops([]string{"#30404d", "#f0f0f0"}, intensity)
+	case config.HeatmapPaletteViridis:
+		return blendStops([]string{"#440154", "#3b528b", "#21918c", "#5ec962", "#fde725"}, intensity)
+	default:
+		return getSegmentColor(intensity)
+	}
+}
+
+// blendStops blends evenly spaced colours by intensity, from the first colour
+// at zero to the last at maxIntensity.
+func blendStops(stops []string, intensity float64) colorful.Color {
+	f := math.Max(0, math.Min(intensity/maxIntensity, 1)) * float64(len(stops)-1)
+	i := min(int(f), len(stops)-2)
+
+	c1, _ := colorful.Hex(stops[i])
+	c2, _ := colorful.Hex(stops[i+1])
+	return c1.BlendLab(c2, f-float64(i))
+}
+
 func getSegmentColor(intensity float64) colorful.Color {
 	colorBlue, _ := colorful.Hex("#1e90ff")   // DodgerBlue
 	colorGreen, _ := colorful.Hex("#228b22")  // ForestGreen
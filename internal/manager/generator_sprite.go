@@ -12,17 +12,83 @@ import (
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
 )
 
+// SpriteOptions are the options used to generate a sprite image.
+type SpriteOptions struct {
+	// Maximum number of rows in the sprite image
+	Rows int
+	// Number of columns in the sprite image
+	Columns int
+	// Interval in seconds between thumbnails. If zero, the thumbnails are
+	// spread evenly across the video
+	Interval float64
+	Format   models.SpriteFormat
+}
+
+func spriteOptionsFromConfig(c spriteConfig) SpriteOptions {
+	return SpriteOptions{
+		Rows:     c.GetSpriteRows(),
+		Columns:  c.GetSpriteColumns(),
+		Interval: c.GetSpriteInterval(),
+		Format:   c.GetSpriteFormat(),
+	}
+}
+
+type spriteConfig interface {
+	GetSpriteRows() int
+	GetSpriteColumns() int
+	GetSpriteInterval() float64
+	GetSpriteFormat() models.SpriteFormat
+}
+
+// newSpriteLayout returns the sprite layout for a video of the given
+// duration, using the sprite settings from the configuration.
+func newSpriteLayout(c spriteConfig, duration float64) generate.SpriteLayout {
+	return generate.NewSpriteLayout(duration, c.GetSpriteRows(), c.GetSpriteColumns(), c.GetSpriteInterval())
+}
+
+func spriteImageFilePath(p generate.ScenePaths, hash string, format models.SpriteFormat) string {
+	if format == models.SpriteFormatWebp {
+		return p.GetSpriteWebpImageFilePath(hash)
+	}
+
+	return p.GetSpriteImageFilePath(hash)
+}
+
+// FindSpriteImageFilePath returns the path and format of the existing sprite
+// image for the scene hash, preferring the given format. If no sprite image
+// exists, the path for the given format is returned.
+func FindSpriteImageFilePath(p generate.ScenePaths, hash string, format models.SpriteFormat) (string, models.SpriteFormat) {
+	ret := spriteImageFilePath(p, hash, format)
+	if exists, _ := fsutil.FileExists(ret); exists {
+		return ret, format
+	}
+
+	for _, f := range models.AllSpriteFormat {
+		if f == format {
+			continue
+		}
+
+		path := spriteImageFilePath(p, hash, f)
+		if exists, _ := fsutil.FileExists(path); exists {
+			return path, f
+		}
+	}
+
+	return ret, format
+}
+
 type SpriteGenerator struct {
 	Info *generatorInfo
 
 	VideoChecksum   string
 	ImageOutputPath string
 	VTTOutputPath   string
-	Rows            int
-	Columns         int
+	Layout          generate.SpriteLayout
+	Format          models.SpriteFormat
 	SlowSeek        bool // use alternate seek function, very slow!
 
 	Overwrite bool
@@ -30,13 +96,14 @@ type SpriteGenerator struct {
 	g *generate.Generator
 }
 
-func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageOutputPath string, vttOutputPath string, rows int, cols int) (*SpriteGenerator, error) {
+func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageOutputPath string, vttOutputPath string, options SpriteOptions) (*SpriteGenerator, error) {
 	exists, err := fsutil.FileExists(videoFile.Path)
 	if !exists {
 		return nil, err
 	}
 	slowSeek := false
-	chunkCount := rows * cols
+	layout := generate.NewSpriteLayout(videoFile.VideoStreamDuration, options.Rows, options.Columns, options.Interval)
+	chunkCount := layout.Chunks
 
 	// For files with small duration / low frame count  try to seek using frame number intead of seconds
 	if videoFile.VideoStreamDuration < 5 || (0 < videoFile.FrameCount && videoFile.FrameCount <= int64(chunkCount)) { // some files can have FrameCount == 0, only use SlowSeek  if duration < 5
@@ -71,9 +138,9 @@ func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageO
 		VideoChecksum:   videoChecksum,
 		ImageOutputPath: imageOutputPath,
 		VTTOutputPath:   vttOutputPath,
-		Rows:            rows,
+		Layout:          layout,
+		Format:          options.Format,
 		SlowSeek:        slowSeek,
-		Columns:         cols,
 		g: &generate.Generator{
 			Encoder:      instance.FFMpeg,
 			FFMpegConfig: instance.Config,
@@ -139,7 +206,12 @@ func (g *SpriteGenerator) generateSpriteImage() error {
 		return fmt.Errorf("images slice is empty, failed to generate sprite images for %s", g.Info.VideoFile.Path)
 	}
 
-	return imaging.Save(g.g.CombineSpriteImages(images), g.ImageOutputPath)
+	montage := g.g.CombineSpriteImages(images, g.Layout)
+	if g.Format == models.SpriteFormatWebp {
+		return g.g.SpriteImageWebp(context.TODO(), montage, g.ImageOutputPath)
+	}
+
+	return imaging.Save(montage, g.ImageOutputPath)
 }
 
 func (g *SpriteGenerator) generateSpriteVTT() error {
@@ -158,7 +230,10 @@ func (g *SpriteGenerator) generateSpriteVTT() error {
 		stepSize /= g.Info.FrameRate
 	}
 
-	return g.g.SpriteVTT(context.TODO(), g.VTTOutputPath, g.ImageOutputPath, stepSize)
+	layout := g.Layout
+	layout.StepSize = stepSize
+
+	return g.g.SpriteVTT(context.TODO(), g.VTTOutputPath, g.ImageOutputPath, layout)
 }
 
 func (g *SpriteGenerator) imageExists() bool {
@@ -0,0 +1,255 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+var ErrNoGroupProposals = errors.New("no group proposals have been detected")
+
+// groupProposalReports holds the last report of groups proposed from
+// multi-part releases.
+type groupProposalReports struct {
+	mutex sync.Mutex
+	last  *group.ProposalReport
+}
+
+func (r *groupProposalReports) get() *group.ProposalReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *groupProposalReports) set(report *group.ProposalReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = report
+}
+
+// GroupProposals returns the last report of proposed groups, or nil if
+// multi-part releases have not been detected.
+func (s *Manager) GroupProposals() *group.ProposalReport {
+	return s.groupProposals.get()
+}
+
+// DetectGroups starts a job that detects multi-part releases among the
+// scenes that are not in a group, and stores the groups proposed from them
+// as the last report. No groups are created.
+func (s *Manager) DetectGroups(ctx context.Context) int {
+	return s.JobManager.Add(ctx, "Detecting multi-part scenes...", &DetectGroupsJob{})
+}
+
+// ApplyGroupProposals starts a job that creates the groups of the last
+// report. If ids is not nil, only those proposals are applied.
+func (s *Manager) ApplyGroupProposals(ctx context.Context, ids []int) (int, error) {
+	report := s.groupProposals.get()
+	if report == nil {
+		return 0, ErrNoGroupProposals
+	}
+
+	proposals := report.Proposals
+	if ids != nil {
+		selected := make(map[int]bool)
+		for _, id := range ids {
+			selected[id] = true
+		}
+
+		proposals = nil
+		for _, p := range report.Proposals {
+			if selected[p.ID] {
+				proposals = append(proposals, p)
+			}
+		}
+	}
+
+	if len(proposals) == 0 {
+		return 0, errors.New("no group proposals to apply")
+	}
+
+	j := &ApplyGroupProposalsJob{
+		Proposals: proposals,
+	}
+
+	return s.JobManager.Add(ctx, "Creating groups from multi-part scenes...", j), nil
+}
+
+// DetectGroupsJob detects multi-part releases among the scenes that are not
+// in a group, from the part numbers in their filenames.
+type DetectGroupsJob struct{}
+
+func (j *DetectGroupsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	const batchSize = 1000
+
+	mgr := instance
+	r := mgr.Repository
+
+	sceneFilter := &models.SceneFilterType{
+		Groups: &models.HierarchicalMultiCriterionInput{
+			Modifier: models.CriterionModifierIsNull,
+		},
+	}
+
+	var candidates []group.PartCandidate
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				// path is the path of the primary file
+				if !s.Locked && s.Path != "" {
+					candidates = append(candidates, group.PartCandidate{
+						SceneID: s.ID,
+						Path:    s.Path,
+					})
+				}
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	report := &group.ProposalReport{
+		GeneratedAt: time.Now(),
+		Proposals:   group.Propose(candidates),
+	}
+	mgr.groupProposals.set(report)
+
+	logger.Infof("Detected %d multi-part releases in %d scenes", len(report.Proposals), len(candidates))
+	return nil
+}
+
+// ApplyGroupProposalsJob creates a group for each proposal and adds the
+// scenes of its parts at their scene indexes. If a group with the proposed
+// name exists, the scenes are added to it instead.
+type ApplyGroupProposalsJob struct {
+	Proposals []group.Proposal
+}
+
+func (j *ApplyGroupProposalsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	progress.SetTotal(len(j.Proposals))
+
+	for _, p := range j.Proposals {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Creating group %s", p.Name), func() {
+			err := j.apply(ctx, p)
+			if err != nil {
+				logger.Errorf("Error creating group %s: %v", p.Name, err)
+			}
+			progress.ItemDone(strconv.Itoa(p.ID), err)
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (j *ApplyGroupProposalsJob) apply(ctx context.Context, p group.Proposal) error {
+	mgr := instance
+	r := mgr.Repository
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		g, err := r.Group.FindByName(ctx, p.Name, true)
+		if err != nil {
+			return err
+		}
+
+		if g == nil {
+			newGroup := models.NewGroup()
+			newGroup.Name = p.Name
+			newGroup.ContainingGroups = models.NewRelatedGroupDescriptions(nil)
+			newGroup.SubGroups = models.NewRelatedGroupDescriptions(nil)
+
+			if err := mgr.GroupService.Create(ctx, &newGroup, nil, nil); err != nil {
+				return err
+			}
+			g = &newGroup
+		}
+
+		added := 0
+		for _, part := range p.Parts {
+			s, err := r.Scene.Find(ctx, part.SceneID)
+			if err != nil {
+				return err
+			}
+
+			// the scene may have been deleted or locked since the
+			// proposal was made
+			if s == nil || s.Locked {
+				logger.Infof("Skipping scene %d of group %s", part.SceneID, p.Name)
+				continue
+			}
+
+			index := part.SceneIndex
+			partial := models.NewScenePartial()
+			partial.GroupIDs = &models.UpdateGroupIDs{
+				Groups: []models.GroupsScenes{{GroupID: g.ID, SceneIndex: &index}},
+				Mode:   models.RelationshipUpdateModeAdd,
+			}
+
+			if _, err := r.Scene.UpdatePartial(ctx, s.ID, partial); err != nil {
+				return fmt.Errorf("adding scene %d to group: %w", s.ID, err)
+			}
+			added++
+		}
+
+		logger.Infof("Added %d scenes to group %s", added, p.Name)
+		return nil
+	})
+}
+
+// Retry returns a job that applies the proposals with the given ids again.
+func (j *ApplyGroupProposalsJob) Retry(ids []string) job.JobExec {
+	retry := make(map[string]bool)
+	for _, id := range ids {
+		retry[id] = true
+	}
+
+	var proposals []group.Proposal
+	for _, p := range j.Proposals {
+		if retry[strconv.Itoa(p.ID)] {
+			proposals = append(proposals, p)
+		}
+	}
+
+	return &ApplyGroupProposalsJob{
+		Proposals: proposals,
+	}
+}
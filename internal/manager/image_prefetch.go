@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// imagePrefetches tracks the image thumbnails being generated ahead of
+// their use, so that each is only generated once when requested again
+// before it is done.
+type imagePrefetches struct {
+	mutex  sync.Mutex
+	queued map[string]bool
+}
+
+func newImagePrefetches() *imagePrefetches {
+	return &imagePrefetches{
+		queued: make(map[string]bool),
+	}
+}
+
+// add marks the thumbnail with the path as queued. Returns false if it was
+// already queued.
+func (p *imagePrefetches) add(path string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.queued[path] {
+		return false
+	}
+	p.queued[path] = true
+	return true
+}
+
+func (p *imagePrefetches) remove(path string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.queued, path)
+}
+
+// PrefetchImageThumbnails generates the thumbnails of the profile for the
+// images in the background, in order, such as for the next images of a
+// slideshow. Thumbnails which exist or are already queued are skipped. The
+// images must have their primary file loaded. Nothing is generated if
+// writing thumbnails is disabled, since they would not be kept.
+func (s *Manager) PrefetchImageThumbnails(images []*models.Image, profile image.ThumbnailProfile) {
+	if !s.Config.IsWriteImageThumbnails() {
+		return
+	}
+
+	var tasks []*GenerateImageThumbnailTask
+	var paths []string
+	for _, img := range images {
+		if img.Files.Primary() == nil {
+			continue
+		}
+
+		path := s.Paths.Generated.GetThumbnailProfilePath(img.Checksum, profile.Key(), profile.Format.Extension())
+		if exists, _ := fsutil.FileExists(path); exists || !s.imagePrefetches.add(path) {
+			continue
+		}
+
+		tasks = append(tasks, &GenerateImageThumbnailTask{
+			Image:        *img,
+			Profiles:     []image.ThumbnailProfile{profile},
+			ProfilesOnly: true,
+		})
+		paths = append(paths, path)
+	}
+
+	if len(tasks) == 0 {
+		return
+	}
+
+	go func() {
+		for i, t := range tasks {
+			// share the limit on concurrent generation with the thumbnails
+			// generated when requested
+			wg := &s.ImageThumbnailGenerateWaitGroup
+			wg.Add()
+			t.Start(context.Background())
+			wg.Done()
+
+			s.imagePrefetches.remove(paths[i])
+		}
+	}()
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/log"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/contentgate"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/group"
@@ -27,10 +29,15 @@ import (
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/syncplay"
 	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stashapp/stash/ui"
 )
 
+// deleteConfirmationTimeout is how long a file deletion confirmation token
+// remains valid.
+const deleteConfirmationTimeout = 5 * time.Minute
+
 // Called at startup
 func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	ctx := context.TODO()
@@ -51,6 +58,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		Repository:           db.Scene,
 		MarkerRepository:     db.SceneMarker,
 		SimilarityRepository: db.SceneSimilarity,
+		ExtraRepository:      db.SceneExtra,
 		PluginCache:          pluginCache,
 		Paths:                mgrPaths,
 		Config:               cfg,
@@ -91,8 +99,30 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		JobManager:      initJobManager(cfg),
 		ReadLockManager: fsutil.NewReadLockManager(),
-
-		DownloadStore: NewDownloadStore(),
+		FileLocks:       fsutil.NewFileLocks(),
+
+		DownloadStore:   NewDownloadStore(),
+		DeleteConfirmer: file.NewDeleteConfirmer(deleteConfirmationTimeout),
+		StorageHealth:   file.NewHealthMonitor(storageCheckTimeout),
+		remotes:         newRemoteStashes(),
+		buttplug:        &buttplugDevices{},
+		retention:       &retentionReports{},
+		groupProposals:  &groupProposalReports{},
+		studioProposals: &studioProposalReports{},
+		dateProposals:   &dateProposalReports{},
+		brokenFiles:     newBrokenFiles(),
+		reencodePlans:   &reencodePlans{},
+		autoConversions: newAutoConversions(),
+		consistency:     &consistencyReports{},
+		mediaServers:    &mediaServerSync{},
+		randomScenes:    newRecentlyServed(),
+		onDemandSprites: newOnDemandSprites(),
+		imagePrefetches: newImagePrefetches(),
+		ContentGate:     &contentgate.Gate{},
+		SyncPlay:        syncplay.NewManager(),
+		PhashIndex:      utils.NewPhashIndex(),
+
+		FingerprintCache: file.NewFingerprintCache(),
 
 		PluginCache:  pluginCache,
 		ScraperCache: scraperCache,
@@ -137,6 +167,19 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	instance = mgr
+
+	mgr.monitorStorage()
+	mgr.monitorRetention()
+	mgr.monitorMediaServers()
+	mgr.monitorTempFiles()
+	mgr.monitorLibraryStats()
+
+	if !cfg.IsNewSystem() {
+		mgr.resumeInterruptedJobs(ctx)
+		mgr.loadPhashIndex(ctx)
+		mgr.loadFingerprintCache()
+	}
+
 	return mgr, nil
 }
 
@@ -202,6 +245,10 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshDLNA()
 
 	s.SetBlobStoreOptions()
+	s.SetSortOptions()
+	s.SetTimezone()
+	s.Database.SetBusyTimeout(s.Config.GetDatabaseBusyTimeout())
+	s.Database.SetCacheSize(s.Config.GetDatabaseCacheSize())
 
 	s.writeStashIcon()
 
@@ -316,4 +363,32 @@ func (s *Manager) RefreshFFMpeg(ctx context.Context) {
 
 		s.FFMpeg.InitHWSupport(ctx)
 	}
+
+	s.refreshFFMpegStatus(ctx, ffmpegPath, ffprobePath)
+}
+
+func (s *Manager) refreshFFMpegStatus(ctx context.Context, ffmpegPath string, ffprobePath string) {
+	s.ffmpegStatus = nil
+	s.ffprobeVersion = nil
+
+	if ffmpegPath != "" {
+		status, err := ffmpeg.GetStatus(ctx, ffmpegPath)
+		if err != nil {
+			logger.Warnf("could not get ffmpeg status: %v", err)
+		} else {
+			if !status.VersionSupported() {
+				logger.Warnf("ffmpeg version is unknown or older than the minimum supported version %s", ffmpeg.MinimumVersion)
+			}
+			s.ffmpegStatus = status
+		}
+	}
+
+	if ffprobePath != "" {
+		v, err := ffmpeg.GetFFProbeVersion(ctx, ffprobePath)
+		if err != nil {
+			logger.Warnf("could not detect ffprobe version: %v", err)
+		} else {
+			s.ffprobeVersion = v
+		}
+	}
 }
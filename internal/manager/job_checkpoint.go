@@ -0,0 +1,175 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	checkpointKindScan     = "scan"
+	checkpointKindGenerate = "generate"
+)
+
+// jobShutdownTimeout is how long to wait for running jobs to stop on
+// shutdown before checkpointing them.
+const jobShutdownTimeout = 5 * time.Second
+
+type scanCheckpoint struct {
+	Input ScanMetadataInput `json:"input"`
+	// Path is the path of the last file in walk order before which all
+	// files were scanned.
+	Path string `json:"path"`
+}
+
+func (j *ScanJob) Checkpoint() (string, []byte, error) {
+	c := scanCheckpoint{
+		Input: j.input,
+	}
+	if j.cursor != nil {
+		c.Path = j.cursor.Path()
+	}
+
+	data, err := json.Marshal(c)
+	return checkpointKindScan, data, err
+}
+
+type generateCheckpoint struct {
+	Input GenerateMetadataInput `json:"input"`
+	// Position is the number of queued tasks that were completed.
+	Position int `json:"position"`
+}
+
+func (j *GenerateJob) Checkpoint() (string, []byte, error) {
+	c := generateCheckpoint{
+		Input: j.input,
+	}
+
+	// when not overwriting, completed tasks are not queued again, so the
+	// job resumes where it stopped without skipping any tasks
+	if j.cursor != nil && j.input.Overwrite {
+		c.Position = j.cursor.Position()
+	}
+
+	data, err := json.Marshal(c)
+	return checkpointKindGenerate, data, err
+}
+
+// saveJobCheckpoints stops the job manager and saves the checkpoints of the
+// resumable jobs that did not finish.
+func (s *Manager) saveJobCheckpoints() {
+	checkpoints := s.JobManager.Shutdown(jobShutdownTimeout)
+	if len(checkpoints) == 0 {
+		return
+	}
+
+	if err := s.Database.Ready(); err != nil {
+		logger.Warnf("Could not save job checkpoints: %v", err)
+		return
+	}
+
+	now := time.Now()
+	ctx := context.Background()
+	if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		for _, c := range checkpoints {
+			newCheckpoint := &models.JobCheckpoint{
+				Kind:        c.Kind,
+				Description: c.Description,
+				State:       c.State,
+				CreatedAt:   now,
+			}
+
+			if err := s.Repository.JobCheckpoint.Create(ctx, newCheckpoint); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("Error saving job checkpoints: %v", err)
+		return
+	}
+
+	logger.Infof("Saved %d interrupted jobs", len(checkpoints))
+}
+
+// resumeInterruptedJobs queues the jobs that were interrupted by the last
+// shutdown. The checkpoints are discarded if resuming is disabled.
+func (s *Manager) resumeInterruptedJobs(ctx context.Context) {
+	if err := s.Database.Ready(); err != nil {
+		// database needs migration or setup
+		return
+	}
+
+	var checkpoints []*models.JobCheckpoint
+	if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		checkpoints, err = s.Repository.JobCheckpoint.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range checkpoints {
+			if err := s.Repository.JobCheckpoint.Destroy(ctx, c.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("Error loading job checkpoints: %v", err)
+		return
+	}
+
+	if len(checkpoints) > 0 && !s.Config.GetResumeInterruptedJobs() {
+		logger.Infof("Discarding %d interrupted jobs", len(checkpoints))
+		return
+	}
+
+	for _, c := range checkpoints {
+		e, err := s.jobFromCheckpoint(c)
+		if err != nil {
+			logger.Errorf("Error resuming job %q: %v", c.Description, err)
+			continue
+		}
+
+		logger.Infof("Resuming interrupted job %q", c.Description)
+		s.JobManager.Add(ctx, c.Description, e)
+	}
+}
+
+func (s *Manager) jobFromCheckpoint(c *models.JobCheckpoint) (job.JobExec, error) {
+	switch c.Kind {
+	case checkpointKindScan:
+		var state scanCheckpoint
+		if err := json.Unmarshal(c.State, &state); err != nil {
+			return nil, err
+		}
+
+		return &ScanJob{
+			scanner:       s.newScanner(),
+			input:         state.Input,
+			subscriptions: s.scanSubs,
+			cursor:        file.NewScanCursor(state.Path),
+		}, nil
+	case checkpointKindGenerate:
+		var state generateCheckpoint
+		if err := json.Unmarshal(c.State, &state); err != nil {
+			return nil, err
+		}
+
+		return &GenerateJob{
+			repository: s.Repository,
+			input:      state.Input,
+			cursor:     job.NewCursor(state.Position),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown job kind %q", c.Kind)
+}
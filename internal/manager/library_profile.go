@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+// SwitchLibraryProfile closes the current library and opens the library of
+// the named profile. The switch applies to the whole server. It is refused
+// while jobs are queued or running, since they operate on the current
+// library.
+func (s *Manager) SwitchLibraryProfile(ctx context.Context, name string) error {
+	cfg := s.Config
+	if name == cfg.GetActiveLibraryProfile() {
+		return nil
+	}
+
+	if len(s.JobManager.GetQueue()) > 0 {
+		return errors.New("cannot switch profiles while jobs are queued or running")
+	}
+
+	previous := cfg.GetActiveLibraryProfile()
+	profile, err := cfg.ActivateLibraryProfile(name)
+	if err != nil {
+		return err
+	}
+
+	if err := fsutil.EnsureDir(profile.Generated); err != nil {
+		return fmt.Errorf("creating generated directory: %w", err)
+	}
+	if profile.Blobs != "" {
+		if err := fsutil.EnsureDir(profile.Blobs); err != nil {
+			return fmt.Errorf("creating blobs directory: %w", err)
+		}
+	}
+
+	if err := cfg.Write(); err != nil {
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+
+	if err := s.Database.Close(); err != nil {
+		return fmt.Errorf("closing database: %w", err)
+	}
+
+	s.RefreshConfig()
+	s.SetBlobStoreOptions()
+	s.SetSortOptions()
+	s.SetTimezone()
+
+	if err := s.Database.Open(cfg.GetDatabasePath()); err != nil {
+		var migrationNeededErr *sqlite.MigrationNeededError
+		if !errors.As(err, &migrationNeededErr) {
+			return fmt.Errorf("opening database: %w", err)
+		}
+		logger.Warn(err)
+	}
+
+	s.RefreshStreamManager()
+	s.RefreshDLNA()
+
+	logger.Infof("Switched library profile from %q to %q", previous, name)
+	return nil
+}
+
+// SaveLibraryProfile adds or replaces an inactive library profile and writes
+// the configuration.
+func (s *Manager) SaveLibraryProfile(input config.LibraryProfileInput) (*config.LibraryProfile, error) {
+	profile, err := s.Config.SaveLibraryProfile(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Config.Write(); err != nil {
+		return nil, fmt.Errorf("writing configuration: %w", err)
+	}
+
+	return profile, nil
+}
+
+// DeleteLibraryProfile removes an inactive library profile and writes the
+// configuration.
+func (s *Manager) DeleteLibraryProfile(name string) error {
+	if err := s.Config.DeleteLibraryProfile(name); err != nil {
+		return err
+	}
+
+	return s.Config.Write()
+}
@@ -0,0 +1,195 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// libraryStatsCheckInterval is how often it is checked whether the
+	// library statistics are due to be recomputed.
+	libraryStatsCheckInterval = time.Hour
+
+	// libraryStatsRecomputeInterval is how often the library statistics are
+	// fully recomputed, correcting changes not followed by the database.
+	libraryStatsRecomputeInterval = 24 * time.Hour
+)
+
+// LibraryStats returns the stored library statistics. The statistics are
+// computed first if they have never been computed.
+func (s *Manager) LibraryStats(ctx context.Context) (*models.LibraryStats, error) {
+	r := s.Repository
+
+	var ret *models.LibraryStats
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.LibraryStats.Get(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret.ComputedAt == nil {
+		return s.RecomputeLibraryStats(ctx)
+	}
+
+	return ret, nil
+}
+
+// RecomputeLibraryStats fully computes the library statistics, and stores
+// them in place of the maintained statistics.
+func (s *Manager) RecomputeLibraryStats(ctx context.Context) (*models.LibraryStats, error) {
+	r := s.Repository
+
+	var ret *models.LibraryStats
+	// statistics are computed and stored in the same write transaction, so
+	// that no changes are made to the library in between
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = computeLibraryStats(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ret.ComputedAt = &now
+
+		return r.LibraryStats.Set(ctx, *ret)
+	}); err != nil {
+		return nil, fmt.Errorf("computing library statistics: %w", err)
+	}
+
+	return ret, nil
+}
+
+func computeLibraryStats(ctx context.Context, r models.Repository) (*models.LibraryStats, error) {
+	sceneQB := r.Scene
+	imageQB := r.Image
+	galleryQB := r.Gallery
+
+	var ret models.LibraryStats
+	var err error
+
+	if ret.SceneCount, err = sceneQB.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.ScenesSize, err = sceneQB.Size(ctx); err != nil {
+		return nil, err
+	}
+	if ret.ScenesDuration, err = sceneQB.Duration(ctx); err != nil {
+		return nil, err
+	}
+	if ret.ImageCount, err = imageQB.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.ImagesSize, err = imageQB.Size(ctx); err != nil {
+		return nil, err
+	}
+	if ret.GalleryCount, err = galleryQB.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.PerformerCount, err = r.Performer.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.StudioCount, err = r.Studio.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.GroupCount, err = r.Group.Count(ctx); err != nil {
+		return nil, err
+	}
+	if ret.TagCount, err = r.Tag.Count(ctx); err != nil {
+		return nil, err
+	}
+
+	scenesOCount, err := sceneQB.GetAllOCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imagesOCount, err := imageQB.OCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	galleriesOCount, err := galleryQB.OCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.TotalOCount = scenesOCount + imagesOCount + galleriesOCount
+
+	scenesOMGCount, err := sceneQB.GetAllOMGCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imagesOMGCount, err := imageQB.GetAllOMGCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	galleriesOMGCount, err := galleryQB.GetAllOMGCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.TotalOMGCount = scenesOMGCount + imagesOMGCount + galleriesOMGCount
+
+	if ret.TotalPlayDuration, err = sceneQB.PlayDuration(ctx); err != nil {
+		return nil, err
+	}
+	if ret.TotalPlayCount, err = sceneQB.CountAllViews(ctx); err != nil {
+		return nil, err
+	}
+	if ret.ScenesPlayed, err = sceneQB.CountUniqueViews(ctx); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// monitorLibraryStats periodically recomputes the library statistics in the
+// background.
+func (s *Manager) monitorLibraryStats() {
+	go func() {
+		ticker := time.NewTicker(libraryStatsCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if s.Config.IsNewSystem() {
+				continue
+			}
+
+			ctx := context.Background()
+			var stats *models.LibraryStats
+			if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+				var err error
+				stats, err = s.Repository.LibraryStats.Get(ctx)
+				return err
+			}); err != nil {
+				logger.Errorf("[stats] error reading library statistics: %v", err)
+				continue
+			}
+
+			if stats.ComputedAt != nil && time.Since(*stats.ComputedAt) < libraryStatsRecomputeInterval {
+				continue
+			}
+
+			s.JobManager.Add(ctx, "Recomputing library statistics...", &LibraryStatsJob{})
+		}
+	}()
+}
+
+// LibraryStatsJob recomputes the library statistics.
+type LibraryStatsJob struct{}
+
+func (j *LibraryStatsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	progress.Indefinite()
+
+	stats, err := instance.RecomputeLibraryStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("[stats] recomputed library statistics: %d scenes, %d images, %d galleries", stats.SceneCount, stats.ImageCount, stats.GalleryCount)
+	return nil
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/log"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/contentgate"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -27,6 +29,9 @@ import (
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/syncplay"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 
 	// register custom migrations
 	_ "github.com/stashapp/stash/pkg/sqlite/migrations"
@@ -46,11 +51,77 @@ type Manager struct {
 	FFProbe       *ffmpeg.FFProbe
 	StreamManager *ffmpeg.StreamManager
 
+	// ffmpegStatus and ffprobeVersion are detected when ffmpeg is refreshed
+	ffmpegStatus   *ffmpeg.Status
+	ffprobeVersion *ffmpeg.Version
+
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	// FileLocks holds the files being replaced by conversion tasks, which
+	// are skipped by scans
+	FileLocks *fsutil.FileLocks
+
+	DownloadStore   *DownloadStore
+	SessionStore    *session.Store
+	DeleteConfirmer *file.DeleteConfirmer
+
+	// StorageHealth tracks whether the stash paths are reachable
+	StorageHealth *file.HealthMonitor
+
+	remotes *remoteStashes
+
+	// buttplug is the connection to the Buttplug server controlling devices
+	buttplug *buttplugDevices
+
+	// retention holds the last report of scenes matching the retention rules
+	retention *retentionReports
+
+	// groupProposals holds the last report of groups proposed from
+	// multi-part releases
+	groupProposals *groupProposalReports
+
+	// studioProposals holds the last report of studios inferred for scenes
+	studioProposals *studioProposalReports
+
+	// dateProposals holds the last report of dates inferred for scenes
+	dateProposals *dateProposalReports
+
+	// brokenFiles holds the files rejected as broken by scans
+	brokenFiles *brokenFiles
+
+	// reencodePlans holds the last plan of files to re-encode
+	reencodePlans *reencodePlans
+
+	// autoConversions tracks the files queued by automatic conversion
+	autoConversions *autoConversions
+
+	// consistency holds the report of the last library consistency check
+	consistency *consistencyReports
+
+	// mediaServers holds the report of the last sync with the media servers
+	mediaServers *mediaServerSync
+
+	// randomScenes holds the scenes recently served by play random
+	randomScenes *recentlyServed
+
+	// onDemandSprites limits the generation of sprites when first requested
+	onDemandSprites *onDemandSprites
+
+	// imagePrefetches tracks the image thumbnails generated ahead of use
+	imagePrefetches *imagePrefetches
+
+	// ContentGate hides content with the gated tags until the PIN is entered
+	ContentGate *contentgate.Gate
+
+	// SyncPlay holds the synchronized playback sessions
+	SyncPlay *syncplay.Manager
 
-	DownloadStore *DownloadStore
-	SessionStore  *session.Store
+	// PhashIndex finds scenes with similar phashes
+	PhashIndex *utils.PhashIndex
+
+	// FingerprintCache holds the fingerprints of scanned files, so that
+	// unchanged files are not hashed again when rescanned
+	FingerprintCache *file.FingerprintCache
 
 	PluginCache  *plugin.Cache
 	ScraperCache *scraper.Cache
@@ -93,6 +164,21 @@ func (s *Manager) SetBlobStoreOptions() {
 	})
 }
 
+// SetSortOptions sets the configured collation and locale used by the
+// database to sort by text fields.
+func (s *Manager) SetSortOptions() {
+	s.Database.SetSortOptions(sqlite.SortOptions{
+		Collation: s.Config.GetSortCollation(),
+		Locale:    s.Config.GetSortLocale(),
+	})
+}
+
+// SetTimezone sets the configured time zone in which the database groups
+// event times into days.
+func (s *Manager) SetTimezone() {
+	s.Database.SetTimezone(s.Config.GetTimezone())
+}
+
 func (s *Manager) RefreshConfig() {
 	cfg := s.Config
 	*s.Paths = paths.NewPaths(cfg.GetGeneratedPath(), cfg.GetBlobsPath())
@@ -404,6 +490,12 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 		ffprobePath = s.FFProbe.Path()
 	}
 
+	var ffprobeVersion *string
+	if s.ffprobeVersion != nil {
+		v := s.ffprobeVersion.String()
+		ffprobeVersion = &v
+	}
+
 	return &SystemStatus{
 		Os:             runtime.GOOS,
 		WorkingDir:     workingDir,
@@ -415,18 +507,53 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 		ConfigPath:     &configFile,
 		FfmpegPath:     &ffmpegPath,
 		FfprobePath:    &ffprobePath,
+		FfprobeVersion: ffprobeVersion,
+		Ffmpeg:         s.getFFMpegStatus(),
+
+		DatabaseLockedCount: int(txn.LockedCount()),
 	}
 }
 
+func (s *Manager) getFFMpegStatus() *FFMpegStatus {
+	if s.ffmpegStatus == nil {
+		return nil
+	}
+
+	ret := &FFMpegStatus{
+		Path:             s.ffmpegStatus.Path,
+		MinimumVersion:   ffmpeg.MinimumVersion.String(),
+		VersionSupported: s.ffmpegStatus.VersionSupported(),
+		MissingFeatures:  s.ffmpegStatus.MissingFeatures,
+		HardwareEncoders: s.ffmpegStatus.HardwareEncoders,
+		Managed:          filepath.Dir(s.ffmpegStatus.Path) == s.Config.GetConfigPathAbs(),
+	}
+	if s.ffmpegStatus.Version != nil {
+		v := s.ffmpegStatus.Version.String()
+		ret.Version = &v
+	}
+
+	return ret
+}
+
 // Shutdown gracefully stops the manager
 func (s *Manager) Shutdown() {
 	// TODO: Each part of the manager needs to gracefully stop at some point
 
+	s.saveJobCheckpoints()
+
 	if s.StreamManager != nil {
 		s.StreamManager.Shutdown()
 		s.StreamManager = nil
 	}
 
+	s.remotes.close()
+	s.buttplug.close()
+	if s.ScraperCache != nil {
+		s.ScraperCache.CloseCDP()
+	}
+	s.savePhashIndex()
+	s.saveFingerprintCache()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
@@ -54,10 +54,7 @@ func isImage(pathname string) bool {
 
 func getScanPaths(inputPaths []string) []*config.StashConfig {
 	stashPaths := config.GetInstance().GetStashPaths()
-	var savedScreensPath string
-	if instance != nil && instance.Paths.Generated != nil {
-		savedScreensPath = instance.Paths.Generated.SavedScreens
-	}
+	savedScreensPath := getSavedScreensPath()
 
 	if len(inputPaths) == 0 {
 		return stashPaths
@@ -101,6 +98,13 @@ type ScanMetadataInput struct {
 
 	// Filter options for the scan
 	Filter *ScanMetaDataFilterInput `json:"filter"`
+
+	// Log the throughput of each stage of the scan when it finishes
+	Benchmark bool `json:"benchmark"`
+
+	// Recalculate fingerprints from the file contents instead of using the
+	// fingerprint cache
+	VerifyFingerprints bool `json:"verifyFingerprints"`
 }
 
 // Filter options for meta data scannning
@@ -114,7 +118,18 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		return 0, err
 	}
 
-	scanner := &file.Scanner{
+	scanJob := ScanJob{
+		scanner:       s.newScanner(),
+		input:         input,
+		subscriptions: s.scanSubs,
+		cursor:        file.NewScanCursor(""),
+	}
+
+	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+}
+
+func (s *Manager) newScanner() *file.Scanner {
+	return &file.Scanner{
 		Repository: file.NewRepository(s.Repository),
 		FileDecorators: []file.Decorator{
 			&file.FilteredDecorator{
@@ -131,16 +146,9 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 			},
 		},
 		FingerprintCalculator: &FingerprintCalculator{s.Config},
-		FS:                    &file.OsFS{},
-	}
-
-	scanJob := ScanJob{
-		scanner:       scanner,
-		input:         input,
-		subscriptions: s.scanSubs,
+		FingerprintCache:      s.FingerprintCache,
+		FS:                    s.stashFS(false),
 	}
-
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -231,21 +239,64 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 	j := &GenerateJob{
 		repository: s.Repository,
 		input:      input,
+		cursor:     job.NewCursor(0),
 	}
 
 	return s.JobManager.Add(ctx, "Generating...", j), nil
 }
 
+// GenerateHeatmap queues a job that regenerates the heatmap and interactive
+// speed of the scene with the given options. The files of the scene must be
+// loaded.
+func (s *Manager) GenerateHeatmap(ctx context.Context, scene *models.Scene, options HeatmapOptions) (int, error) {
+	primaryFile := scene.Files.Primary()
+	if primaryFile == nil || !primaryFile.Interactive {
+		return 0, fmt.Errorf("scene %d is not interactive", scene.ID)
+	}
+
+	t := &GenerateInteractiveHeatmapSpeedTask{
+		repository:          s.Repository,
+		Scene:               *scene,
+		Overwrite:           true,
+		fileNamingAlgorithm: s.Config.GetVideoFileNamingAlgorithm(),
+		HeatmapOptions:      &options,
+	}
+
+	return s.RunSingleTask(ctx, t), nil
+}
+
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
-	return s.generateScreenshot(ctx, sceneId, nil)
+	return s.generateScreenshot(ctx, sceneId, func(scene models.Scene) Task {
+		return newCoverTask(s.Repository, scene, true)
+	})
 }
 
 func (s *Manager) GenerateScreenshot(ctx context.Context, sceneId string, at float64) int {
-	return s.generateScreenshot(ctx, sceneId, &at)
+	return s.generateScreenshot(ctx, sceneId, func(scene models.Scene) Task {
+		return &GenerateCoverTask{
+			repository:   s.Repository,
+			Scene:        scene,
+			ScreenshotAt: &at,
+			Overwrite:    true,
+		}
+	})
 }
 
-// generate default screenshot if at is nil
-func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *float64) int {
+// RerollCover generates the cover of the scene from the best of a new set of
+// candidate frames. Returns the job ID.
+func (s *Manager) RerollCover(ctx context.Context, sceneId string) int {
+	return s.generateScreenshot(ctx, sceneId, func(scene models.Scene) Task {
+		return &SmartCoverTask{
+			repository: s.Repository,
+			Scene:      scene,
+			Overwrite:  true,
+			Reroll:     true,
+		}
+	})
+}
+
+// generateScreenshot runs the cover task returned by newTask for the scene
+func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, newTask func(scene models.Scene) Task) int {
 	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
 		logger.Warnf("failure generating screenshot: %v", err)
 	}
@@ -271,13 +322,7 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 			return fmt.Errorf("error finding scene for screenshot generation: %w", err)
 		}
 
-		task := GenerateCoverTask{
-			repository:   s.Repository,
-			Scene:        *scene,
-			ScreenshotAt: at,
-			Overwrite:    true,
-		}
-
+		task := newTask(*scene)
 		task.Start(ctx)
 
 		logger.Infof("Generate screenshot finished")
@@ -296,67 +341,16 @@ func (s *Manager) ScanVideoFileThreats(ctx context.Context, fileID string) (int,
 	}
 
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
-		fileIDInt, err := strconv.Atoi(fileID)
+		videoFile, err := s.findThreatScanFile(ctx, fileID)
 		if err != nil {
-			return fmt.Errorf("invalid file id %s: %w", fileID, err)
-		}
-
-		var videoFile *models.VideoFile
-		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
-			files, err := s.Repository.File.Find(ctx, models.FileID(fileIDInt))
-			if err != nil {
-				return err
-			}
-			if len(files) == 0 {
-				return fmt.Errorf("file with id %s not found", fileID)
-			}
-
-			var ok bool
-			videoFile, ok = files[0].(*models.VideoFile)
-			if !ok {
-				return fmt.Errorf("file %s is not a video file", fileID)
-			}
-			return nil
-		}); err != nil {
 			return err
 		}
 
-		if videoFile.ZipFileID != nil {
-			return fmt.Errorf("scanning files inside zip archives is not supported")
-		}
-
-		progress.SetTotal(2)
-		progress.ExecuteTask("Scanning metadata...", func() {})
-		progress.Increment()
-
-		scanner := threatscan.NewScanner(s.FFProbe, s.FFMpeg)
-		threats, err := scanner.Scan(ctx, videoFile.Path)
-		if err != nil {
-			return fmt.Errorf("scan failed: %w", err)
-		}
-
-		progress.ExecuteTask("Updating file...", func() {})
-		progress.Increment()
-
-		threatsStr := threatscan.FormatThreats(threats)
-		videoFile.Threats = threatsStr
-		scannedAt := time.Now()
-		videoFile.ThreatsScannedAt = &scannedAt
+		progress.ExecuteTask(fmt.Sprintf("Scanning %s...", videoFile.Path), func() {
+			_, err = s.scanFileThreats(ctx, videoFile)
+		})
 
-		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
-			return s.Repository.File.Update(ctx, videoFile)
-		}); err != nil {
-			return fmt.Errorf("failed to update file: %w", err)
-		}
-
-		if len(threats) > 0 {
-			logger.Infof("Threat scan found %d threat(s) in file %s: %s",
-				len(threats), videoFile.Path, strings.ReplaceAll(threatsStr, "\n", "; "))
-		} else {
-			logger.Infof("Threat scan completed: no threats found in file %s", videoFile.Path)
-		}
-
-		return nil
+		return err
 	})
 
 	return s.JobManager.Add(ctx, fmt.Sprintf("Scanning file %s for threats", fileID), j), nil
@@ -470,12 +464,15 @@ type AutoTagMetadataInput struct {
 	Studios []string `json:"studios"`
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
+	// Also tag locked scenes and galleries
+	IncludeLocked bool `json:"includeLocked"`
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 	j := autoTagJob{
 		repository: s.Repository,
 		input:      input,
+		cache:      *s.Config.GetAutoTagMatchCache(),
 	}
 
 	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
@@ -489,7 +486,7 @@ type CleanMetadataInput struct {
 
 func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 	cleaner := &file.Cleaner{
-		FS:         &file.OsFS{},
+		FS:         file.NewRetryFS(&file.OsFS{}),
 		Repository: file.NewRepository(s.Repository),
 		Handlers: []file.CleanHandler{
 			&cleanHandler{},
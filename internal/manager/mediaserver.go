@@ -0,0 +1,388 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/mediaserver"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// mediaServerCheckInterval is how often it is checked whether a scheduled
+// media server sync is due.
+const mediaServerCheckInterval = 10 * time.Minute
+
+// mediaServerTimeout limits the time taken by each request to a media
+// server. Listing the items of a large library can take some time.
+const mediaServerTimeout = 5 * time.Minute
+
+// mediaServerSync holds the report of the last sync with the media servers.
+type mediaServerSync struct {
+	mutex sync.Mutex
+	last  *mediaserver.Report
+	// running is true while a sync job is queued or running
+	running bool
+}
+
+func (m *mediaServerSync) get() *mediaserver.Report {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.last
+}
+
+func (m *mediaServerSync) set(report *mediaserver.Report) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.last = report
+}
+
+// start returns false if a sync is already queued or running.
+func (m *mediaServerSync) start() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.running {
+		return false
+	}
+	m.running = true
+	return true
+}
+
+func (m *mediaServerSync) done() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.running = false
+}
+
+// MediaServerSyncReport returns the report of the last sync with the media
+// servers, or nil if they have not been synced.
+func (s *Manager) MediaServerSyncReport() *mediaserver.Report {
+	return s.mediaServers.get()
+}
+
+// SyncMediaServers starts a job that syncs the watch state and ratings of
+// scenes with the configured media servers. Returns false if a sync is
+// already queued or running.
+func (s *Manager) SyncMediaServers(ctx context.Context) (int, bool) {
+	if !s.mediaServers.start() {
+		return 0, false
+	}
+
+	return s.JobManager.Add(ctx, "Syncing with media servers...", &MediaServerSyncJob{}), true
+}
+
+// monitorMediaServers periodically syncs with the media servers in the
+// background, at the configured interval.
+func (s *Manager) monitorMediaServers() {
+	go func() {
+		ticker := time.NewTicker(mediaServerCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			interval := s.Config.GetMediaServerSyncInterval()
+			if interval <= 0 || s.Config.IsNewSystem() || len(s.Config.GetMediaServers()) == 0 {
+				continue
+			}
+
+			if last := s.mediaServers.get(); last != nil && time.Since(last.GeneratedAt) < interval {
+				continue
+			}
+
+			s.SyncMediaServers(context.Background())
+		}
+	}()
+}
+
+// mediaServerScene is a scene that media server items are matched to.
+type mediaServerScene struct {
+	mediaserver.Candidate
+	Locked bool
+	State  mediaserver.State
+}
+
+// MediaServerSyncJob maps the items of each media server to scenes by their
+// file, and syncs their watch state and ratings in both directions. Items
+// which do not match a scene are listed in the report.
+type MediaServerSyncJob struct{}
+
+func (j *MediaServerSyncJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+	defer mgr.mediaServers.done()
+
+	servers := mgr.Config.GetMediaServers()
+	if len(servers) == 0 {
+		logger.Info("[mediaserver] no media servers configured")
+		return nil
+	}
+
+	statePath := mgr.Config.GetMediaServerSyncStatePath()
+	state, err := mediaserver.LoadSyncState(statePath)
+	if err != nil {
+		return fmt.Errorf("loading media server sync state: %w", err)
+	}
+
+	scenes, err := j.scenes(ctx)
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]mediaserver.Candidate, 0, len(scenes))
+	for _, s := range scenes {
+		candidates = append(candidates, s.Candidate)
+	}
+	matcher := mediaserver.NewMatcher(candidates)
+
+	report := &mediaserver.Report{
+		GeneratedAt: time.Now(),
+	}
+
+	progress.SetTotal(len(servers))
+	for _, server := range servers {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Syncing with %s", server.Name), func() {
+			serverReport, err := j.syncServer(ctx, server, matcher, scenes, state)
+			if err != nil {
+				logger.Errorf("[mediaserver] error syncing with %s: %v", server.Name, err)
+				errStr := err.Error()
+				serverReport.Error = &errStr
+			} else {
+				logger.Infof("[mediaserver] synced %d items with %s: %d scenes and %d items updated, %d unmatched",
+					serverReport.Matched, server.Name, serverReport.StashUpdated, serverReport.ServerUpdated, len(serverReport.Unmatched))
+			}
+
+			report.Servers = append(report.Servers, serverReport)
+			progress.ItemDone(server.Name, err)
+		})
+
+		progress.Increment()
+	}
+
+	// remove the state of servers which are no longer configured
+	for name := range state {
+		found := false
+		for _, server := range servers {
+			found = found || server.Name == name
+		}
+		if !found {
+			delete(state, name)
+		}
+	}
+
+	if err := state.Save(statePath); err != nil {
+		logger.Errorf("[mediaserver] error saving sync state: %v", err)
+	}
+
+	mgr.mediaServers.set(report)
+	return nil
+}
+
+// scenes returns the scenes with files, by id.
+func (j *MediaServerSyncJob) scenes(ctx context.Context) (map[int]*mediaServerScene, error) {
+	const batchSize = 1000
+
+	r := instance.Repository
+	ret := make(map[int]*mediaServerScene)
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, nil, findFilter)
+			if err != nil {
+				return err
+			}
+
+			ids := make([]int, len(scenes))
+			for i, s := range scenes {
+				ids[i] = s.ID
+			}
+
+			viewCounts, err := r.Scene.GetManyViewCount(ctx, ids)
+			if err != nil {
+				return fmt.Errorf("getting view counts: %w", err)
+			}
+
+			for i, s := range scenes {
+				if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+					return err
+				}
+
+				f := s.Files.Primary()
+				if f == nil {
+					continue
+				}
+
+				ret[s.ID] = &mediaServerScene{
+					Candidate: mediaserver.Candidate{
+						SceneID: s.ID,
+						Path:    f.Path,
+						Size:    f.Size,
+					},
+					Locked: s.Locked,
+					State: mediaserver.State{
+						Played: viewCounts[i] > 0,
+						Rating: mediaserver.ServerRating(s.Rating),
+					},
+				}
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding scenes: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (j *MediaServerSyncJob) syncServer(ctx context.Context, server mediaserver.Server, matcher *mediaserver.Matcher, scenes map[int]*mediaServerScene, state mediaserver.SyncState) (mediaserver.ServerReport, error) {
+	ret := mediaserver.ServerReport{
+		Name: server.Name,
+	}
+
+	client, err := mediaserver.NewClient(server, &http.Client{Timeout: mediaServerTimeout})
+	if err != nil {
+		return ret, err
+	}
+
+	items, err := client.Items(ctx)
+	if err != nil {
+		return ret, err
+	}
+
+	last := state[server.Name]
+	synced := make(map[string]mediaserver.SyncedItem)
+
+	for _, item := range items {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		sceneID := matcher.Match(server, item)
+		s := scenes[sceneID]
+		if s == nil {
+			ret.Unmatched = append(ret.Unmatched, mediaserver.UnmatchedItem{
+				ID:    item.ID,
+				Title: item.Title,
+				Path:  item.Path,
+			})
+			continue
+		}
+
+		ret.Matched++
+
+		var lastState *mediaserver.State
+		if l, ok := last[item.ID]; ok && l.SceneID == sceneID {
+			lastState = &l.State
+		}
+
+		serverState := mediaserver.State{Played: item.Played, Rating: item.Rating}
+		actions := mediaserver.Reconcile(s.State, serverState, lastState)
+
+		// ratings of locked scenes are not changed, and are not synced
+		// until the scene is unlocked
+		if s.Locked && actions.UpdateStashRating {
+			actions.UpdateStashRating = false
+			actions.Synced.Rating = s.State.Rating
+		}
+
+		stashUpdated, serverUpdated, err := j.apply(ctx, client, item, s, actions)
+		if err != nil {
+			logger.Errorf("[mediaserver] error syncing %s with scene %d: %v", item.Title, sceneID, err)
+			// keep the last state, so that the changes are retried
+			if lastState != nil {
+				synced[item.ID] = last[item.ID]
+			}
+			continue
+		}
+
+		if stashUpdated {
+			ret.StashUpdated++
+		}
+		if serverUpdated {
+			ret.ServerUpdated++
+		}
+
+		s.State = actions.Synced
+		synced[item.ID] = mediaserver.SyncedItem{
+			SceneID: sceneID,
+			State:   actions.Synced,
+		}
+	}
+
+	state[server.Name] = synced
+	return ret, nil
+}
+
+// apply applies the actions to the scene and the item. Returns whether the
+// scene and the item were changed.
+func (j *MediaServerSyncJob) apply(ctx context.Context, client mediaserver.Client, item mediaserver.Item, s *mediaServerScene, actions mediaserver.Actions) (stashUpdated bool, serverUpdated bool, err error) {
+	if actions.SetServerPlayed != nil {
+		if err := client.SetPlayed(ctx, item.ID, *actions.SetServerPlayed); err != nil {
+			return false, false, fmt.Errorf("setting played: %w", err)
+		}
+		serverUpdated = true
+	}
+
+	if actions.UpdateServerRating {
+		if err := client.SetRating(ctx, item.ID, actions.Synced.Rating); err != nil {
+			return false, serverUpdated, fmt.Errorf("setting rating: %w", err)
+		}
+		serverUpdated = true
+	}
+
+	if !actions.AddStashPlay && !actions.UpdateStashRating {
+		return false, serverUpdated, nil
+	}
+
+	r := instance.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if actions.AddStashPlay {
+			playedAt := time.Now()
+			if item.LastPlayedAt != nil {
+				playedAt = *item.LastPlayedAt
+			}
+
+			if _, err := r.Scene.AddViews(ctx, s.SceneID, []time.Time{playedAt}); err != nil {
+				return fmt.Errorf("adding play: %w", err)
+			}
+		}
+
+		if actions.UpdateStashRating {
+			partial := models.NewScenePartial()
+			partial.Rating = models.NewOptionalIntPtr(mediaserver.StashRating(actions.Synced.Rating))
+
+			if _, err := r.Scene.UpdatePartial(ctx, s.SceneID, partial); err != nil {
+				return fmt.Errorf("updating rating: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, serverUpdated, err
+	}
+
+	return true, serverUpdated, nil
+}
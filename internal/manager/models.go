@@ -15,6 +15,21 @@ type SystemStatus struct {
 	HomeDir        string           `json:"home_dir"`
 	FfmpegPath     *string          `json:"ffmpegPath"`
 	FfprobePath    *string          `json:"ffprobePath"`
+	FfprobeVersion *string          `json:"ffprobeVersion"`
+	Ffmpeg         *FFMpegStatus    `json:"ffmpeg"`
+	// DatabaseLockedCount is the number of transaction attempts that failed
+	// because the database was locked
+	DatabaseLockedCount int `json:"databaseLockedCount"`
+}
+
+type FFMpegStatus struct {
+	Path             string   `json:"path"`
+	Version          *string  `json:"version"`
+	MinimumVersion   string   `json:"minimumVersion"`
+	VersionSupported bool     `json:"versionSupported"`
+	MissingFeatures  []string `json:"missingFeatures"`
+	HardwareEncoders []string `json:"hardwareEncoders"`
+	Managed          bool     `json:"managed"`
 }
 
 type SetupInput struct {
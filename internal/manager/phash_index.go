@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// phashIndexFilename is the name of the file in the cache directory that the
+// phash index is saved to on shutdown.
+const phashIndexFilename = "phash_index.json"
+
+func (s *Manager) phashIndexPath() string {
+	return filepath.Join(s.Config.GetCachePath(), phashIndexFilename)
+}
+
+// loadPhashIndex loads the phash index saved on shutdown, or builds it from
+// the database if there is none. The saved index is removed once loaded, so
+// that an index that was not saved by a clean shutdown is never used.
+func (s *Manager) loadPhashIndex(ctx context.Context) {
+	if !s.Config.IsPhashIndexEnabled() {
+		logger.Info("Phash index is disabled by the low memory profile")
+		return
+	}
+
+	fn := s.phashIndexPath()
+	data, err := os.ReadFile(fn)
+	if err == nil {
+		var entries []utils.PhashEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			logger.Warnf("Error reading phash index, rebuilding: %v", err)
+		} else {
+			s.PhashIndex.Load(entries)
+			logger.Debugf("Loaded phash index with %d files", len(entries))
+		}
+
+		if err := os.Remove(fn); err != nil {
+			logger.Warnf("Error removing saved phash index: %v", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("Error reading phash index, rebuilding: %v", err)
+	}
+
+	if !s.PhashIndex.Ready() {
+		s.RebuildPhashIndex(ctx)
+	}
+}
+
+// savePhashIndex saves the phash index to the cache directory, so that it
+// does not need to be rebuilt on startup.
+func (s *Manager) savePhashIndex() {
+	if !s.PhashIndex.Ready() {
+		return
+	}
+
+	entries := s.PhashIndex.Entries()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		logger.Errorf("Error saving phash index: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(s.phashIndexPath(), data, 0644); err != nil {
+		logger.Errorf("Error saving phash index: %v", err)
+	}
+}
+
+// RebuildPhashIndex queues a job that rebuilds the phash index from the
+// database.
+func (s *Manager) RebuildPhashIndex(ctx context.Context) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		var hashes []*utils.Phash
+		if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			hashes, err = s.Repository.Scene.FindAllPhashes(ctx)
+			return err
+		}); err != nil {
+			return fmt.Errorf("finding phashes: %w", err)
+		}
+
+		entries := make([]utils.PhashEntry, len(hashes))
+		for i, h := range hashes {
+			entries[i] = utils.PhashEntry{
+				FileID:   h.FileID,
+				SceneID:  h.SceneID,
+				Hash:     h.Hash,
+				Duration: h.Duration,
+			}
+		}
+
+		s.PhashIndex.Load(entries)
+		logger.Infof("Built phash index with %d files", len(entries))
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Building phash index...", j)
+}
+
+// indexPhash adds the phash of a scene file to the phash index, if it has
+// one and the index has been loaded.
+func (s *Manager) indexPhash(sceneID int, f *models.VideoFile) {
+	if !s.PhashIndex.Ready() {
+		// the file is included when the index is built
+		return
+	}
+
+	var hash int64
+	switch v := f.Fingerprints.Get(models.FingerprintTypePhash).(type) {
+	case int64:
+		hash = v
+	case int:
+		hash = int64(v)
+	default:
+		return
+	}
+
+	s.PhashIndex.Set(utils.PhashEntry{
+		FileID:   int(f.ID),
+		SceneID:  sceneID,
+		Hash:     hash,
+		Duration: f.Duration,
+	})
+}
@@ -0,0 +1,168 @@
+package manager
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// recentlyServedTTL is the time after which the scenes served to a session
+// that has not requested a random scene are forgotten.
+const recentlyServedTTL = 24 * time.Hour
+
+// defaultRandomSession is the session of requests without a session or user.
+const defaultRandomSession = "default"
+
+type RandomSceneInput struct {
+	// Scenes to choose from. Defaults to all scenes.
+	SceneFilter *models.SceneFilterType `json:"scene_filter"`
+	// Use the random best rating threshold rather than the random rating
+	// threshold.
+	Best bool `json:"best"`
+	// Session whose recently served scenes are excluded. Defaults to the
+	// current user.
+	Session *string `json:"session"`
+}
+
+// recentlyServed holds the scenes recently served by play random to each
+// session, newest last.
+type recentlyServed struct {
+	mutex    sync.Mutex
+	sessions map[string]*servedScenes
+}
+
+type servedScenes struct {
+	ids      []int
+	lastUsed time.Time
+}
+
+func newRecentlyServed() *recentlyServed {
+	return &recentlyServed{
+		sessions: make(map[string]*servedScenes),
+	}
+}
+
+// pick chooses a random id from candidates that is not one of the last
+// window ids served to the session, and records it as served. If every
+// candidate has been served recently, the ids served longest ago are served
+// again. Returns false if there are no candidates.
+func (r *recentlyServed) pick(session string, candidates []int, window int, now time.Time) (int, bool) {
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for k, s := range r.sessions {
+		if now.Sub(s.lastUsed) > recentlyServedTTL {
+			delete(r.sessions, k)
+		}
+	}
+
+	s := r.sessions[session]
+	if s == nil {
+		s = &servedScenes{}
+		r.sessions[session] = s
+	}
+	s.lastUsed = now
+
+	isCandidate := make(map[int]bool, len(candidates))
+	for _, id := range candidates {
+		isCandidate[id] = true
+	}
+
+	// exclude the most recently served candidates, leaving at least one
+	excluded := make(map[int]bool)
+	for i := len(s.ids) - 1; i >= 0 && len(excluded) < window && len(excluded) < len(isCandidate)-1; i-- {
+		if id := s.ids[i]; isCandidate[id] {
+			excluded[id] = true
+		}
+	}
+
+	var eligible []int
+	for id := range isCandidate {
+		if !excluded[id] {
+			eligible = append(eligible, id)
+		}
+	}
+
+	ret := eligible[rand.Intn(len(eligible))]
+
+	s.ids = append(s.ids, ret)
+	if window > 0 && len(s.ids) > window {
+		s.ids = s.ids[len(s.ids)-window:]
+	}
+
+	return ret, true
+}
+
+// RandomScene returns a random scene rated at least the random rating
+// threshold, that has not recently been served to the session. Scenes hidden
+// by the content gate are not chosen. Returns nil if no scene matches.
+func (s *Manager) RandomScene(ctx context.Context, input RandomSceneInput) (*models.Scene, error) {
+	threshold := s.Config.GetRandomRatingThreshold()
+	if input.Best {
+		threshold = s.Config.GetRandomBestRatingThreshold()
+	}
+
+	sceneFilter := input.SceneFilter
+	if threshold > 0 {
+		sceneFilter = &models.SceneFilterType{
+			OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+				And: input.SceneFilter,
+			},
+			Rating100: &models.IntCriterionInput{
+				// scenes rated at least the threshold
+				Value:    threshold - 1,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}
+	}
+
+	if c := s.GatedTagsCriterion(); c != nil {
+		sceneFilter = &models.SceneFilterType{
+			OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+				And: sceneFilter,
+			},
+			Tags: c,
+		}
+	}
+
+	sessionKey := defaultRandomSession
+	if input.Session != nil && *input.Session != "" {
+		sessionKey = *input.Session
+	} else if userID := session.GetCurrentUserID(ctx); userID != nil {
+		sessionKey = *userID
+	}
+
+	var ret *models.Scene
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		perPage := models.PerPageAll
+		result, err := s.Repository.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: &models.FindFilterType{PerPage: &perPage},
+			},
+			SceneFilter: sceneFilter,
+		})
+		if err != nil {
+			return err
+		}
+
+		id, found := s.randomScenes.pick(sessionKey, result.IDs, s.Config.GetPlayRandomExclusionWindow(), time.Now())
+		if !found {
+			return nil
+		}
+
+		ret, err = s.Repository.Scene.Find(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,56 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentlyServed_pick(t *testing.T) {
+	r := newRecentlyServed()
+	now := time.Now()
+	candidates := []int{1, 2, 3, 4, 5}
+
+	// with a window covering all candidates, every candidate is served once
+	// before any is repeated
+	served := make(map[int]bool)
+	for i := 0; i < len(candidates); i++ {
+		id, found := r.pick("a", candidates, 10, now)
+		assert.True(t, found)
+		assert.False(t, served[id], "scene %d served twice", id)
+		served[id] = true
+	}
+
+	// then the scene served longest ago is served again
+	first := r.sessions["a"].ids[0]
+	id, _ := r.pick("a", candidates, 10, now)
+	assert.Equal(t, first, id)
+
+	// sessions are independent
+	id, _ = r.pick("b", []int{3}, 10, now)
+	assert.Equal(t, 3, id)
+
+	// idle sessions are forgotten
+	r.pick("b", []int{3}, 10, now.Add(recentlyServedTTL+time.Minute))
+	assert.NotContains(t, r.sessions, "a")
+
+	_, found := r.pick("a", nil, 10, now)
+	assert.False(t, found)
+}
+
+func TestRecentlyServed_pickWindow(t *testing.T) {
+	r := newRecentlyServed()
+	now := time.Now()
+	candidates := []int{1, 2, 3}
+
+	// a window of one only prevents consecutive repeats
+	last := 0
+	for i := 0; i < 20; i++ {
+		id, _ := r.pick("a", candidates, 1, now)
+		assert.NotEqual(t, last, id)
+		last = id
+	}
+
+	assert.Len(t, r.sessions["a"].ids, 1)
+}
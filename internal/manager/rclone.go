@@ -0,0 +1,292 @@
+package manager
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/rclone"
+)
+
+// rcloneCacheSize is the maximum size of the cache of streamed chunks of
+// files in rclone stashes.
+const rcloneCacheSize = 1 << 30 // 1GiB
+
+// remoteStashes holds the rclone servers and file systems of the rclone
+// stashes. Servers are started when a stash is first used.
+type remoteStashes struct {
+	mutex   sync.Mutex
+	servers map[string]*rclone.Server
+	fs      map[string]*rclone.FS
+	health  map[string]file.PathHealth
+}
+
+func newRemoteStashes() *remoteStashes {
+	return &remoteStashes{
+		servers: make(map[string]*rclone.Server),
+		fs:      make(map[string]*rclone.FS),
+		health:  make(map[string]file.PathHealth),
+	}
+}
+
+// find returns the started file system containing the file path, or nil if
+// there is none.
+func (r *remoteStashes) find(name string) *rclone.FS {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for root, f := range r.fs {
+		if fsutil.IsPathInDir(root, name) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// status returns the result of the last listing of the stash. Stashes that
+// have not been listed are assumed to be online.
+func (r *remoteStashes) status(root string) file.PathHealth {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	h, found := r.health[root]
+	if !found {
+		h = file.PathHealth{
+			Path:   root,
+			Online: true,
+		}
+	}
+
+	return h
+}
+
+func (r *remoteStashes) setStatus(root string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	h := file.PathHealth{
+		Path:      root,
+		Online:    err == nil,
+		CheckedAt: &now,
+	}
+	if err != nil {
+		h.Error = err.Error()
+	}
+
+	r.health[root] = h
+}
+
+func (r *remoteStashes) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for root, s := range r.servers {
+		s.Close()
+		delete(r.servers, root)
+		delete(r.fs, root)
+	}
+}
+
+// remoteFS returns the file system of the rclone stash with the given root,
+// starting its server if it is not running.
+func (s *Manager) remoteFS(root string) (*rclone.FS, error) {
+	r := s.remotes
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if server := r.servers[root]; server != nil {
+		if server.Running() {
+			return r.fs[root], nil
+		}
+
+		logger.Warnf("rclone server for %s stopped, restarting", root)
+	}
+
+	client, err := rclone.Resolve(s.Config.GetRclonePath())
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := client.Serve(root)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := rclone.NewChunkCache(filepath.Join(s.Config.GetCachePath(), "rclone"), rcloneCacheSize)
+	f := rclone.NewFS(root, server.URL, cache)
+
+	r.servers[root] = server
+	r.fs[root] = f
+	return f, nil
+}
+
+// refreshRemoteStashes lists the files of the rclone stashes containing the
+// scan paths. It returns the scan paths without those in stashes that could
+// not be listed.
+func (s *Manager) refreshRemoteStashes(ctx context.Context, paths []string) []string {
+	remotes := s.Config.GetStashPaths().Remotes()
+	if len(remotes) == 0 {
+		return paths
+	}
+
+	client, resolveErr := rclone.Resolve(s.Config.GetRclonePath())
+
+	var failed []string
+	for _, stash := range remotes {
+		root := stash.Path
+		if !containsAny(root, paths) {
+			continue
+		}
+
+		err := resolveErr
+		if err == nil {
+			var f *rclone.FS
+			f, err = s.remoteFS(root)
+			if err == nil {
+				logger.Infof("Listing rclone stash %s", root)
+				err = f.Refresh(ctx, client)
+			}
+		}
+
+		s.remotes.setStatus(root, err)
+		if err != nil {
+			logger.Errorf("Error listing rclone stash %s, skipping: %v", root, err)
+			failed = append(failed, root)
+		}
+	}
+
+	var ret []string
+	for _, p := range paths {
+		if !fsutil.IsPathInDirs(failed, p) {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
+// containsAny returns true if any of the paths are within root.
+func containsAny(root string, paths []string) bool {
+	for _, p := range paths {
+		if fsutil.IsPathInDir(root, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// stashFS returns a file system that reads files in rclone stashes from
+// their remotes, and other files from the OS. If streaming is true, files in
+// rclone stashes are opened for streaming, caching the parts that are read.
+func (s *Manager) stashFS(streaming bool) models.FS {
+	return &stashFS{
+		FS:        file.NewRetryFS(&file.OsFS{}),
+		manager:   s,
+		streaming: streaming,
+	}
+}
+
+// StreamFS returns the file system used to serve files to clients.
+func (s *Manager) StreamFS() models.FS {
+	return s.stashFS(true)
+}
+
+type stashFS struct {
+	models.FS
+	manager   *Manager
+	streaming bool
+}
+
+// remote returns the file system of the rclone stash containing name, or nil
+// if name is not in an rclone stash.
+func (f *stashFS) remote(name string) (*rclone.FS, error) {
+	if ret := f.manager.remotes.find(name); ret != nil {
+		return ret, nil
+	}
+
+	// start the server of a stash that has not been used since startup
+	for _, stash := range f.manager.Config.GetStashPaths().Remotes() {
+		if fsutil.IsPathInDir(stash.Path, name) {
+			return f.manager.remoteFS(stash.Path)
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *stashFS) Stat(name string) (fs.FileInfo, error) {
+	r, err := f.remote(name)
+	switch {
+	case err != nil:
+		return nil, err
+	case r == nil:
+		return f.FS.Stat(name)
+	case f.streaming:
+		return statFile(r.OpenCached(name))
+	default:
+		return r.Stat(name)
+	}
+}
+
+func statFile(file fs.ReadDirFile, err error) (fs.FileInfo, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return file.Stat()
+}
+
+func (f *stashFS) Lstat(name string) (fs.FileInfo, error) {
+	r, err := f.remote(name)
+	switch {
+	case err != nil:
+		return nil, err
+	case r == nil:
+		return f.FS.Lstat(name)
+	case f.streaming:
+		return statFile(r.OpenCached(name))
+	default:
+		return r.Lstat(name)
+	}
+}
+
+func (f *stashFS) Open(name string) (fs.ReadDirFile, error) {
+	r, err := f.remote(name)
+	switch {
+	case err != nil:
+		return nil, err
+	case r == nil:
+		return f.FS.Open(name)
+	case f.streaming:
+		return r.OpenCached(name)
+	default:
+		return r.Open(name)
+	}
+}
+
+func (f *stashFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return file.OpenZip(f, name, size)
+}
+
+// ProbePath returns the path of local files, and the URL of files in rclone
+// stashes.
+func (f *stashFS) ProbePath(name string) (string, bool) {
+	r, err := f.remote(name)
+	switch {
+	case err != nil:
+		return "", false
+	case r == nil:
+		return file.ProbePath(f.FS, name)
+	default:
+		return r.ProbePath(name)
+	}
+}
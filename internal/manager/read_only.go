@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// IsReadOnlyPath returns true if path is in a stash path marked as read-only.
+func (s *Manager) IsReadOnlyPath(path string) bool {
+	return s.Config.GetStashPaths().IsReadOnlyPath(path)
+}
+
+// ValidateWritable returns a *file.ReadOnlyPathError if any of the paths is
+// in a read-only stash path.
+func (s *Manager) ValidateWritable(paths ...string) error {
+	for _, p := range paths {
+		if s.IsReadOnlyPath(p) {
+			return &file.ReadOnlyPathError{Path: p}
+		}
+	}
+
+	return nil
+}
+
+// NewFileDeleter returns a file deleter that refuses to delete files in
+// read-only stash paths.
+func (s *Manager) NewFileDeleter() *file.Deleter {
+	d := file.NewDeleter()
+	d.ReadOnly = s
+	return d
+}
+
+// NewFileMover returns a file mover that refuses to move files from or to
+// read-only stash paths.
+func (s *Manager) NewFileMover(fileStore models.FileFinderUpdater, folderStore models.FolderReaderWriter) *file.Mover {
+	m := file.NewMover(fileStore, folderStore)
+	m.ReadOnly = s
+	return m
+}
@@ -0,0 +1,436 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+var ErrNoReencodePlan = errors.New("no re-encode plan has been made")
+
+const (
+	// MaxReencodeSamples is the maximum number of segments of each file
+	// encoded when planning a re-encode.
+	MaxReencodeSamples = 5
+	// reencodeSampleDuration is the length in seconds of each sample.
+	reencodeSampleDuration = 5
+)
+
+// reencodePlans holds the last plan of files to re-encode.
+type reencodePlans struct {
+	mutex sync.Mutex
+	last  *scene.ReencodePlan
+}
+
+func (r *reencodePlans) get() *scene.ReencodePlan {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *reencodePlans) set(plan *scene.ReencodePlan) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = plan
+}
+
+// ReencodePlan returns the last plan of files to re-encode, or nil if no
+// plan has been made.
+func (s *Manager) ReencodePlan() *scene.ReencodePlan {
+	return s.reencodePlans.get()
+}
+
+// PlanReencode starts a job that estimates the size of the primary files of
+// the scenes matching the filter once re-encoded to the target, and stores
+// the plan of the files worth re-encoding. If samples is not zero, that
+// many short segments of each file are encoded to measure the bitrate and
+// speed of the target, otherwise they are estimated. No files are changed.
+func (s *Manager) PlanReencode(ctx context.Context, target ffmpeg.ReencodeTarget, sceneFilter *models.SceneFilterType, samples int) (int, error) {
+	if err := target.Validate(); err != nil {
+		return 0, err
+	}
+
+	if samples < 0 || samples > MaxReencodeSamples {
+		return 0, fmt.Errorf("samples must be between 0 and %d", MaxReencodeSamples)
+	}
+
+	j := &PlanReencodeJob{
+		Target:      target,
+		SceneFilter: sceneFilter,
+		Samples:     samples,
+	}
+
+	return s.JobManager.Add(ctx, "Planning re-encode...", j), nil
+}
+
+// ApplyReencodePlan starts a job that re-encodes the files of the last
+// plan. If ids is not nil, only those estimates are re-encoded.
+func (s *Manager) ApplyReencodePlan(ctx context.Context, ids []int) (int, error) {
+	plan := s.reencodePlans.get()
+	if plan == nil {
+		return 0, ErrNoReencodePlan
+	}
+
+	var selected map[int]bool
+	if ids != nil {
+		selected = make(map[int]bool)
+		for _, id := range ids {
+			selected[id] = true
+		}
+	}
+
+	var estimates []scene.ReencodeEstimate
+	for _, e := range plan.Estimates {
+		if selected == nil || selected[e.ID] {
+			estimates = append(estimates, e)
+		}
+	}
+
+	if len(estimates) == 0 {
+		return 0, errors.New("no files to re-encode")
+	}
+
+	j := &ApplyReencodePlanJob{
+		Target:    plan.Target,
+		Estimates: estimates,
+	}
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Re-encoding %d files to %s...", len(estimates), plan.Target), j), nil
+}
+
+// PlanReencodeJob estimates the re-encoding of the primary files of the
+// scenes matching the filter.
+type PlanReencodeJob struct {
+	Target      ffmpeg.ReencodeTarget
+	SceneFilter *models.SceneFilterType
+	Samples     int
+}
+
+func (j *PlanReencodeJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+
+	candidates, err := j.findCandidates(ctx)
+	if err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+
+	samples := j.Samples
+	if samples > 0 && mgr.FFMpeg == nil {
+		logger.Warn("ffmpeg is not available, re-encode sizes are estimated without samples")
+		samples = 0
+	}
+
+	// files are sampled outside of a transaction
+	if samples > 0 {
+		stashPaths := mgr.Config.GetStashPaths()
+
+		progress.SetTotal(len(candidates))
+		for i := range candidates {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			c := &candidates[i]
+
+			// files in rclone stashes are too slow to sample
+			if !stashPaths.IsRemotePath(c.File.Path) && !j.Target.Unchanged(c.File.VideoCodec, c.File.Height) {
+				progress.ExecuteTask(fmt.Sprintf("Sampling %s", c.File.Path), func() {
+					var err error
+					c.Samples, err = j.sample(ctx, c.File, samples)
+					if err != nil {
+						logger.Warnf("Error sampling %s: %v", c.File.Path, err)
+					}
+				})
+			}
+
+			progress.Increment()
+		}
+	}
+
+	plan := scene.NewReencodePlan(j.Target, candidates, time.Now())
+	mgr.reencodePlans.set(plan)
+
+	logger.Infof("Planned re-encode of %d files to %s, saving %d bytes", len(plan.Estimates), j.Target, plan.Savings())
+	return nil
+}
+
+// findCandidates returns the primary files of the scenes matching the
+// filter.
+func (j *PlanReencodeJob) findCandidates(ctx context.Context) ([]scene.ReencodeCandidate, error) {
+	const batchSize = 1000
+
+	r := instance.Repository
+
+	var ret []scene.ReencodeCandidate
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, j.SceneFilter, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+					return err
+				}
+
+				f := s.Files.Primary()
+				if f == nil {
+					continue
+				}
+
+				ret = append(ret, scene.ReencodeCandidate{
+					SceneID: s.ID,
+					File:    f,
+				})
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// sample encodes count segments spread evenly through the file, returning
+// the size and encoding time of each. Files too short to sample return no
+// samples.
+func (j *PlanReencodeJob) sample(ctx context.Context, f *models.VideoFile, count int) ([]scene.ReencodeSample, error) {
+	mgr := instance
+
+	duration := f.DurationFinite()
+	if duration < float64(count*reencodeSampleDuration) {
+		return nil, nil
+	}
+
+	videoFile, err := mgr.FFProbe.NewVideoFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading video file: %w", err)
+	}
+
+	output := filepath.Join(mgr.Config.GetTempPath(), fmt.Sprintf("reencode_sample_%d.mp4", f.ID))
+	defer os.Remove(output)
+
+	var ret []scene.ReencodeSample
+	for i := 0; i < count; i++ {
+		start := duration*float64(i+1)/float64(count+1) - reencodeSampleDuration/2.0
+		args := j.Target.SampleArgs(videoFile, max(start, 0), reencodeSampleDuration, output)
+
+		began := time.Now()
+		if err := mgr.FFMpeg.Command(ctx, args).Run(); err != nil {
+			return nil, fmt.Errorf("encoding sample at %.2fs: %w", start, err)
+		}
+		elapsed := time.Since(began)
+
+		info, err := os.Stat(output)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, scene.ReencodeSample{
+			Duration: reencodeSampleDuration,
+			Size:     info.Size(),
+			Elapsed:  elapsed,
+		})
+	}
+
+	return ret, nil
+}
+
+// ApplyReencodePlanJob re-encodes the file of each estimate, one at a time.
+type ApplyReencodePlanJob struct {
+	Target    ffmpeg.ReencodeTarget
+	Estimates []scene.ReencodeEstimate
+}
+
+func (j *ApplyReencodePlanJob) Execute(ctx context.Context, progress *job.Progress) error {
+	progress.SetTotal(len(j.Estimates))
+
+	for _, e := range j.Estimates {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Re-encoding %s", e.Path), func() {
+			err := j.apply(ctx, e)
+			if err != nil {
+				logger.Errorf("Error re-encoding %s: %v", e.Path, err)
+			}
+			progress.ItemDone(strconv.Itoa(e.ID), err)
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (j *ApplyReencodePlanJob) apply(ctx context.Context, e scene.ReencodeEstimate) error {
+	r := instance.Repository
+
+	var s *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.Scene.Find(ctx, e.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return nil
+		}
+
+		return s.LoadFiles(ctx, r.Scene)
+	}); err != nil {
+		return err
+	}
+
+	// the scene or file may have been deleted since the plan was made
+	if s == nil {
+		return fmt.Errorf("scene %d not found", e.SceneID)
+	}
+
+	var f *models.VideoFile
+	for _, vf := range s.Files.List() {
+		if vf.ID == e.FileID {
+			f = vf
+			break
+		}
+	}
+	if f == nil {
+		return fmt.Errorf("file %d not found in scene %d", e.FileID, e.SceneID)
+	}
+
+	// the file may have been re-encoded since
+	if j.Target.Unchanged(f.VideoCodec, f.Height) {
+		logger.Infof("[reencode] %s is already %s", f.Path, j.Target)
+		return nil
+	}
+
+	t := &ReencodeTask{
+		TrimVideoTask: *newTrimVideoTask(s, f),
+		Target:        j.Target,
+	}
+
+	return t.Execute(ctx, &job.Progress{})
+}
+
+// Retry returns a job that re-encodes the files of the estimates with the
+// given ids again.
+func (j *ApplyReencodePlanJob) Retry(ids []string) job.JobExec {
+	retry := make(map[string]bool)
+	for _, id := range ids {
+		retry[id] = true
+	}
+
+	var estimates []scene.ReencodeEstimate
+	for _, e := range j.Estimates {
+		if retry[strconv.Itoa(e.ID)] {
+			estimates = append(estimates, e)
+		}
+	}
+
+	return &ApplyReencodePlanJob{
+		Target:    j.Target,
+		Estimates: estimates,
+	}
+}
+
+// newReplaceGenerator returns the generator regenerating the content of
+// scenes whose video files are replaced.
+func newReplaceGenerator() *generate.Generator {
+	mgr := instance
+	return &generate.Generator{
+		Encoder:      mgr.FFMpeg,
+		FFMpegConfig: mgr.Config,
+		LockManager:  mgr.ReadLockManager,
+		MarkerPaths:  mgr.Paths.SceneMarkers,
+		ScenePaths:   mgr.Paths.Scene,
+		Overwrite:    true,
+	}
+}
+
+// newTrimVideoTask returns a task that replaces the video file of the scene.
+func newTrimVideoTask(s *models.Scene, f *models.VideoFile) *TrimVideoTask {
+	mgr := instance
+	return &TrimVideoTask{
+		Scene:                 *s,
+		FileID:                f.ID,
+		FileNamingAlgorithm:   mgr.Config.GetVideoFileNamingAlgorithm(),
+		G:                     newReplaceGenerator(),
+		FFMpeg:                mgr.FFMpeg,
+		FFProbe:               mgr.FFProbe,
+		Config:                mgr.Config,
+		Paths:                 mgr.Paths,
+		Repository:            mgr.Repository,
+		FingerprintCalculator: &FingerprintCalculator{Config: mgr.Config},
+	}
+}
+
+// ReencodeTask re-encodes a scene video file to the target encoding. The
+// re-encoded file replaces the original in the same way as a trimmed file.
+type ReencodeTask struct {
+	TrimVideoTask
+
+	Target ffmpeg.ReencodeTarget
+}
+
+func (t *ReencodeTask) GetDescription() string {
+	return fmt.Sprintf("Re-encoding %s to %s", t.Scene.Path, t.Target)
+}
+
+func (t *ReencodeTask) Execute(ctx context.Context, progress *job.Progress) error {
+	t.edit = t
+	return t.TrimVideoTask.Execute(ctx, progress)
+}
+
+func (t *ReencodeTask) description() string {
+	return fmt.Sprintf("Re-encoding to %s", t.Target)
+}
+
+func (t *ReencodeTask) apply(ctx context.Context, inputPath, outputPath string, progress *job.Progress) error {
+	videoFile, err := t.FFProbe.NewVideoFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("error reading video file: %w", err)
+	}
+
+	logger.Infof("[reencode] re-encoding %s to %s", inputPath, t.Target)
+
+	args := t.Target.Args(videoFile, outputPath)
+
+	progress.SetPercent(0)
+
+	cmd := t.FFMpeg.Command(ctx, args)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg re-encode failed: %w", err)
+	}
+
+	progress.SetPercent(1)
+	return nil
+}
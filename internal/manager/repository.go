@@ -12,8 +12,12 @@ import (
 type SceneService interface {
 	Create(ctx context.Context, input *models.Scene, fileIDs []models.FileID, coverImage []byte) (*models.Scene, error)
 	AssignFile(ctx context.Context, sceneID int, fileID models.FileID) error
+	SetExtra(ctx context.Context, sceneID int, fileID models.FileID, role models.SceneExtraRole) error
+	RemoveExtra(ctx context.Context, fileID models.FileID) error
 	Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *scene.FileDeleter, options scene.MergeOptions) error
 	Destroy(ctx context.Context, scene *models.Scene, fileDeleter *scene.FileDeleter, deleteGenerated, deleteFile bool) error
+	FindRelinkMatch(ctx context.Context, s *models.Scene, options scene.RelinkOptions) (*scene.RelinkMatch, error)
+	Relink(ctx context.Context, sceneID int, fileID models.FileID, fileDeleter *scene.FileDeleter) error
 
 	FindByIDs(ctx context.Context, ids []int, load ...scene.LoadRelationshipOption) ([]*models.Scene, error)
 	sceneFingerprintGetter
@@ -30,6 +34,7 @@ type GalleryService interface {
 
 	SetCover(ctx context.Context, g *models.Gallery, coverImageId int) error
 	ResetCover(ctx context.Context, g *models.Gallery) error
+	ReorderImages(ctx context.Context, g *models.Gallery, imageIDs []int, insertPointID int, insertAfter bool) error
 
 	Destroy(ctx context.Context, i *models.Gallery, fileDeleter *image.FileDeleter, deleteGenerated, deleteFile bool) ([]*models.Image, error)
 
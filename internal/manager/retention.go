@@ -0,0 +1,396 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/retention"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// retentionCheckInterval is how often it is checked whether a scheduled
+// retention report is due.
+const retentionCheckInterval = time.Hour
+
+var ErrNoRetentionReport = errors.New("no retention report has been generated")
+
+// retentionReports holds the last retention report, which is the list of
+// scenes that are deleted or archived when retention is applied.
+type retentionReports struct {
+	mutex sync.Mutex
+	last  *retention.Report
+}
+
+func (r *retentionReports) get() *retention.Report {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *retentionReports) set(report *retention.Report) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = report
+}
+
+// RetentionReport returns the last retention report, or nil if no report has
+// been generated.
+func (s *Manager) RetentionReport() *retention.Report {
+	return s.retention.get()
+}
+
+// RunRetentionReport evaluates the retention rules against the library and
+// stores the result as the last report. No files are changed. Locked scenes
+// are only evaluated if includeLocked is true.
+func (s *Manager) RunRetentionReport(ctx context.Context, includeLocked bool) (*retention.Report, error) {
+	rules := s.Config.GetRetentionRules()
+
+	var candidates []retention.Candidate
+	if len(rules) > 0 {
+		var err error
+		candidates, err = s.retentionCandidates(ctx, nil, includeLocked)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := retention.Evaluate(rules, candidates, time.Now())
+	report.IncludeLocked = includeLocked
+	s.retention.set(report)
+
+	logger.Infof("[retention] %d scenes match retention rules, %s reclaimable", len(report.Items), utils.FormatBytes(report.ReclaimableBytes))
+	return report, nil
+}
+
+// retentionCandidates returns the scenes that retention rules are evaluated
+// against. If sceneIDs is nil, all scenes with files are returned. Locked
+// scenes are excluded unless includeLocked is true.
+func (s *Manager) retentionCandidates(ctx context.Context, sceneIDs []int, includeLocked bool) ([]retention.Candidate, error) {
+	const batchSize = 1000
+
+	r := s.Repository
+	stashPaths := s.Config.GetStashPaths()
+
+	var ret []retention.Candidate
+	add := func(ctx context.Context, scenes []*models.Scene) error {
+		ids := make([]int, len(scenes))
+		for i, ss := range scenes {
+			ids[i] = ss.ID
+		}
+
+		lastViewed, err := r.Scene.GetManyLastViewed(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("getting last viewed dates: %w", err)
+		}
+
+		for i, ss := range scenes {
+			if ss.Locked && !includeLocked {
+				continue
+			}
+
+			if err := ss.LoadFiles(ctx, r.Scene); err != nil {
+				return err
+			}
+
+			c := retention.Candidate{
+				SceneID:      ss.ID,
+				Rating:       ss.Rating,
+				LastPlayedAt: lastViewed[i],
+				CreatedAt:    ss.CreatedAt,
+			}
+
+			for _, f := range ss.Files.List() {
+				// files in read-only or rclone stashes cannot be removed
+				if s.IsReadOnlyPath(f.Path) || stashPaths.IsRemotePath(f.Path) {
+					c.Paths = nil
+					break
+				}
+
+				c.Paths = append(c.Paths, f.Path)
+				c.Size += f.Size
+			}
+
+			if len(c.Paths) > 0 {
+				ret = append(ret, c)
+			}
+		}
+
+		return nil
+	}
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		if sceneIDs != nil {
+			scenes, err := r.Scene.FindByIDs(ctx, sceneIDs)
+			if err != nil {
+				return err
+			}
+			return add(ctx, scenes)
+		}
+
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, nil, findFilter)
+			if err != nil {
+				return err
+			}
+
+			if err := add(ctx, scenes); err != nil {
+				return err
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding retention candidates: %w", err)
+	}
+
+	return ret, nil
+}
+
+// ApplyRetention starts a job that deletes or archives the files of the
+// scenes in the last retention report. If sceneIDs is not nil, only those
+// scenes of the report are processed. Each scene is checked against the
+// current rules again before its files are removed.
+func (s *Manager) ApplyRetention(ctx context.Context, sceneIDs []int) (int, error) {
+	report := s.retention.get()
+	if report == nil {
+		return 0, ErrNoRetentionReport
+	}
+
+	items := report.Items
+	if sceneIDs != nil {
+		selected := make(map[int]bool)
+		for _, id := range sceneIDs {
+			selected[id] = true
+		}
+
+		items = nil
+		for _, item := range report.Items {
+			if selected[item.SceneID] {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if len(items) == 0 {
+		return 0, errors.New("no scenes to apply retention to")
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.SceneID
+	}
+
+	j := &RetentionJob{
+		SceneIDs:      ids,
+		IncludeLocked: report.IncludeLocked,
+	}
+
+	return s.JobManager.Add(ctx, "Applying retention rules...", j), nil
+}
+
+// monitorRetention periodically generates a retention report in the
+// background, at the configured interval.
+func (s *Manager) monitorRetention() {
+	go func() {
+		ticker := time.NewTicker(retentionCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			interval := s.Config.GetRetentionReportInterval()
+			if interval <= 0 || s.Config.IsNewSystem() || len(s.Config.GetRetentionRules()) == 0 {
+				continue
+			}
+
+			if last := s.retention.get(); last != nil && time.Since(last.GeneratedAt) < interval {
+				continue
+			}
+
+			if _, err := s.RunRetentionReport(context.Background(), false); err != nil {
+				logger.Errorf("[retention] error generating retention report: %v", err)
+			}
+		}
+	}()
+}
+
+// RetentionJob deletes or archives the files of scenes that match the
+// retention rules, recording each action in the retention audit log.
+type RetentionJob struct {
+	SceneIDs      []int
+	IncludeLocked bool
+}
+
+func (j *RetentionJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := instance
+	rules := mgr.Config.GetRetentionRules()
+	archivePath := mgr.Config.GetRetentionArchivePath()
+	audit := retention.NewAuditLog(mgr.Config.GetRetentionAuditLogPath())
+
+	// re-evaluate the scenes, in case they have been played or rated since
+	// the report was generated
+	candidates, err := mgr.retentionCandidates(ctx, j.SceneIDs, j.IncludeLocked)
+	if err != nil {
+		return err
+	}
+
+	report := retention.Evaluate(rules, candidates, time.Now())
+	progress.SetTotal(len(j.SceneIDs))
+
+	matched := make(map[int]bool)
+	for _, item := range report.Items {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		matched[item.SceneID] = true
+
+		progress.ExecuteTask(fmt.Sprintf("Applying retention to scene %d", item.SceneID), func() {
+			err := j.apply(ctx, item, archivePath, audit)
+			if err != nil {
+				logger.Errorf("[retention] scene %d: %v", item.SceneID, err)
+			}
+			progress.ItemDone(strconv.Itoa(item.SceneID), err)
+		})
+
+		progress.Increment()
+	}
+
+	for _, id := range j.SceneIDs {
+		if !matched[id] {
+			logger.Infof("[retention] skipping scene %d, which no longer matches the retention rules", id)
+			progress.Increment()
+		}
+	}
+
+	return nil
+}
+
+func (j *RetentionJob) apply(ctx context.Context, item retention.Item, archivePath string, audit *retention.AuditLog) error {
+	mgr := instance
+	r := mgr.Repository
+
+	var s *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.Scene.Find(ctx, item.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", item.SceneID)
+		}
+		return s.LoadFiles(ctx, r.Scene)
+	}); err != nil {
+		return err
+	}
+
+	fileNamingAlgo := mgr.Config.GetVideoFileNamingAlgorithm()
+	KillRunningStreams(s, fileNamingAlgo)
+
+	entry := func(path string, size int64) retention.AuditEntry {
+		return retention.AuditEntry{
+			Time:    time.Now(),
+			SceneID: item.SceneID,
+			Path:    path,
+			Rule:    item.Rule,
+			Action:  item.Action,
+			Size:    size,
+		}
+	}
+
+	writeAudit := func(e retention.AuditEntry, err error) {
+		if err != nil {
+			e.Error = err.Error()
+		}
+		if auditErr := audit.Write(e); auditErr != nil {
+			logger.Errorf("[retention] %v", auditErr)
+		}
+	}
+
+	// archived files are moved before the scene is removed, so that the
+	// scene is kept if any of them cannot be moved
+	deleteFile := item.Action == retention.ActionDelete
+	if item.Action == retention.ActionArchive {
+		if archivePath == "" {
+			return errors.New("retention archive path is not set")
+		}
+
+		for _, f := range s.Files.List() {
+			e := entry(f.Path, f.Size)
+			dest, err := retention.Archive(archivePath, f.Path)
+			e.Destination = dest
+			writeAudit(e, err)
+			if err != nil {
+				return fmt.Errorf("archiving %s: %w", f.Path, err)
+			}
+
+			logger.Infof("[retention] archived %s to %s (rule %q)", f.Path, dest, item.Rule)
+		}
+	}
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        mgr.NewFileDeleter(),
+		FileNamingAlgo: fileNamingAlgo,
+		Paths:          mgr.Paths,
+	}
+
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return mgr.SceneService.Destroy(ctx, s, fileDeleter, true, deleteFile)
+	})
+	if err != nil {
+		fileDeleter.Rollback()
+	} else {
+		fileDeleter.Commit()
+	}
+
+	if deleteFile {
+		for _, f := range s.Files.List() {
+			writeAudit(entry(f.Path, f.Size), err)
+			if err == nil {
+				logger.Infof("[retention] deleted %s (rule %q)", f.Path, item.Rule)
+			}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("destroying scene: %w", err)
+	}
+
+	return nil
+}
+
+// Retry returns a job that applies retention to the scenes with the given
+// ids again.
+func (j *RetentionJob) Retry(ids []string) job.JobExec {
+	var sceneIDs []int
+	for _, id := range ids {
+		if i, err := strconv.Atoi(id); err == nil {
+			sceneIDs = append(sceneIDs, i)
+		}
+	}
+
+	return &RetentionJob{
+		SceneIDs:      sceneIDs,
+		IncludeLocked: j.IncludeLocked,
+	}
+}
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/reverseimage"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+// reverseImageSearchTimeout is the timeout for the request to each reverse
+// image search provider.
+const reverseImageSearchTimeout = 60 * time.Second
+
+type ReverseImageSearchInput struct {
+	// Performer whose image is searched
+	PerformerID *string `json:"performer_id"`
+	// Gallery whose cover is searched
+	GalleryID *string `json:"gallery_id"`
+	// Names of the providers to search. Defaults to all providers.
+	Providers []string `json:"providers"`
+}
+
+// ReverseImageMatch is a page found by reverse image search, with the
+// scrapers that can scrape the performer or gallery from the page.
+type ReverseImageMatch struct {
+	reverseimage.Match
+	ScraperIDs []string `json:"scraper_ids"`
+}
+
+// ReverseImageSearch searches the reverse image search providers for pages
+// showing the image of a performer or the cover of a gallery. The pages can
+// be scraped with the returned scrapers, in the same way as the results of
+// a fingerprint search of a scene.
+func (s *Manager) ReverseImageSearch(ctx context.Context, input ReverseImageSearchInput) ([]*ReverseImageMatch, error) {
+	providers, err := s.reverseImageSearchProviders(input.Providers)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		data []byte
+		ty   scraper.ScrapeContentType
+	)
+
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		switch {
+		case input.PerformerID != nil:
+			ty = scraper.ScrapeContentTypePerformer
+			data, err = s.performerSearchImage(ctx, *input.PerformerID)
+		case input.GalleryID != nil:
+			ty = scraper.ScrapeContentTypeGallery
+			data, err = s.gallerySearchImage(ctx, *input.GalleryID)
+		default:
+			err = errors.New("one of performer_id or gallery_id must be set")
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	client := reverseimage.Client{
+		HTTPClient: &http.Client{Timeout: reverseImageSearchTimeout},
+		UserAgent:  s.Config.GetScraperUserAgent(),
+	}
+
+	matches, err := client.Search(ctx, providers, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*ReverseImageMatch, len(matches))
+	for i, m := range matches {
+		ret[i] = &ReverseImageMatch{
+			Match:      *m,
+			ScraperIDs: s.ScraperCache.URLScrapers(m.URL, ty),
+		}
+	}
+
+	return ret, nil
+}
+
+// reverseImageSearchProviders returns the providers with the given names, or
+// all providers if names is empty.
+func (s *Manager) reverseImageSearchProviders(names []string) ([]reverseimage.Provider, error) {
+	providers := s.Config.GetReverseImageSearchProviders()
+	if len(names) == 0 {
+		return providers, nil
+	}
+
+	byName := make(map[string]reverseimage.Provider)
+	for _, p := range providers {
+		byName[p.Name] = p
+	}
+
+	var ret []reverseimage.Provider
+	for _, n := range names {
+		p, found := byName[n]
+		if !found {
+			return nil, fmt.Errorf("reverse image search provider %q not found", n)
+		}
+		ret = append(ret, p)
+	}
+
+	return ret, nil
+}
+
+func (s *Manager) performerSearchImage(ctx context.Context, performerID string) ([]byte, error) {
+	id, err := strconv.Atoi(performerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	data, err := s.Repository.Performer.GetImage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting performer image: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("performer with id %d has no image", id)
+	}
+
+	return data, nil
+}
+
+// gallerySearchImage returns the thumbnail of the gallery cover if it has
+// been generated, otherwise the cover image itself.
+func (s *Manager) gallerySearchImage(ctx context.Context, galleryID string) ([]byte, error) {
+	id, err := strconv.Atoi(galleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	r := s.Repository
+	cover, err := image.FindGalleryCover(ctx, r.Image, id, s.Config.GetGalleryCoverRegex())
+	if err != nil {
+		return nil, fmt.Errorf("finding gallery cover: %w", err)
+	}
+
+	if cover == nil {
+		return nil, fmt.Errorf("gallery with id %d has no cover", id)
+	}
+
+	thumbPath := s.Paths.Generated.GetThumbnailPath(cover.Checksum, models.DefaultGthumbWidth)
+	if exists, _ := fsutil.FileExists(thumbPath); exists {
+		return os.ReadFile(thumbPath)
+	}
+
+	if err := cover.LoadPrimaryFile(ctx, r.File); err != nil {
+		return nil, fmt.Errorf("loading cover file: %w", err)
+	}
+
+	// clips cannot be searched
+	f, ok := cover.Files.Primary().(*models.ImageFile)
+	if !ok {
+		return nil, fmt.Errorf("cover of gallery with id %d is not an image", id)
+	}
+
+	reader, err := f.Open(file.NewRetryFS(&file.OsFS{}))
+	if err != nil {
+		return nil, fmt.Errorf("opening cover file: %w", err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
@@ -3,6 +3,8 @@ package manager
 import (
 	"context"
 	"errors"
+	"io"
+	"io/fs"
 	"net/http"
 	"strconv"
 	"strings"
@@ -131,7 +133,47 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	// We trust that the request context will be closed, so we don't need to call Cancel on the
 	// returned context here.
 	_ = GetInstance().ReadLockManager.ReadLock(streamRequestCtx, filepath)
-	http.ServeFile(w, r, filepath)
+	serveStreamFile(w, r, filepath)
+}
+
+// StreamExtraDirect serves the file of a scene extra.
+func (s *SceneServer) StreamExtraDirect(f *models.VideoFile, w http.ResponseWriter, r *http.Request) {
+	streamRequestCtx := ffmpeg.NewStreamRequestContext(w, r)
+	_ = GetInstance().ReadLockManager.ReadLock(streamRequestCtx, f.Path)
+	serveStreamFile(w, r, f.Path)
+}
+
+// serveStreamFile serves the file at path, retrying reads that fail with
+// transient errors so that playback from a network mount survives brief
+// outages. Files in rclone stashes are read from their remote.
+func serveStreamFile(w http.ResponseWriter, r *http.Request, path string) {
+	f, err := GetInstance().StreamFS().Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+
+		logger.Warnf("[stream] error opening %s: %v", path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		logger.Warnf("[stream] error getting file info for %s: %v", path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		http.ServeFile(w, r, path)
+		return
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
 }
 
 func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// SceneArtifactMode is how the generated files of a scene are updated after
+// its file is replaced.
+type SceneArtifactMode int
+
+const (
+	// SceneArtifactsRename moves the generated files to the new hash of the
+	// scene. It is used where the content and timing of the video are
+	// unchanged, such as when it is converted, reduced or remuxed.
+	SceneArtifactsRename SceneArtifactMode = iota
+	// SceneArtifactsRegenerate deletes the generated files of the old hash,
+	// and generates the same kinds of files for the new file. It is used
+	// where the video was edited, such as when it is trimmed or transformed.
+	SceneArtifactsRegenerate
+)
+
+// MigrateSceneArtifacts updates the files generated for a scene after its
+// file was replaced, changing the hash of the scene from oldHash. The
+// transcode is always regenerated, since the audio tracks or encoding of the
+// new file may differ from it. Files are regenerated by a generate job,
+// which runs after the current job.
+//
+// Must not be called within a transaction.
+func (s *Manager) MigrateSceneArtifacts(ctx context.Context, sceneID int, oldHash string, mode SceneArtifactMode) error {
+	if oldHash == "" {
+		return nil
+	}
+
+	var newHash string
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		found, err := s.Repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("scene %d not found", sceneID)
+		}
+
+		newHash = found.GetHash(s.Config.GetVideoFileNamingAlgorithm())
+		return nil
+	}); err != nil {
+		return fmt.Errorf("loading scene: %w", err)
+	}
+
+	generated := scene.FindGeneratedFiles(s.Paths, oldHash)
+
+	var regenerate scene.GeneratedFiles
+	switch mode {
+	case SceneArtifactsRename:
+		regenerate.Transcodes = generated.Transcodes
+
+		transcodePath := s.Paths.Scene.GetTranscodePath(oldHash)
+		if err := os.Remove(transcodePath); err != nil && !os.IsNotExist(err) {
+			logger.Warnf("failed to remove transcode %s: %v", transcodePath, err)
+		}
+
+		if newHash != "" && newHash != oldHash {
+			scene.MigrateHash(s.Paths, oldHash, newHash)
+		}
+	case SceneArtifactsRegenerate:
+		regenerate = generated
+
+		deleter := &scene.FileDeleter{
+			Deleter: file.NewDeleter(),
+			Paths:   s.Paths,
+		}
+		if err := deleter.MarkGeneratedFilesForHash(oldHash); err != nil {
+			deleter.Rollback()
+			return fmt.Errorf("deleting generated files: %w", err)
+		}
+		deleter.Commit()
+	}
+
+	if !regenerate.Any() {
+		return nil
+	}
+
+	logger.Infof("regenerating generated files of scene %d", sceneID)
+	if _, err := s.Generate(ctx, GenerateMetadataInput{
+		Sprites:                   regenerate.Sprites,
+		Previews:                  regenerate.Previews,
+		ImagePreviews:             regenerate.ImagePreviews,
+		WallPreviews:              regenerate.WallPreviews,
+		Markers:                   regenerate.MarkerPreviews || regenerate.MarkerImagePreviews || regenerate.MarkerScreenshots || regenerate.MarkerThumbnails,
+		MarkerImagePreviews:       regenerate.MarkerImagePreviews,
+		MarkerScreenshots:         regenerate.MarkerScreenshots,
+		MarkerThumbnails:          regenerate.MarkerThumbnails,
+		Transcodes:                regenerate.Transcodes,
+		ForceTranscodes:           regenerate.Transcodes,
+		InteractiveHeatmapsSpeeds: regenerate.Heatmaps,
+		SceneIDs:                  []string{strconv.Itoa(sceneID)},
+		Overwrite:                 true,
+	}); err != nil {
+		return fmt.Errorf("queueing generation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// on demand sprites have fewer thumbnails than generated sprites, so
+	// that they are quick enough to generate while the scrubber is shown
+	onDemandSpriteRows    = 5
+	onDemandSpriteColumns = 5
+
+	// maximum number of on demand sprites generated at the same time
+	onDemandSpriteConcurrency = 2
+	// on demand sprites generated per second, after the burst
+	onDemandSpriteRate  = 0.2
+	onDemandSpriteBurst = 6
+
+	// time before the sprite of a scene that failed to generate is tried
+	// again
+	onDemandSpriteRetryDelay = time.Hour
+)
+
+var (
+	// ErrSpriteGenerationBusy is returned when an on demand sprite cannot
+	// be generated now because too many are being generated.
+	ErrSpriteGenerationBusy = errors.New("too many sprites are being generated, try again later")
+	// ErrSpriteGenerationFailed is returned when the on demand sprite of the
+	// scene recently failed to generate.
+	ErrSpriteGenerationFailed = errors.New("sprite generation recently failed")
+)
+
+// onDemandSprites limits the generation of on demand sprites. Only one
+// generation of each sprite runs at a time, generations are rate limited,
+// and sprites that fail to generate are not tried again for a while.
+type onDemandSprites struct {
+	limiter *rate.Limiter
+	slots   chan struct{}
+
+	mutex      sync.Mutex
+	inProgress map[string]*spriteGeneration
+	failed     map[string]time.Time
+}
+
+type spriteGeneration struct {
+	done chan struct{}
+	err  error
+}
+
+func newOnDemandSprites() *onDemandSprites {
+	return &onDemandSprites{
+		limiter:    rate.NewLimiter(onDemandSpriteRate, onDemandSpriteBurst),
+		slots:      make(chan struct{}, onDemandSpriteConcurrency),
+		inProgress: make(map[string]*spriteGeneration),
+		failed:     make(map[string]time.Time),
+	}
+}
+
+// generate calls fn to generate the sprite with the key, unless it is
+// already being generated, in which case it waits for that generation to
+// finish. Returns ErrSpriteGenerationBusy without calling fn if the rate or
+// concurrency limit is reached.
+func (o *onDemandSprites) generate(ctx context.Context, key string, now time.Time, fn func() error) error {
+	o.mutex.Lock()
+
+	if failedAt, found := o.failed[key]; found {
+		if now.Sub(failedAt) < onDemandSpriteRetryDelay {
+			o.mutex.Unlock()
+			return ErrSpriteGenerationFailed
+		}
+		delete(o.failed, key)
+	}
+
+	if g := o.inProgress[key]; g != nil {
+		o.mutex.Unlock()
+
+		select {
+		case <-g.done:
+			return g.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case o.slots <- struct{}{}:
+	default:
+		o.mutex.Unlock()
+		return ErrSpriteGenerationBusy
+	}
+
+	if !o.limiter.AllowN(now, 1) {
+		<-o.slots
+		o.mutex.Unlock()
+		return ErrSpriteGenerationBusy
+	}
+
+	g := &spriteGeneration{done: make(chan struct{})}
+	o.inProgress[key] = g
+	o.mutex.Unlock()
+
+	// the sprite is persisted, so generation continues if the request that
+	// started it is cancelled
+	g.err = fn()
+
+	o.mutex.Lock()
+	delete(o.inProgress, key)
+	if g.err != nil {
+		o.failed[key] = now
+	}
+	o.mutex.Unlock()
+
+	<-o.slots
+	close(g.done)
+
+	return g.err
+}
+
+// GenerateSpriteOnDemand generates a reduced sprite for the scene if it does
+// not have one and on demand sprites are enabled. The sprite is written to
+// the same files as a generated sprite, so it is used until the sprite is
+// generated again with overwrite.
+func (s *Manager) GenerateSpriteOnDemand(ctx context.Context, scene *models.Scene) error {
+	if !s.Config.GetSpriteOnDemand() || scene.Path == "" {
+		return nil
+	}
+
+	sceneHash := scene.GetHash(s.Config.GetVideoFileNamingAlgorithm())
+	if sceneHash == "" {
+		return nil
+	}
+
+	options := spriteOptionsFromConfig(s.Config)
+	imagePath := spriteImageFilePath(s.Paths.Scene, sceneHash, options.Format)
+	vttPath := s.Paths.Scene.GetSpriteVttFilePath(sceneHash)
+
+	if spriteExists(imagePath, vttPath) {
+		return nil
+	}
+
+	return s.onDemandSprites.generate(ctx, sceneHash, time.Now(), func() error {
+		// another request may have finished generating the sprite
+		if spriteExists(imagePath, vttPath) {
+			return nil
+		}
+
+		videoFile, err := s.FFProbe.NewVideoFile(scene.Path)
+		if err != nil {
+			return fmt.Errorf("reading video file: %w", err)
+		}
+
+		options.Rows = min(options.Rows, onDemandSpriteRows)
+		options.Columns = min(options.Columns, onDemandSpriteColumns)
+		options.Interval = 0
+
+		generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, options)
+		if err != nil {
+			return fmt.Errorf("creating sprite generator: %w", err)
+		}
+		if generator == nil {
+			return fmt.Errorf("video file %s does not exist", scene.Path)
+		}
+
+		logger.Infof("Generating on demand sprite for %s", scene.Path)
+		if err := generator.Generate(); err != nil {
+			logErrorOutput(err)
+			return fmt.Errorf("generating sprite: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func spriteExists(imagePath string, vttPath string) bool {
+	imageExists, _ := fsutil.FileExists(imagePath)
+	vttExists, _ := fsutil.FileExists(vttPath)
+	return imageExists && vttExists
+}
@@ -0,0 +1,109 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnDemandSprites_generate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("concurrent requests generate once", func(t *testing.T) {
+		o := newOnDemandSprites()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		calls := 0
+		fn := func() error {
+			calls++
+			close(started)
+			<-release
+			return nil
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, o.generate(ctx, "a", now, fn))
+		}()
+
+		<-started
+
+		waitErr := make(chan error)
+		go func() {
+			waitErr <- o.generate(ctx, "a", now, fn)
+		}()
+
+		close(release)
+		wg.Wait()
+		assert.NoError(t, <-waitErr)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("concurrency limit", func(t *testing.T) {
+		o := newOnDemandSprites()
+
+		release := make(chan struct{})
+		var started sync.WaitGroup
+		var wg sync.WaitGroup
+		for _, key := range []string{"a", "b"} {
+			started.Add(1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = o.generate(ctx, key, now, func() error {
+					started.Done()
+					<-release
+					return nil
+				})
+			}()
+		}
+		started.Wait()
+
+		err := o.generate(ctx, "c", now, func() error { return nil })
+		assert.ErrorIs(t, err, ErrSpriteGenerationBusy)
+
+		close(release)
+		wg.Wait()
+		assert.NoError(t, o.generate(ctx, "c", now, func() error { return nil }))
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		o := newOnDemandSprites()
+
+		for i := 0; i < onDemandSpriteBurst; i++ {
+			assert.NoError(t, o.generate(ctx, string(rune('a'+i)), now, func() error { return nil }))
+		}
+
+		err := o.generate(ctx, "z", now, func() error { return nil })
+		assert.ErrorIs(t, err, ErrSpriteGenerationBusy)
+
+		assert.NoError(t, o.generate(ctx, "z", now.Add(time.Minute), func() error { return nil }))
+	})
+
+	t.Run("failure is not retried", func(t *testing.T) {
+		o := newOnDemandSprites()
+
+		failure := errors.New("failed")
+		assert.ErrorIs(t, o.generate(ctx, "a", now, func() error { return failure }), failure)
+
+		called := false
+		fn := func() error {
+			called = true
+			return nil
+		}
+
+		assert.ErrorIs(t, o.generate(ctx, "a", now.Add(time.Minute), fn), ErrSpriteGenerationFailed)
+		assert.False(t, called)
+
+		assert.NoError(t, o.generate(ctx, "a", now.Add(onDemandSpriteRetryDelay), fn))
+		assert.True(t, called)
+	})
+}
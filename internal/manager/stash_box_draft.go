@@ -0,0 +1,261 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/stashbox"
+)
+
+// SceneDraft returns the stash-box draft of the scene with the id. Must be
+// called within a transaction.
+func SceneDraft(ctx context.Context, r models.Repository, id int) (*stashbox.SceneDraft, error) {
+	qb := r.Scene
+	s, err := qb.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("scene with id %d not found", id)
+	}
+
+	cover, err := qb.GetCover(ctx, id)
+	if err != nil {
+		logger.Errorf("Error getting scene cover: %v", err)
+	}
+
+	if err := s.LoadURLs(ctx, qb); err != nil {
+		return nil, fmt.Errorf("loading scene URLs: %w", err)
+	}
+
+	if err := s.LoadStashIDs(ctx, qb); err != nil {
+		return nil, err
+	}
+
+	draft := &stashbox.SceneDraft{
+		Scene: s,
+	}
+
+	pqb := r.Performer
+	sqb := r.Studio
+
+	if s.StudioID != nil {
+		draft.Studio, err = sqb.Find(ctx, *s.StudioID)
+		if err != nil {
+			return nil, err
+		}
+		if draft.Studio == nil {
+			return nil, fmt.Errorf("studio with id %d not found", *s.StudioID)
+		}
+
+		if err := draft.Studio.LoadStashIDs(ctx, sqb); err != nil {
+			return nil, err
+		}
+	}
+
+	// submit all file fingerprints
+	if err := s.LoadFiles(ctx, qb); err != nil {
+		return nil, err
+	}
+
+	scenePerformers, err := pqb.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range scenePerformers {
+		if err := p.LoadStashIDs(ctx, pqb); err != nil {
+			return nil, err
+		}
+	}
+	draft.Performers = scenePerformers
+
+	draft.Tags, err = r.Tag.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.Cover = cover
+
+	return draft, nil
+}
+
+// PerformerDraft returns the performer with the id, with the relationships
+// submitted in drafts loaded, and its image. Must be called within a
+// transaction.
+func PerformerDraft(ctx context.Context, r models.Repository, id int) (*models.Performer, []byte, error) {
+	pqb := r.Performer
+	performer, err := pqb.Find(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if performer == nil {
+		return nil, nil, fmt.Errorf("performer with id %d not found", id)
+	}
+
+	if err := performer.LoadAliases(ctx, pqb); err != nil {
+		return nil, nil, err
+	}
+
+	if err := performer.LoadURLs(ctx, pqb); err != nil {
+		return nil, nil, err
+	}
+
+	if err := performer.LoadStashIDs(ctx, pqb); err != nil {
+		return nil, nil, err
+	}
+
+	img, _ := pqb.GetImage(ctx, performer.ID)
+
+	return performer, img, nil
+}
+
+// SubmitStashBoxDrafts queues drafts of the scenes and performers for
+// submission to the stash-box, and starts a job submitting them. Returns the
+// job ID.
+func (s *Manager) SubmitStashBoxDrafts(ctx context.Context, box *models.StashBox, sceneIDs []int, performerIDs []int) (int, error) {
+	var submissionIDs []int
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		queue := func(typ models.StashBoxSubmissionType, ids []int) error {
+			for _, id := range ids {
+				submission := models.NewStashBoxSubmission(box.Endpoint, typ, id)
+				if err := r.StashBoxSubmission.Create(ctx, &submission); err != nil {
+					return err
+				}
+				submissionIDs = append(submissionIDs, submission.ID)
+			}
+			return nil
+		}
+
+		if err := queue(models.StashBoxSubmissionTypeScene, sceneIDs); err != nil {
+			return err
+		}
+		return queue(models.StashBoxSubmissionTypePerformer, performerIDs)
+	}); err != nil {
+		return 0, err
+	}
+
+	j := &StashBoxDraftSubmissionJob{
+		Box:           *box,
+		SubmissionIDs: submissionIDs,
+	}
+
+	description := fmt.Sprintf("Submitting %d drafts to %s...", len(submissionIDs), box.Name)
+	return s.JobManager.Add(ctx, description, j), nil
+}
+
+// StashBoxDraftSubmissionJob submits queued drafts to a stash-box, retrying
+// requests that fail with transient errors. The result of each submission is
+// recorded in the submission, and as the result of the job item.
+type StashBoxDraftSubmissionJob struct {
+	Box           models.StashBox
+	SubmissionIDs []int
+}
+
+func (j *StashBoxDraftSubmissionJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := instance.Repository
+
+	var submissions []*models.StashBoxSubmission
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		submissions, err = r.StashBoxSubmission.FindMany(ctx, j.SubmissionIDs)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	client := stashbox.NewClient(j.Box, stashbox.ExcludeTagPatterns(instance.Config.GetScraperExcludeTagPatterns()))
+	retrier := stashbox.NewRetrier()
+
+	progress.SetTotal(len(submissions))
+
+	for _, submission := range submissions {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Submitting %s %d", submission.Type, submission.ObjectID), func() {
+			err := j.submit(ctx, client, retrier, submission)
+			if err != nil {
+				logger.Errorf("[stash-box] submitting %s %d draft: %v", submission.Type, submission.ObjectID, err)
+			}
+			progress.ItemDone(strconv.Itoa(submission.ID), err)
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+// submit submits the draft of the submission and records the result.
+func (j *StashBoxDraftSubmissionJob) submit(ctx context.Context, client *stashbox.Client, retrier stashbox.Retrier, submission *models.StashBoxSubmission) error {
+	r := instance.Repository
+
+	var (
+		sceneDraft *stashbox.SceneDraft
+		performer  *models.Performer
+		image      []byte
+	)
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		switch submission.Type {
+		case models.StashBoxSubmissionTypeScene:
+			sceneDraft, err = SceneDraft(ctx, r, submission.ObjectID)
+		case models.StashBoxSubmissionTypePerformer:
+			performer, image, err = PerformerDraft(ctx, r, submission.ObjectID)
+		default:
+			err = fmt.Errorf("invalid submission type %q", submission.Type)
+		}
+		return err
+	})
+
+	var (
+		draftID  *string
+		attempts int
+	)
+	if err == nil {
+		attempts, err = retrier.Do(ctx, func() error {
+			var err error
+			if sceneDraft != nil {
+				draftID, err = client.SubmitSceneDraft(ctx, *sceneDraft)
+			} else {
+				draftID, err = client.SubmitPerformerDraft(ctx, performer, image)
+			}
+			return err
+		})
+	}
+
+	submission.SetResult(draftID, attempts, err)
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.StashBoxSubmission.Update(ctx, submission)
+	}); err != nil {
+		logger.Errorf("[stash-box] saving submission %d: %v", submission.ID, err)
+	}
+
+	return err
+}
+
+// Retry returns a job that submits the drafts of the submissions with the
+// given ids again.
+func (j *StashBoxDraftSubmissionJob) Retry(ids []string) job.JobExec {
+	var submissionIDs []int
+	for _, id := range ids {
+		if i, err := strconv.Atoi(id); err == nil {
+			submissionIDs = append(submissionIDs, i)
+		}
+	}
+
+	return &StashBoxDraftSubmissionJob{
+		Box:           j.Box,
+		SubmissionIDs: submissionIDs,
+	}
+}
@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// storageCheckInterval is how often the stash paths are checked
+	storageCheckInterval = time.Minute
+	// storageCheckTimeout is how long to wait for a stash path to respond
+	// before treating it as offline
+	storageCheckTimeout = 10 * time.Second
+)
+
+// checkStorage checks whether each stash path is reachable, logging any
+// change since the last check.
+func (s *Manager) checkStorage() []file.PathHealth {
+	// rclone stashes are checked when they are listed
+	paths := s.Config.GetStashPaths().Local().Paths()
+	previous := s.StorageHealth.Status(paths)
+
+	ret := s.StorageHealth.Check(paths)
+	for i, h := range ret {
+		if h.Online == previous[i].Online {
+			continue
+		}
+
+		if h.Online {
+			logger.Infof("Stash path %s is back online", h.Path)
+		} else {
+			logger.Warnf("Stash path %s is offline: %s", h.Path, h.Error)
+		}
+	}
+
+	return ret
+}
+
+// monitorStorage periodically checks the stash paths in the background.
+func (s *Manager) monitorStorage() {
+	go func() {
+		ticker := time.NewTicker(storageCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if !s.Config.IsNewSystem() {
+				s.checkStorage()
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// StashStatus returns the result of the last check of each stash path.
+func (s *Manager) StashStatus() []file.PathHealth {
+	stashes := s.Config.GetStashPaths()
+	ret := s.StorageHealth.Status(stashes.Paths())
+	for i, stash := range stashes {
+		if stash.Rclone {
+			ret[i] = s.remotes.status(stash.Path)
+		}
+	}
+
+	return ret
+}
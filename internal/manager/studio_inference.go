@@ -0,0 +1,307 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+var ErrNoStudioProposals = errors.New("no studio proposals have been detected")
+
+// studioProposalReports holds the last report of studios inferred for
+// scenes without a studio.
+type studioProposalReports struct {
+	mutex sync.Mutex
+	last  *studio.ProposalReport
+}
+
+func (r *studioProposalReports) get() *studio.ProposalReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last
+}
+
+func (r *studioProposalReports) set(report *studio.ProposalReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.last = report
+}
+
+// StudioProposals returns the last report of proposed studios, or nil if
+// studios have not been detected.
+func (s *Manager) StudioProposals() *studio.ProposalReport {
+	return s.studioProposals.get()
+}
+
+// DetectStudios starts a job that infers the studios of the scenes without
+// a studio, and stores the proposed studios as the last report. If
+// useWatermarks is true, the text recognised on screen is matched as well.
+// No studios are set.
+func (s *Manager) DetectStudios(ctx context.Context, useWatermarks bool) int {
+	j := &DetectStudiosJob{
+		UseWatermarks: useWatermarks,
+	}
+
+	return s.JobManager.Add(ctx, "Detecting scene studios...", j)
+}
+
+// ApplyStudioProposals starts a job that sets the studios of the last
+// report with at least the minimum confidence. If ids is not nil, only
+// those proposals are applied.
+func (s *Manager) ApplyStudioProposals(ctx context.Context, ids []int, minConfidence float64) (int, error) {
+	report := s.studioProposals.get()
+	if report == nil {
+		return 0, ErrNoStudioProposals
+	}
+
+	var selected map[int]bool
+	if ids != nil {
+		selected = make(map[int]bool)
+		for _, id := range ids {
+			selected[id] = true
+		}
+	}
+
+	var proposals []studio.Proposal
+	for _, p := range report.Proposals {
+		if (selected == nil || selected[p.ID]) && p.Confidence >= minConfidence {
+			proposals = append(proposals, p)
+		}
+	}
+
+	if len(proposals) == 0 {
+		return 0, errors.New("no studio proposals to apply")
+	}
+
+	j := &ApplyStudioProposalsJob{
+		Proposals: proposals,
+	}
+
+	return s.JobManager.Add(ctx, "Setting detected scene studios...", j), nil
+}
+
+// DetectStudiosJob infers the studios of the scenes without a studio from
+// the domains of their urls, the studio names in their filenames and,
+// optionally, the studio names in the text recognised on screen.
+type DetectStudiosJob struct {
+	UseWatermarks bool
+}
+
+func (j *DetectStudiosJob) Execute(ctx context.Context, progress *job.Progress) error {
+	const batchSize = 1000
+
+	mgr := instance
+	r := mgr.Repository
+	cache := mgr.Config.GetAutoTagMatchCache()
+
+	sceneFilter := &models.SceneFilterType{
+		Studios: &models.HierarchicalMultiCriterionInput{
+			Modifier: models.CriterionModifierIsNull,
+		},
+	}
+
+	var candidates []studio.Candidate
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		studios, err := r.Studio.All(ctx)
+		if err != nil {
+			return fmt.Errorf("finding studios: %w", err)
+		}
+
+		domains := studio.NewDomainIndex(studios, mgr.Config.GetStudioDomainMappings())
+
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				if s.Locked {
+					continue
+				}
+
+				evidence, err := j.evidence(ctx, s, domains, cache)
+				if err != nil {
+					return fmt.Errorf("inferring studio of scene %d: %w", s.ID, err)
+				}
+
+				if len(evidence) > 0 {
+					candidates = append(candidates, studio.Candidate{
+						SceneID:  s.ID,
+						Evidence: evidence,
+					})
+				}
+			}
+
+			if len(scenes) != batchSize {
+				more = false
+			} else {
+				*findFilter.Page++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	report := &studio.ProposalReport{
+		GeneratedAt: time.Now(),
+		Proposals:   studio.Propose(candidates),
+	}
+	mgr.studioProposals.set(report)
+
+	logger.Infof("Detected studios for %d scenes", len(report.Proposals))
+	return nil
+}
+
+func (j *DetectStudiosJob) evidence(ctx context.Context, s *models.Scene, domains *studio.DomainIndex, cache *match.Cache) ([]studio.Evidence, error) {
+	r := instance.Repository
+
+	if err := s.LoadURLs(ctx, r.Scene); err != nil {
+		return nil, err
+	}
+
+	var ret []studio.Evidence
+	for _, u := range s.URLs.List() {
+		if e, found := domains.Match(u); found {
+			ret = append(ret, e)
+		}
+	}
+
+	// path is the path of the primary file
+	if s.Path != "" {
+		st, err := match.PathToStudio(ctx, s.Path, r.Studio, cache, true)
+		if err != nil {
+			return nil, err
+		}
+		if st != nil {
+			ret = append(ret, studio.Evidence{
+				Source:     studio.InferenceSourceFilename,
+				StudioID:   st.ID,
+				Confidence: studio.FilenameConfidence,
+			})
+		}
+	}
+
+	if j.UseWatermarks && s.OCRText != "" {
+		st, err := match.PathToStudio(ctx, s.OCRText, r.Studio, cache, false)
+		if err != nil {
+			return nil, err
+		}
+		if st != nil {
+			ret = append(ret, studio.Evidence{
+				Source:     studio.InferenceSourceWatermark,
+				StudioID:   st.ID,
+				Confidence: studio.WatermarkConfidence,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// ApplyStudioProposalsJob sets the studio of the scene of each proposal.
+type ApplyStudioProposalsJob struct {
+	Proposals []studio.Proposal
+}
+
+func (j *ApplyStudioProposalsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	progress.SetTotal(len(j.Proposals))
+
+	for _, p := range j.Proposals {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Setting studio of scene %d", p.SceneID), func() {
+			err := j.apply(ctx, p)
+			if err != nil {
+				logger.Errorf("Error setting studio of scene %d: %v", p.SceneID, err)
+			}
+			progress.ItemDone(strconv.Itoa(p.ID), err)
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (j *ApplyStudioProposalsJob) apply(ctx context.Context, p studio.Proposal) error {
+	r := instance.Repository
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, p.SceneID)
+		if err != nil {
+			return err
+		}
+
+		// the scene may have been deleted, locked or given a studio since
+		// the proposal was made
+		if s == nil || s.Locked || s.StudioID != nil {
+			logger.Infof("Skipping scene %d", p.SceneID)
+			return nil
+		}
+
+		st, err := r.Studio.Find(ctx, p.StudioID)
+		if err != nil {
+			return err
+		}
+		if st == nil {
+			return fmt.Errorf("studio %d not found", p.StudioID)
+		}
+
+		partial := models.NewScenePartial()
+		partial.StudioID = models.NewOptionalInt(st.ID)
+
+		if _, err := r.Scene.UpdatePartial(ctx, s.ID, partial); err != nil {
+			return err
+		}
+
+		logger.Infof("Set studio of scene %d to %s", s.ID, st.Name)
+		return nil
+	})
+}
+
+// Retry returns a job that applies the proposals with the given ids again.
+func (j *ApplyStudioProposalsJob) Retry(ids []string) job.JobExec {
+	retry := make(map[string]bool)
+	for _, id := range ids {
+		retry[id] = true
+	}
+
+	var proposals []studio.Proposal
+	for _, p := range j.Proposals {
+		if retry[strconv.Itoa(p.ID)] {
+			proposals = append(proposals, p)
+		}
+	}
+
+	return &ApplyStudioProposalsJob{
+		Proposals: proposals,
+	}
+}
@@ -11,6 +11,13 @@ type Task interface {
 	GetDescription() string
 }
 
+// TaskWithBytesProcessed is a task that reports the number of bytes it read
+// after it has started, for job resource accounting.
+type TaskWithBytesProcessed interface {
+	Task
+	GetBytesProcessed() int64
+}
+
 // TaskWithProgress is a task that supports progress reporting
 type TaskWithProgress interface {
 	StartWithProgress(context.Context, *job.Progress)
@@ -0,0 +1,171 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type BlobStoreCleaner interface {
+	FindUnreferencedBlobs(ctx context.Context, n uint, lastChecksum string) ([]string, error)
+	Size(ctx context.Context, checksum string) (int64, error)
+	DeleteUnreferenced(ctx context.Context, checksum string) (bool, error)
+}
+
+type CleanBlobsOptions struct {
+	// Do a dry run. Don't delete any blobs
+	DryRun bool `json:"dryRun"`
+}
+
+// CleanBlobsJob removes blobs that are no longer referenced by any object.
+type CleanBlobsJob struct {
+	TxnManager txn.Manager
+	BlobStore  BlobStoreCleaner
+	Vacuumer   Vacuumer
+	Options    CleanBlobsOptions
+}
+
+func (j *CleanBlobsJob) logPrefix() string {
+	if j.Options.DryRun {
+		return "[dry run] "
+	}
+
+	return ""
+}
+
+func (j *CleanBlobsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	var (
+		checksums []string
+		err       error
+	)
+	progress.ExecuteTask("Finding unreferenced blobs", func() {
+		checksums, err = j.findUnreferencedBlobs(ctx)
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error finding unreferenced blobs: %w", err)
+	}
+
+	if len(checksums) == 0 {
+		logger.Infof("No unreferenced blobs found")
+		return nil
+	}
+
+	progress.SetTotal(len(checksums))
+
+	var (
+		count int
+		size  int64
+	)
+	progress.ExecuteTask(fmt.Sprintf("Cleaning %d blobs", len(checksums)), func() {
+		count, size = j.cleanBlobs(ctx, progress, checksums)
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	logger.Infof("%sRemoved %d unreferenced blobs (%s)", j.logPrefix(), count, utils.FormatBytes(size))
+
+	if !j.Options.DryRun && count > 0 {
+		// run a vacuum to reclaim space
+		progress.ExecuteTask("Vacuuming database", func() {
+			if err := j.Vacuumer.Vacuum(ctx); err != nil {
+				logger.Errorf("Error vacuuming database: %v", err)
+			}
+		})
+	}
+
+	logger.Infof("Finished cleaning blobs")
+	return nil
+}
+
+func (j *CleanBlobsJob) findUnreferencedBlobs(ctx context.Context) ([]string, error) {
+	const batchSize = 1000
+
+	var ret []string
+	lastChecksum := ""
+	for {
+		if job.IsCancelled(ctx) {
+			return nil, nil
+		}
+
+		var batch []string
+		if err := txn.WithReadTxn(ctx, j.TxnManager, func(ctx context.Context) error {
+			var err error
+			batch, err = j.BlobStore.FindUnreferencedBlobs(ctx, batchSize, lastChecksum)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		if len(batch) == 0 {
+			return ret, nil
+		}
+
+		ret = append(ret, batch...)
+		lastChecksum = batch[len(batch)-1]
+	}
+}
+
+func (j *CleanBlobsJob) cleanBlobs(ctx context.Context, progress *job.Progress, checksums []string) (int, int64) {
+	var (
+		count int
+		size  int64
+	)
+
+	for _, checksum := range checksums {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		progress.ExecuteTask("Cleaning blob "+checksum, func() {
+			defer progress.Increment()
+
+			var (
+				blobSize int64
+				deleted  bool
+			)
+			if err := txn.WithTxn(ctx, j.TxnManager, func(ctx context.Context) error {
+				var err error
+				blobSize, err = j.BlobStore.Size(ctx, checksum)
+				if err != nil {
+					return err
+				}
+
+				logger.Debugf("%sDeleting unreferenced blob %s (%s)", j.logPrefix(), checksum, utils.FormatBytes(blobSize))
+
+				if j.Options.DryRun {
+					deleted = true
+					return nil
+				}
+
+				deleted, err = j.BlobStore.DeleteUnreferenced(ctx, checksum)
+				return err
+			}); err != nil {
+				logger.Errorf("Error cleaning blob %s: %v", checksum, err)
+				return
+			}
+
+			if !deleted {
+				logger.Debugf("Blob %s is referenced or no longer exists - not deleted", checksum)
+				return
+			}
+
+			count++
+			size += blobSize
+		})
+	}
+
+	return count, size
+}
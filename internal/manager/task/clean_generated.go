@@ -14,6 +14,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type CleanGeneratedOptions struct {
@@ -27,9 +28,19 @@ type CleanGeneratedOptions struct {
 
 	ImageThumbnails bool `json:"imageThumbnails"`
 
+	InteractiveHeatmaps bool `json:"interactiveHeatmaps"`
+
 	DryRun bool `json:"dryRun"`
 }
 
+// orphanedFiles records the number and total size of orphaned files of a
+// specific type.
+type orphanedFiles struct {
+	typ   string
+	count int
+	size  int64
+}
+
 type BlobCleaner interface {
 	EntryExists(ctx context.Context, checksum string) (bool, error)
 }
@@ -47,9 +58,59 @@ type CleanGeneratedJob struct {
 	dryRunPrefix  string
 	totalTasks    int
 	tasksComplete int
+
+	orphans []*orphanedFiles
 }
 
-func (j *CleanGeneratedJob) deleteFile(path string) {
+// recordOrphan adds the size of the file or directory at path to the
+// orphaned file statistics for the given type.
+func (j *CleanGeneratedJob) recordOrphan(typ string, path string) {
+	var o *orphanedFiles
+	for _, oo := range j.orphans {
+		if oo.typ == typ {
+			o = oo
+			break
+		}
+	}
+
+	if o == nil {
+		o = &orphanedFiles{typ: typ}
+		j.orphans = append(j.orphans, o)
+	}
+
+	if err := filepath.Walk(path, func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			o.count++
+			o.size += info.Size()
+		}
+		return nil
+	}); err != nil {
+		logger.Warnf("error getting size of %s: %v", path, err)
+	}
+}
+
+func (j *CleanGeneratedJob) reportOrphans() {
+	var (
+		totalCount int
+		totalSize  int64
+	)
+
+	for _, o := range j.orphans {
+		logger.Infof("%sFound %d orphaned %s files (%s)", j.dryRunPrefix, o.count, o.typ, utils.FormatBytes(o.size))
+		totalCount += o.count
+		totalSize += o.size
+	}
+
+	logger.Infof("%sFound %d orphaned generated files in total (%s)", j.dryRunPrefix, totalCount, utils.FormatBytes(totalSize))
+}
+
+func (j *CleanGeneratedJob) deleteFile(typ string, path string) {
+	j.recordOrphan(typ, path)
+
 	if j.Options.DryRun {
 		logger.Debugf("would delete file: %s", path)
 		return
@@ -60,7 +121,9 @@ func (j *CleanGeneratedJob) deleteFile(path string) {
 	}
 }
 
-func (j *CleanGeneratedJob) deleteDir(path string) {
+func (j *CleanGeneratedJob) deleteDir(typ string, path string) {
+	j.recordOrphan(typ, path)
+
 	if j.Options.DryRun {
 		logger.Debugf("would delete file: %s", path)
 		return
@@ -92,6 +155,9 @@ func (j *CleanGeneratedJob) countTasks() int {
 	if j.Options.ImageThumbnails {
 		tasks++
 	}
+	if j.Options.InteractiveHeatmaps {
+		tasks++
+	}
 	return tasks
 }
 
@@ -108,6 +174,7 @@ func (j *CleanGeneratedJob) logError(err error) {
 
 func (j *CleanGeneratedJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.tasksComplete = 0
+	j.orphans = nil
 
 	if !j.BlobsStorageType.IsValid() {
 		return fmt.Errorf("invalid blobs storage type: %s", j.BlobsStorageType)
@@ -179,11 +246,22 @@ func (j *CleanGeneratedJob) Execute(ctx context.Context, progress *job.Progress)
 		j.taskComplete(progress)
 	}
 
+	if j.Options.InteractiveHeatmaps {
+		progress.ExecuteTask("Cleaning interactive heatmap files", func() {
+			if err := j.cleanInteractiveHeatmapFiles(ctx, progress); err != nil {
+				j.logError(fmt.Errorf("error cleaning interactive heatmap files: %w", err))
+			}
+		})
+		j.taskComplete(progress)
+	}
+
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
 	}
 
+	j.reportOrphans()
+
 	logger.Infof("Finished cleaning generated files")
 	return nil
 }
@@ -299,7 +377,7 @@ func (j *CleanGeneratedJob) cleanBlobFiles(ctx context.Context, progress *job.Pr
 
 			if !exists {
 				j.logDelete("deleting unused blob file: %s", blobname)
-				j.deleteFile(path)
+				j.deleteFile("blob", path)
 			}
 
 			return nil
@@ -350,6 +428,12 @@ func (j *CleanGeneratedJob) getSpriteFileHash(basename string) (string, error) {
 
 	var hash string
 	_, err := fmt.Sscanf(basename, spritePattern, &hash)
+	if err != nil {
+		// also try webp sprites
+		webpPattern := patternPrefix + "_sprite.webp"
+		_, err = fmt.Sscanf(basename, webpPattern, &hash)
+	}
+
 	if err != nil {
 		// also try thumbs
 		thumbPattern := patternPrefix + "_thumbs.vtt"
@@ -406,7 +490,7 @@ func (j *CleanGeneratedJob) cleanSpriteFiles(ctx context.Context, progress *job.
 
 		if len(exists) == 0 {
 			j.logDelete("deleting unused sprite file: %s", filename)
-			j.deleteFile(path)
+			j.deleteFile("sprite", path)
 		}
 
 		return nil
@@ -459,7 +543,7 @@ func (j *CleanGeneratedJob) cleanSceneFiles(ctx context.Context, path string, ty
 
 		if len(exists) == 0 {
 			j.logDelete("deleting unused %s file: %s", typ, filename)
-			j.deleteFile(path)
+			j.deleteFile(typ, path)
 		}
 
 		return nil
@@ -476,7 +560,11 @@ func (j *CleanGeneratedJob) getScreenshotFileHash(basename string) (string, erro
 	// include the extension - which could be mp4/jpg/webp
 	_, err := fmt.Sscanf(basename, j.hashPatternPrefix()+".%s", &hash, &ext)
 	if err != nil {
-		return "", err
+		// also try wall previews
+		_, err = fmt.Sscanf(basename, j.hashPatternPrefix()+"_wall.mp4", &hash)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	return fmt.Sprintf("%x", hash), nil
@@ -500,6 +588,20 @@ func (j *CleanGeneratedJob) cleanTranscodeFiles(ctx context.Context, progress *j
 	return j.cleanSceneFiles(ctx, j.Paths.Generated.Transcodes, "transcode", j.getTranscodeFileHash, progress)
 }
 
+func (j *CleanGeneratedJob) getInteractiveHeatmapFileHash(basename string) (string, error) {
+	var hash string
+	_, err := fmt.Sscanf(basename, j.hashPatternPrefix()+".png", &hash)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash), nil
+}
+
+func (j *CleanGeneratedJob) cleanInteractiveHeatmapFiles(ctx context.Context, progress *job.Progress) error {
+	return j.cleanSceneFiles(ctx, j.Paths.Generated.InteractiveHeatmap, "interactive heatmap", j.getInteractiveHeatmapFileHash, progress)
+}
+
 func (j *CleanGeneratedJob) getMarkerSceneFileHash(basename string) (string, error) {
 	var hash string
 	_, err := fmt.Sscanf(basename, j.hashPatternPrefix(), &hash)
@@ -574,7 +676,7 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 
 				if len(scenes) == 0 {
 					j.logDelete("deleting unused marker directory: %s", sceneHash)
-					j.deleteDir(path)
+					j.deleteDir("marker", path)
 				} else {
 					// get the markers now
 					for _, scene := range scenes {
@@ -625,7 +727,7 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 		if marker == nil {
 			// not found, delete the file
 			j.logDelete("deleting unused marker file: %s", filename)
-			j.deleteFile(path)
+			j.deleteFile("marker", path)
 		}
 
 		return nil
@@ -718,7 +820,7 @@ func (j *CleanGeneratedJob) cleanThumbnailFiles(ctx context.Context, progress *j
 
 		if len(exists) == 0 {
 			j.logDelete("deleting unused thumbnail file: %s", filename)
-			j.deleteFile(path)
+			j.deleteFile("thumbnail", path)
 		}
 
 		return nil
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanGeneratedJob_getInteractiveHeatmapFileHash(t *testing.T) {
+	const (
+		oshash = "0123456789abcdef"
+		md5    = "0123456789abcdef0123456789abcdef"
+	)
+
+	tests := []struct {
+		name     string
+		algo     models.HashAlgorithm
+		basename string
+		want     string
+		wantErr  bool
+	}{
+		{"oshash", models.HashAlgorithmOshash, oshash + ".png", oshash, false},
+		{"md5", models.HashAlgorithmMd5, md5 + ".png", md5, false},
+		{"oshash wrong extension", models.HashAlgorithmOshash, oshash + ".jpg", "", true},
+		{"not hex", models.HashAlgorithmOshash, "heatmap.png", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &CleanGeneratedJob{
+				VideoFileNamingAlgorithm: tt.algo,
+			}
+
+			got, err := j.getInteractiveHeatmapFileHash(tt.basename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getInteractiveHeatmapFileHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCleanGeneratedJob_getScreenshotFileHash(t *testing.T) {
+	const oshash = "0123456789abcdef"
+
+	tests := []struct {
+		name     string
+		basename string
+		want     string
+		wantErr  bool
+	}{
+		{"preview", oshash + ".mp4", oshash, false},
+		{"webp", oshash + ".webp", oshash, false},
+		{"wall preview", oshash + "_wall.mp4", oshash, false},
+		{"unknown suffix", oshash + "_other.mp4", "", true},
+		{"not hex", "preview.mp4", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &CleanGeneratedJob{
+				VideoFileNamingAlgorithm: models.HashAlgorithmOshash,
+			}
+
+			got, err := j.getScreenshotFileHash(tt.basename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getScreenshotFileHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
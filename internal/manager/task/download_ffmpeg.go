@@ -64,6 +64,19 @@ func (s *DownloadFFmpegJob) download(ctx context.Context, progress *job.Progress
 			return err
 		}
 	}
+
+	// validate that the downloaded ffmpeg is supported
+	status, err := ffmpeg.GetStatus(ctx, filepath.Join(s.ConfigDirectory, executables[0]))
+	if err != nil {
+		return fmt.Errorf("downloaded ffmpeg could not be executed: %w", err)
+	}
+	if !status.VersionSupported() {
+		return fmt.Errorf("downloaded ffmpeg version is older than the minimum supported version %s", ffmpeg.MinimumVersion)
+	}
+	if len(status.MissingFeatures) > 0 {
+		logger.Warnf("downloaded ffmpeg is missing codec support: %v", status.MissingFeatures)
+	}
+
 	return nil
 }
 
@@ -221,20 +234,28 @@ func (s *DownloadFFmpegJob) unzip(src string) error {
 			return err
 		}
 
+		// extract to a temporary file and rename it, so that an existing
+		// executable that is in use can be replaced
 		unzippedPath := filepath.Join(s.ConfigDirectory, filename)
-		unzippedOutput, err := os.Create(unzippedPath)
+		tmpPath := unzippedPath + ".tmp"
+		unzippedOutput, err := os.Create(tmpPath)
 		if err != nil {
 			return err
 		}
 
 		_, err = io.Copy(unzippedOutput, rc)
 		if err != nil {
+			unzippedOutput.Close()
 			return err
 		}
 
 		if err := unzippedOutput.Close(); err != nil {
 			return err
 		}
+
+		if err := os.Rename(tmpPath, unzippedPath); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ApplySceneParserBatch starts a job that applies the accepted changes of a
+// scene parser batch. Returns the job ID.
+func (s *Manager) ApplySceneParserBatch(ctx context.Context, batchID int) int {
+	j := &applySceneParserBatchJob{
+		repository: s.Repository,
+		batchID:    batchID,
+	}
+
+	return s.JobManager.Add(ctx, "Applying scene parser changes...", j)
+}
+
+type applySceneParserBatchJob struct {
+	repository models.Repository
+	batchID    int
+}
+
+func (j *applySceneParserBatchJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	var changes []*models.SceneParserChange
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		accepted := models.SceneParserChangeStatusAccepted
+		var err error
+		changes, err = r.SceneParserBatch.FindChanges(ctx, j.batchID, &accepted)
+		return err
+	}); err != nil {
+		return fmt.Errorf("finding accepted changes: %w", err)
+	}
+
+	// changes are ordered by scene
+	var sceneIDs []int
+	byScene := make(map[int][]*models.SceneParserChange)
+	for _, c := range changes {
+		if _, found := byScene[c.SceneID]; !found {
+			sceneIDs = append(sceneIDs, c.SceneID)
+		}
+		byScene[c.SceneID] = append(byScene[c.SceneID], c)
+	}
+
+	progress.SetTotal(len(sceneIDs))
+
+	applied := 0
+	for _, sceneID := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Updating scene %d", sceneID), func() {
+			defer progress.Increment()
+
+			if err := j.applyScene(ctx, sceneID, byScene[sceneID]); err != nil {
+				logger.Errorf("Error applying parser changes to scene %d: %v", sceneID, err)
+				return
+			}
+
+			applied++
+		})
+	}
+
+	logger.Infof("Applied scene parser changes to %d scenes", applied)
+	return nil
+}
+
+func (j *applySceneParserBatchJob) applyScene(ctx context.Context, sceneID int, changes []*models.SceneParserChange) error {
+	r := j.repository
+
+	partial := models.NewScenePartial()
+	ids := make([]int, len(changes))
+	for i, c := range changes {
+		if err := c.Apply(&partial); err != nil {
+			return err
+		}
+		ids[i] = c.ID
+	}
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		if _, err := r.Scene.UpdatePartial(ctx, sceneID, partial); err != nil {
+			return err
+		}
+
+		return r.SceneParserBatch.UpdateChangeStatus(ctx, ids, models.SceneParserChangeStatusApplied)
+	})
+}
@@ -52,13 +52,14 @@ func (j *autoTagJob) isFileBasedAutoTag(input AutoTagMetadataInput) bool {
 
 func (j *autoTagJob) autoTagFiles(ctx context.Context, progress *job.Progress, paths []string, performers, studios, tags bool) {
 	t := autoTagFilesTask{
-		paths:      paths,
-		performers: performers,
-		studios:    studios,
-		tags:       tags,
-		progress:   progress,
-		repository: j.repository,
-		cache:      &j.cache,
+		paths:         paths,
+		performers:    performers,
+		studios:       studios,
+		tags:          tags,
+		includeLocked: j.input.IncludeLocked,
+		progress:      progress,
+		repository:    j.repository,
+		cache:         &j.cache,
 	}
 
 	t.process(ctx)
@@ -126,8 +127,9 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 
 	r := j.repository
 	tagger := autotag.Tagger{
-		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		TxnManager:    r.TxnManager,
+		Cache:         &j.cache,
+		IncludeLocked: j.input.IncludeLocked,
 	}
 
 	for _, performerId := range performerIds {
@@ -180,6 +182,13 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 				}
 
 				err := func() error {
+					// aliases are matched with the aliases matching mode
+					if j.cache.Performers.Aliases {
+						if err := performer.LoadAliases(ctx, r.Performer); err != nil {
+							return fmt.Errorf("loading aliases: %w", err)
+						}
+					}
+
 					if err := tagger.PerformerScenes(ctx, performer, paths, r.Scene); err != nil {
 						return fmt.Errorf("processing scenes: %w", err)
 					}
@@ -223,8 +232,9 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 
 	r := j.repository
 	tagger := autotag.Tagger{
-		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		TxnManager:    r.TxnManager,
+		Cache:         &j.cache,
+		IncludeLocked: j.input.IncludeLocked,
 	}
 
 	for _, studioId := range studioIds {
@@ -321,8 +331,9 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 
 	r := j.repository
 	tagger := autotag.Tagger{
-		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		TxnManager:    r.TxnManager,
+		Cache:         &j.cache,
+		IncludeLocked: j.input.IncludeLocked,
 	}
 
 	for _, tagId := range tagIds {
@@ -412,10 +423,11 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 }
 
 type autoTagFilesTask struct {
-	paths      []string
-	performers bool
-	studios    bool
-	tags       bool
+	paths         []string
+	performers    bool
+	studios       bool
+	tags          bool
+	includeLocked bool
 
 	progress   *job.Progress
 	repository models.Repository
@@ -428,6 +440,11 @@ func (t *autoTagFilesTask) makeSceneFilter() *models.SceneFilterType {
 	organized := false
 	ret.Organized = &organized
 
+	if !t.includeLocked {
+		locked := false
+		ret.Locked = &locked
+	}
+
 	return ret
 }
 
@@ -495,6 +512,11 @@ func (t *autoTagFilesTask) makeGalleryFilter() *models.GalleryFilterType {
 	organized := false
 	ret.Organized = &organized
 
+	if !t.includeLocked {
+		locked := false
+		ret.Locked = &locked
+	}
+
 	return ret
 }
 
@@ -0,0 +1,249 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+// maxCapturedFrames limits the number of frames extracted by a single
+// capture, so that a small interval on a long scene does not flood the
+// saved_screens directory.
+const maxCapturedFrames = 500
+
+type CaptureGalleryOptions struct {
+	// Timestamps in seconds. Ignored if Interval is set.
+	Timestamps []float64
+	// Capture a frame every Interval seconds.
+	Interval *float64
+	// Title of the new gallery. Defaults to the scene title.
+	Title *string
+}
+
+// captureTimes returns the sorted, de-duplicated frame times for the given
+// options, clamped to the scene duration.
+func captureTimes(duration float64, opts CaptureGalleryOptions) ([]float64, error) {
+	var ret []float64
+
+	if opts.Interval != nil {
+		if *opts.Interval <= 0 {
+			return nil, fmt.Errorf("interval must be greater than zero")
+		}
+
+		for t := 0.0; t < duration; t += *opts.Interval {
+			ret = append(ret, t)
+		}
+	} else {
+		seen := make(map[int]bool)
+		for _, t := range opts.Timestamps {
+			if t < 0 || t >= duration {
+				continue
+			}
+
+			ms := int(math.Round(t * 1000))
+			if seen[ms] {
+				continue
+			}
+			seen[ms] = true
+			ret = append(ret, t)
+		}
+		sort.Float64s(ret)
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no timestamps within the scene duration")
+	}
+
+	if len(ret) > maxCapturedFrames {
+		return nil, fmt.Errorf("too many frames requested (%d), maximum is %d", len(ret), maxCapturedFrames)
+	}
+
+	return ret, nil
+}
+
+// CaptureGalleryFromScene starts a job that extracts frames from a scene into
+// a new folder in the saved_screens directory, scans it as a gallery and links
+// the gallery to the scene. The gallery and its images are given the scene's
+// performers, tags and studio. Returns the job id.
+func (s *Manager) CaptureGalleryFromScene(ctx context.Context, sceneID int, opts CaptureGalleryOptions) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	if s.Paths.Generated.SavedScreens == "" {
+		return 0, fmt.Errorf("saved_screens path is not configured")
+	}
+
+	var scene *models.Scene
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		scene, err = s.Repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if err := scene.LoadPrimaryFile(ctx, s.Repository.File); err != nil {
+			return err
+		}
+		if err := scene.LoadPerformerIDs(ctx, s.Repository.Scene); err != nil {
+			return err
+		}
+		return scene.LoadTagIDs(ctx, s.Repository.Scene)
+	}); err != nil {
+		return 0, err
+	}
+
+	videoFile := scene.Files.Primary()
+	if videoFile == nil {
+		return 0, fmt.Errorf("scene %d has no video file", sceneID)
+	}
+
+	times, err := captureTimes(videoFile.Duration, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		dir := filepath.Join(s.Paths.Generated.SavedScreens, fmt.Sprintf("scene_%d_%s", sceneID, time.Now().UTC().Format("20060102_150405")))
+		if err := fsutil.EnsureDirAll(dir); err != nil {
+			return fmt.Errorf("creating capture directory: %w", err)
+		}
+		if err := ensureForceGalleryFile(dir); err != nil {
+			return fmt.Errorf("preparing gallery marker: %w", err)
+		}
+
+		g := generate.Generator{
+			Encoder:      s.FFMpeg,
+			FFMpegConfig: s.Config,
+			LockManager:  s.ReadLockManager,
+			ScenePaths:   s.Paths.Scene,
+			Overwrite:    true,
+		}
+
+		progress.SetTotal(len(times))
+		for _, at := range times {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			progress.ExecuteTask(fmt.Sprintf("Capturing frame at %.2fs", at), func() {
+				at := at
+				data, err := g.Screenshot(ctx, videoFile.Path, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
+					At: &at,
+				})
+				if err != nil {
+					logger.Errorf("Error capturing frame at %.2fs: %v", at, err)
+					logErrorOutput(err)
+					return
+				}
+
+				p := filepath.Join(dir, fmt.Sprintf("frame_%09dms.jpg", int(math.Round(at*1000))))
+				if err := os.WriteFile(p, data, 0o664); err != nil {
+					logger.Errorf("Error writing frame %s: %v", p, err)
+				}
+			})
+			progress.Increment()
+		}
+
+		scanJob := ScanJob{
+			scanner:       s.newScanner(),
+			input:         ScanMetadataInput{Paths: []string{dir}},
+			subscriptions: s.scanSubs,
+		}
+		if err := scanJob.Execute(ctx, progress); err != nil {
+			return fmt.Errorf("scanning captured frames: %w", err)
+		}
+
+		return s.linkCapturedGallery(ctx, scene, dir, opts.Title)
+	})
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Capturing gallery from %s...", scene.GetTitle()), j), nil
+}
+
+func (s *Manager) linkCapturedGallery(ctx context.Context, scene *models.Scene, dir string, title *string) error {
+	r := s.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		galleries, err := r.Gallery.FindByPath(ctx, dir)
+		if err != nil {
+			return err
+		}
+		if len(galleries) == 0 {
+			return fmt.Errorf("no gallery was created for %s", dir)
+		}
+		gallery := galleries[0]
+
+		galleryTitle := scene.GetTitle()
+		if title != nil && *title != "" {
+			galleryTitle = *title
+		}
+
+		performerIDs := scene.PerformerIDs.List()
+		tagIDs := scene.TagIDs.List()
+
+		galleryPartial := models.NewGalleryPartial()
+		galleryPartial.Title = models.NewOptionalString(galleryTitle)
+		galleryPartial.SceneIDs = &models.UpdateIDs{
+			IDs:  []int{scene.ID},
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		galleryPartial.PerformerIDs = &models.UpdateIDs{
+			IDs:  performerIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		galleryPartial.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		if scene.StudioID != nil {
+			galleryPartial.StudioID = models.NewOptionalInt(*scene.StudioID)
+		}
+		if scene.Date != nil {
+			galleryPartial.Date = models.NewOptionalDate(*scene.Date)
+		}
+
+		if _, err := r.Gallery.UpdatePartial(ctx, gallery.ID, galleryPartial); err != nil {
+			return fmt.Errorf("updating gallery: %w", err)
+		}
+
+		images, err := r.Image.FindByGalleryID(ctx, gallery.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, img := range images {
+			imagePartial := models.NewImagePartial()
+			imagePartial.PerformerIDs = &models.UpdateIDs{
+				IDs:  performerIDs,
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+			imagePartial.TagIDs = &models.UpdateIDs{
+				IDs:  tagIDs,
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+			if scene.StudioID != nil {
+				imagePartial.StudioID = models.NewOptionalInt(*scene.StudioID)
+			}
+
+			if _, err := r.Image.UpdatePartial(ctx, img.ID, imagePartial); err != nil {
+				return fmt.Errorf("updating image %d: %w", img.ID, err)
+			}
+		}
+
+		logger.Infof("Captured %d frames from scene %d into gallery %d", len(images), scene.ID, gallery.ID)
+		return nil
+	})
+}
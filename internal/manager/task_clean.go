@@ -17,6 +17,7 @@ import (
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/hook"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
 )
 
 type cleaner interface {
@@ -39,10 +40,24 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		logger.Infof("Running in Dry Mode")
 	}
 
+	// recheck the stash paths so that files on a mount that dropped since
+	// the last check are not cleaned
+	instance.checkStorage()
+
+	var skipPaths []string
+	for _, p := range instance.StorageHealth.OfflinePaths() {
+		logger.Warnf("%s is offline. Files in this path will not be cleaned", p)
+		skipPaths = append(skipPaths, p)
+	}
+
+	// rclone stashes are read-only
+	skipPaths = append(skipPaths, instance.Config.GetStashPaths().Remotes().Paths()...)
+
 	j.cleaner.Clean(ctx, file.CleanOptions{
 		Paths:      j.input.Paths,
 		DryRun:     j.input.DryRun,
 		PathFilter: newCleanFilter(instance.Config),
+		SkipPaths:  skipPaths,
 	}, progress)
 
 	if job.IsCancelled(ctx) {
@@ -151,7 +166,8 @@ func newCleanFilter(c *config.Config) *cleanFilter {
 		scanFilter: scanFilter{
 			extensionConfig:   newExtensionConfig(c),
 			stashPaths:        c.GetStashPaths(),
-			generatedPath:     c.GetGeneratedPath(),
+			excludedPaths:     c.GetScanExcludedPaths(),
+			savedScreensPath:  getSavedScreensPath(),
 			videoExcludeRegex: generateRegexps(c.GetExcludes()),
 			imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
 		},
@@ -159,9 +175,6 @@ func newCleanFilter(c *config.Config) *cleanFilter {
 }
 
 func (f *cleanFilter) Accept(ctx context.Context, path string, info fs.FileInfo) bool {
-	//  #1102 - clean anything in generated path
-	generatedPath := f.generatedPath
-
 	var stash *config.StashConfig
 	fileOrFolder := "File"
 
@@ -177,8 +190,9 @@ func (f *cleanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 		return false
 	}
 
-	if fsutil.IsPathInDir(generatedPath, path) {
-		logger.Infof("%s is in generated path. Marking to clean: \"%s\"", fileOrFolder, path)
+	//  #1102 - clean anything in generated path and the other data directories
+	if f.inDataDir(path) {
+		logger.Infof("%s is in a stash data directory. Marking to clean: \"%s\"", fileOrFolder, path)
 		return false
 	}
 
@@ -269,6 +283,10 @@ func (h *cleanHandler) HandleFile(ctx context.Context, fileDeleter *file.Deleter
 		return err
 	}
 
+	txn.AddPostCommitHook(ctx, func(ctx context.Context) {
+		GetInstance().PhashIndex.Remove(int(fileID))
+	})
+
 	return nil
 }
 
@@ -0,0 +1,51 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanFilter_Accept(t *testing.T) {
+	root := t.TempDir()
+	generated := filepath.Join(root, "generated")
+	cache := filepath.Join(root, "cache")
+	savedScreens := filepath.Join(generated, "saved_screens")
+
+	f := &cleanFilter{
+		scanFilter: scanFilter{
+			stashPaths:       config.StashConfigs{{Path: root}},
+			excludedPaths:    []string{generated, cache},
+			savedScreensPath: savedScreens,
+		},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"library folder", filepath.Join(root, "videos"), true},
+		{"generated folder", filepath.Join(generated, "screenshots"), false},
+		{"cache folder", filepath.Join(cache, "thumbs"), false},
+		{"saved screens", savedScreens, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.MkdirAll(tt.path, 0755); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.want, f.Accept(context.Background(), tt.path, info))
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/stashapp/stash/internal/manager/config"
@@ -189,6 +188,9 @@ func (t *ConvertHLSToMP4Task) convertToMP4(ctx context.Context, f *models.VideoF
 	// Use original filename for backup in temp
 	originalFilename := filepath.Base(f.Path)
 	backupTempFile := filepath.Join(backupTempDir, originalFilename)
+
+	// the scanner skips these files until the conversion is done
+	defer instance.FileLocks.Lock(f.Path, tempFile, backupTempFile)()
 	logger.Infof("[convert] HLS backup temp file path: %s", backupTempFile)
 
 	// Create backup copy of ORIGINAL HLS file in temp directory BEFORE conversion
@@ -310,22 +312,9 @@ func (t *ConvertHLSToMP4Task) convertToMP4(ctx context.Context, f *models.VideoF
 		logger.Infof("[convert] recalculated HLS file hashes")
 	}
 
-	// Regenerate sprites with new hash after HLS conversion (oldHash saved at start of function)
-	logger.Infof("[convert] regenerating sprites for converted HLS file")
-	if err := t.Repository.WithTxn(ctx, func(ctx context.Context) error {
-		return t.regenerateSprites(ctx, oldHash)
-	}); err != nil {
-		logger.Warnf("[convert] failed to regenerate HLS sprites: %v", err)
-		// Don't fail the conversion if sprite generation fails
-	}
-
-	// Generate VTT file for the updated video if it doesn't exist
-	if err := t.Repository.WithTxn(ctx, func(ctx context.Context) error {
-		return t.generateVTTFile(ctx, newFile, originalPath)
-	}); err != nil {
-		logger.Warnf("[convert] failed to generate VTT file for HLS: %v", err)
-	} else {
-		logger.Infof("[convert] generated VTT file for HLS")
+	// move the generated files to the new hash (oldHash saved at start of function)
+	if err := instance.MigrateSceneArtifacts(ctx, t.Scene.ID, oldHash, SceneArtifactsRename); err != nil {
+		logger.Warnf("[convert] failed to update generated files of HLS scene: %v", err)
 	}
 
 	// Mark conversion as successful - temp file will be moved, not deleted
@@ -384,14 +373,15 @@ func (t *ConvertHLSToMP4Task) testHardwareCodec(codec ffmpeg.VideoCodec) bool {
 	return err == nil
 }
 
-func (t *ConvertHLSToMP4Task) getVideoArgsForCodec(codec ffmpeg.VideoCodec, w, h int) ffmpeg.Args {
+func (t *ConvertHLSToMP4Task) getVideoArgsForCodec(codec ffmpeg.VideoCodec, w, h int, deinterlace models.DeinterlaceFilter) ffmpeg.Args {
 	var videoArgs ffmpeg.Args
 
+	var videoFilter ffmpeg.VideoFilter
+	videoFilter = videoFilter.Deinterlace(deinterlace)
 	if w != 0 && h != 0 {
-		var videoFilter ffmpeg.VideoFilter
 		videoFilter = videoFilter.ScaleDimensions(w, h)
-		videoArgs = videoArgs.VideoFilter(videoFilter)
 	}
+	videoArgs = videoArgs.VideoFilter(videoFilter)
 
 	switch codec {
 	case ffmpeg.VideoCodecN264, ffmpeg.VideoCodecN264H:
@@ -455,6 +445,8 @@ func (t *ConvertHLSToMP4Task) performConversionWithProgress(ctx context.Context,
 		return fmt.Errorf("error reading HLS video file: %w", err)
 	}
 
+	deinterlace := ffmpeg.DeinterlaceFilterFor(videoFile.Interlaced(), t.Scene.Deinterlace, t.Config.GetDeinterlaceFilter())
+
 	w, h := videoFile.Width, videoFile.Height
 	transcodeSize := t.Config.GetMaxTranscodeSize()
 
@@ -474,12 +466,18 @@ func (t *ConvertHLSToMP4Task) performConversionWithProgress(ctx context.Context,
 		"-avoid_negative_ts", "make_zero",
 	}
 
-	extraInputArgs := append(t.Config.GetTranscodeInputArgs(),
+	extraInputArgs, extraOutputArgs := ffmpeg.MergeTranscodeArgs(
+		t.Config.GetTranscodeInputArgs(),
+		t.Config.GetTranscodeOutputArgs(),
+		t.Scene.TranscodeArgs,
+	)
+
+	extraInputArgs = append(extraInputArgs,
 		"-fflags", "+genpts",
 		"-avoid_negative_ts", "make_zero",
 	)
 
-	extraOutputArgs := append(t.Config.GetTranscodeOutputArgs(),
+	extraOutputArgs = append(extraOutputArgs,
 		"-movflags", "+faststart",
 	)
 
@@ -488,7 +486,7 @@ func (t *ConvertHLSToMP4Task) performConversionWithProgress(ctx context.Context,
 	if hwCodec != nil {
 		logger.Infof("[convert] attempting hardware acceleration for HLS with codec: %s", hwCodec.Name)
 
-		videoArgs := t.getVideoArgsForCodec(*hwCodec, w, h)
+		videoArgs := t.getVideoArgsForCodec(*hwCodec, w, h, deinterlace)
 
 		args := transcoder.Transcode(inputPath, transcoder.TranscodeOptions{
 			OutputPath:      outputPath,
@@ -520,11 +518,12 @@ func (t *ConvertHLSToMP4Task) performConversionWithProgress(ctx context.Context,
 	}
 
 	var videoArgs ffmpeg.Args
+	var videoFilter ffmpeg.VideoFilter
+	videoFilter = videoFilter.Deinterlace(deinterlace)
 	if w != 0 && h != 0 {
-		var videoFilter ffmpeg.VideoFilter
 		videoFilter = videoFilter.ScaleDimensions(w, h)
-		videoArgs = videoArgs.VideoFilter(videoFilter)
 	}
+	videoArgs = videoArgs.VideoFilter(videoFilter)
 
 	videoArgs = append(videoArgs,
 		"-pix_fmt", "yuv420p",
@@ -730,47 +729,6 @@ func (t *ConvertHLSToMP4Task) recalculateFileHashes(ctx context.Context, file *m
 	return nil
 }
 
-func (t *ConvertHLSToMP4Task) generateVTTFile(ctx context.Context, file *models.VideoFile, filePath string) error {
-	// Check if VTT file already exists
-	sceneHash := t.Scene.GetHash(t.FileNamingAlgorithm)
-	vttPath := t.Paths.Scene.GetSpriteVttFilePath(sceneHash)
-
-	if _, err := os.Stat(vttPath); err == nil {
-		logger.Infof("[convert] VTT file already exists for HLS: %s", vttPath)
-		return nil
-	}
-
-	// Check if sprite image exists
-	spritePath := t.Paths.Scene.GetSpriteImageFilePath(sceneHash)
-	if _, err := os.Stat(spritePath); err != nil {
-		logger.Infof("[convert] sprite image does not exist for HLS, skipping VTT generation: %s", spritePath)
-		return nil
-	}
-
-	// Generate VTT file using the Generator
-	generator := &generate.Generator{
-		Encoder:      t.FFMpeg,
-		FFMpegConfig: t.Config,
-		LockManager:  t.G.LockManager,
-		ScenePaths:   t.Paths.Scene,
-	}
-
-	// Calculate step size for VTT generation
-	// Use default values similar to sprite generation
-	stepSize := 10.0 // Default step size in seconds
-	if file.Duration > 0 {
-		stepSize = file.Duration / 100.0 // Divide video into ~100 segments
-	}
-
-	logger.Infof("[convert] generating VTT file for HLS: %s", vttPath)
-	if err := generator.SpriteVTT(ctx, vttPath, spritePath, stepSize); err != nil {
-		return fmt.Errorf("failed to generate VTT file for HLS: %w", err)
-	}
-
-	logger.Infof("[convert] successfully generated VTT file for HLS: %s", vttPath)
-	return nil
-}
-
 func (t *ConvertHLSToMP4Task) monitorFileSize(filePath string, originalSize int64, progress *job.Progress, done chan bool) {
 	ticker := time.NewTicker(2 * time.Second) // Check every 2 seconds
 	defer ticker.Stop()
@@ -915,140 +873,3 @@ func (t *ConvertHLSToMP4Task) copyFileContent(src, dst string) error {
 	logger.Infof("[convert] successfully copied HLS file content from %s to %s", src, dst)
 	return nil
 }
-
-// regenerateSprites regenerates sprites for the scene after HLS conversion
-// NOTE: This function expects to be called within an existing transaction context
-func (t *ConvertHLSToMP4Task) regenerateSprites(ctx context.Context, oldHash string) error {
-	// Get updated scene from database with new hash
-	// Use the existing transaction context instead of creating a new one
-	updatedScene, err := t.Repository.Scene.Find(ctx, t.Scene.ID)
-	if err != nil {
-		return fmt.Errorf("failed to load updated scene: %w", err)
-	}
-
-	if updatedScene != nil {
-		if err := updatedScene.LoadFiles(ctx, t.Repository.Scene); err != nil {
-			return fmt.Errorf("failed to load scene files: %w", err)
-		}
-	}
-
-	if updatedScene == nil {
-		return fmt.Errorf("updated scene not found")
-	}
-
-	newHash := updatedScene.GetHash(t.FileNamingAlgorithm)
-	logger.Infof("[convert] HLS sprite migration: old hash=%s, new hash=%s", oldHash, newHash)
-
-	// Check if sprites exist for OLD hash
-	oldSpriteImagePath := t.Paths.Scene.GetSpriteImageFilePath(oldHash)
-	oldSpriteVttPath := t.Paths.Scene.GetSpriteVttFilePath(oldHash)
-
-	// Paths for NEW hash
-	newSpriteImagePath := t.Paths.Scene.GetSpriteImageFilePath(newHash)
-	newSpriteVttPath := t.Paths.Scene.GetSpriteVttFilePath(newHash)
-
-	logger.Infof("[convert] checking old HLS sprites:")
-	logger.Infof("[convert]   old image: %s", oldSpriteImagePath)
-	logger.Infof("[convert]   old vtt: %s", oldSpriteVttPath)
-	logger.Infof("[convert] new HLS sprite paths:")
-	logger.Infof("[convert]   new image: %s", newSpriteImagePath)
-	logger.Infof("[convert]   new vtt: %s", newSpriteVttPath)
-
-	oldSpriteImageExists := false
-	oldSpriteVttExists := false
-
-	if _, err := os.Stat(oldSpriteImagePath); err == nil {
-		oldSpriteImageExists = true
-		logger.Infof("[convert] old HLS sprite image exists")
-	} else {
-		logger.Infof("[convert] old HLS sprite image does not exist")
-	}
-
-	if _, err := os.Stat(oldSpriteVttPath); err == nil {
-		oldSpriteVttExists = true
-		logger.Infof("[convert] old HLS sprite vtt exists")
-	} else {
-		logger.Infof("[convert] old HLS sprite vtt does not exist")
-	}
-
-	// If both old sprites exist, rename them to new hash
-	if oldSpriteImageExists && oldSpriteVttExists {
-		logger.Infof("[convert] migrating existing HLS sprites from old hash to new hash")
-
-		// First, update VTT file content to reference new hash
-		if err := t.updateVttFileHash(oldSpriteVttPath, oldHash, newHash); err != nil {
-			logger.Warnf("[convert] failed to update HLS VTT file hash: %v", err)
-			// Continue with migration even if VTT update fails
-		} else {
-			logger.Infof("[convert] updated HLS VTT file to reference new hash")
-		}
-
-		// Rename sprite image
-		if err := os.Rename(oldSpriteImagePath, newSpriteImagePath); err != nil {
-			logger.Warnf("[convert] failed to rename HLS sprite image: %v", err)
-		} else {
-			logger.Infof("[convert] renamed HLS sprite image: %s -> %s", oldSpriteImagePath, newSpriteImagePath)
-		}
-
-		// Rename sprite vtt
-		if err := os.Rename(oldSpriteVttPath, newSpriteVttPath); err != nil {
-			logger.Warnf("[convert] failed to rename HLS sprite vtt: %v", err)
-		} else {
-			logger.Infof("[convert] renamed HLS sprite vtt: %s -> %s", oldSpriteVttPath, newSpriteVttPath)
-		}
-
-		logger.Infof("[convert] HLS sprite migration completed for scene %d", t.Scene.ID)
-		return nil
-	}
-
-	// If old sprites don't exist, check if new sprites already exist
-	newSpriteImageExists := false
-	newSpriteVttExists := false
-
-	if _, err := os.Stat(newSpriteImagePath); err == nil {
-		newSpriteImageExists = true
-	}
-
-	if _, err := os.Stat(newSpriteVttPath); err == nil {
-		newSpriteVttExists = true
-	}
-
-	if newSpriteImageExists && newSpriteVttExists {
-		logger.Infof("[convert] sprites already exist for new HLS hash, skipping regeneration")
-		return nil
-	}
-
-	// Generate new sprites
-	logger.Infof("[convert] generating new sprites for HLS scene %d", t.Scene.ID)
-	spriteTask := GenerateSpriteTask{
-		Scene:               *updatedScene, // Use updated scene with new hash
-		Overwrite:           true,          // Force regeneration with new hash
-		fileNamingAlgorithm: t.FileNamingAlgorithm,
-	}
-
-	// Run sprite generation
-	spriteTask.Start(ctx)
-	logger.Infof("[convert] generated new sprites for HLS scene %d with hash %s", t.Scene.ID, newHash)
-	return nil
-}
-
-// updateVttFileHash updates the VTT file to replace old hash with new hash in image references
-func (t *ConvertHLSToMP4Task) updateVttFileHash(vttPath, oldHash, newHash string) error {
-	// Read VTT file content
-	content, err := os.ReadFile(vttPath)
-	if err != nil {
-		return fmt.Errorf("failed to read VTT file: %w", err)
-	}
-
-	// Replace old hash with new hash in the content
-	oldContent := string(content)
-	newContent := strings.ReplaceAll(oldContent, oldHash, newHash)
-
-	// Write updated content back to VTT file
-	if err := os.WriteFile(vttPath, []byte(newContent), 0644); err != nil {
-		return fmt.Errorf("failed to write updated VTT file: %w", err)
-	}
-
-	logger.Infof("[convert] updated HLS VTT file: replaced %s with %s", oldHash, newHash)
-	return nil
-}
@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// subtitledDownloadExpiry is how long a video rendered with subtitles can be
+// downloaded before it is removed.
+const subtitledDownloadExpiry = 6 * time.Hour
+
+// CaptionSelection selects a caption of the primary file of a scene.
+type CaptionSelection struct {
+	LanguageCode string
+	CaptionType  string
+}
+
+// SubtitledDownload is a video being rendered with subtitles for download.
+type SubtitledDownload struct {
+	JobID        int
+	DownloadHash string
+	// Filename is the name the file is downloaded as.
+	Filename string
+}
+
+// DownloadWithSubtitles starts a job that renders the primary file of the
+// scene to a temporary mp4 file with the selected captions, for devices that
+// do not support external subtitles. The file can be downloaded once the job
+// has finished, and is removed after subtitledDownloadExpiry. Only one
+// caption can be burned in.
+func (s *Manager) DownloadWithSubtitles(ctx context.Context, sceneID int, selected []CaptionSelection, mode ffmpeg.SubtitleMode) (*SubtitledDownload, error) {
+	if len(selected) == 0 {
+		return nil, errors.New("no captions selected")
+	}
+	if mode == ffmpeg.SubtitleModeBurnIn && len(selected) > 1 {
+		return nil, errors.New("only one caption can be burned in")
+	}
+
+	if s.FFMpeg == nil || s.FFProbe == nil {
+		return nil, errors.New("ffmpeg is not configured")
+	}
+
+	r := s.Repository
+
+	var (
+		primaryFile *models.VideoFile
+		captions    []*models.VideoCaption
+	)
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scene, err := r.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if err := scene.LoadPrimaryFile(ctx, r.File); err != nil {
+			return err
+		}
+
+		primaryFile = scene.Files.Primary()
+		if primaryFile == nil {
+			return fmt.Errorf("scene %d has no files", sceneID)
+		}
+
+		captions, err = r.File.GetCaptions(ctx, primaryFile.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var subtitles []ffmpeg.Subtitle
+	for _, sel := range selected {
+		var found *models.VideoCaption
+		for _, c := range captions {
+			if c.LanguageCode == sel.LanguageCode && c.CaptionType == sel.CaptionType {
+				found = c
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, fmt.Errorf("caption %s (%s) not found", sel.LanguageCode, sel.CaptionType)
+		}
+
+		subtitles = append(subtitles, ffmpeg.Subtitle{
+			Path:         found.Path(primaryFile.Path),
+			LanguageCode: found.LanguageCode,
+		})
+	}
+
+	if err := fsutil.EnsureDir(s.Paths.Generated.Downloads); err != nil {
+		return nil, err
+	}
+
+	out, err := os.CreateTemp(s.Paths.Generated.Downloads, "subtitled*.mp4")
+	if err != nil {
+		return nil, err
+	}
+	out.Close()
+
+	downloadHash, err := s.DownloadStore.RegisterFile(out.Name(), "video/mp4", true)
+	if err != nil {
+		os.Remove(out.Name())
+		return nil, fmt.Errorf("error registering file for download: %w", err)
+	}
+
+	// the file is registered before it is rendered, so that the download
+	// path can be returned, and is not served until it is complete
+	if err := os.Remove(out.Name()); err != nil {
+		s.DownloadStore.RemoveFile(downloadHash)
+		return nil, err
+	}
+
+	t := &DownloadWithSubtitlesTask{
+		FFMpeg:       s.FFMpeg,
+		FFProbe:      s.FFProbe,
+		InputPath:    primaryFile.Path,
+		Subtitles:    subtitles,
+		Mode:         mode,
+		OutputPath:   out.Name(),
+		DownloadHash: downloadHash,
+	}
+
+	base := strings.TrimSuffix(primaryFile.Basename, filepath.Ext(primaryFile.Basename))
+	jobID := s.JobManager.Add(ctx, fmt.Sprintf("Rendering %s with subtitles...", primaryFile.Basename), t)
+
+	return &SubtitledDownload{
+		JobID:        jobID,
+		DownloadHash: downloadHash,
+		Filename:     base + ".mp4",
+	}, nil
+}
+
+// DownloadWithSubtitlesTask renders a video file with subtitles to a
+// registered download.
+type DownloadWithSubtitlesTask struct {
+	FFMpeg       *ffmpeg.FFMpeg
+	FFProbe      *ffmpeg.FFProbe
+	InputPath    string
+	Subtitles    []ffmpeg.Subtitle
+	Mode         ffmpeg.SubtitleMode
+	OutputPath   string
+	DownloadHash string
+}
+
+func (t *DownloadWithSubtitlesTask) Execute(ctx context.Context, progress *job.Progress) error {
+	if err := t.render(ctx); err != nil {
+		instance.DownloadStore.RemoveFile(t.DownloadHash)
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+		return err
+	}
+
+	instance.DownloadStore.ExpireFile(t.DownloadHash, subtitledDownloadExpiry)
+	logger.Infof("[subtitles] rendered %s with subtitles to %s", t.InputPath, t.OutputPath)
+	return nil
+}
+
+func (t *DownloadWithSubtitlesTask) render(ctx context.Context) error {
+	videoFile, err := t.FFProbe.NewVideoFile(t.InputPath)
+	if err != nil {
+		return fmt.Errorf("error reading video file: %w", err)
+	}
+
+	// render to a partial file, so that an incomplete file is never served
+	partialPath := t.OutputPath + ".part"
+	defer os.Remove(partialPath)
+
+	args := ffmpeg.SubtitledVideoArgs(videoFile, t.Subtitles, t.Mode, partialPath)
+	if err := t.FFMpeg.Command(ctx, args).Run(); err != nil {
+		return fmt.Errorf("ffmpeg rendering subtitles failed: %w", err)
+	}
+
+	return os.Rename(partialPath, t.OutputPath)
+}
@@ -440,6 +440,8 @@ func fileToJSON(f models.File) jsonschema.DirEntry {
 			Duration:         ff.Duration,
 			VideoCodec:       ff.VideoCodec,
 			AudioCodec:       ff.AudioCodec,
+			AudioLanguages:   ff.AudioLanguages,
+			Interlaced:       ff.Interlaced,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
@@ -564,6 +566,14 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		notes, err := r.Note.FindByObject(ctx, models.NoteObject{SceneID: &s.ID})
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene notes: %v", sceneHash, err)
+			continue
+		}
+
+		newSceneJSON.Notes = jsonschema.NotesToJSON(notes)
+
 		if t.includeDependencies {
 			if s.StudioID != nil {
 				t.studios.IDs = sliceutil.AppendUnique(t.studios.IDs, *s.StudioID)
@@ -936,6 +946,14 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 
 		newPerformerJSON.Tags = tag.GetNames(tags)
 
+		notes, err := r.Note.FindByObject(ctx, models.NoteObject{PerformerID: &p.ID})
+		if err != nil {
+			logger.Errorf("[performers] <%s> error getting performer notes: %v", p.Name, err)
+			continue
+		}
+
+		newPerformerJSON.Notes = jsonschema.NotesToJSON(notes)
+
 		if t.includeDependencies {
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
@@ -1010,6 +1028,14 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 
 		newStudioJSON.Tags = tag.GetNames(tags)
 
+		notes, err := r.Note.FindByObject(ctx, models.NoteObject{StudioID: &s.ID})
+		if err != nil {
+			logger.Errorf("[studios] <%s> error getting studio notes: %v", s.Name, err)
+			continue
+		}
+
+		newStudioJSON.Notes = jsonschema.NotesToJSON(notes)
+
 		if t.includeDependencies {
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
@@ -21,17 +21,23 @@ type GenerateMetadataInput struct {
 	Sprites             bool                         `json:"sprites"`
 	Previews            bool                         `json:"previews"`
 	ImagePreviews       bool                         `json:"imagePreviews"`
+	WallPreviews        bool                         `json:"wallPreviews"`
 	PreviewOptions      *GeneratePreviewOptionsInput `json:"previewOptions"`
 	Markers             bool                         `json:"markers"`
 	MarkerImagePreviews bool                         `json:"markerImagePreviews"`
 	MarkerScreenshots   bool                         `json:"markerScreenshots"`
+	MarkerThumbnails    bool                         `json:"markerThumbnails"`
 	Transcodes          bool                         `json:"transcodes"`
 	// Generate transcodes even if not required
-	ForceTranscodes           bool `json:"forceTranscodes"`
+	ForceTranscodes bool `json:"forceTranscodes"`
+	// Apply the saved scene video filters and transforms to generated transcodes
+	TranscodeVideoFilters     bool `json:"transcodeVideoFilters"`
 	Phashes                   bool `json:"phashes"`
 	InteractiveHeatmapsSpeeds bool `json:"interactiveHeatmapsSpeeds"`
 	ClipPreviews              bool `json:"clipPreviews"`
 	ImageThumbnails           bool `json:"imageThumbnails"`
+	// Rules skipping previews and sprites for some scenes
+	Exclusions *models.GenerateExclusions `json:"exclusions"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
@@ -62,6 +68,15 @@ type GenerateJob struct {
 	overwrite      bool
 	fileNamingAlgo models.HashAlgorithm
 
+	thumbnailProfiles []image.ThumbnailProfile
+
+	previewExclusion *sceneExclusion
+	spriteExclusion  *sceneExclusion
+
+	// cursor tracks the completed tasks so that the job can be resumed.
+	// Nil if the job is not resumable.
+	cursor *job.Cursor
+
 	totals totalsGenerate
 }
 
@@ -70,12 +85,16 @@ type totalsGenerate struct {
 	sprites                  int64
 	previews                 int64
 	imagePreviews            int64
+	wallPreviews             int64
 	markers                  int64
 	transcodes               int64
 	phashes                  int64
 	interactiveHeatmapSpeeds int64
 	clipPreviews             int64
 	imageThumbnails          int64
+	// excluded counts the scenes whose previews or sprites were skipped
+	// by the exclusions
+	excluded int64
 
 	tasks int
 }
@@ -117,6 +136,10 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 
 		r := j.repository
 		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			if err := j.resolveExclusions(ctx); err != nil {
+				return err
+			}
+
 			qb := r.Scene
 			if len(j.input.SceneIDs) == 0 && len(j.input.MarkerIDs) == 0 {
 				j.queueTasks(ctx, g, queue)
@@ -163,6 +186,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.ImagePreviews {
 			logMsg += fmt.Sprintf(" %d image previews", totals.imagePreviews)
 		}
+		if j.input.WallPreviews {
+			logMsg += fmt.Sprintf(" %d wall previews", totals.wallPreviews)
+		}
 		if j.input.Markers {
 			logMsg += fmt.Sprintf(" %d markers", totals.markers)
 		}
@@ -184,6 +210,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if logMsg == "Generating" {
 			logMsg = "Nothing selected to generate"
 		}
+		if totals.excluded > 0 {
+			logMsg += fmt.Sprintf(" (skipped previews or sprites of %d excluded scenes)", totals.excluded)
+		}
 		logger.Infof(logMsg)
 
 		progress.SetTotal(int(totals.tasks))
@@ -203,17 +232,40 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		}
 	}()
 
+	skip := 0
+	if j.cursor != nil {
+		skip = j.cursor.Position()
+		if skip > 0 {
+			logger.Infof("Resuming generate after %d tasks", skip)
+		}
+	}
+
+	index := 0
 	for f := range queue {
 		if job.IsCancelled(ctx) {
 			break
 		}
 
+		taskIndex := index
+		index++
+		if taskIndex < skip {
+			progress.Increment()
+			continue
+		}
+
 		wg.Add()
 		// #1879 - need to make a copy of f - otherwise there is a race condition
 		// where f is changed when the goroutine runs
 		localTask := f
 		go progress.ExecuteTask(localTask.GetDescription(), func() {
 			localTask.Start(ctx)
+			if bt, ok := localTask.(TaskWithBytesProcessed); ok {
+				progress.AddBytes(bt.GetBytesProcessed())
+			}
+			// don't mark tasks as done if they were interrupted
+			if j.cursor != nil && !job.IsCancelled(ctx) {
+				j.cursor.Done(taskIndex)
+			}
 			wg.Done()
 			progress.Increment()
 		})
@@ -231,6 +283,39 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 	return nil
 }
 
+// resolveExclusions resolves the tags of the preview and sprite exclusions.
+func (j *GenerateJob) resolveExclusions(ctx context.Context) error {
+	exclusions := j.input.Exclusions
+	if exclusions == nil {
+		return nil
+	}
+
+	var err error
+	j.previewExclusion, err = newSceneExclusion(ctx, j.repository.Tag, exclusions.Previews)
+	if err != nil {
+		return fmt.Errorf("resolving preview exclusions: %w", err)
+	}
+
+	j.spriteExclusion, err = newSceneExclusion(ctx, j.repository.Tag, exclusions.Sprites)
+	if err != nil {
+		return fmt.Errorf("resolving sprite exclusions: %w", err)
+	}
+
+	return nil
+}
+
+// isExcluded returns true if the scene matches the exclusion, logging
+// errors as not excluded.
+func (j *GenerateJob) isExcluded(ctx context.Context, e *sceneExclusion, s *models.Scene) bool {
+	excluded, err := e.excludes(ctx, j.repository.Scene, s)
+	if err != nil {
+		logger.Errorf("Error checking generate exclusions of scene %d: %v", s.ID, err)
+		return false
+	}
+
+	return excluded
+}
+
 func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, queue chan<- Task) {
 	j.totals = totalsGenerate{}
 
@@ -244,6 +329,7 @@ func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generato
 	findFilter := models.BatchFindFilter(batchSize)
 
 	r := j.repository
+	stashPaths := config.GetInstance().GetStashPaths()
 
 	for more := true; more; {
 		if job.IsCancelled(ctx) {
@@ -266,6 +352,11 @@ func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generato
 				return
 			}
 
+			// ffmpeg cannot read files in rclone stashes
+			if stashPaths.IsRemotePath(ss.Path) {
+				continue
+			}
+
 			j.queueSceneJobs(ctx, g, ss, queue)
 		}
 
@@ -283,6 +374,8 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 	findFilter := models.BatchFindFilter(batchSize)
 
 	r := j.repository
+	stashPaths := config.GetInstance().GetStashPaths()
+	j.thumbnailProfiles = config.GetInstance().GetImageThumbnailProfiles()
 
 	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
 		if job.IsCancelled(ctx) {
@@ -305,6 +398,10 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 				return
 			}
 
+			if stashPaths.IsRemotePath(ss.Path) {
+				continue
+			}
+
 			j.queueImageJob(g, ss, queue)
 		}
 
@@ -351,15 +448,28 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 	return ret
 }
 
+func getWallPreviewOptions() generate.WallPreviewOptions {
+	config := config.GetInstance()
+
+	return generate.WallPreviewOptions{
+		Height: config.GetWallPreviewHeight(),
+		CRF:    config.GetWallPreviewCRF(),
+		Preset: config.GetWallPreviewPreset().String(),
+		Audio:  config.GetPreviewAudio(),
+	}
+}
+
 func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task) {
 	r := j.repository
 
+	excludeSprites := j.input.Sprites && j.isExcluded(ctx, j.spriteExclusion, scene)
+	excludePreviews := j.input.Previews && j.isExcluded(ctx, j.previewExclusion, scene)
+	if excludeSprites || excludePreviews {
+		j.totals.excluded++
+	}
+
 	if j.input.Covers {
-		task := &GenerateCoverTask{
-			repository: r,
-			Scene:      *scene,
-			Overwrite:  j.overwrite,
-		}
+		task := newCoverTask(r, *scene, j.overwrite)
 
 		if task.required(ctx) {
 			j.totals.covers++
@@ -368,7 +478,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Sprites {
+	if j.input.Sprites && !excludeSprites {
 		task := &GenerateSpriteTask{
 			Scene:               *scene,
 			Overwrite:           j.overwrite,
@@ -388,11 +498,13 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 	}
 	options := getGeneratePreviewOptions(*generatePreviewOptions)
 
-	if j.input.Previews {
+	if j.input.Previews && !excludePreviews {
 		task := &GeneratePreviewTask{
 			Scene:               *scene,
 			ImagePreview:        j.input.ImagePreviews,
+			WallPreview:         j.input.WallPreviews,
 			Options:             options,
+			WallOptions:         getWallPreviewOptions(),
 			Overwrite:           j.overwrite,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			generator:           g,
@@ -405,6 +517,9 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			if task.imagePreviewRequired() {
 				j.totals.imagePreviews++
 			}
+			if task.wallPreviewRequired() {
+				j.totals.wallPreviews++
+			}
 
 			j.totals.tasks++
 			queue <- task
@@ -419,6 +534,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			ImagePreview:        j.input.MarkerImagePreviews,
 			Screenshot:          j.input.MarkerScreenshots,
+			Thumbnail:           j.input.MarkerThumbnails,
 
 			generator: g,
 		}
@@ -438,6 +554,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Scene:               *scene,
 			Overwrite:           j.overwrite,
 			Force:               forceTranscode,
+			ApplyVideoFilters:   j.input.TranscodeVideoFilters,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			g:                   g,
 		}
@@ -488,6 +605,9 @@ func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.Scene
 		Marker:              marker,
 		Overwrite:           j.overwrite,
 		fileNamingAlgorithm: j.fileNamingAlgo,
+		ImagePreview:        j.input.MarkerImagePreviews,
+		Screenshot:          j.input.MarkerScreenshots,
+		Thumbnail:           j.input.MarkerThumbnails,
 		generator:           g,
 	}
 	j.totals.markers++
@@ -500,6 +620,7 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 		task := &GenerateImageThumbnailTask{
 			Image:     *image,
 			Overwrite: j.overwrite,
+			Profiles:  j.thumbnailProfiles,
 		}
 
 		if task.required() {
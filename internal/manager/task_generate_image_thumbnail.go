@@ -14,6 +14,11 @@ import (
 type GenerateImageThumbnailTask struct {
 	Image     models.Image
 	Overwrite bool
+	// Profiles are the thumbnail profiles generated in addition to the
+	// default thumbnail.
+	Profiles []image.ThumbnailProfile
+	// ProfilesOnly skips the default thumbnail.
+	ProfilesOnly bool
 }
 
 func (t *GenerateImageThumbnailTask) GetDescription() string {
@@ -34,8 +39,6 @@ func (t *GenerateImageThumbnailTask) Start(ctx context.Context) {
 		return
 	}
 
-	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(t.Image.Checksum, models.DefaultGthumbWidth)
-
 	logger.Debugf("Generating thumbnail for %s", path)
 
 	mgr := GetInstance()
@@ -48,24 +51,52 @@ func (t *GenerateImageThumbnailTask) Start(ctx context.Context) {
 	}
 
 	encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, clipPreviewOptions)
-	data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
 
-	if err != nil {
-		// don't log for animated images
-		if !errors.Is(err, image.ErrNotSupportedForThumbnail) {
-			logger.Errorf("[generator] getting thumbnail for image %s: %w", path, err)
+	if t.defaultRequired() {
+		thumbPath := mgr.Paths.Generated.GetThumbnailPath(t.Image.Checksum, models.DefaultGthumbWidth)
+		data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
+		if err != nil {
+			// don't log for animated images
+			if !errors.Is(err, image.ErrNotSupportedForThumbnail) {
+				logger.Errorf("[generator] getting thumbnail for image %s: %w", path, err)
+			}
+			return
+		}
+
+		err = fsutil.WriteFile(thumbPath, data)
+		if err != nil {
+			logger.Errorf("[generator] writing thumbnail for image %s: %w", path, err)
+			return
 		}
-		return
 	}
 
-	err = fsutil.WriteFile(thumbPath, data)
-	if err != nil {
-		logger.Errorf("[generator] writing thumbnail for image %s: %w", path, err)
-		return
+	for _, p := range t.requiredProfiles() {
+		thumbPath := mgr.Paths.Generated.GetThumbnailProfilePath(t.Image.Checksum, p.Key(), p.Format.Extension())
+		data, err := encoder.GetProfileThumbnail(f, p)
+		if err != nil {
+			// don't log for animated images
+			if !errors.Is(err, image.ErrNotSupportedForThumbnail) {
+				logger.Errorf("[generator] getting %s thumbnail for image %s: %v", p.Key(), path, err)
+			}
+			return
+		}
+
+		if err := fsutil.WriteFile(thumbPath, data); err != nil {
+			logger.Errorf("[generator] writing %s thumbnail for image %s: %v", p.Key(), path, err)
+			return
+		}
 	}
 }
 
 func (t *GenerateImageThumbnailTask) required() bool {
+	return t.defaultRequired() || len(t.requiredProfiles()) > 0
+}
+
+func (t *GenerateImageThumbnailTask) defaultRequired() bool {
+	if t.ProfilesOnly {
+		return false
+	}
+
 	vf, ok := t.Image.Files.Primary().(models.VisualFile)
 	if !ok {
 		return false
@@ -84,3 +115,25 @@ func (t *GenerateImageThumbnailTask) required() bool {
 
 	return !exists
 }
+
+// requiredProfiles returns the profiles whose thumbnails need to be
+// generated.
+func (t *GenerateImageThumbnailTask) requiredProfiles() []image.ThumbnailProfile {
+	if _, ok := t.Image.Files.Primary().(models.VisualFile); !ok {
+		return nil
+	}
+
+	if t.Overwrite {
+		return t.Profiles
+	}
+
+	var ret []image.ThumbnailProfile
+	for _, p := range t.Profiles {
+		thumbPath := GetInstance().Paths.Generated.GetThumbnailProfilePath(t.Image.Checksum, p.Key(), p.Format.Extension())
+		if exists, _ := fsutil.FileExists(thumbPath); !exists {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
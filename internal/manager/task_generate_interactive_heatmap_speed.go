@@ -15,6 +15,8 @@ type GenerateInteractiveHeatmapSpeedTask struct {
 	Scene               models.Scene
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
+	// HeatmapOptions overrides the heatmap options in the configuration.
+	HeatmapOptions *HeatmapOptions
 }
 
 func (t *GenerateInteractiveHeatmapSpeedTask) GetDescription() string {
@@ -35,9 +37,12 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 	videoChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
 	funscriptPath := video.GetFunscriptPath(t.Scene.Path)
 	heatmapPath := instance.Paths.Scene.GetInteractiveHeatmapPath(videoChecksum)
-	drawRange := instance.Config.GetDrawFunscriptHeatmapRange()
+	options := HeatmapOptionsFromConfig(instance.Config)
+	if t.HeatmapOptions != nil {
+		options = *t.HeatmapOptions
+	}
 
-	generator := NewInteractiveHeatmapSpeedGenerator(drawRange)
+	generator := NewInteractiveHeatmapSpeedGenerator(options)
 
 	err := generator.Generate(funscriptPath, heatmapPath, t.Scene.Files.Primary().Duration)
 
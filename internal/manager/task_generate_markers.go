@@ -20,6 +20,7 @@ type GenerateMarkersTask struct {
 
 	ImagePreview bool
 	Screenshot   bool
+	Thumbnail    bool
 
 	generator *generate.Generator
 }
@@ -139,6 +140,13 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *models.VideoFile, scene
 			logErrorOutput(err)
 		}
 	}
+
+	if t.Thumbnail {
+		if err := g.SceneMarkerThumbnail(context.TODO(), videoFile.Path, sceneHash, seconds); err != nil {
+			logger.Errorf("[generator] failed to generate marker thumbnail: %v", err)
+			logErrorOutput(err)
+		}
+	}
 }
 
 func (t *GenerateMarkersTask) markersNeeded(ctx context.Context) int {
@@ -173,8 +181,9 @@ func (t *GenerateMarkersTask) markerExists(sceneChecksum string, seconds int) bo
 	videoExists := t.videoExists(sceneChecksum, seconds)
 	imageExists := !t.ImagePreview || t.imageExists(sceneChecksum, seconds)
 	screenshotExists := !t.Screenshot || t.screenshotExists(sceneChecksum, seconds)
+	thumbnailExists := !t.Thumbnail || t.thumbnailExists(sceneChecksum, seconds)
 
-	return videoExists && imageExists && screenshotExists
+	return videoExists && imageExists && screenshotExists && thumbnailExists
 }
 
 func (t *GenerateMarkersTask) videoExists(sceneChecksum string, seconds int) bool {
@@ -209,3 +218,14 @@ func (t *GenerateMarkersTask) screenshotExists(sceneChecksum string, seconds int
 
 	return screenshotExists
 }
+
+func (t *GenerateMarkersTask) thumbnailExists(sceneChecksum string, seconds int) bool {
+	if sceneChecksum == "" {
+		return false
+	}
+
+	thumbnailPath := instance.Paths.SceneMarkers.GetThumbnailPath(sceneChecksum, seconds)
+	thumbnailExists, _ := fsutil.FileExists(thumbnailPath)
+
+	return thumbnailExists
+}
@@ -15,6 +15,8 @@ type GeneratePhashTask struct {
 	File                *models.VideoFile
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
+
+	bytesProcessed int64
 }
 
 func (t *GeneratePhashTask) GetDescription() string {
@@ -57,6 +59,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		}
 
 		hash = int64(*generated)
+		t.bytesProcessed = t.File.Size
 	}
 
 	r := t.repository
@@ -67,9 +70,37 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		})
 
 		return r.File.Update(ctx, t.File)
-	}); err != nil && ctx.Err() == nil {
-		logger.Errorf("Error setting phash: %v", err)
+	}); err != nil {
+		if ctx.Err() == nil {
+			logger.Errorf("Error setting phash: %v", err)
+		}
+		return
+	}
+
+	t.indexPhash(ctx)
+}
+
+// indexPhash adds the new phash to the phash index for each scene of the
+// file.
+func (t *GeneratePhashTask) indexPhash(ctx context.Context) {
+	r := t.repository
+	var scenes []*models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		scenes, err = r.Scene.FindByFileID(ctx, t.File.ID)
+		return err
+	}); err != nil {
+		logger.Warnf("Error finding scenes of %s for phash index: %v", t.File.Path, err)
+		return
 	}
+
+	for _, s := range scenes {
+		instance.indexPhash(s.ID, t.File)
+	}
+}
+
+func (t *GeneratePhashTask) GetBytesProcessed() int64 {
+	return t.bytesProcessed
 }
 
 func (t *GeneratePhashTask) findExistingPhash(ctx context.Context) (interface{}, error) {
@@ -13,8 +13,10 @@ import (
 type GeneratePreviewTask struct {
 	Scene        models.Scene
 	ImagePreview bool
+	WallPreview  bool
 
-	Options generate.PreviewOptions
+	Options     generate.PreviewOptions
+	WallOptions generate.WallPreviewOptions
 
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
@@ -23,6 +25,7 @@ type GeneratePreviewTask struct {
 
 	videoPreviewExists *bool
 	imagePreviewExists *bool
+	wallPreviewExists  *bool
 }
 
 func (t *GeneratePreviewTask) GetDescription() string {
@@ -59,6 +62,13 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 			logErrorOutput(err)
 		}
 	}
+
+	if t.wallPreviewRequired() {
+		if err := t.generator.PreviewWall(context.TODO(), t.Scene.Path, videoChecksum, t.WallOptions); err != nil {
+			logger.Errorf("error generating wall preview: %v", err)
+			logErrorOutput(err)
+		}
+	}
 }
 
 func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration float64, videoFrameRate float64) error {
@@ -86,7 +96,7 @@ func (t *GeneratePreviewTask) generateWebp(videoChecksum string) error {
 }
 
 func (t *GeneratePreviewTask) required() bool {
-	return t.videoPreviewRequired() || t.imagePreviewRequired()
+	return t.videoPreviewRequired() || t.imagePreviewRequired() || t.wallPreviewRequired()
 }
 
 func (t *GeneratePreviewTask) videoPreviewRequired() bool {
@@ -136,3 +146,29 @@ func (t *GeneratePreviewTask) imagePreviewRequired() bool {
 
 	return !*t.imagePreviewExists
 }
+
+func (t *GeneratePreviewTask) wallPreviewRequired() bool {
+	if !t.WallPreview {
+		return false
+	}
+
+	if t.Scene.Path == "" {
+		return false
+	}
+
+	if t.Overwrite {
+		return true
+	}
+
+	sceneChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
+	if sceneChecksum == "" {
+		return false
+	}
+
+	if t.wallPreviewExists == nil {
+		wallExists, _ := fsutil.FileExists(instance.Paths.Scene.GetWallPreviewPath(sceneChecksum))
+		t.wallPreviewExists = &wallExists
+	}
+
+	return !*t.wallPreviewExists
+}
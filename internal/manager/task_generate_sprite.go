@@ -37,10 +37,11 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 		return
 	}
 
+	options := spriteOptionsFromConfig(instance.Config)
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
-	imagePath := instance.Paths.Scene.GetSpriteImageFilePath(sceneHash)
+	imagePath := spriteImageFilePath(instance.Paths.Scene, sceneHash, options.Format)
 	vttPath := instance.Paths.Scene.GetSpriteVttFilePath(sceneHash)
-	generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, 9, 9)
+	generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, options)
 
 	if err != nil {
 		logger.Errorf("error creating sprite generator: %s", err.Error())
@@ -74,7 +75,7 @@ func (t *GenerateSpriteTask) doesSpriteExist(sceneChecksum string) bool {
 		return false
 	}
 
-	imageExists, _ := fsutil.FileExists(instance.Paths.Scene.GetSpriteImageFilePath(sceneChecksum))
+	imageExists, _ := fsutil.FileExists(spriteImageFilePath(instance.Paths.Scene, sceneChecksum, instance.Config.GetSpriteFormat()))
 	vttExists, _ := fsutil.FileExists(instance.Paths.Scene.GetSpriteVttFilePath(sceneChecksum))
 	return imageExists && vttExists
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
@@ -80,6 +81,12 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 				return fmt.Errorf("scene with id %d not found", id)
 			}
 
+			if scene.Locked && !j.input.IncludeLocked {
+				logger.Infof("Skipping locked scene %s", scene.Path)
+				progress.Increment()
+				continue
+			}
+
 			j.identifyScene(ctx, scene, sources)
 		}
 
@@ -91,6 +98,34 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 	return nil
 }
 
+// PreviewIdentify returns the changes that identifying the scene with the
+// given input would make, without making them.
+func PreviewIdentify(ctx context.Context, sceneID int, input identify.Options) (*identify.Preview, error) {
+	j := CreateIdentifyJob(input)
+
+	sources, err := j.getSources()
+	if err != nil {
+		return nil, err
+	}
+
+	r := instance.Repository
+
+	var s *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.Scene.Find(ctx, sceneID)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("finding scene id %d: %w", sceneID, err)
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("%w: scene with id %d", models.ErrNotFound, sceneID)
+	}
+
+	return j.sceneIdentifier(sources).Preview(ctx, s)
+}
+
 func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.ScraperSource) error {
 	r := instance.Repository
 
@@ -99,6 +134,11 @@ func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.
 	sceneFilter := scene.FilterFromPaths(j.input.Paths)
 	sceneFilter.Organized = &organised
 
+	if !j.input.IncludeLocked {
+		locked := false
+		sceneFilter.Locked = &locked
+	}
+
 	sort := "path"
 	findFilter := &models.FindFilterType{
 		Sort: &sort,
@@ -137,32 +177,50 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 
 	var taskError error
 	j.progress.ExecuteTask("Identifying "+s.Path, func() {
-		r := instance.Repository
-		task := identify.SceneIdentifier{
-			TxnManager:         r.TxnManager,
-			SceneReaderUpdater: r.Scene,
-			StudioReaderWriter: r.Studio,
-			PerformerCreator:   r.Performer,
-			TagFinderCreator:   r.Tag,
-
-			DefaultOptions:              j.input.Options,
-			Sources:                     sources,
-			SceneUpdatePostHookExecutor: j.postHookExecutor,
-		}
-
-		taskError = task.Identify(ctx, s)
+		taskError = j.sceneIdentifier(sources).Identify(ctx, s)
 	})
 
 	if taskError != nil {
 		logger.Errorf("Error encountered identifying %s: %v", s.Path, taskError)
 	}
 
+	j.progress.ItemDone(strconv.Itoa(s.ID), taskError)
 	j.progress.Increment()
 }
 
+// Retry returns a job that identifies only the scenes with the given IDs,
+// using the same sources and options.
+func (j *IdentifyJob) Retry(ids []string) job.JobExec {
+	input := j.input
+	input.SceneIDs = ids
+	input.Paths = nil
+
+	return &IdentifyJob{
+		postHookExecutor: j.postHookExecutor,
+		input:            input,
+		stashBoxes:       j.stashBoxes,
+	}
+}
+
+func (j *IdentifyJob) sceneIdentifier(sources []identify.ScraperSource) *identify.SceneIdentifier {
+	r := instance.Repository
+	return &identify.SceneIdentifier{
+		TxnManager:         r.TxnManager,
+		SceneReaderUpdater: r.Scene,
+		StudioReaderWriter: r.Studio,
+		PerformerCreator:   r.Performer,
+		TagFinderCreator:   r.Tag,
+		PerformerGetter:    r.Performer,
+
+		DefaultOptions:              j.input.Options,
+		Sources:                     sources,
+		SceneUpdatePostHookExecutor: j.postHookExecutor,
+	}
+}
+
 func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	var ret []identify.ScraperSource
-	for _, source := range j.input.Sources {
+	for _, source := range identify.SortSources(j.input.Sources) {
 		// get scraper source
 		stashBox, err := j.getStashBox(source.Source)
 		if err != nil {
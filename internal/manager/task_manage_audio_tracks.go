@@ -0,0 +1,206 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+// ManageAudioTracksTask removes, reorders and extracts the audio tracks of a
+// scene video file. Streams are copied without re-encoding. The remuxed file
+// replaces the original in place, and generated files are migrated to the new
+// scene hash.
+type ManageAudioTracksTask struct {
+	Repository            models.Repository
+	FFMpeg                *ffmpeg.FFMpeg
+	FFProbe               *ffmpeg.FFProbe
+	Paths                 *paths.Paths
+	FileNamingAlgorithm   models.HashAlgorithm
+	FingerprintCalculator interface {
+		CalculateFingerprints(f *models.BaseFile, o file.Opener, useExisting bool) ([]models.Fingerprint, error)
+	}
+
+	Scene models.Scene
+	File  *models.VideoFile
+
+	// Audio tracks of the remuxed file. If nil, the file is not remuxed.
+	Tracks *ffmpeg.AudioTrackOptions
+	// Indexes of audio tracks to extract to sidecar files next to the video file
+	Extract []int
+}
+
+func (t *ManageAudioTracksTask) GetDescription() string {
+	return fmt.Sprintf("Managing audio tracks of %s", t.File.Path)
+}
+
+func (t *ManageAudioTracksTask) Execute(ctx context.Context, progress *job.Progress) error {
+	probe, err := t.FFProbe.NewVideoFile(t.File.Path)
+	if err != nil {
+		return fmt.Errorf("reading video file: %w", err)
+	}
+
+	tracks := probe.AudioTracks()
+	for _, i := range t.Extract {
+		if i < 0 || i >= len(tracks) {
+			return fmt.Errorf("audio track %d does not exist", i)
+		}
+	}
+
+	if t.Tracks != nil {
+		if err := t.Tracks.Validate(len(tracks)); err != nil {
+			return err
+		}
+	}
+
+	total := len(t.Extract)
+	if t.Tracks != nil {
+		total++
+	}
+	progress.SetTotal(total)
+
+	for _, i := range t.Extract {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		var err error
+		progress.ExecuteTask(fmt.Sprintf("Extracting audio track %d", i), func() {
+			err = t.extract(ctx, tracks[i])
+		})
+		progress.Increment()
+
+		if err != nil {
+			return fmt.Errorf("extracting audio track %d: %w", i, err)
+		}
+	}
+
+	if t.Tracks == nil || job.IsCancelled(ctx) {
+		return nil
+	}
+
+	progress.ExecuteTask("Remuxing audio tracks", func() {
+		err = t.remux(ctx, probe)
+	})
+	progress.Increment()
+
+	if err != nil {
+		return fmt.Errorf("remuxing %s: %w", t.File.Path, err)
+	}
+
+	logger.Infof("[audio-tracks] updated audio tracks of %s", t.File.Path)
+	return nil
+}
+
+// sidecarPath returns the path of the sidecar file for the audio track.
+// The language of the track is included in the filename when known.
+func (t *ManageAudioTracksTask) sidecarPath(track ffmpeg.AudioTrack) string {
+	path := t.File.Path
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	name := fmt.Sprintf("%s.%d", base, track.Index)
+	if track.Language != "" && track.Language != "und" {
+		name += "." + fsutil.SanitiseBasename(track.Language)
+	}
+
+	return name + ffmpeg.AudioTrackExtension(track.Codec)
+}
+
+func (t *ManageAudioTracksTask) extract(ctx context.Context, track ffmpeg.AudioTrack) error {
+	output := t.sidecarPath(track)
+	if exists, _ := fsutil.FileExists(output); exists {
+		return fmt.Errorf("%s already exists", output)
+	}
+
+	args := ffmpeg.ExtractAudioArgs(t.File.Path, track.Index, output)
+	if err := t.FFMpeg.Generate(ctx, args); err != nil {
+		_ = os.Remove(output)
+		return err
+	}
+
+	logger.Infof("[audio-tracks] extracted audio track %d of %s to %s", track.Index, t.File.Path, output)
+	return nil
+}
+
+func (t *ManageAudioTracksTask) remux(ctx context.Context, original *ffmpeg.VideoFile) error {
+	path := t.File.Path
+
+	// write to the same directory so that the original can be replaced by renaming
+	tmpPath := filepath.Join(filepath.Dir(path), ".remux."+filepath.Base(path))
+	if err := t.FFMpeg.Generate(ctx, t.Tracks.RemuxArgs(path, tmpPath)); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	remuxed, err := t.FFProbe.NewVideoFile(tmpPath)
+	if err == nil && (remuxed.VideoCodec != original.VideoCodec || remuxed.FileDuration <= 0) {
+		err = fmt.Errorf("unexpected video codec %q or duration %f", remuxed.VideoCodec, remuxed.FileDuration)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("remuxed file is invalid: %w", err)
+	}
+
+	oldHash := t.Scene.GetHash(t.FileNamingAlgorithm)
+	KillRunningStreams(&t.Scene, t.FileNamingAlgorithm)
+
+	if err := fsutil.SafeMove(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replacing original file: %w", err)
+	}
+
+	if err := t.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		return t.updateFile(ctx, remuxed)
+	}); err != nil {
+		return fmt.Errorf("updating file: %w", err)
+	}
+
+	// the video is unchanged, but the transcode contains the old audio tracks
+	if err := instance.MigrateSceneArtifacts(ctx, t.Scene.ID, oldHash, SceneArtifactsRename); err != nil {
+		logger.Warnf("[audio-tracks] failed to update generated files: %v", err)
+	}
+
+	return nil
+}
+
+// updateFile updates the file metadata and fingerprints after remuxing.
+// The video stream is unchanged, so the existing phash is kept.
+func (t *ManageAudioTracksTask) updateFile(ctx context.Context, remuxed *ffmpeg.VideoFile) error {
+	f := t.File
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return err
+	}
+
+	f.Size = info.Size()
+	f.ModTime = info.ModTime()
+	f.AudioCodec = remuxed.AudioCodec
+	f.AudioLanguages = remuxed.AudioLanguages()
+	f.BitRate = remuxed.Bitrate
+
+	phash := f.Fingerprints.For(models.FingerprintTypePhash)
+
+	fingerprints, err := t.FingerprintCalculator.CalculateFingerprints(f.Base(), &trimFileOpener{path: f.Path}, false)
+	if err != nil {
+		return fmt.Errorf("calculating fingerprints: %w", err)
+	}
+
+	f.Fingerprints = models.Fingerprints{}
+	for _, fp := range fingerprints {
+		f.Fingerprints = f.Fingerprints.AppendUnique(fp)
+	}
+	if phash != nil {
+		f.Fingerprints = f.Fingerprints.AppendUnique(*phash)
+	}
+
+	return t.Repository.File.Update(ctx, f)
+}
@@ -0,0 +1,276 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type NormalizeFilenamesInput struct {
+	// Paths to normalize the filenames in. Defaults to all library paths.
+	Paths []string `json:"paths"`
+	// Scheme used to replace non-ASCII characters. Defaults to NONE.
+	Scheme *file.TransliterationScheme `json:"scheme"`
+	// Filesystem that filenames must be valid on. Defaults to POSIX.
+	Filesystem *file.TargetFilesystem `json:"filesystem"`
+	// Dry run mode. Don't rename any files, only report the new names
+	DryRun bool `json:"dryRun"`
+}
+
+func (i NormalizeFilenamesInput) options() file.NormalizeOptions {
+	ret := file.NormalizeOptions{
+		Scheme:     file.TransliterationNone,
+		Filesystem: file.TargetFilesystemPOSIX,
+	}
+	if i.Scheme != nil {
+		ret.Scheme = *i.Scheme
+	}
+	if i.Filesystem != nil {
+		ret.Filesystem = *i.Filesystem
+	}
+
+	return ret
+}
+
+// FilenameNormalization is a file whose basename is changed by normalizing
+// filenames.
+type FilenameNormalization struct {
+	File        models.File
+	NewBasename string
+	// Conflict is true if the new basename is already used in the folder,
+	// or is also the new basename of another file. Conflicting files are
+	// not renamed.
+	Conflict bool
+}
+
+func (n *FilenameNormalization) NewPath() string {
+	return filepath.Join(filepath.Dir(n.File.Base().Path), n.NewBasename)
+}
+
+func (s *Manager) NormalizeFilenames(ctx context.Context, input NormalizeFilenamesInput) int {
+	j := &NormalizeFilenamesTask{
+		repository: s.Repository,
+		input:      input,
+		scanSubs:   s.scanSubs,
+	}
+
+	return s.JobManager.Add(ctx, "Normalizing filenames...", j)
+}
+
+// FindFilenameNormalizations returns the files whose filenames would be
+// changed by normalizing filenames with the given input. No files are
+// renamed.
+func (s *Manager) FindFilenameNormalizations(ctx context.Context, input NormalizeFilenamesInput) ([]*FilenameNormalization, error) {
+	var ret []*FilenameNormalization
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = s.findFilenameNormalizations(ctx, input)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *Manager) findFilenameNormalizations(ctx context.Context, input NormalizeFilenamesInput) ([]*FilenameNormalization, error) {
+	const batchSize = 1000
+
+	stashPaths := s.Config.GetStashPaths()
+	paths := input.Paths
+	if len(paths) == 0 {
+		paths = stashPaths.Paths()
+	}
+
+	o := input.options()
+	if !o.Scheme.IsValid() || !o.Filesystem.IsValid() {
+		return nil, errors.New("invalid filename normalization options")
+	}
+
+	r := s.Repository
+	var ret []*FilenameNormalization
+	for offset := 0; ; offset += batchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		files, err := r.File.FindAllInPaths(ctx, paths, batchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("finding files: %w", err)
+		}
+
+		for _, f := range files {
+			base := f.Base()
+
+			// files in zip files cannot be renamed, nor can files in
+			// read-only or rclone stashes
+			if base.ZipFileID != nil || s.IsReadOnlyPath(base.Path) || stashPaths.IsRemotePath(base.Path) {
+				continue
+			}
+
+			newBasename := file.NormalizeBasename(base.Basename, o)
+			if newBasename == base.Basename {
+				continue
+			}
+
+			n := &FilenameNormalization{
+				File:        f,
+				NewBasename: newBasename,
+			}
+
+			// the new name may be used by a file that is not in the library
+			// or is missing from the filesystem
+			if _, err := os.Lstat(n.NewPath()); !errors.Is(err, fs.ErrNotExist) {
+				n.Conflict = true
+			} else if existing, err := r.File.FindByBasenameAndParentFolderID(ctx, newBasename, base.ParentFolderID); err != nil {
+				return nil, fmt.Errorf("finding file %s: %w", n.NewPath(), err)
+			} else if existing != nil {
+				n.Conflict = true
+			}
+
+			ret = append(ret, n)
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+	}
+
+	markFilenameNormalizationConflicts(ret, o.Filesystem != file.TargetFilesystemPOSIX)
+
+	return ret, nil
+}
+
+// markFilenameNormalizationConflicts marks files in the same folder that are
+// given the same new basename as conflicting. If ignoreCase is true, names
+// that only differ by case are considered the same.
+func markFilenameNormalizationConflicts(ns []*FilenameNormalization, ignoreCase bool) {
+	type key struct {
+		folderID models.FolderID
+		basename string
+	}
+
+	claims := make(map[key][]*FilenameNormalization)
+	for _, n := range ns {
+		k := key{n.File.Base().ParentFolderID, n.NewBasename}
+		if ignoreCase {
+			k.basename = strings.ToLower(k.basename)
+		}
+		claims[k] = append(claims[k], n)
+	}
+
+	for _, claimants := range claims {
+		if len(claimants) < 2 {
+			continue
+		}
+
+		for _, n := range claimants {
+			n.Conflict = true
+		}
+	}
+}
+
+// NormalizeFilenamesTask transliterates filenames and removes characters
+// that are illegal on a target filesystem, renaming the files with the file
+// mover.
+type NormalizeFilenamesTask struct {
+	repository models.Repository
+	input      NormalizeFilenamesInput
+	scanSubs   *subscriptionManager
+}
+
+func (j *NormalizeFilenamesTask) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Infof("Starting normalizing of filenames")
+	start := time.Now()
+	if j.input.DryRun {
+		logger.Infof("Running in Dry Run Mode")
+	}
+
+	var (
+		ns  []*FilenameNormalization
+		err error
+	)
+	progress.ExecuteTask("Finding filenames to normalize", func() {
+		ns, err = GetInstance().FindFilenameNormalizations(ctx, j.input)
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error finding filenames to normalize: %w", err)
+	}
+
+	progress.SetTotal(len(ns))
+
+	renamed := 0
+	for _, n := range ns {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			break
+		}
+
+		progress.ExecuteTask("Renaming "+n.File.Base().Path, func() {
+			defer progress.Increment()
+			if j.rename(ctx, n) {
+				renamed++
+			}
+		})
+	}
+
+	if renamed > 0 {
+		j.scanSubs.notify()
+	}
+
+	elapsed := time.Since(start)
+	logger.Infof("Finished normalizing filenames. Renamed %d of %d files (%s)", renamed, len(ns), elapsed)
+	return nil
+}
+
+func (j *NormalizeFilenamesTask) rename(ctx context.Context, n *FilenameNormalization) bool {
+	path := n.File.Base().Path
+
+	if n.Conflict {
+		logger.Infof("Not renaming %s to %s, as the name is already used", path, n.NewBasename)
+		return false
+	}
+
+	if j.input.DryRun {
+		logger.Infof("%s would be renamed to %s", path, n.NewBasename)
+		return false
+	}
+
+	r := j.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		mover := GetInstance().NewFileMover(r.File, r.Folder)
+		mover.RegisterHooks(ctx)
+
+		folder, err := r.Folder.Find(ctx, n.File.Base().ParentFolderID)
+		if err != nil {
+			return fmt.Errorf("finding folder: %w", err)
+		}
+		if folder == nil {
+			return fmt.Errorf("folder with id %d not found", n.File.Base().ParentFolderID)
+		}
+
+		return mover.Move(ctx, n.File, folder, n.NewBasename)
+	}); err != nil {
+		logger.Errorf("Error renaming %s to %s: %v", path, n.NewBasename, err)
+		return false
+	}
+
+	logger.Infof("Renamed %s to %s", path, n.NewBasename)
+	return true
+}
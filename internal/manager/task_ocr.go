@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/png"
+	"net/http"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/ocr"
+	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// ocrRequestTimeout is the timeout of requests to the OCR service.
+const ocrRequestTimeout = time.Minute
+
+type OCRMetadataInput struct {
+	// scene ids to recognize text in. Defaults to all scenes
+	SceneIDs []string `json:"sceneIDs"`
+	// recognize text in scenes in which it was already recognized
+	Overwrite bool `json:"overwrite"`
+}
+
+// ocrBackend returns the configured OCR backend.
+func (s *Manager) ocrBackend() (ocr.Backend, error) {
+	c := s.Config
+	ret, err := ocr.NewBackend(c.GetOCRCommand(), c.GetOCRArgs(), c.GetOCREndpoint(), c.GetOCRAPIKey())
+	if err != nil {
+		return nil, err
+	}
+
+	if h, ok := ret.(ocr.HTTP); ok {
+		h.HTTPClient = &http.Client{Timeout: ocrRequestTimeout}
+		h.UserAgent = c.GetScraperUserAgent()
+		ret = h
+	}
+
+	return ret, nil
+}
+
+// OCR starts a job recognizing the on-screen text in scenes, such as titles,
+// watermarks and site names. The text is stored with the scene, where it is
+// searched and used to match studios when auto tagging.
+func (s *Manager) OCR(ctx context.Context, input OCRMetadataInput) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	backend, err := s.ocrBackend()
+	if err != nil {
+		return 0, err
+	}
+
+	j := &OCRJob{
+		repository: s.Repository,
+		backend:    backend,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Recognizing on-screen text...", j), nil
+}
+
+// OCRJob recognizes the on-screen text in scenes.
+type OCRJob struct {
+	repository models.Repository
+	backend    ocr.Backend
+	input      OCRMetadataInput
+}
+
+func (j *OCRJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	var scenes []*models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		if len(j.input.SceneIDs) == 0 {
+			scenes, err = r.Scene.All(ctx)
+			return err
+		}
+
+		ids, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
+		if err != nil {
+			return err
+		}
+
+		scenes, err = r.Scene.FindMany(ctx, ids)
+		return err
+	}); err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+
+	var todo []*models.Scene
+	for _, s := range scenes {
+		if s.Path != "" && (j.input.Overwrite || s.OCRAnalyzedAt == nil) {
+			todo = append(todo, s)
+		}
+	}
+
+	logger.Infof("Recognizing on-screen text in %d scenes", len(todo))
+	progress.SetTotal(len(todo))
+
+	for _, s := range todo {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Recognizing text in %s", s.GetTitle()), func() {
+			if err := j.recognize(ctx, s); err != nil {
+				logger.Errorf("Error recognizing text in %s: %v", s.Path, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	logger.Info("Finished recognizing on-screen text")
+	return nil
+}
+
+// recognize recognizes the text in frames of the scene, and stores it with
+// the scene.
+func (j *OCRJob) recognize(ctx context.Context, s *models.Scene) error {
+	r := j.repository
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		return s.LoadPrimaryFile(ctx, r.File)
+	}); err != nil {
+		return err
+	}
+
+	videoFile := s.Files.Primary()
+	if videoFile == nil {
+		return nil
+	}
+
+	if exists, err := fsutil.FileExists(videoFile.Path); err != nil || !exists {
+		logger.Warnf("Video file no longer exists, skipping text recognition: %s", videoFile.Path)
+		return nil
+	}
+
+	g := generate.Generator{
+		Encoder:      instance.FFMpeg,
+		FFMpegConfig: instance.Config,
+		LockManager:  instance.ReadLockManager,
+		ScenePaths:   instance.Paths.Scene,
+	}
+
+	var texts []string
+	for _, at := range generate.OCRFrameTimes(videoFile.Duration, instance.Config.GetOCRFrames()) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		img, err := g.OCRFrame(ctx, videoFile.Path, at, videoFile.Width)
+		if err != nil {
+			logger.Debugf("Error taking frame of %s at %f: %v", videoFile.Path, at, err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return fmt.Errorf("encoding frame: %w", err)
+		}
+
+		text, err := j.backend.Recognize(ctx, buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		texts = append(texts, text)
+	}
+
+	text := ocr.Merge(texts)
+	logger.Tracef("Recognized text in %s: %q", videoFile.Path, text)
+
+	partial := models.ScenePartial{
+		OCRText:       models.NewOptionalString(text),
+		OCRAnalyzedAt: models.NewOptionalTime(time.Now()),
+	}
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		_, err := r.Scene.UpdatePartial(ctx, s.ID, partial)
+		return err
+	})
+}
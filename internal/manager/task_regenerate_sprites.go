@@ -97,7 +97,7 @@ func (t *RegenerateSpritesTask) Execute(ctx context.Context, progress *job.Progr
 	}
 
 	// Get sprite file paths
-	spriteImagePath := t.Paths.Scene.GetSpriteImageFilePath(sceneHash)
+	spriteImagePath, _ := FindSpriteImageFilePath(t.Paths.Scene, sceneHash, instance.Config.GetSpriteFormat())
 	spriteVttPath := t.Paths.Scene.GetSpriteVttFilePath(sceneHash)
 
 	logger.Infof("[regenerate-sprites] sprite image path: %s", spriteImagePath)
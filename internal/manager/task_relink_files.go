@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+type RelinkFilesInput struct {
+	// Match files by identical perceptual hash
+	MatchPhash bool `json:"matchPhash"`
+	// Match files by file size and duration
+	MatchSizeDuration bool `json:"matchSizeDuration"`
+	// Review mode. Don't relink any files, only report matches
+	Review bool `json:"review"`
+}
+
+func (i RelinkFilesInput) options() scene.RelinkOptions {
+	return scene.RelinkOptions{
+		MatchPhash:        i.MatchPhash,
+		MatchSizeDuration: i.MatchSizeDuration,
+	}
+}
+
+func (s *Manager) RelinkFiles(ctx context.Context, input RelinkFilesInput) int {
+	j := &RelinkFilesTask{
+		repository:   s.Repository,
+		sceneService: s.SceneService,
+		input:        input,
+		scanSubs:     s.scanSubs,
+	}
+
+	return s.JobManager.Add(ctx, "Relinking files...", j)
+}
+
+// FindRelinkMatches returns all scenes whose files are missing, along with
+// the existing files that they may be relinked to.
+func (s *Manager) FindRelinkMatches(ctx context.Context, input RelinkFilesInput) ([]*scene.RelinkMatch, error) {
+	var ret []*scene.RelinkMatch
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = findRelinkMatches(ctx, s.Repository.Scene, s.SceneService, input.options())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	markAmbiguousRelinkMatches(ret)
+
+	return ret, nil
+}
+
+func findRelinkMatches(ctx context.Context, r models.SceneReader, sceneService SceneService, options scene.RelinkOptions) ([]*scene.RelinkMatch, error) {
+	var ret []*scene.RelinkMatch
+	err := scene.BatchProcess(ctx, r, nil, nil, func(s *models.Scene) error {
+		if err := s.LoadFiles(ctx, r); err != nil {
+			return err
+		}
+
+		m, err := sceneService.FindRelinkMatch(ctx, s, options)
+		if err != nil {
+			return fmt.Errorf("finding relink match for scene %q: %w", s.DisplayName(), err)
+		}
+
+		if m != nil {
+			ret = append(ret, m)
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// markAmbiguousRelinkMatches marks matches that share a candidate file as
+// conflicting, so that they are not relinked automatically.
+func markAmbiguousRelinkMatches(matches []*scene.RelinkMatch) {
+	claims := make(map[models.FileID][]*scene.RelinkMatch)
+	for _, m := range matches {
+		for _, c := range m.Candidates {
+			claims[c.File.ID] = append(claims[c.File.ID], m)
+		}
+	}
+
+	for _, claimants := range claims {
+		if len(claimants) < 2 {
+			continue
+		}
+
+		for _, m := range claimants {
+			m.Conflicting = true
+		}
+	}
+}
+
+// RelinkFilesTask relinks scenes whose files have all gone missing to
+// existing files with matching fingerprints.
+type RelinkFilesTask struct {
+	repository   models.Repository
+	sceneService SceneService
+	input        RelinkFilesInput
+	scanSubs     *subscriptionManager
+}
+
+func (j *RelinkFilesTask) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Infof("Starting relinking of missing files")
+	start := time.Now()
+	if j.input.Review {
+		logger.Infof("Running in Review Mode")
+	}
+
+	var (
+		matches []*scene.RelinkMatch
+		err     error
+	)
+	progress.ExecuteTask("Finding scenes with missing files", func() {
+		err = j.repository.WithReadTxn(ctx, func(ctx context.Context) error {
+			matches, err = findRelinkMatches(ctx, j.repository.Scene, j.sceneService, j.input.options())
+			return err
+		})
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error finding scenes with missing files: %w", err)
+	}
+
+	markAmbiguousRelinkMatches(matches)
+	progress.SetTotal(len(matches))
+
+	relinked := 0
+	for _, m := range matches {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask("Relinking scene "+m.Scene.DisplayName(), func() {
+			defer progress.Increment()
+
+			if j.relink(ctx, m) {
+				relinked++
+			}
+		})
+	}
+
+	if relinked > 0 {
+		j.scanSubs.notify()
+	}
+
+	elapsed := time.Since(start)
+	logger.Infof("Finished relinking files. Relinked %d of %d scenes with missing files (%s)", relinked, len(matches), elapsed)
+	return nil
+}
+
+func (j *RelinkFilesTask) relink(ctx context.Context, m *scene.RelinkMatch) bool {
+	name := m.Scene.DisplayName()
+
+	switch {
+	case len(m.Candidates) == 0:
+		logger.Infof("No matching files found for scene %q", name)
+		return false
+	case m.Ambiguous():
+		logger.Infof("Ambiguous matches found for scene %q. Review required:", name)
+		for _, c := range m.Candidates {
+			logger.Infof("  %s (%s)", c.File.Path, c.MatchType)
+		}
+		return false
+	}
+
+	c := m.Candidates[0]
+	if j.input.Review {
+		logger.Infof("Scene %q matches %s (%s)", name, c.File.Path, c.MatchType)
+		return false
+	}
+
+	mgr := GetInstance()
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        file.NewDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	if err := j.repository.WithTxn(ctx, func(ctx context.Context) error {
+		fileDeleter.RegisterHooks(ctx)
+		return j.sceneService.Relink(ctx, m.Scene.ID, c.File.ID, fileDeleter)
+	}); err != nil {
+		logger.Errorf("Error relinking scene %q to %s: %v", name, c.File.Path, err)
+		return false
+	}
+
+	return true
+}
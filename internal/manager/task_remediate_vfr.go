@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// ErrNoTimingIssues is returned when remediating a video file without
+// timing issues.
+var ErrNoTimingIssues = errors.New("video file has no timing issues")
+
+// RemediateVFRTask fixes the frame and audio timing of a scene video file.
+// Variable frame rate and interlaced video is re-encoded to a constant frame
+// rate, and audio that is offset or has drifted is resampled to the video
+// timestamps. The video is only re-encoded where needed, otherwise it is
+// re-muxed. The fixed file replaces the original in the same way as a trimmed
+// file.
+type RemediateVFRTask struct {
+	TrimVideoTask
+
+	issues ffmpeg.TimingIssues
+}
+
+func (t *RemediateVFRTask) GetDescription() string {
+	return fmt.Sprintf("Fixing frame rate and audio sync of %s", t.Scene.Path)
+}
+
+// Detect probes the video file, returning its timing issues.
+func (t *RemediateVFRTask) Detect() (ffmpeg.TimingIssues, error) {
+	for _, f := range t.Scene.Files.List() {
+		if f.ID != t.FileID {
+			continue
+		}
+
+		videoFile, err := t.FFProbe.NewVideoFile(f.Path)
+		if err != nil {
+			return ffmpeg.TimingIssues{}, fmt.Errorf("error reading video file: %w", err)
+		}
+
+		return videoFile.TimingIssues(), nil
+	}
+
+	return ffmpeg.TimingIssues{}, fmt.Errorf("file %d not found in scene %d", t.FileID, t.Scene.ID)
+}
+
+func (t *RemediateVFRTask) Execute(ctx context.Context, progress *job.Progress) error {
+	issues, err := t.Detect()
+	if err != nil {
+		return err
+	}
+
+	if !issues.Any() {
+		logger.Infof("[remediate-vfr] %s has no timing issues", t.Scene.Path)
+		return nil
+	}
+
+	t.issues = issues
+	t.edit = t
+	return t.TrimVideoTask.Execute(ctx, progress)
+}
+
+func (t *RemediateVFRTask) description() string {
+	return "Fixing frame rate and audio sync"
+}
+
+func (t *RemediateVFRTask) apply(ctx context.Context, inputPath, outputPath string, progress *job.Progress) error {
+	videoFile, err := t.FFProbe.NewVideoFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("error reading video file: %w", err)
+	}
+
+	if t.issues.ReencodeVideo() {
+		logger.Infof("[remediate-vfr] re-encoding %s to %s fps (%s)", inputPath, t.issues.FrameRate, t.issues)
+	} else {
+		logger.Infof("[remediate-vfr] re-muxing %s (%s)", inputPath, t.issues)
+	}
+
+	args := ffmpeg.RemediateTimingArgs(videoFile, t.issues, t.Config.GetDeinterlaceFilter(), outputPath)
+
+	progress.SetPercent(0)
+
+	cmd := t.FFMpeg.Command(ctx, args)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg remediation failed: %w", err)
+	}
+
+	progress.SetPercent(1)
+	return nil
+}
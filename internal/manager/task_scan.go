@@ -32,6 +32,10 @@ type ScanJob struct {
 	scanner       scanner
 	input         ScanMetadataInput
 	subscriptions *subscriptionManager
+
+	// cursor tracks the scanned files so that the scan can be resumed. Nil
+	// if the job is not resumable.
+	cursor *file.ScanCursor
 }
 
 func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
@@ -53,9 +57,24 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 	c := mgr.Config
 	repo := mgr.Repository
 
+	paths = mgr.refreshRemoteStashes(ctx, paths)
+
+	for _, p := range c.GetStashPaths().Overlapping(c.GetScanExcludedPaths()) {
+		logger.Warnf("Stash data directory %q is inside a library path. It is excluded from the scan, but should be moved out of the library.", p)
+	}
+
 	start := time.Now()
 
-	const taskQueueSize = 200000
+	var timer *job.StageTimer
+	if input.Benchmark {
+		timer = job.NewStageTimer()
+	}
+
+	// files are read by the scanner using the io tasks, while generation
+	// during the scan is cpu bound and is run in a separate task queue. The
+	// queue is bounded so that reading files is slowed down to the rate at
+	// which content is generated.
+	const taskQueueSize = 1000
 	taskQueue := job.NewTaskQueue(ctx, progress, taskQueueSize, cfg.GetParallelTasksWithAutoDetection())
 
 	var minModTime time.Time
@@ -63,13 +82,19 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		minModTime = *j.input.Filter.MinModTime
 	}
 
-	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
+	mgr.brokenFiles.clear(paths)
+
+	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress, timer), file.ScanOptions{
 		Paths:                  paths,
 		ScanFilters:            []file.PathFilter{newScanFilter(c, repo, minModTime)},
 		ZipFileExtensions:      cfg.GetGalleryExtensions(),
-		ParallelTasks:          cfg.GetParallelTasksWithAutoDetection(),
+		ParallelTasks:          cfg.GetParallelIOTasksWithAutoDetection(),
 		HandlerRequiredFilters: []file.Filter{newHandlerRequiredFilter(cfg, repo)},
 		Rescan:                 j.input.Rescan,
+		BypassFingerprintCache: j.input.VerifyFingerprints,
+		Cursor:                 j.cursor,
+		StageTimer:             timer,
+		OnBrokenFile:           mgr.brokenFiles.add,
 	}, progress)
 
 	taskQueue.Close()
@@ -79,13 +104,81 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		return nil
 	}
 
+	if input.ScanGenerateGalleryChapters {
+		j.createGalleryChapters(ctx, paths, start)
+	}
+
+	mgr.queueAutoConversions(ctx, paths)
+
 	elapsed := time.Since(start)
 	logger.Info(fmt.Sprintf("Scan finished (%s)", elapsed))
 
+	for _, s := range timer.Stats() {
+		logger.Infof("Scan benchmark - %s", s)
+	}
+
 	j.subscriptions.notify()
 	return nil
 }
 
+// createGalleryChapters creates chapters from the subfolders of the zip and
+// folder based galleries that were created or had images added since start.
+func (j *ScanJob) createGalleryChapters(ctx context.Context, paths []string, start time.Time) {
+	const batchSize = 1000
+	r := GetInstance().Repository
+
+	var galleries []*models.Gallery
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		findFilter := models.BatchFindFilter(batchSize)
+		for more := true; more; {
+			batch, _, err := r.Gallery.Query(ctx, nil, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, g := range batch {
+				if g.Path == "" || g.UpdatedAt.Before(start) {
+					continue
+				}
+
+				if len(paths) > 0 && !fsutil.IsPathInDirs(paths, g.Path) {
+					continue
+				}
+
+				galleries = append(galleries, g)
+			}
+
+			more = len(batch) == batchSize
+			*findFilter.Page++
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("Error finding scanned galleries: %v", err)
+		return
+	}
+
+	for _, g := range galleries {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		var created int
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = gallery.CreateFolderChapters(ctx, g, r.Image, r.GalleryChapter)
+			return err
+		}); err != nil {
+			logger.Errorf("Error creating chapters for gallery %s: %v", g.DisplayName(), err)
+			continue
+		}
+
+		if created > 0 {
+			logger.Infof("Created %d chapters for gallery %s", created, g.DisplayName())
+		}
+	}
+}
+
 type extensionConfig struct {
 	vidExt []string
 	imgExt []string
@@ -250,17 +343,27 @@ type scanFilter struct {
 	CaptionUpdater video.CaptionUpdater
 
 	stashPaths        config.StashConfigs
-	generatedPath     string
+	excludedPaths     []string
 	savedScreensPath  string
 	videoExcludeRegex []*regexp.Regexp
 	imageExcludeRegex []*regexp.Regexp
 	minModTime        time.Time
+	fileLocks         *fsutil.FileLocks
 }
 
-func newScanFilter(c *config.Config, repo models.Repository, minModTime time.Time) *scanFilter {
-	var savedScreensPath string
+// getSavedScreensPath returns the path of the saved screenshots, which are
+// scanned even though they are in the generated directory.
+func getSavedScreensPath() string {
 	if instance != nil && instance.Paths.Generated != nil {
-		savedScreensPath = instance.Paths.Generated.SavedScreens
+		return instance.Paths.Generated.SavedScreens
+	}
+	return ""
+}
+
+func newScanFilter(c *config.Config, repo models.Repository, minModTime time.Time) *scanFilter {
+	var fileLocks *fsutil.FileLocks
+	if instance != nil {
+		fileLocks = instance.FileLocks
 	}
 
 	return &scanFilter{
@@ -269,22 +372,30 @@ func newScanFilter(c *config.Config, repo models.Repository, minModTime time.Tim
 		FileFinder:        repo.File,
 		CaptionUpdater:    repo.File,
 		stashPaths:        c.GetStashPaths(),
-		generatedPath:     c.GetGeneratedPath(),
-		savedScreensPath:  savedScreensPath,
+		excludedPaths:     c.GetScanExcludedPaths(),
+		savedScreensPath:  getSavedScreensPath(),
 		videoExcludeRegex: generateRegexps(c.GetExcludes()),
 		imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
 		minModTime:        minModTime,
+		fileLocks:         fileLocks,
 	}
 }
 
+// inDataDir returns true if the path is in a directory written by stash
+// itself, such as the generated directory, other than saved_screens.
+func (f *scanFilter) inDataDir(path string) bool {
+	if !fsutil.IsPathInDirs(f.excludedPaths, path) {
+		return false
+	}
+
+	return f.savedScreensPath == "" || !fsutil.IsPathInDir(f.savedScreensPath, path)
+}
+
 func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo) bool {
-	if fsutil.IsPathInDir(f.generatedPath, path) {
-		if f.savedScreensPath != "" && fsutil.IsPathInDir(f.savedScreensPath, path) {
-			// allow scanning saved_screens
-		} else {
-			logger.Warnf("Skipping %q as it overlaps with the generated folder", path)
-			return false
-		}
+	// never scan the files written by stash itself
+	if f.inDataDir(path) {
+		logger.Debugf("Skipping %q as it is in a stash data directory", path)
+		return false
 	}
 
 	// exit early on cutoff
@@ -292,6 +403,12 @@ func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 		return false
 	}
 
+	// files being replaced by a conversion are picked up by the next scan
+	if f.fileLocks != nil && f.fileLocks.IsLocked(path) {
+		logger.Infof("Skipping %q as it is in use by another task", path)
+		return false
+	}
+
 	s := f.stashPaths.GetStashFromDirPath(path)
 	if s == nil {
 		if f.savedScreensPath != "" && fsutil.IsPathInDir(f.savedScreensPath, path) {
@@ -372,11 +489,12 @@ func galleryFileFilter(ctx context.Context, f models.File) bool {
 	return isZip(f.Base().Basename)
 }
 
-func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progress *job.Progress) []file.Handler {
+func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progress *job.Progress, timer *job.StageTimer) []file.Handler {
 	mgr := GetInstance()
 	c := mgr.Config
 	r := mgr.Repository
 	pluginCache := mgr.PluginCache
+	stashPaths := c.GetStashPaths()
 
 	return []file.Handler{
 		&file.FilteredHandler{
@@ -388,7 +506,9 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 					input:              options,
 					taskQueue:          taskQueue,
 					progress:           progress,
+					timer:              timer,
 					paths:              mgr.Paths,
+					stashPaths:         stashPaths,
 					sequentialScanning: c.GetSequentialScanning(),
 				},
 				ScanConfig: &scanConfig{
@@ -413,15 +533,19 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 			Filter: file.FilterFunc(videoFileFilter),
 			Handler: &scene.ScanHandler{
 				CreatorUpdater: r.Scene,
+				ExtraFinder:    r.SceneExtra,
 				CaptionUpdater: r.File,
 				PluginCache:    pluginCache,
 				ScanGenerator: &sceneGenerators{
 					input:               options,
 					taskQueue:           taskQueue,
 					progress:            progress,
+					timer:               timer,
 					paths:               mgr.Paths,
+					stashPaths:          stashPaths,
 					fileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 					sequentialScanning:  c.GetSequentialScanning(),
+					exclusions:          defaultGenerateExclusions(c),
 				},
 				FileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 				Paths:               mgr.Paths,
@@ -434,11 +558,28 @@ type imageGenerators struct {
 	input     ScanMetadataInput
 	taskQueue *job.TaskQueue
 	progress  *job.Progress
+	timer     *job.StageTimer
 
 	paths              *paths.Paths
+	stashPaths         config.StashConfigs
 	sequentialScanning bool
 }
 
+// queueGenerateTask runs fn in the task queue, or immediately if sequential
+// is true. The time taken by fn is recorded under stage.
+func queueGenerateTask(ctx context.Context, taskQueue *job.TaskQueue, sequential bool, timer *job.StageTimer, stage string, description string, fn func(ctx context.Context)) {
+	timed := func(ctx context.Context) {
+		defer timer.Start(stage)()
+		fn(ctx)
+	}
+
+	if sequential {
+		timed(ctx)
+	} else {
+		taskQueue.AddBlocking(description, timed)
+	}
+}
+
 func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f models.File) error {
 	const overwrite = false
 
@@ -446,26 +587,32 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 	t := g.input
 	path := f.Base().Path
 
+	// ffmpeg cannot read files in rclone stashes
+	if g.stashPaths.IsRemotePath(path) {
+		return nil
+	}
+
 	// this is a bit of a hack: the task requires files to be loaded, but
 	// we don't really need to since we already have the file
 	ii := *i
 	ii.Files = models.NewRelatedFiles([]models.File{f})
 
 	if t.ScanGenerateThumbnails {
-		// this should be quick, so always generate sequentially
-		taskThumbnail := GenerateImageThumbnailTask{
-			Image:     ii,
-			Overwrite: overwrite,
-		}
+		queueGenerateTask(ctx, g.taskQueue, g.sequentialScanning, g.timer, "thumbnail", fmt.Sprintf("Generating thumbnail for %s", path), func(ctx context.Context) {
+			taskThumbnail := GenerateImageThumbnailTask{
+				Image:     ii,
+				Overwrite: overwrite,
+			}
 
-		taskThumbnail.Start(ctx)
+			taskThumbnail.Start(ctx)
+		})
 	}
 
 	// avoid adding a task if the file isn't a video file
 	_, isVideo := f.(*models.VideoFile)
 	if isVideo && t.ScanGenerateClipPreviews {
 		progress.AddTotal(1)
-		previewsFn := func(ctx context.Context) {
+		queueGenerateTask(ctx, g.taskQueue, g.sequentialScanning, g.timer, "clip preview", fmt.Sprintf("Generating preview for %s", path), func(ctx context.Context) {
 			taskPreview := GenerateClipPreviewTask{
 				Image:     ii,
 				Overwrite: overwrite,
@@ -473,13 +620,7 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 
 			taskPreview.Start(ctx)
 			progress.Increment()
-		}
-
-		if g.sequentialScanning {
-			previewsFn(ctx)
-		} else {
-			g.taskQueue.Add(fmt.Sprintf("Generating preview for %s", path), previewsFn)
-		}
+		})
 	}
 
 	return nil
@@ -489,10 +630,27 @@ type sceneGenerators struct {
 	input     ScanMetadataInput
 	taskQueue *job.TaskQueue
 	progress  *job.Progress
+	timer     *job.StageTimer
 
 	paths               *paths.Paths
+	stashPaths          config.StashConfigs
 	fileNamingAlgorithm models.HashAlgorithm
 	sequentialScanning  bool
+
+	// exclusions are the default generate exclusions. Only their file
+	// rules apply, since scanned scenes are not tagged yet.
+	exclusions models.GenerateExclusions
+}
+
+// defaultGenerateExclusions returns the exclusions of the default generate
+// settings.
+func defaultGenerateExclusions(c *config.Config) models.GenerateExclusions {
+	defaults := c.GetDefaultGenerateSettings()
+	if defaults == nil || defaults.Exclusions == nil {
+		return models.GenerateExclusions{}
+	}
+
+	return *defaults.Exclusions
 }
 
 func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *models.VideoFile) error {
@@ -502,11 +660,22 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 	t := g.input
 	path := f.Path
 
+	// ffmpeg cannot read files in rclone stashes
+	if g.stashPaths.IsRemotePath(path) {
+		return nil
+	}
+
 	mgr := GetInstance()
 
-	if t.ScanGenerateSprites {
+	mgr.indexPhash(s.ID, f)
+
+	excludes := func(e *models.GenerateExclusion) bool {
+		return e != nil && e.ExcludesFile(f)
+	}
+
+	if t.ScanGenerateSprites && !excludes(g.exclusions.Sprites) {
 		progress.AddTotal(1)
-		spriteFn := func(ctx context.Context) {
+		queueGenerateTask(ctx, g.taskQueue, g.sequentialScanning, g.timer, "sprite", fmt.Sprintf("Generating sprites for %s", path), func(ctx context.Context) {
 			taskSprite := GenerateSpriteTask{
 				Scene:               *s,
 				Overwrite:           overwrite,
@@ -514,18 +683,12 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 			}
 			taskSprite.Start(ctx)
 			progress.Increment()
-		}
-
-		if g.sequentialScanning {
-			spriteFn(ctx)
-		} else {
-			g.taskQueue.Add(fmt.Sprintf("Generating sprites for %s", path), spriteFn)
-		}
+		})
 	}
 
 	if t.ScanGeneratePhashes {
 		progress.AddTotal(1)
-		phashFn := func(ctx context.Context) {
+		queueGenerateTask(ctx, g.taskQueue, g.sequentialScanning, g.timer, "phash", fmt.Sprintf("Generating phash for %s", path), func(ctx context.Context) {
 			taskPhash := GeneratePhashTask{
 				repository:          mgr.Repository,
 				File:                f,
@@ -534,18 +697,12 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 			}
 			taskPhash.Start(ctx)
 			progress.Increment()
-		}
-
-		if g.sequentialScanning {
-			phashFn(ctx)
-		} else {
-			g.taskQueue.Add(fmt.Sprintf("Generating phash for %s", path), phashFn)
-		}
+		})
 	}
 
-	if t.ScanGeneratePreviews {
+	if t.ScanGeneratePreviews && !excludes(g.exclusions.Previews) {
 		progress.AddTotal(1)
-		previewsFn := func(ctx context.Context) {
+		queueGenerateTask(ctx, g.taskQueue, g.sequentialScanning, g.timer, "preview", fmt.Sprintf("Generating preview for %s", path), func(ctx context.Context) {
 			options := getGeneratePreviewOptions(GeneratePreviewOptionsInput{})
 
 			generator := &generate.Generator{
@@ -567,23 +724,15 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 			}
 			taskPreview.Start(ctx)
 			progress.Increment()
-		}
-
-		if g.sequentialScanning {
-			previewsFn(ctx)
-		} else {
-			g.taskQueue.Add(fmt.Sprintf("Generating preview for %s", path), previewsFn)
-		}
+		})
 	}
 
 	if t.ScanGenerateCovers {
 		progress.AddTotal(1)
-		g.taskQueue.Add(fmt.Sprintf("Generating cover for %s", path), func(ctx context.Context) {
-			taskCover := GenerateCoverTask{
-				repository: mgr.Repository,
-				Scene:      *s,
-				Overwrite:  overwrite,
-			}
+		// covers are always generated in the task queue
+		const sequential = false
+		queueGenerateTask(ctx, g.taskQueue, sequential, g.timer, "cover", fmt.Sprintf("Generating cover for %s", path), func(ctx context.Context) {
+			taskCover := newCoverTask(mgr.Repository, *s, overwrite)
 			taskCover.Start(ctx)
 			progress.Increment()
 		})
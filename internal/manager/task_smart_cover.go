@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+// coverTask generates the cover of a scene.
+type coverTask interface {
+	Task
+	required(ctx context.Context) bool
+}
+
+// newCoverTask returns the task generating the default cover of the scene,
+// using smart covers if configured.
+func newCoverTask(repository models.Repository, scene models.Scene, overwrite bool) coverTask {
+	if instance.Config.GetSmartCovers() {
+		return &SmartCoverTask{
+			repository: repository,
+			Scene:      scene,
+			Overwrite:  overwrite,
+		}
+	}
+
+	return &GenerateCoverTask{
+		repository: repository,
+		Scene:      scene,
+		Overwrite:  overwrite,
+	}
+}
+
+// SmartCoverTask generates the cover of a scene from the candidate frame
+// with the best score, rather than from a frame at a fixed time.
+type SmartCoverTask struct {
+	repository models.Repository
+	Scene      models.Scene
+	Overwrite  bool
+	// Reroll takes the candidate frames at random times, so that a different
+	// frame is chosen than by the previous run.
+	Reroll bool
+}
+
+func (t *SmartCoverTask) GetDescription() string {
+	return fmt.Sprintf("Choosing cover for %s", t.Scene.GetTitle())
+}
+
+func (t *SmartCoverTask) coverTask(at *float64) *GenerateCoverTask {
+	return &GenerateCoverTask{
+		repository:   t.repository,
+		Scene:        t.Scene,
+		ScreenshotAt: at,
+		Overwrite:    t.Overwrite,
+	}
+}
+
+func (t *SmartCoverTask) required(ctx context.Context) bool {
+	return t.coverTask(nil).required(ctx)
+}
+
+func (t *SmartCoverTask) Start(ctx context.Context) {
+	r := t.repository
+
+	var required bool
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		required = t.required(ctx)
+
+		return t.Scene.LoadPrimaryFile(ctx, r.File)
+	}); err != nil {
+		logger.Error(err)
+	}
+
+	if !required {
+		return
+	}
+
+	videoFile := t.Scene.Files.Primary()
+	if videoFile == nil {
+		return
+	}
+
+	if exists, err := fsutil.FileExists(videoFile.Path); err != nil || !exists {
+		logger.Warnf("Video file no longer exists, skipping cover generation: %s", videoFile.Path)
+		return
+	}
+
+	at, err := t.chooseFrame(ctx, videoFile)
+	if err != nil {
+		logger.Warnf("Error choosing cover for %s, using default: %v", videoFile.Path, err)
+		at = nil
+	}
+
+	// the cover is generated at the full resolution of the video from the
+	// chosen time
+	task := t.coverTask(at)
+	task.Overwrite = true
+	task.Start(ctx)
+}
+
+// chooseFrame scores the candidate frames of the video, and returns the time
+// of the frame with the best score.
+func (t *SmartCoverTask) chooseFrame(ctx context.Context, videoFile *models.VideoFile) (*float64, error) {
+	// take frames from the middle of each part of the video, unless rerolling
+	offset := 0.5
+	if t.Reroll {
+		offset = rand.Float64()
+	}
+
+	times := generate.CoverCandidateTimes(videoFile.Duration, instance.Config.GetSmartCoverCandidates(), offset)
+	if len(times) == 0 {
+		return nil, fmt.Errorf("invalid duration %f", videoFile.Duration)
+	}
+
+	g := generate.Generator{
+		Encoder:      instance.FFMpeg,
+		FFMpegConfig: instance.Config,
+		LockManager:  instance.ReadLockManager,
+		ScenePaths:   instance.Paths.Scene,
+	}
+
+	preferPeople := instance.Config.GetSmartCoverPreferPeople()
+
+	var best *float64
+	bestScore := -1.0
+	for _, at := range times {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		img, err := g.CoverCandidate(ctx, videoFile.Path, at)
+		if err != nil {
+			logger.Debugf("Error taking cover candidate of %s at %f: %v", videoFile.Path, at, err)
+			continue
+		}
+
+		score := generate.ScoreFrame(img).Total(preferPeople)
+		logger.Tracef("Cover candidate of %s at %f scored %f", videoFile.Path, at, score)
+
+		if score > bestScore {
+			best = &at
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return nil, errors.New("no candidate frames could be taken")
+	}
+
+	return best, nil
+}
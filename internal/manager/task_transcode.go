@@ -20,6 +20,9 @@ type GenerateTranscodeTask struct {
 	// is true, generate even if video is browser-supported
 	Force bool
 
+	// if true, the scene video filters and transforms are applied to the transcode
+	ApplyVideoFilters bool
+
 	g *generate.Generator
 }
 
@@ -82,7 +85,13 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	// if scale is being set, then we can't use stream copy
 	scaleSet := w == 0 && h == 0
 
-	if scaleSet && videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
+	var videoFilter ffmpeg.VideoFilter
+	videoFilter = videoFilter.Deinterlace(ffmpeg.DeinterlaceFilterFor(f.Interlaced, t.Scene.Deinterlace, config.GetInstance().GetDeinterlaceFilter()))
+	if t.ApplyVideoFilters {
+		videoFilter = videoFilter.VideoAdjustments(t.Scene.VideoFilters, t.Scene.VideoTransforms)
+	}
+
+	if scaleSet && videoFilter == "" && t.Scene.TranscodeArgs.IsEmpty() && videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
 		if audioCodec == ffmpeg.MissingUnsupported {
 			err = t.g.TranscodeCopyVideo(ctx, videoFile.Path, sceneHash)
 		} else {
@@ -90,8 +99,10 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 		}
 	} else {
 		options := generate.TranscodeOptions{
-			Width:  w,
-			Height: h,
+			Width:         w,
+			Height:        h,
+			VideoFilter:   videoFilter,
+			TranscodeArgs: t.Scene.TranscodeArgs,
 		}
 
 		if audioCodec == ffmpeg.MissingUnsupported {
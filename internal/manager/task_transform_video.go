@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// TransformVideoTask rotates, crops and flips a scene video file.
+// A rotation on its own is applied losslessly by changing the display rotation
+// of the video stream where possible. Otherwise the video is re-encoded.
+// The transformed file replaces the original in the same way as a trimmed file.
+type TransformVideoTask struct {
+	TrimVideoTask
+	Transform ffmpeg.VideoTransform
+}
+
+func (t *TransformVideoTask) GetDescription() string {
+	return fmt.Sprintf("Transforming video %s", t.Scene.Path)
+}
+
+func (t *TransformVideoTask) Execute(ctx context.Context, progress *job.Progress) error {
+	t.edit = t
+	return t.TrimVideoTask.Execute(ctx, progress)
+}
+
+func (t *TransformVideoTask) description() string {
+	return "Transforming video"
+}
+
+func (t *TransformVideoTask) apply(ctx context.Context, inputPath, outputPath string, progress *job.Progress) error {
+	videoFile, err := t.FFProbe.NewVideoFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("error reading video file: %w", err)
+	}
+
+	if err := t.Transform.Validate(videoFile.Width, videoFile.Height); err != nil {
+		return err
+	}
+
+	// the output is always h264 in an mp4 container
+	lossless := t.Transform.Lossless() && videoFile.VideoCodec == ffmpeg.H264 && t.FFMpeg.SupportsDisplayRotation()
+
+	var args ffmpeg.Args
+	if lossless {
+		args = append(args, t.Transform.DisplayRotationArgs(videoFile.Rotation)...)
+		args = args.Input(inputPath)
+		args = args.VideoCodec(ffmpeg.VideoCodecCopy)
+		logger.Infof("[transform-video] rotating %s by %d degrees without re-encoding", inputPath, t.Transform.Rotate)
+	} else {
+		var videoFilter ffmpeg.VideoFilter
+		videoFilter = videoFilter.Transform(t.Transform)
+
+		args = args.Input(inputPath)
+		args = args.VideoFilter(videoFilter)
+		args = args.VideoCodec(ffmpeg.VideoCodecLibX264)
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "medium",
+			"-crf", "18",
+		)
+		logger.Infof("[transform-video] re-encoding %s with filter %s", inputPath, videoFilter)
+	}
+
+	if ffmpeg.IsValidAudioForContainer(ffmpeg.ProbeAudioCodec(videoFile.AudioCodec), ffmpeg.Mp4) {
+		args = args.AudioCodec(ffmpeg.AudioCodecCopy)
+	} else {
+		args = args.AudioCodec(ffmpeg.AudioCodecAAC)
+	}
+
+	args = append(args, "-map_metadata", "0", "-movflags", "+faststart")
+	args = args.Output(outputPath)
+
+	progress.SetPercent(0)
+
+	cmd := t.FFMpeg.Command(ctx, args)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg transform failed: %w", err)
+	}
+
+	progress.SetPercent(1)
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
 )
 
@@ -31,6 +32,13 @@ func (o *trimFileOpener) Open() (io.ReadCloser, error) {
 	return os.Open(o.path)
 }
 
+// videoEdit produces an edited copy of a video file, which replaces the
+// original file in the same way as a trimmed file.
+type videoEdit interface {
+	description() string
+	apply(ctx context.Context, inputPath, outputPath string, progress *job.Progress) error
+}
+
 type TrimVideoTask struct {
 	Scene                 models.Scene
 	FileID                models.FileID // Конкретный файл для обрезки
@@ -46,6 +54,9 @@ type TrimVideoTask struct {
 	FingerprintCalculator interface {
 		CalculateFingerprints(f *models.BaseFile, o file.Opener, useExisting bool) ([]models.Fingerprint, error)
 	}
+
+	// edit replaces trimming when set
+	edit videoEdit
 }
 
 func (t *TrimVideoTask) GetDescription() string {
@@ -93,8 +104,12 @@ func (t *TrimVideoTask) Execute(ctx context.Context, progress *job.Progress) err
 	if t.EndTime != nil {
 		endStr = fmt.Sprintf("%.2fs", *t.EndTime)
 	}
-	logger.Infof("[trim-video] trimming video of scene %d from %s to %s (duration: %.2fs)",
-		t.Scene.ID, startStr, endStr, targetFile.Duration)
+	if t.edit == nil {
+		logger.Infof("[trim-video] trimming video of scene %d from %s to %s (duration: %.2fs)",
+			t.Scene.ID, startStr, endStr, targetFile.Duration)
+	} else {
+		logger.Infof("[trim-video] %s of scene %d", strings.ToLower(t.edit.description()), t.Scene.ID)
+	}
 
 	progress.SetTotal(3)
 	progress.SetProcessed(0)
@@ -201,6 +216,9 @@ func (t *TrimVideoTask) trimVideo(ctx context.Context, f *models.VideoFile, prog
 	// Use original filename for backup in temp
 	originalFilename := filepath.Base(f.Path)
 	backupTempFile := filepath.Join(backupTempDir, originalFilename)
+
+	// the scanner skips these files until the conversion is done
+	defer instance.FileLocks.Lock(f.Path, tempFile, backupTempFile)()
 	logger.Infof("[trim-video] Backup temp file path: %s", backupTempFile)
 
 	// Create backup copy of ORIGINAL file in temp directory BEFORE conversion
@@ -235,7 +253,12 @@ func (t *TrimVideoTask) trimVideo(ctx context.Context, f *models.VideoFile, prog
 		}
 	}()
 
-	if err := t.performTrimWithProgress(ctx, f.Path, tempFile, progress); err != nil {
+	if t.edit != nil {
+		if err := t.edit.apply(ctx, f.Path, tempFile, progress); err != nil {
+			logger.Errorf("[trim-video] %s failed: %v", strings.ToLower(t.edit.description()), err)
+			return fmt.Errorf("%s failed: %w", strings.ToLower(t.edit.description()), err)
+		}
+	} else if err := t.performTrimWithProgress(ctx, f.Path, tempFile, progress); err != nil {
 		logger.Errorf("[trim-video] trim failed: %v", err)
 		return fmt.Errorf("trim failed: %w", err)
 	}
@@ -262,6 +285,7 @@ func (t *TrimVideoTask) trimVideo(ctx context.Context, f *models.VideoFile, prog
 	if isUpdated {
 		// File was updated, check if we need to copy temp file to existing file
 		finalPath := newFile.Base().Path
+		defer instance.FileLocks.Lock(finalPath)()
 		logger.Infof("[trim-video] checking if temp file needs to be copied to existing file: %s", finalPath)
 
 		// Only copy if paths are different (avoid copying file to itself)
@@ -284,6 +308,7 @@ func (t *TrimVideoTask) trimVideo(ctx context.Context, f *models.VideoFile, prog
 	} else {
 		// New file was created, move temp file to final location
 		finalPath := t.getFinalPath(newFile)
+		defer instance.FileLocks.Lock(finalPath)()
 		logger.Infof("[trim-video] moving file from %s to %s", tempFile, finalPath)
 
 		// Check if temp file exists
@@ -448,29 +473,25 @@ func (t *TrimVideoTask) trimVideo(ctx context.Context, f *models.VideoFile, prog
 	logger.Infof("[trim-video] waiting for hash recalculation to complete")
 	time.Sleep(2 * time.Second)
 
-	// Regenerate sprites with new hash after trim (oldHash saved at start of function)
-	logger.Infof("[trim-video] regenerating sprites for trimmed file")
-	if err := t.Repository.WithTxn(ctx, func(ctx context.Context) error {
-		return t.regenerateSprites(ctx, oldHash)
-	}); err != nil {
-		logger.Warnf("[trim-video] failed to regenerate sprites: %v", err)
-		// Don't fail the conversion if sprite generation fails
+	// The generated files of the old video no longer match the trimmed one.
+	// They are regenerated by a job queued after this one, by which time the
+	// markers have been moved (oldHash saved at start of function).
+	if err := instance.MigrateSceneArtifacts(ctx, t.Scene.ID, oldHash, SceneArtifactsRegenerate); err != nil {
+		logger.Warnf("[trim-video] failed to update generated files: %v", err)
 	}
 
-	// Generate VTT file for the new video if it doesn't exist
-	if err := t.Repository.WithTxn(ctx, func(ctx context.Context) error {
-		return t.generateVTTFile(ctx, newFile, finalPath)
-	}); err != nil {
-		logger.Warnf("[trim-video] failed to generate VTT file: %v", err)
-	} else {
-		logger.Infof("[trim-video] generated VTT file")
+	// Move markers to their position in the trimmed video
+	if err := t.updateMarkers(ctx, newFile); err != nil {
+		logger.Warnf("[trim-video] failed to update scene markers: %v", err)
 	}
 
 	// Clear start_time and end_time from scene after successful trim
-	if err := t.clearTrimTimes(ctx); err != nil {
-		logger.Warnf("[trim-video] failed to clear trim times: %v", err)
-	} else {
-		logger.Infof("[trim-video] cleared start_time and end_time from scene")
+	if t.edit == nil {
+		if err := t.clearTrimTimes(ctx); err != nil {
+			logger.Warnf("[trim-video] failed to clear trim times: %v", err)
+		} else {
+			logger.Infof("[trim-video] cleared start_time and end_time from scene")
+		}
 	}
 
 	// Clean up backup temp file only after all operations are successful
@@ -557,6 +578,12 @@ func (t *TrimVideoTask) monitorFileSizeWithQueue(tempFile string, originalSize i
 						startVal, endVal, percent*100,
 						float64(currentSize)/1024/1024,
 						float64(originalSize)/1024/1024)
+					if t.edit != nil {
+						statusText = fmt.Sprintf("%s - %.1f%% (%.2f/%.2f MB)",
+							t.edit.description(), percent*100,
+							float64(currentSize)/1024/1024,
+							float64(originalSize)/1024/1024)
+					}
 
 					select {
 					case <-done:
@@ -582,6 +609,9 @@ func (t *TrimVideoTask) monitorFileSizeWithQueue(tempFile string, originalSize i
 					statusText := fmt.Sprintf("Trimming video from %.2fs to %.2fs - %.2f MB",
 						startVal, endVal,
 						float64(currentSize)/1024/1024)
+					if t.edit != nil {
+						statusText = fmt.Sprintf("%s - %.2f MB", t.edit.description(), float64(currentSize)/1024/1024)
+					}
 
 					select {
 					case <-done:
@@ -777,6 +807,7 @@ func (t *TrimVideoTask) createNewVideoFile(ctx context.Context, filePath string)
 		existingVideoFile.Height = videoFile.Height
 		existingVideoFile.FrameRate = videoFile.FrameRate
 		existingVideoFile.BitRate = videoFile.Bitrate
+		existingVideoFile.Interlaced = videoFile.Interlaced()
 		existingVideoFile.Format = "mp4"
 
 		// Recalculate file hash as content has changed
@@ -821,6 +852,7 @@ func (t *TrimVideoTask) createNewVideoFile(ctx context.Context, filePath string)
 		Height:     videoFile.Height,
 		FrameRate:  videoFile.FrameRate,
 		BitRate:    videoFile.Bitrate,
+		Interlaced: videoFile.Interlaced(),
 		Format:     "mp4",
 	}
 
@@ -853,8 +885,10 @@ func (t *TrimVideoTask) updateSceneWithNewFile(ctx context.Context, newFile *mod
 		scenePartial := models.NewScenePartial()
 		scenePartial.PrimaryFileID = &newFile.ID
 		// Clear start_time and end_time after trimming
-		scenePartial.StartTime = models.OptionalFloat64{Null: true, Set: true}
-		scenePartial.EndTime = models.OptionalFloat64{Null: true, Set: true}
+		if t.edit == nil {
+			scenePartial.StartTime = models.OptionalFloat64{Null: true, Set: true}
+			scenePartial.EndTime = models.OptionalFloat64{Null: true, Set: true}
+		}
 		// Ensure scene is not marked as broken
 		scenePartial.IsBroken = models.NewOptionalBool(false)
 
@@ -982,62 +1016,6 @@ func (t *TrimVideoTask) recalculateFileHashes(ctx context.Context, file *models.
 	return nil
 }
 
-func (t *TrimVideoTask) generateVTTFile(ctx context.Context, file *models.VideoFile, filePath string) error {
-	// Get updated scene from database with new hash
-	updatedScene, err := t.Repository.Scene.Find(ctx, t.Scene.ID)
-	if err != nil {
-		return fmt.Errorf("failed to load updated scene: %w", err)
-	}
-
-	if updatedScene != nil {
-		if err := updatedScene.LoadFiles(ctx, t.Repository.Scene); err != nil {
-			return fmt.Errorf("failed to load scene files: %w", err)
-		}
-	}
-
-	if updatedScene == nil {
-		return fmt.Errorf("updated scene not found")
-	}
-
-	// Check if VTT file already exists
-	sceneHash := updatedScene.GetHash(t.FileNamingAlgorithm)
-	vttPath := t.Paths.Scene.GetSpriteVttFilePath(sceneHash)
-
-	if _, err := os.Stat(vttPath); err == nil {
-		logger.Infof("[trim-video] VTT file already exists: %s", vttPath)
-		return nil
-	}
-
-	// Check if sprite image exists
-	spritePath := t.Paths.Scene.GetSpriteImageFilePath(sceneHash)
-	if _, err := os.Stat(spritePath); err != nil {
-		logger.Infof("[trim-video] sprite image does not exist, skipping VTT generation: %s", spritePath)
-		return nil
-	}
-
-	// Generate VTT file using the Generator
-	generator := &generate.Generator{
-		Encoder:      t.FFMpeg,
-		FFMpegConfig: t.Config,
-		LockManager:  t.G.LockManager,
-		ScenePaths:   t.Paths.Scene,
-	}
-
-	// Calculate step size for VTT generation
-	stepSize := 10.0
-	if file.Duration > 0 {
-		stepSize = file.Duration / 100.0
-	}
-
-	logger.Infof("[trim-video] generating VTT file: %s", vttPath)
-	if err := generator.SpriteVTT(ctx, vttPath, spritePath, stepSize); err != nil {
-		return fmt.Errorf("failed to generate VTT file: %w", err)
-	}
-
-	logger.Infof("[trim-video] successfully generated VTT file: %s", vttPath)
-	return nil
-}
-
 func (t *TrimVideoTask) isFileAssociatedWithScene(ctx context.Context, fileID models.FileID) (bool, error) {
 	// Get all files associated with the scene
 	sceneFiles, err := t.Repository.Scene.GetFiles(ctx, t.Scene.ID)
@@ -1085,141 +1063,59 @@ func (t *TrimVideoTask) copyFileContent(src, dst string) error {
 	return nil
 }
 
-func (t *TrimVideoTask) regenerateSprites(ctx context.Context, oldHash string) error {
-	// Get updated scene from database with new hash
-	updatedScene, err := t.Repository.Scene.Find(ctx, t.Scene.ID)
-	if err != nil {
-		return fmt.Errorf("failed to load updated scene: %w", err)
+// clearTrimTimes removes start_time and end_time from the scene after successful trim
+// updateMarkers shifts the markers of the scene by the trimmed start time,
+// and deletes those that are outside of the trimmed range. The files
+// generated for the markers are handled by MigrateSceneArtifacts.
+func (t *TrimVideoTask) updateMarkers(ctx context.Context, newFile *models.VideoFile) error {
+	start := 0.0
+	if t.StartTime != nil && t.edit == nil {
+		start = *t.StartTime
 	}
 
-	if updatedScene == nil {
-		return fmt.Errorf("updated scene not found")
+	var end *float64
+	if t.edit == nil {
+		end = t.EndTime
 	}
 
-	newHash := updatedScene.GetHash(t.FileNamingAlgorithm)
-	logger.Infof("[trim-video] sprite migration: old hash=%s, new hash=%s", oldHash, newHash)
+	return t.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		qb := t.Repository.SceneMarker
 
-	// If hash is empty, try to get phash from scene files
-	if newHash == "" {
-		logger.Infof("[trim-video] scene hash is empty, trying to get phash from files")
+		markers, err := qb.FindBySceneID(ctx, t.Scene.ID)
+		if err != nil {
+			return fmt.Errorf("finding scene markers: %w", err)
+		}
 
-		// Load scene files to get phash
-		if err := updatedScene.LoadFiles(ctx, t.Repository.Scene); err != nil {
-			logger.Warnf("[trim-video] failed to load scene files: %v", err)
-		} else {
-			// Look for phash in scene files
-			for _, vf := range updatedScene.Files.List() {
-				videoFile := vf
-				if phash := videoFile.Base().Fingerprints.Get(models.FingerprintTypePhash); phash != nil {
-					newHash = phash.(string)
-					logger.Infof("[trim-video] found phash in file %d: %s", videoFile.ID, newHash)
-					break
+		for _, m := range markers {
+			if !scene.MarkerInRange(m, start, end) {
+				if err := qb.Destroy(ctx, m.ID); err != nil {
+					return fmt.Errorf("destroying scene marker %d: %w", m.ID, err)
 				}
+				logger.Infof("[trim-video] deleted scene marker %d outside of trimmed range", m.ID)
+				continue
 			}
-		}
-	}
-
-	// Check if sprites exist for OLD hash
-	oldSpriteImagePath := t.Paths.Scene.GetSpriteImageFilePath(oldHash)
-	oldSpriteVttPath := t.Paths.Scene.GetSpriteVttFilePath(oldHash)
-
-	// Paths for NEW hash
-	newSpriteImagePath := t.Paths.Scene.GetSpriteImageFilePath(newHash)
-	newSpriteVttPath := t.Paths.Scene.GetSpriteVttFilePath(newHash)
 
-	logger.Infof("[trim-video] checking old sprites:")
-	logger.Infof("[trim-video]   old image: %s", oldSpriteImagePath)
-	logger.Infof("[trim-video]   old vtt: %s", oldSpriteVttPath)
-	logger.Infof("[trim-video] new sprite paths:")
-	logger.Infof("[trim-video]   new image: %s", newSpriteImagePath)
-	logger.Infof("[trim-video]   new vtt: %s", newSpriteVttPath)
-
-	// Verify that the new hash is not empty
-	if newHash == "" {
-		logger.Errorf("[trim-video] new hash is empty, cannot generate sprites")
-		logger.Infof("[trim-video] scene OSHash: '%s', Checksum: '%s'", updatedScene.OSHash, updatedScene.Checksum)
-		logger.Infof("[trim-video] file naming algorithm: %s", t.FileNamingAlgorithm)
-		return fmt.Errorf("new hash is empty")
-	}
-
-	oldSpriteImageExists := false
-	oldSpriteVttExists := false
-
-	if _, err := os.Stat(oldSpriteImagePath); err == nil {
-		oldSpriteImageExists = true
-		logger.Infof("[trim-video] old sprite image exists")
-	} else {
-		logger.Infof("[trim-video] old sprite image does not exist")
-	}
-
-	if _, err := os.Stat(oldSpriteVttPath); err == nil {
-		oldSpriteVttExists = true
-		logger.Infof("[trim-video] old sprite vtt exists")
-	} else {
-		logger.Infof("[trim-video] old sprite vtt does not exist")
-	}
-
-	// For video trimming, we need to regenerate sprites as video content has changed
-	// Delete old sprites first
-	if oldSpriteImageExists {
-		logger.Infof("[trim-video] deleting old sprite image: %s", oldSpriteImagePath)
-		if err := os.Remove(oldSpriteImagePath); err != nil {
-			logger.Warnf("[trim-video] failed to delete old sprite image: %v", err)
-		}
-	}
-
-	if oldSpriteVttExists {
-		logger.Infof("[trim-video] deleting old sprite VTT: %s", oldSpriteVttPath)
-		if err := os.Remove(oldSpriteVttPath); err != nil {
-			logger.Warnf("[trim-video] failed to delete old sprite VTT: %v", err)
-		}
-	}
-
-	// If old sprites don't exist, check if new sprites already exist
-	newSpriteImageExists := false
-	newSpriteVttExists := false
-
-	if _, err := os.Stat(newSpriteImagePath); err == nil {
-		newSpriteImageExists = true
-	}
+			if start == 0 {
+				continue
+			}
 
-	if _, err := os.Stat(newSpriteVttPath); err == nil {
-		newSpriteVttExists = true
-	}
+			seconds, endSeconds := scene.ShiftMarker(m, -start, newFile.Duration)
 
-	// Always generate new sprites for trimmed video as content has changed
-	// Delete existing sprites for new hash if they exist
-	if newSpriteImageExists {
-		logger.Infof("[trim-video] deleting existing sprite image for new hash: %s", newSpriteImagePath)
-		if err := os.Remove(newSpriteImagePath); err != nil {
-			logger.Warnf("[trim-video] failed to delete existing sprite image: %v", err)
-		}
-	}
+			updatedMarker := models.NewSceneMarkerPartial()
+			updatedMarker.Seconds = models.NewOptionalFloat64(seconds)
+			if endSeconds != nil {
+				updatedMarker.EndSeconds = models.NewOptionalFloat64(*endSeconds)
+			}
 
-	if newSpriteVttExists {
-		logger.Infof("[trim-video] deleting existing sprite VTT for new hash: %s", newSpriteVttPath)
-		if err := os.Remove(newSpriteVttPath); err != nil {
-			logger.Warnf("[trim-video] failed to delete existing sprite VTT: %v", err)
+			if _, err := qb.UpdatePartial(ctx, m.ID, updatedMarker); err != nil {
+				return fmt.Errorf("updating scene marker %d: %w", m.ID, err)
+			}
 		}
-	}
-
-	logger.Infof("[trim-video] generating new sprites for trimmed video scene %d", t.Scene.ID)
-	logger.Infof("[trim-video] using scene hash for sprite generation: %s", newHash)
-	logger.Infof("[trim-video] scene path for sprite generation: %s", updatedScene.Path)
-
-	spriteTask := GenerateSpriteTask{
-		Scene:               *updatedScene,
-		Overwrite:           true,
-		fileNamingAlgorithm: t.FileNamingAlgorithm,
-	}
 
-	// Run sprite generation
-	spriteTask.Start(ctx)
-	logger.Infof("[trim-video] generated new sprites for scene %d with hash %s", t.Scene.ID, newHash)
-	return nil
+		return nil
+	})
 }
 
-// clearTrimTimes removes start_time and end_time from the scene after successful trim
 func (t *TrimVideoTask) clearTrimTimes(ctx context.Context) error {
 	return t.Repository.WithTxn(ctx, func(ctx context.Context) error {
 		// Create scene partial to clear start_time and end_time
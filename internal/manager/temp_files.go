@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// tempFilesCheckInterval is how often the temp directory is cleaned up
+// according to the configured limits.
+const tempFilesCheckInterval = time.Hour
+
+// TempFiles returns the files and directories in the temp directory, such as
+// the backups of conversion tasks, oldest first.
+func (s *Manager) TempFiles() ([]fsutil.TempEntry, error) {
+	return fsutil.ListTempEntries(s.Config.GetTempPath())
+}
+
+// IsTempFileInUse returns true if the temp file is being used by a running
+// task, in which case it cannot be purged.
+func (s *Manager) IsTempFileInUse(path string) bool {
+	return s.FileLocks.IsLocked(path)
+}
+
+// PurgeTempFiles removes the files in the temp directory with the given
+// names, or all files if names is nil. Only files older than olderThan are
+// removed, if it is not zero. Files in use by a running task are never
+// removed. Returns the removed files.
+func (s *Manager) PurgeTempFiles(names []string, olderThan time.Duration) ([]fsutil.TempEntry, error) {
+	entries, err := s.TempFiles()
+	if err != nil {
+		return nil, fmt.Errorf("listing temp files: %w", err)
+	}
+
+	// only names listed in the temp directory are matched, so that paths
+	// outside of it cannot be given
+	var selected map[string]bool
+	if names != nil {
+		selected = make(map[string]bool)
+		for _, n := range names {
+			selected[n] = true
+		}
+	}
+
+	now := time.Now()
+	var purge []fsutil.TempEntry
+	for _, e := range entries {
+		if selected != nil && !selected[e.Name] {
+			continue
+		}
+		if olderThan > 0 && now.Sub(e.ModTime) <= olderThan {
+			continue
+		}
+		if s.IsTempFileInUse(e.Path) {
+			logger.Infof("[temp] not removing %s: in use", e.Path)
+			continue
+		}
+
+		purge = append(purge, e)
+	}
+
+	return s.removeTempFiles(purge), nil
+}
+
+// removeTempFiles removes the entries, returning those which were removed.
+func (s *Manager) removeTempFiles(entries []fsutil.TempEntry) []fsutil.TempEntry {
+	var ret []fsutil.TempEntry
+	var freed int64
+	for _, e := range entries {
+		if err := os.RemoveAll(e.Path); err != nil {
+			logger.Warnf("[temp] error removing %s: %v", e.Path, err)
+			continue
+		}
+
+		ret = append(ret, e)
+		freed += e.Size
+	}
+
+	if len(ret) > 0 {
+		logger.Infof("[temp] removed %d temp files, %s freed", len(ret), utils.FormatBytes(freed))
+	}
+
+	return ret
+}
+
+// cleanupTempFiles removes the files in the temp directory exceeding the
+// configured maximum age and size, oldest first.
+func (s *Manager) cleanupTempFiles() {
+	policy := fsutil.TempCleanupPolicy{
+		MaxAge:  s.Config.GetTempFilesMaxAge(),
+		MaxSize: s.Config.GetTempFilesMaxSize(),
+	}
+	if policy.MaxAge <= 0 && policy.MaxSize <= 0 {
+		return
+	}
+
+	entries, err := s.TempFiles()
+	if err != nil {
+		logger.Warnf("[temp] error listing temp files: %v", err)
+		return
+	}
+
+	s.removeTempFiles(policy.Expired(entries, time.Now(), s.IsTempFileInUse))
+}
+
+// monitorTempFiles periodically cleans up the temp directory in the
+// background, so that files left behind by failed tasks do not accumulate.
+func (s *Manager) monitorTempFiles() {
+	go func() {
+		ticker := time.NewTicker(tempFilesCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if !s.Config.IsNewSystem() {
+				s.cleanupTempFiles()
+			}
+
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,123 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/threatscan"
+)
+
+// threatScanSyncMaxSize is the size of the largest file scanned for threats
+// while the request waits. Larger files are scanned in a job.
+const threatScanSyncMaxSize = 512 << 20 // 512MiB
+
+// FileThreatScan is the result of an on demand threat scan of a file.
+type FileThreatScan struct {
+	// JobID is set if the file is too large to scan while the request waits.
+	// The findings are stored with the file when the job completes.
+	JobID     *int                `json:"job_id"`
+	Findings  []threatscan.Result `json:"findings"`
+	ScannedAt *time.Time          `json:"scanned_at"`
+}
+
+// ScanFileForThreats scans a video file for security threats and stores the
+// findings with the file. Files up to threatScanSyncMaxSize are scanned
+// before returning, larger files are scanned in a job.
+func (s *Manager) ScanFileForThreats(ctx context.Context, fileID string) (*FileThreatScan, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return nil, err
+	}
+
+	videoFile, err := s.findThreatScanFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if videoFile.Size > threatScanSyncMaxSize {
+		jobID, err := s.ScanVideoFileThreats(ctx, fileID)
+		if err != nil {
+			return nil, err
+		}
+		return &FileThreatScan{JobID: &jobID}, nil
+	}
+
+	threats, err := s.scanFileThreats(ctx, videoFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileThreatScan{
+		Findings:  threats,
+		ScannedAt: videoFile.ThreatsScannedAt,
+	}, nil
+}
+
+// findThreatScanFile returns the video file with the ID, or an error if it
+// cannot be scanned for threats.
+func (s *Manager) findThreatScanFile(ctx context.Context, fileID string) (*models.VideoFile, error) {
+	fileIDInt, err := strconv.Atoi(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file id %s: %w", fileID, err)
+	}
+
+	var videoFile *models.VideoFile
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		files, err := s.Repository.File.Find(ctx, models.FileID(fileIDInt))
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("file with id %s not found", fileID)
+		}
+
+		var ok bool
+		videoFile, ok = files[0].(*models.VideoFile)
+		if !ok {
+			return fmt.Errorf("file %s is not a video file", fileID)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if videoFile.ZipFileID != nil {
+		return nil, fmt.Errorf("scanning files inside zip archives is not supported")
+	}
+
+	return videoFile, nil
+}
+
+// scanFileThreats scans the video file for threats and stores the findings
+// with the file.
+func (s *Manager) scanFileThreats(ctx context.Context, videoFile *models.VideoFile) ([]threatscan.Result, error) {
+	scanner := threatscan.NewScanner(s.FFProbe, s.FFMpeg)
+	threats, err := scanner.Scan(ctx, videoFile.Path)
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	threatsStr := threatscan.FormatThreats(threats)
+	videoFile.Threats = threatsStr
+	scannedAt := time.Now()
+	videoFile.ThreatsScannedAt = &scannedAt
+
+	if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		return s.Repository.File.Update(ctx, videoFile)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update file: %w", err)
+	}
+
+	if len(threats) > 0 {
+		logger.Infof("Threat scan found %d threat(s) in file %s: %s",
+			len(threats), videoFile.Path, strings.ReplaceAll(threatsStr, "\n", "; "))
+	} else {
+		logger.Infof("Threat scan completed: no threats found in file %s", videoFile.Path)
+	}
+
+	return threats, nil
+}
@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// TrimImpact returns the markers of the scene and the funscript actions of
+// its file f which are affected by trimming f to the range from start to
+// end seconds. A nil start or end keeps the beginning or end of the file.
+// Nothing is changed.
+func (s *Manager) TrimImpact(ctx context.Context, sc *models.Scene, f *models.VideoFile, start, end *float64) (*scene.TrimImpact, error) {
+	r := s.Repository
+
+	var markers []*models.SceneMarker
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		markers, err = r.SceneMarker.FindBySceneID(ctx, sc.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var actions []float64
+	if f.Interactive {
+		script, err := LoadFunscriptData(video.GetFunscriptPath(f.Path))
+		if err != nil {
+			// the trim is not prevented by an unreadable funscript
+			logger.Warnf("[trim-video] error loading funscript of %s: %v", f.Path, err)
+		}
+
+		for _, a := range script.Actions {
+			// action times are in milliseconds
+			actions = append(actions, a.At/1000)
+		}
+	}
+
+	ret := scene.NewTrimImpact(start, end, f.Duration, markers, actions)
+	return &ret, nil
+}
@@ -0,0 +1,330 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/videophash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// osFileOpener implements file.Opener for OS files
+type osFileOpener struct {
+	path string
+}
+
+func (o *osFileOpener) Open() (io.ReadCloser, error) {
+	return os.Open(o.path)
+}
+
+// videoFileReplacement replaces a video file of a scene with an encoded copy
+// of it, such as one converted to MP4 or reduced in resolution. The new file
+// is named as the original with the mp4 extension, in the same folder, or
+// with a number appended if another file has that name.
+//
+// No transaction is held while files are copied or fingerprinted. The
+// database is changed in short transactions, ordered so that a crash at any
+// point leaves a consistent state:
+//   - the encoded file is moved into place with a rename, so a partially
+//     copied file is never left under the final name
+//   - the new file, its fingerprints and its association with the scene are
+//     written in a single transaction once the file is in place. If this
+//     does not happen, the new file is picked up by the next scan.
+//   - the original file is deleted only after that transaction, and its
+//     record after the file. If this does not happen, the original remains
+//     a file of the scene, or is removed by the next clean.
+type videoFileReplacement struct {
+	Scene    models.Scene
+	Original *models.VideoFile
+	// EncodedPath is the path of the encoded copy. It is removed once moved
+	// into place.
+	EncodedPath string
+	// ClearBroken clears the broken flag of the scene.
+	ClearBroken bool
+
+	FFMpeg                *ffmpeg.FFMpeg
+	FFProbe               *ffmpeg.FFProbe
+	Repository            models.Repository
+	FingerprintCalculator interface {
+		CalculateFingerprints(f *models.BaseFile, o file.Opener, useExisting bool) ([]models.Fingerprint, error)
+	}
+
+	// LogPrefix prefixes log messages, such as [convert].
+	LogPrefix string
+}
+
+// maxFinalPathAttempts is the number of names tried for the new file before
+// giving up.
+const maxFinalPathAttempts = 100
+
+// finalPath returns the path of the new file. This is the path of the
+// original with the mp4 extension, unless that path is used by another file,
+// in which case a number is appended to the name, such as "name (1).mp4".
+// Must be called in a transaction.
+func (r *videoFileReplacement) finalPath(ctx context.Context) (string, error) {
+	dir := filepath.Dir(r.Original.Path)
+	name := strings.TrimSuffix(r.Original.Basename, filepath.Ext(r.Original.Basename))
+
+	for i := 0; i < maxFinalPathAttempts; i++ {
+		basename := name + ".mp4"
+		if i > 0 {
+			basename = fmt.Sprintf("%s (%d).mp4", name, i)
+		}
+		path := filepath.Join(dir, basename)
+
+		// the original may be replaced in place
+		if path == r.Original.Path {
+			return path, nil
+		}
+
+		existing, err := r.Repository.File.FindByBasenameAndParentFolderID(ctx, basename, r.Original.ParentFolderID)
+		if err != nil {
+			return "", fmt.Errorf("finding existing file: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		// files not yet scanned have no record
+		if _, err := os.Stat(path); err == nil {
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		return path, nil
+	}
+
+	return "", fmt.Errorf("no unused name for %s.mp4 in %s", name, dir)
+}
+
+// replace moves the encoded file into place and replaces the original file
+// of the scene with it. Returns the new file.
+func (r *videoFileReplacement) replace(ctx context.Context) (*models.VideoFile, error) {
+	var finalPath string
+	if err := r.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		finalPath, err = r.finalPath(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	defer instance.FileLocks.Lock(finalPath)()
+
+	probe, err := r.FFProbe.NewVideoFile(r.EncodedPath)
+	if err != nil {
+		return nil, fmt.Errorf("probing encoded file: %w", err)
+	}
+
+	if err := r.moveIntoPlace(finalPath); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(finalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the details and fingerprints of the new file are determined before
+	// the transaction, since hashing a large file takes some time
+	newFile := &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			Path:           finalPath,
+			Basename:       filepath.Base(finalPath),
+			Size:           info.Size(),
+			ParentFolderID: r.Original.ParentFolderID,
+			CreatedAt:      r.Original.CreatedAt,
+			UpdatedAt:      time.Now(),
+			DirEntry: models.DirEntry{
+				ModTime: info.ModTime(),
+			},
+		},
+		Duration:   probe.FileDuration,
+		VideoCodec: probe.VideoCodec,
+		AudioCodec: probe.AudioCodec,
+		Width:      probe.Width,
+		Height:     probe.Height,
+		FrameRate:  probe.FrameRate,
+		BitRate:    probe.Bitrate,
+		Format:     "mp4",
+	}
+
+	fingerprints, err := r.fingerprints(newFile)
+	if err != nil {
+		return nil, err
+	}
+	newFile.Fingerprints = fingerprints
+
+	// replaced is true if the new file has a record of its own, which
+	// replaces that of the original
+	replaced := false
+	if err := r.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		replaced, err = r.storeFile(ctx, newFile)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("storing new file: %w", err)
+	}
+
+	logger.Infof("%s replaced file %s of scene %d with %s", r.LogPrefix, r.Original.Path, r.Scene.ID, finalPath)
+
+	if !replaced {
+		return newFile, nil
+	}
+
+	if err := os.Remove(r.Original.Path); err != nil {
+		logger.Warnf("%s failed to remove original file %s: %v", r.LogPrefix, r.Original.Path, err)
+		return newFile, nil
+	}
+
+	if err := r.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		return r.Repository.File.Destroy(ctx, r.Original.ID)
+	}); err != nil {
+		logger.Warnf("%s failed to delete record of original file %s: %v", r.LogPrefix, r.Original.Path, err)
+	}
+
+	return newFile, nil
+}
+
+// moveIntoPlace copies the encoded file next to the final path, and renames
+// it to the final path.
+func (r *videoFileReplacement) moveIntoPlace(finalPath string) error {
+	partPath := finalPath + ".part"
+	defer instance.FileLocks.Lock(partPath)()
+
+	if err := copyFileSynced(r.EncodedPath, partPath); err != nil {
+		_ = os.Remove(partPath)
+		return fmt.Errorf("copying encoded file: %w", err)
+	}
+
+	if err := os.Rename(partPath, finalPath); err != nil {
+		_ = os.Remove(partPath)
+		return fmt.Errorf("moving encoded file into place: %w", err)
+	}
+
+	if err := os.Remove(r.EncodedPath); err != nil {
+		logger.Warnf("%s failed to remove encoded file %s: %v", r.LogPrefix, r.EncodedPath, err)
+	}
+
+	return nil
+}
+
+func (r *videoFileReplacement) fingerprints(f *models.VideoFile) (models.Fingerprints, error) {
+	calculated, err := r.FingerprintCalculator.CalculateFingerprints(f.Base(), &osFileOpener{path: f.Path}, false)
+	if err != nil {
+		return nil, fmt.Errorf("calculating fingerprints: %w", err)
+	}
+
+	var ret models.Fingerprints
+	for _, fp := range calculated {
+		ret = ret.AppendUnique(fp)
+	}
+
+	if f.Duration > 0 {
+		phash, err := videophash.Generate(r.FFMpeg, f)
+		if err != nil {
+			logger.Warnf("%s failed to calculate phash of %s: %v", r.LogPrefix, f.Path, err)
+		} else {
+			ret = ret.AppendUnique(models.Fingerprint{
+				Type:        models.FingerprintTypePhash,
+				Fingerprint: int64(*phash),
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// storeFile creates or updates the record of the new file, assigns it to the
+// scene and makes it the primary file if the original was. Returns true if a
+// record was created, rather than an existing one updated.
+func (r *videoFileReplacement) storeFile(ctx context.Context, newFile *models.VideoFile) (bool, error) {
+	qb := r.Repository.File
+
+	existing, err := qb.FindByBasenameAndParentFolderID(ctx, newFile.Basename, newFile.ParentFolderID)
+	if err != nil {
+		return false, fmt.Errorf("finding existing file: %w", err)
+	}
+
+	created := existing == nil
+	if existing != nil {
+		// the new file overwrote the original, which already had the mp4
+		// extension. The path of any other file is not used by finalPath.
+		if existing.Base().ID != r.Original.ID {
+			return false, fmt.Errorf("file %s is already in use by another file", newFile.Path)
+		}
+
+		newFile.ID = r.Original.ID
+		newFile.CreatedAt = r.Original.CreatedAt
+		if err := qb.Update(ctx, newFile); err != nil {
+			return false, fmt.Errorf("updating file: %w", err)
+		}
+	} else if err := qb.Create(ctx, newFile); err != nil {
+		return false, fmt.Errorf("creating file: %w", err)
+	}
+
+	sceneFiles, err := r.Repository.Scene.GetFiles(ctx, r.Scene.ID)
+	if err != nil {
+		return false, err
+	}
+
+	assigned := false
+	for _, f := range sceneFiles {
+		assigned = assigned || f.ID == newFile.ID
+	}
+	if !assigned {
+		if err := r.Repository.Scene.AssignFiles(ctx, r.Scene.ID, []models.FileID{newFile.ID}); err != nil {
+			return false, fmt.Errorf("assigning file to scene: %w", err)
+		}
+	}
+
+	partial := models.NewScenePartial()
+	if primary := r.Scene.Files.Primary(); primary == nil || primary.ID == r.Original.ID {
+		partial.PrimaryFileID = &newFile.ID
+	}
+	if r.ClearBroken {
+		partial.IsBroken = models.NewOptionalBool(false)
+	}
+
+	if _, err := r.Repository.Scene.UpdatePartial(ctx, r.Scene.ID, partial); err != nil {
+		return false, fmt.Errorf("updating scene: %w", err)
+	}
+
+	return created, nil
+}
+
+// copyFileSynced copies the content of src to dst, and syncs dst to disk.
+func copyFileSynced(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		dstFile.Close()
+		return err
+	}
+
+	if err := dstFile.Sync(); err != nil {
+		dstFile.Close()
+		return err
+	}
+
+	return dstFile.Close()
+}
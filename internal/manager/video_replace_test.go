@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVideoFileReplacement_finalPath(t *testing.T) {
+	const folderID = models.FolderID(1)
+
+	videoFile := func(id models.FileID, path string) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				ID:             id,
+				Path:           path,
+				Basename:       filepath.Base(path),
+				ParentFolderID: folderID,
+			},
+		}
+	}
+
+	dir := t.TempDir()
+	// an unscanned file, which has no record
+	if err := os.WriteFile(filepath.Join(dir, "b (1).mp4"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		original *models.VideoFile
+		// records are the existing file records by basename
+		records map[string]models.File
+		want    string
+	}{
+		{
+			"unused",
+			videoFile(1, filepath.Join(dir, "a.avi")),
+			nil,
+			filepath.Join(dir, "a.mp4"),
+		},
+		{
+			"original is mp4",
+			videoFile(1, filepath.Join(dir, "a.mp4")),
+			nil,
+			filepath.Join(dir, "a.mp4"),
+		},
+		{
+			"used by another file",
+			videoFile(1, filepath.Join(dir, "a.avi")),
+			map[string]models.File{"a.mp4": videoFile(2, filepath.Join(dir, "a.mp4"))},
+			filepath.Join(dir, "a (1).mp4"),
+		},
+		{
+			"used by another file and an unscanned file",
+			videoFile(1, filepath.Join(dir, "b.avi")),
+			map[string]models.File{"b.mp4": videoFile(2, filepath.Join(dir, "b.mp4"))},
+			filepath.Join(dir, "b (2).mp4"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			db.File.On("FindByBasenameAndParentFolderID", mock.Anything, mock.Anything, folderID).Return(
+				func(_ context.Context, basename string, _ models.FolderID) models.File {
+					return tt.records[basename]
+				},
+				nil,
+			).Maybe()
+
+			r := &videoFileReplacement{
+				Original:   tt.original,
+				Repository: db.Repository(),
+			}
+
+			got, err := r.finalPath(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package activity summarises when scenes were played and their o-counts
+// recorded, as a calendar of days with the streaks of consecutive days of
+// viewing, and as the statistics of the performers of the scenes.
+package activity
+
+import (
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Day is the activity of a single day.
+type Day struct {
+	// Date is formatted as YYYY-MM-DD.
+	Date   string
+	Views  int
+	OCount int
+}
+
+// Streak is a run of consecutive days with views.
+type Streak struct {
+	Start string
+	End   string
+	Days  int
+}
+
+// Bucket is the activity of a day of the week, from 0 for Sunday, or an
+// hour of the day.
+type Bucket struct {
+	Index  int
+	Views  int
+	OCount int
+}
+
+// Calendar is the activity between two days.
+type Calendar struct {
+	// Days are every day of the range, including those without activity.
+	Days        []Day
+	ActiveDays  int
+	TotalViews  int
+	TotalOCount int
+	// CurrentStreak is the number of consecutive days with views up to the
+	// last day of the range. A streak ending the day before still counts,
+	// since the last day may not be over.
+	CurrentStreak int
+	// LongestStreak is nil if there were no views.
+	LongestStreak *Streak
+	Weekdays      []Bucket
+	Hours         []Bucket
+}
+
+// NewCalendar returns the activity of the views and o dates, in the time
+// zone loc, of the days from the day of start to the day of end. Dates
+// outside of the range are ignored.
+func NewCalendar(views, oDates []time.Time, start, end time.Time, loc *time.Location) Calendar {
+	first := day(start.In(loc))
+	last := day(end.In(loc))
+
+	ret := Calendar{
+		Weekdays: make([]Bucket, 7),
+		Hours:    make([]Bucket, 24),
+	}
+	for i := range ret.Weekdays {
+		ret.Weekdays[i].Index = i
+	}
+	for i := range ret.Hours {
+		ret.Hours[i].Index = i
+	}
+
+	index := make(map[string]int)
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateFormat)
+		index[date] = len(ret.Days)
+		ret.Days = append(ret.Days, Day{Date: date})
+	}
+
+	add := func(dates []time.Time, o bool) {
+		for _, t := range dates {
+			t = t.In(loc)
+			i, found := index[t.Format(dateFormat)]
+			if !found {
+				continue
+			}
+
+			if o {
+				ret.Days[i].OCount++
+				ret.TotalOCount++
+				ret.Weekdays[t.Weekday()].OCount++
+				ret.Hours[t.Hour()].OCount++
+			} else {
+				ret.Days[i].Views++
+				ret.TotalViews++
+				ret.Weekdays[t.Weekday()].Views++
+				ret.Hours[t.Hour()].Views++
+			}
+		}
+	}
+	add(views, false)
+	add(oDates, true)
+
+	var streak *Streak
+	for i, d := range ret.Days {
+		if d.Views > 0 || d.OCount > 0 {
+			ret.ActiveDays++
+		}
+
+		if d.Views == 0 {
+			streak = nil
+			continue
+		}
+
+		if streak == nil {
+			streak = &Streak{Start: d.Date}
+		}
+		streak.End = d.Date
+		streak.Days++
+
+		if ret.LongestStreak == nil || streak.Days > ret.LongestStreak.Days {
+			longest := *streak
+			ret.LongestStreak = &longest
+		}
+
+		if i >= len(ret.Days)-2 {
+			ret.CurrentStreak = streak.Days
+		}
+	}
+
+	return ret
+}
+
+// day returns the start of the day of t, in the location of t.
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
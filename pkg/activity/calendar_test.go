@@ -0,0 +1,61 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalendar(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 3, day, hour, 0, 0, 0, loc)
+	}
+
+	views := []time.Time{
+		// outside of the range
+		at(1, 12),
+		at(2, 10), at(2, 11),
+		at(3, 10),
+		at(4, 23),
+		// day 5 has only an o
+		at(6, 10),
+		at(7, 1),
+		// stored in UTC, where it is on the previous day
+		at(8, 1).UTC(),
+	}
+	oDates := []time.Time{at(5, 10), at(7, 1)}
+
+	c := NewCalendar(views, oDates, at(2, 0), at(9, 22), loc)
+
+	assert.Len(t, c.Days, 8)
+	assert.Equal(t, Day{Date: "2024-03-02", Views: 2}, c.Days[0])
+	assert.Equal(t, Day{Date: "2024-03-05", OCount: 1}, c.Days[3])
+	assert.Equal(t, Day{Date: "2024-03-08", Views: 1}, c.Days[6])
+	assert.Equal(t, Day{Date: "2024-03-09"}, c.Days[7])
+
+	assert.Equal(t, 7, c.ActiveDays)
+	assert.Equal(t, 7, c.TotalViews)
+	assert.Equal(t, 2, c.TotalOCount)
+
+	assert.Equal(t, &Streak{Start: "2024-03-02", End: "2024-03-04", Days: 3}, c.LongestStreak)
+	// the last day has no views yet
+	assert.Equal(t, 3, c.CurrentStreak)
+
+	// 2024-03-02 was a Saturday
+	assert.Equal(t, Bucket{Index: 6, Views: 2}, c.Weekdays[6])
+	assert.Equal(t, Bucket{Index: 1, Views: 2, OCount: 1}, c.Hours[1])
+	assert.Equal(t, Bucket{Index: 10, Views: 3, OCount: 1}, c.Hours[10])
+}
+
+func TestNewCalendarNoViews(t *testing.T) {
+	now := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	c := NewCalendar(nil, nil, now.AddDate(0, 0, -2), now, time.UTC)
+
+	assert.Len(t, c.Days, 3)
+	assert.Nil(t, c.LongestStreak)
+	assert.Zero(t, c.CurrentStreak)
+	assert.Len(t, c.Weekdays, 7)
+	assert.Len(t, c.Hours, 24)
+}
@@ -0,0 +1,154 @@
+package activity
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// PerformerStatsSort is the statistic by which performers are ranked.
+type PerformerStatsSort string
+
+const (
+	PerformerStatsSortWatchTime        PerformerStatsSort = "WATCH_TIME"
+	PerformerStatsSortViews            PerformerStatsSort = "VIEWS"
+	PerformerStatsSortOCount           PerformerStatsSort = "O_COUNT"
+	PerformerStatsSortAttributedOCount PerformerStatsSort = "ATTRIBUTED_O_COUNT"
+)
+
+func (e PerformerStatsSort) IsValid() bool {
+	switch e {
+	case PerformerStatsSortWatchTime, PerformerStatsSortViews, PerformerStatsSortOCount, PerformerStatsSortAttributedOCount:
+		return true
+	}
+	return false
+}
+
+func (e PerformerStatsSort) String() string {
+	return string(e)
+}
+
+func (e *PerformerStatsSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformerStatsSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerStatsSort", str)
+	}
+	return nil
+}
+
+func (e PerformerStatsSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneActivity is the activity of a scene in a time range.
+type SceneActivity struct {
+	SceneID      int
+	PerformerIDs []int
+	Views        int
+	OCount       int
+	// WatchTime is the time the scene was played in the range, in seconds.
+	WatchTime float64
+}
+
+// WatchTimeInRange estimates the time a scene was played in a range, from
+// its total play duration. The play duration is not recorded per view, so it
+// is shared equally between the views of the scene.
+func WatchTimeInRange(playDuration float64, views, totalViews int) float64 {
+	if totalViews <= 0 || views <= 0 {
+		return 0
+	}
+	if views >= totalViews {
+		return playDuration
+	}
+
+	return playDuration * float64(views) / float64(totalViews)
+}
+
+// PerformerStats is the activity of the scenes of a performer in a time
+// range.
+type PerformerStats struct {
+	PerformerID int
+	// Rank is the position of the performer by the sorted statistic, from
+	// 1. Performers with equal values share a rank.
+	Rank       int
+	SceneCount int
+	Views      int
+	// WatchTime is in seconds.
+	WatchTime float64
+	// OCount is the o-count of the scenes of the performer.
+	OCount int
+	// AttributedOCount shares the o-count of each scene equally between
+	// its performers, so that it is not counted more than once in total.
+	AttributedOCount float64
+}
+
+func (s PerformerStats) value(by PerformerStatsSort) float64 {
+	switch by {
+	case PerformerStatsSortViews:
+		return float64(s.Views)
+	case PerformerStatsSortOCount:
+		return float64(s.OCount)
+	case PerformerStatsSortAttributedOCount:
+		return s.AttributedOCount
+	}
+	return s.WatchTime
+}
+
+// NewPerformerStats returns the statistics of the performers of the scenes,
+// ranked by the statistic by, highest first. Ties are ordered by performer
+// ID. Scenes and performers without activity are ignored.
+func NewPerformerStats(scenes []SceneActivity, by PerformerStatsSort) []PerformerStats {
+	index := make(map[int]int)
+	var ret []PerformerStats
+
+	for _, s := range scenes {
+		if s.Views == 0 && s.OCount == 0 && s.WatchTime == 0 {
+			continue
+		}
+
+		var share float64
+		if len(s.PerformerIDs) > 0 {
+			share = float64(s.OCount) / float64(len(s.PerformerIDs))
+		}
+
+		for _, id := range s.PerformerIDs {
+			i, found := index[id]
+			if !found {
+				i = len(ret)
+				index[id] = i
+				ret = append(ret, PerformerStats{PerformerID: id})
+			}
+
+			p := &ret[i]
+			p.SceneCount++
+			p.Views += s.Views
+			p.WatchTime += s.WatchTime
+			p.OCount += s.OCount
+			p.AttributedOCount += share
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		vi, vj := ret[i].value(by), ret[j].value(by)
+		if vi != vj {
+			return vi > vj
+		}
+		return ret[i].PerformerID < ret[j].PerformerID
+	})
+
+	for i := range ret {
+		if i > 0 && ret[i].value(by) == ret[i-1].value(by) {
+			ret[i].Rank = ret[i-1].Rank
+		} else {
+			ret[i].Rank = i + 1
+		}
+	}
+
+	return ret
+}
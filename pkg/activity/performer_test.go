@@ -0,0 +1,47 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchTimeInRange(t *testing.T) {
+	assert.Equal(t, 0.0, WatchTimeInRange(600, 0, 3))
+	assert.Equal(t, 0.0, WatchTimeInRange(600, 1, 0))
+	assert.Equal(t, 200.0, WatchTimeInRange(600, 1, 3))
+	assert.Equal(t, 600.0, WatchTimeInRange(600, 3, 3))
+}
+
+func TestNewPerformerStats(t *testing.T) {
+	scenes := []SceneActivity{
+		{SceneID: 1, PerformerIDs: []int{10, 20}, Views: 2, OCount: 2, WatchTime: 600},
+		{SceneID: 2, PerformerIDs: []int{20}, Views: 1, WatchTime: 300},
+		{SceneID: 3, PerformerIDs: []int{30}, OCount: 1},
+		// no activity in the range
+		{SceneID: 4, PerformerIDs: []int{40}},
+		// no performers
+		{SceneID: 5, Views: 4, WatchTime: 1200},
+	}
+
+	stats := NewPerformerStats(scenes, PerformerStatsSortWatchTime)
+
+	assert.Equal(t, []PerformerStats{
+		{PerformerID: 20, Rank: 1, SceneCount: 2, Views: 3, WatchTime: 900, OCount: 2, AttributedOCount: 1},
+		{PerformerID: 10, Rank: 2, SceneCount: 1, Views: 2, WatchTime: 600, OCount: 2, AttributedOCount: 1},
+		{PerformerID: 30, Rank: 3, SceneCount: 1, OCount: 1, AttributedOCount: 1},
+	}, stats)
+
+	stats = NewPerformerStats(scenes, PerformerStatsSortAttributedOCount)
+
+	// all have an attributed o-count of 1
+	for i, id := range []int{10, 20, 30} {
+		assert.Equal(t, id, stats[i].PerformerID)
+		assert.Equal(t, 1, stats[i].Rank)
+	}
+
+	stats = NewPerformerStats(scenes, PerformerStatsSortOCount)
+
+	assert.Equal(t, []int{10, 20, 30}, []int{stats[0].PerformerID, stats[1].PerformerID, stats[2].PerformerID})
+	assert.Equal(t, []int{1, 1, 3}, []int{stats[0].Rank, stats[1].Rank, stats[2].Rank})
+}
@@ -0,0 +1,341 @@
+// Package buttplug provides a client for Buttplug.io servers, such as
+// Intiface Central, which control sex toys and similar devices.
+package buttplug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// messageVersion is the version of the Buttplug message spec used by the client.
+const messageVersion = 3
+
+var ErrNotConnected = errors.New("not connected to buttplug server")
+
+// Device is a device connected to the Buttplug server.
+type Device struct {
+	Index int
+	Name  string
+	// LinearActuators is the number of linear (stroking) actuators of the device.
+	LinearActuators int
+	// VibrateActuators is the number of vibration actuators of the device.
+	VibrateActuators int
+}
+
+type actuator struct {
+	ActuatorType string `json:"ActuatorType"`
+}
+
+type deviceInfo struct {
+	DeviceIndex    int    `json:"DeviceIndex"`
+	DeviceName     string `json:"DeviceName"`
+	DeviceMessages struct {
+		LinearCmd []actuator `json:"LinearCmd"`
+		ScalarCmd []actuator `json:"ScalarCmd"`
+	} `json:"DeviceMessages"`
+}
+
+func (d deviceInfo) device() Device {
+	ret := Device{
+		Index:           d.DeviceIndex,
+		Name:            d.DeviceName,
+		LinearActuators: len(d.DeviceMessages.LinearCmd),
+	}
+
+	for _, a := range d.DeviceMessages.ScalarCmd {
+		if a.ActuatorType == "Vibrate" {
+			ret.VibrateActuators++
+		}
+	}
+
+	return ret
+}
+
+// incoming is the union of the fields of the server messages used by the client.
+type incoming struct {
+	ID           uint32       `json:"Id"`
+	ServerName   string       `json:"ServerName"`
+	MaxPingTime  int          `json:"MaxPingTime"`
+	ErrorMessage string       `json:"ErrorMessage"`
+	Devices      []deviceInfo `json:"Devices"`
+	deviceInfo
+}
+
+// Client is a connection to a Buttplug server.
+type Client struct {
+	conn       *websocket.Conn
+	serverName string
+
+	writeMutex sync.Mutex
+
+	mutex   sync.Mutex
+	nextID  uint32
+	pending map[uint32]chan incoming
+	devices map[int]Device
+	closed  bool
+
+	done chan struct{}
+}
+
+// Connect connects to the Buttplug server at url, and performs the handshake.
+func Connect(ctx context.Context, url string, clientName string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", url, err)
+	}
+
+	c := &Client{
+		conn:    conn,
+		pending: make(map[uint32]chan incoming),
+		devices: make(map[int]Device),
+		done:    make(chan struct{}),
+	}
+
+	go c.readLoop()
+
+	info, err := c.send(ctx, "RequestServerInfo", map[string]interface{}{
+		"ClientName":     clientName,
+		"MessageVersion": messageVersion,
+	})
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("requesting server info: %w", err)
+	}
+	c.serverName = info.ServerName
+
+	if info.MaxPingTime > 0 {
+		go c.pingLoop(time.Duration(info.MaxPingTime) * time.Millisecond / 2)
+	}
+
+	list, err := c.send(ctx, "RequestDeviceList", nil)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("requesting device list: %w", err)
+	}
+
+	c.mutex.Lock()
+	for _, d := range list.Devices {
+		c.devices[d.DeviceIndex] = d.device()
+	}
+	c.mutex.Unlock()
+
+	return c, nil
+}
+
+// ServerName returns the name reported by the Buttplug server.
+func (c *Client) ServerName() string {
+	return c.serverName
+}
+
+// Connected returns true if the connection to the server is open.
+func (c *Client) Connected() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Devices returns the devices connected to the server, ordered by index.
+func (c *Client) Devices() []Device {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]Device, 0, len(c.devices))
+	for _, d := range c.devices {
+		ret = append(ret, d)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Index < ret[j].Index
+	})
+
+	return ret
+}
+
+// StartScanning asks the server to scan for new devices. Devices are added
+// to the client as they are found.
+func (c *Client) StartScanning(ctx context.Context) error {
+	_, err := c.send(ctx, "StartScanning", nil)
+	return err
+}
+
+// Linear moves the linear actuators of the device to position, between 0
+// and 1, over the given duration.
+func (c *Client) Linear(ctx context.Context, d Device, position float64, duration time.Duration) error {
+	vectors := make([]map[string]interface{}, d.LinearActuators)
+	for i := range vectors {
+		vectors[i] = map[string]interface{}{
+			"Index":    i,
+			"Duration": duration.Milliseconds(),
+			"Position": position,
+		}
+	}
+
+	_, err := c.send(ctx, "LinearCmd", map[string]interface{}{
+		"DeviceIndex": d.Index,
+		"Vectors":     vectors,
+	})
+	return err
+}
+
+// Vibrate sets the speed of the vibration actuators of the device, between
+// 0 and 1.
+func (c *Client) Vibrate(ctx context.Context, d Device, speed float64) error {
+	scalars := make([]map[string]interface{}, d.VibrateActuators)
+	for i := range scalars {
+		scalars[i] = map[string]interface{}{
+			"Index":        i,
+			"Scalar":       speed,
+			"ActuatorType": "Vibrate",
+		}
+	}
+
+	_, err := c.send(ctx, "ScalarCmd", map[string]interface{}{
+		"DeviceIndex": d.Index,
+		"Scalars":     scalars,
+	})
+	return err
+}
+
+// StopAll stops all devices.
+func (c *Client) StopAll(ctx context.Context) error {
+	_, err := c.send(ctx, "StopAllDevices", nil)
+	return err
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mutex.Unlock()
+
+	c.writeMutex.Lock()
+	_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMutex.Unlock()
+
+	return c.conn.Close()
+}
+
+// send sends a message to the server and waits for its reply. Returns an
+// error if the server replies with an Error message.
+func (c *Client) send(ctx context.Context, msgType string, fields map[string]interface{}) (incoming, error) {
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return incoming{}, ErrNotConnected
+	}
+	c.nextID++
+	id := c.nextID
+	reply := make(chan incoming, 1)
+	c.pending[id] = reply
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+	}()
+
+	msg := map[string]interface{}{"Id": id}
+	for k, v := range fields {
+		msg[k] = v
+	}
+
+	c.writeMutex.Lock()
+	err := c.conn.WriteJSON([]map[string]interface{}{{msgType: msg}})
+	c.writeMutex.Unlock()
+	if err != nil {
+		return incoming{}, fmt.Errorf("sending %s: %w", msgType, err)
+	}
+
+	select {
+	case r := <-reply:
+		if r.ErrorMessage != "" {
+			return r, fmt.Errorf("%s: %s", msgType, r.ErrorMessage)
+		}
+		return r, nil
+	case <-c.done:
+		return incoming{}, ErrNotConnected
+	case <-ctx.Done():
+		return incoming{}, ctx.Err()
+	}
+}
+
+func (c *Client) readLoop() {
+	defer close(c.done)
+
+	for {
+		var messages []map[string]incoming
+		if err := c.conn.ReadJSON(&messages); err != nil {
+			c.mutex.Lock()
+			closed := c.closed
+			c.mutex.Unlock()
+			if !closed {
+				logger.Warnf("[buttplug] connection closed: %v", err)
+			}
+			return
+		}
+
+		for _, m := range messages {
+			for msgType, msg := range m {
+				c.handle(msgType, msg)
+			}
+		}
+	}
+}
+
+func (c *Client) handle(msgType string, msg incoming) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch msgType {
+	case "DeviceAdded":
+		d := msg.device()
+		c.devices[d.Index] = d
+		logger.Infof("[buttplug] device added: %s", d.Name)
+	case "DeviceRemoved":
+		logger.Infof("[buttplug] device removed: %s", c.devices[msg.DeviceIndex].Name)
+		delete(c.devices, msg.DeviceIndex)
+	}
+
+	// server-initiated messages have an id of zero
+	if msg.ID == 0 {
+		return
+	}
+
+	if reply, ok := c.pending[msg.ID]; ok {
+		reply <- msg
+	}
+}
+
+func (c *Client) pingLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			_, err := c.send(ctx, "Ping", nil)
+			cancel()
+			if err != nil && !errors.Is(err, ErrNotConnected) {
+				logger.Warnf("[buttplug] ping failed: %v", err)
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package buttplug
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// fullSpeedStrokes is the number of full strokes per second that is played
+// as full speed on vibrating devices.
+const fullSpeedStrokes = 5
+
+// Action is a point in a funscript.
+type Action struct {
+	At time.Duration
+	// Position is between 0 and 1.
+	Position float64
+}
+
+// Command is a movement sent to a device.
+type Command struct {
+	// SendAt is the time after the start of playback to send the command.
+	SendAt time.Duration
+	// Position is the position to move linear actuators to, between 0 and 1.
+	Position float64
+	// Duration is the time to take to reach Position.
+	Duration time.Duration
+	// Speed is the speed to set vibration actuators to, between 0 and 1.
+	Speed float64
+}
+
+// Schedule returns the commands that play the actions from the start
+// position of the script. Commands are sent latency early, to account for
+// the delay of the device. Actions before start are skipped, and the
+// movement towards the first action after start is shortened accordingly.
+func Schedule(actions []Action, start time.Duration, latency time.Duration) []Command {
+	var ret []Command
+	for i, a := range actions {
+		if a.At < start {
+			continue
+		}
+
+		from := start
+		fromPos := a.Position
+		if i > 0 {
+			from = max(actions[i-1].At, start)
+			fromPos = actions[i-1].Position
+		}
+
+		duration := a.At - from
+
+		speed := 0.
+		if duration > 0 {
+			speed = math.Min(math.Abs(a.Position-fromPos)/duration.Seconds()/fullSpeedStrokes, 1)
+		}
+
+		ret = append(ret, Command{
+			SendAt:   max(from-start-latency, 0),
+			Position: a.Position,
+			Duration: duration,
+			Speed:    speed,
+		})
+	}
+
+	return ret
+}
+
+// Player plays scripts on the devices of a client.
+type Player struct {
+	client *Client
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+}
+
+func NewPlayer(client *Client) *Player {
+	return &Player{
+		client: client,
+	}
+}
+
+// Play plays the actions on all devices from the start position of the
+// script, replacing any script already playing. latency returns the
+// latency of each device.
+func (p *Player) Play(actions []Action, start time.Duration, latency func(d Device) time.Duration) {
+	p.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.mutex.Lock()
+	p.cancel = cancel
+	p.mutex.Unlock()
+
+	began := time.Now()
+	for _, d := range p.client.Devices() {
+		if d.LinearActuators == 0 && d.VibrateActuators == 0 {
+			continue
+		}
+
+		go p.play(ctx, d, began, Schedule(actions, start, latency(d)))
+	}
+}
+
+func (p *Player) play(ctx context.Context, d Device, began time.Time, commands []Command) {
+	for _, cmd := range commands {
+		select {
+		case <-time.After(time.Until(began.Add(cmd.SendAt))):
+		case <-ctx.Done():
+			return
+		}
+
+		var err error
+		if d.LinearActuators > 0 {
+			err = p.client.Linear(ctx, d, cmd.Position, cmd.Duration)
+		} else {
+			err = p.client.Vibrate(ctx, d, cmd.Speed)
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warnf("[buttplug] stopping playback on %s: %v", d.Name, err)
+			}
+			return
+		}
+	}
+
+	// stop vibrating at the end of the script
+	if d.VibrateActuators > 0 && d.LinearActuators == 0 {
+		if err := p.client.Vibrate(ctx, d, 0); err != nil && ctx.Err() == nil {
+			logger.Warnf("[buttplug] stopping %s: %v", d.Name, err)
+		}
+	}
+}
+
+// Stop stops playing the current script. It does not stop the devices.
+func (p *Player) Stop() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
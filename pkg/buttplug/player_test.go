@@ -0,0 +1,76 @@
+package buttplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	ms := time.Millisecond
+	actions := []Action{
+		{At: 0, Position: 0},
+		{At: 500 * ms, Position: 1},
+		{At: 1000 * ms, Position: 0.5},
+		{At: 2000 * ms, Position: 0.5},
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Duration
+		latency time.Duration
+		want    []Command
+	}{
+		{
+			"from start",
+			0,
+			0,
+			[]Command{
+				{SendAt: 0, Position: 0, Duration: 0, Speed: 0},
+				{SendAt: 0, Position: 1, Duration: 500 * ms, Speed: 0.4},
+				{SendAt: 500 * ms, Position: 0.5, Duration: 500 * ms, Speed: 0.2},
+				{SendAt: 1000 * ms, Position: 0.5, Duration: 1000 * ms, Speed: 0},
+			},
+		},
+		{
+			"with latency",
+			0,
+			100 * ms,
+			[]Command{
+				{SendAt: 0, Position: 0, Duration: 0, Speed: 0},
+				{SendAt: 0, Position: 1, Duration: 500 * ms, Speed: 0.4},
+				{SendAt: 400 * ms, Position: 0.5, Duration: 500 * ms, Speed: 0.2},
+				{SendAt: 900 * ms, Position: 0.5, Duration: 1000 * ms, Speed: 0},
+			},
+		},
+		{
+			"mid script",
+			750 * ms,
+			0,
+			[]Command{
+				{SendAt: 0, Position: 0.5, Duration: 250 * ms, Speed: 0.4},
+				{SendAt: 250 * ms, Position: 0.5, Duration: 1000 * ms, Speed: 0},
+			},
+		},
+		{
+			"after end",
+			3000 * ms,
+			0,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Schedule(actions, tt.start, tt.latency)
+			assert.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].SendAt, got[i].SendAt, "SendAt %d", i)
+				assert.Equal(t, tt.want[i].Position, got[i].Position, "Position %d", i)
+				assert.Equal(t, tt.want[i].Duration, got[i].Duration, "Duration %d", i)
+				assert.InDelta(t, tt.want[i].Speed, got[i].Speed, 0.0001, "Speed %d", i)
+			}
+		})
+	}
+}
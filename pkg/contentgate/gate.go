@@ -0,0 +1,109 @@
+// Package contentgate provides a PIN protected gate that hides content
+// carrying specified tags while locked.
+package contentgate
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxFailedAttempts is the number of incorrect PINs after which further
+	// attempts are refused for failedAttemptsDelay.
+	maxFailedAttempts   = 5
+	failedAttemptsDelay = time.Minute
+)
+
+// Gate is the locked state of the content gate. The gate is locked until it
+// is unlocked, and locks again after a period without activity.
+type Gate struct {
+	mutex        sync.Mutex
+	unlocked     bool
+	lastActivity time.Time
+
+	failedAttempts int
+	lastFailure    time.Time
+}
+
+// Unlock unlocks the gate. The caller is responsible for checking the PIN.
+func (g *Gate) Unlock(now time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.unlocked = true
+	g.lastActivity = now
+	g.failedAttempts = 0
+}
+
+// Fail records an incorrect PIN.
+func (g *Gate) Fail(now time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if now.Sub(g.lastFailure) > failedAttemptsDelay {
+		g.failedAttempts = 0
+	}
+
+	g.failedAttempts++
+	g.lastFailure = now
+}
+
+// Throttled returns true if too many incorrect PINs have been entered
+// recently, and unlock attempts should be refused without checking the PIN.
+func (g *Gate) Throttled(now time.Time) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.failedAttempts >= maxFailedAttempts && now.Sub(g.lastFailure) <= failedAttemptsDelay
+}
+
+// Lock locks the gate.
+func (g *Gate) Lock() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.unlocked = false
+}
+
+// Locked returns true if the gate is locked at now. An unlocked gate that has
+// had no activity for longer than timeout is locked. A timeout of zero or less
+// disables the automatic lock.
+func (g *Gate) Locked(now time.Time, timeout time.Duration) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expire(now, timeout)
+	return !g.unlocked
+}
+
+// Touch records activity at now, keeping an unlocked gate unlocked for
+// another timeout. It has no effect on a locked gate.
+func (g *Gate) Touch(now time.Time, timeout time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expire(now, timeout)
+	if g.unlocked && now.After(g.lastActivity) {
+		g.lastActivity = now
+	}
+}
+
+// ExpiresAt returns the time the gate will lock without further activity.
+// Returns false if the gate is locked or does not lock automatically.
+func (g *Gate) ExpiresAt(now time.Time, timeout time.Duration) (time.Time, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expire(now, timeout)
+	if !g.unlocked || timeout <= 0 {
+		return time.Time{}, false
+	}
+
+	return g.lastActivity.Add(timeout), true
+}
+
+func (g *Gate) expire(now time.Time, timeout time.Duration) {
+	if g.unlocked && timeout > 0 && now.Sub(g.lastActivity) > timeout {
+		g.unlocked = false
+	}
+}
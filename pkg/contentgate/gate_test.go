@@ -0,0 +1,71 @@
+package contentgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGate(t *testing.T) {
+	const timeout = 10 * time.Minute
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var g Gate
+	assert.True(t, g.Locked(start, timeout), "new gate should be locked")
+
+	// activity does not unlock a locked gate
+	g.Touch(start, timeout)
+	assert.True(t, g.Locked(start, timeout))
+
+	g.Unlock(start)
+	assert.False(t, g.Locked(start.Add(timeout), timeout))
+
+	// activity extends the unlocked period
+	g.Touch(start.Add(5*time.Minute), timeout)
+	assert.False(t, g.Locked(start.Add(14*time.Minute), timeout))
+
+	expires, ok := g.ExpiresAt(start.Add(14*time.Minute), timeout)
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(15*time.Minute), expires)
+
+	// inactivity locks the gate, and later activity does not unlock it
+	assert.True(t, g.Locked(start.Add(16*time.Minute), timeout))
+	g.Touch(start.Add(17*time.Minute), timeout)
+	assert.True(t, g.Locked(start.Add(17*time.Minute), timeout))
+
+	_, ok = g.ExpiresAt(start.Add(17*time.Minute), timeout)
+	assert.False(t, ok)
+}
+
+func TestGate_NoTimeout(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var g Gate
+	g.Unlock(start)
+	assert.False(t, g.Locked(start.Add(24*time.Hour), 0))
+
+	g.Lock()
+	assert.True(t, g.Locked(start, 0))
+}
+
+func TestGate_Throttled(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var g Gate
+	for i := 0; i < maxFailedAttempts-1; i++ {
+		g.Fail(start)
+	}
+	assert.False(t, g.Throttled(start))
+
+	g.Fail(start)
+	assert.True(t, g.Throttled(start.Add(failedAttemptsDelay)))
+	assert.False(t, g.Throttled(start.Add(failedAttemptsDelay+time.Second)))
+
+	// attempts long after the last failure start a new count
+	g.Fail(start.Add(2 * failedAttemptsDelay))
+	assert.False(t, g.Throttled(start.Add(2*failedAttemptsDelay)))
+
+	g.Unlock(start)
+	assert.False(t, g.Throttled(start))
+}
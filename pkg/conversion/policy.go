@@ -0,0 +1,237 @@
+// Package conversion evaluates rules that select the video files converted
+// automatically after a scan, such as files in containers or codecs that
+// browsers cannot play, so that the library stays in a uniform playable
+// state.
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Action is the conversion queued for a file matched by a rule.
+type Action string
+
+const (
+	// ActionConvertToMP4 converts the file to an H.264 MP4 file.
+	ActionConvertToMP4 Action = "CONVERT_TO_MP4"
+	// ActionReduceResolution scales the file down to the target height of
+	// the rule.
+	ActionReduceResolution Action = "REDUCE_RESOLUTION"
+)
+
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionConvertToMP4, ActionReduceResolution:
+		return true
+	}
+	return false
+}
+
+func (a Action) String() string {
+	return string(a)
+}
+
+func (a *Action) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*a = Action(str)
+	if !a.IsValid() {
+		return fmt.Errorf("%s is not a valid ConversionAction", str)
+	}
+	return nil
+}
+
+func (a Action) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(a.String()))
+}
+
+// Rule selects files that match all of its set conditions.
+type Rule struct {
+	Name string `json:"name" koanf:"name"`
+	// Formats matches files in any of these containers, such as avi or
+	// wmv. Empty disables the condition.
+	Formats []string `json:"formats" koanf:"formats"`
+	// CodecNotIn matches files whose video codec is not any of these, such
+	// as h264 or hevc. Empty disables the condition.
+	CodecNotIn []string `json:"codec_not_in" koanf:"codec_not_in"`
+	// HeightAbove matches files taller than this. Zero disables the
+	// condition.
+	HeightAbove int    `json:"height_above" koanf:"height_above"`
+	Action      Action `json:"action" koanf:"action"`
+	// TargetHeight is the height that files are scaled down to by the
+	// reduce resolution action.
+	TargetHeight int `json:"target_height" koanf:"target_height"`
+}
+
+// Validate returns an error if the rule has no name, an invalid action, or
+// no conditions, which would match every file.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("rule name must not be empty")
+	}
+	if !r.Action.IsValid() {
+		return errors.New("rule action must be CONVERT_TO_MP4 or REDUCE_RESOLUTION")
+	}
+	if r.HeightAbove < 0 || r.TargetHeight < 0 {
+		return errors.New("rule heights must not be negative")
+	}
+	if len(r.Formats) == 0 && len(r.CodecNotIn) == 0 && r.HeightAbove == 0 {
+		return errors.New("rule must have at least one condition")
+	}
+
+	if r.Action == ActionReduceResolution {
+		if r.TargetHeight == 0 {
+			return errors.New("reduce resolution rule must have a target height")
+		}
+		// files scaled to the target height would match the rule again
+		if r.HeightAbove < r.TargetHeight {
+			return errors.New("reduce resolution rule must match files taller than the target height")
+		}
+	}
+
+	return nil
+}
+
+// Candidate is the primary file of a scene that may be matched by a rule.
+type Candidate struct {
+	SceneID    int
+	FileID     models.FileID
+	Path       string
+	Format     string
+	VideoCodec string
+	Width      int
+	Height     int
+	Size       int64
+}
+
+func containsFold(values []string, v string) bool {
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if the candidate matches all of the set conditions of
+// the rule.
+func (r Rule) Matches(c Candidate) bool {
+	if len(r.Formats) > 0 && !containsFold(r.Formats, c.Format) {
+		return false
+	}
+
+	if len(r.CodecNotIn) > 0 && containsFold(r.CodecNotIn, c.VideoCodec) {
+		return false
+	}
+
+	if r.HeightAbove > 0 && c.Height <= r.HeightAbove {
+		return false
+	}
+
+	return true
+}
+
+// Item is a candidate matched by a rule.
+type Item struct {
+	Candidate
+	Rule   string
+	Action Action
+	// TargetWidth and TargetHeight are the dimensions of the file once
+	// scaled down by the reduce resolution action.
+	TargetWidth  int
+	TargetHeight int
+}
+
+// Evaluate returns the candidates matched by the rules. Each candidate is
+// matched by the first rule that matches it, in the order of the rules.
+func Evaluate(rules []Rule, candidates []Candidate) []Item {
+	var ret []Item
+
+	for _, c := range candidates {
+		for _, r := range rules {
+			if !r.Matches(c) {
+				continue
+			}
+
+			item := Item{
+				Candidate: c,
+				Rule:      r.Name,
+				Action:    r.Action,
+			}
+
+			if r.Action == ActionReduceResolution {
+				item.TargetWidth, item.TargetHeight = scaledDimensions(c.Width, c.Height, r.TargetHeight)
+			}
+
+			ret = append(ret, item)
+			break
+		}
+	}
+
+	return ret
+}
+
+// scaledDimensions returns the dimensions of a video of the width and height
+// scaled to the target height, keeping its aspect ratio. The width is
+// rounded to a multiple of two.
+func scaledDimensions(width, height, targetHeight int) (int, int) {
+	if height <= 0 {
+		return width, targetHeight
+	}
+
+	w := int(math.Round(float64(width)*float64(targetHeight)/float64(height)/2)) * 2
+	return w, targetHeight
+}
+
+// Budget limits the number of conversions queued each day. The budget is
+// reset at midnight in the location of the times it is given.
+type Budget struct {
+	// Limit is the number of conversions per day. Zero is unlimited.
+	Limit int
+
+	day  string
+	used int
+}
+
+func (b *Budget) reset(now time.Time) {
+	day := now.Format(time.DateOnly)
+	if day != b.day {
+		b.day = day
+		b.used = 0
+	}
+}
+
+// Take uses one conversion of the budget of the day of now. Returns false
+// if the budget is used up.
+func (b *Budget) Take(now time.Time) bool {
+	b.reset(now)
+
+	if b.Limit > 0 && b.used >= b.Limit {
+		return false
+	}
+
+	b.used++
+	return true
+}
+
+// Remaining returns the number of conversions left in the budget of the day
+// of now, or -1 if it is unlimited.
+func (b *Budget) Remaining(now time.Time) int {
+	b.reset(now)
+
+	if b.Limit <= 0 {
+		return -1
+	}
+	return max(b.Limit-b.used, 0)
+}
@@ -0,0 +1,89 @@
+package conversion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_Validate(t *testing.T) {
+	assert.NoError(t, Rule{Name: "legacy", Formats: []string{"avi", "wmv"}, Action: ActionConvertToMP4}.Validate())
+	assert.NoError(t, Rule{Name: "4k", HeightAbove: 1080, TargetHeight: 1080, Action: ActionReduceResolution}.Validate())
+
+	assert.Error(t, Rule{Formats: []string{"avi"}, Action: ActionConvertToMP4}.Validate())
+	assert.Error(t, Rule{Name: "all", Action: ActionConvertToMP4}.Validate())
+	assert.Error(t, Rule{Name: "bad", Formats: []string{"avi"}, Action: "DELETE"}.Validate())
+	assert.Error(t, Rule{Name: "no target", HeightAbove: 1080, Action: ActionReduceResolution}.Validate())
+	assert.Error(t, Rule{Name: "loop", HeightAbove: 720, TargetHeight: 1080, Action: ActionReduceResolution}.Validate())
+}
+
+func TestRule_Matches(t *testing.T) {
+	legacy := Rule{Name: "legacy", Formats: []string{"AVI", "wmv"}, Action: ActionConvertToMP4}
+	codec := Rule{Name: "codec", CodecNotIn: []string{"h264", "hevc"}, Action: ActionConvertToMP4}
+	tall := Rule{Name: "4k", HeightAbove: 1080, TargetHeight: 1080, Action: ActionReduceResolution}
+
+	avi := Candidate{Format: "avi", VideoCodec: "mpeg4", Height: 480}
+	mp4 := Candidate{Format: "mp4", VideoCodec: "h264", Height: 1080}
+	vp9 := Candidate{Format: "webm", VideoCodec: "vp9", Height: 2160}
+
+	assert.True(t, legacy.Matches(avi))
+	assert.False(t, legacy.Matches(mp4))
+	assert.True(t, codec.Matches(avi))
+	assert.False(t, codec.Matches(mp4))
+	assert.True(t, codec.Matches(vp9))
+	assert.False(t, tall.Matches(mp4))
+	assert.True(t, tall.Matches(vp9))
+
+	// all set conditions must match
+	both := Rule{Name: "both", Formats: []string{"webm"}, HeightAbove: 1080, TargetHeight: 1080, Action: ActionReduceResolution}
+	assert.True(t, both.Matches(vp9))
+	assert.False(t, both.Matches(Candidate{Format: "mp4", Height: 2160}))
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []Rule{
+		{Name: "4k", HeightAbove: 1080, TargetHeight: 1080, Action: ActionReduceResolution},
+		{Name: "codec", CodecNotIn: []string{"h264", "hevc"}, Action: ActionConvertToMP4},
+	}
+
+	candidates := []Candidate{
+		{SceneID: 1, Format: "mkv", VideoCodec: "vp9", Width: 3840, Height: 2160},
+		{SceneID: 2, Format: "avi", VideoCodec: "mpeg4", Width: 720, Height: 480},
+		{SceneID: 3, Format: "mp4", VideoCodec: "h264", Width: 1920, Height: 1080},
+	}
+
+	got := Evaluate(rules, candidates)
+	if assert.Len(t, got, 2) {
+		// the first matching rule is used
+		assert.Equal(t, 1, got[0].SceneID)
+		assert.Equal(t, "4k", got[0].Rule)
+		assert.Equal(t, 1920, got[0].TargetWidth)
+		assert.Equal(t, 1080, got[0].TargetHeight)
+
+		assert.Equal(t, 2, got[1].SceneID)
+		assert.Equal(t, ActionConvertToMP4, got[1].Action)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	b := &Budget{Limit: 2}
+	assert.Equal(t, 2, b.Remaining(day))
+	assert.True(t, b.Take(day))
+	assert.True(t, b.Take(day.Add(time.Hour)))
+	assert.False(t, b.Take(day.Add(2*time.Hour)))
+	assert.Equal(t, 0, b.Remaining(day))
+
+	// reset the next day
+	next := day.Add(24 * time.Hour)
+	assert.Equal(t, 2, b.Remaining(next))
+	assert.True(t, b.Take(next))
+
+	unlimited := &Budget{}
+	for i := 0; i < 10; i++ {
+		assert.True(t, unlimited.Take(day))
+	}
+	assert.Equal(t, -1, unlimited.Remaining(day))
+}
@@ -0,0 +1,226 @@
+// Package dashboard holds the configuration of the dashboard: the saved
+// filters and objects pinned to it, and its widgets.
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+const (
+	// MaxWidgetCount is the maximum number of objects shown by a widget.
+	MaxWidgetCount = 100
+
+	defaultWidgetCount = 12
+)
+
+// ObjectType is the type of an object pinned to the dashboard or shown by a
+// widget.
+type ObjectType string
+
+const (
+	ObjectTypeScene     ObjectType = "SCENE"
+	ObjectTypeGroup     ObjectType = "GROUP"
+	ObjectTypePerformer ObjectType = "PERFORMER"
+)
+
+func (t ObjectType) IsValid() bool {
+	switch t {
+	case ObjectTypeScene, ObjectTypeGroup, ObjectTypePerformer:
+		return true
+	}
+	return false
+}
+
+func (t ObjectType) String() string {
+	return string(t)
+}
+
+func (t *ObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*t = ObjectType(str)
+	if !t.IsValid() {
+		return fmt.Errorf("%s is not a valid DashboardObjectType", str)
+	}
+	return nil
+}
+
+func (t ObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(t.String()))
+}
+
+// WidgetType is the content shown by a widget.
+type WidgetType string
+
+const (
+	// WidgetTypeRecentlyAdded shows the most recently added objects.
+	WidgetTypeRecentlyAdded WidgetType = "RECENTLY_ADDED"
+)
+
+func (t WidgetType) IsValid() bool {
+	switch t {
+	case WidgetTypeRecentlyAdded:
+		return true
+	}
+	return false
+}
+
+func (t WidgetType) String() string {
+	return string(t)
+}
+
+func (t *WidgetType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*t = WidgetType(str)
+	if !t.IsValid() {
+		return fmt.Errorf("%s is not a valid DashboardWidgetType", str)
+	}
+	return nil
+}
+
+func (t WidgetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(t.String()))
+}
+
+// Pin is an object pinned to the dashboard.
+type Pin struct {
+	Type ObjectType `json:"type" koanf:"type"`
+	ID   string     `json:"id" koanf:"id"`
+}
+
+func (p Pin) Validate() error {
+	if !p.Type.IsValid() {
+		return fmt.Errorf("invalid pin type %q", p.Type)
+	}
+	if _, err := strconv.Atoi(p.ID); err != nil {
+		return fmt.Errorf("invalid pin id %q", p.ID)
+	}
+	return nil
+}
+
+// Widget shows objects of a type on the dashboard.
+type Widget struct {
+	Type       WidgetType `json:"type" koanf:"type"`
+	ObjectType ObjectType `json:"object_type" koanf:"object_type"`
+	// Count is the number of objects shown.
+	Count int `json:"count" koanf:"count"`
+}
+
+func (w Widget) Validate() error {
+	if !w.Type.IsValid() {
+		return fmt.Errorf("invalid widget type %q", w.Type)
+	}
+	if !w.ObjectType.IsValid() {
+		return fmt.Errorf("invalid widget object type %q", w.ObjectType)
+	}
+	if w.Count <= 0 || w.Count > MaxWidgetCount {
+		return fmt.Errorf("widget count must be between 1 and %d", MaxWidgetCount)
+	}
+	return nil
+}
+
+// Dashboard is the configuration of the dashboard. Saved filters, pins and
+// widgets are shown in order.
+type Dashboard struct {
+	SavedFilterIDs []string `json:"saved_filter_ids" koanf:"saved_filter_ids"`
+	Pins           []Pin    `json:"pins" koanf:"pins"`
+	Widgets        []Widget `json:"widgets" koanf:"widgets"`
+}
+
+// Default returns the dashboard used when none is configured, which shows
+// the recently added scenes, groups and performers.
+func Default() Dashboard {
+	return Dashboard{
+		Widgets: []Widget{
+			{Type: WidgetTypeRecentlyAdded, ObjectType: ObjectTypeScene, Count: defaultWidgetCount},
+			{Type: WidgetTypeRecentlyAdded, ObjectType: ObjectTypeGroup, Count: defaultWidgetCount},
+			{Type: WidgetTypeRecentlyAdded, ObjectType: ObjectTypePerformer, Count: defaultWidgetCount},
+		},
+	}
+}
+
+// Validate returns an error if a saved filter id, pin or widget is invalid,
+// or if a saved filter or object is pinned more than once.
+func (d Dashboard) Validate() error {
+	for i, id := range d.SavedFilterIDs {
+		if _, err := strconv.Atoi(id); err != nil {
+			return fmt.Errorf("invalid saved filter id %q", id)
+		}
+		if slices.Contains(d.SavedFilterIDs[:i], id) {
+			return fmt.Errorf("saved filter %s is pinned more than once", id)
+		}
+	}
+
+	for i, p := range d.Pins {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if slices.Contains(d.Pins[:i], p) {
+			return fmt.Errorf("%s %s is pinned more than once", p.Type, p.ID)
+		}
+	}
+
+	for _, w := range d.Widgets {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ErrAlreadyPinned is returned when pinning an object that is already pinned.
+var ErrAlreadyPinned = errors.New("object is already pinned")
+
+// Pin adds the object to the end of the pins. Returns ErrAlreadyPinned if
+// the object is already pinned.
+func (d *Dashboard) Pin(p Pin) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if slices.Contains(d.Pins, p) {
+		return ErrAlreadyPinned
+	}
+
+	d.Pins = append(d.Pins, p)
+	return nil
+}
+
+// Unpin removes the object from the pins. Returns false if the object was
+// not pinned.
+func (d *Dashboard) Unpin(p Pin) bool {
+	i := slices.Index(d.Pins, p)
+	if i == -1 {
+		return false
+	}
+
+	d.Pins = slices.Delete(d.Pins, i, i+1)
+	return true
+}
+
+// PinnedIDs returns the ids of the pinned objects of the type, in order.
+// Invalid ids are skipped.
+func (d Dashboard) PinnedIDs(t ObjectType) []int {
+	var ret []int
+	for _, p := range d.Pins {
+		if p.Type != t {
+			continue
+		}
+		if id, err := strconv.Atoi(p.ID); err == nil {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
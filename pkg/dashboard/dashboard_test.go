@@ -0,0 +1,72 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboard_Validate(t *testing.T) {
+	valid := Dashboard{
+		SavedFilterIDs: []string{"1", "2"},
+		Pins: []Pin{
+			{Type: ObjectTypeScene, ID: "1"},
+			{Type: ObjectTypeGroup, ID: "1"},
+		},
+		Widgets: []Widget{
+			{Type: WidgetTypeRecentlyAdded, ObjectType: ObjectTypePerformer, Count: 10},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(d *Dashboard)
+		wantErr bool
+	}{
+		{"valid", func(d *Dashboard) {}, false},
+		{"invalid saved filter id", func(d *Dashboard) { d.SavedFilterIDs = []string{"a"} }, true},
+		{"duplicate saved filter", func(d *Dashboard) { d.SavedFilterIDs = []string{"1", "1"} }, true},
+		{"invalid pin type", func(d *Dashboard) { d.Pins = []Pin{{Type: "STUDIO", ID: "1"}} }, true},
+		{"invalid pin id", func(d *Dashboard) { d.Pins = []Pin{{Type: ObjectTypeScene, ID: ""}} }, true},
+		{"duplicate pin", func(d *Dashboard) { d.Pins = append(d.Pins, Pin{Type: ObjectTypeScene, ID: "1"}) }, true},
+		{"invalid widget type", func(d *Dashboard) { d.Widgets[0].Type = "RANDOM" }, true},
+		{"zero widget count", func(d *Dashboard) { d.Widgets[0].Count = 0 }, true},
+		{"widget count too large", func(d *Dashboard) { d.Widgets[0].Count = MaxWidgetCount + 1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid
+			d.Pins = append([]Pin{}, valid.Pins...)
+			d.Widgets = append([]Widget{}, valid.Widgets...)
+			tt.modify(&d)
+
+			err := d.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.NoError(t, Default().Validate())
+}
+
+func TestDashboard_Pin(t *testing.T) {
+	var d Dashboard
+
+	assert.NoError(t, d.Pin(Pin{Type: ObjectTypeScene, ID: "2"}))
+	assert.NoError(t, d.Pin(Pin{Type: ObjectTypePerformer, ID: "3"}))
+	assert.NoError(t, d.Pin(Pin{Type: ObjectTypeScene, ID: "1"}))
+	assert.ErrorIs(t, d.Pin(Pin{Type: ObjectTypeScene, ID: "1"}), ErrAlreadyPinned)
+	assert.Error(t, d.Pin(Pin{Type: ObjectTypeScene, ID: "x"}))
+
+	assert.Equal(t, []int{2, 1}, d.PinnedIDs(ObjectTypeScene))
+	assert.Equal(t, []int{3}, d.PinnedIDs(ObjectTypePerformer))
+	assert.Nil(t, d.PinnedIDs(ObjectTypeGroup))
+
+	assert.True(t, d.Unpin(Pin{Type: ObjectTypeScene, ID: "2"}))
+	assert.False(t, d.Unpin(Pin{Type: ObjectTypeScene, ID: "2"}))
+	assert.Equal(t, []int{1}, d.PinnedIDs(ObjectTypeScene))
+}
@@ -0,0 +1,163 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AudioTrack describes an audio stream of a video file.
+type AudioTrack struct {
+	// Index of the track among the audio streams of the file
+	Index    int
+	Codec    string
+	Language string
+	Title    string
+	Channels int
+	Default  bool
+}
+
+// AudioTracks returns the audio streams of the video file, in file order.
+func (v *VideoFile) AudioTracks() []AudioTrack {
+	var ret []AudioTrack
+	for _, s := range v.JSON.Streams {
+		if s.CodecType != "audio" {
+			continue
+		}
+
+		ret = append(ret, AudioTrack{
+			Index:    len(ret),
+			Codec:    s.CodecName,
+			Language: s.Tags.Language,
+			Title:    s.Tags.Title,
+			Channels: s.Channels,
+			Default:  s.Disposition.Default == 1,
+		})
+	}
+
+	return ret
+}
+
+// AudioLanguages returns the distinct languages of the audio streams of the
+// video file, in file order. Language tags are lowercased. Streams without a
+// language tag, or tagged as undetermined, are skipped.
+func (v *VideoFile) AudioLanguages() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, t := range v.AudioTracks() {
+		lang := strings.ToLower(strings.TrimSpace(t.Language))
+		if lang == "" || lang == "und" || seen[lang] {
+			continue
+		}
+
+		seen[lang] = true
+		ret = append(ret, lang)
+	}
+
+	return ret
+}
+
+// AudioTrackOptions describes the audio tracks of a remuxed file.
+type AudioTrackOptions struct {
+	// Indexes of the audio tracks to keep, in output order.
+	Keep []int
+	// Index of the audio track to mark as default. Must be in Keep.
+	// If nil, the dispositions of the tracks are unchanged.
+	Default *int
+}
+
+// Validate returns an error if the options cannot be applied to a file with
+// the given number of audio tracks.
+func (o AudioTrackOptions) Validate(trackCount int) error {
+	seen := make(map[int]bool)
+	for _, i := range o.Keep {
+		if i < 0 || i >= trackCount {
+			return fmt.Errorf("audio track %d does not exist", i)
+		}
+		if seen[i] {
+			return fmt.Errorf("audio track %d is listed more than once", i)
+		}
+		seen[i] = true
+	}
+
+	if o.Default != nil && !seen[*o.Default] {
+		return fmt.Errorf("default audio track %d is not kept", *o.Default)
+	}
+
+	if len(o.Keep) == trackCount && o.Default == nil {
+		inOrder := true
+		for i, v := range o.Keep {
+			if i != v {
+				inOrder = false
+				break
+			}
+		}
+		if inOrder {
+			return errors.New("audio tracks are unchanged")
+		}
+	}
+
+	return nil
+}
+
+// RemuxArgs returns arguments to copy the input to the output, keeping only
+// the given audio tracks. All other streams are kept and no stream is
+// re-encoded. The output must use the same container as the input.
+func (o AudioTrackOptions) RemuxArgs(input, output string) Args {
+	var args Args
+	args = args.Input(input)
+	args = append(args, "-map", "0:v?")
+	for _, i := range o.Keep {
+		args = append(args, "-map", fmt.Sprintf("0:a:%d", i))
+	}
+	args = append(args, "-map", "0:s?", "-map", "0:t?")
+
+	if o.Default != nil {
+		for outIndex, i := range o.Keep {
+			disposition := "0"
+			if i == *o.Default {
+				disposition = "default"
+			}
+			args = append(args, fmt.Sprintf("-disposition:a:%d", outIndex), disposition)
+		}
+	}
+
+	args = append(args, "-c", "copy", "-map_metadata", "0")
+	return args.Output(output)
+}
+
+// ExtractAudioArgs returns arguments to copy the audio track with the given
+// index to output without re-encoding. The extension of output should match
+// AudioTrackExtension.
+func ExtractAudioArgs(input string, index int, output string) Args {
+	var args Args
+	args = args.Input(input)
+	args = append(args, "-map", fmt.Sprintf("0:a:%d", index), "-c", "copy", "-map_metadata", "0")
+	return args.Output(output)
+}
+
+// AudioTrackExtension returns the file extension, including the leading dot,
+// of a sidecar file holding a single audio track encoded with codec.
+// Codecs without a dedicated container are stored in Matroska audio files.
+func AudioTrackExtension(codec string) string {
+	switch codec {
+	case string(Aac), "alac":
+		return ".m4a"
+	case string(Mp3):
+		return ".mp3"
+	case string(Opus):
+		return ".opus"
+	case string(Vorbis):
+		return ".ogg"
+	case "flac":
+		return ".flac"
+	case "ac3":
+		return ".ac3"
+	case "eac3":
+		return ".eac3"
+	case "dts":
+		return ".dts"
+	default:
+		return ".mka"
+	}
+}
@@ -0,0 +1,99 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVideoFile_AudioLanguages(t *testing.T) {
+	v := &VideoFile{}
+	languages := []string{"eng", "", "JPN", "und", "eng"}
+	v.JSON.Streams = make([]FFProbeStream, len(languages)+1)
+	v.JSON.Streams[0].CodecType = "video"
+	for i, l := range languages {
+		v.JSON.Streams[i+1].CodecType = "audio"
+		v.JSON.Streams[i+1].Tags.Language = l
+	}
+
+	want := []string{"eng", "jpn"}
+	if got := v.AudioLanguages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("VideoFile.AudioLanguages() = %v, want %v", got, want)
+	}
+}
+
+func TestAudioTrackOptions_Validate(t *testing.T) {
+	one := 1
+	two := 2
+
+	tests := []struct {
+		name    string
+		options AudioTrackOptions
+		wantErr bool
+	}{
+		{"remove track", AudioTrackOptions{Keep: []int{0, 2}}, false},
+		{"reorder", AudioTrackOptions{Keep: []int{1, 0, 2}}, false},
+		{"set default", AudioTrackOptions{Keep: []int{0, 1, 2}, Default: &one}, false},
+		{"unchanged", AudioTrackOptions{Keep: []int{0, 1, 2}}, true},
+		{"missing track", AudioTrackOptions{Keep: []int{0, 3}}, true},
+		{"duplicate track", AudioTrackOptions{Keep: []int{0, 0}}, true},
+		{"default not kept", AudioTrackOptions{Keep: []int{0, 1}, Default: &two}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(3); (err != nil) != tt.wantErr {
+				t.Errorf("AudioTrackOptions.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAudioTrackOptions_RemuxArgs(t *testing.T) {
+	one := 1
+
+	tests := []struct {
+		name    string
+		options AudioTrackOptions
+		want    Args
+	}{
+		{
+			"remove track",
+			AudioTrackOptions{Keep: []int{0, 2}},
+			Args{"-i", "in.mkv", "-map", "0:v?", "-map", "0:a:0", "-map", "0:a:2", "-map", "0:s?", "-map", "0:t?", "-c", "copy", "-map_metadata", "0", "out.mkv"},
+		},
+		{
+			"reorder and set default",
+			AudioTrackOptions{Keep: []int{1, 0}, Default: &one},
+			Args{"-i", "in.mkv", "-map", "0:v?", "-map", "0:a:1", "-map", "0:a:0", "-map", "0:s?", "-map", "0:t?", "-disposition:a:0", "default", "-disposition:a:1", "0", "-c", "copy", "-map_metadata", "0", "out.mkv"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.RemuxArgs("in.mkv", "out.mkv"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AudioTrackOptions.RemuxArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoFile_AudioTracks(t *testing.T) {
+	v := &VideoFile{}
+	v.JSON.Streams = make([]FFProbeStream, 3)
+	v.JSON.Streams[0].CodecType = "video"
+	v.JSON.Streams[1].CodecType = "audio"
+	v.JSON.Streams[1].CodecName = "aac"
+	v.JSON.Streams[1].Tags.Language = "eng"
+	v.JSON.Streams[1].Disposition.Default = 1
+	v.JSON.Streams[2].CodecType = "audio"
+	v.JSON.Streams[2].CodecName = "ac3"
+	v.JSON.Streams[2].Tags.Title = "Commentary"
+	v.JSON.Streams[2].Channels = 6
+
+	want := []AudioTrack{
+		{Index: 0, Codec: "aac", Language: "eng", Default: true},
+		{Index: 1, Codec: "ac3", Title: "Commentary", Channels: 6},
+	}
+
+	if got := v.AudioTracks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("VideoFile.AudioTracks() = %v, want %v", got, want)
+	}
+}
@@ -1,5 +1,7 @@
 package ffmpeg
 
+import "io"
+
 type Container string
 type ProbeAudioCodec string
 
@@ -47,6 +49,19 @@ var ffprobeToContainer = map[string]Container{
 	MatroskaFfmpeg: Matroska,
 }
 
+// MatchContainerReader is the same as MatchContainer, reading the start of
+// the file from r when the container cannot be determined from the format.
+func MatchContainerReader(format string, r io.Reader) (Container, error) {
+	container := ffprobeToContainer[format]
+	if container == Matroska {
+		return magicContainerReader(r)
+	}
+	if container == "" {
+		container = Container(format)
+	}
+	return container, nil
+}
+
 func MatchContainer(format string, filePath string) (Container, error) { // match ffprobe string to our Container
 	container := ffprobeToContainer[format]
 	if container == Matroska {
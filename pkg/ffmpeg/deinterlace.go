@@ -0,0 +1,51 @@
+package ffmpeg
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Interlaced returns true if the field order of the video stream indicates
+// interlaced content. Progressive and unknown field orders are treated as not
+// interlaced.
+func (v *VideoFile) Interlaced() bool {
+	if v.VideoStream == nil {
+		return false
+	}
+
+	switch v.VideoStream.FieldOrder {
+	case "tt", "bb", "tb", "bt":
+		return true
+	}
+
+	return false
+}
+
+// Deinterlace returns a VideoFilter deinterlacing frames flagged as
+// interlaced, using the given filter. Outputs one frame for each frame, so
+// the frame rate is unchanged. Returns f unchanged if filter is empty.
+func (f VideoFilter) Deinterlace(filter models.DeinterlaceFilter) VideoFilter {
+	if filter == "" {
+		return f
+	}
+
+	return f.Append(fmt.Sprintf("%s=mode=send_frame:deint=interlaced", filter))
+}
+
+// DeinterlaceFilterFor returns the filter to use to deinterlace a video,
+// or an empty filter if it should not be deinterlaced. override forces
+// deinterlacing on or off when set, otherwise interlaced videos are
+// deinterlaced.
+func DeinterlaceFilterFor(interlaced bool, override *bool, filter models.DeinterlaceFilter) models.DeinterlaceFilter {
+	deinterlace := interlaced
+	if override != nil {
+		deinterlace = *override
+	}
+
+	if !deinterlace {
+		return ""
+	}
+
+	return filter
+}
@@ -0,0 +1,71 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestVideoFile_Interlaced(t *testing.T) {
+	tests := []struct {
+		fieldOrder string
+		want       bool
+	}{
+		{"", false},
+		{"progressive", false},
+		{"unknown", false},
+		{"tt", true},
+		{"bb", true},
+		{"tb", true},
+		{"bt", true},
+	}
+
+	for _, tt := range tests {
+		v := &VideoFile{VideoStream: &FFProbeStream{FieldOrder: tt.fieldOrder}}
+		if got := v.Interlaced(); got != tt.want {
+			t.Errorf("Interlaced() with field order %q = %v, want %v", tt.fieldOrder, got, tt.want)
+		}
+	}
+
+	if (&VideoFile{}).Interlaced() {
+		t.Error("Interlaced() without video stream = true, want false")
+	}
+}
+
+func TestDeinterlaceFilterFor(t *testing.T) {
+	on := true
+	off := false
+
+	tests := []struct {
+		name       string
+		interlaced bool
+		override   *bool
+		want       models.DeinterlaceFilter
+	}{
+		{"interlaced", true, nil, models.DeinterlaceFilterBwdif},
+		{"progressive", false, nil, ""},
+		{"forced on", false, &on, models.DeinterlaceFilterBwdif},
+		{"forced off", true, &off, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeinterlaceFilterFor(tt.interlaced, tt.override, models.DeinterlaceFilterBwdif); got != tt.want {
+				t.Errorf("DeinterlaceFilterFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoFilter_Deinterlace(t *testing.T) {
+	var f VideoFilter
+	if got := f.Deinterlace(""); got != "" {
+		t.Errorf("Deinterlace(\"\") = %q, want empty", got)
+	}
+
+	got := f.Deinterlace(models.DeinterlaceFilterYadif).ScaleDimensions(-2, 720)
+	want := VideoFilter("yadif=mode=send_frame:deint=interlaced,scale=-2:720")
+	if got != want {
+		t.Errorf("Deinterlace(yadif) = %q, want %q", got, want)
+	}
+}
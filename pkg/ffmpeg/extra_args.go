@@ -0,0 +1,70 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// disallowedExtraArgs are options that may not be used in additional
+// arguments, since they add inputs or outputs, or overwrite files.
+var disallowedExtraArgs = []string{
+	"-i",
+	"-y",
+	"-filter_complex",
+	"-lavfi",
+	"-dump_attachment",
+	"-attach",
+}
+
+// ValidateExtraArgs returns an error if args cannot be used as additional
+// input or output arguments. Each argument must be an option, optionally
+// followed by a single value.
+func ValidateExtraArgs(args []string) error {
+	expectOption := true
+	for i, arg := range args {
+		if arg == "" {
+			return fmt.Errorf("argument %d is empty", i)
+		}
+
+		if strings.ContainsAny(arg, "\x00\r\n") {
+			return fmt.Errorf("argument %q contains invalid characters", arg)
+		}
+
+		isOption := strings.HasPrefix(arg, "-") && len(arg) > 1
+		if !isOption {
+			// values may only follow options
+			if expectOption {
+				return fmt.Errorf("unexpected value %q: expected an option", arg)
+			}
+			expectOption = true
+			continue
+		}
+
+		// strip any stream specifier, such as -c:v
+		name, _, _ := strings.Cut(arg, ":")
+		for _, d := range disallowedExtraArgs {
+			if name == d {
+				return fmt.Errorf("option %q is not allowed", arg)
+			}
+		}
+
+		expectOption = false
+	}
+
+	return nil
+}
+
+// MergeTranscodeArgs returns the global input and output arguments with the
+// scene specific arguments appended, so that the scene arguments take
+// precedence. The global slices are not modified.
+func MergeTranscodeArgs(inputArgs, outputArgs []string, sceneArgs *models.TranscodeArgs) ([]string, []string) {
+	if sceneArgs.IsEmpty() {
+		return inputArgs, outputArgs
+	}
+
+	retIn := append(append([]string{}, inputArgs...), sceneArgs.InputArgs...)
+	retOut := append(append([]string{}, outputArgs...), sceneArgs.OutputArgs...)
+	return retIn, retOut
+}
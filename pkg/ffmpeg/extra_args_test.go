@@ -0,0 +1,35 @@
+package ffmpeg
+
+import "testing"
+
+func TestValidateExtraArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"deinterlace", []string{"-vf", "yadif"}, false},
+		{"hwaccel", []string{"-hwaccel", "cuda", "-hwaccel_output_format", "cuda"}, false},
+		{"flag", []string{"-an", "-sn"}, false},
+		{"negative value", []string{"-itsoffset", "-1.5"}, false},
+		{"stream specifier", []string{"-c:a", "aac"}, false},
+		{"empty arg", []string{"-vf", ""}, true},
+		{"newline", []string{"-vf", "yadif\n"}, true},
+		{"leading value", []string{"yadif"}, true},
+		{"extra output", []string{"-vf", "yadif", "/tmp/out.mp4"}, true},
+		{"input", []string{"-i", "/etc/passwd"}, true},
+		{"overwrite", []string{"-y"}, true},
+		{"filter complex", []string{"-filter_complex", "[0:v]null"}, true},
+		{"attachment", []string{"-dump_attachment:t", "out"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExtraArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateExtraArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,9 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
-	"regexp"
-	"strconv"
-	"strings"
 
 	stashExec "github.com/stashapp/stash/pkg/exec"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -43,24 +40,7 @@ func ValidateFFMpegCodecSupport(ffmpegPath string) error {
 		return err
 	}
 
-	var missingSupport []string
-
-	if !strings.Contains(output, "--enable-libopus") {
-		missingSupport = append(missingSupport, "libopus")
-	}
-	if !strings.Contains(output, "--enable-libvpx") {
-		missingSupport = append(missingSupport, "libvpx")
-	}
-	if !strings.Contains(output, "--enable-libx264") {
-		missingSupport = append(missingSupport, "libx264")
-	}
-	if !strings.Contains(output, "--enable-libx265") {
-		missingSupport = append(missingSupport, "libx265")
-	}
-	if !strings.Contains(output, "--enable-libwebp") {
-		missingSupport = append(missingSupport, "libwebp")
-	}
-
+	missingSupport := missingFeatures(output)
 	if len(missingSupport) > 0 {
 		return fmt.Errorf("ffmpeg missing codec support: %v", missingSupport)
 	}
@@ -145,8 +125,6 @@ func ResolveFFMpeg(path string, fallbackPath string) string {
 	return ret
 }
 
-var version_re = regexp.MustCompile(`ffmpeg version n?((\d+)\.(\d+)(?:\.(\d+))?)`)
-
 func (f *FFMpeg) getVersion() error {
 	var args Args
 	args = append(args, "-version")
@@ -160,29 +138,9 @@ func (f *FFMpeg) getVersion() error {
 		return err
 	}
 
-	stdoutStr := stdout.String()
-	match := version_re.FindStringSubmatchIndex(stdoutStr)
-	if match == nil {
-		return errors.New("version string malformed")
-	}
-
-	majorS := stdoutStr[match[4]:match[5]]
-	minorS := stdoutStr[match[6]:match[7]]
-
-	// patch is optional
-	var patchS string
-	if match[8] != -1 && match[9] != -1 {
-		patchS = stdoutStr[match[8]:match[9]]
-	}
-
-	if i, err := strconv.Atoi(majorS); err == nil {
-		f.version.major = i
-	}
-	if i, err := strconv.Atoi(minorS); err == nil {
-		f.version.minor = i
-	}
-	if i, err := strconv.Atoi(patchS); err == nil {
-		f.version.patch = i
+	f.version, err = parseVersion("ffmpeg", stdout.String())
+	if err != nil {
+		return err
 	}
 	logger.Debugf("FFMpeg version %s detected", f.version.String())
 
@@ -241,3 +199,8 @@ func (f *FFMpeg) Command(ctx context.Context, args []string) *exec.Cmd {
 func (f *FFMpeg) Path() string {
 	return f.ffmpeg
 }
+
+// Version returns the detected ffmpeg version.
+func (f *FFMpeg) Version() Version {
+	return f.version
+}
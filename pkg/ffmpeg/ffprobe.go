@@ -103,13 +103,18 @@ type VideoFile struct {
 	Bitrate             int64
 	Size                int64
 	CreationTime        time.Time
+	// Date is the date of the date tag, such as the QuickTime ©day atom.
+	// QuickTimeCreationDate is the recording date written by Apple devices.
+	// Both are zero if unset or not a full date.
+	Date                  time.Time
+	QuickTimeCreationDate time.Time
 
 	VideoCodec   string
 	VideoBitrate int64
 	Width        int
 	Height       int
 	FrameRate    float64
-	Rotation     int64
+	Rotation     int64 // counter-clockwise display rotation in degrees
 	FrameCount   int64
 
 	AudioCodec string
@@ -264,6 +269,11 @@ func (f *FFProbe) GetReadFrameCount(path string) (int64, error) {
 	return fc.FrameCount, err
 }
 
+// isURL returns true if the input of ffprobe is a URL rather than a file.
+func isURL(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
+
 func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	if probeJSON == nil {
 		return nil, fmt.Errorf("failed to get ffprobe json for <%s>", filePath)
@@ -285,15 +295,22 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	result.Container = probeJSON.Format.FormatName
 	duration, _ := strconv.ParseFloat(probeJSON.Format.Duration, 64)
 	result.FileDuration = math.Round(duration*100) / 100
-	fileStat, err := os.Stat(filePath)
-	if err != nil {
-		statErr := fmt.Errorf("error statting file <%s>: %w", filePath, err)
-		logger.Errorf("%v", statErr)
-		return nil, statErr
+	if isURL(filePath) {
+		// remote files cannot be statted
+		result.Size, _ = strconv.ParseInt(probeJSON.Format.Size, 10, 64)
+	} else {
+		fileStat, err := os.Stat(filePath)
+		if err != nil {
+			statErr := fmt.Errorf("error statting file <%s>: %w", filePath, err)
+			logger.Errorf("%v", statErr)
+			return nil, statErr
+		}
+		result.Size = fileStat.Size()
 	}
-	result.Size = fileStat.Size()
 	result.StartTime, _ = strconv.ParseFloat(probeJSON.Format.StartTime, 64)
 	result.CreationTime = probeJSON.Format.Tags.CreationTime.Time
+	result.Date = parseTagDate(probeJSON.Format.Tags.Date)
+	result.QuickTimeCreationDate = parseTagDate(probeJSON.Format.Tags.QuickTimeCreationDate)
 
 	audioStream := result.getAudioStream()
 	if audioStream != nil {
@@ -331,11 +348,13 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 		result.Width = videoStream.Width
 		result.Height = videoStream.Height
 
+		result.Rotation = displayRotation(videoStream)
 		if isRotated(videoStream) {
 			result.Width = videoStream.Height
 			result.Height = videoStream.Width
 		}
 
+		var err error
 		result.VideoStreamDuration, err = strconv.ParseFloat(videoStream.Duration, 64)
 		if err != nil {
 			// Revert to the historical behaviour, which is still correct in the vast majority of cases.
@@ -346,6 +365,42 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	return result, nil
 }
 
+// displayRotation returns the counter-clockwise display rotation of the stream.
+// The rotate tag used by older versions of ffmpeg is clockwise.
+// tagDateLayouts are the layouts of the date tags written by common muxers
+// and devices. Year-only dates are not parsed.
+var tagDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTagDate returns the time of a date tag, or the zero time if it is
+// not a full date.
+func parseTagDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range tagDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func displayRotation(s *FFProbeStream) int64 {
+	for _, sd := range s.SideDataList {
+		if sd.Rotation != 0 {
+			return int64(sd.Rotation)
+		}
+	}
+
+	rotate, _ := strconv.ParseInt(s.Tags.Rotate, 10, 64)
+	return -rotate
+}
+
 func isRotated(s *FFProbeStream) bool {
 	rotate, _ := strconv.ParseInt(s.Tags.Rotate, 10, 64)
 	if rotate != 180 && rotate != 0 {
@@ -0,0 +1,30 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagDate(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want time.Time
+	}{
+		{"2019-05-12T14:20:11+0200", time.Date(2019, 5, 12, 14, 20, 11, 0, time.FixedZone("", 2*60*60))},
+		{"2019-05-12T14:20:11Z", time.Date(2019, 5, 12, 14, 20, 11, 0, time.UTC)},
+		{"2019-05-12 14:20:11", time.Date(2019, 5, 12, 14, 20, 11, 0, time.UTC)},
+		{" 2019-05-12 ", time.Date(2019, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{"2019", time.Time{}},
+		{"", time.Time{}},
+		{"yesterday", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got := parseTagDate(tt.tag)
+			assert.True(t, tt.want.Equal(got), "parseTagDate(%q) = %v, want %v", tt.tag, got, tt.want)
+		})
+	}
+}
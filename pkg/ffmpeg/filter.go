@@ -33,7 +33,12 @@ func (f VideoFilter) ScaleDimensions(w, h int) VideoFilter {
 
 // ScaleMaxSize returns a VideoFilter scaling to maxDimensions, maintaining aspect ratio using force_original_aspect_ratio=decrease.
 func (f VideoFilter) ScaleMaxSize(maxDimensions int) VideoFilter {
-	return f.Append(fmt.Sprintf("scale=%v:%v:force_original_aspect_ratio=decrease", maxDimensions, maxDimensions))
+	return f.ScaleMaxDimensions(maxDimensions, maxDimensions)
+}
+
+// ScaleMaxDimensions returns a VideoFilter scaling to fit within w and h, maintaining aspect ratio using force_original_aspect_ratio=decrease.
+func (f VideoFilter) ScaleMaxDimensions(w, h int) VideoFilter {
+	return f.Append(fmt.Sprintf("scale=%v:%v:force_original_aspect_ratio=decrease", w, h))
 }
 
 // ScaleMax returns a VideoFilter scaling to maxSize. It will scale width if it is larger than height, otherwise it will scale height.
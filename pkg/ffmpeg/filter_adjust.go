@@ -0,0 +1,131 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Default values of the scene video filters and transforms.
+// These must match the values used by the scene player.
+const (
+	filterDefaultContrast     = 100
+	filterDefaultBrightness   = 100
+	filterDefaultGamma        = 100
+	filterDefaultSaturate     = 100
+	filterDefaultHueRotate    = 0
+	filterDefaultWhiteBalance = 100
+	filterDefaultColour       = 100
+	filterDefaultBlur         = 0
+
+	transformDefaultRotate      = 2
+	transformDefaultAspectRatio = 150
+)
+
+func intOrDefault(v *int, def int) int {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func formatFilterFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// HasVideoAdjustments returns true if filters or transforms differ from
+// their default values.
+func HasVideoAdjustments(filters *models.VideoFilters, transforms *models.VideoTransforms) bool {
+	var f VideoFilter
+	return f.VideoAdjustments(filters, transforms) != ""
+}
+
+// VideoAdjustments returns a VideoFilter applying the scene video filters
+// and transforms, rendering the video as it is displayed in the scene player.
+// The zoom component of the scale transform is a display setting and is not
+// applied.
+func (f VideoFilter) VideoAdjustments(filters *models.VideoFilters, transforms *models.VideoTransforms) VideoFilter {
+	return f.videoFilters(filters).videoTransforms(transforms)
+}
+
+func (f VideoFilter) videoFilters(filters *models.VideoFilters) VideoFilter {
+	if filters == nil {
+		return f
+	}
+
+	// colour balance and gamma are applied first, followed by the remaining
+	// filters in the same order as the player's css filter
+	wb := float64(intOrDefault(filters.WhiteBalance, filterDefaultWhiteBalance)-filterDefaultWhiteBalance) / 200
+	red := 1 + wb + float64(intOrDefault(filters.Red, filterDefaultColour)-filterDefaultColour)/100
+	green := 1 + float64(intOrDefault(filters.Green, filterDefaultColour)-filterDefaultColour)/100
+	blue := 1 - wb + float64(intOrDefault(filters.Blue, filterDefaultColour)-filterDefaultColour)/100
+	if red != 1 || green != 1 || blue != 1 {
+		f = f.Append(fmt.Sprintf("colorchannelmixer=rr=%s:gg=%s:bb=%s", formatFilterFloat(red), formatFilterFloat(green), formatFilterFloat(blue)))
+	}
+
+	// the player raises each channel to the power of the exponent, while
+	// ffmpeg raises it to the power of 1/gamma
+	if gamma := intOrDefault(filters.Gamma, filterDefaultGamma); gamma != filterDefaultGamma {
+		exponent := 1 + float64(filterDefaultGamma-gamma)/200
+		// eq accepts gamma values up to 10
+		if exponent < 0.1 {
+			exponent = 0.1
+		}
+		f = f.Append("eq=gamma=" + formatFilterFloat(1/exponent))
+	}
+
+	if contrast := intOrDefault(filters.Contrast, filterDefaultContrast); contrast != filterDefaultContrast {
+		f = f.Append("eq=contrast=" + formatFilterFloat(float64(contrast)/100))
+	}
+
+	// css brightness is a multiplier, whereas eq brightness is an offset
+	if brightness := intOrDefault(filters.Brightness, filterDefaultBrightness); brightness != filterDefaultBrightness {
+		v := formatFilterFloat(float64(brightness) / 100)
+		f = f.Append(fmt.Sprintf("colorchannelmixer=rr=%s:gg=%s:bb=%s", v, v, v))
+	}
+
+	if saturate := intOrDefault(filters.Saturate, filterDefaultSaturate); saturate != filterDefaultSaturate {
+		f = f.Append("eq=saturation=" + formatFilterFloat(float64(saturate)/100))
+	}
+
+	if hue := intOrDefault(filters.HueRotate, filterDefaultHueRotate); hue != filterDefaultHueRotate {
+		f = f.Append(fmt.Sprintf("hue=h=%d", hue))
+	}
+
+	if blur := intOrDefault(filters.Blur, filterDefaultBlur); blur > filterDefaultBlur {
+		f = f.Append("gblur=sigma=" + formatFilterFloat(float64(blur)/10))
+	}
+
+	return f
+}
+
+func (f VideoFilter) videoTransforms(transforms *models.VideoTransforms) VideoFilter {
+	if transforms == nil {
+		return f
+	}
+
+	// stretch according to the aspect ratio, keeping dimensions even
+	aspectRatio := intOrDefault(transforms.AspectRatio, transformDefaultAspectRatio)
+	switch {
+	case aspectRatio > transformDefaultAspectRatio:
+		x := formatFilterFloat(float64(100+aspectRatio-transformDefaultAspectRatio) / 100)
+		f = f.Append(fmt.Sprintf("scale=trunc(iw*%s/2)*2:ih", x))
+	case aspectRatio < transformDefaultAspectRatio:
+		y := formatFilterFloat(float64(100+transformDefaultAspectRatio-aspectRatio) / 100)
+		f = f.Append(fmt.Sprintf("scale=iw:trunc(ih*%s/2)*2", y))
+	}
+
+	// rotation is in 90 degree steps from the default
+	rotate := intOrDefault(transforms.Rotate, transformDefaultRotate) - transformDefaultRotate
+	switch ((rotate % 4) + 4) % 4 {
+	case 1:
+		f = f.Append("transpose=clock")
+	case 2:
+		f = f.Append("hflip").Append("vflip")
+	case 3:
+		f = f.Append("transpose=cclock")
+	}
+
+	return f
+}
@@ -0,0 +1,117 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestVideoFilter_VideoAdjustments(t *testing.T) {
+	tests := []struct {
+		name       string
+		filters    *models.VideoFilters
+		transforms *models.VideoTransforms
+		want       VideoFilter
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+			"",
+		},
+		{
+			"defaults",
+			&models.VideoFilters{
+				Contrast:     intPtr(100),
+				Brightness:   intPtr(100),
+				Gamma:        intPtr(100),
+				Saturate:     intPtr(100),
+				HueRotate:    intPtr(0),
+				WhiteBalance: intPtr(100),
+				Red:          intPtr(100),
+				Green:        intPtr(100),
+				Blue:         intPtr(100),
+				Blur:         intPtr(0),
+			},
+			&models.VideoTransforms{
+				Rotate:      intPtr(2),
+				Scale:       intPtr(100),
+				AspectRatio: intPtr(150),
+			},
+			"",
+		},
+		{
+			"colour balance",
+			&models.VideoFilters{
+				WhiteBalance: intPtr(150),
+				Green:        intPtr(90),
+			},
+			nil,
+			"colorchannelmixer=rr=1.25:gg=0.9:bb=0.75",
+		},
+		{
+			"adjustments",
+			&models.VideoFilters{
+				Contrast:   intPtr(120),
+				Brightness: intPtr(80),
+				Gamma:      intPtr(200),
+				Saturate:   intPtr(0),
+				HueRotate:  intPtr(90),
+				Blur:       intPtr(25),
+			},
+			nil,
+			"eq=gamma=2,eq=contrast=1.2,colorchannelmixer=rr=0.8:gg=0.8:bb=0.8,eq=saturation=0,hue=h=90,gblur=sigma=2.5",
+		},
+		{
+			"rotate clockwise",
+			nil,
+			&models.VideoTransforms{Rotate: intPtr(3)},
+			"transpose=clock",
+		},
+		{
+			"rotate counter-clockwise",
+			nil,
+			&models.VideoTransforms{Rotate: intPtr(1)},
+			"transpose=cclock",
+		},
+		{
+			"rotate 180",
+			nil,
+			&models.VideoTransforms{Rotate: intPtr(0)},
+			"hflip,vflip",
+		},
+		{
+			"zoom ignored",
+			nil,
+			&models.VideoTransforms{Scale: intPtr(150)},
+			"",
+		},
+		{
+			"wide aspect ratio",
+			nil,
+			&models.VideoTransforms{AspectRatio: intPtr(175)},
+			"scale=trunc(iw*1.25/2)*2:ih",
+		},
+		{
+			"tall aspect ratio",
+			nil,
+			&models.VideoTransforms{AspectRatio: intPtr(100), Rotate: intPtr(3)},
+			"scale=iw:trunc(ih*1.5/2)*2,transpose=clock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f VideoFilter
+			if got := f.VideoAdjustments(tt.filters, tt.transforms); got != tt.want {
+				t.Errorf("VideoFilter.VideoAdjustments() = %q, want %q", got, tt.want)
+			}
+			if got := HasVideoAdjustments(tt.filters, tt.transforms); got != (tt.want != "") {
+				t.Errorf("HasVideoAdjustments() = %v, want %v", got, tt.want != "")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ffmpeg
 
 import (
 	"bytes"
+	"io"
 	"os"
 )
 
@@ -48,8 +49,12 @@ func magicContainer(filePath string) (Container, error) {
 
 	defer file.Close()
 
+	return magicContainerReader(file)
+}
+
+func magicContainerReader(r io.Reader) (Container, error) {
 	buf := make([]byte, 4096)
-	_, err = file.Read(buf)
+	_, err := r.Read(buf)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,241 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ReencodeCodec is the video codec of a re-encoded file.
+type ReencodeCodec string
+
+const (
+	ReencodeCodecH264 ReencodeCodec = "H264"
+	ReencodeCodecHEVC ReencodeCodec = "HEVC"
+)
+
+func (e ReencodeCodec) IsValid() bool {
+	switch e {
+	case ReencodeCodecH264, ReencodeCodecHEVC:
+		return true
+	}
+	return false
+}
+
+func (e ReencodeCodec) String() string {
+	return string(e)
+}
+
+func (e *ReencodeCodec) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReencodeCodec(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReencodeCodec", str)
+	}
+	return nil
+}
+
+func (e ReencodeCodec) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// probeCodec returns the codec name reported by ffprobe.
+func (e ReencodeCodec) probeCodec() string {
+	if e == ReencodeCodecHEVC {
+		return Hevc
+	}
+	return H264
+}
+
+// DefaultCRF returns the default constant rate factor of the encoder of
+// the codec.
+func (e ReencodeCodec) DefaultCRF() int {
+	return reencodeRates[e].referenceCRF
+}
+
+func (e ReencodeCodec) encoder() VideoCodec {
+	if e == ReencodeCodecHEVC {
+		return VideoCodecLibX265
+	}
+	return VideoCodecLibX264
+}
+
+const (
+	// ReencodeAudioBitrate is the bitrate assumed for the audio of a
+	// re-encoded file, in bits per second. Audio is copied where possible, so
+	// this is an estimate of typical stereo AAC audio.
+	ReencodeAudioBitrate = 128000
+
+	// defaultFrameRate is assumed for videos without a known frame rate.
+	defaultFrameRate = 30
+)
+
+// reencodeRate is the rate of an encoder at its reference CRF.
+type reencodeRate struct {
+	// bits per pixel of each frame
+	bitsPerPixel float64
+	referenceCRF int
+	// pixels encoded per second with the medium preset on a typical cpu
+	pixelsPerSecond float64
+}
+
+var reencodeRates = map[ReencodeCodec]reencodeRate{
+	// 1080p30 at about 5 Mbps, encoded at about twice real time
+	ReencodeCodecH264: {bitsPerPixel: 0.08, referenceCRF: 23, pixelsPerSecond: 125e6},
+	// about 40% smaller than h264 at the same quality, but four times slower
+	ReencodeCodecHEVC: {bitsPerPixel: 0.05, referenceCRF: 28, pixelsPerSecond: 30e6},
+}
+
+// ReencodeTarget is the encoding of a re-encoded video file. The output is
+// an mp4 file.
+type ReencodeTarget struct {
+	Codec ReencodeCodec
+	// CRF is the constant rate factor of the encoder, from 0 to 51. Lower
+	// values give a higher quality.
+	CRF int
+	// MaxHeight caps the height of the video, keeping its aspect ratio. Zero
+	// keeps the height.
+	MaxHeight int
+}
+
+func (t ReencodeTarget) Validate() error {
+	if !t.Codec.IsValid() {
+		return fmt.Errorf("invalid codec %q", t.Codec)
+	}
+	if t.CRF < 0 || t.CRF > 51 {
+		return fmt.Errorf("crf must be between 0 and 51")
+	}
+	if t.MaxHeight < 0 {
+		return fmt.Errorf("max height must not be negative")
+	}
+	return nil
+}
+
+func (t ReencodeTarget) String() string {
+	ret := fmt.Sprintf("%s crf %d", t.Codec, t.CRF)
+	if t.MaxHeight > 0 {
+		ret += fmt.Sprintf(" max %dp", t.MaxHeight)
+	}
+	return ret
+}
+
+// Dimensions returns the dimensions of a video of the width and height once
+// re-encoded. Scaled dimensions are rounded to multiples of two.
+func (t ReencodeTarget) Dimensions(width, height int) (int, int) {
+	if t.MaxHeight <= 0 || height <= t.MaxHeight || height <= 0 {
+		return width, height
+	}
+
+	w := int(math.Round(float64(width)*float64(t.MaxHeight)/float64(height)/2)) * 2
+	return w, t.MaxHeight
+}
+
+// Unchanged returns true if the video of the codec and height already has
+// the target codec and is within the height cap. Re-encoding such a video
+// only loses quality.
+func (t ReencodeTarget) Unchanged(videoCodec string, height int) bool {
+	_, h := t.Dimensions(0, height)
+	return videoCodec == t.Codec.probeCodec() && h == height
+}
+
+// EstimateVideoBitrate estimates the bitrate in bits per second of the video
+// stream of a video of the dimensions and frame rate once re-encoded. Every
+// 6 CRF halves or doubles the bitrate.
+func (t ReencodeTarget) EstimateVideoBitrate(width, height int, frameRate float64) float64 {
+	rate := reencodeRates[t.Codec]
+	if frameRate <= 0 {
+		frameRate = defaultFrameRate
+	}
+
+	w, h := t.Dimensions(width, height)
+	bpp := rate.bitsPerPixel * math.Pow(2, float64(rate.referenceCRF-t.CRF)/6)
+	return bpp * float64(w*h) * frameRate
+}
+
+// EstimateEncodeSpeed estimates how many times faster than real time a
+// video of the dimensions and frame rate is encoded.
+func (t ReencodeTarget) EstimateEncodeSpeed(width, height int, frameRate float64) float64 {
+	rate := reencodeRates[t.Codec]
+	if frameRate <= 0 {
+		frameRate = defaultFrameRate
+	}
+
+	// the source is decoded at full size, so the larger size is used
+	pixels := float64(width * height)
+	if pixels <= 0 {
+		return 1
+	}
+
+	return rate.pixelsPerSecond / (pixels * frameRate)
+}
+
+func (t ReencodeTarget) videoArgs(v *VideoFile) Args {
+	var args Args
+
+	if _, h := t.Dimensions(v.Width, v.Height); h != v.Height {
+		var videoFilter VideoFilter
+		args = args.VideoFilter(videoFilter.ScaleHeight(h))
+	}
+
+	args = args.VideoCodec(t.Codec.encoder())
+	args = append(args,
+		"-pix_fmt", "yuv420p",
+		"-preset", "medium",
+		"-crf", strconv.Itoa(t.CRF),
+	)
+
+	// tag hevc so that it plays in browsers which support it
+	if t.Codec == ReencodeCodecHEVC {
+		args = append(args, "-tag:v", "hvc1")
+	}
+
+	return args
+}
+
+// Args returns the arguments re-encoding the video file to an mp4 file at
+// output. The audio is copied if mp4 supports it.
+func (t ReencodeTarget) Args(v *VideoFile, output string) Args {
+	var args Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(LogLevelError)
+	args = args.Overwrite()
+	args = args.Input(v.Path)
+	args = append(args, "-map", "0:v:0", "-map", "0:a?")
+	args = append(args, t.videoArgs(v)...)
+
+	if IsValidAudioForContainer(ProbeAudioCodec(v.AudioCodec), Mp4) {
+		args = args.AudioCodec(AudioCodecCopy)
+	} else {
+		args = args.AudioCodec(AudioCodecAAC)
+	}
+
+	args = append(args, "-map_metadata", "0", "-movflags", "+faststart")
+	args = args.Output(output)
+
+	return args
+}
+
+// SampleArgs returns the arguments re-encoding the video stream of a
+// segment of the video file, from start for duration seconds, to an mp4
+// file at output. The size of the output is that of the video stream of
+// the segment.
+func (t ReencodeTarget) SampleArgs(v *VideoFile, start, duration float64, output string) Args {
+	var args Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(LogLevelError)
+	args = args.Overwrite()
+	args = args.Seek(start)
+	args = args.Input(v.Path)
+	args = args.Duration(duration)
+	args = append(args, "-map", "0:v:0")
+	args = append(args, t.videoArgs(v)...)
+	args = args.SkipAudio()
+	args = args.Output(output)
+
+	return args
+}
@@ -0,0 +1,77 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReencodeTargetDimensions(t *testing.T) {
+	target := ReencodeTarget{Codec: ReencodeCodecH264, CRF: 23, MaxHeight: 720}
+
+	w, h := target.Dimensions(1920, 1080)
+	assert.Equal(t, 1280, w)
+	assert.Equal(t, 720, h)
+
+	// rounded to an even width
+	w, h = target.Dimensions(1438, 1080)
+	assert.Equal(t, 958, w)
+	assert.Equal(t, 720, h)
+
+	w, h = target.Dimensions(640, 480)
+	assert.Equal(t, 640, w)
+	assert.Equal(t, 480, h)
+
+	target.MaxHeight = 0
+	w, h = target.Dimensions(1920, 1080)
+	assert.Equal(t, 1920, w)
+	assert.Equal(t, 1080, h)
+}
+
+func TestReencodeTargetUnchanged(t *testing.T) {
+	target := ReencodeTarget{Codec: ReencodeCodecHEVC, CRF: 28, MaxHeight: 1080}
+
+	assert.True(t, target.Unchanged(Hevc, 1080))
+	assert.False(t, target.Unchanged(Hevc, 2160))
+	assert.False(t, target.Unchanged(H264, 1080))
+}
+
+func TestReencodeTargetEstimateVideoBitrate(t *testing.T) {
+	target := ReencodeTarget{Codec: ReencodeCodecH264, CRF: 23}
+	base := target.EstimateVideoBitrate(1920, 1080, 30)
+	assert.InDelta(t, 5e6, base, 0.1e6)
+
+	// 6 crf halves the bitrate
+	target.CRF = 29
+	assert.InDelta(t, base/2, target.EstimateVideoBitrate(1920, 1080, 30), 1)
+
+	// unknown frame rate
+	target.CRF = 23
+	assert.Equal(t, base, target.EstimateVideoBitrate(1920, 1080, 0))
+
+	// scaled to a quarter of the pixels
+	target.MaxHeight = 540
+	assert.InDelta(t, base/4, target.EstimateVideoBitrate(1920, 1080, 30), 1)
+
+	// at the default crf of each encoder
+	hevc := ReencodeTarget{Codec: ReencodeCodecHEVC, CRF: 28}
+	assert.Less(t, hevc.EstimateVideoBitrate(1920, 1080, 30), base)
+}
+
+func TestReencodeTargetArgs(t *testing.T) {
+	v := &VideoFile{
+		Path:       "in.mkv",
+		Width:      3840,
+		Height:     2160,
+		AudioCodec: "vorbis",
+	}
+
+	target := ReencodeTarget{Codec: ReencodeCodecHEVC, CRF: 26, MaxHeight: 1080}
+	args := target.Args(v, "out.mp4")
+
+	assert.Subset(t, []string(args), []string{"-vf", "scale=-2:1080", "libx265", "-crf", "26", "-tag:v", "hvc1", "aac", "out.mp4"})
+
+	sample := target.SampleArgs(v, 60, 5, "sample.mp4")
+	assert.Subset(t, []string(sample), []string{"-ss", "60", "-t", "5", "-an", "sample.mp4"})
+	assert.NotContains(t, []string(sample), "0:a?")
+}
@@ -0,0 +1,80 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sceneChangeThreshold is the scene score above which a frame is the
+	// first frame of a new scene.
+	sceneChangeThreshold = 0.4
+	// sceneChangeWidth is the width frames are scaled to before scoring,
+	// which is much faster and barely affects the score.
+	sceneChangeWidth = 160
+)
+
+// NextSceneChange returns the time in seconds of the first scene change in
+// the video at path after start, looking at most window seconds ahead.
+// Returns nil if there is no scene change within the window.
+func (f *FFMpeg) NextSceneChange(ctx context.Context, path string, start float64, window float64) (*float64, error) {
+	vf := VideoFilter("").
+		ScaleWidth(sceneChangeWidth).
+		Append(fmt.Sprintf("select='gt(scene,%v)'", sceneChangeThreshold)).
+		Append("metadata=print:file=-")
+
+	var args Args
+	args = args.LogLevel(LogLevelError).
+		Seek(start).
+		Duration(window).
+		Input(path).
+		SkipAudio().
+		VideoFilter(vf).
+		Format("null").
+		Output("-")
+
+	out, err := f.GenerateOutput(ctx, args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := parseSceneChangeOutput(out)
+	if ret == nil {
+		return nil, nil
+	}
+
+	t := start + *ret
+	return &t, nil
+}
+
+// parseSceneChangeOutput returns the time of the first frame printed by the
+// metadata filter, relative to the start of the input, or nil if no frames
+// were printed.
+func parseSceneChangeOutput(out []byte) *float64 {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		_, after, found := strings.Cut(scanner.Text(), "pts_time:")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(after)
+		if len(fields) == 0 {
+			continue
+		}
+
+		t, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil || t <= 0 {
+			// a change at the start is not after it
+			continue
+		}
+
+		return &t
+	}
+
+	return nil
+}
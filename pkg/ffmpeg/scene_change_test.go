@@ -0,0 +1,40 @@
+package ffmpeg
+
+import (
+	"testing"
+)
+
+func TestParseSceneChangeOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want *float64
+	}{
+		{"empty", "", nil},
+		{
+			"first change",
+			"frame:0    pts:2048   pts_time:2.048\nlavfi.scene_score=0.512\nframe:1    pts:9216   pts_time:9.216\nlavfi.scene_score=0.633\n",
+			floatPtr(2.048),
+		},
+		{
+			"skip start",
+			"frame:0    pts:0      pts_time:0\nlavfi.scene_score=1.000\nframe:1    pts:4000   pts_time:4\nlavfi.scene_score=0.451\n",
+			floatPtr(4),
+		},
+		{"invalid", "frame:0    pts:N/A    pts_time:N/A\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSceneChangeOutput([]byte(tt.out))
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil || *got != *tt.want:
+				t.Errorf("parseSceneChangeOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
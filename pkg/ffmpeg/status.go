@@ -0,0 +1,156 @@
+package ffmpeg
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+)
+
+// MinimumVersion is the oldest ffmpeg version supported by stash.
+var MinimumVersion = Version{major: 4}
+
+// requiredFeatures are the configuration flags that ffmpeg must be built with.
+var requiredFeatures = []string{"libopus", "libvpx", "libx264", "libx265", "libwebp"}
+
+// hardwareEncoders are the hardware encoders reported in the status, if
+// ffmpeg was built with them.
+var hardwareEncoders = []string{
+	"h264_nvenc",
+	"hevc_nvenc",
+	"h264_qsv",
+	"hevc_qsv",
+	"h264_amf",
+	"h264_videotoolbox",
+	"h264_vaapi",
+	"hevc_vaapi",
+	"vp9_vaapi",
+	"vp9_qsv",
+}
+
+// Status describes an ffmpeg executable.
+type Status struct {
+	Path string
+	// Version is nil if the version could not be detected.
+	Version *Version
+	// MissingFeatures are the required features that ffmpeg was not built with.
+	MissingFeatures []string
+	// HardwareEncoders are the hardware encoders that ffmpeg was built with.
+	// This does not guarantee that the hardware is present.
+	HardwareEncoders []string
+}
+
+// VersionSupported returns true if the version was detected and is at least
+// MinimumVersion.
+func (s Status) VersionSupported() bool {
+	return s.Version != nil && s.Version.Gteq(MinimumVersion)
+}
+
+// Supported returns true if the version is supported and no required
+// features are missing.
+func (s Status) Supported() bool {
+	return s.VersionSupported() && len(s.MissingFeatures) == 0
+}
+
+// GetStatus returns the status of the ffmpeg executable at ffmpegPath.
+// Returns an error if ffmpeg could not be executed.
+func GetStatus(ctx context.Context, ffmpegPath string) (*Status, error) {
+	output, err := stashExec.CommandContext(ctx, ffmpegPath, "-version").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Status{
+		Path:            ffmpegPath,
+		MissingFeatures: missingFeatures(string(output)),
+	}
+
+	if v, err := parseVersion("ffmpeg", string(output)); err == nil {
+		ret.Version = &v
+	}
+
+	encoders, err := stashExec.CommandContext(ctx, ffmpegPath, "-hide_banner", "-encoders").Output()
+	if err != nil {
+		return nil, err
+	}
+	ret.HardwareEncoders = findEncoders(string(encoders), hardwareEncoders)
+
+	return ret, nil
+}
+
+// GetFFProbeVersion returns the version of the ffprobe executable at
+// ffprobePath.
+func GetFFProbeVersion(ctx context.Context, ffprobePath string) (*Version, error) {
+	output, err := stashExec.CommandContext(ctx, ffprobePath, "-version").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := parseVersion("ffprobe", string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+var versionREs = map[string]*regexp.Regexp{
+	"ffmpeg":  regexp.MustCompile(`ffmpeg version n?(\d+)\.(\d+)(?:\.(\d+))?`),
+	"ffprobe": regexp.MustCompile(`ffprobe version n?(\d+)\.(\d+)(?:\.(\d+))?`),
+}
+
+// parseVersion parses the version from the -version output of program.
+func parseVersion(program string, output string) (Version, error) {
+	match := versionREs[program].FindStringSubmatch(output)
+	if match == nil {
+		return Version{}, errors.New("version string malformed")
+	}
+
+	var ret Version
+	ret.major, _ = strconv.Atoi(match[1])
+	ret.minor, _ = strconv.Atoi(match[2])
+	// patch is optional
+	if match[3] != "" {
+		ret.patch, _ = strconv.Atoi(match[3])
+	}
+
+	return ret, nil
+}
+
+// missingFeatures returns the required features that are not enabled in the
+// build configuration included in output.
+func missingFeatures(output string) []string {
+	var ret []string
+	for _, f := range requiredFeatures {
+		if !strings.Contains(output, "--enable-"+f) {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+// findEncoders returns the encoders in names that are listed in the
+// -encoders output.
+func findEncoders(output string, names []string) []string {
+	listed := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		// lines are formatted as: " V....D h264_nvenc  NVIDIA NVENC H.264 encoder"
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			listed[fields[1]] = true
+		}
+	}
+
+	var ret []string
+	for _, n := range names {
+		if listed[n] {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
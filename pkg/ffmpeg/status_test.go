@@ -0,0 +1,82 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		output  string
+		want    Version
+		wantErr bool
+	}{
+		{"full version", "ffmpeg", "ffmpeg version 6.1.1 Copyright (c) 2000-2023", Version{6, 1, 1}, false},
+		{"no patch", "ffmpeg", "ffmpeg version 6.1-static https://johnvansickle.com", Version{6, 1, 0}, false},
+		{"n prefix", "ffmpeg", "ffmpeg version n5.1.2 Copyright", Version{5, 1, 2}, false},
+		{"ffprobe", "ffprobe", "ffprobe version 4.4.2-0ubuntu0.22.04.1", Version{4, 4, 2}, false},
+		{"wrong program", "ffprobe", "ffmpeg version 6.1.1", Version{}, true},
+		{"git build", "ffmpeg", "ffmpeg version N-109421-g1234567", Version{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersion(tt.program, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingFeatures(t *testing.T) {
+	output := "configuration: --enable-gpl --enable-libopus --enable-libvpx --enable-libx264 --enable-libwebp"
+	want := []string{"libx265"}
+	if got := missingFeatures(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("missingFeatures() = %v, want %v", got, want)
+	}
+}
+
+func TestFindEncoders(t *testing.T) {
+	output := `Encoders:
+ V..... = Video
+ ------
+ V....D libx264              libx264 H.264 / AVC / MPEG-4 AVC / MPEG-4 part 10 (codec h264)
+ V....D h264_nvenc           NVIDIA NVENC H.264 encoder (codec h264)
+ V....D hevc_vaapi           H.265/HEVC (VAAPI) (codec hevc)
+`
+	want := []string{"h264_nvenc", "hevc_vaapi"}
+	if got := findEncoders(output, hardwareEncoders); !reflect.DeepEqual(got, want) {
+		t.Errorf("findEncoders() = %v, want %v", got, want)
+	}
+}
+
+func TestStatus_Supported(t *testing.T) {
+	old := Version{3, 4, 0}
+	current := Version{6, 1, 0}
+
+	tests := []struct {
+		name   string
+		status Status
+		want   bool
+	}{
+		{"supported", Status{Version: &current}, true},
+		{"old version", Status{Version: &old}, false},
+		{"unknown version", Status{}, false},
+		{"missing features", Status{Version: &current, MissingFeatures: []string{"libx265"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Supported(); got != tt.want {
+				t.Errorf("Status.Supported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -260,6 +260,13 @@ type StreamOptions struct {
 	Resolution string
 	Hash       string
 	Segment    string
+
+	// Scene specific ffmpeg arguments, appended to the global arguments.
+	TranscodeArgs *models.TranscodeArgs
+
+	// Deinterlace is the filter used to deinterlace the video. Empty if the
+	// video should not be deinterlaced.
+	Deinterlace models.DeinterlaceFilter
 }
 
 type transcodeProcess struct {
@@ -288,6 +295,8 @@ type runningStream struct {
 	vf               *models.VideoFile
 	maxTranscodeSize int
 	outputDir        string
+	transcodeArgs    *models.TranscodeArgs
+	deinterlace      models.DeinterlaceFilter
 
 	waitingSegments []*waitingSegment
 	tp              *transcodeProcess
@@ -327,15 +336,25 @@ func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
 }
 
 func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
-	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
-	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
+	extraInputArgs, extraOutputArgs := MergeTranscodeArgs(
+		sm.config.GetLiveTranscodeInputArgs(),
+		sm.config.GetLiveTranscodeOutputArgs(),
+		s.transcodeArgs,
+	)
 
 	args := Args{"-hide_banner"}
 	args = args.LogLevel(LogLevelError)
 
 	codec := HLSGetCodec(sm, s.streamType.Name)
 
-	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
+	// deinterlacing is not possible when copying the video stream
+	deinterlace := s.deinterlace
+	if codec == VideoCodecCopy {
+		deinterlace = ""
+	}
+
+	// the deinterlace filter requires software filtered frames
+	fullhw := deinterlace == "" && sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
@@ -348,7 +367,11 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 	audioCodec := ProbeAudioCodec(s.vf.AudioCodec)
 	videoOnly := audioCodec == MissingUnsupported
 
-	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw)
+	var videoFilter VideoFilter
+	videoFilter = videoFilter.Deinterlace(deinterlace)
+	if hwFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw); hwFilter != "" {
+		videoFilter = videoFilter.Append(string(hwFilter))
+	}
 
 	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
 
@@ -648,6 +671,8 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 			vf:               options.VideoFile,
 			maxTranscodeSize: maxTranscodeSize,
 			outputDir:        outputDir,
+			transcodeArgs:    options.TranscodeArgs,
+			deinterlace:      options.Deinterlace,
 
 			// initialize to cap 10 to avoid reallocations
 			waitingSegments: make([]*waitingSegment, 0, 10),
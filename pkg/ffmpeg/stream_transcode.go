@@ -179,6 +179,9 @@ var (
 		MimeType: MimeMkvVideo,
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
+			if codec != VideoCodecCopy {
+				args = args.VideoFilter(videoFilter)
+			}
 			if videoOnly {
 				args = args.SkipAudio()
 			} else {
@@ -274,6 +277,17 @@ type TranscodeOptions struct {
 	Resolution    string
 	StartTime     float64
 	AudioOffsetMs int
+
+	// Scene video filters and transforms to apply to the stream.
+	VideoFilters    *models.VideoFilters
+	VideoTransforms *models.VideoTransforms
+
+	// Scene specific ffmpeg arguments, appended to the global arguments.
+	TranscodeArgs *models.TranscodeArgs
+
+	// Deinterlace is the filter used to deinterlace the video. Empty if the
+	// video should not be deinterlaced.
+	Deinterlace models.DeinterlaceFilter
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -316,15 +330,28 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 	if o.Resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
 	}
-	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
-	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
+	extraInputArgs, extraOutputArgs := MergeTranscodeArgs(
+		sm.config.GetLiveTranscodeInputArgs(),
+		sm.config.GetLiveTranscodeOutputArgs(),
+		o.TranscodeArgs,
+	)
 
 	args := Args{"-hide_banner"}
 	args = args.LogLevel(LogLevelError)
 
 	codec := o.FileGetCodec(sm, maxTranscodeSize)
 
-	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, o.VideoFile, maxTranscodeSize)
+	// video adjustments and deinterlacing require the video to be
+	// re-encoded in software filtered frames
+	adjust := HasVideoAdjustments(o.VideoFilters, o.VideoTransforms) || o.Deinterlace != ""
+	if adjust && codec == VideoCodecCopy {
+		codec = VideoCodecLibX264
+		if o.StreamType.MimeType == MimeWebmVideo {
+			codec = VideoCodecVP9
+		}
+	}
+
+	fullhw := !adjust && sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, o.VideoFile, maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
@@ -337,7 +364,12 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 	audioCodec := ProbeAudioCodec(o.VideoFile.AudioCodec)
 	videoOnly := audioCodec == MissingUnsupported
 
-	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw)
+	var videoFilter VideoFilter
+	videoFilter = videoFilter.Deinterlace(o.Deinterlace)
+	videoFilter = videoFilter.VideoAdjustments(o.VideoFilters, o.VideoTransforms)
+	if hwFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw); hwFilter != "" {
+		videoFilter = videoFilter.Append(string(hwFilter))
+	}
 
 	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly)...)
 
@@ -0,0 +1,137 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SubtitleMode is how subtitles are added to a video for devices which do
+// not support external subtitle files.
+type SubtitleMode string
+
+const (
+	// SubtitleModeBurnIn renders the subtitles into the video frames. The
+	// video is re-encoded.
+	SubtitleModeBurnIn SubtitleMode = "BURN_IN"
+	// SubtitleModeSoft adds the subtitles as subtitle streams, which can be
+	// turned on and off by the player.
+	SubtitleModeSoft SubtitleMode = "SOFT"
+)
+
+func (e SubtitleMode) IsValid() bool {
+	switch e {
+	case SubtitleModeBurnIn, SubtitleModeSoft:
+		return true
+	}
+	return false
+}
+
+func (e SubtitleMode) String() string {
+	return string(e)
+}
+
+func (e *SubtitleMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SubtitleMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SubtitleMode", str)
+	}
+	return nil
+}
+
+func (e SubtitleMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Subtitle is a subtitle file added to a video.
+type Subtitle struct {
+	Path string
+	// LanguageCode is the language of the subtitles. Unknown languages are
+	// empty or "00".
+	LanguageCode string
+}
+
+// A filter option value is escaped by filterOptionEscaper, and then again by
+// filterGraphEscaper as part of the filter graph.
+var (
+	filterOptionEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `:`, `\:`)
+	filterGraphEscaper  = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `[`, `\[`, `]`, `\]`, `,`, `\,`, `;`, `\;`)
+)
+
+// escapeFilterValue escapes a value, such as a path, to be used as the value
+// of a filter option in a filter graph.
+func escapeFilterValue(v string) string {
+	return filterGraphEscaper.Replace(filterOptionEscaper.Replace(v))
+}
+
+// SubtitledVideoArgs returns the arguments converting the video file to an
+// mp4 file with the subtitles. When burning in, only the first subtitle file
+// is used and the video is encoded with x264. Soft subtitles are added as
+// mov_text streams, and the video stream is copied where mp4 supports it.
+func SubtitledVideoArgs(v *VideoFile, subtitles []Subtitle, mode SubtitleMode, output string) Args {
+	var args Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(LogLevelError)
+	args = args.Overwrite()
+	args = args.Input(v.Path)
+
+	if mode == SubtitleModeSoft {
+		for _, s := range subtitles {
+			args = args.Input(s.Path)
+		}
+	}
+
+	args = append(args, "-map", "0:v:0", "-map", "0:a:0?")
+
+	if mode == SubtitleModeBurnIn {
+		var videoFilter VideoFilter
+		if len(subtitles) > 0 {
+			videoFilter = videoFilter.Append("subtitles=" + escapeFilterValue(subtitles[0].Path))
+		}
+
+		args = args.VideoFilter(videoFilter)
+		args = args.VideoCodec(VideoCodecLibX264)
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "veryfast",
+			"-crf", "20",
+		)
+	} else {
+		for i, s := range subtitles {
+			args = append(args, "-map", fmt.Sprintf("%d:s:0", i+1))
+			if s.LanguageCode != "" && s.LanguageCode != "00" {
+				args = append(args, fmt.Sprintf("-metadata:s:s:%d", i), "language="+s.LanguageCode)
+			}
+		}
+
+		switch v.VideoCodec {
+		case H264, H265, Hevc:
+			args = args.VideoCodec(VideoCodecCopy)
+		default:
+			args = args.VideoCodec(VideoCodecLibX264)
+			args = append(args, "-pix_fmt", "yuv420p", "-preset", "veryfast", "-crf", "20")
+		}
+
+		args = append(args, "-c:s", "mov_text")
+	}
+
+	if v.AudioStream != nil {
+		if IsValidAudioForContainer(ProbeAudioCodec(v.AudioCodec), Mp4) {
+			args = args.AudioCodec(AudioCodecCopy)
+		} else {
+			args = args.AudioCodec(AudioCodecAAC)
+		}
+	}
+
+	args = append(args, "-movflags", "+faststart")
+	args = args.Format(FormatMP4)
+	args = args.Output(output)
+
+	return args
+}
@@ -0,0 +1,38 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeFilterValue(t *testing.T) {
+	assert.Equal(t, `/stash/movie.srt`, escapeFilterValue("/stash/movie.srt"))
+	assert.Equal(t, `C\\:\\\\stash\\\\it\\\'s \[1\]\,2.srt`, escapeFilterValue(`C:\stash\it's [1],2.srt`))
+}
+
+func TestSubtitledVideoArgs(t *testing.T) {
+	v := &VideoFile{
+		Path:        "/stash/movie.mkv",
+		VideoCodec:  H264,
+		AudioCodec:  "flac",
+		AudioStream: &FFProbeStream{},
+	}
+	subtitles := []Subtitle{
+		{Path: "/stash/movie.en.srt", LanguageCode: "en"},
+		{Path: "/stash/movie.00.vtt", LanguageCode: "00"},
+	}
+
+	soft := strings.Join(SubtitledVideoArgs(v, subtitles, SubtitleModeSoft, "out.mp4"), " ")
+	assert.Contains(t, soft, "-i /stash/movie.mkv -i /stash/movie.en.srt -i /stash/movie.00.vtt")
+	assert.Contains(t, soft, "-map 1:s:0 -metadata:s:s:0 language=en -map 2:s:0 -c:v copy -c:s mov_text")
+	assert.Contains(t, soft, "-c:a aac")
+	assert.NotContains(t, soft, "-vf")
+
+	burn := strings.Join(SubtitledVideoArgs(v, subtitles, SubtitleModeBurnIn, "out.mp4"), " ")
+	assert.Contains(t, burn, "-vf subtitles=/stash/movie.en.srt -c:v libx264")
+	assert.NotContains(t, burn, "movie.00.vtt")
+	assert.NotContains(t, burn, "mov_text")
+	assert.True(t, strings.HasSuffix(burn, "-f mp4 out.mp4"))
+}
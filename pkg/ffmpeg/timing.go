@@ -0,0 +1,233 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// vfrTolerance is the relative difference between the real and average
+	// frame rates above which the frame rate is considered variable.
+	vfrTolerance = 0.01
+
+	// maxAudioOffset is the difference in seconds between the start of the
+	// audio and video streams above which the audio is out of sync. This is
+	// about one frame at 25 fps.
+	maxAudioOffset = 0.04
+
+	// maxAudioDrift and maxAudioDriftRatio are the absolute and relative
+	// differences between the durations of the audio and video streams above
+	// which the audio has drifted.
+	maxAudioDrift      = 0.5
+	maxAudioDriftRatio = 0.005
+)
+
+// standardFrameRates are the frame rates that measured frame rates are
+// rounded to, as accepted by the fps filter.
+var standardFrameRates = []struct {
+	rate  float64
+	value string
+}{
+	{24000.0 / 1001, "24000/1001"},
+	{24, "24"},
+	{25, "25"},
+	{30000.0 / 1001, "30000/1001"},
+	{30, "30"},
+	{48, "48"},
+	{50, "50"},
+	{60000.0 / 1001, "60000/1001"},
+	{60, "60"},
+	{120, "120"},
+}
+
+// parseRate parses a rate of the form "num/den" or a decimal number. Returns
+// 0 if the rate is invalid.
+func parseRate(s string) float64 {
+	num, den, found := strings.Cut(s, "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0
+	}
+
+	if !found {
+		return n
+	}
+
+	d, err := strconv.ParseFloat(den, 64)
+	if err != nil || d == 0 {
+		return 0
+	}
+
+	return n / d
+}
+
+// constantFrameRate returns the value of the fps filter converting a video
+// with the measured frame rate to a constant frame rate.
+func constantFrameRate(rate float64) string {
+	for _, r := range standardFrameRates {
+		if math.Abs(rate-r.rate)/r.rate < 0.005 {
+			return r.value
+		}
+	}
+
+	return strconv.FormatFloat(math.Round(rate*1000)/1000, 'f', -1, 64)
+}
+
+// TimingIssues are the frame and audio timing problems of a video file,
+// which are common in recordings and HLS rips, and cause stuttering, seeking
+// errors and audio out of sync.
+type TimingIssues struct {
+	// VariableFrameRate is true if the frame rate of the video varies.
+	VariableFrameRate bool
+	Interlaced        bool
+	// AudioOffset is the time in seconds between the start of the video and
+	// the start of the audio, if the audio is out of sync.
+	AudioOffset float64
+	// AudioDrift is the difference in seconds between the durations of the
+	// audio and video streams, if the audio has drifted.
+	AudioDrift float64
+
+	// FrameRate is the constant frame rate to convert the video to, as
+	// accepted by the fps filter.
+	FrameRate string
+}
+
+// Any returns true if there are any timing issues.
+func (i TimingIssues) Any() bool {
+	return i.VariableFrameRate || i.Interlaced || i.AudioOffset != 0 || i.AudioDrift != 0
+}
+
+// ReencodeVideo returns true if the video stream must be re-encoded to fix
+// the issues. Otherwise only the audio is re-encoded.
+func (i TimingIssues) ReencodeVideo() bool {
+	return i.VariableFrameRate || i.Interlaced
+}
+
+// String returns a description of the issues.
+func (i TimingIssues) String() string {
+	var parts []string
+	if i.VariableFrameRate {
+		parts = append(parts, "variable frame rate")
+	}
+	if i.Interlaced {
+		parts = append(parts, "interlaced")
+	}
+	if i.AudioOffset != 0 {
+		parts = append(parts, fmt.Sprintf("audio offset %.3fs", i.AudioOffset))
+	}
+	if i.AudioDrift != 0 {
+		parts = append(parts, fmt.Sprintf("audio drift %.3fs", i.AudioDrift))
+	}
+
+	if len(parts) == 0 {
+		return "none"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// TimingIssues returns the timing issues of the video file, detected from the
+// probed stream metadata.
+func (v *VideoFile) TimingIssues() TimingIssues {
+	var ret TimingIssues
+	if v.VideoStream == nil {
+		return ret
+	}
+
+	ret.Interlaced = v.Interlaced()
+
+	realRate := parseRate(v.VideoStream.RFrameRate)
+	avg := parseRate(v.VideoStream.AvgFrameRate)
+	if realRate > 0 && avg > 0 {
+		// the real frame rate of interlaced video may be the field rate
+		fieldRate := ret.Interlaced && math.Abs(realRate-2*avg)/avg <= vfrTolerance
+		ret.VariableFrameRate = !fieldRate && math.Abs(realRate-avg)/avg > vfrTolerance
+	}
+
+	switch {
+	case avg > 0:
+		ret.FrameRate = constantFrameRate(avg)
+	case realRate > 0:
+		ret.FrameRate = constantFrameRate(realRate)
+	}
+
+	if v.AudioStream != nil {
+		videoStart, videoErr := strconv.ParseFloat(v.VideoStream.StartTime, 64)
+		audioStart, audioErr := strconv.ParseFloat(v.AudioStream.StartTime, 64)
+		if videoErr == nil && audioErr == nil && math.Abs(audioStart-videoStart) > maxAudioOffset {
+			ret.AudioOffset = audioStart - videoStart
+		}
+
+		videoDuration, videoErr := strconv.ParseFloat(v.VideoStream.Duration, 64)
+		audioDuration, audioErr := strconv.ParseFloat(v.AudioStream.Duration, 64)
+		if videoErr == nil && audioErr == nil && videoDuration > 0 {
+			drift := audioDuration - videoDuration
+			if math.Abs(drift) > maxAudioDrift && math.Abs(drift)/videoDuration > maxAudioDriftRatio {
+				ret.AudioDrift = drift
+			}
+		}
+	}
+
+	return ret
+}
+
+// RemediateTimingArgs returns the arguments converting the video file to an
+// mp4 file without the timing issues. Timestamps are regenerated, the video is
+// converted to a constant frame rate and deinterlaced with the deinterlace
+// filter where needed, and the audio is resampled to the video timestamps,
+// which removes offsets and drift. The video stream is copied if it has no
+// issues.
+func RemediateTimingArgs(v *VideoFile, issues TimingIssues, deinterlace models.DeinterlaceFilter, output string) Args {
+	var args Args
+	args = append(args, "-hide_banner", "-fflags", "+genpts")
+	args = args.LogLevel(LogLevelError)
+	args = args.Overwrite()
+	args = args.Input(v.Path)
+	args = append(args, "-map", "0:v:0", "-map", "0:a?")
+
+	if issues.ReencodeVideo() {
+		var videoFilter VideoFilter
+		if issues.Interlaced {
+			videoFilter = videoFilter.Deinterlace(deinterlace)
+		}
+		if issues.FrameRate != "" {
+			videoFilter = videoFilter.Append("fps=" + issues.FrameRate)
+		}
+
+		args = args.VideoFilter(videoFilter)
+		args = args.VideoCodec(VideoCodecLibX264)
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "medium",
+			"-crf", "18",
+		)
+	} else {
+		args = args.VideoCodec(VideoCodecCopy)
+	}
+
+	if v.AudioStream != nil {
+		if issues.AudioOffset != 0 || issues.AudioDrift != 0 || issues.ReencodeVideo() {
+			// stretch, pad and trim the audio to match its timestamps,
+			// starting at the start of the video
+			args = append(args, "-af", "aresample=async=1000:first_pts=0")
+			args = args.AudioCodec(AudioCodecAAC)
+		} else if IsValidAudioForContainer(ProbeAudioCodec(v.AudioCodec), Mp4) {
+			args = args.AudioCodec(AudioCodecCopy)
+		} else {
+			args = args.AudioCodec(AudioCodecAAC)
+		}
+	}
+
+	args = append(args,
+		"-avoid_negative_ts", "make_zero",
+		"-map_metadata", "0",
+		"-movflags", "+faststart",
+	)
+	args = args.Output(output)
+
+	return args
+}
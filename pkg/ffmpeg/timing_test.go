@@ -0,0 +1,93 @@
+package ffmpeg
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestVideoFile_TimingIssues(t *testing.T) {
+	tests := []struct {
+		name  string
+		video FFProbeStream
+		audio *FFProbeStream
+		want  TimingIssues
+	}{
+		{
+			"constant",
+			FFProbeStream{RFrameRate: "30000/1001", AvgFrameRate: "30000/1001", StartTime: "0.000000", Duration: "60"},
+			&FFProbeStream{StartTime: "0.010000", Duration: "60.2"},
+			TimingIssues{FrameRate: "30000/1001"},
+		},
+		{
+			"variable",
+			FFProbeStream{RFrameRate: "90000/1", AvgFrameRate: "1798000/60000"},
+			nil,
+			TimingIssues{VariableFrameRate: true, FrameRate: "30000/1001"},
+		},
+		{
+			"interlaced field rate",
+			FFProbeStream{RFrameRate: "50/1", AvgFrameRate: "25/1", FieldOrder: "tt"},
+			nil,
+			TimingIssues{Interlaced: true, FrameRate: "25"},
+		},
+		{
+			"audio offset and drift",
+			FFProbeStream{RFrameRate: "25/1", AvgFrameRate: "25/1", StartTime: "1.400000", Duration: "600"},
+			&FFProbeStream{StartTime: "0.900000", Duration: "610"},
+			TimingIssues{AudioOffset: -0.5, AudioDrift: 10, FrameRate: "25"},
+		},
+		{
+			"non standard rate",
+			FFProbeStream{AvgFrameRate: "15.5"},
+			nil,
+			TimingIssues{FrameRate: "15.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			video := tt.video
+			v := &VideoFile{VideoStream: &video, AudioStream: tt.audio}
+			got := v.TimingIssues()
+
+			// compare offsets with a tolerance for float parsing
+			if got.AudioOffset != 0 && tt.want.AudioOffset != 0 && math.Abs(got.AudioOffset-tt.want.AudioOffset) < 1e-9 {
+				got.AudioOffset = tt.want.AudioOffset
+			}
+
+			if got != tt.want {
+				t.Errorf("TimingIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if (&VideoFile{}).TimingIssues().Any() {
+		t.Error("TimingIssues() without video stream has issues")
+	}
+}
+
+func TestRemediateTimingArgs(t *testing.T) {
+	v := &VideoFile{
+		Path:        "in.ts",
+		AudioCodec:  "aac",
+		AudioStream: &FFProbeStream{},
+	}
+
+	issues := TimingIssues{AudioOffset: 0.5, FrameRate: "25"}
+	got := strings.Join(RemediateTimingArgs(v, issues, models.DeinterlaceFilterBwdif, "out.mp4"), " ")
+	if !strings.Contains(got, "-c:v copy") || !strings.Contains(got, "aresample") || !strings.Contains(got, "-c:a aac") {
+		t.Errorf("audio remediation args = %s", got)
+	}
+
+	issues = TimingIssues{VariableFrameRate: true, Interlaced: true, FrameRate: "25"}
+	got = strings.Join(RemediateTimingArgs(v, issues, models.DeinterlaceFilterBwdif, "out.mp4"), " ")
+	if !strings.Contains(got, "-vf bwdif=mode=send_frame:deint=interlaced,fps=25") || !strings.Contains(got, "-c:v libx264") {
+		t.Errorf("video remediation args = %s", got)
+	}
+	if !strings.HasSuffix(got, "out.mp4") {
+		t.Errorf("remediation args do not end with the output: %s", got)
+	}
+}
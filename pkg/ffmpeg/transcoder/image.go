@@ -13,12 +13,27 @@ type ImageThumbnailOptions struct {
 	OutputFormat  ffmpeg.ImageFormat
 	OutputPath    string
 	MaxDimensions int
-	Quality       int
+	// MaxHeight is the maximum height of the thumbnail, where it differs from
+	// the maximum width. Defaults to MaxDimensions.
+	MaxHeight int
+	Quality   int
+
+	// Codec is the codec of the thumbnail. Defaults to mjpeg.
+	Codec *ffmpeg.VideoCodec
+	// CodecArgs are added after the codec, and are used to set the quality
+	// of codecs which do not use Quality.
+	CodecArgs ffmpeg.Args
+	// MuxFormat is the format of the output stream. Defaults to image2pipe.
+	MuxFormat ffmpeg.ImageFormat
 }
 
 func ImageThumbnail(input string, options ImageThumbnailOptions) ffmpeg.Args {
 	var videoFilter ffmpeg.VideoFilter
-	videoFilter = videoFilter.ScaleMaxSize(options.MaxDimensions)
+	if options.MaxHeight > 0 {
+		videoFilter = videoFilter.ScaleMaxDimensions(options.MaxDimensions, options.MaxHeight)
+	} else {
+		videoFilter = videoFilter.ScaleMaxSize(options.MaxDimensions)
+	}
 
 	var args ffmpeg.Args
 	args = append(args, "-hide_banner")
@@ -27,8 +42,14 @@ func ImageThumbnail(input string, options ImageThumbnailOptions) ffmpeg.Args {
 	args = args.Overwrite().
 		ImageFormat(options.InputFormat).
 		Input(input).
-		VideoFilter(videoFilter).
-		VideoCodec(ffmpeg.VideoCodecMJpeg)
+		VideoFilter(videoFilter)
+
+	codec := ffmpeg.VideoCodecMJpeg
+	if options.Codec != nil {
+		codec = *options.Codec
+	}
+	args = args.VideoCodec(codec)
+	args = append(args, options.CodecArgs...)
 
 	args = append(args, "-frames:v", "1")
 
@@ -36,7 +57,12 @@ func ImageThumbnail(input string, options ImageThumbnailOptions) ffmpeg.Args {
 		args = args.FixedQualityScaleVideo(options.Quality)
 	}
 
-	args = args.ImageFormat(ffmpeg.ImageFormatImage2Pipe).
+	muxFormat := ffmpeg.ImageFormatImage2Pipe
+	if options.MuxFormat != "" {
+		muxFormat = options.MuxFormat
+	}
+
+	args = args.ImageFormat(muxFormat).
 		Output(options.OutputPath).
 		ImageFormat(options.OutputFormat)
 
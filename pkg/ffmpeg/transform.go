@@ -0,0 +1,102 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VideoCrop is a region of the displayed video frame, in pixels.
+type VideoCrop struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// VideoTransform is a rotation, crop and flip of a video.
+// The crop is applied first, followed by the flips and then the rotation.
+type VideoTransform struct {
+	// Clockwise rotation in degrees. Must be a multiple of 90.
+	Rotate         int
+	Crop           *VideoCrop
+	FlipHorizontal bool
+	FlipVertical   bool
+}
+
+func (t VideoTransform) rotation() int {
+	return ((t.Rotate % 360) + 360) % 360
+}
+
+// IsIdentity returns true if the transform does not change the video.
+func (t VideoTransform) IsIdentity() bool {
+	return t.rotation() == 0 && t.Crop == nil && !t.FlipHorizontal && !t.FlipVertical
+}
+
+// Validate returns an error if the transform cannot be applied to a video
+// with the given display dimensions.
+func (t VideoTransform) Validate(width, height int) error {
+	if t.Rotate%90 != 0 {
+		return fmt.Errorf("rotation %d is not a multiple of 90 degrees", t.Rotate)
+	}
+
+	if c := t.Crop; c != nil {
+		if c.X < 0 || c.Y < 0 || c.Width <= 0 || c.Height <= 0 {
+			return errors.New("crop region must have a non-negative position and positive size")
+		}
+		if c.X+c.Width > width || c.Y+c.Height > height {
+			return fmt.Errorf("crop region %dx%d+%d+%d exceeds video dimensions %dx%d", c.Width, c.Height, c.X, c.Y, width, height)
+		}
+	}
+
+	if t.IsIdentity() {
+		return errors.New("transform does not change the video")
+	}
+
+	return nil
+}
+
+// Lossless returns true if the transform can be applied by changing the
+// display rotation of the video stream, without re-encoding.
+func (t VideoTransform) Lossless() bool {
+	return t.Crop == nil && !t.FlipHorizontal && !t.FlipVertical
+}
+
+// DisplayRotationArgs returns input arguments that set the display rotation
+// of the video stream, given its existing counter-clockwise display rotation.
+// The video must be stream copied. Requires ffmpeg 6.0 or later.
+func (t VideoTransform) DisplayRotationArgs(existing int64) Args {
+	ccw := ((int(existing)-t.rotation())%360 + 360) % 360
+	return Args{"-display_rotation", fmt.Sprint(ccw)}
+}
+
+// Transform returns a VideoFilter applying the transform. The crop is
+// rounded to even dimensions as required by most encoders.
+func (f VideoFilter) Transform(t VideoTransform) VideoFilter {
+	if c := t.Crop; c != nil {
+		f = f.Append(fmt.Sprintf("crop=%d:%d:%d:%d", c.Width&^1, c.Height&^1, c.X, c.Y))
+	}
+
+	if t.FlipHorizontal {
+		f = f.Append("hflip")
+	}
+	if t.FlipVertical {
+		f = f.Append("vflip")
+	}
+
+	switch t.rotation() {
+	case 90:
+		f = f.Append("transpose=clock")
+	case 180:
+		f = f.Append("hflip").Append("vflip")
+	case 270:
+		f = f.Append("transpose=cclock")
+	}
+
+	return f
+}
+
+// SupportsDisplayRotation returns true if ffmpeg can set the display
+// rotation of a stream when copying it.
+func (f *FFMpeg) SupportsDisplayRotation() bool {
+	return f.version.Gteq(Version{6, 0, 0})
+}
@@ -0,0 +1,79 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVideoTransform_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform VideoTransform
+		wantErr   bool
+	}{
+		{"rotate", VideoTransform{Rotate: 90}, false},
+		{"rotate negative", VideoTransform{Rotate: -90}, false},
+		{"invalid rotation", VideoTransform{Rotate: 45}, true},
+		{"identity", VideoTransform{Rotate: 360}, true},
+		{"flip", VideoTransform{FlipHorizontal: true}, false},
+		{"crop", VideoTransform{Crop: &VideoCrop{X: 10, Y: 10, Width: 100, Height: 100}}, false},
+		{"crop full frame", VideoTransform{Crop: &VideoCrop{Width: 640, Height: 480}}, false},
+		{"crop outside frame", VideoTransform{Crop: &VideoCrop{X: 600, Width: 100, Height: 100}}, true},
+		{"crop empty", VideoTransform{Crop: &VideoCrop{Width: 0, Height: 100}}, true},
+		{"crop negative", VideoTransform{Crop: &VideoCrop{X: -1, Width: 100, Height: 100}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transform.Validate(640, 480); (err != nil) != tt.wantErr {
+				t.Errorf("VideoTransform.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVideoTransform_DisplayRotationArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform VideoTransform
+		existing  int64
+		want      Args
+	}{
+		{"clockwise", VideoTransform{Rotate: 90}, 0, Args{"-display_rotation", "270"}},
+		{"counter-clockwise", VideoTransform{Rotate: 270}, 0, Args{"-display_rotation", "90"}},
+		{"existing rotation", VideoTransform{Rotate: 90}, -90, Args{"-display_rotation", "180"}},
+		{"undo rotation", VideoTransform{Rotate: -90}, -90, Args{"-display_rotation", "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transform.DisplayRotationArgs(tt.existing); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VideoTransform.DisplayRotationArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoFilter_Transform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform VideoTransform
+		want      VideoFilter
+	}{
+		{"rotate 90", VideoTransform{Rotate: 90}, "transpose=clock"},
+		{"rotate 180", VideoTransform{Rotate: 180}, "hflip,vflip"},
+		{"rotate -90", VideoTransform{Rotate: -90}, "transpose=cclock"},
+		{"flip", VideoTransform{FlipHorizontal: true, FlipVertical: true}, "hflip,vflip"},
+		{
+			"crop flip and rotate",
+			VideoTransform{Rotate: 90, FlipHorizontal: true, Crop: &VideoCrop{X: 10, Y: 20, Width: 101, Height: 50}},
+			"crop=100:50:10:20,hflip,transpose=clock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f VideoFilter
+			if got := f.Transform(tt.transform); got != tt.want {
+				t.Errorf("VideoFilter.Transform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,6 +25,10 @@ type FFProbeJSON struct {
 			MinorVersion     string        `json:"minor_version"`
 			Title            string        `json:"title"`
 			Comment          string        `json:"comment"`
+			// Date is the recording or release date, such as the QuickTime
+			// ©day atom or the Matroska DATE tag
+			Date                  string `json:"date"`
+			QuickTimeCreationDate string `json:"com.apple.quicktime.creationdate"`
 		} `json:"tags"`
 	} `json:"format"`
 	Streams []FFProbeStream `json:"streams"`
@@ -65,6 +69,7 @@ type FFProbeStream struct {
 	} `json:"disposition"`
 	Duration          string `json:"duration"`
 	DurationTs        int64  `json:"duration_ts"`
+	FieldOrder        string `json:"field_order,omitempty"`
 	HasBFrames        int    `json:"has_b_frames,omitempty"`
 	Height            int    `json:"height,omitempty"`
 	Index             int    `json:"index"`
@@ -85,6 +90,7 @@ type FFProbeStream struct {
 		HandlerName  string        `json:"handler_name"`
 		Language     string        `json:"language"`
 		Rotate       string        `json:"rotate"`
+		Title        string        `json:"title"`
 	} `json:"tags"`
 	TimeBase      string `json:"time_base"`
 	Width         int    `json:"width,omitempty"`
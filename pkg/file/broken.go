@@ -0,0 +1,76 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// BrokenReason is the reason a file is rejected as broken by the scan.
+type BrokenReason string
+
+const (
+	// BrokenReasonZeroByte is a file without content.
+	BrokenReasonZeroByte BrokenReason = "ZERO_BYTE"
+	// BrokenReasonTruncated is a file whose content ends early, such as an
+	// interrupted download or copy.
+	BrokenReasonTruncated BrokenReason = "TRUNCATED"
+	// BrokenReasonPlaceholder is a file which only reserves space for
+	// content, such as a preallocated download or a stub left by a sync
+	// client.
+	BrokenReasonPlaceholder BrokenReason = "PLACEHOLDER"
+)
+
+func (e BrokenReason) IsValid() bool {
+	switch e {
+	case BrokenReasonZeroByte, BrokenReasonTruncated, BrokenReasonPlaceholder:
+		return true
+	}
+	return false
+}
+
+func (e BrokenReason) String() string {
+	return string(e)
+}
+
+func (e *BrokenReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BrokenReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BrokenFileReason", str)
+	}
+	return nil
+}
+
+func (e BrokenReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// BrokenFileError is returned by a decorator for a file that is broken. The
+// file is not created, so that no objects are created for content which
+// cannot be played or viewed.
+type BrokenFileError struct {
+	Reason BrokenReason
+	// Detail describes the problem, such as the missing part of the file.
+	Detail string
+}
+
+func (e *BrokenFileError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("broken file: %s", e.Reason)
+	}
+	return fmt.Sprintf("broken file: %s: %s", e.Reason, e.Detail)
+}
+
+// BrokenFile is a file that was rejected as broken by a scan.
+type BrokenFile struct {
+	Path       string       `json:"path"`
+	Reason     BrokenReason `json:"reason"`
+	Detail     string       `json:"detail"`
+	DetectedAt time.Time    `json:"detected_at"`
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -38,6 +39,11 @@ type CleanOptions struct {
 	// PathFilter are used to determine if a file should be included.
 	// Excluded files are marked for cleaning.
 	PathFilter PathFilter
+
+	// SkipPaths are library paths that must not be cleaned, such as paths
+	// that are not reachable, where missing files cannot be distinguished
+	// from deleted files.
+	SkipPaths []string
 }
 
 // Clean starts the clean process.
@@ -329,9 +335,17 @@ func isNotFound(err error) bool {
 			errors.As(err, &pathErr))
 }
 
+func (j *cleanJob) isSkipped(path string) bool {
+	return fsutil.IsPathInDirs(j.options.SkipPaths, path)
+}
+
 func (j *cleanJob) shouldClean(ctx context.Context, f models.File) bool {
 	path := f.Base().Path
 
+	if j.isSkipped(path) {
+		return false
+	}
+
 	info, err := f.Base().Info(j.FS)
 	if err != nil && !isNotFound(err) {
 		logger.Errorf("error getting file info for %q, not cleaning: %v", path, err)
@@ -354,6 +368,10 @@ func (j *cleanJob) shouldClean(ctx context.Context, f models.File) bool {
 func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool {
 	path := f.Path
 
+	if j.isSkipped(path) {
+		return false
+	}
+
 	info, err := f.Info(j.FS)
 
 	if err != nil && !isNotFound(err) {
@@ -65,8 +65,17 @@ func newRenamerRemoverImpl() renamerRemoverImpl {
 // filesystem using the Complete method.
 type Deleter struct {
 	RenamerRemover RenamerRemover
-	files          []string
-	dirs           []string
+	// ReadOnly, if set, prevents marking files in read-only paths.
+	ReadOnly ReadOnlyChecker
+	// DryRun, if true, records the library files to be destroyed without
+	// marking anything for deletion.
+	DryRun bool
+
+	files []string
+	dirs  []string
+
+	destroyed     []string
+	destroyedSize int64
 }
 
 func NewDeleter() *Deleter {
@@ -93,6 +102,10 @@ func (d *Deleter) RegisterHooks(ctx context.Context) {
 // error.
 func (d *Deleter) Files(paths []string) error {
 	for _, p := range paths {
+		if err := checkWritable(d.ReadOnly, p); err != nil {
+			return err
+		}
+
 		// fail silently if the file does not exist
 		if _, err := d.RenamerRemover.Stat(p); err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
@@ -103,6 +116,10 @@ func (d *Deleter) Files(paths []string) error {
 			return fmt.Errorf("check file %q exists: %w", p, err)
 		}
 
+		if d.DryRun {
+			continue
+		}
+
 		if err := d.renameForDelete(p); err != nil {
 			return fmt.Errorf("marking file %q for deletion: %w", p, err)
 		}
@@ -119,6 +136,10 @@ func (d *Deleter) Files(paths []string) error {
 // error.
 func (d *Deleter) Dirs(paths []string) error {
 	for _, p := range paths {
+		if err := checkWritable(d.ReadOnly, p); err != nil {
+			return err
+		}
+
 		// fail silently if the file does not exist
 		if _, err := d.RenamerRemover.Stat(p); err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
@@ -129,6 +150,10 @@ func (d *Deleter) Dirs(paths []string) error {
 			return fmt.Errorf("check directory %q exists: %w", p, err)
 		}
 
+		if d.DryRun {
+			continue
+		}
+
 		if err := d.renameForDelete(p); err != nil {
 			return fmt.Errorf("marking directory %q for deletion: %w", p, err)
 		}
@@ -150,6 +175,8 @@ func (d *Deleter) Rollback() {
 
 	d.files = nil
 	d.dirs = nil
+	d.destroyed = nil
+	d.destroyedSize = 0
 }
 
 // Commit deletes all files marked for deletion and clears the marked list.
@@ -170,6 +197,25 @@ func (d *Deleter) Commit() {
 
 	d.files = nil
 	d.dirs = nil
+	d.destroyed = nil
+	d.destroyedSize = 0
+}
+
+// Destroyed returns the paths and total size of the library files marked for
+// deletion by Destroy and DestroyZip. Generated files are not included.
+func (d *Deleter) Destroyed() ([]string, int64) {
+	return d.destroyed, d.destroyedSize
+}
+
+// destroyFile marks a library file for deletion.
+func (d *Deleter) destroyFile(f models.File) error {
+	if err := d.Files([]string{f.Base().Path}); err != nil {
+		return err
+	}
+
+	d.destroyed = append(d.destroyed, f.Base().Path)
+	d.destroyedSize += f.Base().Size
+	return nil
 }
 
 func (d *Deleter) renameForDelete(path string) error {
@@ -187,7 +233,7 @@ func Destroy(ctx context.Context, destroyer models.FileDestroyer, f models.File,
 
 	// don't delete files in zip files
 	if deleteFile && f.Base().ZipFileID == nil {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.destroyFile(f); err != nil {
 			return err
 		}
 	}
@@ -230,7 +276,7 @@ func (d *ZipDestroyer) DestroyZip(ctx context.Context, f models.File, fileDelete
 	}
 
 	if deleteFile {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.destroyFile(f); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,115 @@
+package file
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidDeleteConfirmation = errors.New("invalid or expired delete confirmation token")
+	ErrDeleteConfirmationChanged = errors.New("files to be deleted have changed since the confirmation token was issued")
+)
+
+// DeleteConfirmation is a pending file deletion awaiting confirmation.
+type DeleteConfirmation struct {
+	Token     string
+	FileCount int
+	TotalSize int64
+	ExpiresAt time.Time
+}
+
+type pendingDeletion struct {
+	fingerprint string
+	expiresAt   time.Time
+}
+
+// DeleteConfirmer issues tokens for pending file deletions. A token is only
+// valid for the same set of files, and expires after Timeout.
+type DeleteConfirmer struct {
+	Timeout time.Duration
+
+	mutex   sync.Mutex
+	pending map[string]pendingDeletion
+	now     func() time.Time
+}
+
+func NewDeleteConfirmer(timeout time.Duration) *DeleteConfirmer {
+	return &DeleteConfirmer{
+		Timeout: timeout,
+		pending: make(map[string]pendingDeletion),
+		now:     time.Now,
+	}
+}
+
+// Issue returns a confirmation token for deleting the provided files.
+func (c *DeleteConfirmer) Issue(paths []string, totalSize int64) (*DeleteConfirmation, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeExpired()
+
+	ret := &DeleteConfirmation{
+		Token:     hex.EncodeToString(b),
+		FileCount: len(paths),
+		TotalSize: totalSize,
+		ExpiresAt: c.now().Add(c.Timeout),
+	}
+
+	c.pending[ret.Token] = pendingDeletion{
+		fingerprint: deletionFingerprint(paths),
+		expiresAt:   ret.ExpiresAt,
+	}
+
+	return ret, nil
+}
+
+// Confirm consumes the token, returning an error if the token is unknown,
+// has expired, or was issued for a different set of files.
+func (c *DeleteConfirmer) Confirm(token string, paths []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeExpired()
+
+	p, found := c.pending[token]
+	if !found {
+		return ErrInvalidDeleteConfirmation
+	}
+
+	delete(c.pending, token)
+
+	if p.fingerprint != deletionFingerprint(paths) {
+		return ErrDeleteConfirmationChanged
+	}
+
+	return nil
+}
+
+func (c *DeleteConfirmer) removeExpired() {
+	now := c.now()
+	for k, v := range c.pending {
+		if !now.Before(v.expiresAt) {
+			delete(c.pending, k)
+		}
+	}
+}
+
+func deletionFingerprint(paths []string) string {
+	sorted := make([]string, len(paths))
+	copy(sorted, paths)
+	sort.Strings(sorted)
+
+	h := sha256.Sum256([]byte(strings.Join(sorted, "\x00")))
+	return hex.EncodeToString(h[:])
+}
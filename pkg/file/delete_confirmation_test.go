@@ -0,0 +1,79 @@
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestDeleteConfirmer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewDeleteConfirmer(time.Minute)
+	c.now = func() time.Time { return now }
+
+	paths := []string{"/a.mp4", "/b.mp4"}
+
+	issue := func() string {
+		t.Helper()
+		dc, err := c.Issue(paths, 100)
+		if err != nil {
+			t.Fatalf("DeleteConfirmer.Issue() error = %v", err)
+		}
+		if dc.FileCount != 2 || dc.TotalSize != 100 || !dc.ExpiresAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("DeleteConfirmer.Issue() = %+v", dc)
+		}
+		return dc.Token
+	}
+
+	// order of paths is not significant
+	token := issue()
+	if err := c.Confirm(token, []string{"/b.mp4", "/a.mp4"}); err != nil {
+		t.Errorf("DeleteConfirmer.Confirm() error = %v", err)
+	}
+
+	// tokens can only be used once
+	if err := c.Confirm(token, paths); !errors.Is(err, ErrInvalidDeleteConfirmation) {
+		t.Errorf("DeleteConfirmer.Confirm() reused error = %v, want %v", err, ErrInvalidDeleteConfirmation)
+	}
+
+	token = issue()
+	if err := c.Confirm(token, []string{"/a.mp4", "/c.mp4"}); !errors.Is(err, ErrDeleteConfirmationChanged) {
+		t.Errorf("DeleteConfirmer.Confirm() changed error = %v, want %v", err, ErrDeleteConfirmationChanged)
+	}
+
+	token = issue()
+	now = now.Add(time.Minute)
+	if err := c.Confirm(token, paths); !errors.Is(err, ErrInvalidDeleteConfirmation) {
+		t.Errorf("DeleteConfirmer.Confirm() expired error = %v, want %v", err, ErrInvalidDeleteConfirmation)
+	}
+}
+
+func TestDeleter_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.mp4")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &models.BaseFile{Path: path, Size: 10}
+
+	d := NewDeleter()
+	d.DryRun = true
+	if err := d.destroyFile(f); err != nil {
+		t.Fatalf("Deleter.destroyFile() error = %v", err)
+	}
+
+	paths, size := d.Destroyed()
+	if len(paths) != 1 || paths[0] != path || size != 10 {
+		t.Errorf("Deleter.Destroyed() = %v, %d", paths, size)
+	}
+
+	d.Commit()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file was deleted in dry run: %v", err)
+	}
+}
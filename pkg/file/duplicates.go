@@ -0,0 +1,98 @@
+package file
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ExactDuplicateFinder provides the methods needed to find files with
+// identical fingerprints.
+type ExactDuplicateFinder interface {
+	models.FileGetter
+	FindExactDuplicates(ctx context.Context, fingerprintType string) ([][]models.FileID, error)
+}
+
+// ExactDuplicateGroup is a group of files that share the same exact fingerprint.
+type ExactDuplicateGroup struct {
+	Fingerprint models.Fingerprint
+	// Files in the group. The first file is the primary file, which is the
+	// file that is kept when removing duplicates.
+	Files []models.File
+}
+
+// Primary returns the file that should be kept for the group.
+func (g ExactDuplicateGroup) Primary() models.File {
+	if len(g.Files) == 0 {
+		return nil
+	}
+
+	return g.Files[0]
+}
+
+// Duplicates returns all files in the group except the primary file.
+func (g ExactDuplicateGroup) Duplicates() []models.File {
+	if len(g.Files) < 2 {
+		return nil
+	}
+
+	return g.Files[1:]
+}
+
+// ReclaimableSize returns the total size of the files that would be deleted
+// if all but the primary file were removed.
+func (g ExactDuplicateGroup) ReclaimableSize() int64 {
+	var ret int64
+	for _, f := range g.Duplicates() {
+		ret += f.Base().Size
+	}
+
+	return ret
+}
+
+// IsExactFingerprintType returns true if the fingerprint type identifies
+// files by their exact contents.
+func IsExactFingerprintType(fingerprintType string) bool {
+	return fingerprintType == models.FingerprintTypeMD5 || fingerprintType == models.FingerprintTypeOshash
+}
+
+// FindExactDuplicates returns groups of files that share the same fingerprint
+// of the given type. The fingerprint type must be md5 or oshash.
+//
+// Files within each group keep the order returned by r, which is expected to
+// place the file to keep first.
+func FindExactDuplicates(ctx context.Context, r ExactDuplicateFinder, fingerprintType string) ([]ExactDuplicateGroup, error) {
+	if !IsExactFingerprintType(fingerprintType) {
+		return nil, fmt.Errorf("invalid fingerprint type for exact duplicate detection: %s", fingerprintType)
+	}
+
+	groups, err := r.FindExactDuplicates(ctx, fingerprintType)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []ExactDuplicateGroup
+	for _, ids := range groups {
+		files, err := r.Find(ctx, ids...)
+		if err != nil {
+			return nil, fmt.Errorf("finding duplicate files: %w", err)
+		}
+
+		if len(files) < 2 {
+			continue
+		}
+
+		fp := files[0].Base().Fingerprints.For(fingerprintType)
+		if fp == nil {
+			continue
+		}
+
+		ret = append(ret, ExactDuplicateGroup{
+			Fingerprint: *fp,
+			Files:       files,
+		})
+	}
+
+	return ret, nil
+}
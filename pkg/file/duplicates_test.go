@@ -0,0 +1,82 @@
+package file
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDuplicateFinder struct {
+	files  map[models.FileID]models.File
+	groups [][]models.FileID
+}
+
+func (f *testDuplicateFinder) Find(ctx context.Context, ids ...models.FileID) ([]models.File, error) {
+	var ret []models.File
+	for _, id := range ids {
+		ret = append(ret, f.files[id])
+	}
+	return ret, nil
+}
+
+func (f *testDuplicateFinder) FindExactDuplicates(ctx context.Context, fingerprintType string) ([][]models.FileID, error) {
+	return f.groups, nil
+}
+
+func makeDuplicateTestFile(id models.FileID, size int64, oshash string) models.File {
+	return &models.BaseFile{
+		ID:   id,
+		Size: size,
+		Fingerprints: models.Fingerprints{
+			{
+				Type:        models.FingerprintTypeOshash,
+				Fingerprint: oshash,
+			},
+		},
+	}
+}
+
+func TestFindExactDuplicates(t *testing.T) {
+	files := map[models.FileID]models.File{
+		1: makeDuplicateTestFile(1, 10, "a"),
+		2: makeDuplicateTestFile(2, 10, "a"),
+		3: makeDuplicateTestFile(3, 20, "b"),
+		4: makeDuplicateTestFile(4, 20, "b"),
+		5: makeDuplicateTestFile(5, 20, "b"),
+	}
+
+	r := &testDuplicateFinder{
+		files: files,
+		groups: [][]models.FileID{
+			{4, 3, 5},
+			{2, 1},
+			// single file groups are ignored
+			{1},
+		},
+	}
+
+	t.Run("invalid fingerprint type", func(t *testing.T) {
+		_, err := FindExactDuplicates(context.Background(), r, models.FingerprintTypePhash)
+		assert.Error(t, err)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		assert := assert.New(t)
+
+		got, err := FindExactDuplicates(context.Background(), r, models.FingerprintTypeOshash)
+		if !assert.NoError(err) || !assert.Len(got, 2) {
+			return
+		}
+
+		assert.Equal("b", got[0].Fingerprint.Value())
+		assert.Equal([]models.File{files[4], files[3], files[5]}, got[0].Files)
+		assert.Equal(files[4], got[0].Primary())
+		assert.Equal(int64(40), got[0].ReclaimableSize())
+
+		assert.Equal("a", got[1].Fingerprint.Value())
+		assert.Equal([]models.File{files[2], files[1]}, got[1].Files)
+		assert.Equal(int64(10), got[1].ReclaimableSize())
+	})
+}
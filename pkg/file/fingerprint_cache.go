@@ -0,0 +1,98 @@
+package file
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FingerprintCacheKey identifies the contents of a file on disk. A file with
+// the same device, inode, size and modification time is assumed to have
+// unchanged contents.
+type FingerprintCacheKey struct {
+	Device  uint64 `json:"device"`
+	Inode   uint64 `json:"inode"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+}
+
+// NewFingerprintCacheKey returns the cache key of the file. Returns false if
+// the file system does not provide the device and inode of the file.
+func NewFingerprintCacheKey(info fs.FileInfo) (FingerprintCacheKey, bool) {
+	device, inode, ok := fileIdentity(info)
+	if !ok {
+		return FingerprintCacheKey{}, false
+	}
+
+	return FingerprintCacheKey{
+		Device:  device,
+		Inode:   inode,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+	}, true
+}
+
+// FingerprintCacheEntry is a cached set of fingerprints.
+type FingerprintCacheEntry struct {
+	Key          FingerprintCacheKey `json:"key"`
+	Fingerprints models.Fingerprints `json:"fingerprints"`
+}
+
+// FingerprintCache holds the fingerprints calculated for files, so that
+// unchanged files are not hashed again when rescanned. It is safe for
+// concurrent use.
+type FingerprintCache struct {
+	mutex   sync.RWMutex
+	entries map[FingerprintCacheKey]models.Fingerprints
+}
+
+// NewFingerprintCache creates a new, empty FingerprintCache.
+func NewFingerprintCache() *FingerprintCache {
+	return &FingerprintCache{
+		entries: make(map[FingerprintCacheKey]models.Fingerprints),
+	}
+}
+
+// Get returns the cached fingerprints of the file with the given key.
+func (c *FingerprintCache) Get(key FingerprintCacheKey) (models.Fingerprints, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	fp, found := c.entries[key]
+	return fp, found
+}
+
+// Set caches the fingerprints of the file with the given key.
+func (c *FingerprintCache) Set(key FingerprintCacheKey, fp models.Fingerprints) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = fp
+}
+
+// Entries returns the contents of the cache.
+func (c *FingerprintCache) Entries() []FingerprintCacheEntry {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	ret := make([]FingerprintCacheEntry, 0, len(c.entries))
+	for k, fp := range c.entries {
+		ret = append(ret, FingerprintCacheEntry{
+			Key:          k,
+			Fingerprints: fp,
+		})
+	}
+
+	return ret
+}
+
+// Load adds the entries to the cache.
+func (c *FingerprintCache) Load(entries []FingerprintCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, e := range entries {
+		c.entries[e.Key] = e.Fingerprints
+	}
+}
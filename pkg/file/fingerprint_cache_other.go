@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package file
+
+import (
+	"io/fs"
+)
+
+// fileIdentity is not supported on this platform, so fingerprints are not
+// cached.
+func fileIdentity(info fs.FileInfo) (device uint64, inode uint64, ok bool) {
+	return 0, 0, false
+}
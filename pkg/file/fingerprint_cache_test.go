@@ -0,0 +1,64 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintCacheKey(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(fn, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stat := func() FingerprintCacheKey {
+		info, err := os.Stat(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, ok := NewFingerprintCacheKey(info)
+		assert.True(t, ok)
+		return key
+	}
+
+	key := stat()
+	assert.Equal(t, int64(7), key.Size)
+	assert.Equal(t, key, stat(), "unchanged file has the same key")
+
+	modTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(fn, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, key, stat(), "modified file has a different key")
+}
+
+func TestFingerprintCache(t *testing.T) {
+	c := NewFingerprintCache()
+	key := FingerprintCacheKey{Device: 1, Inode: 2, Size: 3, ModTime: 4}
+	fp := models.Fingerprints{
+		{Type: models.FingerprintTypeOshash, Fingerprint: "abc"},
+	}
+
+	_, found := c.Get(key)
+	assert.False(t, found)
+
+	c.Set(key, fp)
+	got, found := c.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, fp, got)
+
+	loaded := NewFingerprintCache()
+	loaded.Load(c.Entries())
+	got, found = loaded.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, fp, got)
+}
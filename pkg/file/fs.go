@@ -65,3 +65,35 @@ func (f *OsFS) OpenZip(name string, size int64) (models.ZipFS, error) {
 func (f *OsFS) IsPathCaseSensitive(path string) (bool, error) {
 	return fsutil.IsFsPathCaseSensitive(path)
 }
+
+// ProbePath returns the path or URL that external programs such as ffprobe
+// can use to read the file at path in fs. Returns false if the file cannot be
+// read by external programs.
+func ProbePath(fs models.FS, path string) (string, bool) {
+	if IsOsFS(fs) {
+		return path, true
+	}
+
+	if p, ok := fs.(interface {
+		ProbePath(path string) (string, bool)
+	}); ok {
+		return p.ProbePath(path)
+	}
+
+	return "", false
+}
+
+// IsOsFS returns true if fs reads files directly from the OS file system,
+// so that file paths can be passed to external programs such as ffprobe.
+func IsOsFS(fs models.FS) bool {
+	for {
+		switch f := fs.(type) {
+		case *OsFS:
+			return true
+		case interface{ Unwrap() models.FS }:
+			fs = f.Unwrap()
+		default:
+			return false
+		}
+	}
+}
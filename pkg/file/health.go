@@ -0,0 +1,159 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// PathHealth is the result of checking whether a library path is reachable.
+type PathHealth struct {
+	Path   string `json:"path"`
+	Online bool   `json:"online"`
+	// Error is the reason the path is offline.
+	Error string `json:"error"`
+	// CheckedAt is nil if the path has not been checked.
+	CheckedAt *time.Time `json:"checkedAt"`
+}
+
+// CheckPath returns an error if the directory at path cannot be listed within
+// the timeout. Listing the directory, rather than only calling stat, detects
+// network mounts that are still mounted but whose server is unreachable.
+func CheckPath(path string, timeout time.Duration) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- listDir(path)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+func listDir(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// HealthMonitor tracks whether library paths are reachable, so that a
+// dropped network mount is not mistaken for deleted files. It is safe for
+// concurrent use.
+type HealthMonitor struct {
+	Timeout time.Duration
+
+	mutex  sync.RWMutex
+	status map[string]PathHealth
+}
+
+func NewHealthMonitor(timeout time.Duration) *HealthMonitor {
+	return &HealthMonitor{
+		Timeout: timeout,
+		status:  make(map[string]PathHealth),
+	}
+}
+
+// Check checks the given paths concurrently and returns the results in the
+// same order. The results of paths that are not in the list are forgotten.
+func (m *HealthMonitor) Check(paths []string) []PathHealth {
+	ret := make([]PathHealth, len(paths))
+
+	var wg sync.WaitGroup
+	for i, p := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			now := time.Now()
+			h := PathHealth{
+				Path:      p,
+				Online:    true,
+				CheckedAt: &now,
+			}
+			if err := CheckPath(p, m.Timeout); err != nil {
+				h.Online = false
+				h.Error = err.Error()
+			}
+			ret[i] = h
+		}()
+	}
+	wg.Wait()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.status = make(map[string]PathHealth, len(ret))
+	for _, h := range ret {
+		m.status[h.Path] = h
+	}
+
+	return ret
+}
+
+// Status returns the last result for each of the given paths. Paths that have
+// not been checked are assumed to be online.
+func (m *HealthMonitor) Status(paths []string) []PathHealth {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ret := make([]PathHealth, len(paths))
+	for i, p := range paths {
+		h, found := m.status[p]
+		if !found {
+			h = PathHealth{
+				Path:   p,
+				Online: true,
+			}
+		}
+		ret[i] = h
+	}
+
+	return ret
+}
+
+// IsOnline returns false if the path is within a checked path that was
+// offline when last checked.
+func (m *HealthMonitor) IsOnline(path string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for p, h := range m.status {
+		if !h.Online && fsutil.IsPathInDir(p, path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OfflinePaths returns the checked paths that were offline when last checked.
+func (m *HealthMonitor) OfflinePaths() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var ret []string
+	for p, h := range m.status {
+		if !h.Online {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,45 @@
+package file
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHealthMonitor(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing")
+
+	m := NewHealthMonitor(time.Second)
+
+	// unchecked paths are assumed to be online
+	if !m.IsOnline(filepath.Join(missing, "file.mp4")) {
+		t.Errorf("IsOnline() = false before check, want true")
+	}
+
+	got := m.Check([]string{dir, missing})
+	if !got[0].Online {
+		t.Errorf("Check() %s online = false, want true: %s", dir, got[0].Error)
+	}
+	if got[1].Online || got[1].Error == "" {
+		t.Errorf("Check() %s online = true, want false with error", missing)
+	}
+
+	if m.IsOnline(filepath.Join(missing, "file.mp4")) {
+		t.Errorf("IsOnline() = true for file in offline path, want false")
+	}
+	if !m.IsOnline(filepath.Join(dir, "file.mp4")) {
+		t.Errorf("IsOnline() = false for file in online path, want true")
+	}
+
+	offline := m.OfflinePaths()
+	if len(offline) != 1 || offline[0] != missing {
+		t.Errorf("OfflinePaths() = %v, want [%s]", offline, missing)
+	}
+
+	// paths that are no longer checked are forgotten
+	m.Check([]string{dir})
+	if len(m.OfflinePaths()) != 0 {
+		t.Errorf("OfflinePaths() = %v after recheck, want none", m.OfflinePaths())
+	}
+}
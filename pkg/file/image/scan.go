@@ -25,14 +25,15 @@ type Decorator struct {
 func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (models.File, error) {
 	base := f.Base()
 
-	// ignore clips in non-OsFS filesystems as ffprobe cannot read them
-	// TODO - copy to temp file if not an OsFS
-	if _, isOs := fs.(*file.OsFS); !isOs {
+	// ignore clips in filesystems that ffprobe cannot read
+	// TODO - copy to temp file if ffprobe cannot read the file system
+	probePath, ok := file.ProbePath(fs, base.Path)
+	if !ok {
 		logger.Debugf("assuming ImageFile for non-OsFS file %q", base.Path)
 		return decorateFallback(fs, f)
 	}
 
-	probe, err := d.FFProbe.NewVideoFile(base.Path)
+	probe, err := d.FFProbe.NewVideoFile(probePath)
 	if err != nil {
 		logger.Warnf("File %q could not be read with ffprobe: %s, assuming ImageFile", base.Path, err)
 		return decorateFallback(fs, f)
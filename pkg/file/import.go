@@ -69,6 +69,8 @@ func (i *Importer) fileJSONToFile(ctx context.Context, fileJSON jsonschema.DirEn
 			Duration:         ff.Duration,
 			VideoCodec:       ff.VideoCodec,
 			AudioCodec:       ff.AudioCodec,
+			AudioLanguages:   ff.AudioLanguages,
+			Interlaced:       ff.Interlaced,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
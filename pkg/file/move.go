@@ -42,6 +42,8 @@ type Mover struct {
 	Renamer DirMakerStatRenamer
 	Files   models.FileFinderUpdater
 	Folders models.FolderReaderWriter
+	// ReadOnly, if set, prevents moving files from or to read-only paths.
+	ReadOnly ReadOnlyChecker
 
 	moved          map[string]string
 	foldersCreated []string
@@ -81,8 +83,15 @@ func (m *Mover) Move(ctx context.Context, f models.File, folder *models.Folder,
 		return nil
 	}
 
-	// ensure that the new path doesn't already exist
 	newPath := filepath.Join(folder.Path, basename)
+	if err := checkWritable(m.ReadOnly, oldPath); err != nil {
+		return err
+	}
+	if err := checkWritable(m.ReadOnly, newPath); err != nil {
+		return err
+	}
+
+	// ensure that the new path doesn't already exist
 	if _, err := m.Renamer.Stat(newPath); !errors.Is(err, fs.ErrNotExist) {
 		return fmt.Errorf("file %s already exists", newPath)
 	}
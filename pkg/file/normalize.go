@@ -0,0 +1,247 @@
+package file
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// TransliterationScheme is how non-ASCII characters in filenames are
+// replaced when normalizing filenames.
+type TransliterationScheme string
+
+const (
+	// TransliterationNone leaves non-ASCII characters as they are.
+	TransliterationNone TransliterationScheme = "NONE"
+	// TransliterationLatin removes diacritics and replaces Cyrillic letters
+	// with Latin letters. Characters of other scripts are left as they are.
+	TransliterationLatin TransliterationScheme = "LATIN"
+	// TransliterationASCII transliterates as TransliterationLatin, then
+	// removes any remaining non-ASCII characters.
+	TransliterationASCII TransliterationScheme = "ASCII"
+)
+
+var AllTransliterationScheme = []TransliterationScheme{
+	TransliterationNone,
+	TransliterationLatin,
+	TransliterationASCII,
+}
+
+func (e TransliterationScheme) IsValid() bool {
+	switch e {
+	case TransliterationNone, TransliterationLatin, TransliterationASCII:
+		return true
+	}
+	return false
+}
+
+func (e TransliterationScheme) String() string {
+	return string(e)
+}
+
+func (e *TransliterationScheme) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransliterationScheme(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransliterationScheme", str)
+	}
+	return nil
+}
+
+func (e TransliterationScheme) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// TargetFilesystem is the filesystem that normalized filenames must be
+// valid on.
+type TargetFilesystem string
+
+const (
+	// TargetFilesystemPOSIX allows any character other than the path
+	// separator, and names of up to 255 bytes.
+	TargetFilesystemPOSIX TargetFilesystem = "POSIX"
+	// TargetFilesystemNTFS disallows the characters <>:"/\|?*, trailing dots
+	// and spaces, and reserved device names such as CON and NUL. Names may
+	// be up to 255 UTF-16 code units.
+	TargetFilesystemNTFS TargetFilesystem = "NTFS"
+	// TargetFilesystemFAT has the rules of TargetFilesystemNTFS, and also
+	// disallows characters outside of the Basic Multilingual Plane, such as
+	// emoji, which many devices reading FAT32 and exFAT drives cannot handle.
+	TargetFilesystemFAT TargetFilesystem = "FAT"
+)
+
+var AllTargetFilesystem = []TargetFilesystem{
+	TargetFilesystemPOSIX,
+	TargetFilesystemNTFS,
+	TargetFilesystemFAT,
+}
+
+func (e TargetFilesystem) IsValid() bool {
+	switch e {
+	case TargetFilesystemPOSIX, TargetFilesystemNTFS, TargetFilesystemFAT:
+		return true
+	}
+	return false
+}
+
+func (e TargetFilesystem) String() string {
+	return string(e)
+}
+
+func (e *TargetFilesystem) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TargetFilesystem(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TargetFilesystem", str)
+	}
+	return nil
+}
+
+func (e TargetFilesystem) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// windows is true if the target has the Windows filename rules.
+func (e TargetFilesystem) windows() bool {
+	return e == TargetFilesystemNTFS || e == TargetFilesystemFAT
+}
+
+// maxNameLength is the maximum length of a name on all target filesystems,
+// in bytes on POSIX and in UTF-16 code units otherwise.
+const maxNameLength = 255
+
+// NormalizeOptions are the options used to normalize filenames. The zero
+// value only removes characters that are illegal on POSIX filesystems.
+type NormalizeOptions struct {
+	Scheme     TransliterationScheme
+	Filesystem TargetFilesystem
+}
+
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// NormalizeBasename returns the basename transliterated and with characters
+// that are illegal on the target filesystem removed. A repeated extension,
+// such as in "video.mp4.mp4", is removed. If nothing is left of the name
+// other than the extension, a short hash of the original name is used.
+func NormalizeBasename(basename string, o NormalizeOptions) string {
+	name := basename
+	switch o.Scheme {
+	case TransliterationLatin:
+		name = utils.Transliterate(name)
+	case TransliterationASCII:
+		name = utils.Transliterate(name)
+		name = strings.Map(func(r rune) rune {
+			if r > unicode.MaxASCII {
+				return -1
+			}
+			return r
+		}, name)
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		if !o.legalRune(r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	stem = trimRepeatedExt(stem, ext)
+
+	// collapse the whitespace left by removed characters
+	stem = strings.Join(strings.Fields(stem), " ")
+	if o.Filesystem.windows() {
+		stem = strings.TrimRight(stem, ". ")
+		if windowsReservedNames[strings.ToUpper(stem)] {
+			stem += "_"
+		}
+	}
+
+	if stem == "" {
+		hash := sha1.Sum([]byte(basename))
+		stem = hex.EncodeToString(hash[:4])
+	}
+
+	return o.truncate(stem, ext) + ext
+}
+
+// legalRune returns false if r is illegal, or is a control character that
+// is problematic, on the target filesystem.
+func (o NormalizeOptions) legalRune(r rune) bool {
+	if r == '/' || unicode.IsControl(r) || r == utf8.RuneError {
+		return false
+	}
+
+	if o.Filesystem.windows() && strings.ContainsRune(`<>:"\|?*`, r) {
+		return false
+	}
+
+	if o.Filesystem == TargetFilesystemFAT && r > 0xFFFF {
+		return false
+	}
+
+	return true
+}
+
+// trimRepeatedExt removes any trailing copies of ext from stem, ignoring
+// case.
+func trimRepeatedExt(stem string, ext string) string {
+	if ext == "" {
+		return stem
+	}
+
+	for len(stem) > len(ext) && strings.EqualFold(filepath.Ext(stem), ext) {
+		stem = stem[:len(stem)-len(ext)]
+	}
+
+	return stem
+}
+
+// truncate shortens stem, so that the name with ext fits the maximum name
+// length of the target filesystem.
+func (o NormalizeOptions) truncate(stem string, ext string) string {
+	length := func(s string) int {
+		if o.Filesystem.windows() {
+			return len(utf16.Encode([]rune(s)))
+		}
+		return len(s)
+	}
+
+	for stem != "" && length(stem)+length(ext) > maxNameLength {
+		_, size := utf8.DecodeLastRuneInString(stem)
+		stem = stem[:len(stem)-size]
+	}
+
+	if o.Filesystem.windows() {
+		stem = strings.TrimRight(stem, ". ")
+	}
+
+	return stem
+}
@@ -0,0 +1,65 @@
+package file
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeBasename(t *testing.T) {
+	var (
+		posix = NormalizeOptions{Scheme: TransliterationNone, Filesystem: TargetFilesystemPOSIX}
+		latin = NormalizeOptions{Scheme: TransliterationLatin, Filesystem: TargetFilesystemPOSIX}
+		ascii = NormalizeOptions{Scheme: TransliterationASCII, Filesystem: TargetFilesystemPOSIX}
+		ntfs  = NormalizeOptions{Scheme: TransliterationNone, Filesystem: TargetFilesystemNTFS}
+		fat   = NormalizeOptions{Scheme: TransliterationASCII, Filesystem: TargetFilesystemFAT}
+	)
+
+	tests := []struct {
+		name     string
+		basename string
+		o        NormalizeOptions
+		want     string
+	}{
+		{"unchanged", "Scene 01.mp4", posix, "Scene 01.mp4"},
+		{"control characters", "Scene\t01\x00.mp4", posix, "Scene 01.mp4"},
+		{"posix keeps colon", "Part: One.mp4", posix, "Part: One.mp4"},
+		{"ntfs removes colon", "Part: One.mp4", ntfs, "Part One.mp4"},
+		{"ntfs illegal characters", `a<b>c"d\e|f?g*.mp4`, ntfs, "abcdefg.mp4"},
+		{"ntfs trailing dots", "Scene... .mp4", ntfs, "Scene.mp4"},
+		{"ntfs reserved name", "con.mp4", ntfs, "con_.mp4"},
+		{"ntfs keeps emoji", "Scene 🎬.mp4", ntfs, "Scene 🎬.mp4"},
+		{"fat removes emoji", "Scene 🎬.mp4", fat, "Scene.mp4"},
+		{"latin", "Zoë Łukasz - Жанна.mp4", latin, "Zoe Lukasz - Zhanna.mp4"},
+		{"latin keeps other scripts", "伏字.mp4", latin, "伏字.mp4"},
+		{"ascii", "Zoë 伏字.mp4", ascii, "Zoe.mp4"},
+		{"ascii empty name", "伏字.mp4", ascii, "07aba576.mp4"},
+		{"double extension", "Scene.mp4.mp4", posix, "Scene.mp4"},
+		{"double extension case", "Scene.MP4.mp4", posix, "Scene.mp4"},
+		{"triple extension", "Scene.mkv.mkv.mkv", posix, "Scene.mkv"},
+		{"different extensions", "Scene.mkv.mp4", posix, "Scene.mkv.mp4"},
+		{"no extension", "Scene", posix, "Scene"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeBasename(tt.basename, tt.o); got != tt.want {
+				t.Errorf("NormalizeBasename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBasename_truncate(t *testing.T) {
+	// each letter is two bytes, but one UTF-16 code unit
+	long := strings.Repeat("ж", 200) + ".mp4"
+
+	got := NormalizeBasename(long, NormalizeOptions{Filesystem: TargetFilesystemPOSIX})
+	if len(got) > maxNameLength || !strings.HasSuffix(got, ".mp4") || !utf8.ValidString(got) {
+		t.Errorf("POSIX name %q is not truncated to %d bytes", got, maxNameLength)
+	}
+
+	got = NormalizeBasename(long, NormalizeOptions{Filesystem: TargetFilesystemNTFS})
+	if got != long {
+		t.Errorf("NTFS name %q should not be truncated", got)
+	}
+}
@@ -0,0 +1,26 @@
+package file
+
+import "fmt"
+
+// ReadOnlyChecker reports whether a path is in a read-only library path.
+type ReadOnlyChecker interface {
+	IsReadOnlyPath(path string) bool
+}
+
+// ReadOnlyPathError is returned when attempting to modify a file in a
+// read-only library path.
+type ReadOnlyPathError struct {
+	Path string
+}
+
+func (e *ReadOnlyPathError) Error() string {
+	return fmt.Sprintf("%s is in a read-only library path", e.Path)
+}
+
+func checkWritable(c ReadOnlyChecker, path string) error {
+	if c != nil && c.IsReadOnlyPath(path) {
+		return &ReadOnlyPathError{Path: path}
+	}
+
+	return nil
+}
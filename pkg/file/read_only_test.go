@@ -0,0 +1,54 @@
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type prefixReadOnlyChecker string
+
+func (c prefixReadOnlyChecker) IsReadOnlyPath(path string) bool {
+	return strings.HasPrefix(path, string(c))
+}
+
+func TestDeleter_ReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	readOnlyDir := filepath.Join(dir, "archive")
+	if err := os.Mkdir(readOnlyDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writable := filepath.Join(dir, "file.mp4")
+	readOnly := filepath.Join(readOnlyDir, "file.mp4")
+	for _, p := range []string{writable, readOnly} {
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewDeleter()
+	d.ReadOnly = prefixReadOnlyChecker(readOnlyDir)
+
+	var readOnlyErr *ReadOnlyPathError
+	if err := d.Files([]string{readOnly}); !errors.As(err, &readOnlyErr) {
+		t.Errorf("Deleter.Files() error = %v, want ReadOnlyPathError", err)
+	}
+	if err := d.Dirs([]string{readOnlyDir}); !errors.As(err, &readOnlyErr) {
+		t.Errorf("Deleter.Dirs() error = %v, want ReadOnlyPathError", err)
+	}
+
+	if err := d.Files([]string{writable}); err != nil {
+		t.Errorf("Deleter.Files() error = %v", err)
+	}
+	d.Commit()
+
+	if _, err := os.Stat(writable); !os.IsNotExist(err) {
+		t.Errorf("writable file was not deleted")
+	}
+	if _, err := os.Stat(readOnly); err != nil {
+		t.Errorf("read-only file was deleted: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBackoff  = 500 * time.Millisecond
+)
+
+// transientErrors are errors that network file systems return while a mount
+// is briefly unavailable.
+var transientErrors = []error{
+	syscall.EIO,
+	syscall.EAGAIN,
+	syscall.ETIMEDOUT,
+	syscall.ESTALE,
+	syscall.ECONNRESET,
+	syscall.EHOSTDOWN,
+	syscall.EHOSTUNREACH,
+	syscall.ENETDOWN,
+	syscall.ENETUNREACH,
+	os.ErrDeadlineExceeded,
+}
+
+// IsTransientError returns true if err may succeed if the operation is
+// retried.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, e := range transientErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryFS is a file system that retries operations that fail with transient
+// IO errors, waiting longer after each failed attempt. Sequential reads from
+// opened files are retried by reopening the file at the same offset.
+type RetryFS struct {
+	models.FS
+
+	// Attempts is the maximum number of attempts of each operation.
+	Attempts int
+	// Backoff is the wait before the first retry. It doubles with each retry.
+	Backoff time.Duration
+}
+
+// NewRetryFS returns a RetryFS using the default number of attempts and
+// backoff.
+func NewRetryFS(fs models.FS) *RetryFS {
+	return &RetryFS{
+		FS:       fs,
+		Attempts: defaultRetryAttempts,
+		Backoff:  defaultRetryBackoff,
+	}
+}
+
+// Unwrap returns the wrapped file system.
+func (f *RetryFS) Unwrap() models.FS {
+	return f.FS
+}
+
+func (f *RetryFS) retry(name string, fn func() error) error {
+	backoff := f.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if !IsTransientError(err) || attempt >= f.Attempts {
+			return err
+		}
+
+		logger.Debugf("Retrying %q in %s after error: %v", name, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (f *RetryFS) Stat(name string) (fs.FileInfo, error) {
+	var ret fs.FileInfo
+	err := f.retry(name, func() error {
+		var err error
+		ret, err = f.FS.Stat(name)
+		return err
+	})
+	return ret, err
+}
+
+func (f *RetryFS) Lstat(name string) (fs.FileInfo, error) {
+	var ret fs.FileInfo
+	err := f.retry(name, func() error {
+		var err error
+		ret, err = f.FS.Lstat(name)
+		return err
+	})
+	return ret, err
+}
+
+func (f *RetryFS) Open(name string) (fs.ReadDirFile, error) {
+	file, err := f.open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &retryFile{
+		ReadDirFile: file,
+		fs:          f,
+		name:        name,
+	}, nil
+}
+
+func (f *RetryFS) open(name string) (fs.ReadDirFile, error) {
+	var ret fs.ReadDirFile
+	err := f.retry(name, func() error {
+		var err error
+		ret, err = f.FS.Open(name)
+		return err
+	})
+	return ret, err
+}
+
+func (f *RetryFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return newZipFS(f, name, size)
+}
+
+// retryFile is a file that reopens itself when a read fails with a transient
+// error.
+type retryFile struct {
+	fs.ReadDirFile
+	fs     *RetryFS
+	name   string
+	offset int64
+}
+
+var errNotSeeker = errors.New("not a Seeker")
+
+// reopen replaces the underlying file with a newly opened one, positioned at
+// the current offset.
+func (f *retryFile) reopen() error {
+	file, err := f.fs.FS.Open(f.name)
+	if err != nil {
+		return err
+	}
+
+	if f.offset > 0 {
+		seeker, ok := file.(io.Seeker)
+		if !ok {
+			file.Close()
+			return errNotSeeker
+		}
+
+		if _, err := seeker.Seek(f.offset, io.SeekStart); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	f.ReadDirFile.Close()
+	f.ReadDirFile = file
+	return nil
+}
+
+func (f *retryFile) Read(p []byte) (int, error) {
+	var n int
+	reopen := false
+	err := f.fs.retry(f.name, func() error {
+		if reopen {
+			if err := f.reopen(); err != nil {
+				return err
+			}
+		}
+		reopen = true
+
+		var err error
+		n, err = f.ReadDirFile.Read(p)
+		f.offset += int64(n)
+		if n > 0 && IsTransientError(err) {
+			// return the data that was read, the next read will retry
+			err = nil
+		}
+		return err
+	})
+	return n, err
+}
+
+func (f *retryFile) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := f.ReadDirFile.(io.Seeker)
+	if !ok {
+		return 0, errNotSeeker
+	}
+
+	ret, err := seeker.Seek(offset, whence)
+	if err == nil {
+		f.offset = ret
+	}
+	return ret, err
+}
+
+// ReadAt retries on the same file rather than reopening it, since zip
+// entries are read concurrently using ReadAt.
+func (f *retryFile) ReadAt(p []byte, off int64) (int, error) {
+	readerAt, ok := f.ReadDirFile.(io.ReaderAt)
+	if !ok {
+		return 0, errNotReaderAt
+	}
+
+	var n int
+	err := f.fs.retry(f.name, func() error {
+		var err error
+		n, err = readerAt.ReadAt(p, off)
+		return err
+	})
+	return n, err
+}
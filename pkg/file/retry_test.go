@@ -0,0 +1,93 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// flakyFS fails the first failures calls to Open with a transient error.
+type flakyFS struct {
+	OsFS
+	failures int
+	opened   int
+}
+
+func (f *flakyFS) Open(name string) (fs.ReadDirFile, error) {
+	f.opened++
+	if f.failures > 0 {
+		f.failures--
+		return nil, &fs.PathError{Op: "open", Path: name, Err: syscall.EIO}
+	}
+
+	return f.OsFS.Open(name)
+}
+
+func TestRetryFS_Open(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "file.mp4")
+	if err := os.WriteFile(fn, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		wantErr  bool
+	}{
+		{"no failures", 0, false},
+		{"transient failures", 2, false},
+		{"too many failures", 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyFS{failures: tt.failures}
+			f := &RetryFS{
+				FS:       flaky,
+				Attempts: 3,
+			}
+
+			file, err := f.Open(fn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if flaky.opened != f.Attempts {
+					t.Errorf("Open() attempts = %d, want %d", flaky.opened, f.Attempts)
+				}
+				return
+			}
+			defer file.Close()
+
+			data, err := io.ReadAll(file)
+			if err != nil || string(data) != "content" {
+				t.Errorf("ReadAll() = %q, %v, want %q", data, err, "content")
+			}
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	if !IsTransientError(&fs.PathError{Op: "read", Path: "x", Err: syscall.ESTALE}) {
+		t.Errorf("IsTransientError(ESTALE) = false, want true")
+	}
+	if IsTransientError(fs.ErrNotExist) {
+		t.Errorf("IsTransientError(ErrNotExist) = true, want false")
+	}
+	if IsTransientError(errors.New("other")) {
+		t.Errorf("IsTransientError(other) = true, want false")
+	}
+}
+
+func TestIsOsFS(t *testing.T) {
+	if !IsOsFS(NewRetryFS(&OsFS{})) {
+		t.Errorf("IsOsFS(RetryFS(OsFS)) = false, want true")
+	}
+	if IsOsFS(NewRetryFS(&flakyFS{})) {
+		t.Errorf("IsOsFS(RetryFS(flakyFS)) = true, want false")
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -23,6 +25,9 @@ const (
 	// maximum number of times to retry in the event of a locked database
 	// use -1 to retry forever
 	maxRetries = -1
+	// maximum number of files in a single zip file that are read
+	// concurrently
+	maxZipEntryReaders = 4
 )
 
 // Scanner scans files into the database.
@@ -30,7 +35,8 @@ const (
 // The scan process works using two goroutines. The first walks through the provided paths
 // in the filesystem. It runs each directory entry through the provided ScanFilters. If none
 // of the filter Accept methods return true, then the file/directory is ignored.
-// Any folders found are handled immediately. Files inside zip files are also handled immediately.
+// Any folders found are handled immediately. Files inside zip files are also handled immediately,
+// streamed from the zip file by a limited number of concurrent readers.
 // All other files encountered are sent to the second goroutine queue.
 //
 // Folders are handled by checking if the folder exists in the database, by its full path.
@@ -54,6 +60,10 @@ type Scanner struct {
 	FS                    models.FS
 	Repository            Repository
 	FingerprintCalculator FingerprintCalculator
+	// FingerprintCache, if set, holds the fingerprints of files keyed by
+	// their device, inode, size and modification time, so that unchanged
+	// files are not hashed again when rescanned.
+	FingerprintCache *FingerprintCache
 
 	// FileDecorators are applied to files as they are scanned.
 	FileDecorators []Decorator
@@ -115,6 +125,7 @@ type scanJob struct {
 	folderPathToID sync.Map
 	zipPathToID    sync.Map
 	count          int
+	walked         int
 
 	txnRetryer txn.Retryer
 }
@@ -137,6 +148,22 @@ type ScanOptions struct {
 
 	// When true files in path will be rescanned even if they haven't changed
 	Rescan bool
+
+	// When true fingerprints are always calculated from the file contents,
+	// ignoring the fingerprint cache. Used to verify file integrity.
+	BypassFingerprintCache bool
+
+	// Cursor, if set, tracks the files that have been processed in walk
+	// order. Files up to the path the cursor resumes after are skipped,
+	// so that an interrupted scan can be resumed.
+	Cursor *ScanCursor
+
+	// StageTimer, if set, records the throughput of the stages of the scan.
+	StageTimer *job.StageTimer
+
+	// OnBrokenFile, if set, is called for files which are rejected by a
+	// decorator as broken.
+	OnBrokenFile func(path string, err *BrokenFileError)
 }
 
 // Scan starts the scanning process.
@@ -159,6 +186,8 @@ type scanFile struct {
 	*models.BaseFile
 	fs   models.FS
 	info fs.FileInfo
+	// index of the file in walk order. Only set for queued files.
+	index int
 }
 
 func (s *scanJob) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -174,6 +203,10 @@ func (s *scanJob) execute(ctx context.Context) {
 	logger.Infof("scanning %d paths", len(paths))
 	s.startTime = time.Now()
 
+	if s.options.Cursor != nil && s.options.Cursor.resumeAfter != "" {
+		logger.Infof("resuming scan after %q", s.options.Cursor.resumeAfter)
+	}
+
 	s.fileQueue = make(chan scanFile, scanQueueSize)
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -225,7 +258,7 @@ func (s *scanJob) queueFiles(ctx context.Context, paths []string) error {
 	return err
 }
 
-func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zipFile *scanFile) fs.WalkDirFunc {
+func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zs *zipScan) fs.WalkDirFunc {
 	return func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			// don't let errors prevent scanning
@@ -268,13 +301,13 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zipFile *scanF
 			info: info,
 		}
 
-		if zipFile != nil {
-			zipFileID, err := s.getZipFileID(ctx, zipFile)
+		if zs != nil {
+			zipFileID, err := s.getZipFileID(ctx, zs.file)
 			if err != nil {
 				return err
 			}
 			ff.ZipFileID = zipFileID
-			ff.ZipFile = zipFile
+			ff.ZipFile = zs.file
 		}
 
 		if info.IsDir() {
@@ -292,19 +325,18 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zipFile *scanF
 		}
 
 		// if zip file is present, we handle immediately
-		if zipFile != nil {
-			s.ProgressReports.ExecuteTask("Scanning "+path, func() {
-				if err := s.handleFile(ctx, ff); err != nil {
-					if !errors.Is(err, context.Canceled) {
-						logger.Errorf("error processing %q: %v", path, err)
-					}
-					// don't return an error, just skip the file
-				}
-			})
+		if zs != nil {
+			s.scanZipEntry(ctx, zs, ff)
+			return nil
+		}
 
+		if s.options.Cursor != nil && s.options.Cursor.scanned(s.options.Paths, path) {
 			return nil
 		}
 
+		ff.index = s.walked
+		s.walked++
+
 		s.fileQueue <- ff
 
 		s.count++
@@ -354,7 +386,59 @@ func (s *scanJob) scanZipFile(ctx context.Context, f scanFile) error {
 
 	defer zipFS.Close()
 
-	return symWalk(zipFS, f.Path, s.queueFileFunc(ctx, zipFS, &f))
+	zs := &zipScan{
+		file:    &f,
+		readers: sizedwaitgroup.New(maxZipEntryReaders),
+		total:   countZipEntries(zipFS, f.Path),
+	}
+
+	err = symWalk(zipFS, f.Path, s.queueFileFunc(ctx, zipFS, zs))
+
+	// wait for the entries being read before closing the zip file
+	zs.readers.Wait()
+
+	return err
+}
+
+// zipScan is the state of the scan of the contents of a zip file.
+type zipScan struct {
+	file    *scanFile
+	readers sizedwaitgroup.SizedWaitGroup
+	// total number of files in the zip file
+	total   int
+	started atomic.Int32
+}
+
+func countZipEntries(zipFS models.FS, path string) int {
+	ret := 0
+	_ = symWalk(zipFS, path, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			ret++
+		}
+		return nil
+	})
+
+	return ret
+}
+
+// scanZipEntry handles a file in a zip file, waiting until one of the zip
+// file's readers is available. The file is streamed from the zip file.
+func (s *scanJob) scanZipEntry(ctx context.Context, zs *zipScan, f scanFile) {
+	zs.readers.Add()
+
+	n := zs.started.Add(1)
+	description := fmt.Sprintf("Scanning %s (%d/%d in %s)", f.Path, n, zs.total, filepath.Base(zs.file.Path))
+
+	go func() {
+		defer zs.readers.Done()
+
+		s.ProgressReports.ExecuteTask(description, func() {
+			if err := s.handleFile(ctx, f); err != nil {
+				s.fileError(f.Path, err)
+				// don't return an error, just skip the file
+			}
+		})
+	}()
 }
 
 func (s *scanJob) processQueue(ctx context.Context) error {
@@ -420,7 +504,28 @@ func (s *scanJob) incrementProgress(f scanFile) {
 	}
 }
 
+// fileError logs an error processing the file. Broken files are passed to
+// the OnBrokenFile callback, since they are expected in a library.
+func (s *scanJob) fileError(path string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	var brokenErr *BrokenFileError
+	if errors.As(err, &brokenErr) {
+		logger.Warnf("skipping %q: %v", path, brokenErr)
+		if s.options.OnBrokenFile != nil {
+			s.options.OnBrokenFile(path, brokenErr)
+		}
+		return
+	}
+
+	logger.Errorf("error processing %q: %v", path, err)
+}
+
 func (s *scanJob) processQueueItem(ctx context.Context, f scanFile) {
+	defer s.options.StageTimer.Start("file")()
+
 	s.ProgressReports.ExecuteTask("Scanning "+f.Path, func() {
 		var err error
 		if f.info.IsDir() {
@@ -429,10 +534,15 @@ func (s *scanJob) processQueueItem(ctx context.Context, f scanFile) {
 			err = s.handleFile(ctx, f)
 		}
 
-		if err != nil && !errors.Is(err, context.Canceled) {
-			logger.Errorf("error processing %q: %v", f.Path, err)
+		if err != nil {
+			s.fileError(f.Path, err)
 		}
 	})
+
+	// don't mark files as done if they were interrupted
+	if s.options.Cursor != nil && ctx.Err() == nil {
+		s.options.Cursor.markDone(f.index, f.Path)
+	}
 }
 
 func (s *scanJob) getFolderID(ctx context.Context, path string) (*models.FolderID, error) {
@@ -724,7 +834,7 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 	baseFile.ParentFolderID = *parentFolderID
 
 	const useExisting = false
-	fp, err := s.calculateFingerprints(f.fs, baseFile, path, useExisting)
+	fp, err := s.calculateFingerprints(f.fs, baseFile, f.info, path, useExisting)
 	if err != nil {
 		return nil, err
 	}
@@ -769,6 +879,8 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 }
 
 func (s *scanJob) fireDecorators(ctx context.Context, fs models.FS, f models.File) (models.File, error) {
+	defer s.options.StageTimer.Start("decorate")()
+
 	for _, h := range s.FileDecorators {
 		var err error
 		f, err = h.Decorate(ctx, fs, f)
@@ -781,6 +893,8 @@ func (s *scanJob) fireDecorators(ctx context.Context, fs models.FS, f models.Fil
 }
 
 func (s *scanJob) fireHandlers(ctx context.Context, f models.File, oldFile models.File) error {
+	defer s.options.StageTimer.Start("handle")()
+
 	for _, h := range s.handlers {
 		if err := h.Handle(ctx, f, oldFile); err != nil {
 			return err
@@ -790,7 +904,33 @@ func (s *scanJob) fireHandlers(ctx context.Context, f models.File, oldFile model
 	return nil
 }
 
-func (s *scanJob) calculateFingerprints(fs models.FS, f *models.BaseFile, path string, useExisting bool) (models.Fingerprints, error) {
+func (s *scanJob) calculateFingerprints(fsys models.FS, f *models.BaseFile, info fs.FileInfo, path string, useExisting bool) (models.Fingerprints, error) {
+	defer s.options.StageTimer.Start("fingerprint")()
+
+	cache := s.FingerprintCache
+	if s.options.BypassFingerprintCache {
+		cache = nil
+	}
+
+	var (
+		cacheKey  FingerprintCacheKey
+		cacheable bool
+	)
+	if cache != nil && info != nil {
+		cacheKey, cacheable = NewFingerprintCacheKey(info)
+	}
+
+	if cacheable && !useExisting {
+		if cached, found := cache.Get(cacheKey); found {
+			// only calculate the fingerprints missing from the cache
+			logger.Debugf("Using cached fingerprints for %s", path)
+			withCached := *f
+			withCached.Fingerprints = cached
+			f = &withCached
+			useExisting = true
+		}
+	}
+
 	// only log if we're (re)calculating fingerprints
 	if !useExisting {
 		logger.Infof("Calculating fingerprints for %s ...", path)
@@ -798,13 +938,17 @@ func (s *scanJob) calculateFingerprints(fs models.FS, f *models.BaseFile, path s
 
 	// calculate primary fingerprint for the file
 	fp, err := s.FingerprintCalculator.CalculateFingerprints(f, &fsOpener{
-		fs:   fs,
+		fs:   fsys,
 		name: path,
 	}, useExisting)
 	if err != nil {
 		return nil, fmt.Errorf("calculating fingerprint for file %q: %w", path, err)
 	}
 
+	if cacheable {
+		cache.Set(cacheKey, fp)
+	}
+
 	return fp, nil
 }
 
@@ -997,7 +1141,7 @@ func (s *scanJob) setMissingMetadata(ctx context.Context, f scanFile, existing m
 
 func (s *scanJob) setMissingFingerprints(ctx context.Context, f scanFile, existing models.File) (models.File, error) {
 	const useExisting = true
-	fp, err := s.calculateFingerprints(f.fs, existing.Base(), f.Path, useExisting)
+	fp, err := s.calculateFingerprints(f.fs, existing.Base(), f.info, f.Path, useExisting)
 	if err != nil {
 		return nil, err
 	}
@@ -1046,7 +1190,7 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 
 	// calculate and update fingerprints for the file
 	const useExisting = false
-	fp, err := s.calculateFingerprints(f.fs, base, path, useExisting)
+	fp, err := s.calculateFingerprints(f.fs, base, f.info, path, useExisting)
 	if err != nil {
 		return nil, err
 	}
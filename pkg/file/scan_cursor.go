@@ -0,0 +1,104 @@
+package file
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// ScanCursor tracks the files that have been scanned in walk order, where
+// files may be scanned out of order. It records the path of the last file
+// before which all files were scanned, so that an interrupted scan can be
+// resumed after that path even if files were added or removed in the
+// meantime. It is safe for concurrent use.
+type ScanCursor struct {
+	mutex       sync.Mutex
+	resumeAfter string
+	path        string
+	next        int
+	done        map[int]string
+}
+
+// NewScanCursor returns a cursor for a scan which resumes after the file
+// with the given path. An empty path scans all files.
+func NewScanCursor(resumeAfter string) *ScanCursor {
+	return &ScanCursor{
+		resumeAfter: resumeAfter,
+		path:        resumeAfter,
+		done:        make(map[int]string),
+	}
+}
+
+// Path returns the path of the last file in walk order before which all
+// files were scanned. Returns the path the scan resumed after if no file has
+// been scanned since.
+func (c *ScanCursor) Path() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.path
+}
+
+// markDone marks the queued file with the given zero-based index as scanned.
+func (c *ScanCursor) markDone(index int, path string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if index < c.next {
+		return
+	}
+
+	c.done[index] = path
+	for {
+		p, ok := c.done[c.next]
+		if !ok {
+			break
+		}
+
+		delete(c.done, c.next)
+		c.path = p
+		c.next++
+	}
+}
+
+// scanned returns true if the file with the given path is walked at or
+// before the path the scan resumes after, when walking roots.
+func (c *ScanCursor) scanned(roots []string, path string) bool {
+	if c.resumeAfter == "" {
+		return false
+	}
+
+	return compareWalkOrder(roots, path, c.resumeAfter) <= 0
+}
+
+// compareWalkOrder compares paths a and b in the order in which they are
+// walked when scanning roots. Roots are walked in order, and directory
+// entries are walked sorted by name, so paths within the same root are
+// ordered by their path components.
+func compareWalkOrder(roots []string, a, b string) int {
+	ra, rb := rootIndex(roots, a), rootIndex(roots, b)
+	if ra != rb {
+		return ra - rb
+	}
+
+	return slices.Compare(splitPath(a), splitPath(b))
+}
+
+// rootIndex returns the index of the first root containing path, or the
+// number of roots if no root contains it.
+func rootIndex(roots []string, path string) int {
+	for i, root := range roots {
+		if fsutil.IsPathInDir(root, path) {
+			return i
+		}
+	}
+
+	return len(roots)
+}
+
+func splitPath(path string) []string {
+	return strings.Split(filepath.Clean(path), string(filepath.Separator))
+}
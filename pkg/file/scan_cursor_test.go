@@ -0,0 +1,92 @@
+package file
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanCursor(t *testing.T) {
+	c := NewScanCursor("/a/1.mp4")
+	assert := assert.New(t)
+
+	// out of order files do not advance the cursor
+	c.markDone(2, "/a/4.mp4")
+	c.markDone(1, "/a/3.mp4")
+	assert.Equal("/a/1.mp4", c.Path())
+
+	c.markDone(0, "/a/2.mp4")
+	assert.Equal("/a/4.mp4", c.Path())
+
+	// files may be completed more than once
+	c.markDone(2, "/a/4.mp4")
+	c.markDone(3, "/a/5.mp4")
+	assert.Equal("/a/5.mp4", c.Path())
+}
+
+func TestScanCursor_scanned(t *testing.T) {
+	roots := []string{"/b", "/a"}
+	assert := assert.New(t)
+
+	assert.False(NewScanCursor("").scanned(roots, "/b/1.mp4"))
+
+	c := NewScanCursor("/a/x/2.mp4")
+	assert.True(c.scanned(roots, "/b/9.mp4"))
+	assert.True(c.scanned(roots, "/a/x/1.mp4"))
+	assert.True(c.scanned(roots, "/a/x/2.mp4"))
+	assert.False(c.scanned(roots, "/a/x/3.mp4"))
+	assert.False(c.scanned(roots, "/a/x-y/1.mp4"))
+	assert.True(c.scanned(roots, "/a/1.mp4"))
+}
+
+func TestCompareWalkOrder(t *testing.T) {
+	root := t.TempDir()
+	roots := []string{filepath.Join(root, "b"), filepath.Join(root, "a")}
+
+	for _, p := range []string{
+		"a/x/1.mp4",
+		"a/x-y/1.mp4",
+		"a/x.mp4",
+		"a/x y.mp4",
+		"a/X.mp4",
+		"b/z/1.mp4",
+		"b/1.mp4",
+	} {
+		fn := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fn, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var walked []string
+	for _, r := range roots {
+		if err := symWalk(&OsFS{}, r, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				walked = append(walked, path)
+			}
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := range walked {
+		for j := range walked {
+			got := compareWalkOrder(roots, walked[i], walked[j])
+			switch {
+			case i < j:
+				assert.Negative(t, got, "%s before %s", walked[i], walked[j])
+			case i > j:
+				assert.Positive(t, got, "%s after %s", walked[i], walked[j])
+			default:
+				assert.Zero(t, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package video
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// integrityChunkSize is the size of the start and end of a file which
+	// are read to check that it has content.
+	integrityChunkSize = 1 << 20
+	// minVideoSize is the size below which a file cannot be a video.
+	minVideoSize = 1024
+	// maxBoxes is the maximum number of top-level boxes of an mp4 file
+	// that are read, so that a corrupt file cannot be read forever.
+	maxBoxes = 10000
+)
+
+// CheckIntegrity returns a *file.BrokenFileError if the video file of the
+// size is empty, truncated or a placeholder. The start and end of the file
+// are read, and the top-level boxes of mp4 and mov files are walked to check
+// that the moov box is present and that no box extends past the end of the
+// file. Other formats are not parsed.
+func CheckIntegrity(r io.ReaderAt, size int64) error {
+	if size == 0 {
+		return &file.BrokenFileError{Reason: file.BrokenReasonZeroByte}
+	}
+
+	head, err := readChunk(r, 0, min(size, integrityChunkSize))
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: "unexpected end of file"}
+	}
+
+	if isZero(head) {
+		return &file.BrokenFileError{Reason: file.BrokenReasonPlaceholder, Detail: "the start of the file is empty"}
+	}
+
+	if size < minVideoSize {
+		return &file.BrokenFileError{Reason: file.BrokenReasonPlaceholder, Detail: fmt.Sprintf("the file is only %d bytes", size)}
+	}
+
+	if size > integrityChunkSize {
+		tail, err := readChunk(r, size-integrityChunkSize, integrityChunkSize)
+		if err != nil {
+			return err
+		}
+		if tail == nil {
+			return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: "unexpected end of file"}
+		}
+
+		// space preallocated by an incomplete download
+		if isZero(tail) {
+			return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: "the end of the file is empty"}
+		}
+	}
+
+	if isMP4(head) {
+		return checkBoxes(r, size)
+	}
+
+	return nil
+}
+
+// readChunk reads length bytes from offset. Returns nil if the file ends
+// before length bytes are read.
+func readChunk(r io.ReaderAt, offset int64, length int64) ([]byte, error) {
+	buf := make([]byte, length)
+	n, err := r.ReadAt(buf, offset)
+	if int64(n) < length {
+		if err == nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// mp4StartBoxes are the types of the first box of mp4 and QuickTime files.
+var mp4StartBoxes = [][]byte{
+	[]byte("ftyp"),
+	[]byte("moov"),
+	[]byte("mdat"),
+	[]byte("free"),
+	[]byte("wide"),
+}
+
+func isMP4(head []byte) bool {
+	if len(head) < 8 {
+		return false
+	}
+
+	for _, t := range mp4StartBoxes {
+		if bytes.Equal(head[4:8], t) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBoxes walks the top-level boxes of an mp4 or QuickTime file. Walking
+// stops once the moov and mdat boxes have been found, so that data
+// appended after them is ignored.
+func checkBoxes(r io.ReaderAt, size int64) error {
+	var hasMoov, hasMdat bool
+	offset := int64(0)
+
+	for i := 0; i < maxBoxes && offset < size && !(hasMoov && hasMdat); i++ {
+		header, err := readChunk(r, offset, min(16, size-offset))
+		if err != nil {
+			return err
+		}
+		if header == nil || len(header) < 8 {
+			return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: fmt.Sprintf("incomplete box at offset %d", offset)}
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+
+		switch boxSize {
+		case 0:
+			// the box extends to the end of the file
+			boxSize = size - offset
+		case 1:
+			if len(header) < 16 {
+				return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: fmt.Sprintf("incomplete %s box at offset %d", boxType, offset)}
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		if boxSize < headerSize {
+			return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: fmt.Sprintf("invalid size of %s box at offset %d", boxType, offset)}
+		}
+
+		if boxSize > size-offset {
+			return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: fmt.Sprintf("%s box at offset %d ends %d bytes after the end of the file", boxType, offset, boxSize-(size-offset))}
+		}
+
+		switch boxType {
+		case "moov":
+			hasMoov = true
+		case "mdat":
+			hasMdat = true
+		}
+
+		offset += boxSize
+	}
+
+	if !hasMoov {
+		return &file.BrokenFileError{Reason: file.BrokenReasonTruncated, Detail: "moov box missing"}
+	}
+
+	return nil
+}
+
+// checkFileIntegrity checks the integrity of the file, if it can be read at
+// random offsets. Otherwise only empty files are detected.
+func checkFileIntegrity(fs models.FS, f *models.BaseFile) error {
+	if f.Size == 0 {
+		return &file.BrokenFileError{Reason: file.BrokenReasonZeroByte}
+	}
+
+	rc, err := fs.Open(f.Path)
+	if err != nil {
+		return fmt.Errorf("opening %q: %w", f.Path, err)
+	}
+	defer rc.Close()
+
+	r, ok := rc.(io.ReaderAt)
+	if !ok {
+		return nil
+	}
+
+	return CheckIntegrity(r, f.Size)
+}
@@ -0,0 +1,68 @@
+package video
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func box(boxType string, size int, content byte) []byte {
+	ret := make([]byte, size)
+	binary.BigEndian.PutUint32(ret, uint32(size))
+	copy(ret[4:], boxType)
+	for i := 8; i < size; i++ {
+		ret[i] = content
+	}
+	return ret
+}
+
+func mp4(boxes ...[]byte) []byte {
+	return bytes.Join(boxes, nil)
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	valid := mp4(box("ftyp", 32, 1), box("moov", 4096, 2), box("mdat", 2*integrityChunkSize, 3))
+
+	tests := []struct {
+		name string
+		data []byte
+		size int64
+		want file.BrokenReason
+	}{
+		{"valid", valid, 0, ""},
+		{"moov at end", mp4(box("ftyp", 32, 1), box("mdat", 4096, 3), box("moov", 4096, 2)), 0, ""},
+		{"trailing data", append(append([]byte{}, valid...), 0xff, 0xff, 0xff, 0xff, 0xff), 0, ""},
+		{"not mp4", bytes.Repeat([]byte{0x1a, 0x45}, 4096), 0, ""},
+		{"zero byte", nil, 0, file.BrokenReasonZeroByte},
+		{"empty start", make([]byte, 4096), 0, file.BrokenReasonPlaceholder},
+		{"tiny", []byte("version https://git-lfs"), 0, file.BrokenReasonPlaceholder},
+		{"empty end", append(box("ftyp", 32, 1), make([]byte, 2*integrityChunkSize)...), 0, file.BrokenReasonTruncated},
+		{"moov missing", mp4(box("ftyp", 32, 1), box("mdat", 4096, 3)), 0, file.BrokenReasonTruncated},
+		{"cut mdat", valid[:len(valid)-4096], 0, file.BrokenReasonTruncated},
+		{"shorter than size", valid, int64(len(valid) + 10), file.BrokenReasonTruncated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.data))
+			}
+
+			err := CheckIntegrity(bytes.NewReader(tt.data), size)
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var brokenErr *file.BrokenFileError
+			if assert.True(t, errors.As(err, &brokenErr), "expected broken file error, got %v", err) {
+				assert.Equal(t, tt.want, brokenErr.Reason)
+			}
+		})
+	}
+}
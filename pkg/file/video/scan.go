@@ -21,18 +21,26 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 	}
 
 	base := f.Base()
-	// TODO - copy to temp file if not an OsFS
-	if _, isOs := fs.(*file.OsFS); !isOs {
+
+	// reject broken files before they are probed, so that no scene is
+	// created for them
+	if err := checkFileIntegrity(fs, base); err != nil {
+		return f, err
+	}
+
+	// TODO - copy to temp file if ffprobe cannot read the file system
+	probePath, ok := file.ProbePath(fs, base.Path)
+	if !ok {
 		return f, fmt.Errorf("video.constructFile: only OsFS is supported")
 	}
 
 	probe := d.FFProbe
-	videoFile, err := probe.NewVideoFile(base.Path)
+	videoFile, err := probe.NewVideoFile(probePath)
 	if err != nil {
 		return f, fmt.Errorf("running ffprobe on %q: %w", base.Path, err)
 	}
 
-	container, err := ffmpeg.MatchContainer(videoFile.Container, base.Path)
+	container, err := matchContainer(fs, videoFile.Container, base.Path)
 	if err != nil {
 		return f, fmt.Errorf("matching container for %q: %w", base.Path, err)
 	}
@@ -44,19 +52,35 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 	}
 
 	return &models.VideoFile{
-		BaseFile:    base,
-		Format:      string(container),
-		VideoCodec:  videoFile.VideoCodec,
-		AudioCodec:  videoFile.AudioCodec,
-		Width:       videoFile.Width,
-		Height:      videoFile.Height,
-		Duration:    videoFile.FileDuration,
-		FrameRate:   videoFile.FrameRate,
-		BitRate:     videoFile.Bitrate,
-		Interactive: interactive,
+		BaseFile:       base,
+		Format:         string(container),
+		VideoCodec:     videoFile.VideoCodec,
+		AudioCodec:     videoFile.AudioCodec,
+		AudioLanguages: videoFile.AudioLanguages(),
+		Interlaced:     videoFile.Interlaced(),
+		Width:          videoFile.Width,
+		Height:         videoFile.Height,
+		Duration:       videoFile.FileDuration,
+		FrameRate:      videoFile.FrameRate,
+		BitRate:        videoFile.Bitrate,
+		Interactive:    interactive,
 	}, nil
 }
 
+func matchContainer(fs models.FS, format string, path string) (ffmpeg.Container, error) {
+	if file.IsOsFS(fs) {
+		return ffmpeg.MatchContainer(format, path)
+	}
+
+	r, err := fs.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	return ffmpeg.MatchContainerReader(format, r)
+}
+
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f models.File) bool {
 	const (
 		unsetString = "unset"
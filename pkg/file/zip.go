@@ -29,6 +29,11 @@ type zipFS struct {
 	zipPath       string
 }
 
+// OpenZip opens the zip file at path in fs as a file system.
+func OpenZip(fs models.FS, path string, size int64) (models.ZipFS, error) {
+	return newZipFS(fs, path, size)
+}
+
 func newZipFS(fs models.FS, path string, size int64) (*zipFS, error) {
 	reader, err := fs.Open(path)
 	if err != nil {
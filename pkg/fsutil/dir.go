@@ -21,6 +21,18 @@ func DirExists(path string) (bool, error) {
 	return true, nil
 }
 
+// IsDirWritable returns nil if a file can be created in the given directory.
+func IsDirWritable(path string) error {
+	f, err := os.CreateTemp(path, ".stash-write-test-*")
+	if err != nil {
+		return err
+	}
+
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
 // IsPathInDir returns true if pathToCheck is within dir.
 func IsPathInDir(dir, pathToCheck string) bool {
 	rel, err := filepath.Rel(dir, pathToCheck)
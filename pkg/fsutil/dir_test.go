@@ -77,3 +77,17 @@ func TestDirExists(t *testing.T) {
 		}
 	}
 }
+
+func TestIsDirWritable(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir := t.TempDir()
+	assert.NoError(IsDirWritable(tmpDir))
+
+	// the test file is removed
+	entries, err := os.ReadDir(tmpDir)
+	assert.NoError(err)
+	assert.Empty(entries)
+
+	assert.Error(IsDirWritable(filepath.Join(tmpDir, "missing")))
+}
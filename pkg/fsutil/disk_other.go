@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !windows
+// +build !linux,!darwin,!freebsd,!windows
+
+package fsutil
+
+import (
+	"errors"
+)
+
+// FreeSpace is not supported on this platform and always returns an error.
+func FreeSpace(path string) (uint64, error) {
+	return 0, errors.New("free space detection is not supported on this platform")
+}
@@ -0,0 +1,61 @@
+package fsutil
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// FileLocks tracks files that are being written or replaced by a task, such
+// as a video conversion, so that other tasks, such as scanning, can leave
+// them alone until they are released. It is safe for concurrent use.
+type FileLocks struct {
+	mutex sync.Mutex
+	locks map[string]int
+}
+
+// NewFileLocks creates a new FileLocks.
+func NewFileLocks() *FileLocks {
+	return &FileLocks{
+		locks: make(map[string]int),
+	}
+}
+
+// Lock marks the files as in use, and returns a function that releases
+// them. A file may be locked more than once, and remains locked until all of
+// its locks are released.
+func (l *FileLocks) Lock(paths ...string) (release func()) {
+	cleaned := make([]string, len(paths))
+	for i, p := range paths {
+		cleaned[i] = filepath.Clean(p)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, p := range cleaned {
+		l.locks[p]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			for _, p := range cleaned {
+				l.locks[p]--
+				if l.locks[p] <= 0 {
+					delete(l.locks, p)
+				}
+			}
+		})
+	}
+}
+
+// IsLocked returns true if the file is in use.
+func (l *FileLocks) IsLocked(path string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.locks[filepath.Clean(path)] > 0
+}
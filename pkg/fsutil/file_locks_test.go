@@ -0,0 +1,34 @@
+package fsutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLocks(t *testing.T) {
+	l := NewFileLocks()
+
+	const (
+		a = "/stash/a.mp4"
+		b = "/stash/b.mp4"
+	)
+
+	assert.False(t, l.IsLocked(a))
+
+	release1 := l.Lock(a, b)
+	assert.True(t, l.IsLocked(a))
+	assert.True(t, l.IsLocked("/stash/./b.mp4"))
+
+	release2 := l.Lock(a)
+	release1()
+	assert.True(t, l.IsLocked(a), "a is still locked by the second lock")
+	assert.False(t, l.IsLocked(b))
+
+	// releasing twice has no effect
+	release1()
+	assert.True(t, l.IsLocked(a))
+
+	release2()
+	assert.False(t, l.IsLocked(a))
+}
@@ -0,0 +1,135 @@
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TempEntry is a file or directory directly within a temp directory, such
+// as the backup of a file being converted.
+type TempEntry struct {
+	Name string
+	Path string
+	// Size is the size of the file, or the total size of the files within
+	// the directory.
+	Size    int64
+	IsDir   bool
+	ModTime time.Time
+}
+
+// ListTempEntries returns the entries directly within dir, oldest first.
+// Returns no entries if dir does not exist.
+func ListTempEntries(dir string) ([]TempEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret []TempEntry
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			// removed since the directory was read
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		entry := TempEntry{
+			Name:    e.Name(),
+			Path:    filepath.Join(dir, e.Name()),
+			Size:    info.Size(),
+			IsDir:   info.IsDir(),
+			ModTime: info.ModTime(),
+		}
+
+		if entry.IsDir {
+			entry.Size = dirSize(entry.Path)
+		}
+
+		ret = append(ret, entry)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].ModTime.Before(ret[j].ModTime)
+	})
+
+	return ret, nil
+}
+
+// dirSize returns the total size of the regular files within dir. Files
+// which cannot be read are not counted.
+func dirSize(dir string) int64 {
+	var ret int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			ret += info.Size()
+		}
+		return nil
+	})
+
+	return ret
+}
+
+// TempCleanupPolicy limits the age and total size of the entries of a temp
+// directory. A zero limit is not applied.
+type TempCleanupPolicy struct {
+	MaxAge  time.Duration
+	MaxSize int64
+}
+
+// Expired returns the entries to remove to satisfy the policy: those older
+// than the maximum age, then the oldest of the rest until their total size
+// is within the maximum size. Entries for which inUse returns true are never
+// returned, but count towards the total size. entries must be ordered oldest
+// first, as returned by ListTempEntries.
+func (p TempCleanupPolicy) Expired(entries []TempEntry, now time.Time, inUse func(path string) bool) []TempEntry {
+	var ret []TempEntry
+	var total int64
+	expired := make([]bool, len(entries))
+
+	for i, e := range entries {
+		if inUse != nil && inUse(e.Path) {
+			total += e.Size
+			continue
+		}
+
+		if p.MaxAge > 0 && now.Sub(e.ModTime) > p.MaxAge {
+			expired[i] = true
+			ret = append(ret, e)
+			continue
+		}
+
+		total += e.Size
+	}
+
+	if p.MaxSize <= 0 {
+		return ret
+	}
+
+	for i, e := range entries {
+		if total <= p.MaxSize {
+			break
+		}
+		if expired[i] || (inUse != nil && inUse(e.Path)) {
+			continue
+		}
+
+		total -= e.Size
+		ret = append(ret, e)
+	}
+
+	return ret
+}
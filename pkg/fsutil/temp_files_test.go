@@ -0,0 +1,109 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTempEntries(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	write := func(path string, size int, modTime time.Time) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(dir, "new.mp4"), 10, now)
+	write(filepath.Join(dir, "old.mp4"), 20, now.Add(-2*time.Hour))
+	write(filepath.Join(dir, "hls", "a.ts"), 5, now)
+	write(filepath.Join(dir, "hls", "b.ts"), 7, now)
+	hlsTime := now.Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "hls"), hlsTime, hlsTime); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ListTempEntries(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"old.mp4", "hls", "new.mp4"}, names, "ordered oldest first")
+
+	assert.True(t, entries[1].IsDir)
+	assert.Equal(t, int64(12), entries[1].Size, "directory size is the total of its files")
+	assert.Equal(t, filepath.Join(dir, "old.mp4"), entries[0].Path)
+
+	entries, err = ListTempEntries(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTempCleanupPolicy_Expired(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	entry := func(name string, size int64, age time.Duration) TempEntry {
+		return TempEntry{Name: name, Path: "/temp/" + name, Size: size, ModTime: now.Add(-age)}
+	}
+
+	entries := []TempEntry{
+		entry("a", 100, 72*time.Hour),
+		entry("b", 50, 48*time.Hour),
+		entry("c", 30, 24*time.Hour),
+		entry("d", 20, time.Hour),
+	}
+
+	names := func(entries []TempEntry) []string {
+		var ret []string
+		for _, e := range entries {
+			ret = append(ret, e.Name)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name   string
+		policy TempCleanupPolicy
+		inUse  []string
+		want   []string
+	}{
+		{"no limits", TempCleanupPolicy{}, nil, nil},
+		{"max age", TempCleanupPolicy{MaxAge: 36 * time.Hour}, nil, []string{"a", "b"}},
+		{"max size", TempCleanupPolicy{MaxSize: 60}, nil, []string{"a", "b"}},
+		{"within max size", TempCleanupPolicy{MaxSize: 200}, nil, nil},
+		{"max age then size", TempCleanupPolicy{MaxAge: 60 * time.Hour, MaxSize: 40}, nil, []string{"a", "b", "c"}},
+		{"in use by age", TempCleanupPolicy{MaxAge: 36 * time.Hour}, []string{"a"}, []string{"b"}},
+		{"in use counts towards size", TempCleanupPolicy{MaxSize: 120}, []string{"a"}, []string{"b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inUse := func(path string) bool {
+				for _, n := range tt.inUse {
+					if path == "/temp/"+n {
+						return true
+					}
+				}
+				return false
+			}
+
+			got := tt.policy.Expired(entries, now, inUse)
+			assert.Equal(t, tt.want, names(got))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package gallery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FolderChapters returns a chapter for each run of images in the same
+// subfolder of root. imagePaths must be in gallery order. Chapter titles are
+// the folder paths relative to root, and image indexes are 1-based.
+// Returns nil if all images are in the same folder.
+func FolderChapters(root string, imagePaths []string) []models.GalleryChapter {
+	var ret []models.GalleryChapter
+	folders := make(map[string]bool)
+	prev := ""
+
+	for i, p := range imagePaths {
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			// not inside the gallery root
+			continue
+		}
+
+		if rel == prev {
+			continue
+		}
+		prev = rel
+		folders[rel] = true
+
+		title := filepath.ToSlash(rel)
+		if rel == "." {
+			title = filepath.Base(root)
+		}
+
+		ret = append(ret, models.GalleryChapter{
+			Title:      title,
+			ImageIndex: i + 1,
+		})
+	}
+
+	if len(folders) < 2 {
+		return nil
+	}
+
+	return ret
+}
+
+type FolderChapterCreator interface {
+	models.GalleryChapterCreator
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.GalleryChapter, error)
+}
+
+// CreateFolderChapters creates chapters for the subfolders of a zip or folder
+// based gallery. Chapters are not created at image indexes that already have
+// a chapter. Returns the number of chapters created.
+func CreateFolderChapters(ctx context.Context, g *models.Gallery, imageFinder models.ImageFinder, w FolderChapterCreator) (int, error) {
+	if g.Path == "" {
+		return 0, nil
+	}
+
+	images, err := imageFinder.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	paths := make([]string, len(images))
+	for i, img := range images {
+		paths[i] = img.Path
+	}
+
+	chapters := FolderChapters(g.Path, paths)
+	if len(chapters) == 0 {
+		return 0, nil
+	}
+
+	existing, err := w.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	existingIndexes := make(map[int]bool)
+	for _, c := range existing {
+		existingIndexes[c.ImageIndex] = true
+	}
+
+	created := 0
+	for _, c := range chapters {
+		if existingIndexes[c.ImageIndex] {
+			continue
+		}
+
+		newChapter := models.NewGalleryChapter()
+		newChapter.Title = c.Title
+		newChapter.ImageIndex = c.ImageIndex
+		newChapter.GalleryID = g.ID
+
+		if err := w.Create(ctx, &newChapter); err != nil {
+			return created, fmt.Errorf("creating chapter %q: %w", c.Title, err)
+		}
+		created++
+	}
+
+	return created, nil
+}
@@ -0,0 +1,63 @@
+package gallery
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderChapters(t *testing.T) {
+	root := filepath.Join("galleries", "set.zip")
+	p := func(elem ...string) string {
+		return filepath.Join(append([]string{root}, elem...)...)
+	}
+
+	tests := []struct {
+		name       string
+		imagePaths []string
+		want       []models.GalleryChapter
+	}{
+		{
+			"no subfolders",
+			[]string{p("1.jpg"), p("2.jpg")},
+			nil,
+		},
+		{
+			"single subfolder",
+			[]string{p("a", "1.jpg"), p("a", "2.jpg")},
+			nil,
+		},
+		{
+			"subfolders",
+			[]string{p("a", "1.jpg"), p("a", "2.jpg"), p("b", "c", "1.jpg")},
+			[]models.GalleryChapter{
+				{Title: "a", ImageIndex: 1},
+				{Title: "b/c", ImageIndex: 3},
+			},
+		},
+		{
+			"root and subfolder",
+			[]string{p("1.jpg"), p("a", "1.jpg")},
+			[]models.GalleryChapter{
+				{Title: "set.zip", ImageIndex: 1},
+				{Title: "a", ImageIndex: 2},
+			},
+		},
+		{
+			"outside root",
+			[]string{filepath.Join("other", "1.jpg"), p("a", "1.jpg"), p("b", "1.jpg")},
+			[]models.GalleryChapter{
+				{Title: "a", ImageIndex: 2},
+				{Title: "b", ImageIndex: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FolderChapters(root, tt.imagePaths)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -39,6 +39,7 @@ func ToBasicJSON(gallery *models.Gallery) (*jsonschema.Gallery, error) {
 	}
 
 	newGalleryJSON.Organized = gallery.Organized
+	newGalleryJSON.Locked = gallery.Locked
 
 	return &newGalleryJSON, nil
 }
@@ -72,10 +73,11 @@ func GetGalleryChaptersJSON(ctx context.Context, chapterReader models.GalleryCha
 
 	for _, galleryChapter := range galleryChapters {
 		galleryChapterJSON := jsonschema.GalleryChapter{
-			Title:      galleryChapter.Title,
-			ImageIndex: galleryChapter.ImageIndex,
-			CreatedAt:  json.JSONTime{Time: galleryChapter.CreatedAt},
-			UpdatedAt:  json.JSONTime{Time: galleryChapter.UpdatedAt},
+			Title:       galleryChapter.Title,
+			ImageIndex:  galleryChapter.ImageIndex,
+			DisplayMode: galleryChapter.DisplayMode,
+			CreatedAt:   json.JSONTime{Time: galleryChapter.CreatedAt},
+			UpdatedAt:   json.JSONTime{Time: galleryChapter.UpdatedAt},
 		}
 
 		results = append(results, galleryChapterJSON)
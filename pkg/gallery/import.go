@@ -88,6 +88,7 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	}
 
 	newGallery.Organized = galleryJSON.Organized
+	newGallery.Locked = galleryJSON.Locked
 	newGallery.CreatedAt = galleryJSON.CreatedAt.GetTime()
 	newGallery.UpdatedAt = galleryJSON.UpdatedAt.GetTime()
 
@@ -3,6 +3,7 @@ package gallery
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -68,6 +69,20 @@ func (s *Service) ResetCover(ctx context.Context, g *models.Gallery) error {
 	return s.Updated(ctx, g.ID)
 }
 
+// ReorderImages moves the images to before or after the insertion point image
+// in the manual order of the gallery.
+func (s *Service) ReorderImages(ctx context.Context, g *models.Gallery, imageIDs []int, insertPointID int, insertAfter bool) error {
+	if slices.Contains(imageIDs, insertPointID) {
+		return fmt.Errorf("image %d cannot be inserted relative to itself", insertPointID)
+	}
+
+	if err := s.Repository.ReorderImages(ctx, g.ID, imageIDs, insertPointID, insertAfter); err != nil {
+		return fmt.Errorf("failed to reorder images: %w", err)
+	}
+
+	return s.Updated(ctx, g.ID)
+}
+
 func AddPerformer(ctx context.Context, qb models.GalleryUpdater, o *models.Gallery, performerID int) error {
 	galleryPartial := models.NewGalleryPartial()
 	galleryPartial.PerformerIDs = &models.UpdateIDs{
@@ -0,0 +1,170 @@
+package group
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// partPatterns match the part number of a multi-part release in a filename
+// without its extension. The first group is the title before the part
+// number, the optional second group is a season and the last group is the
+// part number.
+var partPatterns = []*regexp.Regexp{
+	// part1, pt.2, cd1, disc 2
+	regexp.MustCompile(`(?i)^(.*?)(?:^|[^a-z])()(?:part|pt|cd|disc|disk)[\s._-]*(\d{1,3})(?:\D|$)`),
+	// E01, S01E02, ep 3, episode 4
+	regexp.MustCompile(`(?i)^(.*?)(?:^|[^a-z])(s\d{1,2}[\s._-]*)?(?:episode|ep|e)[\s._-]*(\d{1,3})(?:\D|$)`),
+}
+
+var titleSeparators = regexp.MustCompile(`[\s._]+`)
+
+const titleTrim = " -_.[("
+
+// Part is a scene file which is part of a multi-part release.
+type Part struct {
+	SceneID int
+	Path    string
+	// SceneIndex is the part number, used as the scene index in the group.
+	SceneIndex int
+}
+
+// ParsePart returns the title and part number of a multi-part release from
+// a filename. Returns false if the filename has no part number.
+func ParsePart(basename string) (title string, index int, ok bool) {
+	name := strings.TrimSuffix(basename, filepath.Ext(basename))
+
+	for _, re := range partPatterns {
+		m := re.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+
+		index, err := strconv.Atoi(m[3])
+		if err != nil {
+			continue
+		}
+
+		title := cleanTitle(m[1])
+		if season := strings.ToUpper(cleanTitle(m[2])); season != "" {
+			title = strings.TrimSpace(title + " " + strings.ReplaceAll(season, " ", ""))
+		}
+
+		return title, index, true
+	}
+
+	return "", 0, false
+}
+
+func cleanTitle(s string) string {
+	s = titleSeparators.ReplaceAllString(s, " ")
+	return strings.Trim(s, titleTrim)
+}
+
+// Proposal is a group proposed to be created from the parts of a multi-part
+// release in a folder.
+type Proposal struct {
+	ID     int
+	Name   string
+	Folder string
+	// Parts are ordered by scene index.
+	Parts []Part
+}
+
+// ProposalReport lists the groups proposed from multi-part releases.
+type ProposalReport struct {
+	GeneratedAt time.Time
+	Proposals   []Proposal
+}
+
+// PartCandidate is a scene file considered when proposing groups.
+type PartCandidate struct {
+	SceneID int
+	Path    string
+}
+
+// Propose returns the groups proposed from the multi-part releases among the
+// candidates. Parts are only grouped with parts in the same folder with the
+// same title. A release is only proposed if it has at least two parts, and
+// each part number is used by one scene only, since duplicate part numbers
+// usually mean different versions of the same part. Proposals are ordered
+// by folder and name, and numbered from 1.
+func Propose(candidates []PartCandidate) []Proposal {
+	type releaseKey struct {
+		folder string
+		title  string
+	}
+
+	releases := make(map[releaseKey]*Proposal)
+	for _, c := range candidates {
+		title, index, ok := ParsePart(filepath.Base(c.Path))
+		if !ok {
+			continue
+		}
+
+		folder := filepath.Dir(c.Path)
+		if title == "" {
+			title = cleanTitle(filepath.Base(folder))
+		}
+		if title == "" {
+			continue
+		}
+
+		k := releaseKey{folder, strings.ToLower(title)}
+		p := releases[k]
+		if p == nil {
+			p = &Proposal{Name: title, Folder: folder}
+			releases[k] = p
+		}
+
+		p.Parts = append(p.Parts, Part{SceneID: c.SceneID, Path: c.Path, SceneIndex: index})
+	}
+
+	var ret []Proposal
+	for _, p := range releases {
+		if !validParts(p.Parts) {
+			continue
+		}
+
+		sort.Slice(p.Parts, func(i, j int) bool {
+			return p.Parts[i].SceneIndex < p.Parts[j].SceneIndex
+		})
+		ret = append(ret, *p)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Folder != ret[j].Folder {
+			return ret[i].Folder < ret[j].Folder
+		}
+		return ret[i].Name < ret[j].Name
+	})
+
+	for i := range ret {
+		ret[i].ID = i + 1
+	}
+
+	return ret
+}
+
+// validParts returns true if there are at least two parts with distinct
+// part numbers from distinct scenes.
+func validParts(parts []Part) bool {
+	if len(parts) < 2 {
+		return false
+	}
+
+	indexes := make(map[int]bool)
+	scenes := make(map[int]bool)
+	for _, p := range parts {
+		if indexes[p.SceneIndex] || scenes[p.SceneID] {
+			return false
+		}
+		indexes[p.SceneIndex] = true
+		scenes[p.SceneID] = true
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePart(t *testing.T) {
+	tests := []struct {
+		basename string
+		title    string
+		index    int
+		ok       bool
+	}{
+		{"Movie Name part1.mp4", "Movie Name", 1, true},
+		{"Movie.Name.Pt.2.1080p.mkv", "Movie Name", 2, true},
+		{"movie_name_CD2.avi", "movie name", 2, true},
+		{"Movie Name - Disc 3.mp4", "Movie Name", 3, true},
+		{"Show.S01E02.720p.mp4", "Show S01", 2, true},
+		{"Show - Episode 10.mp4", "Show", 10, true},
+		{"Show [E05].mp4", "Show", 5, true},
+		{"part2.mp4", "", 2, true},
+		{"Apartment 2.mp4", "", 0, false},
+		{"Movie.2019.1080p.x264.mp4", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.basename, func(t *testing.T) {
+			title, index, ok := ParsePart(tt.basename)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.title, title)
+			assert.Equal(t, tt.index, index)
+		})
+	}
+}
+
+func TestPropose(t *testing.T) {
+	candidates := []PartCandidate{
+		{SceneID: 1, Path: "/stash/b/Movie part2.mp4"},
+		{SceneID: 2, Path: "/stash/b/Movie part1.mp4"},
+		{SceneID: 3, Path: "/stash/b/Other.mp4"},
+		// same title in another folder
+		{SceneID: 4, Path: "/stash/a/Movie part1.mp4"},
+		// no title, named after the folder
+		{SceneID: 5, Path: "/stash/a/Release/cd1.avi"},
+		{SceneID: 6, Path: "/stash/a/Release/cd2.avi"},
+		// duplicate part numbers are ambiguous
+		{SceneID: 7, Path: "/stash/c/Show E01.mp4"},
+		{SceneID: 8, Path: "/stash/c/Show E01 (1).mp4"},
+		{SceneID: 9, Path: "/stash/c/Show E02.mp4"},
+	}
+
+	assert.Equal(t, []Proposal{
+		{
+			ID:     1,
+			Name:   "Release",
+			Folder: "/stash/a/Release",
+			Parts: []Part{
+				{SceneID: 5, Path: "/stash/a/Release/cd1.avi", SceneIndex: 1},
+				{SceneID: 6, Path: "/stash/a/Release/cd2.avi", SceneIndex: 2},
+			},
+		},
+		{
+			ID:     2,
+			Name:   "Movie",
+			Folder: "/stash/b",
+			Parts: []Part{
+				{SceneID: 2, Path: "/stash/b/Movie part1.mp4", SceneIndex: 1},
+				{SceneID: 1, Path: "/stash/b/Movie part2.mp4", SceneIndex: 2},
+			},
+		},
+	}, Propose(candidates))
+}
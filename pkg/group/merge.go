@@ -0,0 +1,70 @@
+package group
+
+import (
+	"slices"
+)
+
+// SceneIndex is the position of a scene in a group. Index is nil if the scene
+// has no position.
+type SceneIndex struct {
+	SceneID int
+	Index   *int
+}
+
+// MergeSceneIndexes returns the scenes of the source groups to add to the
+// destination group, with their new scene indexes. Scenes already in the
+// destination group keep their existing index and are not returned. Indexed
+// source scenes are numbered after the last index of the destination group,
+// in the order of the source groups and then of their indexes within each
+// source group. Scenes without an index are added without one.
+func MergeSceneIndexes(dest []SceneIndex, sources [][]SceneIndex) []SceneIndex {
+	next := 1
+	seen := make(map[int]bool)
+	for _, s := range dest {
+		seen[s.SceneID] = true
+		if s.Index != nil {
+			next = max(next, *s.Index+1)
+		}
+	}
+
+	var ret []SceneIndex
+	for _, src := range sources {
+		sorted := slices.Clone(src)
+		slices.SortStableFunc(sorted, compareSceneIndex)
+
+		for _, s := range sorted {
+			if seen[s.SceneID] {
+				continue
+			}
+			seen[s.SceneID] = true
+
+			var index *int
+			if s.Index != nil {
+				i := next
+				index = &i
+				next++
+			}
+
+			ret = append(ret, SceneIndex{
+				SceneID: s.SceneID,
+				Index:   index,
+			})
+		}
+	}
+
+	return ret
+}
+
+// compareSceneIndex orders scenes by index, with unindexed scenes last.
+func compareSceneIndex(a, b SceneIndex) int {
+	switch {
+	case a.Index == nil && b.Index == nil:
+		return 0
+	case a.Index == nil:
+		return 1
+	case b.Index == nil:
+		return -1
+	default:
+		return *a.Index - *b.Index
+	}
+}
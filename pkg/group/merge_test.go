@@ -0,0 +1,59 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSceneIndexes(t *testing.T) {
+	index := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		dest    []SceneIndex
+		sources [][]SceneIndex
+		want    []SceneIndex
+	}{
+		{
+			"empty destination",
+			nil,
+			[][]SceneIndex{
+				{{1, index(2)}, {2, index(1)}},
+			},
+			[]SceneIndex{{2, index(1)}, {1, index(2)}},
+		},
+		{
+			"append after destination",
+			[]SceneIndex{{1, index(1)}, {2, index(5)}, {3, nil}},
+			[][]SceneIndex{
+				{{4, index(1)}, {5, nil}},
+				{{6, index(3)}, {7, index(1)}},
+			},
+			[]SceneIndex{{4, index(6)}, {5, nil}, {7, index(7)}, {6, index(8)}},
+		},
+		{
+			"scene already in destination",
+			[]SceneIndex{{1, index(1)}},
+			[][]SceneIndex{
+				{{1, index(3)}, {2, index(4)}},
+			},
+			[]SceneIndex{{2, index(2)}},
+		},
+		{
+			"scene in more than one source",
+			nil,
+			[][]SceneIndex{
+				{{1, index(1)}},
+				{{1, index(1)}, {2, index(2)}},
+			},
+			[]SceneIndex{{1, index(1)}, {2, index(2)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MergeSceneIndexes(tt.dest, tt.sources))
+		})
+	}
+}
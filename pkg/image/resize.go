@@ -0,0 +1,97 @@
+package image
+
+import (
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// resizeQuality is the encoding quality of resized images.
+const resizeQuality = 80
+
+// ResizeOptions are the dimensions and format of an image resized on request.
+type ResizeOptions struct {
+	// Width and Height are the maximum dimensions of the image, in pixels.
+	// A dimension of zero is not constrained.
+	Width  int
+	Height int
+	Format ThumbnailFormat
+}
+
+// ParseResizeOptions returns the options from the w, h and fmt query
+// parameters. The format defaults to JPEG. Returns nil if none of the
+// parameters are set, in which case the original image should be served.
+func ParseResizeOptions(query neturl.Values) (*ResizeOptions, error) {
+	w, h, format := query.Get("w"), query.Get("h"), query.Get("fmt")
+	if w == "" && h == "" && format == "" {
+		return nil, nil
+	}
+
+	ret := &ResizeOptions{
+		Format: ThumbnailFormatJpeg,
+	}
+
+	var err error
+	if w != "" {
+		if ret.Width, err = strconv.Atoi(w); err != nil {
+			return nil, fmt.Errorf("invalid width %q", w)
+		}
+	}
+	if h != "" {
+		if ret.Height, err = strconv.Atoi(h); err != nil {
+			return nil, fmt.Errorf("invalid height %q", h)
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case "":
+	case "jpg", "jpeg":
+		ret.Format = ThumbnailFormatJpeg
+	case "webp":
+		ret.Format = ThumbnailFormatWebp
+	case "avif":
+		ret.Format = ThumbnailFormatAvif
+	default:
+		return nil, fmt.Errorf("invalid format %q", format)
+	}
+
+	if err := ret.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o ResizeOptions) Validate() error {
+	for _, v := range []int{o.Width, o.Height} {
+		if v != 0 && (v < minThumbnailProfileSize || v > maxThumbnailProfileSize) {
+			return fmt.Errorf("dimensions must be between %d and %d", minThumbnailProfileSize, maxThumbnailProfileSize)
+		}
+	}
+
+	if !o.Format.IsValid() {
+		return fmt.Errorf("invalid format %q", o.Format)
+	}
+
+	return nil
+}
+
+// Key returns a string identifying the options, used in the names of the
+// cached files and in ETags.
+func (o ResizeOptions) Key() string {
+	return fmt.Sprintf("%dx%d_%s_q%d", o.Width, o.Height, strings.ToLower(o.Format.String()), resizeQuality)
+}
+
+// dimensions returns the width and height to fit the image within, with
+// unconstrained dimensions set to the maximum size.
+func (o ResizeOptions) dimensions() (width int, height int) {
+	width, height = o.Width, o.Height
+	if width == 0 {
+		width = maxThumbnailProfileSize
+	}
+	if height == 0 {
+		height = maxThumbnailProfileSize
+	}
+	return width, height
+}
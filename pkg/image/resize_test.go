@@ -0,0 +1,42 @@
+package image
+
+import (
+	neturl "net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResizeOptions(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    *ResizeOptions
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"w=320", &ResizeOptions{Width: 320, Format: ThumbnailFormatJpeg}, false},
+		{"w=320&h=240&fmt=WebP", &ResizeOptions{Width: 320, Height: 240, Format: ThumbnailFormatWebp}, false},
+		{"fmt=avif", &ResizeOptions{Format: ThumbnailFormatAvif}, false},
+		{"w=abc", nil, true},
+		{"h=8", nil, true},
+		{"w=10000", nil, true},
+		{"w=320&fmt=gif", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := neturl.ParseQuery(tt.query)
+			got, err := ParseResizeOptions(query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseResizeOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResizeOptions_dimensions(t *testing.T) {
+	w, h := ResizeOptions{Width: 320}.dimensions()
+	assert.Equal(t, 320, w)
+	assert.Equal(t, maxThumbnailProfileSize, h)
+}
@@ -1,10 +1,11 @@
 package image
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
 	"runtime"
@@ -69,19 +70,42 @@ func NewThumbnailEncoder(ffmpegEncoder *ffmpeg.FFMpeg, ffProbe *ffmpeg.FFProbe,
 // the provided max size. It resizes based on the largest X/Y direction.
 // It returns nil and an error if an error occurs reading, decoding or encoding
 // the image, or if the image is not suitable for thumbnails.
+// The image is streamed to the encoder rather than read into memory, so that
+// large images and images in zip files don't cause memory spikes.
 func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, error) {
-	reader, err := f.Open(&file.OsFS{})
-	if err != nil {
-		return nil, err
+	return e.getThumbnail(f, maxSize, maxSize, nil)
+}
+
+// GetProfileThumbnail returns the thumbnail image of the provided image in
+// the size, format and quality of the profile. It returns errors in the same
+// way as GetThumbnail.
+func (e *ThumbnailEncoder) GetProfileThumbnail(f models.File, profile ThumbnailProfile) ([]byte, error) {
+	return e.getThumbnail(f, profile.Size, profile.Size, &profile)
+}
+
+// GetResized returns the provided image resized to fit within the width and
+// height of the options, in the format of the options. It returns errors in
+// the same way as GetThumbnail.
+func (e *ThumbnailEncoder) GetResized(f models.File, options ResizeOptions) ([]byte, error) {
+	width, height := options.dimensions()
+	profile := ThumbnailProfile{
+		Format:  options.Format,
+		Quality: resizeQuality,
 	}
-	defer reader.Close()
 
-	buf := new(bytes.Buffer)
-	if _, err := buf.ReadFrom(reader); err != nil {
+	return e.getThumbnail(f, width, height, &profile)
+}
+
+// getThumbnail returns the thumbnail fitting within width and height in the
+// format of the profile, or the default JPEG thumbnail if profile is nil.
+func (e *ThumbnailEncoder) getThumbnail(f models.File, width int, height int, profile *ThumbnailProfile) ([]byte, error) {
+	reader, err := f.Open(file.NewRetryFS(&file.OsFS{}))
+	if err != nil {
 		return nil, err
 	}
+	defer reader.Close()
 
-	data := buf.Bytes()
+	buf := bufio.NewReader(reader)
 
 	if imageFile, ok := f.(*models.ImageFile); ok {
 		format := imageFile.Format
@@ -89,7 +113,9 @@ func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, err
 
 		// #2266 - if image is webp, then determine if it is animated
 		if format == formatWebP {
-			animated = isWebPAnimated(data)
+			// header may be shorter than requested, in which case it is not animated
+			header, _ := buf.Peek(webPMaxHeaderSize)
+			animated = isWebPAnimated(header)
 		}
 
 		// #2266 - don't generate a thumbnail for animated images
@@ -100,14 +126,17 @@ func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, err
 
 	// Videofiles can only be thumbnailed with ffmpeg
 	if _, ok := f.(*models.VideoFile); ok {
-		return e.ffmpegImageThumbnail(buf, maxSize)
+		return e.ffmpegImageThumbnail(buf, width, height, profile)
 	}
 
 	// vips has issues loading files from stdin on Windows
 	if e.vips != nil && runtime.GOOS != "windows" {
-		return e.vips.ImageThumbnail(buf, maxSize)
+		if profile != nil {
+			return e.vips.ImageThumbnailFormat(buf, width, height, profile.Format, profile.Quality)
+		}
+		return e.vips.ImageThumbnail(buf, width)
 	} else {
-		return e.ffmpegImageThumbnail(buf, maxSize)
+		return e.ffmpegImageThumbnail(buf, width, height, profile)
 	}
 }
 
@@ -129,17 +158,50 @@ func (e *ThumbnailEncoder) GetPreview(inPath string, outPath string, maxSize int
 	return e.getClipPreview(inPath, outPath, maxSize, clipDuration, fileData.FrameRate)
 }
 
-func (e *ThumbnailEncoder) ffmpegImageThumbnail(image *bytes.Buffer, maxSize int) ([]byte, error) {
-	args := transcoder.ImageThumbnail("-", transcoder.ImageThumbnailOptions{
+func (e *ThumbnailEncoder) ffmpegImageThumbnail(image io.Reader, width int, height int, profile *ThumbnailProfile) ([]byte, error) {
+	options := transcoder.ImageThumbnailOptions{
 		OutputFormat:  ffmpeg.ImageFormatJpeg,
 		OutputPath:    "-",
-		MaxDimensions: maxSize,
+		MaxDimensions: width,
 		Quality:       ffmpegImageQuality,
-	})
+	}
+
+	if height != width {
+		options.MaxHeight = height
+	}
+
+	if profile != nil {
+		setFFMpegThumbnailFormat(&options, profile.Format, profile.Quality)
+	}
+
+	args := transcoder.ImageThumbnail("-", options)
 
 	return e.FFMpeg.GenerateOutput(context.TODO(), args, image)
 }
 
+// setFFMpegThumbnailFormat sets the codec and quality of the options for the
+// format. quality is from 1 to 100, and is mapped to the scale of the codec.
+func setFFMpegThumbnailFormat(options *transcoder.ImageThumbnailOptions, format ThumbnailFormat, quality int) {
+	switch format {
+	case ThumbnailFormatWebp:
+		options.Codec = &ffmpeg.VideoCodecLibWebP
+		options.CodecArgs = ffmpeg.Args{"-quality", fmt.Sprint(quality)}
+		options.OutputFormat = ffmpeg.ImageFormatWebp
+		options.Quality = 0
+	case ThumbnailFormatAvif:
+		// crf is from 0 (best) to 63 (worst)
+		crf := (100 - quality) * 63 / 100
+		options.Codec = &ffmpeg.VideoCodecLibAOM
+		options.CodecArgs = ffmpeg.Args{"-still-picture", "1", "-crf", fmt.Sprint(crf)}
+		options.OutputFormat = ""
+		options.MuxFormat = ffmpeg.ImageFormatAvif
+		options.Quality = 0
+	default:
+		// qscale is from 2 (best) to 31 (worst)
+		options.Quality = 2 + (100-quality)*29/99
+	}
+}
+
 func (e *ThumbnailEncoder) getClipPreview(inPath string, outPath string, maxSize int, clipDuration float64, frameRate float64) error {
 	var thumbFilter ffmpeg.VideoFilter
 	thumbFilter = thumbFilter.ScaleMaxSize(maxSize)
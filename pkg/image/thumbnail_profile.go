@@ -0,0 +1,239 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// ThumbnailFormat is the image format of a thumbnail.
+type ThumbnailFormat string
+
+const (
+	ThumbnailFormatJpeg ThumbnailFormat = "JPEG"
+	ThumbnailFormatWebp ThumbnailFormat = "WEBP"
+	ThumbnailFormatAvif ThumbnailFormat = "AVIF"
+)
+
+var AllThumbnailFormats = []ThumbnailFormat{
+	ThumbnailFormatJpeg,
+	ThumbnailFormatWebp,
+	ThumbnailFormatAvif,
+}
+
+func (e ThumbnailFormat) IsValid() bool {
+	switch e {
+	case ThumbnailFormatJpeg, ThumbnailFormatWebp, ThumbnailFormatAvif:
+		return true
+	}
+	return false
+}
+
+func (e ThumbnailFormat) String() string {
+	return string(e)
+}
+
+func (e *ThumbnailFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ThumbnailFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ThumbnailFormat", str)
+	}
+	return nil
+}
+
+func (e ThumbnailFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// MimeType returns the mime type of the format.
+func (e ThumbnailFormat) MimeType() string {
+	switch e {
+	case ThumbnailFormatWebp:
+		return "image/webp"
+	case ThumbnailFormatAvif:
+		return "image/avif"
+	default:
+		return "image/jpeg"
+	}
+}
+
+// Extension returns the file extension of the format, without the leading
+// dot.
+func (e ThumbnailFormat) Extension() string {
+	switch e {
+	case ThumbnailFormatWebp:
+		return "webp"
+	case ThumbnailFormatAvif:
+		return "avif"
+	default:
+		return "jpg"
+	}
+}
+
+// preference returns the order in which formats are served where a client
+// accepts more than one. Formats which compress better are preferred.
+func (e ThumbnailFormat) preference() int {
+	switch e {
+	case ThumbnailFormatAvif:
+		return 2
+	case ThumbnailFormatWebp:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ThumbnailProfile is a size and format in which image thumbnails are
+// generated.
+type ThumbnailProfile struct {
+	// Size is the maximum width and height of the thumbnail, in pixels.
+	Size   int             `json:"size" koanf:"size"`
+	Format ThumbnailFormat `json:"format" koanf:"format"`
+	// Quality is the encoding quality, from 1 to 100.
+	Quality int `json:"quality" koanf:"quality"`
+}
+
+const (
+	minThumbnailProfileSize = 16
+	maxThumbnailProfileSize = 4096
+)
+
+func (p ThumbnailProfile) Validate() error {
+	if p.Size < minThumbnailProfileSize || p.Size > maxThumbnailProfileSize {
+		return fmt.Errorf("size must be between %d and %d", minThumbnailProfileSize, maxThumbnailProfileSize)
+	}
+
+	if !p.Format.IsValid() {
+		return fmt.Errorf("invalid format %q", p.Format)
+	}
+
+	if p.Quality < 1 || p.Quality > 100 {
+		return errors.New("quality must be between 1 and 100")
+	}
+
+	return nil
+}
+
+// Key returns a string identifying the profile, used in the names of the
+// generated files. Profiles with the same key produce the same thumbnails.
+func (p ThumbnailProfile) Key() string {
+	return fmt.Sprintf("%d_%s_q%d", p.Size, strings.ToLower(p.Format.String()), p.Quality)
+}
+
+// ValidateThumbnailProfiles returns an error if any profile is invalid, or if
+// more than one profile has the same size and format.
+func ValidateThumbnailProfiles(profiles []ThumbnailProfile) error {
+	type sizeFormat struct {
+		size   int
+		format ThumbnailFormat
+	}
+
+	seen := make(map[sizeFormat]bool)
+	for _, p := range profiles {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("thumbnail profile %s: %w", p.Key(), err)
+		}
+
+		k := sizeFormat{p.Size, p.Format}
+		if seen[k] {
+			return fmt.Errorf("duplicate thumbnail profile for size %d and format %s", p.Size, p.Format)
+		}
+		seen[k] = true
+	}
+
+	return nil
+}
+
+// acceptsMimeType returns true if the Accept header value accepts the mime
+// type. JPEG is always accepted, since it was the only format served before
+// profiles were added, and clients which do not send an Accept header must
+// still get an image.
+func acceptsMimeType(accept string, mimeType string) bool {
+	if mimeType == ThumbnailFormatJpeg.MimeType() {
+		return true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		if q, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+
+		// wildcards are not treated as accepting newer formats, since
+		// browsers send image/* without supporting them all
+		if mediaType == mimeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelectThumbnailProfile returns the profile best matching the requested
+// size and the formats accepted by the client, given the value of the
+// Accept header. The smallest profile at least as large as the requested
+// size is used, or the largest profile if none are. Of the profiles of that
+// size, the accepted format which compresses best is used. Returns nil if no
+// profile has an accepted format.
+func SelectThumbnailProfile(profiles []ThumbnailProfile, size int, accept string) *ThumbnailProfile {
+	var ret *ThumbnailProfile
+	for i := range profiles {
+		p := &profiles[i]
+		if !acceptsMimeType(accept, p.Format.MimeType()) {
+			continue
+		}
+
+		if ret == nil || betterThumbnailProfile(*p, *ret, size) {
+			ret = p
+		}
+	}
+
+	return ret
+}
+
+// FindThumbnailProfile returns the profile with the key, or nil if there is
+// none.
+func FindThumbnailProfile(profiles []ThumbnailProfile, key string) *ThumbnailProfile {
+	for i := range profiles {
+		if profiles[i].Key() == key {
+			return &profiles[i]
+		}
+	}
+
+	return nil
+}
+
+// betterThumbnailProfile returns true if p is a better match than current
+// for the requested size.
+func betterThumbnailProfile(p, current ThumbnailProfile, size int) bool {
+	pFits := p.Size >= size
+	currentFits := current.Size >= size
+
+	switch {
+	case pFits != currentFits:
+		return pFits
+	case p.Size != current.Size:
+		if pFits {
+			// smallest that fits
+			return p.Size < current.Size
+		}
+		// otherwise the largest
+		return p.Size > current.Size
+	default:
+		return p.Format.preference() > current.Format.preference()
+	}
+}
@@ -0,0 +1,65 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectThumbnailProfile(t *testing.T) {
+	profiles := []ThumbnailProfile{
+		{Size: 320, Format: ThumbnailFormatJpeg, Quality: 80},
+		{Size: 320, Format: ThumbnailFormatWebp, Quality: 80},
+		{Size: 1280, Format: ThumbnailFormatJpeg, Quality: 80},
+		{Size: 1280, Format: ThumbnailFormatAvif, Quality: 60},
+	}
+
+	const (
+		browserAccept = "image/avif,image/webp,image/apng,image/*,*/*;q=0.8"
+		webpAccept    = "image/webp,*/*"
+	)
+
+	tests := []struct {
+		name   string
+		size   int
+		accept string
+		want   *ThumbnailProfile
+	}{
+		{"smallest that fits", 200, webpAccept, &profiles[1]},
+		{"best format", 640, browserAccept, &profiles[3]},
+		{"no accept header", 640, "", &profiles[2]},
+		{"wildcard only", 200, "image/*", &profiles[0]},
+		{"rejected format", 640, "image/avif;q=0, image/webp", &profiles[2]},
+		{"largest if none fit", 2000, webpAccept, &profiles[2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SelectThumbnailProfile(profiles, tt.size, tt.accept))
+		})
+	}
+
+	avifOnly := []ThumbnailProfile{profiles[3]}
+	assert.Nil(t, SelectThumbnailProfile(avifOnly, 640, webpAccept))
+	assert.Nil(t, SelectThumbnailProfile(nil, 640, browserAccept))
+}
+
+func TestFindThumbnailProfile(t *testing.T) {
+	profiles := []ThumbnailProfile{
+		{Size: 320, Format: ThumbnailFormatJpeg, Quality: 80},
+		{Size: 320, Format: ThumbnailFormatWebp, Quality: 80},
+	}
+
+	assert.Equal(t, &profiles[1], FindThumbnailProfile(profiles, profiles[1].Key()))
+	assert.Nil(t, FindThumbnailProfile(profiles, "320_webp_q90"))
+	assert.Nil(t, FindThumbnailProfile(nil, profiles[0].Key()))
+}
+
+func TestValidateThumbnailProfiles(t *testing.T) {
+	valid := ThumbnailProfile{Size: 320, Format: ThumbnailFormatWebp, Quality: 80}
+	assert.NoError(t, ValidateThumbnailProfiles([]ThumbnailProfile{valid}))
+
+	assert.Error(t, ValidateThumbnailProfiles([]ThumbnailProfile{valid, valid}))
+	assert.Error(t, ValidateThumbnailProfiles([]ThumbnailProfile{{Size: 8, Format: ThumbnailFormatWebp, Quality: 80}}))
+	assert.Error(t, ValidateThumbnailProfiles([]ThumbnailProfile{{Size: 320, Format: "PNG", Quality: 80}}))
+	assert.Error(t, ValidateThumbnailProfiles([]ThumbnailProfile{{Size: 320, Format: ThumbnailFormatWebp, Quality: 101}}))
+}
@@ -3,6 +3,7 @@ package image
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/exec"
@@ -11,12 +12,21 @@ import (
 
 type vipsEncoder string
 
-func (e *vipsEncoder) ImageThumbnail(image *bytes.Buffer, maxSize int) ([]byte, error) {
+const vipsJpegQuality = 70
+
+func (e *vipsEncoder) ImageThumbnail(image io.Reader, maxSize int) ([]byte, error) {
+	return e.ImageThumbnailFormat(image, maxSize, maxSize, ThumbnailFormatJpeg, vipsJpegQuality)
+}
+
+// ImageThumbnailFormat returns the thumbnail of the image fitting within the
+// width and height in the format, encoded with the quality from 1 to 100.
+func (e *vipsEncoder) ImageThumbnailFormat(image io.Reader, width int, height int, format ThumbnailFormat, quality int) ([]byte, error) {
 	args := []string{
 		"thumbnail_source",
 		"[descriptor=0]",
-		".jpg[Q=70,strip]",
-		fmt.Sprint(maxSize),
+		fmt.Sprintf(".%s[Q=%d,strip]", format.Extension(), quality),
+		fmt.Sprint(width),
+		"--height", fmt.Sprint(height),
 		"--size", "down",
 	}
 	data, err := e.run(args, image)
@@ -24,7 +34,7 @@ func (e *vipsEncoder) ImageThumbnail(image *bytes.Buffer, maxSize int) ([]byte,
 	return []byte(data), err
 }
 
-func (e *vipsEncoder) run(args []string, stdin *bytes.Buffer) (string, error) {
+func (e *vipsEncoder) run(args []string, stdin io.Reader) (string, error) {
 	cmd := exec.Command(string(*e), args...)
 
 	var stdout, stderr bytes.Buffer
@@ -7,6 +7,10 @@ import (
 const (
 	formatWebP = "webp"
 	formatGif  = "gif"
+
+	// webPMaxHeaderSize is the number of bytes at the start of a webp file
+	// needed to determine if it is animated.
+	webPMaxHeaderSize = 48
 )
 
 // https://developers.google.com/speed/webp/docs/riff_container
@@ -18,13 +22,11 @@ func isWebPAnimated(buf []byte) bool {
 
 		animationHeaderLoc    = 16
 		minAnimSignatureIndex = 20
-
-		maxSize = 48
 	)
 
 	// truncate the buffer to the max size
-	if len(buf) > maxSize {
-		buf = buf[:maxSize]
+	if len(buf) > webPMaxHeaderSize {
+		buf = buf[:webPMaxHeaderSize]
 	}
 
 	isWebp := len(buf) >= webPHeaderEnd && string(buf[webPHeaderStart:webPHeaderEnd]) == "WEBP" // is WEBP
@@ -0,0 +1,51 @@
+package interactive
+
+import "time"
+
+// LatencyEstimator estimates the latency of a connection from round trip
+// time samples, smoothing them in the same way as TCP (RFC 6298), so that a
+// single slow sample does not throw off the sync.
+type LatencyEstimator struct {
+	srtt    time.Duration
+	rttvar  time.Duration
+	samples int
+}
+
+// Add adds a round trip time sample. Samples which are not positive are
+// ignored.
+func (e *LatencyEstimator) Add(rtt time.Duration) {
+	if rtt <= 0 {
+		return
+	}
+
+	if e.samples == 0 {
+		e.srtt = rtt
+		e.rttvar = rtt / 2
+	} else {
+		diff := e.srtt - rtt
+		if diff < 0 {
+			diff = -diff
+		}
+		e.rttvar = (3*e.rttvar + diff) / 4
+		e.srtt = (7*e.srtt + rtt) / 8
+	}
+
+	e.samples++
+}
+
+// RTT returns the smoothed round trip time, or zero if there are no
+// samples.
+func (e *LatencyEstimator) RTT() time.Duration {
+	return e.srtt
+}
+
+// Jitter returns the variation of the round trip time.
+func (e *LatencyEstimator) Jitter() time.Duration {
+	return e.rttvar
+}
+
+// OneWay returns the estimated time for a message to reach the server,
+// which is half of the smoothed round trip time.
+func (e *LatencyEstimator) OneWay() time.Duration {
+	return e.srtt / 2
+}
@@ -0,0 +1,55 @@
+// Package interactive implements the websocket channel used to keep
+// interactive devices in sync with playback. Clients send frequent
+// heartbeats with the playback position and probe the latency of the
+// connection, so that the position can be compensated for the delay of the
+// network.
+package interactive
+
+// MessageType is the type of a message sent over the channel.
+type MessageType string
+
+const (
+	// MessageTypePing is a latency probe sent by the client. The server
+	// replies with a pong immediately.
+	MessageTypePing MessageType = "ping"
+	// MessageTypePong is the reply to a ping. It echoes the id and client
+	// time of the ping, and adds the server time, so that the client can
+	// measure the round trip time and the offset of its clock.
+	MessageTypePong MessageType = "pong"
+	// MessageTypeHeartbeat is the playback state of the client.
+	MessageTypeHeartbeat MessageType = "heartbeat"
+	// MessageTypeStatus is sent by the server when the devices are started,
+	// resynced or stopped.
+	MessageTypeStatus MessageType = "status"
+	// MessageTypeError is sent by the server when a message cannot be
+	// handled.
+	MessageTypeError MessageType = "error"
+)
+
+// Message is a message sent in either direction. Only the fields of its
+// type are set. Times are unix times in milliseconds.
+type Message struct {
+	Type MessageType `json:"type"`
+
+	// ID identifies a ping and its pong.
+	ID         int64 `json:"id,omitempty"`
+	ClientTime int64 `json:"client_time,omitempty"`
+	ServerTime int64 `json:"server_time,omitempty"`
+
+	// SceneID is the scene being played, and Position the playback
+	// position in seconds when the heartbeat was sent.
+	SceneID  string  `json:"scene_id,omitempty"`
+	Position float64 `json:"position,omitempty"`
+	Playing  bool    `json:"playing,omitempty"`
+	// RTT is the last round trip time measured by the client, in
+	// milliseconds.
+	RTT float64 `json:"rtt,omitempty"`
+
+	// Latency is the estimated one-way latency of the connection, and
+	// Drift the difference between the position of the client and the
+	// devices when they were resynced, in milliseconds.
+	Latency float64 `json:"latency,omitempty"`
+	Drift   float64 `json:"drift,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
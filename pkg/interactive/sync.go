@@ -0,0 +1,95 @@
+package interactive
+
+import "time"
+
+// DefaultDriftThreshold is the difference between the position of the
+// client and the devices above which the devices are resynced.
+const DefaultDriftThreshold = 100 * time.Millisecond
+
+// SyncAction is what must be done to the devices to follow the client.
+type SyncAction int
+
+const (
+	// SyncNone leaves the devices as they are.
+	SyncNone SyncAction = iota
+	// SyncPlay starts playing the script from the position, replacing
+	// the script that is playing.
+	SyncPlay
+	// SyncStop stops the devices.
+	SyncStop
+)
+
+// PlaybackSync follows the playback state of a client, and decides when
+// the devices must be started, resynced or stopped. The position of the
+// devices is extrapolated from the time they were last started, so that
+// regular heartbeats only resync them when they drift apart.
+type PlaybackSync struct {
+	// DriftThreshold is the drift above which the devices are resynced.
+	// DefaultDriftThreshold is used if it is zero.
+	DriftThreshold time.Duration
+
+	playing  bool
+	sceneID  string
+	position time.Duration
+	started  time.Time
+}
+
+// Playing returns true if the devices are playing.
+func (s *PlaybackSync) Playing() bool {
+	return s.playing
+}
+
+// Update updates the state with a heartbeat of the client, which was
+// playing the scene at position when it was received at now. The position
+// should be compensated for the latency of the connection. Returns the
+// action to take and, if the devices were playing the scene, the
+// difference between the position of the client and the devices.
+func (s *PlaybackSync) Update(sceneID string, playing bool, position time.Duration, now time.Time) (SyncAction, time.Duration) {
+	if !playing {
+		if !s.playing {
+			return SyncNone, 0
+		}
+
+		s.playing = false
+		return SyncStop, 0
+	}
+
+	if !s.playing || sceneID != s.sceneID {
+		s.start(sceneID, position, now)
+		return SyncPlay, 0
+	}
+
+	drift := position - s.expected(now)
+
+	threshold := s.DriftThreshold
+	if threshold <= 0 {
+		threshold = DefaultDriftThreshold
+	}
+
+	if drift > threshold || drift < -threshold {
+		s.start(sceneID, position, now)
+		return SyncPlay, drift
+	}
+
+	return SyncNone, drift
+}
+
+// Stop marks the devices as stopped, such as when the client disconnects.
+// Returns true if they were playing.
+func (s *PlaybackSync) Stop() bool {
+	ret := s.playing
+	s.playing = false
+	return ret
+}
+
+func (s *PlaybackSync) start(sceneID string, position time.Duration, now time.Time) {
+	s.playing = true
+	s.sceneID = sceneID
+	s.position = position
+	s.started = now
+}
+
+// expected returns the position of the devices at now.
+func (s *PlaybackSync) expected(now time.Time) time.Duration {
+	return s.position + now.Sub(s.started)
+}
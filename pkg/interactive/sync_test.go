@@ -0,0 +1,70 @@
+package interactive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaybackSync_Update(t *testing.T) {
+	ms := time.Millisecond
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	s := &PlaybackSync{}
+
+	type step struct {
+		name      string
+		sceneID   string
+		playing   bool
+		position  time.Duration
+		now       time.Time
+		wantDo    SyncAction
+		wantDrift time.Duration
+	}
+
+	steps := []step{
+		{"paused", "1", false, 0, at(0), SyncNone, 0},
+		{"start", "1", true, 10 * time.Second, at(0), SyncPlay, 0},
+		{"in sync", "1", true, 10*time.Second + 500*ms, at(500 * ms), SyncNone, 0},
+		{"small drift", "1", true, 11*time.Second + 50*ms, at(1000 * ms), SyncNone, 50 * ms},
+		{"seek", "1", true, 30 * time.Second, at(1500 * ms), SyncPlay, 30*time.Second - 11*time.Second - 500*ms},
+		{"behind", "1", true, 30*time.Second + 300*ms, at(2000 * ms), SyncPlay, -200 * ms},
+		{"other scene", "2", true, 0, at(2100 * ms), SyncPlay, 0},
+		{"pause", "2", false, time.Second, at(3000 * ms), SyncStop, 0},
+		{"still paused", "2", false, time.Second, at(3500 * ms), SyncNone, 0},
+		{"resume", "2", true, time.Second, at(4000 * ms), SyncPlay, 0},
+	}
+
+	for _, st := range steps {
+		gotDo, gotDrift := s.Update(st.sceneID, st.playing, st.position, st.now)
+		assert.Equal(t, st.wantDo, gotDo, st.name)
+		assert.Equal(t, st.wantDrift, gotDrift, st.name)
+	}
+
+	assert.True(t, s.Stop())
+	assert.False(t, s.Stop())
+	assert.False(t, s.Playing())
+}
+
+func TestLatencyEstimator(t *testing.T) {
+	ms := time.Millisecond
+	e := &LatencyEstimator{}
+
+	assert.Zero(t, e.OneWay())
+
+	e.Add(0)
+	assert.Zero(t, e.RTT(), "samples which are not positive are ignored")
+
+	e.Add(100 * ms)
+	assert.Equal(t, 100*ms, e.RTT())
+	assert.Equal(t, 50*ms, e.OneWay())
+	assert.Equal(t, 50*ms, e.Jitter())
+
+	// a single slow sample only moves the estimate by an eighth
+	e.Add(900 * ms)
+	assert.Equal(t, 200*ms, e.RTT())
+	assert.Equal(t, 100*ms, e.OneWay())
+	assert.Equal(t, 237500*time.Microsecond, e.Jitter())
+}
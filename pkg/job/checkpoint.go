@@ -0,0 +1,62 @@
+package job
+
+import "sync"
+
+// Resumable is implemented by jobs that can be resumed after being
+// interrupted by a shutdown.
+type Resumable interface {
+	JobExec
+	// Checkpoint returns the kind of the job and the state needed to create
+	// a job that continues where this one stopped. It is called after the
+	// job has been cancelled.
+	Checkpoint() (kind string, state []byte, err error)
+}
+
+// Checkpoint is the state of a resumable job that did not finish.
+type Checkpoint struct {
+	Description string
+	Kind        string
+	State       []byte
+}
+
+// Cursor tracks how many items at the start of an ordered sequence have been
+// completed, where items may be completed out of order. It is safe for
+// concurrent use.
+type Cursor struct {
+	mutex    sync.Mutex
+	position int
+	done     map[int]bool
+}
+
+// NewCursor returns a cursor where the first position items are complete.
+func NewCursor(position int) *Cursor {
+	return &Cursor{
+		position: position,
+		done:     make(map[int]bool),
+	}
+}
+
+// Done marks the item with the given zero-based index as complete.
+func (c *Cursor) Done(index int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if index < c.position {
+		return
+	}
+
+	c.done[index] = true
+	for c.done[c.position] {
+		delete(c.done, c.position)
+		c.position++
+	}
+}
+
+// Position returns the number of items at the start of the sequence that
+// are complete.
+func (c *Cursor) Position() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.position
+}
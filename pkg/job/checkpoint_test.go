@@ -0,0 +1,125 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	c := NewCursor(2)
+	assert := assert.New(t)
+
+	// items before the start position are ignored
+	c.Done(0)
+	assert.Equal(2, c.Position())
+
+	// out of order items do not advance the cursor
+	c.Done(4)
+	c.Done(3)
+	assert.Equal(2, c.Position())
+
+	c.Done(2)
+	assert.Equal(5, c.Position())
+
+	// items may be completed more than once
+	c.Done(4)
+	c.Done(5)
+	assert.Equal(6, c.Position())
+}
+
+type testResumableExec struct {
+	*testExec
+	cursor *Cursor
+}
+
+func (e *testResumableExec) Checkpoint() (string, []byte, error) {
+	if e.cursor.Position() == 0 {
+		return "test", []byte("queued"), nil
+	}
+	return "test", []byte("running"), nil
+}
+
+func newTestResumableExec(finish chan struct{}) *testResumableExec {
+	return &testResumableExec{
+		testExec: newTestExec(finish),
+		cursor:   NewCursor(0),
+	}
+}
+
+func (e *testResumableExec) Execute(ctx context.Context, p *Progress) error {
+	e.cursor.Done(0)
+	return e.testExec.Execute(ctx, p)
+}
+
+func TestShutdown(t *testing.T) {
+	m := NewManager()
+
+	// finished jobs are not checkpointed
+	finished := newTestResumableExec(nil)
+	m.Add(context.Background(), "finished", finished)
+
+	time.Sleep(sleepTime)
+
+	finish := make(chan struct{})
+	running := newTestResumableExec(finish)
+	m.Add(context.Background(), "running", running)
+
+	// non-resumable jobs are not checkpointed
+	m.Add(context.Background(), "not resumable", newTestExec(make(chan struct{})))
+
+	queued := newTestResumableExec(make(chan struct{}))
+	m.Add(context.Background(), "queued", queued)
+
+	time.Sleep(sleepTime)
+
+	// finish the running job once it has been cancelled
+	go func() {
+		time.Sleep(sleepTime)
+		close(finish)
+	}()
+
+	checkpoints := m.Shutdown(time.Second)
+
+	assert := assert.New(t)
+	assert.True(running.cancelled)
+	assert.Equal([]Checkpoint{
+		{
+			Description: "running",
+			Kind:        "test",
+			State:       []byte("running"),
+		},
+		{
+			Description: "queued",
+			Kind:        "test",
+			State:       []byte("queued"),
+		},
+	}, checkpoints)
+}
+
+func TestShutdownCancelled(t *testing.T) {
+	m := NewManager()
+
+	// jobs cancelled before the shutdown are not checkpointed
+	finish := make(chan struct{})
+	stopping := newTestResumableExec(finish)
+	stoppingID := m.Add(context.Background(), "stopping", stopping)
+
+	cancelled := newTestResumableExec(make(chan struct{}))
+	cancelledID := m.Add(context.Background(), "cancelled", cancelled)
+
+	time.Sleep(sleepTime)
+
+	m.CancelJob(stoppingID)
+	m.CancelJob(cancelledID)
+
+	assert := assert.New(t)
+	assert.Equal(StatusStopping, m.GetJob(stoppingID).Status)
+
+	close(finish)
+
+	checkpoints := m.Shutdown(time.Second)
+	assert.Empty(checkpoints)
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package job
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and system CPU time used by the process
+// and its terminated child processes.
+func processCPUTime() time.Duration {
+	var ret time.Duration
+	for _, who := range []int{syscall.RUSAGE_SELF, syscall.RUSAGE_CHILDREN} {
+		var ru syscall.Rusage
+		if err := syscall.Getrusage(who, &ru); err != nil {
+			continue
+		}
+
+		ret += time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+	}
+
+	return ret
+}
@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package job
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and kernel CPU time used by the process.
+// The CPU time of child processes is not included.
+func processCPUTime() time.Duration {
+	h, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+
+	// durations are in 100-nanosecond intervals
+	toDuration := func(ft syscall.Filetime) time.Duration {
+		return time.Duration((int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)) * 100)
+	}
+
+	return toDuration(kernel) + toDuration(user)
+}
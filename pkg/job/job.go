@@ -54,6 +54,9 @@ type Job struct {
 	// details of the current operations of the job
 	Details     []string
 	Description string
+	// Type groups jobs for resource accounting. It is derived from the
+	// description.
+	Type string
 	// Progress in terms of 0 - 1.
 	Progress  float64
 	StartTime *time.Time
@@ -61,10 +64,22 @@ type Job struct {
 	AddTime   time.Time
 	Error     *string
 
+	// CPUTime is set when the job ends. See Usage.CPUTime.
+	CPUTime        time.Duration
+	BytesProcessed int64
+	// EstimatedEndTime is the predicted end time of a queued or running job.
+	// It is nil if there is not enough information to predict it.
+	EstimatedEndTime *time.Time
+	// Results are the outcomes of the individual items processed by a batch
+	// job, in the order they were processed.
+	Results []ItemResult
+
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
+	done       chan struct{}
 	isStarted  bool // true if job was started via Start(), false if via Add()
+	cpuStart   time.Duration
 }
 
 // TimeElapsed returns the total time elapsed for the job.
@@ -27,6 +27,8 @@ type Manager struct {
 
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
+
+	stats *statsStore
 }
 
 // NewManager initialises and returns a new Manager.
@@ -34,6 +36,7 @@ func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
 		updateThrottleLimit: defaultThrottleLimit,
+		stats:               newStatsStore(),
 	}
 
 	ret.notEmpty = sync.NewCond(&ret.mutex)
@@ -50,6 +53,64 @@ func (m *Manager) Stop() {
 	close(m.stop)
 }
 
+// Shutdown cancels all jobs and stops the dispatcher, waiting up to timeout
+// for the running jobs to stop. Returns the checkpoints of the resumable jobs
+// that did not finish.
+func (m *Manager) Shutdown(timeout time.Duration) []Checkpoint {
+	m.mutex.Lock()
+	var resumable []*Job
+	var running []chan struct{}
+	for _, j := range m.queue {
+		// jobs cancelled by the user before the shutdown are not resumed
+		userCancelled := j.Status == StatusStopping || j.Status == StatusCancelled
+		if _, ok := j.exec.(Resumable); ok && !userCancelled {
+			resumable = append(resumable, j)
+		}
+		if j.Status == StatusRunning {
+			running = append(running, j.done)
+		}
+	}
+	m.mutex.Unlock()
+
+	m.Stop()
+
+	deadline := time.After(timeout)
+wait:
+	for _, done := range running {
+		select {
+		case <-done:
+		case <-deadline:
+			logger.Warnf("Timed out waiting for jobs to stop")
+			break wait
+		}
+	}
+
+	var ret []Checkpoint
+	for _, j := range resumable {
+		m.mutex.Lock()
+		status := j.Status
+		m.mutex.Unlock()
+
+		if status == StatusFinished || status == StatusFailed {
+			continue
+		}
+
+		kind, state, err := j.exec.(Resumable).Checkpoint()
+		if err != nil {
+			logger.Errorf("Error checkpointing job %d - %s: %v", j.ID, j.Description, err)
+			continue
+		}
+
+		ret = append(ret, Checkpoint{
+			Description: j.Description,
+			Kind:        kind,
+			State:       state,
+		})
+	}
+
+	return ret
+}
+
 // Add queues a job.
 func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
@@ -61,6 +122,7 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 		ID:          m.nextID(),
 		Status:      StatusReady,
 		Description: description,
+		Type:        jobType(description),
 		AddTime:     t,
 		exec:        e,
 		outerCtx:    ctx,
@@ -90,6 +152,7 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 		ID:          m.nextID(),
 		Status:      StatusReady,
 		Description: description,
+		Type:        jobType(description),
 		AddTime:     t,
 		exec:        e,
 		outerCtx:    ctx,
@@ -111,7 +174,7 @@ func (m *Manager) notifyNewJob(j *Job) {
 	for _, s := range m.subscriptions {
 		// don't block if channel is full
 		select {
-		case s.newJob <- *j:
+		case s.newJob <- m.copyJob(j):
 		default:
 		}
 	}
@@ -183,11 +246,13 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	t := time.Now()
 	j.StartTime = &t
 	j.Status = StatusRunning
+	j.cpuStart = processCPUTime()
 
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
 	j.cancelFunc = cancelFunc
 
 	done = make(chan struct{})
+	j.done = done
 	go m.executeJob(ctx, j, done)
 
 	m.notifyJobUpdate(j)
@@ -228,6 +293,16 @@ func (m *Manager) onJobFinish(job *Job) {
 	}
 	t := time.Now()
 	job.EndTime = &t
+	job.CPUTime = processCPUTime() - job.cpuStart
+
+	// only successful runs are representative of the job type
+	if job.Status == StatusFinished && job.StartTime != nil {
+		m.stats.add(job.Type, Usage{
+			WallTime:       t.Sub(*job.StartTime),
+			CPUTime:        job.CPUTime,
+			BytesProcessed: job.BytesProcessed,
+		}, t)
+	}
 
 	// Remove jobs that were started via Start() from the queue
 	if job.isStarted {
@@ -324,7 +399,7 @@ func (m *Manager) GetJob(id int) *Job {
 	_, j := m.getJob(append(m.queue, m.graveyard...), id)
 	if j != nil {
 		// make a copy of the job and return the pointer
-		jCopy := *j
+		jCopy := m.copyJob(j)
 		return &jCopy
 	}
 
@@ -339,13 +414,104 @@ func (m *Manager) GetQueue() []Job {
 	var ret []Job
 
 	for _, j := range m.queue {
-		jCopy := *j
-		ret = append(ret, jCopy)
+		ret = append(ret, m.copyJob(j))
 	}
 
 	return ret
 }
 
+// GetStats returns the average resource usage of each job type.
+func (m *Manager) GetStats() []TypeStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.stats.all()
+}
+
+// copyJob returns a copy of the job with the estimated end time set.
+func (m *Manager) copyJob(j *Job) Job {
+	// assumes lock held
+	ret := *j
+	ret.EstimatedEndTime = m.estimatedEndTime(j, time.Now())
+	return ret
+}
+
+// estimatedEndTime predicts the end time of a queued or running job. Queued
+// jobs are run one at a time in queue order, so the end time of a queued job
+// depends on the jobs ahead of it.
+func (m *Manager) estimatedEndTime(j *Job, now time.Time) *time.Time {
+	// assumes lock held
+	switch j.Status {
+	case StatusRunning:
+		return m.runningEndTime(j, now)
+	case StatusReady:
+	default:
+		return nil
+	}
+
+	start := now
+	for _, qj := range m.queue {
+		if qj == j {
+			break
+		}
+
+		if qj.isStarted {
+			continue
+		}
+
+		var end *time.Time
+		switch qj.Status {
+		case StatusRunning:
+			end = m.runningEndTime(qj, now)
+		case StatusReady:
+			end = m.queuedEndTime(qj, start)
+		default:
+			continue
+		}
+
+		if end == nil {
+			return nil
+		}
+		start = *end
+	}
+
+	return m.queuedEndTime(j, start)
+}
+
+func (m *Manager) queuedEndTime(j *Job, start time.Time) *time.Time {
+	s := m.stats.get(j.Type)
+	if s == nil {
+		return nil
+	}
+
+	ret := start.Add(s.WallTime)
+	return &ret
+}
+
+func (m *Manager) runningEndTime(j *Job, now time.Time) *time.Time {
+	elapsed := now.Sub(*j.StartTime)
+
+	// extrapolate from the progress if known, otherwise use the average
+	// duration of the job type
+	var remaining time.Duration
+	if j.Progress > 0 {
+		remaining = time.Duration(float64(elapsed) * (1 - j.Progress) / j.Progress)
+	} else {
+		s := m.stats.get(j.Type)
+		if s == nil {
+			return nil
+		}
+
+		remaining = s.WallTime - elapsed
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	ret := now.Add(remaining)
+	return &ret
+}
+
 // Subscribe subscribes to changes to jobs in the manager queue.
 func (m *Manager) Subscribe(ctx context.Context) *ManagerSubscription {
 	m.mutex.Lock()
@@ -385,7 +551,7 @@ func (m *Manager) notifyJobUpdate(j *Job) {
 	for _, s := range m.subscriptions {
 		// don't block if channel is full
 		select {
-		case s.updatedJob <- *j:
+		case s.updatedJob <- m.copyJob(j):
 		default:
 		}
 	}
@@ -405,6 +571,20 @@ func (u *updater) notifyUpdate() {
 	u.updateTimer = nil
 }
 
+func (u *updater) addBytes(n int64) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.BytesProcessed += n
+}
+
+func (u *updater) addResult(r ItemResult) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.Results = append(u.job.Results, r)
+}
+
 func (u *updater) updateProgress(progress float64, details []string) {
 	u.m.mutex.Lock()
 	defer u.m.mutex.Unlock()
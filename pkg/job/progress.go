@@ -150,6 +150,18 @@ func (p *Progress) AddProcessed(v int) {
 	p.calculatePercent()
 }
 
+// AddBytes adds to the number of bytes processed by the job. This is used
+// for resource accounting.
+func (p *Progress) AddBytes(n int64) {
+	p.updater.addBytes(n)
+}
+
+// ItemDone records the result of processing the item with the given ID.
+// The item failed if err is not nil.
+func (p *Progress) ItemDone(id string, err error) {
+	p.updater.addResult(newItemResult(id, err))
+}
+
 func (p *Progress) addTask(t *task) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
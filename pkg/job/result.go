@@ -0,0 +1,113 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ItemStatus is the outcome of processing a single item of a batch job.
+type ItemStatus string
+
+const (
+	// ItemStatusSucceeded means that the item was processed successfully.
+	ItemStatusSucceeded ItemStatus = "SUCCEEDED"
+	// ItemStatusFailed means that processing the item failed.
+	ItemStatusFailed ItemStatus = "FAILED"
+)
+
+// ItemResult is the outcome of processing a single item, such as a scene,
+// of a batch job.
+type ItemResult struct {
+	// ID is the ID of the entity that was processed.
+	ID     string
+	Status ItemStatus
+	Error  *string
+}
+
+func newItemResult(id string, err error) ItemResult {
+	ret := ItemResult{
+		ID:     id,
+		Status: ItemStatusSucceeded,
+	}
+
+	if err != nil {
+		errStr := err.Error()
+		ret.Status = ItemStatusFailed
+		ret.Error = &errStr
+	}
+
+	return ret
+}
+
+// FailedItems returns the IDs of the items whose last result is a failure,
+// in the order they were first processed.
+func FailedItems(results []ItemResult) []string {
+	last := make(map[string]ItemStatus)
+	var order []string
+	for _, r := range results {
+		if _, seen := last[r.ID]; !seen {
+			order = append(order, r.ID)
+		}
+		last[r.ID] = r.Status
+	}
+
+	var ret []string
+	for _, id := range order {
+		if last[id] == ItemStatusFailed {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
+
+// Retryable is implemented by batch jobs that can be run again for a subset
+// of their items.
+type Retryable interface {
+	JobExec
+	// Retry returns a job that processes only the items with the given IDs.
+	Retry(ids []string) JobExec
+}
+
+var (
+	ErrJobNotFound     = errors.New("job not found")
+	ErrJobNotDone      = errors.New("job has not completed")
+	ErrJobNotRetryable = errors.New("job does not support retrying items")
+	ErrNoFailedItems   = errors.New("job has no failed items")
+)
+
+// RetryFailed queues a new job that processes only the items that failed in
+// the completed job with the provided id. Returns the ID of the new job.
+func (m *Manager) RetryFailed(ctx context.Context, id int) (int, error) {
+	m.mutex.Lock()
+	_, j := m.getJob(append(m.queue, m.graveyard...), id)
+	if j == nil {
+		m.mutex.Unlock()
+		return 0, fmt.Errorf("%w: %d", ErrJobNotFound, id)
+	}
+
+	status := j.Status
+	description := j.Description
+	exec := j.exec
+	failed := FailedItems(j.Results)
+	m.mutex.Unlock()
+
+	switch status {
+	case StatusFinished, StatusFailed, StatusCancelled:
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrJobNotDone, id)
+	}
+
+	retryable, ok := exec.(Retryable)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrJobNotRetryable, id)
+	}
+
+	if len(failed) == 0 {
+		return 0, fmt.Errorf("%w: %d", ErrNoFailedItems, id)
+	}
+
+	description = fmt.Sprintf("%s (retrying %d failed items)", description, len(failed))
+	return m.Add(ctx, description, retryable.Retry(failed)), nil
+}
@@ -0,0 +1,87 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailedItems(t *testing.T) {
+	results := []ItemResult{
+		newItemResult("1", nil),
+		newItemResult("2", errors.New("failed")),
+		newItemResult("3", errors.New("failed")),
+		newItemResult("3", nil),
+		newItemResult("4", errors.New("failed")),
+	}
+
+	assert.Equal(t, []string{"2", "4"}, FailedItems(results))
+	assert.Nil(t, FailedItems(nil))
+}
+
+type retryableExec struct {
+	ids     []string
+	retried []string
+}
+
+func (e *retryableExec) Execute(ctx context.Context, p *Progress) error {
+	for _, id := range e.ids {
+		var err error
+		if id != "1" {
+			err = errors.New("failed")
+		}
+		p.ItemDone(id, err)
+	}
+	return nil
+}
+
+func (e *retryableExec) Retry(ids []string) JobExec {
+	e.retried = ids
+	return &retryableExec{}
+}
+
+func waitForJob(m *Manager, id int) *Job {
+	for i := 0; i < 100; i++ {
+		j := m.GetJob(id)
+		if j.EndTime != nil {
+			return j
+		}
+		time.Sleep(sleepTime)
+	}
+	return m.GetJob(id)
+}
+
+func TestRetryFailed(t *testing.T) {
+	m := NewManager()
+	ctx := context.Background()
+
+	exec := &retryableExec{ids: []string{"1", "2", "3"}}
+	jobID := m.Add(ctx, "batch", exec)
+
+	j := waitForJob(m, jobID)
+	assert.Len(t, j.Results, 3)
+	assert.Equal(t, ItemStatusSucceeded, j.Results[0].Status)
+	assert.Equal(t, ItemStatusFailed, j.Results[1].Status)
+	assert.Equal(t, "failed", *j.Results[1].Error)
+
+	retryID, err := m.RetryFailed(ctx, jobID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, jobID, retryID)
+	assert.Equal(t, []string{"2", "3"}, exec.retried)
+
+	// the retry job has no failed items
+	waitForJob(m, retryID)
+	_, err = m.RetryFailed(ctx, retryID)
+	assert.ErrorIs(t, err, ErrNoFailedItems)
+
+	_, err = m.RetryFailed(ctx, 100)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	plainID := m.Add(ctx, "plain", newTestExec(nil))
+	waitForJob(m, plainID)
+	_, err = m.RetryFailed(ctx, plainID)
+	assert.ErrorIs(t, err, ErrJobNotRetryable)
+}
@@ -0,0 +1,114 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StageStats is the throughput of a single stage of a pipeline.
+type StageStats struct {
+	Name string
+	// Count is the number of items processed by the stage.
+	Count int
+	// Busy is the time spent processing items, summed across all workers.
+	Busy time.Duration
+	// Elapsed is the time between the start of the first item and the end
+	// of the last item.
+	Elapsed time.Duration
+
+	first time.Time
+	last  time.Time
+}
+
+// PerSecond returns the number of items processed per second of elapsed
+// time.
+func (s StageStats) PerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(s.Count) / s.Elapsed.Seconds()
+}
+
+func (s StageStats) String() string {
+	var avg time.Duration
+	if s.Count > 0 {
+		avg = s.Busy / time.Duration(s.Count)
+	}
+
+	return fmt.Sprintf("%s: %d items in %s (%.1f/s, %s average)", s.Name, s.Count, s.Elapsed.Round(time.Millisecond), s.PerSecond(), avg.Round(time.Microsecond))
+}
+
+// StageTimer records the throughput of the stages of a pipeline. It is safe
+// for concurrent use. A nil StageTimer records nothing.
+type StageTimer struct {
+	mutex  sync.Mutex
+	stages []*StageStats
+}
+
+func NewStageTimer() *StageTimer {
+	return &StageTimer{}
+}
+
+// Start starts timing an item of the named stage. The returned function
+// must be called when the item is finished.
+func (t *StageTimer) Start(stage string) func() {
+	if t == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		t.add(stage, start, time.Now())
+	}
+}
+
+func (t *StageTimer) add(stage string, start time.Time, end time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var s *StageStats
+	for _, ss := range t.stages {
+		if ss.Name == stage {
+			s = ss
+			break
+		}
+	}
+
+	if s == nil {
+		s = &StageStats{
+			Name:  stage,
+			first: start,
+		}
+		t.stages = append(t.stages, s)
+	}
+
+	s.Count++
+	s.Busy += end.Sub(start)
+	if start.Before(s.first) {
+		s.first = start
+	}
+	if end.After(s.last) {
+		s.last = end
+	}
+	s.Elapsed = s.last.Sub(s.first)
+}
+
+// Stats returns the throughput of each stage, in the order that the stages
+// were first finished.
+func (t *StageTimer) Stats() []StageStats {
+	if t == nil {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ret := make([]StageStats, len(t.stages))
+	for i, s := range t.stages {
+		ret[i] = *s
+	}
+
+	return ret
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageTimer(t *testing.T) {
+	assert := assert.New(t)
+
+	timer := NewStageTimer()
+	now := time.Now()
+
+	// overlapping items of the same stage
+	timer.add("hash", now, now.Add(2*time.Second))
+	timer.add("hash", now.Add(time.Second), now.Add(4*time.Second))
+	timer.add("phash", now.Add(3*time.Second), now.Add(4*time.Second))
+
+	stats := timer.Stats()
+	assert.Len(stats, 2)
+
+	assert.Equal("hash", stats[0].Name)
+	assert.Equal(2, stats[0].Count)
+	assert.Equal(5*time.Second, stats[0].Busy)
+	assert.Equal(4*time.Second, stats[0].Elapsed)
+	assert.Equal(0.5, stats[0].PerSecond())
+
+	assert.Equal("phash", stats[1].Name)
+	assert.Equal(1, stats[1].Count)
+	assert.Equal(1.0, stats[1].PerSecond())
+}
+
+func TestStageTimerNil(t *testing.T) {
+	var timer *StageTimer
+	timer.Start("hash")()
+	assert.Nil(t, timer.Stats())
+}
@@ -0,0 +1,127 @@
+package job
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// maxStatsSamples is the number of most recent runs averaged per job type.
+const maxStatsSamples = 10
+
+// maxStatsTypes limits the number of job types for which stats are kept.
+const maxStatsTypes = 100
+
+var jobTypeNumbers = regexp.MustCompile(`\d+`)
+
+// jobType returns the type of a job with the provided description. Numbers
+// are replaced so that jobs for different objects share the same type.
+func jobType(description string) string {
+	return jobTypeNumbers.ReplaceAllString(description, "#")
+}
+
+// Usage is the resource usage of a single job run.
+type Usage struct {
+	WallTime time.Duration
+	// CPUTime is the CPU time used by the process and its child processes
+	// while the job was running. It includes the CPU time of any jobs
+	// running concurrently.
+	CPUTime        time.Duration
+	BytesProcessed int64
+}
+
+// TypeStats is the average resource usage of the most recent finished runs
+// of a job type.
+type TypeStats struct {
+	Type           string
+	Runs           int
+	WallTime       time.Duration
+	CPUTime        time.Duration
+	BytesProcessed int64
+	LastRun        time.Time
+}
+
+type typeSamples struct {
+	samples []Usage
+	lastRun time.Time
+}
+
+type statsStore struct {
+	types map[string]*typeSamples
+}
+
+func newStatsStore() *statsStore {
+	return &statsStore{
+		types: make(map[string]*typeSamples),
+	}
+}
+
+func (s *statsStore) add(jobType string, u Usage, t time.Time) {
+	ts := s.types[jobType]
+	if ts == nil {
+		if len(s.types) >= maxStatsTypes {
+			s.removeOldest()
+		}
+
+		ts = &typeSamples{}
+		s.types[jobType] = ts
+	}
+
+	ts.samples = append(ts.samples, u)
+	if len(ts.samples) > maxStatsSamples {
+		ts.samples = ts.samples[1:]
+	}
+	ts.lastRun = t
+}
+
+func (s *statsStore) removeOldest() {
+	var oldest string
+	var oldestTime time.Time
+	for k, v := range s.types {
+		if oldest == "" || v.lastRun.Before(oldestTime) {
+			oldest = k
+			oldestTime = v.lastRun
+		}
+	}
+
+	delete(s.types, oldest)
+}
+
+func (s *statsStore) get(jobType string) *TypeStats {
+	ts := s.types[jobType]
+	if ts == nil || len(ts.samples) == 0 {
+		return nil
+	}
+
+	ret := &TypeStats{
+		Type:    jobType,
+		Runs:    len(ts.samples),
+		LastRun: ts.lastRun,
+	}
+
+	for _, u := range ts.samples {
+		ret.WallTime += u.WallTime
+		ret.CPUTime += u.CPUTime
+		ret.BytesProcessed += u.BytesProcessed
+	}
+
+	n := len(ts.samples)
+	ret.WallTime /= time.Duration(n)
+	ret.CPUTime /= time.Duration(n)
+	ret.BytesProcessed /= int64(n)
+
+	return ret
+}
+
+func (s *statsStore) all() []TypeStats {
+	var ret []TypeStats
+	for k := range s.types {
+		ret = append(ret, *s.get(k))
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Type < ret[j].Type
+	})
+
+	return ret
+}
@@ -0,0 +1,84 @@
+package job
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobType(t *testing.T) {
+	assert.Equal(t, "Generating screenshot for scene id #", jobType("Generating screenshot for scene id 123"))
+	assert.Equal(t, "Generating...", jobType("Generating..."))
+}
+
+func TestStatsStore(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newStatsStore()
+	now := time.Now()
+
+	assert.Nil(s.get("Generating..."))
+
+	s.add("Generating...", Usage{WallTime: time.Second, CPUTime: 2 * time.Second, BytesProcessed: 100}, now)
+	s.add("Generating...", Usage{WallTime: 3 * time.Second, CPUTime: 4 * time.Second, BytesProcessed: 300}, now)
+
+	got := s.get("Generating...")
+	assert.Equal(&TypeStats{
+		Type:           "Generating...",
+		Runs:           2,
+		WallTime:       2 * time.Second,
+		CPUTime:        3 * time.Second,
+		BytesProcessed: 200,
+		LastRun:        now,
+	}, got)
+
+	// only the most recent samples are averaged
+	for i := 0; i < maxStatsSamples; i++ {
+		s.add("Generating...", Usage{WallTime: 10 * time.Second}, now)
+	}
+	got = s.get("Generating...")
+	assert.Equal(maxStatsSamples, got.Runs)
+	assert.Equal(10*time.Second, got.WallTime)
+
+	// the least recently run type is removed when the limit is reached
+	for i := 0; i < maxStatsTypes; i++ {
+		s.add(fmt.Sprintf("type %d", i), Usage{}, now.Add(time.Duration(i+1)*time.Second))
+	}
+	assert.Nil(s.get("Generating..."))
+	assert.Len(s.all(), maxStatsTypes)
+}
+
+func TestEstimatedEndTime(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Manager{stats: newStatsStore()}
+	now := time.Now()
+	m.stats.add("Generating...", Usage{WallTime: time.Minute}, now)
+
+	start := now.Add(-time.Minute)
+	running := &Job{Type: "Scanning...", Status: StatusRunning, StartTime: &start, Progress: 0.5}
+	queued := &Job{Type: "Generating...", Status: StatusReady}
+	unknown := &Job{Type: "Cleaning...", Status: StatusReady}
+	m.queue = []*Job{running, queued, unknown}
+
+	// running job is extrapolated from its progress
+	got := m.estimatedEndTime(running, now)
+	if assert.NotNil(got) {
+		assert.Equal(now.Add(time.Minute), *got)
+	}
+
+	// queued job starts after the running job
+	got = m.estimatedEndTime(queued, now)
+	if assert.NotNil(got) {
+		assert.Equal(now.Add(2*time.Minute), *got)
+	}
+
+	// no stats for the job type
+	assert.Nil(m.estimatedEndTime(unknown, now))
+
+	// jobs after a job with an unknown end time cannot be estimated
+	m.queue = []*Job{running, unknown, queued}
+	assert.Nil(m.estimatedEndTime(queued, now))
+}
@@ -85,6 +85,28 @@ func (tq *TaskQueue) Add(description string, fn func(ctx context.Context)) {
 	}
 }
 
+// AddBlocking queues a task, waiting until there is space in the queue. This
+// bounds the number of pending tasks, so that the caller is slowed down to
+// the rate at which the tasks are executed. The task is ignored if the queue
+// has stopped.
+func (tq *TaskQueue) AddBlocking(description string, fn func(ctx context.Context)) {
+	// Channel may be closed, ignore the panic
+	defer func() {
+		_ = recover()
+	}()
+
+	select {
+	case tq.tasks <- taskExec{
+		task: task{
+			description: description,
+		},
+		fn: fn,
+	}:
+	case <-tq.done:
+		// TaskQueue is closed, ignore
+	}
+}
+
 func (tq *TaskQueue) Close() {
 	close(tq.tasks)
 	// wait for all tasks to finish
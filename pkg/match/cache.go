@@ -6,13 +6,53 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 )
 
-const singleFirstCharacterRegex = `^[\p{L}][.\-_ ]`
+const (
+	singleFirstCharacterRegex = `^[\p{L}][.\-_ ]`
+	nonASCIIRegex             = `[^\x00-\x7F]`
+)
 
 // Cache is used to cache queries that should not change across an autotag process.
 type Cache struct {
+	// Performers, Studios and Tags are the options used to match each type
+	// of entity. They do not change across an autotag process either.
+	Performers Options
+	Studios    Options
+	Tags       Options
+
 	singleCharPerformers []*models.Performer
 	singleCharStudios    []*models.Studio
 	singleCharTags       []*models.Tag
+
+	nonASCIIPerformers []*models.Performer
+	nonASCIIStudios    []*models.Studio
+	nonASCIITags       []*models.Tag
+}
+
+// PerformerOptions returns the options used to match performers. Returns the
+// default options if c is nil.
+func (c *Cache) PerformerOptions() Options {
+	if c == nil {
+		return Options{}
+	}
+	return c.Performers
+}
+
+// StudioOptions returns the options used to match studios. Returns the
+// default options if c is nil.
+func (c *Cache) StudioOptions() Options {
+	if c == nil {
+		return Options{}
+	}
+	return c.Studios
+}
+
+// TagOptions returns the options used to match tags. Returns the default
+// options if c is nil.
+func (c *Cache) TagOptions() Options {
+	if c == nil {
+		return Options{}
+	}
+	return c.Tags
 }
 
 // getSingleLetterPerformers returns all performers with names that start with single character words.
@@ -124,3 +164,125 @@ func getSingleLetterTags(ctx context.Context, c *Cache, reader models.TagAutoTag
 
 	return c.singleCharTags, nil
 }
+
+// getNonASCIIPerformers returns all performers with names or aliases that
+// contain non-ASCII characters. When transliterating, these cannot be found
+// by querying for the start of the words in the path, as "Élodie" does not
+// start with "el".
+func getNonASCIIPerformers(ctx context.Context, c *Cache, reader models.PerformerAutoTagQueryer) ([]*models.Performer, error) {
+	if c == nil {
+		c = &Cache{}
+	}
+
+	if c.nonASCIIPerformers == nil {
+		pp := -1
+		ignoreAutoTag := false
+		performers, _, err := reader.Query(ctx, &models.PerformerFilterType{
+			Name: &models.StringCriterionInput{
+				Value:    nonASCIIRegex,
+				Modifier: models.CriterionModifierMatchesRegex,
+			},
+			IgnoreAutoTag: &ignoreAutoTag,
+			OperatorFilter: models.OperatorFilter[models.PerformerFilterType]{
+				Or: &models.PerformerFilterType{
+					Aliases: &models.StringCriterionInput{
+						Value:    nonASCIIRegex,
+						Modifier: models.CriterionModifierMatchesRegex,
+					},
+					IgnoreAutoTag: &ignoreAutoTag,
+				},
+			},
+		}, &models.FindFilterType{
+			PerPage: &pp,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.nonASCIIPerformers = make([]*models.Performer, 0, len(performers))
+		c.nonASCIIPerformers = append(c.nonASCIIPerformers, performers...)
+	}
+
+	return c.nonASCIIPerformers, nil
+}
+
+// getNonASCIIStudios returns all studios with names or aliases that contain
+// non-ASCII characters. See getNonASCIIPerformers for details.
+func getNonASCIIStudios(ctx context.Context, c *Cache, reader models.StudioAutoTagQueryer) ([]*models.Studio, error) {
+	if c == nil {
+		c = &Cache{}
+	}
+
+	if c.nonASCIIStudios == nil {
+		pp := -1
+		ignoreAutoTag := false
+		studios, _, err := reader.Query(ctx, &models.StudioFilterType{
+			Name: &models.StringCriterionInput{
+				Value:    nonASCIIRegex,
+				Modifier: models.CriterionModifierMatchesRegex,
+			},
+			IgnoreAutoTag: &ignoreAutoTag,
+			OperatorFilter: models.OperatorFilter[models.StudioFilterType]{
+				Or: &models.StudioFilterType{
+					Aliases: &models.StringCriterionInput{
+						Value:    nonASCIIRegex,
+						Modifier: models.CriterionModifierMatchesRegex,
+					},
+					IgnoreAutoTag: &ignoreAutoTag,
+				},
+			},
+		}, &models.FindFilterType{
+			PerPage: &pp,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.nonASCIIStudios = make([]*models.Studio, 0, len(studios))
+		c.nonASCIIStudios = append(c.nonASCIIStudios, studios...)
+	}
+
+	return c.nonASCIIStudios, nil
+}
+
+// getNonASCIITags returns all tags with names or aliases that contain
+// non-ASCII characters. See getNonASCIIPerformers for details.
+func getNonASCIITags(ctx context.Context, c *Cache, reader models.TagAutoTagQueryer) ([]*models.Tag, error) {
+	if c == nil {
+		c = &Cache{}
+	}
+
+	if c.nonASCIITags == nil {
+		pp := -1
+		ignoreAutoTag := false
+		tags, _, err := reader.Query(ctx, &models.TagFilterType{
+			Name: &models.StringCriterionInput{
+				Value:    nonASCIIRegex,
+				Modifier: models.CriterionModifierMatchesRegex,
+			},
+			IgnoreAutoTag: &ignoreAutoTag,
+			OperatorFilter: models.OperatorFilter[models.TagFilterType]{
+				Or: &models.TagFilterType{
+					Aliases: &models.StringCriterionInput{
+						Value:    nonASCIIRegex,
+						Modifier: models.CriterionModifierMatchesRegex,
+					},
+					IgnoreAutoTag: &ignoreAutoTag,
+				},
+			},
+		}, &models.FindFilterType{
+			PerPage: &pp,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.nonASCIITags = make([]*models.Tag, 0, len(tags))
+		c.nonASCIITags = append(c.nonASCIITags, tags...)
+	}
+
+	return c.nonASCIITags, nil
+}
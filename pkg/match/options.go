@@ -0,0 +1,87 @@
+package match
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Mode changes how names are matched against paths.
+type Mode string
+
+const (
+	// ModeWordBoundary requires the words of a name to be separated in the
+	// path, and the name to be delimited by characters other than hyphens
+	// and apostrophes. With this mode "foo bar" does not match "foobar", and
+	// "ann" does not match "ann-marie".
+	ModeWordBoundary Mode = "WORD_BOUNDARY"
+	// ModeTransliterate matches names and paths with diacritics removed and
+	// Cyrillic transliterated to Latin, so that "Zoë" matches "zoe" and
+	// "Анна" matches "anna".
+	ModeTransliterate Mode = "TRANSLITERATE"
+	// ModeAliases matches aliases as well as names. Where the same part of
+	// the path matches both the name of one candidate and the alias of
+	// another, only the name match is used.
+	ModeAliases Mode = "ALIASES"
+)
+
+var AllModes = []Mode{
+	ModeWordBoundary,
+	ModeTransliterate,
+	ModeAliases,
+}
+
+func (e Mode) IsValid() bool {
+	switch e {
+	case ModeWordBoundary, ModeTransliterate, ModeAliases:
+		return true
+	}
+	return false
+}
+
+func (e Mode) String() string {
+	return string(e)
+}
+
+func (e *Mode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Mode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AutoTagMatchMode", str)
+	}
+	return nil
+}
+
+func (e Mode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Options are the matching modes used for one type of entity. The zero
+// value matches names in the default way.
+type Options struct {
+	WordBoundary  bool
+	Transliterate bool
+	Aliases       bool
+}
+
+// NewOptions returns the options with the given modes set. Invalid modes
+// are ignored.
+func NewOptions(modes []Mode) Options {
+	var ret Options
+	for _, m := range modes {
+		switch m {
+		case ModeWordBoundary:
+			ret.WordBoundary = true
+		case ModeTransliterate:
+			ret.Transliterate = true
+		case ModeAliases:
+			ret.Aliases = true
+		}
+	}
+
+	return ret
+}
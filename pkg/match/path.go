@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -85,18 +86,53 @@ func allASCII(s string) bool {
 	return true
 }
 
+// fold returns s as it is matched with the options.
+func (o Options) fold(s string) string {
+	if o.Transliterate {
+		return Transliterate(s)
+	}
+	return s
+}
+
 // nameMatchesPath returns the index in the path for the right-most match.
 // Returns -1 if not found.
 func nameMatchesPath(name, path string) int {
+	return Options{}.nameMatchesPath(name, path)
+}
+
+// nameMatchesPath returns the index in the path for the right-most match.
+// Returns -1 if not found. If o.Transliterate is set, the index is in the
+// transliterated path.
+func (o Options) nameMatchesPath(name, path string) int {
+	name = o.fold(name)
+	path = o.fold(path)
+
 	// #2363 - optimisation: only use unicode character regexp if path contains
 	// unicode characters
-	re := nameToRegexp(name, !allASCII(path))
+	re := o.nameToRegexp(name, !allASCII(path))
 	return regexpMatchesPath(re, path)
 }
 
+// nameSpans returns the start and end indexes of the name in each match in
+// the path. If o.Transliterate is set, the indexes are in the transliterated
+// path.
+func (o Options) nameSpans(name, path string) [][]int {
+	name = o.fold(name)
+	path = strings.ToLower(o.fold(path))
+
+	re := o.nameToRegexp(name, !allASCII(path))
+
+	var ret [][]int
+	for _, m := range re.FindAllStringSubmatchIndex(path, -1) {
+		ret = append(ret, m[2:4])
+	}
+	return ret
+}
+
 // nameToRegexp compiles a regexp pattern to match paths from the given name.
 // Set useUnicode to true if this regexp is to be used on any strings with unicode characters.
-func nameToRegexp(name string, useUnicode bool) *regexp.Regexp {
+// The name is captured in the first group of the pattern.
+func (o Options) nameToRegexp(name string, useUnicode bool) *regexp.Regexp {
 	// escape specific regex characters
 	name = regexp.QuoteMeta(name)
 
@@ -111,13 +147,35 @@ func nameToRegexp(name string, useUnicode bool) *regexp.Regexp {
 		notWord = reNotLetterWordUnicode
 	}
 
-	reStr := strings.ReplaceAll(name, " ", separator+"*")
-	reStr = `(?:^|_|` + notWord + `)` + reStr + `(?:$|_|` + notWord + `)`
+	// words of the name may be run together in the path, unless matching
+	// on word boundaries
+	wordSeparator := separator + "*"
+	if o.WordBoundary {
+		wordSeparator = separator + "+"
+	}
+
+	reStr := strings.ReplaceAll(name, " ", wordSeparator)
+	reStr = `(?:^|_|` + notWord + `)(` + reStr + `)(?:$|_|` + notWord + `)`
 
 	re := regexp.MustCompile(reStr)
 	return re
 }
 
+// pathQueryRegex returns the regex used to query for paths that may match
+// the name. When transliterating, paths matching the transliterated name are
+// also returned. Paths are not transliterated by the query, so a name
+// without diacritics does not find paths where the name has diacritics.
+func (o Options) pathQueryRegex(name string) string {
+	ret := getPathQueryRegex(name)
+	if o.Transliterate {
+		if t := Transliterate(name); t != strings.ToLower(name) {
+			ret = `(?:` + ret + `|` + getPathQueryRegex(t) + `)`
+		}
+	}
+
+	return ret
+}
+
 func regexpMatchesPath(r *regexp.Regexp, path string) int {
 	path = strings.ToLower(path)
 	found := r.FindAllStringIndex(path, -1)
@@ -127,6 +185,80 @@ func regexpMatchesPath(r *regexp.Regexp, path string) int {
 	return found[len(found)-1][0]
 }
 
+// spansOverlap returns true if span overlaps any of the spans.
+func spansOverlap(span []int, spans [][]int) bool {
+	for _, s := range spans {
+		if span[0] < s[1] && s[0] < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCandidates returns the indexes of the names that match the path. If
+// matchAliases is true, candidates whose name does not match are matched by
+// the aliases returned by getAliases. If o.Aliases is set, an alias match is
+// ignored where each of its matches overlaps a name match of a candidate.
+func (o Options) matchCandidates(path string, names []string, matchAliases bool, getAliases func(i int) ([]string, error)) ([]int, error) {
+	type aliasMatch struct {
+		index int
+		spans [][]int
+	}
+
+	var ret []int
+	var nameSpans [][]int
+	var aliasMatches []aliasMatch
+
+	for i, name := range names {
+		if spans := o.nameSpans(name, path); len(spans) > 0 {
+			ret = append(ret, i)
+			nameSpans = append(nameSpans, spans...)
+			continue
+		}
+
+		if !matchAliases {
+			continue
+		}
+
+		aliases, err := getAliases(i)
+		if err != nil {
+			return nil, err
+		}
+
+		var spans [][]int
+		for _, alias := range aliases {
+			spans = append(spans, o.nameSpans(alias, path)...)
+		}
+
+		if len(spans) > 0 {
+			aliasMatches = append(aliasMatches, aliasMatch{index: i, spans: spans})
+		}
+	}
+
+	for _, m := range aliasMatches {
+		if !o.Aliases || slices.ContainsFunc(m.spans, func(s []int) bool {
+			return !spansOverlap(s, nameSpans)
+		}) {
+			ret = append(ret, m.index)
+		}
+	}
+
+	slices.Sort(ret)
+	return ret, nil
+}
+
+// getPathQueryWords returns the words used to query for candidates matching
+// the path. When transliterating, the words of the transliterated path are
+// included.
+func (o Options) getPathQueryWords(path string, trimExt bool) []string {
+	ret := getPathWords(path, trimExt)
+	if o.Transliterate {
+		ret = sliceutil.AppendUniques(ret, getPathWords(Transliterate(path), trimExt))
+	}
+
+	return ret
+}
+
 func getPerformers(ctx context.Context, words []string, performerReader models.PerformerAutoTagQueryer, cache *Cache) ([]*models.Performer, error) {
 	performers, err := performerReader.QueryForAutoTag(ctx, words)
 	if err != nil {
@@ -138,42 +270,65 @@ func getPerformers(ctx context.Context, words []string, performerReader models.P
 		return nil, err
 	}
 
-	return append(performers, swPerformers...), nil
+	performers = append(performers, swPerformers...)
+
+	if cache.PerformerOptions().Transliterate {
+		naPerformers, err := getNonASCIIPerformers(ctx, cache, performerReader)
+		if err != nil {
+			return nil, err
+		}
+
+		performers = append(performers, naPerformers...)
+	}
+
+	return uniqueByID(performers, func(p *models.Performer) int { return p.ID }), nil
+}
+
+// uniqueByID returns the values without those with the same id as an
+// earlier value.
+func uniqueByID[T any](values []T, id func(T) int) []T {
+	seen := make(map[int]bool)
+	var ret []T
+	for _, v := range values {
+		if !seen[id(v)] {
+			seen[id(v)] = true
+			ret = append(ret, v)
+		}
+	}
+	return ret
 }
 
+// PathToPerformers returns the performers that match the given path, using
+// the performer options of the cache. Aliases are only matched with the
+// aliases mode.
 func PathToPerformers(ctx context.Context, path string, reader models.PerformerAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Performer, error) {
-	words := getPathWords(path, trimExt)
+	o := cache.PerformerOptions()
+	words := o.getPathQueryWords(path, trimExt)
 
 	performers, err := getPerformers(ctx, words, reader, cache)
 	if err != nil {
 		return nil, err
 	}
 
-	var ret []*models.Performer
-	for _, p := range performers {
-		matches := false
-		if nameMatchesPath(p.Name, path) != -1 {
-			matches = true
-		}
+	names := make([]string, len(performers))
+	for i, p := range performers {
+		names[i] = p.Name
+	}
 
-		// TODO - disabled alias matching until we can get finer
-		// control over the matching
-		// if !matches {
-		// 	if err := p.LoadAliases(ctx, reader); err != nil {
-		// 		return nil, err
-		// 	}
-
-		// 	for _, alias := range p.Aliases.List() {
-		// 		if nameMatchesPath(alias, path) != -1 {
-		// 			matches = true
-		// 			break
-		// 		}
-		// 	}
-		// }
-
-		if matches {
-			ret = append(ret, p)
+	matched, err := o.matchCandidates(path, names, o.Aliases, func(i int) ([]string, error) {
+		p := performers[i]
+		if err := p.LoadAliases(ctx, reader); err != nil {
+			return nil, err
 		}
+		return p.Aliases.List(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.Performer
+	for _, i := range matched {
+		ret = append(ret, performers[i])
 	}
 
 	return ret, nil
@@ -190,24 +345,37 @@ func getStudios(ctx context.Context, words []string, reader models.StudioAutoTag
 		return nil, err
 	}
 
-	return append(studios, swStudios...), nil
+	studios = append(studios, swStudios...)
+
+	if cache.StudioOptions().Transliterate {
+		naStudios, err := getNonASCIIStudios(ctx, cache, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		studios = append(studios, naStudios...)
+	}
+
+	return uniqueByID(studios, func(s *models.Studio) int { return s.ID }), nil
 }
 
 // PathToStudio returns the Studio that matches the given path.
 // Where multiple matching studios are found, the one that matches the latest
-// position in the path is returned.
+// position in the path is returned. With the aliases mode, a studio matching
+// by name is returned before a studio matching by alias.
 func PathToStudio(ctx context.Context, path string, reader models.StudioAutoTagQueryer, cache *Cache, trimExt bool) (*models.Studio, error) {
-	words := getPathWords(path, trimExt)
+	o := cache.StudioOptions()
+	words := o.getPathQueryWords(path, trimExt)
 	candidates, err := getStudios(ctx, words, reader, cache)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var ret *models.Studio
-	index := -1
+	var ret, aliasRet *models.Studio
+	index, aliasIndex := -1, -1
 	for _, c := range candidates {
-		matchIndex := nameMatchesPath(c.Name, path)
+		matchIndex := o.nameMatchesPath(c.Name, path)
 		if matchIndex != -1 && matchIndex > index {
 			ret = c
 			index = matchIndex
@@ -219,14 +387,23 @@ func PathToStudio(ctx context.Context, path string, reader models.StudioAutoTagQ
 		}
 
 		for _, alias := range aliases {
-			matchIndex = nameMatchesPath(alias, path)
-			if matchIndex != -1 && matchIndex > index {
+			matchIndex = o.nameMatchesPath(alias, path)
+			if o.Aliases {
+				if matchIndex != -1 && matchIndex > aliasIndex {
+					aliasRet = c
+					aliasIndex = matchIndex
+				}
+			} else if matchIndex != -1 && matchIndex > index {
 				ret = c
 				index = matchIndex
 			}
 		}
 	}
 
+	if ret == nil {
+		ret = aliasRet
+	}
+
 	return ret, nil
 }
 
@@ -241,47 +418,53 @@ func getTags(ctx context.Context, words []string, reader models.TagAutoTagQuerye
 		return nil, err
 	}
 
-	return append(tags, swTags...), nil
+	tags = append(tags, swTags...)
+
+	if cache.TagOptions().Transliterate {
+		naTags, err := getNonASCIITags(ctx, cache, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, naTags...)
+	}
+
+	return uniqueByID(tags, func(t *models.Tag) int { return t.ID }), nil
 }
 
+// PathToTags returns the tags that match the given path by name or alias,
+// using the tag options of the cache.
 func PathToTags(ctx context.Context, path string, reader models.TagAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Tag, error) {
-	words := getPathWords(path, trimExt)
+	o := cache.TagOptions()
+	words := o.getPathQueryWords(path, trimExt)
 	tags, err := getTags(ctx, words, reader, cache)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var ret []*models.Tag
-	for _, t := range tags {
-		matches := false
-		if nameMatchesPath(t.Name, path) != -1 {
-			matches = true
-		}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
 
-		if !matches {
-			aliases, err := reader.GetAliases(ctx, t.ID)
-			if err != nil {
-				return nil, err
-			}
-			for _, alias := range aliases {
-				if nameMatchesPath(alias, path) != -1 {
-					matches = true
-					break
-				}
-			}
-		}
+	matched, err := o.matchCandidates(path, names, true, func(i int) ([]string, error) {
+		return reader.GetAliases(ctx, tags[i].ID)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if matches {
-			ret = append(ret, t)
-		}
+	var ret []*models.Tag
+	for _, i := range matched {
+		ret = append(ret, tags[i])
 	}
 
 	return ret, nil
 }
 
-func PathToScenesFn(ctx context.Context, name string, paths []string, sceneReader models.SceneQueryer, fn func(ctx context.Context, scene *models.Scene) error) error {
-	regex := getPathQueryRegex(name)
+func PathToScenesFn(ctx context.Context, name string, paths []string, o Options, sceneReader models.SceneQueryer, fn func(ctx context.Context, scene *models.Scene) error) error {
+	regex := o.pathQueryRegex(name)
 	organized := false
 	filter := models.SceneFilterType{
 		Path: &models.StringCriterionInput{
@@ -320,9 +503,9 @@ func PathToScenesFn(ctx context.Context, name string, paths []string, sceneReade
 		// paths may have unicode characters
 		const useUnicode = true
 
-		r := nameToRegexp(name, useUnicode)
+		r := o.nameToRegexp(o.fold(name), useUnicode)
 		for _, p := range scenes {
-			if regexpMatchesPath(r, p.Path) != -1 {
+			if regexpMatchesPath(r, o.fold(p.Path)) != -1 {
 				if err := fn(ctx, p); err != nil {
 					return fmt.Errorf("processing scene %s: %w", p.GetTitle(), err)
 				}
@@ -339,8 +522,8 @@ func PathToScenesFn(ctx context.Context, name string, paths []string, sceneReade
 	return nil
 }
 
-func PathToImagesFn(ctx context.Context, name string, paths []string, imageReader models.ImageQueryer, fn func(ctx context.Context, scene *models.Image) error) error {
-	regex := getPathQueryRegex(name)
+func PathToImagesFn(ctx context.Context, name string, paths []string, o Options, imageReader models.ImageQueryer, fn func(ctx context.Context, scene *models.Image) error) error {
+	regex := o.pathQueryRegex(name)
 	organized := false
 	filter := models.ImageFilterType{
 		Path: &models.StringCriterionInput{
@@ -379,9 +562,9 @@ func PathToImagesFn(ctx context.Context, name string, paths []string, imageReade
 		// paths may have unicode characters
 		const useUnicode = true
 
-		r := nameToRegexp(name, useUnicode)
+		r := o.nameToRegexp(o.fold(name), useUnicode)
 		for _, p := range images {
-			if regexpMatchesPath(r, p.Path) != -1 {
+			if regexpMatchesPath(r, o.fold(p.Path)) != -1 {
 				if err := fn(ctx, p); err != nil {
 					return fmt.Errorf("processing image %s: %w", p.GetTitle(), err)
 				}
@@ -398,8 +581,8 @@ func PathToImagesFn(ctx context.Context, name string, paths []string, imageReade
 	return nil
 }
 
-func PathToGalleriesFn(ctx context.Context, name string, paths []string, galleryReader models.GalleryQueryer, fn func(ctx context.Context, scene *models.Gallery) error) error {
-	regex := getPathQueryRegex(name)
+func PathToGalleriesFn(ctx context.Context, name string, paths []string, o Options, galleryReader models.GalleryQueryer, fn func(ctx context.Context, scene *models.Gallery) error) error {
+	regex := o.pathQueryRegex(name)
 	organized := false
 	filter := models.GalleryFilterType{
 		Path: &models.StringCriterionInput{
@@ -438,10 +621,10 @@ func PathToGalleriesFn(ctx context.Context, name string, paths []string, gallery
 		// paths may have unicode characters
 		const useUnicode = true
 
-		r := nameToRegexp(name, useUnicode)
+		r := o.nameToRegexp(o.fold(name), useUnicode)
 		for _, p := range galleries {
 			path := p.Path
-			if path != "" && regexpMatchesPath(r, path) != -1 {
+			if path != "" && regexpMatchesPath(r, o.fold(path)) != -1 {
 				if err := fn(ctx, p); err != nil {
 					return fmt.Errorf("processing gallery %s: %w", p.GetTitle(), err)
 				}
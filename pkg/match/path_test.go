@@ -1,6 +1,9 @@
 package match
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func Test_nameMatchesPath(t *testing.T) {
 	const name = "first last"
@@ -99,3 +102,155 @@ func Test_nameMatchesPath(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_nameMatchesPath(t *testing.T) {
+	tests := []struct {
+		testName string
+		options  Options
+		name     string
+		path     string
+		want     bool
+	}{
+		{
+			"run together",
+			Options{},
+			"first last",
+			"firstlast.mp4",
+			true,
+		},
+		{
+			"word boundary run together",
+			Options{WordBoundary: true},
+			"first last",
+			"firstlast.mp4",
+			false,
+		},
+		{
+			"word boundary separated",
+			Options{WordBoundary: true},
+			"first last",
+			"first.last.mp4",
+			true,
+		},
+		{
+			"diacritics",
+			Options{},
+			"zoe",
+			"Zoë.mp4",
+			false,
+		},
+		{
+			"transliterate diacritics in path",
+			Options{Transliterate: true},
+			"zoe",
+			"Zoë.mp4",
+			true,
+		},
+		{
+			"transliterate diacritics in name",
+			Options{Transliterate: true},
+			"Élodie Marçeau",
+			"elodie.marceau.mp4",
+			true,
+		},
+		{
+			"transliterate decomposed path",
+			Options{Transliterate: true},
+			"zoe",
+			"Zoë.mp4",
+			true,
+		},
+		{
+			"transliterate cyrillic name",
+			Options{Transliterate: true},
+			"Анастасия Яковлева",
+			"anastasiya_yakovleva.mp4",
+			true,
+		},
+		{
+			"transliterate cyrillic path other spelling",
+			Options{Transliterate: true},
+			"Alexey",
+			"/Алексей/video.mp4",
+			false,
+		},
+		{
+			"transliterate cyrillic path",
+			Options{Transliterate: true},
+			"Aleksey",
+			"/Алексей/video.mp4",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := tt.options.nameMatchesPath(tt.name, tt.path) != -1; got != tt.want {
+				t.Errorf("Options.nameMatchesPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_matchCandidates(t *testing.T) {
+	names := []string{"Jane", "Jane Doe", "Mary Smith"}
+	aliases := [][]string{nil, {"Jane", "JD"}, {"Mary S"}}
+	getAliases := func(i int) ([]string, error) {
+		return aliases[i], nil
+	}
+
+	tests := []struct {
+		testName     string
+		options      Options
+		matchAliases bool
+		path         string
+		want         []int
+	}{
+		{
+			"names only",
+			Options{},
+			false,
+			"jane.mary s.mp4",
+			[]int{0},
+		},
+		{
+			"aliases matched equally",
+			Options{},
+			true,
+			"jane.mary s.mp4",
+			[]int{0, 1, 2},
+		},
+		{
+			"alias overlapping name ignored",
+			Options{Aliases: true},
+			true,
+			"jane.mary s.mp4",
+			[]int{0, 2},
+		},
+		{
+			"other alias not overlapping name",
+			Options{Aliases: true},
+			true,
+			"jane.jd.mp4",
+			[]int{0, 1},
+		},
+		{
+			"alias without name match",
+			Options{Aliases: true},
+			true,
+			"mary s.mp4",
+			[]int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			got, err := tt.options.matchCandidates(tt.path, names, tt.matchAliases, getAliases)
+			if err != nil {
+				t.Errorf("Options.matchCandidates() error = %v", err)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Options.matchCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ type PerformerFinder interface {
 	models.PerformerQueryer
 	FindByNames(ctx context.Context, names []string, nocase bool) ([]*models.Performer, error)
 	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Performer, error)
+	models.PerformerNamesFinder
 }
 
 type GroupNamesFinder interface {
@@ -63,6 +64,8 @@ func (r SceneRelationships) MatchRelationships(ctx context.Context, s *models.Sc
 
 // ScrapedPerformer matches the provided performer with the
 // performers in the database and sets the ID field if one is found.
+// If no single performer is found, the performers with the same or similar
+// names or aliases are set as the conflicts of the performer.
 func ScrapedPerformer(ctx context.Context, qb PerformerFinder, p *models.ScrapedPerformer, stashBoxEndpoint string) error {
 	if p.StoredID != nil || p.Name == nil {
 		return nil
@@ -98,7 +101,13 @@ func ScrapedPerformer(ctx context.Context, qb PerformerFinder, p *models.Scraped
 	}
 
 	if len(performers) != 1 {
-		// ignore - cannot match
+		// cannot match - find any performers it may be a duplicate of
+		names, err := qb.AllNames(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.Conflicts = PerformerConflicts(*p.Name, names)
 		return nil
 	}
 
@@ -0,0 +1,209 @@
+package match
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// normalizeName returns the name transliterated to lower case, with
+// punctuation removed and its words sorted, so that "Doe, Zoë" and
+// "zoe doe" have the same normalized name.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(Transliterate(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// maxNameDistance returns the edit distance within which normalized names of
+// the length in runes are similar. Short names must match exactly, since
+// most short names are within a couple of edits of other names.
+func maxNameDistance(length int) int {
+	switch {
+	case length < 5:
+		return 0
+	case length < 10:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levenshtein returns the number of single rune insertions, deletions and
+// substitutions needed to change a into b, or max+1 if it is greater than
+// max.
+func levenshtein(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+
+		if rowMin > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// similarNormalizedNames returns true if the normalized names are equal, or
+// within the edit distance allowed for their length.
+func similarNormalizedNames(a, b string) (same bool, similar bool) {
+	if a == b {
+		return true, true
+	}
+
+	ar, br := []rune(a), []rune(b)
+	max := maxNameDistance(min(len(ar), len(br)))
+	return false, max > 0 && levenshtein(ar, br, max) <= max
+}
+
+// PerformerConflicts returns the performers whose name or aliases match the
+// name once normalized, or are similar to it. Each performer is returned
+// once, with the closest match.
+func PerformerConflicts(name string, performers []models.PerformerNames) []*models.PerformerConflict {
+	normalized := normalizeName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	var ret []*models.PerformerConflict
+	for _, p := range performers {
+		var conflict *models.PerformerConflict
+
+		if same, similar := similarNormalizedNames(normalized, normalizeName(p.Name)); same {
+			conflict = &models.PerformerConflict{Reason: models.PerformerConflictReasonName, MatchedName: p.Name}
+		} else if similar {
+			conflict = &models.PerformerConflict{Reason: models.PerformerConflictReasonSimilarName, MatchedName: p.Name}
+		}
+
+		for _, alias := range p.Aliases {
+			if conflict != nil && conflict.Reason == models.PerformerConflictReasonName {
+				break
+			}
+
+			same, similar := similarNormalizedNames(normalized, normalizeName(alias))
+			switch {
+			case same:
+				conflict = &models.PerformerConflict{Reason: models.PerformerConflictReasonAlias, MatchedName: alias}
+			case similar && conflict == nil:
+				conflict = &models.PerformerConflict{Reason: models.PerformerConflictReasonSimilarName, MatchedName: alias}
+			}
+		}
+
+		if conflict != nil {
+			conflict.PerformerID = p.ID
+			ret = append(ret, conflict)
+		}
+	}
+
+	return ret
+}
+
+// DuplicatePerformers returns groups of the IDs of performers that may be
+// duplicates, where a name or alias of each performer in the group is the
+// same as or similar to a name or alias of another performer in the group.
+// To keep the number of comparisons down, similar names are only compared
+// where they start with the same letter once normalized.
+func DuplicatePerformers(performers []models.PerformerNames) [][]int {
+	type entry struct {
+		index int
+		name  []rune
+	}
+
+	// union-find of performer indexes
+	parent := make([]int, len(performers))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if ri, rj := find(i), find(j); ri != rj {
+			parent[max(ri, rj)] = min(ri, rj)
+		}
+	}
+
+	exact := make(map[string]int)
+	blocks := make(map[rune][]entry)
+	for i, p := range performers {
+		names := append([]string{p.Name}, p.Aliases...)
+		for _, n := range names {
+			normalized := normalizeName(n)
+			if normalized == "" {
+				continue
+			}
+
+			if j, ok := exact[normalized]; ok {
+				union(i, j)
+				continue
+			}
+			exact[normalized] = i
+
+			r := []rune(normalized)
+			blocks[r[0]] = append(blocks[r[0]], entry{index: i, name: r})
+		}
+	}
+
+	for _, block := range blocks {
+		for x := range block {
+			for y := x + 1; y < len(block); y++ {
+				a, b := block[x], block[y]
+				if find(a.index) == find(b.index) {
+					continue
+				}
+
+				max := maxNameDistance(min(len(a.name), len(b.name)))
+				if max > 0 && levenshtein(a.name, b.name, max) <= max {
+					union(a.index, b.index)
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i, p := range performers {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], p.ID)
+	}
+
+	var ret [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			ret = append(ret, groups[root])
+		}
+	}
+
+	return ret
+}
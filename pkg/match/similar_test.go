@@ -0,0 +1,111 @@
+package match
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Jane Doe", "doe jane"},
+		{"Doe, Jane", "doe jane"},
+		{"  Zoë   Çelik ", "celik zoe"},
+		{"Анастасия", "anastasiya"},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := normalizeName(tt.s); got != tt.want {
+				t.Errorf("normalizeName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"", "", 2, 0},
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 2, 3},
+		{"jane doe", "jane do", 1, 1},
+		{"abc", "abcdef", 2, 3},
+		{"flaw", "lawn", 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := levenshtein([]rune(tt.a), []rune(tt.b), tt.max); got != tt.want {
+				t.Errorf("levenshtein() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerformerConflicts(t *testing.T) {
+	performers := []models.PerformerNames{
+		{ID: 1, Name: "Jane Doe", Aliases: []string{"Janey"}},
+		{ID: 2, Name: "Anna Smith", Aliases: []string{"Анастасия Смит"}},
+		{ID: 3, Name: "Mia", Aliases: []string{"Jane Doe"}},
+		{ID: 4, Name: "Jonathan Smithers"},
+	}
+
+	tests := []struct {
+		name string
+		want []*models.PerformerConflict
+	}{
+		{
+			"Doe, Jane",
+			[]*models.PerformerConflict{
+				{PerformerID: 1, Reason: models.PerformerConflictReasonName, MatchedName: "Jane Doe"},
+				{PerformerID: 3, Reason: models.PerformerConflictReasonAlias, MatchedName: "Jane Doe"},
+			},
+		},
+		{
+			"Anastasiya Smit",
+			[]*models.PerformerConflict{
+				{PerformerID: 2, Reason: models.PerformerConflictReasonAlias, MatchedName: "Анастасия Смит"},
+			},
+		},
+		{
+			"Jonathon Smithers",
+			[]*models.PerformerConflict{
+				{PerformerID: 4, Reason: models.PerformerConflictReasonSimilarName, MatchedName: "Jonathan Smithers"},
+			},
+		},
+		{"Mio", nil},
+		{"Someone Else", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PerformerConflicts(tt.name, performers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PerformerConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicatePerformers(t *testing.T) {
+	performers := []models.PerformerNames{
+		{ID: 1, Name: "Jane Doe"},
+		{ID: 2, Name: "Mia"},
+		{ID: 3, Name: "Doe, Jane"},
+		{ID: 4, Name: "Mio"},
+		{ID: 5, Name: "Janie", Aliases: []string{"Jane Do"}},
+		{ID: 6, Name: "Katherine Smith"},
+		{ID: 7, Name: "Katharine Smyth"},
+	}
+
+	want := [][]int{{1, 3, 5}, {6, 7}}
+	if got := DuplicatePerformers(performers); !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicatePerformers() = %v, want %v", got, want)
+	}
+}
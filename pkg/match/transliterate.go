@@ -0,0 +1,15 @@
+package match
+
+import (
+	"strings"
+
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// Transliterate returns s in lower case, with diacritics removed and
+// Cyrillic letters replaced with Latin letters. Cyrillic uses a single
+// common romanization, so other spellings of a name need to be added as
+// aliases.
+func Transliterate(s string) string {
+	return utils.Transliterate(strings.ToLower(s))
+}
@@ -0,0 +1,26 @@
+package match
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Plain Name", "plain name"},
+		{"Zoë Çelik", "zoe celik"},
+		{"Zoë", "zoe"},
+		{"Łukasz Øster Straße", "lukasz oster strasse"},
+		{"Анастасия Яковлева", "anastasiya yakovleva"},
+		{"Юлия Щербакова", "yuliya shcherbakova"},
+		{"Алексей", "aleksey"},
+		{"伏字", "伏字"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := Transliterate(tt.s); got != tt.want {
+				t.Errorf("Transliterate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
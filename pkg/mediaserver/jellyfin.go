@@ -0,0 +1,127 @@
+package mediaserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Jellyfin is the client of a Jellyfin server. The watch state and ratings
+// are those of the user with the id UserID.
+type Jellyfin struct {
+	URL        string
+	Token      string
+	UserID     string
+	HTTPClient *http.Client
+}
+
+type jellyfinItemsResponse struct {
+	Items []struct {
+		ID           string `json:"Id"`
+		Name         string `json:"Name"`
+		Path         string `json:"Path"`
+		MediaSources []struct {
+			Size int64 `json:"Size"`
+		} `json:"MediaSources"`
+		UserData struct {
+			Played         bool       `json:"Played"`
+			LastPlayedDate *time.Time `json:"LastPlayedDate"`
+			Rating         *float64   `json:"Rating"`
+		} `json:"UserData"`
+	} `json:"Items"`
+}
+
+type jellyfinUserData struct {
+	Rating float64 `json:"Rating"`
+}
+
+func (j *Jellyfin) request(ctx context.Context, method string, path string, query url.Values, body interface{}, v interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("userId", j.UserID)
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, j.URL+path+"?"+query.Encode(), r)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(`MediaBrowser Token="%s"`, j.Token))
+	return do(j.HTTPClient, req, v)
+}
+
+// Items returns the movies, episodes and other videos of the libraries of
+// the user.
+func (j *Jellyfin) Items(ctx context.Context) ([]Item, error) {
+	query := url.Values{}
+	query.Set("Recursive", "true")
+	query.Set("IncludeItemTypes", "Movie,Episode,Video")
+	query.Set("Fields", "Path,MediaSources")
+	query.Set("EnableUserData", "true")
+
+	var items jellyfinItemsResponse
+	if err := j.request(ctx, http.MethodGet, "/Items", query, nil, &items); err != nil {
+		return nil, fmt.Errorf("listing items: %w", err)
+	}
+
+	ret := make([]Item, len(items.Items))
+	for i, it := range items.Items {
+		item := Item{
+			ID:           it.ID,
+			Title:        it.Name,
+			Path:         it.Path,
+			Played:       it.UserData.Played,
+			LastPlayedAt: it.UserData.LastPlayedDate,
+		}
+
+		if len(it.MediaSources) > 0 {
+			item.Size = it.MediaSources[0].Size
+		}
+
+		if it.UserData.Rating != nil && *it.UserData.Rating > 0 {
+			r := int(math.Round(*it.UserData.Rating))
+			item.Rating = &r
+		}
+
+		ret[i] = item
+	}
+
+	return ret, nil
+}
+
+func (j *Jellyfin) SetPlayed(ctx context.Context, id string, played bool) error {
+	method := http.MethodDelete
+	if played {
+		method = http.MethodPost
+	}
+
+	return j.request(ctx, method, "/UserPlayedItems/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (j *Jellyfin) SetRating(ctx context.Context, id string, rating *int) error {
+	// a rating of 0 is treated as unrated
+	var data jellyfinUserData
+	if rating != nil {
+		data.Rating = float64(*rating)
+	}
+
+	return j.request(ctx, http.MethodPost, "/UserItems/"+url.PathEscape(id)+"/UserData", nil, data, nil)
+}
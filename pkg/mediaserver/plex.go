@@ -0,0 +1,151 @@
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// plexLibraryIdentifier identifies the library in scrobble and rate
+// requests.
+const plexLibraryIdentifier = "com.plexapp.plugins.library"
+
+// Plex item types of the sections listing videos.
+const (
+	plexTypeMovie   = 1
+	plexTypeEpisode = 4
+)
+
+// Plex is the client of a Plex Media Server. The watch state and ratings
+// are those of the owner of the token.
+type Plex struct {
+	URL        string
+	Token      string
+	HTTPClient *http.Client
+}
+
+type plexSectionsResponse struct {
+	MediaContainer struct {
+		Directory []struct {
+			Key  string `json:"key"`
+			Type string `json:"type"`
+		} `json:"Directory"`
+	} `json:"MediaContainer"`
+}
+
+type plexItemsResponse struct {
+	MediaContainer struct {
+		Metadata []struct {
+			RatingKey    string   `json:"ratingKey"`
+			Title        string   `json:"title"`
+			ViewCount    int      `json:"viewCount"`
+			LastViewedAt int64    `json:"lastViewedAt"`
+			UserRating   *float64 `json:"userRating"`
+			Media        []struct {
+				Part []struct {
+					File string `json:"file"`
+					Size int64  `json:"size"`
+				} `json:"Part"`
+			} `json:"Media"`
+		} `json:"Metadata"`
+	} `json:"MediaContainer"`
+}
+
+func (p *Plex) request(ctx context.Context, method string, path string, query url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.URL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Plex-Token", p.Token)
+	return do(p.HTTPClient, req, v)
+}
+
+// Items returns the movies and episodes of the movie and show sections.
+func (p *Plex) Items(ctx context.Context) ([]Item, error) {
+	var sections plexSectionsResponse
+	if err := p.request(ctx, http.MethodGet, "/library/sections", nil, &sections); err != nil {
+		return nil, fmt.Errorf("listing sections: %w", err)
+	}
+
+	var ret []Item
+	for _, d := range sections.MediaContainer.Directory {
+		var itemType int
+		switch d.Type {
+		case "movie":
+			itemType = plexTypeMovie
+		case "show":
+			itemType = plexTypeEpisode
+		default:
+			continue
+		}
+
+		query := url.Values{}
+		query.Set("type", strconv.Itoa(itemType))
+
+		var items plexItemsResponse
+		if err := p.request(ctx, http.MethodGet, "/library/sections/"+url.PathEscape(d.Key)+"/all", query, &items); err != nil {
+			return nil, fmt.Errorf("listing section %s: %w", d.Key, err)
+		}
+
+		for _, m := range items.MediaContainer.Metadata {
+			item := Item{
+				ID:     m.RatingKey,
+				Title:  m.Title,
+				Played: m.ViewCount > 0,
+			}
+
+			if len(m.Media) > 0 && len(m.Media[0].Part) > 0 {
+				item.Path = m.Media[0].Part[0].File
+				item.Size = m.Media[0].Part[0].Size
+			}
+
+			if m.LastViewedAt > 0 {
+				t := time.Unix(m.LastViewedAt, 0)
+				item.LastPlayedAt = &t
+			}
+
+			if m.UserRating != nil && *m.UserRating > 0 {
+				r := int(math.Round(*m.UserRating))
+				item.Rating = &r
+			}
+
+			ret = append(ret, item)
+		}
+	}
+
+	return ret, nil
+}
+
+// SetPlayed scrobbles or unscrobbles the item.
+func (p *Plex) SetPlayed(ctx context.Context, id string, played bool) error {
+	path := "/:/unscrobble"
+	if played {
+		path = "/:/scrobble"
+	}
+
+	query := url.Values{}
+	query.Set("identifier", plexLibraryIdentifier)
+	query.Set("key", id)
+
+	return p.request(ctx, http.MethodGet, path, query, nil)
+}
+
+func (p *Plex) SetRating(ctx context.Context, id string, rating *int) error {
+	query := url.Values{}
+	query.Set("identifier", plexLibraryIdentifier)
+	query.Set("key", id)
+
+	// -1 removes the rating
+	value := -1
+	if rating != nil {
+		value = *rating
+	}
+	query.Set("rating", strconv.Itoa(value))
+
+	return p.request(ctx, http.MethodPut, "/:/rate", query, nil)
+}
@@ -0,0 +1,224 @@
+// Package mediaserver syncs the watch state and ratings of scenes with the
+// items of Plex and Jellyfin servers in both directions.
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerType is the kind of media server.
+type ServerType string
+
+const (
+	ServerTypePlex     ServerType = "PLEX"
+	ServerTypeJellyfin ServerType = "JELLYFIN"
+)
+
+func (e ServerType) IsValid() bool {
+	switch e {
+	case ServerTypePlex, ServerTypeJellyfin:
+		return true
+	}
+	return false
+}
+
+func (e ServerType) String() string {
+	return string(e)
+}
+
+func (e *ServerType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ServerType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MediaServerType", str)
+	}
+	return nil
+}
+
+func (e ServerType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// PathMapping maps the paths of files as seen by a media server to their
+// paths in stash, where the server runs on another machine or in a
+// container.
+type PathMapping struct {
+	// From is the path prefix on the server.
+	From string `json:"from" koanf:"from"`
+	// To is the path prefix in stash.
+	To string `json:"to" koanf:"to"`
+}
+
+// Server is a media server to sync with.
+type Server struct {
+	// Name identifies the server. It must be unique.
+	Name string     `json:"name" koanf:"name"`
+	Type ServerType `json:"type" koanf:"type"`
+	URL  string     `json:"url" koanf:"url"`
+	// Token is the Plex token or Jellyfin API key.
+	Token string `json:"token" koanf:"token"`
+	// UserID is the Jellyfin user whose watch state is synced. Unused for
+	// Plex, where the owner of the token is used.
+	UserID       string        `json:"user_id" koanf:"user_id"`
+	PathMappings []PathMapping `json:"path_mappings" koanf:"path_mappings"`
+}
+
+func (s Server) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if !s.Type.IsValid() {
+		return fmt.Errorf("invalid type %q", s.Type)
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("url must be http or https")
+	}
+
+	if s.Token == "" {
+		return errors.New("token is required")
+	}
+
+	if s.Type == ServerTypeJellyfin && s.UserID == "" {
+		return errors.New("user id is required for Jellyfin")
+	}
+
+	return nil
+}
+
+// ValidateServers returns an error if any server is invalid, or if more than
+// one server has the same name.
+func ValidateServers(servers []Server) error {
+	names := make(map[string]bool)
+	for _, s := range servers {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("media server %q: %w", s.Name, err)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("duplicate media server %q", s.Name)
+		}
+		names[s.Name] = true
+	}
+
+	return nil
+}
+
+// normalizePath converts the separators of a path to forward slashes, so
+// that paths of servers running on Windows can be compared with stash paths.
+func normalizePath(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// MapPath returns the stash path of a file with the given path on the server.
+// The longest matching mapping is used. The path is returned with forward
+// slashes.
+func (s Server) MapPath(p string) string {
+	p = normalizePath(p)
+
+	var best *PathMapping
+	for i := range s.PathMappings {
+		m := &s.PathMappings[i]
+		from := normalizePath(m.From)
+		if from == "" || !strings.HasPrefix(p, from) {
+			continue
+		}
+		if best == nil || len(from) > len(normalizePath(best.From)) {
+			best = m
+		}
+	}
+
+	if best == nil {
+		return p
+	}
+
+	return normalizePath(best.To) + strings.TrimPrefix(p, normalizePath(best.From))
+}
+
+// Item is a video on a media server.
+type Item struct {
+	ID    string
+	Title string
+	// Path is the path of the file on the server.
+	Path string
+	Size int64
+
+	Played       bool
+	LastPlayedAt *time.Time
+	// Rating is on the 0-10 scale, nil if the item is not rated.
+	Rating *int
+}
+
+// Client reads and updates the items of a media server.
+type Client interface {
+	// Items returns the video items of the server.
+	Items(ctx context.Context) ([]Item, error)
+	SetPlayed(ctx context.Context, id string, played bool) error
+	// SetRating sets the rating of the item on the 0-10 scale. A nil rating
+	// removes the rating.
+	SetRating(ctx context.Context, id string, rating *int) error
+}
+
+// NewClient returns the client of the server.
+func NewClient(s Server, httpClient *http.Client) (Client, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	baseURL := strings.TrimSuffix(s.URL, "/")
+
+	switch s.Type {
+	case ServerTypePlex:
+		return &Plex{URL: baseURL, Token: s.Token, HTTPClient: httpClient}, nil
+	case ServerTypeJellyfin:
+		return &Jellyfin{URL: baseURL, Token: s.Token, UserID: s.UserID, HTTPClient: httpClient}, nil
+	}
+
+	return nil, fmt.Errorf("invalid media server type %q", s.Type)
+}
+
+// maxResponseSize limits the size of responses, which list all items of the
+// library.
+const maxResponseSize = 256 << 20
+
+// do sends the request and decodes the JSON response into v, if v is not nil.
+func do(client *http.Client, req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("http error %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServers(t *testing.T) {
+	plex := Server{Name: "plex", Type: ServerTypePlex, URL: "http://localhost:32400", Token: "token"}
+	jellyfin := Server{Name: "jellyfin", Type: ServerTypeJellyfin, URL: "https://localhost:8096", Token: "key", UserID: "user"}
+
+	assert.NoError(t, ValidateServers([]Server{plex, jellyfin}))
+	assert.Error(t, ValidateServers([]Server{plex, plex}))
+
+	noUser := jellyfin
+	noUser.UserID = ""
+	assert.Error(t, noUser.Validate())
+
+	badURL := plex
+	badURL.URL = "ftp://localhost"
+	assert.Error(t, badURL.Validate())
+}
+
+func TestPlex(t *testing.T) {
+	var rated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Plex-Token"))
+
+		switch r.URL.Path {
+		case "/library/sections":
+			_, _ = w.Write([]byte(`{"MediaContainer":{"Directory":[{"key":"1","type":"movie"},{"key":"2","type":"artist"}]}}`))
+		case "/library/sections/1/all":
+			assert.Equal(t, "1", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`{"MediaContainer":{"Metadata":[
+				{"ratingKey":"10","title":"A","viewCount":2,"lastViewedAt":1700000000,"userRating":8.0,"Media":[{"Part":[{"file":"/data/a.mp4","size":100}]}]},
+				{"ratingKey":"11","title":"B","Media":[{"Part":[{"file":"/data/b.mp4","size":200}]}]}
+			]}}`))
+		case "/:/rate":
+			assert.Equal(t, http.MethodPut, r.Method)
+			rated = r.URL.Query().Get("key") + "=" + r.URL.Query().Get("rating")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(Server{Type: ServerTypePlex, URL: server.URL + "/", Token: "token"}, nil)
+	require.NoError(t, err)
+
+	items, err := c.Items(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "10", items[0].ID)
+	assert.Equal(t, "/data/a.mp4", items[0].Path)
+	assert.Equal(t, int64(100), items[0].Size)
+	assert.True(t, items[0].Played)
+	assert.Equal(t, int64(1700000000), items[0].LastPlayedAt.Unix())
+	assert.Equal(t, 8, *items[0].Rating)
+
+	assert.False(t, items[1].Played)
+	assert.Nil(t, items[1].Rating)
+
+	require.NoError(t, c.SetRating(context.Background(), "11", nil))
+	assert.Equal(t, "11=-1", rated)
+}
+
+func TestJellyfin(t *testing.T) {
+	var played []string
+	var rating float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `MediaBrowser Token="key"`, r.Header.Get("Authorization"))
+		assert.Equal(t, "user", r.URL.Query().Get("userId"))
+
+		switch r.URL.Path {
+		case "/Items":
+			_, _ = w.Write([]byte(`{"Items":[
+				{"Id":"abc","Name":"A","Path":"/media/a.mp4","MediaSources":[{"Size":100}],"UserData":{"Played":true,"LastPlayedDate":"2024-01-02T03:04:05Z","Rating":7}}
+			]}`))
+		case "/UserPlayedItems/abc":
+			played = append(played, r.Method)
+		case "/UserItems/abc/UserData":
+			var data jellyfinUserData
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			rating = data.Rating
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(Server{Type: ServerTypeJellyfin, URL: server.URL, Token: "key", UserID: "user"}, nil)
+	require.NoError(t, err)
+
+	items, err := c.Items(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "abc", items[0].ID)
+	assert.True(t, items[0].Played)
+	assert.Equal(t, 7, *items[0].Rating)
+	assert.Equal(t, 2024, items[0].LastPlayedAt.Year())
+
+	require.NoError(t, c.SetPlayed(context.Background(), "abc", true))
+	require.NoError(t, c.SetPlayed(context.Background(), "abc", false))
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, played)
+
+	require.NoError(t, c.SetRating(context.Background(), "abc", intPtr(9)))
+	assert.Equal(t, 9.0, rating)
+}
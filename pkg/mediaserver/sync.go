@@ -0,0 +1,243 @@
+package mediaserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ServerRating converts a stash rating on the 1-100 scale to the 0-10 scale
+// of media servers.
+func ServerRating(rating *int) *int {
+	if rating == nil {
+		return nil
+	}
+
+	r := int(math.Round(float64(*rating) / 10))
+	r = max(1, min(10, r))
+	return &r
+}
+
+// StashRating converts a media server rating on the 0-10 scale to the 1-100
+// scale of stash.
+func StashRating(rating *int) *int {
+	if rating == nil {
+		return nil
+	}
+
+	r := max(1, min(100, *rating*10))
+	return &r
+}
+
+func equalRatings(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// State is the watch state and rating of a scene or item. The rating is on
+// the 0-10 scale of media servers.
+type State struct {
+	Played bool `json:"played"`
+	Rating *int `json:"rating,omitempty"`
+}
+
+// Actions are the changes that bring a scene and a media server item into
+// sync.
+type Actions struct {
+	// AddStashPlay adds a play to the scene.
+	AddStashPlay bool
+	// SetServerPlayed sets the played state of the item.
+	SetServerPlayed *bool
+
+	UpdateStashRating  bool
+	UpdateServerRating bool
+
+	// Synced is the state of both after the changes. Its rating is the one
+	// set by UpdateStashRating or UpdateServerRating.
+	Synced State
+}
+
+// Any returns true if anything is changed.
+func (a Actions) Any() bool {
+	return a.AddStashPlay || a.SetServerPlayed != nil || a.UpdateStashRating || a.UpdateServerRating
+}
+
+// Reconcile returns the changes syncing the state of a scene and a media
+// server item. last is the state when they were last synced, or nil if they
+// have not been synced before.
+//
+// A scene or item played on either side is marked played on the other.
+// Marking an item as unplayed on the server is not synced to stash, since it
+// would remove the play history of the scene, but resetting the plays of a
+// scene marks the item unplayed.
+//
+// A rating changed on one side since the last sync is copied to the other.
+// If both were changed, or they have not been synced before, the stash
+// rating is used unless the scene is not rated.
+func Reconcile(stash, server State, last *State) Actions {
+	ret := Actions{
+		Synced: stash,
+	}
+
+	switch {
+	case stash.Played == server.Played:
+	case stash.Played:
+		played := true
+		ret.SetServerPlayed = &played
+	case last != nil && last.Played:
+		// the plays of the scene were reset
+		played := false
+		ret.SetServerPlayed = &played
+	default:
+		ret.AddStashPlay = true
+		ret.Synced.Played = true
+	}
+
+	if !equalRatings(stash.Rating, server.Rating) {
+		stashChanged := last == nil || !equalRatings(stash.Rating, last.Rating)
+		if stashChanged && (stash.Rating != nil || last != nil) {
+			ret.UpdateServerRating = true
+		} else {
+			ret.UpdateStashRating = true
+			ret.Synced.Rating = server.Rating
+		}
+	}
+
+	return ret
+}
+
+// Candidate is a scene that media server items are matched to.
+type Candidate struct {
+	SceneID int
+	// Path is the path of the primary file of the scene.
+	Path string
+	Size int64
+}
+
+type fileKey struct {
+	basename string
+	size     int64
+}
+
+// Matcher maps media server items to scenes, by the path of their file or,
+// where paths differ and no mapping is configured, by the name and size of
+// their file. Media servers do not expose the fingerprints of files, so the
+// name and size are used in their place.
+type Matcher struct {
+	byPath map[string]int
+	byFile map[fileKey]int
+}
+
+// NewMatcher returns a matcher of the candidates.
+func NewMatcher(candidates []Candidate) *Matcher {
+	ret := &Matcher{
+		byPath: make(map[string]int),
+		byFile: make(map[fileKey]int),
+	}
+
+	for _, c := range candidates {
+		ret.byPath[normalizePath(c.Path)] = c.SceneID
+
+		k := fileKey{strings.ToLower(path.Base(normalizePath(c.Path))), c.Size}
+		if _, found := ret.byFile[k]; found {
+			// ambiguous
+			ret.byFile[k] = 0
+		} else {
+			ret.byFile[k] = c.SceneID
+		}
+	}
+
+	return ret
+}
+
+// Match returns the id of the scene of the item of the server, or 0 if the
+// item does not match exactly one scene.
+func (m *Matcher) Match(s Server, item Item) int {
+	if item.Path == "" {
+		return 0
+	}
+
+	if id, ok := m.byPath[s.MapPath(item.Path)]; ok {
+		return id
+	}
+
+	if item.Size > 0 {
+		k := fileKey{strings.ToLower(path.Base(normalizePath(item.Path))), item.Size}
+		return m.byFile[k]
+	}
+
+	return 0
+}
+
+// SyncedItem is the state of a media server item and its scene when they
+// were last synced.
+type SyncedItem struct {
+	SceneID int `json:"scene_id"`
+	State
+}
+
+// SyncState is the state of the synced items of each server, by server name
+// and item id.
+type SyncState map[string]map[string]SyncedItem
+
+// LoadSyncState reads the sync state from the file. An empty state is
+// returned if the file does not exist.
+func LoadSyncState(fn string) (SyncState, error) {
+	data, err := os.ReadFile(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return SyncState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := SyncState{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Save writes the sync state to the file.
+func (s SyncState) Save(fn string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fn, data, 0644)
+}
+
+// UnmatchedItem is a media server item which does not match a scene.
+type UnmatchedItem struct {
+	ID    string
+	Title string
+	Path  string
+}
+
+// ServerReport is the result of syncing with a server.
+type ServerReport struct {
+	Name    string
+	Matched int
+	// StashUpdated and ServerUpdated are the numbers of scenes and items
+	// that were changed.
+	StashUpdated  int
+	ServerUpdated int
+	Unmatched     []UnmatchedItem
+	// Error is set if the server could not be synced.
+	Error *string
+}
+
+// Report is the result of syncing with the media servers.
+type Report struct {
+	GeneratedAt time.Time
+	Servers     []ServerReport
+}
@@ -0,0 +1,157 @@
+package mediaserver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRatingConversion(t *testing.T) {
+	assert.Nil(t, ServerRating(nil))
+	assert.Equal(t, 8, *ServerRating(intPtr(75)))
+	assert.Equal(t, 1, *ServerRating(intPtr(1)))
+	assert.Equal(t, 10, *ServerRating(intPtr(100)))
+
+	assert.Nil(t, StashRating(nil))
+	assert.Equal(t, 70, *StashRating(intPtr(7)))
+}
+
+func TestReconcile(t *testing.T) {
+	played := true
+	unplayed := false
+
+	tests := []struct {
+		name   string
+		stash  State
+		server State
+		last   *State
+		want   Actions
+	}{
+		{
+			"in sync",
+			State{Played: true, Rating: intPtr(8)},
+			State{Played: true, Rating: intPtr(8)},
+			nil,
+			Actions{Synced: State{Played: true, Rating: intPtr(8)}},
+		},
+		{
+			"played in stash",
+			State{Played: true},
+			State{},
+			nil,
+			Actions{SetServerPlayed: &played, Synced: State{Played: true}},
+		},
+		{
+			"played on server",
+			State{},
+			State{Played: true},
+			nil,
+			Actions{AddStashPlay: true, Synced: State{Played: true}},
+		},
+		{
+			"plays reset in stash",
+			State{},
+			State{Played: true},
+			&State{Played: true},
+			Actions{SetServerPlayed: &unplayed, Synced: State{}},
+		},
+		{
+			"unplayed on server",
+			State{Played: true},
+			State{},
+			&State{Played: true},
+			Actions{SetServerPlayed: &played, Synced: State{Played: true}},
+		},
+		{
+			"first sync prefers stash rating",
+			State{Rating: intPtr(6)},
+			State{Rating: intPtr(9)},
+			nil,
+			Actions{UpdateServerRating: true, Synced: State{Rating: intPtr(6)}},
+		},
+		{
+			"first sync of unrated scene",
+			State{},
+			State{Rating: intPtr(9)},
+			nil,
+			Actions{UpdateStashRating: true, Synced: State{Rating: intPtr(9)}},
+		},
+		{
+			"rated on server",
+			State{Rating: intPtr(6)},
+			State{Rating: intPtr(9)},
+			&State{Rating: intPtr(6)},
+			Actions{UpdateStashRating: true, Synced: State{Rating: intPtr(9)}},
+		},
+		{
+			"rating removed in stash",
+			State{},
+			State{Rating: intPtr(6)},
+			&State{Rating: intPtr(6)},
+			Actions{UpdateServerRating: true, Synced: State{}},
+		},
+		{
+			"rated on both",
+			State{Rating: intPtr(4)},
+			State{Rating: intPtr(9)},
+			&State{Rating: intPtr(6)},
+			Actions{UpdateServerRating: true, Synced: State{Rating: intPtr(4)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reconcile(tt.stash, tt.server, tt.last)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.Any(), got.Any())
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]Candidate{
+		{SceneID: 1, Path: "/stash/movies/a.mp4", Size: 100},
+		{SceneID: 2, Path: "/stash/other/b.mp4", Size: 200},
+		{SceneID: 3, Path: "/stash/dupes/c.mp4", Size: 300},
+		{SceneID: 4, Path: "/stash/dupes2/c.mp4", Size: 300},
+	})
+
+	s := Server{
+		PathMappings: []PathMapping{
+			{From: `D:\`, To: "/stash/"},
+			{From: `D:\Movies\`, To: "/stash/movies/"},
+		},
+	}
+
+	assert.Equal(t, 1, m.Match(s, Item{Path: `D:\Movies\a.mp4`}))
+	assert.Equal(t, 2, m.Match(s, Item{Path: `D:\other\b.mp4`}))
+	// by name and size
+	assert.Equal(t, 2, m.Match(s, Item{Path: "/media/B.mp4", Size: 200}))
+	assert.Equal(t, 0, m.Match(s, Item{Path: "/media/b.mp4", Size: 201}))
+	// ambiguous
+	assert.Equal(t, 0, m.Match(s, Item{Path: "/media/c.mp4", Size: 300}))
+	assert.Equal(t, 0, m.Match(s, Item{}))
+}
+
+func TestSyncState(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadSyncState(fn)
+	require.NoError(t, err)
+	assert.Empty(t, state)
+
+	state["plex"] = map[string]SyncedItem{
+		"1": {SceneID: 10, State: State{Played: true, Rating: intPtr(7)}},
+	}
+	require.NoError(t, state.Save(fn))
+
+	got, err := LoadSyncState(fn)
+	require.NoError(t, err)
+	assert.Equal(t, state, got)
+}
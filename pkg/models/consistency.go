@@ -0,0 +1,22 @@
+package models
+
+import "context"
+
+// ConsistencyChecker finds the broken references of the library.
+type ConsistencyChecker interface {
+	// FindIssues returns the objects with broken references of the
+	// category.
+	FindIssues(ctx context.Context, category ConsistencyCategory) ([]ConsistencyIssue, error)
+}
+
+// ConsistencyFixer repairs broken references which cannot be repaired
+// through the other stores.
+type ConsistencyFixer interface {
+	// ClearGalleryFolder removes the folder of the gallery.
+	ClearGalleryFolder(ctx context.Context, galleryID int) error
+}
+
+type ConsistencyReaderWriter interface {
+	ConsistencyChecker
+	ConsistencyFixer
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 )
 
 type OperatorFilter[T any] struct {
@@ -195,6 +196,10 @@ type OrientationCriterionInput struct {
 	Value []OrientationEnum `json:"value"`
 }
 
+type TagRequirementsCriterionInput struct {
+	Value []TagRequirementsStatus `json:"value"`
+}
+
 type CustomFieldCriterionInput struct {
 	Field    string            `json:"field"`
 	Value    []any             `json:"value"`
@@ -209,18 +214,27 @@ type FingerprintFilterInput struct {
 }
 
 type VideoFileFilterInput struct {
-	Format      *StringCriterionInput      `json:"format,omitempty"`
-	Resolution  *ResolutionCriterionInput  `json:"resolution,omitempty"`
-	Orientation *OrientationCriterionInput `json:"orientation,omitempty"`
-	Framerate   *IntCriterionInput         `json:"framerate,omitempty"`
-	Bitrate     *IntCriterionInput         `json:"bitrate,omitempty"`
-	VideoCodec  *StringCriterionInput      `json:"video_codec,omitempty"`
-	AudioCodec  *StringCriterionInput      `json:"audio_codec,omitempty"`
+	Format        *StringCriterionInput      `json:"format,omitempty"`
+	Resolution    *ResolutionCriterionInput  `json:"resolution,omitempty"`
+	Orientation   *OrientationCriterionInput `json:"orientation,omitempty"`
+	Framerate     *IntCriterionInput         `json:"framerate,omitempty"`
+	Bitrate       *IntCriterionInput         `json:"bitrate,omitempty"`
+	VideoCodec    *StringCriterionInput      `json:"video_codec,omitempty"`
+	AudioCodec    *StringCriterionInput      `json:"audio_codec,omitempty"`
+	AudioLanguage *StringCriterionInput      `json:"audio_language,omitempty"`
 	// in seconds
 	Duration         *IntCriterionInput    `json:"duration,omitempty"`
 	Captions         *StringCriterionInput `json:"captions,omitempty"`
 	Interactive      *bool                 `json:"interactive,omitempty"`
 	InteractiveSpeed *IntCriterionInput    `json:"interactive_speed,omitempty"`
+	// Filter by whether threats were detected in the file
+	HasThreats *bool `json:"has_threats,omitempty"`
+	// Filter by whether the file has a threat of at least the severity
+	ThreatSeverity *ThreatSeverity `json:"threat_severity,omitempty"`
+	// Filter by whether the file was last scanned for threats before the time
+	ThreatsScannedBefore *time.Time `json:"threats_scanned_before,omitempty"`
+	// Filter by whether the file was never scanned for threats
+	ThreatsNeverScanned *bool `json:"threats_never_scanned,omitempty"`
 }
 
 type ImageFileFilterInput struct {
@@ -51,6 +51,56 @@ func (e SortDirectionEnum) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// SortCollation is how text fields are compared when sorting.
+type SortCollation string
+
+const (
+	// SortCollationNatural compares case-insensitively, with numbers compared
+	// by value, so that "title 2" is sorted before "title 10".
+	SortCollationNatural SortCollation = "NATURAL"
+	// SortCollationLocale compares by the rules of the sort locale,
+	// case-insensitively and with numbers compared by value.
+	SortCollationLocale SortCollation = "LOCALE"
+	// SortCollationBinary compares the bytes of the text, which is
+	// case-sensitive.
+	SortCollationBinary SortCollation = "BINARY"
+)
+
+var AllSortCollation = []SortCollation{
+	SortCollationNatural,
+	SortCollationLocale,
+	SortCollationBinary,
+}
+
+func (e SortCollation) IsValid() bool {
+	switch e {
+	case SortCollationNatural, SortCollationLocale, SortCollationBinary:
+		return true
+	}
+	return false
+}
+
+func (e SortCollation) String() string {
+	return string(e)
+}
+
+func (e *SortCollation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortCollation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortCollation", str)
+	}
+	return nil
+}
+
+func (e SortCollation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type FindFilterType struct {
 	Q    *string `json:"q"`
 	Page *int    `json:"page"`
@@ -58,6 +108,28 @@ type FindFilterType struct {
 	PerPage   *int               `json:"per_page"`
 	Sort      *string            `json:"sort"`
 	Direction *SortDirectionEnum `json:"direction"`
+	// Collation is used to sort by text fields. Uses the default collation
+	// if nil.
+	Collation *SortCollation `json:"collation"`
+	// ThenBy are sort keys applied in order after Sort, such as sorting by
+	// studio then by date.
+	ThenBy []*SortKey `json:"then_by"`
+	// Seed of the random sort. Results sorted randomly with the same seed are
+	// in the same order in every query, so that pages do not repeat results.
+	Seed *int `json:"seed"`
+}
+
+// SortKey is an additional key that results are sorted by.
+type SortKey struct {
+	Sort      string             `json:"sort"`
+	Direction *SortDirectionEnum `json:"direction"`
+}
+
+func (k SortKey) GetDirection() string {
+	if k.Direction != nil && k.Direction.IsValid() {
+		return k.Direction.String()
+	}
+	return "ASC"
 }
 
 func (ff FindFilterType) GetSort(defaultSort string) string {
@@ -67,6 +139,12 @@ func (ff FindFilterType) GetSort(defaultSort string) string {
 	} else {
 		sort = *ff.Sort
 	}
+
+	// a seeded random sort is in the same order for each query
+	if sort == "random" && ff.Seed != nil {
+		sort = fmt.Sprintf("random_%d", uint64(*ff.Seed))
+	}
+
 	return sort
 }
 
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFilterType_GetSort(t *testing.T) {
+	random := "random"
+	title := "title"
+	seed := 12345
+
+	assert.Equal(t, "date", FindFilterType{}.GetSort("date"))
+	assert.Equal(t, "title", FindFilterType{Sort: &title, Seed: &seed}.GetSort("date"))
+	assert.Equal(t, "random", FindFilterType{Sort: &random}.GetSort("date"))
+	assert.Equal(t, "random_12345", FindFilterType{Sort: &random, Seed: &seed}.GetSort("date"))
+}
@@ -23,6 +23,8 @@ type GalleryFilterType struct {
 	Organized *bool `json:"organized"`
 	// Filter by pinned
 	Pinned *bool `json:"pinned"`
+	// Filter by locked
+	Locked *bool `json:"locked"`
 	// Filter by o-counter
 	OCounter *IntCriterionInput `json:"o_counter"`
 	// Filter by omg-counter
@@ -99,6 +101,9 @@ type GalleryDestroyInput struct {
 	// If true, then the zip file will be deleted if the gallery is zip-file-based.
 	// If gallery is folder-based, then any files not associated with other
 	// galleries will be deleted, along with the folder, if it is not empty.
-	DeleteFile      *bool `json:"delete_file"`
-	DeleteGenerated *bool `json:"delete_generated"`
+	DeleteFile        *bool   `json:"delete_file"`
+	DeleteGenerated   *bool   `json:"delete_generated"`
+	ConfirmationToken *string `json:"confirmation_token"`
+	// Also destroy locked galleries when destroying several galleries
+	IncludeLocked *bool `json:"include_locked"`
 }
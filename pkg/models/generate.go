@@ -11,15 +11,64 @@ type GenerateMetadataOptions struct {
 	Sprites                   bool                    `json:"sprites"`
 	Previews                  bool                    `json:"previews"`
 	ImagePreviews             bool                    `json:"imagePreviews"`
+	WallPreviews              bool                    `json:"wallPreviews"`
 	PreviewOptions            *GeneratePreviewOptions `json:"previewOptions"`
 	Markers                   bool                    `json:"markers"`
 	MarkerImagePreviews       bool                    `json:"markerImagePreviews"`
 	MarkerScreenshots         bool                    `json:"markerScreenshots"`
+	MarkerThumbnails          bool                    `json:"markerThumbnails"`
 	Transcodes                bool                    `json:"transcodes"`
 	Phashes                   bool                    `json:"phashes"`
 	InteractiveHeatmapsSpeeds bool                    `json:"interactiveHeatmapsSpeeds"`
 	ImageThumbnails           bool                    `json:"imageThumbnails"`
 	ClipPreviews              bool                    `json:"clipPreviews"`
+	Exclusions                *GenerateExclusions     `json:"exclusions"`
+}
+
+// GenerateExclusions are the rules skipping the generation of each kind of
+// media for some scenes.
+type GenerateExclusions struct {
+	// Previews applies to video, image and wall previews.
+	Previews *GenerateExclusion `json:"previews"`
+	Sprites  *GenerateExclusion `json:"sprites"`
+}
+
+// GenerateExclusion skips the generation of a kind of media for the scenes
+// matching any of its rules.
+type GenerateExclusion struct {
+	// Scenes with any of these tags, or their sub-tags, are skipped.
+	TagIDs []string `json:"tagIds"`
+	// Scenes whose primary file is shorter than this, in seconds, are
+	// skipped.
+	MinDuration *float64 `json:"minDuration"`
+	// Scenes whose primary file is larger than this resolution are skipped.
+	MaxResolution *ResolutionEnum `json:"maxResolution"`
+}
+
+// ExcludesFile returns true if the video file is shorter than the minimum
+// duration or larger than the maximum resolution. Tags are not considered.
+func (e GenerateExclusion) ExcludesFile(f *VideoFile) bool {
+	if f == nil {
+		return false
+	}
+
+	if e.MinDuration != nil && f.Duration < *e.MinDuration {
+		return true
+	}
+
+	if e.MaxResolution != nil {
+		// resolutions are by the smaller side, as in the resolution filter
+		size := f.Width
+		if f.Height < size {
+			size = f.Height
+		}
+
+		if size > e.MaxResolution.GetMaxResolution() {
+			return true
+		}
+	}
+
+	return false
 }
 
 type GeneratePreviewOptions struct {
@@ -92,3 +141,87 @@ func (e *PreviewPreset) UnmarshalGQL(v interface{}) error {
 func (e PreviewPreset) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type SpriteFormat string
+
+const (
+	SpriteFormatJpg  SpriteFormat = "JPG"
+	SpriteFormatWebp SpriteFormat = "WEBP"
+)
+
+var AllSpriteFormat = []SpriteFormat{
+	SpriteFormatJpg,
+	SpriteFormatWebp,
+}
+
+func (e SpriteFormat) IsValid() bool {
+	switch e {
+	case SpriteFormatJpg, SpriteFormatWebp:
+		return true
+	}
+	return false
+}
+
+func (e SpriteFormat) String() string {
+	return string(e)
+}
+
+func (e *SpriteFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SpriteFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SpriteFormat", str)
+	}
+	return nil
+}
+
+func (e SpriteFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// DeinterlaceFilter is the ffmpeg filter used to deinterlace interlaced
+// video.
+type DeinterlaceFilter string
+
+const (
+	DeinterlaceFilterYadif DeinterlaceFilter = "yadif"
+	DeinterlaceFilterBwdif DeinterlaceFilter = "bwdif"
+)
+
+var AllDeinterlaceFilter = []DeinterlaceFilter{
+	DeinterlaceFilterYadif,
+	DeinterlaceFilterBwdif,
+}
+
+func (e DeinterlaceFilter) IsValid() bool {
+	switch e {
+	case DeinterlaceFilterYadif, DeinterlaceFilterBwdif:
+		return true
+	}
+	return false
+}
+
+func (e DeinterlaceFilter) String() string {
+	return string(e)
+}
+
+func (e *DeinterlaceFilter) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DeinterlaceFilter(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DeinterlaceFilter", str)
+	}
+	return nil
+}
+
+func (e DeinterlaceFilter) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
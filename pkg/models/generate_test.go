@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateExclusion_ExcludesFile(t *testing.T) {
+	minDuration := 60.0
+	maxResolution := ResolutionEnumFourK
+
+	hd := &VideoFile{Width: 1920, Height: 1080, Duration: 600}
+	short := &VideoFile{Width: 1920, Height: 1080, Duration: 30}
+	// portrait 8K, larger than 4K by its smaller side
+	huge := &VideoFile{Width: 4320, Height: 7680, Duration: 600}
+	// 4K is the largest resolution that is not excluded
+	fourK := &VideoFile{Width: 3840, Height: 2160, Duration: 600}
+
+	tests := []struct {
+		name      string
+		exclusion GenerateExclusion
+		file      *VideoFile
+		want      bool
+	}{
+		{"no rules", GenerateExclusion{}, short, false},
+		{"no file", GenerateExclusion{MinDuration: &minDuration}, nil, false},
+		{"long enough", GenerateExclusion{MinDuration: &minDuration}, hd, false},
+		{"too short", GenerateExclusion{MinDuration: &minDuration}, short, true},
+		{"small enough", GenerateExclusion{MaxResolution: &maxResolution}, fourK, false},
+		{"too large", GenerateExclusion{MaxResolution: &maxResolution}, huge, true},
+		{"tags only", GenerateExclusion{TagIDs: []string{"1"}}, short, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.exclusion.ExcludesFile(tt.file))
+		})
+	}
+}
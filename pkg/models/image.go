@@ -88,15 +88,17 @@ type ImageUpdateInput struct {
 }
 
 type ImageDestroyInput struct {
-	ID              string `json:"id"`
-	DeleteFile      *bool  `json:"delete_file"`
-	DeleteGenerated *bool  `json:"delete_generated"`
+	ID                string  `json:"id"`
+	DeleteFile        *bool   `json:"delete_file"`
+	DeleteGenerated   *bool   `json:"delete_generated"`
+	ConfirmationToken *string `json:"confirmation_token"`
 }
 
 type ImagesDestroyInput struct {
-	Ids             []string `json:"ids"`
-	DeleteFile      *bool    `json:"delete_file"`
-	DeleteGenerated *bool    `json:"delete_generated"`
+	Ids               []string `json:"ids"`
+	DeleteFile        *bool    `json:"delete_file"`
+	DeleteGenerated   *bool    `json:"delete_generated"`
+	ConfirmationToken *string  `json:"confirmation_token"`
 }
 
 type ImageQueryOptions struct {
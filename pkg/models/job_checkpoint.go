@@ -0,0 +1,18 @@
+package models
+
+import "context"
+
+type JobCheckpointReader interface {
+	// All returns the checkpoints in the order they were created.
+	All(ctx context.Context) ([]*JobCheckpoint, error)
+}
+
+type JobCheckpointWriter interface {
+	Create(ctx context.Context, newObject *JobCheckpoint) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type JobCheckpointReaderWriter interface {
+	JobCheckpointReader
+	JobCheckpointWriter
+}
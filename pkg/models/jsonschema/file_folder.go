@@ -86,6 +86,9 @@ type VideoFile struct {
 	FrameRate  float64 `json:"frame_rate,omitempty"`
 	BitRate    int64   `json:"bitrate,omitempty"`
 
+	AudioLanguages []string `json:"audio_languages,omitempty"`
+	Interlaced     bool     `json:"interlaced,omitempty"`
+
 	Interactive      bool `json:"interactive,omitempty"`
 	InteractiveSpeed *int `json:"interactive_speed,omitempty"`
 }
@@ -11,10 +11,11 @@ import (
 )
 
 type GalleryChapter struct {
-	Title      string        `json:"title,omitempty"`
-	ImageIndex int           `json:"image_index,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Title       string        `json:"title,omitempty"`
+	ImageIndex  int           `json:"image_index,omitempty"`
+	DisplayMode *int          `json:"display_mode,omitempty"`
+	CreatedAt   json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt   json.JSONTime `json:"updated_at,omitempty"`
 }
 
 type Gallery struct {
@@ -28,6 +29,7 @@ type Gallery struct {
 	Photographer string           `json:"photographer,omitempty"`
 	Rating       int              `json:"rating,omitempty"`
 	Organized    bool             `json:"organized,omitempty"`
+	Locked       bool             `json:"locked,omitempty"`
 	Chapters     []GalleryChapter `json:"chapters,omitempty"`
 	Studio       string           `json:"studio,omitempty"`
 	Performers   []string         `json:"performers,omitempty"`
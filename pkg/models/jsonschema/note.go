@@ -0,0 +1,32 @@
+package jsonschema
+
+import (
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+type Note struct {
+	Content   string        `json:"content,omitempty"`
+	Author    string        `json:"author,omitempty"`
+	CreatedAt json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt json.JSONTime `json:"updated_at,omitempty"`
+}
+
+// NotesToJSON returns the notes in the export format.
+func NotesToJSON(notes []*models.Note) []Note {
+	var ret []Note
+	for _, n := range notes {
+		j := Note{
+			Content:   n.Content,
+			CreatedAt: json.JSONTime{Time: n.CreatedAt},
+			UpdatedAt: json.JSONTime{Time: n.UpdatedAt},
+		}
+		if n.Author != nil {
+			j.Author = *n.Author
+		}
+
+		ret = append(ret, j)
+	}
+
+	return ret
+}
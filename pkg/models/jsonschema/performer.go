@@ -54,6 +54,7 @@ type Performer struct {
 	Aliases       StringOrStringList `json:"aliases,omitempty"`
 	Favorite      bool               `json:"favorite,omitempty"`
 	Tags          []string           `json:"tags,omitempty"`
+	Notes         []Note             `json:"notes,omitempty"`
 	Image         string             `json:"image,omitempty"`
 	CreatedAt     json.JSONTime      `json:"created_at,omitempty"`
 	UpdatedAt     json.JSONTime      `json:"updated_at,omitempty"`
@@ -64,6 +65,7 @@ type Performer struct {
 	Weight        int                `json:"weight,omitempty"`
 	StashIDs      []models.StashID   `json:"stash_ids,omitempty"`
 	IgnoreAutoTag bool               `json:"ignore_auto_tag,omitempty"`
+	Locked        bool               `json:"locked,omitempty"`
 
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 
@@ -52,6 +52,7 @@ type Scene struct {
 	ShootDate string   `json:"shoot_date,omitempty"`
 	Rating    int      `json:"rating,omitempty"`
 	Organized bool     `json:"organized,omitempty"`
+	Locked    bool     `json:"locked,omitempty"`
 
 	// deprecated - for import only
 	OCounter int `json:"o_counter,omitempty"`
@@ -63,6 +64,7 @@ type Scene struct {
 	Groups     []SceneGroup  `json:"movies,omitempty"`
 	Tags       []string      `json:"tags,omitempty"`
 	Markers    []SceneMarker `json:"markers,omitempty"`
+	Notes      []Note        `json:"notes,omitempty"`
 	Files      []string      `json:"files,omitempty"`
 	Cover      string        `json:"cover,omitempty"`
 	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
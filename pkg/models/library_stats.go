@@ -0,0 +1,18 @@
+package models
+
+import "context"
+
+type LibraryStatsReader interface {
+	// Get returns the stored library statistics.
+	Get(ctx context.Context) (*LibraryStats, error)
+}
+
+type LibraryStatsWriter interface {
+	// Set replaces the stored library statistics.
+	Set(ctx context.Context, stats LibraryStats) error
+}
+
+type LibraryStatsReaderWriter interface {
+	LibraryStatsReader
+	LibraryStatsWriter
+}
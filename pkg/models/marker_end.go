@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// MarkerAutoEnd is the heuristic setting the end of markers created without
+// an end, when their primary tag has no default duration.
+type MarkerAutoEnd string
+
+const (
+	// MarkerAutoEndNone leaves the end unset.
+	MarkerAutoEndNone MarkerAutoEnd = "NONE"
+	// MarkerAutoEndNextMarker ends the marker at the start of the next marker
+	// of the scene, or at the end of the scene.
+	MarkerAutoEndNextMarker MarkerAutoEnd = "NEXT_MARKER"
+	// MarkerAutoEndSceneChange ends the marker at the first scene change
+	// after its start, or at the next marker if that is sooner.
+	MarkerAutoEndSceneChange MarkerAutoEnd = "SCENE_CHANGE"
+)
+
+var AllMarkerAutoEnd = []MarkerAutoEnd{
+	MarkerAutoEndNone,
+	MarkerAutoEndNextMarker,
+	MarkerAutoEndSceneChange,
+}
+
+func (e MarkerAutoEnd) IsValid() bool {
+	switch e {
+	case MarkerAutoEndNone, MarkerAutoEndNextMarker, MarkerAutoEndSceneChange:
+		return true
+	}
+	return false
+}
+
+func (e MarkerAutoEnd) String() string {
+	return string(e)
+}
+
+func (e *MarkerAutoEnd) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MarkerAutoEnd(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MarkerAutoEnd", str)
+	}
+	return nil
+}
+
+func (e MarkerAutoEnd) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// MarkerEndSettings set the end of markers created without an end.
+type MarkerEndSettings struct {
+	// TagDurations are the default durations of markers by primary tag.
+	TagDurations []*MarkerTagDuration `json:"tagDurations"`
+	// AutoEnd applies to markers whose primary tag has no default duration.
+	// Defaults to MarkerAutoEndNone.
+	AutoEnd MarkerAutoEnd `json:"autoEnd"`
+	// MaxDuration is the longest duration set by AutoEnd, in seconds.
+	MaxDuration *float64 `json:"maxDuration"`
+}
+
+// MarkerTagDuration is the default duration of markers with the primary tag.
+type MarkerTagDuration struct {
+	TagID string `json:"tagId"`
+	// Duration is in seconds.
+	Duration float64 `json:"duration"`
+}
+
+// TagDuration returns the default duration of markers with the primary tag,
+// or nil if it has none.
+func (s MarkerEndSettings) TagDuration(tagID int) *float64 {
+	id := strconv.Itoa(tagID)
+	for _, d := range s.TagDurations {
+		if d != nil && d.TagID == id && d.Duration > 0 {
+			ret := d.Duration
+			return &ret
+		}
+	}
+
+	return nil
+}
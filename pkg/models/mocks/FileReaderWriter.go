@@ -291,6 +291,52 @@ func (_m *FileReaderWriter) FindByZipFileID(ctx context.Context, zipFileID model
 	return r0, r1
 }
 
+// FindExactDuplicates provides a mock function with given fields: ctx, fingerprintType
+func (_m *FileReaderWriter) FindExactDuplicates(ctx context.Context, fingerprintType string) ([][]models.FileID, error) {
+	ret := _m.Called(ctx, fingerprintType)
+
+	var r0 [][]models.FileID
+	if rf, ok := ret.Get(0).(func(context.Context, string) [][]models.FileID); ok {
+		r0 = rf(ctx, fingerprintType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]models.FileID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprintType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindVideoFilesBySizeAndDuration provides a mock function with given fields: ctx, size, duration, tolerance
+func (_m *FileReaderWriter) FindVideoFilesBySizeAndDuration(ctx context.Context, size int64, duration float64, tolerance float64) ([]models.File, error) {
+	ret := _m.Called(ctx, size, duration, tolerance)
+
+	var r0 []models.File
+	if rf, ok := ret.Get(0).(func(context.Context, int64, float64, float64) []models.File); ok {
+		r0 = rf(ctx, size, duration, tolerance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.File)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, float64, float64) error); ok {
+		r1 = rf(ctx, size, duration, tolerance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCaptions provides a mock function with given fields: ctx, fileID
 func (_m *FileReaderWriter) GetCaptions(ctx context.Context, fileID models.FileID) ([]*models.VideoCaption, error) {
 	ret := _m.Called(ctx, fileID)
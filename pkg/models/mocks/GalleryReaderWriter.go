@@ -129,6 +129,27 @@ func (_m *GalleryReaderWriter) Create(ctx context.Context, newGallery *models.Ga
 	return r0
 }
 
+// DecrementOMGCounter provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) DecrementOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Destroy provides a mock function with given fields: ctx, id
 func (_m *GalleryReaderWriter) Destroy(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -396,6 +417,27 @@ func (_m *GalleryReaderWriter) FindUserGalleryByTitle(ctx context.Context, title
 	return r0, r1
 }
 
+// GetAllOMGCount provides a mock function with given fields: ctx
+func (_m *GalleryReaderWriter) GetAllOMGCount(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetFiles(ctx context.Context, relatedID int) ([]models.File, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -442,6 +484,29 @@ func (_m *GalleryReaderWriter) GetImageIDs(ctx context.Context, relatedID int) (
 	return r0, r1
 }
 
+// GetImageOrderIndex provides a mock function with given fields: ctx, galleryID, imageID
+func (_m *GalleryReaderWriter) GetImageOrderIndex(ctx context.Context, galleryID int, imageID int) (*int, error) {
+	ret := _m.Called(ctx, galleryID, imageID)
+
+	var r0 *int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *int); ok {
+		r0 = rf(ctx, galleryID, imageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, galleryID, imageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManyFileIDs provides a mock function with given fields: ctx, ids
 func (_m *GalleryReaderWriter) GetManyFileIDs(ctx context.Context, ids []int) ([][]models.FileID, error) {
 	ret := _m.Called(ctx, ids)
@@ -465,6 +530,29 @@ func (_m *GalleryReaderWriter) GetManyFileIDs(ctx context.Context, ids []int) ([
 	return r0, r1
 }
 
+// GetOMGDatesInRange provides a mock function with given fields: ctx, start, end
+func (_m *GalleryReaderWriter) GetOMGDatesInRange(ctx context.Context, start time.Time, end time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []time.Time); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPerformerIDs provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -557,6 +645,50 @@ func (_m *GalleryReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]st
 	return r0, r1
 }
 
+// GetViewEventsInRange provides a mock function with given fields: ctx, start, end
+func (_m *GalleryReaderWriter) GetViewEventsInRange(ctx context.Context, start time.Time, end time.Time) ([]models.HistoryEvent, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []models.HistoryEvent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []models.HistoryEvent); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementOMGCounter provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) IncrementOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, galleryFilter, findFilter
 func (_m *GalleryReaderWriter) Query(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType) ([]*models.Gallery, int, error) {
 	ret := _m.Called(ctx, galleryFilter, findFilter)
@@ -629,6 +761,20 @@ func (_m *GalleryReaderWriter) RemoveImages(ctx context.Context, galleryID int,
 	return r0
 }
 
+// ReorderImages provides a mock function with given fields: ctx, galleryID, imageIDs, insertPointID, insertAfter
+func (_m *GalleryReaderWriter) ReorderImages(ctx context.Context, galleryID int, imageIDs []int, insertPointID int, insertAfter bool) error {
+	ret := _m.Called(ctx, galleryID, imageIDs, insertPointID, insertAfter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int, int, bool) error); ok {
+		r0 = rf(ctx, galleryID, imageIDs, insertPointID, insertAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetCover provides a mock function with given fields: ctx, galleryID
 func (_m *GalleryReaderWriter) ResetCover(ctx context.Context, galleryID int) error {
 	ret := _m.Called(ctx, galleryID)
@@ -643,6 +789,27 @@ func (_m *GalleryReaderWriter) ResetCover(ctx context.Context, galleryID int) er
 	return r0
 }
 
+// ResetOMGCounter provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) ResetOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetCover provides a mock function with given fields: ctx, galleryID, coverImageID
 func (_m *GalleryReaderWriter) SetCover(ctx context.Context, galleryID int, coverImageID int) error {
 	ret := _m.Called(ctx, galleryID, coverImageID)
@@ -859,6 +1026,48 @@ func (_m *GalleryReaderWriter) DeleteAllViews(ctx context.Context, id int) (int,
 	return r0, r1
 }
 
+// DeleteViewsInRange provides a mock function with given fields: ctx, id, start, end
+func (_m *GalleryReaderWriter) DeleteViewsInRange(ctx context.Context, id int, start time.Time, end time.Time) (int, error) {
+	ret := _m.Called(ctx, id, start, end)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, id, start, end)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteViewsBefore provides a mock function with given fields: ctx, before
+func (_m *GalleryReaderWriter) DeleteViewsBefore(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteViews provides a mock function with given fields: ctx, id, dates
 func (_m *GalleryReaderWriter) DeleteViews(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
 	ret := _m.Called(ctx, id, dates)
@@ -1131,6 +1340,29 @@ func (_m *GalleryReaderWriter) GetViewDates(ctx context.Context, relatedID int)
 	return r0, r1
 }
 
+// GetViewDatesInRange provides a mock function with given fields: ctx, start, end
+func (_m *GalleryReaderWriter) GetViewDatesInRange(ctx context.Context, start time.Time, end time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []time.Time); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IncrementOCounter provides a mock function with given fields: ctx, id
 func (_m *GalleryReaderWriter) IncrementOCounter(ctx context.Context, id int) (int, error) {
 	ret := _m.Called(ctx, id)
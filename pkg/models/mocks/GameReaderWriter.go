@@ -206,6 +206,48 @@ func (_m *GameReaderWriter) DeleteAllViews(ctx context.Context, id int) (int, er
 	return r0, r1
 }
 
+// DeleteViewsInRange provides a mock function with given fields: ctx, id, start, end
+func (_m *GameReaderWriter) DeleteViewsInRange(ctx context.Context, id int, start time.Time, end time.Time) (int, error) {
+	ret := _m.Called(ctx, id, start, end)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, id, start, end)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteViewsBefore provides a mock function with given fields: ctx, before
+func (_m *GameReaderWriter) DeleteViewsBefore(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteO provides a mock function with given fields: ctx, id, dates
 func (_m *GameReaderWriter) DeleteO(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
 	ret := _m.Called(ctx, id, dates)
@@ -335,6 +377,29 @@ func (_m *GameReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.
 	return r0, r1
 }
 
+// GetImage provides a mock function with given fields: ctx, gameID
+func (_m *GameReaderWriter) GetImage(ctx context.Context, gameID int) ([]byte, error) {
+	ret := _m.Called(ctx, gameID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, int) []byte); ok {
+		r0 = rf(ctx, gameID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, gameID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManyOCount provides a mock function with given fields: ctx, ids
 func (_m *GameReaderWriter) GetManyOCount(ctx context.Context, ids []int) ([]int, error) {
 	ret := _m.Called(ctx, ids)
@@ -519,6 +584,52 @@ func (_m *GameReaderWriter) GetOMGDates(ctx context.Context, id int) ([]time.Tim
 	return r0, r1
 }
 
+// GetTagIDs provides a mock function with given fields: ctx, relatedID
+func (_m *GameReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]int, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *GameReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetViewDates provides a mock function with given fields: ctx, id
 func (_m *GameReaderWriter) GetViewDates(ctx context.Context, id int) ([]time.Time, error) {
 	ret := _m.Called(ctx, id)
@@ -542,6 +653,27 @@ func (_m *GameReaderWriter) GetViewDates(ctx context.Context, id int) ([]time.Ti
 	return r0, r1
 }
 
+// HasImage provides a mock function with given fields: ctx, gameID
+func (_m *GameReaderWriter) HasImage(ctx context.Context, gameID int) (bool, error) {
+	ret := _m.Called(ctx, gameID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, gameID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, gameID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IncrementOCounter provides a mock function with given fields: ctx, id
 func (_m *GameReaderWriter) IncrementOCounter(ctx context.Context, id int) (int, error) {
 	ret := _m.Called(ctx, id)
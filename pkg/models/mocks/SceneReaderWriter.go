@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
+
+	utils "github.com/stashapp/stash/pkg/utils"
 )
 
 // SceneReaderWriter is an autogenerated mock type for the SceneReaderWriter type
@@ -67,6 +69,29 @@ func (_m *SceneReaderWriter) AddO(ctx context.Context, id int, dates []time.Time
 	return r0, r1
 }
 
+// AddOMG provides a mock function with given fields: ctx, id, dates
+func (_m *SceneReaderWriter) AddOMG(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddViews provides a mock function with given fields: ctx, sceneID, dates
 func (_m *SceneReaderWriter) AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error) {
 	ret := _m.Called(ctx, sceneID, dates)
@@ -332,6 +357,27 @@ func (_m *SceneReaderWriter) Create(ctx context.Context, newScene *models.Scene,
 	return r0
 }
 
+// DecrementOMGCounter provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) DecrementOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAllViews provides a mock function with given fields: ctx, id
 func (_m *SceneReaderWriter) DeleteAllViews(ctx context.Context, id int) (int, error) {
 	ret := _m.Called(ctx, id)
@@ -353,6 +399,71 @@ func (_m *SceneReaderWriter) DeleteAllViews(ctx context.Context, id int) (int, e
 	return r0, r1
 }
 
+// DeleteOMG provides a mock function with given fields: ctx, id, dates
+func (_m *SceneReaderWriter) DeleteOMG(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteViewsInRange provides a mock function with given fields: ctx, id, start, end
+func (_m *SceneReaderWriter) DeleteViewsInRange(ctx context.Context, id int, start time.Time, end time.Time) (int, error) {
+	ret := _m.Called(ctx, id, start, end)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, id, start, end)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteViewsBefore provides a mock function with given fields: ctx, before
+func (_m *SceneReaderWriter) DeleteViewsBefore(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteO provides a mock function with given fields: ctx, id, dates
 func (_m *SceneReaderWriter) DeleteO(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
 	ret := _m.Called(ctx, id, dates)
@@ -710,6 +821,29 @@ func (_m *SceneReaderWriter) FindDuplicates(ctx context.Context, distance int, d
 	return r0, r1
 }
 
+// FindAllPhashes provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) FindAllPhashes(ctx context.Context) ([]*utils.Phash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*utils.Phash
+	if rf, ok := ret.Get(0).(func(context.Context) []*utils.Phash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*utils.Phash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids
 func (_m *SceneReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.Scene, error) {
 	ret := _m.Called(ctx, ids)
@@ -754,6 +888,27 @@ func (_m *SceneReaderWriter) GetAllOCount(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
+// GetAllOMGCount provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) GetAllOMGCount(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) GetCover(ctx context.Context, sceneID int) ([]byte, error) {
 	ret := _m.Called(ctx, sceneID)
@@ -938,6 +1093,52 @@ func (_m *SceneReaderWriter) GetManyODates(ctx context.Context, ids []int) ([][]
 	return r0, r1
 }
 
+// GetManyOMGCount provides a mock function with given fields: ctx, ids
+func (_m *SceneReaderWriter) GetManyOMGCount(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyOMGDates provides a mock function with given fields: ctx, ids
+func (_m *SceneReaderWriter) GetManyOMGDates(ctx context.Context, ids []int) ([][]time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManyViewCount provides a mock function with given fields: ctx, ids
 func (_m *SceneReaderWriter) GetManyViewCount(ctx context.Context, ids []int) ([]int, error) {
 	ret := _m.Called(ctx, ids)
@@ -1028,6 +1229,117 @@ func (_m *SceneReaderWriter) GetODates(ctx context.Context, relatedID int) ([]ti
 	return r0, r1
 }
 
+// GetOEventsInRange provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) GetOEventsInRange(ctx context.Context, start time.Time, end time.Time) ([]models.HistoryEvent, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []models.HistoryEvent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []models.HistoryEvent); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOMGCount provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetOMGCount(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOMGCounter provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOMGDates provides a mock function with given fields: ctx, relatedID
+func (_m *SceneReaderWriter) GetOMGDates(ctx context.Context, relatedID int) ([]time.Time, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOMGDatesInRange provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) GetOMGDatesInRange(ctx context.Context, start time.Time, end time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []time.Time); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPerformerIDs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -1143,6 +1455,52 @@ func (_m *SceneReaderWriter) GetViewDates(ctx context.Context, relatedID int) ([
 	return r0, r1
 }
 
+// GetViewDatesInRange provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) GetViewDatesInRange(ctx context.Context, start time.Time, end time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []time.Time); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetViewEventsInRange provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) GetViewEventsInRange(ctx context.Context, start time.Time, end time.Time) ([]models.HistoryEvent, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []models.HistoryEvent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []models.HistoryEvent); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.HistoryEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, error) {
 	ret := _m.Called(ctx, sceneID)
@@ -1164,6 +1522,27 @@ func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, e
 	return r0, r1
 }
 
+// IncrementOMGCounter provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) IncrementOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OCountByPerformerID provides a mock function with given fields: ctx, performerID
 func (_m *SceneReaderWriter) OCountByPerformerID(ctx context.Context, performerID int) (int, error) {
 	ret := _m.Called(ctx, performerID)
@@ -1292,6 +1671,48 @@ func (_m *SceneReaderWriter) ResetO(ctx context.Context, id int) (int, error) {
 	return r0, r1
 }
 
+// ResetOMG provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) ResetOMG(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetOMGCounter provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) ResetOMGCounter(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveActivity provides a mock function with given fields: ctx, sceneID, resumeTime, playDuration
 func (_m *SceneReaderWriter) SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error) {
 	ret := _m.Called(ctx, sceneID, resumeTime, playDuration)
@@ -1408,13 +1829,13 @@ func (_m *SceneReaderWriter) GetAggregatedViewHistory(ctx context.Context, page,
 	return r0, r1
 }
 
-// GetCombinedAggregatedViewHistory provides a mock function with given fields: ctx, page, perPage
-func (_m *SceneReaderWriter) GetCombinedAggregatedViewHistory(ctx context.Context, page, perPage int) ([]models.CombinedAggregatedView, error) {
-	ret := _m.Called(ctx, page, perPage)
+// GetCombinedAggregatedViewHistory provides a mock function with given fields: ctx, options
+func (_m *SceneReaderWriter) GetCombinedAggregatedViewHistory(ctx context.Context, options models.CombinedViewHistoryOptions) ([]models.CombinedAggregatedView, error) {
+	ret := _m.Called(ctx, options)
 
 	var r0 []models.CombinedAggregatedView
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.CombinedAggregatedView); ok {
-		r0 = rf(ctx, page, perPage)
+	if rf, ok := ret.Get(0).(func(context.Context, models.CombinedViewHistoryOptions) []models.CombinedAggregatedView); ok {
+		r0 = rf(ctx, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.CombinedAggregatedView)
@@ -1422,8 +1843,8 @@ func (_m *SceneReaderWriter) GetCombinedAggregatedViewHistory(ctx context.Contex
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, page, perPage)
+	if rf, ok := ret.Get(1).(func(context.Context, models.CombinedViewHistoryOptions) error); ok {
+		r1 = rf(ctx, options)
 	} else {
 		r1 = ret.Error(1)
 	}
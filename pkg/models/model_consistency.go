@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ConsistencyCategory is a kind of broken reference between the objects of
+// the library and their files.
+type ConsistencyCategory string
+
+const (
+	// ConsistencyCategoryScenesWithoutFiles are scenes with no files.
+	ConsistencyCategoryScenesWithoutFiles ConsistencyCategory = "SCENES_WITHOUT_FILES"
+	// ConsistencyCategoryFilesWithoutScenes are video files which are not
+	// the file of any scene.
+	ConsistencyCategoryFilesWithoutScenes ConsistencyCategory = "FILES_WITHOUT_SCENES"
+	// ConsistencyCategoryMissingPrimaryFile are scenes, images and galleries
+	// with files, none of which is their primary file.
+	ConsistencyCategoryMissingPrimaryFile ConsistencyCategory = "MISSING_PRIMARY_FILE"
+	// ConsistencyCategoryMissingGalleryFolder are galleries whose folder does
+	// not exist.
+	ConsistencyCategoryMissingGalleryFolder ConsistencyCategory = "MISSING_GALLERY_FOLDER"
+	// ConsistencyCategoryMissingFingerprints are files without fingerprints.
+	ConsistencyCategoryMissingFingerprints ConsistencyCategory = "MISSING_FINGERPRINTS"
+)
+
+var AllConsistencyCategory = []ConsistencyCategory{
+	ConsistencyCategoryScenesWithoutFiles,
+	ConsistencyCategoryFilesWithoutScenes,
+	ConsistencyCategoryMissingPrimaryFile,
+	ConsistencyCategoryMissingGalleryFolder,
+	ConsistencyCategoryMissingFingerprints,
+}
+
+func (e ConsistencyCategory) IsValid() bool {
+	switch e {
+	case ConsistencyCategoryScenesWithoutFiles, ConsistencyCategoryFilesWithoutScenes, ConsistencyCategoryMissingPrimaryFile, ConsistencyCategoryMissingGalleryFolder, ConsistencyCategoryMissingFingerprints:
+		return true
+	}
+	return false
+}
+
+func (e ConsistencyCategory) String() string {
+	return string(e)
+}
+
+func (e *ConsistencyCategory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConsistencyCategory(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConsistencyCategory", str)
+	}
+	return nil
+}
+
+func (e ConsistencyCategory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ConsistencyObjectType is the type of object with a broken reference.
+type ConsistencyObjectType string
+
+const (
+	ConsistencyObjectTypeScene   ConsistencyObjectType = "SCENE"
+	ConsistencyObjectTypeImage   ConsistencyObjectType = "IMAGE"
+	ConsistencyObjectTypeGallery ConsistencyObjectType = "GALLERY"
+	ConsistencyObjectTypeFile    ConsistencyObjectType = "FILE"
+)
+
+var AllConsistencyObjectType = []ConsistencyObjectType{
+	ConsistencyObjectTypeScene,
+	ConsistencyObjectTypeImage,
+	ConsistencyObjectTypeGallery,
+	ConsistencyObjectTypeFile,
+}
+
+func (e ConsistencyObjectType) IsValid() bool {
+	switch e {
+	case ConsistencyObjectTypeScene, ConsistencyObjectTypeImage, ConsistencyObjectTypeGallery, ConsistencyObjectTypeFile:
+		return true
+	}
+	return false
+}
+
+func (e ConsistencyObjectType) String() string {
+	return string(e)
+}
+
+func (e *ConsistencyObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConsistencyObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConsistencyObjectType", str)
+	}
+	return nil
+}
+
+func (e ConsistencyObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ConsistencyIssue is an object with a broken reference.
+type ConsistencyIssue struct {
+	ObjectType ConsistencyObjectType `json:"object_type"`
+	ObjectID   int                   `json:"object_id"`
+	// Path is the path of the file, where the object is a file.
+	Path string `json:"path"`
+	// FileID is the file to make the primary file, for a missing primary
+	// file.
+	FileID *FileID `json:"file_id"`
+}
+
+// ConsistencyCategoryReport is the result of checking a category.
+type ConsistencyCategoryReport struct {
+	Category ConsistencyCategory `json:"category"`
+	Issues   []ConsistencyIssue  `json:"issues"`
+	// Fixed is the number of issues fixed. Zero if the category was not
+	// fixed.
+	Fixed int `json:"fixed"`
+	// Failed is the number of issues which could not be fixed.
+	Failed int `json:"failed"`
+}
+
+// ConsistencyReport is the result of a library consistency check.
+type ConsistencyReport struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Categories  []ConsistencyCategoryReport `json:"categories"`
+}
+
+// Total returns the number of issues found in all categories.
+func (r ConsistencyReport) Total() int {
+	ret := 0
+	for _, c := range r.Categories {
+		ret += len(c.Issues)
+	}
+	return ret
+}
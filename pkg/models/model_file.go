@@ -287,6 +287,12 @@ type VideoFile struct {
 	FrameRate  float64 `json:"frame_rate"`
 	BitRate    int64   `json:"bitrate"`
 
+	// AudioLanguages contains the distinct languages of the audio streams.
+	AudioLanguages []string `json:"audio_languages"`
+
+	// Interlaced is true if the video stream is interlaced.
+	Interlaced bool `json:"interlaced"`
+
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
 
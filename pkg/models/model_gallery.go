@@ -19,6 +19,7 @@ type Gallery struct {
 	Rating      *int `json:"rating"`
 	Organized   bool `json:"organized"`
 	Pinned      bool `json:"pinned"`
+	Locked      bool `json:"locked"`
 	OCounter    int  `json:"o_counter"`
 	OmegCounter int  `json:"omg_counter"`
 	DisplayMode int  `json:"display_mode"`
@@ -66,6 +67,7 @@ type GalleryPartial struct {
 	Rating      OptionalInt
 	Organized   OptionalBool
 	Pinned      OptionalBool
+	Locked      OptionalBool
 	OCounter    OptionalInt
 	OmegCounter OptionalInt
 	DisplayMode OptionalInt
@@ -5,12 +5,13 @@ import (
 )
 
 type GalleryChapter struct {
-	ID         int       `json:"id"`
-	Title      string    `json:"title"`
-	ImageIndex int       `json:"image_index"`
-	GalleryID  int       `json:"gallery_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	ImageIndex  int       `json:"image_index"`
+	GalleryID   int       `json:"gallery_id"`
+	DisplayMode *int      `json:"display_mode"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 func NewGalleryChapter() GalleryChapter {
@@ -24,11 +25,12 @@ func NewGalleryChapter() GalleryChapter {
 // GalleryChapterPartial represents part of a GalleryChapter object.
 // It is used to update the database entry.
 type GalleryChapterPartial struct {
-	Title      OptionalString
-	ImageIndex OptionalInt
-	GalleryID  OptionalInt
-	CreatedAt  OptionalTime
-	UpdatedAt  OptionalTime
+	Title       OptionalString
+	ImageIndex  OptionalInt
+	GalleryID   OptionalInt
+	DisplayMode OptionalInt
+	CreatedAt   OptionalTime
+	UpdatedAt   OptionalTime
 }
 
 func NewGalleryChapterPartial() GalleryChapterPartial {
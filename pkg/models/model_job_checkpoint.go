@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// JobCheckpoint is the saved state of a job that was interrupted by a
+// shutdown. It is used to resume the job on the next startup.
+type JobCheckpoint struct {
+	ID          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	// State is the kind-specific state of the job.
+	State     []byte    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// LibraryStats are the statistics of the library. They are kept up to date
+// as the library changes, and recomputed periodically to correct any drift.
+type LibraryStats struct {
+	SceneCount        int     `json:"scene_count"`
+	ScenesSize        float64 `json:"scenes_size"`
+	ScenesDuration    float64 `json:"scenes_duration"`
+	ImageCount        int     `json:"image_count"`
+	ImagesSize        float64 `json:"images_size"`
+	GalleryCount      int     `json:"gallery_count"`
+	PerformerCount    int     `json:"performer_count"`
+	StudioCount       int     `json:"studio_count"`
+	GroupCount        int     `json:"group_count"`
+	TagCount          int     `json:"tag_count"`
+	TotalOCount       int     `json:"total_o_count"`
+	TotalOMGCount     int     `json:"total_omg_count"`
+	TotalPlayDuration float64 `json:"total_play_duration"`
+	TotalPlayCount    int     `json:"total_play_count"`
+	ScenesPlayed      int     `json:"scenes_played"`
+	// ComputedAt is the time the statistics were last fully computed. Nil if
+	// they have never been computed.
+	ComputedAt *time.Time `json:"computed_at"`
+}
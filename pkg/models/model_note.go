@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Note is a timestamped freeform note on a scene, performer or studio. Notes
+// are separate from the details of the object, and are kept as a journal.
+type Note struct {
+	ID          int  `json:"id"`
+	SceneID     *int `json:"scene_id"`
+	PerformerID *int `json:"performer_id"`
+	StudioID    *int `json:"studio_id"`
+	// Content is the note text, in markdown.
+	Content string `json:"content"`
+	// Author is the user that created the note. Nil if credentials are not
+	// configured.
+	Author    *string   `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewNote() Note {
+	currentTime := time.Now()
+	return Note{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// Validate returns an error if the note does not have content, or is not
+// attached to exactly one object.
+func (n Note) Validate() error {
+	if strings.TrimSpace(n.Content) == "" {
+		return errors.New("content must not be empty")
+	}
+
+	set := 0
+	for _, id := range []*int{n.SceneID, n.PerformerID, n.StudioID} {
+		if id != nil {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return errors.New("exactly one of scene_id, performer_id and studio_id must be set")
+	}
+
+	return nil
+}
+
+// NoteObject identifies the objects to find notes for. Nil fields are not
+// filtered on.
+type NoteObject struct {
+	SceneID     *int
+	PerformerID *int
+	StudioID    *int
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_Validate(t *testing.T) {
+	id := 1
+
+	n := NewNote()
+	n.SceneID = &id
+	assert.Error(t, n.Validate())
+
+	n.Content = "  "
+	assert.Error(t, n.Validate())
+
+	n.Content = "needs **recut**"
+	assert.NoError(t, n.Validate())
+
+	n.StudioID = &id
+	assert.Error(t, n.Validate())
+
+	n.SceneID = nil
+	assert.NoError(t, n.Validate())
+
+	n.StudioID = nil
+	assert.Error(t, n.Validate())
+}
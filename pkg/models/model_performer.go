@@ -33,6 +33,7 @@ type Performer struct {
 	Weight        *int   `json:"weight"`
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
 	SmallRole     bool   `json:"small_role"`
+	Locked        bool   `json:"locked"`
 
 	Aliases       RelatedStrings                `json:"aliases"`
 	URLs          RelatedStrings                `json:"urls"`
@@ -92,6 +93,7 @@ type PerformerPartial struct {
 	Weight        OptionalInt
 	IgnoreAutoTag OptionalBool
 	SmallRole     OptionalBool
+	Locked        OptionalBool
 
 	Aliases      *UpdateStrings
 	TagIDs       *UpdateIDs
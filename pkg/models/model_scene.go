@@ -21,6 +21,7 @@ type Scene struct {
 	Rating                  *int    `json:"rating"`
 	Organized               bool    `json:"organized"`
 	Pinned                  bool    `json:"pinned"`
+	Locked                  bool    `json:"locked"`
 	IsBroken                bool    `json:"is_broken"`
 	IsNotBroken             bool    `json:"is_not_broken"`
 	AudioOffsetMs           int     `json:"audio_offset_ms"`
@@ -53,6 +54,16 @@ type Scene struct {
 	VideoFilters    *VideoFilters    `json:"video_filters"`
 	VideoTransforms *VideoTransforms `json:"video_transforms"`
 
+	// Additional ffmpeg arguments used when transcoding the scene
+	TranscodeArgs *TranscodeArgs `json:"transcode_args"`
+	// Deinterlace forces deinterlacing on or off when transcoding. If nil,
+	// interlaced files are deinterlaced.
+	Deinterlace *bool `json:"deinterlace"`
+
+	// On-screen text recognized in the frames of the scene
+	OCRText       string     `json:"ocr_text"`
+	OCRAnalyzedAt *time.Time `json:"ocr_analyzed_at"`
+
 	URLs            RelatedStrings         `json:"urls"`
 	GalleryIDs      RelatedIDs             `json:"gallery_ids"`
 	TagIDs          RelatedIDs             `json:"tag_ids"`
@@ -84,6 +95,7 @@ type ScenePartial struct {
 	Rating                  OptionalInt
 	Organized               OptionalBool
 	Pinned                  OptionalBool
+	Locked                  OptionalBool
 	IsBroken                OptionalBool
 	IsNotBroken             OptionalBool
 	AudioOffsetMs           OptionalInt
@@ -97,9 +109,13 @@ type ScenePartial struct {
 	PlayDuration            OptionalFloat64
 	StartTime               OptionalFloat64
 	EndTime                 OptionalFloat64
+	Deinterlace             OptionalBool
+	OCRText                 OptionalString
+	OCRAnalyzedAt           OptionalTime
 
 	VideoFilters    *VideoFilters
 	VideoTransforms *VideoTransforms
+	TranscodeArgs   *TranscodeArgs
 
 	URLs            *UpdateStrings
 	GalleryIDs      *UpdateIDs
@@ -382,3 +398,15 @@ type VideoTransforms struct {
 	Scale       *int `json:"scale"`
 	AspectRatio *int `json:"aspect_ratio"`
 }
+
+// TranscodeArgs represents additional ffmpeg arguments used when transcoding
+// a scene. They are appended to the global transcode arguments.
+type TranscodeArgs struct {
+	InputArgs  []string `json:"input_args"`
+	OutputArgs []string `json:"output_args"`
+}
+
+// IsEmpty returns true if no arguments are set.
+func (a *TranscodeArgs) IsEmpty() bool {
+	return a == nil || (len(a.InputArgs) == 0 && len(a.OutputArgs) == 0)
+}
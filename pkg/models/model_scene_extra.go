@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SceneExtraRole is the role of an auxiliary file of a scene.
+type SceneExtraRole string
+
+const (
+	SceneExtraRoleTrailer         SceneExtraRole = "TRAILER"
+	SceneExtraRoleBehindTheScenes SceneExtraRole = "BEHIND_THE_SCENES"
+	SceneExtraRoleAlternateCut    SceneExtraRole = "ALTERNATE_CUT"
+)
+
+var AllSceneExtraRole = []SceneExtraRole{
+	SceneExtraRoleTrailer,
+	SceneExtraRoleBehindTheScenes,
+	SceneExtraRoleAlternateCut,
+}
+
+func (e SceneExtraRole) IsValid() bool {
+	switch e {
+	case SceneExtraRoleTrailer, SceneExtraRoleBehindTheScenes, SceneExtraRoleAlternateCut:
+		return true
+	}
+	return false
+}
+
+func (e SceneExtraRole) String() string {
+	return string(e)
+}
+
+func (e *SceneExtraRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneExtraRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneExtraRole", str)
+	}
+	return nil
+}
+
+func (e SceneExtraRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneExtra is an auxiliary video file of a scene, such as a trailer. Extras
+// are not files of the scene, so they are not played as the scene and are
+// excluded from its duration and size.
+type SceneExtra struct {
+	SceneID int            `json:"scene_id"`
+	FileID  FileID         `json:"file_id"`
+	Role    SceneExtraRole `json:"role"`
+}
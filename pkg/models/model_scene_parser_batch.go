@@ -0,0 +1,237 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type SceneParserField string
+
+const (
+	SceneParserFieldTitle      SceneParserField = "TITLE"
+	SceneParserFieldDate       SceneParserField = "DATE"
+	SceneParserFieldRating     SceneParserField = "RATING"
+	SceneParserFieldStudio     SceneParserField = "STUDIO"
+	SceneParserFieldPerformers SceneParserField = "PERFORMERS"
+	SceneParserFieldTags       SceneParserField = "TAGS"
+	SceneParserFieldGroups     SceneParserField = "GROUPS"
+)
+
+var AllSceneParserField = []SceneParserField{
+	SceneParserFieldTitle,
+	SceneParserFieldDate,
+	SceneParserFieldRating,
+	SceneParserFieldStudio,
+	SceneParserFieldPerformers,
+	SceneParserFieldTags,
+	SceneParserFieldGroups,
+}
+
+func (e SceneParserField) IsValid() bool {
+	switch e {
+	case SceneParserFieldTitle, SceneParserFieldDate, SceneParserFieldRating, SceneParserFieldStudio, SceneParserFieldPerformers, SceneParserFieldTags, SceneParserFieldGroups:
+		return true
+	}
+	return false
+}
+
+func (e SceneParserField) String() string {
+	return string(e)
+}
+
+func (e *SceneParserField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneParserField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneParserField", str)
+	}
+	return nil
+}
+
+func (e SceneParserField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SceneParserChangeStatus string
+
+const (
+	// Change has not been reviewed
+	SceneParserChangeStatusPending SceneParserChangeStatus = "PENDING"
+	// Change will be applied when the batch is applied
+	SceneParserChangeStatusAccepted SceneParserChangeStatus = "ACCEPTED"
+	// Change will not be applied
+	SceneParserChangeStatusRejected SceneParserChangeStatus = "REJECTED"
+	// Change has been applied to the scene
+	SceneParserChangeStatusApplied SceneParserChangeStatus = "APPLIED"
+)
+
+var AllSceneParserChangeStatus = []SceneParserChangeStatus{
+	SceneParserChangeStatusPending,
+	SceneParserChangeStatusAccepted,
+	SceneParserChangeStatusRejected,
+	SceneParserChangeStatusApplied,
+}
+
+func (e SceneParserChangeStatus) IsValid() bool {
+	switch e {
+	case SceneParserChangeStatusPending, SceneParserChangeStatusAccepted, SceneParserChangeStatusRejected, SceneParserChangeStatusApplied:
+		return true
+	}
+	return false
+}
+
+func (e SceneParserChangeStatus) String() string {
+	return string(e)
+}
+
+func (e *SceneParserChangeStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneParserChangeStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneParserChangeStatus", str)
+	}
+	return nil
+}
+
+func (e SceneParserChangeStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneParserBatch is a persisted run of the scene filename parser, whose
+// changes can be reviewed before being applied.
+type SceneParserBatch struct {
+	ID        int       `json:"id"`
+	Pattern   string    `json:"pattern"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SceneParserChange is a change to a single field of a scene proposed by a
+// parser batch. Value is set for single-valued fields, IDs for relationship
+// fields.
+type SceneParserChange struct {
+	ID      int                     `json:"id"`
+	BatchID int                     `json:"batch_id"`
+	SceneID int                     `json:"scene_id"`
+	Field   SceneParserField        `json:"field"`
+	Value   *string                 `json:"value"`
+	IDs     []int                   `json:"ids"`
+	Status  SceneParserChangeStatus `json:"status"`
+}
+
+// Changes returns a pending change for each field set in the parser result.
+func (r SceneParserResult) Changes() ([]*SceneParserChange, error) {
+	var ret []*SceneParserChange
+
+	addValue := func(field SceneParserField, v *string) {
+		if v != nil {
+			ret = append(ret, &SceneParserChange{Field: field, Value: v})
+		}
+	}
+
+	addIDs := func(field SceneParserField, ids []string) error {
+		if len(ids) == 0 {
+			return nil
+		}
+
+		converted, err := stringslice.StringSliceToIntSlice(ids)
+		if err != nil {
+			return fmt.Errorf("converting %s ids: %w", field, err)
+		}
+
+		ret = append(ret, &SceneParserChange{Field: field, IDs: converted})
+		return nil
+	}
+
+	addValue(SceneParserFieldTitle, r.Title)
+	addValue(SceneParserFieldDate, r.Date)
+
+	if r.Rating != nil {
+		rating := strconv.Itoa(*r.Rating)
+		addValue(SceneParserFieldRating, &rating)
+	}
+
+	addValue(SceneParserFieldStudio, r.StudioID)
+
+	if err := addIDs(SceneParserFieldPerformers, r.PerformerIds); err != nil {
+		return nil, err
+	}
+	if err := addIDs(SceneParserFieldTags, r.TagIds); err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]string, len(r.Movies))
+	for i, m := range r.Movies {
+		groupIDs[i] = m.MovieID
+	}
+	if err := addIDs(SceneParserFieldGroups, groupIDs); err != nil {
+		return nil, err
+	}
+
+	for _, c := range ret {
+		if r.Scene != nil {
+			c.SceneID = r.Scene.ID
+		}
+		c.Status = SceneParserChangeStatusPending
+	}
+
+	return ret, nil
+}
+
+// Apply sets the field of the change in the scene partial. Relationship
+// fields replace the existing relationships, as the filename parser does.
+func (c SceneParserChange) Apply(partial *ScenePartial) error {
+	value := ""
+	if c.Value != nil {
+		value = *c.Value
+	}
+
+	switch c.Field {
+	case SceneParserFieldTitle:
+		partial.Title = NewOptionalString(value)
+	case SceneParserFieldDate:
+		d, err := ParseDate(value)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", value, err)
+		}
+		partial.Date = NewOptionalDate(d)
+	case SceneParserFieldRating:
+		rating, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid rating %q: %w", value, err)
+		}
+		partial.Rating = NewOptionalInt(rating)
+	case SceneParserFieldStudio:
+		studioID, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid studio id %q: %w", value, err)
+		}
+		partial.StudioID = NewOptionalInt(studioID)
+	case SceneParserFieldPerformers:
+		partial.PerformerIDs = &UpdateIDs{IDs: c.IDs, Mode: RelationshipUpdateModeSet}
+	case SceneParserFieldTags:
+		partial.TagIDs = &UpdateIDs{IDs: c.IDs, Mode: RelationshipUpdateModeSet}
+	case SceneParserFieldGroups:
+		groups := make([]GroupsScenes, len(c.IDs))
+		for i, id := range c.IDs {
+			groups[i] = GroupsScenes{GroupID: id}
+		}
+		partial.GroupIDs = &UpdateGroupIDs{Groups: groups, Mode: RelationshipUpdateModeSet}
+	default:
+		return fmt.Errorf("unsupported field %s", c.Field)
+	}
+
+	return nil
+}
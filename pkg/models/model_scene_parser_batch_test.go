@@ -0,0 +1,103 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneParserResult_Changes(t *testing.T) {
+	title := "title"
+	date := "2024-01-02"
+	rating := 80
+	studioID := "3"
+
+	r := SceneParserResult{
+		Scene:        &Scene{ID: 1},
+		Title:        &title,
+		Date:         &date,
+		Rating:       &rating,
+		StudioID:     &studioID,
+		PerformerIds: []string{"4", "5"},
+		Movies:       []*SceneMovieID{{MovieID: "6"}},
+	}
+
+	got, err := r.Changes()
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	if assert.Len(got, 6) {
+		for _, c := range got {
+			assert.Equal(1, c.SceneID)
+			assert.Equal(SceneParserChangeStatusPending, c.Status)
+		}
+
+		assert.Equal(SceneParserFieldTitle, got[0].Field)
+		assert.Equal("80", *got[2].Value)
+		assert.Equal(SceneParserFieldPerformers, got[4].Field)
+		assert.Equal([]int{4, 5}, got[4].IDs)
+		assert.Equal(SceneParserFieldGroups, got[5].Field)
+		assert.Equal([]int{6}, got[5].IDs)
+	}
+
+	r.TagIds = []string{"invalid"}
+	_, err = r.Changes()
+	assert.Error(err)
+}
+
+func TestSceneParserChange_Apply(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		change  SceneParserChange
+		want    ScenePartial
+		wantErr bool
+	}{
+		{
+			"title",
+			SceneParserChange{Field: SceneParserFieldTitle, Value: str("title")},
+			ScenePartial{Title: NewOptionalString("title")},
+			false,
+		},
+		{
+			"date",
+			SceneParserChange{Field: SceneParserFieldDate, Value: str("2024-01-02")},
+			ScenePartial{Date: NewOptionalDate(Date{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})},
+			false,
+		},
+		{
+			"invalid rating",
+			SceneParserChange{Field: SceneParserFieldRating, Value: str("x")},
+			ScenePartial{},
+			true,
+		},
+		{
+			"tags",
+			SceneParserChange{Field: SceneParserFieldTags, IDs: []int{1}},
+			ScenePartial{TagIDs: &UpdateIDs{IDs: []int{1}, Mode: RelationshipUpdateModeSet}},
+			false,
+		},
+		{
+			"groups",
+			SceneParserChange{Field: SceneParserFieldGroups, IDs: []int{2}},
+			ScenePartial{GroupIDs: &UpdateGroupIDs{Groups: []GroupsScenes{{GroupID: 2}}, Mode: RelationshipUpdateModeSet}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ScenePartial
+			err := tt.change.Apply(&got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -138,6 +138,9 @@ type ScrapedPerformer struct {
 	RemoteSiteID       *string  `json:"remote_site_id"`
 	RemoteDeleted      bool     `json:"remote_deleted"`
 	RemoteMergedIntoId *string  `json:"remote_merged_into_id"`
+	// Set if the performer did not match, but may be a duplicate of existing
+	// performers
+	Conflicts []*PerformerConflict `json:"conflicts"`
 }
 
 func (ScrapedPerformer) IsScrapedContent() {}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrShareLinkExpired   = errors.New("share link has expired")
+	ErrShareLinkViewLimit = errors.New("share link view limit reached")
+)
+
+// ShareLink is a tokenized link to a single scene or gallery that can be
+// viewed without authentication.
+type ShareLink struct {
+	ID        int    `json:"id"`
+	Token     string `json:"token"`
+	SceneID   *int   `json:"scene_id"`
+	GalleryID *int   `json:"gallery_id"`
+	// AllowDownload allows the shared files to be downloaded.
+	AllowDownload bool `json:"allow_download"`
+	// MaxViews is the number of times the link can be opened. Nil for no
+	// limit.
+	MaxViews  *int       `json:"max_views"`
+	ViewCount int        `json:"view_count"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewShareLink() ShareLink {
+	return ShareLink{
+		CreatedAt: time.Now(),
+	}
+}
+
+// Expired returns true if the link has expired at the given time.
+func (l ShareLink) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Validate returns an error if the link has expired or its view limit has
+// been reached at the given time.
+func (l ShareLink) Validate(now time.Time) error {
+	if l.Expired(now) {
+		return ErrShareLinkExpired
+	}
+
+	if l.MaxViews != nil && l.ViewCount >= *l.MaxViews {
+		return ErrShareLinkViewLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShareLinkValidate(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	one := 1
+	two := 2
+
+	tests := []struct {
+		name string
+		link ShareLink
+		want error
+	}{
+		{"no limits", ShareLink{}, nil},
+		{"not expired", ShareLink{ExpiresAt: &future}, nil},
+		{"expired", ShareLink{ExpiresAt: &past}, ErrShareLinkExpired},
+		{"expires now", ShareLink{ExpiresAt: &now}, ErrShareLinkExpired},
+		{"views remaining", ShareLink{MaxViews: &two, ViewCount: 1}, nil},
+		{"view limit reached", ShareLink{MaxViews: &one, ViewCount: 1}, ErrShareLinkViewLimit},
+		{"expired and view limit reached", ShareLink{ExpiresAt: &past, MaxViews: &one, ViewCount: 1}, ErrShareLinkExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.Validate(now); got != tt.want {
+				t.Errorf("ShareLink.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// SmartPlaylist is a playlist of the scenes matching a filter, which players
+// advance through using the playback rules of the playlist.
+type SmartPlaylist struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	SceneFilter *SceneFilterType `json:"scene_filter"`
+	// Sort is the sort of the scenes. Random sorts are stored with their
+	// seed, so that the order is the same for all clients.
+	Sort      string            `json:"sort"`
+	Direction SortDirectionEnum `json:"direction"`
+	// MaxItemDuration is the number of seconds of each scene played before
+	// advancing to the next scene. Nil plays the whole scene.
+	MaxItemDuration *int `json:"max_item_duration"`
+	// UseMarkers plays each scene from its first marker, up to the end of
+	// the marker if it has one.
+	UseMarkers bool `json:"use_markers"`
+	// SkipWatched skips the scenes that have been played.
+	SkipWatched bool      `json:"skip_watched"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewSmartPlaylist() SmartPlaylist {
+	currentTime := time.Now()
+	return SmartPlaylist{
+		Direction: SortDirectionEnumAsc,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// Validate returns an error if the playlist has no name, or has an invalid
+// direction or item duration.
+func (p SmartPlaylist) Validate() error {
+	if p.Name == "" {
+		return errors.New("name must not be empty")
+	}
+
+	if !p.Direction.IsValid() {
+		return errors.New("direction must be ASC or DESC")
+	}
+
+	if p.MaxItemDuration != nil && *p.MaxItemDuration <= 0 {
+		return errors.New("max_item_duration must be greater than zero")
+	}
+
+	return nil
+}
+
+// FindFilter returns the find filter returning all of the scenes of the
+// playlist in order.
+func (p SmartPlaylist) FindFilter() *FindFilterType {
+	perPage := PerPageAll
+	direction := p.Direction
+
+	ret := &FindFilterType{
+		PerPage:   &perPage,
+		Direction: &direction,
+	}
+
+	if p.Sort != "" {
+		sort := p.Sort
+		ret.Sort = &sort
+	}
+
+	return ret
+}
+
+// PlaylistSegment is the part of a scene played by a smart playlist.
+type PlaylistSegment struct {
+	Start float64
+	// End is nil if the scene is played to its end.
+	End *float64
+}
+
+// Segment returns the part of a scene of the given duration and markers that
+// is played by the playlist.
+func (p SmartPlaylist) Segment(duration float64, markers []*SceneMarker) PlaylistSegment {
+	var ret PlaylistSegment
+
+	if p.UseMarkers {
+		var first *SceneMarker
+		for _, m := range markers {
+			if first == nil || m.Seconds < first.Seconds {
+				first = m
+			}
+		}
+
+		if first != nil {
+			ret.Start = first.Seconds
+			if first.EndSeconds != nil {
+				end := *first.EndSeconds
+				ret.End = &end
+			}
+		}
+	}
+
+	if p.MaxItemDuration != nil {
+		end := ret.Start + float64(*p.MaxItemDuration)
+		if ret.End == nil || *ret.End > end {
+			ret.End = &end
+		}
+	}
+
+	// the segment ends with the scene
+	if ret.End != nil && duration > 0 && *ret.End >= duration {
+		ret.End = nil
+	}
+
+	return ret
+}
+
+// NextPlaylistIndex returns the index in ids of the scene after current, or
+// zero if current is nil or is no longer in the playlist. Returns -1 if
+// current is the last scene.
+func NextPlaylistIndex(ids []int, current *int) int {
+	next := 0
+	if current != nil {
+		for i, id := range ids {
+			if id == *current {
+				next = i + 1
+				break
+			}
+		}
+	}
+
+	if next >= len(ids) {
+		return -1
+	}
+
+	return next
+}
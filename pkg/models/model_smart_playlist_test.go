@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmartPlaylist_Validate(t *testing.T) {
+	zero := 0
+
+	p := NewSmartPlaylist()
+	assert.Error(t, p.Validate())
+
+	p.Name = "favourites"
+	assert.NoError(t, p.Validate())
+
+	p.MaxItemDuration = &zero
+	assert.Error(t, p.Validate())
+
+	p.MaxItemDuration = nil
+	p.Direction = "UP"
+	assert.Error(t, p.Validate())
+}
+
+func TestSmartPlaylist_Segment(t *testing.T) {
+	seconds := func(v float64) *float64 { return &v }
+	thirty := 30
+
+	markers := []*SceneMarker{
+		{Seconds: 120, EndSeconds: seconds(150)},
+		{Seconds: 60},
+	}
+
+	// whole scene
+	p := SmartPlaylist{}
+	assert.Equal(t, PlaylistSegment{}, p.Segment(600, markers))
+
+	// first 30 seconds
+	p.MaxItemDuration = &thirty
+	assert.Equal(t, PlaylistSegment{End: seconds(30)}, p.Segment(600, markers))
+
+	// 30 seconds from the first marker
+	p.UseMarkers = true
+	assert.Equal(t, PlaylistSegment{Start: 60, End: seconds(90)}, p.Segment(600, markers))
+
+	// to the end of the first marker, within the maximum duration
+	p.MaxItemDuration = nil
+	markers[1].EndSeconds = seconds(75)
+	assert.Equal(t, PlaylistSegment{Start: 60, End: seconds(75)}, p.Segment(600, markers))
+
+	// segments ending after the scene end with the scene
+	p.MaxItemDuration = &thirty
+	assert.Equal(t, PlaylistSegment{Start: 60}, p.Segment(70, markers))
+
+	// scenes without markers are played from the start
+	assert.Equal(t, PlaylistSegment{End: seconds(30)}, p.Segment(600, nil))
+}
+
+func TestNextPlaylistIndex(t *testing.T) {
+	ids := []int{4, 2, 7}
+	id := func(v int) *int { return &v }
+
+	assert.Equal(t, 0, NextPlaylistIndex(ids, nil))
+	assert.Equal(t, 1, NextPlaylistIndex(ids, id(4)))
+	assert.Equal(t, 2, NextPlaylistIndex(ids, id(2)))
+	assert.Equal(t, -1, NextPlaylistIndex(ids, id(7)))
+	// scenes no longer in the playlist restart it
+	assert.Equal(t, 0, NextPlaylistIndex(ids, id(9)))
+	assert.Equal(t, -1, NextPlaylistIndex(nil, nil))
+}
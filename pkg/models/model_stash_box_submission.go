@@ -0,0 +1,149 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// StashBoxSubmissionType is the type of object submitted to stash-box as a
+// draft.
+type StashBoxSubmissionType string
+
+const (
+	StashBoxSubmissionTypeScene     StashBoxSubmissionType = "SCENE"
+	StashBoxSubmissionTypePerformer StashBoxSubmissionType = "PERFORMER"
+)
+
+var AllStashBoxSubmissionType = []StashBoxSubmissionType{
+	StashBoxSubmissionTypeScene,
+	StashBoxSubmissionTypePerformer,
+}
+
+func (e StashBoxSubmissionType) IsValid() bool {
+	switch e {
+	case StashBoxSubmissionTypeScene, StashBoxSubmissionTypePerformer:
+		return true
+	}
+	return false
+}
+
+func (e StashBoxSubmissionType) String() string {
+	return string(e)
+}
+
+func (e *StashBoxSubmissionType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StashBoxSubmissionType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StashBoxSubmissionType", str)
+	}
+	return nil
+}
+
+func (e StashBoxSubmissionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StashBoxSubmissionStatus is the state of a queued draft submission.
+type StashBoxSubmissionStatus string
+
+const (
+	// StashBoxSubmissionStatusPending means that the draft is queued and has
+	// not been submitted yet.
+	StashBoxSubmissionStatusPending StashBoxSubmissionStatus = "PENDING"
+	// StashBoxSubmissionStatusSubmitted means that stash-box accepted the
+	// draft.
+	StashBoxSubmissionStatusSubmitted StashBoxSubmissionStatus = "SUBMITTED"
+	// StashBoxSubmissionStatusFailed means that the draft could not be
+	// submitted.
+	StashBoxSubmissionStatusFailed StashBoxSubmissionStatus = "FAILED"
+)
+
+var AllStashBoxSubmissionStatus = []StashBoxSubmissionStatus{
+	StashBoxSubmissionStatusPending,
+	StashBoxSubmissionStatusSubmitted,
+	StashBoxSubmissionStatusFailed,
+}
+
+func (e StashBoxSubmissionStatus) IsValid() bool {
+	switch e {
+	case StashBoxSubmissionStatusPending, StashBoxSubmissionStatusSubmitted, StashBoxSubmissionStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e StashBoxSubmissionStatus) String() string {
+	return string(e)
+}
+
+func (e *StashBoxSubmissionStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StashBoxSubmissionStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StashBoxSubmissionStatus", str)
+	}
+	return nil
+}
+
+func (e StashBoxSubmissionStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StashBoxSubmission is a draft of a scene or performer queued for
+// submission to a stash-box endpoint, and its outcome.
+type StashBoxSubmission struct {
+	ID       int                    `json:"id"`
+	Endpoint string                 `json:"endpoint"`
+	Type     StashBoxSubmissionType `json:"type"`
+	// ObjectID is the ID of the scene or performer.
+	ObjectID int                      `json:"object_id"`
+	Status   StashBoxSubmissionStatus `json:"status"`
+	// DraftID is the ID of the draft returned by stash-box once submitted.
+	DraftID *string `json:"draft_id"`
+	// Error is the error of the last failed attempt.
+	Error *string `json:"error"`
+	// Attempts is the number of requests made to submit the draft.
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewStashBoxSubmission(endpoint string, typ StashBoxSubmissionType, objectID int) StashBoxSubmission {
+	currentTime := time.Now()
+	return StashBoxSubmission{
+		Endpoint:  endpoint,
+		Type:      typ,
+		ObjectID:  objectID,
+		Status:    StashBoxSubmissionStatusPending,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// SetResult records the outcome of an attempt to submit the draft.
+func (s *StashBoxSubmission) SetResult(draftID *string, attempts int, err error) {
+	s.Attempts += attempts
+	s.UpdatedAt = time.Now()
+
+	if err != nil {
+		errStr := err.Error()
+		s.Status = StashBoxSubmissionStatusFailed
+		s.Error = &errStr
+		return
+	}
+
+	s.Status = StashBoxSubmissionStatusSubmitted
+	s.DraftID = draftID
+	s.Error = nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStashBoxSubmission_SetResult(t *testing.T) {
+	s := NewStashBoxSubmission("https://stashdb.org/graphql", StashBoxSubmissionTypeScene, 1)
+	assert.Equal(t, StashBoxSubmissionStatusPending, s.Status)
+
+	s.SetResult(nil, 3, errors.New("service unavailable"))
+	assert.Equal(t, StashBoxSubmissionStatusFailed, s.Status)
+	assert.Equal(t, "service unavailable", *s.Error)
+	assert.Equal(t, 3, s.Attempts)
+
+	draftID := "draft"
+	s.SetResult(&draftID, 1, nil)
+	assert.Equal(t, StashBoxSubmissionStatusSubmitted, s.Status)
+	assert.Equal(t, &draftID, s.DraftID)
+	assert.Nil(t, s.Error)
+	assert.Equal(t, 4, s.Attempts)
+}
@@ -0,0 +1,22 @@
+package models
+
+import "context"
+
+type NoteReader interface {
+	// Find returns nil, nil if the note does not exist.
+	Find(ctx context.Context, id int) (*Note, error)
+	// FindByObject returns the notes of the object, most recent first, or
+	// all notes if no object is set.
+	FindByObject(ctx context.Context, object NoteObject) ([]*Note, error)
+}
+
+type NoteWriter interface {
+	Create(ctx context.Context, newObject *Note) error
+	Update(ctx context.Context, updatedObject *Note) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type NoteReaderWriter interface {
+	NoteReader
+	NoteWriter
+}
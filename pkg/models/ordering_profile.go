@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OrderingProfile is the default ordering of the results of an entity type.
+// It is applied by the server to find filters that do not set a sort, so
+// that all clients get the same ordering.
+type OrderingProfile struct {
+	Mode      FilterMode         `json:"mode" koanf:"mode"`
+	Sort      string             `json:"sort" koanf:"sort"`
+	Direction *SortDirectionEnum `json:"direction" koanf:"direction"`
+	// PerPage is used if the find filter does not set a page size.
+	PerPage *int       `json:"per_page" koanf:"per_page"`
+	ThenBy  []*SortKey `json:"then_by" koanf:"then_by"`
+}
+
+func (p OrderingProfile) Validate() error {
+	if !p.Mode.IsValid() {
+		return fmt.Errorf("invalid mode %q", p.Mode)
+	}
+
+	if p.Sort == "" {
+		return errors.New("sort cannot be blank")
+	}
+
+	if p.Direction != nil && !p.Direction.IsValid() {
+		return fmt.Errorf("invalid direction %q", *p.Direction)
+	}
+
+	if p.PerPage != nil && *p.PerPage < PerPageAll {
+		return errors.New("per page must be -1 or greater")
+	}
+
+	for _, k := range p.ThenBy {
+		if k == nil || k.Sort == "" {
+			return errors.New("then by sort cannot be blank")
+		}
+
+		if k.Direction != nil && !k.Direction.IsValid() {
+			return fmt.Errorf("invalid then by direction %q", *k.Direction)
+		}
+	}
+
+	return nil
+}
+
+// Apply returns a copy of the find filter with the ordering of the profile.
+// The sort, direction and additional sort keys are only used if the find
+// filter does not set a sort, and the page size only if the find filter
+// does not set a page size. A nil find filter is treated as empty.
+func (p OrderingProfile) Apply(findFilter *FindFilterType) *FindFilterType {
+	var ret FindFilterType
+	if findFilter != nil {
+		ret = *findFilter
+	}
+
+	if ret.Sort == nil || *ret.Sort == "" {
+		sort := p.Sort
+		ret.Sort = &sort
+		ret.Direction = p.Direction
+		if len(ret.ThenBy) == 0 {
+			ret.ThenBy = p.ThenBy
+		}
+	}
+
+	if ret.PerPage == nil && p.PerPage != nil {
+		perPage := *p.PerPage
+		ret.PerPage = &perPage
+	}
+
+	return &ret
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderingProfile_Apply(t *testing.T) {
+	desc := SortDirectionEnumDesc
+	asc := SortDirectionEnumAsc
+	perPage := 40
+	customPerPage := 10
+	sort := "title"
+	empty := ""
+
+	p := OrderingProfile{
+		Mode:      FilterModeScenes,
+		Sort:      "studio",
+		Direction: &asc,
+		PerPage:   &perPage,
+		ThenBy:    []*SortKey{{Sort: "date", Direction: &desc}},
+	}
+
+	tests := []struct {
+		name       string
+		findFilter *FindFilterType
+		want       *FindFilterType
+	}{
+		{
+			"nil",
+			nil,
+			&FindFilterType{Sort: &p.Sort, Direction: &asc, PerPage: &perPage, ThenBy: p.ThenBy},
+		},
+		{
+			"empty sort",
+			&FindFilterType{Sort: &empty, PerPage: &customPerPage},
+			&FindFilterType{Sort: &p.Sort, Direction: &asc, PerPage: &customPerPage, ThenBy: p.ThenBy},
+		},
+		{
+			"sort set",
+			&FindFilterType{Sort: &sort, Direction: &desc},
+			&FindFilterType{Sort: &sort, Direction: &desc, PerPage: &perPage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.Apply(tt.findFilter))
+		})
+	}
+}
+
+func TestOrderingProfile_Validate(t *testing.T) {
+	invalidDirection := SortDirectionEnum("UP")
+	allPerPage := PerPageAll
+	invalidPerPage := -2
+
+	tests := []struct {
+		name    string
+		p       OrderingProfile
+		wantErr bool
+	}{
+		{"valid", OrderingProfile{Mode: FilterModeScenes, Sort: "date", PerPage: &allPerPage, ThenBy: []*SortKey{{Sort: "title"}}}, false},
+		{"invalid mode", OrderingProfile{Mode: "SONGS", Sort: "date"}, true},
+		{"blank sort", OrderingProfile{Mode: FilterModeScenes}, true},
+		{"invalid direction", OrderingProfile{Mode: FilterModeScenes, Sort: "date", Direction: &invalidDirection}, true},
+		{"invalid per page", OrderingProfile{Mode: FilterModeScenes, Sort: "date", PerPage: &invalidPerPage}, true},
+		{"blank then by", OrderingProfile{Mode: FilterModeScenes, Sort: "date", ThenBy: []*SortKey{{}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
@@ -84,6 +84,14 @@ func (gp *generatedPaths) GetThumbnailPath(checksum string, width int) string {
 	return filepath.Join(gp.Thumbnails, fsutil.GetIntraDir(checksum, thumbDirDepth, thumbDirLength), fname)
 }
 
+// GetThumbnailProfilePath returns the path of the thumbnail generated for a
+// thumbnail profile. The key identifies the size, format and quality of the
+// profile, so that changed profiles do not use stale thumbnails.
+func (gp *generatedPaths) GetThumbnailProfilePath(checksum string, key string, ext string) string {
+	fname := fmt.Sprintf("%s_%s.%s", checksum, key, ext)
+	return filepath.Join(gp.Thumbnails, fsutil.GetIntraDir(checksum, thumbDirDepth, thumbDirLength), fname)
+}
+
 func (gp *generatedPaths) GetClipPreviewPath(checksum string, width int) string {
 	fname := fmt.Sprintf("%s_%d.webm", checksum, width)
 	return filepath.Join(gp.Thumbnails, fsutil.GetIntraDir(checksum, thumbDirDepth, thumbDirLength), fname)
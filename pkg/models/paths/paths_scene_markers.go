@@ -31,3 +31,7 @@ func (sp *sceneMarkerPaths) GetWebpPreviewPath(checksum string, seconds int) str
 func (sp *sceneMarkerPaths) GetScreenshotPath(checksum string, seconds int) string {
 	return filepath.Join(sp.GetFolderPath(checksum), strconv.Itoa(seconds)+".jpg")
 }
+
+func (sp *sceneMarkerPaths) GetThumbnailPath(checksum string, seconds int) string {
+	return filepath.Join(sp.GetFolderPath(checksum), strconv.Itoa(seconds)+"_thumb.jpg")
+}
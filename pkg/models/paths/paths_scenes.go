@@ -42,10 +42,20 @@ func (sp *scenePaths) GetWebpPreviewPath(checksum string) string {
 	return filepath.Join(sp.Screenshots, checksum+".webp")
 }
 
+// GetWallPreviewPath returns the path of the lightweight preview video
+// played on the wall by low-power clients.
+func (sp *scenePaths) GetWallPreviewPath(checksum string) string {
+	return filepath.Join(sp.Screenshots, checksum+"_wall.mp4")
+}
+
 func (sp *scenePaths) GetSpriteImageFilePath(checksum string) string {
 	return filepath.Join(sp.Vtt, checksum+"_sprite.jpg")
 }
 
+func (sp *scenePaths) GetSpriteWebpImageFilePath(checksum string) string {
+	return filepath.Join(sp.Vtt, checksum+"_sprite.webp")
+}
+
 func (sp *scenePaths) GetSpriteVttFilePath(checksum string) string {
 	return filepath.Join(sp.Vtt, checksum+"_thumbs.vtt")
 }
@@ -174,6 +174,8 @@ type PerformerFilterType struct {
 	HairColor *StringCriterionInput `json:"hair_color"`
 	// Filter by weight
 	Weight *IntCriterionInput `json:"weight"`
+	// Filter by body mass index
+	Bmi *FloatCriterionInput `json:"bmi"`
 	// Filter by death year
 	DeathYear *IntCriterionInput `json:"death_year"`
 	// Filter by studios where performer appears in scene/image/gallery
@@ -186,6 +188,8 @@ type PerformerFilterType struct {
 	IgnoreAutoTag *bool `json:"ignore_auto_tag"`
 	// Filter by small role value
 	SmallRole *bool `json:"small_role"`
+	// Filter by locked
+	Locked *bool `json:"locked"`
 	// Filter by birthdate
 	Birthdate *DateCriterionInput `json:"birth_date"`
 	// Filter by death date
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PerformerConflictReason is the way in which a scraped performer name
+// matches an existing performer that it could not be matched to.
+type PerformerConflictReason string
+
+const (
+	// PerformerConflictReasonName means that the name is the name of the
+	// performer, spelled differently or shared with other performers.
+	PerformerConflictReasonName PerformerConflictReason = "NAME"
+	// PerformerConflictReasonAlias means that the name is an alias of the
+	// performer.
+	PerformerConflictReasonAlias PerformerConflictReason = "ALIAS"
+	// PerformerConflictReasonSimilarName means that the name is within a
+	// small number of edits of the name or an alias of the performer.
+	PerformerConflictReasonSimilarName PerformerConflictReason = "SIMILAR_NAME"
+)
+
+var AllPerformerConflictReason = []PerformerConflictReason{
+	PerformerConflictReasonName,
+	PerformerConflictReasonAlias,
+	PerformerConflictReasonSimilarName,
+}
+
+func (e PerformerConflictReason) IsValid() bool {
+	switch e {
+	case PerformerConflictReasonName, PerformerConflictReasonAlias, PerformerConflictReasonSimilarName:
+		return true
+	}
+	return false
+}
+
+func (e PerformerConflictReason) String() string {
+	return string(e)
+}
+
+func (e *PerformerConflictReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformerConflictReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerConflictReason", str)
+	}
+	return nil
+}
+
+func (e PerformerConflictReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// PerformerConflict is an existing performer that a scraped performer may be
+// a duplicate of. Conflicts are resolved by linking the scraped name as an
+// alias of the performer, creating a new performer, or merging performers.
+type PerformerConflict struct {
+	PerformerID int                     `json:"performer_id"`
+	Reason      PerformerConflictReason `json:"reason"`
+	// MatchedName is the name or alias of the performer that was matched.
+	MatchedName string `json:"matched_name"`
+}
+
+// PerformerNames are the name and aliases of a performer.
+type PerformerNames struct {
+	ID      int
+	Name    string
+	Aliases []string
+}
@@ -0,0 +1,31 @@
+package models
+
+// PoseTagCoverage is the marker coverage of a single pose tag in a scene.
+type PoseTagCoverage struct {
+	Tag *Tag `json:"tag"`
+	// Number of markers with the pose tag as primary or secondary tag
+	MarkerCount int `json:"marker_count"`
+	// Total duration in seconds of the markers with the pose tag
+	Duration float64 `json:"duration"`
+}
+
+// PoseCoverage is the pose tag coverage of a scene based on its markers.
+type PoseCoverage struct {
+	Poses []*PoseTagCoverage `json:"poses"`
+	// Duration in seconds covered by pose markers. Overlapping markers are
+	// only counted once.
+	TaggedDuration float64 `json:"tagged_duration"`
+	// Ratio of the scene duration covered by pose markers. Nil if the scene
+	// duration is unknown.
+	Coverage *float64 `json:"coverage"`
+}
+
+// PoseTagSuggestion is a pose tag suggested for a scene based on the markers
+// of similar scenes.
+type PoseTagSuggestion struct {
+	Tag *Tag `json:"tag"`
+	// Similarity weighted ratio of similar scenes with the pose tag
+	Score float64 `json:"score"`
+	// Number of similar scenes with the pose tag
+	SceneCount int `json:"scene_count"`
+}
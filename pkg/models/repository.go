@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/pkg/txn"
 )
@@ -21,22 +22,53 @@ type Repository struct {
 	Gallery               GalleryReaderWriter
 	GalleryChapter        GalleryChapterReaderWriter
 	Image                 ImageReaderWriter
+	JobCheckpoint         JobCheckpointReaderWriter
+	LibraryStats          LibraryStatsReaderWriter
+	Consistency           ConsistencyReaderWriter
 	Group                 GroupReaderWriter
+	Note                  NoteReaderWriter
 	Performer             PerformerReaderWriter
 	PerformerProfileImage PerformerProfileImageReaderWriter
 	Scene                 SceneReaderWriter
+	SceneExtra            SceneExtraReaderWriter
 	SceneMarker           SceneMarkerReaderWriter
 	SceneSimilarity       SceneSimilarityReaderWriter
+	SceneParserBatch      SceneParserBatchReaderWriter
+	ShareLink             ShareLinkReaderWriter
+	SmartPlaylist         SmartPlaylistReaderWriter
+	StashBoxSubmission    StashBoxSubmissionReaderWriter
 	Studio                StudioReaderWriter
 	Tag                   TagReaderWriter
 	SavedFilter           SavedFilterReaderWriter
 	ColorPreset           ColorPresetReaderWriter
 }
 
+const (
+	// txnRetries is the number of attempts made by WithRetryTxn to run a
+	// transaction that fails because the database is locked.
+	txnRetries = 4
+	// txnBackoff is the maximum time waited before retrying such a
+	// transaction for the first time.
+	txnBackoff = 100 * time.Millisecond
+)
+
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
 	return txn.WithTxn(ctx, r.TxnManager, fn)
 }
 
+// WithRetryTxn executes fn in a writable transaction, which is retried if it
+// fails because the database is locked by another connection. fn may be
+// called more than once, so it must reset any state it sets outside of the
+// transaction, and changes outside of the database should be made in
+// post-commit hooks.
+func (r *Repository) WithRetryTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.Retryer{
+		Manager: r.TxnManager,
+		Retries: txnRetries,
+		Backoff: txnBackoff,
+	}.WithTxn(ctx, fn)
+}
+
 func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
 	return txn.WithReadTxn(ctx, r.TxnManager, fn)
 }
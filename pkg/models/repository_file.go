@@ -17,6 +17,7 @@ type FileFinder interface {
 	FindAllInPaths(ctx context.Context, p []string, limit, offset int) ([]File, error)
 	FindByPath(ctx context.Context, path string) (File, error)
 	FindByFingerprint(ctx context.Context, fp Fingerprint) ([]File, error)
+	FindVideoFilesBySizeAndDuration(ctx context.Context, size int64, duration float64, tolerance float64) ([]File, error)
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]File, error)
 	FindByFileInfo(ctx context.Context, info fs.FileInfo, size int64) ([]File, error)
 	FindByBasenameAndParentFolderID(ctx context.Context, basename string, parentFolderID FolderID) (File, error)
@@ -71,6 +72,7 @@ type FileReader interface {
 
 	GetCaptions(ctx context.Context, fileID FileID) ([]*VideoCaption, error)
 	IsPrimary(ctx context.Context, fileID FileID) (bool, error)
+	FindExactDuplicates(ctx context.Context, fingerprintType string) ([][]FileID, error)
 }
 
 type FileFingerprintWriter interface {
@@ -76,6 +76,7 @@ type GalleryReader interface {
 	ViewDateReader
 
 	All(ctx context.Context) ([]*Gallery, error)
+	GetImageOrderIndex(ctx context.Context, galleryID int, imageID int) (*int, error)
 }
 
 // GalleryOCounter provides methods to manage o-counter for galleries.
@@ -112,6 +113,7 @@ type GalleryWriter interface {
 	RemoveImages(ctx context.Context, galleryID int, imageIDs ...int) error
 	SetCover(ctx context.Context, galleryID int, coverImageID int) error
 	ResetCover(ctx context.Context, galleryID int) error
+	ReorderImages(ctx context.Context, galleryID int, imageIDs []int, insertPointID int, insertAfter bool) error
 }
 
 // GalleryReaderWriter provides all gallery methods.
@@ -20,6 +20,12 @@ type PerformerFinder interface {
 	FindByNames(ctx context.Context, names []string, nocase bool) ([]*Performer, error)
 }
 
+// PerformerNamesFinder provides methods to find the names of performers.
+type PerformerNamesFinder interface {
+	// AllNames returns the names and aliases of all performers.
+	AllNames(ctx context.Context) ([]PerformerNames, error)
+}
+
 // PerformerQueryer provides methods to query performers.
 type PerformerQueryer interface {
 	Query(ctx context.Context, performerFilter *PerformerFilterType, findFilter *FindFilterType) ([]*Performer, int, error)
@@ -58,6 +64,14 @@ type PerformerDestroyer interface {
 	Destroy(ctx context.Context, id int) error
 }
 
+// PerformerMerger provides methods to merge performers.
+type PerformerMerger interface {
+	// Merge moves the relationships of the source performers to the
+	// destination, adds their names as aliases of the destination, and
+	// destroys them.
+	Merge(ctx context.Context, source []int, destination int) error
+}
+
 type PerformerFinderCreator interface {
 	PerformerFinder
 	PerformerCreator
@@ -71,6 +85,7 @@ type PerformerCreatorUpdater interface {
 // PerformerReader provides all methods to read performers.
 type PerformerReader interface {
 	PerformerFinder
+	PerformerNamesFinder
 	PerformerQueryer
 	PerformerAutoTagQueryer
 	PerformerCounter
@@ -93,6 +108,7 @@ type PerformerWriter interface {
 	PerformerCreator
 	PerformerUpdater
 	PerformerDestroyer
+	PerformerMerger
 }
 
 // PerformerReaderWriter provides all performer methods.
@@ -3,6 +3,8 @@ package models
 import (
 	"context"
 	"time"
+
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 // SceneGetter provides methods to get scenes by ID.
@@ -28,6 +30,7 @@ type SceneFinder interface {
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGroupID(ctx context.Context, groupID int) ([]*Scene, error)
 	FindDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]*Scene, error)
+	FindAllPhashes(ctx context.Context) ([]*utils.Phash, error)
 }
 
 // SceneQueryer provides methods to query scenes.
@@ -74,12 +77,20 @@ type ViewDateReader interface {
 	CountUniqueViews(ctx context.Context) (int, error)
 	GetManyViewCount(ctx context.Context, ids []int) ([]int, error)
 	GetViewDates(ctx context.Context, relatedID int) ([]time.Time, error)
+	GetViewDatesInRange(ctx context.Context, start, end time.Time) ([]time.Time, error)
+	GetViewEventsInRange(ctx context.Context, start, end time.Time) ([]HistoryEvent, error)
 	GetManyViewDates(ctx context.Context, ids []int) ([][]time.Time, error)
 	GetManyLastViewed(ctx context.Context, ids []int) ([]*time.Time, error)
 	GetAggregatedViewHistory(ctx context.Context, page, perPage int) ([]AggregatedView, error)
 	GetAggregatedViewHistoryCount(ctx context.Context) (int, error)
 }
 
+// HistoryEvent is a single view or o-count of the object with the ID
+type HistoryEvent struct {
+	ID   int       `json:"id"`
+	Date time.Time `json:"date"`
+}
+
 // ViewEvent represents a single view event with scene information
 type ViewEvent struct {
 	SceneID  int        `json:"scene_id"`
@@ -113,6 +124,8 @@ type CombinedAggregatedView struct {
 	ODate       *time.Time `json:"o_date"`
 	OmgDate     *time.Time `json:"omg_date"`
 	ViewCount   int        `json:"view_count"`
+	// Date of the earliest view in the group
+	EarliestViewDate time.Time `json:"earliest_view_date"`
 }
 
 type ODateReader interface {
@@ -122,6 +135,7 @@ type ODateReader interface {
 	GetODates(ctx context.Context, relatedID int) ([]time.Time, error)
 	GetManyODates(ctx context.Context, ids []int) ([][]time.Time, error)
 	GetODatesInRange(ctx context.Context, start, end time.Time) ([]time.Time, error)
+	GetOEventsInRange(ctx context.Context, start, end time.Time) ([]HistoryEvent, error)
 }
 
 type OMGDateReader interface {
@@ -153,7 +167,7 @@ type SceneReader interface {
 	StashIDLoader
 	VideoFileLoader
 
-	GetCombinedAggregatedViewHistory(ctx context.Context, page, perPage int) ([]CombinedAggregatedView, error)
+	GetCombinedAggregatedViewHistory(ctx context.Context, options CombinedViewHistoryOptions) ([]CombinedAggregatedView, error)
 	GetCombinedAggregatedViewHistoryCount(ctx context.Context) (int, error)
 	GetOMGCounter(ctx context.Context, id int) (int, error)
 
@@ -183,6 +197,12 @@ type ViewHistoryWriter interface {
 	AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error)
 	DeleteViews(ctx context.Context, id int, dates []time.Time) ([]time.Time, error)
 	DeleteAllViews(ctx context.Context, id int) (int, error)
+	// DeleteViewsInRange deletes the views of the object between start and
+	// end inclusive. Returns the number of deleted views.
+	DeleteViewsInRange(ctx context.Context, id int, start, end time.Time) (int, error)
+	// DeleteViewsBefore deletes the views of all objects before the given
+	// time. Returns the number of deleted views.
+	DeleteViewsBefore(ctx context.Context, before time.Time) (int, error)
 }
 
 // SceneWriter provides all methods to modify scenes.
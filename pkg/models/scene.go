@@ -1,6 +1,9 @@
 package models
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type PHashDuplicationCriterionInput struct {
 	Duplicated *bool `json:"duplicated"`
@@ -15,6 +18,8 @@ type SceneFilterType struct {
 	Code     *StringCriterionInput `json:"code"`
 	Details  *StringCriterionInput `json:"details"`
 	Director *StringCriterionInput `json:"director"`
+	// Filter by on-screen text recognized in the scene
+	OCRText *StringCriterionInput `json:"ocr_text"`
 	// Filter by file oshash
 	Oshash *StringCriterionInput `json:"oshash"`
 	// Filter by file checksum
@@ -33,8 +38,18 @@ type SceneFilterType struct {
 	Organized *bool `json:"organized"`
 	// Filter by pinned
 	Pinned *bool `json:"pinned"`
+	// Filter by locked
+	Locked *bool `json:"locked"`
 	// Filter by is_broken
 	IsBroken *bool `json:"is_broken"`
+	// Filter by whether a file of the scene has detected threats
+	HasThreats *bool `json:"has_threats"`
+	// Filter by whether a file of the scene has a threat of at least the severity
+	ThreatSeverity *ThreatSeverity `json:"threat_severity"`
+	// Filter by whether a file of the scene was last scanned for threats before the time
+	ThreatsScannedBefore *time.Time `json:"threats_scanned_before"`
+	// Filter by whether a file of the scene was never scanned for threats
+	ThreatsNeverScanned *bool `json:"threats_never_scanned"`
 	// Filter by o-counter
 	OCounter *IntCriterionInput `json:"o_counter"`
 	// Filter by omg-counter
@@ -53,10 +68,16 @@ type SceneFilterType struct {
 	VideoCodec *StringCriterionInput `json:"video_codec"`
 	// Filter by audio codec
 	AudioCodec *StringCriterionInput `json:"audio_codec"`
+	// Filter by audio language
+	AudioLanguage *StringCriterionInput `json:"audio_language"`
 	// Filter by duration (in seconds)
 	Duration *IntCriterionInput `json:"duration"`
+	// Filter by color preset tag requirements status
+	TagRequirements *TagRequirementsCriterionInput `json:"tag_requirements"`
 	// Filter to only include scenes which have markers. `true` or `false`
 	HasMarkers *string `json:"has_markers"`
+	// Filter to only include scenes which have markers with pose tags
+	HasPoseCoverage *bool `json:"has_pose_coverage"`
 	// Filter to only include scenes missing this property
 	IsMissing *string `json:"is_missing"`
 	// Filter to only include scenes with this studio
@@ -229,19 +250,24 @@ type SceneUpdateInput struct {
 	EndTime         *float64         `json:"end_time"`
 	VideoFilters    *VideoFilters    `json:"video_filters"`
 	VideoTransforms *VideoTransforms `json:"video_transforms"`
+	TranscodeArgs   *TranscodeArgs   `json:"transcode_args"`
+	Deinterlace     *bool            `json:"deinterlace"`
 	PrimaryFileID   *string          `json:"primary_file_id"`
 }
 
 type SceneDestroyInput struct {
-	ID              string `json:"id"`
-	DeleteFile      *bool  `json:"delete_file"`
-	DeleteGenerated *bool  `json:"delete_generated"`
+	ID                string  `json:"id"`
+	DeleteFile        *bool   `json:"delete_file"`
+	DeleteGenerated   *bool   `json:"delete_generated"`
+	ConfirmationToken *string `json:"confirmation_token"`
 }
 
 type ScenesDestroyInput struct {
-	Ids             []string `json:"ids"`
-	DeleteFile      *bool    `json:"delete_file"`
-	DeleteGenerated *bool    `json:"delete_generated"`
+	Ids               []string `json:"ids"`
+	DeleteFile        *bool    `json:"delete_file"`
+	DeleteGenerated   *bool    `json:"delete_generated"`
+	ConfirmationToken *string  `json:"confirmation_token"`
+	IncludeLocked     *bool    `json:"include_locked"`
 }
 
 type ReduceResolutionInput struct {
@@ -258,6 +284,30 @@ type TrimVideoInput struct {
 	EndTime   float64 `json:"end_time"`
 }
 
+type ManageAudioTracksInput struct {
+	SceneID      string `json:"scene_id"`
+	FileID       string `json:"file_id"`
+	Tracks       []int  `json:"tracks"`
+	DefaultTrack *int   `json:"default_track"`
+	Extract      []int  `json:"extract"`
+}
+
+type VideoCropInput struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type TransformVideoInput struct {
+	SceneID        string          `json:"scene_id"`
+	FileID         string          `json:"file_id"`
+	Rotate         *int            `json:"rotate"`
+	Crop           *VideoCropInput `json:"crop"`
+	FlipHorizontal *bool           `json:"flip_horizontal"`
+	FlipVertical   *bool           `json:"flip_vertical"`
+}
+
 func NewSceneQueryResult(getter SceneGetter) *SceneQueryResult {
 	return &SceneQueryResult{
 		getter: getter,
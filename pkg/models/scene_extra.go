@@ -0,0 +1,22 @@
+package models
+
+import "context"
+
+type SceneExtraReader interface {
+	// FindBySceneID returns the extras of the scene, ordered by role.
+	FindBySceneID(ctx context.Context, sceneID int) ([]*SceneExtra, error)
+	// FindByFileID returns nil, nil if the file is not an extra.
+	FindByFileID(ctx context.Context, fileID FileID) (*SceneExtra, error)
+}
+
+type SceneExtraWriter interface {
+	// Set makes the file an extra of the scene with the role, removing it
+	// from the files of any scene.
+	Set(ctx context.Context, extra SceneExtra) error
+	Destroy(ctx context.Context, fileID FileID) error
+}
+
+type SceneExtraReaderWriter interface {
+	SceneExtraReader
+	SceneExtraWriter
+}
@@ -0,0 +1,25 @@
+package models
+
+import "context"
+
+type SceneParserBatchReader interface {
+	All(ctx context.Context) ([]*SceneParserBatch, error)
+	Find(ctx context.Context, id int) (*SceneParserBatch, error)
+	// FindChanges returns the changes of the batch, optionally filtered by
+	// status.
+	FindChanges(ctx context.Context, batchID int, status *SceneParserChangeStatus) ([]*SceneParserChange, error)
+}
+
+type SceneParserBatchWriter interface {
+	// Create creates the batch along with its changes.
+	Create(ctx context.Context, newObject *SceneParserBatch, changes []*SceneParserChange) error
+	// UpdateChangeStatus sets the status of the changes. Changes that have
+	// already been applied are not updated.
+	UpdateChangeStatus(ctx context.Context, ids []int, status SceneParserChangeStatus) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type SceneParserBatchReaderWriter interface {
+	SceneParserBatchReader
+	SceneParserBatchWriter
+}
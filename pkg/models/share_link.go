@@ -0,0 +1,25 @@
+package models
+
+import "context"
+
+type ShareLinkReader interface {
+	Find(ctx context.Context, id int) (*ShareLink, error)
+	// FindByToken returns nil, nil if the token does not exist.
+	FindByToken(ctx context.Context, token string) (*ShareLink, error)
+	// FindByObject returns the links of the scene or gallery, or all links if
+	// sceneID and galleryID are both nil.
+	FindByObject(ctx context.Context, sceneID *int, galleryID *int) ([]*ShareLink, error)
+}
+
+type ShareLinkWriter interface {
+	Create(ctx context.Context, newObject *ShareLink) error
+	// IncrementViewCount increments the view count of the link if its view
+	// limit has not been reached. Returns false if it has.
+	IncrementViewCount(ctx context.Context, id int) (bool, error)
+	Destroy(ctx context.Context, id int) error
+}
+
+type ShareLinkReaderWriter interface {
+	ShareLinkReader
+	ShareLinkWriter
+}
@@ -0,0 +1,21 @@
+package models
+
+import "context"
+
+type SmartPlaylistReader interface {
+	// Find returns nil, nil if the playlist does not exist.
+	Find(ctx context.Context, id int) (*SmartPlaylist, error)
+	// All returns the playlists ordered by name.
+	All(ctx context.Context) ([]*SmartPlaylist, error)
+}
+
+type SmartPlaylistWriter interface {
+	Create(ctx context.Context, newObject *SmartPlaylist) error
+	Update(ctx context.Context, updatedObject *SmartPlaylist) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type SmartPlaylistReaderWriter interface {
+	SmartPlaylistReader
+	SmartPlaylistWriter
+}
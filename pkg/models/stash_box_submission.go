@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+type StashBoxSubmissionReader interface {
+	FindMany(ctx context.Context, ids []int) ([]*StashBoxSubmission, error)
+	// FindByEndpoint returns the submissions to the endpoint, most recent
+	// first.
+	FindByEndpoint(ctx context.Context, endpoint string) ([]*StashBoxSubmission, error)
+}
+
+type StashBoxSubmissionWriter interface {
+	Create(ctx context.Context, newObject *StashBoxSubmission) error
+	Update(ctx context.Context, updatedObject *StashBoxSubmission) error
+}
+
+type StashBoxSubmissionReaderWriter interface {
+	StashBoxSubmissionReader
+	StashBoxSubmissionWriter
+}
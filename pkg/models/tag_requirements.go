@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type TagRequirementsStatus string
+
+const (
+	// All required color presets are satisfied
+	TagRequirementsStatusSatisfied TagRequirementsStatus = "SATISFIED"
+	// At least TagRequirementsPartialRatio of the required color presets are
+	// satisfied
+	TagRequirementsStatusPartial TagRequirementsStatus = "PARTIAL"
+	// Fewer than TagRequirementsPartialRatio of the required color presets
+	// are satisfied
+	TagRequirementsStatusUnsatisfied TagRequirementsStatus = "UNSATISFIED"
+)
+
+var AllTagRequirementsStatus = []TagRequirementsStatus{
+	TagRequirementsStatusSatisfied,
+	TagRequirementsStatusPartial,
+	TagRequirementsStatusUnsatisfied,
+}
+
+func (e TagRequirementsStatus) IsValid() bool {
+	switch e {
+	case TagRequirementsStatusSatisfied, TagRequirementsStatusPartial, TagRequirementsStatusUnsatisfied:
+		return true
+	}
+	return false
+}
+
+func (e TagRequirementsStatus) String() string {
+	return string(e)
+}
+
+func (e *TagRequirementsStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TagRequirementsStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TagRequirementsStatus", str)
+	}
+	return nil
+}
+
+func (e TagRequirementsStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// TagRequirementsPartialRatio is the ratio of required color presets that
+// must be satisfied for the requirements to be partially satisfied.
+const TagRequirementsPartialRatio = 0.75
+
+// ColorPresetRequirement is the evaluation of a single color preset
+// requirement.
+type ColorPresetRequirement struct {
+	ColorPreset *ColorPreset `json:"color_preset"`
+	// Required is false for optional requirements, which do not count
+	// towards the status.
+	Required bool `json:"required"`
+	// Satisfied is true if a tag with the color of the preset is present.
+	Satisfied bool `json:"satisfied"`
+}
+
+// TagRequirements is the evaluation of the color preset requirements for a
+// set of tags.
+type TagRequirements struct {
+	Status TagRequirementsStatus `json:"status"`
+	// Number of satisfied required presets
+	Satisfied int `json:"satisfied"`
+	// Number of required presets
+	Total int `json:"total"`
+	// Number of required presets that must be satisfied for partial status
+	MinRequired  int                       `json:"min_required"`
+	Requirements []*ColorPresetRequirement `json:"requirements"`
+}
+
+// IsRequirementPreset returns true if the color preset describes a tag
+// requirement. Presets without a requirements description only name a
+// color.
+func (p ColorPreset) IsRequirementPreset() bool {
+	return strings.TrimSpace(p.TagRequirementsDescription) != ""
+}
+
+// TagRequirementsMinRequired returns the number of required presets that must
+// be satisfied for the requirements to be partially satisfied.
+func TagRequirementsMinRequired(total int) int {
+	// equivalent to ceil(total * TagRequirementsPartialRatio), without
+	// floating point errors
+	return (total*3 + 3) / 4
+}
+
+// EvaluateTagRequirements evaluates the color preset requirements against the
+// colors of the tags. A requirement is satisfied if a tag has the color of
+// the preset. Colors are compared case-insensitively.
+func EvaluateTagRequirements(presets []*ColorPreset, tagColors []string) *TagRequirements {
+	colors := make(map[string]bool)
+	for _, c := range tagColors {
+		if c != "" {
+			colors[strings.ToLower(c)] = true
+		}
+	}
+
+	ret := &TagRequirements{}
+	for _, p := range presets {
+		if !p.IsRequirementPreset() {
+			continue
+		}
+
+		r := &ColorPresetRequirement{
+			ColorPreset: p,
+			Required:    p.RequiredForRequirements,
+			Satisfied:   colors[strings.ToLower(p.Color)],
+		}
+		ret.Requirements = append(ret.Requirements, r)
+
+		if r.Required {
+			ret.Total++
+			if r.Satisfied {
+				ret.Satisfied++
+			}
+		}
+	}
+
+	sort.SliceStable(ret.Requirements, func(i, j int) bool {
+		return ret.Requirements[i].ColorPreset.Sort < ret.Requirements[j].ColorPreset.Sort
+	})
+
+	ret.MinRequired = TagRequirementsMinRequired(ret.Total)
+
+	switch {
+	case ret.Satisfied == ret.Total:
+		ret.Status = TagRequirementsStatusSatisfied
+	case ret.Satisfied >= ret.MinRequired:
+		ret.Status = TagRequirementsStatusPartial
+	default:
+		ret.Status = TagRequirementsStatusUnsatisfied
+	}
+
+	return ret
+}
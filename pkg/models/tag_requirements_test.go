@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagRequirementsMinRequired(t *testing.T) {
+	tests := []struct {
+		total int
+		want  int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{3, 3},
+		{4, 3},
+		{5, 4},
+		{8, 6},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, TagRequirementsMinRequired(tt.total), "total %d", tt.total)
+	}
+}
+
+func TestEvaluateTagRequirements(t *testing.T) {
+	presets := []*ColorPreset{
+		{ID: 1, Color: "#ff0000", Sort: 2, TagRequirementsDescription: "action", RequiredForRequirements: true},
+		{ID: 2, Color: "#00FF00", Sort: 1, TagRequirementsDescription: "location", RequiredForRequirements: true},
+		{ID: 3, Color: "#0000ff", Sort: 3, TagRequirementsDescription: "clothing", RequiredForRequirements: true},
+		{ID: 4, Color: "#ffff00", Sort: 4, TagRequirementsDescription: "lighting", RequiredForRequirements: true},
+		{ID: 5, Color: "#00ffff", Sort: 0, TagRequirementsDescription: "extra", RequiredForRequirements: false},
+		// not a requirement preset
+		{ID: 6, Color: "#ffffff", Sort: 0},
+	}
+
+	tests := []struct {
+		name      string
+		colors    []string
+		status    TagRequirementsStatus
+		satisfied int
+	}{
+		{"none", nil, TagRequirementsStatusUnsatisfied, 0},
+		{"partial", []string{"#FF0000", "#00ff00", "#0000ff"}, TagRequirementsStatusPartial, 3},
+		{"optional only", []string{"#00ffff", "#ffffff"}, TagRequirementsStatusUnsatisfied, 0},
+		{"all", []string{"#ff0000", "#00ff00", "#0000ff", "#ffff00", ""}, TagRequirementsStatusSatisfied, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateTagRequirements(presets, tt.colors)
+			assert.Equal(t, tt.status, got.Status)
+			assert.Equal(t, tt.satisfied, got.Satisfied)
+			assert.Equal(t, 4, got.Total)
+			assert.Equal(t, 3, got.MinRequired)
+			if assert.Len(t, got.Requirements, 5) {
+				// sorted by preset sort order
+				assert.Equal(t, 5, got.Requirements[0].ColorPreset.ID)
+				assert.False(t, got.Requirements[0].Required)
+				assert.Equal(t, 2, got.Requirements[1].ColorPreset.ID)
+			}
+		})
+	}
+
+	got := EvaluateTagRequirements(nil, []string{"#ff0000"})
+	assert.Equal(t, TagRequirementsStatusSatisfied, got.Status)
+	assert.Equal(t, 0, got.Total)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ThreatSeverity is the severity of a threat found in a video file.
+type ThreatSeverity string
+
+const (
+	ThreatSeverityLow      ThreatSeverity = "LOW"
+	ThreatSeverityMedium   ThreatSeverity = "MEDIUM"
+	ThreatSeverityHigh     ThreatSeverity = "HIGH"
+	ThreatSeverityCritical ThreatSeverity = "CRITICAL"
+)
+
+// AllThreatSeverity is every severity, from the lowest.
+var AllThreatSeverity = []ThreatSeverity{
+	ThreatSeverityLow,
+	ThreatSeverityMedium,
+	ThreatSeverityHigh,
+	ThreatSeverityCritical,
+}
+
+func (e ThreatSeverity) IsValid() bool {
+	switch e {
+	case ThreatSeverityLow, ThreatSeverityMedium, ThreatSeverityHigh, ThreatSeverityCritical:
+		return true
+	}
+	return false
+}
+
+// AtLeast returns true if the severity is the same as or higher than o.
+func (e ThreatSeverity) AtLeast(o ThreatSeverity) bool {
+	return e.level() >= o.level()
+}
+
+func (e ThreatSeverity) level() int {
+	for i, s := range AllThreatSeverity {
+		if s == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e ThreatSeverity) String() string {
+	return string(e)
+}
+
+func (e *ThreatSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ThreatSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ThreatSeverity", str)
+	}
+	return nil
+}
+
+func (e ThreatSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
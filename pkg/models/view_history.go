@@ -0,0 +1,111 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ViewHistoryGrouping string
+
+const (
+	// Views of the same item on the same day are grouped
+	ViewHistoryGroupingDay ViewHistoryGrouping = "DAY"
+	// Views of the same item less than ViewHistorySessionGap apart are
+	// grouped
+	ViewHistoryGroupingSession ViewHistoryGrouping = "SESSION"
+)
+
+var AllViewHistoryGrouping = []ViewHistoryGrouping{
+	ViewHistoryGroupingDay,
+	ViewHistoryGroupingSession,
+}
+
+func (e ViewHistoryGrouping) IsValid() bool {
+	switch e {
+	case ViewHistoryGroupingDay, ViewHistoryGroupingSession:
+		return true
+	}
+	return false
+}
+
+func (e ViewHistoryGrouping) String() string {
+	return string(e)
+}
+
+func (e *ViewHistoryGrouping) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ViewHistoryGrouping(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ViewHistoryGrouping", str)
+	}
+	return nil
+}
+
+func (e ViewHistoryGrouping) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ViewHistorySessionGap is the maximum time between two views of the same
+// item for them to be in the same session.
+const ViewHistorySessionGap = 30 * time.Minute
+
+// ViewHistoryCursor is the position of an entry in the view history, which
+// is ordered by view date, content type and content id descending.
+type ViewHistoryCursor struct {
+	ViewDate    time.Time
+	ContentType string
+	ContentID   int
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c ViewHistoryCursor) Encode() string {
+	s := fmt.Sprintf("%s|%s|%d", c.ViewDate.UTC().Format(time.RFC3339), c.ContentType, c.ContentID)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeViewHistoryCursor parses a cursor returned by Encode.
+func DecodeViewHistoryCursor(s string) (*ViewHistoryCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.Split(string(b), "|")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	viewDate, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor date: %w", err)
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor id: %w", err)
+	}
+
+	return &ViewHistoryCursor{
+		ViewDate:    viewDate,
+		ContentType: parts[1],
+		ContentID:   id,
+	}, nil
+}
+
+// CombinedViewHistoryOptions are the options for querying the combined view
+// history of scenes and galleries.
+type CombinedViewHistoryOptions struct {
+	Grouping ViewHistoryGrouping
+	// If set, only entries after the cursor are returned and Page is ignored
+	After   *ViewHistoryCursor
+	Page    int
+	PerPage int
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewHistoryCursor(t *testing.T) {
+	c := ViewHistoryCursor{
+		ViewDate:    time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		ContentType: "scene",
+		ContentID:   12,
+	}
+
+	got, err := DecodeViewHistoryCursor(c.Encode())
+	if assert.NoError(t, err) {
+		assert.True(t, c.ViewDate.Equal(got.ViewDate))
+		assert.Equal(t, c.ContentType, got.ContentType)
+		assert.Equal(t, c.ContentID, got.ContentID)
+	}
+
+	for _, s := range []string{"", "!!", "YWJj"} {
+		_, err := DecodeViewHistoryCursor(s)
+		assert.Error(t, err, s)
+	}
+}
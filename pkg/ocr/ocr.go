@@ -0,0 +1,236 @@
+// Package ocr extracts on-screen text, such as titles, watermarks and site
+// names, from video frames. Text is recognized by a pluggable backend: an
+// OCR program such as tesseract, or an HTTP service.
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"unicode"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+)
+
+const (
+	// ImagePlaceholder is replaced with the path of the image in the
+	// arguments of a command backend.
+	ImagePlaceholder = "{image}"
+
+	// maxResponseSize is the maximum size of a backend response, in bytes.
+	maxResponseSize = 1024 * 1024
+
+	// minLineLength is the minimum length of a recognized line of text.
+	// Shorter lines are usually noise.
+	minLineLength = 3
+	// minLineLetterRatio is the minimum proportion of letters and digits in
+	// a recognized line of text.
+	minLineLetterRatio = 0.5
+)
+
+// DefaultArgs are the arguments of a command backend if none are
+// configured. These write the text recognized by tesseract to stdout.
+var DefaultArgs = []string{ImagePlaceholder, "stdout"}
+
+// Backend recognizes the text in an image.
+type Backend interface {
+	// Recognize returns the text in the PNG encoded image.
+	Recognize(ctx context.Context, img []byte) (string, error)
+}
+
+// Command is a backend running an OCR program, which writes the text in the
+// image to stdout.
+type Command struct {
+	Path string
+	// Args are the arguments of the program. ImagePlaceholder is replaced
+	// with the path of the image. Defaults to DefaultArgs.
+	Args []string
+}
+
+func (c Command) Recognize(ctx context.Context, img []byte) (string, error) {
+	f, err := os.CreateTemp("", "stash-ocr-*.png")
+	if err != nil {
+		return "", fmt.Errorf("creating image file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(img)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("writing image file: %w", err)
+	}
+
+	args := c.Args
+	if len(args) == 0 {
+		args = DefaultArgs
+	}
+
+	cmdArgs := make([]string, len(args))
+	for i, a := range args {
+		cmdArgs[i] = strings.ReplaceAll(a, ImagePlaceholder, f.Name())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := stashExec.CommandContext(ctx, c.Path, cmdArgs...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running %s: %w: %s", c.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// HTTP is a backend posting the image to an OCR service. The image is posted
+// to the endpoint as the "image" field of a multipart form, and the endpoint
+// responds with a JSON object of the form
+//
+//	{"text": "..."}
+type HTTP struct {
+	Endpoint string
+	// APIKey is sent as a bearer token, if set.
+	APIKey     string
+	UserAgent  string
+	HTTPClient *http.Client
+}
+
+type httpResponse struct {
+	Text string `json:"text"`
+}
+
+func (h HTTP) Recognize(ctx context.Context, img []byte) (string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("image", "image.png")
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(img); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Endpoint, &body)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	if h.UserAgent != "" {
+		req.Header.Set("User-Agent", h.UserAgent)
+	}
+	if h.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.APIKey)
+	}
+
+	client := h.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("http error %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	var r httpResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&r); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	return r.Text, nil
+}
+
+// ValidateEndpoint returns an error if endpoint is not an http or https url.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("endpoint must be an http or https url")
+	}
+
+	return nil
+}
+
+// ErrNoBackend is returned when no OCR backend is configured.
+var ErrNoBackend = errors.New("no OCR backend is configured")
+
+// NewBackend returns the HTTP backend if endpoint is set, otherwise the
+// command backend if command is set. Returns ErrNoBackend if neither is set.
+func NewBackend(command string, args []string, endpoint string, apiKey string) (Backend, error) {
+	switch {
+	case endpoint != "":
+		return HTTP{
+			Endpoint: endpoint,
+			APIKey:   apiKey,
+		}, nil
+	case command != "":
+		return Command{
+			Path: command,
+			Args: args,
+		}, nil
+	default:
+		return nil, ErrNoBackend
+	}
+}
+
+// Merge combines the text recognized in the frames of a video. Whitespace is
+// collapsed, lines that are too short or mostly symbols are discarded as
+// noise, and repeated lines, such as watermarks shown throughout the video,
+// are kept once.
+func Merge(texts []string) string {
+	seen := make(map[string]bool)
+	var lines []string
+	for _, t := range texts {
+		for _, l := range strings.Split(t, "\n") {
+			l = strings.Join(strings.Fields(l), " ")
+			if !isText(l) {
+				continue
+			}
+
+			key := strings.ToLower(l)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			lines = append(lines, l)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isText(line string) bool {
+	runes := []rune(line)
+	if len(runes) < minLineLength {
+		return false
+	}
+
+	letters := 0
+	for _, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			letters++
+		}
+	}
+
+	return float64(letters)/float64(len(runes)) >= minLineLetterRatio
+}
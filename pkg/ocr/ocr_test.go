@@ -0,0 +1,69 @@
+package ocr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	texts := []string{
+		"  SiteName.com  \n~~\n\nEpisode   12",
+		"sitename.com\n|_-=\nA\nPresents",
+		"",
+	}
+
+	assert.Equal(t, "SiteName.com\nEpisode 12\nPresents", Merge(texts))
+	assert.Equal(t, "", Merge(nil))
+}
+
+func TestNewBackend(t *testing.T) {
+	_, err := NewBackend("", nil, "", "")
+	assert.ErrorIs(t, err, ErrNoBackend)
+
+	b, err := NewBackend("tesseract", nil, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, Command{Path: "tesseract"}, b)
+
+	b, err = NewBackend("tesseract", nil, "http://localhost/ocr", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, HTTP{Endpoint: "http://localhost/ocr", APIKey: "key"}, b)
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	assert.NoError(t, ValidateEndpoint("https://localhost:8000/ocr"))
+	assert.Error(t, ValidateEndpoint("ftp://localhost/ocr"))
+	assert.Error(t, ValidateEndpoint("/ocr"))
+}
+
+func TestHTTPRecognize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		f, _, err := r.FormFile("image")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer f.Close()
+
+		data, _ := io.ReadAll(f)
+		assert.Equal(t, "png", string(data))
+
+		_, _ = w.Write([]byte(`{"text": "Site Name"}`))
+	}))
+	defer server.Close()
+
+	h := HTTP{Endpoint: server.URL, APIKey: "key"}
+	text, err := h.Recognize(context.Background(), []byte("png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Site Name", text)
+
+	h.Endpoint = server.URL + "/missing"
+	server.Config.Handler = http.NotFoundHandler()
+	_, err = h.Recognize(context.Background(), []byte("png"))
+	assert.Error(t, err)
+}
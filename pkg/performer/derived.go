@@ -0,0 +1,138 @@
+package performer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Limits outside of which performer measurements are reported as
+// inconsistent. They are deliberately loose, and are only intended to catch
+// data entry errors such as swapped fields or wrong units.
+const (
+	minHeightCm = 100
+	maxHeightCm = 230
+	minWeightKg = 30
+	maxWeightKg = 250
+	minBMI      = 13
+	maxBMI      = 50
+)
+
+// AgeAt returns the age in whole years of someone born on birthdate at the
+// given date.
+func AgeAt(birthdate models.Date, at models.Date) int {
+	age := at.Year() - birthdate.Year()
+	if at.Month() < birthdate.Month() || (at.Month() == birthdate.Month() && at.Day() < birthdate.Day()) {
+		age--
+	}
+
+	return age
+}
+
+// Age returns the current age of the performer, or their age at death. Returns
+// nil if the performer has no birthdate.
+func Age(p *models.Performer) *int {
+	if p.Birthdate == nil {
+		return nil
+	}
+
+	at := models.Date{Time: time.Now()}
+	if p.DeathDate != nil {
+		at = *p.DeathDate
+	}
+
+	ret := AgeAt(*p.Birthdate, at)
+	return &ret
+}
+
+// BMI returns the body mass index of the performer. Returns nil if the
+// performer does not have both a height and a weight.
+func BMI(p *models.Performer) *float64 {
+	if p.Height == nil || p.Weight == nil || *p.Height <= 0 {
+		return nil
+	}
+
+	m := float64(*p.Height) / 100
+	ret := float64(*p.Weight) / (m * m)
+	return &ret
+}
+
+var careerYearRE = regexp.MustCompile(`\d{4}`)
+
+// CareerSpan returns the start and end years of the performer's career
+// length, which is typically in the form "2010 - 2015" or "2010 -". The end
+// year is nil if the career is ongoing or the end is not known.
+func CareerSpan(careerLength string) (start *int, end *int) {
+	years := careerYearRE.FindAllStringIndex(careerLength, 2)
+	if len(years) == 0 {
+		return nil, nil
+	}
+
+	year := func(loc []int) *int {
+		y, _ := strconv.Atoi(careerLength[loc[0]:loc[1]])
+		return &y
+	}
+
+	// a single year is the start, unless it follows the separator
+	if len(years) == 1 {
+		if dashBefore(careerLength, years[0][0]) {
+			return nil, year(years[0])
+		}
+		return year(years[0]), nil
+	}
+
+	return year(years[0]), year(years[1])
+}
+
+func dashBefore(s string, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		switch s[j] {
+		case ' ':
+			continue
+		case '-':
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// Inconsistencies returns descriptions of the values of the performer that
+// are implausible or contradict each other.
+func Inconsistencies(p *models.Performer) []string {
+	var ret []string
+
+	if p.Height != nil && (*p.Height < minHeightCm || *p.Height > maxHeightCm) {
+		ret = append(ret, fmt.Sprintf("height %dcm is outside %d-%dcm", *p.Height, minHeightCm, maxHeightCm))
+	}
+
+	if p.Weight != nil && (*p.Weight < minWeightKg || *p.Weight > maxWeightKg) {
+		ret = append(ret, fmt.Sprintf("weight %dkg is outside %d-%dkg", *p.Weight, minWeightKg, maxWeightKg))
+	}
+
+	if bmi := BMI(p); bmi != nil && (*bmi < minBMI || *bmi > maxBMI) {
+		ret = append(ret, fmt.Sprintf("BMI %.1f from height and weight is outside %d-%d", *bmi, minBMI, maxBMI))
+	}
+
+	if p.Birthdate != nil && p.DeathDate != nil && p.Birthdate.After(*p.DeathDate) {
+		ret = append(ret, fmt.Sprintf("death date %s is before birthdate %s", p.DeathDate, p.Birthdate))
+	}
+
+	start, end := CareerSpan(p.CareerLength)
+	if start != nil && end != nil && *end < *start {
+		ret = append(ret, fmt.Sprintf("career end %d is before career start %d", *end, *start))
+	}
+	if start != nil && p.Birthdate != nil && *start < p.Birthdate.Year() {
+		ret = append(ret, fmt.Sprintf("career start %d is before birth year %d", *start, p.Birthdate.Year()))
+	}
+	if end != nil && p.DeathDate != nil && *end > p.DeathDate.Year() {
+		ret = append(ret, fmt.Sprintf("career end %d is after death year %d", *end, p.DeathDate.Year()))
+	}
+
+	return ret
+}
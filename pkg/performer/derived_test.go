@@ -0,0 +1,98 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDate(s string) *models.Date {
+	d, err := models.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func TestAgeAt(t *testing.T) {
+	tests := []struct {
+		birthdate string
+		at        string
+		want      int
+	}{
+		{"1990-06-15", "2020-06-14", 29},
+		{"1990-06-15", "2020-06-15", 30},
+		{"1990-06-15", "2020-12-01", 30},
+		{"2000-02-29", "2021-02-28", 20},
+		{"1990-06-15", "1989-01-01", -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.birthdate+" "+tt.at, func(t *testing.T) {
+			assert.Equal(t, tt.want, AgeAt(*testDate(tt.birthdate), *testDate(tt.at)))
+		})
+	}
+}
+
+func TestCareerSpan(t *testing.T) {
+	year := func(y int) *int { return &y }
+
+	tests := []struct {
+		careerLength string
+		wantStart    *int
+		wantEnd      *int
+	}{
+		{"", nil, nil},
+		{"unknown", nil, nil},
+		{"2010 - 2015", year(2010), year(2015)},
+		{"2010-2015", year(2010), year(2015)},
+		{"2010 -", year(2010), nil},
+		{"2010 - present", year(2010), nil},
+		{"- 2015", nil, year(2015)},
+		{"2010", year(2010), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.careerLength, func(t *testing.T) {
+			start, end := CareerSpan(tt.careerLength)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestInconsistencies(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name      string
+		performer models.Performer
+		want      int
+	}{
+		{"empty", models.Performer{}, 0},
+		{"plausible", models.Performer{
+			Height:       intPtr(170),
+			Weight:       intPtr(60),
+			Birthdate:    testDate("1990-01-01"),
+			CareerLength: "2010 - 2015",
+		}, 0},
+		{"height in inches", models.Performer{Height: intPtr(66)}, 1},
+		{"height and weight swapped", models.Performer{Height: intPtr(60), Weight: intPtr(170)}, 2},
+		{"death before birth", models.Performer{
+			Birthdate: testDate("1990-01-01"),
+			DeathDate: testDate("1980-01-01"),
+		}, 1},
+		{"career before birth", models.Performer{
+			Birthdate:    testDate("1990-01-01"),
+			CareerLength: "1985 -",
+		}, 1},
+		{"career end before start", models.Performer{CareerLength: "2015 - 2010"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, Inconsistencies(&tt.performer), tt.want)
+		})
+	}
+}
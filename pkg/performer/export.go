@@ -37,6 +37,7 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 		Details:        performer.Details,
 		HairColor:      performer.HairColor,
 		IgnoreAutoTag:  performer.IgnoreAutoTag,
+		Locked:         performer.Locked,
 		CreatedAt:      json.JSONTime{Time: performer.CreatedAt},
 		UpdatedAt:      json.JSONTime{Time: performer.UpdatedAt},
 	}
@@ -211,6 +211,7 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		HairColor:      performerJSON.HairColor,
 		Favorite:       performerJSON.Favorite,
 		IgnoreAutoTag:  performerJSON.IgnoreAutoTag,
+		Locked:         performerJSON.Locked,
 		CreatedAt:      performerJSON.CreatedAt.GetTime(),
 		UpdatedAt:      performerJSON.UpdatedAt.GetTime(),
 
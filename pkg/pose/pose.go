@@ -0,0 +1,296 @@
+// Package pose provides analytics of pose tags in scene markers.
+package pose
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// DefaultSimilarScenes is the number of similar scenes considered when
+// suggesting pose tags.
+const DefaultSimilarScenes = 20
+
+type MarkerFinder interface {
+	FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneMarker, error)
+	models.TagIDLoader
+}
+
+type TagFinder interface {
+	FindMany(ctx context.Context, ids []int) ([]*models.Tag, error)
+}
+
+type SimilarSceneFinder interface {
+	FindSimilarScenes(ctx context.Context, sceneID int, limit int) ([]*models.SceneSimilarity, error)
+}
+
+// Analyzer computes pose tag coverage and suggestions. It must be used within
+// a transaction.
+type Analyzer struct {
+	Markers    MarkerFinder
+	Tags       TagFinder
+	Similarity SimilarSceneFinder
+}
+
+// markerPoses is a marker with the pose tags among its primary and secondary
+// tags.
+type markerPoses struct {
+	start  float64
+	end    float64
+	tagIDs []int
+}
+
+func (m markerPoses) duration() float64 {
+	return m.end - m.start
+}
+
+// scenePoses returns the markers of the scene which have pose tags, along with
+// the pose tags that were loaded.
+func (a *Analyzer) scenePoses(ctx context.Context, sceneID int, tags map[int]*models.Tag) ([]markerPoses, error) {
+	markers, err := a.Markers.FindBySceneID(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("finding markers for scene %d: %w", sceneID, err)
+	}
+
+	var ret []markerPoses
+	for _, m := range markers {
+		tagIDs, err := a.Markers.GetTagIDs(ctx, m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting tags for marker %d: %w", m.ID, err)
+		}
+		tagIDs = sliceutil.AppendUniques([]int{m.PrimaryTagID}, tagIDs)
+
+		if err := a.loadTags(ctx, tagIDs, tags); err != nil {
+			return nil, err
+		}
+
+		poseIDs := sliceutil.Filter(tagIDs, func(id int) bool {
+			return tags[id].IsPoseTag
+		})
+		if len(poseIDs) == 0 {
+			continue
+		}
+
+		mp := markerPoses{
+			start:  m.Seconds,
+			end:    m.Seconds,
+			tagIDs: poseIDs,
+		}
+		if m.EndSeconds != nil && *m.EndSeconds > m.Seconds {
+			mp.end = *m.EndSeconds
+		}
+		ret = append(ret, mp)
+	}
+
+	return ret, nil
+}
+
+// loadTags loads the tags which are not yet in the cache.
+func (a *Analyzer) loadTags(ctx context.Context, ids []int, cache map[int]*models.Tag) error {
+	var missing []int
+	for _, id := range ids {
+		if _, ok := cache[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	tags, err := a.Tags.FindMany(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("finding tags: %w", err)
+	}
+
+	for _, t := range tags {
+		cache[t.ID] = t
+	}
+
+	return nil
+}
+
+// Coverage returns the pose tag coverage of the scene. duration is the scene
+// duration in seconds, or zero if unknown.
+func (a *Analyzer) Coverage(ctx context.Context, sceneID int, duration float64) (*models.PoseCoverage, error) {
+	tags := make(map[int]*models.Tag)
+	markers, err := a.scenePoses(ctx, sceneID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeCoverage(markers, tags, duration), nil
+}
+
+func computeCoverage(markers []markerPoses, tags map[int]*models.Tag, duration float64) *models.PoseCoverage {
+	byTag := make(map[int]*models.PoseTagCoverage)
+	ret := &models.PoseCoverage{
+		Poses: []*models.PoseTagCoverage{},
+	}
+
+	for _, m := range markers {
+		for _, id := range m.tagIDs {
+			c := byTag[id]
+			if c == nil {
+				c = &models.PoseTagCoverage{Tag: tags[id]}
+				byTag[id] = c
+				ret.Poses = append(ret.Poses, c)
+			}
+			c.MarkerCount++
+			c.Duration += m.duration()
+		}
+	}
+
+	sort.Slice(ret.Poses, func(i, j int) bool {
+		if ret.Poses[i].Duration != ret.Poses[j].Duration {
+			return ret.Poses[i].Duration > ret.Poses[j].Duration
+		}
+		return ret.Poses[i].Tag.Name < ret.Poses[j].Tag.Name
+	})
+
+	ret.TaggedDuration = unionDuration(markers)
+
+	if duration > 0 {
+		coverage := ret.TaggedDuration / duration
+		if coverage > 1 {
+			coverage = 1
+		}
+		ret.Coverage = &coverage
+	}
+
+	return ret
+}
+
+// unionDuration returns the total duration of the markers, counting
+// overlapping ranges once.
+func unionDuration(markers []markerPoses) float64 {
+	sorted := make([]markerPoses, len(markers))
+	copy(sorted, markers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start < sorted[j].start
+	})
+
+	var total float64
+	var start, end float64
+	for i, m := range sorted {
+		if i == 0 || m.start > end {
+			total += end - start
+			start, end = m.start, m.end
+			continue
+		}
+		if m.end > end {
+			end = m.end
+		}
+	}
+
+	return total + end - start
+}
+
+// Suggest returns pose tags which are not present in the markers of the scene,
+// ranked by how common they are in the markers of similar scenes. Scenes are
+// weighted by their similarity score. If limit is zero or less, all
+// suggestions are returned.
+func (a *Analyzer) Suggest(ctx context.Context, sceneID int, limit int) ([]*models.PoseTagSuggestion, error) {
+	tags := make(map[int]*models.Tag)
+
+	markers, err := a.scenePoses(ctx, sceneID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	similar, err := a.Similarity.FindSimilarScenes(ctx, sceneID, DefaultSimilarScenes)
+	if err != nil {
+		return nil, fmt.Errorf("finding similar scenes: %w", err)
+	}
+
+	var scenes []similarScenePoses
+	seen := make(map[int]bool)
+	for _, s := range similar {
+		if seen[s.SimilarSceneID] {
+			continue
+		}
+		seen[s.SimilarSceneID] = true
+
+		m, err := a.scenePoses(ctx, s.SimilarSceneID, tags)
+		if err != nil {
+			return nil, err
+		}
+
+		scenes = append(scenes, similarScenePoses{
+			score:  s.SimilarityScore,
+			tagIDs: poseTagIDs(m),
+		})
+	}
+
+	ret := rankSuggestions(poseTagIDs(markers), scenes, tags)
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+
+	return ret, nil
+}
+
+type similarScenePoses struct {
+	score  float64
+	tagIDs []int
+}
+
+func poseTagIDs(markers []markerPoses) []int {
+	var ret []int
+	for _, m := range markers {
+		ret = sliceutil.AppendUniques(ret, m.tagIDs)
+	}
+	return ret
+}
+
+func rankSuggestions(existing []int, scenes []similarScenePoses, tags map[int]*models.Tag) []*models.PoseTagSuggestion {
+	// only scenes with pose markers contribute to the total weight, so that
+	// scenes which have not been tagged do not dilute the scores
+	var totalWeight float64
+	byTag := make(map[int]*models.PoseTagSuggestion)
+	ret := []*models.PoseTagSuggestion{}
+
+	for _, s := range scenes {
+		if len(s.tagIDs) == 0 {
+			continue
+		}
+		totalWeight += s.score
+
+		for _, id := range s.tagIDs {
+			if slices.Contains(existing, id) {
+				continue
+			}
+
+			sg := byTag[id]
+			if sg == nil {
+				sg = &models.PoseTagSuggestion{Tag: tags[id]}
+				byTag[id] = sg
+				ret = append(ret, sg)
+			}
+			sg.SceneCount++
+			sg.Score += s.score
+		}
+	}
+
+	for _, sg := range ret {
+		if totalWeight > 0 {
+			sg.Score /= totalWeight
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		if ret[i].SceneCount != ret[j].SceneCount {
+			return ret[i].SceneCount > ret[j].SceneCount
+		}
+		return ret[i].Tag.Name < ret[j].Tag.Name
+	})
+
+	return ret
+}
@@ -0,0 +1,85 @@
+package pose
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTags = map[int]*models.Tag{
+	1: {ID: 1, Name: "a", IsPoseTag: true},
+	2: {ID: 2, Name: "b", IsPoseTag: true},
+	3: {ID: 3, Name: "c", IsPoseTag: true},
+}
+
+func TestUnionDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		markers []markerPoses
+		want    float64
+	}{
+		{"none", nil, 0},
+		{"single", []markerPoses{{start: 10, end: 20}}, 10},
+		{"point", []markerPoses{{start: 10, end: 10}}, 0},
+		{"disjoint", []markerPoses{{start: 30, end: 40}, {start: 0, end: 10}}, 20},
+		{"overlapping", []markerPoses{{start: 0, end: 10}, {start: 5, end: 15}}, 15},
+		{"contained", []markerPoses{{start: 0, end: 20}, {start: 5, end: 10}}, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unionDuration(tt.markers))
+		})
+	}
+}
+
+func TestComputeCoverage(t *testing.T) {
+	markers := []markerPoses{
+		{start: 0, end: 10, tagIDs: []int{1}},
+		{start: 5, end: 25, tagIDs: []int{1, 2}},
+		{start: 30, end: 30, tagIDs: []int{3}},
+	}
+
+	got := computeCoverage(markers, testTags, 100)
+
+	assert := assert.New(t)
+	assert.Equal(25.0, got.TaggedDuration)
+	if assert.NotNil(got.Coverage) {
+		assert.Equal(0.25, *got.Coverage)
+	}
+	if assert.Len(got.Poses, 3) {
+		assert.Equal(1, got.Poses[0].Tag.ID)
+		assert.Equal(2, got.Poses[0].MarkerCount)
+		assert.Equal(30.0, got.Poses[0].Duration)
+		assert.Equal(2, got.Poses[1].Tag.ID)
+		assert.Equal(3, got.Poses[2].Tag.ID)
+		assert.Equal(1, got.Poses[2].MarkerCount)
+	}
+
+	got = computeCoverage(nil, testTags, 0)
+	assert.Empty(got.Poses)
+	assert.Nil(got.Coverage)
+}
+
+func TestRankSuggestions(t *testing.T) {
+	scenes := []similarScenePoses{
+		{score: 0.8, tagIDs: []int{1, 2}},
+		{score: 0.4, tagIDs: []int{2, 3}},
+		// scenes without pose markers are ignored
+		{score: 0.9},
+	}
+
+	got := rankSuggestions([]int{1}, scenes, testTags)
+
+	assert := assert.New(t)
+	if assert.Len(got, 2) {
+		assert.Equal(2, got[0].Tag.ID)
+		assert.Equal(2, got[0].SceneCount)
+		assert.InDelta(1.0, got[0].Score, 0.0001)
+		assert.Equal(3, got[1].Tag.ID)
+		assert.InDelta(1.0/3, got[1].Score, 0.0001)
+	}
+
+	assert.Empty(rankSuggestions(nil, nil, testTags))
+}
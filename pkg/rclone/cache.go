@@ -0,0 +1,162 @@
+package rclone
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// ChunkCache stores chunks of remote files on disk, so that recently played
+// parts of a file are not downloaded again when seeking. The least recently
+// used chunks are removed when the cache exceeds its maximum size. It is safe
+// for concurrent use.
+type ChunkCache struct {
+	Dir     string
+	MaxSize int64
+
+	mutex sync.Mutex
+	// size is the total size of the cached chunks, or -1 if not yet known.
+	size int64
+}
+
+func NewChunkCache(dir string, maxSize int64) *ChunkCache {
+	return &ChunkCache{
+		Dir:     dir,
+		MaxSize: maxSize,
+		size:    -1,
+	}
+}
+
+// chunkKey returns the cache key of a remote file. The key changes when the
+// file is modified, so that stale chunks are not used.
+func chunkKey(path string, size int64, modTime time.Time) string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s\x00%d\x00%d", path, size, modTime.UnixNano())))
+	return hex.EncodeToString(h[:])
+}
+
+func (c *ChunkCache) chunkPath(key string, index int64) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%s-%d", key, index))
+}
+
+// Get returns the cached chunk, or false if it is not cached.
+func (c *ChunkCache) Get(key string, index int64) ([]byte, bool) {
+	fn := c.chunkPath(key, index)
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, false
+	}
+
+	// mark the chunk as recently used
+	now := time.Now()
+	_ = os.Chtimes(fn, now, now)
+
+	return data, true
+}
+
+// Put adds a chunk to the cache, removing the least recently used chunks if
+// the cache is full.
+func (c *ChunkCache) Put(key string, index int64, data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		logger.Warnf("error creating rclone cache directory: %v", err)
+		return
+	}
+
+	if c.size < 0 {
+		c.size = c.diskSize()
+	}
+
+	// write to a temporary file so that partial chunks are never read
+	fn := c.chunkPath(key, index)
+	tmp := fn + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		logger.Warnf("error writing rclone cache chunk: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, fn); err != nil {
+		logger.Warnf("error writing rclone cache chunk: %v", err)
+		_ = os.Remove(tmp)
+		return
+	}
+
+	c.size += int64(len(data))
+	if c.size > c.MaxSize {
+		c.evict()
+	}
+}
+
+type cachedChunk struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (c *ChunkCache) chunks() []cachedChunk {
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return nil
+	}
+
+	var ret []cachedChunk
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) == ".tmp" {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		ret = append(ret, cachedChunk{
+			path:    filepath.Join(c.Dir, e.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	return ret
+}
+
+func (c *ChunkCache) diskSize() int64 {
+	var ret int64
+	for _, cc := range c.chunks() {
+		ret += cc.size
+	}
+	return ret
+}
+
+// evict removes the least recently used chunks until the cache is within its
+// maximum size.
+func (c *ChunkCache) evict() {
+	chunks := c.chunks()
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].modTime.Before(chunks[j].modTime)
+	})
+
+	c.size = 0
+	for _, cc := range chunks {
+		c.size += cc.size
+	}
+
+	for _, cc := range chunks {
+		if c.size <= c.MaxSize {
+			return
+		}
+
+		if err := os.Remove(cc.path); err != nil {
+			logger.Warnf("error removing rclone cache chunk: %v", err)
+			continue
+		}
+		c.size -= cc.size
+	}
+}
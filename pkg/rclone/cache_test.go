@@ -0,0 +1,41 @@
+package rclone
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestChunkCache(t *testing.T) {
+	dir := t.TempDir()
+	c := NewChunkCache(dir, 25)
+	chunk := bytes.Repeat([]byte("x"), 10)
+
+	c.Put("a", 0, chunk)
+	c.Put("a", 1, chunk)
+
+	// make chunk 0 the most recently used
+	past := time.Now().Add(-time.Hour)
+	for _, fn := range []string{"a-0", "a-1"} {
+		if err := os.Chtimes(filepath.Join(dir, fn), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, found := c.Get("a", 0); !found {
+		t.Fatalf("Get() chunk 0 not found")
+	}
+
+	// exceeds the maximum size, evicting chunk 1
+	c.Put("a", 2, chunk)
+
+	if _, found := c.Get("a", 1); found {
+		t.Errorf("Get() found evicted chunk 1")
+	}
+	for _, i := range []int64{0, 2} {
+		if data, found := c.Get("a", i); !found || !bytes.Equal(data, chunk) {
+			t.Errorf("Get() chunk %d = %q, %v, want %q", i, data, found, chunk)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package rclone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// chunkSize is the size of the ranges requested from the server
+	chunkSize = 4 << 20 // 4MiB
+
+	requestTimeout = time.Minute
+)
+
+// Lister lists the files of a remote.
+type Lister interface {
+	ListJSON(ctx context.Context, remote string) ([]Entry, error)
+}
+
+// FS is a read-only file system for an rclone remote, backed by an rclone
+// http server. Files and directories are found using a listing of the
+// remote, which is loaded by Refresh.
+type FS struct {
+	// Root is the remote path, such as "gdrive:Videos". File paths are the
+	// root joined with the path of the file within the remote.
+	Root string
+	// URL is the base URL of a server serving Root.
+	URL string
+	// Cache stores the chunks read by files opened with OpenCached. Nil
+	// disables caching.
+	Cache *ChunkCache
+
+	client *http.Client
+
+	mutex    sync.RWMutex
+	entries  map[string]*Entry
+	children map[string][]*Entry
+}
+
+func NewFS(root string, url string, cache *ChunkCache) *FS {
+	return &FS{
+		Root:  root,
+		URL:   url,
+		Cache: cache,
+		client: &http.Client{
+			Timeout: requestTimeout,
+		},
+		entries:  make(map[string]*Entry),
+		children: make(map[string][]*Entry),
+	}
+}
+
+// Refresh replaces the listing of the remote.
+func (f *FS) Refresh(ctx context.Context, lister Lister) error {
+	list, err := lister.ListJSON(ctx, f.Root)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]*Entry, len(list))
+	children := make(map[string][]*Entry)
+	for i := range list {
+		e := &list[i]
+		entries[e.Path] = e
+
+		dir := path.Dir(e.Path)
+		if dir == "." {
+			dir = ""
+		}
+		children[dir] = append(children[dir], e)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.entries = entries
+	f.children = children
+	return nil
+}
+
+// Contains returns true if the file path is within the remote.
+func (f *FS) Contains(name string) bool {
+	return fsutil.IsPathInDir(f.Root, name)
+}
+
+// rel returns the path of name within the remote, using forward slashes.
+func (f *FS) rel(op string, name string) (string, error) {
+	rel, err := filepath.Rel(f.Root, name)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	if rel == "." {
+		return "", nil
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+func (f *FS) entry(op string, name string) (string, *Entry, error) {
+	rel, err := f.rel(op, name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if rel == "" {
+		return rel, &Entry{
+			Name:  filepath.Base(f.Root),
+			IsDir: true,
+		}, nil
+	}
+
+	f.mutex.RLock()
+	e := f.entries[rel]
+	f.mutex.RUnlock()
+
+	if e == nil {
+		return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	return rel, e, nil
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	_, e, err := f.entry("stat", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileInfo{e}, nil
+}
+
+// Lstat is the same as Stat, since remotes do not have symlinks.
+func (f *FS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Stat(name)
+}
+
+func (f *FS) Open(name string) (fs.ReadDirFile, error) {
+	rel, e, err := f.entry("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsDir {
+		f.mutex.RLock()
+		children := f.children[rel]
+		f.mutex.RUnlock()
+
+		return &dirFile{
+			info:    &fileInfo{e},
+			entries: children,
+		}, nil
+	}
+
+	return f.newFile(rel, e, nil), nil
+}
+
+// OpenCached opens a file for streaming, caching the chunks that are read.
+// The file is found using the server if it is not in the listing.
+func (f *FS) OpenCached(name string) (fs.ReadDirFile, error) {
+	rel, err := f.rel("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, e, err := f.entry("open", name)
+	if errors.Is(err, fs.ErrNotExist) {
+		e, err = f.head(rel)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsDir {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+
+	return f.newFile(rel, e, f.Cache), nil
+}
+
+func (f *FS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return file.OpenZip(f, name, size)
+}
+
+func (f *FS) IsPathCaseSensitive(path string) (bool, error) {
+	return true, nil
+}
+
+// ProbePath returns the URL of the file, so that it can be read by ffprobe.
+func (f *FS) ProbePath(name string) (string, bool) {
+	rel, err := f.rel("probe", name)
+	if err != nil {
+		return "", false
+	}
+
+	return f.fileURL(rel), true
+}
+
+func (f *FS) fileURL(rel string) string {
+	segments := strings.Split(rel, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	return f.URL + "/" + strings.Join(segments, "/")
+}
+
+// head gets the size and modification time of a file from the server.
+func (f *FS) head(rel string) (*Entry, error) {
+	resp, err := f.client.Head(f.fileURL(rel))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &fs.PathError{Op: "open", Path: rel, Err: fs.ErrNotExist}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("getting %s: %s", rel, resp.Status)
+	}
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &Entry{
+		Path:    rel,
+		Name:    path.Base(rel),
+		Size:    resp.ContentLength,
+		ModTime: modTime,
+	}, nil
+}
+
+func (f *FS) newFile(rel string, e *Entry, cache *ChunkCache) *remoteFile {
+	return &remoteFile{
+		fs:    f,
+		info:  &fileInfo{e},
+		url:   f.fileURL(rel),
+		key:   chunkKey(f.Root+"/"+rel, e.Size, e.ModTime),
+		cache: cache,
+	}
+}
+
+type fileInfo struct {
+	entry *Entry
+}
+
+func (i *fileInfo) Name() string       { return i.entry.Name }
+func (i *fileInfo) Size() int64        { return i.entry.Size }
+func (i *fileInfo) ModTime() time.Time { return i.entry.ModTime }
+func (i *fileInfo) IsDir() bool        { return i.entry.IsDir }
+func (i *fileInfo) Sys() any           { return nil }
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.entry.IsDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// dirFile is a directory of the remote listing.
+type dirFile struct {
+	info    *fileInfo
+	entries []*Entry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n > 0 {
+		if len(remaining) == 0 {
+			return nil, io.EOF
+		}
+		if n < len(remaining) {
+			remaining = remaining[:n]
+		}
+	}
+
+	ret := make([]fs.DirEntry, len(remaining))
+	for i, e := range remaining {
+		ret[i] = fs.FileInfoToDirEntry(&fileInfo{e})
+	}
+	d.offset += len(remaining)
+
+	return ret, nil
+}
+
+// remoteFile reads a file from the server in chunks. ReadAt is safe for
+// concurrent use.
+type remoteFile struct {
+	fs     *FS
+	info   *fileInfo
+	url    string
+	key    string
+	cache  *ChunkCache
+	offset int64
+}
+
+func (r *remoteFile) Stat() (fs.FileInfo, error) { return r.info, nil }
+func (r *remoteFile) Close() error               { return nil }
+
+func (r *remoteFile) ReadDir(int) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: r.info.Name(), Err: errors.New("not a directory")}
+}
+
+func (r *remoteFile) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *remoteFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.info.Size()
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *remoteFile) ReadAt(p []byte, off int64) (int, error) {
+	size := r.info.Size()
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= size {
+			return n, io.EOF
+		}
+
+		index := pos / chunkSize
+		chunk, err := r.chunk(index)
+		if err != nil {
+			return n, err
+		}
+
+		n += copy(p[n:], chunk[pos-index*chunkSize:])
+	}
+
+	return n, nil
+}
+
+func (r *remoteFile) chunk(index int64) ([]byte, error) {
+	if r.cache != nil {
+		if data, found := r.cache.Get(r.key, index); found {
+			return data, nil
+		}
+	}
+
+	start := index * chunkSize
+	end := min(start+chunkSize, r.info.Size()) - 1
+
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(end, 10))
+
+	resp, err := r.fs.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// a server that ignores the range returns the whole file
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+	case resp.StatusCode == http.StatusOK && start == 0:
+	default:
+		return nil, fmt.Errorf("reading %s: %s", r.info.Name(), resp.Status)
+	}
+
+	want := end - start + 1
+	data, err := io.ReadAll(io.LimitReader(resp.Body, want))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != want {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if r.cache != nil {
+		r.cache.Put(r.key, index, data)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,116 @@
+package rclone
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLister []Entry
+
+func (l testLister) ListJSON(ctx context.Context, remote string) ([]Entry, error) {
+	return l, nil
+}
+
+// newTestServer serves files from memory, supporting range requests.
+func newTestServer(files map[string][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, found := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(data))
+	}))
+}
+
+func TestFS(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), chunkSize/5)
+	server := newTestServer(map[string][]byte{
+		"dir/a file.mp4": content,
+	})
+	defer server.Close()
+
+	const root = "remote:Videos"
+	f := NewFS(root, server.URL, NewChunkCache(t.TempDir(), chunkSize*4))
+	if err := f.Refresh(context.Background(), testLister{
+		{Path: "dir", Name: "dir", IsDir: true},
+		{Path: "dir/a file.mp4", Name: "a file.mp4", Size: int64(len(content))},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := filepath.Join(root, "dir", "a file.mp4")
+
+	info, err := f.Stat(fn)
+	if err != nil || info.Size() != int64(len(content)) {
+		t.Fatalf("Stat() = %v, %v, want size %d", info, err, len(content))
+	}
+
+	if _, err := f.Stat(filepath.Join(root, "missing.mp4")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() missing error = %v, want ErrNotExist", err)
+	}
+
+	dir, err := f.Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := dir.ReadDir(-1)
+	if err != nil || len(entries) != 1 || entries[0].Name() != "dir" || !entries[0].IsDir() {
+		t.Errorf("ReadDir() = %v, %v, want [dir]", entries, err)
+	}
+
+	for _, open := range []func(string) (fs.ReadDirFile, error){f.Open, f.OpenCached} {
+		r, err := open(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// read across a chunk boundary
+		buf := make([]byte, 20)
+		ra := r.(io.ReaderAt)
+		if n, err := ra.ReadAt(buf, chunkSize-10); err != nil || n != len(buf) {
+			t.Errorf("ReadAt() = %d, %v, want %d", n, err, len(buf))
+		}
+		if !bytes.Equal(buf, content[chunkSize-10:chunkSize+10]) {
+			t.Errorf("ReadAt() read %q, want %q", buf, content[chunkSize-10:chunkSize+10])
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(data, content) {
+			t.Errorf("ReadAll() read %d bytes, %v, want %d", len(data), err, len(content))
+		}
+	}
+}
+
+func TestFS_OpenCachedWithoutListing(t *testing.T) {
+	server := newTestServer(map[string][]byte{
+		"a.mp4": []byte("content"),
+	})
+	defer server.Close()
+
+	const root = "remote:"
+	f := NewFS(root, server.URL, nil)
+
+	if _, err := f.Open(filepath.Join(root, "a.mp4")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open() error = %v, want ErrNotExist", err)
+	}
+
+	r, err := f.OpenCached(filepath.Join(root, "a.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil || string(data) != "content" {
+		t.Errorf("ReadAll() = %q, %v, want %q", data, err, "content")
+	}
+}
@@ -0,0 +1,170 @@
+// Package rclone provides read-only access to cloud storage using the rclone
+// executable.
+package rclone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"time"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// serveTimeout is how long to wait for an rclone http server to start.
+const serveTimeout = 30 * time.Second
+
+// Client runs rclone commands.
+type Client struct {
+	// Path is the path to the rclone executable.
+	Path string
+}
+
+// Resolve returns a client using the configured rclone path, or the rclone
+// executable in the PATH if it is not set.
+func Resolve(configuredPath string) (*Client, error) {
+	if configuredPath != "" {
+		isFile, err := fsutil.FileExists(configuredPath)
+		switch {
+		case err == nil && isFile:
+			return &Client{Path: configuredPath}, nil
+		case err == nil && !isFile:
+			logger.Warnf("configured rclone path is not a file: %s", configuredPath)
+		case err != nil:
+			logger.Warnf("unable to use configured rclone path: %v", err)
+		}
+	}
+
+	path, err := exec.LookPath("rclone")
+	if err != nil {
+		return nil, fmt.Errorf("rclone executable not in PATH: %w", err)
+	}
+
+	return &Client{Path: path}, nil
+}
+
+// Entry is a file or directory returned by rclone lsjson.
+type Entry struct {
+	// Path is relative to the listed remote, using forward slashes.
+	Path    string    `json:"Path"`
+	Name    string    `json:"Name"`
+	Size    int64     `json:"Size"`
+	ModTime time.Time `json:"ModTime"`
+	IsDir   bool      `json:"IsDir"`
+}
+
+// ListJSON recursively lists the files and directories of a remote, such as
+// "gdrive:Videos".
+func (c *Client) ListJSON(ctx context.Context, remote string) ([]Entry, error) {
+	cmd := stashExec.CommandContext(ctx, c.Path, "lsjson", "--recursive", "--no-mimetype", remote)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("listing %s: %w: %s", remote, err, exitErr.Stderr)
+		}
+		return nil, fmt.Errorf("listing %s: %w", remote, err)
+	}
+
+	var ret []Entry
+	if err := json.Unmarshal(out, &ret); err != nil {
+		return nil, fmt.Errorf("parsing listing of %s: %w", remote, err)
+	}
+
+	return ret, nil
+}
+
+// Server is an rclone http server serving a remote on a local port.
+type Server struct {
+	// URL is the base URL of the server, without a trailing slash.
+	URL string
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Serve starts an rclone http server for the remote, listening on a free
+// local port. The server is read-only.
+func (c *Client) Serve(remote string) (*Server, error) {
+	addr, err := freeAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := stashExec.Command(c.Path, "serve", "http", remote, "--addr", addr, "--read-only")
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting rclone server for %s: %w", remote, err)
+	}
+
+	s := &Server{
+		URL:  "http://" + addr,
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		_ = cmd.Wait()
+		close(s.done)
+	}()
+
+	if err := s.waitReady(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("starting rclone server for %s: %w", remote, err)
+	}
+
+	logger.Debugf("rclone serving %s at %s", remote, s.URL)
+	return s, nil
+}
+
+func freeAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	return l.Addr().String(), nil
+}
+
+func (s *Server) waitReady() error {
+	deadline := time.After(serveTimeout)
+	for {
+		resp, err := http.Head(s.URL + "/")
+		if err == nil {
+			resp.Body.Close()
+			return nil
+		}
+
+		select {
+		case <-s.done:
+			return errors.New("rclone exited")
+		case <-deadline:
+			return fmt.Errorf("timed out after %s", serveTimeout)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Running returns false if the server process has exited.
+func (s *Server) Running() bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	if s.Running() {
+		_ = s.cmd.Process.Kill()
+	}
+	<-s.done
+}
@@ -0,0 +1,42 @@
+package retention
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// ArchivePath returns the path in the archive directory root of the file at
+// path. The full directory structure of the file is kept, so that files from
+// different libraries do not collide.
+func ArchivePath(root string, path string) string {
+	vol := filepath.VolumeName(path)
+	rest := path[len(vol):]
+
+	// drive letters and UNC shares become a directory
+	vol = strings.Trim(strings.ReplaceAll(vol, ":", ""), `\/`)
+
+	return filepath.Join(root, vol, rest)
+}
+
+// Archive moves the file at path into the archive directory root, and
+// returns its new path. Existing files in the archive are not overwritten.
+func Archive(root string, path string) (string, error) {
+	dest := ArchivePath(root, path)
+
+	if exists, _ := fsutil.FileExists(dest); exists {
+		return "", fmt.Errorf("archived file %s already exists", dest)
+	}
+
+	if err := fsutil.EnsureDirAll(filepath.Dir(dest)); err != nil {
+		return "", fmt.Errorf("creating archive directory: %w", err)
+	}
+
+	if err := fsutil.SafeMove(path, dest); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
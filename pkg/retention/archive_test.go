@@ -0,0 +1,32 @@
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "archive")
+	src := filepath.Join(dir, "videos", "a.mp4")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(src), 0755))
+	require.NoError(t, os.WriteFile(src, []byte("video"), 0644))
+
+	dest, err := Archive(root, src)
+	require.NoError(t, err)
+
+	assert.Equal(t, ArchivePath(root, src), dest)
+	assert.NoFileExists(t, src)
+	assert.FileExists(t, dest)
+
+	// existing archived files are not overwritten
+	require.NoError(t, os.WriteFile(src, []byte("other"), 0644))
+	_, err = Archive(root, src)
+	assert.Error(t, err)
+	assert.FileExists(t, src)
+}
@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry records the action taken on a file of a matched scene.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	SceneID int       `json:"scene_id"`
+	Path    string    `json:"path"`
+	Rule    string    `json:"rule"`
+	Action  Action    `json:"action"`
+	// Destination is the path of the archived file.
+	Destination string `json:"destination,omitempty"`
+	Size        int64  `json:"size"`
+	// Error is set if the action failed.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog appends entries to a file, one JSON object per line.
+type AuditLog struct {
+	mutex sync.Mutex
+	path  string
+}
+
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{
+		path: path,
+	}
+}
+
+// Write appends the entry to the log file.
+func (l *AuditLog) Write(e AuditEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening retention audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing retention audit log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Package retention evaluates rules that select scenes whose files can be
+// removed from the library to reclaim space, such as unwatched, low rated
+// scenes added a long time ago.
+package retention
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Action is what is done with the files of a scene matched by a rule.
+type Action string
+
+const (
+	// ActionDelete deletes the files.
+	ActionDelete Action = "DELETE"
+	// ActionArchive moves the files to the archive path.
+	ActionArchive Action = "ARCHIVE"
+)
+
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionDelete, ActionArchive:
+		return true
+	}
+	return false
+}
+
+func (a Action) String() string {
+	return string(a)
+}
+
+func (a *Action) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*a = Action(str)
+	if !a.IsValid() {
+		return fmt.Errorf("%s is not a valid RetentionAction", str)
+	}
+	return nil
+}
+
+func (a Action) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(a.String()))
+}
+
+const day = 24 * time.Hour
+
+// Rule selects scenes that match all of its set conditions.
+type Rule struct {
+	Name string `json:"name" koanf:"name"`
+	// Unwatched matches scenes that have never been played.
+	Unwatched bool `json:"unwatched" koanf:"unwatched"`
+	// RatingBelow matches scenes rated below this value, on the 1-100 scale.
+	// Unrated scenes match. Zero disables the condition.
+	RatingBelow int `json:"rating_below" koanf:"rating_below"`
+	// OlderThanDays matches scenes added to the library more than this
+	// number of days ago. Zero disables the condition.
+	OlderThanDays int `json:"older_than_days" koanf:"older_than_days"`
+	// NotPlayedForDays matches scenes that have not been played for this
+	// number of days, including scenes that have never been played. Zero
+	// disables the condition.
+	NotPlayedForDays int    `json:"not_played_for_days" koanf:"not_played_for_days"`
+	Action           Action `json:"action" koanf:"action"`
+}
+
+// Validate returns an error if the rule has no name, an invalid action, or
+// no conditions, which would match every scene.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("rule name must not be empty")
+	}
+	if !r.Action.IsValid() {
+		return errors.New("rule action must be DELETE or ARCHIVE")
+	}
+	if r.RatingBelow < 0 || r.OlderThanDays < 0 || r.NotPlayedForDays < 0 {
+		return errors.New("rule conditions must not be negative")
+	}
+	if !r.Unwatched && r.RatingBelow == 0 && r.OlderThanDays == 0 && r.NotPlayedForDays == 0 {
+		return errors.New("rule must have at least one condition")
+	}
+
+	return nil
+}
+
+// Candidate is a scene that may be matched by a rule.
+type Candidate struct {
+	SceneID int
+	// Paths are the paths of the files of the scene.
+	Paths []string
+	// Size is the total size of the files of the scene.
+	Size int64
+	// Rating is nil if the scene is not rated.
+	Rating *int
+	// LastPlayedAt is nil if the scene has never been played.
+	LastPlayedAt *time.Time
+	CreatedAt    time.Time
+}
+
+// Matches returns true if the candidate matches all of the set conditions of
+// the rule at the time now.
+func (r Rule) Matches(c Candidate, now time.Time) bool {
+	if r.Unwatched && c.LastPlayedAt != nil {
+		return false
+	}
+
+	if r.RatingBelow > 0 && c.Rating != nil && *c.Rating >= r.RatingBelow {
+		return false
+	}
+
+	if r.OlderThanDays > 0 && now.Sub(c.CreatedAt) < time.Duration(r.OlderThanDays)*day {
+		return false
+	}
+
+	if r.NotPlayedForDays > 0 && c.LastPlayedAt != nil && now.Sub(*c.LastPlayedAt) < time.Duration(r.NotPlayedForDays)*day {
+		return false
+	}
+
+	return true
+}
+
+// Item is a candidate matched by a rule.
+type Item struct {
+	Candidate
+	Rule   string
+	Action Action
+}
+
+// Report lists the scenes matched by the retention rules.
+type Report struct {
+	GeneratedAt time.Time
+	Items       []Item
+	// ReclaimableBytes is the total size of the files of the matched scenes.
+	ReclaimableBytes int64
+	// IncludeLocked is true if locked scenes were evaluated.
+	IncludeLocked bool
+}
+
+// Evaluate returns the report of the candidates matched by the rules at the
+// time now. Each candidate is matched by the first rule that matches it, in
+// the order of the rules.
+func Evaluate(rules []Rule, candidates []Candidate, now time.Time) *Report {
+	ret := &Report{
+		GeneratedAt: now,
+	}
+
+	for _, c := range candidates {
+		for _, r := range rules {
+			if !r.Matches(c, now) {
+				continue
+			}
+
+			ret.Items = append(ret.Items, Item{
+				Candidate: c,
+				Rule:      r.Name,
+				Action:    r.Action,
+			})
+			ret.ReclaimableBytes += c.Size
+			break
+		}
+	}
+
+	return ret
+}
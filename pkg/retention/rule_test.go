@@ -0,0 +1,83 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMatches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) *time.Time {
+		ret := now.Add(-time.Duration(d) * day)
+		return &ret
+	}
+	rating := func(v int) *int { return &v }
+
+	rule := Rule{
+		Name:          "stale",
+		Unwatched:     true,
+		RatingBelow:   40,
+		OlderThanDays: 730,
+		Action:        ActionDelete,
+	}
+
+	old := *daysAgo(800)
+
+	tests := []struct {
+		name      string
+		rule      Rule
+		candidate Candidate
+		want      bool
+	}{
+		{"matches", rule, Candidate{Rating: rating(20), CreatedAt: old}, true},
+		{"unrated matches", rule, Candidate{CreatedAt: old}, true},
+		{"watched", rule, Candidate{LastPlayedAt: daysAgo(500), CreatedAt: old}, false},
+		{"rated too high", rule, Candidate{Rating: rating(40), CreatedAt: old}, false},
+		{"too recent", rule, Candidate{CreatedAt: *daysAgo(100)}, false},
+		{"not played recently", Rule{NotPlayedForDays: 365}, Candidate{LastPlayedAt: daysAgo(400)}, true},
+		{"played recently", Rule{NotPlayedForDays: 365}, Candidate{LastPlayedAt: daysAgo(10)}, false},
+		{"never played", Rule{NotPlayedForDays: 365}, Candidate{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Matches(tt.candidate, now))
+		})
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	assert.NoError(t, Rule{Name: "a", Unwatched: true, Action: ActionArchive}.Validate())
+	assert.Error(t, Rule{Unwatched: true, Action: ActionArchive}.Validate())
+	assert.Error(t, Rule{Name: "a", Unwatched: true, Action: "MOVE"}.Validate())
+	assert.Error(t, Rule{Name: "a", Action: ActionDelete}.Validate())
+	assert.Error(t, Rule{Name: "a", RatingBelow: -1, Action: ActionDelete}.Validate())
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Now()
+	rating := func(v int) *int { return &v }
+
+	rules := []Rule{
+		{Name: "low", RatingBelow: 20, Action: ActionDelete},
+		{Name: "unwatched", Unwatched: true, Action: ActionArchive},
+	}
+
+	played := now.Add(-time.Hour)
+	candidates := []Candidate{
+		{SceneID: 1, Size: 100, Rating: rating(10)},
+		{SceneID: 2, Size: 200, Rating: rating(80)},
+		{SceneID: 3, Size: 400, Rating: rating(80), LastPlayedAt: &played},
+	}
+
+	got := Evaluate(rules, candidates, now)
+
+	assert.Equal(t, now, got.GeneratedAt)
+	assert.Equal(t, int64(300), got.ReclaimableBytes)
+	assert.Equal(t, []Item{
+		{Candidate: candidates[0], Rule: "low", Action: ActionDelete},
+		{Candidate: candidates[1], Rule: "unwatched", Action: ActionArchive},
+	}, got.Items)
+}
@@ -0,0 +1,50 @@
+// Package reverseimage searches reverse image search providers for pages
+// showing an image, such as the profile image of a performer or the cover of
+// a gallery. The pages found can be scraped to identify the content.
+package reverseimage
+
+import (
+	"errors"
+	"net/url"
+)
+
+// Provider is a reverse image search service. Providers are queried with a
+// generic protocol: the image is posted to the endpoint as the "image" field
+// of a multipart form, and the endpoint responds with a JSON object of the
+// form
+//
+//	{"matches": [{"url": "...", "score": 0.9, "title": "...", "thumbnail": "..."}]}
+//
+// where score is the similarity of the match, between 0 and 1. Title and
+// thumbnail are optional. Services that use a different protocol can be
+// adapted with a small proxy.
+type Provider struct {
+	Name     string `json:"name" koanf:"name"`
+	Endpoint string `json:"endpoint" koanf:"endpoint"`
+	// APIKey is sent as a bearer token, if set.
+	APIKey string `json:"api_key" koanf:"api_key"`
+	// MinScore is the minimum score of the matches returned by the
+	// provider. Matches with lower scores are discarded.
+	MinScore float64 `json:"min_score" koanf:"min_score"`
+}
+
+func (p Provider) Validate() error {
+	if p.Name == "" {
+		return errors.New("name cannot be blank")
+	}
+
+	if p.Endpoint == "" {
+		return errors.New("endpoint cannot be blank")
+	}
+
+	u, err := url.Parse(p.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("endpoint must be an http or https url")
+	}
+
+	if p.MinScore < 0 || p.MinScore > 1 {
+		return errors.New("min score must be between 0 and 1")
+	}
+
+	return nil
+}
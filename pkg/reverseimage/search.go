@@ -0,0 +1,172 @@
+package reverseimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// maxResponseSize is the maximum size of a provider response, in bytes.
+const maxResponseSize = 10 * 1024 * 1024
+
+// Match is a page showing an image similar to the searched image.
+type Match struct {
+	// Provider is the name of the provider that found the match.
+	Provider string  `json:"provider"`
+	URL      string  `json:"url"`
+	Score    float64 `json:"score"`
+	Title    *string `json:"title"`
+	// Thumbnail is the url of a thumbnail of the matched image.
+	Thumbnail *string `json:"thumbnail"`
+}
+
+type searchResponse struct {
+	Matches []*Match `json:"matches"`
+}
+
+// Client searches reverse image search providers.
+type Client struct {
+	HTTPClient *http.Client
+	UserAgent  string
+}
+
+// Search searches the providers for the image. The matches of all providers
+// are returned, sorted by descending score. If several providers match the
+// same url, only the match with the highest score is returned. An error is
+// returned only if every provider fails.
+func (c Client) Search(ctx context.Context, providers []Provider, image []byte) ([]*Match, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("no reverse image search providers configured")
+	}
+
+	var (
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+		matches []*Match
+		errs    []error
+	)
+
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+
+			m, err := c.searchProvider(ctx, p, image)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				logger.Warnf("reverse image search provider %s: %v", p.Name, err)
+				errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+				return
+			}
+			matches = append(matches, m...)
+		}(p)
+	}
+
+	wg.Wait()
+
+	if len(errs) == len(providers) {
+		return nil, errors.Join(errs...)
+	}
+
+	return mergeMatches(matches), nil
+}
+
+func (c Client) searchProvider(ctx context.Context, p Provider, image []byte) ([]*Match, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("image", "image")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(image); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Endpoint, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	var r searchResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&r); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	var ret []*Match
+	for _, m := range r.Matches {
+		if m == nil || strings.TrimSpace(m.URL) == "" || m.Score < p.MinScore {
+			continue
+		}
+
+		m.Provider = p.Name
+		m.URL = strings.TrimSpace(m.URL)
+		ret = append(ret, m)
+	}
+
+	return ret, nil
+}
+
+// mergeMatches removes matches of the same url with lower scores, and sorts
+// the matches by descending score, then by url.
+func mergeMatches(matches []*Match) []*Match {
+	best := make(map[string]*Match)
+	for _, m := range matches {
+		if existing, found := best[m.URL]; !found || m.Score > existing.Score {
+			best[m.URL] = m
+		}
+	}
+
+	ret := make([]*Match, 0, len(best))
+	for _, m := range best {
+		ret = append(ret, m)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].URL < ret[j].URL
+	})
+
+	return ret
+}
@@ -0,0 +1,101 @@
+package reverseimage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProvider(t *testing.T, name string, response string) Provider {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		f, _, err := r.FormFile("image")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+
+		if b, _ := io.ReadAll(f); string(b) != "image data" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	return Provider{
+		Name:     name,
+		Endpoint: srv.URL,
+		APIKey:   "key",
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	a := newTestProvider(t, "a", `{"matches": [
+		{"url": "https://example.com/1", "score": 0.5},
+		{"url": "https://example.com/2", "score": 0.9, "title": "two"},
+		{"url": "", "score": 1}
+	]}`)
+	b := newTestProvider(t, "b", `{"matches": [
+		{"url": " https://example.com/1 ", "score": 0.7},
+		{"url": "https://example.com/3", "score": 0.1}
+	]}`)
+	b.MinScore = 0.2
+
+	unauthorized := newTestProvider(t, "unauthorized", `{}`)
+	unauthorized.APIKey = ""
+
+	c := Client{}
+	got, err := c.Search(context.Background(), []Provider{a, b, unauthorized}, []byte("image data"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var urls []string
+	var providers []string
+	for _, m := range got {
+		urls = append(urls, m.URL)
+		providers = append(providers, m.Provider)
+	}
+
+	assert.Equal(t, []string{"https://example.com/2", "https://example.com/1"}, urls)
+	assert.Equal(t, []string{"a", "b"}, providers)
+
+	_, err = c.Search(context.Background(), []Provider{unauthorized}, []byte("image data"))
+	assert.Error(t, err)
+}
+
+func TestProvider_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Provider
+		wantErr bool
+	}{
+		{"valid", Provider{Name: "a", Endpoint: "https://example.com/search"}, false},
+		{"blank name", Provider{Endpoint: "https://example.com/search"}, true},
+		{"blank endpoint", Provider{Name: "a"}, true},
+		{"relative endpoint", Provider{Name: "a", Endpoint: "/search"}, true},
+		{"unsupported scheme", Provider{Name: "a", Endpoint: "ftp://example.com/search"}, true},
+		{"min score too high", Provider{Name: "a", Endpoint: "https://example.com/search", MinScore: 1.5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
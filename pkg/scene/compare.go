@@ -0,0 +1,326 @@
+package scene
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// comparePhashMaxDistance is the phash distance at and above which the
+// phash similarity of two scenes is zero.
+const comparePhashMaxDistance = 16
+
+// Comparison is the differences between two scenes, A and B, used to decide
+// whether and how to merge them.
+type Comparison struct {
+	// Fields are the compared metadata fields, followed by the fields and
+	// fingerprints of the primary files, prefixed with "file." and
+	// "fingerprint.".
+	Fields []FieldDifference
+	// Lists are the compared list fields, such as tags and performers.
+	Lists []ListDifference
+	// MarkersOnlyA and MarkersOnlyB are the markers of each scene without a
+	// marker at the same time with the same primary tag in the other scene.
+	MarkersOnlyA  []*models.SceneMarker
+	MarkersOnlyB  []*models.SceneMarker
+	CommonMarkers int
+	Similarity    Similarity
+}
+
+// FieldDifference is a field of two scenes. Unset values are nil.
+type FieldDifference struct {
+	Field string
+	A     *string
+	B     *string
+	Equal bool
+}
+
+// ListDifference is a list field of two scenes.
+type ListDifference struct {
+	Field  string
+	OnlyA  []string
+	OnlyB  []string
+	Common []string
+}
+
+// Similarity is how similar two scenes are, by content and by metadata.
+type Similarity struct {
+	// SameFingerprint is true if a file of each scene has the same oshash or
+	// MD5.
+	SameFingerprint bool
+	// PhashDistance is the smallest distance between the phashes of the files
+	// of the scenes. Nil if either scene has no phash.
+	PhashDistance *int
+	// DurationDifference is the difference between the durations of the
+	// primary files, in seconds. Nil if either scene has no files.
+	DurationDifference *float64
+	// MetadataMatch is the fraction of the metadata fields set in both scenes
+	// that are equal. Nil if no fields are set in both.
+	MetadataMatch *float64
+	// PerformerOverlap is the number of performers of both scenes divided by
+	// the number of performers of either. Nil if either has no performers.
+	PerformerOverlap *float64
+	// Score is from 0 to 1. It is 1 if the scenes have the same fingerprint,
+	// otherwise it is the weighted average of the phash, duration, metadata
+	// and performer similarities that are known, with the phash weighted
+	// most.
+	Score float64
+}
+
+// Compare returns the differences between scenes a and b, which must have
+// their relationships and files loaded, and their markers.
+func Compare(a, b *models.Scene, markersA, markersB []*models.SceneMarker) Comparison {
+	metadata := compareMetadata(a, b)
+	ret := Comparison{
+		Fields: append(metadata, compareFiles(a.Files.Primary(), b.Files.Primary())...),
+		Lists:  compareLists(a, b),
+	}
+
+	ret.MarkersOnlyA = MissingMarkers(markersA, markersB)
+	ret.MarkersOnlyB = MissingMarkers(markersB, markersA)
+	ret.CommonMarkers = len(markersA) - len(ret.MarkersOnlyA)
+
+	ret.Similarity = compareSimilarity(a, b, metadata, ret.Lists)
+
+	return ret
+}
+
+func stringValue(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func newFieldDifference(field string, a, b *string) FieldDifference {
+	return FieldDifference{
+		Field: field,
+		A:     a,
+		B:     b,
+		Equal: (a == nil && b == nil) || (a != nil && b != nil && *a == *b),
+	}
+}
+
+func intPtrValue(v *int) *string {
+	if v == nil {
+		return nil
+	}
+	return stringValue(strconv.Itoa(*v))
+}
+
+func datePtrValue(d *models.Date) *string {
+	if d == nil {
+		return nil
+	}
+	return stringValue(d.String())
+}
+
+func compareMetadata(a, b *models.Scene) []FieldDifference {
+	return []FieldDifference{
+		newFieldDifference("title", stringValue(a.Title), stringValue(b.Title)),
+		newFieldDifference("code", stringValue(a.Code), stringValue(b.Code)),
+		newFieldDifference("details", stringValue(a.Details), stringValue(b.Details)),
+		newFieldDifference("director", stringValue(a.Director), stringValue(b.Director)),
+		newFieldDifference("date", datePtrValue(a.Date), datePtrValue(b.Date)),
+		newFieldDifference("shoot_date", datePtrValue(a.ShootDate), datePtrValue(b.ShootDate)),
+		newFieldDifference("rating", intPtrValue(a.Rating), intPtrValue(b.Rating)),
+		newFieldDifference("studio_id", intPtrValue(a.StudioID), intPtrValue(b.StudioID)),
+		newFieldDifference("organized", stringValue(strconv.FormatBool(a.Organized)), stringValue(strconv.FormatBool(b.Organized))),
+	}
+}
+
+func compareFiles(a, b *models.VideoFile) []FieldDifference {
+	fileValue := func(f *models.VideoFile, fn func(f *models.VideoFile) string) *string {
+		if f == nil {
+			return nil
+		}
+		return stringValue(fn(f))
+	}
+
+	fields := []struct {
+		name  string
+		value func(f *models.VideoFile) string
+	}{
+		{"file.path", func(f *models.VideoFile) string { return f.Path }},
+		{"file.size", func(f *models.VideoFile) string { return strconv.FormatInt(f.Size, 10) }},
+		{"file.duration", func(f *models.VideoFile) string { return strconv.FormatFloat(f.Duration, 'f', 2, 64) }},
+		{"file.resolution", func(f *models.VideoFile) string { return fmt.Sprintf("%dx%d", f.Width, f.Height) }},
+		{"file.format", func(f *models.VideoFile) string { return f.Format }},
+		{"file.video_codec", func(f *models.VideoFile) string { return f.VideoCodec }},
+		{"file.audio_codec", func(f *models.VideoFile) string { return f.AudioCodec }},
+		{"file.frame_rate", func(f *models.VideoFile) string { return strconv.FormatFloat(f.FrameRate, 'f', 2, 64) }},
+		{"file.bitrate", func(f *models.VideoFile) string { return strconv.FormatInt(f.BitRate, 10) }},
+	}
+
+	var ret []FieldDifference
+	for _, field := range fields {
+		ret = append(ret, newFieldDifference(field.name, fileValue(a, field.value), fileValue(b, field.value)))
+	}
+
+	for _, t := range []string{models.FingerprintTypeOshash, models.FingerprintTypeMD5, models.FingerprintTypePhash} {
+		value := func(f *models.VideoFile) string {
+			if fp := f.Fingerprints.For(t); fp != nil {
+				return fp.Value()
+			}
+			return ""
+		}
+		ret = append(ret, newFieldDifference("fingerprint."+t, fileValue(a, value), fileValue(b, value)))
+	}
+
+	return ret
+}
+
+func newListDifference(field string, a, b []string) ListDifference {
+	ret := ListDifference{Field: field}
+
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+	}
+	inA := make(map[string]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+		if inB[v] {
+			ret.Common = append(ret.Common, v)
+		} else {
+			ret.OnlyA = append(ret.OnlyA, v)
+		}
+	}
+	for _, v := range b {
+		if !inA[v] {
+			ret.OnlyB = append(ret.OnlyB, v)
+		}
+	}
+
+	return ret
+}
+
+func idStrings(ids []int) []string {
+	ret := make([]string, len(ids))
+	for i, id := range ids {
+		ret[i] = strconv.Itoa(id)
+	}
+	return ret
+}
+
+func groupIDStrings(groups []models.GroupsScenes) []string {
+	ret := make([]string, len(groups))
+	for i, g := range groups {
+		ret[i] = strconv.Itoa(g.GroupID)
+	}
+	return ret
+}
+
+func stashIDStrings(stashIDs []models.StashID) []string {
+	ret := make([]string, len(stashIDs))
+	for i, s := range stashIDs {
+		ret[i] = s.Endpoint + ":" + s.StashID
+	}
+	return ret
+}
+
+func compareLists(a, b *models.Scene) []ListDifference {
+	return []ListDifference{
+		newListDifference("urls", a.URLs.List(), b.URLs.List()),
+		newListDifference("tag_ids", idStrings(a.TagIDs.List()), idStrings(b.TagIDs.List())),
+		newListDifference("performer_ids", idStrings(a.PerformerIDs.List()), idStrings(b.PerformerIDs.List())),
+		newListDifference("group_ids", groupIDStrings(a.Groups.List()), groupIDStrings(b.Groups.List())),
+		newListDifference("gallery_ids", idStrings(a.GalleryIDs.List()), idStrings(b.GalleryIDs.List())),
+		newListDifference("stash_ids", stashIDStrings(a.StashIDs.List()), stashIDStrings(b.StashIDs.List())),
+	}
+}
+
+func compareSimilarity(a, b *models.Scene, metadata []FieldDifference, lists []ListDifference) Similarity {
+	var ret Similarity
+
+	filesA, filesB := a.Files.List(), b.Files.List()
+	for _, fa := range filesA {
+		for _, fb := range filesB {
+			for _, t := range []string{models.FingerprintTypeOshash, models.FingerprintTypeMD5} {
+				va, vb := fa.Fingerprints.For(t), fb.Fingerprints.For(t)
+				if va != nil && vb != nil && va.Value() == vb.Value() {
+					ret.SameFingerprint = true
+				}
+			}
+
+			pa, pb := fa.Fingerprints.For(models.FingerprintTypePhash), fb.Fingerprints.For(models.FingerprintTypePhash)
+			if pa != nil && pb != nil {
+				d := bits.OnesCount64(uint64(pa.Int64() ^ pb.Int64()))
+				if ret.PhashDistance == nil || d < *ret.PhashDistance {
+					ret.PhashDistance = &d
+				}
+			}
+		}
+	}
+
+	fa, fb := a.Files.Primary(), b.Files.Primary()
+	if fa != nil && fb != nil {
+		d := fa.Duration - fb.Duration
+		if d < 0 {
+			d = -d
+		}
+		ret.DurationDifference = &d
+	}
+
+	set, equal := 0, 0
+	for _, f := range metadata {
+		// organized is always set, and is not evidence of the same content
+		if f.Field != "organized" && f.A != nil && f.B != nil {
+			set++
+			if f.Equal {
+				equal++
+			}
+		}
+	}
+	if set > 0 {
+		m := float64(equal) / float64(set)
+		ret.MetadataMatch = &m
+	}
+
+	for _, l := range lists {
+		if l.Field != "performer_ids" {
+			continue
+		}
+		if len(l.OnlyA)+len(l.Common) > 0 && len(l.OnlyB)+len(l.Common) > 0 {
+			o := float64(len(l.Common)) / float64(len(l.OnlyA)+len(l.OnlyB)+len(l.Common))
+			ret.PerformerOverlap = &o
+		}
+	}
+
+	ret.Score = ret.score(fa, fb)
+	return ret
+}
+
+func (s Similarity) score(fa, fb *models.VideoFile) float64 {
+	if s.SameFingerprint {
+		return 1
+	}
+
+	var total, weights float64
+	add := func(v float64, weight float64) {
+		total += v * weight
+		weights += weight
+	}
+
+	if s.PhashDistance != nil {
+		add(max(0, 1-float64(*s.PhashDistance)/comparePhashMaxDistance), 3)
+	}
+	if s.DurationDifference != nil {
+		if longest := max(fa.Duration, fb.Duration); longest > 0 {
+			add(max(0, 1-*s.DurationDifference/longest), 1)
+		}
+	}
+	if s.MetadataMatch != nil {
+		add(*s.MetadataMatch, 1)
+	}
+	if s.PerformerOverlap != nil {
+		add(*s.PerformerOverlap, 1)
+	}
+
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
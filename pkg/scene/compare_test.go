@@ -0,0 +1,109 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	str := func(v string) *string { return &v }
+	intPtr := func(v int) *int { return &v }
+
+	videoFile := func(path string, duration float64, oshash string, phash int64) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				Path: path,
+				Fingerprints: models.Fingerprints{
+					{Type: models.FingerprintTypeOshash, Fingerprint: oshash},
+					{Type: models.FingerprintTypePhash, Fingerprint: phash},
+				},
+			},
+			Duration: duration,
+			Width:    1920,
+			Height:   1080,
+		}
+	}
+
+	newScene := func(title string, rating *int, performerIDs []int, files ...*models.VideoFile) *models.Scene {
+		return &models.Scene{
+			Title:        title,
+			Rating:       rating,
+			URLs:         models.NewRelatedStrings([]string{"https://example.com/" + title}),
+			TagIDs:       models.NewRelatedIDs([]int{1, 2}),
+			PerformerIDs: models.NewRelatedIDs(performerIDs),
+			Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
+			GalleryIDs:   models.NewRelatedIDs([]int{}),
+			StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+			Files:        models.NewRelatedVideoFiles(files),
+		}
+	}
+
+	a := newScene("a", intPtr(80), []int{1, 2}, videoFile("/a.mp4", 600, "aaaa", 0x0f))
+	b := newScene("b", intPtr(80), []int{2, 3}, videoFile("/b.mp4", 540, "bbbb", 0x0c))
+
+	markersA := []*models.SceneMarker{{Seconds: 10, PrimaryTagID: 1}, {Seconds: 20, PrimaryTagID: 1}}
+	markersB := []*models.SceneMarker{{Seconds: 10, PrimaryTagID: 1}, {Seconds: 30, PrimaryTagID: 2}}
+
+	got := Compare(a, b, markersA, markersB)
+
+	fields := make(map[string]FieldDifference)
+	for _, f := range got.Fields {
+		fields[f.Field] = f
+	}
+	assert.Equal(t, FieldDifference{Field: "title", A: str("a"), B: str("b")}, fields["title"])
+	assert.Equal(t, FieldDifference{Field: "rating", A: str("80"), B: str("80"), Equal: true}, fields["rating"])
+	assert.Equal(t, FieldDifference{Field: "date", Equal: true}, fields["date"])
+	assert.Equal(t, FieldDifference{Field: "file.duration", A: str("600.00"), B: str("540.00")}, fields["file.duration"])
+	assert.True(t, fields["file.resolution"].Equal)
+	assert.Equal(t, FieldDifference{Field: "fingerprint.oshash", A: str("aaaa"), B: str("bbbb")}, fields["fingerprint.oshash"])
+	assert.Equal(t, FieldDifference{Field: "fingerprint.md5", Equal: true}, fields["fingerprint.md5"])
+
+	lists := make(map[string]ListDifference)
+	for _, l := range got.Lists {
+		lists[l.Field] = l
+	}
+	assert.Equal(t, ListDifference{Field: "tag_ids", Common: []string{"1", "2"}}, lists["tag_ids"])
+	assert.Equal(t, ListDifference{Field: "performer_ids", OnlyA: []string{"1"}, OnlyB: []string{"3"}, Common: []string{"2"}}, lists["performer_ids"])
+	assert.Equal(t, ListDifference{Field: "group_ids"}, lists["group_ids"])
+
+	assert.Equal(t, []*models.SceneMarker{markersA[1]}, got.MarkersOnlyA)
+	assert.Equal(t, []*models.SceneMarker{markersB[1]}, got.MarkersOnlyB)
+	assert.Equal(t, 1, got.CommonMarkers)
+
+	s := got.Similarity
+	assert.False(t, s.SameFingerprint)
+	assert.Equal(t, intPtr(2), s.PhashDistance)
+	assert.Equal(t, 60.0, *s.DurationDifference)
+	// title differs and rating is equal
+	assert.Equal(t, 0.5, *s.MetadataMatch)
+	assert.InDelta(t, 1.0/3, *s.PerformerOverlap, 1e-9)
+	// (0.875*3 + 0.9 + 0.5 + 1/3) / 6
+	assert.InDelta(t, (0.875*3+0.9+0.5+1.0/3)/6, s.Score, 1e-9)
+
+	// a file of b with the same oshash as a
+	b = newScene("b", nil, []int{}, videoFile("/b.mp4", 540, "bbbb", 0x0c), videoFile("/c.mp4", 600, "aaaa", 0x0f))
+	s = Compare(a, b, nil, nil).Similarity
+	assert.True(t, s.SameFingerprint)
+	assert.Equal(t, intPtr(0), s.PhashDistance)
+	assert.Nil(t, s.PerformerOverlap)
+	assert.Equal(t, 1.0, s.Score)
+}
+
+func TestCompareNoFiles(t *testing.T) {
+	newScene := func() *models.Scene {
+		return &models.Scene{
+			URLs:         models.NewRelatedStrings([]string{}),
+			TagIDs:       models.NewRelatedIDs([]int{}),
+			PerformerIDs: models.NewRelatedIDs([]int{}),
+			Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
+			GalleryIDs:   models.NewRelatedIDs([]int{}),
+			StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+			Files:        models.NewRelatedVideoFiles([]*models.VideoFile{}),
+		}
+	}
+
+	s := Compare(newScene(), newScene(), nil, nil).Similarity
+	assert.Equal(t, Similarity{}, s)
+}
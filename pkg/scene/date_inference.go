@@ -0,0 +1,279 @@
+package scene
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// DateSource is the kind of evidence a scene date is inferred from.
+type DateSource string
+
+const (
+	// DateSourceContainer is the creation time of the container, which is
+	// often the time the file was encoded rather than released.
+	DateSourceContainer DateSource = "CONTAINER"
+	// DateSourceQuickTime is the date tag or the recording date written
+	// by cameras and phones in the QuickTime metadata.
+	DateSourceQuickTime DateSource = "QUICKTIME"
+	// DateSourceFilename is a date in the filename of the scene.
+	DateSourceFilename DateSource = "FILENAME"
+)
+
+var dateSources = []DateSource{
+	DateSourceContainer,
+	DateSourceQuickTime,
+	DateSourceFilename,
+}
+
+func (e DateSource) IsValid() bool {
+	switch e {
+	case DateSourceContainer, DateSourceQuickTime, DateSourceFilename:
+		return true
+	}
+	return false
+}
+
+func (e DateSource) String() string {
+	return string(e)
+}
+
+func (e *DateSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DateSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneDateSource", str)
+	}
+	return nil
+}
+
+func (e DateSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Confidences of each kind of evidence on its own. Dates in filenames are
+// usually release dates, while the container creation time is often the
+// time the file was last encoded.
+const (
+	FilenameDateConfidence        = 0.75
+	FilenameShortDateConfidence   = 0.6
+	FilenameCompactDateConfidence = 0.5
+	QuickTimeDateConfidence       = 0.6
+	ContainerDateConfidence       = 0.3
+)
+
+var (
+	// 2019-05-12, 2019.05.12, 2019_05_12 or 2019 05 12
+	filenameDateRE = regexp.MustCompile(`(?:^|\D)((?:19|20)\d{2})[-._ ](\d{2})[-._ ](\d{2})(?:\D|$)`)
+	// 19.05.12, as used in scene release names
+	filenameShortDateRE = regexp.MustCompile(`(?:^|\D)(\d{2})\.(\d{2})\.(\d{2})(?:\D|$)`)
+	// 20190512
+	filenameCompactDateRE = regexp.MustCompile(`(?:^|\D)((?:19|20)\d{2})(\d{2})(\d{2})(?:\D|$)`)
+)
+
+// DateEvidence is a date inferred for a scene from a single source.
+type DateEvidence struct {
+	Source     DateSource
+	Date       models.Date
+	Confidence float64
+}
+
+// plausibleDate returns the date of t, and false if t is unset, a default
+// value written by muxers, before 1900 or in the future.
+func plausibleDate(t time.Time, now time.Time) (models.Date, bool) {
+	if t.IsZero() || t.Year() < 1900 || t.After(now) {
+		return models.Date{}, false
+	}
+
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	// the QuickTime and unix epochs are written when no time is set
+	if d.Equal(time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)) || d.Equal(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		return models.Date{}, false
+	}
+
+	return models.Date{Time: d}, true
+}
+
+// filenameDate returns the date of the year, month and day tokens, and
+// false if they are not a valid date.
+func filenameDate(year, month, day string, now time.Time) (models.Date, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+
+	if len(year) == 2 {
+		// two digit years are in the past century
+		if y <= now.Year()%100 {
+			y += 2000
+		} else {
+			y += 1900
+		}
+	}
+
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	// time.Date normalises invalid days, such as February 30
+	if t.Month() != time.Month(m) || t.Day() != d {
+		return models.Date{}, false
+	}
+
+	return plausibleDate(t, now)
+}
+
+// FilenameDates returns the dates in the filename of path, which is
+// matched without its extension.
+func FilenameDates(path string, now time.Time) []DateEvidence {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	var ret []DateEvidence
+	for _, p := range []struct {
+		re         *regexp.Regexp
+		confidence float64
+	}{
+		{filenameDateRE, FilenameDateConfidence},
+		{filenameShortDateRE, FilenameShortDateConfidence},
+		{filenameCompactDateRE, FilenameCompactDateConfidence},
+	} {
+		for _, m := range p.re.FindAllStringSubmatch(name, -1) {
+			if d, ok := filenameDate(m[1], m[2], m[3], now); ok {
+				ret = append(ret, DateEvidence{
+					Source:     DateSourceFilename,
+					Date:       d,
+					Confidence: p.confidence,
+				})
+			}
+		}
+	}
+
+	return ret
+}
+
+// MetadataDates returns the dates of the container creation time, the date
+// tag and the QuickTime recording date of a file. Unset and implausible
+// dates are ignored.
+func MetadataDates(creationTime, dateTag, quickTimeCreationDate time.Time, now time.Time) []DateEvidence {
+	var ret []DateEvidence
+	for _, t := range []struct {
+		time       time.Time
+		source     DateSource
+		confidence float64
+	}{
+		{creationTime, DateSourceContainer, ContainerDateConfidence},
+		{dateTag, DateSourceQuickTime, QuickTimeDateConfidence},
+		{quickTimeCreationDate, DateSourceQuickTime, QuickTimeDateConfidence},
+	} {
+		if d, ok := plausibleDate(t.time, now); ok {
+			ret = append(ret, DateEvidence{
+				Source:     t.source,
+				Date:       d,
+				Confidence: t.confidence,
+			})
+		}
+	}
+
+	return ret
+}
+
+// DateCandidate is a scene without a date and the evidence found for it.
+type DateCandidate struct {
+	SceneID  int
+	Evidence []DateEvidence
+}
+
+// DateProposal is a date proposed for a scene.
+type DateProposal struct {
+	ID      int
+	SceneID int
+	Date    models.Date
+	// Confidence is from 0 to 1, combining the confidence of each source.
+	Confidence float64
+	// Sources are the kinds of evidence for the date.
+	Sources []DateSource
+}
+
+// DateProposalReport lists the dates proposed for scenes without a date.
+type DateProposalReport struct {
+	GeneratedAt time.Time
+	Proposals   []DateProposal
+}
+
+// InferDate returns the date with the highest confidence from the evidence.
+// The confidences of independent sources for the same date are combined, so
+// that a date found in both the filename and the metadata is more likely
+// than one found in the filename alone. Returns false if there is no
+// evidence.
+func InferDate(evidence []DateEvidence) (DateProposal, bool) {
+	// highest confidence of each source, by date
+	bySource := make(map[models.Date]map[DateSource]float64)
+	for _, e := range evidence {
+		sources := bySource[e.Date]
+		if sources == nil {
+			sources = make(map[DateSource]float64)
+			bySource[e.Date] = sources
+		}
+		if e.Confidence > sources[e.Source] {
+			sources[e.Source] = e.Confidence
+		}
+	}
+
+	var ret DateProposal
+	found := false
+	for date, sources := range bySource {
+		p := DateProposal{Date: date}
+
+		doubt := 1.0
+		for _, source := range dateSources {
+			if c, ok := sources[source]; ok {
+				doubt *= 1 - c
+				p.Sources = append(p.Sources, source)
+			}
+		}
+		p.Confidence = 1 - doubt
+
+		// prefer the earlier date on a tie, so the result does not depend
+		// on map order
+		if !found || p.Confidence > ret.Confidence || (p.Confidence == ret.Confidence && date.Before(ret.Date.Time)) {
+			ret = p
+			found = true
+		}
+	}
+
+	return ret, found
+}
+
+// ProposeDates returns a proposal for each candidate with evidence, ordered
+// by descending confidence. Proposals are numbered from 1 in that order.
+func ProposeDates(candidates []DateCandidate) []DateProposal {
+	var ret []DateProposal
+	for _, c := range candidates {
+		p, ok := InferDate(c.Evidence)
+		if !ok {
+			continue
+		}
+
+		p.SceneID = c.SceneID
+		ret = append(ret, p)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Confidence > ret[j].Confidence
+	})
+
+	for i := range ret {
+		ret[i].ID = i + 1
+	}
+
+	return ret
+}
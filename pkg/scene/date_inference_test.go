@@ -0,0 +1,113 @@
+package scene
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func testDate(s string) models.Date {
+	d, err := models.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func dateStrings(evidence []DateEvidence) []string {
+	var ret []string
+	for _, e := range evidence {
+		ret = append(ret, e.Date.String())
+	}
+	return ret
+}
+
+func TestFilenameDates(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/stash/Studio - 2019-05-12 - Title.mp4", []string{"2019-05-12"}},
+		{"/stash/Studio.2019.05.12.Title.mp4", []string{"2019-05-12"}},
+		{"/stash/Studio_2019_05_12.mp4", []string{"2019-05-12"}},
+		{"/stash/Studio.19.05.12.Performer.mp4", []string{"2019-05-12"}},
+		{"/stash/Studio.98.05.12.Performer.mp4", []string{"1998-05-12"}},
+		{"/stash/studio_20190512_title.mp4", []string{"2019-05-12"}},
+		{"/stash/2019-02-30.mp4", nil},
+		{"/stash/2025-01-01.mp4", nil},
+		{"/stash/title 1080p.mp4", nil},
+		{"/stash/2019-05-12/title.mp4", nil},
+		{"/stash/id 123456789.mp4", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, dateStrings(FilenameDates(tt.path, now)))
+		})
+	}
+}
+
+func TestMetadataDates(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	got := MetadataDates(
+		time.Date(2020, 3, 4, 23, 0, 0, 0, time.UTC),
+		time.Time{},
+		time.Date(2019, 5, 12, 1, 0, 0, 0, time.FixedZone("", 2*60*60)),
+		now,
+	)
+	assert.Equal(t, []DateEvidence{
+		{Source: DateSourceContainer, Date: testDate("2020-03-04"), Confidence: ContainerDateConfidence},
+		{Source: DateSourceQuickTime, Date: testDate("2019-05-12"), Confidence: QuickTimeDateConfidence},
+	}, got, "the recording date is in its own time zone")
+
+	got = MetadataDates(
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		now,
+	)
+	assert.Empty(t, got, "default and future dates are ignored")
+}
+
+func TestInferDate(t *testing.T) {
+	_, found := InferDate(nil)
+	assert.False(t, found)
+
+	p, found := InferDate([]DateEvidence{
+		{Source: DateSourceFilename, Date: testDate("2019-05-12"), Confidence: 0.6},
+		{Source: DateSourceFilename, Date: testDate("2019-05-12"), Confidence: 0.75},
+		{Source: DateSourceContainer, Date: testDate("2019-05-12"), Confidence: 0.3},
+		{Source: DateSourceQuickTime, Date: testDate("2020-01-01"), Confidence: 0.8},
+	})
+	assert.True(t, found)
+	assert.Equal(t, testDate("2019-05-12"), p.Date)
+	assert.InDelta(t, 1-0.25*0.7, p.Confidence, 0.0001)
+	assert.Equal(t, []DateSource{DateSourceContainer, DateSourceFilename}, p.Sources)
+
+	p, _ = InferDate([]DateEvidence{
+		{Source: DateSourceFilename, Date: testDate("2019-05-12"), Confidence: 0.5},
+		{Source: DateSourceFilename, Date: testDate("2018-01-01"), Confidence: 0.5},
+	})
+	assert.Equal(t, testDate("2018-01-01"), p.Date, "earlier date on a tie")
+}
+
+func TestProposeDates(t *testing.T) {
+	got := ProposeDates([]DateCandidate{
+		{SceneID: 1, Evidence: []DateEvidence{{Source: DateSourceContainer, Date: testDate("2019-05-12"), Confidence: 0.3}}},
+		{SceneID: 2},
+		{SceneID: 3, Evidence: []DateEvidence{{Source: DateSourceFilename, Date: testDate("2020-01-01"), Confidence: 0.75}}},
+	})
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, 1, got[0].ID)
+		assert.Equal(t, 3, got[0].SceneID)
+		assert.Equal(t, 2, got[1].ID)
+		assert.Equal(t, 1, got[1].SceneID)
+	}
+}
@@ -22,8 +22,12 @@ type FileDeleter struct {
 
 // MarkGeneratedFiles marks for deletion the generated files for the provided scene.
 func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
-	sceneHash := scene.GetHash(d.FileNamingAlgo)
+	return d.MarkGeneratedFilesForHash(scene.GetHash(d.FileNamingAlgo))
+}
 
+// MarkGeneratedFilesForHash marks for deletion the files generated for the
+// provided scene hash, such as that of a file the scene no longer has.
+func (d *FileDeleter) MarkGeneratedFilesForHash(sceneHash string) error {
 	if sceneHash == "" {
 		return nil
 	}
@@ -51,6 +55,12 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		files = append(files, streamPreviewImagePath)
 	}
 
+	wallPreviewPath := d.Paths.Scene.GetWallPreviewPath(sceneHash)
+	exists, _ = fsutil.FileExists(wallPreviewPath)
+	if exists {
+		files = append(files, wallPreviewPath)
+	}
+
 	transcodePath := d.Paths.Scene.GetTranscodePath(sceneHash)
 	exists, _ = fsutil.FileExists(transcodePath)
 	if exists {
@@ -63,6 +73,12 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		files = append(files, spritePath)
 	}
 
+	spriteWebpPath := d.Paths.Scene.GetSpriteWebpImageFilePath(sceneHash)
+	exists, _ = fsutil.FileExists(spriteWebpPath)
+	if exists {
+		files = append(files, spriteWebpPath)
+	}
+
 	vttPath := d.Paths.Scene.GetSpriteVttFilePath(sceneHash)
 	exists, _ = fsutil.FileExists(vttPath)
 	if exists {
@@ -84,6 +100,7 @@ func (d *FileDeleter) MarkMarkerFiles(scene *models.Scene, seconds int) error {
 	videoPath := d.Paths.SceneMarkers.GetVideoPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
 	imagePath := d.Paths.SceneMarkers.GetWebpPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
 	screenshotPath := d.Paths.SceneMarkers.GetScreenshotPath(scene.GetHash(d.FileNamingAlgo), seconds)
+	thumbnailPath := d.Paths.SceneMarkers.GetThumbnailPath(scene.GetHash(d.FileNamingAlgo), seconds)
 
 	var files []string
 
@@ -102,6 +119,11 @@ func (d *FileDeleter) MarkMarkerFiles(scene *models.Scene, seconds int) error {
 		files = append(files, screenshotPath)
 	}
 
+	exists, _ = fsutil.FileExists(thumbnailPath)
+	if exists {
+		files = append(files, thumbnailPath)
+	}
+
 	return d.Files(files)
 }
 
@@ -180,6 +202,27 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 		}
 	}
 
+	// extras belong to the scene only, so they are deleted with it
+	extras, err := s.ExtraRepository.FindBySceneID(ctx, scene.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range extras {
+		files, err := s.File.Find(ctx, e.FileID)
+		if err != nil {
+			return err
+		}
+
+		const deleteFile = true
+		for _, f := range files {
+			logger.Info("Deleting scene extra file: ", f.Base().Path)
+			if err := file.Destroy(ctx, s.File, f, fileDeleter.Deleter, deleteFile); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
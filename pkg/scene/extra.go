@@ -0,0 +1,82 @@
+package scene
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// SetExtra makes the video file an extra of the scene with the role. The
+// file is removed from the files of any scene, so it must not be the primary
+// file of a scene.
+func (s *Service) SetExtra(ctx context.Context, sceneID int, fileID models.FileID, role models.SceneExtraRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("invalid extra role %q", role)
+	}
+
+	f, err := s.File.Find(ctx, fileID)
+	if err != nil {
+		return err
+	}
+
+	if len(f) == 0 {
+		return fmt.Errorf("file with id %d not found", fileID)
+	}
+
+	ff := f[0]
+	if _, ok := ff.(*models.VideoFile); !ok {
+		return fmt.Errorf("%s is not a video file", ff.Base().Path)
+	}
+
+	isPrimary, err := s.File.IsPrimary(ctx, fileID)
+	if err != nil {
+		return err
+	}
+
+	if isPrimary {
+		return errors.New("cannot make a primary file an extra")
+	}
+
+	return s.ExtraRepository.Set(ctx, models.SceneExtra{
+		SceneID: sceneID,
+		FileID:  fileID,
+		Role:    role,
+	})
+}
+
+// mergeExtras moves the extras of src to dest.
+func (s *Service) mergeExtras(ctx context.Context, dest *models.Scene, src *models.Scene) error {
+	extras, err := s.ExtraRepository.FindBySceneID(ctx, src.ID)
+	if err != nil {
+		return fmt.Errorf("finding extras of scene %d: %w", src.ID, err)
+	}
+
+	for _, e := range extras {
+		e.SceneID = dest.ID
+		if err := s.ExtraRepository.Set(ctx, *e); err != nil {
+			return fmt.Errorf("moving extra %d to scene %d: %w", e.FileID, dest.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveExtra returns the extra file to the files of its scene.
+func (s *Service) RemoveExtra(ctx context.Context, fileID models.FileID) error {
+	extra, err := s.ExtraRepository.FindByFileID(ctx, fileID)
+	if err != nil {
+		return err
+	}
+
+	if extra == nil {
+		return fmt.Errorf("file with id %d is not a scene extra", fileID)
+	}
+
+	if err := s.ExtraRepository.Destroy(ctx, fileID); err != nil {
+		return err
+	}
+
+	return s.Repository.AddFileID(ctx, extra.SceneID, fileID)
+}
@@ -0,0 +1,165 @@
+package generate
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+)
+
+const (
+	// width of the frames scored when choosing a cover
+	coverCandidateWidth = 320
+
+	// proportion of the start and end of the video that cover candidates
+	// are not taken from, to skip intros and credits
+	coverCandidateMargin = 0.05
+
+	// variance of the laplacian at which a frame scores half for sharpness
+	sharpnessHalfScore = 200.0
+	// luma standard deviation at which a frame scores fully for contrast
+	contrastFullScore = 0.2
+	// ideal mean luma of a cover
+	idealBrightness = 0.45
+	// proportion of skin toned pixels at which a frame scores fully for
+	// people
+	skinFullScore = 0.15
+)
+
+// CoverCandidate returns a low resolution frame of the video at the given
+// time, to be scored as a cover.
+func (g Generator) CoverCandidate(ctx context.Context, input string, seconds float64) (image.Image, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	ssOptions := transcoder.ScreenshotOptions{
+		OutputPath: "-",
+		OutputType: transcoder.ScreenshotOutputTypeBMP,
+		Width:      coverCandidateWidth,
+	}
+
+	args := transcoder.ScreenshotTime(input, seconds, ssOptions)
+
+	return g.generateImage(lockCtx, args)
+}
+
+// CoverCandidateTimes returns the times of count candidate cover frames,
+// spread evenly across the video excluding its start and end. Offset is the
+// position of each frame within its part of the video, from 0 to 1.
+func CoverCandidateTimes(duration float64, count int, offset float64) []float64 {
+	if duration <= 0 || count <= 0 {
+		return nil
+	}
+
+	start := duration * coverCandidateMargin
+	step := (duration - 2*start) / float64(count)
+
+	ret := make([]float64, count)
+	for i := range ret {
+		ret[i] = start + (float64(i)+offset)*step
+	}
+
+	return ret
+}
+
+// FrameScore is the measured quality of a candidate cover frame. All values
+// are from 0 to 1.
+type FrameScore struct {
+	// Sharpness is derived from the variance of the laplacian of the frame.
+	// Blurry frames score low.
+	Sharpness float64
+	// Brightness is the mean luma of the frame.
+	Brightness float64
+	// Contrast is the standard deviation of the luma of the frame.
+	Contrast float64
+	// Skin is the proportion of skin toned pixels, used to detect frames
+	// with people in them.
+	Skin float64
+}
+
+// Total returns the overall score of the frame. Black, white and blurry
+// frames score low. If preferPeople is true, frames with more skin toned
+// pixels score higher.
+func (s FrameScore) Total(preferPeople bool) float64 {
+	exposure := 1 - math.Min(math.Abs(s.Brightness-idealBrightness)/idealBrightness, 1)
+	contrast := math.Min(s.Contrast/contrastFullScore, 1)
+
+	ret := 0.5*s.Sharpness + 0.25*exposure + 0.25*contrast
+
+	if preferPeople {
+		ret = 0.75*ret + 0.25*math.Min(s.Skin/skinFullScore, 1)
+	}
+
+	return ret
+}
+
+// ScoreFrame measures the quality of a candidate cover frame.
+func ScoreFrame(img image.Image) FrameScore {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return FrameScore{}
+	}
+
+	luma := make([]float64, w*h)
+	var sum float64
+	var skin int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.YCbCrModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.YCbCr)
+			l := float64(c.Y)
+			luma[y*w+x] = l
+			sum += l
+
+			if isSkinTone(c) {
+				skin++
+			}
+		}
+	}
+
+	n := float64(w * h)
+	mean := sum / n
+
+	var variance float64
+	for _, l := range luma {
+		variance += (l - mean) * (l - mean)
+	}
+	variance /= n
+
+	return FrameScore{
+		Sharpness:  laplacianSharpness(luma, w, h),
+		Brightness: mean / 255,
+		Contrast:   math.Sqrt(variance) / 255,
+		Skin:       float64(skin) / n,
+	}
+}
+
+// laplacianSharpness returns the sharpness score of the luma values, from the
+// variance of their laplacian.
+func laplacianSharpness(luma []float64, w int, h int) float64 {
+	if w < 3 || h < 3 {
+		return 0
+	}
+
+	var sum, sumSq float64
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			l := luma[i-w] + luma[i+w] + luma[i-1] + luma[i+1] - 4*luma[i]
+			sum += l
+			sumSq += l * l
+		}
+	}
+
+	n := float64((w - 2) * (h - 2))
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+
+	return variance / (variance + sharpnessHalfScore)
+}
+
+func isSkinTone(c color.YCbCr) bool {
+	return c.Y > 40 && c.Cb >= 77 && c.Cb <= 127 && c.Cr >= 133 && c.Cr <= 173
+}
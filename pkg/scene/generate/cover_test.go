@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverCandidateTimes(t *testing.T) {
+	assert.Equal(t, []float64{5, 27.5, 50, 72.5}, CoverCandidateTimes(100, 4, 0))
+	assert.Equal(t, []float64{16.25, 38.75, 61.25, 83.75}, CoverCandidateTimes(100, 4, 0.5))
+	assert.Nil(t, CoverCandidateTimes(0, 4, 0.5))
+	assert.Nil(t, CoverCandidateTimes(100, 0, 0.5))
+}
+
+func fillImage(w, h int, fn func(x, y int) color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fn(x, y))
+		}
+	}
+	return img
+}
+
+func TestScoreFrame(t *testing.T) {
+	black := fillImage(32, 32, func(x, y int) color.Color { return color.Black })
+	flat := fillImage(32, 32, func(x, y int) color.Color { return color.Gray{Y: 115} })
+	detailed := fillImage(32, 32, func(x, y int) color.Color {
+		if (x/2+y/2)%2 == 0 {
+			return color.Gray{Y: 40}
+		}
+		return color.Gray{Y: 190}
+	})
+	skin := fillImage(32, 32, func(x, y int) color.Color {
+		if (x/2+y/2)%2 == 0 {
+			return color.RGBA{R: 224, G: 172, B: 140, A: 255}
+		}
+		return color.RGBA{R: 90, G: 60, B: 45, A: 255}
+	})
+
+	blackScore := ScoreFrame(black)
+	assert.Equal(t, 0.0, blackScore.Brightness)
+	assert.Equal(t, 0.0, blackScore.Sharpness)
+
+	flatScore := ScoreFrame(flat)
+	assert.Equal(t, 0.0, flatScore.Contrast)
+
+	detailedScore := ScoreFrame(detailed)
+	assert.Greater(t, detailedScore.Sharpness, 0.9)
+	assert.Equal(t, 0.0, detailedScore.Skin)
+
+	assert.Greater(t, detailedScore.Total(false), flatScore.Total(false))
+	assert.Greater(t, flatScore.Total(false), blackScore.Total(false))
+
+	skinScore := ScoreFrame(skin)
+	assert.Greater(t, skinScore.Skin, 0.4)
+	assert.Greater(t, skinScore.Total(true), skinScore.Total(false)*0.75)
+	assert.Greater(t, skinScore.Total(true)-detailedScore.Total(true), skinScore.Total(false)-detailedScore.Total(false))
+}
@@ -31,6 +31,7 @@ type MarkerPaths interface {
 	GetVideoPreviewPath(checksum string, seconds int) string
 	GetWebpPreviewPath(checksum string, seconds int) string
 	GetScreenshotPath(checksum string, seconds int) string
+	GetThumbnailPath(checksum string, seconds int) string
 }
 
 type ScenePaths interface {
@@ -38,8 +39,10 @@ type ScenePaths interface {
 
 	GetVideoPreviewPath(checksum string) string
 	GetWebpPreviewPath(checksum string) string
+	GetWallPreviewPath(checksum string) string
 
 	GetSpriteImageFilePath(checksum string) string
+	GetSpriteWebpImageFilePath(checksum string) string
 	GetSpriteVttFilePath(checksum string) string
 
 	GetTranscodePath(checksum string) string
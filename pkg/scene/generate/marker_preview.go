@@ -18,6 +18,8 @@ const (
 	markerWebpFPS       = 12
 
 	markerScreenshotQuality = 2
+
+	markerThumbnailWidth = 320
 )
 
 func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds float64, endSeconds *float64, includeAudio bool) error {
@@ -175,6 +177,31 @@ func (g Generator) SceneMarkerScreenshot(ctx context.Context, input string, hash
 	return nil
 }
 
+// SceneMarkerThumbnail generates a small image of the frame at the start of
+// the marker, for use in marker lists and on the scene scrubber.
+func (g Generator) SceneMarkerThumbnail(ctx context.Context, input string, hash string, seconds float64) error {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	output := g.MarkerPaths.GetThumbnailPath(hash, int(seconds))
+	if !g.Overwrite {
+		if exists, _ := fsutil.FileExists(output); exists {
+			return nil
+		}
+	}
+
+	if err := g.generateFile(lockCtx, g.MarkerPaths, jpgPattern, output, g.sceneMarkerScreenshot(input, SceneMarkerScreenshotOptions{
+		Seconds: seconds,
+		Width:   markerThumbnailWidth,
+	})); err != nil {
+		return err
+	}
+
+	logger.Debug("created marker thumbnail: ", output)
+
+	return nil
+}
+
 type SceneMarkerScreenshotOptions struct {
 	Seconds float64
 	Width   int
@@ -0,0 +1,58 @@
+package generate
+
+import (
+	"context"
+	"image"
+
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+)
+
+const (
+	// maximum width of the frames in which text is recognized. Larger
+	// frames are scaled down.
+	ocrFrameMaxWidth = 1920
+
+	// time of the first frame in which text is recognized, as titles are
+	// usually shown at the start of the video
+	ocrIntroTime = 3.0
+)
+
+// OCRFrame returns the frame of the video at the given time, in which
+// on-screen text is to be recognized. Width is the width of the video.
+func (g Generator) OCRFrame(ctx context.Context, input string, seconds float64, width int) (image.Image, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	ssOptions := transcoder.ScreenshotOptions{
+		OutputPath: "-",
+		OutputType: transcoder.ScreenshotOutputTypeBMP,
+	}
+	if width > ocrFrameMaxWidth {
+		ssOptions.Width = ocrFrameMaxWidth
+	}
+
+	args := transcoder.ScreenshotTime(input, seconds, ssOptions)
+
+	return g.generateImage(lockCtx, args)
+}
+
+// OCRFrameTimes returns the times of count frames in which on-screen text is
+// to be recognized. The first frame is near the start of the video, where
+// titles are shown, and the rest are spread evenly across the video.
+func OCRFrameTimes(duration float64, count int) []float64 {
+	if duration <= 0 || count <= 0 {
+		return nil
+	}
+
+	if count == 1 || duration <= 2*ocrIntroTime {
+		return []float64{min(ocrIntroTime, duration/2)}
+	}
+
+	ret := []float64{ocrIntroTime}
+	step := duration / float64(count-1)
+	for i := 0; i < count-1; i++ {
+		ret = append(ret, (float64(i)+0.5)*step)
+	}
+
+	return ret
+}
@@ -0,0 +1,15 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCRFrameTimes(t *testing.T) {
+	assert.Equal(t, []float64{3, 12.5, 37.5, 62.5, 87.5}, OCRFrameTimes(100, 5))
+	assert.Equal(t, []float64{3}, OCRFrameTimes(100, 1))
+	assert.Equal(t, []float64{2}, OCRFrameTimes(4, 5))
+	assert.Nil(t, OCRFrameTimes(0, 5))
+	assert.Nil(t, OCRFrameTimes(100, 0))
+}
@@ -21,6 +21,8 @@ const (
 
 	scenePreviewImageFPS = 12
 
+	wallPreviewAudioBitrate = "64k"
+
 	minSegmentDuration = 0.75
 )
 
@@ -283,6 +285,85 @@ func (g Generator) PreviewWebp(ctx context.Context, input string, hash string) e
 	return nil
 }
 
+// WallPreviewOptions is the profile of the lightweight previews played on
+// the wall by low-power clients.
+type WallPreviewOptions struct {
+	Height int
+	CRF    int
+	Preset string
+	Audio  bool
+}
+
+// PreviewWall generates a low resolution H.264 version of the preview video,
+// which can be decoded by clients such as TV browsers without transcoding.
+func (g Generator) PreviewWall(ctx context.Context, input string, hash string, options WallPreviewOptions) error {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	output := g.ScenePaths.GetWallPreviewPath(hash)
+	if !g.Overwrite {
+		if exists, _ := fsutil.FileExists(output); exists {
+			return nil
+		}
+	}
+
+	logger.Infof("[generator] generating wall preview for %s", input)
+
+	src := g.ScenePaths.GetVideoPreviewPath(hash)
+
+	if err := g.generateFile(lockCtx, g.ScenePaths, mp4Pattern, output, g.previewVideoToWall(src, options)); err != nil {
+		return err
+	}
+
+	logger.Debug("created wall preview: ", output)
+
+	return nil
+}
+
+func (g Generator) previewVideoToWall(input string, options WallPreviewOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		var videoFilter ffmpeg.VideoFilter
+		videoFilter = videoFilter.ScaleHeight(options.Height)
+
+		var videoArgs ffmpeg.Args
+		videoArgs = videoArgs.VideoFilter(videoFilter)
+
+		// baseline-compatible settings, so that hardware decoders of
+		// low-power devices can play the preview
+		videoArgs = append(videoArgs,
+			"-pix_fmt", "yuv420p",
+			"-profile:v", "main",
+			"-level", "3.1",
+			"-preset", options.Preset,
+			"-crf", strconv.Itoa(options.CRF),
+			"-movflags", "+faststart",
+			"-threads", "4",
+		)
+
+		encodeOptions := transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+
+			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoArgs:  videoArgs,
+
+			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
+			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+		}
+
+		if options.Audio {
+			var audioArgs ffmpeg.Args
+			audioArgs = audioArgs.AudioBitrate(wallPreviewAudioBitrate)
+
+			encodeOptions.AudioCodec = ffmpeg.AudioCodecAAC
+			encodeOptions.AudioArgs = audioArgs
+		}
+
+		args := transcoder.Transcode(input, encodeOptions)
+
+		return g.generate(lockCtx, args)
+	}
+}
+
 func (g Generator) previewVideoToImage(input string) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		var videoFilter ffmpeg.VideoFilter
@@ -9,6 +9,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/disintegration/imaging"
@@ -21,11 +22,47 @@ import (
 const (
 	spriteScreenshotWidth = 160
 
-	spriteRows   = 9
-	spriteCols   = 9
-	spriteChunks = spriteRows * spriteCols
+	spritePngPattern  = "*.png"
+	spriteWebpQuality = 80
 )
 
+// SpriteLayout describes the thumbnails of a sprite image.
+type SpriteLayout struct {
+	Columns int
+	// Chunks is the number of thumbnails in the sprite image
+	Chunks int
+	// StepSize is the time in seconds between thumbnails
+	StepSize float64
+}
+
+// NewSpriteLayout returns the layout of a sprite image with at most
+// rows * columns thumbnails for a video of the given duration. If interval
+// is greater than zero, a thumbnail is taken every interval seconds, up to
+// the maximum number of thumbnails. Otherwise, the maximum number of
+// thumbnails is spread evenly across the video.
+func NewSpriteLayout(duration float64, rows int, columns int, interval float64) SpriteLayout {
+	chunks := rows * columns
+	if interval > 0 && duration > 0 {
+		chunks = min(max(int(math.Ceil(duration/interval)), 1), chunks)
+	}
+
+	ret := SpriteLayout{
+		Columns: columns,
+		Chunks:  chunks,
+	}
+
+	if duration > 0 {
+		ret.StepSize = duration / float64(chunks)
+	}
+
+	return ret
+}
+
+// Rows returns the number of rows in the sprite image.
+func (l SpriteLayout) Rows() int {
+	return (l.Chunks + l.Columns - 1) / l.Columns
+}
+
 func (g Generator) SpriteScreenshot(ctx context.Context, input string, seconds float64) (image.Image, error) {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
@@ -70,16 +107,16 @@ func (g Generator) generateImage(lockCtx *fsutil.LockContext, args ffmpeg.Args)
 	return img, nil
 }
 
-func (g Generator) CombineSpriteImages(images []image.Image) image.Image {
+func (g Generator) CombineSpriteImages(images []image.Image, layout SpriteLayout) image.Image {
 	// Combine all of the thumbnails into a sprite image
 	width := images[0].Bounds().Size().X
 	height := images[0].Bounds().Size().Y
-	canvasWidth := width * spriteCols
-	canvasHeight := height * spriteRows
+	canvasWidth := width * layout.Columns
+	canvasHeight := height * layout.Rows()
 	montage := imaging.New(canvasWidth, canvasHeight, color.NRGBA{})
 	for index := 0; index < len(images); index++ {
-		x := width * (index % spriteCols)
-		y := height * int(math.Floor(float64(index)/float64(spriteRows)))
+		x := width * (index % layout.Columns)
+		y := height * (index / layout.Columns)
 		img := images[index]
 		montage = imaging.Paste(montage, img, image.Pt(x, y))
 	}
@@ -87,14 +124,49 @@ func (g Generator) CombineSpriteImages(images []image.Image) image.Image {
 	return montage
 }
 
-func (g Generator) SpriteVTT(ctx context.Context, output string, spritePath string, stepSize float64) error {
+// SpriteImageWebp encodes the sprite image as webp and writes it to output.
+func (g Generator) SpriteImageWebp(ctx context.Context, img image.Image, output string) error {
+	lockCtx := g.LockManager.ReadLock(ctx, output)
+	defer lockCtx.Cancel()
+
+	return g.generateFile(lockCtx, g.ScenePaths, webpPattern, output, g.spriteImageWebp(img))
+}
+
+func (g Generator) spriteImageWebp(img image.Image) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		// ffmpeg is used to encode webp, so write a lossless intermediate image
+		pngFile, err := g.tempFile(g.ScenePaths, spritePngPattern)
+		if err != nil {
+			return err
+		}
+
+		pngFn := pngFile.Name()
+		defer func() {
+			_ = os.Remove(pngFn)
+		}()
+
+		if err := imaging.Save(img, pngFn); err != nil {
+			return fmt.Errorf("writing intermediate sprite image: %w", err)
+		}
+
+		args := transcoder.Transcode(pngFn, transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+			VideoCodec: ffmpeg.VideoCodecLibWebP,
+			VideoArgs:  ffmpeg.Args{"-q:v", strconv.Itoa(spriteWebpQuality)},
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
+
+func (g Generator) SpriteVTT(ctx context.Context, output string, spritePath string, layout SpriteLayout) error {
 	lockCtx := g.LockManager.ReadLock(ctx, spritePath)
 	defer lockCtx.Cancel()
 
-	return g.generateFile(lockCtx, g.ScenePaths, vttPattern, output, g.spriteVTT(spritePath, stepSize))
+	return g.generateFile(lockCtx, g.ScenePaths, vttPattern, output, g.spriteVTT(spritePath, layout))
 }
 
-func (g Generator) spriteVTT(spritePath string, stepSize float64) generateFn {
+func (g Generator) spriteVTT(spritePath string, layout SpriteLayout) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		spriteImage, err := os.Open(spritePath)
 		if err != nil {
@@ -106,15 +178,15 @@ func (g Generator) spriteVTT(spritePath string, stepSize float64) generateFn {
 		if err != nil {
 			return err
 		}
-		width := image.Width / spriteCols
-		height := image.Height / spriteRows
+		width := image.Width / layout.Columns
+		height := image.Height / layout.Rows()
 
 		vttLines := []string{"WEBVTT", ""}
-		for index := 0; index < spriteChunks; index++ {
-			x := width * (index % spriteCols)
-			y := height * int(math.Floor(float64(index)/float64(spriteRows)))
-			startTime := utils.GetVTTTime(float64(index) * stepSize)
-			endTime := utils.GetVTTTime(float64(index+1) * stepSize)
+		for index := 0; index < layout.Chunks; index++ {
+			x := width * (index % layout.Columns)
+			y := height * (index / layout.Columns)
+			startTime := utils.GetVTTTime(float64(index) * layout.StepSize)
+			endTime := utils.GetVTTTime(float64(index+1) * layout.StepSize)
 
 			vttLines = append(vttLines, startTime+" --> "+endTime)
 			vttLines = append(vttLines, fmt.Sprintf("%s#xywh=%d,%d,%d,%d", spriteImageName, x, y, width, height))
@@ -7,11 +7,34 @@ import (
 	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 type TranscodeOptions struct {
 	Width  int
 	Height int
+
+	// Video filter applied before scaling, such as the scene video adjustments
+	VideoFilter ffmpeg.VideoFilter
+
+	// Scene specific ffmpeg arguments, appended to the global arguments
+	TranscodeArgs *models.TranscodeArgs
+}
+
+func (g Generator) transcodeExtraArgs(options TranscodeOptions) ([]string, []string) {
+	return ffmpeg.MergeTranscodeArgs(
+		g.FFMpegConfig.GetTranscodeInputArgs(),
+		g.FFMpegConfig.GetTranscodeOutputArgs(),
+		options.TranscodeArgs,
+	)
+}
+
+func (o TranscodeOptions) videoFilter() ffmpeg.VideoFilter {
+	videoFilter := o.VideoFilter
+	if o.Width != 0 && o.Height != 0 {
+		videoFilter = videoFilter.ScaleDimensions(o.Width, o.Height)
+	}
+	return videoFilter
 }
 
 func (g Generator) Transcode(ctx context.Context, input string, hash string, options TranscodeOptions) error {
@@ -66,12 +89,10 @@ func (g Generator) makeTranscode(lockCtx *fsutil.LockContext, hash string, gener
 
 func (g Generator) transcode(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		extraInputArgs, extraOutputArgs := g.transcodeExtraArgs(options)
+
 		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
+		videoArgs = videoArgs.VideoFilter(options.videoFilter())
 
 		videoArgs = append(videoArgs,
 			"-pix_fmt", "yuv420p",
@@ -87,8 +108,8 @@ func (g Generator) transcode(input string, options TranscodeOptions) generateFn
 			VideoArgs:  videoArgs,
 			AudioCodec: ffmpeg.AudioCodecAAC,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+			ExtraInputArgs:  extraInputArgs,
+			ExtraOutputArgs: extraOutputArgs,
 		})
 
 		return g.generate(lockCtx, args)
@@ -97,12 +118,10 @@ func (g Generator) transcode(input string, options TranscodeOptions) generateFn
 
 func (g Generator) transcodeVideo(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		extraInputArgs, extraOutputArgs := g.transcodeExtraArgs(options)
+
 		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
+		videoArgs = videoArgs.VideoFilter(options.videoFilter())
 
 		videoArgs = append(videoArgs,
 			"-pix_fmt", "yuv420p",
@@ -121,8 +140,8 @@ func (g Generator) transcodeVideo(input string, options TranscodeOptions) genera
 			VideoArgs:  videoArgs,
 			AudioArgs:  audioArgs,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+			ExtraInputArgs:  extraInputArgs,
+			ExtraOutputArgs: extraOutputArgs,
 		})
 
 		return g.generate(lockCtx, args)
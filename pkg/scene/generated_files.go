@@ -0,0 +1,76 @@
+package scene
+
+import (
+	"os"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+// GeneratedFiles are the kinds of files generated for a scene hash.
+type GeneratedFiles struct {
+	Previews      bool
+	ImagePreviews bool
+	WallPreviews  bool
+	Sprites       bool
+	Transcodes    bool
+	Heatmaps      bool
+
+	// Marker files are found in the markers folder of the hash.
+	MarkerPreviews      bool
+	MarkerImagePreviews bool
+	MarkerScreenshots   bool
+	MarkerThumbnails    bool
+}
+
+// Any returns true if any kind of file was generated.
+func (g GeneratedFiles) Any() bool {
+	return g.Previews || g.ImagePreviews || g.WallPreviews || g.Sprites || g.Transcodes || g.Heatmaps ||
+		g.MarkerPreviews || g.MarkerImagePreviews || g.MarkerScreenshots || g.MarkerThumbnails
+}
+
+// FindGeneratedFiles returns the kinds of files generated for the scene hash.
+func FindGeneratedFiles(p *paths.Paths, hash string) GeneratedFiles {
+	if hash == "" {
+		return GeneratedFiles{}
+	}
+
+	exists := func(path string) bool {
+		ret, _ := fsutil.FileExists(path)
+		return ret
+	}
+
+	scenePaths := p.Scene
+	ret := GeneratedFiles{
+		Previews:      exists(scenePaths.GetVideoPreviewPath(hash)),
+		ImagePreviews: exists(scenePaths.GetWebpPreviewPath(hash)),
+		WallPreviews:  exists(scenePaths.GetWallPreviewPath(hash)),
+		Sprites: exists(scenePaths.GetSpriteVttFilePath(hash)) ||
+			exists(scenePaths.GetSpriteImageFilePath(hash)) ||
+			exists(scenePaths.GetSpriteWebpImageFilePath(hash)),
+		Transcodes: exists(scenePaths.GetTranscodePath(hash)),
+		Heatmaps:   exists(scenePaths.GetInteractiveHeatmapPath(hash)),
+	}
+
+	entries, err := os.ReadDir(p.SceneMarkers.GetFolderPath(hash))
+	if err != nil {
+		return ret
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case strings.HasSuffix(name, "_thumb.jpg"):
+			ret.MarkerThumbnails = true
+		case strings.HasSuffix(name, ".jpg"):
+			ret.MarkerScreenshots = true
+		case strings.HasSuffix(name, ".webp"):
+			ret.MarkerImagePreviews = true
+		case strings.HasSuffix(name, ".mp4"):
+			ret.MarkerPreviews = true
+		}
+	}
+
+	return ret
+}
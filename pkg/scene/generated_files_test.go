@@ -0,0 +1,42 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindGeneratedFiles(t *testing.T) {
+	const hash = "hash"
+
+	p := paths.NewPaths(t.TempDir(), "")
+
+	assert.False(t, FindGeneratedFiles(&p, hash).Any())
+
+	for _, fn := range []string{
+		p.Scene.GetVideoPreviewPath(hash),
+		p.Scene.GetSpriteVttFilePath(hash),
+		p.SceneMarkers.GetVideoPreviewPath(hash, 10),
+		p.SceneMarkers.GetThumbnailPath(hash, 10),
+		// generated for another hash
+		p.Scene.GetWallPreviewPath("other"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fn, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, GeneratedFiles{
+		Previews:         true,
+		Sprites:          true,
+		MarkerPreviews:   true,
+		MarkerThumbnails: true,
+	}, FindGeneratedFiles(&p, hash))
+	assert.False(t, FindGeneratedFiles(&p, "").Any())
+}
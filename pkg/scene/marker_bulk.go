@@ -0,0 +1,122 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ShiftMarker returns the start and end times of the marker moved by offset
+// seconds. Times are clamped to the start of the scene and, if duration is
+// greater than zero, to the end of the scene.
+func ShiftMarker(m *models.SceneMarker, offset float64, duration float64) (float64, *float64) {
+	clamp := func(v float64) float64 {
+		v = max(v, 0)
+		if duration > 0 {
+			v = min(v, duration)
+		}
+		return v
+	}
+
+	seconds := clamp(m.Seconds + offset)
+
+	var endSeconds *float64
+	if m.EndSeconds != nil {
+		end := clamp(*m.EndSeconds + offset)
+		endSeconds = &end
+	}
+
+	return seconds, endSeconds
+}
+
+// MarkerInRange returns true if the marker overlaps the range from start to
+// end seconds. If end is nil, the range extends to the end of the scene.
+func MarkerInRange(m *models.SceneMarker, start float64, end *float64) bool {
+	markerEnd := m.Seconds
+	if m.EndSeconds != nil {
+		markerEnd = max(*m.EndSeconds, m.Seconds)
+	}
+
+	if markerEnd < start {
+		return false
+	}
+
+	return end == nil || m.Seconds < *end
+}
+
+func sameMarker(a, b *models.SceneMarker) bool {
+	return a.Seconds == b.Seconds && a.PrimaryTagID == b.PrimaryTagID
+}
+
+// MissingMarkers returns the markers in src that do not exist in existing.
+// Markers are considered the same if they have the same start time and
+// primary tag.
+func MissingMarkers(src []*models.SceneMarker, existing []*models.SceneMarker) []*models.SceneMarker {
+	var ret []*models.SceneMarker
+	for _, m := range src {
+		found := false
+		for _, e := range existing {
+			if sameMarker(m, e) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ret = append(ret, m)
+		}
+	}
+
+	return ret
+}
+
+// MarkerCopier provides the methods needed to copy scene markers.
+type MarkerCopier interface {
+	models.SceneMarkerFinder
+	models.SceneMarkerCreator
+	models.TagIDLoader
+	UpdateTags(ctx context.Context, markerID int, tagIDs []int) error
+}
+
+// CopyMarkers copies the markers of the source scene to the destination
+// scene, including their tags. Markers that already exist in the destination
+// scene are not copied. Returns the created markers.
+func CopyMarkers(ctx context.Context, qb MarkerCopier, srcID int, destID int) ([]*models.SceneMarker, error) {
+	src, err := qb.FindBySceneID(ctx, srcID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scene markers: %w", err)
+	}
+
+	existing, err := qb.FindBySceneID(ctx, destID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scene markers: %w", err)
+	}
+
+	var ret []*models.SceneMarker
+	for _, m := range MissingMarkers(src, existing) {
+		tagIDs, err := qb.GetTagIDs(ctx, m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting tags of scene marker %d: %w", m.ID, err)
+		}
+
+		newMarker := models.NewSceneMarker()
+		newMarker.Title = m.Title
+		newMarker.Seconds = m.Seconds
+		newMarker.EndSeconds = m.EndSeconds
+		newMarker.PrimaryTagID = m.PrimaryTagID
+		newMarker.SceneID = destID
+
+		if err := qb.Create(ctx, &newMarker); err != nil {
+			return nil, fmt.Errorf("creating scene marker: %w", err)
+		}
+
+		if err := qb.UpdateTags(ctx, newMarker.ID, tagIDs); err != nil {
+			return nil, fmt.Errorf("updating tags of scene marker %d: %w", newMarker.ID, err)
+		}
+
+		ret = append(ret, &newMarker)
+	}
+
+	return ret, nil
+}
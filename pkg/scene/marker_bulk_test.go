@@ -0,0 +1,76 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShiftMarker(t *testing.T) {
+	end := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		marker     models.SceneMarker
+		offset     float64
+		duration   float64
+		wantSecs   float64
+		wantEndSec *float64
+	}{
+		{"forward", models.SceneMarker{Seconds: 10, EndSeconds: end(20)}, 5, 100, 15, end(25)},
+		{"backward", models.SceneMarker{Seconds: 10}, -4.5, 100, 5.5, nil},
+		{"before start", models.SceneMarker{Seconds: 10, EndSeconds: end(20)}, -15, 100, 0, end(5)},
+		{"after end", models.SceneMarker{Seconds: 90, EndSeconds: end(95)}, 8, 100, 98, end(100)},
+		{"unknown duration", models.SceneMarker{Seconds: 90}, 20, 0, 110, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSecs, gotEnd := ShiftMarker(&tt.marker, tt.offset, tt.duration)
+			assert.Equal(t, tt.wantSecs, gotSecs)
+			assert.Equal(t, tt.wantEndSec, gotEnd)
+		})
+	}
+}
+
+func TestMarkerInRange(t *testing.T) {
+	end := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name   string
+		marker models.SceneMarker
+		start  float64
+		end    *float64
+		want   bool
+	}{
+		{"inside", models.SceneMarker{Seconds: 20}, 10, end(30), true},
+		{"before start", models.SceneMarker{Seconds: 5}, 10, end(30), false},
+		{"ends after start", models.SceneMarker{Seconds: 5, EndSeconds: end(15)}, 10, end(30), true},
+		{"ends before start", models.SceneMarker{Seconds: 5, EndSeconds: end(8)}, 10, end(30), false},
+		{"at end", models.SceneMarker{Seconds: 30}, 10, end(30), false},
+		{"no end", models.SceneMarker{Seconds: 500}, 10, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MarkerInRange(&tt.marker, tt.start, tt.end))
+		})
+	}
+}
+
+func TestMissingMarkers(t *testing.T) {
+	src := []*models.SceneMarker{
+		{ID: 1, Seconds: 10, PrimaryTagID: 1},
+		{ID: 2, Seconds: 20, PrimaryTagID: 1},
+		{ID: 3, Seconds: 20, PrimaryTagID: 2},
+	}
+	existing := []*models.SceneMarker{
+		{ID: 4, Seconds: 10, PrimaryTagID: 1},
+		{ID: 5, Seconds: 20, PrimaryTagID: 3},
+	}
+
+	assert.Equal(t, []*models.SceneMarker{src[1], src[2]}, MissingMarkers(src, existing))
+	assert.Equal(t, src, MissingMarkers(src, nil))
+	assert.Empty(t, MissingMarkers(src, src))
+}
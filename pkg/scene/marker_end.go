@@ -0,0 +1,107 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// defaultSceneChangeWindow is how far ahead of the start of a marker scene
+// changes are looked for, in seconds, when nothing else bounds its end.
+const defaultSceneChangeWindow = 300
+
+// SceneChangeFinder finds scene changes in video files.
+type SceneChangeFinder interface {
+	NextSceneChange(ctx context.Context, path string, start float64, window float64) (*float64, error)
+}
+
+// markerEndLimit returns the soonest of the start of the next marker after
+// seconds, the end of the scene if duration is greater than zero, and the
+// maximum duration after seconds. Returns nil if none of them apply.
+func markerEndLimit(settings models.MarkerEndSettings, seconds float64, others []*models.SceneMarker, duration float64) *float64 {
+	var ret *float64
+	limit := func(v float64) {
+		if ret == nil || v < *ret {
+			ret = &v
+		}
+	}
+
+	for _, o := range others {
+		if o.Seconds > seconds {
+			limit(o.Seconds)
+		}
+	}
+
+	if duration > 0 {
+		limit(duration)
+	}
+
+	if settings.MaxDuration != nil && *settings.MaxDuration > 0 {
+		limit(seconds + *settings.MaxDuration)
+	}
+
+	return ret
+}
+
+// MarkerEnd returns the end of the marker m, which is created without an
+// end, or nil if it should be left without one. others are the existing
+// markers of the scene and f is its primary file, which may be nil.
+//
+// Markers whose primary tag has a default duration end that long after
+// their start. Otherwise the end is set by the AutoEnd heuristic of the
+// settings. Scene changes are found using finder, and if there is none
+// before the next marker, the marker ends as with MarkerAutoEndNextMarker.
+// Ends are never later than the end of the scene.
+func MarkerEnd(ctx context.Context, settings models.MarkerEndSettings, m *models.SceneMarker, others []*models.SceneMarker, f *models.VideoFile, finder SceneChangeFinder) (*float64, error) {
+	var duration float64
+	if f != nil {
+		duration = f.Duration
+	}
+
+	if d := settings.TagDuration(m.PrimaryTagID); d != nil {
+		end := m.Seconds + *d
+		if duration > 0 {
+			end = min(end, duration)
+		}
+		return endAfter(m.Seconds, &end), nil
+	}
+
+	switch settings.AutoEnd {
+	case models.MarkerAutoEndNextMarker:
+		return endAfter(m.Seconds, markerEndLimit(settings, m.Seconds, others, duration)), nil
+	case models.MarkerAutoEndSceneChange:
+		limit := markerEndLimit(settings, m.Seconds, others, duration)
+		if f == nil || finder == nil {
+			return endAfter(m.Seconds, limit), nil
+		}
+
+		window := float64(defaultSceneChangeWindow)
+		if limit != nil {
+			window = *limit - m.Seconds
+		}
+
+		if window > 0 {
+			change, err := finder.NextSceneChange(ctx, f.Path, m.Seconds, window)
+			if err != nil {
+				return nil, fmt.Errorf("finding scene change after %f: %w", m.Seconds, err)
+			}
+
+			if change != nil && (limit == nil || *change < *limit) {
+				limit = change
+			}
+		}
+
+		return endAfter(m.Seconds, limit), nil
+	}
+
+	return nil, nil
+}
+
+// endAfter returns end if it is after seconds, otherwise nil.
+func endAfter(seconds float64, end *float64) *float64 {
+	if end == nil || *end <= seconds {
+		return nil
+	}
+	return end
+}
@@ -0,0 +1,67 @@
+package scene
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type sceneChangeFinderFunc func(start float64, window float64) *float64
+
+func (f sceneChangeFinderFunc) NextSceneChange(ctx context.Context, path string, start float64, window float64) (*float64, error) {
+	return f(start, window), nil
+}
+
+func TestMarkerEnd(t *testing.T) {
+	end := func(v float64) *float64 { return &v }
+
+	others := []*models.SceneMarker{
+		{Seconds: 10},
+		{Seconds: 60},
+	}
+	file := &models.VideoFile{BaseFile: &models.BaseFile{Path: "scene.mp4"}, Duration: 100}
+
+	changeAt := func(v float64) SceneChangeFinder {
+		return sceneChangeFinderFunc(func(start float64, window float64) *float64 {
+			if v > start && v < start+window {
+				return &v
+			}
+			return nil
+		})
+	}
+
+	tagDurations := []*models.MarkerTagDuration{{TagID: "1", Duration: 30}}
+
+	tests := []struct {
+		name     string
+		settings models.MarkerEndSettings
+		marker   models.SceneMarker
+		file     *models.VideoFile
+		finder   SceneChangeFinder
+		want     *float64
+	}{
+		{"none", models.MarkerEndSettings{}, models.SceneMarker{Seconds: 20}, file, nil, nil},
+		{"tag duration", models.MarkerEndSettings{TagDurations: tagDurations}, models.SceneMarker{Seconds: 20, PrimaryTagID: 1}, file, nil, end(50)},
+		{"tag duration past end", models.MarkerEndSettings{TagDurations: tagDurations}, models.SceneMarker{Seconds: 90, PrimaryTagID: 1}, file, nil, end(100)},
+		{"other tag", models.MarkerEndSettings{TagDurations: tagDurations}, models.SceneMarker{Seconds: 20, PrimaryTagID: 2}, file, nil, nil},
+		{"next marker", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndNextMarker}, models.SceneMarker{Seconds: 20}, file, nil, end(60)},
+		{"end of scene", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndNextMarker}, models.SceneMarker{Seconds: 70}, file, nil, end(100)},
+		{"max duration", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndNextMarker, MaxDuration: end(15)}, models.SceneMarker{Seconds: 20}, file, nil, end(35)},
+		{"unbounded", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndNextMarker}, models.SceneMarker{Seconds: 70}, nil, nil, nil},
+		{"at end of scene", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndNextMarker}, models.SceneMarker{Seconds: 100}, file, nil, nil},
+		{"scene change", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndSceneChange}, models.SceneMarker{Seconds: 20}, file, changeAt(42), end(42)},
+		{"scene change after next marker", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndSceneChange}, models.SceneMarker{Seconds: 20}, file, changeAt(80), end(60)},
+		{"no scene change", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndSceneChange}, models.SceneMarker{Seconds: 70}, file, changeAt(5), end(100)},
+		{"scene change without file", models.MarkerEndSettings{AutoEnd: models.MarkerAutoEndSceneChange}, models.SceneMarker{Seconds: 20}, nil, changeAt(42), end(60)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarkerEnd(context.Background(), tt.settings, &tt.marker, others, tt.file, tt.finder)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
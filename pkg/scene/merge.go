@@ -20,6 +20,7 @@ type MergeOptions struct {
 	ScenePartial       models.ScenePartial
 	IncludePlayHistory bool
 	IncludeOHistory    bool
+	IncludeOMGHistory  bool
 }
 
 func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *FileDeleter, options MergeOptions) error {
@@ -57,6 +58,10 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 		if err := s.mergeSceneMarkers(ctx, dest, src); err != nil {
 			return err
 		}
+
+		if err := s.mergeExtras(ctx, dest, src); err != nil {
+			return err
+		}
 	}
 
 	// move files to destination scene
@@ -116,6 +121,25 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 		}
 	}
 
+	// merge omg history
+	if options.IncludeOMGHistory {
+		var allDates []time.Time
+		for _, src := range sources {
+			thisDates, err := s.Repository.GetOMGDates(ctx, src.ID)
+			if err != nil {
+				return fmt.Errorf("getting omg dates for scene %d: %w", src.ID, err)
+			}
+
+			allDates = append(allDates, thisDates...)
+		}
+
+		if len(allDates) > 0 {
+			if _, err := s.Repository.AddOMG(ctx, destinationID, allDates); err != nil {
+				return fmt.Errorf("adding omg dates to scene %d: %w", destinationID, err)
+			}
+		}
+	}
+
 	// delete old scenes
 	for _, src := range sources {
 		const deleteGenerated = true
@@ -24,6 +24,10 @@ func MigrateHash(p *paths.Paths, oldHash string, newHash string) {
 	newPath = scenePaths.GetWebpPreviewPath(newHash)
 	migrateSceneFiles(oldPath, newPath)
 
+	oldPath = scenePaths.GetWallPreviewPath(oldHash)
+	newPath = scenePaths.GetWallPreviewPath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+
 	oldPath = scenePaths.GetTranscodePath(oldHash)
 	newPath = scenePaths.GetTranscodePath(newHash)
 	migrateSceneFiles(oldPath, newPath)
@@ -37,6 +41,11 @@ func MigrateHash(p *paths.Paths, oldHash string, newHash string) {
 	migrateSceneFiles(oldPath, newPath)
 	migrateVttFile(newVttPath, oldPath, newPath)
 
+	oldPath = scenePaths.GetSpriteWebpImageFilePath(oldHash)
+	newPath = scenePaths.GetSpriteWebpImageFilePath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+	migrateVttFile(newVttPath, oldPath, newPath)
+
 	oldPath = scenePaths.GetInteractiveHeatmapPath(oldHash)
 	newPath = scenePaths.GetInteractiveHeatmapPath(newHash)
 	migrateSceneFiles(oldPath, newPath)
@@ -0,0 +1,174 @@
+package scene
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// MinReencodeSavings is the fraction of the size of a file which must be
+// saved for it to be re-encoded. Files saving less are not worth the loss
+// of quality.
+const MinReencodeSavings = 0.1
+
+// ReencodeSample is a segment of a video file re-encoded to measure the
+// bitrate and speed of the target encoding.
+type ReencodeSample struct {
+	// Duration is the length of the segment in seconds.
+	Duration float64
+	// Size is the size of the encoded video stream in bytes.
+	Size int64
+	// Elapsed is the time taken to encode the segment.
+	Elapsed time.Duration
+}
+
+// ReencodeCandidate is the primary video file of a scene and the samples
+// encoded from it, if any.
+type ReencodeCandidate struct {
+	SceneID int
+	File    *models.VideoFile
+	Samples []ReencodeSample
+}
+
+// ReencodeEstimate is the projected result of re-encoding a video file.
+type ReencodeEstimate struct {
+	ID            int
+	SceneID       int
+	FileID        models.FileID
+	Path          string
+	SourceSize    int64
+	EstimatedSize int64
+	// Duration is the duration of the video in seconds.
+	Duration float64
+	// EstimatedTime is the time projected to re-encode the file.
+	EstimatedTime time.Duration
+	// Sampled is true if the estimate is from sample encodes rather than
+	// the bitrate heuristic.
+	Sampled bool
+}
+
+// Savings returns the number of bytes projected to be saved.
+func (e ReencodeEstimate) Savings() int64 {
+	return e.SourceSize - e.EstimatedSize
+}
+
+// ReencodePlan lists the files projected to be smaller once re-encoded to
+// the target.
+type ReencodePlan struct {
+	GeneratedAt time.Time
+	Target      ffmpeg.ReencodeTarget
+	Estimates   []ReencodeEstimate
+	// Skipped is the number of files already in the target encoding or
+	// projected to save too little.
+	Skipped int
+
+	SourceSize    int64
+	EstimatedSize int64
+	EstimatedTime time.Duration
+}
+
+// Savings returns the total number of bytes projected to be saved.
+func (p ReencodePlan) Savings() int64 {
+	return p.SourceSize - p.EstimatedSize
+}
+
+// EstimateReencode estimates the size of the file of the candidate and the
+// time taken once re-encoded to the target. The bitrate and speed are
+// measured from the samples of the candidate if it has any, otherwise they
+// are estimated from the dimensions and frame rate of the file.
+func EstimateReencode(target ffmpeg.ReencodeTarget, c ReencodeCandidate) ReencodeEstimate {
+	f := c.File
+	duration := f.DurationFinite()
+	frameRate := f.FrameRateFinite()
+
+	videoBitrate := target.EstimateVideoBitrate(f.Width, f.Height, frameRate)
+	speed := target.EstimateEncodeSpeed(f.Width, f.Height, frameRate)
+
+	var sampleDuration float64
+	var sampleSize int64
+	var sampleElapsed time.Duration
+	for _, s := range c.Samples {
+		sampleDuration += s.Duration
+		sampleSize += s.Size
+		sampleElapsed += s.Elapsed
+	}
+
+	sampled := sampleDuration > 0 && sampleSize > 0
+	if sampled {
+		videoBitrate = float64(sampleSize*8) / sampleDuration
+		if sampleElapsed > 0 {
+			speed = sampleDuration / sampleElapsed.Seconds()
+		}
+	}
+
+	bitrate := videoBitrate
+	if f.AudioCodec != "" {
+		bitrate += ffmpeg.ReencodeAudioBitrate
+	}
+
+	ret := ReencodeEstimate{
+		SceneID:       c.SceneID,
+		FileID:        f.ID,
+		Path:          f.Path,
+		SourceSize:    f.Size,
+		EstimatedSize: int64(bitrate * duration / 8),
+		Duration:      duration,
+		Sampled:       sampled,
+	}
+
+	if speed > 0 {
+		ret.EstimatedTime = time.Duration(duration / speed * float64(time.Second))
+	}
+
+	return ret
+}
+
+// worthReencoding returns true if the file of the candidate is not already
+// in the target encoding and the estimate saves at least
+// MinReencodeSavings of its size.
+func worthReencoding(target ffmpeg.ReencodeTarget, c ReencodeCandidate, e ReencodeEstimate) bool {
+	if c.File.DurationFinite() <= 0 || c.File.Size <= 0 {
+		return false
+	}
+
+	if target.Unchanged(c.File.VideoCodec, c.File.Height) {
+		return false
+	}
+
+	return float64(e.Savings()) >= float64(e.SourceSize)*MinReencodeSavings
+}
+
+// NewReencodePlan estimates the re-encoding of each candidate to the
+// target, and returns the plan of the files worth re-encoding, ordered by
+// their savings.
+func NewReencodePlan(target ffmpeg.ReencodeTarget, candidates []ReencodeCandidate, now time.Time) *ReencodePlan {
+	ret := &ReencodePlan{
+		GeneratedAt: now,
+		Target:      target,
+	}
+
+	for _, c := range candidates {
+		e := EstimateReencode(target, c)
+		if !worthReencoding(target, c, e) {
+			ret.Skipped++
+			continue
+		}
+
+		ret.Estimates = append(ret.Estimates, e)
+		ret.SourceSize += e.SourceSize
+		ret.EstimatedSize += e.EstimatedSize
+		ret.EstimatedTime += e.EstimatedTime
+	}
+
+	sort.SliceStable(ret.Estimates, func(i, j int) bool {
+		return ret.Estimates[i].Savings() > ret.Estimates[j].Savings()
+	})
+
+	for i := range ret.Estimates {
+		ret.Estimates[i].ID = i + 1
+	}
+
+	return ret
+}
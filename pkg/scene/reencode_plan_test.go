@@ -0,0 +1,88 @@
+package scene
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateReencode(t *testing.T) {
+	target := ffmpeg.ReencodeTarget{Codec: ffmpeg.ReencodeCodecHEVC, CRF: 28}
+
+	f := &models.VideoFile{
+		BaseFile:   &models.BaseFile{ID: 2, Path: "a.mp4", Size: 1 << 30},
+		Width:      1920,
+		Height:     1080,
+		Duration:   600,
+		FrameRate:  30,
+		VideoCodec: ffmpeg.H264,
+		AudioCodec: string(ffmpeg.Aac),
+	}
+
+	heuristic := EstimateReencode(target, ReencodeCandidate{SceneID: 1, File: f})
+	assert.False(t, heuristic.Sampled)
+	assert.Equal(t, 1, heuristic.SceneID)
+	assert.Equal(t, models.FileID(2), heuristic.FileID)
+	wantBitrate := target.EstimateVideoBitrate(1920, 1080, 30) + ffmpeg.ReencodeAudioBitrate
+	assert.InDelta(t, wantBitrate*600/8, heuristic.EstimatedSize, 1)
+	assert.Greater(t, heuristic.EstimatedTime, time.Duration(0))
+
+	// 2 Mbps encoded at twice real time
+	sampled := EstimateReencode(target, ReencodeCandidate{
+		SceneID: 1,
+		File:    f,
+		Samples: []ReencodeSample{
+			{Duration: 5, Size: 1250000, Elapsed: 2 * time.Second},
+			{Duration: 5, Size: 1250000, Elapsed: 3 * time.Second},
+		},
+	})
+	assert.True(t, sampled.Sampled)
+	assert.Equal(t, int64((2e6+ffmpeg.ReencodeAudioBitrate)*600/8), sampled.EstimatedSize)
+	assert.Equal(t, 300*time.Second, sampled.EstimatedTime)
+}
+
+func TestNewReencodePlan(t *testing.T) {
+	target := ffmpeg.ReencodeTarget{Codec: ffmpeg.ReencodeCodecHEVC, CRF: 28, MaxHeight: 1080}
+
+	file := func(id models.FileID, size int64, height int, codec string) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile:   &models.BaseFile{ID: id, Size: size},
+			Width:      height * 16 / 9,
+			Height:     height,
+			Duration:   600,
+			FrameRate:  30,
+			VideoCodec: codec,
+		}
+	}
+
+	candidates := []ReencodeCandidate{
+		// large h264, worth re-encoding
+		{SceneID: 1, File: file(1, 2<<30, 1080, ffmpeg.H264)},
+		// 4k hevc, scaled to 1080p
+		{SceneID: 2, File: file(2, 4<<30, 2160, ffmpeg.Hevc)},
+		// already hevc within the cap
+		{SceneID: 3, File: file(3, 2<<30, 1080, ffmpeg.Hevc)},
+		// already small
+		{SceneID: 4, File: file(4, 100<<20, 1080, ffmpeg.H264)},
+	}
+
+	now := time.Now()
+	plan := NewReencodePlan(target, candidates, now)
+
+	assert.Equal(t, now, plan.GeneratedAt)
+	assert.Equal(t, 2, plan.Skipped)
+	if assert.Len(t, plan.Estimates, 2) {
+		assert.Equal(t, 2, plan.Estimates[0].SceneID)
+		assert.Equal(t, 1, plan.Estimates[0].ID)
+		assert.Equal(t, 1, plan.Estimates[1].SceneID)
+		assert.Equal(t, 2, plan.Estimates[1].ID)
+	}
+
+	assert.Equal(t, int64(6<<30), plan.SourceSize)
+	assert.Equal(t, plan.Estimates[0].EstimatedSize+plan.Estimates[1].EstimatedSize, plan.EstimatedSize)
+	assert.Equal(t, plan.Estimates[0].Savings()+plan.Estimates[1].Savings(), plan.Savings())
+	assert.Equal(t, plan.Estimates[0].EstimatedTime+plan.Estimates[1].EstimatedTime, plan.EstimatedTime)
+}
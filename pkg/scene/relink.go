@@ -0,0 +1,342 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// relinkDurationTolerance is the maximum difference in seconds between
+// durations for files to be considered a size and duration match.
+const relinkDurationTolerance = 1.0
+
+type RelinkMatchType string
+
+const (
+	RelinkMatchTypeOshash       RelinkMatchType = "OSHASH"
+	RelinkMatchTypePhash        RelinkMatchType = "PHASH"
+	RelinkMatchTypeSizeDuration RelinkMatchType = "SIZE_DURATION"
+)
+
+type RelinkOptions struct {
+	// MatchPhash allows files to be matched by perceptual hash. Only exact
+	// phash matches are considered; similar files are not matched.
+	MatchPhash bool
+	// MatchSizeDuration allows files to be matched by file size and duration
+	MatchSizeDuration bool
+}
+
+// RelinkCandidate is an existing file that may replace the missing files of a scene.
+type RelinkCandidate struct {
+	File      *models.VideoFile
+	MatchType RelinkMatchType
+}
+
+// RelinkMatch is a scene whose files are all missing, along with the
+// candidate files that it may be relinked to.
+type RelinkMatch struct {
+	Scene        *models.Scene
+	MissingFiles []*models.VideoFile
+	Candidates   []RelinkCandidate
+	// Conflicting is true if a candidate file also matches another scene
+	Conflicting bool
+}
+
+// Ambiguous returns true if the match cannot be relinked automatically.
+func (m RelinkMatch) Ambiguous() bool {
+	return len(m.Candidates) != 1 || m.Conflicting
+}
+
+// missingFiles returns the files of the scene that no longer exist on disk.
+// Files within zip files are never considered missing.
+// The scene's files must be loaded.
+func missingFiles(s *models.Scene) []*models.VideoFile {
+	var ret []*models.VideoFile
+	for _, f := range s.Files.List() {
+		if f.ZipFileID != nil {
+			continue
+		}
+
+		if exists, _ := fsutil.FileExists(f.Path); !exists {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+// FindRelinkMatch returns a match for the scene if all of its files are
+// missing. Returns nil if the scene has any existing files.
+// The scene's files must be loaded.
+//
+// Candidate files are matched by oshash first. If no files match by oshash,
+// then phash and size and duration are tried in turn, if enabled in options.
+func (s *Service) FindRelinkMatch(ctx context.Context, scene *models.Scene, options RelinkOptions) (*RelinkMatch, error) {
+	files := scene.Files.List()
+	missing := missingFiles(scene)
+	if len(files) == 0 || len(missing) != len(files) {
+		return nil, nil
+	}
+
+	ret := &RelinkMatch{
+		Scene:        scene,
+		MissingFiles: missing,
+	}
+
+	matchers := []struct {
+		matchType RelinkMatchType
+		enabled   bool
+		find      func(f *models.VideoFile) ([]models.File, error)
+	}{
+		{RelinkMatchTypeOshash, true, func(f *models.VideoFile) ([]models.File, error) {
+			fp := f.Fingerprints.For(models.FingerprintTypeOshash)
+			if fp == nil {
+				return nil, nil
+			}
+			return s.File.FindByFingerprint(ctx, *fp)
+		}},
+		{RelinkMatchTypePhash, options.MatchPhash, func(f *models.VideoFile) ([]models.File, error) {
+			fp := f.Fingerprints.For(models.FingerprintTypePhash)
+			if fp == nil {
+				return nil, nil
+			}
+			return s.File.FindByFingerprint(ctx, *fp)
+		}},
+		{RelinkMatchTypeSizeDuration, options.MatchSizeDuration, func(f *models.VideoFile) ([]models.File, error) {
+			if f.Size <= 0 || f.Duration <= 0 {
+				return nil, nil
+			}
+			return s.File.FindVideoFilesBySizeAndDuration(ctx, f.Size, f.Duration, relinkDurationTolerance)
+		}},
+	}
+
+	for _, m := range matchers {
+		if !m.enabled {
+			continue
+		}
+
+		seen := make(map[models.FileID]bool)
+		for _, mf := range missing {
+			found, err := m.find(mf)
+			if err != nil {
+				return nil, fmt.Errorf("finding files matching %s: %w", mf.Path, err)
+			}
+
+			for _, f := range found {
+				vf, ok := f.(*models.VideoFile)
+				if !ok || seen[vf.ID] {
+					continue
+				}
+				seen[vf.ID] = true
+
+				ok, err := s.isRelinkCandidate(ctx, scene, vf)
+				if err != nil {
+					return nil, err
+				}
+
+				if ok {
+					ret.Candidates = append(ret.Candidates, RelinkCandidate{
+						File:      vf,
+						MatchType: m.matchType,
+					})
+				}
+			}
+		}
+
+		if len(ret.Candidates) > 0 {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// isRelinkCandidate returns true if the file may be relinked to the scene.
+func (s *Service) isRelinkCandidate(ctx context.Context, scene *models.Scene, f *models.VideoFile) (bool, error) {
+	reason, err := s.relinkIneligibleReason(ctx, scene, f)
+	if err != nil {
+		return false, err
+	}
+
+	return reason == "", nil
+}
+
+// relinkIneligibleReason returns the reason the file cannot be relinked to
+// the scene, or an empty string if it can. A file cannot be relinked if it
+// does not exist on disk, is already part of the scene, or is part of
+// another scene that has other files or user-edited metadata.
+func (s *Service) relinkIneligibleReason(ctx context.Context, scene *models.Scene, f *models.VideoFile) (string, error) {
+	if f.ZipFileID == nil {
+		if exists, _ := fsutil.FileExists(f.Path); !exists {
+			return "file does not exist", nil
+		}
+	}
+
+	others, err := s.Repository.FindByFileID(ctx, f.ID)
+	if err != nil {
+		return "", fmt.Errorf("finding scenes for file %s: %w", f.Path, err)
+	}
+
+	for _, o := range others {
+		if o.ID == scene.ID {
+			return "file is already part of the scene", nil
+		}
+
+		if err := o.LoadFiles(ctx, s.Repository); err != nil {
+			return "", err
+		}
+
+		if len(o.Files.List()) > 1 {
+			return fmt.Sprintf("file belongs to scene %d which has other files", o.ID), nil
+		}
+
+		edited, err := s.hasUserMetadata(ctx, o)
+		if err != nil {
+			return "", err
+		}
+
+		if edited {
+			return fmt.Sprintf("file belongs to scene %d which has its own metadata - merge the scenes instead", o.ID), nil
+		}
+	}
+
+	return "", nil
+}
+
+// hasUserMetadata returns true if the scene has any metadata that would be
+// lost if the scene were destroyed. Scene markers are not considered, since
+// they are moved to the relinked scene.
+func (s *Service) hasUserMetadata(ctx context.Context, scene *models.Scene) (bool, error) {
+	if scene.Title != "" || scene.Code != "" || scene.Details != "" || scene.Director != "" ||
+		scene.Date != nil || scene.ShootDate != nil || scene.Rating != nil ||
+		scene.Organized || scene.StudioID != nil {
+		return true, nil
+	}
+
+	r := s.Repository
+	if err := scene.LoadURLs(ctx, r); err != nil {
+		return false, err
+	}
+	if err := scene.LoadGalleryIDs(ctx, r); err != nil {
+		return false, err
+	}
+	if err := scene.LoadPerformerIDs(ctx, r); err != nil {
+		return false, err
+	}
+	if err := scene.LoadTagIDs(ctx, r); err != nil {
+		return false, err
+	}
+	if err := scene.LoadGroups(ctx, r); err != nil {
+		return false, err
+	}
+	if err := scene.LoadStashIDs(ctx, r); err != nil {
+		return false, err
+	}
+
+	if len(scene.URLs.List()) > 0 || len(scene.GalleryIDs.List()) > 0 ||
+		len(scene.PerformerIDs.List()) > 0 || len(scene.TagIDs.List()) > 0 ||
+		len(scene.Groups.List()) > 0 || len(scene.StashIDs.List()) > 0 {
+		return true, nil
+	}
+
+	views, err := r.CountViews(ctx, scene.ID)
+	if err != nil {
+		return false, fmt.Errorf("counting views: %w", err)
+	}
+
+	oCount, err := r.GetOCount(ctx, scene.ID)
+	if err != nil {
+		return false, fmt.Errorf("getting o-count: %w", err)
+	}
+
+	return views > 0 || oCount > 0, nil
+}
+
+// Relink attaches the file to the scene in place of its missing files.
+// Any scene that was created for the file by scanning has its markers moved
+// to the scene and is then destroyed. Relinking is refused if that scene has
+// other files or any user-edited metadata, since it would be lost.
+// The missing files are removed from the database.
+func (s *Service) Relink(ctx context.Context, sceneID int, fileID models.FileID, fileDeleter *FileDeleter) error {
+	dest, err := s.Repository.Find(ctx, sceneID)
+	if err != nil {
+		return fmt.Errorf("finding scene %d: %w", sceneID, err)
+	}
+
+	if dest == nil {
+		return fmt.Errorf("scene %d not found", sceneID)
+	}
+
+	if err := dest.LoadFiles(ctx, s.Repository); err != nil {
+		return err
+	}
+
+	ff, err := s.File.Find(ctx, fileID)
+	if err != nil {
+		return err
+	}
+
+	if len(ff) == 0 {
+		return fmt.Errorf("file %d not found", fileID)
+	}
+
+	f, ok := ff[0].(*models.VideoFile)
+	if !ok {
+		return fmt.Errorf("%s is not a video file", ff[0].Base().Path)
+	}
+
+	reason, err := s.relinkIneligibleReason(ctx, dest, f)
+	if err != nil {
+		return err
+	}
+
+	if reason != "" {
+		return fmt.Errorf("cannot relink %s: %s", f.Path, reason)
+	}
+
+	missing := missingFiles(dest)
+
+	sources, err := s.Repository.FindByFileID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("finding scenes for file %s: %w", f.Path, err)
+	}
+
+	if err := s.Repository.AssignFiles(ctx, sceneID, []models.FileID{fileID}); err != nil {
+		return fmt.Errorf("assigning file to scene: %w", err)
+	}
+
+	scenePartial := models.NewScenePartial()
+	scenePartial.PrimaryFileID = &fileID
+	dest, err = s.Repository.UpdatePartial(ctx, sceneID, scenePartial)
+	if err != nil {
+		return fmt.Errorf("updating scene: %w", err)
+	}
+
+	for _, src := range sources {
+		if err := s.mergeSceneMarkers(ctx, dest, src); err != nil {
+			return err
+		}
+
+		// generated files belong to the relinked file, so don't delete them
+		const deleteGenerated = false
+		const deleteFile = false
+		if err := s.Destroy(ctx, src, fileDeleter, deleteGenerated, deleteFile); err != nil {
+			return fmt.Errorf("deleting scene %d: %w", src.ID, err)
+		}
+	}
+
+	for _, mf := range missing {
+		logger.Infof("Relinking scene %q from missing file %s to %s", dest.DisplayName(), mf.Path, f.Path)
+
+		const deleteFile = false
+		if err := file.Destroy(ctx, s.File, mf, fileDeleter.Deleter, deleteFile); err != nil {
+			return fmt.Errorf("destroying missing file %s: %w", mf.Path, err)
+		}
+	}
+
+	return nil
+}
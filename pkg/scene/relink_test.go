@@ -0,0 +1,228 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	relinkSceneID = iota + 1
+	relinkOtherSceneID
+)
+
+const (
+	relinkMissingFileID models.FileID = iota + 1
+	relinkExistingFileID
+	relinkOtherFileID
+)
+
+const relinkOshash = "relinkOshash"
+
+func makeRelinkFile(id models.FileID, path string) *models.VideoFile {
+	return &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			ID:   id,
+			Path: path,
+			Fingerprints: models.Fingerprints{
+				{Type: models.FingerprintTypeOshash, Fingerprint: relinkOshash},
+			},
+		},
+	}
+}
+
+// makeRelinkScene returns a scene with all relationships loaded and empty.
+func makeRelinkScene(id int, files ...*models.VideoFile) *models.Scene {
+	return &models.Scene{
+		ID:           id,
+		Files:        models.NewRelatedVideoFiles(files),
+		URLs:         models.NewRelatedStrings([]string{}),
+		GalleryIDs:   models.NewRelatedIDs([]int{}),
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+	}
+}
+
+func writeRelinkFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "existing.mp4")
+	if err := os.WriteFile(path, []byte("video"), 0644); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+
+	return path
+}
+
+func TestService_relinkIneligibleReason(t *testing.T) {
+	existingPath := writeRelinkFile(t)
+	missingPath := filepath.Join(t.TempDir(), "missing.mp4")
+
+	rating := 50
+
+	tests := []struct {
+		name         string
+		file         *models.VideoFile
+		others       []*models.Scene
+		views        int
+		oCount       int
+		wantEligible bool
+	}{
+		{
+			"missing file",
+			makeRelinkFile(relinkExistingFileID, missingPath),
+			nil,
+			0,
+			0,
+			false,
+		},
+		{
+			"unattached file",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			nil,
+			0,
+			0,
+			true,
+		},
+		{
+			"file in same scene",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{makeRelinkScene(relinkSceneID)},
+			0,
+			0,
+			false,
+		},
+		{
+			"scanned scene",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{makeRelinkScene(relinkOtherSceneID, makeRelinkFile(relinkExistingFileID, existingPath))},
+			0,
+			0,
+			true,
+		},
+		{
+			"scene with other files",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{makeRelinkScene(relinkOtherSceneID,
+				makeRelinkFile(relinkExistingFileID, existingPath),
+				makeRelinkFile(relinkOtherFileID, existingPath),
+			)},
+			0,
+			0,
+			false,
+		},
+		{
+			"scene with rating",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{func() *models.Scene {
+				s := makeRelinkScene(relinkOtherSceneID, makeRelinkFile(relinkExistingFileID, existingPath))
+				s.Rating = &rating
+				return s
+			}()},
+			0,
+			0,
+			false,
+		},
+		{
+			"scene with tags",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{func() *models.Scene {
+				s := makeRelinkScene(relinkOtherSceneID, makeRelinkFile(relinkExistingFileID, existingPath))
+				s.TagIDs = models.NewRelatedIDs([]int{1})
+				return s
+			}()},
+			0,
+			0,
+			false,
+		},
+		{
+			"scene with play history",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{makeRelinkScene(relinkOtherSceneID, makeRelinkFile(relinkExistingFileID, existingPath))},
+			1,
+			0,
+			false,
+		},
+		{
+			"scene with o-count",
+			makeRelinkFile(relinkExistingFileID, existingPath),
+			[]*models.Scene{makeRelinkScene(relinkOtherSceneID, makeRelinkFile(relinkExistingFileID, existingPath))},
+			0,
+			1,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			db.Scene.On("FindByFileID", testCtx, tt.file.ID).Return(tt.others, nil)
+			db.Scene.On("CountViews", testCtx, relinkOtherSceneID).Return(tt.views, nil)
+			db.Scene.On("GetOCount", testCtx, relinkOtherSceneID).Return(tt.oCount, nil)
+
+			s := &Service{
+				File:       db.File,
+				Repository: db.Scene,
+			}
+
+			scene := makeRelinkScene(relinkSceneID, makeRelinkFile(relinkMissingFileID, missingPath))
+			reason, err := s.relinkIneligibleReason(testCtx, scene, tt.file)
+			if err != nil {
+				t.Errorf("Service.relinkIneligibleReason() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.wantEligible, reason == "", "reason: %s", reason)
+		})
+	}
+}
+
+func TestService_FindRelinkMatch(t *testing.T) {
+	existingPath := writeRelinkFile(t)
+	missingPath := filepath.Join(t.TempDir(), "missing.mp4")
+
+	existing := makeRelinkFile(relinkExistingFileID, existingPath)
+	missing := makeRelinkFile(relinkMissingFileID, missingPath)
+
+	db := mocks.NewDatabase()
+	db.File.On("FindByFingerprint", testCtx, models.Fingerprint{
+		Type:        models.FingerprintTypeOshash,
+		Fingerprint: relinkOshash,
+	}).Return([]models.File{existing}, nil)
+	db.Scene.On("FindByFileID", testCtx, relinkExistingFileID).Return(nil, nil)
+
+	s := &Service{
+		File:       db.File,
+		Repository: db.Scene,
+	}
+
+	t.Run("missing files", func(t *testing.T) {
+		got, err := s.FindRelinkMatch(testCtx, makeRelinkScene(relinkSceneID, missing), RelinkOptions{})
+		if err != nil {
+			t.Errorf("Service.FindRelinkMatch() error = %v", err)
+			return
+		}
+
+		if !assert.NotNil(t, got) {
+			return
+		}
+
+		assert.Equal(t, []*models.VideoFile{missing}, got.MissingFiles)
+		assert.Equal(t, []RelinkCandidate{{File: existing, MatchType: RelinkMatchTypeOshash}}, got.Candidates)
+		assert.False(t, got.Ambiguous())
+	})
+
+	t.Run("existing files", func(t *testing.T) {
+		got, err := s.FindRelinkMatch(testCtx, makeRelinkScene(relinkSceneID, missing, existing), RelinkOptions{})
+		if err != nil {
+			t.Errorf("Service.FindRelinkMatch() error = %v", err)
+			return
+		}
+
+		assert.Nil(t, got)
+	})
+}
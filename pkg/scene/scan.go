@@ -32,12 +32,20 @@ type ScanCreatorUpdater interface {
 	AddFileID(ctx context.Context, id int, fileID models.FileID) error
 }
 
+// ScanExtraFinder finds the scene extras of scanned files.
+type ScanExtraFinder interface {
+	FindByFileID(ctx context.Context, fileID models.FileID) (*models.SceneExtra, error)
+}
+
 type ScanGenerator interface {
 	Generate(ctx context.Context, s *models.Scene, f *models.VideoFile) error
 }
 
 type ScanHandler struct {
 	CreatorUpdater ScanCreatorUpdater
+	// ExtraFinder is used to skip the files that are scene extras. If nil,
+	// extras are not skipped.
+	ExtraFinder ScanExtraFinder
 
 	ScanGenerator  ScanGenerator
 	CaptionUpdater video.CaptionUpdater
@@ -83,6 +91,17 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		}
 	}
 
+	// extras are not files of scenes, and must not create new scenes
+	if h.ExtraFinder != nil {
+		extra, err := h.ExtraFinder.FindByFileID(ctx, f.Base().ID)
+		if err != nil {
+			return fmt.Errorf("finding scene extra: %w", err)
+		}
+		if extra != nil {
+			return nil
+		}
+	}
+
 	// try to match the file to a scene
 	existing, err := h.CreatorUpdater.FindByFileID(ctx, f.Base().ID)
 	if err != nil {
@@ -17,6 +17,7 @@ type Service struct {
 	Repository           models.SceneReaderWriter
 	MarkerRepository     models.SceneMarkerReaderWriter
 	SimilarityRepository models.SceneSimilarityReaderWriter
+	ExtraRepository      models.SceneExtraReaderWriter
 	PluginCache          *plugin.Cache
 
 	Paths  *paths.Paths
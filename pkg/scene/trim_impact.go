@@ -0,0 +1,143 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// DenseScriptActionRate is the number of funscript actions per second above
+// which a removed range is considered a dense part of the script.
+const DenseScriptActionRate = 1.0
+
+// TrimRange is a range of seconds removed from a video by a trim.
+type TrimRange struct {
+	Start float64
+	End   float64
+}
+
+func (r TrimRange) Duration() float64 {
+	return r.End - r.Start
+}
+
+func (r TrimRange) contains(seconds float64) bool {
+	return seconds >= r.Start && seconds < r.End
+}
+
+// TrimImpact describes the markers and funscript actions affected by
+// trimming a scene.
+type TrimImpact struct {
+	RemovedRanges   []TrimRange
+	RemovedDuration float64
+	// RemovedMarkers are the markers entirely within the removed ranges,
+	// which are deleted by the trim.
+	RemovedMarkers []*models.SceneMarker
+	// CutMarkers are the markers which are kept but partly removed.
+	CutMarkers []*models.SceneMarker
+	// ScriptActions is the number of actions of the funscript, and
+	// RemovedScriptActions the number within the removed ranges.
+	ScriptActions        int
+	RemovedScriptActions int
+	// RemovedScriptCoverage is the percentage of the actions of the
+	// funscript within the removed ranges.
+	RemovedScriptCoverage float64
+	// DenseScriptRemoved is true if a removed range has at least
+	// DenseScriptActionRate actions per second.
+	DenseScriptRemoved bool
+	// Warnings describe the interactive metadata lost by the trim.
+	Warnings []string
+}
+
+// TrimRemovedRanges returns the ranges removed by trimming a video of the
+// duration to the range from start to end seconds. A nil start or end
+// keeps the beginning or end of the video.
+func TrimRemovedRanges(start, end *float64, duration float64) []TrimRange {
+	var ret []TrimRange
+	if start != nil && *start > 0 {
+		ret = append(ret, TrimRange{Start: 0, End: min(*start, duration)})
+	}
+	if end != nil && *end < duration {
+		ret = append(ret, TrimRange{Start: max(*end, 0), End: duration})
+	}
+
+	return ret
+}
+
+// NewTrimImpact returns the impact of trimming a video of the duration to
+// the range from start to end seconds, for the markers of the scene and the
+// times of the actions of its funscript, in seconds.
+func NewTrimImpact(start, end *float64, duration float64, markers []*models.SceneMarker, scriptActions []float64) TrimImpact {
+	ret := TrimImpact{
+		RemovedRanges: TrimRemovedRanges(start, end, duration),
+		ScriptActions: len(scriptActions),
+	}
+
+	for _, r := range ret.RemovedRanges {
+		ret.RemovedDuration += r.Duration()
+	}
+
+	keepStart := 0.0
+	if start != nil {
+		keepStart = *start
+	}
+
+	for _, m := range markers {
+		if !MarkerInRange(m, keepStart, end) {
+			ret.RemovedMarkers = append(ret.RemovedMarkers, m)
+			continue
+		}
+
+		markerEnd := m.Seconds
+		if m.EndSeconds != nil {
+			markerEnd = max(*m.EndSeconds, m.Seconds)
+		}
+		if m.Seconds < keepStart || (end != nil && markerEnd > *end) {
+			ret.CutMarkers = append(ret.CutMarkers, m)
+		}
+	}
+
+	removedByRange := make([]int, len(ret.RemovedRanges))
+	for _, at := range scriptActions {
+		for i, r := range ret.RemovedRanges {
+			if r.contains(at) {
+				removedByRange[i]++
+				ret.RemovedScriptActions++
+				break
+			}
+		}
+	}
+
+	if ret.ScriptActions > 0 {
+		ret.RemovedScriptCoverage = float64(ret.RemovedScriptActions) / float64(ret.ScriptActions) * 100
+	}
+
+	for i, r := range ret.RemovedRanges {
+		if r.Duration() > 0 && float64(removedByRange[i])/r.Duration() >= DenseScriptActionRate {
+			ret.DenseScriptRemoved = true
+		}
+	}
+
+	ret.Warnings = ret.warnings()
+	return ret
+}
+
+func (i TrimImpact) warnings() []string {
+	var ret []string
+	if n := len(i.RemovedMarkers); n > 0 {
+		ret = append(ret, fmt.Sprintf("%d markers are in the removed ranges and will be deleted", n))
+	}
+	if n := len(i.CutMarkers); n > 0 {
+		ret = append(ret, fmt.Sprintf("%d markers are partly in the removed ranges and will be shortened", n))
+	}
+	if i.RemovedScriptActions > 0 {
+		ret = append(ret, fmt.Sprintf("%.1f%% of the funscript actions are in the removed ranges", i.RemovedScriptCoverage))
+	}
+	if i.DenseScriptRemoved {
+		ret = append(ret, "a removed range contains a dense part of the funscript")
+	}
+	if i.ScriptActions > 0 && len(i.RemovedRanges) > 0 {
+		ret = append(ret, "the funscript is not trimmed with the video and will be out of sync")
+	}
+
+	return ret
+}
@@ -0,0 +1,52 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimRemovedRanges(t *testing.T) {
+	seconds := func(v float64) *float64 { return &v }
+
+	assert.Empty(t, TrimRemovedRanges(nil, nil, 100))
+	assert.Equal(t, []TrimRange{{0, 10}}, TrimRemovedRanges(seconds(10), nil, 100))
+	assert.Equal(t, []TrimRange{{90, 100}}, TrimRemovedRanges(nil, seconds(90), 100))
+	assert.Equal(t, []TrimRange{{0, 10}, {90, 100}}, TrimRemovedRanges(seconds(10), seconds(90), 100))
+	assert.Empty(t, TrimRemovedRanges(seconds(0), seconds(100), 100))
+}
+
+func TestNewTrimImpact(t *testing.T) {
+	seconds := func(v float64) *float64 { return &v }
+
+	removed := &models.SceneMarker{ID: 1, Seconds: 2, EndSeconds: seconds(5)}
+	cut := &models.SceneMarker{ID: 2, Seconds: 85, EndSeconds: seconds(95)}
+	kept := &models.SceneMarker{ID: 3, Seconds: 50}
+	markers := []*models.SceneMarker{removed, cut, kept}
+
+	// 20 actions in the first 10 seconds, 20 in the kept range
+	var actions []float64
+	for i := 0; i < 20; i++ {
+		actions = append(actions, float64(i)*0.5)
+		actions = append(actions, 20+float64(i))
+	}
+
+	got := NewTrimImpact(seconds(10), seconds(90), 100, markers, actions)
+
+	assert.Equal(t, 20.0, got.RemovedDuration)
+	assert.Equal(t, []*models.SceneMarker{removed}, got.RemovedMarkers)
+	assert.Equal(t, []*models.SceneMarker{cut}, got.CutMarkers)
+	assert.Equal(t, 40, got.ScriptActions)
+	assert.Equal(t, 20, got.RemovedScriptActions)
+	assert.Equal(t, 50.0, got.RemovedScriptCoverage)
+	assert.True(t, got.DenseScriptRemoved)
+	assert.Len(t, got.Warnings, 5)
+
+	got = NewTrimImpact(nil, seconds(90), 100, []*models.SceneMarker{kept}, nil)
+	assert.Empty(t, got.RemovedMarkers)
+	assert.Empty(t, got.CutMarkers)
+	assert.Zero(t, got.RemovedScriptCoverage)
+	assert.False(t, got.DenseScriptRemoved)
+	assert.Empty(t, got.Warnings)
+}
@@ -124,5 +124,10 @@ func (s *Service) AssignFile(ctx context.Context, sceneID int, fileID models.Fil
 		return errors.New("cannot reassign primary file")
 	}
 
+	// extras assigned to a scene become files of the scene
+	if err := s.ExtraRepository.Destroy(ctx, fileID); err != nil {
+		return err
+	}
+
 	return s.Repository.AssignFiles(ctx, sceneID, []models.FileID{fileID})
 }
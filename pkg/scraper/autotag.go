@@ -109,6 +109,14 @@ func (s autotagScraper) viaScene(ctx context.Context, _client *http.Client, scen
 			return fmt.Errorf("autotag scraper viaScene: %w", err)
 		}
 
+		// fall back to the on-screen text recognized in the scene
+		if studio == nil && scene.OCRText != "" {
+			studio, err = autotagMatchStudio(ctx, scene.OCRText, s.studioReader, trimExt)
+			if err != nil {
+				return fmt.Errorf("autotag scraper viaScene: %w", err)
+			}
+		}
+
 		tags, err := autotagMatchTags(ctx, path, s.tagReader, trimExt)
 		if err != nil {
 			return fmt.Errorf("autotag scraper viaScene: %w", err)
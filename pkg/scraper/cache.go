@@ -40,6 +40,9 @@ type GlobalConfig interface {
 	GetScraperUserAgent() string
 	GetScrapersPath() string
 	GetScraperCDPPath() string
+	GetScraperCDPMaxSessions() int
+	GetScraperCDPTimeout() int
+	GetScraperCDPMaxMemory() int
 	GetScraperCertCheck() bool
 	GetPythonPath() string
 	GetProxy() string
@@ -251,6 +254,28 @@ func (c Cache) GetScraper(scraperID string) *Scraper {
 	return nil
 }
 
+// URLScrapers returns the ids of the scrapers that can scrape the given
+// content type from the url, sorted by scraper name.
+func (c Cache) URLScrapers(url string, ty ScrapeContentType) []string {
+	var specs []Scraper
+	for _, s := range c.scrapers {
+		if _, ok := s.(urlScraper); ok && s.supportsURL(url, ty) {
+			specs = append(specs, s.spec())
+		}
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return strings.ToLower(specs[i].Name) < strings.ToLower(specs[j].Name)
+	})
+
+	ret := make([]string, len(specs))
+	for i, s := range specs {
+		ret[i] = s.ID
+	}
+
+	return ret
+}
+
 func (c Cache) findScraper(scraperID string) scraper {
 	s, ok := c.scrapers[scraperID]
 	if ok {
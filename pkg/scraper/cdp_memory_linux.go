@@ -0,0 +1,77 @@
+//go:build linux
+// +build linux
+
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// processTreeMemory returns the resident memory in bytes of the process with
+// the given pid and all of its descendants. Chrome runs each renderer in a
+// separate process, so the memory of the browser process alone is not
+// representative.
+func processTreeMemory(pid int) (int64, bool) {
+	stats, err := filepath.Glob("/proc/[0-9]*/stat")
+	if err != nil {
+		return 0, false
+	}
+
+	children := make(map[int][]int)
+	for _, stat := range stats {
+		data, err := os.ReadFile(stat)
+		if err != nil {
+			// the process has exited
+			continue
+		}
+
+		// the command name may contain spaces, so the fields are after the
+		// last closing parenthesis: state, then ppid
+		s := string(data)
+		fields := strings.Fields(s[strings.LastIndexByte(s, ')')+1:])
+		if len(fields) < 2 {
+			continue
+		}
+
+		child, err1 := strconv.Atoi(filepath.Base(filepath.Dir(stat)))
+		parent, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	pageSize := int64(os.Getpagesize())
+
+	var total int64
+	found := false
+	queue := []int{pid}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(p), "statm"))
+		if err != nil {
+			continue
+		}
+
+		// the second field is the resident set size in pages
+		fields := strings.Fields(string(data))
+		if len(fields) < 2 {
+			continue
+		}
+
+		pages, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		found = true
+		total += pages * pageSize
+		queue = append(queue, children[p]...)
+	}
+
+	return total, found
+}
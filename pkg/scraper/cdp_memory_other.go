@@ -0,0 +1,10 @@
+//go:build !linux
+// +build !linux
+
+package scraper
+
+// processTreeMemory is not supported on this platform, so the browser is
+// not restarted when its memory grows.
+func processTreeMemory(pid int) (int64, bool) {
+	return 0, false
+}
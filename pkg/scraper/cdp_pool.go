@@ -0,0 +1,418 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// defaultCDPMaxSessions is the default number of pages that may be
+	// loaded at the same time using CDP.
+	defaultCDPMaxSessions = 2
+)
+
+// CDPSession is a page being loaded using CDP.
+type CDPSession struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// CDPStatus is the state of the browser used to load pages using CDP.
+type CDPStatus struct {
+	// Running is true if a browser is allocated.
+	Running bool `json:"running"`
+	// Remote is true if the browser is a remote instance, which is not
+	// restarted when its memory grows.
+	Remote    bool       `json:"remote"`
+	StartedAt *time.Time `json:"started_at"`
+	// MemoryBytes is the resident memory of a local browser and its child
+	// processes, if it can be determined.
+	MemoryBytes *int64 `json:"memory_bytes"`
+	// Restarts is the number of times the browser was restarted because
+	// its memory grew beyond the limit.
+	Restarts       int           `json:"restarts"`
+	MaxSessions    int           `json:"max_sessions"`
+	ActiveSessions []*CDPSession `json:"active_sessions"`
+	// WaitingSessions is the number of scrapes waiting for a session.
+	WaitingSessions int `json:"waiting_sessions"`
+}
+
+// cdpPool shares a browser between scrapes that use CDP, opening a tab in
+// the browser for each page. The number of tabs open at the same time is
+// limited, and a local browser is restarted once its memory grows beyond
+// the configured limit.
+type cdpPool struct {
+	mutex sync.Mutex
+	// changed is closed and replaced when a session ends or the browser
+	// is closed, to wake scrapes waiting for a session.
+	changed chan struct{}
+
+	// key identifies the options that the browser was allocated with, so
+	// that it is reallocated when they change.
+	key        string
+	browserCtx context.Context
+	cancel     context.CancelFunc
+	remote     bool
+	userDir    string
+	startedAt  time.Time
+
+	restarts       int
+	restartPending bool
+
+	sessions map[int]*CDPSession
+	nextID   int
+	waiting  int
+}
+
+// cdpSessions is the pool used by all scrapers. There is a single pool as
+// the browser is a process wide resource.
+var cdpSessions = &cdpPool{
+	changed:  make(chan struct{}),
+	sessions: make(map[int]*CDPSession),
+}
+
+func cdpMaxSessions(globalConfig GlobalConfig) int {
+	if n := globalConfig.GetScraperCDPMaxSessions(); n > 0 {
+		return n
+	}
+	return defaultCDPMaxSessions
+}
+
+func cdpTimeout(globalConfig GlobalConfig) time.Duration {
+	if n := globalConfig.GetScraperCDPTimeout(); n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	return scrapeGetTimeout
+}
+
+func cdpPoolKey(globalConfig GlobalConfig) string {
+	return globalConfig.GetScraperCDPPath() + "\n" + globalConfig.GetProxy()
+}
+
+// notify wakes all waiting scrapes. Must be called with the mutex held.
+func (p *cdpPool) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// needsRestart returns true if the browser must be closed before it is
+// used by a scrape with the given options, because its memory grew beyond
+// the limit, the options changed or the browser has exited. Must be called
+// with the mutex held.
+func (p *cdpPool) needsRestart(key string) bool {
+	return p.browserCtx != nil && (p.restartPending || p.key != key || p.browserCtx.Err() != nil)
+}
+
+// acquire waits for a session to be available, allocating the browser if
+// needed, and returns the context of a new tab. The context is cancelled
+// when ctx is cancelled or the scrape times out. release must be called
+// once the page is loaded.
+func (p *cdpPool) acquire(ctx context.Context, pageURL string, globalConfig GlobalConfig) (_ context.Context, release func(), err error) {
+	maxSessions := cdpMaxSessions(globalConfig)
+	key := cdpPoolKey(globalConfig)
+
+	p.mutex.Lock()
+	p.waiting++
+	for {
+		// the browser is restarted once the sessions using it have ended
+		if len(p.sessions) < maxSessions && (!p.needsRestart(key) || len(p.sessions) == 0) {
+			break
+		}
+
+		changed := p.changed
+		p.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			p.mutex.Lock()
+			p.waiting--
+			p.mutex.Unlock()
+			return nil, nil, ctx.Err()
+		}
+
+		p.mutex.Lock()
+	}
+	p.waiting--
+
+	if p.needsRestart(key) {
+		if p.restartPending {
+			p.restarts++
+		}
+		p.closeBrowser()
+	}
+
+	if p.browserCtx == nil {
+		if err := p.allocate(ctx, globalConfig); err != nil {
+			p.mutex.Unlock()
+			return nil, nil, err
+		}
+		p.key = key
+	}
+
+	p.nextID++
+	session := &CDPSession{
+		ID:        p.nextID,
+		URL:       pageURL,
+		StartedAt: time.Now(),
+	}
+	p.sessions[session.ID] = session
+
+	tabCtx, cancelTab := chromedp.NewContext(p.browserCtx)
+	p.mutex.Unlock()
+
+	// the tab is derived from the browser rather than ctx, so it is
+	// closed explicitly when ctx is done
+	stop := context.AfterFunc(ctx, cancelTab)
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, cdpTimeout(globalConfig))
+
+	release = func() {
+		stop()
+		cancelTimeout()
+		// closes the tab
+		cancelTab()
+		p.release(session.ID, globalConfig)
+	}
+
+	return tabCtx, release, nil
+}
+
+func (p *cdpPool) release(id int, globalConfig GlobalConfig) {
+	p.mutex.Lock()
+	delete(p.sessions, id)
+	browserCtx := p.browserCtx
+	remote := p.remote
+	p.notify()
+	p.mutex.Unlock()
+
+	maxMemory := int64(globalConfig.GetScraperCDPMaxMemory()) * 1024 * 1024
+	if browserCtx == nil || remote || maxMemory <= 0 {
+		return
+	}
+
+	memory, ok := browserMemory(browserCtx)
+	if !ok || memory <= maxMemory {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// the browser may have been replaced in the meantime
+	if p.browserCtx == browserCtx && !p.restartPending {
+		logger.Infof("[scraper] CDP browser is using %d MB, restarting once current scrapes finish", memory/1024/1024)
+		p.restartPending = true
+	}
+}
+
+// browserMemory returns the resident memory of a local browser.
+func browserMemory(browserCtx context.Context) (int64, bool) {
+	c := chromedp.FromContext(browserCtx)
+	if c == nil || c.Browser == nil {
+		return 0, false
+	}
+
+	proc := c.Browser.Process()
+	if proc == nil {
+		return 0, false
+	}
+
+	return processTreeMemory(proc.Pid)
+}
+
+// allocate starts a local browser or connects to a remote browser. Must be
+// called with the mutex held.
+func (p *cdpPool) allocate(ctx context.Context, globalConfig GlobalConfig) error {
+	var (
+		allocCtx    context.Context
+		cancelAlloc context.CancelFunc
+		remote      bool
+		userDir     string
+	)
+
+	if isCDPPathHTTP(globalConfig) || isCDPPathWS(globalConfig) {
+		address, err := remoteCDPAddress(ctx, globalConfig)
+		if err != nil {
+			return err
+		}
+
+		// the browser outlives the scrape that allocates it
+		allocCtx, cancelAlloc = chromedp.NewRemoteAllocator(context.Background(), address)
+		remote = true
+	} else {
+		// use a temporary user directory for chrome
+		dir, err := os.MkdirTemp("", "stash-chromedp")
+		if err != nil {
+			return err
+		}
+		userDir = dir
+
+		opts := append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.UserDataDir(dir),
+		)
+		if cdpPath := globalConfig.GetScraperCDPPath(); cdpPath != "" {
+			opts = append(opts, chromedp.ExecPath(cdpPath))
+		}
+		if globalConfig.GetProxy() != "" {
+			url, _, _ := splitProxyAuth(globalConfig.GetProxy())
+			opts = append(opts, chromedp.ProxyServer(url))
+		}
+
+		allocCtx, cancelAlloc = chromedp.NewExecAllocator(context.Background(), opts...)
+	}
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+
+	// start the browser
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancelBrowser()
+		cancelAlloc()
+		if userDir != "" {
+			os.RemoveAll(userDir)
+		}
+		return fmt.Errorf("starting CDP browser: %w", err)
+	}
+
+	p.browserCtx = browserCtx
+	p.cancel = func() {
+		cancelBrowser()
+		cancelAlloc()
+	}
+	p.remote = remote
+	p.userDir = userDir
+	p.startedAt = time.Now()
+	p.restartPending = false
+
+	if remote {
+		logger.Debugf("[scraper] connected to CDP browser at %s", globalConfig.GetScraperCDPPath())
+	} else {
+		logger.Debugf("[scraper] started CDP browser")
+	}
+
+	return nil
+}
+
+// remoteCDPAddress returns the websocket address of the configured remote
+// browser.
+func remoteCDPAddress(ctx context.Context, globalConfig GlobalConfig) (string, error) {
+	remote := globalConfig.GetScraperCDPPath()
+
+	// -------------------------------------------------------------------
+	// #1023
+	// when chromium is listening over RDP it only accepts requests
+	// with host headers that are either IPs or `localhost`
+	cdpURL, err := url.Parse(remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CDP Path: %v", err)
+	}
+	hostname := cdpURL.Hostname()
+	if hostname != "localhost" {
+		if net.ParseIP(hostname) == nil { // not an IP
+			addr, err := net.LookupIP(hostname)
+			if err != nil || len(addr) == 0 { // can not resolve to IP
+				return "", fmt.Errorf("CDP: hostname <%s> can not be resolved", hostname)
+			}
+			if len(addr[0]) == 0 { // nil IP
+				return "", fmt.Errorf("CDP: hostname <%s> resolved to nil", hostname)
+			}
+			// addr is a valid IP
+			// replace the host part of the cdpURL with the IP
+			cdpURL.Host = strings.Replace(cdpURL.Host, hostname, addr[0].String(), 1)
+			// use that for remote
+			remote = cdpURL.String()
+		}
+	}
+	// --------------------------------------------------------------------
+
+	// if CDPPath is http(s) then we need to get the websocket URL
+	if isCDPPathHTTP(globalConfig) {
+		return getRemoteCDPWSAddress(ctx, remote)
+	}
+
+	return remote, nil
+}
+
+// closeBrowser closes the browser, or the connection to a remote browser.
+// Must be called with the mutex held.
+func (p *cdpPool) closeBrowser() {
+	if p.browserCtx == nil {
+		return
+	}
+
+	p.cancel()
+	if p.userDir != "" {
+		if err := os.RemoveAll(p.userDir); err != nil {
+			logger.Warnf("[scraper] error removing CDP user directory %s: %v", p.userDir, err)
+		}
+	}
+
+	p.browserCtx = nil
+	p.cancel = nil
+	p.userDir = ""
+	p.restartPending = false
+	p.notify()
+}
+
+func (p *cdpPool) close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.closeBrowser()
+}
+
+func (p *cdpPool) status(globalConfig GlobalConfig) CDPStatus {
+	p.mutex.Lock()
+	ret := CDPStatus{
+		Running:         p.browserCtx != nil,
+		Remote:          p.remote,
+		Restarts:        p.restarts,
+		MaxSessions:     cdpMaxSessions(globalConfig),
+		WaitingSessions: p.waiting,
+	}
+	for _, s := range p.sessions {
+		session := *s
+		ret.ActiveSessions = append(ret.ActiveSessions, &session)
+	}
+	browserCtx := p.browserCtx
+	if browserCtx != nil {
+		startedAt := p.startedAt
+		ret.StartedAt = &startedAt
+	}
+	p.mutex.Unlock()
+
+	sort.Slice(ret.ActiveSessions, func(i, j int) bool {
+		return ret.ActiveSessions[i].ID < ret.ActiveSessions[j].ID
+	})
+
+	if browserCtx != nil && !ret.Remote {
+		if memory, ok := browserMemory(browserCtx); ok {
+			ret.MemoryBytes = &memory
+		}
+	}
+
+	return ret
+}
+
+// CDPStatus returns the state of the browser used by scrapers that load
+// pages using CDP.
+func (c Cache) CDPStatus() CDPStatus {
+	return cdpSessions.status(c.globalConfig)
+}
+
+// CloseCDP closes the browser used by scrapers that load pages using CDP.
+// A new browser is started when it is next needed.
+func (c Cache) CloseCDP() {
+	cdpSessions.close()
+}
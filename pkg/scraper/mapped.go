@@ -68,9 +68,12 @@ func (s mappedConfig) process(ctx context.Context, q mappedQuery, common commonM
 			}
 
 			logger.Infof("mappedConfig.process: key '%s' found %d results", k, len(found))
-			if len(found) > 0 {
+			if len(found) == 0 {
+				traceFromContext(ctx).addSelector(k, selector, found, nil, err)
+			} else {
 				result := s.postProcess(ctx, q, attrConfig, found)
 				logger.Infof("mappedConfig.process: key '%s' after post-processing: %d results", k, len(result))
+				traceFromContext(ctx).addSelector(k, selector, found, result, err)
 
 				// HACK - if the key is URLs, then we need to set the value as a multi-value
 				isMulti := isMulti != nil && isMulti(k)
@@ -81,8 +84,6 @@ func (s mappedConfig) process(ctx context.Context, q mappedQuery, common commonM
 						ret = ret.setSingleValue(i, k, text)
 					}
 				}
-			} else {
-				logger.Infof("mappedConfig.process: key '%s' found no results", k)
 			}
 		}
 	}
@@ -0,0 +1,357 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// maxTraceBodySize is the number of bytes of each request and response body
+// kept in a trace.
+const maxTraceBodySize = 256 * 1024
+
+// redactedHeaders are headers whose values are not kept in a trace, so that
+// traces can be shared without leaking credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"Apikey":        true,
+	"X-Api-Key":     true,
+}
+
+// ScraperTraceInput is the input of a traced scrape. One of URL, Query or
+// the fragment inputs must be set.
+type ScraperTraceInput struct {
+	ScraperID string            `json:"scraper_id"`
+	Type      ScrapeContentType `json:"type"`
+	// URL to scrape with the url scrapers of the scraper
+	URL *string `json:"url"`
+	// Query to scrape with the name scraper of the scraper
+	Query          *string                     `json:"query"`
+	SceneInput     *models.ScrapedSceneInput   `json:"scene_input"`
+	PerformerInput *ScrapedPerformerInput      `json:"performer_input"`
+	GalleryInput   *models.ScrapedGalleryInput `json:"gallery_input"`
+	ImageInput     *models.ScrapedImageInput   `json:"image_input"`
+}
+
+type ScraperTraceHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ScraperTraceExchange is an HTTP request made by a scraper, or a page
+// loaded using Chrome CDP, and its response.
+type ScraperTraceExchange struct {
+	Method          string                `json:"method"`
+	URL             string                `json:"url"`
+	RequestHeaders  []*ScraperTraceHeader `json:"request_headers"`
+	RequestBody     *string               `json:"request_body"`
+	Status          *int                  `json:"status"`
+	ResponseHeaders []*ScraperTraceHeader `json:"response_headers"`
+	// ResponseBody is nil if the response is not text, such as an image.
+	ResponseBody *string `json:"response_body"`
+	// Truncated is true if a body is longer than maxTraceBodySize.
+	Truncated  bool    `json:"truncated"`
+	DurationMs int     `json:"duration_ms"`
+	Error      *string `json:"error"`
+}
+
+// ScraperTraceSelector is a selector of a mapped scraper and the values it
+// matched.
+type ScraperTraceSelector struct {
+	Key      string `json:"key"`
+	Selector string `json:"selector"`
+	// Matches are the values found by the selector.
+	Matches []string `json:"matches"`
+	// Results are the values after post-processing.
+	Results []string `json:"results"`
+	Error   *string  `json:"error"`
+}
+
+// ScraperTrace records what a scraper did during a scrape: the HTTP
+// exchanges, the selectors that were evaluated and the final output. HTTP
+// requests made by script scrapers are not recorded.
+type ScraperTrace struct {
+	Exchanges []*ScraperTraceExchange `json:"exchanges"`
+	Selectors []*ScraperTraceSelector `json:"selectors"`
+	// Results is the scraped content after post-processing.
+	Results []ScrapedContent `json:"results"`
+	Error   *string          `json:"error"`
+
+	mutex sync.Mutex
+}
+
+type traceKey struct{}
+
+func withTrace(ctx context.Context, t *ScraperTrace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// traceFromContext returns the trace of the scrape, or nil if the scrape is
+// not traced. The methods of ScraperTrace may be called on nil.
+func traceFromContext(ctx context.Context) *ScraperTrace {
+	t, _ := ctx.Value(traceKey{}).(*ScraperTrace)
+	return t
+}
+
+func (t *ScraperTrace) addExchange(e *ScraperTraceExchange) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Exchanges = append(t.Exchanges, e)
+}
+
+func (t *ScraperTrace) addSelector(key string, selector string, matches []string, results []string, err error) {
+	if t == nil {
+		return
+	}
+
+	s := &ScraperTraceSelector{
+		Key:      key,
+		Selector: selector,
+		Matches:  matches,
+		Results:  results,
+	}
+	if err != nil {
+		s.Error = errorString(err)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Selectors = append(t.Selectors, s)
+}
+
+// traceCDP records a page loaded using Chrome CDP. It returns a reader of
+// the same content as r.
+func (t *ScraperTrace) traceCDP(url string, start time.Time, r io.Reader, err error) (io.Reader, error) {
+	if t == nil {
+		return r, err
+	}
+
+	e := &ScraperTraceExchange{
+		Method:     "CDP",
+		URL:        url,
+		DurationMs: int(time.Since(start).Milliseconds()),
+	}
+	defer t.addExchange(e)
+
+	if err != nil {
+		e.Error = errorString(err)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		e.Error = errorString(err)
+		return nil, err
+	}
+
+	e.ResponseBody, e.Truncated = traceBody(body)
+	return bytes.NewReader(body), nil
+}
+
+func errorString(err error) *string {
+	s := err.Error()
+	return &s
+}
+
+func traceHeaders(h http.Header) []*ScraperTraceHeader {
+	var ret []*ScraperTraceHeader
+	for name, values := range h {
+		for _, v := range values {
+			if redactedHeaders[http.CanonicalHeaderKey(name)] {
+				v = "[redacted]"
+			}
+			ret = append(ret, &ScraperTraceHeader{Name: name, Value: v})
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
+
+// traceBody returns the body truncated to maxTraceBodySize.
+func traceBody(body []byte) (*string, bool) {
+	truncated := len(body) > maxTraceBodySize
+	if truncated {
+		body = body[:maxTraceBodySize]
+	}
+
+	s := string(bytes.ToValidUTF8(body, nil))
+	return &s, truncated
+}
+
+// isTextContent returns true if the content type is text, such as HTML or
+// JSON. An empty content type is assumed to be text.
+func isTextContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "xml") ||
+		strings.HasSuffix(mediaType, "javascript") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+// traceTransport records the requests made with it and their responses.
+type traceTransport struct {
+	base  http.RoundTripper
+	trace *ScraperTrace
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := &ScraperTraceExchange{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: traceHeaders(req.Header),
+	}
+	defer t.trace.addExchange(e)
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			if len(b) > 0 && isTextContent(req.Header.Get("Content-Type")) {
+				e.RequestBody, e.Truncated = traceBody(b)
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	e.DurationMs = int(time.Since(start).Milliseconds())
+	if err != nil {
+		e.Error = errorString(err)
+		return nil, err
+	}
+
+	e.Status = &resp.StatusCode
+	e.ResponseHeaders = traceHeaders(resp.Header)
+
+	if !isTextContent(resp.Header.Get("Content-Type")) {
+		return resp, nil
+	}
+
+	// the body is read here, so that it can be kept, and replaced with a
+	// reader of the same content
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		e.Error = errorString(err)
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var truncated bool
+	e.ResponseBody, truncated = traceBody(body)
+	e.Truncated = e.Truncated || truncated
+
+	return resp, nil
+}
+
+// Trace runs the scraper with the given input, recording the HTTP exchanges,
+// the selector matches and the final output. An error is returned if the
+// scraper cannot be used with the input. Errors during the scrape are
+// returned in the trace.
+func (c Cache) Trace(ctx context.Context, input ScraperTraceInput) (*ScraperTrace, error) {
+	s := c.findScraper(input.ScraperID)
+	if s == nil {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, input.ScraperID)
+	}
+
+	t := &ScraperTrace{}
+	ctx = withTrace(ctx, t)
+
+	// use a client recording the exchanges of this scrape only
+	base := c.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client := *c.client
+	client.Transport = &traceTransport{
+		base:  base,
+		trace: t,
+	}
+	c.client = &client
+
+	var (
+		results []ScrapedContent
+		err     error
+	)
+
+	switch {
+	case input.URL != nil:
+		results, err = c.traceURL(ctx, s, *input.URL, input.Type)
+	case input.Query != nil:
+		results, err = c.ScrapeName(ctx, input.ScraperID, *input.Query, input.Type)
+	default:
+		fragment := Input{
+			Performer: input.PerformerInput,
+			Scene:     input.SceneInput,
+			Gallery:   input.GalleryInput,
+			Image:     input.ImageInput,
+		}
+		if fragment == (Input{}) {
+			return nil, errors.New("one of url, query or a fragment input must be set")
+		}
+
+		var content ScrapedContent
+		content, err = c.ScrapeFragment(ctx, input.ScraperID, fragment)
+		if content != nil {
+			results = []ScrapedContent{content}
+		}
+	}
+
+	if err != nil {
+		t.Error = errorString(err)
+	}
+	t.Results = results
+
+	return t, nil
+}
+
+func (c Cache) traceURL(ctx context.Context, s scraper, url string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	ul, ok := s.(urlScraper)
+	if !ok || !s.supportsURL(url, ty) {
+		return nil, fmt.Errorf("%w: scraper %s cannot scrape %v from url %s", ErrNotSupported, s.spec().ID, ty, url)
+	}
+
+	content, err := ul.viaURL(ctx, c.client, url, ty)
+	if err != nil || content == nil {
+		return nil, err
+	}
+
+	content, err = c.postScrapeSingle(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ScrapedContent{content}, nil
+}
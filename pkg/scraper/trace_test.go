@@ -0,0 +1,89 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTraceTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		if r.URL.Path == "/image" {
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte{0xff, 0xd8})
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = io.WriteString(w, "<html>page</html>")
+	}))
+	defer srv.Close()
+
+	trace := &ScraperTrace{}
+	client := &http.Client{
+		Transport: &traceTransport{base: http.DefaultTransport, trace: trace},
+	}
+
+	get := func(path string) string {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+path, strings.NewReader(`{"query":"q"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("ApiKey", "secret")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	// the scraper must still be able to read the body
+	if got := get("/page"); got != "<html>page</html>" {
+		t.Errorf("body = %q, want page", got)
+	}
+	get("/image")
+
+	if len(trace.Exchanges) != 2 {
+		t.Fatalf("got %d exchanges, want 2", len(trace.Exchanges))
+	}
+
+	page := trace.Exchanges[0]
+	if page.Status == nil || *page.Status != http.StatusOK {
+		t.Errorf("status = %v, want 200", page.Status)
+	}
+	if page.RequestBody == nil || *page.RequestBody != `{"query":"q"}` {
+		t.Errorf("request body = %v, want json", page.RequestBody)
+	}
+	if page.ResponseBody == nil || *page.ResponseBody != "<html>page</html>" {
+		t.Errorf("response body = %v, want page", page.ResponseBody)
+	}
+
+	for _, h := range append(page.RequestHeaders, page.ResponseHeaders...) {
+		if strings.Contains(h.Value, "secret") {
+			t.Errorf("header %s was not redacted", h.Name)
+		}
+	}
+
+	if image := trace.Exchanges[1]; image.ResponseBody != nil {
+		t.Errorf("image response body should not be kept")
+	}
+}
+
+func TestTraceBody(t *testing.T) {
+	body, truncated := traceBody([]byte(strings.Repeat("a", maxTraceBodySize+1)))
+	if !truncated || len(*body) != maxTraceBodySize {
+		t.Errorf("body of length %d, truncated %v, want %d and true", len(*body), truncated, maxTraceBodySize)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -29,7 +28,9 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil && driverOptions.UseCDP {
 		// get the page using chrome dp
-		return urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
+		start := time.Now()
+		r, err := urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
+		return traceFromContext(ctx).traceCDP(loadURL, start, r, err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loadURL, nil)
@@ -102,80 +103,11 @@ func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverO
 		sleepDuration = time.Duration(driverOptions.Sleep) * time.Second
 	}
 
-	// if scraperCDPPath is a remote address, then allocate accordingly
-	cdpPath := globalConfig.GetScraperCDPPath()
-	if cdpPath != "" {
-		var cancelAct context.CancelFunc
-
-		if isCDPPathHTTP(globalConfig) || isCDPPathWS(globalConfig) {
-			remote := cdpPath
-
-			// -------------------------------------------------------------------
-			// #1023
-			// when chromium is listening over RDP it only accepts requests
-			// with host headers that are either IPs or `localhost`
-			cdpURL, err := url.Parse(remote)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse CDP Path: %v", err)
-			}
-			hostname := cdpURL.Hostname()
-			if hostname != "localhost" {
-				if net.ParseIP(hostname) == nil { // not an IP
-					addr, err := net.LookupIP(hostname)
-					if err != nil || len(addr) == 0 { // can not resolve to IP
-						return nil, fmt.Errorf("CDP: hostname <%s> can not be resolved", hostname)
-					}
-					if len(addr[0]) == 0 { // nil IP
-						return nil, fmt.Errorf("CDP: hostname <%s> resolved to nil", hostname)
-					}
-					// addr is a valid IP
-					// replace the host part of the cdpURL with the IP
-					cdpURL.Host = strings.Replace(cdpURL.Host, hostname, addr[0].String(), 1)
-					// use that for remote
-					remote = cdpURL.String()
-				}
-			}
-			// --------------------------------------------------------------------
-
-			// if CDPPath is http(s) then we need to get the websocket URL
-			if isCDPPathHTTP(globalConfig) {
-				var err error
-				remote, err = getRemoteCDPWSAddress(ctx, remote)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			ctx, cancelAct = chromedp.NewRemoteAllocator(ctx, remote)
-		} else {
-			// use a temporary user directory for chrome
-			dir, err := os.MkdirTemp("", "stash-chromedp")
-			if err != nil {
-				return nil, err
-			}
-			defer os.RemoveAll(dir)
-
-			opts := append(chromedp.DefaultExecAllocatorOptions[:],
-				chromedp.UserDataDir(dir),
-				chromedp.ExecPath(cdpPath),
-			)
-			if globalConfig.GetProxy() != "" {
-				url, _, _ := splitProxyAuth(globalConfig.GetProxy())
-				opts = append(opts, chromedp.ProxyServer(url))
-			}
-
-			ctx, cancelAct = chromedp.NewExecAllocator(ctx, opts...)
-		}
-
-		defer cancelAct()
+	ctx, release, err := cdpSessions.acquire(ctx, urlCDP, globalConfig)
+	if err != nil {
+		return nil, err
 	}
-
-	ctx, cancel := chromedp.NewContext(ctx)
-	defer cancel()
-
-	// add a fixed timeout for the http request
-	ctx, cancel = context.WithTimeout(ctx, scrapeGetTimeout)
-	defer cancel()
+	defer release()
 
 	var res string
 	headers := cdpHeaders(driverOptions)
@@ -213,7 +145,7 @@ func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverO
 		})
 	}
 
-	err := chromedp.Run(ctx,
+	err = chromedp.Run(ctx,
 		network.Enable(),
 		setCDPCookies(driverOptions),
 		printCDPCookies(driverOptions, "Cookies found"),
@@ -283,11 +215,44 @@ func getRemoteCDPWSAddress(ctx context.Context, url string) (string, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return "", err
 	}
-	remote := result["webSocketDebuggerUrl"].(string)
+	remote, ok := result["webSocketDebuggerUrl"].(string)
+	if !ok {
+		return "", fmt.Errorf("webSocketDebuggerUrl not found in response from %s", url)
+	}
 	logger.Debugf("Remote cdp instance found %s", remote)
 	return remote, err
 }
 
+// CheckCDP checks that the configured CDP instance responds, or that the
+// configured Chrome executable exists. Returns nil if CDP is not configured.
+func CheckCDP(ctx context.Context, globalConfig GlobalConfig) error {
+	cdpPath := globalConfig.GetScraperCDPPath()
+	if cdpPath == "" {
+		return nil
+	}
+
+	if !isCDPPathHTTP(globalConfig) && !isCDPPathWS(globalConfig) {
+		if _, err := os.Stat(cdpPath); err != nil {
+			return fmt.Errorf("chrome executable not found: %w", err)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(cdpPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse CDP Path: %w", err)
+	}
+
+	// the version endpoint is served over http for websocket addresses
+	if u.Scheme == "ws" {
+		u.Scheme = "http"
+		u.Path = "/json/version"
+	}
+
+	_, err = getRemoteCDPWSAddress(ctx, u.String())
+	return err
+}
+
 func cdpHeaders(driverOptions scraperDriverOptions) map[string]interface{} {
 	headers := map[string]interface{}{}
 	if driverOptions.Headers != nil {
@@ -837,6 +837,18 @@ func (mockGlobalConfig) GetScraperCDPPath() string {
 	return ""
 }
 
+func (mockGlobalConfig) GetScraperCDPMaxSessions() int {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperCDPTimeout() int {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperCDPMaxMemory() int {
+	return 0
+}
+
 func (mockGlobalConfig) GetScraperCertCheck() bool {
 	return false
 }
@@ -3,6 +3,7 @@ package blob
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -62,6 +63,32 @@ func (s *FilesystemReader) Read(ctx context.Context, checksum string) ([]byte, e
 	return io.ReadAll(f)
 }
 
+// Size returns the size of the blob file for the given checksum.
+// Returns 0 if the file does not exist.
+func (s *FilesystemReader) Size(ctx context.Context, checksum string) (int64, error) {
+	if s.path == "" {
+		return 0, fmt.Errorf("no path set")
+	}
+
+	fn := s.checksumToPath(checksum)
+	f, err := s.fs.Open(fn)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("opening file %q: %w", fn, err)
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("getting file info for %q: %w", fn, err)
+	}
+
+	return info.Size(), nil
+}
+
 type FilesystemStore struct {
 	FilesystemReader
 	deleter *file.Deleter
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+)
+
+type blobReference struct {
+	table  string
+	column string
+}
+
+// blobReferences lists all columns that reference a blob checksum.
+var blobReferences = []blobReference{
+	{sceneTable, sceneCoverBlobColumn},
+	{performerTable, performerImageBlobColumn},
+	{performerProfileImagesTable, performerProfileImageBlobColumn},
+	{studioTable, studioImageBlobColumn},
+	{tagTable, tagImageBlobColumn},
+	{groupTable, groupFrontImageBlobColumn},
+	{groupTable, groupBackImageBlobColumn},
+}
+
+// FindUnreferencedBlobs returns up to n checksums of blobs that are not
+// referenced by any object, ordered by checksum and starting after lastChecksum.
+func (qb *BlobStore) FindUnreferencedBlobs(ctx context.Context, n uint, lastChecksum string) ([]string, error) {
+	table := qb.table()
+	checksumCol := table.Col(blobChecksumColumn)
+	q := dialect.From(table).Select(checksumCol).Order(checksumCol.Asc()).Limit(n)
+
+	for _, ref := range blobReferences {
+		refTable := goqu.T(ref.table)
+		refCol := refTable.Col(ref.column)
+		q = q.Where(checksumCol.NotIn(
+			dialect.From(refTable).Select(refCol).Where(refCol.IsNotNull()),
+		))
+	}
+
+	if lastChecksum != "" {
+		q = q.Where(checksumCol.Gt(lastChecksum))
+	}
+
+	const single = false
+	var checksums []string
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var checksum string
+		if err := rows.Scan(&checksum); err != nil {
+			return err
+		}
+		checksums = append(checksums, checksum)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return checksums, nil
+}
+
+// DeleteUnreferenced deletes the blob if it is not referenced by any object.
+// Returns true if the blob was deleted.
+func (qb *BlobStore) DeleteUnreferenced(ctx context.Context, checksum string) (bool, error) {
+	table := qb.table()
+	q := dialect.Delete(table).Where(table.Col(blobChecksumColumn).Eq(checksum))
+
+	ret, err := exec(ctx, q)
+	if err != nil {
+		if qb.isConstraintError(err) {
+			// blob is still referenced - do not delete
+			return false, nil
+		}
+
+		return false, fmt.Errorf("deleting from %s: %w", table, err)
+	}
+
+	if n, err := ret.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	if qb.options.UseFilesystem {
+		if err := qb.fsStore.Delete(ctx, checksum); err != nil {
+			return false, fmt.Errorf("deleting from filesystem: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// Size returns the number of bytes used to store the blob with the given
+// checksum, in the database and on the filesystem.
+func (qb *BlobStore) Size(ctx context.Context, checksum string) (int64, error) {
+	table := qb.table()
+	q := dialect.From(table).Select(
+		goqu.COALESCE(goqu.L("length(?)", table.Col("blob")), 0),
+	).Where(table.Col(blobChecksumColumn).Eq(checksum))
+
+	var ret int64
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return 0, fmt.Errorf("getting blob size from database: %w", err)
+	}
+
+	if qb.options.UseFilesystem {
+		fsSize, err := qb.fsStore.Size(ctx, checksum)
+		if err != nil {
+			return 0, fmt.Errorf("getting blob size from filesystem: %w", err)
+		}
+		ret += fsSize
+	}
+
+	return ret, nil
+}
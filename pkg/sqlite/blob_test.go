@@ -6,8 +6,11 @@ package sqlite_test
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +46,136 @@ func testUpdateImage(t *testing.T, ctx context.Context, id int, updateFn updateI
 
 	return nil
 }
+
+func TestBlobReferences(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		// ensure that every column referencing the blobs table is checked when
+		// finding unreferenced blobs
+		_, rows, err := db.QuerySQL(ctx, `SELECT m.name, p."from" FROM sqlite_master m
+JOIN pragma_foreign_key_list(m.name) p
+WHERE m.type = 'table' AND p."table" = 'blobs'`, nil)
+		if err != nil {
+			t.Errorf("querying foreign keys: %v", err)
+			return nil
+		}
+
+		var got [][2]string
+		for _, row := range rows {
+			got = append(got, [2]string{fmt.Sprint(row[0]), fmt.Sprint(row[1])})
+		}
+
+		assert.ElementsMatch(t, got, sqlite.BlobReferences())
+		return nil
+	})
+}
+
+func writeBlobs(t *testing.T, ctx context.Context, store *sqlite.BlobStore, data ...string) []string {
+	var ret []string
+	for _, d := range data {
+		checksum, err := store.Write(ctx, []byte(d))
+		if err != nil {
+			t.Fatalf("writing blob: %v", err)
+		}
+		ret = append(ret, checksum)
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+func TestBlobStore_FindUnreferencedBlobs(t *testing.T) {
+	runWithRollbackTxn(t, "paging", func(t *testing.T, ctx context.Context) {
+		unreferenced := writeBlobs(t, ctx, db.Blobs, "unreferenced1", "unreferenced2", "unreferenced3")
+
+		const referencedImage = "referenced"
+		if err := db.Tag.UpdateImage(ctx, tagIDs[tagIdxWithScene], []byte(referencedImage)); err != nil {
+			t.Fatalf("updating tag image: %v", err)
+		}
+		referenced := md5.FromString(referencedImage)
+
+		// page through all unreferenced blobs one at a time
+		var got []string
+		lastChecksum := ""
+		for {
+			page, err := db.Blobs.FindUnreferencedBlobs(ctx, 1, lastChecksum)
+			if err != nil {
+				t.Fatalf("FindUnreferencedBlobs: %v", err)
+			}
+
+			if len(page) == 0 {
+				break
+			}
+
+			assert.Len(t, page, 1)
+			if lastChecksum != "" {
+				assert.Greater(t, page[0], lastChecksum)
+			}
+
+			got = append(got, page...)
+			lastChecksum = page[0]
+		}
+
+		assert.Subset(t, got, unreferenced)
+		assert.NotContains(t, got, referenced)
+	})
+}
+
+func TestBlobStore_Size(t *testing.T) {
+	const data = "blob size"
+
+	runWithRollbackTxn(t, "database", func(t *testing.T, ctx context.Context) {
+		checksum := writeBlobs(t, ctx, db.Blobs, data)[0]
+
+		got, err := db.Blobs.Size(ctx, checksum)
+		if err != nil {
+			t.Fatalf("Size: %v", err)
+		}
+		assert.Equal(t, int64(len(data)), got)
+	})
+
+	runWithRollbackTxn(t, "filesystem", func(t *testing.T, ctx context.Context) {
+		store := sqlite.NewBlobStore(sqlite.BlobStoreOptions{
+			UseFilesystem: true,
+			Path:          t.TempDir(),
+		})
+		checksum := writeBlobs(t, ctx, store, data)[0]
+
+		got, err := store.Size(ctx, checksum)
+		if err != nil {
+			t.Fatalf("Size: %v", err)
+		}
+		assert.Equal(t, int64(len(data)), got)
+	})
+}
+
+func TestBlobStore_DeleteUnreferenced(t *testing.T) {
+	runWithRollbackTxn(t, "unreferenced", func(t *testing.T, ctx context.Context) {
+		checksum := writeBlobs(t, ctx, db.Blobs, "delete unreferenced")[0]
+
+		deleted, err := db.Blobs.DeleteUnreferenced(ctx, checksum)
+		if err != nil {
+			t.Fatalf("DeleteUnreferenced: %v", err)
+		}
+		assert.True(t, deleted)
+
+		// deleting again should report that nothing was deleted
+		deleted, err = db.Blobs.DeleteUnreferenced(ctx, checksum)
+		if err != nil {
+			t.Fatalf("DeleteUnreferenced: %v", err)
+		}
+		assert.False(t, deleted)
+	})
+
+	runWithRollbackTxn(t, "referenced", func(t *testing.T, ctx context.Context) {
+		const image = "delete referenced"
+		if err := db.Tag.UpdateImage(ctx, tagIDs[tagIdxWithScene], []byte(image)); err != nil {
+			t.Fatalf("updating tag image: %v", err)
+		}
+
+		deleted, err := db.Blobs.DeleteUnreferenced(ctx, md5.FromString(image))
+		if err != nil {
+			t.Fatalf("DeleteUnreferenced: %v", err)
+		}
+		assert.False(t, deleted)
+	})
+}
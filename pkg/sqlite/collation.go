@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"sync"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+const (
+	// naturalCollation is the custom collation for models.SortCollationNatural.
+	naturalCollation = "NATURAL_CI"
+	// localeCollation is the custom collation for models.SortCollationLocale.
+	localeCollation = "LOCALE_CI"
+	// binaryCollation is the built-in sqlite collation comparing bytes.
+	binaryCollation = "BINARY"
+)
+
+// SortOptions are the options used to sort by text fields.
+type SortOptions struct {
+	// Collation is used where the find filter does not set a collation.
+	Collation models.SortCollation
+	// Locale is the BCP 47 language tag whose rules are used by the locale
+	// collation.
+	Locale string
+}
+
+// sortCollation holds the sort options. Collations are registered with
+// each connection by the driver, so the options are shared by all databases.
+var sortCollation = struct {
+	mutex     sync.Mutex
+	collation models.SortCollation
+	collator  *collate.Collator
+}{
+	collation: models.SortCollationNatural,
+	collator:  newLocaleCollator(language.English),
+}
+
+func newLocaleCollator(tag language.Tag) *collate.Collator {
+	return collate.New(tag, collate.IgnoreCase, collate.Numeric)
+}
+
+// SetSortOptions sets the default collation and the locale used to sort by
+// text fields.
+func (db *Database) SetSortOptions(options SortOptions) {
+	tag, err := language.Parse(options.Locale)
+	if err != nil {
+		logger.Warnf("invalid sort locale %q, using English: %v", options.Locale, err)
+		tag = language.English
+	}
+
+	collation := options.Collation
+	if !collation.IsValid() {
+		collation = models.SortCollationNatural
+	}
+
+	sortCollation.mutex.Lock()
+	defer sortCollation.mutex.Unlock()
+
+	sortCollation.collation = collation
+	sortCollation.collator = newLocaleCollator(tag)
+}
+
+// localeCompare compares strings using the rules of the sort locale. The
+// collator is not safe for concurrent use.
+func localeCompare(s string, s2 string) int {
+	sortCollation.mutex.Lock()
+	defer sortCollation.mutex.Unlock()
+
+	return sortCollation.collator.CompareString(s, s2)
+}
+
+// getCollation returns the collation used to sort by text fields for the
+// find filter.
+func getCollation(findFilter *models.FindFilterType) string {
+	sortCollation.mutex.Lock()
+	collation := sortCollation.collation
+	sortCollation.mutex.Unlock()
+
+	if findFilter != nil && findFilter.Collation != nil && findFilter.Collation.IsValid() {
+		collation = *findFilter.Collation
+	}
+
+	switch collation {
+	case models.SortCollationLocale:
+		return localeCollation
+	case models.SortCollationBinary:
+		return binaryCollation
+	default:
+		return naturalCollation
+	}
+}
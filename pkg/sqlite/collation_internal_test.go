@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleCompare(t *testing.T) {
+	db := &Database{}
+
+	tests := []struct {
+		locale string
+		values []string
+		want   []string
+	}{
+		{
+			"en",
+			[]string{"title 10", "Title 2", "title 1"},
+			[]string{"title 1", "Title 2", "title 10"},
+		},
+		{
+			// ё is sorted with е, rather than after я by code point
+			"ru",
+			[]string{"Яна", "ель", "Anna", "ёж", "zoe", "ива"},
+			[]string{"Anna", "zoe", "ёж", "ель", "ива", "Яна"},
+		},
+	}
+
+	defer db.SetSortOptions(SortOptions{Collation: models.SortCollationNatural, Locale: "en"})
+
+	for _, tt := range tests {
+		db.SetSortOptions(SortOptions{Collation: models.SortCollationLocale, Locale: tt.locale})
+
+		got := slices.Clone(tt.values)
+		slices.SortFunc(got, localeCompare)
+		assert.Equal(t, tt.want, got, "locale %s", tt.locale)
+	}
+}
+
+func TestGetCollation(t *testing.T) {
+	db := &Database{}
+	defer db.SetSortOptions(SortOptions{Collation: models.SortCollationNatural, Locale: "en"})
+
+	binary := models.SortCollationBinary
+
+	assert.Equal(t, naturalCollation, getCollation(nil))
+	assert.Equal(t, binaryCollation, getCollation(&models.FindFilterType{Collation: &binary}))
+
+	db.SetSortOptions(SortOptions{Collation: models.SortCollationLocale, Locale: "ru"})
+	assert.Equal(t, localeCollation, getCollation(nil))
+	assert.Equal(t, localeCollation, getCollation(&models.FindFilterType{}))
+	assert.Equal(t, binaryCollation, getCollation(&models.FindFilterType{Collation: &binary}))
+}
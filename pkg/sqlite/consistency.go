@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	findScenesWithoutFilesQuery = `
+SELECT scenes.id FROM scenes
+WHERE NOT EXISTS (SELECT 1 FROM scenes_files WHERE scenes_files.scene_id = scenes.id)
+ORDER BY scenes.id
+`
+
+	findVideoFilesWithoutScenesQuery = `
+SELECT files.id, folders.path, files.basename FROM files
+INNER JOIN video_files ON video_files.file_id = files.id
+INNER JOIN folders ON folders.id = files.parent_folder_id
+WHERE NOT EXISTS (SELECT 1 FROM scenes_files WHERE scenes_files.file_id = files.id)
+ORDER BY files.id
+`
+
+	// the lowest file id of each object is proposed as its primary file
+	findMissingPrimaryFilesQuery = `
+SELECT j.%[2]s AS id, MIN(j.file_id) AS file_id FROM %[1]s j
+WHERE NOT EXISTS (SELECT 1 FROM %[1]s p WHERE p.%[2]s = j.%[2]s AND p."primary" = 1)
+GROUP BY j.%[2]s
+ORDER BY j.%[2]s
+`
+
+	findGalleriesWithMissingFolderQuery = `
+SELECT galleries.id FROM galleries
+WHERE galleries.folder_id IS NOT NULL
+AND NOT EXISTS (SELECT 1 FROM folders WHERE folders.id = galleries.folder_id)
+ORDER BY galleries.id
+`
+
+	findFilesWithoutFingerprintsQuery = `
+SELECT files.id, folders.path, files.basename FROM files
+INNER JOIN folders ON folders.id = files.parent_folder_id
+WHERE NOT EXISTS (SELECT 1 FROM files_fingerprints WHERE files_fingerprints.file_id = files.id)
+ORDER BY files.id
+`
+)
+
+// primaryFileJoins are the tables joining objects to their files.
+var primaryFileJoins = []struct {
+	objectType models.ConsistencyObjectType
+	table      string
+	idColumn   string
+}{
+	{models.ConsistencyObjectTypeScene, scenesFilesTable, sceneIDColumn},
+	{models.ConsistencyObjectTypeImage, imagesFilesTable, imageIDColumn},
+	{models.ConsistencyObjectTypeGallery, galleriesFilesTable, galleryIDColumn},
+}
+
+// ConsistencyStore finds references between objects and files which are
+// broken, such as after the database was modified outside of stash.
+type ConsistencyStore struct{}
+
+func NewConsistencyStore() *ConsistencyStore {
+	return &ConsistencyStore{}
+}
+
+func (qb *ConsistencyStore) FindIssues(ctx context.Context, category models.ConsistencyCategory) ([]models.ConsistencyIssue, error) {
+	switch category {
+	case models.ConsistencyCategoryScenesWithoutFiles:
+		return qb.findObjects(ctx, models.ConsistencyObjectTypeScene, findScenesWithoutFilesQuery)
+	case models.ConsistencyCategoryFilesWithoutScenes:
+		return qb.findFiles(ctx, findVideoFilesWithoutScenesQuery)
+	case models.ConsistencyCategoryMissingPrimaryFile:
+		return qb.findMissingPrimaryFiles(ctx)
+	case models.ConsistencyCategoryMissingGalleryFolder:
+		return qb.findObjects(ctx, models.ConsistencyObjectTypeGallery, findGalleriesWithMissingFolderQuery)
+	case models.ConsistencyCategoryMissingFingerprints:
+		return qb.findFiles(ctx, findFilesWithoutFingerprintsQuery)
+	}
+
+	return nil, fmt.Errorf("invalid consistency category %q", category)
+}
+
+func (qb *ConsistencyStore) findObjects(ctx context.Context, objectType models.ConsistencyObjectType, query string) ([]models.ConsistencyIssue, error) {
+	var ids []int
+	if err := dbWrapper.Select(ctx, &ids, query); err != nil {
+		return nil, err
+	}
+
+	ret := make([]models.ConsistencyIssue, len(ids))
+	for i, id := range ids {
+		ret[i] = models.ConsistencyIssue{
+			ObjectType: objectType,
+			ObjectID:   id,
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *ConsistencyStore) findFiles(ctx context.Context, query string) ([]models.ConsistencyIssue, error) {
+	var rows []struct {
+		ID         int    `db:"id"`
+		FolderPath string `db:"path"`
+		Basename   string `db:"basename"`
+	}
+	if err := dbWrapper.Select(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	ret := make([]models.ConsistencyIssue, len(rows))
+	for i, r := range rows {
+		ret[i] = models.ConsistencyIssue{
+			ObjectType: models.ConsistencyObjectTypeFile,
+			ObjectID:   r.ID,
+			Path:       filepath.Join(r.FolderPath, r.Basename),
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *ConsistencyStore) findMissingPrimaryFiles(ctx context.Context) ([]models.ConsistencyIssue, error) {
+	var ret []models.ConsistencyIssue
+	for _, j := range primaryFileJoins {
+		var rows []struct {
+			ID     int           `db:"id"`
+			FileID models.FileID `db:"file_id"`
+		}
+		query := fmt.Sprintf(findMissingPrimaryFilesQuery, j.table, j.idColumn)
+		if err := dbWrapper.Select(ctx, &rows, query); err != nil {
+			return nil, err
+		}
+
+		for _, r := range rows {
+			fileID := r.FileID
+			ret = append(ret, models.ConsistencyIssue{
+				ObjectType: j.objectType,
+				ObjectID:   r.ID,
+				FileID:     &fileID,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *ConsistencyStore) ClearGalleryFolder(ctx context.Context, galleryID int) error {
+	table := galleryTableMgr.table
+	q := dialect.Update(table).Set(goqu.Record{folderIDColumn: nil}).Where(galleryTableMgr.byID(galleryID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("clearing folder of gallery %d: %w", galleryID, err)
+	}
+
+	return nil
+}
@@ -81,6 +81,31 @@ func joinedStringCriterionHandler(c *models.StringCriterionInput, column string,
 	}
 }
 
+// delimitedStringCriterionHandler filters on a column containing a
+// comma-separated list of values. The equals modifiers match a single
+// element of the list. Other modifiers match against the whole column.
+func delimitedStringCriterionHandler(c *models.StringCriterionInput, column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		if addJoinFn != nil {
+			addJoinFn(f)
+		}
+
+		list := "(',' || COALESCE(" + column + ", '') || ',')"
+		switch c.Modifier {
+		case models.CriterionModifierEquals:
+			f.addWhere(list+" LIKE ?", "%,"+c.Value+",%")
+		case models.CriterionModifierNotEquals:
+			f.addWhere(list+" NOT LIKE ?", "%,"+c.Value+",%")
+		default:
+			stringCriterionHandler(c, column)(ctx, f)
+		}
+	}
+}
+
 func enumCriterionHandler(modifier models.CriterionModifier, values []string, column string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if modifier.IsValid() {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -32,9 +33,13 @@ const (
 
 	// environment variable to set the cache size
 	cacheSizeEnv = "STASH_SQLITE_CACHE_SIZE"
+
+	// default time that connections wait for a lock held by another
+	// connection before failing with SQLITE_BUSY
+	defaultBusyTimeout = 50 * time.Millisecond
 )
 
-var appSchemaVersion uint = 108
+var appSchemaVersion uint = 124
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -74,14 +79,23 @@ type storeRepository struct {
 	Gallery               *GalleryStore
 	GalleryChapter        *GalleryChapterStore
 	Scene                 *SceneStore
+	SceneExtra            *SceneExtraStore
 	SceneMarker           *SceneMarkerStore
 	SceneSimilarity       *SceneSimilarityStore
+	SceneParserBatch      *SceneParserBatchStore
+	ShareLink             *ShareLinkStore
+	SmartPlaylist         *SmartPlaylistStore
+	StashBoxSubmission    *StashBoxSubmissionStore
+	JobCheckpoint         *JobCheckpointStore
+	LibraryStats          *LibraryStatsStore
+	Consistency           *ConsistencyStore
 	Performer             *PerformerStore
 	PerformerProfileImage *PerformerProfileImageStore
 	SavedFilter           *SavedFilterStore
 	Studio                *StudioStore
 	Tag                   *TagStore
 	Group                 *GroupStore
+	Note                  *NoteStore
 	ColorPreset           *colorPresetRepository
 }
 
@@ -92,6 +106,9 @@ type Database struct {
 	writeDB *sqlx.DB
 	dbPath  string
 
+	busyTimeout time.Duration
+	cacheSize   int
+
 	schemaVersion uint
 
 	lockChan chan struct{}
@@ -114,8 +131,16 @@ func NewDatabase() *Database {
 		Folder:                folderStore,
 		Game:                  gameStore,
 		Scene:                 NewSceneStore(r, blobStore),
+		SceneExtra:            NewSceneExtraStore(),
 		SceneMarker:           NewSceneMarkerStore(),
 		SceneSimilarity:       NewSceneSimilarityStore(),
+		SceneParserBatch:      NewSceneParserBatchStore(),
+		ShareLink:             NewShareLinkStore(),
+		SmartPlaylist:         NewSmartPlaylistStore(),
+		StashBoxSubmission:    NewStashBoxSubmissionStore(),
+		JobCheckpoint:         NewJobCheckpointStore(),
+		LibraryStats:          NewLibraryStatsStore(),
+		Consistency:           NewConsistencyStore(),
 		Image:                 NewImageStore(r),
 		Gallery:               galleryStore,
 		GalleryChapter:        NewGalleryChapterStore(),
@@ -124,6 +149,7 @@ func NewDatabase() *Database {
 		Studio:                studioStore,
 		Tag:                   tagStore,
 		Group:                 NewGroupStore(blobStore),
+		Note:                  NewNoteStore(),
 		SavedFilter:           NewSavedFilterStore(),
 		ColorPreset:           NewColorPresetRepository(nil, tagStore), // Will be set later
 	}
@@ -140,6 +166,19 @@ func (db *Database) SetBlobStoreOptions(options BlobStoreOptions) {
 	*db.Blobs = *NewBlobStore(options)
 }
 
+// SetBusyTimeout sets the time that connections wait for a lock held by
+// another connection. Takes effect when the database is next opened.
+func (db *Database) SetBusyTimeout(timeout time.Duration) {
+	db.busyTimeout = timeout
+}
+
+// SetCacheSize sets the size of the page cache of each connection in KiB,
+// or zero for the SQLite default. The cache size environment variable takes
+// precedence. Takes effect when the database is next opened.
+func (db *Database) SetCacheSize(kib int) {
+	db.cacheSize = kib
+}
+
 // Ready returns an error if the database is not ready to begin transactions.
 func (db *Database) Ready() error {
 	if db.readDB == nil || db.writeDB == nil {
@@ -245,7 +284,12 @@ func (db *Database) Close() error {
 
 func (db *Database) open(disableForeignKeys bool, writable bool) (*sqlx.DB, error) {
 	// https://github.com/mattn/go-sqlite3
-	url := "file:" + db.dbPath + "?_journal=WAL&_sync=NORMAL&_busy_timeout=50"
+	busyTimeout := db.busyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = defaultBusyTimeout
+	}
+
+	url := "file:" + db.dbPath + "?_journal=WAL&_sync=NORMAL&_busy_timeout=" + strconv.FormatInt(busyTimeout.Milliseconds(), 10)
 	if !disableForeignKeys {
 		url += "&_fk=true"
 	}
@@ -260,6 +304,9 @@ func (db *Database) open(disableForeignKeys bool, writable bool) (*sqlx.DB, erro
 	// default is -2000 which is 2MB
 	if cacheSize := os.Getenv(cacheSizeEnv); cacheSize != "" {
 		url += "&_cache_size=" + cacheSize
+	} else if db.cacheSize > 0 {
+		// negative values are in KiB rather than pages
+		url += "&_cache_size=-" + strconv.Itoa(db.cacheSize)
 	}
 
 	conn, err := sqlx.Open(sqlite3Driver, url)
@@ -454,6 +501,21 @@ func analyze(ctx context.Context, db *sqlx.DB) error {
 	return err
 }
 
+// QuickCheck runs a quick integrity check on the database. Returns the
+// problems found, or nil if the database is ok.
+func (db *Database) QuickCheck(ctx context.Context) ([]string, error) {
+	var results []string
+	if err := db.readDB.SelectContext(ctx, &results, "PRAGMA quick_check"); err != nil {
+		return nil, err
+	}
+
+	if len(results) == 1 && results[0] == "ok" {
+		return nil, nil
+	}
+
+	return results, nil
+}
+
 // flushWAL flushes the Write-Ahead Log (WAL) to the main database file.
 // It also truncates the WAL file to 0 bytes.
 func flushWAL(ctx context.Context, db *sqlx.DB) error {
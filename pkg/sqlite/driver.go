@@ -30,6 +30,7 @@ func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
 				"durationToTinyInt": durationToTinyIntFn,
 				"basename":          basenameFn,
 				"phash_distance":    phashDistanceFn,
+				"local_date":        localDateFn,
 			}
 
 			for name, fn := range funcs {
@@ -39,7 +40,7 @@ func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
 			}
 
 			// COLLATE NATURAL_CI - Case insensitive natural sort
-			err := conn.RegisterCollation("NATURAL_CI", func(s string, s2 string) int {
+			err := conn.RegisterCollation(naturalCollation, func(s string, s2 string) int {
 				if casefolded.NaturalLess(s, s2) {
 					return -1
 				} else {
@@ -51,6 +52,12 @@ func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
 				return fmt.Errorf("error registering natural sort collation: %v", err)
 			}
 
+			// COLLATE LOCALE_CI - Case insensitive natural sort using the
+			// rules of the sort locale
+			if err := conn.RegisterCollation(localeCollation, localeCompare); err != nil {
+				return fmt.Errorf("error registering locale sort collation: %v", err)
+			}
+
 			return nil
 		},
 	}
@@ -0,0 +1,14 @@
+//go:build integration
+// +build integration
+
+package sqlite
+
+// BlobReferences returns the table and column of each blob reference
+// handled by the blob store.
+func BlobReferences() [][2]string {
+	var ret [][2]string
+	for _, r := range blobReferences {
+		ret = append(ret, [2]string{r.table, r.column})
+	}
+	return ret
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"gopkg.in/guregu/null.v4"
 )
 
@@ -29,6 +30,27 @@ const (
 	captionTypeColumn     = "caption_type"
 )
 
+var findExactDuplicateFilesQuery = `
+SELECT dupes.fingerprint, files.id AS file_id, (
+	EXISTS (SELECT 1 FROM scenes_files WHERE scenes_files.file_id = files.id AND scenes_files.` + "`primary`" + ` = 1)
+	OR EXISTS (SELECT 1 FROM images_files WHERE images_files.file_id = files.id AND images_files.` + "`primary`" + ` = 1)
+	OR EXISTS (SELECT 1 FROM galleries_files WHERE galleries_files.file_id = files.id AND galleries_files.` + "`primary`" + ` = 1)
+) AS is_primary
+FROM (
+	SELECT files_fingerprints.fingerprint, SUM(files.size) AS total_size
+	FROM files_fingerprints
+	INNER JOIN files ON (files_fingerprints.file_id = files.id)
+	WHERE files_fingerprints.type = ?
+		AND files.zip_file_id IS NULL
+	GROUP BY files_fingerprints.fingerprint
+	HAVING COUNT(files_fingerprints.file_id) > 1
+) AS dupes
+INNER JOIN files_fingerprints ON (files_fingerprints.fingerprint = dupes.fingerprint AND files_fingerprints.type = ?)
+INNER JOIN files ON (files_fingerprints.file_id = files.id)
+WHERE files.zip_file_id IS NULL
+ORDER BY dupes.total_size DESC, dupes.fingerprint, is_primary DESC, files.id;
+`
+
 type basicFileRow struct {
 	ID             models.FileID   `db:"id" goqu:"skipinsert"`
 	Basename       string          `db:"basename"`
@@ -59,6 +81,8 @@ type videoFileRow struct {
 	Duration         float64       `db:"duration"`
 	VideoCodec       string        `db:"video_codec"`
 	AudioCodec       string        `db:"audio_codec"`
+	AudioLanguages   null.String   `db:"audio_languages"`
+	Interlaced       bool          `db:"interlaced"`
 	FrameRate        float64       `db:"frame_rate"`
 	BitRate          int64         `db:"bit_rate"`
 	Interactive      bool          `db:"interactive"`
@@ -75,6 +99,10 @@ func (f *videoFileRow) fromVideoFile(ff models.VideoFile) {
 	f.Duration = ff.Duration
 	f.VideoCodec = ff.VideoCodec
 	f.AudioCodec = ff.AudioCodec
+	if len(ff.AudioLanguages) > 0 {
+		f.AudioLanguages = null.StringFrom(strings.Join(ff.AudioLanguages, ","))
+	}
+	f.Interlaced = ff.Interlaced
 	f.FrameRate = ff.FrameRate
 	f.BitRate = ff.BitRate
 	f.Interactive = ff.Interactive
@@ -109,6 +137,8 @@ type videoFileQueryRow struct {
 	Duration         null.Float  `db:"duration"`
 	VideoCodec       null.String `db:"video_codec"`
 	AudioCodec       null.String `db:"audio_codec"`
+	AudioLanguages   null.String `db:"audio_languages"`
+	Interlaced       null.Bool   `db:"interlaced"`
 	FrameRate        null.Float  `db:"frame_rate"`
 	BitRate          null.Int    `db:"bit_rate"`
 	Interactive      null.Bool   `db:"interactive"`
@@ -125,11 +155,15 @@ func (f *videoFileQueryRow) resolve() *models.VideoFile {
 		Duration:         f.Duration.Float64,
 		VideoCodec:       f.VideoCodec.String,
 		AudioCodec:       f.AudioCodec.String,
+		Interlaced:       f.Interlaced.Bool,
 		FrameRate:        f.FrameRate.Float64,
 		BitRate:          f.BitRate.Int64,
 		Interactive:      f.Interactive.Bool,
 		InteractiveSpeed: nullIntPtr(f.InteractiveSpeed),
 	}
+	if f.AudioLanguages.String != "" {
+		ret.AudioLanguages = strings.Split(f.AudioLanguages.String, ",")
+	}
 	if f.Threats.Valid {
 		ret.Threats = f.Threats.String
 	}
@@ -147,6 +181,8 @@ func videoFileQueryColumns() []interface{} {
 		table.Col("duration"),
 		table.Col("video_codec"),
 		table.Col("audio_codec"),
+		table.Col("audio_languages"),
+		table.Col("interlaced"),
 		table.Col("frame_rate"),
 		table.Col("bit_rate"),
 		table.Col("interactive"),
@@ -769,6 +805,20 @@ func (qb *FileStore) FindByFingerprint(ctx context.Context, fp models.Fingerprin
 	return qb.findBySubquery(ctx, sq)
 }
 
+// FindVideoFilesBySizeAndDuration finds video files with the given size and
+// a duration within tolerance seconds of the given duration.
+func (qb *FileStore) FindVideoFilesBySizeAndDuration(ctx context.Context, size int64, duration float64, tolerance float64) ([]models.File, error) {
+	table := qb.table()
+	videoFileTable := videoFileTableMgr.table
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("size").Eq(size),
+		videoFileTable.Col("duration").Between(goqu.Range(duration-tolerance, duration+tolerance)),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
 func (qb *FileStore) FindByZipFileID(ctx context.Context, zipFileID models.FileID) ([]models.File, error) {
 	table := qb.table()
 
@@ -855,6 +905,41 @@ func (qb *FileStore) IsPrimary(ctx context.Context, fileID models.FileID) (bool,
 	return ret > 0, nil
 }
 
+type exactDuplicateFileRow struct {
+	Fingerprint string        `db:"fingerprint"`
+	FileID      models.FileID `db:"file_id"`
+	IsPrimary   bool          `db:"is_primary"`
+}
+
+// FindExactDuplicates returns groups of file IDs that share the same
+// fingerprint of the given type. Files inside zip files are not included.
+// Groups are ordered by their total file size, largest first.
+// Within each group, files that are the primary file of a scene, image or
+// gallery are ordered first, followed by the oldest file record.
+func (qb *FileStore) FindExactDuplicates(ctx context.Context, fingerprintType string) ([][]models.FileID, error) {
+	var rows []exactDuplicateFileRow
+	if err := dbWrapper.Select(ctx, &rows, findExactDuplicateFilesQuery, fingerprintType, fingerprintType); err != nil {
+		return nil, fmt.Errorf("finding exact duplicate files: %w", err)
+	}
+
+	var ret [][]models.FileID
+	var current []models.FileID
+	for i, row := range rows {
+		if i > 0 && row.Fingerprint != rows[i-1].Fingerprint {
+			ret = append(ret, current)
+			current = nil
+		}
+
+		current = sliceutil.AppendUnique(current, row.FileID)
+	}
+
+	if len(current) > 0 {
+		ret = append(ret, current)
+	}
+
+	return ret, nil
+}
+
 func (qb *FileStore) validateFilter(fileFilter *models.FileFilterType) error {
 	const and = "AND"
 	const or = "OR"
@@ -1032,6 +1117,7 @@ func (qb *FileStore) setQuerySort(query *queryBuilder, findFilter *models.FindFi
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
+	collation := getCollation(findFilter)
 	sort := findFilter.GetSort("path")
 
 	// CVE-2024-32231 - ensure sort is in the list of allowed sorts
@@ -1045,7 +1131,7 @@ func (qb *FileStore) setQuerySort(query *queryBuilder, findFilter *models.FindFi
 		// special handling for path
 		query.sortAndPagination += fmt.Sprintf(" ORDER BY folders.path %s, files.basename %[1]s", direction)
 	default:
-		query.sortAndPagination += getSort(sort, direction, "files")
+		query.sortAndPagination += getSort(sort, direction, "files", collation)
 	}
 
 	return nil
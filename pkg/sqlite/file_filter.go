@@ -266,11 +266,19 @@ func (qb *videoFileFilterHandler) criterionHandler() criterionHandler {
 		intCriterionHandler(videoFileFilter.Bitrate, "video_files.bit_rate", qb.addVideoFilesTable),
 		qb.codecCriterionHandler(videoFileFilter.VideoCodec, "video_files.video_codec", qb.addVideoFilesTable),
 		qb.codecCriterionHandler(videoFileFilter.AudioCodec, "video_files.audio_codec", qb.addVideoFilesTable),
+		delimitedStringCriterionHandler(videoFileFilter.AudioLanguage, "video_files.audio_languages", qb.addVideoFilesTable),
 
 		boolCriterionHandler(videoFileFilter.Interactive, "video_files.interactive", qb.addVideoFilesTable),
 		intCriterionHandler(videoFileFilter.InteractiveSpeed, "video_files.interactive_speed", qb.addVideoFilesTable),
 
 		qb.captionCriterionHandler(videoFileFilter.Captions),
+
+		threatCriterionHandler(threatFilter{
+			hasThreats:    videoFileFilter.HasThreats,
+			severity:      videoFileFilter.ThreatSeverity,
+			scannedBefore: videoFileFilter.ThreatsScannedBefore,
+			neverScanned:  videoFileFilter.ThreatsNeverScanned,
+		}, videoFileWhere, qb.addVideoFilesTable),
 	}
 }
 
@@ -662,3 +662,58 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_FindExactDuplicates(t *testing.T) {
+	runWithRollbackTxn(t, "exact duplicates", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.File
+
+		newFile := func(basename string, size int64, md5 string, zipFileID *models.FileID) models.FileID {
+			f := &models.BaseFile{
+				DirEntry: models.DirEntry{
+					ZipFileID: zipFileID,
+				},
+				Path:           getFilePath(folderIdxWithFiles, basename),
+				ParentFolderID: folderIDs[folderIdxWithFiles],
+				Basename:       basename,
+				Size:           size,
+				Fingerprints: []models.Fingerprint{
+					{
+						Type:        models.FingerprintTypeMD5,
+						Fingerprint: md5,
+					},
+				},
+			}
+
+			if err := qb.Create(ctx, f); err != nil {
+				t.Fatalf("FileStore.Create() error = %v", err)
+			}
+
+			return f.ID
+		}
+
+		small1 := newFile("dupe_small_1", 10, "dupe-small", nil)
+		small2 := newFile("dupe_small_2", 10, "dupe-small", nil)
+		// files in zip files are excluded
+		newFile("dupe_small_zip", 10, "dupe-small", &fileIDs[fileIdxZip])
+		large1 := newFile("dupe_large_1", 100, "dupe-large", nil)
+		large2 := newFile("dupe_large_2", 100, "dupe-large", nil)
+		newFile("unique", 1000, "unique", nil)
+
+		// primary files are ordered first
+		if err := db.Scene.Create(ctx, &models.Scene{}, []models.FileID{small2}); err != nil {
+			t.Fatalf("SceneStore.Create() error = %v", err)
+		}
+
+		got, err := qb.FindExactDuplicates(ctx, models.FingerprintTypeMD5)
+		if err != nil {
+			t.Errorf("FileStore.FindExactDuplicates() error = %v", err)
+			return
+		}
+
+		assert.Equal([][]models.FileID{
+			{large1, large2},
+			{small2, small1},
+		}, got)
+	})
+}
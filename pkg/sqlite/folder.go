@@ -527,6 +527,7 @@ func (qb *FolderStore) setQuerySort(query *queryBuilder, findFilter *models.Find
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
+	collation := getCollation(findFilter)
 	sort := findFilter.GetSort("path")
 
 	// CVE-2024-32231 - ensure sort is in the list of allowed sorts
@@ -535,7 +536,7 @@ func (qb *FolderStore) setQuerySort(query *queryBuilder, findFilter *models.Find
 	}
 
 	direction := findFilter.GetDirection()
-	query.sortAndPagination += getSort(sort, direction, "folders")
+	query.sortAndPagination += getSort(sort, direction, "folders", collation)
 
 	return nil
 }
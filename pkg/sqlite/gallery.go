@@ -47,6 +47,7 @@ type galleryRow struct {
 	Rating      null.Int  `db:"rating"`
 	Organized   bool      `db:"organized"`
 	Pinned      bool      `db:"pinned"`
+	Locked      bool      `db:"locked"`
 	OCounter    int       `db:"o_counter"`
 	OmegCounter int       `db:"omg_counter"`
 	DisplayMode null.Int  `db:"display_mode"`
@@ -66,6 +67,7 @@ func (r *galleryRow) fromGallery(o models.Gallery) {
 	r.Rating = intFromPtr(o.Rating)
 	r.Organized = o.Organized
 	r.Pinned = o.Pinned
+	r.Locked = o.Locked
 	r.OCounter = o.OCounter
 	r.OmegCounter = o.OmegCounter
 	r.DisplayMode = intFromValue(o.DisplayMode)
@@ -95,6 +97,7 @@ func (r *galleryQueryRow) resolve() *models.Gallery {
 		Rating:        nullIntPtr(r.Rating),
 		Organized:     r.Organized,
 		Pinned:        r.Pinned,
+		Locked:        r.Locked,
 		OCounter:      r.OCounter,
 		OmegCounter:   r.OmegCounter,
 		DisplayMode:   int(r.DisplayMode.Int64),
@@ -127,6 +130,7 @@ func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
 	r.setBool("pinned", o.Pinned)
+	r.setBool("locked", o.Locked)
 	r.setInt("omg_counter", o.OmegCounter)
 	r.setNullInt("display_mode", o.DisplayMode)
 	r.setNullInt("studio_id", o.StudioID)
@@ -821,6 +825,8 @@ var gallerySortOptions = sortOptions{
 }
 
 func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	collation := getCollation(findFilter)
+
 	sort := "created_at"
 	direction := "DESC"
 
@@ -834,6 +840,11 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		return err
 	}
 
+	thenBy, err := galleryThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return err
+	}
+
 	// Define helper functions for adding joins
 	addFileTable := func() {
 		query.addJoins(
@@ -877,18 +888,20 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		} else if sort == "file_mod_time" {
 			sortCol := "mod_time"
 			addFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, fileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, fileTable, collation)
 		} else if sort == "path" {
 			// special handling for path
 			addFileTable()
 			addFolderTable()
 			query.sortAndPagination += fmt.Sprintf(", COALESCE(folders.path, '') || COALESCE(file_folder.path, '') || COALESCE(files.basename, '') COLLATE NATURAL_CI %s", direction)
 		} else {
-			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, "galleries")
+			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, "galleries", collation)
 		}
 
+		query.sortAndPagination += thenBy
+
 		// Add title as final sort
-		query.sortAndPagination += ", COALESCE(galleries.title, galleries.id) COLLATE NATURAL_CI ASC"
+		query.sortAndPagination += ", COALESCE(galleries.title, galleries.id) COLLATE " + collation + " ASC"
 		return nil
 	}
 
@@ -910,7 +923,7 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 	case "o_counter":
 		query.sortAndPagination += getCountSort(galleryTable, galleriesODatesTable, galleryIDColumn, direction)
 	case "omg_counter":
-		query.sortAndPagination += getSort(sort, direction, "galleries")
+		query.sortAndPagination += getSort(sort, direction, "galleries", collation)
 	case "path":
 		// special handling for path
 		addFileTable()
@@ -919,17 +932,19 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 	case "file_mod_time":
 		sort = "mod_time"
 		addFileTable()
-		query.sortAndPagination += getSort(sort, direction, fileTable)
+		query.sortAndPagination += getSort(sort, direction, fileTable, collation)
 	case "title":
 		addFileTable()
 		addFolderTable()
-		query.sortAndPagination += " ORDER BY COALESCE(galleries.title, files.basename, basename(COALESCE(folders.path, ''))) COLLATE NATURAL_CI " + direction + ", file_folder.path COLLATE NATURAL_CI " + direction
+		query.sortAndPagination += " ORDER BY COALESCE(galleries.title, files.basename, basename(COALESCE(folders.path, ''))) COLLATE " + collation + " " + direction + ", file_folder.path COLLATE NATURAL_CI " + direction
 	default:
-		query.sortAndPagination += getSort(sort, direction, "galleries")
+		query.sortAndPagination += getSort(sort, direction, "galleries", collation)
 	}
 
+	query.sortAndPagination += thenBy
+
 	// Whatever the sorting, always use title/id as a final sort
-	query.sortAndPagination += ", COALESCE(galleries.title, galleries.id) COLLATE NATURAL_CI ASC"
+	query.sortAndPagination += ", COALESCE(galleries.title, galleries.id) COLLATE " + collation + " ASC"
 
 	return nil
 }
@@ -976,6 +991,29 @@ func (qb *GalleryStore) ResetCover(ctx context.Context, galleryID int) error {
 	return imageGalleriesTableMgr.resetCover(ctx, galleryID)
 }
 
+// ReorderImages moves the images to before or after the insertion point image.
+// Images of the gallery that have not been manually ordered are first given an
+// order index based on the default gallery order.
+func (qb *GalleryStore) ReorderImages(ctx context.Context, galleryID int, imageIDs []int, insertPointID int, insertAfter bool) error {
+	if err := imageGalleriesTableMgr.initOrderIndexes(ctx, galleryID); err != nil {
+		return err
+	}
+
+	for _, id := range imageIDs {
+		if err := imageGalleriesTableMgr.reorderImage(ctx, galleryID, id, insertPointID, insertAfter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetImageOrderIndex returns the manual order index of the image in the
+// gallery, or nil if the image has not been manually ordered.
+func (qb *GalleryStore) GetImageOrderIndex(ctx context.Context, galleryID int, imageID int) (*int, error) {
+	return imageGalleriesTableMgr.getOrderIndex(ctx, imageID, galleryID)
+}
+
 func (qb *GalleryStore) GetSceneIDs(ctx context.Context, id int) ([]int, error) {
 	return galleryRepository.scenes.getIDs(ctx, id)
 }
@@ -1101,7 +1139,7 @@ func (qb *GalleryStore) GetAggregatedViewHistory(ctx context.Context, page, perP
 				MIN(gvd.view_date) as earliest_view_date,
 				MAX(gvd.view_date) as latest_view_date
 			FROM galleries_view_dates gvd
-			GROUP BY gvd.gallery_id, DATE(gvd.view_date)
+			GROUP BY gvd.gallery_id, ` + localDate("gvd.view_date") + `
 			ORDER BY MAX(gvd.view_date) DESC
 		) gv
 	`
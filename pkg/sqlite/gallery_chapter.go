@@ -10,6 +10,7 @@ import (
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -19,12 +20,13 @@ const (
 )
 
 type galleryChapterRow struct {
-	ID         int       `db:"id" goqu:"skipinsert"`
-	Title      string    `db:"title"` // TODO: make db schema (and gql schema) nullable
-	ImageIndex int       `db:"image_index"`
-	GalleryID  int       `db:"gallery_id"`
-	CreatedAt  Timestamp `db:"created_at"`
-	UpdatedAt  Timestamp `db:"updated_at"`
+	ID          int       `db:"id" goqu:"skipinsert"`
+	Title       string    `db:"title"` // TODO: make db schema (and gql schema) nullable
+	ImageIndex  int       `db:"image_index"`
+	GalleryID   int       `db:"gallery_id"`
+	DisplayMode null.Int  `db:"display_mode"`
+	CreatedAt   Timestamp `db:"created_at"`
+	UpdatedAt   Timestamp `db:"updated_at"`
 }
 
 func (r *galleryChapterRow) fromGalleryChapter(o models.GalleryChapter) {
@@ -32,18 +34,20 @@ func (r *galleryChapterRow) fromGalleryChapter(o models.GalleryChapter) {
 	r.Title = o.Title
 	r.ImageIndex = o.ImageIndex
 	r.GalleryID = o.GalleryID
+	r.DisplayMode = intFromPtr(o.DisplayMode)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 }
 
 func (r *galleryChapterRow) resolve() *models.GalleryChapter {
 	ret := &models.GalleryChapter{
-		ID:         r.ID,
-		Title:      r.Title,
-		ImageIndex: r.ImageIndex,
-		GalleryID:  r.GalleryID,
-		CreatedAt:  r.CreatedAt.Timestamp,
-		UpdatedAt:  r.UpdatedAt.Timestamp,
+		ID:          r.ID,
+		Title:       r.Title,
+		ImageIndex:  r.ImageIndex,
+		GalleryID:   r.GalleryID,
+		DisplayMode: nullIntPtr(r.DisplayMode),
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
 	}
 
 	return ret
@@ -62,6 +66,7 @@ func (r *galleryChapterRowRecord) fromPartial(o models.GalleryChapterPartial) {
 	}
 	r.setInt("image_index", o.ImageIndex)
 	r.setInt("gallery_id", o.GalleryID)
+	r.setNullInt("display_mode", o.DisplayMode)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
 }
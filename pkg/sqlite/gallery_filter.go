@@ -91,6 +91,7 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		qb.urlsCriterionHandler(filter.URL),
 		boolCriterionHandler(filter.Organized, "galleries.organized", nil),
 		boolCriterionHandler(filter.Pinned, "galleries.pinned", nil),
+		boolCriterionHandler(filter.Locked, "galleries.locked", nil),
 		qb.missingCriterionHandler(filter.IsMissing),
 		qb.tagsCriterionHandler(filter.Tags),
 		qb.tagCountCriterionHandler(filter.TagCount),
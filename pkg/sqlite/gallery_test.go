@@ -3001,6 +3001,47 @@ func TestGallerySetAndResetCover(t *testing.T) {
 	})
 }
 
+func TestGalleryReorderImages(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		sqb := db.Gallery
+		galleryID := galleryIDs[galleryIdxWithTwoImages]
+		image1ID := imageIDs[imageIdx1WithGallery]
+		image2ID := imageIDs[imageIdx2WithGallery]
+
+		index, err := sqb.GetImageOrderIndex(ctx, galleryID, image1ID)
+		assert.Nil(t, err)
+		assert.Nil(t, index)
+
+		// move the second image before the first
+		err = sqb.ReorderImages(ctx, galleryID, []int{image2ID}, image1ID, false)
+		assert.Nil(t, err)
+
+		index1, err := sqb.GetImageOrderIndex(ctx, galleryID, image1ID)
+		assert.Nil(t, err)
+		index2, err := sqb.GetImageOrderIndex(ctx, galleryID, image2ID)
+		assert.Nil(t, err)
+
+		if assert.NotNil(t, index1) && assert.NotNil(t, index2) {
+			assert.Less(t, *index2, *index1)
+		}
+
+		// and back after it
+		err = sqb.ReorderImages(ctx, galleryID, []int{image2ID}, image1ID, true)
+		assert.Nil(t, err)
+
+		index1, err = sqb.GetImageOrderIndex(ctx, galleryID, image1ID)
+		assert.Nil(t, err)
+		index2, err = sqb.GetImageOrderIndex(ctx, galleryID, image2ID)
+		assert.Nil(t, err)
+
+		if assert.NotNil(t, index1) && assert.NotNil(t, index2) {
+			assert.Greater(t, *index2, *index1)
+		}
+
+		return nil
+	})
+}
+
 // TODO Count
 // TODO All
 // TODO Query
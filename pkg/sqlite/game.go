@@ -480,6 +480,14 @@ func (qb *GameStore) DeleteAllViews(ctx context.Context, id int) (int, error) {
 	return qb.viewDateManager.DeleteAllViews(ctx, id)
 }
 
+func (qb *GameStore) DeleteViewsInRange(ctx context.Context, id int, start, end time.Time) (int, error) {
+	return qb.viewDateManager.DeleteViewsInRange(ctx, id, start, end)
+}
+
+func (qb *GameStore) DeleteViewsBefore(ctx context.Context, before time.Time) (int, error) {
+	return qb.viewDateManager.DeleteViewsBefore(ctx, before)
+}
+
 func (qb *GameStore) GetODates(ctx context.Context, id int) ([]time.Time, error) {
 	return qb.oDateManager.GetODates(ctx, id)
 }
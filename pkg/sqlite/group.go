@@ -494,6 +494,8 @@ var groupSortOptions = sortOptions{
 }
 
 func (qb *GroupStore) setGroupSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	collation := getCollation(findFilter)
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -509,27 +511,34 @@ func (qb *GroupStore) setGroupSort(query *queryBuilder, findFilter *models.FindF
 		return err
 	}
 
+	thenBy, err := groupThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return err
+	}
+
 	switch sort {
 	case "sub_group_order":
 		// sub_group_order is a special sort that sorts by the order_index of the subgroups
 		if query.hasJoin("groups_parents") {
-			query.sortAndPagination += getSort("order_index", direction, "groups_parents")
+			query.sortAndPagination += getSort("order_index", direction, "groups_parents", collation)
 		} else {
 			// this will give unexpected results if the query is not filtered by a parent group and
 			// the group has multiple parents and order indexes
 			query.join(groupRelationsTable, "", "groups.id = groups_relations.sub_id")
-			query.sortAndPagination += getSort("order_index", direction, groupRelationsTable)
+			query.sortAndPagination += getSort("order_index", direction, groupRelationsTable, collation)
 		}
 	case "tag_count":
 		query.sortAndPagination += getCountSort(groupTable, groupsTagsTable, groupIDColumn, direction)
 	case "scenes_count": // generic getSort won't work for this
 		query.sortAndPagination += getCountSort(groupTable, groupsScenesTable, groupIDColumn, direction)
 	default:
-		query.sortAndPagination += getSort(sort, direction, "groups")
+		query.sortAndPagination += getSort(sort, direction, "groups", collation)
 	}
 
+	query.sortAndPagination += thenBy
+
 	// Whatever the sorting, always use name/id as a final sort
-	query.sortAndPagination += ", COALESCE(groups.name, groups.id) COLLATE NATURAL_CI ASC"
+	query.sortAndPagination += ", COALESCE(groups.name, groups.id) COLLATE " + collation + " ASC"
 	return nil
 }
 
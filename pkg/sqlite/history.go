@@ -15,6 +15,14 @@ func (qb *viewDateManager) GetViewDates(ctx context.Context, id int) ([]time.Tim
 	return qb.tableMgr.getDates(ctx, id)
 }
 
+func (qb *viewDateManager) GetViewDatesInRange(ctx context.Context, start, end time.Time) ([]time.Time, error) {
+	return qb.tableMgr.getDatesInRange(ctx, start, end)
+}
+
+func (qb *viewDateManager) GetViewEventsInRange(ctx context.Context, start, end time.Time) ([]models.HistoryEvent, error) {
+	return qb.tableMgr.getEventsInRange(ctx, start, end)
+}
+
 func (qb *viewDateManager) GetManyViewDates(ctx context.Context, ids []int) ([][]time.Time, error) {
 	return qb.tableMgr.getManyDates(ctx, ids)
 }
@@ -65,7 +73,7 @@ func (qb *viewDateManager) GetAggregatedViewHistory(ctx context.Context, page, p
 				MIN(svd.view_date) as earliest_view_date,
 				MAX(svd.view_date) as latest_view_date
 			FROM scenes_view_dates svd
-			GROUP BY svd.scene_id, DATE(svd.view_date)
+			GROUP BY svd.scene_id, ` + localDate("svd.view_date") + `
 			ORDER BY MAX(svd.view_date) DESC
 		) gv
 	`
@@ -191,6 +199,14 @@ func (qb *viewDateManager) DeleteAllViews(ctx context.Context, id int) (int, err
 	return qb.tableMgr.deleteAllDates(ctx, id)
 }
 
+func (qb *viewDateManager) DeleteViewsInRange(ctx context.Context, id int, start, end time.Time) (int, error) {
+	return qb.tableMgr.deleteDatesInRange(ctx, id, start, end)
+}
+
+func (qb *viewDateManager) DeleteViewsBefore(ctx context.Context, before time.Time) (int, error) {
+	return qb.tableMgr.deleteDatesBefore(ctx, before)
+}
+
 type oDateManager struct {
 	tableMgr *viewHistoryTable
 }
@@ -235,6 +251,10 @@ func (qb *oDateManager) GetODatesInRange(ctx context.Context, start, end time.Ti
 	return qb.tableMgr.getDatesInRange(ctx, start, end)
 }
 
+func (qb *oDateManager) GetOEventsInRange(ctx context.Context, start, end time.Time) ([]models.HistoryEvent, error) {
+	return qb.tableMgr.getEventsInRange(ctx, start, end)
+}
+
 type omgDateManager struct {
 	tableMgr *viewHistoryTable
 }
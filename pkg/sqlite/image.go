@@ -957,6 +957,7 @@ var imageSortOptions = sortOptions{
 	"file_count",
 	"file_mod_time",
 	"filesize",
+	"gallery_order",
 	"id",
 	"o_counter",
 	"omg_counter",
@@ -970,6 +971,8 @@ var imageSortOptions = sortOptions{
 }
 
 func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *models.FindFilterType) error {
+	collation := getCollation(findFilter)
+
 	sortClause := ""
 
 	if findFilter != nil && findFilter.Sort != nil && *findFilter.Sort != "" {
@@ -1011,6 +1014,16 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			addFilesJoin()
 			addFolderJoin()
 			sortClause = " ORDER BY COALESCE(folders.path, '') || COALESCE(files.basename, '') COLLATE NATURAL_CI " + direction
+		case "gallery_order":
+			// images that have not been manually ordered are sorted by path after
+			// the ordered images
+			q.addJoins(join{
+				table:    galleriesImagesTable,
+				onClause: "galleries_images.image_id = images.id",
+			})
+			addFilesJoin()
+			addFolderJoin()
+			sortClause = " ORDER BY galleries_images.order_index IS NULL, galleries_images.order_index " + direction + ", COALESCE(folders.path, '') || COALESCE(files.basename, '') COLLATE NATURAL_CI " + direction
 		case "file_count":
 			sortClause = getCountSort(imageTable, imagesFilesTable, imageIDColumn, direction)
 		case "tag_count":
@@ -1018,20 +1031,26 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 		case "performer_count":
 			sortClause = getCountSort(imageTable, performersImagesTable, imageIDColumn, direction)
 		case "o_counter", "omg_counter":
-			sortClause = getSort(sort, direction, "images")
+			sortClause = getSort(sort, direction, "images", collation)
 		case "mod_time", "filesize":
 			addFilesJoin()
-			sortClause = getSort(sort, direction, "files")
+			sortClause = getSort(sort, direction, "files", collation)
 		case "title":
 			addFilesJoin()
 			addFolderJoin()
-			sortClause = " ORDER BY COALESCE(images.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+			sortClause = " ORDER BY COALESCE(images.title, files.basename) COLLATE " + collation + " " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 		default:
-			sortClause = getSort(sort, direction, "images")
+			sortClause = getSort(sort, direction, "images", collation)
+		}
+
+		thenBy, err := imageThenBySorts.getThenBySort(findFilter, collation)
+		if err != nil {
+			return err
 		}
+		sortClause += thenBy
 
 		// Whatever the sorting, always use title/id as a final sort
-		sortClause += ", COALESCE(images.title, images.id) COLLATE NATURAL_CI ASC"
+		sortClause += ", COALESCE(images.title, images.id) COLLATE " + collation + " ASC"
 	}
 
 	q.sortAndPagination = sortClause + getPagination(findFilter)
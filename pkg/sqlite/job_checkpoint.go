@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	jobCheckpointTable = "job_checkpoints"
+)
+
+type jobCheckpointRow struct {
+	ID          int          `db:"id" goqu:"skipinsert"`
+	Kind        string       `db:"kind"`
+	Description string       `db:"description"`
+	State       []byte       `db:"state"`
+	CreatedAt   UTCTimestamp `db:"created_at"`
+}
+
+func (r *jobCheckpointRow) fromJobCheckpoint(o models.JobCheckpoint) {
+	r.ID = o.ID
+	r.Kind = o.Kind
+	r.Description = o.Description
+	r.State = o.State
+	r.CreatedAt = UTCTimestamp{Timestamp{Timestamp: o.CreatedAt}}
+}
+
+func (r *jobCheckpointRow) resolve() *models.JobCheckpoint {
+	return &models.JobCheckpoint{
+		ID:          r.ID,
+		Kind:        r.Kind,
+		Description: r.Description,
+		State:       r.State,
+		CreatedAt:   r.CreatedAt.Timestamp.Timestamp,
+	}
+}
+
+type JobCheckpointStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewJobCheckpointStore() *JobCheckpointStore {
+	return &JobCheckpointStore{
+		repository: repository{
+			tableName: jobCheckpointTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: jobCheckpointTableMgr,
+	}
+}
+
+func (qb *JobCheckpointStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *JobCheckpointStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *JobCheckpointStore) Create(ctx context.Context, newObject *models.JobCheckpoint) error {
+	var r jobCheckpointRow
+	r.fromJobCheckpoint(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *JobCheckpointStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *JobCheckpointStore) All(ctx context.Context) ([]*models.JobCheckpoint, error) {
+	q := qb.selectDataset().Order(qb.table().Col(idColumn).Asc())
+
+	const single = false
+	var ret []*models.JobCheckpoint
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f jobCheckpointRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting %s: %w", jobCheckpointTable, err)
+	}
+
+	return ret, nil
+}
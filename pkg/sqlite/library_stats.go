@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	libraryStatsTable = "library_stats"
+
+	// library_stats has a single row, which is created by its migration
+	libraryStatsID = 1
+)
+
+type libraryStatsRow struct {
+	SceneCount        int           `db:"scene_count"`
+	ScenesSize        float64       `db:"scenes_size"`
+	ScenesDuration    float64       `db:"scenes_duration"`
+	ImageCount        int           `db:"image_count"`
+	ImagesSize        float64       `db:"images_size"`
+	GalleryCount      int           `db:"gallery_count"`
+	PerformerCount    int           `db:"performer_count"`
+	StudioCount       int           `db:"studio_count"`
+	GroupCount        int           `db:"group_count"`
+	TagCount          int           `db:"tag_count"`
+	TotalOCount       int           `db:"total_o_count"`
+	TotalOMGCount     int           `db:"total_omg_count"`
+	TotalPlayDuration float64       `db:"total_play_duration"`
+	TotalPlayCount    int           `db:"total_play_count"`
+	ScenesPlayed      int           `db:"scenes_played"`
+	ComputedAt        NullTimestamp `db:"computed_at"`
+}
+
+func (r *libraryStatsRow) fromLibraryStats(o models.LibraryStats) {
+	r.SceneCount = o.SceneCount
+	r.ScenesSize = o.ScenesSize
+	r.ScenesDuration = o.ScenesDuration
+	r.ImageCount = o.ImageCount
+	r.ImagesSize = o.ImagesSize
+	r.GalleryCount = o.GalleryCount
+	r.PerformerCount = o.PerformerCount
+	r.StudioCount = o.StudioCount
+	r.GroupCount = o.GroupCount
+	r.TagCount = o.TagCount
+	r.TotalOCount = o.TotalOCount
+	r.TotalOMGCount = o.TotalOMGCount
+	r.TotalPlayDuration = o.TotalPlayDuration
+	r.TotalPlayCount = o.TotalPlayCount
+	r.ScenesPlayed = o.ScenesPlayed
+	r.ComputedAt = NullTimestampFromTimePtr(o.ComputedAt)
+}
+
+func (r *libraryStatsRow) resolve() *models.LibraryStats {
+	return &models.LibraryStats{
+		SceneCount:        r.SceneCount,
+		ScenesSize:        r.ScenesSize,
+		ScenesDuration:    r.ScenesDuration,
+		ImageCount:        r.ImageCount,
+		ImagesSize:        r.ImagesSize,
+		GalleryCount:      r.GalleryCount,
+		PerformerCount:    r.PerformerCount,
+		StudioCount:       r.StudioCount,
+		GroupCount:        r.GroupCount,
+		TagCount:          r.TagCount,
+		TotalOCount:       r.TotalOCount,
+		TotalOMGCount:     r.TotalOMGCount,
+		TotalPlayDuration: r.TotalPlayDuration,
+		TotalPlayCount:    r.TotalPlayCount,
+		ScenesPlayed:      r.ScenesPlayed,
+		ComputedAt:        r.ComputedAt.TimePtr(),
+	}
+}
+
+// LibraryStatsStore stores the library statistics. The statistics are
+// maintained by triggers as the library changes.
+type LibraryStatsStore struct {
+	tableMgr *table
+}
+
+func NewLibraryStatsStore() *LibraryStatsStore {
+	return &LibraryStatsStore{
+		tableMgr: libraryStatsTableMgr,
+	}
+}
+
+func (qb *LibraryStatsStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *LibraryStatsStore) Get(ctx context.Context) (*models.LibraryStats, error) {
+	q := dialect.From(qb.table()).Select(qb.table().All()).Where(qb.tableMgr.byID(libraryStatsID))
+
+	const single = true
+	var ret *models.LibraryStats
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f libraryStatsRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = f.resolve()
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting %s: %w", libraryStatsTable, err)
+	}
+
+	if ret == nil {
+		return nil, errors.New("library statistics row is missing")
+	}
+
+	return ret, nil
+}
+
+func (qb *LibraryStatsStore) Set(ctx context.Context, stats models.LibraryStats) error {
+	var r libraryStatsRow
+	r.fromLibraryStats(stats)
+
+	q := dialect.Update(qb.table()).Set(r).Where(qb.tableMgr.byID(libraryStatsID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", libraryStatsTable, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// maintained statistics must match the statistics computed from the library
+func TestLibraryStatsMaintained(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		if _, err := createScene(ctx, 640, 480); err != nil {
+			t.Errorf("Error creating scene: %s", err.Error())
+			return nil
+		}
+
+		got, err := db.LibraryStats.Get(ctx)
+		if err != nil {
+			t.Errorf("Error getting library stats: %s", err.Error())
+			return nil
+		}
+
+		sceneCount, _ := db.Scene.Count(ctx)
+		scenesSize, _ := db.Scene.Size(ctx)
+		scenesDuration, _ := db.Scene.Duration(ctx)
+		imageCount, _ := db.Image.Count(ctx)
+		imagesSize, _ := db.Image.Size(ctx)
+		galleryCount, _ := db.Gallery.Count(ctx)
+		performerCount, _ := db.Performer.Count(ctx)
+		studioCount, _ := db.Studio.Count(ctx)
+		groupCount, _ := db.Group.Count(ctx)
+		tagCount, _ := db.Tag.Count(ctx)
+		playDuration, _ := db.Scene.PlayDuration(ctx)
+		playCount, _ := db.Scene.CountAllViews(ctx)
+		scenesPlayed, _ := db.Scene.CountUniqueViews(ctx)
+
+		assert.Equal(t, sceneCount, got.SceneCount)
+		assert.InDelta(t, scenesSize, got.ScenesSize, 0.001)
+		assert.InDelta(t, scenesDuration, got.ScenesDuration, 0.001)
+		assert.Equal(t, imageCount, got.ImageCount)
+		assert.InDelta(t, imagesSize, got.ImagesSize, 0.001)
+		assert.Equal(t, galleryCount, got.GalleryCount)
+		assert.Equal(t, performerCount, got.PerformerCount)
+		assert.Equal(t, studioCount, got.StudioCount)
+		assert.Equal(t, groupCount, got.GroupCount)
+		assert.Equal(t, tagCount, got.TagCount)
+		assert.InDelta(t, playDuration, got.TotalPlayDuration, 0.001)
+		assert.Equal(t, playCount, got.TotalPlayCount)
+		assert.Equal(t, scenesPlayed, got.ScenesPlayed)
+
+		return nil
+	})
+}
+
+func TestLibraryStatsSet(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		computedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		stats := models.LibraryStats{
+			SceneCount:   1,
+			ScenesSize:   2,
+			TagCount:     3,
+			ScenesPlayed: 4,
+			ComputedAt:   &computedAt,
+		}
+
+		if err := db.LibraryStats.Set(ctx, stats); err != nil {
+			t.Errorf("Error setting library stats: %s", err.Error())
+			return nil
+		}
+
+		got, err := db.LibraryStats.Get(ctx)
+		if err != nil {
+			t.Errorf("Error getting library stats: %s", err.Error())
+			return nil
+		}
+
+		assert.Equal(t, stats.SceneCount, got.SceneCount)
+		assert.Equal(t, stats.ScenesSize, got.ScenesSize)
+		assert.Equal(t, stats.TagCount, got.TagCount)
+		assert.Equal(t, stats.ScenesPlayed, got.ScenesPlayed)
+		if assert.NotNil(t, got.ComputedAt) {
+			assert.True(t, computedAt.Equal(*got.ComputedAt))
+		}
+
+		return nil
+	})
+}
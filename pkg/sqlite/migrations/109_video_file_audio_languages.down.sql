@@ -0,0 +1,2 @@
+-- Note: SQLite doesn't support DROP COLUMN directly.
+-- The column `audio_languages` will remain in the table but will be ignored.
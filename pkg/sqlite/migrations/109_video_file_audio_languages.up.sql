@@ -0,0 +1,6 @@
+PRAGMA foreign_keys=OFF;
+
+-- comma-separated list of audio stream languages
+ALTER TABLE `video_files` ADD COLUMN `audio_languages` TEXT;
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,2 @@
+-- Note: SQLite doesn't support DROP COLUMN directly.
+-- The column `transcode_args` will remain in the table but will be ignored.
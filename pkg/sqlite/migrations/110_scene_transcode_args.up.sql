@@ -0,0 +1,6 @@
+PRAGMA foreign_keys=OFF;
+
+-- JSON object of additional ffmpeg input and output arguments
+ALTER TABLE `scenes` ADD COLUMN `transcode_args` TEXT;
+
+PRAGMA foreign_keys=ON;
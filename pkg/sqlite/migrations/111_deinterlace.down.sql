@@ -0,0 +1,3 @@
+-- Note: SQLite doesn't support DROP COLUMN directly.
+-- The columns `video_files.interlaced` and `scenes.deinterlace` will remain in
+-- the tables but will be ignored.
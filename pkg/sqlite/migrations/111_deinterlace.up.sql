@@ -0,0 +1,9 @@
+PRAGMA foreign_keys=OFF;
+
+-- set during scan from the field order of the video stream
+ALTER TABLE `video_files` ADD COLUMN `interlaced` BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- null uses the detected field order, otherwise forces deinterlacing on or off
+ALTER TABLE `scenes` ADD COLUMN `deinterlace` BOOLEAN;
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS `index_scene_parser_changes_on_batch_id`;
+DROP TABLE IF EXISTS `scene_parser_changes`;
+DROP TABLE IF EXISTS `scene_parser_batches`;
@@ -0,0 +1,20 @@
+CREATE TABLE `scene_parser_batches` (
+  `id` integer not null primary key autoincrement,
+  `pattern` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE TABLE `scene_parser_changes` (
+  `id` integer not null primary key autoincrement,
+  `batch_id` integer not null,
+  `scene_id` integer not null,
+  `field` varchar(255) not null,
+  `value` text,
+  `ids` text,
+  `status` varchar(255) not null,
+  foreign key(`batch_id`) references `scene_parser_batches`(`id`) on delete CASCADE,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_parser_changes_on_batch_id` ON `scene_parser_changes` (`batch_id`);
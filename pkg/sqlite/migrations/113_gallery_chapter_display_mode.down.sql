@@ -0,0 +1 @@
+ALTER TABLE `galleries_chapters` DROP COLUMN `display_mode`;
@@ -0,0 +1,2 @@
+-- null uses the display mode of the gallery
+ALTER TABLE `galleries_chapters` ADD COLUMN `display_mode` integer;
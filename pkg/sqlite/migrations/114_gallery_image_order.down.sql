@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `index_galleries_images_on_gallery_id_order_index`;
+ALTER TABLE `galleries_images` DROP COLUMN `order_index`;
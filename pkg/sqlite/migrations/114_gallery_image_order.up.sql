@@ -0,0 +1,4 @@
+-- null for images that have not been manually ordered within the gallery
+ALTER TABLE `galleries_images` ADD COLUMN `order_index` integer;
+
+CREATE INDEX `index_galleries_images_on_gallery_id_order_index` on `galleries_images` (`gallery_id`, `order_index`);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `index_share_links_on_gallery_id`;
+DROP INDEX IF EXISTS `index_share_links_on_scene_id`;
+DROP INDEX IF EXISTS `index_share_links_on_token`;
+DROP TABLE IF EXISTS `share_links`;
@@ -0,0 +1,17 @@
+CREATE TABLE `share_links` (
+  `id` integer not null primary key autoincrement,
+  `token` varchar(255) not null,
+  `scene_id` integer,
+  `gallery_id` integer,
+  `allow_download` boolean not null default '0',
+  `max_views` integer,
+  `view_count` integer not null default 0,
+  `expires_at` datetime,
+  `created_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_share_links_on_token` ON `share_links` (`token`);
+CREATE INDEX `index_share_links_on_scene_id` ON `share_links` (`scene_id`);
+CREATE INDEX `index_share_links_on_gallery_id` ON `share_links` (`gallery_id`);
@@ -0,0 +1,7 @@
+CREATE TABLE `job_checkpoints` (
+  `id` integer not null primary key autoincrement,
+  `kind` varchar(255) not null,
+  `description` varchar(255) not null,
+  `state` blob not null,
+  `created_at` datetime not null
+);
@@ -0,0 +1,3 @@
+ALTER TABLE `scenes` DROP COLUMN `locked`;
+ALTER TABLE `performers` DROP COLUMN `locked`;
+ALTER TABLE `galleries` DROP COLUMN `locked`;
@@ -0,0 +1,3 @@
+ALTER TABLE `scenes` ADD COLUMN `locked` BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE `performers` ADD COLUMN `locked` BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE `galleries` ADD COLUMN `locked` BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,35 @@
+DROP TRIGGER `library_stats_scenes_insert`;
+DROP TRIGGER `library_stats_scenes_delete`;
+DROP TRIGGER `library_stats_images_insert`;
+DROP TRIGGER `library_stats_images_delete`;
+DROP TRIGGER `library_stats_galleries_insert`;
+DROP TRIGGER `library_stats_galleries_delete`;
+DROP TRIGGER `library_stats_performers_insert`;
+DROP TRIGGER `library_stats_performers_delete`;
+DROP TRIGGER `library_stats_studios_insert`;
+DROP TRIGGER `library_stats_studios_delete`;
+DROP TRIGGER `library_stats_groups_insert`;
+DROP TRIGGER `library_stats_groups_delete`;
+DROP TRIGGER `library_stats_tags_insert`;
+DROP TRIGGER `library_stats_tags_delete`;
+DROP TRIGGER `library_stats_scenes_play_duration`;
+DROP TRIGGER `library_stats_images_o_counter`;
+DROP TRIGGER `library_stats_scenes_files_insert`;
+DROP TRIGGER `library_stats_scenes_files_delete`;
+DROP TRIGGER `library_stats_images_files_insert`;
+DROP TRIGGER `library_stats_images_files_delete`;
+DROP TRIGGER `library_stats_files_size`;
+DROP TRIGGER `library_stats_video_files_duration`;
+DROP TRIGGER `library_stats_scenes_o_dates_insert`;
+DROP TRIGGER `library_stats_scenes_o_dates_delete`;
+DROP TRIGGER `library_stats_galleries_o_dates_insert`;
+DROP TRIGGER `library_stats_galleries_o_dates_delete`;
+DROP TRIGGER `library_stats_scenes_omg_dates_insert`;
+DROP TRIGGER `library_stats_scenes_omg_dates_delete`;
+DROP TRIGGER `library_stats_images_omg_dates_insert`;
+DROP TRIGGER `library_stats_images_omg_dates_delete`;
+DROP TRIGGER `library_stats_galleries_omg_dates_insert`;
+DROP TRIGGER `library_stats_galleries_omg_dates_delete`;
+DROP TRIGGER `library_stats_scenes_view_dates_insert`;
+DROP TRIGGER `library_stats_scenes_view_dates_delete`;
+DROP TABLE `library_stats`;
@@ -0,0 +1,201 @@
+-- library_stats holds the library statistics, maintained by the triggers
+-- below as objects are changed. Changes the triggers cannot follow are
+-- corrected when the statistics are recomputed. computed_at is the time of
+-- the last full computation, and is null until the statistics are first
+-- computed.
+CREATE TABLE `library_stats` (
+  `id` integer not null primary key check (`id` = 1),
+  `scene_count` integer not null default 0,
+  `scenes_size` real not null default 0,
+  `scenes_duration` real not null default 0,
+  `image_count` integer not null default 0,
+  `images_size` real not null default 0,
+  `gallery_count` integer not null default 0,
+  `performer_count` integer not null default 0,
+  `studio_count` integer not null default 0,
+  `group_count` integer not null default 0,
+  `tag_count` integer not null default 0,
+  `total_o_count` integer not null default 0,
+  `total_omg_count` integer not null default 0,
+  `total_play_duration` real not null default 0,
+  `total_play_count` integer not null default 0,
+  `scenes_played` integer not null default 0,
+  `computed_at` datetime
+);
+
+INSERT INTO `library_stats` (`id`) VALUES (1);
+
+CREATE TRIGGER `library_stats_scenes_insert` AFTER INSERT ON `scenes`
+BEGIN
+  UPDATE `library_stats` SET `scene_count` = `scene_count` + 1, `total_play_duration` = `total_play_duration` + COALESCE(NEW.`play_duration`, 0);
+END;
+
+CREATE TRIGGER `library_stats_scenes_delete` AFTER DELETE ON `scenes`
+BEGIN
+  UPDATE `library_stats` SET `scene_count` = `scene_count` - 1, `total_play_duration` = `total_play_duration` - COALESCE(OLD.`play_duration`, 0);
+END;
+
+CREATE TRIGGER `library_stats_images_insert` AFTER INSERT ON `images`
+BEGIN
+  UPDATE `library_stats` SET `image_count` = `image_count` + 1, `total_o_count` = `total_o_count` + NEW.`o_counter`;
+END;
+
+CREATE TRIGGER `library_stats_images_delete` AFTER DELETE ON `images`
+BEGIN
+  UPDATE `library_stats` SET `image_count` = `image_count` - 1, `total_o_count` = `total_o_count` - OLD.`o_counter`;
+END;
+
+CREATE TRIGGER `library_stats_galleries_insert` AFTER INSERT ON `galleries`
+BEGIN
+  UPDATE `library_stats` SET `gallery_count` = `gallery_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_galleries_delete` AFTER DELETE ON `galleries`
+BEGIN
+  UPDATE `library_stats` SET `gallery_count` = `gallery_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_performers_insert` AFTER INSERT ON `performers`
+BEGIN
+  UPDATE `library_stats` SET `performer_count` = `performer_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_performers_delete` AFTER DELETE ON `performers`
+BEGIN
+  UPDATE `library_stats` SET `performer_count` = `performer_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_studios_insert` AFTER INSERT ON `studios`
+BEGIN
+  UPDATE `library_stats` SET `studio_count` = `studio_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_studios_delete` AFTER DELETE ON `studios`
+BEGIN
+  UPDATE `library_stats` SET `studio_count` = `studio_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_groups_insert` AFTER INSERT ON `groups`
+BEGIN
+  UPDATE `library_stats` SET `group_count` = `group_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_groups_delete` AFTER DELETE ON `groups`
+BEGIN
+  UPDATE `library_stats` SET `group_count` = `group_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_tags_insert` AFTER INSERT ON `tags`
+BEGIN
+  UPDATE `library_stats` SET `tag_count` = `tag_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_tags_delete` AFTER DELETE ON `tags`
+BEGIN
+  UPDATE `library_stats` SET `tag_count` = `tag_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_scenes_play_duration` AFTER UPDATE OF `play_duration` ON `scenes`
+BEGIN
+  UPDATE `library_stats` SET `total_play_duration` = `total_play_duration` + COALESCE(NEW.`play_duration`, 0) - COALESCE(OLD.`play_duration`, 0);
+END;
+
+CREATE TRIGGER `library_stats_images_o_counter` AFTER UPDATE OF `o_counter` ON `images`
+BEGIN
+  UPDATE `library_stats` SET `total_o_count` = `total_o_count` + NEW.`o_counter` - OLD.`o_counter`;
+END;
+
+CREATE TRIGGER `library_stats_scenes_files_insert` AFTER INSERT ON `scenes_files`
+BEGIN
+  UPDATE `library_stats` SET `scenes_size` = `scenes_size` + COALESCE((SELECT `size` FROM `files` WHERE `id` = NEW.`file_id`), 0),
+    `scenes_duration` = `scenes_duration` + COALESCE((SELECT `duration` FROM `video_files` WHERE `file_id` = NEW.`file_id`), 0);
+END;
+
+CREATE TRIGGER `library_stats_scenes_files_delete` AFTER DELETE ON `scenes_files`
+BEGIN
+  UPDATE `library_stats` SET `scenes_size` = `scenes_size` - COALESCE((SELECT `size` FROM `files` WHERE `id` = OLD.`file_id`), 0),
+    `scenes_duration` = `scenes_duration` - COALESCE((SELECT `duration` FROM `video_files` WHERE `file_id` = OLD.`file_id`), 0);
+END;
+
+CREATE TRIGGER `library_stats_images_files_insert` AFTER INSERT ON `images_files`
+BEGIN
+  UPDATE `library_stats` SET `images_size` = `images_size` + COALESCE((SELECT `size` FROM `files` WHERE `id` = NEW.`file_id`), 0);
+END;
+
+CREATE TRIGGER `library_stats_images_files_delete` AFTER DELETE ON `images_files`
+BEGIN
+  UPDATE `library_stats` SET `images_size` = `images_size` - COALESCE((SELECT `size` FROM `files` WHERE `id` = OLD.`file_id`), 0);
+END;
+
+CREATE TRIGGER `library_stats_files_size` AFTER UPDATE OF `size` ON `files`
+BEGIN
+  UPDATE `library_stats` SET `scenes_size` = `scenes_size` + (NEW.`size` - OLD.`size`) * (SELECT COUNT(*) FROM `scenes_files` WHERE `file_id` = NEW.`id`),
+    `images_size` = `images_size` + (NEW.`size` - OLD.`size`) * (SELECT COUNT(*) FROM `images_files` WHERE `file_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER `library_stats_video_files_duration` AFTER UPDATE OF `duration` ON `video_files`
+BEGIN
+  UPDATE `library_stats` SET `scenes_duration` = `scenes_duration` + (NEW.`duration` - OLD.`duration`) * (SELECT COUNT(*) FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+END;
+
+CREATE TRIGGER `library_stats_scenes_o_dates_insert` AFTER INSERT ON `scenes_o_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_o_count` = `total_o_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_scenes_o_dates_delete` AFTER DELETE ON `scenes_o_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_o_count` = `total_o_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_galleries_o_dates_insert` AFTER INSERT ON `galleries_o_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_o_count` = `total_o_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_galleries_o_dates_delete` AFTER DELETE ON `galleries_o_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_o_count` = `total_o_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_scenes_omg_dates_insert` AFTER INSERT ON `scenes_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_scenes_omg_dates_delete` AFTER DELETE ON `scenes_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_images_omg_dates_insert` AFTER INSERT ON `images_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_images_omg_dates_delete` AFTER DELETE ON `images_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_galleries_omg_dates_insert` AFTER INSERT ON `galleries_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` + 1;
+END;
+
+CREATE TRIGGER `library_stats_galleries_omg_dates_delete` AFTER DELETE ON `galleries_omg_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_omg_count` = `total_omg_count` - 1;
+END;
+
+CREATE TRIGGER `library_stats_scenes_view_dates_insert` AFTER INSERT ON `scenes_view_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_play_count` = `total_play_count` + 1,
+    `scenes_played` = `scenes_played` + ((SELECT COUNT(*) FROM `scenes_view_dates` WHERE `scene_id` = NEW.`scene_id`) = 1);
+END;
+
+CREATE TRIGGER `library_stats_scenes_view_dates_delete` AFTER DELETE ON `scenes_view_dates`
+BEGIN
+  UPDATE `library_stats` SET `total_play_count` = `total_play_count` - 1,
+    `scenes_played` = `scenes_played` - ((SELECT COUNT(*) FROM `scenes_view_dates` WHERE `scene_id` = OLD.`scene_id`) = 0);
+END;
@@ -0,0 +1,2 @@
+ALTER TABLE `scenes` DROP COLUMN `ocr_text`;
+ALTER TABLE `scenes` DROP COLUMN `ocr_analyzed_at`;
@@ -0,0 +1,3 @@
+-- on-screen text recognized in the frames of the scene
+ALTER TABLE `scenes` ADD COLUMN `ocr_text` TEXT;
+ALTER TABLE `scenes` ADD COLUMN `ocr_analyzed_at` DATETIME;
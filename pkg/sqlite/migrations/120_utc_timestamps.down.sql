@@ -0,0 +1 @@
+-- the times converted to UTC are kept in UTC
@@ -0,0 +1,14 @@
+-- event times are stored in UTC. Convert the times stored with an offset
+-- from UTC, so that they compare and group correctly with the times stored
+-- in UTC.
+UPDATE `scenes_view_dates` SET `view_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) WHERE `view_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) IS NOT NULL;
+UPDATE `scenes_o_dates` SET `o_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) WHERE `o_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) IS NOT NULL;
+UPDATE `scenes_omg_dates` SET `omg_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) WHERE `omg_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) IS NOT NULL;
+UPDATE `images_omg_dates` SET `omg_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) WHERE `omg_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) IS NOT NULL;
+UPDATE `galleries_view_dates` SET `view_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) WHERE `view_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) IS NOT NULL;
+UPDATE `galleries_o_dates` SET `o_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) WHERE `o_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) IS NOT NULL;
+UPDATE `galleries_omg_dates` SET `omg_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) WHERE `omg_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) IS NOT NULL;
+UPDATE `games_view_dates` SET `view_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) WHERE `view_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `view_date`) IS NOT NULL;
+UPDATE `games_o_dates` SET `o_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) WHERE `o_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `o_date`) IS NOT NULL;
+UPDATE `games_omg_dates` SET `omg_date` = strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) WHERE `omg_date` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `omg_date`) IS NOT NULL;
+UPDATE `job_checkpoints` SET `created_at` = strftime('%Y-%m-%dT%H:%M:%SZ', `created_at`) WHERE `created_at` NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', `created_at`) IS NOT NULL;
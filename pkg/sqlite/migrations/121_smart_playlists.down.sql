@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `index_smart_playlists_on_name`;
+DROP TABLE IF EXISTS `smart_playlists`;
@@ -0,0 +1,16 @@
+-- smart playlists are played with the scenes matching scene_filter, which
+-- is stored as json
+CREATE TABLE `smart_playlists` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `scene_filter` text,
+  `sort` varchar(255),
+  `sort_direction` varchar(10) not null default 'ASC',
+  `max_item_duration` integer,
+  `use_markers` boolean not null default '0',
+  `skip_watched` boolean not null default '0',
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_smart_playlists_on_name` ON `smart_playlists` (`name`);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `index_scenes_extras_on_scene_id`;
+DROP TABLE IF EXISTS `scenes_extras`;
@@ -0,0 +1,11 @@
+-- extras are auxiliary files of a scene, such as trailers. They are not in
+-- scenes_files, so they are excluded from the duration and size of scenes.
+CREATE TABLE `scenes_extras` (
+  `file_id` integer not null primary key,
+  `scene_id` integer not null,
+  `role` varchar(255) not null,
+  foreign key(`file_id`) references `files`(`id`) on delete CASCADE,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scenes_extras_on_scene_id` ON `scenes_extras` (`scene_id`);
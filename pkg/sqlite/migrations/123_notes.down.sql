@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `index_notes_on_studio_id`;
+DROP INDEX IF EXISTS `index_notes_on_performer_id`;
+DROP INDEX IF EXISTS `index_notes_on_scene_id`;
+DROP TABLE IF EXISTS `notes`;
@@ -0,0 +1,17 @@
+CREATE TABLE `notes` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer,
+  `performer_id` integer,
+  `studio_id` integer,
+  `content` text not null,
+  `author` varchar(255),
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_notes_on_scene_id` ON `notes` (`scene_id`);
+CREATE INDEX `index_notes_on_performer_id` ON `notes` (`performer_id`);
+CREATE INDEX `index_notes_on_studio_id` ON `notes` (`studio_id`);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `index_stash_box_submissions_on_endpoint`;
+DROP TABLE IF EXISTS `stash_box_submissions`;
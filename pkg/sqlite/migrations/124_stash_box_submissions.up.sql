@@ -0,0 +1,14 @@
+CREATE TABLE `stash_box_submissions` (
+  `id` integer not null primary key autoincrement,
+  `endpoint` varchar(255) not null,
+  `type` varchar(255) not null,
+  `object_id` integer not null,
+  `status` varchar(255) not null,
+  `draft_id` varchar(255),
+  `error` text,
+  `attempts` integer not null default 0,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE INDEX `index_stash_box_submissions_on_endpoint` ON `stash_box_submissions` (`endpoint`, `created_at`);
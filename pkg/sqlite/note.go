@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	noteTable = "notes"
+)
+
+type noteRow struct {
+	ID          int         `db:"id" goqu:"skipinsert"`
+	SceneID     null.Int    `db:"scene_id"`
+	PerformerID null.Int    `db:"performer_id"`
+	StudioID    null.Int    `db:"studio_id"`
+	Content     string      `db:"content"`
+	Author      null.String `db:"author"`
+	CreatedAt   Timestamp   `db:"created_at"`
+	UpdatedAt   Timestamp   `db:"updated_at"`
+}
+
+func (r *noteRow) fromNote(o models.Note) {
+	r.ID = o.ID
+	r.SceneID = intFromPtr(o.SceneID)
+	r.PerformerID = intFromPtr(o.PerformerID)
+	r.StudioID = intFromPtr(o.StudioID)
+	r.Content = o.Content
+	r.Author = null.StringFromPtr(o.Author)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *noteRow) resolve() *models.Note {
+	return &models.Note{
+		ID:          r.ID,
+		SceneID:     nullIntPtr(r.SceneID),
+		PerformerID: nullIntPtr(r.PerformerID),
+		StudioID:    nullIntPtr(r.StudioID),
+		Content:     r.Content,
+		Author:      nullStringPtr(r.Author),
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
+	}
+}
+
+type NoteStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewNoteStore() *NoteStore {
+	return &NoteStore{
+		repository: repository{
+			tableName: noteTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: noteTableMgr,
+	}
+}
+
+func (qb *NoteStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *NoteStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *NoteStore) Create(ctx context.Context, newObject *models.Note) error {
+	var r noteRow
+	r.fromNote(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *NoteStore) Update(ctx context.Context, updatedObject *models.Note) error {
+	var r noteRow
+	r.fromNote(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *NoteStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *NoteStore) Find(ctx context.Context, id int) (*models.Note, error) {
+	ret, err := qb.getMany(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *NoteStore) FindByObject(ctx context.Context, object models.NoteObject) ([]*models.Note, error) {
+	q := qb.selectDataset()
+
+	if object.SceneID != nil {
+		q = q.Where(qb.table().Col(sceneIDColumn).Eq(*object.SceneID))
+	}
+	if object.PerformerID != nil {
+		q = q.Where(qb.table().Col(performerIDColumn).Eq(*object.PerformerID))
+	}
+	if object.StudioID != nil {
+		q = q.Where(qb.table().Col(studioIDColumn).Eq(*object.StudioID))
+	}
+
+	q = q.Order(qb.table().Col("created_at").Desc(), qb.table().Col(idColumn).Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *NoteStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Note, error) {
+	const single = false
+	var ret []*models.Note
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f noteRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -65,6 +65,7 @@ type performerRow struct {
 	Weight        null.Int    `db:"weight"`
 	IgnoreAutoTag bool        `db:"ignore_auto_tag"`
 	SmallRole     bool        `db:"small_role"`
+	Locked        bool        `db:"locked"`
 	PrimaryTagID  null.Int    `db:"primary_tag_id"`
 
 	// not used in resolution or updates
@@ -102,6 +103,7 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	r.Weight = intFromPtr(o.Weight)
 	r.IgnoreAutoTag = o.IgnoreAutoTag
 	r.SmallRole = o.SmallRole
+	r.Locked = o.Locked
 	r.PrimaryTagID = intFromPtr(o.PrimaryTagID)
 }
 
@@ -132,6 +134,7 @@ func (r *performerRow) resolve() *models.Performer {
 		Weight:        nullIntPtr(r.Weight),
 		IgnoreAutoTag: r.IgnoreAutoTag,
 		SmallRole:     r.SmallRole,
+		Locked:        r.Locked,
 		PrimaryTagID:  nullIntPtr(r.PrimaryTagID),
 	}
 
@@ -178,6 +181,7 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullInt("weight", o.Weight)
 	r.setBool("ignore_auto_tag", o.IgnoreAutoTag)
 	r.setBool("small_role", o.SmallRole)
+	r.setBool("locked", o.Locked)
 	r.setNullInt("primary_tag_id", o.PrimaryTagID)
 }
 
@@ -411,6 +415,106 @@ func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
 	return performerRepository.destroyExisting(ctx, []int{id})
 }
 
+// Merge merges the source performers into the destination performer. The
+// scenes, images, galleries, tags, stash IDs, URLs and notes of the sources
+// are moved to the destination, and the names and aliases of the sources
+// are added to its aliases. The source performers are then destroyed.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	dest, err := qb.find(ctx, destination)
+	if err != nil {
+		return fmt.Errorf("finding destination performer: %w", err)
+	}
+
+	sources, err := qb.FindMany(ctx, source)
+	if err != nil {
+		return fmt.Errorf("finding source performers: %w", err)
+	}
+
+	performerTables := map[string]string{
+		performersScenesTable:    sceneIDColumn,
+		performersImagesTable:    imageIDColumn,
+		performersGalleriesTable: galleryIDColumn,
+		performersTagsTable:      tagIDColumn,
+	}
+
+	for table, idColumn := range performerTables {
+		_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			append(args, destination)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+	}
+
+	// scene tags are unique by scene, tag and performer
+	_, err = dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+scenesTagsTable+` SET performer_id = ? WHERE performer_id IN `+inBinding, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"performer_stash_ids", noteTable} {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET performer_id = ? WHERE performer_id IN "+inBinding, args...); err != nil {
+			return err
+		}
+	}
+
+	for _, src := range sources {
+		urls, err := qb.GetURLs(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		if err := performersURLsTableMgr.addJoins(ctx, destination, urls); err != nil {
+			return err
+		}
+
+		aliases, err := qb.GetAliases(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		aliases = append(aliases, src.Name)
+		slices.Sort(aliases)
+		aliases = slices.DeleteFunc(slices.Compact(aliases), func(a string) bool {
+			return a == "" || a == dest.Name
+		})
+		if err := performersAliasesTableMgr.addJoins(ctx, destination, aliases); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *PerformerStore) Find(ctx context.Context, id int) (*models.Performer, error) {
 	ret, err := qb.find(ctx, id)
@@ -571,6 +675,47 @@ func (qb *PerformerStore) FindByNames(ctx context.Context, names []string, nocas
 	return ret, nil
 }
 
+// AllNames returns the names and aliases of all performers, ordered by ID.
+func (qb *PerformerStore) AllNames(ctx context.Context) ([]models.PerformerNames, error) {
+	table := qb.table()
+	q := dialect.From(table).LeftJoin(
+		performersAliasesJoinTable,
+		goqu.On(table.Col(idColumn).Eq(performersAliasesJoinTable.Col(performerIDColumn))),
+	).Select(
+		table.Col(idColumn),
+		table.Col("name"),
+		performersAliasesJoinTable.Col(performerAliasColumn),
+	).Order(table.Col(idColumn).Asc())
+
+	var ret []models.PerformerNames
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var (
+			id    int
+			name  null.String
+			alias null.String
+		)
+		if err := rows.Scan(&id, &name, &alias); err != nil {
+			return err
+		}
+
+		if len(ret) == 0 || ret[len(ret)-1].ID != id {
+			ret = append(ret, models.PerformerNames{ID: id, Name: name.String})
+		}
+
+		if alias.Valid {
+			last := &ret[len(ret)-1]
+			last.Aliases = append(last.Aliases, alias.String)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting performer names: %w", err)
+	}
+
+	return ret, nil
+}
+
 func (qb *PerformerStore) CountByTagID(ctx context.Context, tagID int) (int, error) {
 	joinTable := performersTagsJoinTable
 
@@ -592,19 +737,18 @@ func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string) (
 	// TODO - Query needs to be changed to support queries of this type, and
 	// this method should be removed
 	table := qb.table()
-	sq := dialect.From(table).Select(table.Col(idColumn))
-	// TODO - disabled alias matching until we get finer control over it
-	// .LeftJoin(
-	// 	performersAliasesJoinTable,
-	// 	goqu.On(performersAliasesJoinTable.Col(performerIDColumn).Eq(table.Col(idColumn))),
-	// )
+	// performers matching by alias are returned, as aliases are matched
+	// with the aliases matching mode
+	sq := dialect.From(table).Select(table.Col(idColumn)).LeftJoin(
+		performersAliasesJoinTable,
+		goqu.On(performersAliasesJoinTable.Col(performerIDColumn).Eq(table.Col(idColumn))),
+	)
 
 	var whereClauses []exp.Expression
 
 	for _, w := range words {
 		whereClauses = append(whereClauses, table.Col("name").Like(w+"%"))
-		// TODO - see above
-		// whereClauses = append(whereClauses, performersAliasesJoinTable.Col("alias").Like(w+"%"))
+		whereClauses = append(whereClauses, performersAliasesJoinTable.Col(performerAliasColumn).Like(w+"%"))
 	}
 
 	sq = sq.Where(
@@ -766,6 +910,8 @@ var performerSortOptions = sortOptions{
 }
 
 func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (string, error) {
+	collation := getCollation(findFilter)
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -800,11 +946,17 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (s
 	case "last_o_at":
 		sortQuery += qb.sortByLastOAt(direction)
 	default:
-		sortQuery += getSort(sort, direction, "performers")
+		sortQuery += getSort(sort, direction, "performers", collation)
+	}
+
+	thenBy, err := performerThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return "", err
 	}
+	sortQuery += thenBy
 
 	// Whatever the sorting, always use name/id as a final sort
-	sortQuery += ", COALESCE(performers.name, performers.id) COLLATE NATURAL_CI ASC"
+	sortQuery += ", COALESCE(performers.name, performers.id) COLLATE " + collation + " ASC"
 	return sortQuery, nil
 }
 
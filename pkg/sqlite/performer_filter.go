@@ -82,6 +82,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 
 		boolCriterionHandler(filter.FilterFavorites, tableName+".favorite", nil),
 		boolCriterionHandler(filter.IgnoreAutoTag, tableName+".ignore_auto_tag", nil),
+		boolCriterionHandler(filter.Locked, tableName+".locked", nil),
 
 		yearFilterCriterionHandler(filter.BirthYear, tableName+".birthdate"),
 		yearFilterCriterionHandler(filter.DeathYear, tableName+".death_date"),
@@ -136,6 +137,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(filter.HairColor, tableName+".hair_color"),
 		qb.urlsCriterionHandler(filter.URL),
 		intCriterionHandler(filter.Weight, tableName+".weight", nil),
+		floatCriterionHandler(filter.Bmi, "("+tableName+".weight * 10000.0 / ("+tableName+".height * "+tableName+".height))", nil),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if filter.StashID != nil {
 				performerRepository.stashIDs.join(f, "performer_stash_ids", "performers.id")
@@ -39,6 +39,12 @@ func (r *updateRecord) setBool(destField string, v models.OptionalBool) {
 	}
 }
 
+func (r *updateRecord) setNullBool(destField string, v models.OptionalBool) {
+	if v.Set {
+		r.set(destField, null.BoolFromPtr(v.Ptr()))
+	}
+}
+
 func (r *updateRecord) setInt(destField string, v models.OptionalInt) {
 	if v.Set {
 		if v.Null {
@@ -70,6 +70,7 @@ ORDER BY SUM(file_size) DESC;
 
 var findAllPhashesQuery = `
 SELECT scenes.id as id
+    , files.id as file_id
     , files_fingerprints.fingerprint as phash
     , video_files.duration as duration
 FROM scenes
@@ -92,6 +93,7 @@ type sceneRow struct {
 	Rating                  null.Int    `db:"rating"`
 	Organized               bool        `db:"organized"`
 	Pinned                  bool        `db:"pinned"`
+	Locked                  bool        `db:"locked"`
 	IsBroken                bool        `db:"is_broken"`
 	IsNotBroken             bool        `db:"is_not_broken"`
 	AudioOffsetMs           int         `db:"audio_offset_ms"`
@@ -107,8 +109,13 @@ type sceneRow struct {
 	EndTime                 null.Float  `db:"end_time"`
 	VideoFilters            zero.String `db:"video_filters"`
 	VideoTransforms         zero.String `db:"video_transforms"`
+	TranscodeArgs           zero.String `db:"transcode_args"`
+	Deinterlace             null.Bool   `db:"deinterlace"`
 	OmegCounter             int         `db:"omg_counter"`
 
+	OCRText       zero.String   `db:"ocr_text"`
+	OCRAnalyzedAt NullTimestamp `db:"ocr_analyzed_at"`
+
 	// not used in resolutions or updates
 	CoverBlob zero.String `db:"cover_blob"`
 }
@@ -124,6 +131,7 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.Rating = intFromPtr(o.Rating)
 	r.Organized = o.Organized
 	r.Pinned = o.Pinned
+	r.Locked = o.Locked
 	r.IsBroken = o.IsBroken
 	r.IsNotBroken = o.IsNotBroken
 	r.AudioOffsetMs = o.AudioOffsetMs
@@ -137,6 +145,9 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.PlayDuration = o.PlayDuration
 	r.StartTime = float64FromPtr(o.StartTime)
 	r.EndTime = float64FromPtr(o.EndTime)
+	r.Deinterlace = null.BoolFromPtr(o.Deinterlace)
+	r.OCRText = zero.StringFrom(o.OCRText)
+	r.OCRAnalyzedAt = NullTimestampFromTimePtr(o.OCRAnalyzedAt)
 
 	// Video filters and transforms
 	if o.VideoFilters != nil {
@@ -149,6 +160,11 @@ func (r *sceneRow) fromScene(o models.Scene) {
 			r.VideoTransforms = zero.StringFrom(string(data))
 		}
 	}
+	if !o.TranscodeArgs.IsEmpty() {
+		if data, err := json.Marshal(o.TranscodeArgs); err == nil {
+			r.TranscodeArgs = zero.StringFrom(string(data))
+		}
+	}
 }
 
 type sceneQueryRow struct {
@@ -172,6 +188,7 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		Rating:                  nullIntPtr(r.Rating),
 		Organized:               r.Organized,
 		Pinned:                  r.Pinned,
+		Locked:                  r.Locked,
 		IsBroken:                r.IsBroken,
 		IsNotBroken:             r.IsNotBroken,
 		AudioOffsetMs:           r.AudioOffsetMs,
@@ -191,6 +208,10 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		PlayDuration: r.PlayDuration,
 		StartTime:    nullFloatPtr(r.StartTime),
 		EndTime:      nullFloatPtr(r.EndTime),
+		Deinterlace:  nullBoolPtr(r.Deinterlace),
+
+		OCRText:       r.OCRText.String,
+		OCRAnalyzedAt: r.OCRAnalyzedAt.TimePtr(),
 	}
 
 	// Deserialize video filters and transforms from JSON
@@ -206,6 +227,12 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 			ret.VideoTransforms = &transforms
 		}
 	}
+	if r.TranscodeArgs.Valid && r.TranscodeArgs.String != "" {
+		var args models.TranscodeArgs
+		if err := json.Unmarshal([]byte(r.TranscodeArgs.String), &args); err == nil {
+			ret.TranscodeArgs = &args
+		}
+	}
 
 	if r.PrimaryFileFolderPath.Valid && r.PrimaryFileBasename.Valid {
 		ret.Path = filepath.Join(r.PrimaryFileFolderPath.String, r.PrimaryFileBasename.String)
@@ -227,6 +254,7 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
 	r.setBool("pinned", o.Pinned)
+	r.setBool("locked", o.Locked)
 	r.setBool("is_broken", o.IsBroken)
 	r.setBool("is_not_broken", o.IsNotBroken)
 	r.setInt("audio_offset_ms", o.AudioOffsetMs)
@@ -240,6 +268,9 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setFloat64("play_duration", o.PlayDuration)
 	r.setNullFloat64("start_time", o.StartTime)
 	r.setNullFloat64("end_time", o.EndTime)
+	r.setNullBool("deinterlace", o.Deinterlace)
+	r.setNullString("ocr_text", o.OCRText)
+	r.setNullTimestamp("ocr_analyzed_at", o.OCRAnalyzedAt)
 
 	// Video filters and transforms
 	if o.VideoFilters != nil {
@@ -252,6 +283,13 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 			r.set("video_transforms", string(data))
 		}
 	}
+	if o.TranscodeArgs != nil {
+		if o.TranscodeArgs.IsEmpty() {
+			r.set("transcode_args", nil)
+		} else if data, err := json.Marshal(o.TranscodeArgs); err == nil {
+			r.set("transcode_args", string(data))
+		}
+	}
 }
 
 type sceneRepositoryType struct {
@@ -1111,7 +1149,7 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		)
 
 		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
+		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title", "scenes.ocr_text"}
 		query.parseQueryString(searchColumns, *q)
 	}
 
@@ -1255,6 +1293,8 @@ var sceneSortOptions = sortOptions{
 }
 
 func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	collation := getCollation(findFilter)
+
 	sort := "created_at"
 	direction := "DESC"
 
@@ -1268,6 +1308,11 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		return err
 	}
 
+	thenBy, err := sceneThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return err
+	}
+
 	// If no search query, sort pinned items first, then by selected sort
 	if findFilter == nil || findFilter.Q == nil || *findFilter.Q == "" {
 		// Always start with pinned sorting first
@@ -1317,28 +1362,28 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 			query.sortAndPagination += getCountSortWithoutOrderBy(sceneTable, scenesODatesTable, sceneIDColumn, direction)
 		} else if sort == "group_scene_number" {
 			query.join(groupsScenesTable, "scene_group", "scenes.id = scene_group.scene_id")
-			query.sortAndPagination += getSortWithoutOrderBy("scene_index", direction, "scene_group")
+			query.sortAndPagination += getSortWithoutOrderBy("scene_index", direction, "scene_group", collation)
 		} else if sort == "movie_scene_number" {
 			query.join(groupsScenesTable, "", "scenes.id = groups_scenes.scene_id")
-			query.sortAndPagination += getSortWithoutOrderBy("scene_index", direction, groupsScenesTable)
+			query.sortAndPagination += getSortWithoutOrderBy("scene_index", direction, groupsScenesTable, collation)
 		} else if sort == "filesize" {
 			addFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, fileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, fileTable, collation)
 		} else if sort == "bitrate" {
 			sortCol := "bit_rate"
 			addVideoFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, videoFileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, videoFileTable, collation)
 		} else if sort == "file_mod_time" {
 			sortCol := "mod_time"
 			addFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, fileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, fileTable, collation)
 		} else if sort == "framerate" {
 			sortCol := "frame_rate"
 			addVideoFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, videoFileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sortCol, direction, videoFileTable, collation)
 		} else if sort == "duration" {
 			addVideoFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, videoFileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, videoFileTable, collation)
 		} else if sort == "path" {
 			addFileTable()
 			addFolderTable()
@@ -1361,13 +1406,15 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 			query.sortAndPagination += getCountSortWithoutOrderBy(sceneTable, scenesFilesTable, sceneIDColumn, direction)
 		} else if sort == "interactive" || sort == "interactive_speed" {
 			addVideoFileTable()
-			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, videoFileTable)
+			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, videoFileTable, collation)
 		} else {
-			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, "scenes")
+			query.sortAndPagination += getSortWithoutOrderBy(sort, direction, "scenes", collation)
 		}
 
+		query.sortAndPagination += thenBy
+
 		// Add title as final sort
-		query.sortAndPagination += ", COALESCE(scenes.title, scenes.id) COLLATE NATURAL_CI ASC"
+		query.sortAndPagination += ", COALESCE(scenes.title, scenes.id) COLLATE " + collation + " ASC"
 		return nil
 	}
 
@@ -1407,10 +1454,10 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 	switch sort {
 	case "movie_scene_number":
 		query.join(groupsScenesTable, "", "scenes.id = groups_scenes.scene_id")
-		query.sortAndPagination += getSort("scene_index", direction, groupsScenesTable)
+		query.sortAndPagination += getSort("scene_index", direction, groupsScenesTable, collation)
 	case "group_scene_number":
 		query.join(groupsScenesTable, "scene_group", "scenes.id = scene_group.scene_id")
-		query.sortAndPagination += getSort("scene_index", direction, "scene_group")
+		query.sortAndPagination += getSort("scene_index", direction, "scene_group", collation)
 	case "tag_count":
 		query.sortAndPagination += getCountSort(sceneTable, scenesTagsTable, sceneIDColumn, direction)
 	case "performer_count":
@@ -1437,28 +1484,28 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 	case "bitrate":
 		sort = "bit_rate"
 		addVideoFileTable()
-		query.sortAndPagination += getSort(sort, direction, videoFileTable)
+		query.sortAndPagination += getSort(sort, direction, videoFileTable, collation)
 	case "file_mod_time":
 		sort = "mod_time"
 		addFileTable()
-		query.sortAndPagination += getSort(sort, direction, fileTable)
+		query.sortAndPagination += getSort(sort, direction, fileTable, collation)
 	case "framerate":
 		sort = "frame_rate"
 		addVideoFileTable()
-		query.sortAndPagination += getSort(sort, direction, videoFileTable)
+		query.sortAndPagination += getSort(sort, direction, videoFileTable, collation)
 	case "filesize":
 		addFileTable()
-		query.sortAndPagination += getSort(sort, direction, fileTable)
+		query.sortAndPagination += getSort(sort, direction, fileTable, collation)
 	case "duration":
 		addVideoFileTable()
-		query.sortAndPagination += getSort(sort, direction, videoFileTable)
+		query.sortAndPagination += getSort(sort, direction, videoFileTable, collation)
 	case "interactive", "interactive_speed":
 		addVideoFileTable()
-		query.sortAndPagination += getSort(sort, direction, videoFileTable)
+		query.sortAndPagination += getSort(sort, direction, videoFileTable, collation)
 	case "title":
 		addFileTable()
 		addFolderTable()
-		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE " + collation + " " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
 		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
 	case "last_played_at":
@@ -1467,14 +1514,18 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "o_counter":
 		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+	case "last_omg_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(omg_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesOMGDatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "omg_counter":
-		query.sortAndPagination += getSort(sort, direction, "scenes")
+		query.sortAndPagination += getCountSort(sceneTable, scenesOMGDatesTable, sceneIDColumn, direction)
 	default:
-		query.sortAndPagination += getSort(sort, direction, "scenes")
+		query.sortAndPagination += getSort(sort, direction, "scenes", collation)
 	}
 
+	query.sortAndPagination += thenBy
+
 	// Whatever the sorting, always use title/id as a final sort
-	query.sortAndPagination += ", COALESCE(scenes.title, scenes.id) COLLATE NATURAL_CI ASC"
+	query.sortAndPagination += ", COALESCE(scenes.title, scenes.id) COLLATE " + collation + " ASC"
 
 	return nil
 }
@@ -1655,20 +1706,8 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 			}
 		}
 	} else {
-		var hashes []*utils.Phash
-
-		if err := sceneRepository.queryFunc(ctx, findAllPhashesQuery, nil, false, func(rows *sqlx.Rows) error {
-			phash := utils.Phash{
-				Bucket:   -1,
-				Duration: -1,
-			}
-			if err := rows.StructScan(&phash); err != nil {
-				return err
-			}
-
-			hashes = append(hashes, &phash)
-			return nil
-		}); err != nil {
+		hashes, err := qb.FindAllPhashes(ctx)
+		if err != nil {
 			return nil, err
 		}
 
@@ -1687,6 +1726,28 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 	return duplicates, nil
 }
 
+// FindAllPhashes returns the phashes of all scene files.
+func (qb *SceneStore) FindAllPhashes(ctx context.Context) ([]*utils.Phash, error) {
+	var hashes []*utils.Phash
+
+	if err := sceneRepository.queryFunc(ctx, findAllPhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+		phash := utils.Phash{
+			Bucket:   -1,
+			Duration: -1,
+		}
+		if err := rows.StructScan(&phash); err != nil {
+			return err
+		}
+
+		hashes = append(hashes, &phash)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
 func sortByPath(scenes [][]*models.Scene) {
 	lessFunc := func(i int, j int) bool {
 		firstPathI := getFirstPath(scenes[i])
@@ -1783,15 +1844,57 @@ func (qb *SceneStore) GetAggregatedViewHistoryCount(ctx context.Context) (int, e
 	return qb.viewDateManager.GetAggregatedViewHistoryCount(ctx)
 }
 
-func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page, perPage int) ([]models.CombinedAggregatedView, error) {
+// viewHistoryGroupsQuery returns a query grouping the views of the table by
+// id, with the view count and the earliest and latest view date of each group.
+func viewHistoryGroupsQuery(table, idColumn string, grouping models.ViewHistoryGrouping) string {
+	if grouping == models.ViewHistoryGroupingSession {
+		// a view starts a new session if the previous view of the same item
+		// is more than the session gap before it
+		return fmt.Sprintf(`
+			SELECT
+				%[2]s,
+				COUNT(*) as view_count,
+				MIN(view_date) as earliest_view_date,
+				MAX(view_date) as view_date
+			FROM (
+				SELECT
+					%[2]s,
+					view_date,
+					SUM(new_session) OVER (PARTITION BY %[2]s ORDER BY view_date ROWS UNBOUNDED PRECEDING) as session_id
+				FROM (
+					SELECT
+						%[2]s,
+						view_date,
+						CASE WHEN (julianday(view_date) - julianday(LAG(view_date) OVER (PARTITION BY %[2]s ORDER BY view_date))) * 86400 <= %[3]d
+							THEN 0 ELSE 1 END as new_session
+					FROM %[1]s
+				)
+			)
+			GROUP BY %[2]s, session_id
+		`, table, idColumn, int(models.ViewHistorySessionGap.Seconds()))
+	}
+
+	return fmt.Sprintf(`
+		SELECT
+			%[2]s,
+			COUNT(*) as view_count,
+			MIN(view_date) as earliest_view_date,
+			MAX(view_date) as view_date
+		FROM %[1]s
+		GROUP BY %[2]s, %[3]s
+	`, table, idColumn, localDate("view_date"))
+}
+
+func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, options models.CombinedViewHistoryOptions) ([]models.CombinedAggregatedView, error) {
 	// Create a combined query that unions scenes and galleries view history
 	// Scenes from scenes_view_dates
-	scenesQuery := `
+	scenesQuery := fmt.Sprintf(`
 		SELECT
 			'scene' as content_type,
 			gv.scene_id as content_id,
 			gv.view_date,
 			gv.view_count,
+			gv.earliest_view_date,
 			(
 				SELECT sod.o_date
 				FROM scenes_o_dates sod
@@ -1808,24 +1911,17 @@ func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page
 				ORDER BY somgd.omg_date ASC
 				LIMIT 1
 			) as omg_date
-		FROM (
-			SELECT
-				svd.scene_id,
-				COUNT(*) as view_count,
-				MIN(svd.view_date) as earliest_view_date,
-				MAX(svd.view_date) as view_date
-			FROM scenes_view_dates svd
-			GROUP BY svd.scene_id, DATE(svd.view_date)
-		) gv
-	`
+		FROM (%s) gv
+	`, viewHistoryGroupsQuery(scenesViewDatesTable, sceneIDColumn, options.Grouping))
 
 	// Galleries from galleries_view_dates
-	galleriesQuery := `
+	galleriesQuery := fmt.Sprintf(`
 		SELECT
 			'gallery' as content_type,
 			gv.gallery_id as content_id,
 			gv.view_date,
 			gv.view_count,
+			gv.earliest_view_date,
 			(
 				SELECT god.o_date
 				FROM galleries_o_dates god
@@ -1842,16 +1938,8 @@ func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page
 				ORDER BY gomgd.omg_date ASC
 				LIMIT 1
 			) as omg_date
-		FROM (
-			SELECT
-				gvd.gallery_id,
-				COUNT(*) as view_count,
-				MIN(gvd.view_date) as earliest_view_date,
-				MAX(gvd.view_date) as view_date
-			FROM galleries_view_dates gvd
-			GROUP BY gvd.gallery_id, DATE(gvd.view_date)
-		) gv
-	`
+		FROM (%s) gv
+	`, viewHistoryGroupsQuery(galleriesViewDatesTable, galleryIDColumn, options.Grouping))
 
 	// Combine both queries with UNION ALL and sort
 	combinedQuery := fmt.Sprintf(`
@@ -1860,16 +1948,29 @@ func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page
 			UNION ALL
 			%s
 		) combined
-		ORDER BY view_date DESC
 	`, scenesQuery, galleriesQuery)
 
+	var args []interface{}
+	if c := options.After; c != nil {
+		viewDate := c.ViewDate.UTC().Format(TimestampFormat)
+		combinedQuery += `
+		WHERE view_date < ? OR (view_date = ? AND (content_type < ? OR (content_type = ? AND content_id < ?)))
+		`
+		args = append(args, viewDate, viewDate, c.ContentType, c.ContentType, c.ContentID)
+	}
+
+	combinedQuery += " ORDER BY view_date DESC, content_type DESC, content_id DESC"
+
 	// Add pagination
-	if perPage > 0 {
-		offset := (page - 1) * perPage
-		combinedQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", perPage, offset)
+	if options.PerPage > 0 {
+		offset := 0
+		if options.After == nil && options.Page > 1 {
+			offset = (options.Page - 1) * options.PerPage
+		}
+		combinedQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", options.PerPage, offset)
 	}
 
-	rows, err := dbWrapper.QueryxContext(ctx, combinedQuery)
+	rows, err := dbWrapper.QueryxContext(ctx, combinedQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1879,10 +1980,11 @@ func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page
 	for rows.Next() {
 		var result models.CombinedAggregatedView
 		var viewDateStr string
+		var earliestViewDateStr string
 		var oDateStr *string
 		var omgDateStr *string
 
-		err := rows.Scan(&result.ContentType, &result.ContentID, &viewDateStr, &result.ViewCount, &oDateStr, &omgDateStr)
+		err := rows.Scan(&result.ContentType, &result.ContentID, &viewDateStr, &result.ViewCount, &earliestViewDateStr, &oDateStr, &omgDateStr)
 		if err != nil {
 			return nil, err
 		}
@@ -1892,6 +1994,11 @@ func (qb *SceneStore) GetCombinedAggregatedViewHistory(ctx context.Context, page
 			return nil, err
 		}
 
+		result.EarliestViewDate, err = time.Parse(time.RFC3339, earliestViewDateStr)
+		if err != nil {
+			return nil, err
+		}
+
 		if oDateStr != nil && *oDateStr != "" {
 			oDate, err := time.Parse(time.RFC3339, *oDateStr)
 			if err != nil {
@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	sceneExtraTable = "scenes_extras"
+)
+
+type sceneExtraRow struct {
+	FileID  models.FileID         `db:"file_id"`
+	SceneID int                   `db:"scene_id"`
+	Role    models.SceneExtraRole `db:"role"`
+}
+
+func (r *sceneExtraRow) resolve() *models.SceneExtra {
+	return &models.SceneExtra{
+		SceneID: r.SceneID,
+		FileID:  r.FileID,
+		Role:    r.Role,
+	}
+}
+
+type SceneExtraStore struct {
+	tableMgr *table
+}
+
+func NewSceneExtraStore() *SceneExtraStore {
+	return &SceneExtraStore{
+		tableMgr: sceneExtraTableMgr,
+	}
+}
+
+func (qb *SceneExtraStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *SceneExtraStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *SceneExtraStore) Set(ctx context.Context, extra models.SceneExtra) error {
+	// a file is either a file or an extra of a scene
+	if err := scenesFilesTableMgr.destroyJoins(ctx, []models.FileID{extra.FileID}); err != nil {
+		return err
+	}
+
+	q := dialect.Insert(qb.table()).Prepared(true).
+		Cols(fileIDColumn, sceneIDColumn, "role").
+		Vals(goqu.Vals{extra.FileID, extra.SceneID, extra.Role}).
+		OnConflict(goqu.DoUpdate(fileIDColumn, goqu.Record{
+			sceneIDColumn: extra.SceneID,
+			"role":        extra.Role,
+		}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting scene extra: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *SceneExtraStore) Destroy(ctx context.Context, fileID models.FileID) error {
+	q := dialect.Delete(qb.table()).Where(qb.tableMgr.byID(fileID))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying scene extra: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *SceneExtraStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneExtra, error) {
+	q := qb.selectDataset().
+		Where(qb.table().Col(sceneIDColumn).Eq(sceneID)).
+		Order(qb.table().Col("role").Asc(), qb.table().Col(fileIDColumn).Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+// returns nil, nil if not found
+func (qb *SceneExtraStore) FindByFileID(ctx context.Context, fileID models.FileID) (*models.SceneExtra, error) {
+	ret, err := qb.getMany(ctx, qb.selectDataset().Where(qb.tableMgr.byID(fileID)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *SceneExtraStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneExtra, error) {
+	const single = false
+	var ret []*models.SceneExtra
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f sceneExtraRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -62,6 +62,7 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(sceneFilter.Code, "scenes.code"),
 		stringCriterionHandler(sceneFilter.Details, "scenes.details"),
 		stringCriterionHandler(sceneFilter.Director, "scenes.director"),
+		stringCriterionHandler(sceneFilter.OCRText, "scenes.ocr_text"),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.Oshash != nil {
 				qb.addSceneFilesTable(f)
@@ -94,9 +95,17 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
-		intCriterionHandler(sceneFilter.OmegCounter, "scenes.omg_counter", nil),
+		qb.omgCountCriterionHandler(sceneFilter.OmegCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
 		boolCriterionHandler(sceneFilter.Pinned, "scenes.pinned", nil),
+		boolCriterionHandler(sceneFilter.Locked, "scenes.locked", nil),
+		boolCriterionHandler(sceneFilter.IsBroken, "scenes.is_broken", nil),
+		threatCriterionHandler(threatFilter{
+			hasThreats:    sceneFilter.HasThreats,
+			severity:      sceneFilter.ThreatSeverity,
+			scannedBefore: sceneFilter.ThreatsScannedBefore,
+			neverScanned:  sceneFilter.ThreatsNeverScanned,
+		}, sceneVideoFileWhere, nil),
 
 		floatIntCriterionHandler(sceneFilter.Duration, "video_files.duration", qb.addVideoFilesTable),
 		resolutionCriterionHandler(sceneFilter.Resolution, "video_files.height", "video_files.width", qb.addVideoFilesTable),
@@ -105,8 +114,11 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		intCriterionHandler(sceneFilter.Bitrate, "video_files.bit_rate", qb.addVideoFilesTable),
 		qb.codecCriterionHandler(sceneFilter.VideoCodec, "video_files.video_codec", qb.addVideoFilesTable),
 		qb.codecCriterionHandler(sceneFilter.AudioCodec, "video_files.audio_codec", qb.addVideoFilesTable),
+		delimitedStringCriterionHandler(sceneFilter.AudioLanguage, "video_files.audio_languages", qb.addVideoFilesTable),
 
+		qb.tagRequirementsCriterionHandler(sceneFilter.TagRequirements),
 		qb.hasMarkersCriterionHandler(sceneFilter.HasMarkers),
+		qb.hasPoseCoverageCriterionHandler(sceneFilter.HasPoseCoverage),
 		qb.isMissingCriterionHandler(sceneFilter.IsMissing),
 		qb.urlsCriterionHandler(sceneFilter.URL),
 
@@ -254,6 +266,16 @@ func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionI
 	return h.handler(count)
 }
 
+func (qb *sceneFilterHandler) omgCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    scenesOMGDatesTable,
+		primaryFK:    sceneIDColumn,
+	}
+
+	return h.handler(count)
+}
+
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
 	h := countCriterionHandlerBuilder{
 		primaryTable: sceneTable,
@@ -309,6 +331,70 @@ func (qb *sceneFilterHandler) hasMarkersCriterionHandler(hasMarkers *string) cri
 	}
 }
 
+// scenePoseMarkerExists matches scenes with a marker that has a pose tag as
+// primary or secondary tag
+const scenePoseMarkerExists = `EXISTS (
+	SELECT 1 FROM scene_markers
+	LEFT JOIN scene_markers_tags ON scene_markers_tags.scene_marker_id = scene_markers.id
+	INNER JOIN tags ON tags.id = scene_markers.primary_tag_id OR tags.id = scene_markers_tags.tag_id
+	WHERE scene_markers.scene_id = scenes.id AND tags.is_pose_tag = 1
+)`
+
+func (qb *sceneFilterHandler) hasPoseCoverageCriterionHandler(hasPoseCoverage *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if hasPoseCoverage != nil {
+			if *hasPoseCoverage {
+				f.addWhere(scenePoseMarkerExists)
+			} else {
+				f.addWhere("NOT " + scenePoseMarkerExists)
+			}
+		}
+	}
+}
+
+const (
+	// requirementPresetsWhere matches the color presets which are required
+	// tag requirements
+	requirementPresetsWhere = "color_presets.required_for_requirements = 1 AND TRIM(COALESCE(color_presets.tag_requirements_description, '')) != ''"
+
+	requirementPresetsTotal = "(SELECT COUNT(*) FROM color_presets WHERE " + requirementPresetsWhere + ")"
+
+	// requirementPresetsSatisfied counts the required presets with the color
+	// of a scene or scene performer tag
+	requirementPresetsSatisfied = "(SELECT COUNT(*) FROM color_presets WHERE " + requirementPresetsWhere + ` AND EXISTS (
+		SELECT 1 FROM scenes_tags INNER JOIN tags ON tags.id = scenes_tags.tag_id
+		WHERE scenes_tags.scene_id = scenes.id AND LOWER(tags.color) = LOWER(color_presets.color)
+	))`
+
+	// requirementPresetsMinRequired is the SQL equivalent of
+	// models.TagRequirementsMinRequired
+	requirementPresetsMinRequired = "((" + requirementPresetsTotal + " * 3 + 3) / 4)"
+)
+
+func (qb *sceneFilterHandler) tagRequirementsCriterionHandler(c *models.TagRequirementsCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		var clauses []sqlClause
+		for _, v := range c.Value {
+			switch v {
+			case models.TagRequirementsStatusSatisfied:
+				clauses = append(clauses, makeClause(requirementPresetsSatisfied+" = "+requirementPresetsTotal))
+			case models.TagRequirementsStatusPartial:
+				clauses = append(clauses, makeClause(requirementPresetsSatisfied+" < "+requirementPresetsTotal+" AND "+requirementPresetsSatisfied+" >= "+requirementPresetsMinRequired))
+			case models.TagRequirementsStatusUnsatisfied:
+				clauses = append(clauses, makeClause(requirementPresetsSatisfied+" < "+requirementPresetsMinRequired))
+			}
+		}
+
+		if len(clauses) > 0 {
+			f.whereClauses = append(f.whereClauses, orClauses(clauses...))
+		}
+	}
+}
+
 func (qb *sceneFilterHandler) isMissingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
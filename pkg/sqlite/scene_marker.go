@@ -371,6 +371,8 @@ var sceneMarkerSortOptions = sortOptions{
 }
 
 func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	collation := getCollation(findFilter)
+
 	sort := findFilter.GetSort("title")
 	direction := findFilter.GetDirection()
 
@@ -383,15 +385,15 @@ func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *
 	case "scenes_updated_at":
 		sort = "updated_at"
 		query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
-		query.sortAndPagination += getSort(sort, direction, sceneTable)
+		query.sortAndPagination += getSort(sort, direction, sceneTable, collation)
 	case "title":
 		query.join(tagTable, "", "scene_markers.primary_tag_id = tags.id")
-		query.sortAndPagination += " ORDER BY COALESCE(NULLIF(scene_markers.title,''), tags.name) COLLATE NATURAL_CI " + direction
+		query.sortAndPagination += " ORDER BY COALESCE(NULLIF(scene_markers.title,''), tags.name) COLLATE " + collation + " " + direction
 	case "duration":
 		sort = "(scene_markers.end_seconds - scene_markers.seconds)"
-		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable)
+		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable, collation)
 	default:
-		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable)
+		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable, collation)
 	}
 
 	query.sortAndPagination += ", scene_markers.scene_id ASC, scene_markers.seconds ASC"
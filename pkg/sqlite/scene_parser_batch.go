@@ -0,0 +1,242 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	sceneParserBatchTable  = "scene_parser_batches"
+	sceneParserChangeTable = "scene_parser_changes"
+)
+
+type sceneParserBatchRow struct {
+	ID        int       `db:"id" goqu:"skipinsert"`
+	Pattern   string    `db:"pattern"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *sceneParserBatchRow) fromSceneParserBatch(o models.SceneParserBatch) {
+	r.ID = o.ID
+	r.Pattern = o.Pattern
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *sceneParserBatchRow) resolve() *models.SceneParserBatch {
+	return &models.SceneParserBatch{
+		ID:        r.ID,
+		Pattern:   r.Pattern,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+type sceneParserChangeRow struct {
+	ID      int         `db:"id" goqu:"skipinsert"`
+	BatchID int         `db:"batch_id"`
+	SceneID int         `db:"scene_id"`
+	Field   string      `db:"field"`
+	Value   zero.String `db:"value"`
+	IDs     zero.String `db:"ids"`
+	Status  string      `db:"status"`
+}
+
+func (r *sceneParserChangeRow) fromSceneParserChange(o models.SceneParserChange) {
+	r.ID = o.ID
+	r.BatchID = o.BatchID
+	r.SceneID = o.SceneID
+	r.Field = o.Field.String()
+	r.Value = zero.StringFromPtr(o.Value)
+	if len(o.IDs) > 0 {
+		r.IDs = zero.StringFrom(encodeJSONOrEmpty(o.IDs))
+	}
+	r.Status = o.Status.String()
+}
+
+func (r *sceneParserChangeRow) resolve() *models.SceneParserChange {
+	ret := &models.SceneParserChange{
+		ID:      r.ID,
+		BatchID: r.BatchID,
+		SceneID: r.SceneID,
+		Field:   models.SceneParserField(r.Field),
+		Value:   r.Value.Ptr(),
+		Status:  models.SceneParserChangeStatus(r.Status),
+	}
+
+	decodeJSON(r.IDs.String, &ret.IDs)
+
+	return ret
+}
+
+type SceneParserBatchStore struct {
+	repository
+	tableMgr       *table
+	changeTableMgr *table
+}
+
+func NewSceneParserBatchStore() *SceneParserBatchStore {
+	return &SceneParserBatchStore{
+		repository: repository{
+			tableName: sceneParserBatchTable,
+			idColumn:  idColumn,
+		},
+		tableMgr:       sceneParserBatchTableMgr,
+		changeTableMgr: sceneParserChangeTableMgr,
+	}
+}
+
+func (qb *SceneParserBatchStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *SceneParserBatchStore) changeTable() exp.IdentifierExpression {
+	return qb.changeTableMgr.table
+}
+
+func (qb *SceneParserBatchStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *SceneParserBatchStore) Create(ctx context.Context, newObject *models.SceneParserBatch, changes []*models.SceneParserChange) error {
+	var r sceneParserBatchRow
+	r.fromSceneParserBatch(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		c.BatchID = id
+
+		var cr sceneParserChangeRow
+		cr.fromSceneParserChange(*c)
+
+		changeID, err := qb.changeTableMgr.insertID(ctx, cr)
+		if err != nil {
+			return err
+		}
+
+		c.ID = changeID
+	}
+
+	updated, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *SceneParserBatchStore) UpdateChangeStatus(ctx context.Context, ids []int, status models.SceneParserChangeStatus) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	q := dialect.Update(qb.changeTable()).Prepared(true).
+		Set(goqu.Record{"status": status.String()}).
+		Where(
+			qb.changeTableMgr.idColumn.In(ids),
+			qb.changeTable().Col("status").Neq(models.SceneParserChangeStatusApplied.String()),
+		)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", sceneParserChangeTable, err)
+	}
+
+	// touch the batches of the changes
+	batchIDs := dialect.From(qb.changeTable()).Select(qb.changeTable().Col("batch_id")).
+		Where(qb.changeTableMgr.idColumn.In(ids))
+
+	uq := dialect.Update(qb.table()).Prepared(true).
+		Set(goqu.Record{"updated_at": Timestamp{Timestamp: time.Now()}}).
+		Where(qb.tableMgr.idColumn.In(batchIDs))
+
+	if _, err := exec(ctx, uq); err != nil {
+		return fmt.Errorf("updating %s: %w", sceneParserBatchTable, err)
+	}
+
+	return nil
+}
+
+func (qb *SceneParserBatchStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *SceneParserBatchStore) Find(ctx context.Context, id int) (*models.SceneParserBatch, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *SceneParserBatchStore) All(ctx context.Context) ([]*models.SceneParserBatch, error) {
+	q := qb.selectDataset().Order(qb.table().Col("created_at").Desc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *SceneParserBatchStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneParserBatch, error) {
+	const single = false
+	var ret []*models.SceneParserBatch
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f sceneParserBatchRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *SceneParserBatchStore) FindChanges(ctx context.Context, batchID int, status *models.SceneParserChangeStatus) ([]*models.SceneParserChange, error) {
+	table := qb.changeTable()
+	q := dialect.From(table).Select(table.All()).Where(table.Col("batch_id").Eq(batchID))
+
+	if status != nil {
+		q = q.Where(table.Col("status").Eq(status.String()))
+	}
+
+	q = q.Order(table.Col("scene_id").Asc(), table.Col(idColumn).Asc())
+
+	const single = false
+	var ret []*models.SceneParserChange
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f sceneParserChangeRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	shareLinkTable = "share_links"
+)
+
+type shareLinkRow struct {
+	ID            int           `db:"id" goqu:"skipinsert"`
+	Token         string        `db:"token"`
+	SceneID       null.Int      `db:"scene_id"`
+	GalleryID     null.Int      `db:"gallery_id"`
+	AllowDownload bool          `db:"allow_download"`
+	MaxViews      null.Int      `db:"max_views"`
+	ViewCount     int           `db:"view_count"`
+	ExpiresAt     NullTimestamp `db:"expires_at"`
+	CreatedAt     Timestamp     `db:"created_at"`
+}
+
+func (r *shareLinkRow) fromShareLink(o models.ShareLink) {
+	r.ID = o.ID
+	r.Token = o.Token
+	r.SceneID = intFromPtr(o.SceneID)
+	r.GalleryID = intFromPtr(o.GalleryID)
+	r.AllowDownload = o.AllowDownload
+	r.MaxViews = intFromPtr(o.MaxViews)
+	r.ViewCount = o.ViewCount
+	r.ExpiresAt = NullTimestampFromTimePtr(o.ExpiresAt)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+}
+
+func (r *shareLinkRow) resolve() *models.ShareLink {
+	return &models.ShareLink{
+		ID:            r.ID,
+		Token:         r.Token,
+		SceneID:       nullIntPtr(r.SceneID),
+		GalleryID:     nullIntPtr(r.GalleryID),
+		AllowDownload: r.AllowDownload,
+		MaxViews:      nullIntPtr(r.MaxViews),
+		ViewCount:     r.ViewCount,
+		ExpiresAt:     r.ExpiresAt.TimePtr(),
+		CreatedAt:     r.CreatedAt.Timestamp,
+	}
+}
+
+type ShareLinkStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewShareLinkStore() *ShareLinkStore {
+	return &ShareLinkStore{
+		repository: repository{
+			tableName: shareLinkTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: shareLinkTableMgr,
+	}
+}
+
+func (qb *ShareLinkStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *ShareLinkStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *ShareLinkStore) Create(ctx context.Context, newObject *models.ShareLink) error {
+	var r shareLinkRow
+	r.fromShareLink(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+// IncrementViewCount increments the view count of the link if its view
+// limit has not been reached. Returns false if it has.
+func (qb *ShareLinkStore) IncrementViewCount(ctx context.Context, id int) (bool, error) {
+	table := qb.table()
+	q := dialect.Update(table).Prepared(true).
+		Set(goqu.Record{"view_count": goqu.L("view_count + 1")}).
+		Where(
+			qb.tableMgr.byID(id),
+			goqu.Or(
+				table.Col("max_views").IsNull(),
+				table.Col("view_count").Lt(table.Col("max_views")),
+			),
+		)
+
+	ret, err := exec(ctx, q)
+	if err != nil {
+		return false, fmt.Errorf("updating %s: %w", shareLinkTable, err)
+	}
+
+	n, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (qb *ShareLinkStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *ShareLinkStore) Find(ctx context.Context, id int) (*models.ShareLink, error) {
+	return qb.find(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+}
+
+// returns nil, nil if not found
+func (qb *ShareLinkStore) FindByToken(ctx context.Context, token string) (*models.ShareLink, error) {
+	return qb.find(ctx, qb.selectDataset().Where(qb.table().Col("token").Eq(token)))
+}
+
+func (qb *ShareLinkStore) FindByObject(ctx context.Context, sceneID *int, galleryID *int) ([]*models.ShareLink, error) {
+	q := qb.selectDataset()
+
+	if sceneID != nil {
+		q = q.Where(qb.table().Col(sceneIDColumn).Eq(*sceneID))
+	}
+	if galleryID != nil {
+		q = q.Where(qb.table().Col(galleryIDColumn).Eq(*galleryID))
+	}
+
+	q = q.Order(qb.table().Col("created_at").Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *ShareLinkStore) find(ctx context.Context, q *goqu.SelectDataset) (*models.ShareLink, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *ShareLinkStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.ShareLink, error) {
+	const single = false
+	var ret []*models.ShareLink
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f shareLinkRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	smartPlaylistTable = "smart_playlists"
+)
+
+type smartPlaylistRow struct {
+	ID              int                      `db:"id" goqu:"skipinsert"`
+	Name            string                   `db:"name"`
+	SceneFilter     zero.String              `db:"scene_filter"`
+	Sort            zero.String              `db:"sort"`
+	Direction       models.SortDirectionEnum `db:"sort_direction"`
+	MaxItemDuration null.Int                 `db:"max_item_duration"`
+	UseMarkers      bool                     `db:"use_markers"`
+	SkipWatched     bool                     `db:"skip_watched"`
+	CreatedAt       Timestamp                `db:"created_at"`
+	UpdatedAt       Timestamp                `db:"updated_at"`
+}
+
+func (r *smartPlaylistRow) fromSmartPlaylist(o models.SmartPlaylist) {
+	r.ID = o.ID
+	r.Name = o.Name
+	// encode the filter as json
+	if o.SceneFilter != nil {
+		r.SceneFilter = zero.StringFrom(encodeJSONOrEmpty(o.SceneFilter))
+	}
+	r.Sort = zero.StringFrom(o.Sort)
+	r.Direction = o.Direction
+	r.MaxItemDuration = intFromPtr(o.MaxItemDuration)
+	r.UseMarkers = o.UseMarkers
+	r.SkipWatched = o.SkipWatched
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *smartPlaylistRow) resolve() *models.SmartPlaylist {
+	ret := &models.SmartPlaylist{
+		ID:              r.ID,
+		Name:            r.Name,
+		Sort:            r.Sort.String,
+		Direction:       r.Direction,
+		MaxItemDuration: nullIntPtr(r.MaxItemDuration),
+		UseMarkers:      r.UseMarkers,
+		SkipWatched:     r.SkipWatched,
+		CreatedAt:       r.CreatedAt.Timestamp,
+		UpdatedAt:       r.UpdatedAt.Timestamp,
+	}
+
+	// decode the filter from json
+	if r.SceneFilter.String != "" {
+		ret.SceneFilter = &models.SceneFilterType{}
+		decodeJSON(r.SceneFilter.String, ret.SceneFilter)
+	}
+
+	return ret
+}
+
+type SmartPlaylistStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewSmartPlaylistStore() *SmartPlaylistStore {
+	return &SmartPlaylistStore{
+		repository: repository{
+			tableName: smartPlaylistTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: smartPlaylistTableMgr,
+	}
+}
+
+func (qb *SmartPlaylistStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *SmartPlaylistStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *SmartPlaylistStore) Create(ctx context.Context, newObject *models.SmartPlaylist) error {
+	var r smartPlaylistRow
+	r.fromSmartPlaylist(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *SmartPlaylistStore) Update(ctx context.Context, updatedObject *models.SmartPlaylist) error {
+	var r smartPlaylistRow
+	r.fromSmartPlaylist(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *SmartPlaylistStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *SmartPlaylistStore) Find(ctx context.Context, id int) (*models.SmartPlaylist, error) {
+	ret, err := qb.getMany(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *SmartPlaylistStore) All(ctx context.Context) ([]*models.SmartPlaylist, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("name").Asc()))
+}
+
+func (qb *SmartPlaylistStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SmartPlaylist, error) {
+	const single = false
+	var ret []*models.SmartPlaylist
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f smartPlaylistRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
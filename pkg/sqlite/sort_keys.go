@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// thenBySort is an expression that results can be sorted by after the
+// primary sort.
+type thenBySort struct {
+	expr string
+	// text is true if the expression is compared using the collation.
+	text bool
+}
+
+// thenBySorts are the sort keys of a table that can be used as additional
+// sort keys of a find filter.
+type thenBySorts map[string]thenBySort
+
+// commonThenBySorts returns the sort keys of the columns shared by most
+// tables.
+func commonThenBySorts(table string) thenBySorts {
+	return thenBySorts{
+		"id":         {expr: table + ".id"},
+		"created_at": {expr: table + ".created_at"},
+		"updated_at": {expr: table + ".updated_at"},
+	}
+}
+
+// with returns a copy of o with the columns of the table added as sort keys.
+// Columns prefixed with "~" are compared using the collation.
+func (o thenBySorts) with(table string, columns ...string) thenBySorts {
+	ret := make(thenBySorts, len(o)+len(columns))
+	for k, v := range o {
+		ret[k] = v
+	}
+
+	for _, c := range columns {
+		text := strings.HasPrefix(c, "~")
+		c = strings.TrimPrefix(c, "~")
+		ret[c] = thenBySort{expr: table + "." + c, text: text}
+	}
+
+	return ret
+}
+
+// withStudio returns a copy of o with the studio name added as a sort key.
+func (o thenBySorts) withStudio(table string) thenBySorts {
+	ret := o.with(table)
+	ret["studio"] = thenBySort{
+		expr: fmt.Sprintf("(SELECT %[1]s.name FROM %[1]s WHERE %[1]s.id = %[2]s.studio_id)", studioTable, table),
+		text: true,
+	}
+	return ret
+}
+
+// withSortName returns a copy of o with the tag name added as a sort key.
+// Tags are sorted by their sort name if set.
+func (o thenBySorts) withSortName() thenBySorts {
+	ret := o.with(tagTable)
+	ret["name"] = thenBySort{
+		expr: fmt.Sprintf("COALESCE(%[1]s.sort_name, %[1]s.name)", tagTable),
+		text: true,
+	}
+	return ret
+}
+
+// getThenBySort returns the additional sort keys of the find filter, to be
+// appended to the ORDER BY clause. An error is returned if a sort key is
+// not supported.
+func (o thenBySorts) getThenBySort(findFilter *models.FindFilterType, collation string) (string, error) {
+	if findFilter == nil {
+		return "", nil
+	}
+
+	var ret strings.Builder
+	for _, k := range findFilter.ThenBy {
+		if k == nil {
+			continue
+		}
+
+		s, ok := o[k.Sort]
+		if !ok {
+			return "", fmt.Errorf("invalid then by sort: %s", k.Sort)
+		}
+
+		ret.WriteString(", " + s.expr)
+		if s.text {
+			ret.WriteString(" COLLATE " + collation)
+		}
+		ret.WriteString(" " + k.GetDirection())
+	}
+
+	return ret.String(), nil
+}
+
+var (
+	sceneThenBySorts     = commonThenBySorts(sceneTable).with(sceneTable, "~title", "~code", "date", "rating", "organized").withStudio(sceneTable)
+	performerThenBySorts = commonThenBySorts(performerTable).with(performerTable, "~name", "birthdate", "rating", "gender", "favorite")
+	studioThenBySorts    = commonThenBySorts(studioTable).with(studioTable, "~name", "rating", "favorite")
+	tagThenBySorts       = commonThenBySorts(tagTable).with(tagTable, "favorite").withSortName()
+	galleryThenBySorts   = commonThenBySorts(galleryTable).with(galleryTable, "~title", "date", "rating", "organized").withStudio(galleryTable)
+	imageThenBySorts     = commonThenBySorts(imageTable).with(imageTable, "~title", "date", "rating", "organized").withStudio(imageTable)
+	groupThenBySorts     = commonThenBySorts(groupTable).with(groupTable, "~name", "date", "rating", "duration").withStudio(groupTable)
+)
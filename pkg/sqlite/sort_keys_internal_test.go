@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThenBySorts_getThenBySort(t *testing.T) {
+	desc := models.SortDirectionEnumDesc
+
+	tests := []struct {
+		name    string
+		thenBy  []*models.SortKey
+		want    string
+		wantErr bool
+	}{
+		{
+			"none",
+			nil,
+			"",
+			false,
+		},
+		{
+			"studio then date",
+			[]*models.SortKey{{Sort: "studio"}, {Sort: "date", Direction: &desc}},
+			", (SELECT studios.name FROM studios WHERE studios.id = scenes.studio_id) COLLATE NATURAL_CI ASC, scenes.date DESC",
+			false,
+		},
+		{
+			"text column",
+			[]*models.SortKey{{Sort: "title", Direction: &desc}},
+			", scenes.title COLLATE NATURAL_CI DESC",
+			false,
+		},
+		{
+			"invalid",
+			[]*models.SortKey{{Sort: "title; DROP TABLE scenes"}},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sceneThenBySorts.getThenBySort(&models.FindFilterType{ThenBy: tt.thenBy}, naturalCollation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getThenBySort() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagThenBySorts(t *testing.T) {
+	got, err := tagThenBySorts.getThenBySort(&models.FindFilterType{
+		ThenBy: []*models.SortKey{{Sort: "name"}},
+	}, binaryCollation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ", COALESCE(tags.sort_name, tags.name) COLLATE BINARY ASC", got)
+}
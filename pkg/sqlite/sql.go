@@ -78,7 +78,10 @@ func getSortDirection(direction string) string {
 		return direction
 	}
 }
-func getSort(sort string, direction string, tableName string) string {
+
+// getSort returns the ORDER BY clause for the sort. Name and title columns
+// are sorted using collation.
+func getSort(sort string, direction string, tableName string, collation string) string {
 	direction = getSortDirection(direction)
 
 	switch {
@@ -106,10 +109,10 @@ func getSort(sort string, direction string, tableName string) string {
 			colName = sort
 		}
 		if strings.Compare(sort, "name") == 0 {
-			return " ORDER BY " + colName + " COLLATE NATURAL_CI " + direction
+			return " ORDER BY " + colName + " COLLATE " + collation + " " + direction
 		}
 		if strings.Compare(sort, "title") == 0 {
-			return " ORDER BY " + colName + " COLLATE NATURAL_CI " + direction
+			return " ORDER BY " + colName + " COLLATE " + collation + " " + direction
 		}
 
 		return " ORDER BY " + colName + " " + direction
@@ -141,7 +144,7 @@ func getCountSortWithoutOrderBy(primaryTable, joinTable, primaryFK, direction st
 	return fmt.Sprintf(", (SELECT COUNT(*) FROM %s AS sort WHERE sort.%s = %s.id) %s", joinTable, primaryFK, primaryTable, getSortDirection(direction))
 }
 
-func getSortWithoutOrderBy(sort string, direction string, tableName string) string {
+func getSortWithoutOrderBy(sort string, direction string, tableName string, collation string) string {
 	direction = getSortDirection(direction)
 
 	switch {
@@ -169,10 +172,10 @@ func getSortWithoutOrderBy(sort string, direction string, tableName string) stri
 			colName = sort
 		}
 		if strings.Compare(sort, "name") == 0 {
-			return ", " + colName + " COLLATE NATURAL_CI " + direction
+			return ", " + colName + " COLLATE " + collation + " " + direction
 		}
 		if strings.Compare(sort, "title") == 0 {
-			return ", " + colName + " COLLATE NATURAL_CI " + direction
+			return ", " + colName + " COLLATE " + collation + " " + direction
 		}
 
 		return ", " + colName + " " + direction
@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	stashBoxSubmissionTable = "stash_box_submissions"
+)
+
+type stashBoxSubmissionRow struct {
+	ID        int                             `db:"id" goqu:"skipinsert"`
+	Endpoint  string                          `db:"endpoint"`
+	Type      models.StashBoxSubmissionType   `db:"type"`
+	ObjectID  int                             `db:"object_id"`
+	Status    models.StashBoxSubmissionStatus `db:"status"`
+	DraftID   null.String                     `db:"draft_id"`
+	Error     null.String                     `db:"error"`
+	Attempts  int                             `db:"attempts"`
+	CreatedAt Timestamp                       `db:"created_at"`
+	UpdatedAt Timestamp                       `db:"updated_at"`
+}
+
+func (r *stashBoxSubmissionRow) fromStashBoxSubmission(o models.StashBoxSubmission) {
+	r.ID = o.ID
+	r.Endpoint = o.Endpoint
+	r.Type = o.Type
+	r.ObjectID = o.ObjectID
+	r.Status = o.Status
+	r.DraftID = null.StringFromPtr(o.DraftID)
+	r.Error = null.StringFromPtr(o.Error)
+	r.Attempts = o.Attempts
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *stashBoxSubmissionRow) resolve() *models.StashBoxSubmission {
+	return &models.StashBoxSubmission{
+		ID:        r.ID,
+		Endpoint:  r.Endpoint,
+		Type:      r.Type,
+		ObjectID:  r.ObjectID,
+		Status:    r.Status,
+		DraftID:   nullStringPtr(r.DraftID),
+		Error:     nullStringPtr(r.Error),
+		Attempts:  r.Attempts,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+type StashBoxSubmissionStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewStashBoxSubmissionStore() *StashBoxSubmissionStore {
+	return &StashBoxSubmissionStore{
+		repository: repository{
+			tableName: stashBoxSubmissionTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: stashBoxSubmissionTableMgr,
+	}
+}
+
+func (qb *StashBoxSubmissionStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *StashBoxSubmissionStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *StashBoxSubmissionStore) Create(ctx context.Context, newObject *models.StashBoxSubmission) error {
+	var r stashBoxSubmissionRow
+	r.fromStashBoxSubmission(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.FindMany(ctx, []int{id})
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+	if len(updated) == 0 {
+		return fmt.Errorf("%s with id %d not found after create", stashBoxSubmissionTable, id)
+	}
+
+	*newObject = *updated[0]
+
+	return nil
+}
+
+func (qb *StashBoxSubmissionStore) Update(ctx context.Context, updatedObject *models.StashBoxSubmission) error {
+	var r stashBoxSubmissionRow
+	r.fromStashBoxSubmission(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+// FindMany returns the submissions with the ids, in the order of the ids.
+// Submissions that do not exist are omitted.
+func (qb *StashBoxSubmissionStore) FindMany(ctx context.Context, ids []int) ([]*models.StashBoxSubmission, error) {
+	found, err := qb.getMany(ctx, qb.selectDataset().Where(qb.tableMgr.byIDInts(ids...)))
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.StashBoxSubmission, len(found))
+	for _, s := range found {
+		byID[s.ID] = s
+	}
+
+	var ret []*models.StashBoxSubmission
+	for _, id := range ids {
+		if s, ok := byID[id]; ok {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *StashBoxSubmissionStore) FindByEndpoint(ctx context.Context, endpoint string) ([]*models.StashBoxSubmission, error) {
+	q := qb.selectDataset().
+		Where(qb.table().Col("endpoint").Eq(endpoint)).
+		Order(qb.table().Col("created_at").Desc(), qb.table().Col(idColumn).Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *StashBoxSubmissionStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.StashBoxSubmission, error) {
+	const single = false
+	var ret []*models.StashBoxSubmission
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f stashBoxSubmissionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -591,6 +591,8 @@ var studioSortOptions = sortOptions{
 }
 
 func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string, error) {
+	collation := getCollation(findFilter)
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -619,11 +621,17 @@ func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string,
 	case "child_count":
 		sortQuery += getCountSort(studioTable, studioTable, studioParentIDColumn, direction)
 	default:
-		sortQuery += getSort(sort, direction, "studios")
+		sortQuery += getSort(sort, direction, "studios", collation)
+	}
+
+	thenBy, err := studioThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return "", err
 	}
+	sortQuery += thenBy
 
 	// Whatever the sorting, always use name/id as a final sort
-	sortQuery += ", COALESCE(studios.name, studios.id) COLLATE NATURAL_CI ASC"
+	sortQuery += ", COALESCE(studios.name, studios.id) COLLATE " + collation + " ASC"
 	return sortQuery, nil
 }
 
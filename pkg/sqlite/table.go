@@ -983,6 +983,146 @@ func (t *imageGalleriesTable) resetCover(ctx context.Context, galleryID int) err
 	return nil
 }
 
+// getOrderIndex returns the manual order index of the image in the gallery.
+// Returns nil if the image has not been manually ordered.
+func (t *imageGalleriesTable) getOrderIndex(ctx context.Context, id int, galleryID int) (*int, error) {
+	table := t.table.table
+	q := dialect.Select(table.Col("order_index")).From(table).Where(
+		t.idColumn.Eq(id),
+		table.Col(galleryIDColumn).Eq(galleryID),
+	)
+
+	var ret null.Int
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return nil, fmt.Errorf("getting order index: %w", err)
+	}
+
+	return nullIntPtr(ret), nil
+}
+
+// initOrderIndexes sets the order index of the images in the gallery that
+// have not been manually ordered. These are appended after the ordered images,
+// in the default gallery order.
+func (t *imageGalleriesTable) initOrderIndexes(ctx context.Context, galleryID int) error {
+	table := t.table.table
+
+	maxQ := dialect.Select(goqu.MAX("order_index")).From(table).Where(table.Col(galleryIDColumn).Eq(galleryID))
+
+	var maxIndex null.Int
+	if err := querySimple(ctx, maxQ, &maxIndex); err != nil {
+		return fmt.Errorf("getting max order index: %w", err)
+	}
+
+	imagesFiles := goqu.T(imagesFilesTable)
+	files := goqu.T(fileTable)
+	folders := goqu.T(folderTable)
+	images := goqu.T(imageTable)
+
+	q := dialect.From(table).Select(t.idColumn).
+		InnerJoin(images, goqu.On(images.Col(idColumn).Eq(t.idColumn))).
+		LeftJoin(imagesFiles, goqu.On(
+			imagesFiles.Col(imageIDColumn).Eq(t.idColumn),
+			imagesFiles.Col("primary").Eq(1),
+		)).
+		LeftJoin(files, goqu.On(files.Col(idColumn).Eq(imagesFiles.Col(fileIDColumn)))).
+		LeftJoin(folders, goqu.On(folders.Col(idColumn).Eq(files.Col("parent_folder_id")))).
+		Where(
+			table.Col(galleryIDColumn).Eq(galleryID),
+			table.Col("order_index").IsNull(),
+		).
+		Order(defaultGalleryOrder...)
+
+	var ids []int
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+
+		ids = append(ids, id)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("getting unordered images: %w", err)
+	}
+
+	next := 0
+	if maxIndex.Valid {
+		next = int(maxIndex.Int64) + 1
+	}
+
+	for _, id := range ids {
+		uq := dialect.Update(table).Prepared(true).Set(goqu.Record{
+			"order_index": next,
+		}).Where(t.idColumn.Eq(id), table.Col(galleryIDColumn).Eq(galleryID))
+
+		if _, err := exec(ctx, uq); err != nil {
+			return fmt.Errorf("setting order index in %s: %w", table.GetTable(), err)
+		}
+
+		next++
+	}
+
+	return nil
+}
+
+// reorderImage moves the image to before or after the insertion point image.
+// The gallery must have been ordered with initOrderIndexes.
+func (t *imageGalleriesTable) reorderImage(ctx context.Context, galleryID int, id int, insertPointID int, insertAfter bool) error {
+	table := t.table.table
+
+	insertPointIndex, err := t.getOrderIndex(ctx, insertPointID, galleryID)
+	if err != nil {
+		return err
+	}
+
+	if insertPointIndex == nil {
+		return fmt.Errorf("image %d not found in gallery %d", insertPointID, galleryID)
+	}
+
+	index := *insertPointIndex
+
+	if insertAfter {
+		q := dialect.Select(goqu.MIN("order_index")).From(table).Where(
+			table.Col(galleryIDColumn).Eq(galleryID),
+			table.Col("order_index").Gt(index),
+		)
+
+		var next null.Int
+		if err := querySimple(ctx, q, &next); err != nil {
+			return fmt.Errorf("getting order index: %w", err)
+		}
+
+		if next.Valid {
+			index = int(next.Int64)
+		} else {
+			index++
+		}
+	}
+
+	// make room at the insertion point
+	q := dialect.Update(table).Prepared(true).Set(goqu.Record{
+		"order_index": goqu.L("order_index + 1"),
+	}).Where(
+		table.Col(galleryIDColumn).Eq(galleryID),
+		table.Col("order_index").Gte(index),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	q = dialect.Update(table).Prepared(true).Set(goqu.Record{
+		"order_index": index,
+	}).Where(t.idColumn.Eq(id), table.Col(galleryIDColumn).Eq(galleryID))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
 type relatedFilesTable struct {
 	table
 }
@@ -1344,6 +1484,40 @@ func (t *viewHistoryTable) deleteAllDates(ctx context.Context, id int) (int, err
 	return t.getCount(ctx, id)
 }
 
+// deleteDatesInRange deletes the dates of the id between start and end
+// inclusive. It returns the number of deleted dates.
+func (t *viewHistoryTable) deleteDatesInRange(ctx context.Context, id int, start, end time.Time) (int, error) {
+	table := t.table.table
+	q := dialect.Delete(table).Where(
+		t.idColumn.Eq(id),
+		t.dateColumn.Gte(UTCTimestamp{Timestamp{start}}),
+		t.dateColumn.Lte(UTCTimestamp{Timestamp{end}}),
+	)
+
+	res, err := exec(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("deleting dates in range for id %v: %w", id, err)
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// deleteDatesBefore deletes all dates before the given time. It returns the
+// number of deleted dates.
+func (t *viewHistoryTable) deleteDatesBefore(ctx context.Context, before time.Time) (int, error) {
+	table := t.table.table
+	q := dialect.Delete(table).Where(t.dateColumn.Lt(UTCTimestamp{Timestamp{before}}))
+
+	res, err := exec(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("deleting dates from %s: %w", table.GetTable(), err)
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
 func (t *viewHistoryTable) getDatesInRange(ctx context.Context, start, end time.Time) ([]time.Time, error) {
 	table := t.table.table
 
@@ -1371,6 +1545,35 @@ func (t *viewHistoryTable) getDatesInRange(ctx context.Context, start, end time.
 	return ret, nil
 }
 
+func (t *viewHistoryTable) getEventsInRange(ctx context.Context, start, end time.Time) ([]models.HistoryEvent, error) {
+	table := t.table.table
+
+	q := dialect.Select(
+		t.idColumn,
+		t.dateColumn,
+	).From(table).Where(
+		t.dateColumn.Gte(UTCTimestamp{Timestamp{start}}),
+		t.dateColumn.Lte(UTCTimestamp{Timestamp{end}}),
+	).Order(t.dateColumn.Asc())
+
+	const single = false
+	var ret []models.HistoryEvent
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var id int
+		var date Timestamp
+		if err := rows.Scan(&id, &date); err != nil {
+			return err
+		}
+
+		ret = append(ret, models.HistoryEvent{ID: id, Date: date.Timestamp})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 type sqler interface {
 	ToSQL() (sql string, params []interface{}, err error)
 }
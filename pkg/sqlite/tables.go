@@ -513,6 +513,67 @@ var (
 	}
 )
 
+var (
+	sceneParserBatchTableMgr = &table{
+		table:    goqu.T(sceneParserBatchTable),
+		idColumn: goqu.T(sceneParserBatchTable).Col(idColumn),
+	}
+
+	sceneParserChangeTableMgr = &table{
+		table:    goqu.T(sceneParserChangeTable),
+		idColumn: goqu.T(sceneParserChangeTable).Col(idColumn),
+	}
+)
+
+var (
+	shareLinkTableMgr = &table{
+		table:    goqu.T(shareLinkTable),
+		idColumn: goqu.T(shareLinkTable).Col(idColumn),
+	}
+)
+
+var (
+	sceneExtraTableMgr = &table{
+		table:    goqu.T(sceneExtraTable),
+		idColumn: goqu.T(sceneExtraTable).Col(fileIDColumn),
+	}
+)
+
+var (
+	noteTableMgr = &table{
+		table:    goqu.T(noteTable),
+		idColumn: goqu.T(noteTable).Col(idColumn),
+	}
+)
+
+var (
+	smartPlaylistTableMgr = &table{
+		table:    goqu.T(smartPlaylistTable),
+		idColumn: goqu.T(smartPlaylistTable).Col(idColumn),
+	}
+)
+
+var (
+	stashBoxSubmissionTableMgr = &table{
+		table:    goqu.T(stashBoxSubmissionTable),
+		idColumn: goqu.T(stashBoxSubmissionTable).Col(idColumn),
+	}
+)
+
+var (
+	jobCheckpointTableMgr = &table{
+		table:    goqu.T(jobCheckpointTable),
+		idColumn: goqu.T(jobCheckpointTable).Col(idColumn),
+	}
+)
+
+var (
+	libraryStatsTableMgr = &table{
+		table:    goqu.T(libraryStatsTable),
+		idColumn: goqu.T(libraryStatsTable).Col(idColumn),
+	}
+)
+
 const (
 	colorPresetTable = "color_presets"
 )
@@ -678,10 +678,12 @@ var tagSortOptions = sortOptions{
 }
 
 func (qb *TagStore) getDefaultTagSort() string {
-	return getSort("name", "ASC", "tags")
+	return getSort("name", "ASC", "tags", getCollation(nil))
 }
 
 func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilterType) (string, error) {
+	collation := getCollation(findFilter)
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -700,7 +702,7 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 	sortQuery := ""
 	switch sort {
 	case "name":
-		sortQuery += fmt.Sprintf(" ORDER BY COALESCE(tags.sort_name, tags.name) COLLATE NATURAL_CI %s", getSortDirection(direction))
+		sortQuery += fmt.Sprintf(" ORDER BY COALESCE(tags.sort_name, tags.name) COLLATE %s %s", collation, getSortDirection(direction))
 	case "color_preset":
 		// Sort by color preset order, then by color, then by name
 		sortQuery += fmt.Sprintf(` ORDER BY 
@@ -710,8 +712,8 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 			END %s,
 			COALESCE(color_presets.sort, 999999) %s,
 			COALESCE(color_presets.color, '') %s,
-			COALESCE(tags.sort_name, tags.name) COLLATE NATURAL_CI %s`,
-			getSortDirection(direction), getSortDirection(direction), getSortDirection(direction), getSortDirection(direction))
+			COALESCE(tags.sort_name, tags.name) COLLATE %s %s`,
+			getSortDirection(direction), getSortDirection(direction), getSortDirection(direction), collation, getSortDirection(direction))
 	case "scenes_count":
 		sortQuery += getCountSort(tagTable, scenesTagsTable, tagIDColumn, direction)
 	case "scene_markers_count":
@@ -727,11 +729,17 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 	case "movies_count", "groups_count":
 		sortQuery += getCountSort(tagTable, groupsTagsTable, tagIDColumn, direction)
 	default:
-		sortQuery += getSort(sort, direction, "tags")
+		sortQuery += getSort(sort, direction, "tags", collation)
+	}
+
+	thenBy, err := tagThenBySorts.getThenBySort(findFilter, collation)
+	if err != nil {
+		return "", err
 	}
+	sortQuery += thenBy
 
 	// Whatever the sorting, always use sort_name/name/id as a final sort
-	sortQuery += ", COALESCE(tags.sort_name, tags.name, tags.id) COLLATE NATURAL_CI ASC"
+	sortQuery += ", COALESCE(tags.sort_name, tags.name, tags.id) COLLATE " + collation + " ASC"
 	return sortQuery, nil
 }
 
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/threatscan"
+)
+
+const (
+	// threatsFoundWhere matches video files with detected threats
+	threatsFoundWhere = "COALESCE(video_files.threats, '') != ''"
+	// threatsNeverScannedWhere matches video files which were never scanned
+	// for threats
+	threatsNeverScannedWhere = "(video_files.file_id IS NOT NULL AND video_files.threats_scanned_at IS NULL)"
+)
+
+// threatSeverityWhere returns the condition matching video files with a
+// threat of at least the severity.
+func threatSeverityWhere(severity models.ThreatSeverity) (string, []interface{}) {
+	keywords := threatscan.SeverityKeywords(severity)
+	if len(keywords) == 0 {
+		return threatsFoundWhere, nil
+	}
+
+	clauses := make([]string, len(keywords))
+	args := make([]interface{}, len(keywords))
+	for i, k := range keywords {
+		clauses[i] = "video_files.threats LIKE ?"
+		args[i] = "%" + k + "%"
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// threatFilter is the criteria on the threat scan of video files.
+type threatFilter struct {
+	hasThreats    *bool
+	severity      *models.ThreatSeverity
+	scannedBefore *time.Time
+	neverScanned  *bool
+}
+
+// threatCriterionHandler filters by the threat scan of video files. where
+// turns a condition on the video_files table into a condition on the
+// filtered objects.
+func threatCriterionHandler(filter threatFilter, where func(cond string) string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if filter.hasThreats == nil && filter.severity == nil && filter.scannedBefore == nil && filter.neverScanned == nil {
+			return
+		}
+
+		if addJoinFn != nil {
+			addJoinFn(f)
+		}
+
+		addBool := func(b *bool, cond string) {
+			if b == nil {
+				return
+			}
+			if *b {
+				f.addWhere(where(cond))
+			} else {
+				f.addWhere("NOT " + where(cond))
+			}
+		}
+
+		addBool(filter.hasThreats, threatsFoundWhere)
+		addBool(filter.neverScanned, threatsNeverScannedWhere)
+
+		if filter.severity != nil {
+			cond, args := threatSeverityWhere(*filter.severity)
+			f.addWhere(where(cond), args...)
+		}
+
+		if filter.scannedBefore != nil {
+			f.addWhere(where("video_files.threats_scanned_at < ?"), Timestamp{Timestamp: *filter.scannedBefore})
+		}
+	}
+}
+
+// sceneVideoFileWhere matches scenes with a video file matching the
+// condition.
+func sceneVideoFileWhere(cond string) string {
+	return `EXISTS (
+	SELECT 1 FROM scenes_files
+	INNER JOIN video_files ON video_files.file_id = scenes_files.file_id
+	WHERE scenes_files.scene_id = scenes.id AND ` + cond + `
+)`
+}
+
+// videoFileWhere matches files with the condition on the joined video_files
+// table.
+func videoFileWhere(cond string) string {
+	return "(" + cond + ")"
+}
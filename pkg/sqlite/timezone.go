@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// dayLocation holds the time zone in which days start, for queries that
+// group events by day. Events are stored in UTC, so the location is shared
+// by all databases.
+var dayLocation = struct {
+	mutex sync.Mutex
+	loc   *time.Location
+}{
+	loc: time.Local,
+}
+
+// SetTimezone sets the time zone in which days start when events are
+// grouped by day.
+func (db *Database) SetTimezone(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	dayLocation.mutex.Lock()
+	defer dayLocation.mutex.Unlock()
+
+	dayLocation.loc = loc
+}
+
+// localDate returns the expression of the date of the UTC timestamp column
+// in the time zone in which days start.
+func localDate(column string) string {
+	return fmt.Sprintf("local_date(%s)", column)
+}
+
+// localDateFn is the local_date sqlite function. It returns the date of the
+// UTC timestamp ts in the time zone in which days start, using the offset of
+// the time zone at that time, so that days are split correctly on both sides
+// of a daylight saving change. Returns null if ts is not a timestamp.
+func localDateFn(ts string) (interface{}, error) {
+	dayLocation.mutex.Lock()
+	loc := dayLocation.loc
+	dayLocation.mutex.Unlock()
+
+	d, ok := localDateIn(ts, loc)
+	if !ok {
+		return nil, nil
+	}
+
+	return d, nil
+}
+
+// timestampLayouts are the layouts of the timestamps accepted by
+// localDateFn. Timestamps without an offset are in UTC.
+var timestampLayouts = []string{
+	TimestampFormat,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+func localDateIn(ts string, loc *time.Location) (string, bool) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, ts)
+		if err == nil {
+			return t.In(loc).Format("2006-01-02"), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalDateIn(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ts   string
+		want string
+	}{
+		// UTC+1 before the change on 2024-03-31 at 01:00 UTC
+		{"winter before midnight", "2024-03-30T22:59:59Z", "2024-03-30"},
+		{"winter after midnight", "2024-03-30T23:00:00Z", "2024-03-31"},
+		// UTC+2 after the change
+		{"summer before midnight", "2024-03-31T21:59:59Z", "2024-03-31"},
+		{"summer after midnight", "2024-03-31T22:00:00Z", "2024-04-01"},
+		{"sqlite format", "2024-03-31 22:00:00", "2024-04-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := localDateIn(tt.ts, loc)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := localDateIn("not a timestamp", loc)
+	assert.False(t, ok)
+}
@@ -126,11 +126,20 @@ func (db *Database) Repository() models.Repository {
 		GalleryChapter:        db.GalleryChapter,
 		Image:                 db.Image,
 		Group:                 db.Group,
+		Note:                  db.Note,
 		Performer:             db.Performer,
 		PerformerProfileImage: db.PerformerProfileImage,
 		Scene:                 db.Scene,
+		SceneExtra:            db.SceneExtra,
 		SceneMarker:           db.SceneMarker,
 		SceneSimilarity:       db.SceneSimilarity,
+		SceneParserBatch:      db.SceneParserBatch,
+		ShareLink:             db.ShareLink,
+		SmartPlaylist:         db.SmartPlaylist,
+		StashBoxSubmission:    db.StashBoxSubmission,
+		JobCheckpoint:         db.JobCheckpoint,
+		LibraryStats:          db.LibraryStats,
+		Consistency:           db.Consistency,
 		Studio:                db.Studio,
 		Tag:                   db.Tag,
 		SavedFilter:           db.SavedFilter,
@@ -37,6 +37,15 @@ func nullFloatPtr(i null.Float) *float64 {
 	return &v
 }
 
+func nullBoolPtr(b null.Bool) *bool {
+	if !b.Valid {
+		return nil
+	}
+
+	v := b.Bool
+	return &v
+}
+
 func float64FromPtr(f *float64) null.Float {
 	if f == nil {
 		return null.NewFloat(0, false)
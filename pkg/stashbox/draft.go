@@ -65,6 +65,11 @@ func (c *Client) submitDraft(ctx context.Context, query string, input interface{
 		return err
 	}
 
+	// other error statuses are returned with graphql errors in the body
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(responseBytes)}
+	}
+
 	type response struct {
 		Data   json.RawMessage `json:"data"`
 		Errors json.RawMessage `json:"errors"`
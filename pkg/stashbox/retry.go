@@ -0,0 +1,85 @@
+package stashbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBackoff  = 2 * time.Second
+)
+
+// HTTPStatusError is returned when the stash-box endpoint responds with an
+// error status.
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("stash-box responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsTransientError returns true if err is a network error or an error status
+// that may succeed if the request is retried.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Retrier runs requests again while they fail with transient errors, waiting
+// longer after each failed attempt.
+type Retrier struct {
+	// Attempts is the maximum number of attempts of each request.
+	Attempts int
+	// Backoff is the wait before the first retry. It doubles with each retry.
+	Backoff time.Duration
+}
+
+// NewRetrier returns a Retrier using the default number of attempts and
+// backoff.
+func NewRetrier() Retrier {
+	return Retrier{
+		Attempts: defaultRetryAttempts,
+		Backoff:  defaultRetryBackoff,
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not transient,
+// or the attempts are exhausted. Returns the last error and the number of
+// attempts made.
+func (r Retrier) Do(ctx context.Context, fn func() error) (attempts int, err error) {
+	backoff := r.Backoff
+	for attempts = 1; ; attempts++ {
+		err = fn()
+		if !IsTransientError(err) || attempts >= r.Attempts {
+			return attempts, err
+		}
+
+		logger.Debugf("Retrying stash-box request in %s after error: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return attempts, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
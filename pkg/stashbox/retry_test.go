@@ -0,0 +1,57 @@
+package stashbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"canceled", context.Canceled, false},
+		{"graphql error", errors.New("draft is invalid"), false},
+		{"bad request", &HTTPStatusError{StatusCode: 400}, false},
+		{"too many requests", &HTTPStatusError{StatusCode: 429}, true},
+		{"bad gateway", fmt.Errorf("submitting: %w", &HTTPStatusError{StatusCode: 502}), true},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransientError(tt.err))
+		})
+	}
+}
+
+func TestRetrier_Do(t *testing.T) {
+	r := Retrier{Attempts: 3}
+	transient := &HTTPStatusError{StatusCode: 503}
+
+	calls := 0
+	attempts, err := r.Do(context.Background(), func() error {
+		calls++
+		if calls < 2 {
+			return transient
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	attempts, err = r.Do(context.Background(), func() error { return transient })
+	assert.ErrorIs(t, err, transient)
+	assert.Equal(t, 3, attempts)
+
+	permanent := errors.New("draft is invalid")
+	attempts, err = r.Do(context.Background(), func() error { return permanent })
+	assert.ErrorIs(t, err, permanent)
+	assert.Equal(t, 1, attempts)
+}
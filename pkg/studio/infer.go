@@ -0,0 +1,288 @@
+package studio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// InferenceSource is the kind of evidence a studio is inferred from.
+type InferenceSource string
+
+const (
+	// InferenceSourceURL is the domain of a url of the scene.
+	InferenceSourceURL InferenceSource = "URL"
+	// InferenceSourceFilename is a studio name or alias in the path of the
+	// scene.
+	InferenceSourceFilename InferenceSource = "FILENAME"
+	// InferenceSourceWatermark is a studio name or alias in the text
+	// recognised on screen, such as a watermark.
+	InferenceSourceWatermark InferenceSource = "WATERMARK"
+)
+
+var inferenceSources = []InferenceSource{
+	InferenceSourceURL,
+	InferenceSourceFilename,
+	InferenceSourceWatermark,
+}
+
+func (e InferenceSource) IsValid() bool {
+	switch e {
+	case InferenceSourceURL, InferenceSourceFilename, InferenceSourceWatermark:
+		return true
+	}
+	return false
+}
+
+func (e InferenceSource) String() string {
+	return string(e)
+}
+
+func (e *InferenceSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InferenceSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StudioInferenceSource", str)
+	}
+	return nil
+}
+
+func (e InferenceSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Confidences of each kind of evidence on its own. A domain mapped by the
+// user is trusted over the url of a studio, which may be shared by the
+// studios of a network.
+const (
+	MappedDomainConfidence = 0.95
+	StudioDomainConfidence = 0.9
+	FilenameConfidence     = 0.6
+	WatermarkConfidence    = 0.5
+)
+
+// DomainMapping maps a domain, and its subdomains, to a studio.
+type DomainMapping struct {
+	Domain   string `json:"domain" koanf:"domain"`
+	StudioID int    `json:"studio_id" koanf:"studio_id"`
+}
+
+// ValidateDomainMappings returns an error if a mapping has no domain or
+// studio, or if a domain is mapped more than once.
+func ValidateDomainMappings(mappings []DomainMapping) error {
+	seen := make(map[string]bool)
+	for _, m := range mappings {
+		domain := Domain(m.Domain)
+		if domain == "" {
+			return errors.New("domain is required")
+		}
+		if m.StudioID <= 0 {
+			return fmt.Errorf("studio is required for domain %s", domain)
+		}
+		if seen[domain] {
+			return fmt.Errorf("domain %s is mapped more than once", domain)
+		}
+		seen[domain] = true
+	}
+
+	return nil
+}
+
+// Domain returns the lowercase host of a url, without its port or a www
+// prefix. The scheme of the url is optional. Returns an empty string if the
+// url has no host.
+func Domain(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "//" + rawURL
+	}
+
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
+
+type domainStudio struct {
+	studioID   int
+	confidence float64
+}
+
+// DomainIndex finds the studio of the domain of a url.
+type DomainIndex struct {
+	domains map[string]domainStudio
+}
+
+// NewDomainIndex returns an index of the domains of the studio urls and the
+// user mappings. Mappings take precedence over studio urls. A domain used
+// by the urls of more than one studio is ignored, unless it is mapped.
+func NewDomainIndex(studios []*models.Studio, mappings []DomainMapping) *DomainIndex {
+	ret := &DomainIndex{
+		domains: make(map[string]domainStudio),
+	}
+
+	ambiguous := make(map[string]bool)
+	for _, s := range studios {
+		domain := Domain(s.URL)
+		if domain == "" || ambiguous[domain] {
+			continue
+		}
+
+		if existing, found := ret.domains[domain]; found && existing.studioID != s.ID {
+			ambiguous[domain] = true
+			delete(ret.domains, domain)
+			continue
+		}
+
+		ret.domains[domain] = domainStudio{studioID: s.ID, confidence: StudioDomainConfidence}
+	}
+
+	for _, m := range mappings {
+		if domain := Domain(m.Domain); domain != "" {
+			ret.domains[domain] = domainStudio{studioID: m.StudioID, confidence: MappedDomainConfidence}
+		}
+	}
+
+	return ret
+}
+
+// Match returns the evidence for the studio of the domain of the url. The
+// most specific domain is used, so that a url of members.example.com
+// matches example.com unless members.example.com is indexed itself.
+// Returns false if no domain matches.
+func (i *DomainIndex) Match(rawURL string) (Evidence, bool) {
+	domain := Domain(rawURL)
+	for domain != "" {
+		if s, found := i.domains[domain]; found {
+			return Evidence{
+				Source:     InferenceSourceURL,
+				StudioID:   s.studioID,
+				Confidence: s.confidence,
+			}, true
+		}
+
+		// stop before the top-level domain
+		dot := strings.IndexByte(domain, '.')
+		if dot == -1 || !strings.Contains(domain[dot+1:], ".") {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+
+	return Evidence{}, false
+}
+
+// Evidence is a studio inferred for a scene from a single source.
+type Evidence struct {
+	Source     InferenceSource
+	StudioID   int
+	Confidence float64
+}
+
+// Candidate is a scene without a studio and the evidence found for it.
+type Candidate struct {
+	SceneID  int
+	Evidence []Evidence
+}
+
+// Proposal is a studio proposed for a scene.
+type Proposal struct {
+	ID       int
+	SceneID  int
+	StudioID int
+	// Confidence is from 0 to 1, combining the confidence of each source.
+	Confidence float64
+	// Sources are the kinds of evidence for the studio.
+	Sources []InferenceSource
+}
+
+// ProposalReport lists the studios proposed for scenes without a studio.
+type ProposalReport struct {
+	GeneratedAt time.Time
+	Proposals   []Proposal
+}
+
+// Infer returns the studio with the highest confidence from the evidence.
+// The confidences of independent sources for the same studio are combined,
+// so that a studio found in both the url and the filename is more likely
+// than one found in the url alone. Returns false if there is no evidence.
+func Infer(evidence []Evidence) (Proposal, bool) {
+	// highest confidence of each source, by studio
+	bySource := make(map[int]map[InferenceSource]float64)
+	for _, e := range evidence {
+		sources := bySource[e.StudioID]
+		if sources == nil {
+			sources = make(map[InferenceSource]float64)
+			bySource[e.StudioID] = sources
+		}
+		if e.Confidence > sources[e.Source] {
+			sources[e.Source] = e.Confidence
+		}
+	}
+
+	var ret Proposal
+	found := false
+	for studioID, sources := range bySource {
+		p := Proposal{StudioID: studioID}
+
+		doubt := 1.0
+		for _, source := range inferenceSources {
+			if c, ok := sources[source]; ok {
+				doubt *= 1 - c
+				p.Sources = append(p.Sources, source)
+			}
+		}
+		p.Confidence = 1 - doubt
+
+		// prefer the lower id on a tie, so the result does not depend on
+		// map order
+		if !found || p.Confidence > ret.Confidence || (p.Confidence == ret.Confidence && p.StudioID < ret.StudioID) {
+			ret = p
+			found = true
+		}
+	}
+
+	return ret, found
+}
+
+// Propose returns a proposal for each candidate with evidence, ordered by
+// descending confidence. Proposals are numbered from 1 in that order.
+func Propose(candidates []Candidate) []Proposal {
+	var ret []Proposal
+	for _, c := range candidates {
+		p, ok := Infer(c.Evidence)
+		if !ok {
+			continue
+		}
+
+		p.SceneID = c.SceneID
+		ret = append(ret, p)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Confidence > ret[j].Confidence
+	})
+
+	for i := range ret {
+		ret[i].ID = i + 1
+	}
+
+	return ret
+}
@@ -0,0 +1,134 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.Example.com/scenes/1", "example.com"},
+		{"http://members.example.com:8080", "members.example.com"},
+		{"example.com/path", "example.com"},
+		{"", ""},
+		{"/relative/path", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Domain(tt.url), tt.url)
+	}
+}
+
+func TestValidateDomainMappings(t *testing.T) {
+	assert.NoError(t, ValidateDomainMappings([]DomainMapping{
+		{Domain: "example.com", StudioID: 1},
+		{Domain: "other.com", StudioID: 1},
+	}))
+	assert.Error(t, ValidateDomainMappings([]DomainMapping{{Domain: " ", StudioID: 1}}))
+	assert.Error(t, ValidateDomainMappings([]DomainMapping{{Domain: "example.com"}}))
+	assert.Error(t, ValidateDomainMappings([]DomainMapping{
+		{Domain: "example.com", StudioID: 1},
+		{Domain: "https://www.example.com", StudioID: 2},
+	}))
+}
+
+func TestDomainIndex_Match(t *testing.T) {
+	studios := []*models.Studio{
+		{ID: 1, URL: "https://www.studio.com"},
+		{ID: 2, URL: "https://network.com/site-a"},
+		{ID: 3, URL: "https://network.com/site-b"},
+		{ID: 4},
+	}
+	mappings := []DomainMapping{
+		{Domain: "site-a.network.com", StudioID: 2},
+		{Domain: "alias.com", StudioID: 1},
+	}
+
+	index := NewDomainIndex(studios, mappings)
+
+	tests := []struct {
+		name       string
+		url        string
+		studioID   int
+		confidence float64
+		found      bool
+	}{
+		{"studio url", "https://studio.com/scene/1", 1, StudioDomainConfidence, true},
+		{"subdomain", "https://members.studio.com/scene/1", 1, StudioDomainConfidence, true},
+		{"mapped", "alias.com/video", 1, MappedDomainConfidence, true},
+		{"mapped subdomain", "https://site-a.network.com/1", 2, MappedDomainConfidence, true},
+		{"ambiguous", "https://network.com/1", 0, 0, false},
+		{"unknown", "https://unknown.com/1", 0, 0, false},
+		{"top-level domain only", "https://com", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, found := index.Match(tt.url)
+			assert.Equal(t, tt.found, found)
+			if found {
+				assert.Equal(t, InferenceSourceURL, e.Source)
+				assert.Equal(t, tt.studioID, e.StudioID)
+				assert.Equal(t, tt.confidence, e.Confidence)
+			}
+		})
+	}
+}
+
+func TestPropose(t *testing.T) {
+	candidates := []Candidate{
+		{
+			// filename only
+			SceneID: 1,
+			Evidence: []Evidence{
+				{Source: InferenceSourceFilename, StudioID: 2, Confidence: FilenameConfidence},
+			},
+		},
+		{
+			// no evidence
+			SceneID: 2,
+		},
+		{
+			// url and watermark agree, filename disagrees
+			SceneID: 3,
+			Evidence: []Evidence{
+				{Source: InferenceSourceWatermark, StudioID: 1, Confidence: WatermarkConfidence},
+				{Source: InferenceSourceURL, StudioID: 1, Confidence: StudioDomainConfidence},
+				{Source: InferenceSourceURL, StudioID: 1, Confidence: MappedDomainConfidence},
+				{Source: InferenceSourceFilename, StudioID: 2, Confidence: FilenameConfidence},
+			},
+		},
+		{
+			// tied studios
+			SceneID: 4,
+			Evidence: []Evidence{
+				{Source: InferenceSourceFilename, StudioID: 5, Confidence: FilenameConfidence},
+				{Source: InferenceSourceFilename, StudioID: 4, Confidence: FilenameConfidence},
+			},
+		},
+	}
+
+	got := Propose(candidates)
+
+	assert.Len(t, got, 3)
+
+	assert.Equal(t, 1, got[0].ID)
+	assert.Equal(t, 3, got[0].SceneID)
+	assert.Equal(t, 1, got[0].StudioID)
+	assert.InDelta(t, 1-0.05*0.5, got[0].Confidence, 0.0001)
+	assert.Equal(t, []InferenceSource{InferenceSourceURL, InferenceSourceWatermark}, got[0].Sources)
+
+	assert.Equal(t, 2, got[1].ID)
+	assert.Equal(t, 1, got[1].SceneID)
+	assert.Equal(t, 2, got[1].StudioID)
+	assert.InDelta(t, FilenameConfidence, got[1].Confidence, 0.0001)
+	assert.Equal(t, []InferenceSource{InferenceSourceFilename}, got[1].Sources)
+
+	assert.Equal(t, 4, got[2].SceneID)
+	assert.Equal(t, 4, got[2].StudioID)
+}
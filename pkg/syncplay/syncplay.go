@@ -0,0 +1,523 @@
+// Package syncplay provides synchronized playback sessions, in which the
+// clients watching a scene together play, pause and seek in step.
+package syncplay
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// unjoinedSessionTimeout is the time after which a session that nobody
+	// has joined is removed.
+	unjoinedSessionTimeout = 5 * time.Minute
+
+	// maxLatency is the maximum latency used to compensate the position of
+	// a command. Larger values are assumed to be incorrect.
+	maxLatency = 5 * time.Second
+
+	// eventBufferSize is the number of events buffered for each member.
+	// Events are dropped for members that do not keep up.
+	eventBufferSize = 100
+)
+
+var (
+	ErrSessionNotFound = errors.New("sync play session not found")
+	ErrNotMember       = errors.New("not a member of the sync play session")
+	ErrNotHost         = errors.New("only the host of the sync play session can do this")
+	ErrInvalidAction   = errors.New("invalid sync play action")
+)
+
+// Action is a playback command sent by a member.
+type Action string
+
+const (
+	ActionPlay  Action = "PLAY"
+	ActionPause Action = "PAUSE"
+	ActionSeek  Action = "SEEK"
+)
+
+var AllActions = []Action{
+	ActionPlay,
+	ActionPause,
+	ActionSeek,
+}
+
+func (e Action) IsValid() bool {
+	switch e {
+	case ActionPlay, ActionPause, ActionSeek:
+		return true
+	}
+	return false
+}
+
+func (e Action) String() string {
+	return string(e)
+}
+
+func (e *Action) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Action(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SyncPlayAction", str)
+	}
+	return nil
+}
+
+func (e Action) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// EventType is the type of change broadcast to the members of a session.
+type EventType string
+
+const (
+	EventTypeJoin  EventType = "JOIN"
+	EventTypeLeave EventType = "LEAVE"
+	EventTypePlay  EventType = "PLAY"
+	EventTypePause EventType = "PAUSE"
+	EventTypeSeek  EventType = "SEEK"
+	// EventTypeUpdate is sent when the host or host only setting changes.
+	EventTypeUpdate EventType = "UPDATE"
+	EventTypeEnd    EventType = "END"
+)
+
+var AllEventTypes = []EventType{
+	EventTypeJoin,
+	EventTypeLeave,
+	EventTypePlay,
+	EventTypePause,
+	EventTypeSeek,
+	EventTypeUpdate,
+	EventTypeEnd,
+}
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventTypeJoin, EventTypeLeave, EventTypePlay, EventTypePause, EventTypeSeek, EventTypeUpdate, EventTypeEnd:
+		return true
+	}
+	return false
+}
+
+func (e EventType) String() string {
+	return string(e)
+}
+
+func (e *EventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SyncPlayEventType", str)
+	}
+	return nil
+}
+
+func (e EventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Member is a client that has joined a session.
+type Member struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// User is the authenticated user of the client, if any.
+	User     string    `json:"user"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Session is the state of a synchronized playback session.
+type Session struct {
+	ID      string `json:"id"`
+	SceneID int    `json:"scene_id"`
+	// HostID is the id of the member hosting the session. Empty until the
+	// first member joins.
+	HostID string `json:"host_id"`
+	// HostOnly restricts playback commands to the host.
+	HostOnly  bool      `json:"host_only"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Playing bool `json:"playing"`
+	// Position is the playback position in seconds at UpdatedAt.
+	Position  float64   `json:"position"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Members []Member `json:"members"`
+}
+
+// PositionAt returns the playback position in seconds at t.
+func (s Session) PositionAt(t time.Time) float64 {
+	if !s.Playing || t.Before(s.UpdatedAt) {
+		return s.Position
+	}
+
+	return s.Position + t.Sub(s.UpdatedAt).Seconds()
+}
+
+// Event is a change to a session, broadcast to its members.
+type Event struct {
+	Type EventType `json:"type"`
+	// MemberID is the id of the member that caused the event. The first
+	// event received by a joining member is its own join event, from which
+	// it learns its id.
+	MemberID string `json:"member_id"`
+	// Session is the state of the session after the event.
+	Session Session `json:"session"`
+	// ServerTime is the time the event was sent. Clients use it to estimate
+	// the difference between their clock and the server's.
+	ServerTime time.Time `json:"server_time"`
+}
+
+type session struct {
+	Session
+	events map[string]chan Event
+}
+
+func (s *session) member(id string) (int, bool) {
+	for i, m := range s.Members {
+		if m.ID == id {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// snapshot returns a copy of the session state that is safe to share.
+func (s *session) snapshot() Session {
+	ret := s.Session
+	ret.Members = append([]Member(nil), s.Members...)
+	return ret
+}
+
+func (s *session) broadcast(t EventType, memberID string, now time.Time) {
+	e := Event{
+		Type:       t,
+		MemberID:   memberID,
+		Session:    s.snapshot(),
+		ServerTime: now,
+	}
+
+	for _, ch := range s.events {
+		select {
+		case ch <- e:
+		default:
+			// drop the event rather than blocking the session
+		}
+	}
+}
+
+// Manager holds the active sessions.
+type Manager struct {
+	mutex    sync.Mutex
+	sessions map[string]*session
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		sessions: make(map[string]*session),
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// prune removes sessions that nobody has joined within
+// unjoinedSessionTimeout. Must be called with the mutex held.
+func (m *Manager) prune(now time.Time) {
+	for id, s := range m.sessions {
+		if s.HostID == "" && len(s.Members) == 0 && now.Sub(s.CreatedAt) > unjoinedSessionTimeout {
+			delete(m.sessions, id)
+		}
+	}
+}
+
+// Create creates a paused session for the scene. The first member to join
+// becomes the host.
+func (m *Manager) Create(sceneID int, hostOnly bool, now time.Time) Session {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.prune(now)
+
+	s := &session{
+		Session: Session{
+			ID:        newID(),
+			SceneID:   sceneID,
+			HostOnly:  hostOnly,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		events: make(map[string]chan Event),
+	}
+	m.sessions[s.ID] = s
+
+	return s.snapshot()
+}
+
+// Sessions returns the active sessions, oldest first.
+func (m *Manager) Sessions(now time.Time) []Session {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.prune(now)
+
+	ret := make([]Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		ret = append(ret, s.snapshot())
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+
+	return ret
+}
+
+// Get returns the session with the id.
+func (m *Manager) Get(id string) (Session, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return Session{}, ErrSessionNotFound
+	}
+
+	return s.snapshot(), nil
+}
+
+// Join adds a member to the session, returning the channel of the events of
+// the session. The member leaves the session when ctx is done, and the
+// channel is closed when the member leaves, is removed by the host, or the
+// session ends.
+func (m *Manager) Join(ctx context.Context, id string, name string, user string, now time.Time) (<-chan Event, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	member := Member{
+		ID:       newID(),
+		Name:     name,
+		User:     user,
+		JoinedAt: now,
+	}
+
+	s.Members = append(s.Members, member)
+	if s.HostID == "" {
+		s.HostID = member.ID
+	}
+
+	ch := make(chan Event, eventBufferSize)
+	s.events[member.ID] = ch
+	s.broadcast(EventTypeJoin, member.ID, now)
+
+	go func() {
+		<-ctx.Done()
+		m.leave(id, member.ID, time.Now())
+	}()
+
+	return ch, nil
+}
+
+func (m *Manager) leave(id string, memberID string, now time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return
+	}
+
+	m.removeMember(s, memberID, now)
+}
+
+// removeMember removes the member from the session, passing the host to the
+// longest joined member if the host leaves. The session ends when the last
+// member leaves. Must be called with the mutex held.
+func (m *Manager) removeMember(s *session, memberID string, now time.Time) {
+	i, ok := s.member(memberID)
+	if !ok {
+		return
+	}
+
+	s.Members = append(s.Members[:i], s.Members[i+1:]...)
+	close(s.events[memberID])
+	delete(s.events, memberID)
+
+	if len(s.Members) == 0 {
+		delete(m.sessions, s.ID)
+		return
+	}
+
+	if s.HostID == memberID {
+		s.HostID = s.Members[0].ID
+	}
+
+	s.broadcast(EventTypeLeave, memberID, now)
+}
+
+// find returns the session and checks that the member is in it. If host is
+// true, the member must be the host. Must be called with the mutex held.
+func (m *Manager) find(id string, memberID string, host bool) (*session, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	if _, ok := s.member(memberID); !ok {
+		return nil, ErrNotMember
+	}
+
+	if host && s.HostID != memberID {
+		return nil, ErrNotHost
+	}
+
+	return s, nil
+}
+
+// Command applies a playback command of the member to the session and
+// broadcasts it. position is the playback position in seconds of the member
+// when it sent the command, and latency the time the command took to reach
+// the server. The position of play and seek commands is advanced by the
+// latency, so that members start from where the sender is when they receive
+// the event.
+func (m *Manager) Command(id string, memberID string, action Action, position float64, latency time.Duration, now time.Time) (Session, error) {
+	if !action.IsValid() {
+		return Session{}, ErrInvalidAction
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, err := m.find(id, memberID, false)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if s.HostOnly && s.HostID != memberID {
+		return Session{}, ErrNotHost
+	}
+
+	if latency < 0 {
+		latency = 0
+	} else if latency > maxLatency {
+		latency = maxLatency
+	}
+
+	if position < 0 {
+		position = 0
+	}
+
+	var t EventType
+	switch action {
+	case ActionPlay:
+		s.Playing = true
+		s.Position = position + latency.Seconds()
+		t = EventTypePlay
+	case ActionPause:
+		s.Playing = false
+		s.Position = position
+		t = EventTypePause
+	case ActionSeek:
+		s.Position = position
+		if s.Playing {
+			s.Position += latency.Seconds()
+		}
+		t = EventTypeSeek
+	}
+	s.UpdatedAt = now
+
+	s.broadcast(t, memberID, now)
+	return s.snapshot(), nil
+}
+
+// Update sets the host and the host only setting of the session, if not
+// nil. Only the host can update the session.
+func (m *Manager) Update(id string, memberID string, hostID *string, hostOnly *bool, now time.Time) (Session, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, err := m.find(id, memberID, true)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if hostID != nil {
+		if _, ok := s.member(*hostID); !ok {
+			return Session{}, fmt.Errorf("new host: %w", ErrNotMember)
+		}
+		s.HostID = *hostID
+	}
+
+	if hostOnly != nil {
+		s.HostOnly = *hostOnly
+	}
+
+	s.broadcast(EventTypeUpdate, memberID, now)
+	return s.snapshot(), nil
+}
+
+// Remove removes another member from the session. Only the host can remove
+// members.
+func (m *Manager) Remove(id string, memberID string, targetID string, now time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, err := m.find(id, memberID, true)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.member(targetID); !ok {
+		return ErrNotMember
+	}
+
+	m.removeMember(s, targetID, now)
+	return nil
+}
+
+// End ends the session, closing the event channels of its members. Only the
+// host can end the session.
+func (m *Manager) End(id string, memberID string, now time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, err := m.find(id, memberID, true)
+	if err != nil {
+		return err
+	}
+
+	s.broadcast(EventTypeEnd, memberID, now)
+	for _, ch := range s.events {
+		close(ch)
+	}
+	delete(m.sessions, id)
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package syncplay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func join(t *testing.T, m *Manager, ctx context.Context, id string, name string, now time.Time) (string, <-chan Event) {
+	t.Helper()
+
+	ch, err := m.Join(ctx, id, name, "", now)
+	require.NoError(t, err)
+
+	e := <-ch
+	require.Equal(t, EventTypeJoin, e.Type)
+	return e.MemberID, ch
+}
+
+func TestSessionPositionAt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := Session{Position: 10, UpdatedAt: now}
+
+	assert.Equal(t, 10.0, s.PositionAt(now.Add(5*time.Second)))
+
+	s.Playing = true
+	assert.Equal(t, 15.0, s.PositionAt(now.Add(5*time.Second)))
+	assert.Equal(t, 10.0, s.PositionAt(now.Add(-time.Second)))
+}
+
+func TestManagerCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewManager()
+
+	s := m.Create(1, false, now)
+	host, hostEvents := join(t, m, ctx, s.ID, "host", now)
+	guest, guestEvents := join(t, m, ctx, s.ID, "guest", now)
+
+	// host receives the guest joining
+	e := <-hostEvents
+	assert.Equal(t, EventTypeJoin, e.Type)
+	assert.Equal(t, guest, e.MemberID)
+	assert.Equal(t, host, e.Session.HostID)
+	assert.Len(t, e.Session.Members, 2)
+
+	// play is advanced by the latency
+	got, err := m.Command(s.ID, guest, ActionPlay, 30, 500*time.Millisecond, now)
+	require.NoError(t, err)
+	assert.True(t, got.Playing)
+	assert.Equal(t, 30.5, got.Position)
+
+	for _, ch := range []<-chan Event{hostEvents, guestEvents} {
+		e := <-ch
+		assert.Equal(t, EventTypePlay, e.Type)
+		assert.Equal(t, guest, e.MemberID)
+		assert.Equal(t, 30.5, e.Session.Position)
+	}
+
+	// pause is not
+	got, err = m.Command(s.ID, host, ActionPause, 40, time.Second, now)
+	require.NoError(t, err)
+	assert.False(t, got.Playing)
+	assert.Equal(t, 40.0, got.Position)
+
+	// latency is limited
+	got, err = m.Command(s.ID, host, ActionPlay, 40, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, 45.0, got.Position)
+
+	_, err = m.Command(s.ID, "missing", ActionSeek, 0, 0, now)
+	assert.ErrorIs(t, err, ErrNotMember)
+	_, err = m.Command("missing", host, ActionSeek, 0, 0, now)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestManagerHostControls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewManager()
+
+	s := m.Create(1, true, now)
+	host, hostEvents := join(t, m, ctx, s.ID, "host", now)
+
+	guestCtx, guestCancel := context.WithCancel(ctx)
+	guest, guestEvents := join(t, m, guestCtx, s.ID, "guest", now)
+	<-hostEvents
+
+	_, err := m.Command(s.ID, guest, ActionPlay, 0, 0, now)
+	assert.ErrorIs(t, err, ErrNotHost)
+
+	_, err = m.Update(s.ID, guest, nil, nil, now)
+	assert.ErrorIs(t, err, ErrNotHost)
+
+	// pass the host to the guest
+	got, err := m.Update(s.ID, host, &guest, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, guest, got.HostID)
+	<-hostEvents
+	<-guestEvents
+
+	_, err = m.Command(s.ID, guest, ActionPlay, 0, 0, now)
+	assert.NoError(t, err)
+	<-hostEvents
+	<-guestEvents
+
+	// the host passes back when the guest leaves
+	guestCancel()
+	e := <-hostEvents
+	assert.Equal(t, EventTypeLeave, e.Type)
+	assert.Equal(t, host, e.Session.HostID)
+
+	_, ok := <-guestEvents
+	assert.False(t, ok)
+
+	require.NoError(t, m.End(s.ID, host, now))
+	e = <-hostEvents
+	assert.Equal(t, EventTypeEnd, e.Type)
+	_, ok = <-hostEvents
+	assert.False(t, ok)
+
+	_, err = m.Get(s.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestManagerSessions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewManager()
+
+	first := m.Create(1, false, now)
+	second := m.Create(2, false, now.Add(time.Minute))
+
+	sessions := m.Sessions(now.Add(time.Minute))
+	require.Len(t, sessions, 2)
+	assert.Equal(t, first.ID, sessions[0].ID)
+	assert.Equal(t, second.ID, sessions[1].ID)
+
+	// sessions nobody joined are removed
+	sessions = m.Sessions(now.Add(unjoinedSessionTimeout + 30*time.Second))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, second.ID, sessions[0].ID)
+}
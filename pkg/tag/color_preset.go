@@ -0,0 +1,150 @@
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ColorPresetRule selects the tags that the color of a preset is applied to.
+// A tag matches if its name matches any of the name patterns, and it is a
+// descendant of any of the parent tags. Criteria that are not set are
+// ignored, but a rule must have at least one criterion.
+type ColorPresetRule struct {
+	Preset *models.ColorPreset
+	// NamePatterns are matched against the tag name.
+	NamePatterns []*regexp.Regexp
+	// DescendantIDs are the IDs of the tags below the parent tags of the rule.
+	// Nil if the rule has no parent tags.
+	DescendantIDs map[int]bool
+}
+
+// CompileNamePatterns compiles case-insensitive regular expressions for
+// matching tag names.
+func CompileNamePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	ret := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name pattern %q: %w", p, err)
+		}
+		ret[i] = re
+	}
+
+	return ret, nil
+}
+
+func (r ColorPresetRule) matches(t *models.Tag) bool {
+	if len(r.NamePatterns) == 0 && r.DescendantIDs == nil {
+		return false
+	}
+
+	if r.DescendantIDs != nil && !r.DescendantIDs[t.ID] {
+		return false
+	}
+
+	if len(r.NamePatterns) == 0 {
+		return true
+	}
+
+	for _, re := range r.NamePatterns {
+		if re.MatchString(t.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func presetLess(a, b *models.ColorPreset) bool {
+	if a.Sort != b.Sort {
+		return a.Sort < b.Sort
+	}
+	return a.Name < b.Name
+}
+
+// SortColorPresets sorts presets by their sort order, then by name.
+func SortColorPresets(presets []*models.ColorPreset) {
+	sort.SliceStable(presets, func(i, j int) bool {
+		return presetLess(presets[i], presets[j])
+	})
+}
+
+// ColorPresetChanges returns the new color of each tag that matches a rule.
+// Rules are applied in the sort order of their presets, so that a tag
+// matching more than one rule is given the color of the first preset. Tags
+// that already have a color are only changed if overwrite is true, and tags
+// that already have the color of their preset are not included.
+func ColorPresetChanges(tags []*models.Tag, rules []ColorPresetRule, overwrite bool) map[int]string {
+	sorted := make([]ColorPresetRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return presetLess(sorted[i].Preset, sorted[j].Preset)
+	})
+
+	ret := make(map[int]string)
+	for _, t := range tags {
+		if t.Color != "" && !overwrite {
+			continue
+		}
+
+		for _, r := range sorted {
+			if r.matches(t) {
+				if t.Color != r.Preset.Color {
+					ret[t.ID] = r.Preset.Color
+				}
+				break
+			}
+		}
+	}
+
+	return ret
+}
+
+// ColorPresetGroup is a color preset and the tags with its color.
+type ColorPresetGroup struct {
+	// Preset is nil for the group of tags with colors that are not presets.
+	Preset *models.ColorPreset
+	Tags   []*models.Tag
+}
+
+// GroupByColorPreset groups the tags that have a color by the preset with
+// that color, in preset sort order. Every preset has a group, even if it has
+// no tags. Tags with colors that are not presets are in a final group with a
+// nil preset, which is omitted if empty.
+func GroupByColorPreset(tags []*models.Tag, presets []*models.ColorPreset) []ColorPresetGroup {
+	sorted := make([]*models.ColorPreset, len(presets))
+	copy(sorted, presets)
+	SortColorPresets(sorted)
+
+	ret := make([]ColorPresetGroup, len(sorted))
+	byColor := make(map[string]int)
+	for i, p := range sorted {
+		ret[i].Preset = p
+		// presets sharing a color are grouped under the first
+		if _, found := byColor[p.Color]; !found {
+			byColor[p.Color] = i
+		}
+	}
+
+	var other []*models.Tag
+	for _, t := range tags {
+		if t.Color == "" {
+			continue
+		}
+
+		if i, found := byColor[t.Color]; found {
+			ret[i].Tags = append(ret[i].Tags, t)
+		} else {
+			other = append(other, t)
+		}
+	}
+
+	if len(other) > 0 {
+		ret = append(ret, ColorPresetGroup{Tags: other})
+	}
+
+	return ret
+}
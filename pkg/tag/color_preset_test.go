@@ -0,0 +1,106 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPresetRed = &models.ColorPreset{
+		ID:    1,
+		Name:  "red",
+		Color: "#ff0000",
+		Sort:  2,
+	}
+	testPresetBlue = &models.ColorPreset{
+		ID:    2,
+		Name:  "blue",
+		Color: "#0000ff",
+		Sort:  1,
+	}
+)
+
+func TestColorPresetChanges(t *testing.T) {
+	tags := []*models.Tag{
+		{ID: 1, Name: "Outdoor"},
+		{ID: 2, Name: "Outdoor Pool", Color: "#00ff00"},
+		{ID: 3, Name: "Indoor"},
+		{ID: 4, Name: "Beach"},
+		{ID: 5, Name: "outdoor bar", Color: "#0000ff"},
+	}
+
+	outdoor, err := CompileNamePatterns([]string{"^outdoor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []ColorPresetRule{
+		{
+			Preset:       testPresetRed,
+			NamePatterns: outdoor,
+		},
+		{
+			// blue is applied first since it sorts first
+			Preset:        testPresetBlue,
+			DescendantIDs: map[int]bool{1: true, 4: true},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overwrite bool
+		want      map[int]string
+	}{
+		{
+			"keep existing colors",
+			false,
+			map[int]string{
+				1: "#0000ff",
+				4: "#0000ff",
+			},
+		},
+		{
+			"overwrite",
+			true,
+			map[int]string{
+				1: "#0000ff",
+				2: "#ff0000",
+				4: "#0000ff",
+				5: "#ff0000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ColorPresetChanges(tags, rules, tt.overwrite)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestColorPresetChanges_NoCriteria(t *testing.T) {
+	tags := []*models.Tag{{ID: 1, Name: "one"}}
+	rules := []ColorPresetRule{{Preset: testPresetRed}}
+
+	assert.Empty(t, ColorPresetChanges(tags, rules, true))
+}
+
+func TestGroupByColorPreset(t *testing.T) {
+	tags := []*models.Tag{
+		{ID: 1, Name: "one", Color: "#ff0000"},
+		{ID: 2, Name: "two"},
+		{ID: 3, Name: "three", Color: "#123456"},
+		{ID: 4, Name: "four", Color: "#ff0000"},
+	}
+
+	got := GroupByColorPreset(tags, []*models.ColorPreset{testPresetRed, testPresetBlue})
+
+	assert.Equal(t, []ColorPresetGroup{
+		{Preset: testPresetBlue},
+		{Preset: testPresetRed, Tags: []*models.Tag{tags[0], tags[3]}},
+		{Tags: []*models.Tag{tags[2]}},
+	}, got)
+}
@@ -0,0 +1,80 @@
+package threatscan
+
+import (
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// severityKeywords are the parts of the messages of the threats of each
+// severity, from the highest. Keywords are matched ignoring case, so that
+// stored threats can be matched with LIKE in the database.
+var severityKeywords = []struct {
+	severity models.ThreatSeverity
+	keywords []string
+}{
+	{models.ThreatSeverityCritical, []string{
+		"executable",
+		"RCE",
+		"web shell",
+		"crypto miner",
+		"code execution",
+		"command execution",
+		"environment hijacking",
+	}},
+	{models.ThreatSeverityHigh, []string{
+		"overflow",
+		"exploit vector",
+		"injection",
+		"XXE",
+		"XSS",
+		"SSRF",
+		"path traversal",
+		"sensitive file path",
+	}},
+	{models.ThreatSeverityMedium, []string{
+		"suspicious URL",
+		"polyglot",
+		"steganography",
+		"base64",
+	}},
+}
+
+// Severity returns the severity of the threat, which is the highest
+// severity of the keywords in its message. Threats without a keyword are
+// of low severity.
+func (r Result) Severity() models.ThreatSeverity {
+	return MessageSeverity(r.Message)
+}
+
+// MessageSeverity returns the severity of a threat message, or of a stored
+// threat line.
+func MessageSeverity(message string) models.ThreatSeverity {
+	lower := strings.ToLower(message)
+	for _, s := range severityKeywords {
+		for _, k := range s.keywords {
+			if strings.Contains(lower, strings.ToLower(k)) {
+				return s.severity
+			}
+		}
+	}
+
+	return models.ThreatSeverityLow
+}
+
+// SeverityKeywords returns the keywords of the threats of at least the
+// severity. Returns nil for low severity, since every threat is at least of
+// low severity.
+func SeverityKeywords(severity models.ThreatSeverity) []string {
+	var ret []string
+	for _, s := range severityKeywords {
+		if s.severity.AtLeast(severity) {
+			ret = append(ret, s.keywords...)
+		}
+	}
+
+	if severity == models.ThreatSeverityLow {
+		return nil
+	}
+	return ret
+}
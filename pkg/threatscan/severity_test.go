@@ -0,0 +1,44 @@
+package threatscan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageSeverity(t *testing.T) {
+	tests := []struct {
+		message string
+		want    models.ThreatSeverity
+	}{
+		{"Embedded Windows executable (PE) detected", models.ThreatSeverityCritical},
+		{"Appended Python pickle at end of file (deserialization RCE vector)", models.ThreatSeverityCritical},
+		{"PHP/web shell pattern (eval, base64_decode, shell_exec, etc.)", models.ThreatSeverityCritical},
+		{"[metadata] Shell/command execution pattern", models.ThreatSeverityCritical},
+		{"MP4 container: suspicious atom size (potential integer overflow in ctts/stts/stsc/co64/stco)", models.ThreatSeverityHigh},
+		{"SRT subtitle: embedded HTML/script (XSS vector)", models.ThreatSeverityHigh},
+		{"Script or injection pattern", models.ThreatSeverityHigh},
+		{"Suspicious URL scheme", models.ThreatSeverityMedium},
+		{"Possible LSB steganography: unusually uniform LSB distribution in video frames", models.ThreatSeverityMedium},
+		{"Something else", models.ThreatSeverityLow},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MessageSeverity(tt.message), tt.message)
+	}
+}
+
+func TestSeverityKeywords(t *testing.T) {
+	assert.Nil(t, SeverityKeywords(models.ThreatSeverityLow))
+
+	// every message matching a keyword is of at least the severity
+	for _, severity := range models.AllThreatSeverity[1:] {
+		for _, k := range SeverityKeywords(severity) {
+			assert.True(t, MessageSeverity("x "+strings.ToUpper(k)+" x").AtLeast(severity), k)
+		}
+	}
+
+	assert.Less(t, len(SeverityKeywords(models.ThreatSeverityCritical)), len(SeverityKeywords(models.ThreatSeverityHigh)))
+}
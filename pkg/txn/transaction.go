@@ -4,6 +4,9 @@ package txn
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
 )
 
 type Manager interface {
@@ -135,13 +138,30 @@ func WithDatabase(ctx context.Context, p DatabaseProvider, fn TxnFunc) error {
 	return fn(ctx)
 }
 
+// lockedCount is the number of transactions that failed with a locked
+// database error.
+var lockedCount atomic.Int64
+
+// LockedCount returns the number of transactions run by a Retryer that failed
+// because the database was locked, since startup. Each failed attempt is
+// counted.
+func LockedCount() int64 {
+	return lockedCount.Load()
+}
+
 // Retryer is a provides WithTxn function that retries the transaction
 // if it fails with a locked database error.
 // Transactions are run in exclusive mode.
+// As the whole transaction is retried, fn may be called more than once.
 type Retryer struct {
 	Manager Manager
 	// use value < 0 to retry forever
 	Retries int
+	// Backoff is the maximum time waited before the first retry. The
+	// maximum doubles with each attempt, and a random time up to it is
+	// waited so that competing transactions do not retry in lockstep.
+	// No time is waited if Backoff is 0.
+	Backoff time.Duration
 	OnFail  func(ctx context.Context, err error, attempt int) error
 }
 
@@ -163,12 +183,42 @@ func (r Retryer) WithTxn(ctx context.Context, fn TxnFunc) error {
 			return err
 		}
 
+		lockedCount.Add(1)
+
 		if r.OnFail != nil {
 			if err := r.OnFail(ctx, err, attempt); err != nil {
 				return err
 			}
 		}
+
+		// no time is waited after the last attempt
+		if attempt == r.Retries {
+			break
+		}
+
+		if err := r.wait(ctx, attempt); err != nil {
+			return err
+		}
 	}
 
-	return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+	return fmt.Errorf("failed after %d attempts: %w", r.Retries, err)
+}
+
+// wait waits a random time before the retry following attempt.
+func (r Retryer) wait(ctx context.Context, attempt int) error {
+	if r.Backoff <= 0 {
+		return nil
+	}
+
+	// limit the shift so the maximum does not overflow
+	maxWait := r.Backoff << min(attempt-1, 10)
+	t := time.NewTimer(rand.N(maxWait) + 1)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
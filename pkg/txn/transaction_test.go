@@ -0,0 +1,111 @@
+package txn
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errLocked = errors.New("database is locked")
+
+type mockManager struct{}
+
+func (mockManager) Begin(ctx context.Context, writable bool) (context.Context, error) {
+	return ctx, nil
+}
+
+func (mockManager) Commit(ctx context.Context) error   { return nil }
+func (mockManager) Rollback(ctx context.Context) error { return nil }
+
+func (mockManager) IsLocked(err error) bool {
+	return errors.Is(err, errLocked)
+}
+
+func TestRetryer(t *testing.T) {
+	r := Retryer{
+		Manager: mockManager{},
+		Retries: 3,
+		Backoff: time.Millisecond,
+	}
+
+	before := LockedCount()
+
+	calls := 0
+	err := r.WithTxn(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errLocked
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("WithTxn() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+	if got := LockedCount() - before; got != 2 {
+		t.Errorf("LockedCount() increased by %d, want 2", got)
+	}
+
+	calls = 0
+	err = r.WithTxn(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errLocked
+	})
+	if !errors.Is(err, errLocked) {
+		t.Errorf("WithTxn() error = %v, want %v", err, errLocked)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+
+	// other errors are not retried
+	otherErr := errors.New("other")
+	calls = 0
+	err = r.WithTxn(context.Background(), func(ctx context.Context) error {
+		calls++
+		return otherErr
+	})
+	if !errors.Is(err, otherErr) || calls != 1 {
+		t.Errorf("WithTxn() error = %v after %d calls, want %v after 1", err, calls, otherErr)
+	}
+}
+
+func TestRetryerCancelled(t *testing.T) {
+	r := Retryer{
+		Manager: mockManager{},
+		Retries: -1,
+		Backoff: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return errLocked
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WithTxn() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryerLastAttempt(t *testing.T) {
+	r := Retryer{
+		Manager: mockManager{},
+		Retries: 1,
+		Backoff: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the error of the last attempt is returned without waiting
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return errLocked
+	})
+	if !errors.Is(err, errLocked) {
+		t.Errorf("WithTxn() error = %v, want %v", err, errLocked)
+	}
+}
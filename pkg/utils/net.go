@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// ResolveBindAddresses returns the listen addresses for the given bind
+// addresses and port. Each bind address is either an IPv4 or IPv6 address,
+// or the name of a network interface, in which case all addresses of the
+// interface are used. IPv6 link-local addresses of interfaces are skipped.
+// Returns a single address listening on all interfaces if bindAddresses is
+// empty.
+func ResolveBindAddresses(bindAddresses []string, port int) ([]string, error) {
+	p := strconv.Itoa(port)
+
+	if len(bindAddresses) == 0 {
+		return []string{net.JoinHostPort("", p)}, nil
+	}
+
+	var ret []string
+	seen := make(map[string]bool)
+	add := func(host string) {
+		a := net.JoinHostPort(host, p)
+		if !seen[a] {
+			seen[a] = true
+			ret = append(ret, a)
+		}
+	}
+
+	for _, b := range bindAddresses {
+		if ip := net.ParseIP(b); ip != nil {
+			add(ip.String())
+			continue
+		}
+
+		iface, err := net.InterfaceByName(b)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or network interface: %w", b, err)
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("getting addresses of interface %s: %w", b, err)
+		}
+
+		found := false
+		for _, a := range addrs {
+			ipNet, ok := a.(*net.IPNet)
+			if !ok || (ipNet.IP.To4() == nil && ipNet.IP.IsLinkLocalUnicast()) {
+				continue
+			}
+
+			add(ipNet.IP.String())
+			found = true
+		}
+
+		if !found {
+			return nil, fmt.Errorf("interface %s has no usable addresses", b)
+		}
+	}
+
+	return ret, nil
+}
+
+// ListenAll listens on all of the given addresses, returning a single
+// listener that accepts connections from all of them. The address of the
+// returned listener is the address of the first listener.
+func ListenAll(network string, addresses []string) (net.Listener, error) {
+	if len(addresses) == 1 {
+		return net.Listen(network, addresses[0])
+	}
+
+	ret := &multiListener{
+		conns:  make(chan net.Conn),
+		errs:   make(chan error),
+		closed: make(chan struct{}),
+	}
+
+	for _, a := range addresses {
+		l, err := net.Listen(network, a)
+		if err != nil {
+			_ = ret.Close()
+			return nil, err
+		}
+		ret.listeners = append(ret.listeners, l)
+	}
+
+	for _, l := range ret.listeners {
+		go ret.accept(l)
+	}
+
+	return ret, nil
+}
+
+type multiListener struct {
+	listeners []net.Listener
+	conns     chan net.Conn
+	errs      chan error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *multiListener) accept(listener net.Listener) {
+	for {
+		c, err := listener.Accept()
+		if err != nil {
+			select {
+			case l.errs <- err:
+			case <-l.closed:
+				return
+			}
+
+			// stop accepting on permanently failed listeners
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				return
+			}
+			continue
+		}
+
+		select {
+		case l.conns <- c:
+		case <-l.closed:
+			c.Close()
+			return
+		}
+	}
+}
+
+func (l *multiListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case err := <-l.errs:
+		return nil, err
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *multiListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		for _, listener := range l.listeners {
+			if e := listener.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	})
+	return err
+}
+
+func (l *multiListener) Addr() net.Addr {
+	return l.listeners[0].Addr()
+}
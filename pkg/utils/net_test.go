@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestResolveBindAddresses(t *testing.T) {
+	tests := []struct {
+		name          string
+		bindAddresses []string
+		want          []string
+		wantErr       bool
+	}{
+		{"empty", nil, []string{":9999"}, false},
+		{"ipv4", []string{"192.168.1.2"}, []string{"192.168.1.2:9999"}, false},
+		{"ipv6", []string{"::1"}, []string{"[::1]:9999"}, false},
+		{"ipv6 any", []string{"::"}, []string{"[::]:9999"}, false},
+		{"multiple", []string{"127.0.0.1", "::1"}, []string{"127.0.0.1:9999", "[::1]:9999"}, false},
+		{"duplicate", []string{"127.0.0.1", "127.0.0.1"}, []string{"127.0.0.1:9999"}, false},
+		{"invalid", []string{"not-an-interface-name"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveBindAddresses(tt.bindAddresses, 9999)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveBindAddresses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveBindAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListenAll(t *testing.T) {
+	// reserve two free ports
+	var addresses []string
+	for i := 0; i < 2; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses = append(addresses, l.Addr().String())
+		l.Close()
+	}
+
+	l, err := ListenAll("tcp", addresses)
+	if err != nil {
+		t.Fatalf("ListenAll() error = %v", err)
+	}
+	defer l.Close()
+
+	if got := l.Addr().String(); got != addresses[0] {
+		t.Errorf("Addr() = %v, want %v", got, addresses[0])
+	}
+
+	for _, a := range addresses {
+		c, err := net.Dial("tcp", a)
+		if err != nil {
+			t.Fatalf("dialing %s: %v", a, err)
+		}
+
+		accepted, err := l.Accept()
+		if err != nil {
+			t.Fatalf("Accept() error = %v", err)
+		}
+
+		if got := accepted.LocalAddr().String(); got != a {
+			t.Errorf("accepted connection on %v, want %v", got, a)
+		}
+
+		accepted.Close()
+		c.Close()
+	}
+
+	if err := l.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if _, err := l.Accept(); err == nil {
+		t.Error("Accept() after Close() returned no error")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 type Phash struct {
 	SceneID   int     `db:"id"`
+	FileID    int     `db:"file_id"`
 	Hash      int64   `db:"phash"`
 	Duration  float64 `db:"duration"`
 	Neighbors []int
@@ -0,0 +1,333 @@
+package utils
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PhashEntry is the phash of a scene file in a PhashIndex.
+type PhashEntry struct {
+	FileID   int
+	SceneID  int
+	Hash     int64
+	Duration float64
+}
+
+// PhashIndexStats describes the contents of a PhashIndex.
+type PhashIndexStats struct {
+	// Entries is the number of indexed files.
+	Entries int `json:"entries"`
+	// Nodes is the number of distinct hashes in the tree, including those
+	// whose files have been removed.
+	Nodes int `json:"nodes"`
+	// EmptyNodes is the number of nodes whose files have all been removed.
+	// They are dropped when the index is rebuilt.
+	EmptyNodes int `json:"emptyNodes"`
+	// Depth is the depth of the tree.
+	Depth int `json:"depth"`
+	// Ready is false until the index has been loaded.
+	Ready bool `json:"ready"`
+	// BuiltAt is the time the index was last loaded from the database.
+	BuiltAt *time.Time `json:"builtAt"`
+}
+
+type phashNode struct {
+	hash     uint64
+	entries  []PhashEntry
+	children map[int]*phashNode
+}
+
+// PhashIndex is a BK-tree of scene file phashes, so that files within a
+// hamming distance of a hash can be found without comparing every pair of
+// files. It is safe for concurrent use.
+type PhashIndex struct {
+	mutex   sync.RWMutex
+	root    *phashNode
+	files   map[int]*phashNode
+	nodes   int
+	ready   bool
+	builtAt *time.Time
+}
+
+func NewPhashIndex() *PhashIndex {
+	return &PhashIndex{
+		files: make(map[int]*phashNode),
+	}
+}
+
+func phashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Load replaces the contents of the index and marks it as ready.
+func (idx *PhashIndex) Load(entries []PhashEntry) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.root = nil
+	idx.files = make(map[int]*phashNode, len(entries))
+	idx.nodes = 0
+	for _, e := range entries {
+		idx.set(e)
+	}
+
+	now := time.Now()
+	idx.ready = true
+	idx.builtAt = &now
+}
+
+// Ready returns true if the index has been loaded.
+func (idx *PhashIndex) Ready() bool {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	return idx.ready
+}
+
+// Entries returns all indexed entries.
+func (idx *PhashIndex) Entries() []PhashEntry {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	ret := make([]PhashEntry, 0, len(idx.files))
+	idx.walk(func(n *phashNode) {
+		ret = append(ret, n.entries...)
+	})
+
+	return ret
+}
+
+// Set adds or replaces the entry for a file.
+func (idx *PhashIndex) Set(e PhashEntry) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.set(e)
+}
+
+func (idx *PhashIndex) set(e PhashEntry) {
+	idx.remove(e.FileID)
+
+	hash := uint64(e.Hash)
+	if idx.root == nil {
+		idx.root = &phashNode{hash: hash}
+		idx.nodes++
+	}
+
+	n := idx.root
+	for {
+		d := phashDistance(n.hash, hash)
+		if d == 0 {
+			break
+		}
+
+		child := n.children[d]
+		if child == nil {
+			child = &phashNode{hash: hash}
+			if n.children == nil {
+				n.children = make(map[int]*phashNode)
+			}
+			n.children[d] = child
+			idx.nodes++
+		}
+		n = child
+	}
+
+	n.entries = append(n.entries, e)
+	idx.files[e.FileID] = n
+}
+
+// Remove removes the entry for a file. The node of its hash is kept in the
+// tree, since its children are positioned relative to it.
+func (idx *PhashIndex) Remove(fileID int) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.remove(fileID)
+}
+
+func (idx *PhashIndex) remove(fileID int) {
+	n := idx.files[fileID]
+	if n == nil {
+		return
+	}
+
+	for i, e := range n.entries {
+		if e.FileID == fileID {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			break
+		}
+	}
+	delete(idx.files, fileID)
+}
+
+// Search returns the entries within distance of the hash.
+func (idx *PhashIndex) Search(hash int64, distance int) []PhashEntry {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	var ret []PhashEntry
+	idx.search(uint64(hash), distance, func(n *phashNode) {
+		ret = append(ret, n.entries...)
+	})
+
+	return ret
+}
+
+func (idx *PhashIndex) search(hash uint64, distance int, fn func(n *phashNode)) {
+	if idx.root == nil {
+		return
+	}
+
+	stack := []*phashNode{idx.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := phashDistance(n.hash, hash)
+		if d <= distance && len(n.entries) > 0 {
+			fn(n)
+		}
+
+		// by the triangle inequality, matches can only be below children
+		// whose distance from this node is within distance of d
+		for cd, child := range n.children {
+			if cd >= d-distance && cd <= d+distance {
+				stack = append(stack, child)
+			}
+		}
+	}
+}
+
+// Duplicates returns groups of scene IDs whose files are within distance of
+// each other, and whose durations differ by no more than durationDiff. A
+// negative durationDiff ignores durations. Groups are connected, so that two
+// scenes can be in the same group through a third scene that is similar to
+// both. The results are the same as those of FindDuplicates.
+func (idx *PhashIndex) Duplicates(distance int, durationDiff float64) [][]int {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	// union-find of scene IDs
+	parent := make(map[int]int)
+	var find func(id int) int
+	find = func(id int) int {
+		p, found := parent[id]
+		if !found || p == id {
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[ra] = rb
+		}
+	}
+
+	idx.walk(func(n *phashNode) {
+		for _, e := range n.entries {
+			idx.search(n.hash, distance, func(m *phashNode) {
+				for _, other := range m.entries {
+					if e.SceneID == other.SceneID || !durationsMatch(e.Duration, other.Duration, durationDiff) {
+						continue
+					}
+
+					if _, found := parent[e.SceneID]; !found {
+						parent[e.SceneID] = e.SceneID
+					}
+					if _, found := parent[other.SceneID]; !found {
+						parent[other.SceneID] = other.SceneID
+					}
+					union(e.SceneID, other.SceneID)
+				}
+			})
+		}
+	})
+
+	groups := make(map[int][]int)
+	for id := range parent {
+		root := find(id)
+		groups[root] = append(groups[root], id)
+	}
+
+	var ret [][]int
+	for _, g := range groups {
+		if len(g) > 1 {
+			sort.Ints(g)
+			ret = append(ret, g)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i][0] < ret[j][0]
+	})
+
+	return ret
+}
+
+func durationsMatch(a, b float64, durationDiff float64) bool {
+	if durationDiff < 0 || a <= 0 || b <= 0 {
+		return true
+	}
+
+	return math.Abs(a-b) <= durationDiff
+}
+
+// Stats returns statistics about the index.
+func (idx *PhashIndex) Stats() PhashIndexStats {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	ret := PhashIndexStats{
+		Entries: len(idx.files),
+		Nodes:   idx.nodes,
+		Ready:   idx.ready,
+		BuiltAt: idx.builtAt,
+	}
+
+	idx.walk(func(n *phashNode) {
+		if len(n.entries) == 0 {
+			ret.EmptyNodes++
+		}
+	})
+	ret.Depth = depth(idx.root)
+
+	return ret
+}
+
+func depth(n *phashNode) int {
+	if n == nil {
+		return 0
+	}
+
+	ret := 0
+	for _, child := range n.children {
+		ret = max(ret, depth(child))
+	}
+
+	return ret + 1
+}
+
+// walk calls fn for every node in the tree, including empty nodes.
+func (idx *PhashIndex) walk(fn func(n *phashNode)) {
+	if idx.root == nil {
+		return
+	}
+
+	stack := []*phashNode{idx.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		fn(n)
+		for _, child := range n.children {
+			stack = append(stack, child)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// randomPhashes returns hashes in clusters of similar hashes, so that there
+// are duplicates at small distances.
+func randomPhashes(r *rand.Rand, n int) []PhashEntry {
+	var ret []PhashEntry
+	for len(ret) < n {
+		base := r.Uint64()
+		for i := 0; i < 1+r.Intn(4) && len(ret) < n; i++ {
+			h := base
+			for j := 0; j < r.Intn(8); j++ {
+				h ^= 1 << r.Intn(64)
+			}
+
+			id := len(ret) + 1
+			ret = append(ret, PhashEntry{
+				FileID:   id,
+				SceneID:  id,
+				Hash:     int64(h),
+				Duration: float64(60 + r.Intn(5)),
+			})
+		}
+	}
+
+	return ret
+}
+
+func sortGroups(groups [][]int) [][]int {
+	for _, g := range groups {
+		sort.Ints(g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+	return groups
+}
+
+func TestPhashIndex_Duplicates(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	entries := randomPhashes(r, 500)
+
+	idx := NewPhashIndex()
+	idx.Load(entries)
+
+	for _, distance := range []int{0, 4, 8, 12} {
+		for _, durationDiff := range []float64{-1, 0, 2} {
+			hashes := make([]*Phash, len(entries))
+			for i, e := range entries {
+				hashes[i] = &Phash{
+					SceneID:  e.SceneID,
+					Hash:     e.Hash,
+					Duration: e.Duration,
+					Bucket:   -1,
+				}
+			}
+
+			want := sortGroups(FindDuplicates(hashes, distance, durationDiff))
+			got := idx.Duplicates(distance, durationDiff)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Duplicates(%d, %v) = %v groups, want %v groups", distance, durationDiff, len(got), len(want))
+			}
+		}
+	}
+}
+
+func TestPhashIndex_SetRemove(t *testing.T) {
+	idx := NewPhashIndex()
+	idx.Load(nil)
+
+	idx.Set(PhashEntry{FileID: 1, SceneID: 1, Hash: 0b1111})
+	idx.Set(PhashEntry{FileID: 2, SceneID: 2, Hash: 0b1110})
+	idx.Set(PhashEntry{FileID: 3, SceneID: 3, Hash: 0b0000})
+
+	if got := len(idx.Search(0b1111, 1)); got != 2 {
+		t.Errorf("Search found %d entries, want 2", got)
+	}
+
+	// replacing the hash of a file moves it
+	idx.Set(PhashEntry{FileID: 2, SceneID: 2, Hash: 0b0001})
+	if got := len(idx.Search(0b1111, 1)); got != 1 {
+		t.Errorf("Search after Set found %d entries, want 1", got)
+	}
+
+	idx.Remove(3)
+	got := idx.Search(0, 1)
+	if len(got) != 1 || got[0].FileID != 2 {
+		t.Errorf("Search after Remove = %v, want file 2", got)
+	}
+
+	stats := idx.Stats()
+	if stats.Entries != 2 || stats.Nodes != 4 || stats.EmptyNodes != 2 {
+		t.Errorf("Stats = %+v, want 2 entries, 4 nodes and 2 empty nodes", stats)
+	}
+}
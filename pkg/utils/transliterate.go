@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	unicodenorm "golang.org/x/text/unicode/norm"
+)
+
+// latinLetters maps lower case letters that are not decomposed into a base
+// letter and diacritics, and Cyrillic letters, to Latin letters. Cyrillic
+// uses a single common romanization.
+var latinLetters = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d",
+	'ð': "d", 'þ': "th", 'ı': "i",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u", 'ђ': "dj",
+	'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz",
+}
+
+// Transliterate returns s with diacritics removed and Cyrillic letters
+// replaced with Latin letters. The case of letters is preserved, so that
+// "Жанна" becomes "Zhanna". Letters of other scripts are left as is.
+func Transliterate(s string) string {
+	// compose first, as paths on some filesystems are decomposed
+	var b strings.Builder
+	for _, r := range unicodenorm.NFC.String(s) {
+		lower := unicode.ToLower(r)
+		l, ok := latinLetters[lower]
+		switch {
+		case !ok:
+			b.WriteRune(r)
+		case lower != r && l != "":
+			first, size := utf8.DecodeRuneInString(l)
+			b.WriteRune(unicode.ToUpper(first))
+			b.WriteString(l[size:])
+		default:
+			b.WriteString(l)
+		}
+	}
+
+	// decompose letters into base letters and combining marks, and drop
+	// the marks
+	decomposed := unicodenorm.NFD.String(b.String())
+
+	b.Reset()
+	for _, r := range decomposed {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	// recompose characters such as Hangul syllables, which are decomposed into
+	// letters rather than marks
+	return unicodenorm.NFC.String(b.String())
+}
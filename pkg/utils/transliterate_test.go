@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Plain Name", "Plain Name"},
+		{"Zoë Çelik", "Zoe Celik"},
+		{"Łukasz Øster Straße", "Lukasz Oster Strasse"},
+		{"Жанна Щербакова", "Zhanna Shcherbakova"},
+		{"Подъезд", "Podezd"},
+		// decomposed input, as on macOS filesystems
+		{"Zoë", "Zoe"},
+		{"伏字", "伏字"},
+		{"한국어", "한국어"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := Transliterate(tt.s); got != tt.want {
+				t.Errorf("Transliterate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package utils
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"strconv"
+	"strings"
 )
 
 // from stdlib's time.go
@@ -35,3 +40,110 @@ func GetVTTTime(fracSeconds float64) string {
 	return fmt.Sprintf("%02d:%02d:%02d.%03d", hour, mnt, sec, msec)
 
 }
+
+// ParseVTTTime parses a VTT timestamp (hh:mm:ss.mmm or mm:ss.mmm) and returns
+// the number of seconds.
+func ParseVTTTime(s string) (float64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid VTT timestamp %q", s)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid VTT timestamp %q: %w", s, err)
+	}
+
+	multiplier := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		v, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid VTT timestamp %q: %w", s, err)
+		}
+
+		seconds += float64(v) * multiplier
+		multiplier *= 60
+	}
+
+	return seconds, nil
+}
+
+// VTTThumbnail is a cue of a thumbnail VTT file. It references a region of
+// an image to show between the start and end times.
+type VTTThumbnail struct {
+	Start  float64 `json:"start"`
+	End    float64 `json:"end"`
+	Image  string  `json:"image"`
+	X      int     `json:"x"`
+	Y      int     `json:"y"`
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+}
+
+const vttXYWHFragment = "#xywh="
+
+// ParseVTTThumbnails parses a thumbnail VTT file, such as a generated sprite
+// VTT file. The payload of each cue must be an image URL with an xywh
+// fragment.
+func ParseVTTThumbnails(r io.Reader) ([]VTTThumbnail, error) {
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() || !strings.HasPrefix(strings.TrimPrefix(scanner.Text(), "\ufeff"), "WEBVTT") {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("missing WEBVTT header")
+	}
+
+	var ret []VTTThumbnail
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		start, end, found := strings.Cut(line, "-->")
+		if !found {
+			// skip blank lines and cue identifiers
+			continue
+		}
+
+		var (
+			t   VTTThumbnail
+			err error
+		)
+		t.Start, err = ParseVTTTime(strings.TrimSpace(start))
+		if err != nil {
+			return nil, err
+		}
+
+		// cue settings may follow the end time
+		endFields := strings.Fields(end)
+		if len(endFields) == 0 {
+			return nil, fmt.Errorf("missing end time: %q", line)
+		}
+		t.End, err = ParseVTTTime(endFields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("missing payload for cue %q", line)
+		}
+
+		payload := strings.TrimSpace(scanner.Text())
+		image, xywh, found := strings.Cut(payload, vttXYWHFragment)
+		if !found {
+			return nil, fmt.Errorf("missing xywh fragment in %q", payload)
+		}
+
+		t.Image = image
+		if _, err := fmt.Sscanf(xywh, "%d,%d,%d,%d", &t.X, &t.Y, &t.Width, &t.Height); err != nil {
+			return nil, fmt.Errorf("invalid xywh fragment in %q: %w", payload, err)
+		}
+
+		ret = append(ret, t)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
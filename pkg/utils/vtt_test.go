@@ -2,6 +2,8 @@ package utils
 
 import (
 	"math"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -40,3 +42,77 @@ func TestInvalidTimestamp(t *testing.T) {
 		t.Errorf("TestInvalidTimestamp: GetVTTTime(-Inf) = %v; want %v", got, want)
 	}
 }
+
+func TestParseVTTTime(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    float64
+		wantErr bool
+	}{
+		{"00:00:00.000", 0, false},
+		{"00:01:02.500", 62.5, false},
+		{"25:01:01.100", ((25*60)+1)*60 + 1.1, false},
+		{"01:02.500", 62.5, false},
+		{"02.500", 0, true},
+		{"aa:00:00.000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseVTTTime(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseVTTTime(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+				return
+			}
+			if math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("ParseVTTTime(%q) = %v; want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVTTThumbnails(t *testing.T) {
+	const vtt = `WEBVTT
+
+00:00:00.000 --> 00:00:10.000
+abc_sprite.jpg#xywh=0,0,160,90
+
+1
+00:00:10.000 --> 00:00:20.000 align:start
+abc_sprite.jpg#xywh=160,0,160,90
+`
+
+	got, err := ParseVTTThumbnails(strings.NewReader(vtt))
+	if err != nil {
+		t.Fatalf("ParseVTTThumbnails() error = %v", err)
+	}
+
+	want := []VTTThumbnail{
+		{Start: 0, End: 10, Image: "abc_sprite.jpg", X: 0, Y: 0, Width: 160, Height: 90},
+		{Start: 10, End: 20, Image: "abc_sprite.jpg", X: 160, Y: 0, Width: 160, Height: 90},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseVTTThumbnails() = %v; want %v", got, want)
+	}
+}
+
+func TestParseVTTThumbnailsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		vtt  string
+	}{
+		{"missing header", "00:00:00.000 --> 00:00:10.000\nabc_sprite.jpg#xywh=0,0,160,90\n"},
+		{"missing fragment", "WEBVTT\n\n00:00:00.000 --> 00:00:10.000\nabc_sprite.jpg\n"},
+		{"missing payload", "WEBVTT\n\n00:00:00.000 --> 00:00:10.000"},
+		{"invalid time", "WEBVTT\n\n00:00:xx.000 --> 00:00:10.000\nabc_sprite.jpg#xywh=0,0,160,90\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseVTTThumbnails(strings.NewReader(tt.vtt)); err == nil {
+				t.Errorf("ParseVTTThumbnails() expected error")
+			}
+		})
+	}
+}
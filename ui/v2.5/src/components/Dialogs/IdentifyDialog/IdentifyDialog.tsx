@@ -1,7 +1,8 @@
 import React, { useState, useEffect, useMemo } from "react";
-import { Button, Form } from "react-bootstrap";
+import { Button, Form, Table } from "react-bootstrap";
 import {
   mutateMetadataIdentify,
+  queryIdentifyScenePreview,
   useConfiguration,
   useConfigureDefaults,
   useListSceneScrapers,
@@ -78,6 +79,7 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
     getDefaultOptions()
   );
   const [sources, setSources] = useState<IScraperSource[]>([]);
+  const [preview, setPreview] = useState<GQL.IdentifyPreview>();
   const [editingSource, setEditingSource] = useState<
     IScraperSource | undefined
   >();
@@ -207,7 +209,14 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
     const { identify: identifyDefaults } = configData.configuration.defaults;
 
     if (identifyDefaults) {
-      const mappedSources = identifyDefaults.sources
+      // sources without a priority are tried last, in list order
+      const prioritised = [...identifyDefaults.sources].sort(
+        (a, b) =>
+          (a.priority ?? Number.MAX_SAFE_INTEGER) -
+          (b.priority ?? Number.MAX_SAFE_INTEGER)
+      );
+
+      const mappedSources = prioritised
         .map((s) => {
           const found = allSources.find(
             (ss) =>
@@ -275,13 +284,14 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
 
   function makeIdentifyInput(): GQL.IdentifyMetadataInput {
     return {
-      sources: sources.map((s) => {
+      sources: sources.map((s, i) => {
         return {
           source: {
             scraper_id: s.scraper_id,
             stash_box_endpoint: s.stash_box_endpoint,
           },
           options: s.options,
+          priority: i,
         };
       }),
       options,
@@ -296,6 +306,73 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
     return withoutSpecifics;
   }
 
+  async function onPreview() {
+    if (selectedIds?.length !== 1) return;
+
+    try {
+      const result = await queryIdentifyScenePreview(
+        selectedIds[0],
+        makeIdentifyInput()
+      );
+      setPreview(result.data.identifyScenePreview);
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function renderPreview() {
+    if (!preview) return;
+
+    if (!preview.source) {
+      return (
+        <Form.Group className="identify-preview">
+          <FormattedMessage id="config.tasks.identify.preview_no_match" />
+        </Form.Group>
+      );
+    }
+
+    return (
+      <Form.Group className="identify-preview">
+        <h6>
+          <FormattedMessage
+            id="config.tasks.identify.preview_source"
+            values={{ source: preview.source }}
+          />
+        </h6>
+        {preview.changes.length === 0 ? (
+          <FormattedMessage id="config.tasks.identify.preview_no_changes" />
+        ) : (
+          <Table size="sm">
+            <thead>
+              <tr>
+                <th>
+                  <FormattedMessage id="config.tasks.identify.field" />
+                </th>
+                <th>
+                  <FormattedMessage id="config.tasks.identify.current_value" />
+                </th>
+                <th>
+                  <FormattedMessage
+                    id="config.tasks.identify.proposed_value"
+                  />
+                </th>
+              </tr>
+            </thead>
+            <tbody>
+              {preview.changes.map((c) => (
+                <tr key={c.field}>
+                  <td>{c.field}</td>
+                  <td>{c.current}</td>
+                  <td>{c.proposed}</td>
+                </tr>
+              ))}
+            </tbody>
+          </Table>
+        )}
+      </Form.Group>
+    );
+  }
+
   async function onIdentify() {
     try {
       await mutateMetadataIdentify(makeIdentifyInput());
@@ -442,13 +519,24 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
       }}
       disabled={editingField || savingDefaults || sources.length === 0}
       footerButtons={
-        <OperationButton
-          variant="secondary"
-          disabled={editingField || savingDefaults}
-          operation={setAsDefault}
-        >
-          <FormattedMessage id="actions.set_as_default" />
-        </OperationButton>
+        <>
+          {selectedIds?.length === 1 && (
+            <OperationButton
+              variant="secondary"
+              disabled={editingField || sources.length === 0}
+              operation={onPreview}
+            >
+              <FormattedMessage id="actions.preview" />
+            </OperationButton>
+          )}
+          <OperationButton
+            variant="secondary"
+            disabled={editingField || savingDefaults}
+            operation={setAsDefault}
+          >
+            <FormattedMessage id="actions.set_as_default" />
+          </OperationButton>
+        </>
       }
       leftFooterButtons={
         <Button
@@ -462,6 +550,7 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
     >
       <Form>
         {selectionStatus}
+        {renderPreview()}
         <SourcesList
           sources={sources}
           setSources={(s) => setSources(s)}
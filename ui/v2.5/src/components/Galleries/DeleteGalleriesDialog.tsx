@@ -7,6 +7,8 @@ import { useToast } from "src/hooks/Toast";
 import { ConfigurationContext } from "src/hooks/Config";
 import { FormattedMessage, useIntl } from "react-intl";
 import { faTrashAlt } from "@fortawesome/free-solid-svg-icons";
+import { getDeleteConfirmation, IDeleteConfirmation } from "src/utils/errors";
+import { DeleteConfirmationAlert } from "../Shared/DeleteConfirmationAlert";
 
 interface IDeleteGalleryDialogProps {
   selected: GQL.SlimGalleryDataFragment[];
@@ -47,21 +49,33 @@ export const DeleteGalleriesDialog: React.FC<IDeleteGalleryDialogProps> = (
 
   // Network state
   const [isDeleting, setIsDeleting] = useState(false);
+  const [confirmation, setConfirmation] = useState<IDeleteConfirmation>();
 
-  function getGalleriesDeleteInput(): GQL.GalleryDestroyInput {
+  function getGalleriesDeleteInput(
+    confirmationToken?: string
+  ): GQL.GalleryDestroyInput {
     return {
       ids: props.selected.map((gallery) => gallery.id!),
       delete_file: deleteFile,
       delete_generated: deleteGenerated,
+      confirmation_token: confirmationToken,
     };
   }
 
   async function onDelete() {
     setIsDeleting(true);
     try {
-      await deleteGallery();
+      await deleteGallery({
+        variables: getGalleriesDeleteInput(confirmation?.token),
+      });
       Toast.success(toastMessage);
     } catch (e) {
+      const pending = getDeleteConfirmation(e);
+      if (pending) {
+        setConfirmation(pending);
+        setIsDeleting(false);
+        return;
+      }
       Toast.error(e);
     }
     setIsDeleting(false);
@@ -136,6 +150,7 @@ export const DeleteGalleriesDialog: React.FC<IDeleteGalleryDialogProps> = (
       isRunning={isDeleting}
     >
       <p>{message}</p>
+      {confirmation && <DeleteConfirmationAlert confirmation={confirmation} />}
       {maybeRenderDeleteFileAlert()}
       <Form>
         <Form.Check
@@ -144,7 +159,10 @@ export const DeleteGalleriesDialog: React.FC<IDeleteGalleryDialogProps> = (
           label={intl.formatMessage({
             id: "dialogs.delete_gallery_files",
           })}
-          onChange={() => setDeleteFile(!deleteFile)}
+          onChange={() => {
+            setDeleteFile(!deleteFile);
+            setConfirmation(undefined);
+          }}
         />
         <Form.Check
           id="delete-generated"
@@ -33,6 +33,7 @@ import { GalleryImagesPanel } from "./GalleryImagesPanel";
 import { GalleryAddPanel } from "./GalleryAddPanel";
 import { GalleryFileInfoPanel } from "./GalleryFileInfoPanel";
 import { GalleryScenesPanel } from "./GalleryScenesPanel";
+import { ShareLinksDialog } from "src/components/Shared/ShareLinksDialog";
 import {
   faEllipsisV,
   faChevronRight,
@@ -191,6 +192,20 @@ export const GalleryPage: React.FC<IProps> = ({ gallery, add }) => {
     }
   }
 
+  const [isShareLinksDialogOpen, setIsShareLinksDialogOpen] =
+    useState<boolean>(false);
+
+  function maybeRenderShareLinksDialog() {
+    if (isShareLinksDialogOpen && gallery) {
+      return (
+        <ShareLinksDialog
+          galleryID={gallery.id}
+          onClose={() => setIsShareLinksDialogOpen(false)}
+        />
+      );
+    }
+  }
+
   function maybeRenderDeleteDialog() {
     if (isDeleteAlertOpen && gallery) {
       return (
@@ -228,6 +243,12 @@ export const GalleryPage: React.FC<IProps> = ({ gallery, add }) => {
           >
             <FormattedMessage id="actions.reset_cover" />
           </Dropdown.Item>
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            onClick={() => setIsShareLinksDialogOpen(true)}
+          >
+            <FormattedMessage id="actions.share" />
+          </Dropdown.Item>
           <Dropdown.Item
             className="bg-secondary text-white"
             onClick={() => setIsDeleteAlertOpen(true)}
@@ -419,6 +440,7 @@ export const GalleryPage: React.FC<IProps> = ({ gallery, add }) => {
         <title>{title}</title>
       </Helmet>
       {maybeRenderDeleteDialog()}
+      {maybeRenderShareLinksDialog()}
       <div className={`gallery-tabs ${collapsed ? "collapsed" : ""}`}>
         <div>
           <div className="gallery-header-container">
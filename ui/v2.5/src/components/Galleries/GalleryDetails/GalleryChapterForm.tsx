@@ -13,6 +13,15 @@ import { useToast } from "src/hooks/Toast";
 import isEqual from "lodash-es/isEqual";
 import { formikUtils } from "src/utils/form";
 import { yupFormikValidate, yupInputNumber } from "src/utils/yup";
+import { DisplayMode } from "src/models/list-filter/types";
+import { displayModeMessageID } from "src/components/List/ListViewOptions";
+
+const chapterDisplayModes = [
+  DisplayMode.Grid,
+  DisplayMode.Wall,
+  DisplayMode.Slideshow,
+  DisplayMode.Web,
+];
 
 interface IGalleryChapterForm {
   galleryID: string;
@@ -41,11 +50,13 @@ export const GalleryChapterForm: React.FC<IGalleryChapterForm> = ({
       .moreThan(0)
       .required()
       .label(intl.formatMessage({ id: "image_index" })),
+    display_mode: yup.string().ensure(),
   });
 
   const initialValues = {
     title: chapter?.title ?? "",
     image_index: chapter?.image_index ?? 1,
+    display_mode: chapter?.display_mode?.toString() ?? "",
   };
 
   type InputValues = yup.InferType<typeof schema>;
@@ -57,7 +68,14 @@ export const GalleryChapterForm: React.FC<IGalleryChapterForm> = ({
     onSubmit: (values) => onSave(schema.cast(values)),
   });
 
-  async function onSave(input: InputValues) {
+  async function onSave(values: InputValues) {
+    // an empty display mode uses the display mode of the gallery
+    const input = {
+      ...values,
+      display_mode:
+        values.display_mode === "" ? null : Number(values.display_mode),
+    };
+
     try {
       if (isNew) {
         await galleryChapterCreate({
@@ -103,13 +121,29 @@ export const GalleryChapterForm: React.FC<IGalleryChapterForm> = ({
       sm: 9,
     },
   };
-  const { renderInputField } = formikUtils(intl, formik, splitProps);
+  const { renderInputField, renderSelectField } = formikUtils(
+    intl,
+    formik,
+    splitProps
+  );
+
+  const displayModeEntries = new Map(
+    chapterDisplayModes.map((m) => [
+      intl.formatMessage({ id: displayModeMessageID(m) }),
+      m.toString(),
+    ])
+  );
 
   return (
     <Form noValidate onSubmit={formik.handleSubmit}>
       <div className="form-container px-3">
         {renderInputField("title")}
         {renderInputField("image_index", "number")}
+        {renderSelectField(
+          "display_mode",
+          displayModeEntries,
+          "gallery_chapter_display_mode"
+        )}
       </div>
       <div className="buttons-container px-3">
         <div className="d-flex">
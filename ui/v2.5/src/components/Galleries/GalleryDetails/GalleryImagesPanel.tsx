@@ -5,7 +5,9 @@ import { ListFilterModel } from "src/models/list-filter/filter";
 import { GalleryImagesList } from "../GalleryImagesList";
 import {
   mutateRemoveGalleryImages,
+  mutateReorderGalleryImages,
   mutateSetGalleryCover,
+  queryFindGalleryImageIDs,
 } from "src/core/StashService";
 import {
   showWhenSelected,
@@ -106,6 +108,61 @@ export const GalleryImagesPanel: React.FC<IGalleryDetailsProps> = ({
     }
   }
 
+  async function moveImages(
+    selectedIds: Set<string>,
+    direction: GQL.SortDirectionEnum
+  ) {
+    const imageIDs = Array.from(selectedIds.values());
+
+    try {
+      // find the first or last image in the gallery order that isn't being
+      // moved
+      const result = await queryFindGalleryImageIDs(gallery.id, {
+        per_page: imageIDs.length + 1,
+        sort: "gallery_order",
+        direction,
+      });
+      const insertAt = result.data.findImages.images.find(
+        (i) => !selectedIds.has(i.id)
+      );
+      if (!insertAt) return;
+
+      await mutateReorderGalleryImages({
+        gallery_id: gallery.id,
+        image_ids: imageIDs,
+        insert_at_id: insertAt.id,
+        insert_after: direction === GQL.SortDirectionEnum.Desc,
+      });
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "toast.updated_entity" },
+          {
+            entity: intl.formatMessage({ id: "gallery" }).toLocaleLowerCase(),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function moveToStart(
+    result: GQL.FindImagesQueryResult,
+    filter: ListFilterModel,
+    selectedIds: Set<string>
+  ) {
+    await moveImages(selectedIds, GQL.SortDirectionEnum.Asc);
+  }
+
+  async function moveToEnd(
+    result: GQL.FindImagesQueryResult,
+    filter: ListFilterModel,
+    selectedIds: Set<string>
+  ) {
+    await moveImages(selectedIds, GQL.SortDirectionEnum.Desc);
+  }
+
   async function removeImages(
     result: GQL.FindImagesQueryResult,
     filter: ListFilterModel,
@@ -138,6 +195,18 @@ export const GalleryImagesPanel: React.FC<IGalleryDetailsProps> = ({
       onClick: setCover,
       isDisplayed: showWhenSingleSelection,
     },
+    {
+      text: intl.formatMessage({ id: "actions.move_to_start" }),
+      onClick: moveToStart,
+      isDisplayed: showWhenSelected,
+      postRefetch: true,
+    },
+    {
+      text: intl.formatMessage({ id: "actions.move_to_end" }),
+      onClick: moveToEnd,
+      isDisplayed: showWhenSelected,
+      postRefetch: true,
+    },
     {
       text: intl.formatMessage({ id: "actions.remove_from_gallery" }),
       onClick: removeImages,
@@ -7,6 +7,8 @@ import { useToast } from "src/hooks/Toast";
 import { ConfigurationContext } from "src/hooks/Config";
 import { FormattedMessage, useIntl } from "react-intl";
 import { faTrashAlt } from "@fortawesome/free-solid-svg-icons";
+import { getDeleteConfirmation, IDeleteConfirmation } from "src/utils/errors";
+import { DeleteConfirmationAlert } from "../Shared/DeleteConfirmationAlert";
 
 interface IDeleteImageDialogProps {
   selected: GQL.SlimImageDataFragment[];
@@ -47,21 +49,33 @@ export const DeleteImagesDialog: React.FC<IDeleteImageDialogProps> = (
 
   // Network state
   const [isDeleting, setIsDeleting] = useState(false);
+  const [confirmation, setConfirmation] = useState<IDeleteConfirmation>();
 
-  function getImagesDeleteInput(): GQL.ImagesDestroyInput {
+  function getImagesDeleteInput(
+    confirmationToken?: string
+  ): GQL.ImagesDestroyInput {
     return {
       ids: props.selected.map((image) => image.id),
       delete_file: deleteFile,
       delete_generated: deleteGenerated,
+      confirmation_token: confirmationToken,
     };
   }
 
   async function onDelete() {
     setIsDeleting(true);
     try {
-      await deleteImage();
+      await deleteImage({
+        variables: getImagesDeleteInput(confirmation?.token),
+      });
       Toast.success(toastMessage);
     } catch (e) {
+      const pending = getDeleteConfirmation(e);
+      if (pending) {
+        setConfirmation(pending);
+        setIsDeleting(false);
+        return;
+      }
       Toast.error(e);
     }
     setIsDeleting(false);
@@ -129,13 +143,17 @@ export const DeleteImagesDialog: React.FC<IDeleteImageDialogProps> = (
       isRunning={isDeleting}
     >
       <p>{message}</p>
+      {confirmation && <DeleteConfirmationAlert confirmation={confirmation} />}
       {maybeRenderDeleteFileAlert()}
       <Form>
         <Form.Check
           id="delete-image"
           checked={deleteFile}
           label={intl.formatMessage({ id: "actions.delete_file" })}
-          onChange={() => setDeleteFile(!deleteFile)}
+          onChange={() => {
+            setDeleteFile(!deleteFile);
+            setConfirmation(undefined);
+          }}
         />
         <Form.Check
           id="delete-image-generated"
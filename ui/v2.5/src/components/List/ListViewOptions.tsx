@@ -40,7 +40,7 @@ function getIcon(option: DisplayMode) {
   }
 }
 
-function getLabelId(option: DisplayMode) {
+export function displayModeMessageID(option: DisplayMode) {
   let displayModeId = "unknown";
   switch (option) {
     case DisplayMode.Grid:
@@ -117,7 +117,7 @@ export const ListViewOptions: React.FC<IListViewOptionsProps> = ({
   });
 
   function getLabel(option: DisplayMode) {
-    return intl.formatMessage({ id: getLabelId(option) });
+    return intl.formatMessage({ id: displayModeMessageID(option) });
   }
 
   function onChangeZoom(v: number) {
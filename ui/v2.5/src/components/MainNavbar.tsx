@@ -324,67 +324,36 @@ const getRandomUnratedScene = async (): Promise<string | null> => {
   }
 };
 
+// the server excludes the scenes recently served to this session, so that
+// play random does not repeat scenes
+const randomSceneSession = Math.random().toString(36).slice(2);
+
 const getRandomScene = async (
-  ratingThreshold: number = 55
+  best: boolean = false
 ): Promise<string | null> => {
   try {
     const client = getClient();
 
-    const countResult = await client.query<GQL.FindScenesQuery>({
-      query: GQL.FindScenesDocument,
+    const result = await client.query<GQL.FindRandomSceneQuery>({
+      query: GQL.FindRandomSceneDocument,
       variables: {
-        filter: {
-          per_page: 0,
-        },
-        scene_filter: {
-          rating100: {
-            modifier: GQL.CriterionModifier.GreaterThan,
-            value: ratingThreshold - 1, // > (threshold-1) means >= threshold
-          },
+        input: {
+          best,
+          session: randomSceneSession,
         },
       },
+      fetchPolicy: "network-only",
     });
 
-    const totalCount = countResult.data?.findScenes?.count || 0;
-    if (totalCount === 0) {
-      return null;
-    }
-
-    const randomPage = Math.floor(Math.random() * totalCount) + 1;
-
-    const result = await client.query<GQL.FindScenesQuery>({
-      query: GQL.FindScenesDocument,
-      variables: {
-        filter: {
-          per_page: 1,
-          page: randomPage,
-          sort: "random",
-        },
-        scene_filter: {
-          rating100: {
-            modifier: GQL.CriterionModifier.GreaterThan,
-            value: ratingThreshold - 1, // > (threshold-1) means >= threshold
-          },
-        },
-      },
-    });
-
-    const scenes = result.data?.findScenes?.scenes || [];
-    if (scenes.length === 0) {
-      return null;
-    }
-
-    return scenes[0]?.id || null;
+    return result.data?.findRandomScene?.id ?? null;
   } catch (error) {
     console.error("Error getting random scene:", error);
     return null;
   }
 };
 
-const getRandomBestScene = async (
-  ratingThreshold: number = 90
-): Promise<string | null> => {
-  return getRandomScene(ratingThreshold);
+const getRandomBestScene = async (): Promise<string | null> => {
+  return getRandomScene(true);
 };
 
 const MainNavbarMenuItems = PatchComponent(
@@ -487,7 +456,7 @@ export const MainNavbar: React.FC = () => {
   const handleRandomClick = useCallback(async () => {
     const ratingThreshold =
       configuration?.interface?.randomRatingThreshold ?? 55;
-    const sceneId = await getRandomScene(ratingThreshold);
+    const sceneId = await getRandomScene();
     if (sceneId) {
       history.push(`/scenes/${sceneId}`);
     } else {
@@ -503,7 +472,7 @@ export const MainNavbar: React.FC = () => {
   const handleRandomBestClick = useCallback(async () => {
     const ratingThreshold =
       configuration?.interface?.randomBestRatingThreshold ?? 90;
-    const sceneId = await getRandomBestScene(ratingThreshold);
+    const sceneId = await getRandomBestScene();
     if (sceneId) {
       history.push(`/scenes/${sceneId}`);
     } else {
@@ -5,6 +5,7 @@ import { Button, Card, Form, Table } from "react-bootstrap";
 import { FormattedMessage, useIntl } from "react-intl";
 import clone from "lodash-es/clone";
 import {
+  mutateSceneParserBatchCreate,
   queryParseSceneFilenames,
   useScenesUpdate,
 } from "src/core/StashService";
@@ -15,6 +16,7 @@ import { Pagination } from "src/components/List/Pagination";
 import { IParserInput, ParserInput } from "./ParserInput";
 import { ParserField } from "./ParserField";
 import { SceneParserResult, SceneParserRow } from "./SceneParserRow";
+import { SceneParserBatches } from "./SceneParserBatches";
 
 const initialParserInput = {
   pattern: "{title}.{ext}",
@@ -200,6 +202,35 @@ export const SceneFilenameParser: React.FC = () => {
     onFindClicked(parserInput);
   }
 
+  async function onSaveForReview() {
+    setIsLoading(true);
+
+    try {
+      await mutateSceneParserBatchCreate({
+        filter: {
+          q: parserInput.pattern,
+          sort: "path",
+          direction: GQL.SortDirectionEnum.Asc,
+        },
+        config: {
+          ignoreWords: parserInput.ignoreWords,
+          whitespaceCharacters: parserInput.whitespaceCharacters,
+          capitalizeTitle: parserInput.capitalizeTitle,
+          ignoreOrganized: parserInput.ignoreOrganized,
+        },
+      });
+      Toast.success(
+        intl.formatMessage({
+          id: "config.tools.scene_filename_parser.batch_created",
+        })
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+
+    setIsLoading(false);
+  }
+
   useEffect(() => {
     const newAllTitleSet = !parserResult.some((r) => {
       return !r.title.isSet;
@@ -397,6 +428,11 @@ export const SceneFilenameParser: React.FC = () => {
         <Button variant="primary" onClick={onApply}>
           <FormattedMessage id="actions.apply" />
         </Button>
+        <Button variant="secondary" className="ml-2" onClick={onSaveForReview}>
+          <FormattedMessage
+            id="config.tools.scene_filename_parser.save_batch"
+          />
+        </Button>
       </>
     );
   }
@@ -416,6 +452,7 @@ export const SceneFilenameParser: React.FC = () => {
 
       {isLoading && <LoadingIndicator />}
       {renderTable()}
+      <SceneParserBatches />
     </Card>
   );
 };
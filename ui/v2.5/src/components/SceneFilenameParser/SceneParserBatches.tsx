@@ -0,0 +1,271 @@
+import React, { useState } from "react";
+import { Button, ButtonGroup, Form, Table } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import { Link } from "react-router-dom";
+import * as GQL from "src/core/generated-graphql";
+import {
+  mutateSceneParserBatchApply,
+  mutateSceneParserBatchDestroy,
+  mutateSceneParserChangesUpdate,
+  useFindSceneParserBatch,
+  useFindSceneParserBatches,
+} from "src/core/StashService";
+import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
+import { AlertModal } from "src/components/Shared/Alert";
+import { useToast } from "src/hooks/Toast";
+import TextUtils from "src/utils/text";
+
+type SceneParserChange = NonNullable<
+  GQL.FindSceneParserBatchQuery["findSceneParserBatch"]
+>["changes"][number];
+
+function changeValue(change: SceneParserChange) {
+  if (change.ids) {
+    return change.ids.join(", ");
+  }
+
+  return change.value ?? "";
+}
+
+function sceneName(change: SceneParserChange) {
+  return (
+    change.scene.title ||
+    TextUtils.fileNameFromPath(change.scene.files[0]?.path ?? "")
+  );
+}
+
+export const SceneParserBatches: React.FC = () => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const [batchID, setBatchID] = useState("");
+  const [showDestroy, setShowDestroy] = useState(false);
+
+  const { data: batchesData, refetch: refetchBatches } =
+    useFindSceneParserBatches();
+  const { data, loading, refetch } = useFindSceneParserBatch(batchID);
+
+  const batches = batchesData?.findSceneParserBatches ?? [];
+  const batchName = intl.formatMessage({
+    id: "config.tools.scene_filename_parser.batch",
+  });
+  const batch = data?.findSceneParserBatch;
+
+  async function onSetStatus(
+    ids: string[],
+    status: GQL.SceneParserChangeStatus
+  ) {
+    if (ids.length === 0) return;
+
+    try {
+      await mutateSceneParserChangesUpdate(ids, status);
+      refetch();
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function onSetPendingStatus(status: GQL.SceneParserChangeStatus) {
+    const ids = (batch?.changes ?? [])
+      .filter((c) => c.status === GQL.SceneParserChangeStatus.Pending)
+      .map((c) => c.id);
+    onSetStatus(ids, status);
+  }
+
+  async function onApply() {
+    try {
+      await mutateSceneParserBatchApply(batchID);
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "config.tools.scene_filename_parser.apply_batch",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onDestroy() {
+    setShowDestroy(false);
+
+    try {
+      await mutateSceneParserBatchDestroy(batchID);
+      setBatchID("");
+      refetchBatches();
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function renderChange(change: SceneParserChange) {
+    const reviewable = change.status !== GQL.SceneParserChangeStatus.Applied;
+
+    return (
+      <tr key={change.id}>
+        <td>
+          <Link to={`/scenes/${change.scene.id}`}>{sceneName(change)}</Link>
+        </td>
+        <td>{change.field}</td>
+        <td>{changeValue(change)}</td>
+        <td>{change.status}</td>
+        <td>
+          {reviewable && (
+            <ButtonGroup size="sm">
+              <Button
+                variant="success"
+                disabled={
+                  change.status === GQL.SceneParserChangeStatus.Accepted
+                }
+                onClick={() =>
+                  onSetStatus(
+                    [change.id],
+                    GQL.SceneParserChangeStatus.Accepted
+                  )
+                }
+              >
+                <FormattedMessage
+                  id="config.tools.scene_filename_parser.accept"
+                />
+              </Button>
+              <Button
+                variant="danger"
+                disabled={
+                  change.status === GQL.SceneParserChangeStatus.Rejected
+                }
+                onClick={() =>
+                  onSetStatus(
+                    [change.id],
+                    GQL.SceneParserChangeStatus.Rejected
+                  )
+                }
+              >
+                <FormattedMessage
+                  id="config.tools.scene_filename_parser.reject"
+                />
+              </Button>
+            </ButtonGroup>
+          )}
+        </td>
+      </tr>
+    );
+  }
+
+  function renderBatch() {
+    if (loading) {
+      return <LoadingIndicator />;
+    }
+
+    if (!batch) {
+      return undefined;
+    }
+
+    return (
+      <>
+        <div className="scene-parser-batch-actions">
+          <Button
+            variant="secondary"
+            onClick={() =>
+              onSetPendingStatus(GQL.SceneParserChangeStatus.Accepted)
+            }
+          >
+            <FormattedMessage
+              id="config.tools.scene_filename_parser.accept_pending"
+            />
+          </Button>
+          <Button
+            variant="secondary"
+            onClick={() =>
+              onSetPendingStatus(GQL.SceneParserChangeStatus.Rejected)
+            }
+          >
+            <FormattedMessage
+              id="config.tools.scene_filename_parser.reject_pending"
+            />
+          </Button>
+          <Button variant="primary" onClick={() => onApply()}>
+            <FormattedMessage
+              id="config.tools.scene_filename_parser.apply_batch"
+            />
+          </Button>
+          <Button variant="danger" onClick={() => setShowDestroy(true)}>
+            <FormattedMessage id="actions.delete" />
+          </Button>
+        </div>
+        <Table className="scene-parser-batch-changes">
+          <thead>
+            <tr>
+              <th>{intl.formatMessage({ id: "scene" })}</th>
+              <th>
+                {intl.formatMessage({
+                  id: "config.tools.scene_filename_parser.field",
+                })}
+              </th>
+              <th>
+                {intl.formatMessage({
+                  id: "config.tools.scene_filename_parser.value",
+                })}
+              </th>
+              <th>
+                {intl.formatMessage({
+                  id: "config.tools.scene_filename_parser.status",
+                })}
+              </th>
+              <th />
+            </tr>
+          </thead>
+          <tbody>{batch.changes.map(renderChange)}</tbody>
+        </Table>
+      </>
+    );
+  }
+
+  if (batches.length === 0) {
+    return null;
+  }
+
+  return (
+    <div className="scene-parser-batches">
+      <AlertModal
+        show={showDestroy}
+        text={intl.formatMessage(
+          { id: "dialogs.delete_object_title" },
+          {
+            count: 1,
+            singularEntity: batchName,
+            pluralEntity: batchName,
+          }
+        )}
+        onConfirm={() => onDestroy()}
+        onCancel={() => setShowDestroy(false)}
+      />
+      <h5>
+        {intl.formatMessage({
+          id: "config.tools.scene_filename_parser.batches",
+        })}
+      </h5>
+      <Form.Control
+        as="select"
+        className="input-control"
+        value={batchID}
+        onChange={(e) => setBatchID(e.currentTarget.value)}
+      >
+        <option value="">
+          {intl.formatMessage({
+            id: "config.tools.scene_filename_parser.select_batch",
+          })}
+        </option>
+        {batches.map((b) => (
+          <option key={b.id} value={b.id}>
+            {`${b.pattern} (${TextUtils.formatDateTime(intl, b.created_at)})`}
+          </option>
+        ))}
+      </Form.Control>
+      {renderBatch()}
+    </div>
+  );
+};
@@ -7,6 +7,8 @@ import { useToast } from "src/hooks/Toast";
 import { ConfigurationContext } from "src/hooks/Config";
 import { FormattedMessage, useIntl } from "react-intl";
 import { faTrashAlt } from "@fortawesome/free-solid-svg-icons";
+import { getDeleteConfirmation, IDeleteConfirmation } from "src/utils/errors";
+import { DeleteConfirmationAlert } from "../Shared/DeleteConfirmationAlert";
 import { objectPath } from "src/core/files";
 
 interface IDeleteSceneDialogProps {
@@ -48,22 +50,34 @@ export const DeleteScenesDialog: React.FC<IDeleteSceneDialogProps> = (
 
   // Network state
   const [isDeleting, setIsDeleting] = useState(false);
+  const [confirmation, setConfirmation] = useState<IDeleteConfirmation>();
 
-  function getScenesDeleteInput(): GQL.ScenesDestroyInput {
+  function getScenesDeleteInput(
+    confirmationToken?: string
+  ): GQL.ScenesDestroyInput {
     return {
       ids: props.selected.map((scene) => scene.id),
       delete_file: deleteFile,
       delete_generated: deleteGenerated,
+      confirmation_token: confirmationToken,
     };
   }
 
   async function onDelete() {
     setIsDeleting(true);
     try {
-      await deleteScene();
+      await deleteScene({
+        variables: getScenesDeleteInput(confirmation?.token),
+      });
       Toast.success(toastMessage);
       props.onClose(true);
     } catch (e) {
+      const pending = getDeleteConfirmation(e);
+      if (pending) {
+        setConfirmation(pending);
+        setIsDeleting(false);
+        return;
+      }
       Toast.error(e);
       props.onClose(false);
     }
@@ -143,6 +157,7 @@ export const DeleteScenesDialog: React.FC<IDeleteSceneDialogProps> = (
       isRunning={isDeleting}
     >
       <p>{message}</p>
+      {confirmation && <DeleteConfirmationAlert confirmation={confirmation} />}
       {maybeRenderDeleteFileAlert()}
       <Form>
         <Form.Check
@@ -151,7 +166,10 @@ export const DeleteScenesDialog: React.FC<IDeleteSceneDialogProps> = (
           label={intl.formatMessage({
             id: "actions.delete_file_and_funscript",
           })}
-          onChange={() => setDeleteFile(!deleteFile)}
+          onChange={() => {
+            setDeleteFile(!deleteFile);
+            setConfirmation(undefined);
+          }}
         />
         <Form.Check
           id="delete-generated"
@@ -558,7 +558,11 @@ const SceneCardImage = PatchComponent(
           filters={props.scene.video_filters}
           transforms={props.scene.video_transforms}
           image={props.scene.paths.screenshot ?? undefined}
-          video={props.scene.paths.preview ?? undefined}
+          video={
+            props.scene.paths.wall_preview ??
+            props.scene.paths.preview ??
+            undefined
+          }
           isPortrait={isPortrait()}
           soundActive={configuration?.interface?.soundOnPreview ?? false}
           vttPath={props.scene.paths.vtt ?? undefined}
@@ -0,0 +1,261 @@
+import React, { useEffect, useState } from "react";
+import { Form, Alert, Row, Col, Button, Table } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import { ModalComponent } from "src/components/Shared/Modal";
+import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
+import { Icon } from "src/components/Shared/Icon";
+import { useToast } from "src/hooks/Toast";
+import * as GQL from "src/core/generated-graphql";
+import { useSceneManageAudioTracks } from "src/core/StashService";
+import {
+  faArrowDown,
+  faArrowUp,
+  faVolumeUp,
+} from "@fortawesome/free-solid-svg-icons";
+
+interface IAudioTracksModalProps {
+  scene: GQL.SceneDataFragment;
+  onClose: () => void;
+}
+
+interface ITrackState {
+  track: GQL.AudioTrack;
+  keep: boolean;
+  extract: boolean;
+}
+
+export const AudioTracksModal: React.FC<IAudioTracksModalProps> = ({
+  scene,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [manageAudioTracks] = useSceneManageAudioTracks();
+
+  const [selectedFileId, setSelectedFileId] = useState<string>(
+    scene.files.length > 0 ? scene.files[0].id : ""
+  );
+  const [tracks, setTracks] = useState<ITrackState[]>([]);
+  const [defaultTrack, setDefaultTrack] = useState<number>();
+  const [isProcessing, setIsProcessing] = useState(false);
+
+  const { data, loading } = GQL.useSceneAudioTracksQuery({
+    variables: { scene_id: scene.id, file_id: selectedFileId },
+    skip: !selectedFileId,
+    fetchPolicy: "network-only",
+  });
+
+  useEffect(() => {
+    const fileTracks = data?.sceneAudioTracks ?? [];
+    setTracks(
+      fileTracks.map((track) => ({ track, keep: true, extract: false }))
+    );
+    setDefaultTrack(fileTracks.find((t) => t.default)?.index);
+  }, [data]);
+
+  const kept = tracks.filter((t) => t.keep);
+  const reordered = kept.some((t, i) => t.track.index !== i);
+  const remux =
+    kept.length !== tracks.length ||
+    reordered ||
+    defaultTrack !== tracks.find((t) => t.track.default)?.track.index;
+  const extract = tracks.filter((t) => t.extract).map((t) => t.track.index);
+
+  const valid =
+    kept.length > 0 &&
+    (defaultTrack === undefined ||
+      kept.some((t) => t.track.index === defaultTrack));
+
+  function updateTrack(index: number, v: Partial<ITrackState>) {
+    setTracks(tracks.map((t, i) => (i === index ? { ...t, ...v } : t)));
+  }
+
+  function moveTrack(index: number, offset: number) {
+    const newTracks = [...tracks];
+    const [moved] = newTracks.splice(index, 1);
+    newTracks.splice(index + offset, 0, moved);
+    setTracks(newTracks);
+  }
+
+  async function onApply() {
+    setIsProcessing(true);
+    try {
+      const result = await manageAudioTracks({
+        variables: {
+          input: {
+            scene_id: scene.id,
+            file_id: selectedFileId,
+            tracks: remux ? kept.map((t) => t.track.index) : undefined,
+            default_track: remux ? defaultTrack : undefined,
+            extract,
+          },
+        },
+      });
+
+      if (result.data?.sceneManageAudioTracks) {
+        Toast.success(
+          intl.formatMessage(
+            { id: "actions.manage_audio_tracks_started" },
+            { jobId: result.data.sceneManageAudioTracks }
+          )
+        );
+        onClose();
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setIsProcessing(false);
+    }
+  }
+
+  function trackLabel(track: GQL.AudioTrack) {
+    const parts = [track.codec];
+    if (track.language) parts.push(track.language);
+    if (track.channels) parts.push(`${track.channels}ch`);
+    if (track.title) parts.push(track.title);
+    return `#${track.index} ${parts.join(" · ")}`;
+  }
+
+  function renderTracks() {
+    if (loading) {
+      return <LoadingIndicator small inline />;
+    }
+
+    if (tracks.length === 0) {
+      return (
+        <Alert variant="info">
+          <FormattedMessage id="dialogs.manage_audio_tracks.no_tracks" />
+        </Alert>
+      );
+    }
+
+    return (
+      <Table size="sm">
+        <thead>
+          <tr>
+            <th />
+            <th>
+              <FormattedMessage id="dialogs.manage_audio_tracks.keep" />
+            </th>
+            <th>
+              <FormattedMessage id="dialogs.manage_audio_tracks.default" />
+            </th>
+            <th>
+              <FormattedMessage id="dialogs.manage_audio_tracks.extract" />
+            </th>
+            <th />
+          </tr>
+        </thead>
+        <tbody>
+          {tracks.map((t, i) => (
+            <tr key={t.track.index}>
+              <td>{trackLabel(t.track)}</td>
+              <td>
+                <Form.Check
+                  checked={t.keep}
+                  onChange={() => updateTrack(i, { keep: !t.keep })}
+                />
+              </td>
+              <td>
+                <Form.Check
+                  type="radio"
+                  name="default-track"
+                  disabled={!t.keep}
+                  checked={defaultTrack === t.track.index}
+                  onChange={() => setDefaultTrack(t.track.index)}
+                />
+              </td>
+              <td>
+                <Form.Check
+                  checked={t.extract}
+                  onChange={() => updateTrack(i, { extract: !t.extract })}
+                />
+              </td>
+              <td>
+                <Button
+                  size="sm"
+                  variant="secondary"
+                  disabled={i === 0}
+                  title={intl.formatMessage({
+                    id: "dialogs.manage_audio_tracks.move_up",
+                  })}
+                  onClick={() => moveTrack(i, -1)}
+                >
+                  <Icon icon={faArrowUp} />
+                </Button>
+                <Button
+                  size="sm"
+                  variant="secondary"
+                  className="ml-1"
+                  disabled={i === tracks.length - 1}
+                  title={intl.formatMessage({
+                    id: "dialogs.manage_audio_tracks.move_down",
+                  })}
+                  onClick={() => moveTrack(i, 1)}
+                >
+                  <Icon icon={faArrowDown} />
+                </Button>
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      icon={faVolumeUp}
+      header={intl.formatMessage({ id: "dialogs.manage_audio_tracks.title" })}
+      accept={{
+        variant: remux ? "danger" : "primary",
+        onClick: onApply,
+        text: intl.formatMessage({ id: "actions.apply" }),
+      }}
+      disabled={!valid || (!remux && extract.length === 0)}
+      cancel={{
+        onClick: onClose,
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+      }}
+      isRunning={isProcessing}
+    >
+      <Form>
+        {remux && (
+          <Alert variant="warning">
+            <FormattedMessage id="dialogs.manage_audio_tracks.warning" />
+          </Alert>
+        )}
+
+        {scene.files.length > 1 && (
+          <Form.Group controlId="file-select" as={Row}>
+            <Form.Label column sm={3}>
+              <FormattedMessage id="file" />
+            </Form.Label>
+            <Col sm={9}>
+              <Form.Control
+                as="select"
+                value={selectedFileId}
+                onChange={(e) => setSelectedFileId(e.target.value)}
+                className="input-control"
+              >
+                {scene.files.map((file) => (
+                  <option key={file.id} value={file.id}>
+                    {file.path ? file.path.split("/").pop() : "Unknown file"}
+                  </option>
+                ))}
+              </Form.Control>
+            </Col>
+          </Form.Group>
+        )}
+
+        {renderTracks()}
+
+        <Alert variant="info">
+          <FormattedMessage id="dialogs.manage_audio_tracks.info" />
+        </Alert>
+      </Form>
+    </ModalComponent>
+  );
+};
@@ -0,0 +1,177 @@
+import React, { useState } from "react";
+import { Form, Row, Col } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import { faImages } from "@fortawesome/free-solid-svg-icons";
+import { ModalComponent } from "src/components/Shared/Modal";
+import { useToast } from "src/hooks/Toast";
+import { useCaptureGalleryFromScene } from "src/core/StashService";
+import TextUtils from "src/utils/text";
+
+interface ICaptureGalleryModalProps {
+  sceneId: string;
+  onClose: () => void;
+}
+
+type CaptureMode = "timestamps" | "interval";
+
+function parseTimestamps(v: string) {
+  return v
+    .split(",")
+    .map((t) => t.trim())
+    .filter((t) => t !== "")
+    .map((t) => TextUtils.timestampToSeconds(t))
+    .filter((t): t is number => t !== null && t >= 0);
+}
+
+export const CaptureGalleryModal: React.FC<ICaptureGalleryModalProps> = ({
+  sceneId,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [captureGallery] = useCaptureGalleryFromScene();
+
+  const [mode, setMode] = useState<CaptureMode>("interval");
+  const [timestamps, setTimestamps] = useState("");
+  const [intervalValue, setIntervalValue] = useState("10");
+  const [title, setTitle] = useState("");
+  const [isProcessing, setIsProcessing] = useState(false);
+
+  const parsedTimestamps = parseTimestamps(timestamps);
+  const parsedInterval = Number(intervalValue);
+  const valid =
+    mode === "timestamps"
+      ? parsedTimestamps.length > 0
+      : Number.isFinite(parsedInterval) && parsedInterval > 0;
+
+  const handleSubmit = async () => {
+    if (!valid) {
+      Toast.error(
+        intl.formatMessage({ id: "dialogs.capture_gallery.invalid_input" })
+      );
+      return;
+    }
+
+    setIsProcessing(true);
+    try {
+      const result = await captureGallery({
+        variables: {
+          input: {
+            scene_id: sceneId,
+            timestamps: mode === "timestamps" ? parsedTimestamps : undefined,
+            interval: mode === "interval" ? parsedInterval : undefined,
+            title: title || undefined,
+          },
+        },
+      });
+
+      if (result.data?.captureGalleryFromScene) {
+        Toast.success(
+          intl.formatMessage(
+            { id: "actions.capture_gallery_started" },
+            { jobId: result.data.captureGalleryFromScene }
+          )
+        );
+        onClose();
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setIsProcessing(false);
+    }
+  };
+
+  return (
+    <ModalComponent
+      show
+      icon={faImages}
+      header={intl.formatMessage({ id: "dialogs.capture_gallery.title" })}
+      accept={{
+        onClick: handleSubmit,
+        text: intl.formatMessage({ id: "actions.capture_gallery" }),
+        disabled: !valid,
+      }}
+      cancel={{
+        onClick: onClose,
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+      }}
+      isRunning={isProcessing}
+    >
+      <Form>
+        <Form.Group>
+          <Form.Check
+            type="radio"
+            id="capture-mode-interval"
+            checked={mode === "interval"}
+            onChange={() => setMode("interval")}
+            label={intl.formatMessage({
+              id: "dialogs.capture_gallery.every_interval",
+            })}
+          />
+          <Form.Check
+            type="radio"
+            id="capture-mode-timestamps"
+            checked={mode === "timestamps"}
+            onChange={() => setMode("timestamps")}
+            label={intl.formatMessage({
+              id: "dialogs.capture_gallery.at_timestamps",
+            })}
+          />
+        </Form.Group>
+
+        {mode === "interval" ? (
+          <Form.Group controlId="capture-interval" as={Row}>
+            <Form.Label column sm={3}>
+              <FormattedMessage id="dialogs.capture_gallery.interval" />
+            </Form.Label>
+            <Col sm={9}>
+              <Form.Control
+                type="number"
+                min={0}
+                step="any"
+                className="text-input"
+                value={intervalValue}
+                onChange={(e) => setIntervalValue(e.currentTarget.value)}
+              />
+            </Col>
+          </Form.Group>
+        ) : (
+          <Form.Group controlId="capture-timestamps" as={Row}>
+            <Form.Label column sm={3}>
+              <FormattedMessage id="dialogs.capture_gallery.timestamps" />
+            </Form.Label>
+            <Col sm={9}>
+              <Form.Control
+                className="text-input"
+                value={timestamps}
+                onChange={(e) => setTimestamps(e.currentTarget.value)}
+              />
+              <Form.Text className="text-muted">
+                <FormattedMessage
+                  id="dialogs.capture_gallery.timestamps_desc"
+                />
+              </Form.Text>
+            </Col>
+          </Form.Group>
+        )}
+
+        <Form.Group controlId="capture-title" as={Row}>
+          <Form.Label column sm={3}>
+            <FormattedMessage id="title" />
+          </Form.Label>
+          <Col sm={9}>
+            <Form.Control
+              className="text-input"
+              value={title}
+              onChange={(e) => setTitle(e.currentTarget.value)}
+            />
+            <Form.Text className="text-muted">
+              <FormattedMessage id="dialogs.capture_gallery.title_desc" />
+            </Form.Text>
+          </Col>
+        </Form.Group>
+      </Form>
+    </ModalComponent>
+  );
+};
@@ -25,6 +25,7 @@ import {
   useSceneIncrementO,
   useSceneIncrementOmg,
   useSceneGenerateScreenshot,
+  useSceneRerollCover,
   useSceneSaveFilteredScreenshot,
   useSceneUpdate,
   queryFindScenes,
@@ -32,6 +33,7 @@ import {
   useSceneIncrementPlayCount,
   useSceneConvertToMP4,
   useSceneConvertHLSToMP4,
+  useSceneRemediateVFR,
   useSceneSetBroken,
   useSceneSetNotBroken,
   useScanVideoFileThreats,
@@ -64,8 +66,11 @@ import {
   faCog,
   faCamera,
   faImage,
+  faRandom,
   faCompressAlt,
   faCut,
+  faSyncAlt,
+  faVolumeUp,
   faImages,
   faPhotoVideo,
   faExclamationTriangle,
@@ -74,6 +79,7 @@ import {
   faExchangeAlt,
   faTrash,
   faShieldAlt,
+  faShareAlt,
 } from "@fortawesome/free-solid-svg-icons";
 import { objectPath, objectTitle } from "src/core/files";
 import { RatingSystem } from "src/components/Shared/Rating/RatingSystem";
@@ -123,7 +129,11 @@ const SceneVideoFilterPanel = lazyComponent(
 import { SceneMergeModal } from "../SceneMergeDialog";
 import { ReduceResolutionModal } from "./ReduceResolutionModal";
 import { TrimVideoModal } from "./TrimVideoModal";
+import { TransformVideoModal } from "./TransformVideoModal";
+import { AudioTracksModal } from "./AudioTracksModal";
 import { RegenerateSpritesModal } from "./RegenerateSpritesModal";
+import { CaptureGalleryModal } from "./CaptureGalleryModal";
+import { ShareLinksDialog } from "src/components/Shared/ShareLinksDialog";
 import { ModalComponent } from "src/components/Shared/Modal";
 import { SceneDataUpdateNotification } from "./SceneDataUpdateNotification";
 import { captureFilteredSceneScreenshot } from "./captureFilteredScreenshot";
@@ -238,6 +248,7 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
   const intl = useIntl();
   const [updateScene] = useSceneUpdate();
   const [generateScreenshot] = useSceneGenerateScreenshot();
+  const [rerollCover] = useSceneRerollCover();
   const [saveFilteredScreenshot] = useSceneSaveFilteredScreenshot();
   const { configuration } = useContext(ConfigurationContext);
 
@@ -245,8 +256,13 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
   const [showReduceResolutionModal, setShowReduceResolutionModal] =
     useState(false);
   const [showTrimVideoModal, setShowTrimVideoModal] = useState(false);
+  const [showTransformVideoModal, setShowTransformVideoModal] =
+    useState(false);
+  const [showAudioTracksModal, setShowAudioTracksModal] = useState(false);
   const [showRegenerateSpritesModal, setShowRegenerateSpritesModal] =
     useState(false);
+  const [showCaptureGalleryModal, setShowCaptureGalleryModal] = useState(false);
+  const [showShareLinksDialog, setShowShareLinksDialog] = useState(false);
   const [showConvertToMP4Confirm, setShowConvertToMP4Confirm] = useState(false);
   const [showConvertHLSToMP4Confirm, setShowConvertHLSToMP4Confirm] =
     useState(false);
@@ -260,6 +276,7 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
   const [incrementPlay] = useSceneIncrementPlayCount();
   const [convertToMP4] = useSceneConvertToMP4();
   const [convertHLSToMP4] = useSceneConvertHLSToMP4();
+  const [remediateVFR] = useSceneRemediateVFR();
   const [setBroken] = useSceneSetBroken();
   const [setNotBroken] = useSceneSetNotBroken();
   const [scanVideoFileThreats] = useScanVideoFileThreats();
@@ -548,6 +565,11 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
     Toast.success(intl.formatMessage({ id: "toast.generating_screenshot" }));
   }
 
+  async function onRerollCover() {
+    await rerollCover({ variables: { id: scene.id } });
+    Toast.success(intl.formatMessage({ id: "toast.generating_screenshot" }));
+  }
+
   async function onSaveFilteredScreenshot() {
     if (isSavingFilteredScreenshot) {
       return;
@@ -603,6 +625,28 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
     }
   }
 
+  async function onRemediateVFR() {
+    try {
+      const result = await remediateVFR({
+        variables: {
+          scene_id: scene.id,
+          file_id: scene.files[0].id,
+        },
+      });
+
+      if (result.data?.sceneRemediateVFR) {
+        Toast.success(
+          intl.formatMessage(
+            { id: "actions.remediate_vfr_started" },
+            { jobId: result.data.sceneRemediateVFR }
+          )
+        );
+      }
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   function onConvertHLSToMP4() {
     setShowConvertHLSToMP4Confirm(true);
   }
@@ -764,6 +808,28 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
     }
   }
 
+  function maybeRenderTransformVideoDialog() {
+    if (showTransformVideoModal) {
+      return (
+        <TransformVideoModal
+          scene={scene}
+          onClose={() => setShowTransformVideoModal(false)}
+        />
+      );
+    }
+  }
+
+  function maybeRenderAudioTracksDialog() {
+    if (showAudioTracksModal) {
+      return (
+        <AudioTracksModal
+          scene={scene}
+          onClose={() => setShowAudioTracksModal(false)}
+        />
+      );
+    }
+  }
+
   function maybeRenderRegenerateSpritesDialog() {
     if (showRegenerateSpritesModal) {
       return (
@@ -780,6 +846,28 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
     }
   }
 
+  function maybeRenderCaptureGalleryDialog() {
+    if (showCaptureGalleryModal) {
+      return (
+        <CaptureGalleryModal
+          sceneId={scene.id}
+          onClose={() => setShowCaptureGalleryModal(false)}
+        />
+      );
+    }
+  }
+
+  function maybeRenderShareLinksDialog() {
+    if (showShareLinksDialog) {
+      return (
+        <ShareLinksDialog
+          sceneID={scene.id}
+          onClose={() => setShowShareLinksDialog(false)}
+        />
+      );
+    }
+  }
+
   function maybeRenderConvertToMP4ConfirmDialog() {
     if (showConvertToMP4Confirm) {
       const originalFormat =
@@ -992,6 +1080,16 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
             <Icon icon={faImage} className="mr-2" />
             <FormattedMessage id="actions.generate_thumb_default" />
           </Dropdown.Item>
+          {scene.files.length > 0 && (
+            <Dropdown.Item
+              key="reroll-cover"
+              className="bg-secondary text-white d-flex align-items-center"
+              onClick={() => onRerollCover()}
+            >
+              <Icon icon={faRandom} className="mr-2" />
+              <FormattedMessage id="actions.reroll_cover" />
+            </Dropdown.Item>
+          )}
           {scene.files.length > 0 && (
             <Dropdown.Item
               key="regenerate-sprites"
@@ -1002,6 +1100,24 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
               <FormattedMessage id="actions.regenerate_sprites" />
             </Dropdown.Item>
           )}
+          {scene.files.length > 0 && (
+            <Dropdown.Item
+              key="capture-gallery"
+              className="bg-secondary text-white d-flex align-items-center"
+              onClick={() => setShowCaptureGalleryModal(true)}
+            >
+              <Icon icon={faImages} className="mr-2" />
+              <FormattedMessage id="actions.capture_gallery" />
+            </Dropdown.Item>
+          )}
+          <Dropdown.Item
+            key="share"
+            className="bg-secondary text-white d-flex align-items-center"
+            onClick={() => setShowShareLinksDialog(true)}
+          >
+            <Icon icon={faShareAlt} className="mr-2" />
+            <FormattedMessage id="actions.share" />
+          </Dropdown.Item>
           {hasConversionOptions && (
             <Dropdown.Divider style={{ borderTopColor: "#52616d" }} />
           )}
@@ -1073,6 +1189,36 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
                 </span>
               </OverlayTrigger>
             )}
+          {scene.files.length > 0 && (
+            <Dropdown.Item
+              key="transform-video"
+              className="bg-secondary text-white d-flex align-items-center"
+              onClick={() => setShowTransformVideoModal(true)}
+            >
+              <Icon icon={faSyncAlt} className="mr-2" />
+              <FormattedMessage id="actions.transform_video" />
+            </Dropdown.Item>
+          )}
+          {scene.files.length > 0 && (
+            <Dropdown.Item
+              key="remediate-vfr"
+              className="bg-secondary text-white d-flex align-items-center"
+              onClick={() => onRemediateVFR()}
+            >
+              <Icon icon={faVideo} className="mr-2" />
+              <FormattedMessage id="actions.remediate_vfr" />
+            </Dropdown.Item>
+          )}
+          {scene.files.length > 0 && (
+            <Dropdown.Item
+              key="manage-audio-tracks"
+              className="bg-secondary text-white d-flex align-items-center"
+              onClick={() => setShowAudioTracksModal(true)}
+            >
+              <Icon icon={faVolumeUp} className="mr-2" />
+              <FormattedMessage id="actions.manage_audio_tracks" />
+            </Dropdown.Item>
+          )}
           {boxes.length > 0 && (
             <Dropdown.Divider style={{ borderTopColor: "#52616d" }} />
           )}
@@ -1309,7 +1455,11 @@ const ScenePage: React.FC<IProps> = PatchComponent("ScenePage", (props) => {
       {maybeRenderMergeFromDialog()}
       {maybeRenderReduceResolutionDialog()}
       {maybeRenderTrimVideoDialog()}
+      {maybeRenderTransformVideoDialog()}
+      {maybeRenderAudioTracksDialog()}
       {maybeRenderRegenerateSpritesDialog()}
+      {maybeRenderCaptureGalleryDialog()}
+      {maybeRenderShareLinksDialog()}
       {maybeRenderConvertToMP4ConfirmDialog()}
       {maybeRenderConvertHLSToMP4ConfirmDialog()}
       <div
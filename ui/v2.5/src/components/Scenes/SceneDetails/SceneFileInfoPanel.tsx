@@ -171,6 +171,15 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = ({
           value={file.audio_codec ?? ""}
           truncate
         />
+        <TextField
+          id="media_info.audio_languages"
+          value={file.audio_languages.join(", ")}
+          truncate
+        />
+        <TextField
+          id="media_info.interlaced"
+          value={intl.formatMessage({ id: file.interlaced ? "true" : "false" })}
+        />
         <TextField id="threats_checked" name="Threats checked">
           {file.threats_scanned_at ? (
             <FormattedTime
@@ -27,6 +27,24 @@ function getMatrixValue(value: number, range: SliderRange) {
   return (value - range.default) / range.divider;
 }
 
+function joinArgs(args: string[] | undefined) {
+  return args?.join(" ") ?? "";
+}
+
+function splitArgs(value: string) {
+  return value.split(/\s+/).filter((v) => v !== "");
+}
+
+function deinterlaceToString(v: boolean | null) {
+  if (v === null) return "auto";
+  return v ? "always" : "never";
+}
+
+function deinterlaceFromString(v: string) {
+  if (v === "auto") return null;
+  return v === "always";
+}
+
 interface ISliderProps {
   title: string;
   className?: string;
@@ -188,6 +206,15 @@ export const SceneVideoFilterPanel: React.FC<ISceneVideoFilterPanelProps> = (
   const [forceHLSValue, setForceHLSValue] = useState(
     () => props.scene.force_hls ?? false
   );
+  const [transcodeInputArgs, setTranscodeInputArgs] = useState(() =>
+    joinArgs(props.scene.transcode_args?.input_args)
+  );
+  const [transcodeOutputArgs, setTranscodeOutputArgs] = useState(() =>
+    joinArgs(props.scene.transcode_args?.output_args)
+  );
+  const [deinterlaceValue, setDeinterlaceValue] = useState(
+    () => props.scene.deinterlace ?? null
+  );
 
   // Apply filters and transforms when values change
   useEffect(() => {
@@ -262,12 +289,17 @@ export const SceneVideoFilterPanel: React.FC<ISceneVideoFilterPanelProps> = (
     setAudioPlaybackSpeedValue(props.scene.audio_playback_speed ?? 1.0);
     setAudioPlaybackSpeedText(String(props.scene.audio_playback_speed ?? 1.0));
     setForceHLSValue(props.scene.force_hls ?? false);
+    setTranscodeInputArgs(joinArgs(props.scene.transcode_args?.input_args));
+    setTranscodeOutputArgs(joinArgs(props.scene.transcode_args?.output_args));
+    setDeinterlaceValue(props.scene.deinterlace ?? null);
     // eslint-disable-next-line react-hooks/exhaustive-deps
   }, [
     props.scene.id,
     props.scene.audio_offset_ms,
     props.scene.audio_playback_speed,
     props.scene.force_hls,
+    props.scene.transcode_args,
+    props.scene.deinterlace,
   ]);
 
   // Sync text and numeric values
@@ -705,6 +737,11 @@ export const SceneVideoFilterPanel: React.FC<ISceneVideoFilterPanelProps> = (
           audio_offset_ms: audioOffsetValue,
           audio_playback_speed: audioPlaybackSpeedValue,
           force_hls: forceHLSValue,
+          transcode_args: {
+            input_args: splitArgs(transcodeInputArgs),
+            output_args: splitArgs(transcodeOutputArgs),
+          },
+          deinterlace: deinterlaceValue,
         },
       },
     });
@@ -1058,6 +1095,63 @@ export const SceneVideoFilterPanel: React.FC<ISceneVideoFilterPanelProps> = (
           </small>
         </span>
       </div>
+      <div className="row form-group">
+        <span className="col-sm-3">
+          <FormattedMessage id="scene_gen.deinterlace" />
+        </span>
+        <span className="col-sm-9">
+          <Form.Control
+            as="select"
+            className="input-control"
+            value={deinterlaceToString(deinterlaceValue)}
+            onChange={(e: React.ChangeEvent<HTMLSelectElement>) => {
+              setDeinterlaceValue(deinterlaceFromString(e.target.value));
+            }}
+          >
+            {["auto", "always", "never"].map((v) => (
+              <option key={v} value={v}>
+                {intl.formatMessage({ id: `scene_gen.deinterlace_${v}` })}
+              </option>
+            ))}
+          </Form.Control>
+          <small className="text-muted">
+            <FormattedMessage id="scene_gen.deinterlace_tooltip" />
+          </small>
+        </span>
+      </div>
+      <div className="row form-group">
+        <span className="col-sm-3">
+          <FormattedMessage id="scene_gen.transcode_input_args" />
+        </span>
+        <span className="col-sm-9">
+          <Form.Control
+            className="text-input"
+            type="text"
+            value={transcodeInputArgs}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) => {
+              setTranscodeInputArgs(e.target.value);
+            }}
+          />
+        </span>
+      </div>
+      <div className="row form-group">
+        <span className="col-sm-3">
+          <FormattedMessage id="scene_gen.transcode_output_args" />
+        </span>
+        <span className="col-sm-9">
+          <Form.Control
+            className="text-input"
+            type="text"
+            value={transcodeOutputArgs}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) => {
+              setTranscodeOutputArgs(e.target.value);
+            }}
+          />
+          <small className="text-muted">
+            <FormattedMessage id="scene_gen.transcode_args_tooltip" />
+          </small>
+        </span>
+      </div>
 
       <div className="row form-group">
         <span className="col-12">
@@ -0,0 +1,227 @@
+import React, { useState } from "react";
+import { Form, Alert, Row, Col } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import { ModalComponent } from "src/components/Shared/Modal";
+import { useToast } from "src/hooks/Toast";
+import * as GQL from "src/core/generated-graphql";
+import { useSceneTransformVideo } from "src/core/StashService";
+import { faSyncAlt } from "@fortawesome/free-solid-svg-icons";
+
+interface ITransformVideoModalProps {
+  scene: GQL.SceneDataFragment;
+  onClose: () => void;
+}
+
+const rotations = [0, 90, 180, 270];
+
+export const TransformVideoModal: React.FC<ITransformVideoModalProps> = ({
+  scene,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [transformVideo] = useSceneTransformVideo();
+
+  const [selectedFileId, setSelectedFileId] = useState<string>(
+    scene.files.length > 0 ? scene.files[0].id : ""
+  );
+  const [rotate, setRotate] = useState(0);
+  const [flipHorizontal, setFlipHorizontal] = useState(false);
+  const [flipVertical, setFlipVertical] = useState(false);
+  const [crop, setCrop] = useState(false);
+  const [cropX, setCropX] = useState(0);
+  const [cropY, setCropY] = useState(0);
+  const [cropWidth, setCropWidth] = useState(0);
+  const [cropHeight, setCropHeight] = useState(0);
+  const [isProcessing, setIsProcessing] = useState(false);
+
+  const selectedFile = scene.files.find((f) => f.id === selectedFileId);
+
+  function onSelectFile(id: string) {
+    setSelectedFileId(id);
+    const f = scene.files.find((sf) => sf.id === id);
+    setCropX(0);
+    setCropY(0);
+    setCropWidth(f?.width ?? 0);
+    setCropHeight(f?.height ?? 0);
+  }
+
+  function onToggleCrop(v: boolean) {
+    setCrop(v);
+    if (v && cropWidth === 0 && cropHeight === 0) {
+      setCropWidth(selectedFile?.width ?? 0);
+      setCropHeight(selectedFile?.height ?? 0);
+    }
+  }
+
+  const unchanged = rotate === 0 && !flipHorizontal && !flipVertical && !crop;
+
+  async function onTransform() {
+    setIsProcessing(true);
+    try {
+      const result = await transformVideo({
+        variables: {
+          input: {
+            scene_id: scene.id,
+            file_id: selectedFileId,
+            rotate,
+            flip_horizontal: flipHorizontal,
+            flip_vertical: flipVertical,
+            crop: crop
+              ? { x: cropX, y: cropY, width: cropWidth, height: cropHeight }
+              : undefined,
+          },
+        },
+      });
+
+      if (result.data?.sceneTransformVideo) {
+        Toast.success(
+          intl.formatMessage(
+            { id: "actions.transform_video_started" },
+            { jobId: result.data.sceneTransformVideo }
+          )
+        );
+        onClose();
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setIsProcessing(false);
+    }
+  }
+
+  function renderNumberInput(
+    id: string,
+    value: number,
+    setValue: (v: number) => void
+  ) {
+    return (
+      <Form.Group controlId={id} as={Row}>
+        <Form.Label column sm={3}>
+          <FormattedMessage id={`dialogs.transform_video.${id}`} />
+        </Form.Label>
+        <Col sm={9}>
+          <Form.Control
+            className="text-input"
+            type="number"
+            min={0}
+            value={value}
+            onChange={(e) =>
+              setValue(Number.parseInt(e.target.value, 10) || 0)
+            }
+          />
+        </Col>
+      </Form.Group>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      icon={faSyncAlt}
+      header={intl.formatMessage({ id: "dialogs.transform_video.title" })}
+      accept={{
+        variant: "danger",
+        onClick: onTransform,
+        text: intl.formatMessage({ id: "actions.transform_video" }),
+      }}
+      disabled={unchanged}
+      cancel={{
+        onClick: onClose,
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+      }}
+      isRunning={isProcessing}
+    >
+      <Form>
+        <Alert variant="warning">
+          <FormattedMessage id="dialogs.transform_video.warning" />
+        </Alert>
+
+        {scene.files.length > 1 && (
+          <Form.Group controlId="file-select" as={Row}>
+            <Form.Label column sm={3}>
+              <FormattedMessage id="file" />
+            </Form.Label>
+            <Col sm={9}>
+              <Form.Control
+                as="select"
+                value={selectedFileId}
+                onChange={(e) => onSelectFile(e.target.value)}
+                className="input-control"
+              >
+                {scene.files.map((file) => (
+                  <option key={file.id} value={file.id}>
+                    {file.path ? file.path.split("/").pop() : "Unknown file"} (
+                    {file.width}x{file.height})
+                  </option>
+                ))}
+              </Form.Control>
+            </Col>
+          </Form.Group>
+        )}
+
+        <Form.Group controlId="rotate" as={Row}>
+          <Form.Label column sm={3}>
+            <FormattedMessage id="dialogs.transform_video.rotate" />
+          </Form.Label>
+          <Col sm={9}>
+            <Form.Control
+              as="select"
+              value={rotate}
+              onChange={(e) => setRotate(Number.parseInt(e.target.value, 10))}
+              className="input-control"
+            >
+              {rotations.map((r) => (
+                <option key={r} value={r}>
+                  {r}°
+                </option>
+              ))}
+            </Form.Control>
+          </Col>
+        </Form.Group>
+
+        <Form.Group controlId="flip-horizontal">
+          <Form.Check
+            checked={flipHorizontal}
+            label={intl.formatMessage({
+              id: "dialogs.transform_video.flip_horizontal",
+            })}
+            onChange={() => setFlipHorizontal(!flipHorizontal)}
+          />
+        </Form.Group>
+
+        <Form.Group controlId="flip-vertical">
+          <Form.Check
+            checked={flipVertical}
+            label={intl.formatMessage({
+              id: "dialogs.transform_video.flip_vertical",
+            })}
+            onChange={() => setFlipVertical(!flipVertical)}
+          />
+        </Form.Group>
+
+        <Form.Group controlId="crop">
+          <Form.Check
+            checked={crop}
+            label={intl.formatMessage({ id: "dialogs.transform_video.crop" })}
+            onChange={() => onToggleCrop(!crop)}
+          />
+        </Form.Group>
+
+        {crop && (
+          <>
+            {renderNumberInput("crop_x", cropX, setCropX)}
+            {renderNumberInput("crop_y", cropY, setCropY)}
+            {renderNumberInput("crop_width", cropWidth, setCropWidth)}
+            {renderNumberInput("crop_height", cropHeight, setCropHeight)}
+          </>
+        )}
+
+        <Alert variant="info">
+          <FormattedMessage id="dialogs.transform_video.info" />
+        </Alert>
+      </Form>
+    </ModalComponent>
+  );
+};
@@ -49,6 +49,7 @@ type MergeOptions = {
   values: GQL.SceneUpdateInput;
   includeViewHistory: boolean;
   includeOHistory: boolean;
+  includeOMGHistory: boolean;
 };
 
 interface ISceneMergeDetailsProps {
@@ -86,6 +87,9 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
   const [oCounter, setOCounter] = useState(
     new ScrapeResult<number>(dest.o_counter)
   );
+  const [omgCounter, setOMGCounter] = useState(
+    new ScrapeResult<number>(dest.omgCounter)
+  );
   const [playCount, setPlayCount] = useState(
     new ScrapeResult<number>(dest.play_count)
   );
@@ -281,6 +285,13 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
       )
     );
 
+    setOMGCounter(
+      new ScrapeResult(
+        dest.omgCounter ?? 0,
+        all.map((s) => s.omgCounter ?? 0).reduce((pv, cv) => pv + cv, 0)
+      )
+    );
+
     setPlayCount(
       new ScrapeResult(
         dest.play_count ?? 0,
@@ -328,6 +339,7 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
       date,
       rating,
       oCounter,
+      omgCounter,
       galleries,
       studio,
       performers,
@@ -345,6 +357,7 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
     date,
     rating,
     oCounter,
+    omgCounter,
     galleries,
     studio,
     performers,
@@ -431,6 +444,27 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
           )}
           onChange={(value) => setOCounter(value)}
         />
+        <ScrapeDialogRow
+          title={intl.formatMessage({ id: "omg_counter" })}
+          result={omgCounter}
+          renderOriginalField={() => (
+            <FormControl
+              value={omgCounter.originalValue ?? 0}
+              readOnly
+              onChange={() => {}}
+              className="bg-secondary text-white border-secondary"
+            />
+          )}
+          renderNewField={() => (
+            <FormControl
+              value={omgCounter.newValue ?? 0}
+              readOnly
+              onChange={() => {}}
+              className="bg-secondary text-white border-secondary"
+            />
+          )}
+          onChange={(value) => setOMGCounter(value)}
+        />
         <ScrapeDialogRow
           title={intl.formatMessage({ id: "play_count" })}
           result={playCount}
@@ -605,6 +639,7 @@ const SceneMergeDetails: React.FC<ISceneMergeDetailsProps> = ({
       },
       includeViewHistory: playCount.getNewValue() !== undefined,
       includeOHistory: oCounter.getNewValue() !== undefined,
+      includeOMGHistory: omgCounter.getNewValue() !== undefined,
     };
   }
 
@@ -704,7 +739,8 @@ export const SceneMergeModal: React.FC<ISceneMergeModalProps> = ({
   }
 
   async function onMerge(options: MergeOptions) {
-    const { values, includeViewHistory, includeOHistory } = options;
+    const { values, includeViewHistory, includeOHistory, includeOMGHistory } =
+      options;
     try {
       setRunning(true);
       const result = await mutateSceneMerge(
@@ -712,7 +748,8 @@ export const SceneMergeModal: React.FC<ISceneMergeModalProps> = ({
         sourceScenes.map((s) => s.id),
         values,
         includeViewHistory,
-        includeOHistory
+        includeOHistory,
+        includeOMGHistory
       );
       if (result.data?.sceneMerge) {
         Toast.success(intl.formatMessage({ id: "toast.merged_scenes" }));
@@ -162,12 +162,13 @@ const SceneWall: React.FC<ISceneWallProps> = ({
   const photos: PhotoProps<IScenePhoto>[] = useMemo(() => {
     return scenes.map((s, index) => {
       const { width, height } = getDimensions(s);
+      const preview = s.paths.wall_preview ?? s.paths.preview;
 
       return {
         scene: s,
         src:
-          s.paths.preview && !erroredImgs.includes(s.paths.preview)
-            ? s.paths.preview!
+          preview && !erroredImgs.includes(preview)
+            ? preview
             : s.paths.screenshot!,
         link: sceneQueue
           ? sceneQueue.makeLink(s.id, { sceneIndex: index })
@@ -0,0 +1,240 @@
+import React, { useState } from "react";
+import { Button, Form, Table } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import {
+  useExportConfiguration,
+  useImportConfiguration,
+} from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+import downloadFile from "src/utils/download";
+import { ModalComponent } from "../Shared/Modal";
+import { Setting } from "./Inputs";
+import { SettingSection } from "./SettingSection";
+
+function formatValue(v: unknown) {
+  if (v === null || v === undefined) return "";
+  if (typeof v === "string") return v;
+  return JSON.stringify(v);
+}
+
+interface IImportModalProps {
+  onClose: () => void;
+}
+
+const ImportConfigurationModal: React.FC<IImportModalProps> = ({
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [importConfiguration] = useImportConfiguration();
+
+  const [bundle, setBundle] = useState("");
+  const [changes, setChanges] = useState<GQL.ConfigChange[]>();
+  const [loading, setLoading] = useState(false);
+
+  async function onFileChange(e: React.ChangeEvent<HTMLInputElement>) {
+    const file = e.target.files?.[0];
+    if (!file) return;
+
+    setBundle(await file.text());
+    setChanges(undefined);
+  }
+
+  async function onImport(dryRun: boolean) {
+    setLoading(true);
+    try {
+      const result = await importConfiguration({
+        variables: { input: { bundle, dryRun } },
+      });
+      const ret = result.data?.importConfiguration;
+      if (!ret) return;
+
+      if (!ret.applied) {
+        setChanges(ret.changes);
+        return;
+      }
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tools.configuration_bundle.imported" },
+          { count: ret.changes.length }
+        )
+      );
+      onClose();
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setLoading(false);
+    }
+  }
+
+  function renderChanges() {
+    if (!changes) return;
+
+    if (changes.length === 0) {
+      return (
+        <p>
+          <FormattedMessage id="config.tools.configuration_bundle.no_changes" />
+        </p>
+      );
+    }
+
+    return (
+      <Table size="sm" className="configuration-bundle-changes">
+        <thead>
+          <tr>
+            <th>
+              <FormattedMessage
+                id="config.tools.configuration_bundle.setting"
+              />
+            </th>
+            <th>
+              <FormattedMessage
+                id="config.tools.configuration_bundle.current"
+              />
+            </th>
+            <th>
+              <FormattedMessage id="config.tools.configuration_bundle.new" />
+            </th>
+          </tr>
+        </thead>
+        <tbody>
+          {changes.map((c) => (
+            <tr key={c.key}>
+              <td>
+                <code>{c.key}</code>
+              </td>
+              <td>{formatValue(c.oldValue)}</td>
+              <td>{formatValue(c.newValue)}</td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+    );
+  }
+
+  const previewed = changes !== undefined;
+
+  return (
+    <ModalComponent
+      show
+      modalProps={{ size: "lg" }}
+      header={intl.formatMessage({
+        id: "config.tools.configuration_bundle.import",
+      })}
+      accept={{
+        text: intl.formatMessage({
+          id: previewed ? "actions.apply" : "actions.preview",
+        }),
+        onClick: () => onImport(!previewed),
+      }}
+      disabled={!bundle || (previewed && changes.length === 0)}
+      cancel={{
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+        onClick: onClose,
+      }}
+      isRunning={loading}
+    >
+      <Form.Group>
+        <Form.File
+          accept=".yml,.yaml,.json"
+          onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+            onFileChange(e)
+          }
+        />
+      </Form.Group>
+      <Form.Group>
+        <Form.Control
+          as="textarea"
+          className="text-input"
+          rows={8}
+          value={bundle}
+          onChange={(e) => {
+            setBundle(e.currentTarget.value);
+            setChanges(undefined);
+          }}
+        />
+      </Form.Group>
+      {renderChanges()}
+    </ModalComponent>
+  );
+};
+
+export const ConfigurationBundle: React.FC = () => {
+  const Toast = useToast();
+  const [exportConfiguration] = useExportConfiguration();
+
+  const [format, setFormat] = useState(GQL.ConfigBundleFormat.Yaml);
+  const [includePaths, setIncludePaths] = useState(false);
+  const [showImport, setShowImport] = useState(false);
+
+  async function onExport() {
+    try {
+      const result = await exportConfiguration({
+        variables: { input: { format, includePaths } },
+      });
+      const data = result.data?.exportConfiguration;
+      if (data === undefined) return;
+
+      const json = format === GQL.ConfigBundleFormat.Json;
+      const blob = new Blob([data], {
+        type: json ? "application/json" : "application/yaml",
+      });
+      const url = URL.createObjectURL(blob);
+      downloadFile(url, json ? "stash-config.json" : "stash-config.yml");
+      URL.revokeObjectURL(url);
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  return (
+    <SettingSection headingID="config.tools.configuration_bundle.heading">
+      {showImport && (
+        <ImportConfigurationModal onClose={() => setShowImport(false)} />
+      )}
+      <Setting
+        headingID="config.tools.configuration_bundle.export"
+        subHeadingID="config.tools.configuration_bundle.export_desc"
+      >
+        <Form.Check
+          id="configuration-bundle-include-paths"
+          checked={includePaths}
+          label={
+            <FormattedMessage
+              id="config.tools.configuration_bundle.include_paths"
+            />
+          }
+          onChange={() => setIncludePaths(!includePaths)}
+        />
+        <Form.Control
+          as="select"
+          className="input-control"
+          value={format}
+          onChange={(e) =>
+            setFormat(e.currentTarget.value as GQL.ConfigBundleFormat)
+          }
+        >
+          {Object.values(GQL.ConfigBundleFormat).map((f) => (
+            <option key={f} value={f}>
+              {f}
+            </option>
+          ))}
+        </Form.Control>
+        <Button variant="secondary" onClick={() => onExport()}>
+          <FormattedMessage id="config.tools.configuration_bundle.export" />
+        </Button>
+      </Setting>
+      <Setting
+        headingID="config.tools.configuration_bundle.import"
+        subHeadingID="config.tools.configuration_bundle.import_desc"
+      >
+        <Button variant="secondary" onClick={() => setShowImport(true)}>
+          <FormattedMessage id="config.tools.configuration_bundle.import" />
+        </Button>
+      </Setting>
+    </SettingSection>
+  );
+};
@@ -0,0 +1,79 @@
+import React from "react";
+import { Badge, Button, Table } from "react-bootstrap";
+import { FormattedMessage } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import { useDiagnosticsLazyQuery } from "src/core/StashService";
+import { LoadingIndicator } from "../Shared/LoadingIndicator";
+import { Setting } from "./Inputs";
+import { SettingSection } from "./SettingSection";
+
+function statusVariant(status: GQL.DiagnosticStatus) {
+  switch (status) {
+    case GQL.DiagnosticStatus.Pass:
+      return "success";
+    case GQL.DiagnosticStatus.Warn:
+      return "warning";
+    case GQL.DiagnosticStatus.Fail:
+      return "danger";
+    default:
+      return "secondary";
+  }
+}
+
+const DiagnosticStatusBadge: React.FC<{ status: GQL.DiagnosticStatus }> = ({
+  status,
+}) => <Badge variant={statusVariant(status)}>{status}</Badge>;
+
+export const Diagnostics: React.FC = () => {
+  const [runDiagnostics, { data, loading, error }] = useDiagnosticsLazyQuery();
+
+  function renderReport() {
+    if (loading) return <LoadingIndicator inline small />;
+    if (error) return <h5 className="text-danger">{error.message}</h5>;
+
+    const report = data?.diagnostics;
+    if (!report) return;
+
+    return (
+      <div className="diagnostics-report">
+        <p>
+          <FormattedMessage id="config.tools.diagnostics.overall_status" />{" "}
+          <DiagnosticStatusBadge status={report.status} />
+        </p>
+        <Table size="sm">
+          <tbody>
+            {report.checks.map((check) => (
+              <tr key={check.name}>
+                <td>
+                  <DiagnosticStatusBadge status={check.status} />
+                </td>
+                <td>
+                  <code>{check.name}</code>
+                </td>
+                <td>{check.message}</td>
+              </tr>
+            ))}
+          </tbody>
+        </Table>
+      </div>
+    );
+  }
+
+  return (
+    <SettingSection headingID="config.tools.diagnostics.heading">
+      <Setting
+        headingID="config.tools.diagnostics.heading"
+        subHeadingID="config.tools.diagnostics.description"
+      >
+        <Button
+          variant="secondary"
+          disabled={loading}
+          onClick={() => runDiagnostics()}
+        >
+          <FormattedMessage id="config.tools.diagnostics.run" />
+        </Button>
+      </Setting>
+      {renderReport()}
+    </SettingSection>
+  );
+};
@@ -0,0 +1,215 @@
+import React, { useState } from "react";
+import { Button, Form, Row, Col } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import { faLayerGroup } from "@fortawesome/free-solid-svg-icons";
+import * as GQL from "src/core/generated-graphql";
+import {
+  useDeleteLibraryProfile,
+  useSaveLibraryProfile,
+  useSwitchLibraryProfile,
+} from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+import { ModalComponent } from "../Shared/Modal";
+import { LoadingIndicator } from "../Shared/LoadingIndicator";
+import { SettingSection } from "./SettingSection";
+
+interface ILibraryProfileModalProps {
+  onClose: () => void;
+}
+
+const LibraryProfileModal: React.FC<ILibraryProfileModalProps> = ({
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [saveProfile] = useSaveLibraryProfile();
+
+  const [name, setName] = useState("");
+  const [database, setDatabase] = useState("");
+  const [generated, setGenerated] = useState("");
+  const [blobs, setBlobs] = useState("");
+  const [stashes, setStashes] = useState("");
+  const [saving, setSaving] = useState(false);
+
+  async function onSave() {
+    setSaving(true);
+    try {
+      await saveProfile({
+        variables: {
+          input: {
+            name,
+            database,
+            generated,
+            blobs: blobs || undefined,
+            stashes: stashes
+              .split("\n")
+              .map((s) => s.trim())
+              .filter((s) => s)
+              .map((path) => ({
+                path,
+                excludeVideo: false,
+                excludeImage: false,
+                readOnly: false,
+              })),
+          },
+        },
+      });
+      onClose();
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setSaving(false);
+    }
+  }
+
+  function renderInput(
+    id: string,
+    value: string,
+    setValue: (v: string) => void
+  ) {
+    return (
+      <Form.Group controlId={`library-profile-${id}`} as={Row}>
+        <Form.Label column sm={3}>
+          <FormattedMessage id={`config.library.profiles.${id}`} />
+        </Form.Label>
+        <Col sm={9}>
+          <Form.Control
+            className="text-input"
+            value={value}
+            onChange={(e) => setValue(e.currentTarget.value)}
+          />
+        </Col>
+      </Form.Group>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      icon={faLayerGroup}
+      header={intl.formatMessage({ id: "config.library.profiles.add" })}
+      accept={{
+        onClick: onSave,
+        text: intl.formatMessage({ id: "actions.save" }),
+      }}
+      disabled={!name || !database || !generated}
+      cancel={{
+        onClick: onClose,
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+      }}
+      isRunning={saving}
+    >
+      <Form>
+        {renderInput("name", name, setName)}
+        {renderInput("database", database, setDatabase)}
+        {renderInput("generated", generated, setGenerated)}
+        {renderInput("blobs", blobs, setBlobs)}
+        <Form.Group controlId="library-profile-stashes">
+          <Form.Label>
+            <FormattedMessage id="config.library.profiles.stashes" />
+          </Form.Label>
+          <Form.Control
+            as="textarea"
+            className="text-input"
+            rows={3}
+            value={stashes}
+            onChange={(e) => setStashes(e.currentTarget.value)}
+          />
+        </Form.Group>
+      </Form>
+    </ModalComponent>
+  );
+};
+
+export const LibraryProfiles: React.FC = () => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const { data, loading } = GQL.useLibraryProfilesQuery();
+  const [deleteProfile] = useDeleteLibraryProfile();
+  const [switchProfile] = useSwitchLibraryProfile();
+  const [showAdd, setShowAdd] = useState(false);
+  const [switching, setSwitching] = useState<string>();
+
+  async function onSwitch(name: string) {
+    setSwitching(name);
+    try {
+      await switchProfile({ variables: { name } });
+      // all cached data belongs to the previous library
+      window.location.reload();
+    } catch (e) {
+      Toast.error(e);
+      setSwitching(undefined);
+    }
+  }
+
+  async function onDelete(name: string) {
+    try {
+      await deleteProfile({ variables: { name } });
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  if (loading) return <LoadingIndicator />;
+
+  const active = data?.activeLibraryProfile;
+
+  return (
+    <SettingSection
+      id="library-profiles"
+      headingID="config.library.profiles.heading"
+      subHeadingID="config.library.profiles.description"
+    >
+      {showAdd && <LibraryProfileModal onClose={() => setShowAdd(false)} />}
+      {data?.libraryProfiles.map((p) => (
+        <div className="setting" key={p.name}>
+          <div>
+            <h3>
+              {p.name}
+              {p.name === active && (
+                <span className="ml-2 text-muted">
+                  (
+                  {intl.formatMessage({
+                    id: "config.library.profiles.active",
+                  })}
+                  )
+                </span>
+              )}
+            </h3>
+            <div className="sub-heading">
+              {p.database}
+              <br />
+              {p.stashes.map((s) => s.path).join(", ")}
+            </div>
+          </div>
+          {p.name !== active && (
+            <div>
+              <Button
+                variant="secondary"
+                disabled={switching !== undefined}
+                onClick={() => onSwitch(p.name)}
+              >
+                {intl.formatMessage({ id: "config.library.profiles.switch" })}
+              </Button>
+              <Button
+                variant="danger"
+                className="ml-2"
+                disabled={switching !== undefined}
+                onClick={() => onDelete(p.name)}
+              >
+                {intl.formatMessage({ id: "actions.delete" })}
+              </Button>
+            </div>
+          )}
+        </div>
+      ))}
+      <div className="setting">
+        <div />
+        <Button variant="secondary" onClick={() => setShowAdd(true)}>
+          {intl.formatMessage({ id: "config.library.profiles.add" })}
+        </Button>
+      </div>
+    </SettingSection>
+  );
+};
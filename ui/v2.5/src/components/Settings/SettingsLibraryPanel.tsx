@@ -2,6 +2,7 @@ import React from "react";
 import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
 import { StashSetting } from "./StashConfiguration";
+import { LibraryProfiles } from "./LibraryProfiles";
 import { SettingSection } from "./SettingSection";
 import { BooleanSetting, StringListSetting, StringSetting } from "./Inputs";
 import { useSettings } from "./context";
@@ -36,6 +37,8 @@ export const SettingsLibraryPanel: React.FC = () => {
         onChange={(v) => saveGeneral({ stashes: v })}
       />
 
+      <LibraryProfiles />
+
       <SettingSection headingID="config.library.media_content_extensions">
         <StringSetting
           id="video-extensions"
@@ -158,6 +161,13 @@ export const SettingsLibraryPanel: React.FC = () => {
             saveDefaults({ deleteGenerated: v });
           }}
         />
+        <BooleanSetting
+          id="delete-confirmation"
+          headingID="config.ui.delete_options.options.delete_confirmation.heading"
+          subHeadingID="config.ui.delete_options.options.delete_confirmation.description"
+          checked={general.deleteConfirmation ?? false}
+          onChange={(v) => saveGeneral({ deleteConfirmation: v })}
+        />
       </SettingSection>
     </>
   );
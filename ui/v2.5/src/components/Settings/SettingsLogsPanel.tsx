@@ -1,8 +1,8 @@
 import React, { useEffect, useState } from "react";
 import { useIntl } from "react-intl";
 import * as GQL from "src/core/generated-graphql";
-import { useLoggingSubscribe, queryLogs } from "src/core/StashService";
-import { SelectSetting } from "./Inputs";
+import { useLogsSubscribe } from "src/core/StashService";
+import { SelectSetting, StringSetting } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 import { JobTable } from "./Tasks/JobTable";
 
@@ -70,34 +70,30 @@ class LogEntry {
 const MAX_LOG_ENTRIES = 50000;
 // maximum number of log entries to display
 const MAX_DISPLAY_LOG_ENTRIES = 1000;
+// number of recent log entries to load when subscribing
+const LOG_BACKFILL = 1000;
 const logLevels = ["Trace", "Debug", "Info", "Warning", "Error"];
 
 export const SettingsLogsPanel: React.FC = () => {
   const [entries, setEntries] = useState<LogEntry[]>([]);
-  const { data, error } = useLoggingSubscribe();
   const [logLevel, setLogLevel] = useState<string>("Info");
+  const [subsystem, setSubsystem] = useState("");
+  const { data, error } = useLogsSubscribe({
+    subsystems: subsystem ? [subsystem] : undefined,
+    backfill: LOG_BACKFILL,
+  });
   const intl = useIntl();
 
+  // the subscription is restarted with a new backfill when the subsystem
+  // changes
   useEffect(() => {
-    async function getInitialLogs() {
-      const logQuery = await queryLogs();
-      if (logQuery.error) return;
-
-      const initEntries = logQuery.data.logs.map((e) => new LogEntry(e));
-      if (initEntries.length !== 0) {
-        setEntries((prev) => {
-          return [...prev, ...initEntries].slice(0, MAX_LOG_ENTRIES);
-        });
-      }
-    }
-
-    getInitialLogs();
-  }, []);
+    setEntries([]);
+  }, [subsystem]);
 
   useEffect(() => {
     if (!data) return;
 
-    const newEntries = data.loggingSubscribe.map((e) => new LogEntry(e));
+    const newEntries = data.logs.map((e) => new LogEntry(e));
     newEntries.reverse();
     setEntries((prev) => {
       return [...newEntries, ...prev].slice(0, MAX_LOG_ENTRIES);
@@ -144,6 +140,13 @@ export const SettingsLogsPanel: React.FC = () => {
             </option>
           ))}
         </SelectSetting>
+        <StringSetting
+          id="log-subsystem"
+          headingID="config.logs.subsystem"
+          subHeadingID="config.logs.subsystem_desc"
+          value={subsystem}
+          onChange={(v) => setSubsystem(v.trim())}
+        />
       </SettingSection>
 
       <div className="logs">
@@ -16,12 +16,14 @@ import { ModalComponent } from "../Shared/Modal";
 import { SettingSection } from "./SettingSection";
 import {
   BooleanSetting,
+  Setting,
   StringListSetting,
   StringSetting,
   SelectSetting,
   NumberSetting,
 } from "./Inputs";
 import { useSettings } from "./context";
+import { TagIDSelect } from "../Tags/TagSelect";
 import {
   videoSortOrderIntlMap,
   defaultVideoSort,
@@ -444,6 +446,14 @@ export const SettingsServicesPanel: React.FC = () => {
             onChange={(v) => saveDLNA({ interfaces: v })}
           />
 
+          <StringListSetting
+            id="dlna-bind-addresses"
+            headingID="config.dlna.bind_addresses"
+            subHeadingID="config.dlna.bind_addresses_desc"
+            value={dlna.bindAddresses ?? undefined}
+            onChange={(v) => saveDLNA({ bindAddresses: v })}
+          />
+
           <StringListSetting
             id="dlna-default-ip-whitelist"
             headingID="config.dlna.default_ip_whitelist"
@@ -472,6 +482,46 @@ export const SettingsServicesPanel: React.FC = () => {
             ))}
           </SelectSetting>
         </SettingSection>
+
+        <SettingSection headingID="config.dlna.exclusions">
+          <Setting
+            id="dlna-exclude-tags"
+            headingID="config.dlna.exclude_tags"
+            subHeadingID="config.dlna.exclude_tags_desc"
+          >
+            <TagIDSelect
+              isMulti
+              ids={dlna.excludeTags ?? []}
+              onSelect={(tags) =>
+                saveDLNA({ excludeTags: tags.map((t) => t.id) })
+              }
+            />
+          </Setting>
+
+          <BooleanSetting
+            id="dlna-exclude-unorganized"
+            headingID="config.dlna.exclude_unorganized"
+            subHeadingID="config.dlna.exclude_unorganized_desc"
+            checked={dlna.excludeUnorganized ?? undefined}
+            onChange={(v) => saveDLNA({ excludeUnorganized: v })}
+          />
+
+          <BooleanSetting
+            id="dlna-exclude-broken"
+            headingID="config.dlna.exclude_broken"
+            subHeadingID="config.dlna.exclude_broken_desc"
+            checked={dlna.excludeBroken ?? undefined}
+            onChange={(v) => saveDLNA({ excludeBroken: v })}
+          />
+
+          <BooleanSetting
+            id="dlna-exclude-quarantined"
+            headingID="config.dlna.exclude_quarantined"
+            subHeadingID="config.dlna.exclude_quarantined_desc"
+            checked={dlna.excludeQuarantined ?? undefined}
+            onChange={(v) => saveDLNA({ excludeQuarantined: v })}
+          />
+        </SettingSection>
       </>
     );
   };
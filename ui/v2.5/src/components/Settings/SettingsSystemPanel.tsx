@@ -28,6 +28,8 @@ export const SettingsConfigurationPanel: React.FC = () => {
 
   const { general, loading, error, saveGeneral } = useSettings();
   const [mutateDownloadFFMpeg] = GQL.useDownloadFfMpegMutation();
+  const [mutateUpdateFFMpeg] = GQL.useUpdateFfMpegMutation();
+  const { data: systemStatus } = GQL.useSystemStatusQuery();
 
   const transcodeQualities = [
     GQL.StreamingResolutionEnum.Low,
@@ -124,6 +126,74 @@ export const SettingsConfigurationPanel: React.FC = () => {
     }
   }
 
+  async function onUpdateFFMpeg() {
+    try {
+      const result = await mutateUpdateFFMpeg();
+      if (!result.data?.updateFFMpeg) {
+        Toast.success(
+          intl.formatMessage({
+            id: "config.general.ffmpeg.update_ffmpeg.up_to_date",
+          })
+        );
+        return;
+      }
+
+      // navigate to tasks page to see the progress
+      history.push("/settings?tab=tasks");
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function renderFFMpegStatus() {
+    const status = systemStatus?.systemStatus.ffmpeg;
+    if (!status) {
+      return <FormattedMessage id="config.general.ffmpeg.status.not_found" />;
+    }
+
+    return (
+      <>
+        <div>
+          {status.version ? (
+            <FormattedMessage
+              id="config.general.ffmpeg.status.version"
+              values={{ version: status.version }}
+            />
+          ) : (
+            <FormattedMessage
+              id="config.general.ffmpeg.status.unknown_version"
+            />
+          )}{" "}
+          <code>{status.path}</code>
+        </div>
+        {status.version && !status.versionSupported && (
+          <div className="text-warning">
+            <FormattedMessage
+              id="config.general.ffmpeg.status.unsupported_version"
+              values={{ minimum: status.minimumVersion }}
+            />
+          </div>
+        )}
+        {status.missingFeatures.length > 0 && (
+          <div className="text-warning">
+            <FormattedMessage
+              id="config.general.ffmpeg.status.missing_features"
+              values={{ features: status.missingFeatures.join(", ") }}
+            />
+          </div>
+        )}
+        {status.hardwareEncoders.length > 0 && (
+          <div>
+            <FormattedMessage
+              id="config.general.ffmpeg.status.hardware_encoders"
+              values={{ encoders: status.hardwareEncoders.join(", ") }}
+            />
+          </div>
+        )}
+      </>
+    );
+  }
+
   if (error) return <h1>{error.message}</h1>;
   if (loading) return <LoadingIndicator />;
 
@@ -207,6 +277,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           </Button>
         </Setting>
 
+        <Setting
+          headingID="config.general.ffmpeg.status.heading"
+          subHeading={renderFFMpegStatus()}
+        />
+
+        <Setting
+          headingID="config.general.ffmpeg.update_ffmpeg.heading"
+          subHeadingID="config.general.ffmpeg.update_ffmpeg.description"
+        >
+          <Button variant="secondary" onClick={() => onUpdateFFMpeg()}>
+            <FormattedMessage
+              id="config.general.ffmpeg.update_ffmpeg.heading"
+            />
+          </Button>
+        </Setting>
+
         <StringSetting
           id="python-path"
           headingID="config.general.python_path.heading"
@@ -215,6 +301,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ pythonPath: v })}
         />
 
+        <StringSetting
+          id="rclone-path"
+          headingID="config.general.rclone_path.heading"
+          subHeadingID="config.general.rclone_path.description"
+          value={general.rclonePath ?? undefined}
+          onChange={(v) => saveGeneral({ rclonePath: v })}
+        />
+
+        <StringListSetting
+          id="bind-addresses"
+          headingID="config.general.bind_addresses.heading"
+          subHeadingID="config.general.bind_addresses.description"
+          value={general.bindAddresses ?? undefined}
+          onChange={(v) => saveGeneral({ bindAddresses: v })}
+        />
+
         <StringSetting
           id="backup-directory-path"
           headingID="config.general.backup_directory_path.heading"
@@ -328,6 +430,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ transcodeHardwareAcceleration: v })}
         />
 
+        <SelectSetting
+          id="deinterlace-filter"
+          headingID="config.general.ffmpeg.deinterlace_filter.heading"
+          subHeadingID="config.general.ffmpeg.deinterlace_filter.desc"
+          value={general.deinterlaceFilter ?? GQL.DeinterlaceFilter.Bwdif}
+          onChange={(v) =>
+            saveGeneral({ deinterlaceFilter: v as GQL.DeinterlaceFilter })
+          }
+        >
+          {Object.values(GQL.DeinterlaceFilter).map((f) => (
+            <option key={f} value={f}>
+              {f}
+            </option>
+          ))}
+        </SelectSetting>
+
         <StringListSetting
           advanced
           id="transcode-input-args"
@@ -371,6 +489,23 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.parallelTasks ?? undefined}
           onChange={(v) => saveGeneral({ parallelTasks: v })}
         />
+
+        <NumberSetting
+          advanced
+          id="parallel-io-tasks"
+          headingID="config.general.number_of_parallel_io_tasks_for_scan_head"
+          subHeadingID="config.general.number_of_parallel_io_tasks_for_scan_desc"
+          value={general.parallelIOTasks ?? undefined}
+          onChange={(v) => saveGeneral({ parallelIOTasks: v })}
+        />
+
+        <BooleanSetting
+          id="resume-interrupted-jobs"
+          headingID="config.general.resume_interrupted_jobs.heading"
+          subHeadingID="config.general.resume_interrupted_jobs.description"
+          checked={general.resumeInterruptedJobs ?? undefined}
+          onChange={(v) => saveGeneral({ resumeInterruptedJobs: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.preview_generation">
@@ -419,6 +554,172 @@ export const SettingsConfigurationPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.general.wall_preview_generation">
+        <NumberSetting
+          id="wall-preview-height"
+          headingID="config.general.wall_preview.height.heading"
+          subHeadingID="config.general.wall_preview.height.description"
+          value={general.wallPreviewHeight ?? undefined}
+          min={1}
+          onChange={(v) => saveGeneral({ wallPreviewHeight: v })}
+        />
+
+        <NumberSetting
+          id="wall-preview-crf"
+          headingID="config.general.wall_preview.crf.heading"
+          subHeadingID="config.general.wall_preview.crf.description"
+          value={general.wallPreviewCRF ?? undefined}
+          min={1}
+          max={51}
+          onChange={(v) => saveGeneral({ wallPreviewCRF: v })}
+        />
+
+        <SelectSetting
+          id="wall-preview-preset"
+          headingID="config.general.wall_preview.preset.heading"
+          subHeadingID="config.general.wall_preview.preset.description"
+          value={general.wallPreviewPreset ?? undefined}
+          onChange={(v) =>
+            saveGeneral({
+              wallPreviewPreset: (v as GQL.PreviewPreset) ?? undefined,
+            })
+          }
+        >
+          {Object.keys(GQL.PreviewPreset).map((p) => (
+            <option value={p.toLowerCase()} key={p}>
+              {p}
+            </option>
+          ))}
+        </SelectSetting>
+      </SettingSection>
+
+      <SettingSection headingID="config.general.sprite_generation">
+        <NumberSetting
+          id="sprite-rows"
+          headingID="config.general.sprite.rows.heading"
+          subHeadingID="config.general.sprite.rows.description"
+          value={general.spriteRows ?? undefined}
+          min={1}
+          onChange={(v) => saveGeneral({ spriteRows: v })}
+        />
+
+        <NumberSetting
+          id="sprite-columns"
+          headingID="config.general.sprite.columns.heading"
+          subHeadingID="config.general.sprite.columns.description"
+          value={general.spriteColumns ?? undefined}
+          min={1}
+          onChange={(v) => saveGeneral({ spriteColumns: v })}
+        />
+
+        <NumberSetting
+          id="sprite-interval"
+          headingID="config.general.sprite.interval.heading"
+          subHeadingID="config.general.sprite.interval.description"
+          value={general.spriteInterval ?? undefined}
+          min={0}
+          onChange={(v) => saveGeneral({ spriteInterval: v })}
+        />
+
+        <SelectSetting
+          id="sprite-format"
+          headingID="config.general.sprite.format.heading"
+          subHeadingID="config.general.sprite.format.description"
+          value={general.spriteFormat ?? undefined}
+          onChange={(v) =>
+            saveGeneral({
+              spriteFormat: (v as GQL.SpriteFormat) ?? undefined,
+            })
+          }
+        >
+          {Object.values(GQL.SpriteFormat).map((f) => (
+            <option value={f} key={f}>
+              {f}
+            </option>
+          ))}
+        </SelectSetting>
+
+        <BooleanSetting
+          id="sprite-on-demand"
+          headingID="config.general.sprite.on_demand.heading"
+          subHeadingID="config.general.sprite.on_demand.description"
+          checked={general.spriteOnDemand ?? true}
+          onChange={(v) => saveGeneral({ spriteOnDemand: v })}
+        />
+      </SettingSection>
+
+      <SettingSection headingID="config.general.smart_covers.heading">
+        <BooleanSetting
+          id="smart-covers"
+          headingID="config.general.smart_covers.enabled.heading"
+          subHeadingID="config.general.smart_covers.enabled.description"
+          checked={general.smartCovers ?? false}
+          onChange={(v) => saveGeneral({ smartCovers: v })}
+        />
+
+        <NumberSetting
+          id="smart-cover-candidates"
+          headingID="config.general.smart_covers.candidates.heading"
+          subHeadingID="config.general.smart_covers.candidates.description"
+          value={general.smartCoverCandidates ?? undefined}
+          onChange={(v) => saveGeneral({ smartCoverCandidates: v })}
+          min={1}
+          disabled={!general.smartCovers}
+        />
+
+        <BooleanSetting
+          id="smart-cover-prefer-people"
+          headingID="config.general.smart_covers.prefer_people.heading"
+          subHeadingID="config.general.smart_covers.prefer_people.description"
+          checked={general.smartCoverPreferPeople ?? false}
+          onChange={(v) => saveGeneral({ smartCoverPreferPeople: v })}
+          disabled={!general.smartCovers}
+        />
+      </SettingSection>
+
+      <SettingSection headingID="config.general.ocr.heading">
+        <StringSetting
+          id="ocr-command"
+          headingID="config.general.ocr.command.heading"
+          subHeadingID="config.general.ocr.command.description"
+          value={general.ocrCommand ?? undefined}
+          onChange={(v) => saveGeneral({ ocrCommand: v })}
+        />
+
+        <StringListSetting
+          id="ocr-args"
+          headingID="config.general.ocr.args.heading"
+          subHeadingID="config.general.ocr.args.description"
+          value={general.ocrArgs ?? undefined}
+          onChange={(v) => saveGeneral({ ocrArgs: v })}
+        />
+
+        <StringSetting
+          id="ocr-endpoint"
+          headingID="config.general.ocr.endpoint.heading"
+          subHeadingID="config.general.ocr.endpoint.description"
+          value={general.ocrEndpoint ?? undefined}
+          onChange={(v) => saveGeneral({ ocrEndpoint: v })}
+        />
+
+        <StringSetting
+          id="ocr-api-key"
+          headingID="config.general.ocr.api_key.heading"
+          subHeadingID="config.general.ocr.api_key.description"
+          value={general.ocrApiKey ?? undefined}
+          onChange={(v) => saveGeneral({ ocrApiKey: v })}
+        />
+
+        <NumberSetting
+          id="ocr-frames"
+          headingID="config.general.ocr.frames.heading"
+          subHeadingID="config.general.ocr.frames.description"
+          value={general.ocrFrames ?? undefined}
+          onChange={(v) => saveGeneral({ ocrFrames: v })}
+          min={1}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.general.heatmap_generation">
         <BooleanSetting
           id="heatmap-draw-range"
@@ -427,6 +728,40 @@ export const SettingsConfigurationPanel: React.FC = () => {
           checked={general.drawFunscriptHeatmapRange ?? true}
           onChange={(v) => saveGeneral({ drawFunscriptHeatmapRange: v })}
         />
+
+        <SelectSetting
+          id="heatmap-palette"
+          headingID="config.general.funscript_heatmap_palette"
+          value={general.funscriptHeatmapPalette ?? undefined}
+          onChange={(v) =>
+            saveGeneral({
+              funscriptHeatmapPalette: (v as GQL.HeatmapPalette) ?? undefined,
+            })
+          }
+        >
+          {Object.values(GQL.HeatmapPalette).map((p) => (
+            <option value={p} key={p}>
+              {p}
+            </option>
+          ))}
+        </SelectSetting>
+
+        <NumberSetting
+          id="heatmap-height"
+          headingID="config.general.funscript_heatmap_height"
+          value={general.funscriptHeatmapHeight ?? undefined}
+          min={1}
+          onChange={(v) => saveGeneral({ funscriptHeatmapHeight: v })}
+        />
+
+        <NumberSetting
+          id="heatmap-smoothing"
+          headingID="config.general.funscript_heatmap_smoothing"
+          subHeadingID="config.general.funscript_heatmap_smoothing_desc"
+          value={general.funscriptHeatmapSmoothing ?? undefined}
+          min={1}
+          onChange={(v) => saveGeneral({ funscriptHeatmapSmoothing: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.logging">
@@ -6,8 +6,13 @@ import { Setting } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 import { PatchContainerComponent } from "src/patch";
 import { ExternalLink } from "../Shared/ExternalLink";
-import { useScanAllScenesForThreats } from "src/core/StashService";
+import {
+  mutateMetadataOCR,
+  useScanAllScenesForThreats,
+} from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
+import { Diagnostics } from "./Diagnostics";
+import { ConfigurationBundle } from "./ConfigurationBundle";
 
 const SettingsToolsSection = PatchContainerComponent("SettingsToolsSection");
 
@@ -31,6 +36,24 @@ export const SettingsToolsPanel: React.FC = () => {
     }
   }
 
+  async function onRecognizeSceneText() {
+    try {
+      await mutateMetadataOCR({});
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.recognize_all_scene_text",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   return (
     <>
       <SettingSection headingID="config.tools.heading">
@@ -76,8 +99,19 @@ export const SettingsToolsPanel: React.FC = () => {
               <FormattedMessage id="actions.scan_all_scenes_for_threats" />
             </Button>
           </Setting>
+
+          <Setting
+            headingID="actions.recognize_all_scene_text"
+            subHeadingID="config.tools.recognize_all_scene_text_desc"
+          >
+            <Button variant="secondary" onClick={onRecognizeSceneText}>
+              <FormattedMessage id="actions.recognize_all_scene_text" />
+            </Button>
+          </Setting>
         </SettingsToolsSection>
       </SettingSection>
+      <Diagnostics />
+      <ConfigurationBundle />
     </>
   );
 };
@@ -1,16 +1,19 @@
 import { faEllipsisV } from "@fortawesome/free-solid-svg-icons";
 import React, { useState } from "react";
-import { Button, Form, Row, Col, Dropdown } from "react-bootstrap";
-import { FormattedMessage } from "react-intl";
+import { Badge, Button, Form, Row, Col, Dropdown } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
 import { Icon } from "src/components/Shared/Icon";
 import * as GQL from "src/core/generated-graphql";
+import { useStashStatus } from "src/core/StashService";
 import { FolderSelectDialog } from "../Shared/FolderSelect/FolderSelectDialog";
+import { ModalComponent } from "../Shared/Modal";
 import { BooleanSetting } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 
 interface IStashProps {
   index: number;
   stash: GQL.StashConfig;
+  status?: GQL.StashStatusQuery["stashStatus"][number];
   onSave: (instance: GQL.StashConfig) => void;
   onEdit: () => void;
   onDelete: () => void;
@@ -19,10 +22,13 @@ interface IStashProps {
 const Stash: React.FC<IStashProps> = ({
   index,
   stash,
+  status,
   onSave,
   onEdit,
   onDelete,
 }) => {
+  const intl = useIntl();
+
   // eslint-disable-next-line
   const handleInput = (key: string, value: any) => {
     const newObj = {
@@ -36,8 +42,25 @@ const Stash: React.FC<IStashProps> = ({
 
   return (
     <Row className={`stash-row align-items-center ${classAdd}`}>
-      <Form.Label column md={7}>
+      <Form.Label column md={5}>
         {stash.path}
+        {stash.rclone && (
+          <Badge variant="secondary" className="ml-2">
+            <FormattedMessage id="config.library.rclone.badge" />
+          </Badge>
+        )}
+        {status && !status.online && (
+          <Badge
+            variant="danger"
+            className="ml-2"
+            title={intl.formatMessage(
+              { id: "config.library.stash_offline_desc" },
+              { error: status.error }
+            )}
+          >
+            <FormattedMessage id="config.library.stash_offline" />
+          </Badge>
+        )}
       </Form.Label>
       <Col md={2} xs={4} className="col form-label">
         {/* NOTE - language is opposite to meaning:
@@ -66,6 +89,20 @@ const Stash: React.FC<IStashProps> = ({
           />
         </div>
       </Col>
+
+      <Col md={2} xs={4} className="col-form-label">
+        <div>
+          <h6 className="d-md-none">
+            <FormattedMessage id="read_only" />
+          </h6>
+          <BooleanSetting
+            id={`stash-read-only-${index}`}
+            checked={stash.readOnly || stash.rclone}
+            disabled={stash.rclone}
+            onChange={(v) => handleInput("readOnly", v)}
+          />
+        </div>
+      </Col>
       <Col className="justify-content-end" xs={4} md={1}>
         <Dropdown className="text-right">
           <Dropdown.Toggle
@@ -89,6 +126,48 @@ const Stash: React.FC<IStashProps> = ({
   );
 };
 
+interface IRcloneRemoteDialogProps {
+  defaultValue?: string;
+  onClose: (remote?: string) => void;
+}
+
+const RcloneRemoteDialog: React.FC<IRcloneRemoteDialogProps> = ({
+  defaultValue,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const [remote, setRemote] = useState(defaultValue ?? "");
+
+  return (
+    <ModalComponent
+      show
+      header={intl.formatMessage({ id: "config.library.rclone.add" })}
+      accept={{
+        text: intl.formatMessage({ id: "actions.confirm" }),
+        onClick: () => onClose(remote.trim()),
+      }}
+      disabled={!remote.includes(":")}
+      cancel={{
+        text: intl.formatMessage({ id: "actions.cancel" }),
+        variant: "secondary",
+        onClick: () => onClose(),
+      }}
+    >
+      <Form.Group>
+        <Form.Control
+          className="text-input"
+          placeholder="gdrive:Videos"
+          value={remote}
+          onChange={(e) => setRemote(e.currentTarget.value)}
+        />
+        <Form.Text className="text-muted">
+          <FormattedMessage id="config.library.rclone.remote_desc" />
+        </Form.Text>
+      </Form.Group>
+    </ModalComponent>
+  );
+};
+
 interface IStashConfigurationProps {
   stashes: GQL.StashConfig[];
   setStashes: (v: GQL.StashConfig[]) => void;
@@ -99,6 +178,8 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
   setStashes,
 }) => {
   const [isCreating, setIsCreating] = useState(false);
+  const [isCreatingRemote, setIsCreatingRemote] = useState(false);
+  const { data: statusData } = useStashStatus();
   const [editingIndex, setEditingIndex] = useState<number | undefined>();
 
   function onEdit(index: number) {
@@ -128,6 +209,8 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
                   path: v,
                   excludeVideo: false,
                   excludeImage: false,
+                  readOnly: false,
+                  rclone: false,
                 },
               ]);
             setIsCreating(false);
@@ -135,7 +218,41 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
         />
       ) : undefined}
 
-      {editingIndex !== undefined ? (
+      {isCreatingRemote ? (
+        <RcloneRemoteDialog
+          onClose={(v) => {
+            if (v)
+              setStashes([
+                ...stashes,
+                {
+                  path: v,
+                  excludeVideo: false,
+                  excludeImage: false,
+                  readOnly: true,
+                  rclone: true,
+                },
+              ]);
+            setIsCreatingRemote(false);
+          }}
+        />
+      ) : undefined}
+
+      {editingIndex !== undefined && stashes[editingIndex].rclone ? (
+        <RcloneRemoteDialog
+          defaultValue={stashes[editingIndex].path}
+          onClose={(v) => {
+            if (v)
+              setStashes(
+                stashes.map((vv, index) =>
+                  index === editingIndex ? { ...vv, path: v } : vv
+                )
+              );
+            setEditingIndex(undefined);
+          }}
+        />
+      ) : undefined}
+
+      {editingIndex !== undefined && !stashes[editingIndex].rclone ? (
         <FolderSelectDialog
           defaultValue={stashes[editingIndex].path}
           onClose={(v) => {
@@ -159,7 +276,7 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
       <div className="content" id="stash-table">
         {stashes.length > 0 && (
           <Row className="d-none d-md-flex">
-            <h6 className="col-md-7">
+            <h6 className="col-md-5">
               <FormattedMessage id="path" />
             </h6>
             <h6 className="col-md-2 col-4">
@@ -168,12 +285,16 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
             <h6 className="col-md-2 col-4">
               <FormattedMessage id="images" />
             </h6>
+            <h6 className="col-md-2 col-4">
+              <FormattedMessage id="read_only" />
+            </h6>
           </Row>
         )}
         {stashes.map((stash, index) => (
           <Stash
             index={index}
             stash={stash}
+            status={statusData?.stashStatus.find((s) => s.path === stash.path)}
             onSave={(s) => handleSave(index, s)}
             onEdit={() => onEdit(index)}
             onDelete={() => onDelete(index)}
@@ -183,6 +304,13 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
         <Button className="mt-2" variant="secondary" onClick={() => onNew()}>
           <FormattedMessage id="actions.add_directory" />
         </Button>
+        <Button
+          className="mt-2 ml-2"
+          variant="secondary"
+          onClick={() => setIsCreatingRemote(true)}
+        >
+          <FormattedMessage id="config.library.rclone.add" />
+        </Button>
       </div>
     </>
   );
@@ -200,7 +328,14 @@ export const StashSetting: React.FC<IStashSetting> = ({ value, onChange }) => {
       headingID="library"
       subHeadingID="config.general.directory_locations_to_your_content"
     >
-      <StashConfiguration stashes={value} setStashes={(v) => onChange(v)} />
+      <StashConfiguration
+        stashes={value.map((s) => ({
+          ...s,
+          readOnly: s.readOnly ?? false,
+          rclone: s.rclone ?? false,
+        }))}
+        setStashes={(v) => onChange(v)}
+      />
     </SettingSection>
   );
 };
@@ -56,6 +56,12 @@ const CleanGeneratedOptions: React.FC<{
         subHeadingID="config.tasks.clean_generated.image_thumbnails_desc"
         onChange={(v) => setOptions({ imageThumbnails: v })}
       />
+      <BooleanSetting
+        id="clean-generated-interactive-heatmaps"
+        checked={options.interactiveHeatmaps ?? false}
+        headingID="config.tasks.clean_generated.interactive_heatmaps"
+        onChange={(v) => setOptions({ interactiveHeatmaps: v })}
+      />
       <BooleanSetting
         id="clean-generated-dryrun"
         checked={options.dryRun ?? false}
@@ -76,6 +82,7 @@ export const CleanGeneratedDialog: React.FC<{
   const [options, setOptions] = useState<GQL.CleanGeneratedInput>({
     blobFiles: true,
     imageThumbnails: true,
+    interactiveHeatmaps: true,
     markers: true,
     screenshots: true,
     sprites: true,
@@ -12,7 +12,10 @@ import {
   mutateMigrateBlobs,
   mutateOptimiseDatabase,
   mutateCleanGenerated,
+  mutateCleanBlobs,
   mutateRecalculateSceneSimilarities,
+  mutateRebuildPhashIndex,
+  usePhashIndexStats,
 } from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
 import downloadFile from "src/utils/download";
@@ -177,6 +180,11 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     dryRun: false,
   });
 
+  const [cleanBlobsOptions, setCleanBlobsOptions] =
+    useState<GQL.CleanBlobsInput>({
+      dryRun: false,
+    });
+
   const [migrateBlobsOptions, setMigrateBlobsOptions] =
     useState<GQL.MigrateBlobsInput>({
       deleteOld: true,
@@ -188,6 +196,9 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
       overwriteExisting: false,
     });
 
+  const { data: phashIndexData } = usePhashIndexStats();
+  const phashIndexStats = phashIndexData?.phashIndexStats;
+
   type DialogOpenState = typeof dialogOpen;
 
   function setDialogOpen(s: Partial<DialogOpenState>) {
@@ -277,6 +288,24 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onCleanBlobs() {
+    try {
+      await mutateCleanBlobs(cleanBlobsOptions);
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.clean_blobs",
+            }),
+          }
+        )
+      );
+    } catch (err) {
+      Toast.error(err);
+    }
+  }
+
   async function onMigrateHashNaming() {
     try {
       await mutateMigrateHashNaming();
@@ -382,6 +411,24 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onRebuildPhashIndex() {
+    try {
+      await mutateRebuildPhashIndex();
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.rebuild_phash_index",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onRecalculateSceneSimilarities() {
     try {
       await mutateRecalculateSceneSimilarities();
@@ -508,6 +555,30 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Setting>
         </div>
 
+        <div className="setting-group">
+          <Setting
+            headingID="actions.clean_blobs"
+            subHeadingID="config.tasks.clean_blobs.description"
+          >
+            <Button
+              id="cleanBlobs"
+              variant="danger"
+              type="submit"
+              onClick={() => onCleanBlobs()}
+            >
+              <FormattedMessage id="actions.clean_blobs" />
+            </Button>
+          </Setting>
+          <BooleanSetting
+            id="clean-blobs-dryrun"
+            checked={cleanBlobsOptions.dryRun ?? false}
+            headingID="config.tasks.only_dry_run"
+            onChange={(v) =>
+              setCleanBlobsOptions({ ...cleanBlobsOptions, dryRun: v })
+            }
+          />
+        </div>
+
         <Setting
           headingID="actions.optimise_database"
           subHeading={
@@ -527,6 +598,40 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Button>
         </Setting>
 
+        <Setting
+          headingID="actions.rebuild_phash_index"
+          subHeading={
+            <>
+              <FormattedMessage id="config.tasks.rebuild_phash_index_desc" />
+              {phashIndexStats && (
+                <>
+                  <br />
+                  <FormattedMessage
+                    id={
+                      phashIndexStats.ready
+                        ? "config.tasks.phash_index_stats"
+                        : "config.tasks.phash_index_building"
+                    }
+                    values={{
+                      entries: phashIndexStats.entries,
+                      nodes: phashIndexStats.nodes,
+                      emptyNodes: phashIndexStats.emptyNodes,
+                    }}
+                  />
+                </>
+              )}
+            </>
+          }
+        >
+          <Button
+            id="rebuildPhashIndex"
+            variant="secondary"
+            onClick={() => onRebuildPhashIndex()}
+          >
+            <FormattedMessage id="actions.rebuild_phash_index" />
+          </Button>
+        </Setting>
+
         <Setting
           headingID="actions.recalculate_scene_similarities"
           subHeadingID="config.tasks.recalculate_scene_similarities_desc"
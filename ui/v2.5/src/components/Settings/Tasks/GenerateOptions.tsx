@@ -56,6 +56,15 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             tooltipID="dialogs.scene_gen.image_previews_tooltip"
             onChange={(v) => setOptions({ imagePreviews: v })}
           />
+          <BooleanSetting
+            className="sub-setting"
+            id="wall-preview-task"
+            checked={options.wallPreviews ?? false}
+            disabled={!options.previews}
+            headingID="dialogs.scene_gen.wall_previews"
+            tooltipID="dialogs.scene_gen.wall_previews_tooltip"
+            onChange={(v) => setOptions({ wallPreviews: v })}
+          />
 
           {/* #2251 - only allow preview generation options to be overridden when generating from a selection */}
           {selection ? (
@@ -117,6 +126,14 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             tooltipID="dialogs.scene_gen.marker_screenshots_tooltip"
             onChange={(v) => setOptions({ markerScreenshots: v })}
           />
+          <BooleanSetting
+            id="marker-thumbnail-task"
+            checked={options.markerThumbnails ?? false}
+            disabled={!options.markers}
+            headingID="dialogs.scene_gen.marker_thumbnails"
+            tooltipID="dialogs.scene_gen.marker_thumbnails_tooltip"
+            onChange={(v) => setOptions({ markerThumbnails: v })}
+          />
 
           <BooleanSetting
             advanced
@@ -138,6 +155,16 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
               onChange={(v) => setOptions({ forceTranscodes: v })}
             />
           ) : undefined}
+          <BooleanSetting
+            advanced
+            id="transcode-video-filters"
+            className="sub-setting"
+            checked={options.transcodeVideoFilters ?? false}
+            disabled={!options.transcodes}
+            headingID="dialogs.scene_gen.transcode_video_filters"
+            tooltipID="dialogs.scene_gen.transcode_video_filters_tooltip"
+            onChange={(v) => setOptions({ transcodeVideoFilters: v })}
+          />
 
           <BooleanSetting
             id="phash-task"
@@ -28,6 +28,7 @@ type JobFragment = Pick<
   | "progress"
   | "error"
   | "startTime"
+  | "estimatedEndTime"
 >;
 
 interface IJob {
@@ -133,22 +134,20 @@ const Task: React.FC<IJob> = ({ job }) => {
 
   function maybeRenderETA() {
     if (
-      job.status === GQL.JobStatus.Running &&
-      job.startTime !== null &&
-      job.startTime !== undefined &&
-      job.progress !== null &&
-      job.progress !== undefined &&
-      job.progress > 0
+      (job.status === GQL.JobStatus.Running ||
+        job.status === GQL.JobStatus.Ready) &&
+      job.estimatedEndTime
     ) {
-      const now = new Date();
-      const start = new Date(job.startTime);
-      const nowMS = now.valueOf();
-      const startMS = start.valueOf();
-      const estimatedLength = (nowMS - startMS) / job.progress;
-      const estLenStr = moment.duration(estimatedLength).humanize();
+      // estimated by the server from the progress, or from previous runs
+      // of the same type of job
+      const remaining = Math.max(
+        new Date(job.estimatedEndTime).valueOf() - Date.now(),
+        0
+      );
+      const remainingStr = moment.duration(remaining).humanize();
       return (
         <span className="job-eta">
-          <FormattedMessage id="eta" />: {estLenStr}
+          <FormattedMessage id="eta" />: {remainingStr}
         </span>
       );
     }
@@ -90,6 +90,7 @@ export const LibraryTasks: React.FC = () => {
       scanGeneratePhashes: false,
       scanGenerateThumbnails: false,
       scanGenerateClipPreviews: false,
+      scanGenerateGalleryChapters: false,
     };
   }
 
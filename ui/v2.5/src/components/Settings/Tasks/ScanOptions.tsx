@@ -19,7 +19,9 @@ export const ScanOptions: React.FC<IScanOptions> = ({
     scanGeneratePhashes,
     scanGenerateThumbnails,
     scanGenerateClipPreviews,
+    scanGenerateGalleryChapters,
     rescan,
+    benchmark,
   } = options;
 
   function setOptions(input: Partial<GQL.ScanMetadataInput>) {
@@ -78,6 +80,13 @@ export const ScanOptions: React.FC<IScanOptions> = ({
         headingID="config.tasks.generate_clip_previews_during_scan"
         onChange={(v) => setOptions({ scanGenerateClipPreviews: v })}
       />
+      <BooleanSetting
+        id="scan-generate-gallery-chapters"
+        checked={scanGenerateGalleryChapters ?? false}
+        headingID="config.tasks.generate_gallery_chapters_during_scan"
+        tooltipID="config.tasks.generate_gallery_chapters_during_scan_tooltip"
+        onChange={(v) => setOptions({ scanGenerateGalleryChapters: v })}
+      />
       <BooleanSetting
         id="force-rescan"
         headingID="config.tasks.rescan"
@@ -85,6 +94,14 @@ export const ScanOptions: React.FC<IScanOptions> = ({
         checked={rescan ?? false}
         onChange={(v) => setOptions({ rescan: v })}
       />
+      <BooleanSetting
+        advanced
+        id="scan-benchmark"
+        headingID="config.tasks.scan_benchmark"
+        tooltipID="config.tasks.scan_benchmark_tooltip"
+        checked={benchmark ?? false}
+        onChange={(v) => setOptions({ benchmark: v })}
+      />
     </>
   );
 };
@@ -0,0 +1,19 @@
+import React from "react";
+import { Alert } from "react-bootstrap";
+import { FormattedMessage } from "react-intl";
+import { IDeleteConfirmation } from "src/utils/errors";
+import { FileSize } from "./FileSize";
+
+export const DeleteConfirmationAlert: React.FC<{
+  confirmation: IDeleteConfirmation;
+}> = ({ confirmation }) => (
+  <Alert variant="warning">
+    <FormattedMessage
+      id="dialogs.delete_confirmation_required"
+      values={{
+        count: confirmation.fileCount,
+        size: <FileSize size={confirmation.totalSize} />,
+      }}
+    />
+  </Alert>
+);
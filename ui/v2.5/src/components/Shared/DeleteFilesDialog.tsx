@@ -4,6 +4,8 @@ import { ModalComponent } from "./Modal";
 import { useToast } from "src/hooks/Toast";
 import { FormattedMessage, useIntl } from "react-intl";
 import { faTrashAlt } from "@fortawesome/free-solid-svg-icons";
+import { getDeleteConfirmation, IDeleteConfirmation } from "src/utils/errors";
+import { DeleteConfirmationAlert } from "./DeleteConfirmationAlert";
 
 interface IFile {
   id: string;
@@ -40,11 +42,15 @@ export const DeleteFilesDialog: React.FC<IDeleteSceneDialogProps> = (
 
   // Network state
   const [isDeleting, setIsDeleting] = useState(false);
+  const [confirmation, setConfirmation] = useState<IDeleteConfirmation>();
 
   async function onDelete() {
     setIsDeleting(true);
     try {
-      await mutateDeleteFiles(props.selected.map((f) => f.id));
+      await mutateDeleteFiles(
+        props.selected.map((f) => f.id),
+        confirmation?.token
+      );
 
       // Refetch data to update the UI
       if (props.onRefetch) {
@@ -54,6 +60,12 @@ export const DeleteFilesDialog: React.FC<IDeleteSceneDialogProps> = (
       Toast.success(toastMessage);
       props.onClose(true);
     } catch (e) {
+      const pending = getDeleteConfirmation(e);
+      if (pending) {
+        setConfirmation(pending);
+        setIsDeleting(false);
+        return;
+      }
       Toast.error(e);
       props.onClose(false);
     }
@@ -112,6 +124,7 @@ export const DeleteFilesDialog: React.FC<IDeleteSceneDialogProps> = (
       isRunning={isDeleting}
     >
       <p>{message}</p>
+      {confirmation && <DeleteConfirmationAlert confirmation={confirmation} />}
       {renderDeleteFileAlert()}
     </ModalComponent>
   );
@@ -0,0 +1,221 @@
+import React, { useState } from "react";
+import { Button, Form, Table } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import {
+  faCopy,
+  faShareAlt,
+  faTrash,
+} from "@fortawesome/free-solid-svg-icons";
+import { ModalComponent } from "./Modal";
+import { Icon } from "./Icon";
+import { LoadingIndicator } from "./LoadingIndicator";
+import { useToast } from "src/hooks/Toast";
+import {
+  mutateCreateShareLink,
+  mutateRevokeShareLink,
+  useFindShareLinks,
+} from "src/core/StashService";
+import TextUtils from "src/utils/text";
+
+interface IShareLinksDialogProps {
+  sceneID?: string;
+  galleryID?: string;
+  onClose: () => void;
+}
+
+export const ShareLinksDialog: React.FC<IShareLinksDialogProps> = ({
+  sceneID,
+  galleryID,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const { data, loading } = useFindShareLinks(sceneID, galleryID);
+  const links = data?.findShareLinks ?? [];
+
+  const [allowDownload, setAllowDownload] = useState(false);
+  const [maxViews, setMaxViews] = useState("");
+  const [expiresInHours, setExpiresInHours] = useState("24");
+  const [isCreating, setIsCreating] = useState(false);
+
+  async function onCreate() {
+    const views = parseInt(maxViews, 10);
+    const hours = Number(expiresInHours);
+
+    setIsCreating(true);
+    try {
+      const result = await mutateCreateShareLink({
+        scene_id: sceneID,
+        gallery_id: galleryID,
+        allow_download: allowDownload,
+        max_views: views > 0 ? views : undefined,
+        expires_at:
+          hours > 0
+            ? new Date(Date.now() + hours * 60 * 60 * 1000).toISOString()
+            : undefined,
+      });
+
+      const url = result.data?.createShareLink.url;
+      if (url) {
+        await copyURL(url);
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setIsCreating(false);
+    }
+  }
+
+  async function onRevoke(id: string) {
+    try {
+      await mutateRevokeShareLink(id);
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function copyURL(url: string) {
+    try {
+      await navigator.clipboard.writeText(url);
+      Toast.success(
+        intl.formatMessage({ id: "dialogs.share_links.copied_to_clipboard" })
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function renderLinks() {
+    if (loading) {
+      return <LoadingIndicator small />;
+    }
+
+    if (links.length === 0) {
+      return (
+        <p className="text-muted">
+          <FormattedMessage id="dialogs.share_links.no_links" />
+        </p>
+      );
+    }
+
+    return (
+      <Table size="sm" className="share-links-table">
+        <thead>
+          <tr>
+            <th>
+              <FormattedMessage id="dialogs.share_links.expires" />
+            </th>
+            <th>
+              <FormattedMessage id="dialogs.share_links.views" />
+            </th>
+            <th>
+              <FormattedMessage id="dialogs.share_links.allow_download" />
+            </th>
+            <th />
+          </tr>
+        </thead>
+        <tbody>
+          {links.map((l) => (
+            <tr key={l.id}>
+              <td>
+                {l.expires_at
+                  ? TextUtils.formatDateTime(intl, l.expires_at)
+                  : intl.formatMessage({ id: "dialogs.share_links.never" })}
+              </td>
+              <td>
+                {l.max_views
+                  ? `${l.view_count} / ${l.max_views}`
+                  : l.view_count}
+              </td>
+              <td>
+                <FormattedMessage id={l.allow_download ? "true" : "false"} />
+              </td>
+              <td className="text-right">
+                <Button
+                  size="sm"
+                  variant="secondary"
+                  className="mr-1"
+                  title={intl.formatMessage({
+                    id: "actions.copy_to_clipboard",
+                  })}
+                  onClick={() => copyURL(l.url)}
+                >
+                  <Icon icon={faCopy} />
+                </Button>
+                <Button
+                  size="sm"
+                  variant="danger"
+                  title={intl.formatMessage({ id: "actions.revoke" })}
+                  onClick={() => onRevoke(l.id)}
+                >
+                  <Icon icon={faTrash} />
+                </Button>
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      icon={faShareAlt}
+      header={intl.formatMessage({ id: "dialogs.share_links.title" })}
+      accept={{
+        onClick: onCreate,
+        text: intl.formatMessage({ id: "dialogs.share_links.create" }),
+      }}
+      cancel={{
+        onClick: onClose,
+        text: intl.formatMessage({ id: "actions.close" }),
+        variant: "secondary",
+      }}
+      isRunning={isCreating}
+    >
+      {renderLinks()}
+      <Form>
+        <Form.Group controlId="share-link-expires">
+          <Form.Label>
+            <FormattedMessage id="dialogs.share_links.expires_in_hours" />
+          </Form.Label>
+          <Form.Control
+            type="number"
+            min={0}
+            className="text-input"
+            value={expiresInHours}
+            onChange={(e) => setExpiresInHours(e.currentTarget.value)}
+          />
+          <Form.Text className="text-muted">
+            <FormattedMessage id="dialogs.share_links.zero_for_no_limit" />
+          </Form.Text>
+        </Form.Group>
+        <Form.Group controlId="share-link-max-views">
+          <Form.Label>
+            <FormattedMessage id="dialogs.share_links.max_views" />
+          </Form.Label>
+          <Form.Control
+            type="number"
+            min={0}
+            className="text-input"
+            value={maxViews}
+            onChange={(e) => setMaxViews(e.currentTarget.value)}
+          />
+          <Form.Text className="text-muted">
+            <FormattedMessage id="dialogs.share_links.zero_for_no_limit" />
+          </Form.Text>
+        </Form.Group>
+        <Form.Check
+          id="share-link-allow-download"
+          checked={allowDownload}
+          onChange={() => setAllowDownload(!allowDownload)}
+          label={intl.formatMessage({
+            id: "dialogs.share_links.allow_download",
+          })}
+        />
+      </Form>
+    </ModalComponent>
+  );
+};
@@ -1,11 +1,12 @@
 import React from "react";
 import { useStats } from "src/core/StashService";
-import { FormattedMessage, FormattedNumber } from "react-intl";
+import { FormattedMessage, FormattedNumber, useIntl } from "react-intl";
 import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
 import TextUtils from "src/utils/text";
 import { FileSize } from "../Shared/FileSize";
 
 export const GeneralStats: React.FC = () => {
+  const intl = useIntl();
   const { data, error, loading } = useStats();
 
   if (error) return <span>{error.message}</span>;
@@ -137,6 +138,16 @@ export const GeneralStats: React.FC = () => {
           </p>
         </div>
       </div>
+      {data.stats.last_computed && (
+        <div className="col col-sm-8 m-sm-auto text-muted text-center">
+          <FormattedMessage
+            id="stats.last_computed"
+            values={{
+              time: TextUtils.formatDateTime(intl, data.stats.last_computed),
+            }}
+          />
+        </div>
+      )}
     </div>
   );
 };
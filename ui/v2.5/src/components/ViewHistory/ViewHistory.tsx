@@ -1,62 +1,147 @@
 import React, { useState, useEffect, useRef, useCallback } from "react";
-import { useQuery } from "@apollo/client";
+import { useMutation, useQuery } from "@apollo/client";
 import { useIntl } from "react-intl";
 import { Helmet } from "react-helmet";
+import { Button, Form } from "react-bootstrap";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
+import { AlertModal } from "../Shared/Alert";
 import { useTitleProps } from "src/hooks/title";
-import { FIND_VIEW_HISTORY } from "src/core/StashService/types/viewHistory";
+import { useToast } from "src/hooks/Toast";
+import {
+  CLEAR_VIEW_HISTORY_BEFORE,
+  DELETE_VIEW_HISTORY_ENTRY,
+  FIND_VIEW_HISTORY,
+  PAUSE_VIEW_HISTORY,
+  RESUME_VIEW_HISTORY,
+} from "src/core/StashService/types/viewHistory";
 import { ViewHistoryCard } from "./ViewHistoryCard";
 import { IViewHistoryEntry, IViewHistoryResult } from "./types";
 import "./ViewHistory.scss";
 
 const ITEMS_PER_PAGE = 50;
 
+type Grouping = "DAY" | "SESSION";
+
+// pause durations offered in hours, 0 pauses until resumed
+const PAUSE_HOURS = [1, 24, 0];
+
 export const ViewHistory: React.FC = () => {
   const intl = useIntl();
+  const Toast = useToast();
   const titleProps = useTitleProps({ id: "view_history" });
 
-  const [page, setPage] = useState(1);
+  const [grouping, setGrouping] = useState<Grouping>("DAY");
+  const [cursor, setCursor] = useState<string>();
   const [items, setItems] = useState<IViewHistoryEntry[]>([]);
   const [hasMore, setHasMore] = useState(true);
+  const [clearBefore, setClearBefore] = useState("");
+  const [showClearDialog, setShowClearDialog] = useState(false);
   const loadingRef = useRef<HTMLDivElement>(null);
 
-  const { data, loading, fetchMore } = useQuery<{
+  const { data, loading, refetch } = useQuery<{
     findViewHistory: IViewHistoryResult;
   }>(FIND_VIEW_HISTORY, {
     variables: {
       filter: {
-        page,
         per_page: ITEMS_PER_PAGE,
       },
+      grouping,
+      after: cursor,
     },
+    fetchPolicy: "network-only",
   });
 
+  const [deleteEntry] = useMutation(DELETE_VIEW_HISTORY_ENTRY);
+  const [clearHistoryBefore] = useMutation(CLEAR_VIEW_HISTORY_BEFORE);
+  const [pauseHistory] = useMutation(PAUSE_VIEW_HISTORY);
+  const [resumeHistory] = useMutation(RESUME_VIEW_HISTORY);
+
   // Update items when data changes
   React.useEffect(() => {
     if (data?.findViewHistory?.items) {
       setItems((prev) => {
-        if (page === 1) {
+        if (!cursor) {
           return data.findViewHistory.items;
         }
         return [...prev, ...data.findViewHistory.items];
       });
-      setHasMore(data.findViewHistory.items.length === ITEMS_PER_PAGE);
+      setHasMore(!!data.findViewHistory.nextCursor);
     }
-  }, [data, page]);
+  }, [data, cursor]);
 
   const loadMore = useCallback(() => {
-    if (!loading && hasMore) {
-      setPage((prev) => prev + 1);
-      fetchMore({
+    const next = data?.findViewHistory?.nextCursor;
+    if (!loading && next) {
+      setCursor(next);
+    }
+  }, [loading, data]);
+
+  // reload from the start, after the history has changed
+  const reload = useCallback(() => {
+    if (cursor) {
+      setCursor(undefined);
+    } else {
+      refetch();
+    }
+  }, [cursor, refetch]);
+
+  function onGroupingChanged(value: Grouping) {
+    setCursor(undefined);
+    setGrouping(value);
+  }
+
+  async function onDeleteEntry(item: IViewHistoryEntry) {
+    try {
+      await deleteEntry({
         variables: {
-          filter: {
-            page: page + 1,
-            per_page: ITEMS_PER_PAGE,
+          input: {
+            scene_id: item.scene?.id,
+            gallery_id: item.gallery?.id,
+            from: item.earliestViewDate,
+            to: item.viewDate,
           },
         },
       });
+      setItems((prev) => prev.filter((i) => i !== item));
+    } catch (e) {
+      Toast.error(e);
     }
-  }, [loading, hasMore, page, fetchMore]);
+  }
+
+  async function onClearBefore() {
+    setShowClearDialog(false);
+    try {
+      await clearHistoryBefore({
+        variables: { before: new Date(clearBefore).toISOString() },
+      });
+      setClearBefore("");
+      reload();
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onPause(hours: number) {
+    try {
+      const until =
+        hours > 0
+          ? new Date(Date.now() + hours * 60 * 60 * 1000).toISOString()
+          : undefined;
+      await pauseHistory({ variables: { until } });
+      refetch();
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onResume() {
+    try {
+      await resumeHistory();
+      refetch();
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
 
   // Infinite scroll
   useEffect(() => {
@@ -86,8 +171,10 @@ export const ViewHistory: React.FC = () => {
   const totalViews = data?.findViewHistory?.count || 0;
   const totalOCount = data?.findViewHistory?.totalOCount || 0;
   const totalOMGCount = data?.findViewHistory?.totalOMGCount || 0;
+  const paused = data?.findViewHistory?.paused ?? false;
+  const pausedUntil = data?.findViewHistory?.pausedUntil;
 
-  if (loading && page === 1) {
+  if (loading && !cursor && !data) {
     return <LoadingIndicator />;
   }
 
@@ -101,9 +188,69 @@ export const ViewHistory: React.FC = () => {
     return acc;
   }, {} as Record<string, IViewHistoryEntry[]>);
 
+  function renderPauseControls() {
+    if (paused) {
+      return (
+        <>
+          <span className="view-history-paused">
+            {pausedUntil
+              ? intl.formatMessage(
+                  { id: "view_history_controls.paused_until" },
+                  { time: new Date(pausedUntil).toLocaleString() }
+                )
+              : intl.formatMessage({ id: "view_history_controls.paused" })}
+          </span>
+          <Button variant="secondary" size="sm" onClick={() => onResume()}>
+            {intl.formatMessage({ id: "view_history_controls.resume" })}
+          </Button>
+        </>
+      );
+    }
+
+    return (
+      <Form.Control
+        as="select"
+        size="sm"
+        className="input-control"
+        value=""
+        onChange={(e) => {
+          if (e.currentTarget.value !== "") {
+            onPause(Number(e.currentTarget.value));
+          }
+        }}
+      >
+        <option value="">
+          {intl.formatMessage({ id: "view_history_controls.pause" })}
+        </option>
+        {PAUSE_HOURS.map((h) => (
+          <option key={h} value={h}>
+            {h > 0
+              ? intl.formatMessage(
+                  { id: "view_history_controls.pause_hours" },
+                  { count: h }
+                )
+              : intl.formatMessage({
+                  id: "view_history_controls.pause_until_resumed",
+                })}
+          </option>
+        ))}
+      </Form.Control>
+    );
+  }
+
   return (
     <>
       <Helmet {...titleProps} />
+      <AlertModal
+        show={showClearDialog}
+        text={intl.formatMessage(
+          { id: "view_history_controls.clear_before_confirm" },
+          { date: clearBefore }
+        )}
+        confirmButtonText={intl.formatMessage({ id: "actions.clear" })}
+        onConfirm={() => onClearBefore()}
+        onCancel={() => setShowClearDialog(false)}
+      />
       <div className="view-history-container">
         <div className="view-history-header">
           <h1>{intl.formatMessage({ id: "view_history" })}</h1>
@@ -124,6 +271,43 @@ export const ViewHistory: React.FC = () => {
           </div>
         </div>
 
+        <div className="view-history-controls">
+          <Form.Control
+            as="select"
+            size="sm"
+            className="input-control"
+            value={grouping}
+            onChange={(e) =>
+              onGroupingChanged(e.currentTarget.value as Grouping)
+            }
+          >
+            <option value="DAY">
+              {intl.formatMessage({ id: "view_history_controls.group_day" })}
+            </option>
+            <option value="SESSION">
+              {intl.formatMessage({
+                id: "view_history_controls.group_session",
+              })}
+            </option>
+          </Form.Control>
+          <Form.Control
+            type="date"
+            size="sm"
+            className="input-control"
+            value={clearBefore}
+            onChange={(e) => setClearBefore(e.currentTarget.value)}
+          />
+          <Button
+            variant="danger"
+            size="sm"
+            disabled={!clearBefore}
+            onClick={() => setShowClearDialog(true)}
+          >
+            {intl.formatMessage({ id: "view_history_controls.clear_before" })}
+          </Button>
+          {renderPauseControls()}
+        </div>
+
         <div className="view-history-content">
           {Object.entries(groupedItems).map(([date, dateItems]) => (
             <div key={date} className="view-history-date-group">
@@ -140,6 +324,7 @@ export const ViewHistory: React.FC = () => {
                     oDate={item.oDate}
                     omgDate={item.omgDate}
                     viewCount={item.viewCount}
+                    onDelete={() => onDeleteEntry(item)}
                   />
                 ))}
               </div>
@@ -1,6 +1,7 @@
 import React from "react";
 import { Link, useHistory } from "react-router-dom";
 import { useIntl } from "react-intl";
+import { Button } from "react-bootstrap";
 import * as GQL from "src/core/generated-graphql";
 import { stringToGender } from "src/utils/gender";
 import TextUtils from "src/utils/text";
@@ -15,7 +16,7 @@ import GenderIcon from "../Performers/GenderIcon";
 import { PerformerPopover } from "../Performers/PerformerPopover";
 import { StudioOverlay } from "../Shared/GridCard/StudioOverlay";
 import { Icon } from "../Shared/Icon";
-import { faImage } from "@fortawesome/free-solid-svg-icons";
+import { faImage, faTrashAlt } from "@fortawesome/free-solid-svg-icons";
 import { IScene, IGallery } from "./types";
 import NavUtils from "src/utils/navigation";
 import "./ViewHistoryCard.scss";
@@ -27,6 +28,7 @@ interface IViewHistoryCardProps {
   oDate?: string;
   omgDate?: string;
   viewCount?: number;
+  onDelete?: () => void;
 }
 
 export const ViewHistoryCard: React.FC<IViewHistoryCardProps> = ({
@@ -36,6 +38,7 @@ export const ViewHistoryCard: React.FC<IViewHistoryCardProps> = ({
   oDate,
   omgDate,
   viewCount,
+  onDelete,
 }) => {
   const intl = useIntl();
   const history = useHistory();
@@ -320,6 +323,18 @@ export const ViewHistoryCard: React.FC<IViewHistoryCardProps> = ({
           )}
         </div>
       )}
+
+      {onDelete && (
+        <Button
+          className="view-history-delete-button minimal"
+          variant="secondary"
+          size="sm"
+          title={intl.formatMessage({ id: "actions.delete" })}
+          onClick={onDelete}
+        >
+          <Icon icon={faTrashAlt} />
+        </Button>
+      )}
     </div>
   );
 };
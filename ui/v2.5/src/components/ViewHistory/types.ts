@@ -90,6 +90,7 @@ export interface IViewHistoryEntry {
   scene?: IScene;
   gallery?: IGallery;
   viewDate: string;
+  earliestViewDate: string;
   oDate?: string;
   omgDate?: string;
   viewCount?: number;
@@ -99,5 +100,8 @@ export interface IViewHistoryResult {
   count: number;
   totalOCount: number;
   totalOMGCount: number;
+  nextCursor?: string | null;
+  paused: boolean;
+  pausedUntil?: string | null;
   items: IViewHistoryEntry[];
 }
@@ -137,7 +137,7 @@ export const WallItem = <T extends WallItemType>({
       case "scene":
         const scene = data as GQL.SlimSceneDataFragment;
         return {
-          video: scene.paths.preview ?? undefined,
+          video: scene.paths.wall_preview ?? scene.paths.preview ?? undefined,
           animation: scene.paths.webp ?? undefined,
           image: scene.paths.screenshot ?? undefined,
         };
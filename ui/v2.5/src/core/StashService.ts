@@ -191,6 +191,16 @@ export const querySceneByPathRegex = (filter: GQL.FindFilterType) =>
     variables: { filter },
   });
 
+export const queryIdentifyScenePreview = (
+  sceneID: string,
+  input: GQL.IdentifyMetadataInput
+) =>
+  client.query<GQL.IdentifyScenePreviewQuery>({
+    query: GQL.IdentifyScenePreviewDocument,
+    variables: { scene_id: sceneID, input },
+    fetchPolicy: "no-cache",
+  });
+
 export const useFindImage = (id: string) =>
   GQL.useFindImageQuery({ variables: { id } });
 
@@ -494,6 +504,8 @@ export const useFindSavedFilters = (mode?: GQL.FilterMode) =>
     variables: { mode },
   });
 
+export const useDashboard = () => GQL.useDashboardQuery();
+
 /// Object Mutations
 
 // Increases/decreases the given field of the Stats query by diff
@@ -1136,6 +1148,8 @@ type SceneSaveFilteredScreenshotData = {
 export const useSceneGenerateScreenshot = () =>
   GQL.useSceneGenerateScreenshotMutation();
 
+export const useSceneRerollCover = () => GQL.useSceneRerollCoverMutation();
+
 export const useSceneSaveFilteredScreenshot = () =>
   useMutation<SceneSaveFilteredScreenshotData, SceneSaveFilteredScreenshotVars>(
     gql`
@@ -1147,6 +1161,9 @@ export const useSceneSaveFilteredScreenshot = () =>
     `
   );
 
+export const useCaptureGalleryFromScene = () =>
+  GQL.useCaptureGalleryFromSceneMutation();
+
 export const useOpenInExternalPlayer = () =>
   GQL.useOpenInExternalPlayerMutation();
 
@@ -1168,6 +1185,15 @@ export const useSceneConvertHLSToMP4 = () => {
   return useMutation(mutation);
 };
 
+export const useSceneRemediateVFR = () => {
+  const mutation = gql`
+    mutation SceneRemediateVFR($scene_id: ID!, $file_id: ID!) {
+      sceneRemediateVFR(scene_id: $scene_id, file_id: $file_id)
+    }
+  `;
+  return useMutation(mutation);
+};
+
 export const useSceneReduceResolution = () => {
   const mutation = gql`
     mutation SceneReduceResolution($input: ReduceResolutionInput!) {
@@ -1186,6 +1212,24 @@ export const useSceneTrimVideo = () => {
   return useMutation(mutation);
 };
 
+export const useSceneTransformVideo = () => {
+  const mutation = gql`
+    mutation SceneTransformVideo($input: TransformVideoInput!) {
+      sceneTransformVideo(input: $input)
+    }
+  `;
+  return useMutation(mutation);
+};
+
+export const useSceneManageAudioTracks = () => {
+  const mutation = gql`
+    mutation SceneManageAudioTracks($input: ManageAudioTracksInput!) {
+      sceneManageAudioTracks(input: $input)
+    }
+  `;
+  return useMutation(mutation);
+};
+
 export const useSceneRegenerateSprites = () => {
   const mutation = gql`
     mutation SceneRegenerateSprites($id: ID!) {
@@ -1332,7 +1376,8 @@ export const mutateSceneMerge = (
   source: string[],
   values: GQL.SceneUpdateInput,
   includeViewHistory: boolean,
-  includeOHistory: boolean
+  includeOHistory: boolean,
+  includeOMGHistory: boolean
 ) =>
   client.mutate<GQL.SceneMergeMutation>({
     mutation: GQL.SceneMergeDocument,
@@ -1343,6 +1388,7 @@ export const mutateSceneMerge = (
         values,
         play_history: includeViewHistory,
         o_history: includeOHistory,
+        omg_history: includeOMGHistory,
       },
     },
     update(cache, result) {
@@ -2575,6 +2621,36 @@ export const mutateRemoveGalleryImages = (input: GQL.GalleryRemoveInput) =>
     },
   });
 
+export const queryFindGalleryImageIDs = (
+  galleryID: string,
+  filter: GQL.FindFilterType
+) =>
+  client.query<GQL.FindGalleryImageIDsQuery>({
+    query: GQL.FindGalleryImageIDsDocument,
+    variables: { gallery_id: galleryID, filter },
+    fetchPolicy: "no-cache",
+  });
+
+export const mutateReorderGalleryImages = (
+  input: GQL.ReorderGalleryImagesInput
+) =>
+  client.mutate<GQL.ReorderGalleryImagesMutation>({
+    mutation: GQL.ReorderGalleryImagesDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.reorderGalleryImages) return;
+
+      for (const id of input.image_ids) {
+        cache.evict({
+          id: cache.identify({ __typename: "Image", id }),
+          fieldName: "gallery_order_index",
+        });
+      }
+
+      evictQueries(cache, [GQL.FindImagesDocument]);
+    },
+  });
+
 export const mutateGallerySetPrimaryFile = (id: string, fileID: string) =>
   client.mutate<GQL.GalleryUpdateMutation>({
     mutation: GQL.GalleryUpdateDocument,
@@ -3111,10 +3187,13 @@ export const useSavedFilterDestroy = () =>
     },
   });
 
-export const mutateDeleteFiles = (ids: string[]) =>
+export const mutateDeleteFiles = (
+  ids: string[],
+  confirmationToken?: string
+) =>
   client.mutate<GQL.DeleteFilesMutation>({
     mutation: GQL.DeleteFilesDocument,
-    variables: { ids },
+    variables: { ids, confirmation_token: confirmationToken },
     update(cache, result) {
       if (!result.data?.deleteFiles) return;
 
@@ -3470,6 +3549,8 @@ export const queryLogs = () =>
   });
 
 export const useSystemStatus = () => GQL.useSystemStatusQuery();
+export const useDiagnosticsLazyQuery = () =>
+  GQL.useDiagnosticsLazyQuery({ fetchPolicy: "network-only" });
 export const refetchSystemStatus = () => {
   client.refetchQueries({
     include: [GQL.SystemStatusDocument],
@@ -3480,6 +3561,9 @@ export const useJobsSubscribe = () => GQL.useJobsSubscribeSubscription();
 
 export const useLoggingSubscribe = () => GQL.useLoggingSubscribeSubscription();
 
+export const useLogsSubscribe = (filter: GQL.LogFilterInput) =>
+  GQL.useLogsSubscribeSubscription({ variables: { filter } });
+
 // all scraper-related queries
 export const scraperMutationImpactedQueries = [
   GQL.ListGroupScrapersDocument,
@@ -3569,6 +3653,31 @@ export const useGenerateAPIKey = () =>
     update: updateConfiguration,
   });
 
+export const useSaveLibraryProfile = () =>
+  GQL.useSaveLibraryProfileMutation({
+    update(cache) {
+      evictQueries(cache, [GQL.LibraryProfilesDocument]);
+    },
+  });
+
+export const useDeleteLibraryProfile = () =>
+  GQL.useDeleteLibraryProfileMutation({
+    update(cache) {
+      evictQueries(cache, [GQL.LibraryProfilesDocument]);
+    },
+  });
+
+export const useSwitchLibraryProfile = () =>
+  GQL.useSwitchLibraryProfileMutation();
+
+export const useExportConfiguration = () =>
+  GQL.useExportConfigurationMutation();
+
+export const useImportConfiguration = () =>
+  GQL.useImportConfigurationMutation({
+    update: updateConfiguration,
+  });
+
 export const useConfigureDefaults = () =>
   GQL.useConfigureDefaultsMutation({
     update: updateConfiguration,
@@ -3801,6 +3910,12 @@ export const mutateMetadataGenerate = (input: GQL.GenerateMetadataInput) =>
     variables: { input },
   });
 
+export const mutateMetadataOCR = (input: GQL.OcrMetadataInput) =>
+  client.mutate<GQL.MetadataOcrMutation>({
+    mutation: GQL.MetadataOcrDocument,
+    variables: { input },
+  });
+
 export const mutateMetadataClean = (input: GQL.CleanMetadataInput) =>
   client.mutate<GQL.MetadataCleanMutation>({
     mutation: GQL.MetadataCleanDocument,
@@ -3813,6 +3928,12 @@ export const mutateCleanGenerated = (input: GQL.CleanGeneratedInput) =>
     variables: { input },
   });
 
+export const mutateCleanBlobs = (input: GQL.CleanBlobsInput) =>
+  client.mutate<GQL.MetadataCleanBlobsMutation>({
+    mutation: GQL.MetadataCleanBlobsDocument,
+    variables: { input },
+  });
+
 export const mutateRunPluginTask = (
   pluginId: string,
   taskName: string,
@@ -3862,6 +3983,11 @@ export const mutateOptimiseDatabase = () =>
     mutation: GQL.OptimiseDatabaseDocument,
   });
 
+export const mutateRebuildPhashIndex = () =>
+  client.mutate<GQL.RebuildPhashIndexMutation>({
+    mutation: GQL.RebuildPhashIndexDocument,
+  });
+
 export const mutateMigrateHashNaming = () =>
   client.mutate<GQL.MigrateHashNamingMutation>({
     mutation: GQL.MigrateHashNamingDocument,
@@ -4241,6 +4367,16 @@ export const useGameIncrementView = () =>
 export const useDirectory = (path?: string) =>
   GQL.useDirectoryQuery({ variables: { path } });
 
+export const useStashStatus = () =>
+  GQL.useStashStatusQuery({
+    fetchPolicy: "no-cache",
+  });
+
+export const usePhashIndexStats = () =>
+  GQL.usePhashIndexStatsQuery({
+    fetchPolicy: "no-cache",
+  });
+
 export const queryParseSceneFilenames = (
   filter: GQL.FindFilterType,
   config: GQL.SceneParserInput
@@ -4250,3 +4386,114 @@ export const queryParseSceneFilenames = (
     variables: { filter, config },
     fetchPolicy: "network-only",
   });
+
+export const useFindSceneParserBatches = () =>
+  GQL.useFindSceneParserBatchesQuery({ fetchPolicy: "network-only" });
+
+export const useFindSceneParserBatch = (
+  id: string,
+  status?: GQL.SceneParserChangeStatus
+) =>
+  GQL.useFindSceneParserBatchQuery({
+    variables: { id, status },
+    skip: id === "",
+    fetchPolicy: "network-only",
+  });
+
+export const mutateSceneParserBatchCreate = (
+  input: GQL.SceneParserBatchCreateInput
+) =>
+  client.mutate<GQL.SceneParserBatchCreateMutation>({
+    mutation: GQL.SceneParserBatchCreateDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.sceneParserBatchCreate) return;
+
+      evictQueries(cache, [GQL.FindSceneParserBatchesDocument]);
+    },
+  });
+
+export const mutateSceneParserChangesUpdate = (
+  ids: string[],
+  status: GQL.SceneParserChangeStatus
+) =>
+  client.mutate<GQL.SceneParserChangesUpdateMutation>({
+    mutation: GQL.SceneParserChangesUpdateDocument,
+    variables: { input: { ids, status } },
+  });
+
+export const mutateSceneParserBatchApply = (id: string) =>
+  client.mutate<GQL.SceneParserBatchApplyMutation>({
+    mutation: GQL.SceneParserBatchApplyDocument,
+    variables: { id },
+  });
+
+export const mutateSceneParserBatchDestroy = (id: string) =>
+  client.mutate<GQL.SceneParserBatchDestroyMutation>({
+    mutation: GQL.SceneParserBatchDestroyDocument,
+    variables: { id },
+    update(cache, result) {
+      if (!result.data?.sceneParserBatchDestroy) return;
+
+      evictQueries(cache, [GQL.FindSceneParserBatchesDocument]);
+    },
+  });
+
+export const useFindShareLinks = (sceneID?: string, galleryID?: string) =>
+  GQL.useFindShareLinksQuery({
+    variables: { scene_id: sceneID, gallery_id: galleryID },
+    fetchPolicy: "network-only",
+  });
+
+export const mutateCreateShareLink = (input: GQL.ShareLinkCreateInput) =>
+  client.mutate<GQL.CreateShareLinkMutation>({
+    mutation: GQL.CreateShareLinkDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.createShareLink) return;
+
+      evictQueries(cache, [GQL.FindShareLinksDocument]);
+    },
+  });
+
+export const mutateRevokeShareLink = (id: string) =>
+  client.mutate<GQL.RevokeShareLinkMutation>({
+    mutation: GQL.RevokeShareLinkDocument,
+    variables: { id },
+    update(cache, result) {
+      if (!result.data?.revokeShareLink) return;
+
+      evictQueries(cache, [GQL.FindShareLinksDocument]);
+    },
+  });
+
+export const mutateConfigureDashboard = (input: GQL.DashboardInput) =>
+  client.mutate<GQL.ConfigureDashboardMutation>({
+    mutation: GQL.ConfigureDashboardDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.configureDashboard) return;
+
+      cache.writeQuery<GQL.DashboardQuery>({
+        query: GQL.DashboardDocument,
+        data: { dashboard: result.data.configureDashboard },
+      });
+    },
+  });
+
+export const mutateDashboardPin = (
+  input: GQL.DashboardPinInput,
+  pinned: boolean
+) =>
+  client.mutate<GQL.DashboardPinMutation>({
+    mutation: GQL.DashboardPinDocument,
+    variables: { input, pinned },
+    update(cache, result) {
+      if (!result.data?.dashboardPin) return;
+
+      cache.writeQuery<GQL.DashboardQuery>({
+        query: GQL.DashboardDocument,
+        data: { dashboard: result.data.dashboardPin },
+      });
+    },
+  });
@@ -4,11 +4,21 @@ export const FIND_VIEW_HISTORY = gql`
   query FindViewHistory(
     $filter: FindFilterType
     $historyFilter: ViewHistoryFilter
+    $grouping: ViewHistoryGrouping
+    $after: String
   ) {
-    findViewHistory(filter: $filter, history_filter: $historyFilter) {
+    findViewHistory(
+      filter: $filter
+      history_filter: $historyFilter
+      grouping: $grouping
+      after: $after
+    ) {
       count
       totalOCount
       totalOMGCount
+      nextCursor
+      paused
+      pausedUntil
       items {
         scene {
           id
@@ -97,6 +107,7 @@ export const FIND_VIEW_HISTORY = gql`
           }
         }
         viewDate
+        earliestViewDate
         oDate
         omgDate
         viewCount
@@ -104,3 +115,27 @@ export const FIND_VIEW_HISTORY = gql`
     }
   }
 `;
+
+export const DELETE_VIEW_HISTORY_ENTRY = gql`
+  mutation ViewHistoryDeleteEntry($input: ViewHistoryDeleteEntryInput!) {
+    viewHistoryDeleteEntry(input: $input)
+  }
+`;
+
+export const CLEAR_VIEW_HISTORY_BEFORE = gql`
+  mutation ViewHistoryClearBefore($before: Time!) {
+    viewHistoryClearBefore(before: $before)
+  }
+`;
+
+export const PAUSE_VIEW_HISTORY = gql`
+  mutation ViewHistoryPause($until: Time) {
+    viewHistoryPause(until: $until)
+  }
+`;
+
+export const RESUME_VIEW_HISTORY = gql`
+  mutation ViewHistoryResume {
+    viewHistoryResume
+  }
+`;
@@ -1,5 +1,7 @@
 import {
   ApolloClient,
+  ApolloLink,
+  HttpLink,
   InMemoryCache,
   split,
   from,
@@ -9,7 +11,7 @@ import {
 import { GraphQLWsLink } from "@apollo/client/link/subscriptions";
 import { createClient as createWSClient } from "graphql-ws";
 import { onError } from "@apollo/client/link/error";
-import { getMainDefinition } from "@apollo/client/utilities";
+import { getMainDefinition, hasDirectives } from "@apollo/client/utilities";
 import createUploadLink from "apollo-upload-client/createUploadLink.mjs";
 import * as GQL from "src/core/generated-graphql";
 import { FieldReadFunction } from "@apollo/client/cache";
@@ -129,6 +131,38 @@ export const getPlatformURL = (path?: string) => {
   return url;
 };
 
+// Requests made longer than this after the last user input, such as polling
+// and refetching after events, are marked as background requests, so that
+// they do not delay the automatic lock of the content gate.
+const userInputTimeout = 10000;
+
+let lastUserInput = Date.now();
+
+const onUserInput = () => {
+  lastUserInput = Date.now();
+};
+
+const createBackgroundLink = () => {
+  for (const event of ["pointerdown", "keydown", "wheel", "touchstart"]) {
+    window.addEventListener(event, onUserInput, {
+      capture: true,
+      passive: true,
+    });
+  }
+
+  return new ApolloLink((operation, forward) => {
+    if (Date.now() - lastUserInput > userInputTimeout) {
+      operation.setContext(
+        ({ headers = {} }: { headers?: Record<string, string> }) => ({
+          headers: { ...headers, "X-Stash-Background": "true" },
+        })
+      );
+    }
+
+    return forward(operation);
+  });
+};
+
 export const createClient = () => {
   const url = getPlatformURL("graphql");
 
@@ -139,7 +173,17 @@ export const createClient = () => {
     wsUrl.protocol = "ws:";
   }
 
-  const httpLink = createUploadLink({ uri: url.toString() });
+  const uploadLink = createUploadLink({ uri: url.toString() });
+
+  // the upload link does not support incremental delivery, so operations
+  // using @defer are sent over a plain http link
+  const deferLink = new HttpLink({ uri: url.toString() });
+
+  const httpLink = split(
+    ({ query }) => hasDirectives(["defer"], query),
+    deferLink,
+    uploadLink
+  );
 
   const wsClient = createWSClient({
     url: wsUrl.toString(),
@@ -183,7 +227,7 @@ Please disable it on the server and refresh the page.`);
     httpLink
   );
 
-  const link = from([errorLink, splitLink]);
+  const link = from([errorLink, createBackgroundLink(), splitLink]);
 
   const cache = new InMemoryCache({
     typePolicies,
@@ -0,0 +1,13 @@
+import { BooleanCriterion, BooleanCriterionOption } from "./criterion";
+
+export const HasPoseCoverageCriterionOption = new BooleanCriterionOption(
+  "hasPoseCoverage",
+  "has_pose_coverage",
+  () => new HasPoseCoverageCriterion()
+);
+
+export class HasPoseCoverageCriterion extends BooleanCriterion {
+  constructor() {
+    super(HasPoseCoverageCriterionOption);
+  }
+}
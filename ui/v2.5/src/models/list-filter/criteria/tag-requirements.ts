@@ -0,0 +1,36 @@
+import { CriterionType } from "../types";
+import { ModifierCriterionOption, MultiStringCriterion } from "./criterion";
+import {
+  TagRequirementsCriterionInput,
+  TagRequirementsStatus,
+} from "src/core/generated-graphql";
+
+const stringTagRequirementsMap = new Map<string, TagRequirementsStatus>([
+  ["Satisfied", TagRequirementsStatus.Satisfied],
+  ["Partial", TagRequirementsStatus.Partial],
+  ["Unsatisfied", TagRequirementsStatus.Unsatisfied],
+]);
+
+export class TagRequirementsCriterion extends MultiStringCriterion {
+  public toCriterionInput(): TagRequirementsCriterionInput {
+    return {
+      value: this.value
+        .map((v) => stringTagRequirementsMap.get(v))
+        .filter((v) => v) as TagRequirementsStatus[],
+    };
+  }
+}
+
+class BaseTagRequirementsCriterionOption extends ModifierCriterionOption {
+  constructor(value: CriterionType) {
+    super({
+      messageID: "tag_requirements.title",
+      type: value,
+      options: Array.from(stringTagRequirementsMap.keys()),
+      makeCriterion: () => new TagRequirementsCriterion(this),
+    });
+  }
+}
+
+export const TagRequirementsCriterionOption =
+  new BaseTagRequirementsCriterionOption("tag_requirements");
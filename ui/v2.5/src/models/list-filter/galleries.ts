@@ -3,6 +3,7 @@ import {
   createStringCriterionOption,
   createDateCriterionOption,
   createMandatoryTimestampCriterionOption,
+  createBooleanCriterionOption,
 } from "./criteria/criterion";
 import { PerformerFavoriteCriterionOption } from "./criteria/favorite";
 import { GalleryIsMissingCriterionOption } from "./criteria/is-missing";
@@ -66,6 +67,7 @@ const criterionOptions = [
   createStringCriterionOption("checksum", "media_info.checksum"),
   RatingCriterionOption,
   OrganizedCriterionOption,
+  createBooleanCriterionOption("locked"),
   AverageResolutionCriterionOption,
   GalleryIsMissingCriterionOption,
   TagsCriterionOption,
@@ -88,6 +90,7 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("play_count"),
   createMandatoryTimestampCriterionOption("last_played_at"),
   createMandatoryNumberCriterionOption("o_counter"),
+  createMandatoryNumberCriterionOption("omg_counter"),
 ];
 
 export const GalleryListFilterOptions = new ListFilterOptions(
@@ -32,6 +32,10 @@ const sortByOptions = ["filesize", "file_count", "date", ...MediaSortByOptions]
       messageID: "o_count",
       value: "o_counter",
     },
+    {
+      messageID: "gallery_order",
+      value: "gallery_order",
+    },
   ]);
 const displayModeOptions = [
   DisplayMode.Grid,
@@ -49,6 +53,7 @@ const criterionOptions = [
   GalleriesCriterionOption,
   OrganizedCriterionOption,
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
+  createMandatoryNumberCriterionOption("omg_counter"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
   ImageIsMissingCriterionOption,
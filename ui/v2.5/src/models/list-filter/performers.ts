@@ -103,6 +103,7 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("play_count"),
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
   createBooleanCriterionOption("ignore_auto_tag"),
+  createBooleanCriterionOption("locked"),
   CountryCriterionOption,
   createNumberCriterionOption("height_cm", "height"),
   ...numberCriteria.map((c) => createNumberCriterionOption(c)),
@@ -5,8 +5,10 @@ import {
   createDateCriterionOption,
   createMandatoryTimestampCriterionOption,
   createDurationCriterionOption,
+  createBooleanCriterionOption,
 } from "./criteria/criterion";
 import { HasMarkersCriterionOption } from "./criteria/has-markers";
+import { HasPoseCoverageCriterionOption } from "./criteria/has-pose-coverage";
 import { SceneIsMissingCriterionOption } from "./criteria/is-missing";
 import {
   GroupsCriterionOption,
@@ -35,6 +37,7 @@ import { StashIDCriterionOption } from "./criteria/stash-ids";
 import { RatingCriterionOption } from "./criteria/rating";
 import { PathCriterionOption } from "./criteria/path";
 import { OrientationCriterionOption } from "./criteria/orientation";
+import { TagRequirementsCriterionOption } from "./criteria/tag-requirements";
 
 const defaultSortBy = "date";
 const sortByOptions = [
@@ -61,6 +64,10 @@ const sortByOptions = [
       messageID: "o_count",
       value: "o_counter",
     },
+    {
+      messageID: "omg_counter",
+      value: "omg_counter",
+    },
     {
       messageID: "group_scene_number",
       value: "group_scene_number",
@@ -83,25 +90,31 @@ const criterionOptions = [
   PathCriterionOption,
   createStringCriterionOption("details"),
   createStringCriterionOption("director"),
+  createStringCriterionOption("ocr_text"),
   createMandatoryStringCriterionOption("oshash", "media_info.hash"),
   createStringCriterionOption("checksum", "media_info.checksum"),
   PhashCriterionOption,
   DuplicatedCriterionOption,
   OrganizedCriterionOption,
+  createBooleanCriterionOption("locked"),
   RatingCriterionOption,
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
+  createMandatoryNumberCriterionOption("omg_counter"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
+  TagRequirementsCriterionOption,
   createMandatoryNumberCriterionOption("framerate"),
   createMandatoryNumberCriterionOption("bitrate"),
   createStringCriterionOption("video_codec"),
   createStringCriterionOption("audio_codec"),
+  createStringCriterionOption("audio_language"),
   createDurationCriterionOption("duration"),
   createDurationCriterionOption("resume_time"),
   createDurationCriterionOption("play_duration"),
   createMandatoryNumberCriterionOption("play_count"),
   createMandatoryTimestampCriterionOption("last_played_at"),
   HasMarkersCriterionOption,
+  HasPoseCoverageCriterionOption,
   SceneIsMissingCriterionOption,
   TagsCriterionOption,
   createMandatoryNumberCriterionOption("tag_count"),
@@ -135,10 +135,12 @@ export type CriterionType =
   | "bitrate"
   | "video_codec"
   | "audio_codec"
+  | "audio_language"
   | "duration"
   | "filter_favorites"
   | "favorite"
   | "has_markers"
+  | "has_pose_coverage"
   | "is_missing"
   | "tags"
   | "scene_tags"
@@ -195,9 +197,11 @@ export type CriterionType =
   | "title"
   | "oshash"
   | "orientation"
+  | "tag_requirements"
   | "checksum"
   | "phash_distance"
   | "director"
+  | "ocr_text"
   | "synopsis"
   | "parent_count"
   | "child_count"
@@ -206,6 +210,7 @@ export type CriterionType =
   | "performer_age"
   | "duplicated"
   | "ignore_auto_tag"
+  | "locked"
   | "is_pose_tag"
   | "file_count"
   | "stash_id_endpoint"
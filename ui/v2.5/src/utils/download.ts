@@ -1,6 +1,9 @@
-const downloadFile = (url: string) => {
+const downloadFile = (url: string, filename?: string) => {
   const a = document.createElement("a");
   a.href = url;
+  if (filename) {
+    a.download = filename;
+  }
   a.click();
 };
 
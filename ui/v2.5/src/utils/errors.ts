@@ -3,6 +3,35 @@ import { ApolloError } from "@apollo/client";
 export const apolloError = (error: unknown) =>
   error instanceof ApolloError ? error.message : "";
 
+export interface IDeleteConfirmation {
+  token: string;
+  fileCount: number;
+  totalSize: number;
+}
+
+// returns the pending deletion if the error indicates that deleting files
+// must be confirmed with a token
+export function getDeleteConfirmation(
+  error: unknown
+): IDeleteConfirmation | undefined {
+  if (!(error instanceof ApolloError)) {
+    return;
+  }
+
+  const extensions = error.graphQLErrors.find(
+    (e) => e.extensions?.code === "DELETE_CONFIRMATION_REQUIRED"
+  )?.extensions;
+  if (!extensions) {
+    return;
+  }
+
+  return {
+    token: extensions.confirmation_token as string,
+    fileCount: extensions.file_count as number,
+    totalSize: extensions.total_size as number,
+  };
+}
+
 export function errorToString(error: unknown) {
   let message;
   if (error instanceof Error) {